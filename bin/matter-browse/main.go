@@ -13,7 +13,7 @@
 // limitations under the License.
 
 /*
-matter-browse is a search utility for mDNS protocol.
+matter-browse is a search utility for Matter commissionees.
 
 	NAME
 	matter-browse
@@ -22,6 +22,7 @@ matter-browse is a search utility for mDNS protocol.
 	matter-browse [OPTIONS]
 
 	matter-browse is a search utility for Matter commissionees.
+	It is a thin wrapper of 'matter browse'.
 
 	RETURN VALUE
 	  Return EXIT_SUCCESS or EXIT_FAILURE
@@ -29,65 +30,13 @@ matter-browse is a search utility for mDNS protocol.
 package main
 
 import (
-	"flag"
-	"fmt"
-	"time"
+	"os"
 
-	"github.com/cybergarage/go-logger/log"
-	"github.com/cybergarage/go-matter/matter"
-	"github.com/cybergarage/go-mdns/mdns"
+	"github.com/cybergarage/go-matter/bin/matter/cli"
 )
 
 func main() {
-	verbose := flag.Bool("v", false, "Enable verbose messages")
-	debug := flag.Bool("d", false, "Enable debug messages")
-	flag.Parse()
-
-	// Setup logger
-
-	if *verbose {
-		log.SetSharedLogger(log.NewStdoutLogger(log.LevelTrace))
-	}
-	if *debug {
-		log.SetSharedLogger(log.NewStdoutLogger(log.LevelDebug))
-	}
-
-	// Start a controller for Echonet Lite node
-
-	client := matter.NewCommissioner()
-
-	if *verbose {
-		client.SetListener(client)
-	}
-
-	err := client.Start()
-	if err != nil {
-		return
-	}
-
-	defer client.Stop()
-
-	// err = client.Query(mdns.NewQueryWithService(mdns.AutomaticBrowsingService))
-	// if err != nil {
-	// 	return
-	// }
-
-	services := []string{
-		"_matterc._udp.local",
-	}
-
-	err = client.Query(mdns.NewQueryWithServices(services))
-	if err != nil {
-		return
-	}
-
-	// Wait node responses in the local network
-
-	time.Sleep(time.Second * 10)
-
-	// Output all found nodes
-
-	for n, srv := range client.Services() {
-		fmt.Printf("[%d] %s\n", n, srv.String())
+	if err := cli.ExecuteSubcommand("browse"); err != nil {
+		os.Exit(1)
 	}
 }
@@ -13,7 +13,7 @@
 // limitations under the License.
 
 /*
-matter-dump is a dumpr utility for Matter (mDNS) protocol.
+matter-dump is a dump utility for Matter (mDNS) protocol.
 
 	NAME
 	matter-dump
@@ -21,7 +21,8 @@ matter-dump is a dumpr utility for Matter (mDNS) protocol.
 	SYNOPSIS
 	matter-dump [OPTIONS]
 
-	matter-dump is a dumpr utility for Matter (mDNS) protocol.
+	matter-dump is a dump utility for Matter (mDNS) protocol.
+	It is a thin wrapper of 'matter dump'.
 
 	RETURN VALUE
 	  Return EXIT_SUCCESS or EXIT_FAILURE
@@ -30,51 +31,12 @@ package main
 
 import (
 	"os"
-	"os/signal"
-	"syscall"
 
-	"github.com/cybergarage/go-logger/log"
-	"github.com/cybergarage/puzzledb-go/puzzledb"
+	"github.com/cybergarage/go-matter/bin/matter/cli"
 )
 
 func main() {
-	log.SetSharedLogger(log.NewStdoutLogger(log.LevelTrace))
-
-	client := NewClient()
-	client.SetListener(client)
-
-	err := client.Start()
-	if err != nil {
-		return
+	if err := cli.ExecuteSubcommand("dump"); err != nil {
+		os.Exit(1)
 	}
-
-	defer client.Stop()
-
-	sigCh := make(chan os.Signal, 1)
-
-	signal.Notify(sigCh,
-		os.Interrupt,
-		syscall.SIGHUP,
-		syscall.SIGINT,
-		syscall.SIGTERM)
-
-	exitCh := make(chan int)
-
-	go func() {
-		for {
-			s := <-sigCh
-			switch s {
-			case syscall.SIGINT, syscall.SIGTERM:
-				if err := client.Stop(); err != nil {
-					log.Errorf("%s couldn't be terminated (%s)", puzzledb.ProductName, err.Error())
-					os.Exit(1)
-				}
-				exitCh <- 0
-			}
-		}
-	}()
-
-	code := <-exitCh
-
-	os.Exit(code)
 }
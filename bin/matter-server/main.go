@@ -13,16 +13,16 @@
 // limitations under the License.
 
 /*
-mdnsserver is a ggeneric server for mDNS protocol.
+matter-server is a generic server for mDNS protocol.
 
 	NAME
-	mdnsserver
+	matter-server
 
 	SYNOPSIS
-	mdnsserver [OPTIONS]
+	matter-server [OPTIONS]
 
-	mdnsserver
-	uechosearch is a ggeneric server for mDNS protocol.
+	matter-server is a generic server for mDNS protocol.
+	It is a thin wrapper of 'matter server'.
 
 	RETURN VALUE
 	  Return EXIT_SUCCESS or EXIT_FAILURE
@@ -30,45 +30,13 @@ mdnsserver is a ggeneric server for mDNS protocol.
 package main
 
 import (
-	"flag"
-	"time"
+	"os"
 
-	"github.com/cybergarage/go-logger/log"
+	"github.com/cybergarage/go-matter/bin/matter/cli"
 )
 
 func main() {
-	verbose := flag.Bool("v", false, "Enable verbose output")
-	flag.Parse()
-
-	// Setup logger
-
-	if *verbose {
-		log.SetSharedLogger(log.NewStdoutLogger(log.LevelTrace))
-	}
-
-	// Start a controller for Echonet Lite node
-
-	server := NewServer()
-
-	if *verbose {
-		server.SetListener(server)
-	}
-
-	err := server.Start()
-	if err != nil {
-		return
-	}
-
-	// Wait node responses in the local network
-
-	time.Sleep(time.Second * 1)
-
-	// Output all found nodes
-
-	// Stop the controller
-
-	err = server.Stop()
-	if err != nil {
-		return
+	if err := cli.ExecuteSubcommand("server"); err != nil {
+		os.Exit(1)
 	}
 }
@@ -0,0 +1,92 @@
+// Copyright (C) 2024 The go-matter Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/spf13/cobra"
+)
+
+const (
+	TimeoutFlag = "timeout"
+	TypeFlag    = "type"
+)
+
+func init() {
+	browseCmd.Flags().Duration(TimeoutFlag, time.Second*10, "Wait duration for commissionee responses")
+	browseCmd.Flags().StringSlice(TypeFlag, []string{"commissionable"}, "Service types to search (commissionable, operational, commissioner)")
+	rootCmd.AddCommand(browseCmd)
+}
+
+var browseCmd = &cobra.Command{
+	Use:   "browse",
+	Short: "Search Matter commissionees in the local network.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timeout, err := cmd.Flags().GetDuration(TimeoutFlag)
+		if err != nil {
+			return err
+		}
+
+		typeNames, err := cmd.Flags().GetStringSlice(TypeFlag)
+		if err != nil {
+			return err
+		}
+		types := []matter.ServiceType{}
+		for _, name := range typeNames {
+			typ, err := matter.NewServiceTypeFromString(name)
+			if err != nil {
+				return err
+			}
+			types = append(types, typ)
+		}
+
+		client := matter.NewCommissioner()
+		if isVerbose(cmd) {
+			client.SetListener(client)
+		}
+
+		err = client.Start()
+		if err != nil {
+			return err
+		}
+
+		defer client.Stop()
+
+		// Wait node responses in the local network
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+		defer cancel()
+
+		services, err := client.Browse(ctx, types...)
+		if err != nil {
+			return err
+		}
+
+		// Output all found nodes
+
+		for n, srv := range services {
+			fmt.Printf("[%d] %s\n", n, srv.String())
+			if dt, ok := matter.NewCommissioneeWithService(srv).LookupDeviceType(); ok {
+				fmt.Printf("    device type : %s\n", dt.String())
+			}
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,268 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/matter/credential"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FormatFlag    = "format"
+	FabricIDFlag  = "fabric-id"
+	NodeIDFlag    = "node-id"
+	CATFlag       = "cat"
+	ICACFlag      = "icac"
+	ValidFromFlag = "valid-from"
+	LifetimeFlag  = "lifetime"
+
+	// Certificate formats which are compatible with chip-cert.
+	CertFormatX509PEM = "x509-pem"
+	CertFormatX509DER = "x509-der"
+	CertFormatChip    = "chip"
+	CertFormatChipHex = "chip-hex"
+	CertFormatChipB64 = "chip-b64"
+
+	pemCertificateType = "CERTIFICATE"
+	pemPrivateKeyType  = "EC PRIVATE KEY"
+	tlvStructureByte   = 0x15
+	derSequenceByte    = 0x30
+)
+
+var certFormatExtensions = map[string]string{
+	CertFormatX509PEM: ".pem",
+	CertFormatX509DER: ".der",
+	CertFormatChip:    ".chip",
+	CertFormatChipHex: ".hex",
+	CertFormatChipB64: ".b64",
+}
+
+func init() {
+	certConvertCmd.Flags().String(FormatFlag, CertFormatChip, "Output format (x509-pem, x509-der, chip, chip-hex or chip-b64)")
+	certGenCmd.Flags().String(FormatFlag, CertFormatChip, "Output format of the certificates (x509-pem, x509-der, chip, chip-hex or chip-b64)")
+	certGenCmd.Flags().String(FabricIDFlag, "1", "Fabric ID of the certificates")
+	certGenCmd.Flags().String(NodeIDFlag, "1", "Operational node ID of the NOC")
+	certGenCmd.Flags().StringSlice(CATFlag, nil, "CASE authenticated tags of the NOC in hex")
+	certGenCmd.Flags().Bool(ICACFlag, false, "Issue the NOC by an ICAC")
+	certGenCmd.Flags().String(ValidFromFlag, "", "Start of the validity period in RFC 3339 (default now)")
+	certGenCmd.Flags().Duration(LifetimeFlag, 365*24*time.Hour, "Validity period of the certificates, or 0 for no expiration")
+	certCmd.AddCommand(certConvertCmd)
+	certCmd.AddCommand(certGenCmd)
+	rootCmd.AddCommand(certCmd)
+}
+
+var certCmd = &cobra.Command{
+	Use:   "cert",
+	Short: "Convert and generate Matter certificates as chip-cert does.",
+}
+
+var certConvertCmd = &cobra.Command{
+	Use:   "convert <in> <out>",
+	Short: "Convert a certificate between X.509 (PEM or DER) and Matter TLV, where '-' is the standard output.",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cmd.Flags().GetString(FormatFlag)
+		if err != nil {
+			return err
+		}
+		b, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		cert, err := decodeCertificate(b)
+		if err != nil {
+			return err
+		}
+		out, err := encodeCertificate(cert, format)
+		if err != nil {
+			return err
+		}
+		if args[1] == "-" {
+			_, err = os.Stdout.Write(out)
+			return err
+		}
+		return os.WriteFile(args[1], out, 0o644)
+	},
+}
+
+var certGenCmd = &cobra.Command{
+	Use:   "gen <dir>",
+	Short: "Generate a test RCAC, an optional ICAC and a NOC with their private keys into the directory.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		format, _ := flags.GetString(FormatFlag)
+		if _, ok := certFormatExtensions[format]; !ok {
+			return fmt.Errorf("unknown certificate format : %s", format)
+		}
+		fabricIDFlag, _ := flags.GetString(FabricIDFlag)
+		fabricID, err := strconv.ParseUint(fabricIDFlag, 0, 64)
+		if err != nil {
+			return fmt.Errorf("fabric ID (%s) : %w", fabricIDFlag, err)
+		}
+		nodeIDFlag, _ := flags.GetString(NodeIDFlag)
+		nodeID, err := strconv.ParseUint(nodeIDFlag, 0, 64)
+		if err != nil {
+			return fmt.Errorf("node ID (%s) : %w", nodeIDFlag, err)
+		}
+		catFlags, _ := flags.GetStringSlice(CATFlag)
+		cats := []uint32{}
+		for _, s := range catFlags {
+			cat, err := strconv.ParseUint(strings.TrimPrefix(s, "0x"), 16, 32)
+			if err != nil {
+				return fmt.Errorf("CAT (%s) : %w", s, err)
+			}
+			cats = append(cats, uint32(cat))
+		}
+		withICAC, _ := flags.GetBool(ICACFlag)
+		validity, err := certValidity(cmd)
+		if err != nil {
+			return err
+		}
+
+		rcac, err := credential.NewRootCertificateAuthority(1, fabricID, validity)
+		if err != nil {
+			return err
+		}
+		if err := writeCertificate(args[0], "rcac", format, rcac.Certificate(), rcac.PrivateKey()); err != nil {
+			return err
+		}
+		issuer := rcac
+		if withICAC {
+			issuer, err = rcac.NewIntermediateCertificateAuthority(2, validity)
+			if err != nil {
+				return err
+			}
+			if err := writeCertificate(args[0], "icac", format, issuer.Certificate(), issuer.PrivateKey()); err != nil {
+				return err
+			}
+		}
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return err
+		}
+		noc, err := issuer.IssueNodeCertificate(&key.PublicKey, message.NodeID(nodeID), cats, validity)
+		if err != nil {
+			return err
+		}
+		return writeCertificate(args[0], "noc", format, noc, key)
+	},
+}
+
+// certValidity returns the validity period of the flags.
+func certValidity(cmd *cobra.Command) (credential.Validity, error) {
+	validFrom, _ := cmd.Flags().GetString(ValidFromFlag)
+	lifetime, _ := cmd.Flags().GetDuration(LifetimeFlag)
+	notBefore := time.Now().UTC().Truncate(time.Second)
+	if validFrom != "" {
+		t, err := time.Parse(time.RFC3339, validFrom)
+		if err != nil {
+			return credential.Validity{}, fmt.Errorf("valid from (%s) : %w", validFrom, err)
+		}
+		notBefore = t
+	}
+	if lifetime <= 0 {
+		return credential.Validity{NotBefore: notBefore, NotAfter: time.Time{}}, nil
+	}
+	return credential.NewValidity(notBefore, lifetime), nil
+}
+
+// writeCertificate writes the certificate in the format and the private key in PEM into the directory.
+func writeCertificate(dir string, name string, format string, cert []byte, key *ecdsa.PrivateKey) error {
+	out, err := encodeCertificate(cert, format)
+	if err != nil {
+		return err
+	}
+	certPath := filepath.Join(dir, name+certFormatExtensions[format])
+	if err := os.WriteFile(certPath, out, 0o644); err != nil {
+		return err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	keyPath := filepath.Join(dir, name+"_key.pem")
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: pemPrivateKeyType, Bytes: keyDER}), 0o600); err != nil {
+		return err
+	}
+	fmt.Println(certPath)
+	fmt.Println(keyPath)
+	return nil
+}
+
+// decodeCertificate returns the Matter TLV certificate of the X.509 PEM or DER, or the Matter TLV
+// certificate in binary, hex or base64.
+func decodeCertificate(b []byte) ([]byte, error) {
+	if block, _ := pem.Decode(b); block != nil {
+		if block.Type != pemCertificateType {
+			return nil, fmt.Errorf("PEM block (%s) is not a certificate", block.Type)
+		}
+		return credential.ConvertX509ToTLV(block.Bytes)
+	}
+	if 0 < len(b) {
+		switch b[0] {
+		case derSequenceByte:
+			return credential.ConvertX509ToTLV(b)
+		case tlvStructureByte:
+			return b, nil
+		}
+	}
+	s := string(bytes.TrimSpace(b))
+	if decoded, err := hex.DecodeString(s); err == nil && 0 < len(decoded) {
+		return decodeCertificate(decoded)
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(s); err == nil && 0 < len(decoded) {
+		return decodeCertificate(decoded)
+	}
+	return nil, fmt.Errorf("unknown certificate format")
+}
+
+// encodeCertificate returns the Matter TLV certificate in the format.
+func encodeCertificate(cert []byte, format string) ([]byte, error) {
+	switch format {
+	case CertFormatChip:
+		return cert, nil
+	case CertFormatChipHex:
+		return []byte(hex.EncodeToString(cert) + "\n"), nil
+	case CertFormatChipB64:
+		return []byte(base64.StdEncoding.EncodeToString(cert) + "\n"), nil
+	case CertFormatX509DER, CertFormatX509PEM:
+		der, err := credential.ConvertTLVToX509(cert)
+		if err != nil {
+			return nil, err
+		}
+		if format == CertFormatX509DER {
+			return der, nil
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: pemCertificateType, Bytes: der}), nil
+	}
+	return nil, fmt.Errorf("unknown certificate format : %s", format)
+}
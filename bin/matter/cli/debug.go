@@ -0,0 +1,93 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	debugStateCmd.Flags().Duration(TimeoutFlag, time.Second*10, "Wait duration for each node")
+	debugCmd.AddCommand(debugStateCmd)
+	rootCmd.AddCommand(debugCmd)
+}
+
+var debugCmd = &cobra.Command{
+	Use:   "debug",
+	Short: "Inspect the protocol state for debugging.",
+}
+
+var debugStateCmd = &cobra.Command{
+	Use:   "state [<compressed-fabric-id>-<node-id>[@address]...]",
+	Short: "Probe commissioned nodes and print the commissioner and session state as JSON.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timeout, err := cmd.Flags().GetDuration(TimeoutFlag)
+		if err != nil {
+			return err
+		}
+
+		peers := []matter.OperationalPeer{}
+		for _, arg := range args {
+			peer, err := parseOperationalPeer(arg)
+			if err != nil {
+				return err
+			}
+			peers = append(peers, peer)
+		}
+
+		com := matter.NewCommissioner()
+		if err := com.Start(); err != nil {
+			return err
+		}
+		defer com.Stop()
+
+		// BasicInformation.DataModelRevision is read as a probe which every node supports.
+		path := im.NewAttributePath(0, im.ClusterBasicInformation, im.AttributeBasicInformationDataModelRevision)
+		devices := []matter.OperationalDeviceState{}
+		for _, peer := range peers {
+			dev := com.OperationalDevice(peer)
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			_, err := dev.ReadAttribute(ctx, path)
+			cancel()
+			if err != nil && isVerbose(cmd) {
+				fmt.Fprintf(os.Stderr, "%s : %s : %s\n", peer.InstanceName(), path.Name(), err)
+			}
+			devices = append(devices, dev.Debug())
+			dev.Close()
+		}
+
+		state := struct {
+			Commissioner matter.CommissionerState
+			Devices      []matter.OperationalDeviceState
+		}{
+			Commissioner: com.Debug(),
+			Devices:      devices,
+		}
+		b, err := json.MarshalIndent(state, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	},
+}
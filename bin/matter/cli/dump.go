@@ -0,0 +1,61 @@
+// Copyright (C) 2024 The go-matter Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"github.com/cybergarage/go-logger/log"
+	"github.com/cybergarage/go-mdns/mdns"
+	"github.com/cybergarage/go-mdns/mdns/dns"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(dumpCmd)
+}
+
+var dumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Dump all mDNS messages in the local network.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.SetSharedLogger(log.NewStdoutLogger(log.LevelTrace))
+
+		client := newDumpClient()
+		client.SetListener(client)
+
+		err := client.Start()
+		if err != nil {
+			return err
+		}
+
+		<-cmd.Context().Done()
+
+		return client.Stop()
+	},
+}
+
+type dumpClient struct {
+	*mdns.Client
+}
+
+func newDumpClient() *dumpClient {
+	client := &dumpClient{
+		Client: mdns.NewClient(),
+	}
+	return client
+}
+
+func (client *dumpClient) MessageReceived(msg *dns.Message) {
+	log.HexInfo(msg.Bytes())
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/schema"
+	"github.com/spf13/cobra"
+)
+
+const (
+	PayloadFlag = "payload"
+)
+
+func init() {
+	encodeCommandCmd.Flags().String(PayloadFlag, "", "Command fields as a JSON object keyed by the field names")
+	encodeCmd.AddCommand(encodeCommandCmd)
+	encodeCmd.AddCommand(encodeAttributeCmd)
+	rootCmd.AddCommand(encodeCmd)
+}
+
+var encodeCmd = &cobra.Command{
+	Use:   "encode",
+	Short: "Encode command fields and attribute values into TLV as chip-tool arguments.",
+}
+
+var encodeCommandCmd = &cobra.Command{
+	Use:   "command <cluster> <command> [fields...]",
+	Short: "Encode command fields, which are passed in order or as a JSON payload.",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cluster, err := schema.LookupCluster(args[0])
+		if err != nil {
+			return err
+		}
+		command, err := cluster.LookupCommand(args[1])
+		if err != nil {
+			return err
+		}
+		payload, err := cmd.Flags().GetString(PayloadFlag)
+		if err != nil {
+			return err
+		}
+		var b []byte
+		if 0 < len(payload) {
+			b, err = command.EncodeJSON(payload)
+		} else {
+			b, err = command.EncodeArgs(args[2:])
+		}
+		if err != nil {
+			return fmt.Errorf("%w\nusage: %s", err, command)
+		}
+		fmt.Println(hex.EncodeToString(b))
+		return nil
+	},
+}
+
+var encodeAttributeCmd = &cobra.Command{
+	Use:   "attribute <cluster> <attribute> <value>",
+	Short: "Encode an attribute value.",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cluster, err := schema.LookupCluster(args[0])
+		if err != nil {
+			return err
+		}
+		attr, err := cluster.LookupAttribute(args[1])
+		if err != nil {
+			return err
+		}
+		b, err := attr.EncodeArg(args[2])
+		if err != nil {
+			return err
+		}
+		fmt.Println(hex.EncodeToString(b))
+		return nil
+	},
+}
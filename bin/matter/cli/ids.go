@@ -0,0 +1,53 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(idsCmd)
+}
+
+var idsCmd = &cobra.Command{
+	Use:   "ids [cluster]",
+	Short: "Print the IDs and names of the known clusters, or of the attributes of a cluster.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		if len(args) == 0 {
+			fmt.Fprintln(w, "ID\tCLUSTER")
+			for _, id := range im.Clusters() {
+				fmt.Fprintf(w, "0x%04X\t%s\n", uint32(id), im.ClusterName(id))
+			}
+			return w.Flush()
+		}
+		cluster, ok := im.LookupClusterID(args[0])
+		if !ok {
+			return fmt.Errorf("cluster (%s) is not found", args[0])
+		}
+		fmt.Fprintf(w, "ID\t%s\n", im.ClusterName(cluster))
+		for _, id := range im.ClusterAttributes(cluster) {
+			fmt.Fprintf(w, "0x%04X\t%s\n", uint32(id), im.AttributeName(cluster, id))
+		}
+		return w.Flush()
+	},
+}
@@ -0,0 +1,169 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/storage"
+	"github.com/spf13/cobra"
+)
+
+const (
+	StoreFlag   = "store"
+	RefreshFlag = "refresh"
+	LabelFlag   = "label"
+)
+
+func init() {
+	listCmd.Flags().String(StoreFlag, defaultStorePath(), "Path of the store file")
+	listCmd.Flags().Bool(RefreshFlag, false, "Read the metadata of the nodes before listing")
+	listCmd.Flags().String(LabelFlag, "", "Label to set to the specified node")
+	listCmd.Flags().Duration(TimeoutFlag, time.Second*10, "Wait duration for each node")
+	rootCmd.AddCommand(listCmd)
+}
+
+// defaultStorePath returns the store file in the user configuration directory, or in the current directory.
+func defaultStorePath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "matter.json"
+	}
+	return filepath.Join(dir, "go-matter", "matter.json")
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list [<compressed-fabric-id>-<node-id>[@address]...]",
+	Short: "List commissioned nodes with their metadata.",
+	Long: "List commissioned nodes with their metadata.\n" +
+		"The specified nodes are added to the registry, and refreshed with --refresh or labeled with --label.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := cmd.Flags().GetString(StoreFlag)
+		if err != nil {
+			return err
+		}
+		refresh, err := cmd.Flags().GetBool(RefreshFlag)
+		if err != nil {
+			return err
+		}
+		label, err := cmd.Flags().GetString(LabelFlag)
+		if err != nil {
+			return err
+		}
+		timeout, err := cmd.Flags().GetDuration(TimeoutFlag)
+		if err != nil {
+			return err
+		}
+		if cmd.Flags().Changed(LabelFlag) && len(args) != 1 {
+			return fmt.Errorf("--%s requires a node", LabelFlag)
+		}
+
+		peers := []matter.OperationalPeer{}
+		for _, arg := range args {
+			peer, err := parseOperationalPeer(arg)
+			if err != nil {
+				return err
+			}
+			peers = append(peers, peer)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return err
+		}
+		store, err := storage.NewFileStore(path)
+		if err != nil {
+			return err
+		}
+		reg := matter.NewNodeRegistry(store)
+
+		if cmd.Flags().Changed(LabelFlag) {
+			if err := reg.SetLabel(peers[0], label); err != nil {
+				return err
+			}
+		}
+
+		if refresh {
+			if len(peers) == 0 {
+				records, err := reg.Records()
+				if err != nil {
+					return err
+				}
+				for _, record := range records {
+					peers = append(peers, record.Peer)
+				}
+			}
+			com := matter.NewCommissioner()
+			if err := com.Start(); err != nil {
+				return err
+			}
+			defer com.Stop()
+			for _, peer := range peers {
+				dev := com.OperationalDevice(peer)
+				ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+				_, err := reg.Refresh(ctx, dev)
+				cancel()
+				dev.Close()
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "%s : %s\n", peer.InstanceName(), err)
+				}
+			}
+		}
+
+		records, err := reg.Records()
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NODE\tLABEL\tVENDOR\tPRODUCT\tDEVICE TYPES\tENDPOINTS\tADDRESS\tLAST SEEN")
+		for _, record := range records {
+			dts := []string{}
+			for _, dt := range record.DeviceTypes() {
+				dts = append(dts, fmt.Sprintf("0x%04X", uint32(dt)))
+			}
+			if len(dts) == 0 {
+				dts = append(dts, "-")
+			}
+			label := record.Label
+			if label == "" {
+				label = "-"
+			}
+			addr := "-"
+			if record.Peer.Address.IsValid() {
+				addr = record.Peer.Address.String()
+			}
+			lastSeen := "-"
+			if !record.LastSeen.IsZero() {
+				lastSeen = record.LastSeen.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%s\t%s\t0x%04X\t0x%04X\t%s\t%d\t%s\t%s\n",
+				record.Peer.InstanceName(),
+				label,
+				uint16(record.VendorID),
+				uint16(record.ProductID),
+				strings.Join(dts, ","),
+				len(record.Endpoints),
+				addr,
+				lastSeen)
+		}
+		return w.Flush()
+	},
+}
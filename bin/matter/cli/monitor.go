@@ -0,0 +1,90 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/spf13/cobra"
+)
+
+const (
+	DurationFlag = "duration"
+	QueryFlag    = "query"
+)
+
+func init() {
+	monitorCmd.Flags().Duration(DurationFlag, 0, "Monitor duration (0 to monitor until interrupted)")
+	monitorCmd.Flags().Duration(QueryFlag, 0, "Query interval for commissionable nodes (0 to only listen passively)")
+	rootCmd.AddCommand(monitorCmd)
+}
+
+var monitorCmd = &cobra.Command{
+	Use:   "monitor",
+	Short: "Record commissionable advertisements and flag anomalies in the local network.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		duration, err := cmd.Flags().GetDuration(DurationFlag)
+		if err != nil {
+			return err
+		}
+		interval, err := cmd.Flags().GetDuration(QueryFlag)
+		if err != nil {
+			return err
+		}
+
+		mon := matter.NewAdvertisementMonitor(
+			matter.WithAdvertisementHandler(func(adv *matter.Advertisement) {
+				fmt.Printf("%s %s\n", adv.LastSeen.Format(time.TimeOnly), adv.String())
+			}),
+			matter.WithAnomalyHandler(func(anomaly *matter.AdvertisementAnomaly) {
+				fmt.Printf("%s !! %s\n", anomaly.Time.Format(time.TimeOnly), anomaly.String())
+			}),
+		)
+
+		disc := matter.NewDiscoverer()
+		err = disc.Start()
+		if err != nil {
+			return err
+		}
+
+		defer disc.Stop()
+
+		ctx := cmd.Context()
+		if 0 < duration {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, duration)
+			defer cancel()
+		}
+
+		err = mon.Run(ctx, disc, interval)
+		if err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+			return err
+		}
+
+		// Output the summary
+
+		anomalies := mon.Anomalies()
+		fmt.Printf("%d instances, %d anomalies\n", len(mon.Instances()), len(anomalies))
+		for n, anomaly := range anomalies {
+			fmt.Printf("[%d] %s\n", n, anomaly.String())
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/cybergarage/go-matter/matter/payload"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	payloadCmd.AddCommand(payloadParseCmd)
+	rootCmd.AddCommand(payloadCmd)
+}
+
+var payloadCmd = &cobra.Command{
+	Use:   "payload",
+	Short: "Parse and render onboarding payloads.",
+}
+
+var payloadParseCmd = &cobra.Command{
+	Use:   "parse <code>",
+	Short: "Print the fields of a QR code or manual pairing code.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, err := payload.NewOnboardingPayloadFromString(args[0])
+		if err != nil {
+			return err
+		}
+		return printPayload(p)
+	},
+}
+
+// printPayload prints the fields and the codes of the onboarding payload.
+func printPayload(p *payload.OnboardingPayload) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Vendor ID\t%s\n", p.VendorID())
+	fmt.Fprintf(w, "Product ID\t%s\n", p.ProductID())
+	fmt.Fprintf(w, "Commissioning flow\t%d\n", p.CommissioningFlow())
+	fmt.Fprintf(w, "Discovery capabilities\t%s\n", p.DiscoveryCapabilities())
+	fmt.Fprintf(w, "Discriminator\t%s\n", p.Discriminator())
+	fmt.Fprintf(w, "Passcode\t%08d\n", p.Passcode())
+	if sn, ok := p.SerialNumber(); ok {
+		fmt.Fprintf(w, "Serial number\t%s\n", sn)
+	}
+	if code, err := p.QRCode(); err == nil {
+		fmt.Fprintf(w, "QR code\t%s\n", code)
+	}
+	if code, err := p.ManualPairingCode(); err == nil {
+		fmt.Fprintf(w, "Manual pairing code\t%s\n", code)
+	}
+	return w.Flush()
+}
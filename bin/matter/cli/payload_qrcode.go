@@ -0,0 +1,88 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build qrcode
+
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cybergarage/go-matter/matter/payload"
+	"github.com/spf13/cobra"
+)
+
+const (
+	PNGFlag      = "png"
+	ScaleFlag    = "scale"
+	InvertedFlag = "inverted"
+)
+
+func init() {
+	payloadQRCmd.Flags().String(PNGFlag, "", "Write the QR code to the PNG file instead of the terminal")
+	payloadQRCmd.Flags().Int(ScaleFlag, payload.QRCodeScale, "Pixels of each module of the PNG image")
+	payloadQRCmd.Flags().Bool(InvertedFlag, true, "Draw the light modules for terminals with a dark background")
+	payloadCmd.AddCommand(payloadQRCmd)
+	payloadCmd.AddCommand(payloadScanCmd)
+}
+
+var payloadQRCmd = &cobra.Command{
+	Use:   "qr <code>",
+	Short: "Render the QR code of a QR code or manual pairing code to the terminal or a PNG file.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, err := payload.NewOnboardingPayloadFromString(args[0])
+		if err != nil {
+			return err
+		}
+		name, _ := cmd.Flags().GetString(PNGFlag)
+		if name == "" {
+			inverted, _ := cmd.Flags().GetBool(InvertedFlag)
+			s, err := p.QRCodeTerminal(inverted)
+			if err != nil {
+				return err
+			}
+			fmt.Print(s)
+			return nil
+		}
+		scale, _ := cmd.Flags().GetInt(ScaleFlag)
+		f, err := os.Create(name)
+		if err != nil {
+			return err
+		}
+		if err := p.WriteQRCodePNG(f, scale); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	},
+}
+
+var payloadScanCmd = &cobra.Command{
+	Use:   "scan <image>",
+	Short: "Extract the MT: payload of the QR code in a PNG, JPEG or GIF image, and print its fields.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		code, err := payload.ReadQRCodeImageFile(args[0])
+		if err != nil {
+			return err
+		}
+		p, err := payload.NewOnboardingPayloadFromString(code)
+		if err != nil {
+			return err
+		}
+		return printPayload(p)
+	},
+}
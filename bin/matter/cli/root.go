@@ -0,0 +1,81 @@
+// Copyright (C) 2024 The go-matter Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/cybergarage/go-logger/log"
+	"github.com/spf13/cobra"
+)
+
+const (
+	ProgramName = "matter"
+	VerboseFlag = "verbose"
+	DebugFlag   = "debug"
+)
+
+var rootCmd = &cobra.Command{
+	Use:           ProgramName,
+	Short:         "A command line utility for Matter networks.",
+	SilenceUsage:  true,
+	SilenceErrors: false,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		verbose, _ := cmd.Flags().GetBool(VerboseFlag)
+		debug, _ := cmd.Flags().GetBool(DebugFlag)
+		switch {
+		case debug:
+			log.SetSharedLogger(log.NewStdoutLogger(log.LevelDebug))
+		case verbose:
+			log.SetSharedLogger(log.NewStdoutLogger(log.LevelTrace))
+		}
+	},
+}
+
+func init() {
+	rootCmd.PersistentFlags().BoolP(VerboseFlag, "v", false, "Enable verbose messages")
+	rootCmd.PersistentFlags().BoolP(DebugFlag, "d", false, "Enable debug messages")
+}
+
+// RootCommand returns the root command.
+func RootCommand() *cobra.Command {
+	return rootCmd
+}
+
+// Execute runs the root command until it finishes or the process is interrupted.
+func Execute() error {
+	ctx, stop := signal.NotifyContext(context.Background(),
+		os.Interrupt,
+		syscall.SIGTERM)
+	defer stop()
+	return rootCmd.ExecuteContext(ctx)
+}
+
+// ExecuteSubcommand runs the specified subcommand with the process arguments.
+// It is used by the legacy single-purpose binaries which wrap a subcommand.
+func ExecuteSubcommand(name string) error {
+	rootCmd.SetArgs(append([]string{name}, os.Args[1:]...))
+	return Execute()
+}
+
+// isVerbose returns true if the verbose or debug flag is specified.
+func isVerbose(cmd *cobra.Command) bool {
+	verbose, _ := cmd.Flags().GetBool(VerboseFlag)
+	debug, _ := cmd.Flags().GetBool(DebugFlag)
+	return verbose || debug
+}
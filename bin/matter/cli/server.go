@@ -12,22 +12,48 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package main
+package cli
 
 import (
 	"github.com/cybergarage/go-mdns/mdns"
 	"github.com/cybergarage/go-mdns/mdns/dns"
+	"github.com/spf13/cobra"
 )
 
-type Server struct {
+func init() {
+	rootCmd.AddCommand(serverCmd)
+}
+
+var serverCmd = &cobra.Command{
+	Use:   "server",
+	Short: "Run a generic mDNS server until interrupted.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		server := newServer()
+		if isVerbose(cmd) {
+			server.SetListener(server)
+		}
+
+		err := server.Start()
+		if err != nil {
+			return err
+		}
+
+		<-cmd.Context().Done()
+
+		return server.Stop()
+	},
+}
+
+type server struct {
 	*mdns.Server
 }
 
-func NewServer() *Server {
-	server := &Server{
+func newServer() *server {
+	server := &server{
 		Server: mdns.NewServer(),
 	}
 	return server
 }
-func (server *Server) MessageReceived(msg *dns.Message) {
+
+func (server *server) MessageReceived(msg *dns.Message) {
 }
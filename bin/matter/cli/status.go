@@ -0,0 +1,149 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/spf13/cobra"
+)
+
+const (
+	CountFlag = "count"
+)
+
+func init() {
+	statusCmd.Flags().Duration(TimeoutFlag, time.Second*10, "Wait duration for each node")
+	statusCmd.Flags().Int(CountFlag, 3, "Number of reads sent to each node")
+	rootCmd.AddCommand(statusCmd)
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status <compressed-fabric-id>-<node-id>[@address]...",
+	Short: "Probe commissioned nodes and print their transport statistics.",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		timeout, err := cmd.Flags().GetDuration(TimeoutFlag)
+		if err != nil {
+			return err
+		}
+		count, err := cmd.Flags().GetInt(CountFlag)
+		if err != nil {
+			return err
+		}
+
+		peers := []matter.OperationalPeer{}
+		for _, arg := range args {
+			peer, err := parseOperationalPeer(arg)
+			if err != nil {
+				return err
+			}
+			peers = append(peers, peer)
+		}
+
+		com := matter.NewCommissioner()
+		if err := com.Start(); err != nil {
+			return err
+		}
+		defer com.Stop()
+
+		// BasicInformation.DataModelRevision is read as a probe which every node supports.
+		path := im.NewAttributePath(0, im.ClusterBasicInformation, im.AttributeBasicInformationDataModelRevision)
+		for _, peer := range peers {
+			dev := com.OperationalDevice(peer)
+			for n := 0; n < count; n++ {
+				ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+				_, err := dev.ReadAttribute(ctx, path)
+				cancel()
+				if err == nil {
+					continue
+				}
+				// The node is not probed at all without a CASE establisher, so there are no statistics to print.
+				if errors.Is(err, matter.ErrNotSupported) || cmd.Context().Err() != nil {
+					dev.Close()
+					return fmt.Errorf("%s : %w", peer.InstanceName(), err)
+				}
+				if isVerbose(cmd) {
+					fmt.Fprintf(os.Stderr, "%s : %s : %s\n", peer.InstanceName(), path.Name(), err)
+				}
+			}
+			dev.Close()
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NODE\tSENT\tRECEIVED\tRETRANS\tRTT\tRTTVAR\tHANDSHAKE FAILURES\tLAST SEEN")
+		for _, peer := range peers {
+			stats, _ := com.NodeStats(peer.NodeID)
+			lastSeen := "-"
+			if !stats.LastSeen.IsZero() {
+				lastSeen = stats.LastSeen.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%016X\t%d\t%d\t%d (%.1f%%)\t%s\t%s\t%d\t%s\n",
+				uint64(peer.NodeID),
+				stats.MessagesSent,
+				stats.MessagesReceived,
+				stats.Retransmissions,
+				stats.RetransmissionRate()*100,
+				stats.SmoothedRTT.Round(time.Millisecond),
+				stats.RTTVariance.Round(time.Millisecond),
+				stats.FailedHandshakes,
+				lastSeen)
+		}
+		return w.Flush()
+	},
+}
+
+// parseOperationalPeer returns the peer of the operational instance name with an optional address
+// such as 87E1B004E235A130-8FC7772401CD0696@[fd00::1]:5540.
+func parseOperationalPeer(s string) (matter.OperationalPeer, error) {
+	peer := matter.OperationalPeer{
+		FabricID:           0,
+		CompressedFabricID: 0,
+		NodeID:             0,
+		Address:            netip.AddrPort{},
+	}
+	name, addr, hasAddr := strings.Cut(s, "@")
+	fabric, node, ok := strings.Cut(name, "-")
+	if !ok {
+		return peer, fmt.Errorf("invalid operational instance name : %s", s)
+	}
+	compressed, err := strconv.ParseUint(fabric, 16, 64)
+	if err != nil {
+		return peer, fmt.Errorf("invalid compressed fabric ID : %w", err)
+	}
+	nodeID, err := strconv.ParseUint(node, 16, 64)
+	if err != nil {
+		return peer, fmt.Errorf("invalid node ID : %w", err)
+	}
+	peer.CompressedFabricID = compressed
+	peer.NodeID = matter.NodeID(nodeID)
+	if hasAddr {
+		peer.Address, err = netip.ParseAddrPort(addr)
+		if err != nil {
+			return peer, err
+		}
+	}
+	return peer, nil
+}
@@ -12,24 +12,31 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+/*
+matter is a command line utility for Matter networks.
+
+	NAME
+	matter
+
+	SYNOPSIS
+	matter [OPTIONS] COMMAND
+
+	matter is a command line utility for Matter networks.
+	Run 'matter help' to list the available commands.
+
+	RETURN VALUE
+	  Return EXIT_SUCCESS or EXIT_FAILURE
+*/
 package main
 
 import (
-	"github.com/cybergarage/go-logger/log"
-	"github.com/cybergarage/go-mdns/mdns"
-	"github.com/cybergarage/go-mdns/mdns/dns"
-)
+	"os"
 
-type Client struct {
-	*mdns.Client
-}
+	"github.com/cybergarage/go-matter/bin/matter/cli"
+)
 
-func NewClient() *Client {
-	client := &Client{
-		Client: mdns.NewClient(),
+func main() {
+	if err := cli.Execute(); err != nil {
+		os.Exit(1)
 	}
-	return client
-}
-func (client *Client) MessageReceived(msg *dns.Message) {
-	log.HexInfo(msg.Bytes())
 }
@@ -6,4 +6,10 @@ require (
 	github.com/cybergarage/go-logger v1.3.5
 	github.com/cybergarage/go-mdns v0.0.0-20240306032221-7aa483bd75de
 	github.com/cybergarage/go-safecast v1.2.3
+	github.com/spf13/cobra v1.10.2
+)
+
+require (
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/spf13/pflag v1.0.9 // indirect
 )
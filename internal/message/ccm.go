@@ -0,0 +1,165 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+)
+
+// ccm represents the AES-CCM mode of RFC 3610 with the specified nonce and tag sizes.
+type ccm struct {
+	block   cipher.Block
+	nonce   int
+	tagSize int
+}
+
+func newCCM(block cipher.Block, nonceSize int, tagSize int) (*ccm, error) {
+	if block.BlockSize() != 16 {
+		return nil, fmt.Errorf("%w CCM block size: %d", ErrInvalid, block.BlockSize())
+	}
+	if nonceSize < 7 || 13 < nonceSize {
+		return nil, fmt.Errorf("%w CCM nonce size: %d", ErrInvalid, nonceSize)
+	}
+	if tagSize < 4 || 16 < tagSize || tagSize%2 != 0 {
+		return nil, fmt.Errorf("%w CCM tag size: %d", ErrInvalid, tagSize)
+	}
+	return &ccm{
+		block:   block,
+		nonce:   nonceSize,
+		tagSize: tagSize,
+	}, nil
+}
+
+// lengthSize returns the size of the length field, which is L in RFC 3610.
+func (c *ccm) lengthSize() int {
+	return 15 - c.nonce
+}
+
+// maxLength returns the maximum length of the messages.
+func (c *ccm) maxLength() uint64 {
+	l := c.lengthSize()
+	if 8 <= l {
+		return ^uint64(0)
+	}
+	return (uint64(1) << (8 * l)) - 1
+}
+
+// counterBlock returns the counter block A_i.
+func (c *ccm) counterBlock(nonce []byte, i uint64) []byte {
+	a := make([]byte, 16)
+	a[0] = byte(c.lengthSize() - 1)
+	copy(a[1:], nonce)
+	for n := 15; c.nonce < n; n-- {
+		a[n] = byte(i)
+		i >>= 8
+	}
+	return a
+}
+
+// mac returns the CBC-MAC of the message and the additional data.
+func (c *ccm) mac(nonce []byte, plaintext []byte, data []byte) []byte {
+	b := make([]byte, 16)
+	b[0] = byte(((c.tagSize - 2) / 2) << 3)
+	b[0] |= byte(c.lengthSize() - 1)
+	if 0 < len(data) {
+		b[0] |= 0x40
+	}
+	copy(b[1:], nonce)
+	l := uint64(len(plaintext))
+	for n := 15; c.nonce < n; n-- {
+		b[n] = byte(l)
+		l >>= 8
+	}
+	x := make([]byte, 16)
+	c.block.Encrypt(x, b)
+
+	blocks := func(in []byte) {
+		for 0 < len(in) {
+			n := subtle.XORBytes(x, x, in)
+			c.block.Encrypt(x, x)
+			in = in[n:]
+		}
+	}
+	if 0 < len(data) {
+		var a []byte
+		switch {
+		case len(data) < 0xFF00:
+			a = binary.BigEndian.AppendUint16(nil, uint16(len(data)))
+		case uint64(len(data)) <= 0xFFFFFFFF:
+			a = binary.BigEndian.AppendUint32([]byte{0xFF, 0xFE}, uint32(len(data)))
+		default:
+			a = binary.BigEndian.AppendUint64([]byte{0xFF, 0xFF}, uint64(len(data)))
+		}
+		a = append(a, data...)
+		blocks(a)
+	}
+	blocks(plaintext)
+	return x[:c.tagSize]
+}
+
+// crypt encrypts or decrypts the message in the CTR mode starting from A_1.
+func (c *ccm) crypt(dst []byte, src []byte, nonce []byte) {
+	s := make([]byte, 16)
+	for i := uint64(1); 0 < len(src); i++ {
+		c.block.Encrypt(s, c.counterBlock(nonce, i))
+		n := subtle.XORBytes(dst, src, s)
+		dst, src = dst[n:], src[n:]
+	}
+}
+
+// seal appends the encrypted message and the tag to dst.
+func (c *ccm) seal(dst []byte, nonce []byte, plaintext []byte, data []byte) ([]byte, error) {
+	if len(nonce) != c.nonce {
+		return nil, fmt.Errorf("%w CCM nonce size: %d", ErrInvalid, len(nonce))
+	}
+	if c.maxLength() < uint64(len(plaintext)) {
+		return nil, fmt.Errorf("%w CCM message length: %d", ErrInvalid, len(plaintext))
+	}
+	tag := c.mac(nonce, plaintext, data)
+	s := make([]byte, 16)
+	c.block.Encrypt(s, c.counterBlock(nonce, 0))
+	subtle.XORBytes(tag, tag, s)
+
+	n := len(dst)
+	dst = append(dst, make([]byte, len(plaintext))...)
+	c.crypt(dst[n:], plaintext, nonce)
+	return append(dst, tag...), nil
+}
+
+// open appends the decrypted message to dst if the tag is authentic.
+func (c *ccm) open(dst []byte, nonce []byte, ciphertext []byte, data []byte) ([]byte, error) {
+	if len(nonce) != c.nonce {
+		return nil, fmt.Errorf("%w CCM nonce size: %d", ErrInvalid, len(nonce))
+	}
+	if len(ciphertext) < c.tagSize {
+		return nil, fmt.Errorf("%w CCM message length: %d", ErrInvalid, len(ciphertext))
+	}
+	tag := ciphertext[len(ciphertext)-c.tagSize:]
+	ciphertext = ciphertext[:len(ciphertext)-c.tagSize]
+
+	plaintext := make([]byte, len(ciphertext))
+	c.crypt(plaintext, ciphertext, nonce)
+	expected := c.mac(nonce, plaintext, data)
+	s := make([]byte, 16)
+	c.block.Encrypt(s, c.counterBlock(nonce, 0))
+	subtle.XORBytes(expected, expected, s)
+	if subtle.ConstantTimeCompare(tag, expected) != 1 {
+		return nil, ErrAuthentication
+	}
+	return append(dst, plaintext...), nil
+}
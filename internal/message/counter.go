@@ -14,14 +14,28 @@
 
 package message
 
+import (
+	"crypto/rand"
+	"encoding/binary"
+)
+
 // 4.4.1.5. Message Counter (32 bits)
 // Counter represents a message counter.
 type Counter uint32
 
-// NewCounter returns a new counter.
-func NewCounter() Counter {
+const (
+	// counterInitBits is the bit length of the random initial counter value.
+	counterInitBits = 28
+)
+
+// NewCounter returns a new counter with a random initial value.
+func NewCounter() (Counter, error) {
 	// 4.5.1.1. Message Counter Initialization
-	// TODO: All message counters SHALL be initialized with a random value
+	// All message counters SHALL be initialized with a random value
 	// using the Crypto_DRBG(len = 28) +1 primitive.
-	return 1
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return 0, err
+	}
+	return Counter(binary.LittleEndian.Uint32(b)&((1<<counterInitBits)-1)) + 1, nil
 }
@@ -0,0 +1,74 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/storage"
+)
+
+func TestNewCounter(t *testing.T) {
+	for n := 0; n < 100; n++ {
+		counter, err := NewCounter()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if counter < 1 || (1<<28) < counter {
+			t.Errorf("counter (%d) is out of range", counter)
+		}
+	}
+}
+
+func TestGlobalCounter(t *testing.T) {
+	store := storage.NewMemoryStore()
+	step := uint32(10)
+
+	counter, err := NewGlobalCounter(store, GlobalGroupEncryptedDataCounterKey, WithCounterPersistenceStep(step))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	used := map[Counter]bool{}
+	for n := 0; n < 25; n++ {
+		value, err := counter.Next()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		used[value] = true
+	}
+	last := counter.Value() - 1
+
+	// Simulates a restart without saving the last counter value.
+
+	restarted, err := NewGlobalCounter(store, GlobalGroupEncryptedDataCounterKey, WithCounterPersistenceStep(step))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	value, err := restarted.Next()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if used[value] || value <= last {
+		t.Errorf("counter (%d) is reused after (%d)", value, last)
+	}
+	if Counter(step) < value-last {
+		t.Errorf("counter (%d) jumps more than the step (%d) from (%d)", value, step, last)
+	}
+}
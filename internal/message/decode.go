@@ -0,0 +1,141 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// DecodeLayer represents a layer of a message which is decoded.
+type DecodeLayer string
+
+const (
+	// PacketLayer is the message header layer.
+	PacketLayer DecodeLayer = "packet"
+	// ExchangeLayer is the protocol header layer.
+	ExchangeLayer DecodeLayer = "exchange"
+	// ExtensionsLayer is the message or secured extensions layer.
+	ExtensionsLayer DecodeLayer = "extensions"
+)
+
+// DecodeError represents an error which occurred while decoding a message, and carries where
+// the decoded bytes became malformed.
+type DecodeError struct {
+	// Layer is the layer which was decoded.
+	Layer DecodeLayer
+	// Offset is the byte offset of the malformed field from the start of the decoded bytes.
+	Offset int
+	// Remaining is the number of bytes from the offset to the end of the decoded bytes, or -1 if it is unknown.
+	Remaining int
+	// Err is the underlying error.
+	Err error
+}
+
+// NewDecodeError returns a new decode error.
+func NewDecodeError(layer DecodeLayer, offset int, remaining int, err error) *DecodeError {
+	return &DecodeError{
+		Layer:     layer,
+		Offset:    offset,
+		Remaining: remaining,
+		Err:       err,
+	}
+}
+
+// Error returns the string representation.
+func (e *DecodeError) Error() string {
+	if e.Remaining < 0 {
+		return fmt.Sprintf("%s (%s offset %d)", e.Err, e.Layer, e.Offset)
+	}
+	return fmt.Sprintf("%s (%s offset %d, %d bytes remaining)", e.Err, e.Layer, e.Offset, e.Remaining)
+}
+
+// Unwrap returns the underlying error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// fieldReader reads header fields and tracks the offset of the read bytes. Decode errors wrap
+// the invalid error, which is ErrInvalid unless the fields belong to another package.
+type fieldReader struct {
+	reader  io.Reader
+	offset  int
+	invalid error
+}
+
+func newFieldReader(reader io.Reader, offset int) *fieldReader {
+	return &fieldReader{
+		reader:  reader,
+		offset:  offset,
+		invalid: ErrInvalid,
+	}
+}
+
+// read reads the specified field, and returns a decode error at the start of the field if the bytes are short.
+func (r *fieldReader) read(layer DecodeLayer, name string, b []byte) error {
+	offset := r.offset
+	remaining := r.remaining(offset)
+	n, err := io.ReadFull(r.reader, b)
+	r.offset += n
+	if err != nil {
+		return NewDecodeError(layer, offset, remaining, fmt.Errorf("%w %s: %w", r.invalid, name, err))
+	}
+	return nil
+}
+
+// readLengthPrefixed reads a field prefixed with its 16-bit length. The length is checked against the
+// remaining bytes before the field is allocated, so a malformed length is reported at the length field.
+func (r *fieldReader) readLengthPrefixed(layer DecodeLayer, name string) ([]byte, error) {
+	lengthOffset := r.offset
+	b := make([]byte, 2)
+	if err := r.read(layer, name+" length", b); err != nil {
+		return nil, err
+	}
+	n := int(binary.LittleEndian.Uint16(b))
+	if remaining := r.remaining(r.offset); 0 <= remaining && remaining < n {
+		return nil, r.errorAt(layer, lengthOffset, fmt.Errorf("%w %s length: %d exceeds %d bytes", r.invalid, name, n, remaining))
+	}
+	field := make([]byte, n)
+	if err := r.read(layer, name, field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+// ReadLengthPrefixed reads a field prefixed with its 16-bit length from the reader, which is at the
+// specified offset of the decoded bytes, and returns the field with the number of read bytes. Other
+// layers such as the secured extensions of the protocol header are checked the same way as the message
+// extensions, and their decode errors wrap the specified invalid error of their package.
+func ReadLengthPrefixed(reader io.Reader, offset int, layer DecodeLayer, name string, invalid error) ([]byte, int, error) {
+	r := newFieldReader(reader, offset)
+	r.invalid = invalid
+	field, err := r.readLengthPrefixed(layer, name)
+	return field, r.offset - offset, err
+}
+
+// errorAt returns a decode error at the specified offset.
+func (r *fieldReader) errorAt(layer DecodeLayer, offset int, err error) error {
+	return NewDecodeError(layer, offset, r.remaining(offset), err)
+}
+
+// remaining returns the number of bytes from the specified offset, or -1 if the reader does not know its length.
+func (r *fieldReader) remaining(offset int) int {
+	l, ok := r.reader.(interface{ Len() int })
+	if !ok {
+		return -1
+	}
+	return l.Len() + r.offset - offset
+}
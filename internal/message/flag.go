@@ -0,0 +1,72 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+// 4.4.1.2. Message Flags (8 bits)
+// Flag represents a message flag.
+type Flag uint8
+
+const (
+	flagVersionMask  = 0xF0
+	flagSourceNodeID = 0x04
+	flagDSIZMask     = 0x03
+)
+
+// 4.4.1.2. Message Flags (8 bits)
+// DestinationType represents a DSIZ field which indicates the type of the destination.
+type DestinationType uint8
+
+const (
+	// NoDestination represents that no destination is present.
+	NoDestination = DestinationType(0x00)
+	// DestinationNodeID represents that a 64-bit destination node ID is present.
+	DestinationNodeID = DestinationType(0x01)
+	// DestinationGroupID represents that a 16-bit destination group ID is present.
+	DestinationGroupID = DestinationType(0x02)
+)
+
+// NewFlag returns a new flag with the specified fields.
+func NewFlag(version int, hasSourceNodeID bool, dstType DestinationType) Flag {
+	flag := Flag((version<<4)&flagVersionMask) | Flag(dstType&flagDSIZMask)
+	if hasSourceNodeID {
+		flag |= flagSourceNodeID
+	}
+	return flag
+}
+
+// Version returns the matter message format version.
+func (flag Flag) Version() int {
+	return int((flag & flagVersionMask) >> 4)
+}
+
+// HasSourceNodeID returns true if the message has a source node ID.
+func (flag Flag) HasSourceNodeID() bool {
+	return (flag & flagSourceNodeID) != 0
+}
+
+// DestinationType returns the DSIZ field.
+func (flag Flag) DestinationType() DestinationType {
+	return DestinationType(flag & flagDSIZMask)
+}
+
+// HasDestinationNodeID returns true if the message has a destination node ID.
+func (flag Flag) HasDestinationNodeID() bool {
+	return flag.DestinationType() == DestinationNodeID
+}
+
+// HasDestinationGroupID returns true if the message has a destination group ID.
+func (flag Flag) HasDestinationGroupID() bool {
+	return flag.DestinationType() == DestinationGroupID
+}
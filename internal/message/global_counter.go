@@ -0,0 +1,142 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/cybergarage/go-matter/matter/storage"
+)
+
+// CounterNamespace is the storage namespace of the global message counters.
+const CounterNamespace = "counter"
+
+// 4.5.1. Message Counters
+// Storage keys of the global message counters.
+const (
+	GlobalUnencryptedCounterKey           = "unencrypted"
+	GlobalGroupEncryptedDataCounterKey    = "group-data"
+	GlobalGroupEncryptedControlCounterKey = "group-control"
+)
+
+// DefaultCounterPersistenceStep is the default number of counter values reserved per storage write.
+const DefaultCounterPersistenceStep = 1000
+
+// GlobalCounter represents a global message counter persisted across restarts.
+// The counter reserves values in increments of the persistence step: the end of the
+// reserved range is written to the store before any value in the range is used, and
+// the counter jumps to the persisted end on boot, so a value is never reused even if
+// the process stops without saving the last counter.
+type GlobalCounter struct {
+	sync.Mutex
+	store storage.Store
+	key   string
+	step  uint32
+	value Counter
+	limit Counter
+}
+
+// CounterOption represents an option for a global counter.
+type CounterOption func(*GlobalCounter)
+
+// WithCounterPersistenceStep returns an option to set the number of values reserved per storage write.
+func WithCounterPersistenceStep(step uint32) CounterOption {
+	return func(counter *GlobalCounter) {
+		counter.step = step
+	}
+}
+
+// NewGlobalCounter returns a global counter for the specified key, restoring the persisted state from the store.
+func NewGlobalCounter(store storage.Store, key string, opts ...CounterOption) (*GlobalCounter, error) {
+	counter := &GlobalCounter{
+		Mutex: sync.Mutex{},
+		store: store,
+		key:   key,
+		step:  DefaultCounterPersistenceStep,
+		value: 0,
+		limit: 0,
+	}
+	for _, opt := range opts {
+		opt(counter)
+	}
+	if counter.step == 0 {
+		return nil, fmt.Errorf("%w counter persistence step (%d)", ErrInvalid, counter.step)
+	}
+
+	b, err := store.Get(CounterNamespace, key)
+	switch {
+	case err == nil:
+		if len(b) != 4 {
+			return nil, fmt.Errorf("%w persisted counter (%s) : %X", ErrInvalid, key, b)
+		}
+		counter.value = Counter(binary.BigEndian.Uint32(b))
+	case errors.Is(err, storage.ErrNotFound):
+		counter.value, err = NewCounter()
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	if err := counter.reserve(); err != nil {
+		return nil, err
+	}
+
+	return counter, nil
+}
+
+// reserve persists the end of the next reserved range.
+func (counter *GlobalCounter) reserve() error {
+	limit := counter.value + Counter(counter.step)
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(limit))
+	if err := counter.store.Set(CounterNamespace, counter.key, b); err != nil {
+		return err
+	}
+	counter.limit = limit
+	return nil
+}
+
+// Next returns the current counter value and advances the counter.
+// The counter wraps around after the maximum 32-bit value.
+func (counter *GlobalCounter) Next() (Counter, error) {
+	counter.Lock()
+	defer counter.Unlock()
+	if counter.value == counter.limit {
+		if err := counter.reserve(); err != nil {
+			return 0, err
+		}
+	}
+	value := counter.value
+	counter.value++
+	return value, nil
+}
+
+// Value returns the next counter value without advancing the counter.
+func (counter *GlobalCounter) Value() Counter {
+	counter.Lock()
+	defer counter.Unlock()
+	return counter.value
+}
+
+// String returns the string representation.
+func (counter *GlobalCounter) String() string {
+	return counter.key + ":" + strconv.FormatUint(uint64(counter.Value()), 10)
+}
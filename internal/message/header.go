@@ -0,0 +1,342 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/cybergarage/go-matter/matter/encoding"
+)
+
+const (
+	// HeaderMinSize is the size of the mandatory message header fields.
+	HeaderMinSize = 8
+	// SupportedVersion is the supported message format version.
+	SupportedVersion = 0
+)
+
+// 4.4.1. Message Header Field Descriptions
+// Header represents a message header.
+type Header struct {
+	length             [2]byte
+	flag               Flag
+	SessionID          SessionID
+	SecurityFlag       SecurityFlag
+	Counter            Counter
+	SourceNodeID       NodeID
+	DestinationNodeID  NodeID
+	DestinationGroupID GroupID
+	Extensions         []byte
+}
+
+// NewHeader returns a new header.
+func NewHeader() *Header {
+	header := &Header{
+		length:             [2]byte{},
+		flag:               0,
+		SessionID:          0,
+		SecurityFlag:       0,
+		Counter:            0,
+		SourceNodeID:       0,
+		DestinationNodeID:  0,
+		DestinationGroupID: 0,
+		Extensions:         nil,
+	}
+	return header
+}
+
+// NewHeaderFromBytes returns a new header decoded from the specified message bytes
+// which do not start with the message length field.
+func NewHeaderFromBytes(b []byte) (*Header, error) {
+	return NewHeaderFromReader(bytes.NewReader(b))
+}
+
+// NewHeaderFromReader returns a new header decoded from the specified reader
+// which does not start with the message length field.
+// Malformed headers are reported with a DecodeError.
+func NewHeaderFromReader(reader io.Reader) (*Header, error) {
+	header := NewHeader()
+	err := header.readFields(reader, 0)
+	if err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// SetLength sets a length.
+func (header *Header) SetLength(l uint16) {
+	encoding.Uint16ToBytes(l, &header.length)
+}
+
+// Length returns a length.
+func (header *Header) Length() uint16 {
+	return encoding.Byte2ToUint16(header.length)
+}
+
+// SetFlag sets a flag.
+func (header *Header) SetFlag(f Flag) {
+	header.flag = f
+}
+
+// Flag returns a flag.
+func (header *Header) Flag() Flag {
+	return header.flag
+}
+
+// SetSourceNodeID sets the source node ID and the S flag. Only operational node IDs can be set.
+func (header *Header) SetSourceNodeID(id NodeID) error {
+	if !id.IsValidOperational() {
+		return fmt.Errorf("%w source node ID: %016X (%s)", ErrInvalid, uint64(id), id.Category())
+	}
+	header.SourceNodeID = id
+	header.flag = NewFlag(header.flag.Version(), true, header.flag.DestinationType())
+	return nil
+}
+
+// SetDestinationNodeID sets the destination node ID and the DSIZ field. Only operational node IDs can be set.
+func (header *Header) SetDestinationNodeID(id NodeID) error {
+	if !id.IsValidOperational() {
+		return fmt.Errorf("%w destination node ID: %016X (%s)", ErrInvalid, uint64(id), id.Category())
+	}
+	header.DestinationNodeID = id
+	header.flag = NewFlag(header.flag.Version(), header.flag.HasSourceNodeID(), DestinationNodeID)
+	return nil
+}
+
+// SetDestinationGroupID sets the destination group ID and the DSIZ field.
+func (header *Header) SetDestinationGroupID(id GroupID) error {
+	if id == 0 {
+		return fmt.Errorf("%w destination group ID: %04X", ErrInvalid, uint16(id))
+	}
+	header.DestinationGroupID = id
+	header.flag = NewFlag(header.flag.Version(), header.flag.HasSourceNodeID(), DestinationGroupID)
+	return nil
+}
+
+// IsUnsecured returns true if the message belongs to the unsecured session, whose messages are
+// not encrypted and are counted by the global unencrypted message counter.
+func (header *Header) IsUnsecured() bool {
+	return header.SessionID == 0 && header.SecurityFlag.IsUnicastSession()
+}
+
+// Size returns the encoded size of the header without the message length field.
+func (header *Header) Size() int {
+	size := HeaderMinSize
+	if header.flag.HasSourceNodeID() {
+		size += 8
+	}
+	switch header.flag.DestinationType() {
+	case DestinationNodeID:
+		size += 8
+	case DestinationGroupID:
+		size += 2
+	}
+	if header.SecurityFlag.IsExtendedMessage() {
+		size += 2 + len(header.Extensions)
+	}
+	return size
+}
+
+// Read reads a header which starts with the message length field from the specified reader.
+func (header *Header) Read(reader io.Reader) error {
+	// 4.4.1. Message Header Field Descriptions
+	// Message Length
+	r := newFieldReader(reader, 0)
+	if err := r.read(PacketLayer, "message length", header.length[:]); err != nil {
+		return err
+	}
+	return header.readFields(reader, len(header.length))
+}
+
+func (header *Header) readFields(reader io.Reader, offset int) error {
+	r := newFieldReader(reader, offset)
+	b := make([]byte, 8)
+
+	// 4.4.1.2. Message Flags (8 bits)
+	flagOffset := r.offset
+	if err := r.read(PacketLayer, "message flags", b[:1]); err != nil {
+		return err
+	}
+	header.flag = Flag(b[0])
+	if header.flag.Version() != SupportedVersion {
+		return r.errorAt(PacketLayer, flagOffset, fmt.Errorf("%w version: %d", ErrNotSupported, header.flag.Version()))
+	}
+
+	// 4.4.1.3. Session ID (16 bits)
+	if err := r.read(PacketLayer, "session ID", b[:2]); err != nil {
+		return err
+	}
+	header.SessionID = SessionID(binary.LittleEndian.Uint16(b))
+
+	// 4.4.1.4. Security Flags (8 bits)
+	secFlagOffset := r.offset
+	if err := r.read(PacketLayer, "security flags", b[:1]); err != nil {
+		return err
+	}
+	header.SecurityFlag = SecurityFlag(b[0])
+	if err := validateSecurityFlag(header.SecurityFlag); err != nil {
+		return r.errorAt(PacketLayer, secFlagOffset, err)
+	}
+
+	// 4.4.1.5. Message Counter (32 bits)
+	if err := r.read(PacketLayer, "message counter", b[:4]); err != nil {
+		return err
+	}
+	header.Counter = Counter(binary.LittleEndian.Uint32(b))
+
+	// 4.4.1.6. Source Node ID (64 bits)
+	if header.flag.HasSourceNodeID() {
+		idOffset := r.offset
+		if err := r.read(PacketLayer, "source node ID", b[:8]); err != nil {
+			return err
+		}
+		header.SourceNodeID = NodeID(binary.LittleEndian.Uint64(b))
+		if err := validateNodeID("source node ID", header.SourceNodeID); err != nil {
+			return r.errorAt(PacketLayer, idOffset, err)
+		}
+	}
+
+	// 4.4.1.7. Destination Node ID (0/16/64 bits)
+	idOffset := r.offset
+	switch header.flag.DestinationType() {
+	case NoDestination:
+	case DestinationNodeID:
+		if err := r.read(PacketLayer, "destination node ID", b[:8]); err != nil {
+			return err
+		}
+		header.DestinationNodeID = NodeID(binary.LittleEndian.Uint64(b))
+		if err := validateNodeID("destination node ID", header.DestinationNodeID); err != nil {
+			return r.errorAt(PacketLayer, idOffset, err)
+		}
+	case DestinationGroupID:
+		if err := r.read(PacketLayer, "destination group ID", b[:2]); err != nil {
+			return err
+		}
+		header.DestinationGroupID = GroupID(binary.LittleEndian.Uint16(b))
+		if err := validateGroupID(header.DestinationGroupID); err != nil {
+			return r.errorAt(PacketLayer, idOffset, err)
+		}
+	default:
+		return r.errorAt(PacketLayer, flagOffset, fmt.Errorf("%w destination type: %d", ErrInvalid, header.flag.DestinationType()))
+	}
+
+	// 4.4.1.8. Message Extensions (variable)
+	if header.SecurityFlag.IsExtendedMessage() {
+		extensions, err := r.readLengthPrefixed(ExtensionsLayer, "message extensions")
+		if err != nil {
+			return err
+		}
+		header.Extensions = extensions
+	}
+
+	// The session type in the security flags is inconsistent with the other fields.
+	if err := header.Validate(); err != nil {
+		return r.errorAt(PacketLayer, secFlagOffset, err)
+	}
+	return nil
+}
+
+// Validate returns an error if the flags are reserved or inconsistent with the session type,
+// or if a node ID is not in the operational node ID range.
+func (header *Header) Validate() error {
+	secFlag := header.SecurityFlag
+	if err := validateSecurityFlag(secFlag); err != nil {
+		return err
+	}
+	if header.flag.HasSourceNodeID() {
+		if err := validateNodeID("source node ID", header.SourceNodeID); err != nil {
+			return err
+		}
+	}
+	if header.flag.HasDestinationNodeID() {
+		if err := validateNodeID("destination node ID", header.DestinationNodeID); err != nil {
+			return err
+		}
+	}
+	if header.flag.HasDestinationGroupID() {
+		if err := validateGroupID(header.DestinationGroupID); err != nil {
+			return err
+		}
+	}
+	switch {
+	case secFlag.IsGroupSession():
+		// 4.16.2. Group messages are sent to a group ID from a source node ID.
+		if !header.flag.HasDestinationGroupID() {
+			return fmt.Errorf("%w %s session destination type: %d", ErrInvalid, secFlag.SessionType(), header.flag.DestinationType())
+		}
+		if !header.flag.HasSourceNodeID() {
+			return fmt.Errorf("%w %s session without source node ID", ErrInvalid, secFlag.SessionType())
+		}
+	case secFlag.IsUnicastSession():
+		if header.flag.HasDestinationGroupID() {
+			return fmt.Errorf("%w %s session destination type: %d", ErrInvalid, secFlag.SessionType(), header.flag.DestinationType())
+		}
+	}
+	if !secFlag.IsExtendedMessage() && 0 < len(header.Extensions) {
+		return fmt.Errorf("%w message extensions without MX flag", ErrInvalid)
+	}
+	return nil
+}
+
+func validateSecurityFlag(f SecurityFlag) error {
+	if !f.IsValid() {
+		return fmt.Errorf("%w security flags: %02X", ErrInvalid, uint8(f))
+	}
+	return nil
+}
+
+func validateNodeID(name string, id NodeID) error {
+	if !id.IsValidOperational() {
+		return fmt.Errorf("%w %s: %016X (%s)", ErrInvalid, name, uint64(id), id.Category())
+	}
+	return nil
+}
+
+func validateGroupID(id GroupID) error {
+	if id == 0 {
+		return fmt.Errorf("%w destination group ID: %04X", ErrInvalid, uint16(id))
+	}
+	return nil
+}
+
+// Bytes returns the encoded header without the message length field.
+func (header *Header) Bytes() []byte {
+	return header.AppendBytes(make([]byte, 0, header.Size()))
+}
+
+// AppendBytes appends the encoded header without the message length field to the specified buffer.
+func (header *Header) AppendBytes(b []byte) []byte {
+	b = append(b, byte(header.flag))
+	b = binary.LittleEndian.AppendUint16(b, uint16(header.SessionID))
+	b = append(b, byte(header.SecurityFlag))
+	b = binary.LittleEndian.AppendUint32(b, uint32(header.Counter))
+	if header.flag.HasSourceNodeID() {
+		b = binary.LittleEndian.AppendUint64(b, uint64(header.SourceNodeID))
+	}
+	switch header.flag.DestinationType() {
+	case DestinationNodeID:
+		b = binary.LittleEndian.AppendUint64(b, uint64(header.DestinationNodeID))
+	case DestinationGroupID:
+		b = binary.LittleEndian.AppendUint16(b, uint16(header.DestinationGroupID))
+	}
+	if header.SecurityFlag.IsExtendedMessage() {
+		b = binary.LittleEndian.AppendUint16(b, uint16(len(header.Extensions)))
+		b = append(b, header.Extensions...)
+	}
+	return b
+}
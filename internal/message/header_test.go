@@ -0,0 +1,238 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+func TestHeader(t *testing.T) {
+	newHeader := func(flag Flag, secFlag SecurityFlag, ext []byte) *Header {
+		header := NewHeader()
+		header.SetFlag(flag)
+		header.SessionID = 0x1234
+		header.SecurityFlag = secFlag
+		header.Counter = 0x89ABCDEF
+		header.SourceNodeID = 0x0102030405060708
+		header.DestinationNodeID = 0x1112131415161718
+		header.DestinationGroupID = 0x2122
+		header.Extensions = ext
+		return header
+	}
+
+	tests := []struct {
+		name   string
+		header *Header
+		size   int
+	}{
+		{"minimum", newHeader(NewFlag(0, false, NoDestination), 0x00, nil), 8},
+		{"source", newHeader(NewFlag(0, true, NoDestination), 0x00, nil), 16},
+		{"source+node", newHeader(NewFlag(0, true, DestinationNodeID), 0x00, nil), 24},
+		{"group", newHeader(NewFlag(0, true, DestinationGroupID), 0x01, nil), 18},
+		{"extensions", newHeader(NewFlag(0, false, NoDestination), 0x20, []byte{0x01, 0x02, 0x03}), 13},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := test.header.Bytes()
+			if len(b) != test.size || test.header.Size() != test.size {
+				t.Errorf("%d != %d", len(b), test.size)
+			}
+			header, err := NewHeaderFromBytes(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(header.Bytes(), b) {
+				t.Errorf("%X != %X", header.Bytes(), b)
+			}
+			prefix := []byte{0xFF}
+			if ab := header.AppendBytes(prefix); !bytes.Equal(ab[len(prefix):], b) || ab[0] != prefix[0] {
+				t.Errorf("%X != %X", ab, b)
+			}
+			if header.Counter != test.header.Counter {
+				t.Errorf("%08X != %08X", header.Counter, test.header.Counter)
+			}
+			for n := 0; n < len(b); n++ {
+				_, err := NewHeaderFromBytes(b[:n])
+				if !errors.Is(err, ErrInvalid) {
+					t.Errorf("[%d] %v is not %v", n, err, ErrInvalid)
+				}
+			}
+		})
+	}
+}
+
+func TestHeaderErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		b        []byte
+		expected error
+	}{
+		{"version", []byte{0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, ErrNotSupported},
+		{"dsiz", []byte{0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, ErrInvalid},
+		{"reserved security flags", []byte{0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00, 0x00}, ErrInvalid},
+		{"reserved session type", []byte{0x00, 0x00, 0x00, 0x03, 0x00, 0x00, 0x00, 0x00}, ErrInvalid},
+		{"group without group ID", []byte{0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00}, ErrInvalid},
+		{"group without source", []byte{0x02, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x22, 0x21}, ErrInvalid},
+		{"unspecified source", []byte{0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, ErrInvalid},
+		{"group destination node", []byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, ErrInvalid},
+		{"unicast with group ID", []byte{0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x22, 0x21}, ErrInvalid},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewHeaderFromBytes(test.b)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("%v is not %v", err, test.expected)
+			}
+		})
+	}
+}
+
+func TestHeaderDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		b         []byte
+		layer     DecodeLayer
+		offset    int
+		remaining int
+	}{
+		{"empty", []byte{}, PacketLayer, 0, 0},
+		{"short counter", []byte{0x00, 0x00, 0x00, 0x00, 0x01, 0x02}, PacketLayer, 4, 2},
+		{"short source", []byte{0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01}, PacketLayer, 8, 1},
+		{"version", []byte{0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, PacketLayer, 0, 8},
+		{"reserved security flags", []byte{0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00, 0x00}, PacketLayer, 3, 5},
+		{"unspecified source", []byte{0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, PacketLayer, 8, 8},
+		{"group without source", []byte{0x02, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x22, 0x21}, PacketLayer, 3, 7},
+		{"short extensions", []byte{0x00, 0x00, 0x00, 0x20, 0x00, 0x00, 0x00, 0x00, 0x03, 0x00, 0x01}, ExtensionsLayer, 8, 3},
+		{"short extensions length", []byte{0x00, 0x00, 0x00, 0x20, 0x00, 0x00, 0x00, 0x00, 0x03}, ExtensionsLayer, 8, 1},
+		{"oversized extensions", []byte{0x00, 0x00, 0x00, 0x20, 0x00, 0x00, 0x00, 0x00, 0xFF, 0xFF, 0x01, 0x02}, ExtensionsLayer, 8, 4},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewHeaderFromBytes(test.b)
+			var decodeErr *DecodeError
+			if !errors.As(err, &decodeErr) {
+				t.Fatalf("%v is not a decode error", err)
+			}
+			if decodeErr.Layer != test.layer || decodeErr.Offset != test.offset || decodeErr.Remaining != test.remaining {
+				t.Errorf("%s %d %d != %s %d %d", decodeErr.Layer, decodeErr.Offset, decodeErr.Remaining, test.layer, test.offset, test.remaining)
+			}
+		})
+	}
+
+	// Offsets include the message length field, and are unknown without the length of the reader.
+	header := NewHeader()
+	err := header.Read(io.MultiReader(bytes.NewReader([]byte{0x08, 0x00, 0x00, 0x00, 0x00, 0x00})))
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("%v is not a decode error", err)
+	}
+	if decodeErr.Offset != 6 || decodeErr.Remaining != -1 {
+		t.Errorf("%d %d", decodeErr.Offset, decodeErr.Remaining)
+	}
+}
+
+func TestHeaderExtensions(t *testing.T) {
+	tests := []struct {
+		name       string
+		b          []byte
+		extensions []byte
+		size       int
+	}{
+		{"empty", []byte{0x00, 0x00, 0x00, 0x20, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, []byte{}, 10},
+		{"exact", []byte{0x00, 0x00, 0x00, 0x20, 0x00, 0x00, 0x00, 0x00, 0x02, 0x00, 0xAA, 0xBB}, []byte{0xAA, 0xBB}, 12},
+		{"payload", []byte{0x00, 0x00, 0x00, 0x20, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0xAA, 0xBB, 0xCC}, []byte{0xAA}, 11},
+		{"source", []byte{0x04, 0x00, 0x00, 0x20, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0xAA, 0xBB}, []byte{0xAA}, 19},
+		{"without flag", []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0xAA}, nil, 8},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			header, err := NewHeaderFromBytes(test.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(header.Extensions, test.extensions) || (header.Extensions == nil) != (test.extensions == nil) {
+				t.Errorf("%X != %X", header.Extensions, test.extensions)
+			}
+			if header.Size() != test.size {
+				t.Errorf("%d != %d", header.Size(), test.size)
+			}
+			if !bytes.Equal(header.Bytes(), test.b[:test.size]) {
+				t.Errorf("%X != %X", header.Bytes(), test.b[:test.size])
+			}
+		})
+	}
+}
+
+func TestHeaderNodeIDs(t *testing.T) {
+	header := NewHeader()
+	if err := header.SetSourceNodeID(0x0102030405060708); err != nil {
+		t.Fatal(err)
+	}
+	if err := header.SetDestinationNodeID(0x1112131415161718); err != nil {
+		t.Fatal(err)
+	}
+	if header.Flag() != NewFlag(0, true, DestinationNodeID) {
+		t.Errorf("%02X", uint8(header.Flag()))
+	}
+	if err := header.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	illegalIDs := []NodeID{
+		types.UnspecifiedNodeID,
+		types.NewGroupNodeID(0x0001),
+		types.TemporaryLocalNodeIDMin,
+		types.PAKEKeyIDMin,
+		types.CASEAuthenticatedTagMin,
+	}
+	for _, id := range illegalIDs {
+		t.Run(id.Category().String(), func(t *testing.T) {
+			if err := header.SetSourceNodeID(id); !errors.Is(err, ErrInvalid) {
+				t.Errorf("%v is not %v", err, ErrInvalid)
+			}
+			if err := header.SetDestinationNodeID(id); !errors.Is(err, ErrInvalid) {
+				t.Errorf("%v is not %v", err, ErrInvalid)
+			}
+		})
+	}
+
+	if err := header.SetDestinationGroupID(0); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+	if err := header.SetDestinationGroupID(0x0001); err != nil {
+		t.Fatal(err)
+	}
+	header.SecurityFlag = NewSecurityFlag(GroupSession)
+	if err := header.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkHeaderAppendBytes(b *testing.B) {
+	header := NewHeader()
+	header.SetFlag(NewFlag(0, true, DestinationNodeID))
+	header.SourceNodeID = 0x0102030405060708
+	header.DestinationNodeID = 0x1112131415161718
+	buf := make([]byte, 0, header.Size())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = header.AppendBytes(buf[:0])
+	}
+}
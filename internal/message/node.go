@@ -14,6 +14,10 @@
 
 package message
 
+import (
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
 // 4.4.1.6. Source Node ID (64 bits)
 // NodeID represents a node ID.
-type NodeID uint64
+type NodeID = types.NodeID
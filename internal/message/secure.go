@@ -0,0 +1,97 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"crypto/aes"
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	// SymmetricKeySize is the size of the message encryption keys, which is CRYPTO_SYMMETRIC_KEY_LENGTH_BYTES.
+	SymmetricKeySize = 16
+	// MICSize is the size of the message integrity check, which is CRYPTO_AEAD_MIC_LENGTH_BYTES.
+	MICSize = 16
+	// NonceSize is the size of the security nonce, which is CRYPTO_AEAD_NONCE_LENGTH_BYTES.
+	NonceSize = 13
+)
+
+// 4.8. Message Security
+// SecureCodec represents an encoder and decoder of the secured messages of a session in one direction.
+// The message header is authenticated as additional data, and the payload, which is the protocol
+// header including its secured extensions and the application payload, is encrypted and authenticated.
+type SecureCodec struct {
+	ccm          *ccm
+	sourceNodeID NodeID
+}
+
+// NewSecureCodec returns a new secure codec with the encryption key and the node ID of the sender of the
+// messages. The node ID is used in the security nonce unless the message header has the source node ID,
+// and it is the unspecified node ID for PASE sessions.
+func NewSecureCodec(key []byte, sourceNodeID NodeID) (*SecureCodec, error) {
+	if len(key) != SymmetricKeySize {
+		return nil, fmt.Errorf("%w key size: %d", ErrInvalid, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	c, err := newCCM(block, NonceSize, MICSize)
+	if err != nil {
+		return nil, err
+	}
+	return &SecureCodec{
+		ccm:          c,
+		sourceNodeID: sourceNodeID,
+	}, nil
+}
+
+// 4.8.1.1. Security Nonce
+// Nonce returns the security nonce of the message header.
+func (codec *SecureCodec) Nonce(header *Header) []byte {
+	nodeID := codec.sourceNodeID
+	if header.Flag().HasSourceNodeID() {
+		nodeID = header.SourceNodeID
+	}
+	b := make([]byte, 0, NonceSize)
+	b = append(b, byte(header.SecurityFlag))
+	b = binary.LittleEndian.AppendUint32(b, uint32(header.Counter))
+	return binary.LittleEndian.AppendUint64(b, uint64(nodeID))
+}
+
+// Seal returns the encoded message header followed by the encrypted payload and the MIC.
+func (codec *SecureCodec) Seal(header *Header, payload []byte) ([]byte, error) {
+	if err := header.Validate(); err != nil {
+		return nil, err
+	}
+	b := header.AppendBytes(make([]byte, 0, header.Size()+len(payload)+MICSize))
+	return codec.ccm.seal(b, codec.Nonce(header), payload, b)
+}
+
+// Open decodes the message header, and returns it with the decrypted payload if the MIC is authentic.
+// ErrAuthentication is returned if the header or the payload has been modified.
+func (codec *SecureCodec) Open(b []byte) (*Header, []byte, error) {
+	header, err := NewHeaderFromBytes(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	n := header.Size()
+	payload, err := codec.ccm.open(nil, codec.Nonce(header), b[n:], b[:n])
+	if err != nil {
+		return nil, nil, err
+	}
+	return header, payload, nil
+}
@@ -0,0 +1,158 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"bytes"
+	"crypto/aes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCCM(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       string
+		nonce     string
+		data      string
+		plaintext string
+		tagSize   int
+		expected  string
+	}{
+		{
+			"RFC 3610 packet vector #1",
+			"C0C1C2C3C4C5C6C7C8C9CACBCCCDCECF",
+			"00000003020100A0A1A2A3A4A5",
+			"0001020304050607",
+			"08090A0B0C0D0E0F101112131415161718191A1B1C1D1E",
+			8,
+			"588C979A61C663D2F066D0C2C0F989806D5F6B61DAC38417E8D12CFDF926E0",
+		},
+		{
+			"SP 800-38C example 1",
+			"404142434445464748494A4B4C4D4E4F",
+			"10111213141516",
+			"0001020304050607",
+			"20212223",
+			4,
+			"7162015B4DAC255D",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			decode := func(s string) []byte {
+				b, err := hex.DecodeString(s)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return b
+			}
+			block, err := aes.NewCipher(decode(test.key))
+			if err != nil {
+				t.Fatal(err)
+			}
+			nonce := decode(test.nonce)
+			c, err := newCCM(block, len(nonce), test.tagSize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sealed, err := c.seal(nil, nonce, decode(test.plaintext), decode(test.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(sealed, decode(test.expected)) {
+				t.Errorf("%X != %s", sealed, test.expected)
+			}
+			opened, err := c.open(nil, nonce, sealed, decode(test.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(opened, decode(test.plaintext)) {
+				t.Errorf("%X != %s", opened, test.plaintext)
+			}
+		})
+	}
+}
+
+func TestSecureCodec(t *testing.T) {
+	key := []byte("0123456789ABCDEF")
+	codec, err := NewSecureCodec(key, 0x0102030405060708)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header := NewHeader()
+	header.SessionID = 0x1234
+	header.SecurityFlag = NewSecurityFlag(UnicastSession).With(MessageExtensionsFlag)
+	header.Counter = 0x89ABCDEF
+	header.Extensions = []byte{0xAA, 0xBB}
+	if err := header.SetDestinationNodeID(0x0000000000001234); err != nil {
+		t.Fatal(err)
+	}
+	payload := []byte{0x05, 0x02, 0x34, 0x12, 0x01, 0x00, 0x15, 0x18}
+
+	nonce := codec.Nonce(header)
+	if expected := "20EFCDAB890807060504030201"; hex.EncodeToString(nonce) != strings.ToLower(expected) {
+		t.Errorf("nonce (%X) != (%s)", nonce, expected)
+	}
+
+	b, err := codec.Seal(header, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != header.Size()+len(payload)+MICSize {
+		t.Errorf("sealed size (%d) != (%d)", len(b), header.Size()+len(payload)+MICSize)
+	}
+	if bytes.Contains(b[header.Size():], payload[:4]) {
+		t.Errorf("payload (%X) is not encrypted", b)
+	}
+
+	opened, decrypted, err := codec.Open(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opened.Counter != header.Counter || !bytes.Equal(opened.Extensions, header.Extensions) {
+		t.Errorf("header (%+v) != (%+v)", opened, header)
+	}
+	if !bytes.Equal(decrypted, payload) {
+		t.Errorf("%X != %X", decrypted, payload)
+	}
+
+	// Any modified byte of the header, the payload or the MIC is detected.
+
+	for n := range b {
+		tampered := bytes.Clone(b)
+		tampered[n] ^= 0x01
+		if _, _, err := codec.Open(tampered); err == nil {
+			t.Errorf("tampered byte (%d) is not detected", n)
+		}
+	}
+
+	// The nonce depends on the sender node ID.
+
+	other, err := NewSecureCodec(key, 0x0102030405060709)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := other.Open(b); !errors.Is(err, ErrAuthentication) {
+		t.Errorf("%v is not %v", err, ErrAuthentication)
+	}
+
+	if _, err := NewSecureCodec(key[:8], 0); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+// 4.4.1.4. Security Flags (8 bits)
+// SecurityFlag represents a message security flag.
+type SecurityFlag uint8
+
+const (
+	// MessagePrivacyFlag represents the P flag which indicates that the message uses privacy enhancements.
+	MessagePrivacyFlag SecurityFlag = 0x80
+	// ControlMessageFlag represents the C flag which indicates a message control message.
+	ControlMessageFlag SecurityFlag = 0x40
+	// MessageExtensionsFlag represents the MX flag which indicates that message extensions are present.
+	MessageExtensionsFlag SecurityFlag = 0x20
+
+	securityFlagReservedMask    SecurityFlag = 0x1C
+	securityFlagSessionTypeMask SecurityFlag = 0x03
+)
+
+// NewSecurityFlag returns a new security flag of the specified session type without other flags.
+func NewSecurityFlag(sessionType SessionType) SecurityFlag {
+	return SecurityFlag(sessionType) & securityFlagSessionTypeMask
+}
+
+// With returns a copy of the security flag with the specified flags set.
+func (flag SecurityFlag) With(flags SecurityFlag) SecurityFlag {
+	return flag | flags
+}
+
+// Without returns a copy of the security flag with the specified flags cleared.
+func (flag SecurityFlag) Without(flags SecurityFlag) SecurityFlag {
+	return flag & ^flags
+}
+
+// IsPrivacyMessage returns true if the message is privacy.
+func (flag SecurityFlag) IsPrivacyMessage() bool {
+	return (flag & MessagePrivacyFlag) != 0
+}
+
+// IsControlledMessage returns true if the message is controlled.
+func (flag SecurityFlag) IsControlledMessage() bool {
+	return (flag & ControlMessageFlag) != 0
+}
+
+// IsExtendedMessage returns true if the message is extended.
+func (flag SecurityFlag) IsExtendedMessage() bool {
+	return (flag & MessageExtensionsFlag) != 0
+}
+
+// SessionType returns the session type.
+func (flag SecurityFlag) SessionType() SessionType {
+	return (SessionType)(flag & securityFlagSessionTypeMask)
+}
+
+// IsUnicastSession returns true if the message belongs to a unicast session.
+func (flag SecurityFlag) IsUnicastSession() bool {
+	return flag.SessionType() == UnicastSession
+}
+
+// IsGroupSession returns true if the message belongs to a group session.
+func (flag SecurityFlag) IsGroupSession() bool {
+	return flag.SessionType() == GroupSession
+}
+
+// IsValid returns true if no reserved bit is set and the session type is not reserved.
+func (flag SecurityFlag) IsValid() bool {
+	return (flag&securityFlagReservedMask) == 0 && flag.SessionType().IsValid()
+}
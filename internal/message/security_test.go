@@ -0,0 +1,61 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"testing"
+)
+
+func TestSecurityFlag(t *testing.T) {
+	tests := []struct {
+		flag    SecurityFlag
+		typ     SessionType
+		unicast bool
+		group   bool
+		valid   bool
+	}{
+		{NewSecurityFlag(UnicastSession), UnicastSession, true, false, true},
+		{NewSecurityFlag(GroupSession), GroupSession, false, true, true},
+		{NewSecurityFlag(GroupSession).With(MessagePrivacyFlag | ControlMessageFlag), GroupSession, false, true, true},
+		{NewSecurityFlag(UnicastSession).With(MessageExtensionsFlag), UnicastSession, true, false, true},
+		{SecurityFlag(0x03), SessionType(0x03), false, false, false},
+		{SecurityFlag(0x04), UnicastSession, true, false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.flag.SessionType().String(), func(t *testing.T) {
+			if test.flag.SessionType() != test.typ {
+				t.Errorf("%s != %s", test.flag.SessionType(), test.typ)
+			}
+			if test.flag.IsUnicastSession() != test.unicast {
+				t.Errorf("%02X: unicast %t != %t", uint8(test.flag), test.flag.IsUnicastSession(), test.unicast)
+			}
+			if test.flag.IsGroupSession() != test.group {
+				t.Errorf("%02X: group %t != %t", uint8(test.flag), test.flag.IsGroupSession(), test.group)
+			}
+			if test.flag.IsValid() != test.valid {
+				t.Errorf("%02X: valid %t != %t", uint8(test.flag), test.flag.IsValid(), test.valid)
+			}
+		})
+	}
+
+	flag := NewSecurityFlag(GroupSession).With(MessagePrivacyFlag | MessageExtensionsFlag)
+	if !flag.IsPrivacyMessage() || flag.IsControlledMessage() || !flag.IsExtendedMessage() {
+		t.Errorf("%02X", uint8(flag))
+	}
+	flag = flag.Without(MessagePrivacyFlag)
+	if flag.IsPrivacyMessage() || !flag.IsGroupSession() {
+		t.Errorf("%02X", uint8(flag))
+	}
+}
@@ -21,10 +21,30 @@ type SessionType uint8
 const (
 	// UnicastSession represents a unicast session type.
 	UnicastSession = (SessionType)(0x00)
+	// GroupSession represents a group session type.
+	GroupSession = (SessionType)(0x01)
 	// GroupeSession represents a group session type.
-	GroupeSession = (SessionType)(0x01)
+	//
+	// Deprecated: Use GroupSession.
+	GroupeSession = GroupSession
 )
 
+// IsValid returns true if the session type is not reserved.
+func (typ SessionType) IsValid() bool {
+	return typ == UnicastSession || typ == GroupSession
+}
+
+// String returns the string representation.
+func (typ SessionType) String() string {
+	switch typ {
+	case UnicastSession:
+		return "Unicast"
+	case GroupSession:
+		return "Group"
+	}
+	return "Reserved"
+}
+
 // 4.4.1.3. Session ID (16 bits)
 // SessionID represents a session ID.
 type SessionID uint16
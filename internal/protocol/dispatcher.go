@@ -0,0 +1,90 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Handler represents a handler which receives messages of a registered protocol.
+type Handler func(msg *Message) error
+
+// Dispatcher represents a dispatcher which delivers messages to the handler of their protocol.
+type Dispatcher struct {
+	sync.RWMutex
+	handlers map[QualifiedProtocolID]Handler
+}
+
+// NewDispatcher returns a new dispatcher without handlers.
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		RWMutex:  sync.RWMutex{},
+		handlers: map[QualifiedProtocolID]Handler{},
+	}
+}
+
+// RegisterProtocol registers the handler of the specified standard protocol.
+func (dispatcher *Dispatcher) RegisterProtocol(protocolID ProtocolID, handler Handler) error {
+	return dispatcher.register(NewStandardProtocolID(protocolID), handler)
+}
+
+// RegisterVendorProtocol registers the handler of the specified vendor protocol.
+// The standard vendor ID cannot be used because it is reserved for the Matter protocols.
+func (dispatcher *Dispatcher) RegisterVendorProtocol(vendorID VenderID, protocolID ProtocolID, handler Handler) error {
+	id := NewVendorProtocolID(vendorID, protocolID)
+	if id.IsStandard() {
+		return fmt.Errorf("%w vendor protocol: %s", ErrInvalid, id)
+	}
+	return dispatcher.register(id, handler)
+}
+
+func (dispatcher *Dispatcher) register(id QualifiedProtocolID, handler Handler) error {
+	if handler == nil {
+		return fmt.Errorf("%w protocol handler: %s", ErrInvalid, id)
+	}
+	dispatcher.Lock()
+	defer dispatcher.Unlock()
+	if _, ok := dispatcher.handlers[id]; ok {
+		return fmt.Errorf("protocol %s %w", id, ErrExists)
+	}
+	dispatcher.handlers[id] = handler
+	return nil
+}
+
+// Unregister removes the handler of the specified protocol.
+func (dispatcher *Dispatcher) Unregister(id QualifiedProtocolID) {
+	dispatcher.Lock()
+	defer dispatcher.Unlock()
+	delete(dispatcher.handlers, id)
+}
+
+// LookupHandler returns the handler of the specified protocol.
+func (dispatcher *Dispatcher) LookupHandler(id QualifiedProtocolID) (Handler, bool) {
+	dispatcher.RLock()
+	defer dispatcher.RUnlock()
+	handler, ok := dispatcher.handlers[id]
+	return handler, ok
+}
+
+// Dispatch delivers the message to the handler of its protocol, or returns ErrNotFound.
+func (dispatcher *Dispatcher) Dispatch(msg *Message) error {
+	id := msg.Protocol()
+	handler, ok := dispatcher.LookupHandler(id)
+	if !ok {
+		return fmt.Errorf("protocol %s handler is %w", id, ErrNotFound)
+	}
+	return handler(msg)
+}
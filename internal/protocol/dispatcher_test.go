@@ -0,0 +1,85 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDispatcher(t *testing.T) {
+	const (
+		vendorID   = VenderID(0xFFF1)
+		protocolID = ProtocolID(0x0001)
+	)
+
+	dispatcher := NewDispatcher()
+	received := []QualifiedProtocolID{}
+	handler := func(msg *Message) error {
+		received = append(received, msg.Protocol())
+		return nil
+	}
+
+	if err := dispatcher.RegisterProtocol(protocolID, handler); err != nil {
+		t.Fatal(err)
+	}
+	if err := dispatcher.RegisterVendorProtocol(vendorID, protocolID, handler); err != nil {
+		t.Fatal(err)
+	}
+	if err := dispatcher.RegisterVendorProtocol(vendorID, protocolID, handler); !errors.Is(err, ErrExists) {
+		t.Errorf("%v is not %v", err, ErrExists)
+	}
+	if err := dispatcher.RegisterVendorProtocol(0, protocolID, handler); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+
+	header := NewVendorHeader(vendorID, protocolID, 0x01)
+	if !header.ExchangeFlag.IsVendor() || header.VenderID != vendorID {
+		t.Errorf("%02X %s", uint8(header.ExchangeFlag), header.VenderID)
+	}
+	msg, err := NewMessageFromBytes(NewMessage(header, []byte{0x01}).Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dispatcher.Dispatch(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	header.SetProtocol(NewStandardProtocolID(protocolID))
+	if header.ExchangeFlag.IsVendor() {
+		t.Errorf("%02X", uint8(header.ExchangeFlag))
+	}
+	if err := dispatcher.Dispatch(NewMessage(header, nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []QualifiedProtocolID{
+		NewVendorProtocolID(vendorID, protocolID),
+		NewStandardProtocolID(protocolID),
+	}
+	if len(received) != len(expected) {
+		t.Fatalf("%v != %v", received, expected)
+	}
+	for n, id := range expected {
+		if received[n] != id {
+			t.Errorf("%s != %s", received[n], id)
+		}
+	}
+
+	dispatcher.Unregister(NewVendorProtocolID(vendorID, protocolID))
+	if err := dispatcher.Dispatch(NewMessage(NewVendorHeader(vendorID, protocolID, 0x01), nil)); !errors.Is(err, ErrNotFound) {
+		t.Errorf("%v is not %v", err, ErrNotFound)
+	}
+}
@@ -14,33 +14,55 @@
 
 package protocol
 
+// 4.4.3.1. Exchange Flags (8 bits)
 // ExchangeFlag represents a exchange flag.
 type ExchangeFlag uint8
 
+const (
+	// InitiatorFlag represents the I flag.
+	InitiatorFlag = ExchangeFlag(0x01)
+	// AcknowledgementFlag represents the A flag.
+	AcknowledgementFlag = ExchangeFlag(0x02)
+	// ReliabilityFlag represents the R flag.
+	ReliabilityFlag = ExchangeFlag(0x04)
+	// SecuredExtensionFlag represents the SX flag.
+	SecuredExtensionFlag = ExchangeFlag(0x08)
+	// VendorFlag represents the V flag.
+	VendorFlag = ExchangeFlag(0x10)
+
+	exchangeFlagReservedMask = ExchangeFlag(0xE0)
+)
+
+// 4.4.3.3. Exchange ID (16 bits)
 // ExchangeID represents a exchange ID.
 type ExchangeID uint16
 
 // IsInitiator returns true if the flag is initiator.
 func (flag ExchangeFlag) IsInitiator() bool {
-	return (flag & 0x01) != 0
+	return (flag & InitiatorFlag) != 0
 }
 
 // IsAcknowledgement returns true if the flag is acknowledgement.
 func (flag ExchangeFlag) IsAcknowledgement() bool {
-	return (flag & 0x20) != 0
+	return (flag & AcknowledgementFlag) != 0
 }
 
 // IsReliability returns true if the flag is reliability.
 func (flag ExchangeFlag) IsReliability() bool {
-	return (flag & 0x40) != 0
+	return (flag & ReliabilityFlag) != 0
 }
 
 // IsSecuredExtension returns true if the flag is secured extension.
 func (flag ExchangeFlag) IsSecuredExtension() bool {
-	return (flag & 0x80) != 0
+	return (flag & SecuredExtensionFlag) != 0
 }
 
 // IsVendor returns true if the flag is vendor.
 func (flag ExchangeFlag) IsVendor() bool {
-	return (flag & 0x10) != 0
+	return (flag & VendorFlag) != 0
+}
+
+// IsValid returns true if no reserved bit is set.
+func (flag ExchangeFlag) IsValid() bool {
+	return (flag & exchangeFlagReservedMask) == 0
 }
@@ -0,0 +1,294 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+const (
+	// HeaderMinSize is the size of the mandatory protocol header fields.
+	HeaderMinSize = 6
+	// MaxSecuredExtensionsSize is the maximum size of the secured extensions, which fit in a secured message
+	// of the IPv6 minimum MTU (1280 bytes) with the mandatory header fields and the MIC.
+	MaxSecuredExtensionsSize = 1280 - message.HeaderMinSize - HeaderMinSize - 2 - message.MICSize
+)
+
+// 4.4.3. Protocol Header Field Descriptions
+// Header represents a protocol header.
+type Header struct {
+	ExchangeFlag ExchangeFlag
+	Opcode       Opcode
+	ExchangeID   ExchangeID
+	VenderID     VenderID
+	ProtocolID   ProtocolID
+	AckCounter   message.Counter
+	Extensions   []byte
+	mode         DecodeMode
+	violations   []error
+}
+
+// DecodeMode represents how a header decoder handles reserved bits and inconsistent flags.
+type DecodeMode uint8
+
+const (
+	// LenientMode accepts reserved bits and inconsistent flags, and records them as violations.
+	LenientMode DecodeMode = iota
+	// StrictMode rejects reserved bits and inconsistent flags.
+	StrictMode
+)
+
+// HeaderOption represents an option for decoding a protocol header.
+type HeaderOption func(*Header)
+
+// WithDecodeMode returns an option to set the decode mode.
+func WithDecodeMode(mode DecodeMode) HeaderOption {
+	return func(header *Header) {
+		header.mode = mode
+	}
+}
+
+// NewHeader returns a new protocol header.
+func NewHeader() *Header {
+	return &Header{
+		ExchangeFlag: 0,
+		Opcode:       0,
+		ExchangeID:   0,
+		VenderID:     0,
+		ProtocolID:   0,
+		AckCounter:   0,
+		Extensions:   nil,
+		mode:         LenientMode,
+		violations:   []error{},
+	}
+}
+
+// NewHeaderFromBytes returns a new protocol header decoded from the specified bytes.
+func NewHeaderFromBytes(b []byte, opts ...HeaderOption) (*Header, error) {
+	return NewHeaderFromReader(bytes.NewReader(b), opts...)
+}
+
+// NewHeaderFromReader returns a new protocol header decoded from the specified reader.
+// The header is decoded in the lenient mode unless an option specifies the mode.
+// Malformed headers and violations are reported with a message.DecodeError.
+func NewHeaderFromReader(reader io.Reader, opts ...HeaderOption) (*Header, error) {
+	offset := 0
+	readField := func(layer message.DecodeLayer, name string, b []byte) error {
+		n, err := io.ReadFull(reader, b)
+		offset += n
+		if err != nil {
+			return newDecodeError(reader, layer, offset-n, offset, fmt.Errorf("%w %s: %w", ErrInvalid, name, err))
+		}
+		return nil
+	}
+	violate := func(header *Header, layer message.DecodeLayer, fieldOffset int, err error) error {
+		return header.violate(newDecodeError(reader, layer, fieldOffset, offset, err))
+	}
+
+	header := NewHeader()
+	for _, opt := range opts {
+		opt(header)
+	}
+	b := make([]byte, 4)
+
+	// 4.4.3.1. Exchange Flags (8 bits)
+	if err := readField(message.ExchangeLayer, "exchange flags", b[:1]); err != nil {
+		return nil, err
+	}
+	header.ExchangeFlag = ExchangeFlag(b[0])
+	if !header.ExchangeFlag.IsValid() {
+		err := fmt.Errorf("%w exchange flags: reserved bits %02X", ErrInvalid, uint8(header.ExchangeFlag&exchangeFlagReservedMask))
+		if err := violate(header, message.ExchangeLayer, 0, err); err != nil {
+			return nil, err
+		}
+	}
+
+	// 4.4.3.2. Protocol Opcode (8 bits)
+	if err := readField(message.ExchangeLayer, "protocol opcode", b[:1]); err != nil {
+		return nil, err
+	}
+	header.Opcode = Opcode(b[0])
+
+	// 4.4.3.3. Exchange ID (16 bits)
+	if err := readField(message.ExchangeLayer, "exchange ID", b[:2]); err != nil {
+		return nil, err
+	}
+	header.ExchangeID = ExchangeID(binary.LittleEndian.Uint16(b))
+
+	// 4.4.3.5. Protocol Vendor ID (16 bits)
+	if header.ExchangeFlag.IsVendor() {
+		if err := readField(message.ExchangeLayer, "protocol vendor ID", b[:2]); err != nil {
+			return nil, err
+		}
+		header.VenderID = VenderID(binary.LittleEndian.Uint16(b))
+		if header.VenderID == types.StandardVendorID {
+			err := fmt.Errorf("%w protocol vendor ID: V flag with standard vendor ID", ErrInvalid)
+			if err := violate(header, message.ExchangeLayer, offset-2, err); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// 4.4.3.4. Protocol ID (16 bits)
+	if err := readField(message.ExchangeLayer, "protocol ID", b[:2]); err != nil {
+		return nil, err
+	}
+	header.ProtocolID = ProtocolID(binary.LittleEndian.Uint16(b))
+
+	// 4.4.3.6. Acknowledged Message Counter (32 bits)
+	if header.ExchangeFlag.IsAcknowledgement() {
+		if err := readField(message.ExchangeLayer, "acknowledged message counter", b[:4]); err != nil {
+			return nil, err
+		}
+		header.AckCounter = message.Counter(binary.LittleEndian.Uint32(b))
+	}
+
+	// 4.4.3.7. Secured Extensions (variable)
+	if header.ExchangeFlag.IsSecuredExtension() {
+		lengthOffset := offset
+		extensions, n, err := message.ReadLengthPrefixed(reader, offset, message.ExtensionsLayer, "secured extensions", ErrInvalid)
+		offset += n
+		if err != nil {
+			return nil, err
+		}
+		if MaxSecuredExtensionsSize < len(extensions) {
+			err := fmt.Errorf("%w secured extensions length: %d exceeds %d bytes", ErrInvalid, len(extensions), MaxSecuredExtensionsSize)
+			if err := violate(header, message.ExtensionsLayer, lengthOffset, err); err != nil {
+				return nil, err
+			}
+		}
+		header.Extensions = extensions
+		if len(header.Extensions) == 0 {
+			err := fmt.Errorf("%w secured extensions: SX flag without extension payload", ErrInvalid)
+			if err := violate(header, message.ExtensionsLayer, lengthOffset, err); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return header, nil
+}
+
+// newDecodeError returns a decode error at the specified field offset. The remaining bytes are
+// known only if the reader knows its unread length.
+func newDecodeError(reader io.Reader, layer message.DecodeLayer, fieldOffset int, offset int, err error) error {
+	remaining := -1
+	if l, ok := reader.(interface{ Len() int }); ok {
+		remaining = l.Len() + offset - fieldOffset
+	}
+	return message.NewDecodeError(layer, fieldOffset, remaining, err)
+}
+
+// violate returns the violation in the strict mode, and records it in the lenient mode.
+func (header *Header) violate(err error) error {
+	if header.mode == StrictMode {
+		return err
+	}
+	header.violations = append(header.violations, err)
+	return nil
+}
+
+// DecodeMode returns the mode which the header was decoded with.
+func (header *Header) DecodeMode() DecodeMode {
+	return header.mode
+}
+
+// Violations returns the reserved bits and inconsistent flags accepted in the lenient mode.
+func (header *Header) Violations() []error {
+	return header.violations
+}
+
+// 4.4.3.7. Secured Extensions (variable)
+// SetExtensions sets the secured extensions and the SX flag, or clears both if the extensions are empty.
+// The extensions are encrypted and covered by the MIC with the payload when the message is sealed.
+func (header *Header) SetExtensions(ext []byte) error {
+	if MaxSecuredExtensionsSize < len(ext) {
+		return fmt.Errorf("%w secured extensions length: %d exceeds %d bytes", ErrInvalid, len(ext), MaxSecuredExtensionsSize)
+	}
+	if len(ext) == 0 {
+		header.ExchangeFlag &= ^SecuredExtensionFlag
+		header.Extensions = nil
+		return nil
+	}
+	header.ExchangeFlag |= SecuredExtensionFlag
+	header.Extensions = append([]byte{}, ext...)
+	return nil
+}
+
+// HasExtensions returns true if the header has the secured extensions.
+func (header *Header) HasExtensions() bool {
+	return header.ExchangeFlag.IsSecuredExtension() && 0 < len(header.Extensions)
+}
+
+// Validate returns an error if the secured extensions are inconsistent with the SX flag or oversized.
+func (header *Header) Validate() error {
+	if !header.ExchangeFlag.IsSecuredExtension() {
+		if 0 < len(header.Extensions) {
+			return fmt.Errorf("%w secured extensions without SX flag", ErrInvalid)
+		}
+		return nil
+	}
+	if len(header.Extensions) == 0 {
+		return fmt.Errorf("%w secured extensions: SX flag without extension payload", ErrInvalid)
+	}
+	if MaxSecuredExtensionsSize < len(header.Extensions) {
+		return fmt.Errorf("%w secured extensions length: %d exceeds %d bytes", ErrInvalid, len(header.Extensions), MaxSecuredExtensionsSize)
+	}
+	return nil
+}
+
+// Size returns the encoded size of the header.
+func (header *Header) Size() int {
+	size := HeaderMinSize
+	if header.ExchangeFlag.IsVendor() {
+		size += 2
+	}
+	if header.ExchangeFlag.IsAcknowledgement() {
+		size += 4
+	}
+	if header.ExchangeFlag.IsSecuredExtension() {
+		size += 2 + len(header.Extensions)
+	}
+	return size
+}
+
+// Bytes returns the encoded header.
+func (header *Header) Bytes() []byte {
+	return header.AppendBytes(make([]byte, 0, header.Size()))
+}
+
+// AppendBytes appends the encoded header to the specified buffer.
+func (header *Header) AppendBytes(b []byte) []byte {
+	b = append(b, byte(header.ExchangeFlag), byte(header.Opcode))
+	b = binary.LittleEndian.AppendUint16(b, uint16(header.ExchangeID))
+	if header.ExchangeFlag.IsVendor() {
+		b = binary.LittleEndian.AppendUint16(b, uint16(header.VenderID))
+	}
+	b = binary.LittleEndian.AppendUint16(b, uint16(header.ProtocolID))
+	if header.ExchangeFlag.IsAcknowledgement() {
+		b = binary.LittleEndian.AppendUint32(b, uint32(header.AckCounter))
+	}
+	if header.ExchangeFlag.IsSecuredExtension() {
+		b = binary.LittleEndian.AppendUint16(b, uint16(len(header.Extensions)))
+		b = append(b, header.Extensions...)
+	}
+	return b
+}
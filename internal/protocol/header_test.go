@@ -0,0 +1,280 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-matter/internal/message"
+)
+
+func TestMessage(t *testing.T) {
+	newHeader := func(flag ExchangeFlag, ext []byte) *Header {
+		header := NewHeader()
+		header.ExchangeFlag = flag
+		header.Opcode = ReadRequestMessage
+		header.ExchangeID = 0x1234
+		header.VenderID = 0xFFF1
+		header.ProtocolID = 0x0001
+		header.AckCounter = 0x89ABCDEF
+		header.Extensions = ext
+		return header
+	}
+
+	tests := []struct {
+		name   string
+		header *Header
+		size   int
+	}{
+		{"minimum", newHeader(InitiatorFlag, nil), 6},
+		{"vendor", newHeader(InitiatorFlag|VendorFlag, nil), 8},
+		{"ack", newHeader(AcknowledgementFlag|ReliabilityFlag, nil), 10},
+		{"extensions", newHeader(SecuredExtensionFlag, []byte{0x01, 0x02}), 10},
+		{"all", newHeader(VendorFlag|AcknowledgementFlag|SecuredExtensionFlag, []byte{0x01}), 15},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			payload := []byte{0x15, 0x18}
+			b := NewMessage(test.header, payload).Bytes()
+			if test.header.Size() != test.size {
+				t.Errorf("%d != %d", test.header.Size(), test.size)
+			}
+			msg, err := NewMessageFromBytes(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(msg.Payload(), payload) {
+				t.Errorf("%X != %X", msg.Payload(), payload)
+			}
+			if !bytes.Equal(msg.Bytes(), b) {
+				t.Errorf("%X != %X", msg.Bytes(), b)
+			}
+			if ab := msg.AppendBytes([]byte{0xFF}); !bytes.Equal(ab[1:], b) {
+				t.Errorf("%X != %X", ab[1:], b)
+			}
+			for n := 0; n < test.size; n++ {
+				_, err := NewMessageFromBytes(b[:n])
+				if !errors.Is(err, ErrInvalid) {
+					t.Errorf("[%d] %v is not %v", n, err, ErrInvalid)
+				}
+			}
+		})
+	}
+}
+
+func TestHeaderDecodeMode(t *testing.T) {
+	tests := []struct {
+		name string
+		b    []byte
+	}{
+		{"reserved", []byte{0x21, 0x02, 0x34, 0x12, 0x01, 0x00}},
+		{"standard vendor", []byte{0x11, 0x02, 0x34, 0x12, 0x00, 0x00, 0x01, 0x00}},
+		{"empty extensions", []byte{0x09, 0x02, 0x34, 0x12, 0x01, 0x00, 0x00, 0x00}},
+		{"capped extensions", append([]byte{0x08, 0x02, 0x34, 0x12, 0x01, 0x00, (MaxSecuredExtensionsSize + 1) & 0xFF, (MaxSecuredExtensionsSize + 1) >> 8}, make([]byte, MaxSecuredExtensionsSize+1)...)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			header, err := NewHeaderFromBytes(test.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if header.DecodeMode() != LenientMode {
+				t.Errorf("%d != %d", header.DecodeMode(), LenientMode)
+			}
+			if len(header.Violations()) != 1 || !errors.Is(header.Violations()[0], ErrInvalid) {
+				t.Errorf("%v", header.Violations())
+			}
+			if !bytes.Equal(header.Bytes(), test.b) {
+				t.Errorf("%X != %X", header.Bytes(), test.b)
+			}
+			_, err = NewHeaderFromBytes(test.b, WithDecodeMode(StrictMode))
+			if !errors.Is(err, ErrInvalid) {
+				t.Errorf("%v is not %v", err, ErrInvalid)
+			}
+		})
+	}
+
+	header, err := NewHeaderFromBytes([]byte{0x01, 0x02, 0x34, 0x12, 0x01, 0x00}, WithDecodeMode(StrictMode))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.DecodeMode() != StrictMode || len(header.Violations()) != 0 {
+		t.Errorf("%d %v", header.DecodeMode(), header.Violations())
+	}
+}
+
+func TestHeaderDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		b         []byte
+		opts      []HeaderOption
+		layer     message.DecodeLayer
+		offset    int
+		remaining int
+	}{
+		{"empty", []byte{}, nil, message.ExchangeLayer, 0, 0},
+		{"short exchange ID", []byte{0x01, 0x02, 0x34}, nil, message.ExchangeLayer, 2, 1},
+		{"short protocol ID", []byte{0x11, 0x02, 0x34, 0x12, 0xF1, 0xFF, 0x01}, nil, message.ExchangeLayer, 6, 1},
+		{"short extensions", []byte{0x08, 0x02, 0x34, 0x12, 0x01, 0x00, 0x02, 0x00, 0x01}, nil, message.ExtensionsLayer, 6, 3},
+		{"short extensions length", []byte{0x08, 0x02, 0x34, 0x12, 0x01, 0x00, 0x02}, nil, message.ExtensionsLayer, 6, 1},
+		{"oversized extensions", []byte{0x08, 0x02, 0x34, 0x12, 0x01, 0x00, 0xFF, 0xFF, 0x01}, nil, message.ExtensionsLayer, 6, 3},
+		{"reserved", []byte{0x21, 0x02, 0x34, 0x12, 0x01, 0x00}, []HeaderOption{WithDecodeMode(StrictMode)}, message.ExchangeLayer, 0, 6},
+		{"standard vendor", []byte{0x11, 0x02, 0x34, 0x12, 0x00, 0x00, 0x01, 0x00}, []HeaderOption{WithDecodeMode(StrictMode)}, message.ExchangeLayer, 4, 4},
+		{"empty extensions", []byte{0x09, 0x02, 0x34, 0x12, 0x01, 0x00, 0x00, 0x00}, []HeaderOption{WithDecodeMode(StrictMode)}, message.ExtensionsLayer, 6, 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewMessageFromBytes(test.b, test.opts...)
+			var decodeErr *message.DecodeError
+			if !errors.As(err, &decodeErr) {
+				t.Fatalf("%v is not a decode error", err)
+			}
+			if !errors.Is(err, ErrInvalid) {
+				t.Errorf("%v is not %v", err, ErrInvalid)
+			}
+			if decodeErr.Layer != test.layer || decodeErr.Offset != test.offset || decodeErr.Remaining != test.remaining {
+				t.Errorf("%s %d %d != %s %d %d", decodeErr.Layer, decodeErr.Offset, decodeErr.Remaining, test.layer, test.offset, test.remaining)
+			}
+		})
+	}
+}
+
+func TestMessageExtensions(t *testing.T) {
+	tests := []struct {
+		name       string
+		b          []byte
+		extensions []byte
+		payload    []byte
+	}{
+		{"exact", []byte{0x08, 0x02, 0x34, 0x12, 0x01, 0x00, 0x02, 0x00, 0xAA, 0xBB}, []byte{0xAA, 0xBB}, []byte{}},
+		{"payload", []byte{0x08, 0x02, 0x34, 0x12, 0x01, 0x00, 0x01, 0x00, 0xAA, 0x15, 0x18}, []byte{0xAA}, []byte{0x15, 0x18}},
+		{"ack", []byte{0x0A, 0x02, 0x34, 0x12, 0x01, 0x00, 0x78, 0x56, 0x34, 0x12, 0x01, 0x00, 0xAA, 0x18}, []byte{0xAA}, []byte{0x18}},
+		{"vendor", []byte{0x18, 0x02, 0x34, 0x12, 0xF1, 0xFF, 0x01, 0x00, 0x01, 0x00, 0xAA, 0x18}, []byte{0xAA}, []byte{0x18}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msg, err := NewMessageFromBytes(test.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(msg.Extensions, test.extensions) {
+				t.Errorf("%X != %X", msg.Extensions, test.extensions)
+			}
+			if !bytes.Equal(msg.Payload(), test.payload) {
+				t.Errorf("%X != %X", msg.Payload(), test.payload)
+			}
+			if !bytes.Equal(msg.Bytes(), test.b) {
+				t.Errorf("%X != %X", msg.Bytes(), test.b)
+			}
+		})
+	}
+}
+
+func TestHeaderSetExtensions(t *testing.T) {
+	header := NewHeader()
+	header.ExchangeFlag = InitiatorFlag
+	if err := header.SetExtensions([]byte{0xAA, 0xBB}); err != nil {
+		t.Fatal(err)
+	}
+	if !header.ExchangeFlag.IsSecuredExtension() || !header.HasExtensions() || header.Size() != HeaderMinSize+4 {
+		t.Errorf("%02X %d", uint8(header.ExchangeFlag), header.Size())
+	}
+	decoded, err := NewHeaderFromBytes(header.Bytes(), WithDecodeMode(StrictMode))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded.Extensions, header.Extensions) {
+		t.Errorf("%X != %X", decoded.Extensions, header.Extensions)
+	}
+
+	if err := header.SetExtensions(make([]byte, MaxSecuredExtensionsSize+1)); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+	if err := header.SetExtensions(make([]byte, MaxSecuredExtensionsSize)); err != nil {
+		t.Error(err)
+	}
+	if err := header.SetExtensions(nil); err != nil {
+		t.Fatal(err)
+	}
+	if header.ExchangeFlag != InitiatorFlag || header.HasExtensions() || header.Size() != HeaderMinSize {
+		t.Errorf("%02X %d", uint8(header.ExchangeFlag), header.Size())
+	}
+
+	header.Extensions = []byte{0xAA}
+	if err := header.Validate(); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+	header.Extensions = nil
+	header.ExchangeFlag |= SecuredExtensionFlag
+	if err := header.Validate(); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+}
+
+func TestSealedMessageExtensions(t *testing.T) {
+	codec, err := message.NewSecureCodec([]byte("0123456789ABCDEF"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgHeader := message.NewHeader()
+	msgHeader.SessionID = 0x1234
+	msgHeader.Counter = 0x01
+
+	header := NewHeader()
+	header.ExchangeFlag = InitiatorFlag
+	header.Opcode = ReadRequestMessage
+	header.ExchangeID = 0x1234
+	header.ProtocolID = 0x0001
+	ext := []byte{0xAA, 0xBB, 0xCC}
+	if err := header.SetExtensions(ext); err != nil {
+		t.Fatal(err)
+	}
+	payload := []byte{0x15, 0x18}
+	b, err := NewMessage(header, payload).Seal(codec, msgHeader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(b, ext) {
+		t.Errorf("secured extensions (%X) are not encrypted", b)
+	}
+
+	_, msg, err := OpenMessage(codec, b, WithDecodeMode(StrictMode))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(msg.Extensions, ext) || !bytes.Equal(msg.Payload(), payload) {
+		t.Errorf("%X %X != %X %X", msg.Extensions, msg.Payload(), ext, payload)
+	}
+
+	// The secured extensions are covered by the MIC.
+
+	extOffset := msgHeader.Size() + HeaderMinSize + 2
+	for n := extOffset; n < extOffset+len(ext); n++ {
+		tampered := bytes.Clone(b)
+		tampered[n] ^= 0x01
+		if _, _, err := OpenMessage(codec, tampered); !errors.Is(err, message.ErrAuthentication) {
+			t.Errorf("%v is not %v", err, message.ErrAuthentication)
+		}
+	}
+
+	// Inconsistent headers are not sealed.
+
+	header.Extensions = nil
+	if _, err := NewMessage(header, payload).Seal(codec, msgHeader); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"github.com/cybergarage/go-matter/internal/message"
+)
+
+// Message represents a protocol message which is a decrypted message payload.
+type Message struct {
+	*Header
+	payload []byte
+}
+
+// NewMessage returns a new protocol message with the specified header and application payload.
+func NewMessage(header *Header, payload []byte) *Message {
+	return &Message{
+		Header:  header,
+		payload: payload,
+	}
+}
+
+// NewMessageFromBytes returns a new protocol message decoded from the specified bytes.
+func NewMessageFromBytes(b []byte, opts ...HeaderOption) (*Message, error) {
+	header, err := NewHeaderFromBytes(b, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewMessage(header, b[header.Size():]), nil
+}
+
+// Payload returns the application payload.
+func (msg *Message) Payload() []byte {
+	return msg.payload
+}
+
+// Bytes returns the encoded protocol message.
+func (msg *Message) Bytes() []byte {
+	return msg.AppendBytes(make([]byte, 0, msg.Header.Size()+len(msg.payload)))
+}
+
+// AppendBytes appends the encoded protocol message to the specified buffer.
+func (msg *Message) AppendBytes(b []byte) []byte {
+	return append(msg.Header.AppendBytes(b), msg.payload...)
+}
+
+// OpenMessage decrypts the secured message with the codec, and returns the message header and the protocol
+// message. The protocol header including its secured extensions is decoded only if the MIC is authentic.
+func OpenMessage(codec *message.SecureCodec, b []byte, opts ...HeaderOption) (*message.Header, *Message, error) {
+	header, payload, err := codec.Open(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	msg, err := NewMessageFromBytes(payload, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return header, msg, nil
+}
+
+// Seal returns the secured message of the protocol message with the message header, whose payload
+// including the secured extensions is encrypted and covered by the MIC with the codec.
+func (msg *Message) Seal(codec *message.SecureCodec, header *message.Header) ([]byte, error) {
+	if err := msg.Header.Validate(); err != nil {
+		return nil, err
+	}
+	return codec.Seal(header, msg.Bytes())
+}
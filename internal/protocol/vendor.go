@@ -0,0 +1,83 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// 4.4.3.5. Protocol Vendor ID (16 bits)
+// VenderID represents a vendor ID.
+type VenderID = types.VendorID
+
+// QualifiedProtocolID represents a protocol ID qualified by the vendor ID which defines it.
+type QualifiedProtocolID struct {
+	VendorID   VenderID
+	ProtocolID ProtocolID
+}
+
+// NewStandardProtocolID returns a protocol ID defined by the Matter standard.
+func NewStandardProtocolID(protocolID ProtocolID) QualifiedProtocolID {
+	return NewVendorProtocolID(types.StandardVendorID, protocolID)
+}
+
+// NewVendorProtocolID returns a protocol ID defined by the specified vendor.
+func NewVendorProtocolID(vendorID VenderID, protocolID ProtocolID) QualifiedProtocolID {
+	return QualifiedProtocolID{
+		VendorID:   vendorID,
+		ProtocolID: protocolID,
+	}
+}
+
+// IsStandard returns true if the protocol is defined by the Matter standard.
+func (id QualifiedProtocolID) IsStandard() bool {
+	return id.VendorID == types.StandardVendorID
+}
+
+// String returns the string representation.
+func (id QualifiedProtocolID) String() string {
+	return fmt.Sprintf("%s:0x%04X", id.VendorID, uint16(id.ProtocolID))
+}
+
+// NewVendorHeader returns a new protocol header of the specified vendor protocol.
+func NewVendorHeader(vendorID VenderID, protocolID ProtocolID, opcode Opcode) *Header {
+	header := NewHeader()
+	header.SetProtocol(NewVendorProtocolID(vendorID, protocolID))
+	header.Opcode = opcode
+	return header
+}
+
+// SetProtocol sets the protocol ID and the vendor ID. The V flag is set only for
+// vendor protocols because standard protocols omit the vendor ID field.
+func (header *Header) SetProtocol(id QualifiedProtocolID) {
+	header.ProtocolID = id.ProtocolID
+	if id.IsStandard() {
+		header.ExchangeFlag &= ^VendorFlag
+		header.VenderID = types.StandardVendorID
+		return
+	}
+	header.ExchangeFlag |= VendorFlag
+	header.VenderID = id.VendorID
+}
+
+// Protocol returns the protocol ID qualified by the vendor ID.
+func (header *Header) Protocol() QualifiedProtocolID {
+	if !header.ExchangeFlag.IsVendor() {
+		return NewStandardProtocolID(header.ProtocolID)
+	}
+	return NewVendorProtocolID(header.VenderID, header.ProtocolID)
+}
@@ -0,0 +1,260 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/cybergarage/go-mdns/mdns"
+	"github.com/cybergarage/go-mdns/mdns/dns"
+)
+
+const (
+	// DefaultAdvertisementTTL is the TTL of the advertised records in seconds.
+	DefaultAdvertisementTTL = 120
+)
+
+// CommissionableAdvertiser represents a DNS-SD advertiser of a commissionable node (4.3.1).
+// The advertisement follows the commissioning window: the CM key and the _CM subtype change
+// when the window is opened, closed or expired, and queries are answered only while the window
+// is open unless extended discovery is enabled.
+type CommissionableAdvertiser struct {
+	*mdns.Server
+	window        *CommissioningWindow
+	instance      string
+	host          string
+	addrs         []net.IP
+	port          uint16
+	discriminator Discriminator
+	vendorID      VenderID
+	productID     ProductID
+	extended      bool
+}
+
+// CommissionableAdvertiserOption represents a commissionable advertiser option.
+type CommissionableAdvertiserOption func(*CommissionableAdvertiser)
+
+// WithAdvertisedInstanceName returns an option to set the instance name, which is random by default.
+func WithAdvertisedInstanceName(name string) CommissionableAdvertiserOption {
+	return func(adv *CommissionableAdvertiser) {
+		adv.instance = name
+	}
+}
+
+// WithAdvertisedHost returns an option to set the host name and the addresses of the host.
+func WithAdvertisedHost(host string, addrs ...net.IP) CommissionableAdvertiserOption {
+	return func(adv *CommissionableAdvertiser) {
+		adv.host = host
+		adv.addrs = addrs
+	}
+}
+
+// WithAdvertisedPort returns an option to set the port, which is Port by default.
+func WithAdvertisedPort(port uint16) CommissionableAdvertiserOption {
+	return func(adv *CommissionableAdvertiser) {
+		adv.port = port
+	}
+}
+
+// WithAdvertisedDiscriminator returns an option to set the full 12-bit discriminator.
+func WithAdvertisedDiscriminator(d Discriminator) CommissionableAdvertiserOption {
+	return func(adv *CommissionableAdvertiser) {
+		adv.discriminator = d
+	}
+}
+
+// WithAdvertisedVendorProduct returns an option to set the vendor ID and the product ID.
+func WithAdvertisedVendorProduct(vendorID VenderID, productID ProductID) CommissionableAdvertiserOption {
+	return func(adv *CommissionableAdvertiser) {
+		adv.vendorID = vendorID
+		adv.productID = productID
+	}
+}
+
+// WithExtendedDiscovery returns an option to keep advertising with CM=0 while the window is not open (4.3.1.7).
+func WithExtendedDiscovery(enabled bool) CommissionableAdvertiserOption {
+	return func(adv *CommissionableAdvertiser) {
+		adv.extended = enabled
+	}
+}
+
+// NewCommissionableAdvertiser returns a new advertiser which follows the specified commissioning window.
+func NewCommissionableAdvertiser(window *CommissioningWindow, opts ...CommissionableAdvertiserOption) *CommissionableAdvertiser {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	instance := fmt.Sprintf("%016X", binary.BigEndian.Uint64(b))
+	adv := &CommissionableAdvertiser{
+		Server:        mdns.NewServer(),
+		window:        window,
+		instance:      instance,
+		host:          instance + "." + mdns.DefaultDomain,
+		addrs:         []net.IP{},
+		port:          Port,
+		discriminator: 0,
+		vendorID:      0,
+		productID:     0,
+		extended:      false,
+	}
+	for _, opt := range opts {
+		opt(adv)
+	}
+	adv.Server.SetListener(messageListenerFunc(adv.queryReceived))
+	window.AddListener(adv.windowChanged)
+	return adv
+}
+
+// InstanceName returns the full instance name of the advertised service.
+func (adv *CommissionableAdvertiser) InstanceName() string {
+	return adv.instance + "." + adv.serviceName()
+}
+
+// IsAdvertising returns true if the commissioning window is open or extended discovery is enabled.
+func (adv *CommissionableAdvertiser) IsAdvertising() bool {
+	return adv.extended || adv.window.IsOpen()
+}
+
+func (adv *CommissionableAdvertiser) serviceName() string {
+	return CommissionableServiceType.String() + "." + mdns.DefaultDomain
+}
+
+// subtypeNames returns the subtype names of the specified window status (4.3.1.3).
+func (adv *CommissionableAdvertiser) subtypeNames(status CommissioningWindowStatus) []string {
+	service := adv.serviceName()
+	names := []string{
+		fmt.Sprintf("%s%s._sub.%s", SubtypeDiscriminatorLong, adv.discriminator.DecimalString(), service),
+		fmt.Sprintf("%s%d._sub.%s", SubtypeDiscriminatorShort, adv.discriminator.Short(), service),
+	}
+	if adv.vendorID != 0 {
+		names = append(names, fmt.Sprintf("%s%d._sub.%s", SubtypeVendorID, adv.vendorID, service))
+	}
+	if status != WindowNotOpen {
+		names = append(names, SubtypeCommissioningMode+"._sub."+service)
+	}
+	return names
+}
+
+// Message returns the response message which advertises the current state of the node.
+func (adv *CommissionableAdvertiser) Message() *dns.Message {
+	return adv.message(adv.window.Status(), DefaultAdvertisementTTL)
+}
+
+func (adv *CommissionableAdvertiser) message(status CommissioningWindowStatus, ttl uint) *dns.Message {
+	instance := adv.InstanceName()
+	msg := dns.NewResponseMessage()
+	addRecord := func(rr dns.ResourceRecord, name string, typ dns.Type, data []byte) {
+		msg.AddAnswer(newAdvertisedRecord(rr, name, typ, ttl, data))
+	}
+
+	// 4.3.1.3. Commissioning Subtypes
+	for _, name := range adv.subtypeNames(status) {
+		addRecord(dns.NewPTRRecord(), name, dns.PTR, appendDNSName(nil, instance))
+	}
+	addRecord(dns.NewPTRRecord(), adv.serviceName(), dns.PTR, appendDNSName(nil, instance))
+	srv := binary.BigEndian.AppendUint16([]byte{0x00, 0x00, 0x00, 0x00}, adv.port)
+	addRecord(dns.NewSRVRecord(), instance, dns.SRV, appendDNSName(srv, adv.host))
+
+	// 4.3.1.4. TXT Records
+	txts := []string{
+		fmt.Sprintf("%s=%s", TxtRecordDiscriminator, adv.discriminator.DecimalString()),
+		fmt.Sprintf("%s=%s", TxtRecordCommissioningMode, status.CommissioningMode()),
+	}
+	if adv.vendorID != 0 {
+		txts = append(txts, fmt.Sprintf("%s=%d+%d", TxtRecordVendorProductID, adv.vendorID, adv.productID))
+	}
+	txt := []byte{}
+	for _, s := range txts {
+		txt = append(txt, byte(len(s)))
+		txt = append(txt, s...)
+	}
+	addRecord(dns.NewTXTRecord(), instance, dns.TXT, txt)
+
+	for _, addr := range adv.addrs {
+		if ip := addr.To4(); ip != nil {
+			addRecord(dns.NewAAAARecord(), adv.host, dns.A, ip)
+		} else {
+			addRecord(dns.NewAAAARecord(), adv.host, dns.AAAA, addr.To16())
+		}
+	}
+	return msg
+}
+
+func newAdvertisedRecord(rr dns.ResourceRecord, name string, typ dns.Type, ttl uint, data []byte) dns.ResourceRecord {
+	rr.SetName(name)
+	rr.SetType(typ)
+	rr.SetClass(dns.IN)
+	rr.SetTTL(ttl)
+	rr.SetData(data)
+	return rr
+}
+
+// appendDNSName appends the specified name as uncompressed labels.
+func appendDNSName(b []byte, name string) []byte {
+	for _, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		b = append(b, byte(len(label)))
+		b = append(b, label...)
+	}
+	return append(b, 0x00)
+}
+
+// Respond returns the response to the specified query if the query asks for the service,
+// the subtypes, the instance or the host of the node and the node is advertising.
+func (adv *CommissionableAdvertiser) Respond(query *dns.Message) (*dns.Message, bool) {
+	if query.IsResponse() || !adv.IsAdvertising() {
+		return nil, false
+	}
+	status := adv.window.Status()
+	names := append(adv.subtypeNames(status), adv.serviceName(), adv.InstanceName(), adv.host)
+	for _, q := range query.Questions {
+		for _, name := range names {
+			if q.IsName(name) || q.IsName(name+".") {
+				return adv.message(status, DefaultAdvertisementTTL), true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (adv *CommissionableAdvertiser) queryReceived(msg *dns.Message) {
+	if res, ok := adv.Respond(msg); ok {
+		_ = adv.AnnounceMessage(res)
+	}
+}
+
+// windowChanged announces the new state of the node.
+func (adv *CommissionableAdvertiser) windowChanged(status CommissioningWindowStatus) {
+	_ = adv.AnnounceMessage(adv.Announcement(status))
+}
+
+// Announcement returns the message which is announced when the window changes to the specified status.
+// When the window is closed, the _CM subtype is announced with zero TTL so that controllers remove it,
+// and so are all the records unless extended discovery is enabled.
+func (adv *CommissionableAdvertiser) Announcement(status CommissioningWindowStatus) *dns.Message {
+	if status != WindowNotOpen {
+		return adv.message(status, DefaultAdvertisementTTL)
+	}
+	var msg *dns.Message
+	if adv.extended {
+		msg = adv.message(WindowNotOpen, DefaultAdvertisementTTL)
+	} else {
+		msg = adv.message(WindowNotOpen, 0)
+	}
+	name := SubtypeCommissioningMode + "._sub." + adv.serviceName()
+	msg.AddAnswer(newAdvertisedRecord(dns.NewPTRRecord(), name, dns.PTR, 0, appendDNSName(nil, adv.InstanceName())))
+	return msg
+}
@@ -0,0 +1,190 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/metrics"
+	"github.com/cybergarage/go-matter/matter/payload"
+)
+
+// The following types are the stable API of the package. They wrap the implementation types, and
+// have only the operations which applications need, so the implementation types can change between
+// releases without breaking them. The wire format packages are under the internal directory of the
+// module, so they are not part of the API.
+
+// Controller represents a controller which discovers, commissions and operates nodes.
+type Controller struct {
+	com *Commissioner
+}
+
+// ControllerOption represents a controller option.
+type ControllerOption = CommissionerOption
+
+// NewController returns a new controller.
+func NewController(opts ...ControllerOption) *Controller {
+	return &Controller{
+		com: NewCommissioner(opts...),
+	}
+}
+
+// Start starts the controller.
+func (ctrl *Controller) Start() error {
+	return ctrl.com.Start()
+}
+
+// Stop stops the controller.
+func (ctrl *Controller) Stop() error {
+	return ctrl.com.Stop()
+}
+
+// ServiceTable returns the table of the discovered services.
+func (ctrl *Controller) ServiceTable() *ServiceTable {
+	return ctrl.com.ServiceTable()
+}
+
+// DiscoverCommissionableNodes returns the commissionable nodes of the onboarding payload.
+func (ctrl *Controller) DiscoverCommissionableNodes(ctx context.Context, p *OnboardingPayload) ([]*DiscoveredNode, error) {
+	return ctrl.com.DiscoverCommissionableNodes(ctx, p.payload)
+}
+
+// OperationalDevice returns a handle of the commissioned node.
+func (ctrl *Controller) OperationalDevice(peer OperationalPeer) *Device {
+	return &Device{
+		dev: ctrl.com.OperationalDevice(peer),
+	}
+}
+
+// NodeStats returns the transport statistics of the specified node.
+func (ctrl *Controller) NodeStats(id NodeID) (metrics.PeerStats, bool) {
+	return ctrl.com.NodeStats(id)
+}
+
+// Commissioner returns the commissioner which the controller wraps, whose API may change between releases.
+func (ctrl *Controller) Commissioner() *Commissioner {
+	return ctrl.com
+}
+
+// Session represents the interactions with a commissioned node, which Device implements over CASE sessions.
+type Session interface {
+	// ReadAttribute reads the attributes of the specified path.
+	ReadAttribute(ctx context.Context, path im.AttributePath) ([]im.AttributeData, error)
+	// WriteAttribute writes the TLV encoded value to the specified attribute.
+	WriteAttribute(ctx context.Context, path im.AttributePath, data []byte) error
+	// InvokeCommand invokes the command with the TLV encoded fields, and returns the TLV encoded response fields.
+	InvokeCommand(ctx context.Context, path im.CommandPath, fields []byte) ([]byte, error)
+	// Subscribe subscribes the attributes, and calls the handler for each report.
+	Subscribe(ctx context.Context, req im.SubscribeRequest, handler im.ReportHandler) (im.Subscription, error)
+	// Close closes the session.
+	Close() error
+}
+
+// Device represents a handle of a commissioned node, which is returned by Controller.OperationalDevice.
+type Device struct {
+	dev *OperationalDevice
+}
+
+// Peer returns the operational peer of the node.
+func (dev *Device) Peer() OperationalPeer {
+	return dev.dev.Peer()
+}
+
+// ReadAttribute reads the attributes of the specified path.
+func (dev *Device) ReadAttribute(ctx context.Context, path im.AttributePath) ([]im.AttributeData, error) {
+	return dev.dev.ReadAttribute(ctx, path)
+}
+
+// WriteAttribute writes the TLV encoded value to the specified attribute.
+func (dev *Device) WriteAttribute(ctx context.Context, path im.AttributePath, data []byte) error {
+	return dev.dev.WriteAttribute(ctx, path, data)
+}
+
+// InvokeCommand invokes the command with the TLV encoded fields, and returns the TLV encoded response fields.
+func (dev *Device) InvokeCommand(ctx context.Context, path im.CommandPath, fields []byte) ([]byte, error) {
+	return dev.dev.InvokeCommand(ctx, path, fields)
+}
+
+// Subscribe subscribes the attributes, and calls the handler for each report.
+func (dev *Device) Subscribe(ctx context.Context, req im.SubscribeRequest, handler im.ReportHandler) (im.Subscription, error) {
+	return dev.dev.Subscribe(ctx, req, handler)
+}
+
+// Close closes the handle.
+func (dev *Device) Close() error {
+	return dev.dev.Close()
+}
+
+// OperationalDevice returns the operational device which the handle wraps, whose API may change between releases.
+func (dev *Device) OperationalDevice() *OperationalDevice {
+	return dev.dev
+}
+
+// OnboardingPayload represents the onboarding payload encoded in QR codes and manual pairing codes.
+type OnboardingPayload struct {
+	payload *payload.OnboardingPayload
+}
+
+// NewOnboardingPayloadFromString returns a new onboarding payload decoded from a QR code or a manual pairing code.
+func NewOnboardingPayloadFromString(code string) (*OnboardingPayload, error) {
+	p, err := payload.NewOnboardingPayloadFromString(code)
+	if err != nil {
+		return nil, err
+	}
+	return &OnboardingPayload{
+		payload: p,
+	}, nil
+}
+
+// VendorID returns the vendor ID.
+func (p *OnboardingPayload) VendorID() VenderID {
+	return p.payload.VendorID()
+}
+
+// ProductID returns the product ID.
+func (p *OnboardingPayload) ProductID() ProductID {
+	return p.payload.ProductID()
+}
+
+// Discriminator returns the discriminator which may be a short discriminator.
+func (p *OnboardingPayload) Discriminator() Discriminator {
+	return p.payload.Discriminator()
+}
+
+// Passcode returns the passcode.
+func (p *OnboardingPayload) Passcode() uint32 {
+	return p.payload.Passcode()
+}
+
+// QRCode returns the QR code string of the payload.
+func (p *OnboardingPayload) QRCode() (string, error) {
+	return p.payload.QRCode()
+}
+
+// ManualPairingCode returns the manual pairing code string of the payload.
+func (p *OnboardingPayload) ManualPairingCode() (string, error) {
+	return p.payload.ManualPairingCode()
+}
+
+// String returns the string representation.
+func (p *OnboardingPayload) String() string {
+	return p.payload.String()
+}
+
+// Payload returns the payload which the onboarding payload wraps, whose API may change between releases.
+func (p *OnboardingPayload) Payload() *payload.OnboardingPayload {
+	return p.payload
+}
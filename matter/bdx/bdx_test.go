@@ -0,0 +1,140 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bdx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	matterr "github.com/cybergarage/go-matter/matter/errors"
+)
+
+// testConn represents one end of an in-memory exchange.
+type testConn struct {
+	in  chan *Message
+	out chan *Message
+}
+
+func newTestConns() (*testConn, *testConn) {
+	a := make(chan *Message, 16)
+	b := make(chan *Message, 16)
+	return &testConn{in: a, out: b}, &testConn{in: b, out: a}
+}
+
+func (conn *testConn) Send(ctx context.Context, msg *Message) error {
+	select {
+	case conn.out <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (conn *testConn) Receive(ctx context.Context) (*Message, error) {
+	select {
+	case msg := <-conn.in:
+		return msg, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestMessages(t *testing.T) {
+	inits := []*TransferInit{
+		{TransferControl: ReceiverDrive, MaxBlockSize: 512, StartOffset: 0, MaxLength: 0, FileDesignator: []byte("logs"), Metadata: nil},
+		{TransferControl: SenderDrive | ReceiverDrive, MaxBlockSize: 1024, StartOffset: 16, MaxLength: 4096, FileDesignator: []byte("a"), Metadata: []byte{0x15, 0x18}},
+		{TransferControl: ReceiverDrive, MaxBlockSize: 64, StartOffset: 0, MaxLength: math.MaxUint32 + 1, FileDesignator: []byte("wide"), Metadata: nil},
+	}
+	for _, init := range inits {
+		decoded, err := NewTransferInitFromBytes(init.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if decoded.TransferControl != init.TransferControl || decoded.MaxBlockSize != init.MaxBlockSize ||
+			decoded.StartOffset != init.StartOffset || decoded.MaxLength != init.MaxLength ||
+			!bytes.Equal(decoded.FileDesignator, init.FileDesignator) || !bytes.Equal(decoded.Metadata, init.Metadata) {
+			t.Errorf("%+v != %+v", decoded, init)
+		}
+	}
+	if _, err := NewTransferInitFromBytes([]byte{0x20, 0x00, 0x00}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+
+	block, err := NewBlockFromBytes((&Block{Counter: 7, Data: []byte{1, 2, 3}}).Bytes())
+	if err != nil || block.Counter != 7 || !bytes.Equal(block.Data, []byte{1, 2, 3}) {
+		t.Errorf("%+v (%v)", block, err)
+	}
+
+	status, err := NewStatusFromStatusReportBytes(NewStatusReportBytes(StatusFileDesignatorUnknown))
+	if err != nil || status != StatusFileDesignatorUnknown {
+		t.Errorf("%v (%v)", status, err)
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	sizes := []int{0, 1, 64, 100, 640}
+	for _, size := range sizes {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		file := make([]byte, size)
+		for n := range file {
+			file[n] = byte(n)
+		}
+		sender, receiver := newTestConns()
+		errs := make(chan error, 1)
+		go func() {
+			errs <- Send(ctx, sender, []byte("logs"), bytes.NewReader(file), WithMaxBlockSize(128))
+		}()
+		var buf bytes.Buffer
+		init, err := Receive(ctx, receiver, &buf, func(init *TransferInit) error {
+			if string(init.FileDesignator) != "logs" {
+				return StatusFileDesignatorUnknown
+			}
+			return nil
+		}, WithMaxBlockSize(64))
+		if err != nil {
+			t.Fatalf("%d : %s", size, err)
+		}
+		if err := <-errs; err != nil {
+			t.Fatalf("%d : %s", size, err)
+		}
+		if init.MaxBlockSize != 128 || !bytes.Equal(buf.Bytes(), file) {
+			t.Errorf("%d : %d bytes received", size, buf.Len())
+		}
+		cancel()
+	}
+}
+
+func TestTransferRejected(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	sender, receiver := newTestConns()
+	errs := make(chan error, 1)
+	go func() {
+		errs <- Send(ctx, sender, []byte("unknown"), bytes.NewReader([]byte{1}))
+	}()
+	if _, err := Receive(ctx, receiver, &bytes.Buffer{}, func(init *TransferInit) error {
+		return StatusFileDesignatorUnknown
+	}); !errors.Is(err, StatusFileDesignatorUnknown) {
+		t.Errorf("%v is not %v", err, StatusFileDesignatorUnknown)
+	}
+	err := <-errs
+	if !errors.Is(err, StatusFileDesignatorUnknown) || !errors.Is(err, matterr.ErrRejected) {
+		t.Errorf("%v is not %v", err, StatusFileDesignatorUnknown)
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bdx
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/cybergarage/go-matter/internal/protocol"
+)
+
+// 11.22. Bulk Data Exchange Protocol
+const (
+	// ProtocolID is the protocol ID of the bulk data exchange protocol.
+	ProtocolID protocol.ProtocolID = 0x0002
+	// Version is the BDX protocol version implemented by this package.
+	Version = 0
+)
+
+// 11.22.4. BDX Messages
+const (
+	SendInitOpcode           protocol.Opcode = 0x01
+	SendAcceptOpcode         protocol.Opcode = 0x02
+	ReceiveInitOpcode        protocol.Opcode = 0x04
+	ReceiveAcceptOpcode      protocol.Opcode = 0x05
+	BlockQueryOpcode         protocol.Opcode = 0x10
+	BlockOpcode              protocol.Opcode = 0x11
+	BlockEOFOpcode           protocol.Opcode = 0x12
+	BlockAckOpcode           protocol.Opcode = 0x13
+	BlockAckEOFOpcode        protocol.Opcode = 0x14
+	BlockQueryWithSkipOpcode protocol.Opcode = 0x15
+)
+
+// TransferControl represents the transfer control field which has the version and the transfer modes.
+type TransferControl uint8
+
+const (
+	// SenderDrive represents the mode in which the sender sends the blocks.
+	SenderDrive TransferControl = 0x10
+	// ReceiverDrive represents the mode in which the receiver queries the blocks.
+	ReceiverDrive TransferControl = 0x20
+	// Async represents the asynchronous mode.
+	Async TransferControl = 0x40
+
+	transferControlVersionMask TransferControl = 0x0F
+)
+
+// Version returns the protocol version.
+func (tc TransferControl) Version() uint8 {
+	return uint8(tc & transferControlVersionMask)
+}
+
+// IsReceiverDrive returns true if the receiver drive mode is set.
+func (tc TransferControl) IsReceiverDrive() bool {
+	return tc&ReceiverDrive != 0
+}
+
+// IsSenderDrive returns true if the sender drive mode is set.
+func (tc TransferControl) IsSenderDrive() bool {
+	return tc&SenderDrive != 0
+}
+
+// rangeControl represents the range control field of the init messages.
+type rangeControl uint8
+
+const (
+	rangeDefiniteLength rangeControl = 0x01
+	rangeStartOffset    rangeControl = 0x02
+	rangeWide           rangeControl = 0x10
+)
+
+// TransferInit represents a SendInit or a ReceiveInit message.
+type TransferInit struct {
+	// TransferControl is the proposed version and transfer modes.
+	TransferControl TransferControl
+	// MaxBlockSize is the proposed maximum block size.
+	MaxBlockSize uint16
+	// StartOffset is the offset of the transfer in the file.
+	StartOffset uint64
+	// MaxLength is the length of the file, which is indefinite if zero.
+	MaxLength uint64
+	// FileDesignator identifies the file to transfer.
+	FileDesignator []byte
+	// Metadata is the optional TLV encoded metadata.
+	Metadata []byte
+}
+
+// Bytes returns the encoding of the message.
+func (init *TransferInit) Bytes() []byte {
+	rc := rangeControl(0)
+	if 0 < init.MaxLength {
+		rc |= rangeDefiniteLength
+	}
+	if 0 < init.StartOffset {
+		rc |= rangeStartOffset
+	}
+	if math.MaxUint32 < init.MaxLength || math.MaxUint32 < init.StartOffset {
+		rc |= rangeWide
+	}
+	b := []byte{byte(init.TransferControl), byte(rc)}
+	b = binary.LittleEndian.AppendUint16(b, init.MaxBlockSize)
+	putRange := func(v uint64) {
+		if rc&rangeWide != 0 {
+			b = binary.LittleEndian.AppendUint64(b, v)
+		} else {
+			b = binary.LittleEndian.AppendUint32(b, uint32(v))
+		}
+	}
+	if rc&rangeStartOffset != 0 {
+		putRange(init.StartOffset)
+	}
+	if rc&rangeDefiniteLength != 0 {
+		putRange(init.MaxLength)
+	}
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(init.FileDesignator)))
+	b = append(b, init.FileDesignator...)
+	return append(b, init.Metadata...)
+}
+
+// NewTransferInitFromBytes returns a SendInit or a ReceiveInit message decoded from the specified bytes.
+func NewTransferInitFromBytes(b []byte) (*TransferInit, error) {
+	r := newReader(b, "init")
+	tc := TransferControl(r.uint8())
+	rc := rangeControl(r.uint8())
+	init := &TransferInit{
+		TransferControl: tc,
+		MaxBlockSize:    r.uint16(),
+		StartOffset:     0,
+		MaxLength:       0,
+		FileDesignator:  nil,
+		Metadata:        nil,
+	}
+	getRange := func() uint64 {
+		if rc&rangeWide != 0 {
+			return r.uint64()
+		}
+		return uint64(r.uint32())
+	}
+	if rc&rangeStartOffset != 0 {
+		init.StartOffset = getRange()
+	}
+	if rc&rangeDefiniteLength != 0 {
+		init.MaxLength = getRange()
+	}
+	init.FileDesignator = r.bytes(int(r.uint16()))
+	init.Metadata = r.rest()
+	if r.err != nil {
+		return nil, r.err
+	}
+	if len(init.FileDesignator) == 0 {
+		return nil, fmt.Errorf("%w init : no file designator", ErrInvalid)
+	}
+	return init, nil
+}
+
+// SendAccept represents a SendAccept message.
+type SendAccept struct {
+	// TransferControl is the chosen version and transfer mode.
+	TransferControl TransferControl
+	// MaxBlockSize is the chosen maximum block size.
+	MaxBlockSize uint16
+	// Metadata is the optional TLV encoded metadata.
+	Metadata []byte
+}
+
+// Bytes returns the encoding of the message.
+func (accept *SendAccept) Bytes() []byte {
+	b := []byte{byte(accept.TransferControl)}
+	b = binary.LittleEndian.AppendUint16(b, accept.MaxBlockSize)
+	return append(b, accept.Metadata...)
+}
+
+// NewSendAcceptFromBytes returns a SendAccept message decoded from the specified bytes.
+func NewSendAcceptFromBytes(b []byte) (*SendAccept, error) {
+	r := newReader(b, "send accept")
+	accept := &SendAccept{
+		TransferControl: TransferControl(r.uint8()),
+		MaxBlockSize:    r.uint16(),
+		Metadata:        nil,
+	}
+	accept.Metadata = r.rest()
+	if r.err != nil {
+		return nil, r.err
+	}
+	return accept, nil
+}
+
+// Block represents a Block or a BlockEOF message.
+type Block struct {
+	// Counter is the block counter.
+	Counter uint32
+	// Data is the block data.
+	Data []byte
+}
+
+// Bytes returns the encoding of the message.
+func (block *Block) Bytes() []byte {
+	b := binary.LittleEndian.AppendUint32(nil, block.Counter)
+	return append(b, block.Data...)
+}
+
+// NewBlockFromBytes returns a Block or a BlockEOF message decoded from the specified bytes.
+func NewBlockFromBytes(b []byte) (*Block, error) {
+	r := newReader(b, "block")
+	block := &Block{
+		Counter: r.uint32(),
+		Data:    nil,
+	}
+	block.Data = r.rest()
+	if r.err != nil {
+		return nil, r.err
+	}
+	return block, nil
+}
+
+// NewCounterBytes returns the encoding of a BlockQuery, BlockAck or BlockAckEOF message which has only the block counter.
+func NewCounterBytes(counter uint32) []byte {
+	return binary.LittleEndian.AppendUint32(nil, counter)
+}
+
+// NewCounterFromBytes returns the block counter of a BlockQuery, BlockAck or BlockAckEOF message.
+func NewCounterFromBytes(b []byte) (uint32, error) {
+	r := newReader(b, "block counter")
+	counter := r.uint32()
+	return counter, r.err
+}
+
+// reader reads the little endian fields of a message, and keeps the first error.
+type reader struct {
+	b    []byte
+	name string
+	err  error
+}
+
+func newReader(b []byte, name string) *reader {
+	return &reader{b: b, name: name, err: nil}
+}
+
+func (r *reader) next(n int) []byte {
+	if r.err != nil {
+		return make([]byte, n)
+	}
+	if len(r.b) < n {
+		r.err = fmt.Errorf("%w %s : short message", ErrInvalid, r.name)
+		return make([]byte, n)
+	}
+	b := r.b[:n]
+	r.b = r.b[n:]
+	return b
+}
+
+func (r *reader) uint8() uint8 {
+	return r.next(1)[0]
+}
+
+func (r *reader) uint16() uint16 {
+	return binary.LittleEndian.Uint16(r.next(2))
+}
+
+func (r *reader) uint32() uint32 {
+	return binary.LittleEndian.Uint32(r.next(4))
+}
+
+func (r *reader) uint64() uint64 {
+	return binary.LittleEndian.Uint64(r.next(8))
+}
+
+func (r *reader) bytes(n int) []byte {
+	return append([]byte{}, r.next(n)...)
+}
+
+func (r *reader) rest() []byte {
+	if r.err != nil || len(r.b) == 0 {
+		return nil
+	}
+	b := append([]byte{}, r.b...)
+	r.b = nil
+	return b
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bdx
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cybergarage/go-matter/internal/protocol"
+	matterr "github.com/cybergarage/go-matter/matter/errors"
+)
+
+// StatusReportOpcode is the opcode of the StatusReport message of the secure channel protocol,
+// which ends a transfer with a BDX status.
+const StatusReportOpcode protocol.Opcode = 0x40
+
+const (
+	generalCodeFailure = 0x0001
+	statusReportSize   = 8
+)
+
+// 11.22.3.2. Status Codes
+// Status represents a BDX status code which is reported by a peer to end a transfer.
+type Status uint16
+
+const (
+	StatusLengthTooLarge             Status = 0x0012
+	StatusLengthTooShort             Status = 0x0013
+	StatusLengthMismatch             Status = 0x0014
+	StatusLengthRequired             Status = 0x0015
+	StatusBadMessageContents         Status = 0x0016
+	StatusBadBlockCounter            Status = 0x0017
+	StatusUnexpectedMessage          Status = 0x0018
+	StatusResponderBusy              Status = 0x0019
+	StatusTransferFailedUnknownError Status = 0x001F
+	StatusTransferMethodNotSupported Status = 0x0050
+	StatusFileDesignatorUnknown      Status = 0x0051
+	StatusStartOffsetNotSupported    Status = 0x0052
+	StatusVersionNotSupported        Status = 0x0053
+	StatusUnknown                    Status = 0x005F
+)
+
+// Error returns the string representation as an error.
+func (status Status) Error() string {
+	return fmt.Sprintf("bdx status 0x%04X", uint16(status))
+}
+
+// Is returns true if the target is the category of the requests rejected by peers.
+func (status Status) Is(target error) bool {
+	return target == matterr.ErrRejected
+}
+
+// NewStatusReportBytes returns the payload of a StatusReport message of the specified status.
+func NewStatusReportBytes(status Status) []byte {
+	b := binary.LittleEndian.AppendUint16(nil, generalCodeFailure)
+	b = binary.LittleEndian.AppendUint32(b, uint32(ProtocolID))
+	return binary.LittleEndian.AppendUint16(b, uint16(status))
+}
+
+// NewStatusFromStatusReportBytes returns the BDX status of the specified StatusReport payload.
+func NewStatusFromStatusReportBytes(b []byte) (Status, error) {
+	if len(b) < statusReportSize {
+		return 0, fmt.Errorf("%w status report : short message", ErrInvalid)
+	}
+	if id := binary.LittleEndian.Uint32(b[2:]); id != uint32(ProtocolID) {
+		return 0, fmt.Errorf("%w status report : protocol (0x%08X)", ErrInvalid, id)
+	}
+	return Status(binary.LittleEndian.Uint16(b[6:])), nil
+}
@@ -0,0 +1,283 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bdx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cybergarage/go-matter/internal/protocol"
+)
+
+const (
+	// DefaultMaxBlockSize is the default maximum block size proposed and accepted by transfers.
+	DefaultMaxBlockSize = 1024
+)
+
+// Message represents a BDX message, or a StatusReport message which ends a transfer.
+type Message struct {
+	// Opcode is the message opcode.
+	Opcode protocol.Opcode
+	// Payload is the message payload.
+	Payload []byte
+}
+
+// NewMessage returns a new message.
+func NewMessage(opcode protocol.Opcode, payload []byte) *Message {
+	return &Message{
+		Opcode:  opcode,
+		Payload: payload,
+	}
+}
+
+// Conn represents an exchange on which a transfer runs, such as an exchange over a secure session
+// to the peer. The exchange delivers the messages reliably and in order.
+type Conn interface {
+	// Send sends the message to the peer.
+	Send(ctx context.Context, msg *Message) error
+	// Receive returns the next message from the peer.
+	Receive(ctx context.Context) (*Message, error)
+}
+
+// TransferOption represents an option of a transfer.
+type TransferOption func(*transfer)
+
+type transfer struct {
+	maxBlockSize uint16
+	metadata     []byte
+}
+
+// WithMaxBlockSize sets the maximum block size which is proposed or accepted.
+func WithMaxBlockSize(n uint16) TransferOption {
+	return func(t *transfer) {
+		if 0 < n {
+			t.maxBlockSize = n
+		}
+	}
+}
+
+// WithMetadata sets the TLV encoded metadata of the init or accept message.
+func WithMetadata(b []byte) TransferOption {
+	return func(t *transfer) {
+		t.metadata = b
+	}
+}
+
+func newTransfer(opts ...TransferOption) *transfer {
+	t := &transfer{
+		maxBlockSize: DefaultMaxBlockSize,
+		metadata:     nil,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// receive returns the next message, and the status of a StatusReport message as an error.
+func receive(ctx context.Context, conn Conn) (*Message, error) {
+	msg, err := conn.Receive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if msg.Opcode == StatusReportOpcode {
+		status, err := NewStatusFromStatusReportBytes(msg.Payload)
+		if err != nil {
+			return nil, err
+		}
+		return nil, status
+	}
+	return msg, nil
+}
+
+// fail reports the status to the peer unless the error is reported by the peer or the context is done,
+// and returns the error.
+func fail(ctx context.Context, conn Conn, err error, status Status) error {
+	var peerStatus Status
+	if errors.As(err, &peerStatus) || ctx.Err() != nil {
+		return err
+	}
+	_ = conn.Send(ctx, NewMessage(StatusReportOpcode, NewStatusReportBytes(status)))
+	return err
+}
+
+// Send sends the file read from the reader as the initiator of a receiver drive transfer, which
+// starts with SendInit and ends when the receiver acknowledges the BlockEOF. This is how nodes
+// upload files such as diagnostic logs to the requesting controller.
+func Send(ctx context.Context, conn Conn, designator []byte, r io.Reader, opts ...TransferOption) error {
+	t := newTransfer(opts...)
+	init := &TransferInit{
+		TransferControl: TransferControl(Version) | ReceiverDrive,
+		MaxBlockSize:    t.maxBlockSize,
+		StartOffset:     0,
+		MaxLength:       0,
+		FileDesignator:  designator,
+		Metadata:        t.metadata,
+	}
+	if err := conn.Send(ctx, NewMessage(SendInitOpcode, init.Bytes())); err != nil {
+		return err
+	}
+	msg, err := receive(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if msg.Opcode != SendAcceptOpcode {
+		return fail(ctx, conn, fmt.Errorf("%w : opcode (0x%02X)", ErrUnexpected, msg.Opcode), StatusUnexpectedMessage)
+	}
+	accept, err := NewSendAcceptFromBytes(msg.Payload)
+	if err != nil {
+		return fail(ctx, conn, err, StatusBadMessageContents)
+	}
+	if !accept.TransferControl.IsReceiverDrive() || accept.MaxBlockSize == 0 || t.maxBlockSize < accept.MaxBlockSize {
+		return fail(ctx, conn, fmt.Errorf("%w send accept : transfer control (0x%02X) block size (%d)", ErrInvalid, uint8(accept.TransferControl), accept.MaxBlockSize), StatusBadMessageContents)
+	}
+
+	buf := make([]byte, accept.MaxBlockSize)
+	for counter := uint32(0); ; counter++ {
+		msg, err := receive(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if msg.Opcode != BlockQueryOpcode {
+			return fail(ctx, conn, fmt.Errorf("%w : opcode (0x%02X)", ErrUnexpected, msg.Opcode), StatusUnexpectedMessage)
+		}
+		queried, err := NewCounterFromBytes(msg.Payload)
+		if err != nil {
+			return fail(ctx, conn, err, StatusBadMessageContents)
+		}
+		if queried != counter {
+			return fail(ctx, conn, fmt.Errorf("%w block query : counter (%d) != (%d)", ErrInvalid, queried, counter), StatusBadBlockCounter)
+		}
+		n, err := io.ReadFull(r, buf)
+		eof := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+		if err != nil && !eof {
+			return fail(ctx, conn, err, StatusTransferFailedUnknownError)
+		}
+		block := &Block{Counter: counter, Data: buf[:n]}
+		opcode := BlockOpcode
+		if eof {
+			opcode = BlockEOFOpcode
+		}
+		if err := conn.Send(ctx, NewMessage(opcode, block.Bytes())); err != nil {
+			return err
+		}
+		if !eof {
+			continue
+		}
+		msg, err = receive(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if msg.Opcode != BlockAckEOFOpcode {
+			return fail(ctx, conn, fmt.Errorf("%w : opcode (0x%02X)", ErrUnexpected, msg.Opcode), StatusUnexpectedMessage)
+		}
+		return nil
+	}
+}
+
+// AcceptFunc represents a function which decides whether to accept a transfer. Transfers are rejected
+// with the returned error, whose Status is reported to the sender if it has one.
+type AcceptFunc func(init *TransferInit) error
+
+// Receive receives a file into the writer as the responder of a receiver drive transfer which
+// the sender starts with SendInit, and returns the init message. A nil accept function accepts any file.
+func Receive(ctx context.Context, conn Conn, w io.Writer, accept AcceptFunc, opts ...TransferOption) (*TransferInit, error) {
+	t := newTransfer(opts...)
+	msg, err := receive(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	if msg.Opcode != SendInitOpcode {
+		return nil, fail(ctx, conn, fmt.Errorf("%w : opcode (0x%02X)", ErrUnexpected, msg.Opcode), StatusUnexpectedMessage)
+	}
+	init, err := NewTransferInitFromBytes(msg.Payload)
+	if err != nil {
+		return nil, fail(ctx, conn, err, StatusBadMessageContents)
+	}
+	if init.TransferControl.Version() != Version {
+		return nil, fail(ctx, conn, fmt.Errorf("%w send init : version (%d)", ErrInvalid, init.TransferControl.Version()), StatusVersionNotSupported)
+	}
+	if !init.TransferControl.IsReceiverDrive() {
+		return nil, fail(ctx, conn, fmt.Errorf("%w send init : transfer control (0x%02X)", ErrInvalid, uint8(init.TransferControl)), StatusTransferMethodNotSupported)
+	}
+	if init.StartOffset != 0 {
+		return nil, fail(ctx, conn, fmt.Errorf("%w send init : start offset (%d)", ErrInvalid, init.StartOffset), StatusStartOffsetNotSupported)
+	}
+	if accept != nil {
+		if err := accept(init); err != nil {
+			var status Status
+			if !errors.As(err, &status) {
+				status = StatusTransferFailedUnknownError
+			}
+			_ = conn.Send(ctx, NewMessage(StatusReportOpcode, NewStatusReportBytes(status)))
+			return nil, err
+		}
+	}
+	blockSize := min(init.MaxBlockSize, t.maxBlockSize)
+	if blockSize == 0 {
+		return nil, fail(ctx, conn, fmt.Errorf("%w send init : block size (0)", ErrInvalid), StatusBadMessageContents)
+	}
+	res := &SendAccept{
+		TransferControl: TransferControl(Version) | ReceiverDrive,
+		MaxBlockSize:    blockSize,
+		Metadata:        t.metadata,
+	}
+	if err := conn.Send(ctx, NewMessage(SendAcceptOpcode, res.Bytes())); err != nil {
+		return nil, err
+	}
+
+	length := uint64(0)
+	for counter := uint32(0); ; counter++ {
+		if err := conn.Send(ctx, NewMessage(BlockQueryOpcode, NewCounterBytes(counter))); err != nil {
+			return nil, err
+		}
+		msg, err := receive(ctx, conn)
+		if err != nil {
+			return nil, err
+		}
+		if msg.Opcode != BlockOpcode && msg.Opcode != BlockEOFOpcode {
+			return nil, fail(ctx, conn, fmt.Errorf("%w : opcode (0x%02X)", ErrUnexpected, msg.Opcode), StatusUnexpectedMessage)
+		}
+		block, err := NewBlockFromBytes(msg.Payload)
+		if err != nil {
+			return nil, fail(ctx, conn, err, StatusBadMessageContents)
+		}
+		if block.Counter != counter {
+			return nil, fail(ctx, conn, fmt.Errorf("%w block : counter (%d) != (%d)", ErrInvalid, block.Counter, counter), StatusBadBlockCounter)
+		}
+		if int(blockSize) < len(block.Data) {
+			return nil, fail(ctx, conn, fmt.Errorf("%w block : size (%d) > (%d)", ErrInvalid, len(block.Data), blockSize), StatusBadMessageContents)
+		}
+		length += uint64(len(block.Data))
+		if 0 < init.MaxLength && init.MaxLength < length {
+			return nil, fail(ctx, conn, fmt.Errorf("%w block : length (%d) > (%d)", ErrInvalid, length, init.MaxLength), StatusLengthTooLarge)
+		}
+		if _, err := w.Write(block.Data); err != nil {
+			return nil, fail(ctx, conn, err, StatusTransferFailedUnknownError)
+		}
+		if msg.Opcode == BlockOpcode {
+			continue
+		}
+		if 0 < init.MaxLength && length != init.MaxLength {
+			return nil, fail(ctx, conn, fmt.Errorf("%w block : length (%d) != (%d)", ErrInvalid, length, init.MaxLength), StatusLengthMismatch)
+		}
+		if err := conn.Send(ctx, NewMessage(BlockAckEOFOpcode, NewCounterBytes(counter))); err != nil {
+			return nil, err
+		}
+		return init, nil
+	}
+}
@@ -0,0 +1,578 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+func TestServiceDescriptor(t *testing.T) {
+	tests := []struct {
+		name       string
+		desc       *ServiceDescriptor
+		additional bool
+		expected   []byte
+	}{
+		{"3840", NewServiceDescriptor(3840, 0xFFF1, 0x8000), false, []byte{0x00, 0x00, 0x0F, 0xF1, 0xFF, 0x00, 0x80, 0x00}},
+		{"additional", NewServiceDescriptor(0x123, 0x0001, 0x0002), true, []byte{0x00, 0x23, 0x01, 0x01, 0x00, 0x02, 0x00, 0x01}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.desc.SetAdditionalData(test.additional)
+			b := test.desc.Bytes()
+			if !bytes.Equal(b, test.expected) {
+				t.Errorf("%X != %X", b, test.expected)
+			}
+			desc, err := NewServiceDescriptorFromBytes(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *desc != *test.desc {
+				t.Errorf("%s != %s", desc, test.desc)
+			}
+		})
+	}
+
+	errTests := []struct {
+		name     string
+		b        []byte
+		expected error
+	}{
+		{"short", []byte{0x00, 0x00, 0x0F}, ErrInvalid},
+		{"opcode", []byte{0x01, 0x00, 0x0F, 0xF1, 0xFF, 0x00, 0x80, 0x00}, ErrNotSupported},
+		{"version", []byte{0x00, 0x00, 0x1F, 0xF1, 0xFF, 0x00, 0x80, 0x00}, ErrNotSupported},
+	}
+	for _, test := range errTests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewServiceDescriptorFromBytes(test.b)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("%v is not %v", err, test.expected)
+			}
+		})
+	}
+}
+
+func TestPacket(t *testing.T) {
+	tests := []struct {
+		name     string
+		b        []byte
+		expected Packet
+	}{
+		{"first segment", []byte{0x05, 0x00, 0x03, 0x00, 0xAA, 0xBB, 0xCC}, Packet{Flags: BeginningFlag | EndingFlag, Opcode: 0, Ack: 0, Sequence: 0, MessageLength: 3, Payload: []byte{0xAA, 0xBB, 0xCC}}},
+		{"piggybacked ack", []byte{0x0A, 0x04, 0x05, 0xAA}, Packet{Flags: ContinuingFlag | AckFlag, Opcode: 0, Ack: 4, Sequence: 5, MessageLength: 0, Payload: []byte{0xAA}}},
+		{"standalone ack", []byte{0x08, 0x01, 0x02}, Packet{Flags: AckFlag, Opcode: 0, Ack: 1, Sequence: 2, MessageLength: 0, Payload: []byte{}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkt, err := NewPacketFromBytes(test.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pkt.Flags != test.expected.Flags || pkt.Ack != test.expected.Ack || pkt.Sequence != test.expected.Sequence || pkt.MessageLength != test.expected.MessageLength || !bytes.Equal(pkt.Payload, test.expected.Payload) {
+				t.Errorf("%+v != %+v", pkt, test.expected)
+			}
+			if !bytes.Equal(pkt.Bytes(), test.b) {
+				t.Errorf("%X != %X", pkt.Bytes(), test.b)
+			}
+		})
+	}
+
+	invalids := [][]byte{
+		{},
+		{0x80, 0x00},
+		{0x03, 0x00, 0x00, 0x00},
+		{0x65, 0x6C, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x04},
+		{0x08, 0x01},
+		{0x01, 0x00, 0x03},
+	}
+	for _, b := range invalids {
+		if _, err := NewPacketFromBytes(b); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%X : %v is not %v", b, err, ErrInvalid)
+		}
+	}
+}
+
+func TestHandshake(t *testing.T) {
+	reqBytes := []byte{0x65, 0x6C, 0x04, 0x00, 0x00, 0x00, 0xF7, 0x00, 0x06}
+	if !IsHandshakePacket(reqBytes) {
+		t.Errorf("%X is not a handshake packet", reqBytes)
+	}
+	req, err := NewHandshakeRequestFromBytes(reqBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Versions[0] != 4 || req.Versions[1] != 0 || req.MTU != 247 || req.WindowSize != 6 {
+		t.Errorf("%+v", req)
+	}
+	if !bytes.Equal(req.Bytes(), reqBytes) {
+		t.Errorf("%X != %X", req.Bytes(), reqBytes)
+	}
+
+	resBytes := []byte{0x65, 0x6C, 0x04, 0xF4, 0x00, 0x06}
+	res, err := NewHandshakeResponseFromBytes(resBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Version != 4 || res.SegmentSize != 244 || res.WindowSize != 6 {
+		t.Errorf("%+v", res)
+	}
+	if !bytes.Equal(res.Bytes(), resBytes) {
+		t.Errorf("%X != %X", res.Bytes(), resBytes)
+	}
+
+	if _, err := NewHandshakeRequestFromBytes(resBytes); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+	if _, err := NewHandshakeResponseFromBytes([]byte{0x65, 0x6C, 0x14, 0xF4, 0x00, 0x06}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+}
+
+func TestMemoryTransport(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	transport := NewMemoryTransport()
+	dev := NewMemoryDevice("00:11:22:33:44:55", -40, NewServiceDescriptor(3840, 0xFFF1, 0x8000))
+	transport.AddDevice(dev)
+
+	// Scan
+
+	scanCtx, scanCancel := context.WithCancel(ctx)
+	found := make(chan Device, 2)
+	scanDone := make(chan error)
+	go func() {
+		scanDone <- transport.Scan(scanCtx, func(dev Device) { found <- dev })
+	}()
+	if d := <-found; d.Address() != dev.Address() {
+		t.Errorf("%s != %s", d.Address(), dev.Address())
+	}
+	late := NewMemoryDevice("00:11:22:33:44:66", -70, NewServiceDescriptor(840, 0xFFF1, 0x8001))
+	transport.AddDevice(late)
+	if d := <-found; d.ServiceDescriptor().Discriminator() != 840 {
+		t.Errorf("%d != %d", d.ServiceDescriptor().Discriminator(), 840)
+	}
+	scanCancel()
+	if err := <-scanDone; err != nil {
+		t.Error(err)
+	}
+
+	// Connect failures
+
+	gattErr := errors.New("gatt error")
+	dev.SetConnectError(gattErr)
+	if _, err := dev.Connect(ctx); !errors.Is(err, gattErr) {
+		t.Errorf("%v is not %v", err, gattErr)
+	}
+	dev.SetConnectError(nil)
+
+	// Exchange packets
+
+	dev.SetMTU(247)
+	svc, err := dev.Connect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if svc.MTU() != 247 {
+		t.Errorf("%d != %d", svc.MTU(), 247)
+	}
+	peripheral, err := dev.Accept(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.Read(ctx); !errors.Is(err, ErrNotOpened) {
+		t.Errorf("%v is not %v", err, ErrNotOpened)
+	}
+	if err := peripheral.Indicate(ctx, []byte{0x00}); !errors.Is(err, ErrNotOpened) {
+		t.Errorf("%v is not %v", err, ErrNotOpened)
+	}
+
+	if err := svc.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := peripheral.Subscribed(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	req := []byte{0x65, 0x6C, 0x04, 0x00, 0x00, 0x00, 0xF7, 0x00, 0x06}
+	if err := svc.Write(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	b, err := peripheral.Receive(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, req) {
+		t.Errorf("%X != %X", b, req)
+	}
+
+	res := []byte{0x65, 0x6C, 0x04, 0xF7, 0x00, 0x06}
+	if err := peripheral.Indicate(ctx, res); err != nil {
+		t.Fatal(err)
+	}
+	b, err = svc.Read(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, res) {
+		t.Errorf("%X != %X", b, res)
+	}
+
+	// Close
+
+	if err := svc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := peripheral.Receive(ctx); !errors.Is(err, ErrClosed) {
+		t.Errorf("%v is not %v", err, ErrClosed)
+	}
+	if err := svc.Write(ctx, req); !errors.Is(err, ErrClosed) {
+		t.Errorf("%v is not %v", err, ErrClosed)
+	}
+}
+
+func TestConnector(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	transport := NewMemoryTransport()
+	near := NewMemoryDevice("00:11:22:33:44:01", -40, NewServiceDescriptor(3840, 0xFFF1, 0x8000))
+	middle := NewMemoryDevice("00:11:22:33:44:02", -60, NewServiceDescriptor(3841, 0xFFF1, 0x8000))
+	far := NewMemoryDevice("00:11:22:33:44:03", -80, NewServiceDescriptor(3840, 0xFFF1, 0x8000))
+	other := NewMemoryDevice("00:11:22:33:44:04", -30, NewServiceDescriptor(840, 0xFFF1, 0x8000))
+	for _, dev := range []*MemoryDevice{far, other, middle, near} {
+		transport.AddDevice(dev)
+	}
+
+	conn := NewConnector(transport,
+		WithScanDuration(10*time.Millisecond),
+		WithConnectMaxAttempts(3),
+		WithConnectBackoff(time.Millisecond, 2*time.Millisecond))
+
+	// Candidates which match the short discriminator are ordered by the signal strengths.
+
+	candidates, err := conn.Candidates(ctx, types.NewShortDiscriminator(0xF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	addrs := []string{}
+	for _, dev := range candidates {
+		addrs = append(addrs, dev.Address())
+	}
+	expected := []string{near.Address(), middle.Address(), far.Address()}
+	if strings.Join(addrs, ",") != strings.Join(expected, ",") {
+		t.Errorf("%v != %v", addrs, expected)
+	}
+
+	// A GATT error on the strongest candidate falls back to the next one.
+
+	gattErr := errors.New("gatt error")
+	near.SetConnectError(gattErr)
+	dev, svc, err := conn.Connect(ctx, 3840)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dev.Address() != far.Address() {
+		t.Errorf("%s != %s", dev.Address(), far.Address())
+	}
+	svc.Close()
+
+	// The attempt history is returned if all candidates fail.
+
+	far.SetConnectError(gattErr)
+	_, _, err = conn.Connect(ctx, 3840)
+	var connErr *ConnectError
+	if !errors.As(err, &connErr) {
+		t.Fatalf("%v is not %T", err, connErr)
+	}
+	if len(connErr.Attempts) != 3 || connErr.Attempts[2].Address != near.Address() {
+		t.Errorf("%s", connErr)
+	}
+	if !errors.Is(err, gattErr) {
+		t.Errorf("%v is not %v", err, gattErr)
+	}
+
+	if _, _, err := conn.Connect(ctx, 100); !errors.Is(err, ErrNotFound) {
+		t.Errorf("%v is not %v", err, ErrNotFound)
+	}
+}
+
+func TestLink(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	transport := NewMemoryTransport()
+	dev := NewMemoryDevice("00:11:22:33:44:01", -40, NewServiceDescriptor(3840, 0xFFF1, 0x8000))
+	transport.AddDevice(dev)
+	conn := NewConnector(transport,
+		WithScanDuration(10*time.Millisecond),
+		WithConnectBackoff(time.Millisecond, 2*time.Millisecond),
+		WithStallCheckInterval(time.Millisecond))
+
+	if _, err := conn.Open(ctx, 3840, 0); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+
+	link, err := conn.Open(ctx, 3840, 4, WithAckTimeout(10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer link.Close()
+	svc := link.Service()
+	flow := link.FlowControl()
+
+	// An unacknowledged segment stalls the session, and the link reconnects with a new flow control.
+
+	if _, err := flow.Send(); err != nil {
+		t.Fatal(err)
+	}
+	for link.Reconnects() == 0 {
+		select {
+		case <-ctx.Done():
+			t.Fatal(ctx.Err())
+		case <-time.After(time.Millisecond):
+		}
+	}
+	if link.Service() == svc || link.FlowControl() == flow || link.FlowControl().Stats().Outstanding != 0 {
+		t.Errorf("session is not renewed")
+	}
+	if err := svc.Write(ctx, []byte{0x00}); !errors.Is(err, ErrClosed) {
+		t.Errorf("%v is not %v", err, ErrClosed)
+	}
+
+	// A failed reconnection ends the link.
+
+	dev.SetConnectError(errors.New("gatt error"))
+	if _, err := link.FlowControl().Send(); err != nil {
+		t.Fatal(err)
+	}
+	for link.Err() == nil {
+		select {
+		case <-ctx.Done():
+			t.Fatal(ctx.Err())
+		case <-time.After(time.Millisecond):
+		}
+	}
+	if link.Service() != nil {
+		t.Errorf("link has a service")
+	}
+	if err := link.Close(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFlowControl(t *testing.T) {
+	now := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	fc, err := NewFlowControl(3, WithFlowClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFlowControl(0); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+
+	// Segments are sent until the window is full.
+
+	for n := 0; n < 3; n++ {
+		seq, err := fc.Send()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seq != uint8(n) {
+			t.Errorf("%d != %d", seq, n)
+		}
+	}
+	if _, err := fc.Send(); !errors.Is(err, ErrWindowFull) {
+		t.Errorf("%v is not %v", err, ErrWindowFull)
+	}
+	if stats := fc.Stats(); stats.Outstanding != 3 || stats.Occupancy() != 1 {
+		t.Errorf("%+v", stats)
+	}
+
+	// An acknowledgement releases the segments up to its sequence number.
+
+	now = now.Add(time.Second)
+	if err := fc.Acknowledged(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := fc.Acknowledged(1); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+	if stats := fc.Stats(); stats.Outstanding != 1 || !stats.LastAck.Equal(now) || !fc.CanSend() {
+		t.Errorf("%+v", stats)
+	}
+
+	// Received segments are acknowledged at once.
+
+	for _, seq := range []uint8{254, 255, 0} {
+		if err := fc.Received(seq); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := fc.Received(2); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+	if ack, ok := fc.Ack(); !ok || ack != 0 {
+		t.Errorf("ack (%d) != 0", ack)
+	}
+	if _, ok := fc.Ack(); ok {
+		t.Errorf("nothing to acknowledge")
+	}
+
+	// The session stalls if the outstanding segment is not acknowledged within the ack timeout.
+
+	if err := fc.CheckStall(); err != nil {
+		t.Error(err)
+	}
+	now = now.Add(AckTimeout)
+	if err := fc.CheckStall(); !errors.Is(err, ErrStalled) {
+		t.Errorf("%v is not %v", err, ErrStalled)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := fc.Monitor(ctx, time.Millisecond); !errors.Is(err, ErrStalled) {
+		t.Errorf("%v is not %v", err, ErrStalled)
+	}
+	if !fc.Stats().Stalled {
+		t.Errorf("%+v", fc.Stats())
+	}
+	if err := fc.Acknowledged(2); err != nil {
+		t.Fatal(err)
+	}
+	if fc.Stats().Stalled {
+		t.Errorf("%+v", fc.Stats())
+	}
+}
+
+// testBlockingTransport represents a BLE stack which ignores contexts until it is released.
+type testBlockingTransport struct {
+	release chan struct{}
+	dev     *testBlockingDevice
+}
+
+func (transport *testBlockingTransport) Scan(ctx context.Context, handler func(Device)) error {
+	handler(transport.dev)
+	<-transport.release
+	handler(transport.dev)
+	return nil
+}
+
+type testBlockingDevice struct {
+	*MemoryDevice
+	release chan struct{}
+	closed  chan struct{}
+}
+
+func (dev *testBlockingDevice) Connect(ctx context.Context) (Service, error) {
+	<-dev.release
+	svc, err := dev.MemoryDevice.Connect(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return &testBlockingService{Service: svc, dev: dev}, nil
+}
+
+type testBlockingService struct {
+	Service
+	dev *testBlockingDevice
+}
+
+func (svc *testBlockingService) Read(ctx context.Context) ([]byte, error) {
+	<-svc.dev.release
+	return svc.Service.Read(context.Background())
+}
+
+func (svc *testBlockingService) Close() error {
+	close(svc.dev.closed)
+	return svc.Service.Close()
+}
+
+func TestContext(t *testing.T) {
+	release := make(chan struct{})
+	dev := &testBlockingDevice{
+		MemoryDevice: NewMemoryDevice("00:11:22:33:44:01", -40, NewServiceDescriptor(3840, 0xFFF1, 0x8000)),
+		release:      release,
+		closed:       make(chan struct{}),
+	}
+	transport := &testBlockingTransport{release: release, dev: dev}
+
+	// The scan ends with the context, and the handler is not called after it.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	found := 0
+	if err := Scan(ctx, transport, func(Device) { found++ }); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	if found != 1 {
+		t.Errorf("found (%d) != 1", found)
+	}
+
+	// The connection ends with the deadline, and a late connection is closed.
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := Connect(ctx, dev); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("%v is not %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); time.Second < elapsed {
+		t.Errorf("connect returned after %s", elapsed)
+	}
+	close(release)
+	select {
+	case <-dev.closed:
+	case <-time.After(5 * time.Second):
+		t.Error("late connection is not closed")
+	}
+
+	// Reads on a connected service end with their contexts.
+	svc, err := Connect(context.Background(), dev.MemoryDevice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svc.Close()
+	if err := svc.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if _, err := svc.Read(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("%v is not %v", err, context.Canceled)
+	}
+	if err := svc.Write(ctx, []byte{0x00}); !errors.Is(err, context.Canceled) {
+		t.Errorf("%v is not %v", err, context.Canceled)
+	}
+
+	// The connector honors the overall deadline.
+	blocked := &testBlockingDevice{
+		MemoryDevice: dev.MemoryDevice,
+		release:      make(chan struct{}),
+		closed:       make(chan struct{}),
+	}
+	defer close(blocked.release)
+	conn := NewConnector(&testBlockingTransport{release: blocked.release, dev: blocked}, WithScanDuration(5*time.Millisecond))
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, _, err := conn.Connect(ctx, 3840); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("%v is not %v", err, context.DeadlineExceeded)
+	}
+}
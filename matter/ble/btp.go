@@ -0,0 +1,233 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// 4.18.3. BTP Packet PDUs
+// PacketFlag represents the header flags of a BTP packet.
+type PacketFlag uint8
+
+const (
+	// BeginningFlag is set on the first segment of a message, which has the message length.
+	BeginningFlag PacketFlag = 0x01
+	// ContinuingFlag is set on the segments following the first segment of a message.
+	ContinuingFlag PacketFlag = 0x02
+	// EndingFlag is set on the last segment of a message.
+	EndingFlag PacketFlag = 0x04
+	// AckFlag is set if the packet has an acknowledged sequence number.
+	AckFlag PacketFlag = 0x08
+	// ManagementFlag is set if the packet has a management opcode.
+	ManagementFlag PacketFlag = 0x20
+	// HandshakeFlag is set on the handshake request and response.
+	HandshakeFlag PacketFlag = 0x40
+
+	packetFlagReservedMask PacketFlag = 0x90
+)
+
+const (
+	// HandshakeOpcode is the management opcode of the handshake request and response.
+	HandshakeOpcode = 0x6C
+	// HandshakeFlags is the header flags of the handshake request and response.
+	HandshakeFlags = HandshakeFlag | ManagementFlag | EndingFlag | BeginningFlag
+	// HandshakeRequestSize is the size of a handshake request.
+	HandshakeRequestSize = 9
+	// HandshakeResponseSize is the size of a handshake response.
+	HandshakeResponseSize = 6
+	// MaxSupportedVersions is the number of versions which a handshake request can list.
+	MaxSupportedVersions = 8
+)
+
+// Has returns true if all the specified flags are set.
+func (flag PacketFlag) Has(f PacketFlag) bool {
+	return (flag & f) == f
+}
+
+// Packet represents a BTP packet which carries a message segment or a standalone acknowledgement.
+type Packet struct {
+	// Flags is the header flags.
+	Flags PacketFlag
+	// Opcode is the management opcode, which is present with ManagementFlag.
+	Opcode uint8
+	// Ack is the acknowledged sequence number, which is present with AckFlag.
+	Ack uint8
+	// Sequence is the sequence number of the packet.
+	Sequence uint8
+	// MessageLength is the length of the whole message, which is present with BeginningFlag.
+	MessageLength uint16
+	// Payload is the message segment.
+	Payload []byte
+}
+
+// IsHandshakePacket returns true if the specified bytes are a handshake request or response.
+func IsHandshakePacket(b []byte) bool {
+	return 0 < len(b) && PacketFlag(b[0]).Has(HandshakeFlag)
+}
+
+// NewPacketFromBytes returns a new packet decoded from the specified bytes, which must not be a handshake packet.
+func NewPacketFromBytes(b []byte) (*Packet, error) {
+	if len(b) == 0 {
+		return nil, fmt.Errorf("%w packet: empty", ErrInvalid)
+	}
+	pkt := &Packet{
+		Flags:         PacketFlag(b[0]),
+		Opcode:        0,
+		Ack:           0,
+		Sequence:      0,
+		MessageLength: 0,
+		Payload:       nil,
+	}
+	switch {
+	case pkt.Flags&packetFlagReservedMask != 0:
+		return nil, fmt.Errorf("%w packet flags: reserved bits %02X", ErrInvalid, uint8(pkt.Flags&packetFlagReservedMask))
+	case pkt.Flags.Has(HandshakeFlag):
+		return nil, fmt.Errorf("%w packet flags: handshake packet %02X", ErrInvalid, uint8(pkt.Flags))
+	case pkt.Flags.Has(BeginningFlag | ContinuingFlag):
+		return nil, fmt.Errorf("%w packet flags: beginning and continuing segment %02X", ErrInvalid, uint8(pkt.Flags))
+	}
+
+	offset := 1
+	readByte := func(name string) (uint8, error) {
+		if len(b) <= offset {
+			return 0, fmt.Errorf("%w packet %s: short packet (%d)", ErrInvalid, name, len(b))
+		}
+		offset++
+		return b[offset-1], nil
+	}
+	var err error
+	if pkt.Flags.Has(ManagementFlag) {
+		if pkt.Opcode, err = readByte("management opcode"); err != nil {
+			return nil, err
+		}
+	}
+	if pkt.Flags.Has(AckFlag) {
+		if pkt.Ack, err = readByte("ack number"); err != nil {
+			return nil, err
+		}
+	}
+	if pkt.Sequence, err = readByte("sequence number"); err != nil {
+		return nil, err
+	}
+	if pkt.Flags.Has(BeginningFlag) {
+		if len(b) < offset+2 {
+			return nil, fmt.Errorf("%w packet message length: short packet (%d)", ErrInvalid, len(b))
+		}
+		pkt.MessageLength = binary.LittleEndian.Uint16(b[offset:])
+		offset += 2
+	}
+	pkt.Payload = b[offset:]
+	return pkt, nil
+}
+
+// Bytes returns the encoded packet.
+func (pkt *Packet) Bytes() []byte {
+	b := []byte{uint8(pkt.Flags)}
+	if pkt.Flags.Has(ManagementFlag) {
+		b = append(b, pkt.Opcode)
+	}
+	if pkt.Flags.Has(AckFlag) {
+		b = append(b, pkt.Ack)
+	}
+	b = append(b, pkt.Sequence)
+	if pkt.Flags.Has(BeginningFlag) {
+		b = binary.LittleEndian.AppendUint16(b, pkt.MessageLength)
+	}
+	return append(b, pkt.Payload...)
+}
+
+// HandshakeRequest represents a BTP handshake request which a central writes to open a session.
+type HandshakeRequest struct {
+	// Versions is the supported BTP versions in the order of preference, where 0 is unused.
+	Versions [MaxSupportedVersions]uint8
+	// MTU is the ATT MTU of the central, or 0 if it is unknown.
+	MTU uint16
+	// WindowSize is the receive window size of the central.
+	WindowSize uint8
+}
+
+// NewHandshakeRequestFromBytes returns a new handshake request decoded from the specified bytes.
+func NewHandshakeRequestFromBytes(b []byte) (*HandshakeRequest, error) {
+	if err := checkHandshakePacket(b, HandshakeRequestSize); err != nil {
+		return nil, err
+	}
+	req := &HandshakeRequest{
+		Versions:   [MaxSupportedVersions]uint8{},
+		MTU:        binary.LittleEndian.Uint16(b[6:]),
+		WindowSize: b[8],
+	}
+	// The versions are packed two per byte, the first one in the low nibble.
+	for n := range req.Versions {
+		req.Versions[n] = (b[2+n/2] >> (4 * (n % 2))) & 0x0F
+	}
+	return req, nil
+}
+
+// Bytes returns the encoded handshake request.
+func (req *HandshakeRequest) Bytes() []byte {
+	b := []byte{uint8(HandshakeFlags), HandshakeOpcode}
+	for n := 0; n < MaxSupportedVersions; n += 2 {
+		b = append(b, (req.Versions[n]&0x0F)|((req.Versions[n+1]&0x0F)<<4))
+	}
+	b = binary.LittleEndian.AppendUint16(b, req.MTU)
+	return append(b, req.WindowSize)
+}
+
+// HandshakeResponse represents a BTP handshake response which a peripheral indicates to accept a session.
+type HandshakeResponse struct {
+	// Version is the selected BTP version.
+	Version uint8
+	// SegmentSize is the selected maximum segment size.
+	SegmentSize uint16
+	// WindowSize is the selected window size.
+	WindowSize uint8
+}
+
+// NewHandshakeResponseFromBytes returns a new handshake response decoded from the specified bytes.
+func NewHandshakeResponseFromBytes(b []byte) (*HandshakeResponse, error) {
+	if err := checkHandshakePacket(b, HandshakeResponseSize); err != nil {
+		return nil, err
+	}
+	if b[2]&0xF0 != 0 {
+		return nil, fmt.Errorf("%w handshake version: reserved bits %02X", ErrInvalid, b[2]&0xF0)
+	}
+	return &HandshakeResponse{
+		Version:     b[2],
+		SegmentSize: binary.LittleEndian.Uint16(b[3:]),
+		WindowSize:  b[5],
+	}, nil
+}
+
+// Bytes returns the encoded handshake response.
+func (res *HandshakeResponse) Bytes() []byte {
+	b := []byte{uint8(HandshakeFlags), HandshakeOpcode, res.Version & 0x0F}
+	b = binary.LittleEndian.AppendUint16(b, res.SegmentSize)
+	return append(b, res.WindowSize)
+}
+
+func checkHandshakePacket(b []byte, size int) error {
+	if len(b) != size {
+		return fmt.Errorf("%w handshake length: %d (expected %d)", ErrInvalid, len(b), size)
+	}
+	if PacketFlag(b[0]) != HandshakeFlags {
+		return fmt.Errorf("%w handshake flags: %02X", ErrInvalid, b[0])
+	}
+	if b[1] != HandshakeOpcode {
+		return fmt.Errorf("%w handshake opcode: %02X", ErrInvalid, b[1])
+	}
+	return nil
+}
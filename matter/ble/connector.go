@@ -0,0 +1,258 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+const (
+	// DefaultScanDuration is the default duration to collect the advertisements of the candidates.
+	DefaultScanDuration = 3 * time.Second
+	// DefaultConnectMaxAttempts is the default number of connection attempts across the candidates.
+	DefaultConnectMaxAttempts = 6
+	// DefaultConnectInitialInterval is the default interval before the second attempt.
+	DefaultConnectInitialInterval = 250 * time.Millisecond
+	// DefaultConnectMaxInterval is the default upper bound of the interval between the attempts.
+	DefaultConnectMaxInterval = 4 * time.Second
+	// DefaultStallCheckInterval is the default interval at which links check their sessions for stalls.
+	DefaultStallCheckInterval = time.Second
+)
+
+// ConnectorOption represents an option of a connector.
+type ConnectorOption func(*Connector)
+
+// WithScanDuration sets the duration to collect the advertisements of the candidates.
+func WithScanDuration(d time.Duration) ConnectorOption {
+	return func(conn *Connector) {
+		conn.scanDuration = d
+	}
+}
+
+// WithConnectMaxAttempts sets the number of connection attempts across the candidates, which is at least one.
+func WithConnectMaxAttempts(n int) ConnectorOption {
+	return func(conn *Connector) {
+		conn.maxAttempts = n
+	}
+}
+
+// WithConnectBackoff sets the interval before the second attempt, which doubles on each later
+// attempt up to the specified maximum interval.
+func WithConnectBackoff(initial time.Duration, max time.Duration) ConnectorOption {
+	return func(conn *Connector) {
+		conn.initialInterval = initial
+		conn.maxInterval = max
+	}
+}
+
+// WithStallCheckInterval sets the interval at which the opened links check their sessions for stalls.
+func WithStallCheckInterval(d time.Duration) ConnectorOption {
+	return func(conn *Connector) {
+		conn.stallInterval = d
+	}
+}
+
+// Connector represents a BLE central which connects to a commissionable device of a discriminator.
+// When several devices advertise a matching discriminator, the devices with the stronger signals
+// are tried first, and failed connections are retried on the next candidates with backoff.
+type Connector struct {
+	transport       Transport
+	scanDuration    time.Duration
+	maxAttempts     int
+	initialInterval time.Duration
+	maxInterval     time.Duration
+	stallInterval   time.Duration
+}
+
+// NewConnector returns a new connector on the specified transport.
+func NewConnector(transport Transport, opts ...ConnectorOption) *Connector {
+	conn := &Connector{
+		transport:       transport,
+		scanDuration:    DefaultScanDuration,
+		maxAttempts:     DefaultConnectMaxAttempts,
+		initialInterval: DefaultConnectInitialInterval,
+		maxInterval:     DefaultConnectMaxInterval,
+		stallInterval:   DefaultStallCheckInterval,
+	}
+	for _, opt := range opts {
+		opt(conn)
+	}
+	return conn
+}
+
+// Candidates scans the devices which advertise the specified discriminator for the scan duration,
+// and returns them in descending order of the signal strengths.
+func (conn *Connector) Candidates(ctx context.Context, discriminator types.Discriminator) ([]Device, error) {
+	scanCtx, cancel := context.WithTimeout(ctx, conn.scanDuration)
+	defer cancel()
+
+	var mutex sync.Mutex
+	devices := map[string]Device{}
+	err := Scan(scanCtx, conn.transport, func(dev Device) {
+		desc := dev.ServiceDescriptor()
+		if desc == nil || !discriminator.Matches(desc.Discriminator()) {
+			return
+		}
+		mutex.Lock()
+		devices[dev.Address()] = dev
+		mutex.Unlock()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	candidates := make([]Device, 0, len(devices))
+	for _, dev := range devices {
+		candidates = append(candidates, dev)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].RSSI() != candidates[j].RSSI() {
+			return candidates[j].RSSI() < candidates[i].RSSI()
+		}
+		return candidates[i].Address() < candidates[j].Address()
+	})
+	return candidates, nil
+}
+
+// Connect connects to a device which advertises the specified discriminator, and returns the device
+// and its opened service. Each attempt connects and subscribes within the context, so the scan and
+// all attempts end by the deadline of the context even if the transport does not honor it.
+// If all attempts fail, the returned ConnectError has the attempt history.
+func (conn *Connector) Connect(ctx context.Context, discriminator types.Discriminator) (Device, Service, error) {
+	candidates, err := conn.Candidates(ctx, discriminator)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, nil, fmt.Errorf("device (D:%s) is %w", discriminator, ErrNotFound)
+	}
+
+	connErr := &ConnectError{
+		Discriminator: discriminator,
+		Attempts:      []ConnectAttempt{},
+	}
+	for n := 0; n < max(conn.maxAttempts, 1); n++ {
+		if 0 < n {
+			timer := time.NewTimer(conn.interval(n))
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				connErr.Attempts = append(connErr.Attempts, ConnectAttempt{Address: "", RSSI: 0, Err: ctx.Err()})
+				return nil, nil, connErr
+			}
+		}
+		dev := candidates[n%len(candidates)]
+		svc, err := openService(ctx, dev)
+		if err == nil {
+			return dev, svc, nil
+		}
+		connErr.Attempts = append(connErr.Attempts, ConnectAttempt{Address: dev.Address(), RSSI: dev.RSSI(), Err: err})
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, nil, connErr
+}
+
+// openService connects to the device and subscribes to the C2 characteristic.
+func openService(ctx context.Context, dev Device) (Service, error) {
+	svc, err := Connect(ctx, dev)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.Open(ctx); err != nil {
+		svc.Close()
+		return nil, err
+	}
+	return svc, nil
+}
+
+// Reconnect closes the specified service of a stalled or a disconnected session, and connects to a
+// device which advertises the specified discriminator again.
+func (conn *Connector) Reconnect(ctx context.Context, svc Service, discriminator types.Discriminator) (Device, Service, error) {
+	if svc != nil {
+		svc.Close()
+	}
+	return conn.Connect(ctx, discriminator)
+}
+
+// interval returns the interval before the specified attempt, which starts from zero.
+func (conn *Connector) interval(attempt int) time.Duration {
+	interval := conn.initialInterval
+	for n := 1; n < attempt; n++ {
+		interval *= 2
+		if conn.maxInterval <= interval {
+			return conn.maxInterval
+		}
+	}
+	return interval
+}
+
+// ConnectAttempt represents a failed connection attempt.
+type ConnectAttempt struct {
+	// Address is the address of the tried device, which is empty if the attempt was canceled before the connection.
+	Address string
+	// RSSI is the signal strength of the tried device.
+	RSSI int
+	// Err is the error of the attempt.
+	Err error
+}
+
+// String returns the string representation.
+func (attempt ConnectAttempt) String() string {
+	if len(attempt.Address) == 0 {
+		return attempt.Err.Error()
+	}
+	return fmt.Sprintf("%s (%d dBm) : %s", attempt.Address, attempt.RSSI, attempt.Err)
+}
+
+// ConnectError represents an error which is returned when all connection attempts failed.
+type ConnectError struct {
+	// Discriminator is the discriminator of the devices.
+	Discriminator types.Discriminator
+	// Attempts is the failed attempts in the tried order.
+	Attempts []ConnectAttempt
+}
+
+// Error returns the attempt history.
+func (e *ConnectError) Error() string {
+	attempts := make([]string, len(e.Attempts))
+	for n, attempt := range e.Attempts {
+		attempts[n] = fmt.Sprintf("[%d] %s", n, attempt)
+	}
+	return fmt.Sprintf("device (D:%s) connection failed after %d attempts : %s", e.Discriminator, len(e.Attempts), strings.Join(attempts, ", "))
+}
+
+// Unwrap returns the errors of the attempts.
+func (e *ConnectError) Unwrap() []error {
+	errs := make([]error, len(e.Attempts))
+	for n, attempt := range e.Attempts {
+		errs[n] = attempt.Err
+	}
+	return errs
+}
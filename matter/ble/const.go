@@ -0,0 +1,32 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+// 4.18.1. BTP GATT Service
+const (
+	// ServiceUUID is the 16-bit UUID of the Matter BLE service.
+	ServiceUUID = 0xFFF6
+	// C1UUID is the characteristic which a central writes BTP packets to.
+	C1UUID = "18EE2EF5-263D-4559-959F-4F9C429F9D11"
+	// C2UUID is the characteristic which a peripheral indicates BTP packets on.
+	C2UUID = "18EE2EF5-263D-4559-959F-4F9C429F9D12"
+	// C3UUID is the characteristic which exposes the additional commissioning data.
+	C3UUID = "64630238-8772-45F2-B87D-748A83218F04"
+)
+
+const (
+	// MinATTMTU is the minimum ATT MTU of BLE.
+	MinATTMTU = 23
+)
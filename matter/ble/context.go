@@ -0,0 +1,139 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// BLE stacks do not always honor contexts; some GATT operations block until the link layer
+// gives up, which can be much longer than the commissioning timeout. The functions below bound
+// the operations with their contexts regardless of the transport implementation.
+
+// result represents the result of an operation which runs in its own goroutine.
+type result[T any] struct {
+	v   T
+	err error
+}
+
+// runContext runs the operation, and returns the context error as soon as the context is done.
+// The result of an operation which completes after the context is done is passed to the cleanup
+// function, which may be nil.
+func runContext[T any](ctx context.Context, op func() (T, error), cleanup func(T)) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+	done := make(chan result[T], 1)
+	go func() {
+		v, err := op()
+		done <- result[T]{v: v, err: err}
+	}()
+	select {
+	case res := <-done:
+		return res.v, res.err
+	case <-ctx.Done():
+		go func() {
+			res := <-done
+			if res.err == nil && cleanup != nil {
+				cleanup(res.v)
+			}
+		}()
+		return zero, ctx.Err()
+	}
+}
+
+// Scan reports the discovered devices to the handler until the context is done like Transport.Scan,
+// and returns when the context is done even if the transport does not. The handler is not called
+// after Scan returns.
+func Scan(ctx context.Context, transport Transport, handler func(Device)) error {
+	var mutex sync.Mutex
+	stopped := false
+	defer func() {
+		mutex.Lock()
+		stopped = true
+		mutex.Unlock()
+	}()
+	_, err := runContext(ctx, func() (struct{}, error) {
+		return struct{}{}, transport.Scan(ctx, func(dev Device) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			if !stopped {
+				handler(dev)
+			}
+		})
+	}, nil)
+	// A scan which ends with the context is a complete scan.
+	if ctxErr := ctx.Err(); ctxErr != nil && errors.Is(err, ctxErr) {
+		return nil
+	}
+	return err
+}
+
+// Connect connects to the device like Device.Connect, and returns the context error as soon as
+// the context is done. A connection which is established after the context is done is closed.
+func Connect(ctx context.Context, dev Device) (Service, error) {
+	svc, err := runContext(ctx, func() (Service, error) {
+		return dev.Connect(ctx)
+	}, func(svc Service) {
+		svc.Close()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewContextService(svc), nil
+}
+
+// contextService represents a service whose operations return as soon as their contexts are done.
+type contextService struct {
+	Service
+}
+
+// NewContextService returns a service whose Open, Write and Read return the context errors as soon
+// as their contexts are done, even if the specified service does not honor the contexts.
+func NewContextService(svc Service) Service {
+	if _, ok := svc.(*contextService); ok {
+		return svc
+	}
+	return &contextService{
+		Service: svc,
+	}
+}
+
+// Open discovers the Matter service and subscribes to the C2 characteristic.
+func (svc *contextService) Open(ctx context.Context) error {
+	_, err := runContext(ctx, func() (struct{}, error) {
+		return struct{}{}, svc.Service.Open(ctx)
+	}, nil)
+	return err
+}
+
+// Write writes the specified packet to the C1 characteristic.
+func (svc *contextService) Write(ctx context.Context, b []byte) error {
+	_, err := runContext(ctx, func() (struct{}, error) {
+		return struct{}{}, svc.Service.Write(ctx, b)
+	}, nil)
+	return err
+}
+
+// Read returns the next packet indicated on the C2 characteristic. A packet which is indicated
+// after the context is done is dropped, as the BTP session is abandoned with the context.
+func (svc *contextService) Read(ctx context.Context) ([]byte, error) {
+	return runContext(ctx, func() ([]byte, error) {
+		return svc.Service.Read(ctx)
+	}, nil)
+}
@@ -0,0 +1,140 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+const (
+	// ServiceDescriptorSize is the size of the Matter BLE service data.
+	ServiceDescriptorSize = 8
+	// CommissionableOpcode is the opcode of a commissionable device advertisement.
+	CommissionableOpcode = 0x00
+	// AdvertisementVersion is the supported advertisement version.
+	AdvertisementVersion = 0
+
+	additionalDataFlag        = 0x01
+	extendedAnnouncementFlag  = 0x02
+	advertisementVersionShift = 12
+)
+
+// 5.4.2.5.6. Advertising Data
+// ServiceDescriptor represents the service data of a commissionable device advertisement.
+type ServiceDescriptor struct {
+	opcode               uint8
+	version              uint8
+	discriminator        types.Discriminator
+	vendorID             types.VendorID
+	productID            types.ProductID
+	additionalData       bool
+	extendedAnnouncement bool
+}
+
+// NewServiceDescriptor returns a new commissionable service descriptor.
+func NewServiceDescriptor(discriminator types.Discriminator, vendorID types.VendorID, productID types.ProductID) *ServiceDescriptor {
+	return &ServiceDescriptor{
+		opcode:               CommissionableOpcode,
+		version:              AdvertisementVersion,
+		discriminator:        types.Discriminator(discriminator.Value()),
+		vendorID:             vendorID,
+		productID:            productID,
+		additionalData:       false,
+		extendedAnnouncement: false,
+	}
+}
+
+// NewServiceDescriptorFromBytes returns a new service descriptor decoded from the specified service data.
+func NewServiceDescriptorFromBytes(b []byte) (*ServiceDescriptor, error) {
+	if len(b) < ServiceDescriptorSize {
+		return nil, fmt.Errorf("%w service data length: %d", ErrInvalid, len(b))
+	}
+	if b[0] != CommissionableOpcode {
+		return nil, fmt.Errorf("%w opcode: %02X", ErrNotSupported, b[0])
+	}
+	dv := binary.LittleEndian.Uint16(b[1:])
+	desc := &ServiceDescriptor{
+		opcode:               b[0],
+		version:              uint8(dv >> advertisementVersionShift),
+		discriminator:        types.Discriminator(dv & types.DiscriminatorMax),
+		vendorID:             types.VendorID(binary.LittleEndian.Uint16(b[3:])),
+		productID:            types.ProductID(binary.LittleEndian.Uint16(b[5:])),
+		additionalData:       (b[7] & additionalDataFlag) != 0,
+		extendedAnnouncement: (b[7] & extendedAnnouncementFlag) != 0,
+	}
+	if desc.version != AdvertisementVersion {
+		return nil, fmt.Errorf("%w advertisement version: %d", ErrNotSupported, desc.version)
+	}
+	return desc, nil
+}
+
+// Discriminator returns the 12-bit discriminator.
+func (desc *ServiceDescriptor) Discriminator() types.Discriminator {
+	return desc.discriminator
+}
+
+// VendorID returns the vendor ID.
+func (desc *ServiceDescriptor) VendorID() types.VendorID {
+	return desc.vendorID
+}
+
+// ProductID returns the product ID.
+func (desc *ServiceDescriptor) ProductID() types.ProductID {
+	return desc.productID
+}
+
+// SetAdditionalData sets whether the additional commissioning data characteristic (C3) is present.
+func (desc *ServiceDescriptor) SetAdditionalData(v bool) {
+	desc.additionalData = v
+}
+
+// HasAdditionalData returns true if the additional commissioning data characteristic (C3) is present.
+func (desc *ServiceDescriptor) HasAdditionalData() bool {
+	return desc.additionalData
+}
+
+// SetExtendedAnnouncement sets whether the device is in the extended announcement.
+func (desc *ServiceDescriptor) SetExtendedAnnouncement(v bool) {
+	desc.extendedAnnouncement = v
+}
+
+// IsExtendedAnnouncement returns true if the device is in the extended announcement.
+func (desc *ServiceDescriptor) IsExtendedAnnouncement() bool {
+	return desc.extendedAnnouncement
+}
+
+// Bytes returns the encoded service data.
+func (desc *ServiceDescriptor) Bytes() []byte {
+	b := []byte{desc.opcode}
+	b = binary.LittleEndian.AppendUint16(b, uint16(desc.version)<<advertisementVersionShift|desc.discriminator.Value())
+	b = binary.LittleEndian.AppendUint16(b, uint16(desc.vendorID))
+	b = binary.LittleEndian.AppendUint16(b, uint16(desc.productID))
+	flags := byte(0)
+	if desc.additionalData {
+		flags |= additionalDataFlag
+	}
+	if desc.extendedAnnouncement {
+		flags |= extendedAnnouncementFlag
+	}
+	return append(b, flags)
+}
+
+// String returns the string representation.
+func (desc *ServiceDescriptor) String() string {
+	return fmt.Sprintf("D:%s VID:%s PID:%s", desc.discriminator, desc.vendorID, desc.productID)
+}
@@ -0,0 +1,36 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	matterr "github.com/cybergarage/go-matter/matter/errors"
+)
+
+var (
+	// ErrInvalid is returned when an advertisement or a GATT value is invalid.
+	ErrInvalid = matterr.New(matterr.ErrWire, "invalid")
+	// ErrNotSupported is returned when an advertisement is not supported.
+	ErrNotSupported = matterr.New(matterr.ErrUnsupported, "not supported")
+	// ErrNotOpened is returned when a service is used before it is opened.
+	ErrNotOpened = matterr.New(matterr.ErrClosed, "not opened")
+	// ErrClosed is returned when a service is already closed.
+	ErrClosed = matterr.New(matterr.ErrClosed, "closed")
+	// ErrNotFound is returned when no device advertises the discriminator.
+	ErrNotFound = matterr.New(matterr.ErrNotFound, "not found")
+	// ErrWindowFull is returned when a segment exceeds the negotiated window.
+	ErrWindowFull = matterr.New(matterr.ErrRejected, "window full")
+	// ErrStalled is returned when sent segments are not acknowledged within the ack timeout.
+	ErrStalled = matterr.New(matterr.ErrTimeout, "stalled")
+)
@@ -0,0 +1,235 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// 4.18.4. BTP Session Timeouts
+const (
+	// AckTimeout is the time in which a sent segment must be acknowledged.
+	AckTimeout = 15 * time.Second
+	// MaxWindowSize is the maximum receive window size which can be negotiated.
+	MaxWindowSize = 255
+)
+
+// FlowStats represents the flow-control state of a BTP session.
+type FlowStats struct {
+	// WindowSize is the negotiated window size of the peer.
+	WindowSize int
+	// Outstanding is the number of the sent segments which are not acknowledged yet.
+	Outstanding int
+	// PendingAcks is the number of the received segments which are not acknowledged yet.
+	PendingAcks int
+	// SegmentsSent is the number of the sent segments.
+	SegmentsSent uint64
+	// SegmentsReceived is the number of the received segments.
+	SegmentsReceived uint64
+	// LastAck is the time when a segment was last acknowledged by the peer.
+	LastAck time.Time
+	// Stalled is true if the oldest outstanding segment is not acknowledged within the ack timeout.
+	Stalled bool
+}
+
+// Occupancy returns the ratio of the outstanding segments to the window size.
+func (stats FlowStats) Occupancy() float64 {
+	if stats.WindowSize == 0 {
+		return 0
+	}
+	return float64(stats.Outstanding) / float64(stats.WindowSize)
+}
+
+// FlowControlOption represents an option of a flow control.
+type FlowControlOption func(*FlowControl)
+
+// WithAckTimeout sets the time in which a sent segment must be acknowledged.
+func WithAckTimeout(d time.Duration) FlowControlOption {
+	return func(fc *FlowControl) {
+		fc.ackTimeout = d
+	}
+}
+
+// WithFlowClock sets the clock which stamps the sent and the acknowledged segments.
+func WithFlowClock(now func() time.Time) FlowControlOption {
+	return func(fc *FlowControl) {
+		fc.now = now
+	}
+}
+
+// FlowControl represents the sliding windows of a BTP session, which track the sequence numbers of
+// the sent and the received segments and detect the session stalls.
+type FlowControl struct {
+	sync.Mutex
+	windowSize   int
+	nextSeq      uint8
+	sentTimes    []time.Time
+	lastReceived uint8
+	lastAcked    uint8
+	received     bool
+	segmentsSent uint64
+	segmentsRecv uint64
+	lastAck      time.Time
+	ackTimeout   time.Duration
+	now          func() time.Time
+}
+
+// NewFlowControl returns a new flow control of the specified window size negotiated in the handshake.
+func NewFlowControl(windowSize int, opts ...FlowControlOption) (*FlowControl, error) {
+	if windowSize <= 0 || MaxWindowSize < windowSize {
+		return nil, fmt.Errorf("%w window size: %d", ErrInvalid, windowSize)
+	}
+	fc := &FlowControl{
+		Mutex:        sync.Mutex{},
+		windowSize:   windowSize,
+		nextSeq:      0,
+		sentTimes:    []time.Time{},
+		lastReceived: 0,
+		lastAcked:    0,
+		received:     false,
+		segmentsSent: 0,
+		segmentsRecv: 0,
+		lastAck:      time.Time{},
+		ackTimeout:   AckTimeout,
+		now:          time.Now,
+	}
+	for _, opt := range opts {
+		opt(fc)
+	}
+	return fc, nil
+}
+
+// CanSend returns true if the peer window has room for another segment.
+func (fc *FlowControl) CanSend() bool {
+	fc.Lock()
+	defer fc.Unlock()
+	return len(fc.sentTimes) < fc.windowSize
+}
+
+// Send returns the sequence number of the next segment, and counts it as outstanding.
+// ErrWindowFull is returned if the peer window has no room.
+func (fc *FlowControl) Send() (uint8, error) {
+	fc.Lock()
+	defer fc.Unlock()
+	if fc.windowSize <= len(fc.sentTimes) {
+		return 0, fmt.Errorf("%w : %d outstanding segments", ErrWindowFull, len(fc.sentTimes))
+	}
+	seq := fc.nextSeq
+	fc.nextSeq++
+	fc.sentTimes = append(fc.sentTimes, fc.now())
+	fc.segmentsSent++
+	return seq, nil
+}
+
+// Acknowledged releases the outstanding segments up to the specified acknowledged sequence number.
+func (fc *FlowControl) Acknowledged(ack uint8) error {
+	fc.Lock()
+	defer fc.Unlock()
+	oldest := fc.nextSeq - uint8(len(fc.sentTimes))
+	released := int(ack-oldest) + 1
+	if len(fc.sentTimes) == 0 || len(fc.sentTimes) < released {
+		return fmt.Errorf("%w ack: %d (outstanding %d-%d)", ErrInvalid, ack, oldest, fc.nextSeq-1)
+	}
+	fc.sentTimes = fc.sentTimes[released:]
+	fc.lastAck = fc.now()
+	return nil
+}
+
+// Received checks the sequence number of a received segment, which must follow the last one.
+func (fc *FlowControl) Received(seq uint8) error {
+	fc.Lock()
+	defer fc.Unlock()
+	if fc.received && seq != fc.lastReceived+1 {
+		return fmt.Errorf("%w sequence number: %d (expected %d)", ErrInvalid, seq, fc.lastReceived+1)
+	}
+	if !fc.received {
+		fc.lastAcked = seq - 1
+	}
+	fc.received = true
+	fc.lastReceived = seq
+	fc.segmentsRecv++
+	return nil
+}
+
+// Ack returns the sequence number to acknowledge, which is piggybacked on the next sent segment
+// or sent in a standalone acknowledgement. False is returned if there is nothing to acknowledge.
+func (fc *FlowControl) Ack() (uint8, bool) {
+	fc.Lock()
+	defer fc.Unlock()
+	if fc.pendingAcks() == 0 {
+		return 0, false
+	}
+	fc.lastAcked = fc.lastReceived
+	return fc.lastReceived, true
+}
+
+func (fc *FlowControl) pendingAcks() int {
+	if !fc.received {
+		return 0
+	}
+	return int(fc.lastReceived - fc.lastAcked)
+}
+
+func (fc *FlowControl) isStalled(now time.Time) bool {
+	return 0 < len(fc.sentTimes) && fc.ackTimeout < now.Sub(fc.sentTimes[0])
+}
+
+// Stats returns the current flow-control state.
+func (fc *FlowControl) Stats() FlowStats {
+	fc.Lock()
+	defer fc.Unlock()
+	return FlowStats{
+		WindowSize:       fc.windowSize,
+		Outstanding:      len(fc.sentTimes),
+		PendingAcks:      fc.pendingAcks(),
+		SegmentsSent:     fc.segmentsSent,
+		SegmentsReceived: fc.segmentsRecv,
+		LastAck:          fc.lastAck,
+		Stalled:          fc.isStalled(fc.now()),
+	}
+}
+
+// CheckStall returns an error wrapping ErrStalled if the oldest outstanding segment is not
+// acknowledged within the ack timeout.
+func (fc *FlowControl) CheckStall() error {
+	fc.Lock()
+	defer fc.Unlock()
+	now := fc.now()
+	if !fc.isStalled(now) {
+		return nil
+	}
+	return fmt.Errorf("%w : %d segments unacknowledged for %s", ErrStalled, len(fc.sentTimes), now.Sub(fc.sentTimes[0]))
+}
+
+// Monitor checks the stall at the specified interval until the context is done, and returns the
+// stall error to let the caller close the session and reconnect with Connector.Reconnect, which
+// the links opened by Connector.Open do.
+func (fc *FlowControl) Monitor(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := fc.CheckStall(); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// Link represents a connection to a device which advertises a discriminator, whose BTP session is
+// tracked by a flow control. When the session stalls, the link reconnects with Connector.Reconnect
+// and starts a new flow control, so the Service and FlowControl are looked up for each exchange.
+type Link struct {
+	sync.Mutex
+	conn          *Connector
+	discriminator types.Discriminator
+	windowSize    int
+	opts          []FlowControlOption
+	dev           Device
+	svc           Service
+	flow          *FlowControl
+	reconnects    int
+	err           error
+	cancel        context.CancelFunc
+	done          chan struct{}
+}
+
+// Open connects to a device which advertises the specified discriminator, and returns a link whose
+// flow control has the window size negotiated in the handshake. The link checks the session for
+// stalls at the stall check interval of the connector until it is closed.
+func (conn *Connector) Open(ctx context.Context, discriminator types.Discriminator, windowSize int, opts ...FlowControlOption) (*Link, error) {
+	flow, err := NewFlowControl(windowSize, opts...)
+	if err != nil {
+		return nil, err
+	}
+	dev, svc, err := conn.Connect(ctx, discriminator)
+	if err != nil {
+		return nil, err
+	}
+	monitorCtx, cancel := context.WithCancel(context.Background())
+	link := &Link{
+		Mutex:         sync.Mutex{},
+		conn:          conn,
+		discriminator: discriminator,
+		windowSize:    windowSize,
+		opts:          opts,
+		dev:           dev,
+		svc:           svc,
+		flow:          flow,
+		reconnects:    0,
+		err:           nil,
+		cancel:        cancel,
+		done:          make(chan struct{}),
+	}
+	go link.monitor(monitorCtx)
+	return link, nil
+}
+
+// monitor reconnects whenever the current session stalls until the context is done or a reconnection fails.
+func (link *Link) monitor(ctx context.Context) {
+	defer close(link.done)
+	for {
+		link.Lock()
+		flow := link.flow
+		svc := link.svc
+		link.Unlock()
+
+		if err := flow.Monitor(ctx, link.conn.stallInterval); err == nil {
+			return
+		}
+		dev, newSvc, err := link.conn.Reconnect(ctx, svc, link.discriminator)
+		if err == nil {
+			flow, err = NewFlowControl(link.windowSize, link.opts...)
+			if err != nil {
+				newSvc.Close()
+			}
+		}
+
+		link.Lock()
+		if err != nil {
+			link.dev, link.svc, link.flow = nil, nil, nil
+			if ctx.Err() == nil {
+				link.err = err
+			}
+			link.Unlock()
+			return
+		}
+		link.dev, link.svc, link.flow = dev, newSvc, flow
+		link.reconnects++
+		link.Unlock()
+	}
+}
+
+// Device returns the connected device, which is nil after a reconnection failed.
+func (link *Link) Device() Device {
+	link.Lock()
+	defer link.Unlock()
+	return link.dev
+}
+
+// Service returns the service of the current session, which is nil after a reconnection failed.
+func (link *Link) Service() Service {
+	link.Lock()
+	defer link.Unlock()
+	return link.svc
+}
+
+// FlowControl returns the flow control of the current session, which is nil after a reconnection failed.
+func (link *Link) FlowControl() *FlowControl {
+	link.Lock()
+	defer link.Unlock()
+	return link.flow
+}
+
+// Reconnects returns the number of the reconnections after stalls.
+func (link *Link) Reconnects() int {
+	link.Lock()
+	defer link.Unlock()
+	return link.reconnects
+}
+
+// Err returns the error of the reconnection which ended the link, or nil.
+func (link *Link) Err() error {
+	link.Lock()
+	defer link.Unlock()
+	return link.err
+}
+
+// Close stops checking the session for stalls, and closes the service of the current session.
+func (link *Link) Close() error {
+	link.cancel()
+	<-link.done
+	link.Lock()
+	defer link.Unlock()
+	if link.svc == nil {
+		return nil
+	}
+	err := link.svc.Close()
+	link.svc = nil
+	return err
+}
@@ -0,0 +1,339 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+	memoryQueueSize = 64
+)
+
+// MemoryTransport represents an in-memory transport for tests which do not have Bluetooth adapters.
+type MemoryTransport struct {
+	sync.Mutex
+	devices  []*MemoryDevice
+	scanners map[*memoryScanner]struct{}
+}
+
+type memoryScanner struct {
+	handler func(Device)
+}
+
+// NewMemoryTransport returns a new in-memory transport.
+func NewMemoryTransport() *MemoryTransport {
+	return &MemoryTransport{
+		Mutex:    sync.Mutex{},
+		devices:  []*MemoryDevice{},
+		scanners: map[*memoryScanner]struct{}{},
+	}
+}
+
+// AddDevice starts advertising the specified device. Running scans report it immediately.
+func (transport *MemoryTransport) AddDevice(dev *MemoryDevice) {
+	transport.Lock()
+	transport.devices = append(transport.devices, dev)
+	scanners := make([]*memoryScanner, 0, len(transport.scanners))
+	for scanner := range transport.scanners {
+		scanners = append(scanners, scanner)
+	}
+	transport.Unlock()
+
+	for _, scanner := range scanners {
+		scanner.handler(dev)
+	}
+}
+
+// RemoveDevice stops advertising the device with the specified address.
+func (transport *MemoryTransport) RemoveDevice(addr string) {
+	transport.Lock()
+	defer transport.Unlock()
+	devices := []*MemoryDevice{}
+	for _, dev := range transport.devices {
+		if dev.Address() == addr {
+			continue
+		}
+		devices = append(devices, dev)
+	}
+	transport.devices = devices
+}
+
+// Scan reports all advertising devices to the handler until the context is done.
+func (transport *MemoryTransport) Scan(ctx context.Context, handler func(Device)) error {
+	scanner := &memoryScanner{
+		handler: handler,
+	}
+
+	transport.Lock()
+	devices := append([]*MemoryDevice{}, transport.devices...)
+	transport.scanners[scanner] = struct{}{}
+	transport.Unlock()
+
+	defer func() {
+		transport.Lock()
+		delete(transport.scanners, scanner)
+		transport.Unlock()
+	}()
+
+	for _, dev := range devices {
+		handler(dev)
+	}
+
+	<-ctx.Done()
+
+	return nil
+}
+
+// MemoryDevice represents an in-memory commissionable device.
+type MemoryDevice struct {
+	sync.Mutex
+	address     string
+	rssi        int
+	descriptor  *ServiceDescriptor
+	mtu         int
+	connectErr  error
+	connections chan *MemoryPeripheral
+}
+
+// NewMemoryDevice returns a new in-memory device which advertises the specified descriptor.
+func NewMemoryDevice(addr string, rssi int, desc *ServiceDescriptor) *MemoryDevice {
+	return &MemoryDevice{
+		Mutex:       sync.Mutex{},
+		address:     addr,
+		rssi:        rssi,
+		descriptor:  desc,
+		mtu:         MinATTMTU,
+		connectErr:  nil,
+		connections: make(chan *MemoryPeripheral, memoryQueueSize),
+	}
+}
+
+// Address returns the device address.
+func (dev *MemoryDevice) Address() string {
+	return dev.address
+}
+
+// SetRSSI sets the received signal strength.
+func (dev *MemoryDevice) SetRSSI(rssi int) {
+	dev.Lock()
+	defer dev.Unlock()
+	dev.rssi = rssi
+}
+
+// RSSI returns the received signal strength.
+func (dev *MemoryDevice) RSSI() int {
+	dev.Lock()
+	defer dev.Unlock()
+	return dev.rssi
+}
+
+// ServiceDescriptor returns the advertised service data.
+func (dev *MemoryDevice) ServiceDescriptor() *ServiceDescriptor {
+	return dev.descriptor
+}
+
+// SetMTU sets the ATT MTU which is negotiated on the next connections.
+func (dev *MemoryDevice) SetMTU(mtu int) {
+	dev.Lock()
+	defer dev.Unlock()
+	dev.mtu = mtu
+}
+
+// SetConnectError sets the error which the next connections fail with to simulate GATT errors.
+// A nil error makes the connections succeed again.
+func (dev *MemoryDevice) SetConnectError(err error) {
+	dev.Lock()
+	defer dev.Unlock()
+	dev.connectErr = err
+}
+
+// Connect connects to the device and returns the central side of the service.
+func (dev *MemoryDevice) Connect(ctx context.Context) (Service, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	dev.Lock()
+	err := dev.connectErr
+	mtu := dev.mtu
+	dev.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := &memoryPipe{
+		Mutex:       sync.Mutex{},
+		writes:      make(chan []byte, memoryQueueSize),
+		indications: make(chan []byte, memoryQueueSize),
+		subscribed:  make(chan struct{}),
+		done:        make(chan struct{}),
+		isOpened:    false,
+		isClosed:    false,
+	}
+	central := &memoryService{
+		memoryPipe: pipe,
+		mtu:        mtu,
+	}
+	peripheral := &MemoryPeripheral{
+		memoryPipe: pipe,
+	}
+
+	select {
+	case dev.connections <- peripheral:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	return central, nil
+}
+
+// Accept returns the peripheral side of the next connection.
+func (dev *MemoryDevice) Accept(ctx context.Context) (*MemoryPeripheral, error) {
+	select {
+	case peripheral := <-dev.connections:
+		return peripheral, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// memoryPipe represents the GATT characteristics shared by a central and a peripheral.
+type memoryPipe struct {
+	sync.Mutex
+	writes      chan []byte
+	indications chan []byte
+	subscribed  chan struct{}
+	done        chan struct{}
+	isOpened    bool
+	isClosed    bool
+}
+
+func (pipe *memoryPipe) send(ctx context.Context, ch chan []byte, b []byte) error {
+	select {
+	case <-pipe.done:
+		return ErrClosed
+	default:
+	}
+	select {
+	case ch <- append([]byte{}, b...):
+		return nil
+	case <-pipe.done:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (pipe *memoryPipe) receive(ctx context.Context, ch chan []byte) ([]byte, error) {
+	select {
+	case b := <-ch:
+		return b, nil
+	case <-pipe.done:
+		return nil, ErrClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close closes both sides of the connection.
+func (pipe *memoryPipe) Close() error {
+	pipe.Lock()
+	defer pipe.Unlock()
+	if pipe.isClosed {
+		return nil
+	}
+	pipe.isClosed = true
+	close(pipe.done)
+	return nil
+}
+
+// memoryService represents the central side of an in-memory connection.
+type memoryService struct {
+	*memoryPipe
+	mtu int
+}
+
+// Open subscribes to the C2 characteristic.
+func (svc *memoryService) Open(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	svc.Lock()
+	defer svc.Unlock()
+	if svc.isClosed {
+		return ErrClosed
+	}
+	if !svc.isOpened {
+		svc.isOpened = true
+		close(svc.subscribed)
+	}
+	return nil
+}
+
+// MTU returns the negotiated ATT MTU.
+func (svc *memoryService) MTU() int {
+	return svc.mtu
+}
+
+// Write writes the specified packet to the C1 characteristic.
+func (svc *memoryService) Write(ctx context.Context, b []byte) error {
+	return svc.send(ctx, svc.writes, b)
+}
+
+// Read returns the next packet indicated on the C2 characteristic.
+func (svc *memoryService) Read(ctx context.Context) ([]byte, error) {
+	svc.Lock()
+	opened := svc.isOpened
+	svc.Unlock()
+	if !opened {
+		return nil, ErrNotOpened
+	}
+	return svc.receive(ctx, svc.indications)
+}
+
+// MemoryPeripheral represents the peripheral side of an in-memory connection.
+type MemoryPeripheral struct {
+	*memoryPipe
+}
+
+// Subscribed waits until the central subscribes to the C2 characteristic.
+func (peripheral *MemoryPeripheral) Subscribed(ctx context.Context) error {
+	select {
+	case <-peripheral.subscribed:
+		return nil
+	case <-peripheral.done:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Receive returns the next packet which the central wrote to the C1 characteristic.
+func (peripheral *MemoryPeripheral) Receive(ctx context.Context) ([]byte, error) {
+	return peripheral.receive(ctx, peripheral.writes)
+}
+
+// Indicate indicates the specified packet on the C2 characteristic.
+func (peripheral *MemoryPeripheral) Indicate(ctx context.Context, b []byte) error {
+	peripheral.Lock()
+	opened := peripheral.isOpened
+	peripheral.Unlock()
+	if !opened {
+		return ErrNotOpened
+	}
+	return peripheral.send(ctx, peripheral.indications, b)
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"context"
+)
+
+// Transport represents a BLE central which discovers and connects to commissionable devices.
+// Implementations should return as soon as the contexts of their operations are done. Scan, Connect
+// and NewContextService bound the operations of implementations which do not.
+type Transport interface {
+	// Scan reports the discovered commissionable devices to the handler until the context is done.
+	Scan(ctx context.Context, handler func(Device)) error
+}
+
+// Device represents a discovered commissionable device.
+type Device interface {
+	// Address returns the device address.
+	Address() string
+	// RSSI returns the received signal strength of the last advertisement.
+	RSSI() int
+	// ServiceDescriptor returns the advertised service data.
+	ServiceDescriptor() *ServiceDescriptor
+	// Connect connects to the device and returns the Matter BLE service.
+	Connect(ctx context.Context) (Service, error)
+}
+
+// Service represents the Matter BLE GATT service of a connected device.
+type Service interface {
+	// Open subscribes to the C2 characteristic to receive indications.
+	Open(ctx context.Context) error
+	// MTU returns the negotiated ATT MTU.
+	MTU() int
+	// Write writes the specified packet to the C1 characteristic.
+	Write(ctx context.Context, b []byte) error
+	// Read returns the next packet indicated on the C2 characteristic.
+	Read(ctx context.Context) ([]byte, error)
+	// Close disconnects from the device.
+	Close() error
+}
@@ -0,0 +1,243 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/decode"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/schema"
+)
+
+// Service represents a discovered DNS-SD service.
+type Service struct {
+	Name          string   `json:"name"`
+	Host          string   `json:"host"`
+	Port          uint     `json:"port"`
+	Addrs         []string `json:"addrs"`
+	Discriminator *uint16  `json:"discriminator,omitempty"`
+	VendorID      *uint16  `json:"vendorId,omitempty"`
+	ProductID     *uint16  `json:"productId,omitempty"`
+	DeviceName    string   `json:"deviceName,omitempty"`
+}
+
+func newService(srv *matter.DiscoveredService) Service {
+	addrs := make([]string, 0, len(srv.Addrs))
+	for _, addr := range srv.Addrs {
+		addrs = append(addrs, addr.String())
+	}
+	service := Service{
+		Name:          srv.Name,
+		Host:          srv.Host,
+		Port:          srv.Port,
+		Addrs:         addrs,
+		Discriminator: nil,
+		VendorID:      nil,
+		ProductID:     nil,
+		DeviceName:    "",
+	}
+	node := matter.NewCommissioneeWithService(srv.Service)
+	if d, ok := node.LookupDiscriminator(); ok {
+		v := uint16(d)
+		service.Discriminator = &v
+	}
+	if vid, ok := node.LookupVendorID(); ok {
+		v := uint16(vid)
+		service.VendorID = &v
+	}
+	if pid, ok := node.LookupProductID(); ok {
+		v := uint16(pid)
+		service.ProductID = &v
+	}
+	if name, ok := node.LookupDeviceName(); ok {
+		service.DeviceName = name
+	}
+	return service
+}
+
+// OnboardingRequest represents a request to resolve an onboarding code.
+type OnboardingRequest struct {
+	// Code is a QR code or a manual pairing code.
+	Code string `json:"code"`
+}
+
+// OnboardingResponse represents the device of an onboarding code and the discovered nodes which match it.
+type OnboardingResponse struct {
+	Discriminator uint16    `json:"discriminator"`
+	VendorID      uint16    `json:"vendorId"`
+	ProductID     uint16    `json:"productId"`
+	Matches       []Service `json:"matches"`
+}
+
+// NodeRequest represents a request to register a commissioned node.
+type NodeRequest struct {
+	// FabricID is the fabric ID which the node is commissioned into.
+	FabricID uint64 `json:"fabricId"`
+	// Address is the operational address of the node such as [fe80::1]:5540, which is resolved if it is empty.
+	Address string `json:"address,omitempty"`
+}
+
+// Node represents a registered node.
+type Node struct {
+	Name     string `json:"name"`
+	FabricID uint64 `json:"fabricId"`
+	NodeID   uint64 `json:"nodeId"`
+	Address  string `json:"address,omitempty"`
+}
+
+func newNode(dev *matter.Device) Node {
+	peer := dev.Peer()
+	node := Node{
+		Name:     peer.InstanceName(),
+		FabricID: peer.FabricID,
+		NodeID:   uint64(peer.NodeID),
+		Address:  "",
+	}
+	if peer.Address.IsValid() {
+		node.Address = peer.Address.String()
+	}
+	return node
+}
+
+// ReadRequest represents a request to read attributes.
+type ReadRequest struct {
+	// Path is an attribute path such as 1/OnOff/OnOff, whose fields may be wildcards.
+	Path string `json:"path"`
+}
+
+// AttributeData represents a read attribute.
+type AttributeData struct {
+	// Path is the concrete attribute path such as 1/0x0006/0x0000.
+	Path        string `json:"path"`
+	DataVersion uint32 `json:"dataVersion"`
+	// Value is the JSON value of the attribute. See Value for the representation.
+	Value any `json:"value"`
+	// TLV is the hexadecimal TLV encoded value.
+	TLV string `json:"tlv"`
+}
+
+// ReadResponse represents the read attributes.
+type ReadResponse struct {
+	Attributes []AttributeData `json:"attributes"`
+}
+
+// WriteRequest represents a request to write an attribute.
+type WriteRequest struct {
+	// Path is a concrete attribute path such as 1/OnOff/OnTime.
+	Path string `json:"path"`
+	// Value is the JSON value as in the encode command, which requires the schema of the attribute.
+	Value json.RawMessage `json:"value,omitempty"`
+	// TLV is the hexadecimal TLV encoded value, which is written instead of the value if it is specified.
+	TLV string `json:"tlv,omitempty"`
+}
+
+// InvokeRequest represents a request to invoke a command.
+type InvokeRequest struct {
+	// Path is a command path such as 1/OnOff/Toggle, whose cluster and command may be names or IDs.
+	Path string `json:"path"`
+	// Fields is the JSON object of the fields as in the encode command, which requires the schema of the command.
+	Fields json.RawMessage `json:"fields,omitempty"`
+	// TLV is the hexadecimal TLV encoded fields, which are sent instead of the fields if they are specified.
+	TLV string `json:"tlv,omitempty"`
+}
+
+// InvokeResponse represents the response fields of a command.
+type InvokeResponse struct {
+	// Value is the JSON value of the response fields, or null if the command has no response.
+	Value any `json:"value"`
+	// TLV is the hexadecimal TLV encoded response fields.
+	TLV string `json:"tlv"`
+}
+
+// Error represents an error response.
+type Error struct {
+	Error string `json:"error"`
+	// Status is the interaction model status if the node rejected the request.
+	Status string `json:"status,omitempty"`
+}
+
+// Value returns the JSON value of the TLV encoded value. Structures are objects keyed by the field
+// tags, arrays and lists are arrays, and octet strings are hexadecimal strings with the hex: prefix.
+func Value(b []byte) (any, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	hints := decode.Hints{
+		Start:          decode.TLVLayer,
+		LengthPrefixed: false,
+		Codec:          nil,
+		ProtocolID:     0,
+		Opcode:         0,
+	}
+	msg, err := decode.Decode(b, hints)
+	if err != nil {
+		return nil, fmt.Errorf("%w TLV value : %w", ErrInvalid, err)
+	}
+	if len(msg.Root.Children) != 1 {
+		return nil, fmt.Errorf("%w TLV value : %d elements", ErrInvalid, len(msg.Root.Children))
+	}
+	return nodeValue(msg.Root.Children[0]), nil
+}
+
+func nodeValue(node *decode.Node) any {
+	switch node.Type {
+	case "struct":
+		obj := map[string]any{}
+		for _, child := range node.Children {
+			obj[child.Name] = nodeValue(child)
+		}
+		return obj
+	case "array", "list":
+		elems := []any{}
+		for _, child := range node.Children {
+			elems = append(elems, nodeValue(child))
+		}
+		return elems
+	}
+	if b, ok := node.Value.([]byte); ok {
+		return schema.HexPrefix + hex.EncodeToString(b)
+	}
+	return node.Value
+}
+
+func newAttributeData(data im.AttributeData) (*AttributeData, error) {
+	v, err := Value(data.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &AttributeData{
+		Path:        data.Path.String(),
+		DataVersion: uint32(data.DataVersion),
+		Value:       v,
+		TLV:         hex.EncodeToString(data.Data),
+	}, nil
+}
+
+// readJSON decodes the request body, which must not have unknown fields. The body is
+// limited to MaxRequestSize bytes.
+func readJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxRequestSize)
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%w request : %w", ErrInvalid, err)
+	}
+	return nil
+}
@@ -0,0 +1,490 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package http provides a bridge which exposes the controller as a small REST API with JSON payloads,
+// so that applications written in other languages can drive the controller. The bridge is built only
+// on the stable API of the matter package, and serves the following endpoints:
+//
+//	GET    /discovery               lists the discovered DNS-SD services
+//	POST   /discovery               searches commissionable nodes
+//	POST   /onboarding/resolve      resolves an onboarding code to the discovered commissionable nodes
+//	GET    /nodes                   lists the registered nodes
+//	PUT    /nodes/{name}            registers a commissioned node of the operational instance name
+//	DELETE /nodes/{name}            unregisters the node
+//	POST   /nodes/{name}/read       reads attributes
+//	POST   /nodes/{name}/write      writes an attribute
+//	POST   /nodes/{name}/invoke     invokes a command
+//
+// The bridge does not pair or commission nodes, since the matter package has no commissioning
+// flow yet. Resolving an onboarding code only parses it and matches it against the discovered
+// nodes, without establishing a PASE session. Nodes are commissioned by a commissioner such as
+// chip-tool, and registered to the bridge with the operational instance name, which is
+// <compressed-fabric-id>-<node-id> in hexadecimal.
+// Errors are returned as an Error object with the status code of their category.
+package http
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	matterr "github.com/cybergarage/go-matter/matter/errors"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/schema"
+)
+
+const (
+	// DefaultTimeout is the default timeout of the operations on nodes.
+	DefaultTimeout = time.Second * 30
+	// MaxRequestSize is the maximum size of a request body.
+	MaxRequestSize = 64 * 1024
+)
+
+// Server represents a REST bridge of a controller. The server is safe for concurrent use.
+type Server struct {
+	sync.RWMutex
+	ctrl    *matter.Controller
+	devices map[string]*matter.Device
+	timeout time.Duration
+	mux     *http.ServeMux
+}
+
+// ServerOption represents a server option.
+type ServerOption func(*Server)
+
+// WithTimeout returns an option to set the timeout of the operations on nodes.
+func WithTimeout(timeout time.Duration) ServerOption {
+	return func(srv *Server) {
+		srv.timeout = timeout
+	}
+}
+
+// NewServer returns a new bridge of the specified controller, which is started and stopped by the caller.
+func NewServer(ctrl *matter.Controller, opts ...ServerOption) *Server {
+	srv := &Server{
+		RWMutex: sync.RWMutex{},
+		ctrl:    ctrl,
+		devices: map[string]*matter.Device{},
+		timeout: DefaultTimeout,
+		mux:     http.NewServeMux(),
+	}
+	for _, opt := range opts {
+		opt(srv)
+	}
+	srv.mux.HandleFunc("GET /discovery", srv.handleServices)
+	srv.mux.HandleFunc("POST /discovery", srv.handleSearch)
+	srv.mux.HandleFunc("POST /onboarding/resolve", srv.handleResolveOnboarding)
+	srv.mux.HandleFunc("GET /nodes", srv.handleNodes)
+	srv.mux.HandleFunc("PUT /nodes/{name}", srv.handlePutNode)
+	srv.mux.HandleFunc("DELETE /nodes/{name}", srv.handleDeleteNode)
+	srv.mux.HandleFunc("POST /nodes/{name}/read", srv.handleRead)
+	srv.mux.HandleFunc("POST /nodes/{name}/write", srv.handleWrite)
+	srv.mux.HandleFunc("POST /nodes/{name}/invoke", srv.handleInvoke)
+	return srv
+}
+
+// ServeHTTP serves the request.
+func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	srv.mux.ServeHTTP(w, r)
+}
+
+// Close closes the handles of the registered nodes.
+func (srv *Server) Close() error {
+	srv.Lock()
+	devices := srv.devices
+	srv.devices = map[string]*matter.Device{}
+	srv.Unlock()
+	var errs []error
+	for _, dev := range devices {
+		if err := dev.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// AddNode registers the commissioned node, and replaces the node of the same instance name.
+func (srv *Server) AddNode(peer matter.OperationalPeer) *matter.Device {
+	dev := srv.ctrl.OperationalDevice(peer)
+	srv.Lock()
+	old, ok := srv.devices[peer.InstanceName()]
+	srv.devices[peer.InstanceName()] = dev
+	srv.Unlock()
+	if ok {
+		old.Close()
+	}
+	return dev
+}
+
+// LookupNode returns the handle of the node of the specified operational instance name.
+func (srv *Server) LookupNode(name string) (*matter.Device, bool) {
+	srv.RLock()
+	defer srv.RUnlock()
+	dev, ok := srv.devices[strings.ToUpper(name)]
+	return dev, ok
+}
+
+// RemoveNode unregisters the node of the specified operational instance name.
+func (srv *Server) RemoveNode(name string) error {
+	srv.Lock()
+	dev, ok := srv.devices[strings.ToUpper(name)]
+	delete(srv.devices, strings.ToUpper(name))
+	srv.Unlock()
+	if !ok {
+		return fmt.Errorf("node (%s) is %w", name, ErrNotFound)
+	}
+	return dev.Close()
+}
+
+func (srv *Server) lookupNode(r *http.Request) (*matter.Device, error) {
+	name := r.PathValue("name")
+	dev, ok := srv.LookupNode(name)
+	if !ok {
+		return nil, fmt.Errorf("node (%s) is %w", name, ErrNotFound)
+	}
+	return dev, nil
+}
+
+func (srv *Server) handleServices(w http.ResponseWriter, r *http.Request) {
+	services := []Service{}
+	for _, service := range srv.ctrl.ServiceTable().Services() {
+		services = append(services, newService(service))
+	}
+	writeJSON(w, http.StatusOK, services)
+}
+
+func (srv *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	if err := srv.ctrl.Commissioner().Search(); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (srv *Server) handleResolveOnboarding(w http.ResponseWriter, r *http.Request) {
+	var req OnboardingRequest
+	if err := readJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	onboarding, err := matter.NewOnboardingPayloadFromString(req.Code)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	res := OnboardingResponse{
+		Discriminator: uint16(onboarding.Discriminator()),
+		VendorID:      uint16(onboarding.VendorID()),
+		ProductID:     uint16(onboarding.ProductID()),
+		Matches:       []Service{},
+	}
+	match := matter.NewCommissionableNodeMatcher(onboarding.Payload())
+	for _, service := range srv.ctrl.ServiceTable().Services() {
+		if match(matter.NewCommissioneeWithService(service.Service)) {
+			res.Matches = append(res.Matches, newService(service))
+		}
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (srv *Server) handleNodes(w http.ResponseWriter, r *http.Request) {
+	srv.RLock()
+	nodes := make([]Node, 0, len(srv.devices))
+	for _, dev := range srv.devices {
+		nodes = append(nodes, newNode(dev))
+	}
+	srv.RUnlock()
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	writeJSON(w, http.StatusOK, nodes)
+}
+
+func (srv *Server) handlePutNode(w http.ResponseWriter, r *http.Request) {
+	var req NodeRequest
+	if err := readJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	peer, err := parseOperationalPeer(r.PathValue("name"), req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newNode(srv.AddNode(peer)))
+}
+
+func (srv *Server) handleDeleteNode(w http.ResponseWriter, r *http.Request) {
+	if err := srv.RemoveNode(r.PathValue("name")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (srv *Server) handleRead(w http.ResponseWriter, r *http.Request) {
+	dev, err := srv.lookupNode(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req ReadRequest
+	if err := readJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	path, err := im.ParseAttributePath(req.Path)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), srv.timeout)
+	defer cancel()
+	attrs, err := dev.ReadAttribute(ctx, path)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	res := ReadResponse{
+		Attributes: []AttributeData{},
+	}
+	for _, attr := range attrs {
+		data, err := newAttributeData(attr)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		res.Attributes = append(res.Attributes, *data)
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (srv *Server) handleWrite(w http.ResponseWriter, r *http.Request) {
+	dev, err := srv.lookupNode(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req WriteRequest
+	if err := readJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	path, err := im.ParseAttributePath(req.Path)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if path.IsWildcard() {
+		writeError(w, fmt.Errorf("%w attribute path (%s) : wildcards are not written", ErrInvalid, req.Path))
+		return
+	}
+	data, err := encodeTLV(req.TLV, req.Value, func(v string) ([]byte, error) {
+		cluster, err := schema.LookupCluster(strconv.FormatUint(uint64(path.Cluster), 10))
+		if err != nil {
+			return nil, err
+		}
+		attr, err := cluster.LookupAttribute(strconv.FormatUint(uint64(path.Attribute), 10))
+		if err != nil {
+			return nil, err
+		}
+		return attr.EncodeJSON(v)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), srv.timeout)
+	defer cancel()
+	if err := dev.WriteAttribute(ctx, path, data); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (srv *Server) handleInvoke(w http.ResponseWriter, r *http.Request) {
+	dev, err := srv.lookupNode(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req InvokeRequest
+	if err := readJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	path, command, err := parseCommandPath(req.Path)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	fields, err := encodeTLV(req.TLV, req.Fields, func(v string) ([]byte, error) {
+		if command == nil {
+			return nil, fmt.Errorf("command (%s) is %w", req.Path, schema.ErrNotFound)
+		}
+		return command.EncodeJSON(v)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), srv.timeout)
+	defer cancel()
+	b, err := dev.InvokeCommand(ctx, path, fields)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	v, err := Value(b)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, InvokeResponse{
+		Value: v,
+		TLV:   hex.EncodeToString(b),
+	})
+}
+
+// parseOperationalPeer returns the peer of the operational instance name and the request.
+func parseOperationalPeer(name string, req NodeRequest) (matter.OperationalPeer, error) {
+	peer := matter.OperationalPeer{
+		FabricID:           req.FabricID,
+		CompressedFabricID: 0,
+		NodeID:             0,
+		Address:            netip.AddrPort{},
+	}
+	fabric, node, ok := strings.Cut(name, "-")
+	if !ok {
+		return peer, fmt.Errorf("%w operational instance name (%s)", ErrInvalid, name)
+	}
+	compressed, err := strconv.ParseUint(fabric, 16, 64)
+	if err != nil {
+		return peer, fmt.Errorf("%w compressed fabric ID (%s)", ErrInvalid, fabric)
+	}
+	nodeID, err := strconv.ParseUint(node, 16, 64)
+	if err != nil {
+		return peer, fmt.Errorf("%w node ID (%s)", ErrInvalid, node)
+	}
+	peer.CompressedFabricID = compressed
+	peer.NodeID = matter.NodeID(nodeID)
+	if 0 < len(req.Address) {
+		peer.Address, err = netip.ParseAddrPort(req.Address)
+		if err != nil {
+			return peer, fmt.Errorf("%w address (%s)", ErrInvalid, req.Address)
+		}
+	}
+	return peer, nil
+}
+
+// parseCommandPath returns the command path such as 1/OnOff/Toggle, and the schema of the command
+// if the cluster and the command are known.
+func parseCommandPath(s string) (im.CommandPath, *schema.Command, error) {
+	path := im.NewCommandPath(0, 0, 0)
+	fields := strings.Split(s, "/")
+	if len(fields) != 3 {
+		return path, nil, fmt.Errorf("%w command path (%s)", ErrInvalid, s)
+	}
+	endpoint, err := strconv.ParseUint(fields[0], 0, 16)
+	if err != nil || im.EndpointID(endpoint) == im.WildcardEndpointID {
+		return path, nil, fmt.Errorf("%w command path (%s) endpoint", ErrInvalid, s)
+	}
+	path.Endpoint = im.EndpointID(endpoint)
+	cluster, err := schema.LookupCluster(fields[1])
+	if err != nil {
+		id, err := strconv.ParseUint(fields[1], 0, 32)
+		if err != nil {
+			return path, nil, fmt.Errorf("%w command path (%s) cluster", ErrInvalid, s)
+		}
+		path.Cluster = im.ClusterID(id)
+	} else {
+		path.Cluster = cluster.ID
+	}
+	var command *schema.Command
+	if cluster != nil {
+		command, _ = cluster.LookupCommand(fields[2])
+	}
+	if command != nil {
+		path.Command = command.ID
+		return path, command, nil
+	}
+	id, err := strconv.ParseUint(fields[2], 0, 32)
+	if err != nil {
+		return path, nil, fmt.Errorf("%w command path (%s) command", ErrInvalid, s)
+	}
+	path.Command = im.CommandID(id)
+	return path, nil, nil
+}
+
+// encodeTLV returns the hexadecimal TLV if it is specified, otherwise the TLV encoding of the JSON value.
+func encodeTLV(s string, v json.RawMessage, encode func(string) ([]byte, error)) ([]byte, error) {
+	if 0 < len(s) {
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w TLV (%s)", ErrInvalid, s)
+		}
+		return b, nil
+	}
+	if len(v) == 0 {
+		return nil, fmt.Errorf("%w request : no value", ErrInvalid)
+	}
+	return encode(string(v))
+}
+
+// statusCode returns the status code of the category of the error.
+func statusCode(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, matterr.ErrInvalidArgument):
+		return http.StatusBadRequest
+	case errors.Is(err, matterr.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, matterr.ErrUnsupported):
+		return http.StatusNotImplemented
+	case errors.Is(err, matterr.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, matterr.ErrRejected), errors.Is(err, matterr.ErrWire), errors.Is(err, matterr.ErrSecurity), errors.Is(err, matterr.ErrClosed):
+		return http.StatusBadGateway
+	}
+	return http.StatusInternalServerError
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	res := Error{
+		Error:  err.Error(),
+		Status: "",
+	}
+	var status im.Status
+	if errors.As(err, &status) {
+		res.Status = status.String()
+	}
+	writeJSON(w, statusCode(err), res)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+const (
+	// DefaultBulkParallelism is the default number of nodes operated concurrently in bulk operations.
+	DefaultBulkParallelism = 16
+)
+
+// InvokeResult represents the result of a command invoked on a node in a bulk operation.
+type InvokeResult struct {
+	// Device is the node.
+	Device *OperationalDevice
+	// Response is the TLV encoded response fields.
+	Response []byte
+	// Err is the error of the node, which is nil on success.
+	Err error
+}
+
+// ReadResult represents the result of attributes read from a node in a bulk operation.
+type ReadResult struct {
+	// Device is the node.
+	Device *OperationalDevice
+	// Data is the read attribute data.
+	Data []im.AttributeData
+	// Err is the error of the node, which is nil on success.
+	Err error
+}
+
+// WithBulkParallelism returns an option to set the maximum number of nodes operated concurrently
+// in bulk operations. Non-positive numbers are replaced with DefaultBulkParallelism.
+func WithBulkParallelism(n int) CommissionerOption {
+	return func(com *Commissioner) {
+		if n <= 0 {
+			n = DefaultBulkParallelism
+		}
+		com.parallelism = n
+	}
+}
+
+// InvokeOnNodes invokes the command on the specified nodes concurrently, and returns the results
+// in the order of the nodes. The returned error joins the errors of the failed nodes, and nodes
+// which are not started before the context is done fail with the context error.
+func (com *Commissioner) InvokeOnNodes(ctx context.Context, devs []*OperationalDevice, path im.CommandPath, fields []byte) ([]InvokeResult, error) {
+	results := make([]InvokeResult, len(devs))
+	com.fanOut(ctx, devs, func(i int, err error) {
+		results[i] = InvokeResult{Device: devs[i], Response: nil, Err: err}
+		if err == nil {
+			results[i].Response, results[i].Err = devs[i].InvokeCommand(ctx, path, fields)
+		}
+	})
+	errs := make([]error, 0, len(results))
+	for _, res := range results {
+		errs = append(errs, nodeError(res.Device, res.Err))
+	}
+	return results, errors.Join(errs...)
+}
+
+// ReadFromNodes reads the attributes of the path from the specified nodes concurrently, and returns
+// the results in the order of the nodes. The returned error joins the errors of the failed nodes,
+// and nodes which are not started before the context is done fail with the context error.
+func (com *Commissioner) ReadFromNodes(ctx context.Context, devs []*OperationalDevice, path im.AttributePath) ([]ReadResult, error) {
+	results := make([]ReadResult, len(devs))
+	com.fanOut(ctx, devs, func(i int, err error) {
+		results[i] = ReadResult{Device: devs[i], Data: nil, Err: err}
+		if err == nil {
+			results[i].Data, results[i].Err = devs[i].ReadAttribute(ctx, path)
+		}
+	})
+	errs := make([]error, 0, len(results))
+	for _, res := range results {
+		errs = append(errs, nodeError(res.Device, res.Err))
+	}
+	return results, errors.Join(errs...)
+}
+
+// fanOut calls the operation for each node with at most the parallelism of the commissioner
+// running at once. The operation is called with the context error instead for the nodes
+// which are not started before the context is done.
+func (com *Commissioner) fanOut(ctx context.Context, devs []*OperationalDevice, op func(i int, err error)) {
+	sem := make(chan struct{}, com.parallelism)
+	var wg sync.WaitGroup
+	for i := range devs {
+		if err := ctx.Err(); err != nil {
+			op(i, err)
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			op(i, ctx.Err())
+			continue
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			op(i, nil)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func nodeError(dev *OperationalDevice, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("node (%016X) : %w", uint64(dev.NodeID()), err)
+}
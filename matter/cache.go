@@ -0,0 +1,260 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// AttributeCache represents a client-side cache of attribute values which are kept with the
+// data versions of their clusters. Reads of attributes covered by a subscription of the cache
+// are served locally, since the subscription reports their changes. Other reads send the data
+// versions of the cached clusters as filters, so the nodes report only the clusters which changed.
+type AttributeCache struct {
+	sync.Mutex
+	clusters      map[cacheKey]*cacheCluster
+	subscriptions map[NodeID][]*cacheSubscription
+}
+
+// cacheKey represents a cluster of a node.
+type cacheKey struct {
+	node     NodeID
+	endpoint im.EndpointID
+	cluster  im.ClusterID
+}
+
+// cacheCluster represents the cached attributes of a cluster of the same data version.
+type cacheCluster struct {
+	dataVersion im.DataVersion
+	attributes  map[im.AttributeID][]byte
+	// complete is true if all the attributes of the cluster are cached.
+	complete bool
+}
+
+// NewAttributeCache returns a new empty attribute cache.
+func NewAttributeCache() *AttributeCache {
+	return &AttributeCache{
+		Mutex:         sync.Mutex{},
+		clusters:      map[cacheKey]*cacheCluster{},
+		subscriptions: map[NodeID][]*cacheSubscription{},
+	}
+}
+
+// Update stores the reported attribute data of the specified node. When the data version of
+// a cluster changes, its cached attributes which no subscription of the cache keeps fresh are dropped.
+func (cache *AttributeCache) Update(node NodeID, data []im.AttributeData) {
+	cache.Lock()
+	defer cache.Unlock()
+	cache.update(node, data)
+}
+
+func (cache *AttributeCache) update(node NodeID, data []im.AttributeData) {
+	for _, d := range data {
+		key := cacheKey{node: node, endpoint: d.Path.Endpoint, cluster: d.Path.Cluster}
+		c, ok := cache.clusters[key]
+		if !ok {
+			c = &cacheCluster{dataVersion: d.DataVersion, attributes: map[im.AttributeID][]byte{}, complete: false}
+			cache.clusters[key] = c
+		}
+		if c.dataVersion != d.DataVersion {
+			for id := range c.attributes {
+				if !cache.isSubscribed(node, im.NewAttributePath(d.Path.Endpoint, d.Path.Cluster, id)) {
+					delete(c.attributes, id)
+				}
+			}
+			c.dataVersion = d.DataVersion
+			c.complete = false
+		}
+		c.attributes[d.Path.Attribute] = d.Data
+	}
+}
+
+// Lookup returns the cached data of the specified concrete attribute.
+func (cache *AttributeCache) Lookup(node NodeID, path im.AttributePath) (im.AttributeData, bool) {
+	cache.Lock()
+	defer cache.Unlock()
+	return cache.lookup(node, path)
+}
+
+func (cache *AttributeCache) lookup(node NodeID, path im.AttributePath) (im.AttributeData, bool) {
+	c, ok := cache.clusters[cacheKey{node: node, endpoint: path.Endpoint, cluster: path.Cluster}]
+	if !ok {
+		return im.AttributeData{Path: path, DataVersion: 0, Data: nil}, false
+	}
+	b, ok := c.attributes[path.Attribute]
+	return im.AttributeData{Path: path, DataVersion: c.dataVersion, Data: b}, ok
+}
+
+// DataVersionFilters returns the data versions of the cached clusters of the node which can answer
+// the specified path: the clusters caching the attribute of a concrete attribute path, or the
+// complete clusters for a wildcard attribute path.
+func (cache *AttributeCache) DataVersionFilters(node NodeID, path im.AttributePath) []im.DataVersionFilter {
+	cache.Lock()
+	defer cache.Unlock()
+	return cache.dataVersionFilters(node, path)
+}
+
+func (cache *AttributeCache) dataVersionFilters(node NodeID, path im.AttributePath) []im.DataVersionFilter {
+	filters := []im.DataVersionFilter{}
+	for key, c := range cache.clusters {
+		if key.node != node || !path.Match(im.NewAttributePath(key.endpoint, key.cluster, path.Attribute)) {
+			continue
+		}
+		if path.Attribute == im.WildcardAttributeID {
+			if !c.complete {
+				continue
+			}
+		} else if _, ok := c.attributes[path.Attribute]; !ok {
+			continue
+		}
+		filters = append(filters, im.DataVersionFilter{Endpoint: key.endpoint, Cluster: key.cluster, DataVersion: c.dataVersion})
+	}
+	sort.Slice(filters, func(i, j int) bool {
+		if filters[i].Endpoint != filters[j].Endpoint {
+			return filters[i].Endpoint < filters[j].Endpoint
+		}
+		return filters[i].Cluster < filters[j].Cluster
+	})
+	return filters
+}
+
+// Invalidate drops the cached attributes of the specified node.
+func (cache *AttributeCache) Invalidate(node NodeID) {
+	cache.Lock()
+	defer cache.Unlock()
+	for key := range cache.clusters {
+		if key.node == node {
+			delete(cache.clusters, key)
+		}
+	}
+}
+
+// isSubscribed returns true if a subscription of the cache covers the specified concrete path.
+func (cache *AttributeCache) isSubscribed(node NodeID, path im.AttributePath) bool {
+	for _, sub := range cache.subscriptions[node] {
+		if covers(sub.paths, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// Read reads the attributes of the specified path through the cache. A concrete attribute covered
+// by a subscription of the cache is returned without a read interaction. Otherwise the node is read
+// with the data version filters of the cached clusters, and the attributes of the clusters which the
+// node omits as unchanged are returned from the cache.
+func (cache *AttributeCache) Read(ctx context.Context, dev *OperationalDevice, path im.AttributePath) ([]im.AttributeData, error) {
+	node := dev.NodeID()
+	cache.Lock()
+	if !path.IsWildcard() && cache.isSubscribed(node, path) {
+		if d, ok := cache.lookup(node, path); ok {
+			cache.Unlock()
+			return []im.AttributeData{d}, nil
+		}
+	}
+	filters := cache.dataVersionFilters(node, path)
+	cache.Unlock()
+
+	data, err := dev.ReadAttributeFiltered(ctx, path, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.Lock()
+	defer cache.Unlock()
+	cache.update(node, data)
+	reported := map[cacheKey]bool{}
+	for _, d := range data {
+		key := cacheKey{node: node, endpoint: d.Path.Endpoint, cluster: d.Path.Cluster}
+		reported[key] = true
+		// The reported clusters are complete since all their attributes are read.
+		if path.Attribute == im.WildcardAttributeID {
+			cache.clusters[key].complete = true
+		}
+	}
+	for _, f := range filters {
+		key := cacheKey{node: node, endpoint: f.Endpoint, cluster: f.Cluster}
+		c, ok := cache.clusters[key]
+		if reported[key] || !ok {
+			continue
+		}
+		ids := make([]im.AttributeID, 0, len(c.attributes))
+		for id := range c.attributes {
+			if path.Match(im.NewAttributePath(f.Endpoint, f.Cluster, id)) {
+				ids = append(ids, id)
+			}
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		for _, id := range ids {
+			data = append(data, im.AttributeData{Path: im.NewAttributePath(f.Endpoint, f.Cluster, id), DataVersion: c.dataVersion, Data: c.attributes[id]})
+		}
+	}
+	return data, nil
+}
+
+// Subscribe subscribes the attributes with SubscribeShared, and keeps the cache fresh with the
+// reports until the subscription is closed. The handler, which may be nil, is called after the
+// cache is updated with each report.
+func (cache *AttributeCache) Subscribe(ctx context.Context, dev *OperationalDevice, req im.SubscribeRequest, handler im.ReportHandler) (im.Subscription, error) {
+	node := dev.NodeID()
+	sub, err := dev.SubscribeShared(ctx, req, func(data []im.AttributeData) {
+		cache.Update(node, data)
+		if handler != nil {
+			handler(data)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	cs := &cacheSubscription{
+		Subscription: sub,
+		cache:        cache,
+		node:         node,
+		paths:        append([]im.AttributePath{}, req.Paths...),
+	}
+	cache.Lock()
+	cache.subscriptions[node] = append(cache.subscriptions[node], cs)
+	cache.Unlock()
+	return cs, nil
+}
+
+// cacheSubscription represents a subscription which keeps the cache fresh.
+type cacheSubscription struct {
+	im.Subscription
+	cache *AttributeCache
+	node  NodeID
+	paths []im.AttributePath
+}
+
+// Close cancels the subscription, and the cached attributes are no longer served without reads.
+func (sub *cacheSubscription) Close() error {
+	sub.cache.Lock()
+	subs := sub.cache.subscriptions[sub.node]
+	for n, s := range subs {
+		if s == sub {
+			sub.cache.subscriptions[sub.node] = append(subs[:n], subs[n+1:]...)
+			break
+		}
+	}
+	if len(sub.cache.subscriptions[sub.node]) == 0 {
+		delete(sub.cache.subscriptions, sub.node)
+	}
+	sub.cache.Unlock()
+	return sub.Subscription.Close()
+}
@@ -0,0 +1,301 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/credential"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// 11.10. General Commissioning Cluster
+const (
+	generalCommissioningClusterID  im.ClusterID = 0x0030
+	armFailSafeCommandID           im.CommandID = 0x00
+	commissioningCompleteCommandID im.CommandID = 0x04
+)
+
+// 11.18. Node Operational Credentials Cluster
+const (
+	operationalCredentialsClusterID im.ClusterID   = 0x003E
+	nocsAttributeID                 im.AttributeID = 0x0000
+	csrRequestCommandID             im.CommandID   = 0x04
+	updateNOCCommandID              im.CommandID   = 0x07
+	nocStructNOCTag                                = 1
+	nocStructICACTag                               = 2
+	csrNonceSize                                   = 32
+)
+
+const (
+	// DefaultRotationFailSafe is the fail-safe period armed while the NOC of a node is rotated.
+	DefaultRotationFailSafe = 60 * time.Second
+	// DefaultRenewBefore is the default period before expiration in which certificates are rotated.
+	DefaultRenewBefore = 30 * 24 * time.Hour
+)
+
+// NOCIssuer represents a certificate authority which issues node operational certificates.
+type NOCIssuer interface {
+	// IssueNOC returns a new NOC and an optional ICAC for the specified node from the TLV encoded
+	// NOCSRElements which contain the CSR and the nonce sent to the node.
+	IssueNOC(ctx context.Context, peer OperationalPeer, nocsrElements []byte) (noc []byte, icac []byte, err error)
+}
+
+// OperationalCertificate reads the NOC of the node on the fabric of the accessing controller.
+func (dev *OperationalDevice) OperationalCertificate(ctx context.Context) (*credential.Certificate, error) {
+	data, err := dev.ReadAttribute(ctx, im.NewAttributePath(0, operationalCredentialsClusterID, nocsAttributeID))
+	if err != nil {
+		return nil, err
+	}
+	nodeID := dev.NodeID()
+	var found *credential.Certificate
+	for _, d := range data {
+		nocs := datatype.NewList(func() datatype.Value {
+			return datatype.NewStruct(
+				datatype.NewField(nocStructNOCTag, new(datatype.OctetString)),
+				datatype.NewField(nocStructICACTag, datatype.NewNullable(new(datatype.OctetString))))
+		})
+		if err := datatype.Decode(d.Data, nocs); err != nil {
+			return nil, err
+		}
+		for _, elem := range nocs.Elements {
+			field, _ := elem.(*datatype.Struct).LookupField(nocStructNOCTag)
+			cert, err := credential.ParseCertificate(*field.Value.(*datatype.OctetString))
+			if err != nil {
+				return nil, err
+			}
+			if cert.NodeID == nodeID || found == nil {
+				found = cert
+			}
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("NOC of node (%016X) is %w", uint64(nodeID), ErrNotFound)
+	}
+	return found, nil
+}
+
+// RotateNOC replaces the NOC of the node with a new one issued by the specified issuer
+// using UpdateNOC in a fail-safe context, and returns the new NOC. The session is
+// re-established with the new NOC before the rotation is committed, so the node reverts
+// to the previous NOC if the new one can not be used.
+func (dev *OperationalDevice) RotateNOC(ctx context.Context, issuer NOCIssuer) (*credential.Certificate, error) {
+	if err := dev.armFailSafe(ctx, DefaultRotationFailSafe); err != nil {
+		return nil, err
+	}
+	cert, err := dev.rotateNOC(ctx, issuer)
+	if err != nil {
+		// The fail-safe also expires by itself if the node can not be reached.
+		_ = dev.armFailSafe(ctx, 0)
+		return nil, err
+	}
+	return cert, nil
+}
+
+func (dev *OperationalDevice) rotateNOC(ctx context.Context, issuer NOCIssuer) (*credential.Certificate, error) {
+	nonce := make([]byte, csrNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	nonceValue := datatype.OctetString(nonce)
+	isForUpdate := datatype.Bool(true)
+	nocsrElements := datatype.OctetString{}
+	signature := datatype.OctetString{}
+	err := dev.invoke(ctx, operationalCredentialsClusterID, csrRequestCommandID,
+		datatype.NewStruct(
+			datatype.NewField(0, &nonceValue),
+			datatype.NewOptionalField(1, &isForUpdate)),
+		datatype.NewStruct(
+			datatype.NewField(0, &nocsrElements),
+			datatype.NewField(1, &signature)))
+	if err != nil {
+		return nil, err
+	}
+
+	noc, icac, err := issuer.IssueNOC(ctx, dev.Peer(), nocsrElements)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := credential.ParseCertificate(noc)
+	if err != nil {
+		return nil, err
+	}
+
+	nocValue := datatype.OctetString(noc)
+	icacValue := datatype.OctetString(icac)
+	icacField := datatype.NewOptionalField(1, &icacValue)
+	icacField.Present = 0 < len(icac)
+	status := datatype.Enum8(0)
+	fabricIndex := datatype.Uint8(0)
+	debugText := datatype.String("")
+	err = dev.invoke(ctx, operationalCredentialsClusterID, updateNOCCommandID,
+		datatype.NewStruct(datatype.NewField(0, &nocValue), icacField),
+		datatype.NewStruct(
+			datatype.NewField(0, &status),
+			datatype.NewOptionalField(1, &fabricIndex),
+			datatype.NewOptionalField(2, &debugText)))
+	if err != nil {
+		return nil, err
+	}
+	if status != 0 {
+		return nil, fmt.Errorf("UpdateNOC is %w : status (%d) %s", ErrRejected, status, debugText)
+	}
+
+	// The node presents the new NOC in new sessions, which commit the rotation.
+	dev.Lock()
+	err = dev.closeSession()
+	dev.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if err := dev.invokeCommissioning(ctx, commissioningCompleteCommandID, datatype.NewStruct()); err != nil {
+		return nil, err
+	}
+	return cert, nil
+}
+
+// armFailSafe arms the fail-safe of the node for the specified period, or disarms it with zero.
+func (dev *OperationalDevice) armFailSafe(ctx context.Context, expiry time.Duration) error {
+	seconds := datatype.Uint16(expiry / time.Second)
+	breadcrumb := datatype.Uint64(0)
+	return dev.invokeCommissioning(ctx, armFailSafeCommandID,
+		datatype.NewStruct(datatype.NewField(0, &seconds), datatype.NewField(1, &breadcrumb)))
+}
+
+// invokeCommissioning invokes the General Commissioning command whose response has an error code and a debug text.
+func (dev *OperationalDevice) invokeCommissioning(ctx context.Context, id im.CommandID, fields datatype.Value) error {
+	code := datatype.Enum8(0)
+	debugText := datatype.String("")
+	err := dev.invoke(ctx, generalCommissioningClusterID, id, fields,
+		datatype.NewStruct(datatype.NewField(0, &code), datatype.NewField(1, &debugText)))
+	if err != nil {
+		return err
+	}
+	if code != 0 {
+		return fmt.Errorf("command (0x%04X/0x%02X) is %w : error code (%d) %s", generalCommissioningClusterID, id, ErrRejected, code, debugText)
+	}
+	return nil
+}
+
+// invoke invokes the command on the root endpoint, and decodes the response fields.
+func (dev *OperationalDevice) invoke(ctx context.Context, cluster im.ClusterID, id im.CommandID, fields datatype.Value, res datatype.Value) error {
+	b, err := datatype.Encode(fields)
+	if err != nil {
+		return err
+	}
+	b, err = dev.InvokeCommand(ctx, im.NewCommandPath(0, cluster, id), b)
+	if err != nil {
+		return err
+	}
+	return datatype.Decode(b, res)
+}
+
+// CertificateMonitorOption represents a certificate monitor option.
+type CertificateMonitorOption func(*CertificateMonitor)
+
+// WithRenewBefore returns an option to set the period before expiration in which certificates are rotated.
+func WithRenewBefore(d time.Duration) CertificateMonitorOption {
+	return func(mon *CertificateMonitor) {
+		mon.renewBefore = d
+	}
+}
+
+// WithRotationHandler returns an option to set the handler which is called after each rotation
+// with the new certificate, or with the error if the rotation fails.
+func WithRotationHandler(h func(dev *OperationalDevice, cert *credential.Certificate, err error)) CertificateMonitorOption {
+	return func(mon *CertificateMonitor) {
+		mon.handler = h
+	}
+}
+
+// WithMonitorClock returns an option to set the clock of the certificate monitor.
+func WithMonitorClock(now func() time.Time) CertificateMonitorOption {
+	return func(mon *CertificateMonitor) {
+		mon.now = now
+	}
+}
+
+// CertificateMonitor checks the NOCs of commissioned nodes, and rotates them before they expire.
+// Check is called by an application scheduler at the returned times, or Run checks periodically.
+type CertificateMonitor struct {
+	issuer      NOCIssuer
+	renewBefore time.Duration
+	handler     func(dev *OperationalDevice, cert *credential.Certificate, err error)
+	now         func() time.Time
+}
+
+// NewCertificateMonitor returns a new certificate monitor which rotates NOCs with the specified issuer.
+func NewCertificateMonitor(issuer NOCIssuer, opts ...CertificateMonitorOption) *CertificateMonitor {
+	mon := &CertificateMonitor{
+		issuer:      issuer,
+		renewBefore: DefaultRenewBefore,
+		handler:     nil,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(mon)
+	}
+	return mon
+}
+
+// Check rotates the NOCs of the specified nodes which expire within the renewal period,
+// and returns the time when the next NOC is due for rotation, which is zero if no NOCs expire.
+// The returned error joins the errors of the nodes which could not be checked or rotated.
+func (mon *CertificateMonitor) Check(ctx context.Context, devs []*OperationalDevice) (time.Time, error) {
+	var next time.Time
+	errs := []error{}
+	for _, dev := range devs {
+		cert, err := dev.OperationalCertificate(ctx)
+		if err == nil && cert.ExpiresWithin(mon.now(), mon.renewBefore) {
+			cert, err = dev.RotateNOC(ctx, mon.issuer)
+			if mon.handler != nil {
+				mon.handler(dev, cert, err)
+			}
+		}
+		if err != nil {
+			errs = append(errs, nodeError(dev, err))
+			continue
+		}
+		if !cert.HasExpiration() {
+			continue
+		}
+		due := cert.NotAfter.Add(-mon.renewBefore)
+		if next.IsZero() || due.Before(next) {
+			next = due
+		}
+	}
+	return next, errors.Join(errs...)
+}
+
+// Run checks the nodes returned by the specified function at the due times, and at least
+// at the specified interval, until the context is done. Failures are reported to the rotation
+// handler, and the failed nodes are checked again after the interval.
+func (mon *CertificateMonitor) Run(ctx context.Context, devs func() []*OperationalDevice, interval time.Duration) error {
+	for {
+		next, _ := mon.Check(ctx, devs())
+		wait := interval
+		if d := next.Sub(mon.now()); !next.IsZero() && 0 < d && d < wait {
+			wait = d
+		}
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package administratorcommissioning
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// 11.19. Administrator Commissioning Cluster
+const (
+	ClusterID       im.ClusterID = 0x003C
+	ClusterRevision              = 1
+
+	WindowStatusAttributeID     im.AttributeID = 0x0000
+	AdminFabricIndexAttributeID im.AttributeID = 0x0001
+	AdminVendorIDAttributeID    im.AttributeID = 0x0002
+
+	OpenCommissioningWindowCommandID      im.CommandID = 0x00
+	OpenBasicCommissioningWindowCommandID im.CommandID = 0x01
+	RevokeCommissioningCommandID          im.CommandID = 0x02
+)
+
+// 11.19.6. Status Codes
+const (
+	StatusBusy               im.Status = 0x02
+	StatusPAKEParameterError im.Status = 0x03
+	StatusWindowNotOpen      im.Status = 0x04
+)
+
+// 11.19.8.1. OpenCommissioningWindow Command
+const (
+	// MinCommissioningTimeout is the minimum duration of a commissioning window.
+	MinCommissioningTimeout = 3 * time.Minute
+	// MaxCommissioningTimeout is the maximum duration of a commissioning window.
+	MaxCommissioningTimeout = 15 * time.Minute
+	// PAKEPasscodeVerifierSize is the size of the serialized SPAKE2+ verifier.
+	PAKEPasscodeVerifierSize = 97
+)
+
+const (
+	timeoutTag = iota
+	verifierTag
+	discriminatorTag
+	iterationsTag
+	saltTag
+)
+
+// OpenCommissioningWindowRequest represents the fields of the OpenCommissioningWindow command,
+// which opens a window of the Enhanced Commissioning Method on a commissioned node.
+type OpenCommissioningWindowRequest struct {
+	// Timeout is the duration of the window, which is rounded down to seconds.
+	Timeout time.Duration
+	// PAKEPasscodeVerifier is the serialized SPAKE2+ verifier of the new passcode.
+	PAKEPasscodeVerifier []byte
+	// Discriminator is the 12-bit discriminator which the node advertises while the window is open.
+	Discriminator types.Discriminator
+	// Iterations is the PBKDF iterations which the verifier was derived with.
+	Iterations uint32
+	// Salt is the PBKDF salt which the verifier was derived with.
+	Salt []byte
+}
+
+// Validate returns an error if the fields are out of the ranges of the command.
+func (req *OpenCommissioningWindowRequest) Validate() error {
+	if req.Timeout < MinCommissioningTimeout || MaxCommissioningTimeout < req.Timeout {
+		return fmt.Errorf("%w commissioning timeout: %s (%s..%s)", ErrInvalid, req.Timeout, MinCommissioningTimeout, MaxCommissioningTimeout)
+	}
+	if len(req.PAKEPasscodeVerifier) != PAKEPasscodeVerifierSize {
+		return fmt.Errorf("%w PAKE passcode verifier size: %d", ErrInvalid, len(req.PAKEPasscodeVerifier))
+	}
+	if req.Discriminator.IsShort() || !req.Discriminator.IsValid() {
+		return fmt.Errorf("%w discriminator: %s", ErrInvalid, req.Discriminator.HexString())
+	}
+	return nil
+}
+
+// Fields returns the command fields.
+func (req *OpenCommissioningWindowRequest) Fields() *datatype.Struct {
+	timeout := datatype.Uint16(req.Timeout / time.Second)
+	verifier := datatype.OctetString(req.PAKEPasscodeVerifier)
+	discriminator := datatype.Uint16(req.Discriminator.Value())
+	iterations := datatype.Uint32(req.Iterations)
+	salt := datatype.OctetString(req.Salt)
+	return datatype.NewStruct(
+		datatype.NewField(timeoutTag, &timeout),
+		datatype.NewField(verifierTag, &verifier),
+		datatype.NewField(discriminatorTag, &discriminator),
+		datatype.NewField(iterationsTag, &iterations),
+		datatype.NewField(saltTag, &salt))
+}
+
+// Bytes returns the TLV encoded command fields.
+func (req *OpenCommissioningWindowRequest) Bytes() ([]byte, error) {
+	return datatype.Encode(req.Fields())
+}
+
+// NewOpenCommissioningWindowRequestFromBytes returns the request decoded from the TLV encoded command fields.
+func NewOpenCommissioningWindowRequestFromBytes(b []byte) (*OpenCommissioningWindowRequest, error) {
+	timeout := new(datatype.Uint16)
+	verifier := new(datatype.OctetString)
+	discriminator := new(datatype.Uint16)
+	iterations := new(datatype.Uint32)
+	salt := new(datatype.OctetString)
+	err := datatype.Decode(b, datatype.NewStruct(
+		datatype.NewField(timeoutTag, timeout),
+		datatype.NewField(verifierTag, verifier),
+		datatype.NewField(discriminatorTag, discriminator),
+		datatype.NewField(iterationsTag, iterations),
+		datatype.NewField(saltTag, salt)))
+	if err != nil {
+		return nil, err
+	}
+	return &OpenCommissioningWindowRequest{
+		Timeout:              time.Duration(*timeout) * time.Second,
+		PAKEPasscodeVerifier: []byte(*verifier),
+		Discriminator:        types.Discriminator(*discriminator),
+		Iterations:           uint32(*iterations),
+		Salt:                 []byte(*salt),
+	}, nil
+}
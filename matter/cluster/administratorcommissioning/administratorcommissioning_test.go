@@ -0,0 +1,70 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package administratorcommissioning
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+func TestOpenCommissioningWindowRequest(t *testing.T) {
+	newRequest := func(timeout time.Duration, verifierSize int, discriminator types.Discriminator) *OpenCommissioningWindowRequest {
+		return &OpenCommissioningWindowRequest{
+			Timeout:              timeout,
+			PAKEPasscodeVerifier: bytes.Repeat([]byte{0x04}, verifierSize),
+			Discriminator:        discriminator,
+			Iterations:           1000,
+			Salt:                 bytes.Repeat([]byte{0x53}, 32),
+		}
+	}
+	tests := []struct {
+		name string
+		req  *OpenCommissioningWindowRequest
+		err  error
+	}{
+		{"valid", newRequest(MinCommissioningTimeout, PAKEPasscodeVerifierSize, 3840), nil},
+		{"max timeout", newRequest(MaxCommissioningTimeout, PAKEPasscodeVerifierSize, 0), nil},
+		{"short timeout", newRequest(MinCommissioningTimeout-time.Second, PAKEPasscodeVerifierSize, 3840), ErrInvalid},
+		{"long timeout", newRequest(MaxCommissioningTimeout+time.Second, PAKEPasscodeVerifierSize, 3840), ErrInvalid},
+		{"verifier", newRequest(MinCommissioningTimeout, PAKEPasscodeVerifierSize-1, 3840), ErrInvalid},
+		{"short discriminator", newRequest(MinCommissioningTimeout, PAKEPasscodeVerifierSize, types.NewShortDiscriminator(0xF)), ErrInvalid},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.req.Validate(); !errors.Is(err, test.err) {
+				t.Fatalf("%v is not %v", err, test.err)
+			}
+			if test.err != nil {
+				return
+			}
+			b, err := test.req.Bytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			req, err := NewOpenCommissioningWindowRequestFromBytes(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if req.Timeout != test.req.Timeout || req.Discriminator != test.req.Discriminator || req.Iterations != test.req.Iterations ||
+				!bytes.Equal(req.PAKEPasscodeVerifier, test.req.PAKEPasscodeVerifier) || !bytes.Equal(req.Salt, test.req.Salt) {
+				t.Errorf("%+v != %+v", req, test.req)
+			}
+		})
+	}
+}
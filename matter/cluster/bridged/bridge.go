@@ -0,0 +1,146 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bridged
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cybergarage/go-matter/matter/cluster/descriptor"
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// Device types of bridges in the device library.
+var (
+	AggregatorDeviceType  = datamodel.NewDeviceType(types.AggregatorDeviceType)
+	BridgedNodeDeviceType = datamodel.NewDeviceType(types.BridgedNodeDeviceType)
+)
+
+// Device represents a bridged device on a dynamic endpoint.
+type Device struct {
+	*datamodel.Endpoint
+	info *Cluster
+}
+
+// BasicInformation returns the bridged device basic information cluster.
+func (dev *Device) BasicInformation() *Cluster {
+	return dev.info
+}
+
+// SetReachable sets the reachability of the bridged device.
+func (dev *Device) SetReachable(reachable bool) {
+	dev.info.SetReachable(reachable)
+}
+
+// Bridge represents an aggregator endpoint which exposes devices of other ecosystems as bridged
+// devices on dynamic endpoints.
+type Bridge struct {
+	sync.Mutex
+	node       *datamodel.Node
+	aggregator *datamodel.Endpoint
+	devices    map[im.EndpointID]*Device
+}
+
+// NewBridge adds a new aggregator endpoint of the specified ID to the node, and returns the bridge of the endpoint.
+func NewBridge(node *datamodel.Node, id im.EndpointID) (*Bridge, error) {
+	aggregator := datamodel.NewEndpoint(id, AggregatorDeviceType)
+	if err := aggregator.AddCluster(descriptor.NewCluster(node, aggregator)); err != nil {
+		return nil, err
+	}
+	if err := node.AddEndpoint(aggregator); err != nil {
+		return nil, err
+	}
+	return &Bridge{
+		Mutex:      sync.Mutex{},
+		node:       node,
+		aggregator: aggregator,
+		devices:    map[im.EndpointID]*Device{},
+	}, nil
+}
+
+// Aggregator returns the aggregator endpoint.
+func (bridge *Bridge) Aggregator() *datamodel.Endpoint {
+	return bridge.aggregator
+}
+
+// AddDevice adds a new bridged device with the specified device types and application clusters
+// on a new dynamic endpoint. The descriptor and bridged device basic information clusters are
+// added to the endpoint, and the endpoint appears in the parts lists of the aggregator and the root.
+func (bridge *Bridge) AddDevice(info Info, deviceTypes []datamodel.DeviceType, clusters ...datamodel.Cluster) (*Device, error) {
+	id, err := bridge.node.NextEndpointID()
+	if err != nil {
+		return nil, err
+	}
+	ep := datamodel.NewEndpoint(id, append([]datamodel.DeviceType{BridgedNodeDeviceType}, deviceTypes...)...)
+	ep.SetParent(bridge.aggregator.ID())
+
+	dev := &Device{
+		Endpoint: ep,
+		info:     NewCluster(ep, info),
+	}
+	for _, cluster := range append([]datamodel.Cluster{descriptor.NewCluster(bridge.node, ep), dev.info}, clusters...) {
+		if err := ep.AddCluster(cluster); err != nil {
+			return nil, err
+		}
+	}
+
+	bridge.Lock()
+	defer bridge.Unlock()
+	if err := bridge.node.AddEndpoint(ep); err != nil {
+		return nil, err
+	}
+	bridge.devices[id] = dev
+	return dev, nil
+}
+
+// RemoveDevice removes the bridged device of the specified endpoint, and emits a Leave event.
+func (bridge *Bridge) RemoveDevice(id im.EndpointID) error {
+	bridge.Lock()
+	defer bridge.Unlock()
+	dev, ok := bridge.devices[id]
+	if !ok {
+		return fmt.Errorf("bridged device (%d) is %w", id, datamodel.ErrNotFound)
+	}
+	dev.EmitEvent(ClusterID, LeaveEventID, datamodel.InfoPriority, datatype.NewStruct())
+	if err := bridge.node.RemoveEndpoint(id); err != nil {
+		return err
+	}
+	delete(bridge.devices, id)
+	return nil
+}
+
+// LookupDevice returns the bridged device of the specified endpoint.
+func (bridge *Bridge) LookupDevice(id im.EndpointID) (*Device, bool) {
+	bridge.Lock()
+	defer bridge.Unlock()
+	dev, ok := bridge.devices[id]
+	return dev, ok
+}
+
+// Devices returns the bridged devices.
+func (bridge *Bridge) Devices() []*Device {
+	bridge.Lock()
+	defer bridge.Unlock()
+	devs := make([]*Device, 0, len(bridge.devices))
+	for _, ep := range bridge.node.PartsList(bridge.aggregator.ID()) {
+		if dev, ok := bridge.devices[ep]; ok {
+			devs = append(devs, dev)
+		}
+	}
+	return devs
+}
@@ -0,0 +1,166 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bridged
+
+import (
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/cluster/descriptor"
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+func readPartsList(t *testing.T, node *datamodel.Node, id im.EndpointID) []im.EndpointID {
+	t.Helper()
+	ep, ok := node.LookupEndpoint(id)
+	if !ok {
+		t.Fatalf("endpoint (%d) is not found", id)
+	}
+	cluster, ok := ep.LookupCluster(descriptor.ClusterID)
+	if !ok {
+		t.Fatalf("endpoint (%d) has no descriptor", id)
+	}
+	v, err := cluster.ReadAttribute(descriptor.PartsListAttributeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := []im.EndpointID{}
+	for _, elem := range v.(*datatype.List).Elements {
+		parts = append(parts, im.EndpointID(*elem.(*datatype.Uint16)))
+	}
+	return parts
+}
+
+func equalParts(parts []im.EndpointID, expected ...im.EndpointID) bool {
+	if len(parts) != len(expected) {
+		return false
+	}
+	for n := range parts {
+		if parts[n] != expected[n] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBridge(t *testing.T) {
+	node := datamodel.NewNode()
+	root := datamodel.NewEndpoint(datamodel.RootEndpointID, datamodel.DeviceType{ID: 0x0016, Revision: 2})
+	if err := root.AddCluster(descriptor.NewCluster(node, root)); err != nil {
+		t.Fatal(err)
+	}
+	if err := node.AddEndpoint(root); err != nil {
+		t.Fatal(err)
+	}
+
+	events := []*datamodel.Event{}
+	node.SetEventHandler(func(ev *datamodel.Event) {
+		events = append(events, ev)
+	})
+
+	bridge, err := NewBridge(node, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aggregatorDescriptor, _ := bridge.Aggregator().LookupCluster(descriptor.ClusterID)
+	version := aggregatorDescriptor.DataVersion()
+
+	light, err := bridge.AddDevice(Info{VendorName: "CyberGarage", ProductName: "ECHONET Lite Light", NodeLabel: "Living", UniqueID: "0123456789"}, []datamodel.DeviceType{{ID: 0x0100, Revision: 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sensor, err := bridge.AddDevice(Info{ProductName: "ECHONET Lite Sensor"}, []datamodel.DeviceType{{ID: 0x0302, Revision: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if light.ID() != 2 || sensor.ID() != 3 {
+		t.Errorf("endpoints (%d, %d) != (2, 3)", light.ID(), sensor.ID())
+	}
+	if parts := readPartsList(t, node, 0); !equalParts(parts, 1, 2, 3) {
+		t.Errorf("root parts %v", parts)
+	}
+	if parts := readPartsList(t, node, 1); !equalParts(parts, 2, 3) {
+		t.Errorf("aggregator parts %v", parts)
+	}
+	if aggregatorDescriptor.DataVersion() == version {
+		t.Error("aggregator data version is not changed")
+	}
+	if len(bridge.Devices()) != 2 {
+		t.Errorf("%d devices", len(bridge.Devices()))
+	}
+
+	// Reachability changes are reported with events only when changed.
+	light.SetReachable(false)
+	light.SetReachable(false)
+	if light.BasicInformation().Reachable() {
+		t.Error("device is reachable")
+	}
+	if len(events) != 1 || events[0].Path != im.NewEventPath(2, ClusterID, ReachableChangedEventID) {
+		t.Fatalf("events %v", events)
+	}
+
+	if err := bridge.RemoveDevice(light.ID()); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[1].Path.Event != LeaveEventID {
+		t.Errorf("events %v", events)
+	}
+	if parts := readPartsList(t, node, 1); !equalParts(parts, 3) {
+		t.Errorf("aggregator parts %v", parts)
+	}
+	if err := bridge.RemoveDevice(light.ID()); err == nil {
+		t.Error("removed device is removed again")
+	}
+
+	// Endpoint IDs of removed devices are not reused.
+	dev, err := bridge.AddDevice(Info{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dev.ID() != 4 {
+		t.Errorf("endpoint (%d) != 4", dev.ID())
+	}
+
+	// Removing the aggregator removes the bridged devices.
+	if err := node.RemoveEndpoint(bridge.Aggregator().ID()); err != nil {
+		t.Fatal(err)
+	}
+	if parts := readPartsList(t, node, 0); !equalParts(parts) {
+		t.Errorf("root parts %v", parts)
+	}
+}
+
+func TestBasicInformationCluster(t *testing.T) {
+	ep := datamodel.NewEndpoint(2)
+	cluster := NewCluster(ep, Info{VendorID: 0xFFF1, ProductName: "A product name longer than thirty-two bytes"})
+
+	v, err := cluster.ReadAttribute(ProductNameAttributeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*v.(*datatype.String)) != MaxStringLength {
+		t.Errorf("%s is not truncated", *v.(*datatype.String))
+	}
+	if _, err := cluster.ReadAttribute(SerialNumberAttributeID); im.StatusOf(err) != im.StatusUnsupportedAttribute {
+		t.Errorf("%v is not %s", err, im.StatusUnsupportedAttribute)
+	}
+	if err := cluster.WriteAttribute(NodeLabelAttributeID, []byte{0x0c, 0x03, 'B', 'e', 'd'}); err != nil {
+		t.Error(err)
+	}
+	if err := cluster.WriteAttribute(ReachableAttributeID, []byte{0x08}); im.StatusOf(err) != im.StatusUnsupportedWrite {
+		t.Errorf("%v is not %s", err, im.StatusUnsupportedWrite)
+	}
+}
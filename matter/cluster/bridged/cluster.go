@@ -0,0 +1,151 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bridged
+
+import (
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// 9.13. Bridged Device Basic Information Cluster
+const (
+	ClusterID       im.ClusterID = 0x0039
+	ClusterRevision              = 4
+
+	VendorNameAttributeID            im.AttributeID = 0x0001
+	VendorIDAttributeID              im.AttributeID = 0x0002
+	ProductNameAttributeID           im.AttributeID = 0x0003
+	NodeLabelAttributeID             im.AttributeID = 0x0005
+	HardwareVersionStringAttributeID im.AttributeID = 0x0008
+	SoftwareVersionStringAttributeID im.AttributeID = 0x000A
+	SerialNumberAttributeID          im.AttributeID = 0x000F
+	ReachableAttributeID             im.AttributeID = 0x0011
+	UniqueIDAttributeID              im.AttributeID = 0x0012
+
+	StartUpEventID          im.EventID = 0x00
+	ShutDownEventID         im.EventID = 0x01
+	LeaveEventID            im.EventID = 0x02
+	ReachableChangedEventID im.EventID = 0x03
+
+	// MaxStringLength is the maximum length of the string attributes.
+	MaxStringLength = 32
+)
+
+// Info represents the basic information of a bridged device. Empty strings and a zero vendor ID
+// are not exposed as attributes.
+type Info struct {
+	VendorName            string
+	VendorID              uint16
+	ProductName           string
+	NodeLabel             string
+	HardwareVersionString string
+	SoftwareVersionString string
+	SerialNumber          string
+	UniqueID              string
+}
+
+// Cluster represents a Bridged Device Basic Information cluster server.
+type Cluster struct {
+	*datamodel.BaseCluster
+	endpoint  *datamodel.Endpoint
+	reachable datatype.Bool
+}
+
+// NewCluster returns a new bridged device basic information cluster of the specified endpoint.
+// The bridged device is reachable initially.
+func NewCluster(ep *datamodel.Endpoint, info Info) *Cluster {
+	cluster := &Cluster{
+		BaseCluster: datamodel.NewBaseCluster(ClusterID, ClusterRevision),
+		endpoint:    ep,
+		reachable:   true,
+	}
+
+	strs := []struct {
+		id       im.AttributeID
+		v        string
+		writable bool
+	}{
+		{VendorNameAttributeID, info.VendorName, false},
+		{ProductNameAttributeID, info.ProductName, false},
+		{NodeLabelAttributeID, info.NodeLabel, true},
+		{HardwareVersionStringAttributeID, info.HardwareVersionString, false},
+		{SoftwareVersionStringAttributeID, info.SoftwareVersionString, false},
+		{SerialNumberAttributeID, info.SerialNumber, false},
+		{UniqueIDAttributeID, info.UniqueID, false},
+	}
+	for _, str := range strs {
+		if len(str.v) == 0 && !str.writable {
+			continue
+		}
+		v := datatype.String(truncate(str.v))
+		if str.writable {
+			cluster.AddAttribute(datamodel.NewWritableAttribute(str.id, &v))
+		} else {
+			cluster.AddAttribute(datamodel.NewAttribute(str.id, &v))
+		}
+	}
+	if info.VendorID != 0 {
+		vendorID := datatype.Uint16(info.VendorID)
+		cluster.AddAttribute(datamodel.NewAttribute(VendorIDAttributeID, &vendorID))
+	}
+	cluster.AddAttribute(datamodel.NewAttribute(ReachableAttributeID, &cluster.reachable))
+
+	return cluster
+}
+
+// truncate returns the string truncated to the maximum length on a UTF-8 boundary.
+func truncate(s string) string {
+	if len(s) <= MaxStringLength {
+		return s
+	}
+	n := MaxStringLength
+	for 0 < n && (s[n]&0xC0) == 0x80 {
+		n--
+	}
+	return s[:n]
+}
+
+// Reachable returns true if the bridged device is reachable.
+func (cluster *Cluster) Reachable() bool {
+	v, err := cluster.ReadAttribute(ReachableAttributeID)
+	if err != nil {
+		return false
+	}
+	return bool(*v.(*datatype.Bool))
+}
+
+// SetReachable sets the reachability of the bridged device, and emits a ReachableChanged event if it changes.
+func (cluster *Cluster) SetReachable(reachable bool) {
+	if cluster.Reachable() == reachable {
+		return
+	}
+	v := datatype.Bool(reachable)
+	cluster.SetAttribute(ReachableAttributeID, &v)
+	cluster.endpoint.EmitEvent(ClusterID, ReachableChangedEventID, datamodel.InfoPriority,
+		datatype.NewStruct(datatype.NewField(0, &v)))
+}
+
+// NotifyStartUp emits a StartUp event of the bridged device with the specified software version.
+func (cluster *Cluster) NotifyStartUp(softwareVersion uint32) {
+	v := datatype.Uint32(softwareVersion)
+	cluster.endpoint.EmitEvent(ClusterID, StartUpEventID, datamodel.CriticalPriority,
+		datatype.NewStruct(datatype.NewField(0, &v)))
+}
+
+// NotifyShutDown emits a ShutDown event of the bridged device.
+func (cluster *Cluster) NotifyShutDown() {
+	cluster.endpoint.EmitEvent(ClusterID, ShutDownEventID, datamodel.CriticalPriority, datatype.NewStruct())
+}
@@ -0,0 +1,303 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commissionercontrol
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/cluster/administratorcommissioning"
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// 9.6. Commissioner Control Cluster
+// The cluster is introduced with Fabric Synchronization in Matter 1.4, and follows the specification as it evolves.
+const (
+	ClusterID       im.ClusterID = 0x0751
+	ClusterRevision              = 1
+
+	SupportedDeviceCategoriesAttributeID im.AttributeID = 0x0000
+
+	RequestCommissioningApprovalCommandID   im.CommandID = 0x00
+	CommissionNodeCommandID                 im.CommandID = 0x01
+	ReverseOpenCommissioningWindowCommandID im.CommandID = 0x02
+
+	CommissioningRequestResultEventID im.EventID = 0x00
+
+	// MinResponseTimeout is the minimum response timeout of CommissionNode.
+	MinResponseTimeout = 30 * time.Second
+	// MaxResponseTimeout is the maximum response timeout of CommissionNode.
+	MaxResponseTimeout = 120 * time.Second
+	// MaxLabelLength is the maximum length of the label of RequestCommissioningApproval.
+	MaxLabelLength = 64
+)
+
+// DeviceCategory represents a bitmap of the device categories which the server can commission.
+type DeviceCategory uint32
+
+const (
+	// FabricSynchronizationCategory represents the devices which synchronize fabrics with the server.
+	FabricSynchronizationCategory DeviceCategory = 0x01
+)
+
+// ApprovalRequest represents a RequestCommissioningApproval command of a client.
+type ApprovalRequest struct {
+	// RequestID is the ID which the client chooses for the request.
+	RequestID uint64
+	// VendorID is the vendor ID of the device to be commissioned.
+	VendorID uint16
+	// ProductID is the product ID of the device to be commissioned.
+	ProductID uint16
+	// Label is the optional label of the device to be commissioned.
+	Label string
+	// ClientNodeID is the node ID of the client in the accessing fabric.
+	ClientNodeID types.NodeID
+	// FabricIndex is the accessing fabric of the client.
+	FabricIndex types.FabricIndex
+}
+
+// ApprovalHandler represents a handler which is called with the approval requests, which are
+// answered later with Cluster.Approve, for example after a user confirms them.
+type ApprovalHandler func(req *ApprovalRequest)
+
+// WindowOpener represents a function which opens a commissioning window on the device of an approved
+// request within the response timeout, and returns the parameters of the window.
+type WindowOpener func(req *ApprovalRequest, timeout time.Duration) (*administratorcommissioning.OpenCommissioningWindowRequest, error)
+
+type approvalKey struct {
+	fabric    types.FabricIndex
+	nodeID    types.NodeID
+	requestID uint64
+}
+
+type approval struct {
+	req      *ApprovalRequest
+	approved bool
+}
+
+// Cluster represents a Commissioner Control cluster server, which lets an administrator of another
+// fabric ask the server to commission a device into its fabric.
+type Cluster struct {
+	*datamodel.BaseCluster
+	mutex      sync.Mutex
+	endpoint   *datamodel.Endpoint
+	categories datatype.Bitmap32
+	handler    ApprovalHandler
+	opener     WindowOpener
+	approvals  map[approvalKey]*approval
+}
+
+// Option represents an option of the commissioner control cluster.
+type Option func(*Cluster)
+
+// WithSupportedDeviceCategories sets the device categories which the server can commission.
+func WithSupportedDeviceCategories(categories DeviceCategory) Option {
+	return func(cluster *Cluster) {
+		cluster.categories = datatype.Bitmap32(categories)
+	}
+}
+
+// WithApprovalHandler sets the handler of the approval requests.
+func WithApprovalHandler(handler ApprovalHandler) Option {
+	return func(cluster *Cluster) {
+		cluster.handler = handler
+	}
+}
+
+// WithWindowOpener sets the function which opens commissioning windows on the approved devices.
+func WithWindowOpener(opener WindowOpener) Option {
+	return func(cluster *Cluster) {
+		cluster.opener = opener
+	}
+}
+
+// NewCluster returns a new commissioner control cluster of the specified endpoint, which supports
+// Fabric Synchronization unless other categories are set.
+func NewCluster(ep *datamodel.Endpoint, opts ...Option) *Cluster {
+	cluster := &Cluster{
+		BaseCluster: datamodel.NewBaseCluster(ClusterID, ClusterRevision),
+		mutex:       sync.Mutex{},
+		endpoint:    ep,
+		categories:  datatype.Bitmap32(FabricSynchronizationCategory),
+		handler:     nil,
+		opener:      nil,
+		approvals:   map[approvalKey]*approval{},
+	}
+	for _, opt := range opts {
+		opt(cluster)
+	}
+	cluster.AddAttribute(datamodel.NewAttribute(SupportedDeviceCategoriesAttributeID, &cluster.categories))
+	cluster.AddCommand(RequestCommissioningApprovalCommandID, cluster.requestCommissioningApproval)
+	cluster.AddCommand(CommissionNodeCommandID, cluster.commissionNode)
+	cluster.AddGeneratedCommand(ReverseOpenCommissioningWindowCommandID)
+	return cluster
+}
+
+func (cluster *Cluster) requestCommissioningApproval(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	if req.FabricIndex == types.NoFabricIndex {
+		return nil, fmt.Errorf("%w : RequestCommissioningApproval over a PASE session", im.StatusUnsupportedAccess)
+	}
+	requestID := new(datatype.Uint64)
+	vendorID := new(datatype.Uint16)
+	productID := new(datatype.Uint16)
+	label := new(datatype.String)
+	if err := req.DecodeFields(datatype.NewStruct(
+		datatype.NewField(0, requestID),
+		datatype.NewField(1, vendorID),
+		datatype.NewField(2, productID),
+		datatype.NewOptionalField(3, label))); err != nil {
+		return nil, err
+	}
+	if MaxLabelLength < len(*label) {
+		return nil, fmt.Errorf("%w : label (%d bytes)", im.StatusConstraintError, len(*label))
+	}
+
+	approvalReq := &ApprovalRequest{
+		RequestID:    uint64(*requestID),
+		VendorID:     uint16(*vendorID),
+		ProductID:    uint16(*productID),
+		Label:        string(*label),
+		ClientNodeID: req.SourceNodeID,
+		FabricIndex:  req.FabricIndex,
+	}
+	key := approvalKey{fabric: req.FabricIndex, nodeID: req.SourceNodeID, requestID: approvalReq.RequestID}
+	cluster.mutex.Lock()
+	if _, ok := cluster.approvals[key]; ok {
+		cluster.mutex.Unlock()
+		return nil, fmt.Errorf("%w : request (%d) is pending", im.StatusBusy, approvalReq.RequestID)
+	}
+	cluster.approvals[key] = &approval{req: approvalReq, approved: false}
+	cluster.mutex.Unlock()
+
+	if cluster.handler != nil {
+		cluster.handler(approvalReq)
+	}
+	return nil, nil
+}
+
+// Approve answers the specified approval request, and emits a CommissioningRequestResult event to
+// the client. Denied requests are forgotten, and approved requests wait for CommissionNode.
+func (cluster *Cluster) Approve(req *ApprovalRequest, approved bool) error {
+	key := approvalKey{fabric: req.FabricIndex, nodeID: req.ClientNodeID, requestID: req.RequestID}
+	cluster.mutex.Lock()
+	pending, ok := cluster.approvals[key]
+	if !ok || pending.approved {
+		cluster.mutex.Unlock()
+		return fmt.Errorf("%w approval request (%d)", ErrNotFound, req.RequestID)
+	}
+	if approved {
+		pending.approved = true
+	} else {
+		delete(cluster.approvals, key)
+	}
+	cluster.mutex.Unlock()
+
+	status := im.StatusSuccess
+	if !approved {
+		status = im.StatusFailure
+	}
+	requestID := datatype.Uint64(req.RequestID)
+	clientNodeID := datatype.Uint64(req.ClientNodeID)
+	statusCode := datatype.Enum8(status)
+	fabricIndex := datatype.Uint8(req.FabricIndex)
+	cluster.endpoint.EmitEvent(ClusterID, CommissioningRequestResultEventID, datamodel.InfoPriority, datatype.NewStruct(
+		datatype.NewField(0, &requestID),
+		datatype.NewField(1, &clientNodeID),
+		datatype.NewField(2, &statusCode),
+		datatype.NewField(datamodel.FabricIndexFieldID, &fabricIndex)))
+	return nil
+}
+
+func (cluster *Cluster) commissionNode(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	if req.FabricIndex == types.NoFabricIndex {
+		return nil, fmt.Errorf("%w : CommissionNode over a PASE session", im.StatusUnsupportedAccess)
+	}
+	requestID := new(datatype.Uint64)
+	timeoutSeconds := new(datatype.Uint16)
+	if err := req.DecodeFields(datatype.NewStruct(
+		datatype.NewField(0, requestID),
+		datatype.NewField(1, timeoutSeconds))); err != nil {
+		return nil, err
+	}
+	timeout := time.Duration(*timeoutSeconds) * time.Second
+	if timeout < MinResponseTimeout || MaxResponseTimeout < timeout {
+		return nil, fmt.Errorf("%w : response timeout (%s)", im.StatusConstraintError, timeout)
+	}
+
+	// Approvals are used once whether the window opens or not.
+	key := approvalKey{fabric: req.FabricIndex, nodeID: req.SourceNodeID, requestID: uint64(*requestID)}
+	cluster.mutex.Lock()
+	pending, ok := cluster.approvals[key]
+	if ok && pending.approved {
+		delete(cluster.approvals, key)
+	}
+	cluster.mutex.Unlock()
+	if !ok || !pending.approved {
+		return nil, fmt.Errorf("%w : request (%d) is not approved", im.StatusFailure, *requestID)
+	}
+	if cluster.opener == nil {
+		return nil, fmt.Errorf("%w : no commissioning window opener", im.StatusFailure)
+	}
+
+	window, err := cluster.opener(pending.req, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("%w : %w", im.StatusFailure, err)
+	}
+	if err := window.Validate(); err != nil {
+		return nil, fmt.Errorf("%w : %w", im.StatusFailure, err)
+	}
+	return datamodel.NewCommandResponse(ReverseOpenCommissioningWindowCommandID, window.Fields()), nil
+}
+
+// RemoveFabric removes the approval requests of the specified fabric.
+func (cluster *Cluster) RemoveFabric(fabric types.FabricIndex) {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	for key := range cluster.approvals {
+		if key.fabric == fabric {
+			delete(cluster.approvals, key)
+		}
+	}
+}
+
+// NewRequestCommissioningApprovalFields returns the fields of RequestCommissioningApproval. The label is
+// omitted if it is empty.
+func NewRequestCommissioningApprovalFields(requestID uint64, vendorID uint16, productID uint16, label string) *datatype.Struct {
+	requestIDValue := datatype.Uint64(requestID)
+	vendorIDValue := datatype.Uint16(vendorID)
+	productIDValue := datatype.Uint16(productID)
+	fields := datatype.NewStruct(
+		datatype.NewField(0, &requestIDValue),
+		datatype.NewField(1, &vendorIDValue),
+		datatype.NewField(2, &productIDValue))
+	if label != "" {
+		labelValue := datatype.String(label)
+		fields.Fields = append(fields.Fields, datatype.NewField(3, &labelValue))
+	}
+	return fields
+}
+
+// NewCommissionNodeFields returns the fields of CommissionNode.
+func NewCommissionNodeFields(requestID uint64, timeout time.Duration) *datatype.Struct {
+	requestIDValue := datatype.Uint64(requestID)
+	timeoutValue := datatype.Uint16(timeout / time.Second)
+	return datatype.NewStruct(
+		datatype.NewField(0, &requestIDValue),
+		datatype.NewField(1, &timeoutValue))
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commissionercontrol
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datamodel/datamodeltest"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+func TestCommissioningApproval(t *testing.T) {
+	node := datamodel.NewNode()
+	ep := datamodel.NewEndpoint(1)
+	if err := node.AddEndpoint(ep); err != nil {
+		t.Fatal(err)
+	}
+	events := []*datamodel.Event{}
+	node.SetEventHandler(func(ev *datamodel.Event) {
+		events = append(events, ev)
+	})
+	requests := []*ApprovalRequest{}
+	cluster := NewCluster(ep, WithApprovalHandler(func(req *ApprovalRequest) {
+		requests = append(requests, req)
+	}))
+
+	v, err := cluster.ReadAttribute(SupportedDeviceCategoriesAttributeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if DeviceCategory(*v.(*datatype.Bitmap32)) != FabricSynchronizationCategory {
+		t.Errorf("supported device categories (0x%X)", *v.(*datatype.Bitmap32))
+	}
+
+	errTests := []struct {
+		id       im.CommandID
+		fabric   types.FabricIndex
+		fields   *datatype.Struct
+		expected error
+	}{
+		{RequestCommissioningApprovalCommandID, types.NoFabricIndex, NewRequestCommissioningApprovalFields(1, 0xFFF1, 0x8000, ""), im.StatusUnsupportedAccess},
+		{RequestCommissioningApprovalCommandID, 1, NewRequestCommissioningApprovalFields(1, 0xFFF1, 0x8000, string(make([]byte, MaxLabelLength+1))), im.StatusConstraintError},
+		{CommissionNodeCommandID, 1, NewCommissionNodeFields(1, MinResponseTimeout-time.Second), im.StatusConstraintError},
+		{CommissionNodeCommandID, 1, NewCommissionNodeFields(1, MaxResponseTimeout), im.StatusFailure},
+	}
+	for _, test := range errTests {
+		if _, err := datamodeltest.Invoke(t, cluster, 1, test.id, test.fabric, test.fields); !errors.Is(err, test.expected) {
+			t.Errorf("%v is not %v", err, test.expected)
+		}
+	}
+
+	for _, requestID := range []uint64{1, 2} {
+		if _, err := datamodeltest.Invoke(t, cluster, 1, RequestCommissioningApprovalCommandID, 1, NewRequestCommissioningApprovalFields(requestID, 0xFFF1, 0x8000, "bridge")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := datamodeltest.Invoke(t, cluster, 1, RequestCommissioningApprovalCommandID, 1, NewRequestCommissioningApprovalFields(1, 0xFFF1, 0x8000, "")); !errors.Is(err, im.StatusBusy) {
+		t.Errorf("%v is not %v", err, im.StatusBusy)
+	}
+	if len(requests) != 2 || requests[0].ClientNodeID != 0x1234 || requests[0].FabricIndex != 1 || requests[0].Label != "bridge" {
+		t.Fatalf("requests %v", requests)
+	}
+
+	// Denied requests are forgotten.
+	if err := cluster.Approve(requests[0], false); err != nil {
+		t.Fatal(err)
+	}
+	if err := cluster.Approve(requests[0], true); !errors.Is(err, ErrNotFound) {
+		t.Errorf("%v is not %v", err, ErrNotFound)
+	}
+	// Approved requests need a window opener.
+	if err := cluster.Approve(requests[1], true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := datamodeltest.Invoke(t, cluster, 1, CommissionNodeCommandID, 1, NewCommissionNodeFields(2, MinResponseTimeout)); !errors.Is(err, im.StatusFailure) {
+		t.Errorf("%v is not %v", err, im.StatusFailure)
+	}
+
+	statuses := []im.Status{im.StatusFailure, im.StatusSuccess}
+	if len(events) != len(statuses) {
+		t.Fatalf("events %v", events)
+	}
+	for n, ev := range events {
+		if ev.Path.Event != CommissioningRequestResultEventID {
+			t.Errorf("event (0x%02X)", ev.Path.Event)
+		}
+		field, ok := ev.Data.(*datatype.Struct).LookupField(2)
+		if !ok || im.Status(*field.Value.(*datatype.Enum8)) != statuses[n] {
+			t.Errorf("event %d status %v", n, field)
+		}
+	}
+}
+
+func TestRemoveFabric(t *testing.T) {
+	cluster := NewCluster(datamodel.NewEndpoint(1))
+	if _, err := datamodeltest.Invoke(t, cluster, 1, RequestCommissioningApprovalCommandID, 1, NewRequestCommissioningApprovalFields(1, 0xFFF1, 0x8000, "")); err != nil {
+		t.Fatal(err)
+	}
+	cluster.RemoveFabric(1)
+	req := &ApprovalRequest{RequestID: 1, ClientNodeID: 0x1234, FabricIndex: 1}
+	if err := cluster.Approve(req, true); !errors.Is(err, ErrNotFound) {
+		t.Errorf("%v is not %v", err, ErrNotFound)
+	}
+}
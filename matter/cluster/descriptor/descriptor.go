@@ -0,0 +1,143 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptor
+
+import (
+	"bytes"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// 9.5. Descriptor Cluster
+const (
+	ClusterID       im.ClusterID = 0x001D
+	ClusterRevision              = 2
+
+	DeviceTypeListAttributeID im.AttributeID = 0x0000
+	ServerListAttributeID     im.AttributeID = 0x0001
+	ClientListAttributeID     im.AttributeID = 0x0002
+	PartsListAttributeID      im.AttributeID = 0x0003
+
+	deviceTypeTag = 0
+	revisionTag   = 1
+)
+
+// Cluster represents a Descriptor cluster server, which describes the device types, the clusters
+// and the parts of an endpoint. The lists are updated whenever they are read, so they follow the
+// endpoints added to and removed from the node at runtime.
+type Cluster struct {
+	*datamodel.BaseCluster
+	node     *datamodel.Node
+	endpoint *datamodel.Endpoint
+}
+
+// NewCluster returns a new descriptor cluster of the specified endpoint on the node.
+func NewCluster(node *datamodel.Node, ep *datamodel.Endpoint) *Cluster {
+	cluster := &Cluster{
+		BaseCluster: datamodel.NewBaseCluster(ClusterID, ClusterRevision),
+		node:        node,
+		endpoint:    ep,
+	}
+	cluster.AddAttribute(datamodel.NewAttribute(DeviceTypeListAttributeID, cluster.deviceTypeList()))
+	cluster.AddAttribute(datamodel.NewAttribute(ServerListAttributeID, cluster.serverList()))
+	cluster.AddAttribute(datamodel.NewAttribute(ClientListAttributeID, newIDList()))
+	cluster.AddAttribute(datamodel.NewAttribute(PartsListAttributeID, cluster.partsList()))
+	return cluster
+}
+
+// ReadAttribute returns the value of the specified attribute after updating the lists.
+func (cluster *Cluster) ReadAttribute(id im.AttributeID) (datatype.Value, error) {
+	cluster.Update()
+	return cluster.BaseCluster.ReadAttribute(id)
+}
+
+// DataVersion returns the data version after updating the lists.
+func (cluster *Cluster) DataVersion() im.DataVersion {
+	cluster.Update()
+	return cluster.BaseCluster.DataVersion()
+}
+
+// Update updates the lists, and changes the data version only if a list is changed.
+func (cluster *Cluster) Update() {
+	cluster.updateAttribute(DeviceTypeListAttributeID, cluster.deviceTypeList())
+	cluster.updateAttribute(ServerListAttributeID, cluster.serverList())
+	cluster.updateAttribute(PartsListAttributeID, cluster.partsList())
+}
+
+func (cluster *Cluster) updateAttribute(id im.AttributeID, v datatype.Value) {
+	current, err := cluster.BaseCluster.ReadAttribute(id)
+	if err != nil {
+		return
+	}
+	currentBytes, err := datatype.Encode(current)
+	if err != nil {
+		return
+	}
+	newBytes, err := datatype.Encode(v)
+	if err != nil || bytes.Equal(currentBytes, newBytes) {
+		return
+	}
+	cluster.SetAttribute(id, v)
+}
+
+func (cluster *Cluster) deviceTypeList() *datatype.List {
+	list := datatype.NewList(func() datatype.Value {
+		return datatype.NewStruct(
+			datatype.NewField(deviceTypeTag, new(datatype.Uint32)),
+			datatype.NewField(revisionTag, new(datatype.Uint16)))
+	})
+	for _, dt := range cluster.endpoint.DeviceTypes() {
+		id := datatype.Uint32(dt.ID)
+		revision := datatype.Uint16(dt.Revision)
+		if revision == 0 {
+			revision = datatype.Uint16(datamodel.NewDeviceType(dt.ID).Revision)
+		}
+		list.Elements = append(list.Elements, datatype.NewStruct(
+			datatype.NewField(deviceTypeTag, &id),
+			datatype.NewField(revisionTag, &revision)))
+	}
+	return list
+}
+
+func (cluster *Cluster) serverList() *datatype.List {
+	ids := []uint32{}
+	for _, c := range cluster.endpoint.Clusters() {
+		ids = append(ids, uint32(c.ID()))
+	}
+	if _, ok := cluster.endpoint.LookupCluster(ClusterID); !ok {
+		ids = append(ids, uint32(ClusterID))
+	}
+	return newIDList(ids...)
+}
+
+func (cluster *Cluster) partsList() *datatype.List {
+	list := datatype.NewList(func() datatype.Value { return new(datatype.Uint16) })
+	for _, id := range cluster.node.PartsList(cluster.endpoint.ID()) {
+		v := datatype.Uint16(id)
+		list.Elements = append(list.Elements, &v)
+	}
+	return list
+}
+
+func newIDList(ids ...uint32) *datatype.List {
+	list := datatype.NewList(func() datatype.Value { return new(datatype.Uint32) })
+	for _, id := range ids {
+		v := datatype.Uint32(id)
+		list.Elements = append(list.Elements, &v)
+	}
+	return list
+}
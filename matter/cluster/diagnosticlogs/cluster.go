@@ -0,0 +1,264 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnosticlogs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/bdx"
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// 11.11. Diagnostic Logs Cluster
+const (
+	ClusterID       im.ClusterID = 0x0032
+	ClusterRevision              = 1
+
+	RetrieveLogsRequestCommandID  im.CommandID = 0x00
+	RetrieveLogsResponseCommandID im.CommandID = 0x01
+
+	// MaxLogContentSize is the maximum size of the logs in RetrieveLogsResponse.
+	MaxLogContentSize = 1024
+	// MaxFileDesignatorSize is the maximum size of the transfer file designator.
+	MaxFileDesignatorSize = 32
+	// DefaultTransferTimeout is the default timeout of BDX transfers of logs.
+	DefaultTransferTimeout = 5 * time.Minute
+)
+
+// Intent represents the kind of the requested logs.
+type Intent uint8
+
+const (
+	EndUserSupportIntent Intent = 0x00
+	NetworkDiagIntent    Intent = 0x01
+	CrashLogsIntent      Intent = 0x02
+)
+
+// IsValid returns true if the intent is defined.
+func (intent Intent) IsValid() bool {
+	return intent <= CrashLogsIntent
+}
+
+// TransferProtocol represents the protocol which the logs are requested with.
+type TransferProtocol uint8
+
+const (
+	ResponsePayloadProtocol TransferProtocol = 0x00
+	BDXProtocol             TransferProtocol = 0x01
+)
+
+// IsValid returns true if the protocol is defined.
+func (protocol TransferProtocol) IsValid() bool {
+	return protocol <= BDXProtocol
+}
+
+// Status represents the status of RetrieveLogsResponse.
+type Status uint8
+
+const (
+	SuccessStatus   Status = 0x00
+	ExhaustedStatus Status = 0x01
+	NoLogsStatus    Status = 0x02
+	BusyStatus      Status = 0x03
+	DeniedStatus    Status = 0x04
+)
+
+// LogProvider represents a function which returns the logs of the specified intent, which are empty if there are no logs.
+type LogProvider func(intent Intent) ([]byte, error)
+
+// BDXInitiator represents a function which opens a BDX exchange to the node which requests the logs.
+type BDXInitiator func(ctx context.Context, req *datamodel.CommandRequest) (bdx.Conn, error)
+
+// Cluster represents a Diagnostic Logs cluster server. Logs which do not fit in the response are
+// sent with BDX if the client requests it and a BDX initiator is set, and are truncated otherwise.
+type Cluster struct {
+	*datamodel.BaseCluster
+	mutex        sync.Mutex
+	provider     LogProvider
+	initiator    BDXInitiator
+	timeout      time.Duration
+	transferring bool
+}
+
+// Option represents an option of the diagnostic logs cluster.
+type Option func(*Cluster)
+
+// WithLogProvider sets the provider of the logs.
+func WithLogProvider(provider LogProvider) Option {
+	return func(cluster *Cluster) {
+		cluster.provider = provider
+	}
+}
+
+// WithBDXInitiator sets the function which opens BDX exchanges to the requesting nodes.
+func WithBDXInitiator(initiator BDXInitiator) Option {
+	return func(cluster *Cluster) {
+		cluster.initiator = initiator
+	}
+}
+
+// WithTransferTimeout sets the timeout of BDX transfers.
+func WithTransferTimeout(d time.Duration) Option {
+	return func(cluster *Cluster) {
+		if 0 < d {
+			cluster.timeout = d
+		}
+	}
+}
+
+// NewCluster returns a new diagnostic logs cluster which has no logs unless a log provider is set.
+func NewCluster(opts ...Option) *Cluster {
+	cluster := &Cluster{
+		BaseCluster:  datamodel.NewBaseCluster(ClusterID, ClusterRevision),
+		mutex:        sync.Mutex{},
+		provider:     nil,
+		initiator:    nil,
+		timeout:      DefaultTransferTimeout,
+		transferring: false,
+	}
+	for _, opt := range opts {
+		opt(cluster)
+	}
+	cluster.AddCommand(RetrieveLogsRequestCommandID, cluster.retrieveLogs)
+	cluster.AddGeneratedCommand(RetrieveLogsResponseCommandID)
+	return cluster
+}
+
+func (cluster *Cluster) retrieveLogs(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	intent := new(datatype.Enum8)
+	protocol := new(datatype.Enum8)
+	designator := new(datatype.String)
+	designatorField := datatype.NewOptionalField(2, designator)
+	if err := req.DecodeFields(datatype.NewStruct(
+		datatype.NewField(0, intent),
+		datatype.NewField(1, protocol),
+		designatorField)); err != nil {
+		return nil, err
+	}
+	if !Intent(*intent).IsValid() || !TransferProtocol(*protocol).IsValid() {
+		return nil, fmt.Errorf("%w : intent (%d) protocol (%d)", im.StatusConstraintError, *intent, *protocol)
+	}
+	if MaxFileDesignatorSize < len(*designator) {
+		return nil, fmt.Errorf("%w : file designator (%d bytes)", im.StatusConstraintError, len(*designator))
+	}
+	if TransferProtocol(*protocol) == BDXProtocol && !designatorField.Present {
+		return nil, fmt.Errorf("%w : BDX without file designator", im.StatusInvalidCommand)
+	}
+
+	var logs []byte
+	if cluster.provider != nil {
+		var err error
+		logs, err = cluster.provider(Intent(*intent))
+		if err != nil {
+			return nil, fmt.Errorf("%w : %w", im.StatusFailure, err)
+		}
+	}
+	if len(logs) == 0 {
+		return newRetrieveLogsResponse(NoLogsStatus, nil), nil
+	}
+	if len(logs) <= MaxLogContentSize || TransferProtocol(*protocol) != BDXProtocol || cluster.initiator == nil {
+		if MaxLogContentSize < len(logs) {
+			return newRetrieveLogsResponse(ExhaustedStatus, logs[:MaxLogContentSize]), nil
+		}
+		return newRetrieveLogsResponse(SuccessStatus, logs), nil
+	}
+	return cluster.transferLogs(req, []byte(*designator), logs)
+}
+
+// transferLogs sends the logs with BDX, and responds after the transfer ends.
+func (cluster *Cluster) transferLogs(req *datamodel.CommandRequest, designator []byte, logs []byte) (*datamodel.CommandResponse, error) {
+	cluster.mutex.Lock()
+	if cluster.transferring {
+		cluster.mutex.Unlock()
+		return newRetrieveLogsResponse(BusyStatus, nil), nil
+	}
+	cluster.transferring = true
+	cluster.mutex.Unlock()
+	defer func() {
+		cluster.mutex.Lock()
+		cluster.transferring = false
+		cluster.mutex.Unlock()
+	}()
+
+	ctx, cancel := context.WithTimeout(req.Context(), cluster.timeout)
+	defer cancel()
+	conn, err := cluster.initiator(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%w : %w", im.StatusFailure, err)
+	}
+	if err := bdx.Send(ctx, conn, designator, bytes.NewReader(logs)); err != nil {
+		var status bdx.Status
+		if errors.As(err, &status) {
+			return newRetrieveLogsResponse(DeniedStatus, nil), nil
+		}
+		return nil, fmt.Errorf("%w : %w", im.StatusFailure, err)
+	}
+	return newRetrieveLogsResponse(SuccessStatus, nil), nil
+}
+
+func newRetrieveLogsResponse(status Status, content []byte) *datamodel.CommandResponse {
+	statusValue := datatype.Enum8(status)
+	contentValue := datatype.OctetString(content)
+	return datamodel.NewCommandResponse(RetrieveLogsResponseCommandID, datatype.NewStruct(
+		datatype.NewField(0, &statusValue),
+		datatype.NewField(1, &contentValue)))
+}
+
+// NewRetrieveLogsRequestFields returns the fields of RetrieveLogsRequest. The file designator is
+// omitted if it is empty.
+func NewRetrieveLogsRequestFields(intent Intent, protocol TransferProtocol, designator string) *datatype.Struct {
+	intentValue := datatype.Enum8(intent)
+	protocolValue := datatype.Enum8(protocol)
+	fields := datatype.NewStruct(
+		datatype.NewField(0, &intentValue),
+		datatype.NewField(1, &protocolValue))
+	if designator != "" {
+		designatorValue := datatype.String(designator)
+		fields.Fields = append(fields.Fields, datatype.NewField(2, &designatorValue))
+	}
+	return fields
+}
+
+// RetrieveLogsResponse represents the fields of RetrieveLogsResponse.
+type RetrieveLogsResponse struct {
+	// Status is the status of the request.
+	Status Status
+	// LogContent is the logs, which are empty if they are sent with BDX.
+	LogContent []byte
+}
+
+// NewRetrieveLogsResponseFromBytes returns the response decoded from the TLV encoded fields.
+func NewRetrieveLogsResponseFromBytes(b []byte) (*RetrieveLogsResponse, error) {
+	status := new(datatype.Enum8)
+	content := new(datatype.OctetString)
+	if err := datatype.Decode(b, datatype.NewStruct(
+		datatype.NewField(0, status),
+		datatype.NewField(1, content),
+		datatype.NewOptionalField(2, new(datatype.EpochUs)),
+		datatype.NewOptionalField(3, new(datatype.Uint64)))); err != nil {
+		return nil, err
+	}
+	return &RetrieveLogsResponse{
+		Status:     Status(*status),
+		LogContent: []byte(*content),
+	}, nil
+}
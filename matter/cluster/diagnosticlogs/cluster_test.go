@@ -0,0 +1,89 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnosticlogs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/bdx"
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datamodel/datamodeltest"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+func retrieveLogs(t *testing.T, cluster *Cluster, fields *datatype.Struct) (*RetrieveLogsResponse, error) {
+	t.Helper()
+	res, err := datamodeltest.Invoke(t, cluster, datamodel.RootEndpointID, RetrieveLogsRequestCommandID, 1, fields)
+	if err != nil {
+		return nil, err
+	}
+	b, err := datatype.Encode(res.Fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewRetrieveLogsResponseFromBytes(b)
+}
+
+func TestRetrieveLogs(t *testing.T) {
+	logs := bytes.Repeat([]byte{0x5A}, MaxLogContentSize+1)
+	cluster := NewCluster(
+		WithLogProvider(func(intent Intent) ([]byte, error) {
+			if intent == CrashLogsIntent {
+				return nil, nil
+			}
+			return logs, nil
+		}),
+		WithBDXInitiator(func(ctx context.Context, req *datamodel.CommandRequest) (bdx.Conn, error) {
+			return nil, errors.New("no exchange")
+		}))
+
+	errTests := []struct {
+		fields   *datatype.Struct
+		expected error
+	}{
+		{NewRetrieveLogsRequestFields(Intent(3), ResponsePayloadProtocol, ""), im.StatusConstraintError},
+		{NewRetrieveLogsRequestFields(EndUserSupportIntent, TransferProtocol(2), ""), im.StatusConstraintError},
+		{NewRetrieveLogsRequestFields(EndUserSupportIntent, BDXProtocol, string(bytes.Repeat([]byte("a"), MaxFileDesignatorSize+1))), im.StatusConstraintError},
+		{NewRetrieveLogsRequestFields(EndUserSupportIntent, BDXProtocol, ""), im.StatusInvalidCommand},
+		{NewRetrieveLogsRequestFields(EndUserSupportIntent, BDXProtocol, "logs"), im.StatusFailure},
+	}
+	for _, test := range errTests {
+		if _, err := retrieveLogs(t, cluster, test.fields); !errors.Is(err, test.expected) {
+			t.Errorf("%v is not %v", err, test.expected)
+		}
+	}
+
+	tests := []struct {
+		intent   Intent
+		status   Status
+		expected []byte
+	}{
+		{EndUserSupportIntent, ExhaustedStatus, logs[:MaxLogContentSize]},
+		{CrashLogsIntent, NoLogsStatus, nil},
+	}
+	for _, test := range tests {
+		res, err := retrieveLogs(t, cluster, NewRetrieveLogsRequestFields(test.intent, ResponsePayloadProtocol, ""))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Status != test.status || !bytes.Equal(res.LogContent, test.expected) {
+			t.Errorf("intent %d : status %d (%d bytes)", test.intent, res.Status, len(res.LogContent))
+		}
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generaldiagnostics
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/storage"
+)
+
+// 11.11. General Diagnostics Cluster
+const (
+	ClusterID       im.ClusterID = 0x0033
+	ClusterRevision              = 2
+
+	NetworkInterfacesAttributeID        im.AttributeID = 0x0000
+	RebootCountAttributeID              im.AttributeID = 0x0001
+	UpTimeAttributeID                   im.AttributeID = 0x0002
+	BootReasonAttributeID               im.AttributeID = 0x0004
+	TestEventTriggersEnabledAttributeID im.AttributeID = 0x0008
+
+	TestEventTriggerCommandID     im.CommandID = 0x00
+	TimeSnapshotCommandID         im.CommandID = 0x01
+	TimeSnapshotResponseCommandID im.CommandID = 0x02
+
+	BootReasonEventID im.EventID = 0x03
+
+	// MaxNetworkInterfaces is the maximum number of network interfaces in NetworkInterfaces.
+	MaxNetworkInterfaces = 8
+	// TestEventTriggerKeySize is the size of the enable key of test event triggers.
+	TestEventTriggerKeySize = 16
+
+	// Namespace is the storage namespace of the diagnostics which are kept across reboots.
+	Namespace      = "diagnostics"
+	rebootCountKey = "reboot-count"
+)
+
+// BootReason represents a reason of the last reboot.
+type BootReason uint8
+
+const (
+	BootReasonUnspecified             BootReason = 0x00
+	BootReasonPowerOnReboot           BootReason = 0x01
+	BootReasonBrownOutReset           BootReason = 0x02
+	BootReasonSoftwareWatchdogReset   BootReason = 0x03
+	BootReasonHardwareWatchdogReset   BootReason = 0x04
+	BootReasonSoftwareUpdateCompleted BootReason = 0x05
+	BootReasonSoftwareReset           BootReason = 0x06
+)
+
+// TestEventTriggerHandler is called when a client requests the specified test event trigger with the
+// valid enable key. It returns an error carrying StatusInvalidCommand for unsupported triggers.
+type TestEventTriggerHandler func(trigger uint64) error
+
+// Cluster represents a General Diagnostics cluster server.
+type Cluster struct {
+	*datamodel.BaseCluster
+	mutex          sync.Mutex
+	endpoint       *datamodel.Endpoint
+	store          storage.Store
+	bootTime       time.Time
+	bootReason     *BootReason
+	interfaces     InterfaceReader
+	enableKey      []byte
+	triggerHandler TestEventTriggerHandler
+}
+
+// Option represents an option of the general diagnostics cluster.
+type Option func(*Cluster)
+
+// WithStore sets the store which keeps the reboot count.
+func WithStore(store storage.Store) Option {
+	return func(cluster *Cluster) {
+		cluster.store = store
+	}
+}
+
+// WithBootReason enables the BootReason attribute of the specified reason.
+func WithBootReason(reason BootReason) Option {
+	return func(cluster *Cluster) {
+		cluster.bootReason = &reason
+	}
+}
+
+// WithInterfaceReader sets the reader of the network interfaces, which is ReadNetworkInterfaces by default.
+func WithInterfaceReader(reader InterfaceReader) Option {
+	return func(cluster *Cluster) {
+		cluster.interfaces = reader
+	}
+}
+
+// WithTestEventTrigger enables the test event triggers which are requested with the specified enable key.
+func WithTestEventTrigger(enableKey []byte, handler TestEventTriggerHandler) Option {
+	return func(cluster *Cluster) {
+		cluster.enableKey = enableKey
+		cluster.triggerHandler = handler
+	}
+}
+
+// NewCluster returns a new general diagnostics cluster on the specified endpoint. The reboot count in
+// the store is incremented, so the cluster should be created once per boot.
+func NewCluster(ep *datamodel.Endpoint, opts ...Option) (*Cluster, error) {
+	cluster := &Cluster{
+		BaseCluster:    datamodel.NewBaseCluster(ClusterID, ClusterRevision),
+		mutex:          sync.Mutex{},
+		endpoint:       ep,
+		store:          nil,
+		bootTime:       time.Now(),
+		bootReason:     nil,
+		interfaces:     ReadNetworkInterfaces,
+		enableKey:      nil,
+		triggerHandler: nil,
+	}
+	for _, opt := range opts {
+		opt(cluster)
+	}
+
+	if cluster.enableKey != nil && (len(cluster.enableKey) != TestEventTriggerKeySize || isZero(cluster.enableKey)) {
+		return nil, fmt.Errorf("%w test event trigger enable key (%X)", datamodel.ErrInvalid, cluster.enableKey)
+	}
+
+	rebootCount, err := cluster.incrementRebootCount()
+	if err != nil {
+		return nil, err
+	}
+
+	triggersEnabled := datatype.Bool(cluster.enableKey != nil)
+	cluster.AddAttribute(datamodel.NewAttribute(NetworkInterfacesAttributeID, newNetworkInterfaceList(nil)))
+	cluster.AddAttribute(datamodel.NewAttribute(RebootCountAttributeID, &rebootCount))
+	cluster.AddAttribute(datamodel.NewAttribute(UpTimeAttributeID, new(datatype.Uint64)))
+	if cluster.bootReason != nil {
+		bootReason := datatype.Enum8(*cluster.bootReason)
+		cluster.AddAttribute(datamodel.NewAttribute(BootReasonAttributeID, &bootReason))
+	}
+	cluster.AddAttribute(datamodel.NewAttribute(TestEventTriggersEnabledAttributeID, &triggersEnabled))
+	cluster.AddCommand(TestEventTriggerCommandID, cluster.testEventTrigger)
+	cluster.AddCommand(TimeSnapshotCommandID, cluster.timeSnapshot)
+	cluster.AddGeneratedCommand(TimeSnapshotResponseCommandID)
+
+	return cluster, nil
+}
+
+func isZero(b []byte) bool {
+	return bytes.Equal(b, make([]byte, len(b)))
+}
+
+// incrementRebootCount returns the reboot count after incrementing it in the store.
+func (cluster *Cluster) incrementRebootCount() (datatype.Uint16, error) {
+	if cluster.store == nil {
+		return 0, nil
+	}
+	count := uint16(0)
+	b, err := cluster.store.Get(Namespace, rebootCountKey)
+	switch {
+	case err == nil:
+		if len(b) != 2 {
+			return 0, fmt.Errorf("%w persisted reboot count : %X", datamodel.ErrInvalid, b)
+		}
+		count = binary.BigEndian.Uint16(b)
+	case errors.Is(err, storage.ErrNotFound):
+	default:
+		return 0, err
+	}
+	if count < math.MaxUint16 {
+		count++
+	}
+	b = binary.BigEndian.AppendUint16(nil, count)
+	if err := cluster.store.Set(Namespace, rebootCountKey, b); err != nil {
+		return 0, err
+	}
+	return datatype.Uint16(count), nil
+}
+
+// UpTime returns the time since the cluster was created.
+func (cluster *Cluster) UpTime() time.Duration {
+	return time.Since(cluster.bootTime)
+}
+
+// NotifyBoot emits a BootReason event. The device calls it after the endpoint is added to the node.
+func (cluster *Cluster) NotifyBoot() {
+	reason := datatype.Enum8(BootReasonUnspecified)
+	if cluster.bootReason != nil {
+		reason = datatype.Enum8(*cluster.bootReason)
+	}
+	cluster.endpoint.EmitEvent(ClusterID, BootReasonEventID, datamodel.CriticalPriority,
+		datatype.NewStruct(datatype.NewField(0, &reason)))
+}
+
+// Update reads the network interfaces, and changes the data version only if they are changed.
+func (cluster *Cluster) Update() error {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	nis, err := cluster.interfaces()
+	if err != nil {
+		return err
+	}
+	list := newNetworkInterfaceList(nis)
+	current, err := cluster.BaseCluster.ReadAttribute(NetworkInterfacesAttributeID)
+	if err != nil {
+		return err
+	}
+	currentBytes, err := datatype.Encode(current)
+	if err != nil {
+		return err
+	}
+	newBytes, err := datatype.Encode(list)
+	if err != nil || bytes.Equal(currentBytes, newBytes) {
+		return err
+	}
+	return cluster.SetAttribute(NetworkInterfacesAttributeID, list)
+}
+
+// ReadAttribute returns the value of the specified attribute after reading the network interfaces,
+// and the current up time for UpTime, which does not change the data version.
+func (cluster *Cluster) ReadAttribute(id im.AttributeID) (datatype.Value, error) {
+	switch id {
+	case NetworkInterfacesAttributeID:
+		if err := cluster.Update(); err != nil {
+			return nil, fmt.Errorf("%w : %w", im.StatusFailure, err)
+		}
+	case UpTimeAttributeID:
+		upTime := datatype.Uint64(cluster.UpTime() / time.Second)
+		return &upTime, nil
+	}
+	return cluster.BaseCluster.ReadAttribute(id)
+}
+
+func newNetworkInterfaceList(nis []NetworkInterface) *datatype.List {
+	list := datatype.NewList(func() datatype.Value { return newNetworkInterfaceStruct(nil) })
+	for n := range nis {
+		if MaxNetworkInterfaces <= n {
+			break
+		}
+		list.Elements = append(list.Elements, newNetworkInterfaceStruct(&nis[n]))
+	}
+	return list
+}
+
+func newAddressList() *datatype.List {
+	return datatype.NewList(func() datatype.Value { return new(datatype.OctetString) })
+}
+
+func newNetworkInterfaceStruct(ni *NetworkInterface) *datatype.Struct {
+	name := new(datatype.String)
+	operational := new(datatype.Bool)
+	hardwareAddr := new(datatype.OctetString)
+	ipv4Addrs := newAddressList()
+	ipv6Addrs := newAddressList()
+	typ := new(datatype.Enum8)
+	if ni != nil {
+		*name = datatype.String(ni.Name)
+		*operational = datatype.Bool(ni.IsOperational)
+		*hardwareAddr = datatype.OctetString(ni.HardwareAddress)
+		*typ = datatype.Enum8(ni.Type)
+		for _, addr := range ni.Addrs {
+			v := datatype.OctetString(addr.AsSlice())
+			if addr.Is4() {
+				ipv4Addrs.Elements = append(ipv4Addrs.Elements, &v)
+			} else {
+				ipv6Addrs.Elements = append(ipv6Addrs.Elements, &v)
+			}
+		}
+	}
+	// The reachability of off-premise services is unknown.
+	return datatype.NewStruct(
+		datatype.NewField(0, name),
+		datatype.NewField(1, operational),
+		datatype.NewField(2, datatype.NewNull(new(datatype.Bool))),
+		datatype.NewField(3, datatype.NewNull(new(datatype.Bool))),
+		datatype.NewField(4, hardwareAddr),
+		datatype.NewField(5, ipv4Addrs),
+		datatype.NewField(6, ipv6Addrs),
+		datatype.NewField(7, typ))
+}
+
+func (cluster *Cluster) testEventTrigger(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	enableKey := new(datatype.OctetString)
+	trigger := new(datatype.Uint64)
+	err := req.DecodeFields(datatype.NewStruct(
+		datatype.NewField(0, enableKey),
+		datatype.NewField(1, trigger)))
+	if err != nil {
+		return nil, err
+	}
+	if len(*enableKey) != TestEventTriggerKeySize {
+		return nil, fmt.Errorf("%w : enable key length (%d)", im.StatusConstraintError, len(*enableKey))
+	}
+	if cluster.enableKey == nil || subtle.ConstantTimeCompare(*enableKey, cluster.enableKey) != 1 {
+		return nil, fmt.Errorf("%w : enable key", im.StatusUnsupportedAccess)
+	}
+	if cluster.triggerHandler == nil {
+		return nil, fmt.Errorf("%w : event trigger (0x%016X)", im.StatusInvalidCommand, *trigger)
+	}
+	return nil, cluster.triggerHandler(uint64(*trigger))
+}
+
+func (cluster *Cluster) timeSnapshot(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	systemTime := datatype.Uint64(cluster.UpTime() / time.Millisecond)
+	posixTime := datatype.Uint64(time.Now().UnixMilli())
+	return datamodel.NewCommandResponse(TimeSnapshotResponseCommandID, datatype.NewStruct(
+		datatype.NewField(0, &systemTime),
+		datatype.NewField(1, datatype.NewNullable(&posixTime)))), nil
+}
@@ -0,0 +1,166 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generaldiagnostics
+
+import (
+	"bytes"
+	"net/netip"
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datamodel/datamodeltest"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/storage"
+)
+
+func TestRebootCount(t *testing.T) {
+	store := storage.NewMemoryStore()
+	for n := 1; n <= 3; n++ {
+		cluster, err := NewCluster(datamodel.NewEndpoint(datamodel.RootEndpointID), WithStore(store))
+		if err != nil {
+			t.Fatal(err)
+		}
+		v, err := cluster.ReadAttribute(RebootCountAttributeID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *v.(*datatype.Uint16) != datatype.Uint16(n) {
+			t.Errorf("reboot count (%d) != %d", *v.(*datatype.Uint16), n)
+		}
+	}
+}
+
+func TestNetworkInterfaces(t *testing.T) {
+	nis := []NetworkInterface{
+		{
+			Name:            "eth0",
+			IsOperational:   true,
+			HardwareAddress: []byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55},
+			Addrs:           []netip.Addr{netip.MustParseAddr("192.168.1.2"), netip.MustParseAddr("fe80::1")},
+			Type:            InterfaceTypeEthernet,
+		},
+	}
+	cluster, err := NewCluster(datamodel.NewEndpoint(datamodel.RootEndpointID),
+		WithInterfaceReader(func() ([]NetworkInterface, error) { return nis, nil }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := cluster.ReadAttribute(NetworkInterfacesAttributeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := v.(*datatype.List)
+	if len(list.Elements) != 1 {
+		t.Fatalf("%d interfaces", len(list.Elements))
+	}
+	ni := list.Elements[0].(*datatype.Struct)
+	ipv4, _ := ni.LookupField(5)
+	ipv6, _ := ni.LookupField(6)
+	if addrs := ipv4.Value.(*datatype.List).Elements; len(addrs) != 1 || !bytes.Equal(*addrs[0].(*datatype.OctetString), []byte{192, 168, 1, 2}) {
+		t.Errorf("IPv4 addresses %v", addrs)
+	}
+	if addrs := ipv6.Value.(*datatype.List).Elements; len(addrs) != 1 {
+		t.Errorf("IPv6 addresses %v", addrs)
+	}
+
+	version := cluster.DataVersion()
+	cluster.ReadAttribute(NetworkInterfacesAttributeID)
+	if cluster.DataVersion() != version {
+		t.Error("data version is changed by the same interfaces")
+	}
+	nis[0].IsOperational = false
+	cluster.ReadAttribute(NetworkInterfacesAttributeID)
+	if cluster.DataVersion() == version {
+		t.Error("data version is not changed")
+	}
+}
+
+func TestTestEventTrigger(t *testing.T) {
+	key := bytes.Repeat([]byte{0xA5}, TestEventTriggerKeySize)
+	triggers := []uint64{}
+	cluster, err := NewCluster(datamodel.NewEndpoint(datamodel.RootEndpointID),
+		WithTestEventTrigger(key, func(trigger uint64) error {
+			triggers = append(triggers, trigger)
+			return nil
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, _ := cluster.ReadAttribute(TestEventTriggersEnabledAttributeID)
+	if !*v.(*datatype.Bool) {
+		t.Error("test event triggers are not enabled")
+	}
+
+	triggerFields := func(key []byte, trigger uint64) *datatype.Struct {
+		k := datatype.OctetString(key)
+		v := datatype.Uint64(trigger)
+		return datatype.NewStruct(datatype.NewField(0, &k), datatype.NewField(1, &v))
+	}
+	if _, err := datamodeltest.Invoke(t, cluster, datamodel.RootEndpointID, TestEventTriggerCommandID, 1, triggerFields(key, 0x0033000000000001)); err != nil {
+		t.Error(err)
+	}
+	wrongKey := bytes.Repeat([]byte{0x5A}, TestEventTriggerKeySize)
+	if _, err := datamodeltest.Invoke(t, cluster, datamodel.RootEndpointID, TestEventTriggerCommandID, 1, triggerFields(wrongKey, 1)); im.StatusOf(err) != im.StatusUnsupportedAccess {
+		t.Errorf("%v is not %s", err, im.StatusUnsupportedAccess)
+	}
+	if _, err := datamodeltest.Invoke(t, cluster, datamodel.RootEndpointID, TestEventTriggerCommandID, 1, triggerFields(key[:8], 1)); im.StatusOf(err) != im.StatusConstraintError {
+		t.Errorf("%v is not %s", err, im.StatusConstraintError)
+	}
+	if len(triggers) != 1 || triggers[0] != 0x0033000000000001 {
+		t.Errorf("triggers %v", triggers)
+	}
+
+	if _, err := NewCluster(datamodel.NewEndpoint(0), WithTestEventTrigger(make([]byte, TestEventTriggerKeySize), nil)); err == nil {
+		t.Error("zero enable key is accepted")
+	}
+}
+
+func TestTimeSnapshotAndBootReason(t *testing.T) {
+	node := datamodel.NewNode()
+	ep := datamodel.NewEndpoint(datamodel.RootEndpointID)
+	if err := node.AddEndpoint(ep); err != nil {
+		t.Fatal(err)
+	}
+	events := []*datamodel.Event{}
+	node.SetEventHandler(func(ev *datamodel.Event) {
+		events = append(events, ev)
+	})
+
+	cluster, err := NewCluster(ep, WithBootReason(BootReasonSoftwareUpdateCompleted))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := datamodeltest.Invoke(t, cluster, datamodel.RootEndpointID, TimeSnapshotCommandID, 1, datatype.NewStruct())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Command != TimeSnapshotResponseCommandID {
+		t.Errorf("response command (0x%02X)", res.Command)
+	}
+	if _, err := datatype.Encode(res.Fields); err != nil {
+		t.Error(err)
+	}
+
+	cluster.NotifyBoot()
+	if len(events) != 1 || events[0].Path.Event != BootReasonEventID || events[0].Priority != datamodel.CriticalPriority {
+		t.Errorf("events %v", events)
+	}
+	v, _ := cluster.ReadAttribute(BootReasonAttributeID)
+	if BootReason(*v.(*datatype.Enum8)) != BootReasonSoftwareUpdateCompleted {
+		t.Errorf("boot reason (%d)", *v.(*datatype.Enum8))
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generaldiagnostics
+
+import (
+	"net"
+	"net/netip"
+)
+
+// InterfaceType represents a type of network interfaces.
+type InterfaceType uint8
+
+const (
+	InterfaceTypeUnspecified InterfaceType = 0x00
+	InterfaceTypeWiFi        InterfaceType = 0x01
+	InterfaceTypeEthernet    InterfaceType = 0x02
+	InterfaceTypeCellular    InterfaceType = 0x03
+	InterfaceTypeThread      InterfaceType = 0x04
+)
+
+// NetworkInterface represents a network interface of the node (NetworkInterface).
+type NetworkInterface struct {
+	// Name is the name of the interface.
+	Name string
+	// IsOperational is true if the interface is up.
+	IsOperational bool
+	// HardwareAddress is the MAC address or the extended address of the interface.
+	HardwareAddress []byte
+	// Addrs are the IPv4 and IPv6 addresses of the interface.
+	Addrs []netip.Addr
+	// Type is the type of the interface.
+	Type InterfaceType
+}
+
+// InterfaceReader returns the network interfaces of the node.
+type InterfaceReader func() ([]NetworkInterface, error)
+
+// ReadNetworkInterfaces returns the non-loopback network interfaces of the host. The interface types
+// are unspecified since they can not be determined portably.
+func ReadNetworkInterfaces() ([]NetworkInterface, error) {
+	ifis, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	nis := []NetworkInterface{}
+	for _, ifi := range ifis {
+		if (ifi.Flags & net.FlagLoopback) != 0 {
+			continue
+		}
+		ni := NetworkInterface{
+			Name:            ifi.Name,
+			IsOperational:   (ifi.Flags & net.FlagUp) != 0,
+			HardwareAddress: ifi.HardwareAddr,
+			Addrs:           []netip.Addr{},
+			Type:            InterfaceTypeUnspecified,
+		}
+		addrs, err := ifi.Addrs()
+		if err != nil {
+			return nil, err
+		}
+		for _, addr := range addrs {
+			ipnet, ok := addr.(*net.IPNet)
+			if !ok {
+				continue
+			}
+			if ip, ok := netip.AddrFromSlice(ipnet.IP); ok {
+				ni.Addrs = append(ni.Addrs, ip.Unmap())
+			}
+		}
+		nis = append(nis, ni)
+	}
+	return nis, nil
+}
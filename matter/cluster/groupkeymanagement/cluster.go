@@ -0,0 +1,279 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupkeymanagement
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/matter/crypto"
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// 11.2. Group Key Management Cluster
+const (
+	ClusterID       im.ClusterID = 0x003F
+	ClusterRevision              = 1
+
+	// FeatureCacheAndSync indicates that the CacheAndSync security policy is supported.
+	FeatureCacheAndSync uint32 = 0x01
+
+	GroupKeyMapAttributeID           im.AttributeID = 0x0000
+	GroupTableAttributeID            im.AttributeID = 0x0001
+	MaxGroupsPerFabricAttributeID    im.AttributeID = 0x0002
+	MaxGroupKeysPerFabricAttributeID im.AttributeID = 0x0003
+
+	KeySetWriteCommandID                  im.CommandID = 0x00
+	KeySetReadCommandID                   im.CommandID = 0x01
+	KeySetReadResponseCommandID           im.CommandID = 0x02
+	KeySetRemoveCommandID                 im.CommandID = 0x03
+	KeySetReadAllIndicesCommandID         im.CommandID = 0x04
+	KeySetReadAllIndicesResponseCommandID im.CommandID = 0x05
+
+	// DefaultMaxGroupsPerFabric is the default number of groups which a fabric can map to key sets.
+	DefaultMaxGroupsPerFabric = 4
+	// DefaultMaxGroupKeysPerFabric is the default number of key sets of a fabric including the IPK.
+	DefaultMaxGroupKeysPerFabric = 3
+)
+
+// FailSafe represents the fail-safe context of the node, which storage.FailSafeStore implements.
+type FailSafe interface {
+	// IsArmed returns true if the fail-safe is armed.
+	IsArmed() bool
+}
+
+type keySetKey struct {
+	fabric types.FabricIndex
+	id     uint16
+}
+
+// Cluster represents a Group Key Management cluster server, which also provides the operational
+// group keys of the key sets to the group message layer.
+type Cluster struct {
+	*datamodel.BaseCluster
+	mutex         sync.Mutex
+	keySets       map[keySetKey]*KeySet
+	maxGroups     int
+	maxGroupKeys  int
+	now           func() time.Time
+	failSafe      FailSafe
+	compressedIDs func(types.FabricIndex) (uint64, bool)
+}
+
+// Option represents an option of the group key management cluster.
+type Option func(*Cluster)
+
+// WithMaxGroupsPerFabric sets the number of groups which a fabric can map to key sets.
+func WithMaxGroupsPerFabric(n uint16) Option {
+	return func(cluster *Cluster) {
+		cluster.maxGroups = int(n)
+	}
+}
+
+// WithMaxGroupKeysPerFabric sets the number of key sets of a fabric including the IPK.
+func WithMaxGroupKeysPerFabric(n uint16) Option {
+	return func(cluster *Cluster) {
+		cluster.maxGroupKeys = int(n)
+	}
+}
+
+// WithCacheAndSync enables the CacheAndSync security policy.
+func WithCacheAndSync() Option {
+	return func(cluster *Cluster) {
+		cluster.SetFeatureMap(cluster.FeatureMap() | FeatureCacheAndSync)
+	}
+}
+
+// WithClock sets the clock which selects the current epoch keys.
+func WithClock(now func() time.Time) Option {
+	return func(cluster *Cluster) {
+		cluster.now = now
+	}
+}
+
+// WithFailSafe sets the fail-safe context which gates the installation of the IPK.
+func WithFailSafe(failSafe FailSafe) Option {
+	return func(cluster *Cluster) {
+		cluster.failSafe = failSafe
+	}
+}
+
+// WithCompressedFabricIDs sets the function which returns the compressed fabric identifier of
+// a fabric, which the operational group keys are derived with.
+func WithCompressedFabricIDs(fn func(types.FabricIndex) (uint64, bool)) Option {
+	return func(cluster *Cluster) {
+		cluster.compressedIDs = fn
+	}
+}
+
+// NewCluster returns a new group key management cluster.
+func NewCluster(opts ...Option) *Cluster {
+	cluster := &Cluster{
+		BaseCluster:   datamodel.NewBaseCluster(ClusterID, ClusterRevision),
+		mutex:         sync.Mutex{},
+		keySets:       map[keySetKey]*KeySet{},
+		maxGroups:     DefaultMaxGroupsPerFabric,
+		maxGroupKeys:  DefaultMaxGroupKeysPerFabric,
+		now:           time.Now,
+		failSafe:      nil,
+		compressedIDs: func(types.FabricIndex) (uint64, bool) { return 0, false },
+	}
+	for _, opt := range opts {
+		opt(cluster)
+	}
+
+	maxGroups := datatype.Uint16(cluster.maxGroups)
+	maxGroupKeys := datatype.Uint16(cluster.maxGroupKeys)
+	keyMap := datamodel.NewWritableFabricScopedAttribute(GroupKeyMapAttributeID, newGroupKeyMapList())
+	keyMap.WritePrivilege = datamodel.ManagePrivilege
+	keyMap.Constraint = cluster.validateGroupKeyMap
+	cluster.AddAttribute(keyMap)
+	cluster.AddAttribute(datamodel.NewFabricScopedAttribute(GroupTableAttributeID,
+		datatype.NewList(func() datatype.Value { return datatype.NewStruct() })))
+	cluster.AddAttribute(datamodel.NewAttribute(MaxGroupsPerFabricAttributeID, &maxGroups))
+	cluster.AddAttribute(datamodel.NewAttribute(MaxGroupKeysPerFabricAttributeID, &maxGroupKeys))
+
+	cluster.AddCommand(KeySetWriteCommandID, cluster.keySetWrite)
+	cluster.AddCommand(KeySetReadCommandID, cluster.keySetRead)
+	cluster.AddCommand(KeySetRemoveCommandID, cluster.keySetRemove)
+	cluster.AddCommand(KeySetReadAllIndicesCommandID, cluster.keySetReadAllIndices)
+	cluster.AddGeneratedCommand(KeySetReadResponseCommandID)
+	cluster.AddGeneratedCommand(KeySetReadAllIndicesResponseCommandID)
+
+	return cluster
+}
+
+// HasFeature returns true if the specified feature is supported.
+func (cluster *Cluster) HasFeature(feature uint32) bool {
+	return cluster.FeatureMap()&feature != 0
+}
+
+// SetIdentityProtectionKey installs the IPK of the fabric as the key set 0, which is done with
+// the AddNOC command and so is only allowed while the fail-safe is armed.
+func (cluster *Cluster) SetIdentityProtectionKey(fabric types.FabricIndex, epochKey []byte) error {
+	if cluster.failSafe == nil || !cluster.failSafe.IsArmed() {
+		return fmt.Errorf("%w : fail-safe is not armed", im.StatusFailsafeRequired)
+	}
+	if !fabric.IsValid() {
+		return fmt.Errorf("%w : fabric (%d)", im.StatusConstraintError, fabric)
+	}
+	if len(epochKey) != crypto.GroupEpochKeyLength {
+		return fmt.Errorf("%w : IPK length (%d)", im.StatusConstraintError, len(epochKey))
+	}
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	cluster.keySets[keySetKey{fabric: fabric, id: IdentityProtectionKeySetID}] = &KeySet{
+		ID:        IdentityProtectionKeySetID,
+		Policy:    SecurityPolicyTrustFirst,
+		EpochKeys: []EpochKey{{Key: slices.Clone(epochKey), StartTime: time.Time{}}},
+	}
+	return nil
+}
+
+// LookupKeySet returns a copy of the specified key set of the fabric.
+func (cluster *Cluster) LookupKeySet(fabric types.FabricIndex, id uint16) (*KeySet, bool) {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	set, ok := cluster.keySets[keySetKey{fabric: fabric, id: id}]
+	if !ok {
+		return nil, false
+	}
+	return set.Clone(), true
+}
+
+// KeySetIDs returns the key set IDs of the fabric in ascending order.
+func (cluster *Cluster) KeySetIDs(fabric types.FabricIndex) []uint16 {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	return cluster.keySetIDs(fabric)
+}
+
+func (cluster *Cluster) keySetIDs(fabric types.FabricIndex) []uint16 {
+	ids := []uint16{}
+	for key := range cluster.keySets {
+		if key.fabric == fabric {
+			ids = append(ids, key.id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// GroupKeyMap returns the key set IDs of the groups of the fabric.
+func (cluster *Cluster) GroupKeyMap(fabric types.FabricIndex) map[message.GroupID]uint16 {
+	groups := map[message.GroupID]uint16{}
+	for _, entry := range cluster.groupKeyMap() {
+		if entry.fabric == fabric {
+			groups[entry.group] = entry.keySet
+		}
+	}
+	return groups
+}
+
+// RemoveFabric removes the key sets and the group key map entries of the specified fabric.
+func (cluster *Cluster) RemoveFabric(fabric types.FabricIndex) {
+	cluster.mutex.Lock()
+	for key := range cluster.keySets {
+		if key.fabric == fabric {
+			delete(cluster.keySets, key)
+		}
+	}
+	cluster.mutex.Unlock()
+	cluster.BaseCluster.RemoveFabric(fabric)
+}
+
+// storeKeySet adds or replaces the key set of the fabric unless the fabric has no room for another key set.
+func (cluster *Cluster) storeKeySet(fabric types.FabricIndex, set *KeySet) error {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	key := keySetKey{fabric: fabric, id: set.ID}
+	if _, ok := cluster.keySets[key]; !ok {
+		// The IPK always occupies a key set of the fabric whether it is installed or not.
+		n := len(cluster.keySetIDs(fabric))
+		if _, ok := cluster.keySets[keySetKey{fabric: fabric, id: IdentityProtectionKeySetID}]; !ok {
+			n++
+		}
+		if cluster.maxGroupKeys <= n {
+			return fmt.Errorf("%w : key sets exceed %d", im.StatusResourceExhausted, cluster.maxGroupKeys)
+		}
+	}
+	cluster.keySets[key] = set
+	return nil
+}
+
+// removeKeySet removes the key set of the fabric, and returns false if it is not found.
+func (cluster *Cluster) removeKeySet(fabric types.FabricIndex, id uint16) bool {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	key := keySetKey{fabric: fabric, id: id}
+	if _, ok := cluster.keySets[key]; !ok {
+		return false
+	}
+	delete(cluster.keySets, key)
+	return true
+}
+
+func (cluster *Cluster) hasKeySet(fabric types.FabricIndex, id uint16) bool {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	_, ok := cluster.keySets[keySetKey{fabric: fabric, id: id}]
+	return ok
+}
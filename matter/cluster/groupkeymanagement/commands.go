@@ -0,0 +1,100 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupkeymanagement
+
+import (
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+func checkFabric(req *datamodel.CommandRequest) error {
+	if !req.FabricIndex.IsValid() {
+		return fmt.Errorf("%w : no accessing fabric", im.StatusUnsupportedAccess)
+	}
+	return nil
+}
+
+func (cluster *Cluster) keySetWrite(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	if err := checkFabric(req); err != nil {
+		return nil, err
+	}
+	fields := newKeySetFields()
+	if err := req.DecodeFields(datatype.NewStruct(datatype.NewField(0, fields.value()))); err != nil {
+		return nil, err
+	}
+	set, err := fields.keySet()
+	if err != nil {
+		return nil, err
+	}
+	if set.Policy == SecurityPolicyCacheAndSync && !cluster.HasFeature(FeatureCacheAndSync) {
+		return nil, fmt.Errorf("%w : CacheAndSync is not supported", im.StatusConstraintError)
+	}
+	if err := cluster.storeKeySet(req.FabricIndex, set); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (cluster *Cluster) keySetRead(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	if err := checkFabric(req); err != nil {
+		return nil, err
+	}
+	id := new(datatype.Uint16)
+	if err := req.DecodeFields(datatype.NewStruct(datatype.NewField(0, id))); err != nil {
+		return nil, err
+	}
+	set, ok := cluster.LookupKeySet(req.FabricIndex, uint16(*id))
+	if !ok {
+		return nil, fmt.Errorf("%w : key set (%d)", im.StatusNotFound, *id)
+	}
+	v, err := newKeySetReadValue(set)
+	if err != nil {
+		return nil, fmt.Errorf("%w : %w", im.StatusFailure, err)
+	}
+	return datamodel.NewCommandResponse(KeySetReadResponseCommandID, datatype.NewStruct(datatype.NewField(0, v))), nil
+}
+
+func (cluster *Cluster) keySetRemove(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	if err := checkFabric(req); err != nil {
+		return nil, err
+	}
+	id := new(datatype.Uint16)
+	if err := req.DecodeFields(datatype.NewStruct(datatype.NewField(0, id))); err != nil {
+		return nil, err
+	}
+	if uint16(*id) == IdentityProtectionKeySetID {
+		return nil, fmt.Errorf("%w : key set (%d) is the IPK", im.StatusInvalidCommand, *id)
+	}
+	if !cluster.removeKeySet(req.FabricIndex, uint16(*id)) {
+		return nil, fmt.Errorf("%w : key set (%d)", im.StatusNotFound, *id)
+	}
+	cluster.removeGroupKeyMapKeySet(req.FabricIndex, uint16(*id))
+	return nil, nil
+}
+
+func (cluster *Cluster) keySetReadAllIndices(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	if err := checkFabric(req); err != nil {
+		return nil, err
+	}
+	list := datatype.NewList(func() datatype.Value { return new(datatype.Uint16) })
+	for _, id := range cluster.KeySetIDs(req.FabricIndex) {
+		v := datatype.Uint16(id)
+		list.Elements = append(list.Elements, &v)
+	}
+	return datamodel.NewCommandResponse(KeySetReadAllIndicesResponseCommandID, datatype.NewStruct(datatype.NewField(0, list))), nil
+}
@@ -0,0 +1,343 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupkeymanagement
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datamodel/datamodeltest"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+const (
+	testEndpointID     im.EndpointID = 0
+	testFabricIndex                  = types.FabricIndex(1)
+	testOtherFabric                  = types.FabricIndex(2)
+	testCompressedID                 = uint64(0x87E1B004E235A130)
+	testEpochKeyString               = "235bf7e62823d358dca4ba50b1535f4b"
+)
+
+type testFailSafe struct {
+	armed bool
+}
+
+func (fs *testFailSafe) IsArmed() bool {
+	return fs.armed
+}
+
+func testEpochKey(t *testing.T, b byte) []byte {
+	t.Helper()
+	if b == 0 {
+		key, err := hex.DecodeString(testEpochKeyString)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return key
+	}
+	return bytes.Repeat([]byte{b}, 16)
+}
+
+// epochKeyArg represents an epoch key and its start time of KeySetWrite, which are null if nil.
+type epochKeyArg struct {
+	key   []byte
+	start *time.Time
+}
+
+func newKeySetWriteFields(t *testing.T, id uint16, policy SecurityPolicy, keys ...epochKeyArg) datatype.Value {
+	t.Helper()
+	fields := newKeySetFields()
+	*fields.id = datatype.Uint16(id)
+	*fields.policy = datatype.Enum8(policy)
+	for n, key := range keys {
+		if key.key != nil {
+			v := datatype.OctetString(key.key)
+			fields.keys[n].Set(&v)
+		}
+		if key.start != nil {
+			v, err := datatype.NewEpochUs(*key.start)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fields.times[n].Set(&v)
+		}
+	}
+	return datatype.NewStruct(datatype.NewField(0, fields.value()))
+}
+
+func newKeySetIDFields(id uint16) datatype.Value {
+	v := datatype.Uint16(id)
+	return datatype.NewStruct(datatype.NewField(0, &v))
+}
+
+func newGroupKeyMap(entries ...[2]uint16) []byte {
+	list := newGroupKeyMapList()
+	for _, entry := range entries {
+		group := datatype.Uint16(entry[0])
+		keySet := datatype.Uint16(entry[1])
+		list.Elements = append(list.Elements, datatype.NewStruct(
+			datatype.NewField(groupKeyMapGroupIDTag, &group),
+			datatype.NewField(groupKeyMapKeySetIDTag, &keySet)))
+	}
+	b, _ := datatype.Encode(list)
+	return b
+}
+
+func TestKeySetWrite(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+	key := testEpochKey(t, 1)
+
+	tests := []struct {
+		name   string
+		id     uint16
+		policy SecurityPolicy
+		keys   []epochKeyArg
+		status im.Status
+	}{
+		{"one key", 1, SecurityPolicyTrustFirst, []epochKeyArg{{key, &t0}}, im.StatusSuccess},
+		{"two keys", 1, SecurityPolicyTrustFirst, []epochKeyArg{{key, &t0}, {key, &t1}}, im.StatusSuccess},
+		{"IPK", IdentityProtectionKeySetID, SecurityPolicyTrustFirst, []epochKeyArg{{key, &t0}}, im.StatusInvalidCommand},
+		{"no key", 1, SecurityPolicyTrustFirst, nil, im.StatusInvalidCommand},
+		{"no start time", 1, SecurityPolicyTrustFirst, []epochKeyArg{{key, nil}}, im.StatusInvalidCommand},
+		{"short key", 1, SecurityPolicyTrustFirst, []epochKeyArg{{key[:8], &t0}}, im.StatusConstraintError},
+		{"gap", 1, SecurityPolicyTrustFirst, []epochKeyArg{{key, &t0}, {nil, nil}, {key, &t1}}, im.StatusInvalidCommand},
+		{"start time order", 1, SecurityPolicyTrustFirst, []epochKeyArg{{key, &t1}, {key, &t0}}, im.StatusInvalidCommand},
+		{"same start time", 1, SecurityPolicyTrustFirst, []epochKeyArg{{key, &t0}, {key, &t0}}, im.StatusInvalidCommand},
+		{"unsupported policy", 1, SecurityPolicyCacheAndSync, []epochKeyArg{{key, &t0}}, im.StatusConstraintError},
+		{"unknown policy", 1, SecurityPolicy(2), []epochKeyArg{{key, &t0}}, im.StatusConstraintError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := NewCluster()
+			_, err := datamodeltest.Invoke(t, cluster, testEndpointID, KeySetWriteCommandID, testFabricIndex, newKeySetWriteFields(t, test.id, test.policy, test.keys...))
+			if status := im.StatusOf(err); status != test.status {
+				t.Fatalf("%s != %s (%v)", status, test.status, err)
+			}
+			if _, ok := cluster.LookupKeySet(testFabricIndex, test.id); ok != (test.status == im.StatusSuccess) {
+				t.Errorf("key set stored : %t", ok)
+			}
+		})
+	}
+
+	t.Run("CacheAndSync", func(t *testing.T) {
+		cluster := NewCluster(WithCacheAndSync())
+		if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, KeySetWriteCommandID, testFabricIndex, newKeySetWriteFields(t, 1, SecurityPolicyCacheAndSync, epochKeyArg{key, &t0})); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("no fabric", func(t *testing.T) {
+		cluster := NewCluster()
+		_, err := datamodeltest.Invoke(t, cluster, testEndpointID, KeySetWriteCommandID, types.NoFabricIndex, newKeySetWriteFields(t, 1, SecurityPolicyTrustFirst, epochKeyArg{key, &t0}))
+		if status := im.StatusOf(err); status != im.StatusUnsupportedAccess {
+			t.Errorf("%s != %s", status, im.StatusUnsupportedAccess)
+		}
+	})
+
+	t.Run("capacity", func(t *testing.T) {
+		cluster := NewCluster()
+		// The IPK occupies one of the key sets of the fabric.
+		for id := uint16(1); id < DefaultMaxGroupKeysPerFabric; id++ {
+			if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, KeySetWriteCommandID, testFabricIndex, newKeySetWriteFields(t, id, SecurityPolicyTrustFirst, epochKeyArg{key, &t0})); err != nil {
+				t.Fatal(err)
+			}
+		}
+		_, err := datamodeltest.Invoke(t, cluster, testEndpointID, KeySetWriteCommandID, testFabricIndex, newKeySetWriteFields(t, DefaultMaxGroupKeysPerFabric, SecurityPolicyTrustFirst, epochKeyArg{key, &t0}))
+		if status := im.StatusOf(err); status != im.StatusResourceExhausted {
+			t.Errorf("%s != %s", status, im.StatusResourceExhausted)
+		}
+		// Existing key sets can be replaced, and the other fabrics have their own capacity.
+		if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, KeySetWriteCommandID, testFabricIndex, newKeySetWriteFields(t, 1, SecurityPolicyTrustFirst, epochKeyArg{key, &t1})); err != nil {
+			t.Error(err)
+		}
+		if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, KeySetWriteCommandID, testOtherFabric, newKeySetWriteFields(t, DefaultMaxGroupKeysPerFabric, SecurityPolicyTrustFirst, epochKeyArg{key, &t0})); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestKeySetReadAndRemove(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cluster := NewCluster()
+	for _, id := range []uint16{2, 1} {
+		if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, KeySetWriteCommandID, testFabricIndex, newKeySetWriteFields(t, id, SecurityPolicyTrustFirst, epochKeyArg{testEpochKey(t, 1), &t0})); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resp, err := datamodeltest.Invoke(t, cluster, testEndpointID, KeySetReadCommandID, testFabricIndex, newKeySetIDFields(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	read := newKeySetFields()
+	b, _ := datatype.Encode(resp.Fields)
+	if err := datatype.Decode(b, datatype.NewStruct(datatype.NewField(0, read.value()))); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := read.keys[0].Get(); ok {
+		t.Error("KeySetRead returned the epoch key")
+	}
+	if start, ok := read.times[0].Get(); !ok || !start.Time().Equal(t0) {
+		t.Errorf("epoch start time (%v) != %v", start, t0)
+	}
+	if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, KeySetReadCommandID, testOtherFabric, newKeySetIDFields(1)); im.StatusOf(err) != im.StatusNotFound {
+		t.Errorf("%s != %s", im.StatusOf(err), im.StatusNotFound)
+	}
+
+	resp, err = datamodeltest.Invoke(t, cluster, testEndpointID, KeySetReadAllIndicesCommandID, testFabricIndex, datatype.NewStruct())
+	if err != nil {
+		t.Fatal(err)
+	}
+	indices := datatype.NewList(func() datatype.Value { return new(datatype.Uint16) })
+	b, _ = datatype.Encode(resp.Fields)
+	if err := datatype.Decode(b, datatype.NewStruct(datatype.NewField(0, indices))); err != nil {
+		t.Fatal(err)
+	}
+	if len(indices.Elements) != 2 || *indices.Elements[0].(*datatype.Uint16) != 1 {
+		t.Errorf("indices (%v) != [1 2]", indices.Elements)
+	}
+
+	if err := datamodel.WriteFabricAttribute(cluster, GroupKeyMapAttributeID, newGroupKeyMap([2]uint16{0x0101, 1}, [2]uint16{0x0102, 2}), testFabricIndex); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, KeySetRemoveCommandID, testFabricIndex, newKeySetIDFields(IdentityProtectionKeySetID)); im.StatusOf(err) != im.StatusInvalidCommand {
+		t.Errorf("%s != %s", im.StatusOf(err), im.StatusInvalidCommand)
+	}
+	if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, KeySetRemoveCommandID, testFabricIndex, newKeySetIDFields(1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, KeySetRemoveCommandID, testFabricIndex, newKeySetIDFields(1)); im.StatusOf(err) != im.StatusNotFound {
+		t.Errorf("%s != %s", im.StatusOf(err), im.StatusNotFound)
+	}
+	groups := cluster.GroupKeyMap(testFabricIndex)
+	if _, ok := groups[0x0101]; ok || len(groups) != 1 {
+		t.Errorf("group key map (%v) has the removed key set", groups)
+	}
+}
+
+func TestGroupKeyMap(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cluster := NewCluster(WithMaxGroupsPerFabric(2))
+	if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, KeySetWriteCommandID, testFabricIndex, newKeySetWriteFields(t, 1, SecurityPolicyTrustFirst, epochKeyArg{testEpochKey(t, 1), &t0})); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		fabric  types.FabricIndex
+		entries [][2]uint16
+		status  im.Status
+	}{
+		{"mapped", testFabricIndex, [][2]uint16{{0x0101, 1}, {0x0102, 1}}, im.StatusSuccess},
+		{"group 0", testFabricIndex, [][2]uint16{{0, 1}}, im.StatusConstraintError},
+		{"IPK", testFabricIndex, [][2]uint16{{0x0101, 0}}, im.StatusConstraintError},
+		{"unknown key set", testFabricIndex, [][2]uint16{{0x0101, 2}}, im.StatusConstraintError},
+		{"other fabric key set", testOtherFabric, [][2]uint16{{0x0101, 1}}, im.StatusConstraintError},
+		{"duplicate group", testFabricIndex, [][2]uint16{{0x0101, 1}, {0x0101, 1}}, im.StatusConstraintError},
+		{"capacity", testFabricIndex, [][2]uint16{{0x0101, 1}, {0x0102, 1}, {0x0103, 1}}, im.StatusResourceExhausted},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := datamodel.WriteFabricAttribute(cluster, GroupKeyMapAttributeID, newGroupKeyMap(test.entries...), test.fabric)
+			if status := im.StatusOf(err); status != test.status {
+				t.Errorf("%s != %s (%v)", status, test.status, err)
+			}
+		})
+	}
+
+	cluster.RemoveFabric(testFabricIndex)
+	if groups := cluster.GroupKeyMap(testFabricIndex); len(groups) != 0 {
+		t.Errorf("group key map (%v) of the removed fabric", groups)
+	}
+	if ids := cluster.KeySetIDs(testFabricIndex); len(ids) != 0 {
+		t.Errorf("key sets (%v) of the removed fabric", ids)
+	}
+}
+
+func TestIdentityProtectionKey(t *testing.T) {
+	failSafe := &testFailSafe{armed: false}
+	cluster := NewCluster(WithFailSafe(failSafe))
+	ipk := testEpochKey(t, 0)
+	if err := cluster.SetIdentityProtectionKey(testFabricIndex, ipk); im.StatusOf(err) != im.StatusFailsafeRequired {
+		t.Errorf("%s != %s", im.StatusOf(err), im.StatusFailsafeRequired)
+	}
+	failSafe.armed = true
+	if err := cluster.SetIdentityProtectionKey(testFabricIndex, ipk[:8]); im.StatusOf(err) != im.StatusConstraintError {
+		t.Errorf("%s != %s", im.StatusOf(err), im.StatusConstraintError)
+	}
+	if err := cluster.SetIdentityProtectionKey(testFabricIndex, ipk); err != nil {
+		t.Fatal(err)
+	}
+	if ids := cluster.KeySetIDs(testFabricIndex); len(ids) != 1 || ids[0] != IdentityProtectionKeySetID {
+		t.Errorf("key sets (%v) != [0]", ids)
+	}
+}
+
+func TestKeyProvider(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+	now := t0.Add(time.Minute)
+	cluster := NewCluster(
+		WithClock(func() time.Time { return now }),
+		WithCompressedFabricIDs(func(fabric types.FabricIndex) (uint64, bool) {
+			return testCompressedID, fabric == testFabricIndex
+		}))
+	fields := newKeySetWriteFields(t, 1, SecurityPolicyTrustFirst,
+		epochKeyArg{testEpochKey(t, 0), &t0},
+		epochKeyArg{testEpochKey(t, 1), &t1})
+	if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, KeySetWriteCommandID, testFabricIndex, fields); err != nil {
+		t.Fatal(err)
+	}
+
+	group := message.GroupID(0x0101)
+	if _, err := cluster.EncryptionKey(testFabricIndex, group); err == nil {
+		t.Error("unmapped group has a key")
+	}
+	if err := datamodel.WriteFabricAttribute(cluster, GroupKeyMapAttributeID, newGroupKeyMap([2]uint16{uint16(group), 1}), testFabricIndex); err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := cluster.EncryptionKey(testFabricIndex, group)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(key.Key) != "a6f5306baf6d050af23ba4bd6b9dd960" || key.SessionID != 0xB9F7 {
+		t.Errorf("operational key (%x, 0x%04X)", key.Key, key.SessionID)
+	}
+	keys, err := cluster.DecryptionKeys(0xB9F7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || !bytes.Equal(keys[0].Key, key.Key) {
+		t.Errorf("decryption keys (%d) have no operational key", len(keys))
+	}
+
+	// The next epoch key is used after its start time.
+	now = t1
+	next, err := cluster.EncryptionKey(testFabricIndex, group)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(next.Key, key.Key) || !next.StartTime.Equal(t1) {
+		t.Error("the next epoch key is not used")
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupkeymanagement
+
+import (
+	"fmt"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+const (
+	groupKeyMapGroupIDTag  = 1
+	groupKeyMapKeySetIDTag = 2
+)
+
+// groupKeyMapEntry represents an entry of the GroupKeyMap attribute (GroupKeyMapStruct).
+type groupKeyMapEntry struct {
+	fabric types.FabricIndex
+	group  message.GroupID
+	keySet uint16
+}
+
+func newGroupKeyMapStruct() datatype.Value {
+	return datatype.NewStruct(
+		datatype.NewField(groupKeyMapGroupIDTag, new(datatype.Uint16)),
+		datatype.NewField(groupKeyMapKeySetIDTag, new(datatype.Uint16)),
+		datatype.NewOptionalField(datamodel.FabricIndexFieldID, new(datatype.Uint8)))
+}
+
+func newGroupKeyMapList() *datatype.List {
+	return datatype.NewList(newGroupKeyMapStruct)
+}
+
+// decodeGroupKeyMapEntries returns the entries of the GroupKeyMap list.
+func decodeGroupKeyMapEntries(list *datatype.List) ([]groupKeyMapEntry, error) {
+	entries := []groupKeyMapEntry{}
+	for n, elem := range list.Elements {
+		st, ok := elem.(*datatype.Struct)
+		if !ok {
+			return nil, fmt.Errorf("%w : group key map (%d) is %s", im.StatusInvalidDataType, n, elem.Type())
+		}
+		group, ok := st.LookupField(groupKeyMapGroupIDTag)
+		if !ok {
+			return nil, fmt.Errorf("%w : group key map (%d) has no group", im.StatusInvalidDataType, n)
+		}
+		keySet, ok := st.LookupField(groupKeyMapKeySetIDTag)
+		if !ok {
+			return nil, fmt.Errorf("%w : group key map (%d) has no key set", im.StatusInvalidDataType, n)
+		}
+		groupID, ok := group.Value.(*datatype.Uint16)
+		if !ok {
+			return nil, fmt.Errorf("%w : group key map (%d) group", im.StatusInvalidDataType, n)
+		}
+		keySetID, ok := keySet.Value.(*datatype.Uint16)
+		if !ok {
+			return nil, fmt.Errorf("%w : group key map (%d) key set", im.StatusInvalidDataType, n)
+		}
+		fabric, _ := datamodel.FabricIndexOf(st)
+		entries = append(entries, groupKeyMapEntry{
+			fabric: fabric,
+			group:  message.GroupID(*groupID),
+			keySet: uint16(*keySetID),
+		})
+	}
+	return entries, nil
+}
+
+// groupKeyMap returns the current entries of the GroupKeyMap attribute.
+func (cluster *Cluster) groupKeyMap() []groupKeyMapEntry {
+	v, err := cluster.ReadAttribute(GroupKeyMapAttributeID)
+	if err != nil {
+		return nil
+	}
+	list, ok := v.(*datatype.List)
+	if !ok {
+		return nil
+	}
+	entries, err := decodeGroupKeyMapEntries(list)
+	if err != nil {
+		return nil
+	}
+	return entries
+}
+
+// validateGroupKeyMap returns CONSTRAINT_ERROR if an entry maps the reserved group 0, a duplicate group
+// or a key set which the fabric does not have, or RESOURCE_EXHAUSTED if the groups exceed the capacity.
+// The entries are already tagged with the accessing fabric.
+func (cluster *Cluster) validateGroupKeyMap(v datatype.Value) error {
+	list, ok := v.(*datatype.List)
+	if !ok {
+		return fmt.Errorf("%w : group key map is %s", im.StatusInvalidDataType, v.Type())
+	}
+	entries, err := decodeGroupKeyMapEntries(list)
+	if err != nil {
+		return err
+	}
+	groups := map[types.FabricIndex]map[message.GroupID]bool{}
+	for _, entry := range entries {
+		if entry.group == 0 {
+			return fmt.Errorf("%w : group (0)", im.StatusConstraintError)
+		}
+		if entry.keySet == IdentityProtectionKeySetID || !cluster.hasKeySet(entry.fabric, entry.keySet) {
+			return fmt.Errorf("%w : key set (%d) of group (0x%04X)", im.StatusConstraintError, entry.keySet, uint16(entry.group))
+		}
+		fabricGroups, ok := groups[entry.fabric]
+		if !ok {
+			fabricGroups = map[message.GroupID]bool{}
+			groups[entry.fabric] = fabricGroups
+		}
+		if fabricGroups[entry.group] {
+			return fmt.Errorf("%w : duplicate group (0x%04X)", im.StatusConstraintError, uint16(entry.group))
+		}
+		fabricGroups[entry.group] = true
+		if cluster.maxGroups < len(fabricGroups) {
+			return fmt.Errorf("%w : groups exceed %d", im.StatusResourceExhausted, cluster.maxGroups)
+		}
+	}
+	return nil
+}
+
+// removeGroupKeyMapKeySet removes the entries of the fabric which map groups to the key set.
+func (cluster *Cluster) removeGroupKeyMapKeySet(fabric types.FabricIndex, keySet uint16) {
+	v, err := cluster.ReadAttribute(GroupKeyMapAttributeID)
+	if err != nil {
+		return
+	}
+	list, ok := v.(*datatype.List)
+	if !ok {
+		return
+	}
+	entries, err := decodeGroupKeyMapEntries(list)
+	if err != nil {
+		return
+	}
+	kept := newGroupKeyMapList()
+	for n, entry := range entries {
+		if entry.fabric == fabric && entry.keySet == keySet {
+			continue
+		}
+		kept.Elements = append(kept.Elements, list.Elements[n])
+	}
+	if len(kept.Elements) == len(list.Elements) {
+		return
+	}
+	cluster.SetAttribute(GroupKeyMapAttributeID, kept)
+}
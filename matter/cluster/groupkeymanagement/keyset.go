@@ -0,0 +1,180 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupkeymanagement
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/crypto"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// IdentityProtectionKeySetID is the ID of the key set of the identity protection key (IPK), which
+// is installed with the NOC of the fabric and can not be written or removed by the key set commands.
+const IdentityProtectionKeySetID uint16 = 0
+
+// SecurityPolicy represents a security policy of group key sets (GroupKeySecurityPolicyEnum).
+type SecurityPolicy uint8
+
+const (
+	// SecurityPolicyTrustFirst accepts the first message counter of each group sender.
+	SecurityPolicyTrustFirst SecurityPolicy = 0x00
+	// SecurityPolicyCacheAndSync caches the messages until the message counter of the sender is synchronized.
+	SecurityPolicyCacheAndSync SecurityPolicy = 0x01
+)
+
+// EpochKey represents an epoch key of a key set, which is used from its start time.
+type EpochKey struct {
+	// Key is the epoch key of GroupEpochKeyLength bytes.
+	Key []byte
+	// StartTime is the time from which the key is used.
+	StartTime time.Time
+}
+
+// KeySet represents a group key set of a fabric (GroupKeySetStruct).
+type KeySet struct {
+	// ID is the key set ID.
+	ID uint16
+	// Policy is the security policy of the key set.
+	Policy SecurityPolicy
+	// EpochKeys are the one to three epoch keys in ascending order of their start times.
+	EpochKeys []EpochKey
+}
+
+// Clone returns a copy of the key set.
+func (set *KeySet) Clone() *KeySet {
+	cloned := &KeySet{
+		ID:        set.ID,
+		Policy:    set.Policy,
+		EpochKeys: make([]EpochKey, len(set.EpochKeys)),
+	}
+	for n, key := range set.EpochKeys {
+		cloned.EpochKeys[n] = EpochKey{Key: slices.Clone(key.Key), StartTime: key.StartTime}
+	}
+	return cloned
+}
+
+// currentEpochKey returns the epoch key with the latest start time which is not after the specified time.
+func (set *KeySet) currentEpochKey(now time.Time) (EpochKey, bool) {
+	for n := len(set.EpochKeys) - 1; 0 <= n; n-- {
+		if !set.EpochKeys[n].StartTime.After(now) {
+			return set.EpochKeys[n], true
+		}
+	}
+	return EpochKey{}, false
+}
+
+const (
+	keySetIDTag     = 0
+	keySetPolicyTag = 1
+	keySetKey0Tag   = 2
+	keySetEpochKeys = 3
+)
+
+// keySetFields represents the fields of a GroupKeySetStruct.
+type keySetFields struct {
+	id     *datatype.Uint16
+	policy *datatype.Enum8
+	keys   [keySetEpochKeys]*datatype.Nullable[*datatype.OctetString]
+	times  [keySetEpochKeys]*datatype.Nullable[*datatype.EpochUs]
+}
+
+func newKeySetFields() *keySetFields {
+	fields := &keySetFields{
+		id:     new(datatype.Uint16),
+		policy: new(datatype.Enum8),
+	}
+	for n := 0; n < keySetEpochKeys; n++ {
+		fields.keys[n] = datatype.NewNull(new(datatype.OctetString))
+		fields.times[n] = datatype.NewNull(new(datatype.EpochUs))
+	}
+	return fields
+}
+
+func (fields *keySetFields) value() *datatype.Struct {
+	st := datatype.NewStruct(
+		datatype.NewField(keySetIDTag, fields.id),
+		datatype.NewField(keySetPolicyTag, fields.policy))
+	for n := 0; n < keySetEpochKeys; n++ {
+		st.Fields = append(st.Fields,
+			datatype.NewField(uint8(keySetKey0Tag+n*2), fields.keys[n]),
+			datatype.NewField(uint8(keySetKey0Tag+n*2+1), fields.times[n]))
+	}
+	return st
+}
+
+// keySet validates the fields as KeySetWrite does, and returns the key set of the fields.
+func (fields *keySetFields) keySet() (*KeySet, error) {
+	set := &KeySet{
+		ID:        uint16(*fields.id),
+		Policy:    SecurityPolicy(*fields.policy),
+		EpochKeys: []EpochKey{},
+	}
+	if set.ID == IdentityProtectionKeySetID {
+		return nil, fmt.Errorf("%w : key set (%d) is the IPK", im.StatusInvalidCommand, set.ID)
+	}
+	if SecurityPolicyCacheAndSync < set.Policy {
+		return nil, fmt.Errorf("%w : security policy (%d)", im.StatusConstraintError, set.Policy)
+	}
+	for n := 0; n < keySetEpochKeys; n++ {
+		key, hasKey := fields.keys[n].Get()
+		start, hasStart := fields.times[n].Get()
+		if hasKey != hasStart {
+			return nil, fmt.Errorf("%w : epoch key (%d) and its start time are not set together", im.StatusInvalidCommand, n)
+		}
+		if !hasKey {
+			if n == 0 {
+				return nil, fmt.Errorf("%w : no epoch key (0)", im.StatusInvalidCommand)
+			}
+			// The later epoch keys must be null after a null epoch key.
+			for ; n < keySetEpochKeys; n++ {
+				if _, ok := fields.keys[n].Get(); ok {
+					return nil, fmt.Errorf("%w : epoch key (%d) follows a null epoch key", im.StatusInvalidCommand, n)
+				}
+				if _, ok := fields.times[n].Get(); ok {
+					return nil, fmt.Errorf("%w : epoch start time (%d) follows a null epoch key", im.StatusInvalidCommand, n)
+				}
+			}
+			break
+		}
+		if len(*key) != crypto.GroupEpochKeyLength {
+			return nil, fmt.Errorf("%w : epoch key (%d) length (%d)", im.StatusConstraintError, n, len(*key))
+		}
+		startTime := start.Time()
+		if 0 < n && !startTime.After(set.EpochKeys[n-1].StartTime) {
+			return nil, fmt.Errorf("%w : epoch start time (%d) is not after the previous one", im.StatusInvalidCommand, n)
+		}
+		set.EpochKeys = append(set.EpochKeys, EpochKey{Key: slices.Clone(*key), StartTime: startTime})
+	}
+	return set, nil
+}
+
+// newKeySetReadValue returns the GroupKeySetStruct of the key set for KeySetRead, whose epoch keys are null.
+func newKeySetReadValue(set *KeySet) (*datatype.Struct, error) {
+	fields := newKeySetFields()
+	*fields.id = datatype.Uint16(set.ID)
+	*fields.policy = datatype.Enum8(set.Policy)
+	for n, key := range set.EpochKeys {
+		start, err := datatype.NewEpochUs(key.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		fields.times[n].Set(&start)
+	}
+	return fields.value(), nil
+}
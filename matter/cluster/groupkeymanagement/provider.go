@@ -0,0 +1,114 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupkeymanagement
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/matter/crypto"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// OperationalKey represents an operational group key derived from an epoch key of a key set.
+type OperationalKey struct {
+	// FabricIndex is the fabric of the key set.
+	FabricIndex types.FabricIndex
+	// KeySetID is the key set ID.
+	KeySetID uint16
+	// SessionID is the group session ID of the key.
+	SessionID uint16
+	// Key is the operational group key.
+	Key []byte
+	// StartTime is the start time of the epoch key.
+	StartTime time.Time
+}
+
+// KeyProvider provides the operational group keys to encrypt and decrypt group messages.
+type KeyProvider interface {
+	// EncryptionKey returns the operational group key of the current epoch key which is mapped to the group.
+	EncryptionKey(fabric types.FabricIndex, group message.GroupID) (*OperationalKey, error)
+	// DecryptionKeys returns the operational group keys of all epoch keys whose group session ID is the specified one.
+	DecryptionKeys(sessionID uint16) ([]*OperationalKey, error)
+}
+
+var _ KeyProvider = (*Cluster)(nil)
+
+// EncryptionKey returns the operational group key of the current epoch key of the key set which is mapped to the group.
+func (cluster *Cluster) EncryptionKey(fabric types.FabricIndex, group message.GroupID) (*OperationalKey, error) {
+	keySetID, ok := cluster.GroupKeyMap(fabric)[group]
+	if !ok {
+		return nil, fmt.Errorf("%w group (0x%04X) of fabric (%d)", ErrNotFound, uint16(group), fabric)
+	}
+	set, ok := cluster.LookupKeySet(fabric, keySetID)
+	if !ok {
+		return nil, fmt.Errorf("%w key set (%d) of fabric (%d)", ErrNotFound, keySetID, fabric)
+	}
+	epochKey, ok := set.currentEpochKey(cluster.now())
+	if !ok {
+		return nil, fmt.Errorf("%w current epoch key of key set (%d)", ErrNotFound, keySetID)
+	}
+	return cluster.operationalKey(fabric, keySetID, epochKey)
+}
+
+// DecryptionKeys returns the operational group keys of all epoch keys of all fabrics whose group
+// session ID is the specified one, which the receivers try in turn to decrypt group messages.
+func (cluster *Cluster) DecryptionKeys(sessionID uint16) ([]*OperationalKey, error) {
+	cluster.mutex.Lock()
+	sets := map[keySetKey]*KeySet{}
+	for key, set := range cluster.keySets {
+		if key.id != IdentityProtectionKeySetID {
+			sets[key] = set.Clone()
+		}
+	}
+	cluster.mutex.Unlock()
+
+	keys := []*OperationalKey{}
+	for key, set := range sets {
+		for _, epochKey := range set.EpochKeys {
+			opKey, err := cluster.operationalKey(key.fabric, key.id, epochKey)
+			if err != nil {
+				return nil, err
+			}
+			if opKey.SessionID == sessionID {
+				keys = append(keys, opKey)
+			}
+		}
+	}
+	return keys, nil
+}
+
+func (cluster *Cluster) operationalKey(fabric types.FabricIndex, keySetID uint16, epochKey EpochKey) (*OperationalKey, error) {
+	compressedID, ok := cluster.compressedIDs(fabric)
+	if !ok {
+		return nil, fmt.Errorf("%w compressed fabric ID of fabric (%d)", ErrNotFound, fabric)
+	}
+	key, err := crypto.GroupOperationalKey(epochKey.Key, compressedID)
+	if err != nil {
+		return nil, err
+	}
+	sessionID, err := crypto.GroupSessionID(key)
+	if err != nil {
+		return nil, err
+	}
+	return &OperationalKey{
+		FabricIndex: fabric,
+		KeySetID:    keySetID,
+		SessionID:   sessionID,
+		Key:         key,
+		StartTime:   epochKey.StartTime,
+	}, nil
+}
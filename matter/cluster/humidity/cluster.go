@@ -0,0 +1,46 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package humidity
+
+import (
+	"github.com/cybergarage/go-matter/matter/cluster/measurement"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// 2.6. Relative Humidity Measurement Cluster
+const (
+	ClusterID       im.ClusterID = 0x0405
+	ClusterRevision              = 3
+)
+
+// Definition is the definition of the Relative Humidity Measurement cluster, whose values are fed in
+// percent and measured in hundredths of a percent.
+var Definition = &measurement.Definition{
+	ID:       ClusterID,
+	Revision: ClusterRevision,
+	Scale:    100,
+	Min:      0,
+	Max:      datatype.Percent100thsMax,
+	NewValue: func(v int64) datatype.Value {
+		h := datatype.Uint16(v)
+		return &h
+	},
+}
+
+// NewCluster returns a new Relative Humidity Measurement cluster.
+func NewCluster(opts ...measurement.Option) (*measurement.Cluster, error) {
+	return measurement.NewCluster(Definition, opts...)
+}
@@ -0,0 +1,266 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identify
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// 1.2. Identify Cluster
+const (
+	ClusterID       im.ClusterID = 0x0003
+	ClusterRevision              = 4
+
+	IdentifyTimeAttributeID im.AttributeID = 0x0000
+	IdentifyTypeAttributeID im.AttributeID = 0x0001
+
+	IdentifyCommandID      im.CommandID = 0x00
+	TriggerEffectCommandID im.CommandID = 0x40
+)
+
+// Type represents how the device identifies itself.
+type Type uint8
+
+const (
+	TypeNone             Type = 0x00
+	TypeLightOutput      Type = 0x01
+	TypeVisibleIndicator Type = 0x02
+	TypeAudibleBeep      Type = 0x03
+	TypeDisplay          Type = 0x04
+	TypeActuator         Type = 0x05
+)
+
+// Effect represents an identification effect of TriggerEffect.
+type Effect uint8
+
+const (
+	EffectBlink         Effect = 0x00
+	EffectBreathe       Effect = 0x01
+	EffectOkay          Effect = 0x02
+	EffectChannelChange Effect = 0x0B
+	EffectFinish        Effect = 0xFE
+	EffectStop          Effect = 0xFF
+)
+
+// EffectVariant represents a variant of an identification effect.
+type EffectVariant uint8
+
+const (
+	EffectVariantDefault EffectVariant = 0x00
+)
+
+// IsValid returns true if the effect is defined.
+func (effect Effect) IsValid() bool {
+	switch effect {
+	case EffectBlink, EffectBreathe, EffectOkay, EffectChannelChange, EffectFinish, EffectStop:
+		return true
+	}
+	return false
+}
+
+// Handler is called when the device starts or stops identifying itself, such as by blinking an LED.
+type Handler func(identifying bool)
+
+// EffectHandler is called when a client triggers an identification effect. EffectFinish asks to
+// complete the current effect, and EffectStop to stop it as soon as possible.
+type EffectHandler func(effect Effect, variant EffectVariant)
+
+// Cluster represents an Identify cluster server.
+type Cluster struct {
+	*datamodel.BaseCluster
+	mutex         sync.Mutex
+	handler       Handler
+	effectHandler EffectHandler
+	deadline      time.Time
+	timer         *time.Timer
+	generation    uint64
+}
+
+// Option represents an option of the identify cluster.
+type Option func(*Cluster)
+
+// WithHandler sets the handler which is called when the device starts or stops identifying itself.
+func WithHandler(handler Handler) Option {
+	return func(cluster *Cluster) {
+		cluster.handler = handler
+	}
+}
+
+// WithEffectHandler sets the handler which is called when a client triggers an identification effect.
+func WithEffectHandler(handler EffectHandler) Option {
+	return func(cluster *Cluster) {
+		cluster.effectHandler = handler
+	}
+}
+
+// NewCluster returns a new identify cluster of the specified identify type.
+func NewCluster(typ Type, opts ...Option) *Cluster {
+	cluster := &Cluster{
+		BaseCluster:   datamodel.NewBaseCluster(ClusterID, ClusterRevision),
+		mutex:         sync.Mutex{},
+		handler:       nil,
+		effectHandler: nil,
+		deadline:      time.Time{},
+		timer:         nil,
+		generation:    0,
+	}
+	for _, opt := range opts {
+		opt(cluster)
+	}
+
+	identifyTime := datatype.Uint16(0)
+	identifyType := datatype.Enum8(typ)
+	cluster.AddAttribute(datamodel.NewWritableAttribute(IdentifyTimeAttributeID, &identifyTime))
+	cluster.AddAttribute(datamodel.NewAttribute(IdentifyTypeAttributeID, &identifyType))
+	cluster.AddCommand(IdentifyCommandID, cluster.identify)
+	cluster.AddCommand(TriggerEffectCommandID, cluster.triggerEffect)
+
+	return cluster
+}
+
+// Identify starts identifying for the specified duration which is rounded up to seconds, or stops
+// identifying if the duration is zero.
+func (cluster *Cluster) Identify(d time.Duration) {
+	seconds := math.Ceil(d.Seconds())
+	if math.MaxUint16 < seconds {
+		seconds = math.MaxUint16
+	}
+	cluster.startIdentify(uint16(seconds))
+}
+
+// IsIdentifying returns true if the device is identifying itself.
+func (cluster *Cluster) IsIdentifying() bool {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	return cluster.timer != nil
+}
+
+// RemainingTime returns the remaining time to identify.
+func (cluster *Cluster) RemainingTime() time.Duration {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	if cluster.timer == nil {
+		return 0
+	}
+	return time.Until(cluster.deadline)
+}
+
+// startIdentify starts or restarts identifying for the specified seconds. The handler is called only
+// when the identification state changes, and IdentifyTime changes its data version only then since
+// its countdown is reported with quieted reporting.
+func (cluster *Cluster) startIdentify(seconds uint16) {
+	cluster.mutex.Lock()
+	cluster.restartIdentify(seconds)
+}
+
+// restartIdentify restarts identifying with the mutex locked, and unlocks it.
+func (cluster *Cluster) restartIdentify(seconds uint16) {
+	wasIdentifying := cluster.timer != nil
+	if cluster.timer != nil {
+		cluster.timer.Stop()
+		cluster.timer = nil
+	}
+	cluster.generation++
+	if 0 < seconds {
+		d := time.Duration(seconds) * time.Second
+		generation := cluster.generation
+		cluster.deadline = time.Now().Add(d)
+		cluster.timer = time.AfterFunc(d, func() {
+			cluster.expire(generation)
+		})
+	}
+	identifying := cluster.timer != nil
+	handler := cluster.handler
+	cluster.mutex.Unlock()
+
+	identifyTime := datatype.Uint16(seconds)
+	cluster.SetAttribute(IdentifyTimeAttributeID, &identifyTime)
+	if identifying != wasIdentifying && handler != nil {
+		handler(identifying)
+	}
+}
+
+// expire stops identifying if identification has not been restarted since the timer of the specified generation started.
+func (cluster *Cluster) expire(generation uint64) {
+	cluster.mutex.Lock()
+	if cluster.generation != generation {
+		cluster.mutex.Unlock()
+		return
+	}
+	cluster.restartIdentify(0)
+}
+
+// ReadAttribute returns the value of the specified attribute, and the remaining seconds as IdentifyTime.
+func (cluster *Cluster) ReadAttribute(id im.AttributeID) (datatype.Value, error) {
+	if id != IdentifyTimeAttributeID {
+		return cluster.BaseCluster.ReadAttribute(id)
+	}
+	remaining := datatype.Uint16(math.Ceil(cluster.RemainingTime().Seconds()))
+	return &remaining, nil
+}
+
+// WriteAttribute writes the specified attribute, and starts or stops identifying when IdentifyTime is written.
+func (cluster *Cluster) WriteAttribute(id im.AttributeID, data []byte) error {
+	if id != IdentifyTimeAttributeID {
+		return cluster.BaseCluster.WriteAttribute(id, data)
+	}
+	identifyTime := new(datatype.Uint16)
+	if err := datatype.Decode(data, identifyTime); err != nil {
+		return fmt.Errorf("%w : %w", im.StatusInvalidDataType, err)
+	}
+	cluster.startIdentify(uint16(*identifyTime))
+	return nil
+}
+
+func (cluster *Cluster) identify(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	identifyTime := new(datatype.Uint16)
+	if err := req.DecodeFields(datatype.NewStruct(datatype.NewField(0, identifyTime))); err != nil {
+		return nil, err
+	}
+	cluster.startIdentify(uint16(*identifyTime))
+	return nil, nil
+}
+
+func (cluster *Cluster) triggerEffect(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	effect := new(datatype.Enum8)
+	variant := new(datatype.Enum8)
+	err := req.DecodeFields(datatype.NewStruct(
+		datatype.NewField(0, effect),
+		datatype.NewField(1, variant)))
+	if err != nil {
+		return nil, err
+	}
+	if !Effect(*effect).IsValid() {
+		return nil, fmt.Errorf("%w : effect (0x%02X)", im.StatusConstraintError, *effect)
+	}
+	// Unsupported variants fall back to the default variant.
+	if EffectVariant(*variant) != EffectVariantDefault {
+		*variant = datatype.Enum8(EffectVariantDefault)
+	}
+	cluster.mutex.Lock()
+	handler := cluster.effectHandler
+	cluster.mutex.Unlock()
+	if handler != nil {
+		handler(Effect(*effect), EffectVariant(*variant))
+	}
+	return nil, nil
+}
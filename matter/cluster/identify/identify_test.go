@@ -0,0 +1,113 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/datamodel/datamodeltest"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+func identifyFields(seconds uint16) *datatype.Struct {
+	v := datatype.Uint16(seconds)
+	return datatype.NewStruct(datatype.NewField(0, &v))
+}
+
+func effectFields(effect Effect, variant EffectVariant) *datatype.Struct {
+	e := datatype.Enum8(effect)
+	v := datatype.Enum8(variant)
+	return datatype.NewStruct(datatype.NewField(0, &e), datatype.NewField(1, &v))
+}
+
+func TestIdentifyCluster(t *testing.T) {
+	states := []bool{}
+	cluster := NewCluster(TypeVisibleIndicator, WithHandler(func(identifying bool) {
+		states = append(states, identifying)
+	}))
+
+	if _, err := datamodeltest.Invoke(t, cluster, 1, IdentifyCommandID, 0, identifyFields(30)); err != nil {
+		t.Fatal(err)
+	}
+	if !cluster.IsIdentifying() {
+		t.Error("not identifying")
+	}
+	v, err := cluster.ReadAttribute(IdentifyTimeAttributeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remaining := *v.(*datatype.Uint16); remaining < 29 || 30 < remaining {
+		t.Errorf("identify time (%d)", remaining)
+	}
+
+	// Restarting does not call the handler again.
+	if _, err := datamodeltest.Invoke(t, cluster, 1, IdentifyCommandID, 0, identifyFields(60)); err != nil {
+		t.Fatal(err)
+	}
+	if err := cluster.WriteAttribute(IdentifyTimeAttributeID, []byte{0x04, 0x00}); err != nil {
+		t.Fatal(err)
+	}
+	if cluster.IsIdentifying() {
+		t.Error("identifying")
+	}
+	if len(states) != 2 || !states[0] || states[1] {
+		t.Errorf("states %v", states)
+	}
+}
+
+func TestIdentifyExpiration(t *testing.T) {
+	stopped := make(chan struct{})
+	cluster := NewCluster(TypeLightOutput, WithHandler(func(identifying bool) {
+		if !identifying {
+			close(stopped)
+		}
+	}))
+	cluster.Identify(time.Millisecond)
+	select {
+	case <-stopped:
+	case <-time.After(3 * time.Second):
+		t.Fatal("identification is not expired")
+	}
+	if v, _ := cluster.ReadAttribute(IdentifyTimeAttributeID); *v.(*datatype.Uint16) != 0 {
+		t.Errorf("identify time (%d)", *v.(*datatype.Uint16))
+	}
+}
+
+func TestTriggerEffect(t *testing.T) {
+	var triggered []Effect
+	var variants []EffectVariant
+	cluster := NewCluster(TypeLightOutput, WithEffectHandler(func(effect Effect, variant EffectVariant) {
+		triggered = append(triggered, effect)
+		variants = append(variants, variant)
+	}))
+
+	if _, err := datamodeltest.Invoke(t, cluster, 1, TriggerEffectCommandID, 0, effectFields(EffectBreathe, 0x05)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := datamodeltest.Invoke(t, cluster, 1, TriggerEffectCommandID, 0, effectFields(EffectStop, EffectVariantDefault)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := datamodeltest.Invoke(t, cluster, 1, TriggerEffectCommandID, 0, effectFields(0x10, EffectVariantDefault)); im.StatusOf(err) != im.StatusConstraintError {
+		t.Errorf("%v is not %s", err, im.StatusConstraintError)
+	}
+	if len(triggered) != 2 || triggered[0] != EffectBreathe || triggered[1] != EffectStop {
+		t.Errorf("effects %v", triggered)
+	}
+	if variants[0] != EffectVariantDefault {
+		t.Errorf("variant (%d) is not the default variant", variants[0])
+	}
+}
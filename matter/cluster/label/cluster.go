@@ -0,0 +1,209 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/storage"
+)
+
+// 9.8. Fixed Label Cluster, 9.9. User Label Cluster
+const (
+	FixedLabelClusterID       im.ClusterID = 0x0040
+	FixedLabelClusterRevision              = 1
+	UserLabelClusterID        im.ClusterID = 0x0041
+	UserLabelClusterRevision               = 1
+
+	LabelListAttributeID im.AttributeID = 0x0000
+
+	// MinUserLabels is the minimum number of user labels which an endpoint supports.
+	MinUserLabels = 4
+
+	// Namespace is the storage namespace of the user labels, which are kept across reboots.
+	Namespace = "userlabel"
+)
+
+// FixedLabelCluster represents a Fixed Label cluster server, whose labels are set by the manufacturer
+// and can not be changed by clients.
+type FixedLabelCluster struct {
+	*datamodel.BaseCluster
+	labels []Label
+}
+
+// NewFixedLabelCluster returns a new fixed label cluster of the specified labels.
+func NewFixedLabelCluster(labels []Label) (*FixedLabelCluster, error) {
+	for _, l := range labels {
+		if err := l.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	cluster := &FixedLabelCluster{
+		BaseCluster: datamodel.NewBaseCluster(FixedLabelClusterID, FixedLabelClusterRevision),
+		labels:      append([]Label{}, labels...),
+	}
+	cluster.AddAttribute(datamodel.NewAttribute(LabelListAttributeID, NewLabelList(cluster.labels)))
+	return cluster, nil
+}
+
+// Labels returns the fixed labels.
+func (cluster *FixedLabelCluster) Labels() []Label {
+	return append([]Label{}, cluster.labels...)
+}
+
+// UserLabelCluster represents a User Label cluster server, whose labels are written by administrators
+// to give names such as rooms to the endpoint.
+type UserLabelCluster struct {
+	*datamodel.BaseCluster
+	mutex    sync.Mutex
+	endpoint im.EndpointID
+	capacity int
+	store    storage.Store
+}
+
+// UserLabelOption represents an option of the user label cluster.
+type UserLabelOption func(*UserLabelCluster)
+
+// WithCapacity sets the maximum number of the user labels, which is not less than MinUserLabels.
+func WithCapacity(n int) UserLabelOption {
+	return func(cluster *UserLabelCluster) {
+		cluster.capacity = max(n, MinUserLabels)
+	}
+}
+
+// WithStore sets the store which keeps the user labels across reboots.
+func WithStore(store storage.Store) UserLabelOption {
+	return func(cluster *UserLabelCluster) {
+		cluster.store = store
+	}
+}
+
+// NewUserLabelCluster returns a new user label cluster of the specified endpoint. The labels are
+// loaded from the store if it is set.
+func NewUserLabelCluster(endpoint im.EndpointID, opts ...UserLabelOption) (*UserLabelCluster, error) {
+	cluster := &UserLabelCluster{
+		BaseCluster: datamodel.NewBaseCluster(UserLabelClusterID, UserLabelClusterRevision),
+		mutex:       sync.Mutex{},
+		endpoint:    endpoint,
+		capacity:    MinUserLabels,
+		store:       nil,
+	}
+	for _, opt := range opts {
+		opt(cluster)
+	}
+
+	list := NewLabelList(nil)
+	if cluster.store != nil {
+		b, err := cluster.store.Get(Namespace, cluster.key())
+		switch {
+		case err == nil:
+			if err := datatype.Decode(b, list); err != nil {
+				return nil, fmt.Errorf("%w stored labels : %w", ErrInvalid, err)
+			}
+		case errors.Is(err, storage.ErrNotFound):
+		default:
+			return nil, err
+		}
+	}
+
+	attr := datamodel.NewWritableAttribute(LabelListAttributeID, list)
+	attr.WritePrivilege = datamodel.ManagePrivilege
+	attr.Constraint = cluster.validate
+	cluster.AddAttribute(attr)
+
+	return cluster, nil
+}
+
+func (cluster *UserLabelCluster) key() string {
+	return fmt.Sprintf("%04X", uint16(cluster.endpoint))
+}
+
+// validate returns RESOURCE_EXHAUSTED if the labels exceed the capacity, or CONSTRAINT_ERROR if a label is too long.
+func (cluster *UserLabelCluster) validate(v datatype.Value) error {
+	labels := NewLabelsFromList(v.(*datatype.List))
+	if cluster.capacity < len(labels) {
+		return fmt.Errorf("%w : labels (%d) exceed %d", im.StatusResourceExhausted, len(labels), cluster.capacity)
+	}
+	for _, l := range labels {
+		if err := l.Validate(); err != nil {
+			return fmt.Errorf("%w : %w", im.StatusConstraintError, err)
+		}
+	}
+	return nil
+}
+
+// Capacity returns the maximum number of the user labels.
+func (cluster *UserLabelCluster) Capacity() int {
+	return cluster.capacity
+}
+
+// Labels returns the user labels.
+func (cluster *UserLabelCluster) Labels() []Label {
+	v, err := cluster.ReadAttribute(LabelListAttributeID)
+	if err != nil {
+		return []Label{}
+	}
+	return NewLabelsFromList(v.(*datatype.List))
+}
+
+// SetLabels replaces the user labels locally.
+func (cluster *UserLabelCluster) SetLabels(labels []Label) error {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	list := NewLabelList(labels)
+	if err := cluster.validate(list); err != nil {
+		return fmt.Errorf("%w labels : %w", ErrInvalid, err)
+	}
+	if err := cluster.SetAttribute(LabelListAttributeID, list); err != nil {
+		return err
+	}
+	return cluster.save(list)
+}
+
+// WriteAttribute writes the specified attribute, and saves the written labels into the store.
+func (cluster *UserLabelCluster) WriteAttribute(id im.AttributeID, data []byte) error {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	if err := cluster.BaseCluster.WriteAttribute(id, data); err != nil {
+		return err
+	}
+	if id != LabelListAttributeID {
+		return nil
+	}
+	v, err := cluster.BaseCluster.ReadAttribute(LabelListAttributeID)
+	if err != nil {
+		return err
+	}
+	return cluster.save(v)
+}
+
+func (cluster *UserLabelCluster) save(v datatype.Value) error {
+	if cluster.store == nil {
+		return nil
+	}
+	b, err := datatype.Encode(v)
+	if err != nil {
+		return err
+	}
+	if err := cluster.store.Set(Namespace, cluster.key(), b); err != nil {
+		return fmt.Errorf("%w : %w", im.StatusFailure, err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+)
+
+const (
+	// MaxLabelLength is the maximum length of the labels in bytes.
+	MaxLabelLength = 16
+	// MaxValueLength is the maximum length of the label values in bytes.
+	MaxValueLength = 16
+)
+
+// 9.7.4.1. LabelStruct Type
+// Label represents a label and value pair, such as "room" and "bedroom 2".
+type Label struct {
+	// Label is the name of the label.
+	Label string
+	// Value is the value of the label.
+	Value string
+}
+
+// NewLabel returns a new label of the specified name and value.
+func NewLabel(label string, value string) Label {
+	return Label{
+		Label: label,
+		Value: value,
+	}
+}
+
+// Validate returns an error if the label or the value is too long.
+func (l Label) Validate() error {
+	if MaxLabelLength < len(l.Label) {
+		return fmt.Errorf("%w label (%s) : longer than %d", ErrInvalid, l.Label, MaxLabelLength)
+	}
+	if MaxValueLength < len(l.Value) {
+		return fmt.Errorf("%w label (%s) value (%s) : longer than %d", ErrInvalid, l.Label, l.Value, MaxValueLength)
+	}
+	return nil
+}
+
+// Lookup returns the value of the first label of the specified name.
+func Lookup(labels []Label, name string) (string, bool) {
+	for _, l := range labels {
+		if l.Label == name {
+			return l.Value, true
+		}
+	}
+	return "", false
+}
+
+// NewLabelList returns a list value of the specified labels.
+func NewLabelList(labels []Label) *datatype.List {
+	list := datatype.NewList(func() datatype.Value { return newLabelStruct(nil) })
+	for n := range labels {
+		list.Elements = append(list.Elements, newLabelStruct(&labels[n]))
+	}
+	return list
+}
+
+func newLabelStruct(l *Label) *datatype.Struct {
+	label := new(datatype.String)
+	value := new(datatype.String)
+	if l != nil {
+		*label = datatype.String(l.Label)
+		*value = datatype.String(l.Value)
+	}
+	return datatype.NewStruct(
+		datatype.NewField(0, label),
+		datatype.NewField(1, value))
+}
+
+// NewLabelsFromList returns the labels of the list value.
+func NewLabelsFromList(list *datatype.List) []Label {
+	labels := make([]Label, 0, len(list.Elements))
+	for _, elem := range list.Elements {
+		st, ok := elem.(*datatype.Struct)
+		if !ok {
+			continue
+		}
+		label, _ := st.LookupField(0)
+		value, _ := st.LookupField(1)
+		labels = append(labels, Label{
+			Label: string(*label.Value.(*datatype.String)),
+			Value: string(*value.Value.(*datatype.String)),
+		})
+	}
+	return labels
+}
+
+// DecodeLabels returns the labels of the TLV encoded label list.
+func DecodeLabels(b []byte) ([]Label, error) {
+	list := NewLabelList(nil)
+	if err := datatype.Decode(b, list); err != nil {
+		return nil, err
+	}
+	return NewLabelsFromList(list), nil
+}
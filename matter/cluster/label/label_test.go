@@ -0,0 +1,134 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/storage"
+)
+
+func encodeLabels(t *testing.T, labels ...Label) []byte {
+	t.Helper()
+	b, err := datatype.Encode(NewLabelList(labels))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestFixedLabelCluster(t *testing.T) {
+	labels := []Label{NewLabel("room", "bedroom 2"), NewLabel("orientation", "North")}
+	cluster, err := NewFixedLabelCluster(labels)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := cluster.ReadAttribute(LabelListAttributeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := NewLabelsFromList(v.(*datatype.List)); !slices.Equal(got, labels) {
+		t.Errorf("%v != %v", got, labels)
+	}
+	if err := cluster.WriteAttribute(LabelListAttributeID, encodeLabels(t)); im.StatusOf(err) != im.StatusUnsupportedWrite {
+		t.Errorf("%v is not %s", err, im.StatusUnsupportedWrite)
+	}
+
+	if _, err := NewFixedLabelCluster([]Label{NewLabel("room", "a room with a long name")}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+}
+
+func TestUserLabelCluster(t *testing.T) {
+	store := storage.NewMemoryStore()
+	cluster, err := NewUserLabelCluster(1, WithStore(store))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cluster.Labels()) != 0 || cluster.Capacity() != MinUserLabels {
+		t.Fatalf("labels %v capacity (%d)", cluster.Labels(), cluster.Capacity())
+	}
+
+	// Writing the labels requires the manage privilege.
+	ep := datamodel.NewEndpoint(1)
+	if err := ep.AddCluster(cluster); err != nil {
+		t.Fatal(err)
+	}
+	node := datamodel.NewNode()
+	if err := node.AddEndpoint(ep); err != nil {
+		t.Fatal(err)
+	}
+	labels := []Label{NewLabel("room", "kitchen"), NewLabel("zone", "downstairs")}
+	req := &datamodel.WriteRequest{
+		Path:        im.NewAttributePath(1, UserLabelClusterID, LabelListAttributeID),
+		Data:        encodeLabels(t, labels...),
+		FabricIndex: 1,
+		Privilege:   datamodel.OperatePrivilege,
+		Timed:       false,
+	}
+	if err := node.WriteAttribute(req); im.StatusOf(err) != im.StatusUnsupportedAccess {
+		t.Errorf("%v is not %s", err, im.StatusUnsupportedAccess)
+	}
+	req.Privilege = datamodel.ManagePrivilege
+	if err := node.WriteAttribute(req); err != nil {
+		t.Fatal(err)
+	}
+	if got := cluster.Labels(); !slices.Equal(got, labels) {
+		t.Errorf("%v != %v", got, labels)
+	}
+
+	// Invalid writes are rejected, and the previous labels are kept.
+	long := NewLabel("room", "a room with a long name")
+	if err := cluster.WriteAttribute(LabelListAttributeID, encodeLabels(t, long)); im.StatusOf(err) != im.StatusConstraintError {
+		t.Errorf("%v is not %s", err, im.StatusConstraintError)
+	}
+	many := []Label{}
+	for n := 0; n <= MinUserLabels; n++ {
+		many = append(many, NewLabel("tag", "value"))
+	}
+	if err := cluster.WriteAttribute(LabelListAttributeID, encodeLabels(t, many...)); im.StatusOf(err) != im.StatusResourceExhausted {
+		t.Errorf("%v is not %s", err, im.StatusResourceExhausted)
+	}
+	if err := cluster.SetLabels(many); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+	if got := cluster.Labels(); !slices.Equal(got, labels) {
+		t.Errorf("%v != %v", got, labels)
+	}
+
+	// The labels are restored from the store.
+	restored, err := NewUserLabelCluster(1, WithStore(store), WithCapacity(8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := restored.Labels(); !slices.Equal(got, labels) {
+		t.Errorf("%v != %v", got, labels)
+	}
+	if err := restored.SetLabels(many); err != nil {
+		t.Error(err)
+	}
+	other, err := NewUserLabelCluster(2, WithStore(store))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(other.Labels()) != 0 {
+		t.Errorf("labels of another endpoint %v", other.Labels())
+	}
+}
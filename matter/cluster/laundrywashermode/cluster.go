@@ -0,0 +1,50 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package laundrywashermode
+
+import (
+	"github.com/cybergarage/go-matter/matter/cluster/modebase"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// Laundry Washer Mode Cluster
+const (
+	ClusterID       im.ClusterID = 0x0051
+	ClusterRevision              = 2
+
+	ModeTagNormal   modebase.ModeTag = 0x4000
+	ModeTagDelicate modebase.ModeTag = 0x4001
+	ModeTagHeavy    modebase.ModeTag = 0x4002
+	ModeTagWhites   modebase.ModeTag = 0x4003
+)
+
+// Definition is the definition of the Laundry Washer Mode cluster, which requires a normal mode.
+var Definition = &modebase.Definition{
+	ID:       ClusterID,
+	Revision: ClusterRevision,
+	ModeTags: map[modebase.ModeTag]string{
+		ModeTagNormal:   "Normal",
+		ModeTagDelicate: "Delicate",
+		ModeTagHeavy:    "Heavy",
+		ModeTagWhites:   "Whites",
+	},
+	RequiredModeTags:    []modebase.ModeTag{ModeTagNormal},
+	SupportsStartUpMode: false,
+}
+
+// NewCluster returns a new Laundry Washer Mode cluster of the specified modes.
+func NewCluster(modes []modebase.ModeOption, current uint8, opts ...modebase.Option) (*modebase.Cluster, error) {
+	return modebase.NewCluster(Definition, modes, current, opts...)
+}
@@ -0,0 +1,374 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localization
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// 11.3. Localization Configuration Cluster, 11.4. Time Format Localization Cluster,
+// 11.5. Unit Localization Cluster
+const (
+	ConfigurationClusterID       im.ClusterID = 0x002B
+	ConfigurationClusterRevision              = 1
+	TimeFormatClusterID          im.ClusterID = 0x002C
+	TimeFormatClusterRevision                 = 1
+	UnitClusterID                im.ClusterID = 0x002D
+	UnitClusterRevision                       = 1
+
+	// FeatureCalendarFormat indicates that the calendar type can be configured.
+	FeatureCalendarFormat uint32 = 0x01
+	// FeatureTemperatureUnit indicates that the temperature unit can be configured.
+	FeatureTemperatureUnit uint32 = 0x01
+
+	ActiveLocaleAttributeID           im.AttributeID = 0x0000
+	SupportedLocalesAttributeID       im.AttributeID = 0x0001
+	HourFormatAttributeID             im.AttributeID = 0x0000
+	ActiveCalendarTypeAttributeID     im.AttributeID = 0x0001
+	SupportedCalendarTypesAttributeID im.AttributeID = 0x0002
+	TemperatureUnitAttributeID        im.AttributeID = 0x0000
+
+	// MaxLocaleLength is the maximum length of the locale tags in bytes.
+	MaxLocaleLength = 35
+	// MaxSupportedLocales is the maximum number of the supported locales.
+	MaxSupportedLocales = 32
+
+	// DefaultLocale is the locale of the default clusters.
+	DefaultLocale = "en-US"
+)
+
+// HourFormat represents an hour format (HourFormatEnum).
+type HourFormat uint8
+
+const (
+	HourFormat12hr            HourFormat = 0x00
+	HourFormat24hr            HourFormat = 0x01
+	HourFormatUseActiveLocale HourFormat = 0xFF
+)
+
+// CalendarType represents a calendar type (CalendarTypeEnum).
+type CalendarType uint8
+
+const (
+	CalendarTypeBuddhist        CalendarType = 0x00
+	CalendarTypeChinese         CalendarType = 0x01
+	CalendarTypeCoptic          CalendarType = 0x02
+	CalendarTypeEthiopian       CalendarType = 0x03
+	CalendarTypeGregorian       CalendarType = 0x04
+	CalendarTypeHebrew          CalendarType = 0x05
+	CalendarTypeIndian          CalendarType = 0x06
+	CalendarTypeIslamic         CalendarType = 0x07
+	CalendarTypeJapanese        CalendarType = 0x08
+	CalendarTypeKorean          CalendarType = 0x09
+	CalendarTypePersian         CalendarType = 0x0A
+	CalendarTypeTaiwanese       CalendarType = 0x0B
+	CalendarTypeUseActiveLocale CalendarType = 0xFF
+)
+
+// TemperatureUnit represents a temperature unit (TempUnitEnum).
+type TemperatureUnit uint8
+
+const (
+	TemperatureUnitFahrenheit TemperatureUnit = 0x00
+	TemperatureUnitCelsius    TemperatureUnit = 0x01
+	TemperatureUnitKelvin     TemperatureUnit = 0x02
+)
+
+// ConfigurationCluster represents a Localization Configuration cluster server, whose active locale
+// is written by clients from the supported locales.
+type ConfigurationCluster struct {
+	*datamodel.BaseCluster
+	supported []string
+}
+
+// NewConfigurationCluster returns a new localization configuration cluster of the specified active
+// locale and supported locales, which must include the active locale.
+func NewConfigurationCluster(active string, supported []string) (*ConfigurationCluster, error) {
+	if MaxSupportedLocales < len(supported) {
+		return nil, fmt.Errorf("%w supported locales (%d) : more than %d", ErrInvalid, len(supported), MaxSupportedLocales)
+	}
+	for _, locale := range supported {
+		if MaxLocaleLength < len(locale) {
+			return nil, fmt.Errorf("%w locale (%s) : longer than %d", ErrInvalid, locale, MaxLocaleLength)
+		}
+	}
+	if !slices.Contains(supported, active) {
+		return nil, fmt.Errorf("%w active locale (%s) : not supported", ErrInvalid, active)
+	}
+	cluster := &ConfigurationCluster{
+		BaseCluster: datamodel.NewBaseCluster(ConfigurationClusterID, ConfigurationClusterRevision),
+		supported:   slices.Clone(supported),
+	}
+
+	activeLocale := datatype.String(active)
+	supportedLocales := datatype.NewList(func() datatype.Value { return new(datatype.String) })
+	for _, locale := range cluster.supported {
+		v := datatype.String(locale)
+		supportedLocales.Elements = append(supportedLocales.Elements, &v)
+	}
+	attr := datamodel.NewWritableAttribute(ActiveLocaleAttributeID, &activeLocale)
+	attr.WritePrivilege = datamodel.ManagePrivilege
+	attr.Constraint = cluster.validateLocale
+	cluster.AddAttribute(attr)
+	cluster.AddAttribute(datamodel.NewAttribute(SupportedLocalesAttributeID, supportedLocales))
+
+	return cluster, nil
+}
+
+// validateLocale returns CONSTRAINT_ERROR if the written locale is not supported.
+func (cluster *ConfigurationCluster) validateLocale(v datatype.Value) error {
+	locale, ok := v.(*datatype.String)
+	if !ok || !slices.Contains(cluster.supported, string(*locale)) {
+		return fmt.Errorf("%w : locale (%v) is not supported", im.StatusConstraintError, v)
+	}
+	return nil
+}
+
+// ActiveLocale returns the active locale.
+func (cluster *ConfigurationCluster) ActiveLocale() string {
+	v, err := cluster.ReadAttribute(ActiveLocaleAttributeID)
+	if err != nil {
+		return ""
+	}
+	locale, ok := v.(*datatype.String)
+	if !ok {
+		return ""
+	}
+	return string(*locale)
+}
+
+// SupportedLocales returns the supported locales.
+func (cluster *ConfigurationCluster) SupportedLocales() []string {
+	return slices.Clone(cluster.supported)
+}
+
+// TimeFormatCluster represents a Time Format Localization cluster server.
+type TimeFormatCluster struct {
+	*datamodel.BaseCluster
+	hourFormat HourFormat
+	calendar   CalendarType
+	calendars  []CalendarType
+}
+
+// TimeFormatOption represents an option of the time format localization cluster.
+type TimeFormatOption func(*TimeFormatCluster)
+
+// WithHourFormat sets the initial hour format.
+func WithHourFormat(format HourFormat) TimeFormatOption {
+	return func(cluster *TimeFormatCluster) {
+		cluster.hourFormat = format
+	}
+}
+
+// WithCalendarTypes enables the CalendarFormat feature of the specified initial and supported calendar types.
+func WithCalendarTypes(active CalendarType, supported ...CalendarType) TimeFormatOption {
+	return func(cluster *TimeFormatCluster) {
+		cluster.calendar = active
+		cluster.calendars = slices.Clone(supported)
+	}
+}
+
+// NewTimeFormatCluster returns a new time format localization cluster.
+func NewTimeFormatCluster(opts ...TimeFormatOption) (*TimeFormatCluster, error) {
+	cluster := &TimeFormatCluster{
+		BaseCluster: datamodel.NewBaseCluster(TimeFormatClusterID, TimeFormatClusterRevision),
+		hourFormat:  HourFormatUseActiveLocale,
+		calendar:    CalendarTypeUseActiveLocale,
+		calendars:   nil,
+	}
+	for _, opt := range opts {
+		opt(cluster)
+	}
+	if err := validateHourFormat(cluster.hourFormat); err != nil {
+		return nil, fmt.Errorf("%w %w", ErrInvalid, err)
+	}
+
+	hourFormat := datatype.Enum8(cluster.hourFormat)
+	attr := datamodel.NewWritableAttribute(HourFormatAttributeID, &hourFormat)
+	attr.WritePrivilege = datamodel.ManagePrivilege
+	attr.Constraint = func(v datatype.Value) error {
+		format, ok := v.(*datatype.Enum8)
+		if !ok {
+			return fmt.Errorf("%w : hour format is %s", im.StatusConstraintError, v.Type())
+		}
+		return validateHourFormat(HourFormat(*format))
+	}
+	cluster.AddAttribute(attr)
+
+	if cluster.calendars != nil {
+		if err := cluster.validateCalendarType(cluster.calendar); err != nil {
+			return nil, fmt.Errorf("%w %w", ErrInvalid, err)
+		}
+		cluster.SetFeatureMap(FeatureCalendarFormat)
+		calendar := datatype.Enum8(cluster.calendar)
+		calendars := datatype.NewList(func() datatype.Value { return new(datatype.Enum8) })
+		for _, c := range cluster.calendars {
+			v := datatype.Enum8(c)
+			calendars.Elements = append(calendars.Elements, &v)
+		}
+		attr := datamodel.NewWritableAttribute(ActiveCalendarTypeAttributeID, &calendar)
+		attr.WritePrivilege = datamodel.ManagePrivilege
+		attr.Constraint = func(v datatype.Value) error {
+			calendar, ok := v.(*datatype.Enum8)
+			if !ok {
+				return fmt.Errorf("%w : calendar type is %s", im.StatusConstraintError, v.Type())
+			}
+			return cluster.validateCalendarType(CalendarType(*calendar))
+		}
+		cluster.AddAttribute(attr)
+		cluster.AddAttribute(datamodel.NewAttribute(SupportedCalendarTypesAttributeID, calendars))
+	}
+
+	return cluster, nil
+}
+
+func validateHourFormat(format HourFormat) error {
+	switch format {
+	case HourFormat12hr, HourFormat24hr, HourFormatUseActiveLocale:
+		return nil
+	}
+	return fmt.Errorf("%w : hour format (%d)", im.StatusConstraintError, format)
+}
+
+// validateCalendarType returns CONSTRAINT_ERROR if the calendar type is not supported.
+func (cluster *TimeFormatCluster) validateCalendarType(calendar CalendarType) error {
+	if calendar == CalendarTypeUseActiveLocale || slices.Contains(cluster.calendars, calendar) {
+		return nil
+	}
+	return fmt.Errorf("%w : calendar type (%d) is not supported", im.StatusConstraintError, calendar)
+}
+
+// HasFeature returns true if the specified feature is supported.
+func (cluster *TimeFormatCluster) HasFeature(feature uint32) bool {
+	return cluster.FeatureMap()&feature != 0
+}
+
+// HourFormat returns the hour format.
+func (cluster *TimeFormatCluster) HourFormat() HourFormat {
+	return HourFormat(readEnum8(cluster.BaseCluster, HourFormatAttributeID, uint8(HourFormatUseActiveLocale)))
+}
+
+// ActiveCalendarType returns the calendar type, which is UseActiveLocale without the CalendarFormat feature.
+func (cluster *TimeFormatCluster) ActiveCalendarType() CalendarType {
+	return CalendarType(readEnum8(cluster.BaseCluster, ActiveCalendarTypeAttributeID, uint8(CalendarTypeUseActiveLocale)))
+}
+
+// UnitCluster represents a Unit Localization cluster server.
+type UnitCluster struct {
+	*datamodel.BaseCluster
+	unit *TemperatureUnit
+}
+
+// UnitOption represents an option of the unit localization cluster.
+type UnitOption func(*UnitCluster)
+
+// WithTemperatureUnit enables the TemperatureUnit feature of the specified initial unit.
+func WithTemperatureUnit(unit TemperatureUnit) UnitOption {
+	return func(cluster *UnitCluster) {
+		cluster.unit = &unit
+	}
+}
+
+// NewUnitCluster returns a new unit localization cluster.
+func NewUnitCluster(opts ...UnitOption) (*UnitCluster, error) {
+	cluster := &UnitCluster{
+		BaseCluster: datamodel.NewBaseCluster(UnitClusterID, UnitClusterRevision),
+		unit:        nil,
+	}
+	for _, opt := range opts {
+		opt(cluster)
+	}
+	if cluster.unit == nil {
+		return cluster, nil
+	}
+	if err := validateTemperatureUnit(*cluster.unit); err != nil {
+		return nil, fmt.Errorf("%w %w", ErrInvalid, err)
+	}
+	cluster.SetFeatureMap(FeatureTemperatureUnit)
+	temperatureUnit := datatype.Enum8(*cluster.unit)
+	attr := datamodel.NewWritableAttribute(TemperatureUnitAttributeID, &temperatureUnit)
+	attr.WritePrivilege = datamodel.ManagePrivilege
+	attr.Constraint = func(v datatype.Value) error {
+		unit, ok := v.(*datatype.Enum8)
+		if !ok {
+			return fmt.Errorf("%w : temperature unit is %s", im.StatusConstraintError, v.Type())
+		}
+		return validateTemperatureUnit(TemperatureUnit(*unit))
+	}
+	cluster.AddAttribute(attr)
+	return cluster, nil
+}
+
+func validateTemperatureUnit(unit TemperatureUnit) error {
+	if TemperatureUnitKelvin < unit {
+		return fmt.Errorf("%w : temperature unit (%d)", im.StatusConstraintError, unit)
+	}
+	return nil
+}
+
+// HasFeature returns true if the specified feature is supported.
+func (cluster *UnitCluster) HasFeature(feature uint32) bool {
+	return cluster.FeatureMap()&feature != 0
+}
+
+// TemperatureUnit returns the temperature unit, or false without the TemperatureUnit feature.
+func (cluster *UnitCluster) TemperatureUnit() (TemperatureUnit, bool) {
+	if !cluster.HasFeature(FeatureTemperatureUnit) {
+		return 0, false
+	}
+	return TemperatureUnit(readEnum8(cluster.BaseCluster, TemperatureUnitAttributeID, 0)), true
+}
+
+func readEnum8(cluster *datamodel.BaseCluster, id im.AttributeID, def uint8) uint8 {
+	v, err := cluster.ReadAttribute(id)
+	if err != nil {
+		return def
+	}
+	e, ok := v.(*datatype.Enum8)
+	if !ok {
+		return def
+	}
+	return uint8(*e)
+}
+
+// AddDefaultClusters adds the localization clusters to the endpoint, which is usually the root endpoint,
+// with the DefaultLocale, the 24-hour format, the Gregorian calendar and the Celsius unit.
+func AddDefaultClusters(ep *datamodel.Endpoint) error {
+	config, err := NewConfigurationCluster(DefaultLocale, []string{DefaultLocale})
+	if err != nil {
+		return err
+	}
+	timeFormat, err := NewTimeFormatCluster(
+		WithHourFormat(HourFormat24hr),
+		WithCalendarTypes(CalendarTypeGregorian, CalendarTypeGregorian))
+	if err != nil {
+		return err
+	}
+	unit, err := NewUnitCluster(WithTemperatureUnit(TemperatureUnitCelsius))
+	if err != nil {
+		return err
+	}
+	for _, cluster := range []datamodel.Cluster{config, timeFormat, unit} {
+		if err := ep.AddCluster(cluster); err != nil {
+			return err
+		}
+	}
+	return nil
+}
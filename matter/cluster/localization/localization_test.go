@@ -0,0 +1,146 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localization
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+func encode(t *testing.T, v datatype.Value) []byte {
+	t.Helper()
+	b, err := datatype.Encode(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestConfigurationCluster(t *testing.T) {
+	if _, err := NewConfigurationCluster("fr-FR", []string{"en-US"}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("unsupported active locale : %v", err)
+	}
+
+	cluster, err := NewConfigurationCluster("en-US", []string{"en-US", "ja-JP"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	locale := datatype.String("ja-JP")
+	if err := cluster.WriteAttribute(ActiveLocaleAttributeID, encode(t, &locale)); err != nil {
+		t.Fatal(err)
+	}
+	if cluster.ActiveLocale() != "ja-JP" {
+		t.Errorf("%s != %s", cluster.ActiveLocale(), "ja-JP")
+	}
+	locale = "fr-FR"
+	err = cluster.WriteAttribute(ActiveLocaleAttributeID, encode(t, &locale))
+	if status := im.StatusOf(err); status != im.StatusConstraintError {
+		t.Errorf("%s != %s", status, im.StatusConstraintError)
+	}
+	if cluster.ActiveLocale() != "ja-JP" {
+		t.Errorf("%s != %s", cluster.ActiveLocale(), "ja-JP")
+	}
+}
+
+func TestTimeFormatCluster(t *testing.T) {
+	cluster, err := NewTimeFormatCluster()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cluster.HasFeature(FeatureCalendarFormat) || cluster.HourFormat() != HourFormatUseActiveLocale {
+		t.Error("default time format")
+	}
+	if _, err := cluster.ReadAttribute(ActiveCalendarTypeAttributeID); err == nil {
+		t.Error("calendar type without CalendarFormat")
+	}
+
+	cluster, err = NewTimeFormatCluster(
+		WithHourFormat(HourFormat12hr),
+		WithCalendarTypes(CalendarTypeGregorian, CalendarTypeGregorian, CalendarTypeJapanese))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		id     im.AttributeID
+		value  uint8
+		status im.Status
+	}{
+		{HourFormatAttributeID, uint8(HourFormat24hr), im.StatusSuccess},
+		{HourFormatAttributeID, 0x02, im.StatusConstraintError},
+		{ActiveCalendarTypeAttributeID, uint8(CalendarTypeJapanese), im.StatusSuccess},
+		{ActiveCalendarTypeAttributeID, uint8(CalendarTypeUseActiveLocale), im.StatusSuccess},
+		{ActiveCalendarTypeAttributeID, uint8(CalendarTypeHebrew), im.StatusConstraintError},
+	}
+	for _, test := range tests {
+		v := datatype.Enum8(test.value)
+		err := cluster.WriteAttribute(test.id, encode(t, &v))
+		if status := im.StatusOf(err); status != test.status {
+			t.Errorf("attribute (0x%04X) value (%d) : %s != %s", test.id, test.value, status, test.status)
+		}
+	}
+	if cluster.HourFormat() != HourFormat24hr || cluster.ActiveCalendarType() != CalendarTypeUseActiveLocale {
+		t.Errorf("time format (%d, %d)", cluster.HourFormat(), cluster.ActiveCalendarType())
+	}
+
+	if _, err := NewTimeFormatCluster(WithCalendarTypes(CalendarTypeHebrew, CalendarTypeGregorian)); !errors.Is(err, ErrInvalid) {
+		t.Errorf("unsupported active calendar type : %v", err)
+	}
+}
+
+func TestUnitCluster(t *testing.T) {
+	cluster, err := NewUnitCluster()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cluster.TemperatureUnit(); ok {
+		t.Error("temperature unit without TemperatureUnit")
+	}
+
+	cluster, err = NewUnitCluster(WithTemperatureUnit(TemperatureUnitFahrenheit))
+	if err != nil {
+		t.Fatal(err)
+	}
+	unit := datatype.Enum8(TemperatureUnitKelvin)
+	if err := cluster.WriteAttribute(TemperatureUnitAttributeID, encode(t, &unit)); err != nil {
+		t.Fatal(err)
+	}
+	unit = 0x03
+	if err := cluster.WriteAttribute(TemperatureUnitAttributeID, encode(t, &unit)); im.StatusOf(err) != im.StatusConstraintError {
+		t.Errorf("%s != %s", im.StatusOf(err), im.StatusConstraintError)
+	}
+	if v, ok := cluster.TemperatureUnit(); !ok || v != TemperatureUnitKelvin {
+		t.Errorf("%d != %d", v, TemperatureUnitKelvin)
+	}
+}
+
+func TestAddDefaultClusters(t *testing.T) {
+	ep := datamodel.NewEndpoint(datamodel.RootEndpointID)
+	if err := AddDefaultClusters(ep); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []im.ClusterID{ConfigurationClusterID, TimeFormatClusterID, UnitClusterID} {
+		if _, ok := ep.LookupCluster(id); !ok {
+			t.Errorf("no cluster (0x%04X)", id)
+		}
+	}
+	config, _ := ep.LookupCluster(ConfigurationClusterID)
+	if locale := config.(*ConfigurationCluster).ActiveLocale(); locale != DefaultLocale {
+		t.Errorf("%s != %s", locale, DefaultLocale)
+	}
+}
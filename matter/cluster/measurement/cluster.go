@@ -0,0 +1,256 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package measurement
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/log"
+)
+
+// 2.1. Measurement Clusters
+// The measurement clusters share the following attributes, and only differ in their data types and ranges.
+const (
+	MeasuredValueAttributeID    im.AttributeID = 0x0000
+	MinMeasuredValueAttributeID im.AttributeID = 0x0001
+	MaxMeasuredValueAttributeID im.AttributeID = 0x0002
+	ToleranceAttributeID        im.AttributeID = 0x0003
+
+	// MaxTolerance is the maximum tolerance in measured value units.
+	MaxTolerance = 2048
+)
+
+// Definition describes a measurement cluster.
+type Definition struct {
+	// ID is the cluster ID.
+	ID im.ClusterID
+	// Revision is the cluster revision.
+	Revision uint16
+	// Scale is the number of measured value units per unit of the values fed to the cluster,
+	// such as 100 for the measured values in hundredths.
+	Scale float64
+	// Min is the minimum measured value in measured value units.
+	Min int64
+	// Max is the maximum measured value in measured value units.
+	Max int64
+	// NewValue returns a value of the data type of the measured value.
+	NewValue func(v int64) datatype.Value
+}
+
+// Reader is called to read the current value from the sensor whenever the measured value is read.
+// The measured value is unknown while the reader returns an error.
+type Reader func() (float64, error)
+
+// Cluster represents a measurement cluster server whose measured value is set by the application,
+// fed from a channel, or read from a reader.
+type Cluster struct {
+	*datamodel.BaseCluster
+	mutex     sync.Mutex
+	def       *Definition
+	min       int64
+	max       int64
+	reader    Reader
+	measured  *datatype.Nullable[datatype.Value]
+	current   int64
+	rangeSet  bool
+	tolerance *datatype.Uint16
+}
+
+// Option represents an option of the measurement clusters.
+type Option func(*Cluster) error
+
+// WithRange sets the minimum and maximum values which the sensor can measure.
+func WithRange(min float64, max float64) Option {
+	return func(cluster *Cluster) error {
+		minValue, err := cluster.toMeasuredValue(min)
+		if err != nil {
+			return err
+		}
+		maxValue, err := cluster.toMeasuredValue(max)
+		if err != nil {
+			return err
+		}
+		if maxValue <= minValue {
+			return fmt.Errorf("%w range (%v, %v)", ErrInvalid, min, max)
+		}
+		cluster.min = minValue
+		cluster.max = maxValue
+		cluster.rangeSet = true
+		return nil
+	}
+}
+
+// WithTolerance sets the magnitude of the possible error of the measured values.
+func WithTolerance(tolerance float64) Option {
+	return func(cluster *Cluster) error {
+		v := math.Round(tolerance * cluster.def.Scale)
+		if math.IsNaN(v) || v < 0 || MaxTolerance < v {
+			return fmt.Errorf("%w tolerance (%v)", ErrInvalid, tolerance)
+		}
+		t := datatype.Uint16(v)
+		cluster.tolerance = &t
+		return nil
+	}
+}
+
+// WithReader sets the reader which is called whenever the measured value is read.
+func WithReader(reader Reader) Option {
+	return func(cluster *Cluster) error {
+		cluster.reader = reader
+		return nil
+	}
+}
+
+// NewCluster returns a new measurement cluster of the specified definition, whose measured value is unknown until it is set.
+func NewCluster(def *Definition, opts ...Option) (*Cluster, error) {
+	cluster := &Cluster{
+		BaseCluster: datamodel.NewBaseCluster(def.ID, def.Revision),
+		mutex:       sync.Mutex{},
+		def:         def,
+		min:         def.Min,
+		max:         def.Max,
+		reader:      nil,
+		measured:    datatype.NewNull(def.NewValue(def.Min)),
+		current:     0,
+		rangeSet:    false,
+		tolerance:   nil,
+	}
+	for _, opt := range opts {
+		if err := opt(cluster); err != nil {
+			return nil, err
+		}
+	}
+
+	minValue := datatype.NewNull(def.NewValue(def.Min))
+	maxValue := datatype.NewNull(def.NewValue(def.Max))
+	if cluster.rangeSet {
+		minValue.Set(def.NewValue(cluster.min))
+		maxValue.Set(def.NewValue(cluster.max))
+	}
+	cluster.AddAttribute(datamodel.NewAttribute(MeasuredValueAttributeID, cluster.measured))
+	cluster.AddAttribute(datamodel.NewAttribute(MinMeasuredValueAttributeID, minValue))
+	cluster.AddAttribute(datamodel.NewAttribute(MaxMeasuredValueAttributeID, maxValue))
+	if cluster.tolerance != nil {
+		cluster.AddAttribute(datamodel.NewAttribute(ToleranceAttributeID, cluster.tolerance))
+	}
+
+	return cluster, nil
+}
+
+// toMeasuredValue returns the specified value in measured value units.
+func (cluster *Cluster) toMeasuredValue(v float64) (int64, error) {
+	scaled := math.Round(v * cluster.def.Scale)
+	if math.IsNaN(scaled) || scaled < float64(cluster.min) || float64(cluster.max) < scaled {
+		return 0, fmt.Errorf("%w measured value (%v)", ErrInvalid, v)
+	}
+	return int64(scaled), nil
+}
+
+// Set sets the measured value, and returns an error if it is out of the measurable range.
+func (cluster *Cluster) Set(v float64) error {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	return cluster.set(v)
+}
+
+func (cluster *Cluster) set(v float64) error {
+	measured, err := cluster.toMeasuredValue(v)
+	if err != nil {
+		return err
+	}
+	if !cluster.measured.Null && cluster.current == measured {
+		return nil
+	}
+	cluster.current = measured
+	cluster.measured = datatype.NewNullable(cluster.def.NewValue(measured))
+	return cluster.SetAttribute(MeasuredValueAttributeID, cluster.measured)
+}
+
+// SetUnknown sets the measured value to null, which indicates that the value can not be measured.
+func (cluster *Cluster) SetUnknown() {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	cluster.setUnknown()
+}
+
+func (cluster *Cluster) setUnknown() {
+	if cluster.measured.Null {
+		return
+	}
+	cluster.measured = datatype.NewNull(cluster.def.NewValue(cluster.def.Min))
+	cluster.SetAttribute(MeasuredValueAttributeID, cluster.measured)
+}
+
+// Value returns the measured value, and false if it is unknown.
+func (cluster *Cluster) Value() (float64, bool) {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	return cluster.value()
+}
+
+func (cluster *Cluster) value() (float64, bool) {
+	if cluster.measured.Null {
+		return 0, false
+	}
+	return float64(cluster.current) / cluster.def.Scale, true
+}
+
+// Feed sets the measured values received from the specified channel until the channel is closed or
+// the context is done. Values out of the measurable range are dropped.
+func (cluster *Cluster) Feed(ctx context.Context, values <-chan float64) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case v, ok := <-values:
+			if !ok {
+				return nil
+			}
+			if err := cluster.Set(v); err != nil {
+				log.Warnf("dropped a measured value of cluster (0x%04X) : %s", cluster.ID(), err)
+			}
+		}
+	}
+}
+
+// Update reads the current value with the reader if it is set.
+func (cluster *Cluster) Update() {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	if cluster.reader == nil {
+		return
+	}
+	v, err := cluster.reader()
+	if err == nil {
+		err = cluster.set(v)
+	}
+	if err != nil {
+		cluster.setUnknown()
+	}
+}
+
+// ReadAttribute returns the value of the specified attribute after reading the measured value with the reader.
+func (cluster *Cluster) ReadAttribute(id im.AttributeID) (datatype.Value, error) {
+	if id == MeasuredValueAttributeID {
+		cluster.Update()
+	}
+	return cluster.BaseCluster.ReadAttribute(id)
+}
@@ -0,0 +1,144 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package measurement
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+var testDefinition = &Definition{
+	ID:       0x0402,
+	Revision: 4,
+	Scale:    100,
+	Min:      datatype.TemperatureMin,
+	Max:      32767,
+	NewValue: func(v int64) datatype.Value {
+		t := datatype.Temperature(v)
+		return &t
+	},
+}
+
+func readMeasuredValue(t *testing.T, cluster *Cluster, id im.AttributeID) (int64, bool) {
+	t.Helper()
+	v, err := cluster.ReadAttribute(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, ok := v.(*datatype.Nullable[datatype.Value]).Get()
+	if !ok {
+		return 0, false
+	}
+	return int64(*value.(*datatype.Temperature)), true
+}
+
+func TestMeasurementCluster(t *testing.T) {
+	cluster, err := NewCluster(testDefinition, WithRange(-10, 50), WithTolerance(0.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := readMeasuredValue(t, cluster, MeasuredValueAttributeID); ok {
+		t.Error("measured value is known before it is set")
+	}
+	if v, _ := readMeasuredValue(t, cluster, MinMeasuredValueAttributeID); v != -1000 {
+		t.Errorf("min measured value (%d) != -1000", v)
+	}
+	if v, _ := readMeasuredValue(t, cluster, MaxMeasuredValueAttributeID); v != 5000 {
+		t.Errorf("max measured value (%d) != 5000", v)
+	}
+	tolerance, err := cluster.ReadAttribute(ToleranceAttributeID)
+	if err != nil || *tolerance.(*datatype.Uint16) != 50 {
+		t.Errorf("tolerance %v : %v", tolerance, err)
+	}
+
+	version := cluster.DataVersion()
+	if err := cluster.Set(21.456); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := readMeasuredValue(t, cluster, MeasuredValueAttributeID); !ok || v != 2146 {
+		t.Errorf("measured value (%d) != 2146", v)
+	}
+	if cluster.DataVersion() == version {
+		t.Error("data version is not changed")
+	}
+	version = cluster.DataVersion()
+	cluster.Set(21.46)
+	if cluster.DataVersion() != version {
+		t.Error("data version is changed by the same value")
+	}
+	if err := cluster.Set(60); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+	cluster.SetUnknown()
+	if _, ok := cluster.Value(); ok {
+		t.Error("measured value is known")
+	}
+
+	if _, err := NewCluster(testDefinition, WithRange(20, 10)); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+	if _, err := NewCluster(testDefinition, WithRange(-300, 10)); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+}
+
+func TestMeasurementFeed(t *testing.T) {
+	cluster, err := NewCluster(testDefinition, WithRange(0, 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := make(chan float64)
+	done := make(chan error)
+	go func() {
+		done <- cluster.Feed(context.Background(), values)
+	}()
+	values <- 12.5
+	values <- 200
+	close(values)
+	if err := <-done; err != nil {
+		t.Error(err)
+	}
+	if v, ok := cluster.Value(); !ok || v != 12.5 {
+		t.Errorf("measured value (%v) != 12.5", v)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := cluster.Feed(ctx, make(chan float64)); !errors.Is(err, context.Canceled) {
+		t.Errorf("%v is not %v", err, context.Canceled)
+	}
+}
+
+func TestMeasurementReader(t *testing.T) {
+	var readErr error
+	celsius := 18.0
+	cluster, err := NewCluster(testDefinition, WithReader(func() (float64, error) {
+		return celsius, readErr
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := readMeasuredValue(t, cluster, MeasuredValueAttributeID); !ok || v != 1800 {
+		t.Errorf("measured value (%d) != 1800", v)
+	}
+	readErr = errors.New("sensor error")
+	if _, ok := readMeasuredValue(t, cluster, MeasuredValueAttributeID); ok {
+		t.Error("measured value is known while the sensor fails")
+	}
+}
@@ -0,0 +1,352 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modebase
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// 1.10. Mode Base Cluster
+const (
+	// FeatureOnOff indicates that the OnMode attribute is supported.
+	FeatureOnOff uint32 = 0x01
+
+	SupportedModesAttributeID im.AttributeID = 0x0000
+	CurrentModeAttributeID    im.AttributeID = 0x0001
+	StartUpModeAttributeID    im.AttributeID = 0x0002
+	OnModeAttributeID         im.AttributeID = 0x0003
+
+	ChangeToModeCommandID         im.CommandID = 0x00
+	ChangeToModeResponseCommandID im.CommandID = 0x01
+
+	// MaxStatusTextLength is the maximum length of status texts of ChangeToModeResponse.
+	MaxStatusTextLength = 64
+)
+
+// ChangeStatus represents a status of ChangeToModeResponse.
+type ChangeStatus uint8
+
+// 1.10.7.2.1.1. Status Field
+const (
+	ChangeStatusSuccess         ChangeStatus = 0x00
+	ChangeStatusUnsupportedMode ChangeStatus = 0x01
+	ChangeStatusGenericFailure  ChangeStatus = 0x02
+	ChangeStatusInvalidInMode   ChangeStatus = 0x03
+
+	// MinDerivedChangeStatus is the first status defined by the derived clusters.
+	MinDerivedChangeStatus ChangeStatus = 0x40
+	// MinManufacturerChangeStatus is the first manufacturer specific status.
+	MinManufacturerChangeStatus ChangeStatus = 0x80
+)
+
+// Definition describes a cluster which is derived from the Mode Base cluster. The derived clusters
+// only differ in these elements, and share the runtime of this package.
+type Definition struct {
+	// ID is the cluster ID.
+	ID im.ClusterID
+	// Revision is the cluster revision.
+	Revision uint16
+	// ModeTags are the names of the mode tags defined by the derived cluster.
+	ModeTags map[ModeTag]string
+	// RequiredModeTags are the mode tags which at least one supported mode must have each.
+	RequiredModeTags []ModeTag
+	// SupportsStartUpMode is true if the StartUpMode and OnMode attributes are allowed.
+	SupportsStartUpMode bool
+}
+
+// ModeTagName returns the name of the specified common or derived mode tag.
+func (def *Definition) ModeTagName(tag ModeTag) (string, bool) {
+	if name, ok := commonModeTagNames[tag]; ok {
+		return name, true
+	}
+	name, ok := def.ModeTags[tag]
+	return name, ok
+}
+
+// ValidateModes returns an error if the modes have an unknown standard tag, or lack a required tag.
+func (def *Definition) ValidateModes(modes []ModeOption) error {
+	err := ValidateModes(modes, func(tag ModeTagValue) error {
+		if tag.Value.IsManufacturer() {
+			if tag.MfgCode == 0 {
+				return fmt.Errorf("%w mode tag (0x%04X) : no manufacturer code", ErrInvalid, tag.Value)
+			}
+			return nil
+		}
+		if _, ok := def.ModeTagName(tag.Value); !ok {
+			return fmt.Errorf("%w mode tag (0x%04X)", ErrInvalid, tag.Value)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, required := range def.RequiredModeTags {
+		found := false
+		for n := range modes {
+			if modes[n].HasTag(required) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			name, _ := def.ModeTagName(required)
+			return fmt.Errorf("%w supported modes : no %s mode", ErrInvalid, name)
+		}
+	}
+	return nil
+}
+
+// ChangeHandler is called when a client requests to change the current mode to the specified mode.
+// The mode is changed only if the handler returns ChangeStatusSuccess, and the status text is
+// returned to the client.
+type ChangeHandler func(mode *ModeOption) (ChangeStatus, string)
+
+// Cluster represents a cluster server which is derived from the Mode Base cluster.
+type Cluster struct {
+	*datamodel.BaseCluster
+	mutex       sync.Mutex
+	def         *Definition
+	modes       []ModeOption
+	currentMode *datatype.Uint8
+	startUpMode *datatype.Nullable[*datatype.Uint8]
+	onMode      *datatype.Nullable[*datatype.Uint8]
+	handler     ChangeHandler
+}
+
+// Option represents an option of the mode clusters.
+type Option func(*Cluster)
+
+// WithStartUpMode sets the mode which the device moves to when it is powered up.
+func WithStartUpMode(mode uint8) Option {
+	return func(cluster *Cluster) {
+		v := datatype.Uint8(mode)
+		cluster.startUpMode = datatype.NewNullable(&v)
+	}
+}
+
+// WithOnMode enables the OnOff feature, and sets the mode which the device moves to when it is turned on.
+func WithOnMode(mode uint8) Option {
+	return func(cluster *Cluster) {
+		v := datatype.Uint8(mode)
+		cluster.onMode = datatype.NewNullable(&v)
+	}
+}
+
+// WithChangeHandler sets the handler which accepts or rejects the mode changes requested by clients.
+func WithChangeHandler(handler ChangeHandler) Option {
+	return func(cluster *Cluster) {
+		cluster.handler = handler
+	}
+}
+
+// NewCluster returns a new mode cluster of the specified definition. The current mode is overridden by
+// the start-up mode if it is specified.
+func NewCluster(def *Definition, modes []ModeOption, current uint8, opts ...Option) (*Cluster, error) {
+	if err := def.ValidateModes(modes); err != nil {
+		return nil, err
+	}
+	currentMode := datatype.Uint8(current)
+	cluster := &Cluster{
+		BaseCluster: datamodel.NewBaseCluster(def.ID, def.Revision),
+		mutex:       sync.Mutex{},
+		def:         def,
+		modes:       append([]ModeOption{}, modes...),
+		currentMode: &currentMode,
+		startUpMode: nil,
+		onMode:      nil,
+		handler:     nil,
+	}
+	for _, opt := range opts {
+		opt(cluster)
+	}
+
+	for _, mode := range []*datatype.Nullable[*datatype.Uint8]{cluster.startUpMode, cluster.onMode} {
+		if mode == nil {
+			continue
+		}
+		if !def.SupportsStartUpMode {
+			return nil, fmt.Errorf("%w start-up mode : not supported by cluster (0x%04X)", ErrInvalid, def.ID)
+		}
+		if _, ok := lookupMode(modes, uint8(*mode.Value)); !ok {
+			return nil, fmt.Errorf("%w mode (%d) : not supported", ErrInvalid, *mode.Value)
+		}
+	}
+	if cluster.startUpMode != nil {
+		*cluster.currentMode = *cluster.startUpMode.Value
+	}
+	if _, ok := lookupMode(modes, uint8(*cluster.currentMode)); !ok {
+		return nil, fmt.Errorf("%w mode (%d) : not supported", ErrInvalid, *cluster.currentMode)
+	}
+
+	cluster.AddAttribute(datamodel.NewAttribute(SupportedModesAttributeID, NewModeOptionList(cluster.modes)))
+	cluster.AddAttribute(datamodel.NewAttribute(CurrentModeAttributeID, cluster.currentMode))
+	if cluster.startUpMode != nil {
+		cluster.AddAttribute(datamodel.NewWritableAttribute(StartUpModeAttributeID, cluster.startUpMode))
+	}
+	if cluster.onMode != nil {
+		cluster.SetFeatureMap(FeatureOnOff)
+		cluster.AddAttribute(datamodel.NewWritableAttribute(OnModeAttributeID, cluster.onMode))
+	}
+	// StartUpMode and OnMode are configured together, so a write request does not apply either alone.
+	cluster.SetTransactional(true)
+	cluster.AddCommand(ChangeToModeCommandID, cluster.changeToMode)
+	cluster.AddGeneratedCommand(ChangeToModeResponseCommandID)
+
+	return cluster, nil
+}
+
+// Definition returns the definition of the derived cluster.
+func (cluster *Cluster) Definition() *Definition {
+	return cluster.def
+}
+
+// SupportedModes returns the supported modes.
+func (cluster *Cluster) SupportedModes() []ModeOption {
+	return append([]ModeOption{}, cluster.modes...)
+}
+
+// CurrentMode returns the current mode.
+func (cluster *Cluster) CurrentMode() ModeOption {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	mode, _ := lookupMode(cluster.modes, uint8(*cluster.currentMode))
+	return *mode
+}
+
+// SetCurrentMode changes the current mode without calling the change handler. Devices call it when
+// they change the mode by themselves, such as a robot which returns to the idle mode.
+func (cluster *Cluster) SetCurrentMode(mode uint8) error {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	return cluster.setCurrentMode(mode)
+}
+
+func (cluster *Cluster) setCurrentMode(mode uint8) error {
+	if _, ok := lookupMode(cluster.modes, mode); !ok {
+		return fmt.Errorf("%w mode (%d) : not supported", ErrInvalid, mode)
+	}
+	if uint8(*cluster.currentMode) == mode {
+		return nil
+	}
+	v := datatype.Uint8(mode)
+	cluster.currentMode = &v
+	return cluster.SetAttribute(CurrentModeAttributeID, cluster.currentMode)
+}
+
+// OnMode returns the mode which the device moves to when it is turned on, and false if it is null or not supported.
+func (cluster *Cluster) OnMode() (uint8, bool) {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	return cluster.nullableMode(OnModeAttributeID)
+}
+
+// StartUpMode returns the mode which the device moves to when it is powered up, and false if it is null or not supported.
+func (cluster *Cluster) StartUpMode() (uint8, bool) {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	return cluster.nullableMode(StartUpModeAttributeID)
+}
+
+func (cluster *Cluster) nullableMode(id im.AttributeID) (uint8, bool) {
+	v, err := cluster.BaseCluster.ReadAttribute(id)
+	if err != nil {
+		return 0, false
+	}
+	mode, ok := v.(*datatype.Nullable[*datatype.Uint8]).Get()
+	if !ok {
+		return 0, false
+	}
+	return uint8(*mode), true
+}
+
+// ApplyOnMode changes the current mode to the OnMode if it is not null. The On/Off cluster on the same
+// endpoint calls it when the device is turned on.
+func (cluster *Cluster) ApplyOnMode() error {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	mode, ok := cluster.nullableMode(OnModeAttributeID)
+	if !ok {
+		return nil
+	}
+	return cluster.setCurrentMode(mode)
+}
+
+// WriteAttribute writes the specified attribute, and rejects the start-up and on modes which are not supported.
+func (cluster *Cluster) WriteAttribute(id im.AttributeID, data []byte) error {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	switch id {
+	case StartUpModeAttributeID, OnModeAttributeID:
+		mode := datatype.NewNull(new(datatype.Uint8))
+		if err := datatype.Decode(data, mode); err != nil {
+			break
+		}
+		if v, ok := mode.Get(); ok {
+			if _, ok := lookupMode(cluster.modes, uint8(*v)); !ok {
+				return fmt.Errorf("%w : mode (%d)", im.StatusConstraintError, *v)
+			}
+		}
+	}
+	return cluster.BaseCluster.WriteAttribute(id, data)
+}
+
+func (cluster *Cluster) changeToMode(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	newMode := new(datatype.Uint8)
+	if err := req.DecodeFields(datatype.NewStruct(datatype.NewField(0, newMode))); err != nil {
+		return nil, err
+	}
+
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+
+	status := ChangeStatusSuccess
+	text := ""
+	mode, ok := lookupMode(cluster.modes, uint8(*newMode))
+	switch {
+	case !ok:
+		status = ChangeStatusUnsupportedMode
+	case *newMode == *cluster.currentMode:
+		// Changing to the current mode always succeeds without the handler.
+	case cluster.handler != nil:
+		status, text = cluster.handler(mode)
+	}
+	if status == ChangeStatusSuccess {
+		if err := cluster.setCurrentMode(uint8(*newMode)); err != nil {
+			return nil, err
+		}
+	}
+
+	return newChangeToModeResponse(status, text), nil
+}
+
+func newChangeToModeResponse(status ChangeStatus, text string) *datamodel.CommandResponse {
+	statusValue := datatype.Enum8(status)
+	statusText := datatype.NewUnset(new(datatype.String))
+	if 0 < len(text) {
+		if MaxStatusTextLength < len(text) {
+			text = text[:MaxStatusTextLength]
+		}
+		v := datatype.String(text)
+		statusText.Set(&v)
+	}
+	return datamodel.NewCommandResponse(ChangeToModeResponseCommandID, datatype.NewStruct(
+		datatype.NewField(0, &statusValue),
+		datatype.NewField(1, statusText)))
+}
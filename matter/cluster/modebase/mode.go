@@ -0,0 +1,201 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modebase
+
+import (
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+)
+
+// ModeTag represents a mode tag, which gives a semantic meaning to a mode.
+type ModeTag uint16
+
+// 1.10.8. Mode Namespace
+const (
+	ModeTagAuto      ModeTag = 0x0000
+	ModeTagQuick     ModeTag = 0x0001
+	ModeTagQuiet     ModeTag = 0x0002
+	ModeTagLowNoise  ModeTag = 0x0003
+	ModeTagLowEnergy ModeTag = 0x0004
+	ModeTagVacation  ModeTag = 0x0005
+	ModeTagMin       ModeTag = 0x0006
+	ModeTagMax       ModeTag = 0x0007
+	ModeTagNight     ModeTag = 0x0008
+	ModeTagDay       ModeTag = 0x0009
+
+	// MinDerivedModeTag is the first mode tag defined by the derived clusters.
+	MinDerivedModeTag ModeTag = 0x4000
+	// MinManufacturerModeTag is the first manufacturer specific mode tag, which is qualified by a vendor ID.
+	MinManufacturerModeTag ModeTag = 0x8000
+
+	// MaxModeLabelLength is the maximum length of mode labels.
+	MaxModeLabelLength = 64
+	// MaxModeTags is the maximum number of mode tags of a mode.
+	MaxModeTags = 8
+	// MaxModes is the maximum number of supported modes.
+	MaxModes = 255
+)
+
+var commonModeTagNames = map[ModeTag]string{
+	ModeTagAuto:      "Auto",
+	ModeTagQuick:     "Quick",
+	ModeTagQuiet:     "Quiet",
+	ModeTagLowNoise:  "LowNoise",
+	ModeTagLowEnergy: "LowEnergy",
+	ModeTagVacation:  "Vacation",
+	ModeTagMin:       "Min",
+	ModeTagMax:       "Max",
+	ModeTagNight:     "Night",
+	ModeTagDay:       "Day",
+}
+
+// IsCommon returns true if the tag is one of the common mode tags.
+func (tag ModeTag) IsCommon() bool {
+	_, ok := commonModeTagNames[tag]
+	return ok
+}
+
+// IsManufacturer returns true if the tag is a manufacturer specific mode tag.
+func (tag ModeTag) IsManufacturer() bool {
+	return MinManufacturerModeTag <= tag
+}
+
+// 1.10.5.2. ModeTagStruct Type
+// ModeTagValue represents a mode tag of a mode, which is qualified by a vendor ID if it is manufacturer specific.
+type ModeTagValue struct {
+	// MfgCode is the vendor ID of a manufacturer specific tag, or zero for the standard tags.
+	MfgCode uint16
+	// Value is the mode tag.
+	Value ModeTag
+}
+
+// 1.10.5.1. ModeOptionStruct Type
+// ModeOption represents a supported mode.
+type ModeOption struct {
+	// Label is the human readable name of the mode.
+	Label string
+	// Mode is the value which identifies the mode.
+	Mode uint8
+	// ModeTags are the tags which give the semantic meaning of the mode.
+	ModeTags []ModeTagValue
+}
+
+// NewModeOption returns a new mode of the specified standard mode tags.
+func NewModeOption(label string, mode uint8, tags ...ModeTag) ModeOption {
+	values := make([]ModeTagValue, 0, len(tags))
+	for _, tag := range tags {
+		values = append(values, ModeTagValue{MfgCode: 0, Value: tag})
+	}
+	return ModeOption{
+		Label:    label,
+		Mode:     mode,
+		ModeTags: values,
+	}
+}
+
+// HasTag returns true if the mode has the specified standard mode tag.
+func (opt *ModeOption) HasTag(tag ModeTag) bool {
+	for _, v := range opt.ModeTags {
+		if v.MfgCode == 0 && v.Value == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupMode returns the mode of the specified value.
+func lookupMode(modes []ModeOption, mode uint8) (*ModeOption, bool) {
+	for n := range modes {
+		if modes[n].Mode == mode {
+			return &modes[n], true
+		}
+	}
+	return nil, false
+}
+
+// ValidateModes returns an error if the modes are empty, or a mode value or label is duplicated or too long.
+// The tags are validated with the specified function if it is not nil.
+func ValidateModes(modes []ModeOption, validateTag func(ModeTagValue) error) error {
+	if len(modes) == 0 || MaxModes < len(modes) {
+		return fmt.Errorf("%w supported modes (%d)", ErrInvalid, len(modes))
+	}
+	values := map[uint8]bool{}
+	labels := map[string]bool{}
+	for _, mode := range modes {
+		if values[mode.Mode] {
+			return fmt.Errorf("%w mode (%d) : duplicated", ErrInvalid, mode.Mode)
+		}
+		values[mode.Mode] = true
+		if len(mode.Label) == 0 || MaxModeLabelLength < len(mode.Label) || labels[mode.Label] {
+			return fmt.Errorf("%w mode (%d) label (%s)", ErrInvalid, mode.Mode, mode.Label)
+		}
+		labels[mode.Label] = true
+		if MaxModeTags < len(mode.ModeTags) {
+			return fmt.Errorf("%w mode (%d) tags (%d)", ErrInvalid, mode.Mode, len(mode.ModeTags))
+		}
+		if validateTag == nil {
+			continue
+		}
+		for _, tag := range mode.ModeTags {
+			if err := validateTag(tag); err != nil {
+				return fmt.Errorf("mode (%d) : %w", mode.Mode, err)
+			}
+		}
+	}
+	return nil
+}
+
+// NewModeOptionList returns a list value of the specified modes. The mode tags are encoded with the
+// same field IDs in the Mode Select cluster, whose semantic tags share the layout.
+func NewModeOptionList(modes []ModeOption) *datatype.List {
+	list := datatype.NewList(func() datatype.Value { return newModeOptionStruct(nil) })
+	for n := range modes {
+		list.Elements = append(list.Elements, newModeOptionStruct(&modes[n]))
+	}
+	return list
+}
+
+func newModeOptionStruct(mode *ModeOption) *datatype.Struct {
+	label := new(datatype.String)
+	value := new(datatype.Uint8)
+	tags := datatype.NewList(func() datatype.Value { return newModeTagStruct(nil) })
+	if mode != nil {
+		*label = datatype.String(mode.Label)
+		*value = datatype.Uint8(mode.Mode)
+		for _, tag := range mode.ModeTags {
+			tags.Elements = append(tags.Elements, newModeTagStruct(&tag))
+		}
+	}
+	return datatype.NewStruct(
+		datatype.NewField(0, label),
+		datatype.NewField(1, value),
+		datatype.NewField(2, tags))
+}
+
+func newModeTagStruct(tag *ModeTagValue) *datatype.Struct {
+	mfgCode := datatype.NewUnset(new(datatype.Uint16))
+	value := new(datatype.Enum16)
+	if tag != nil {
+		if tag.MfgCode != 0 {
+			code := datatype.Uint16(tag.MfgCode)
+			mfgCode.Set(&code)
+		}
+		*value = datatype.Enum16(tag.Value)
+	}
+	return datatype.NewStruct(
+		datatype.NewField(0, mfgCode),
+		datatype.NewField(1, value))
+}
@@ -0,0 +1,233 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modebase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datamodel/datamodeltest"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+const (
+	testModeTagIdle     ModeTag = 0x4000
+	testModeTagCleaning ModeTag = 0x4001
+)
+
+var testDefinition = &Definition{
+	ID:       0x0054,
+	Revision: 3,
+	ModeTags: map[ModeTag]string{
+		testModeTagIdle:     "Idle",
+		testModeTagCleaning: "Cleaning",
+	},
+	RequiredModeTags:    []ModeTag{testModeTagIdle},
+	SupportsStartUpMode: true,
+}
+
+func testModes() []ModeOption {
+	return []ModeOption{
+		NewModeOption("Idle", 0, testModeTagIdle),
+		NewModeOption("Cleaning", 1, testModeTagCleaning),
+		NewModeOption("Quiet", 2, testModeTagCleaning, ModeTagQuiet),
+	}
+}
+
+func changeToMode(t *testing.T, cluster *Cluster, mode uint8) (ChangeStatus, string) {
+	t.Helper()
+	v := datatype.Uint8(mode)
+	res, err := datamodeltest.Invoke(t, cluster, 1, ChangeToModeCommandID, 1, datatype.NewStruct(datatype.NewField(0, &v)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Command != ChangeToModeResponseCommandID {
+		t.Fatalf("response command (0x%02X)", res.Command)
+	}
+	fields := res.Fields.(*datatype.Struct)
+	status, _ := fields.LookupField(0)
+	text, _ := fields.LookupField(1)
+	s, _ := text.Value.(*datatype.Optional[*datatype.String]).Get()
+	return ChangeStatus(*status.Value.(*datatype.Enum8)), string(*s)
+}
+
+func TestValidateModes(t *testing.T) {
+	tests := []struct {
+		name  string
+		modes []ModeOption
+	}{
+		{"empty", []ModeOption{}},
+		{"duplicated mode", []ModeOption{NewModeOption("Idle", 0, testModeTagIdle), NewModeOption("Other", 0)}},
+		{"duplicated label", []ModeOption{NewModeOption("Idle", 0, testModeTagIdle), NewModeOption("Idle", 1)}},
+		{"unknown tag", []ModeOption{NewModeOption("Idle", 0, testModeTagIdle, 0x4010)}},
+		{"manufacturer tag without code", []ModeOption{NewModeOption("Idle", 0, testModeTagIdle, 0x8000)}},
+		{"no required tag", []ModeOption{NewModeOption("Cleaning", 0, testModeTagCleaning)}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := testDefinition.ValidateModes(test.modes); !errors.Is(err, ErrInvalid) {
+				t.Errorf("%v is not %v", err, ErrInvalid)
+			}
+		})
+	}
+
+	modes := testModes()
+	modes[2].ModeTags = append(modes[2].ModeTags, ModeTagValue{MfgCode: 0xFFF1, Value: 0x8001})
+	if err := testDefinition.ValidateModes(modes); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestModeCluster(t *testing.T) {
+	var requested *ModeOption
+	cluster, err := NewCluster(testDefinition, testModes(), 0,
+		WithChangeHandler(func(mode *ModeOption) (ChangeStatus, string) {
+			requested = mode
+			if mode.HasTag(ModeTagQuiet) {
+				return MinDerivedChangeStatus + 1, "Stuck"
+			}
+			return ChangeStatusSuccess, ""
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := cluster.ReadAttribute(SupportedModesAttributeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := datatype.Encode(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := NewModeOptionList(nil)
+	if err := datatype.Decode(b, decoded); err != nil || len(decoded.Elements) != 3 {
+		t.Fatalf("%d modes : %v", len(decoded.Elements), err)
+	}
+
+	version := cluster.DataVersion()
+	if status, _ := changeToMode(t, cluster, 1); status != ChangeStatusSuccess || requested.Mode != 1 {
+		t.Errorf("status (0x%02X)", status)
+	}
+	if cluster.CurrentMode().Mode != 1 || cluster.DataVersion() == version {
+		t.Errorf("current mode (%d)", cluster.CurrentMode().Mode)
+	}
+	if status, text := changeToMode(t, cluster, 2); status != MinDerivedChangeStatus+1 || text != "Stuck" {
+		t.Errorf("status (0x%02X) %s", status, text)
+	}
+	if status, _ := changeToMode(t, cluster, 9); status != ChangeStatusUnsupportedMode {
+		t.Errorf("status (0x%02X)", status)
+	}
+	if cluster.CurrentMode().Mode != 1 {
+		t.Errorf("current mode (%d)", cluster.CurrentMode().Mode)
+	}
+	if _, err := cluster.ReadAttribute(StartUpModeAttributeID); im.StatusOf(err) != im.StatusUnsupportedAttribute {
+		t.Errorf("%v is not %s", err, im.StatusUnsupportedAttribute)
+	}
+}
+
+func TestModeClusterWrite(t *testing.T) {
+	cluster, err := NewCluster(testDefinition, testModes(), 0, WithStartUpMode(2), WithOnMode(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	node := datamodel.NewNode()
+	ep := datamodel.NewEndpoint(1)
+	if err := ep.AddCluster(cluster); err != nil {
+		t.Fatal(err)
+	}
+	if err := node.AddEndpoint(ep); err != nil {
+		t.Fatal(err)
+	}
+
+	data := func(id im.AttributeID, n uint8) im.AttributeData {
+		mode := datatype.Uint8(n)
+		b, err := datatype.Encode(datatype.NewNullable(&mode))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return im.AttributeData{Path: im.NewAttributePath(1, testDefinition.ID, id), DataVersion: 0, Data: b}
+	}
+	privilege := func(im.AttributePath) datamodel.Privilege { return datamodel.OperatePrivilege }
+
+	// The start-up mode is not applied alone when the on mode is not supported.
+	statuses := node.Write(im.WriteRequest{
+		AttributeData: []im.AttributeData{data(StartUpModeAttributeID, 1), data(OnModeAttributeID, 9)},
+		Timed:         false,
+	}, 1, privilege)
+	if statuses[0].Status != im.StatusFailure || statuses[1].Status != im.StatusConstraintError {
+		t.Errorf("(%s, %s) != (%s, %s)", statuses[0].Status, statuses[1].Status, im.StatusFailure, im.StatusConstraintError)
+	}
+	if mode, _ := cluster.StartUpMode(); mode != 2 {
+		t.Errorf("start-up mode (%d) != (2)", mode)
+	}
+
+	statuses = node.Write(im.WriteRequest{
+		AttributeData: []im.AttributeData{data(StartUpModeAttributeID, 1), data(OnModeAttributeID, 2)},
+		Timed:         false,
+	}, 1, privilege)
+	for _, status := range statuses {
+		if status.Status != im.StatusSuccess {
+			t.Errorf("%s : %s", status.Path, status.Status)
+		}
+	}
+	if mode, _ := cluster.StartUpMode(); mode != 1 {
+		t.Errorf("start-up mode (%d) != (1)", mode)
+	}
+}
+
+func TestModeClusterOnMode(t *testing.T) {
+	cluster, err := NewCluster(testDefinition, testModes(), 0, WithStartUpMode(2), WithOnMode(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cluster.CurrentMode().Mode != 2 {
+		t.Errorf("current mode (%d) is not the start-up mode", cluster.CurrentMode().Mode)
+	}
+	if cluster.FeatureMap() != FeatureOnOff {
+		t.Errorf("feature map (0x%X)", cluster.FeatureMap())
+	}
+
+	mode := datatype.Uint8(9)
+	unsupported, _ := datatype.Encode(datatype.NewNullable(&mode))
+	if err := cluster.WriteAttribute(OnModeAttributeID, unsupported); im.StatusOf(err) != im.StatusConstraintError {
+		t.Errorf("%v is not %s", err, im.StatusConstraintError)
+	}
+	null, _ := datatype.Encode(datatype.NewNull(new(datatype.Uint8)))
+	if err := cluster.WriteAttribute(StartUpModeAttributeID, null); err != nil {
+		t.Error(err)
+	}
+	if _, ok := cluster.StartUpMode(); ok {
+		t.Error("start-up mode is not null")
+	}
+
+	if err := cluster.ApplyOnMode(); err != nil {
+		t.Fatal(err)
+	}
+	if cluster.CurrentMode().Mode != 1 {
+		t.Errorf("current mode (%d) is not the on mode", cluster.CurrentMode().Mode)
+	}
+
+	def := *testDefinition
+	def.SupportsStartUpMode = false
+	if _, err := NewCluster(&def, testModes(), 0, WithStartUpMode(0)); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+	if _, err := NewCluster(testDefinition, testModes(), 5); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+}
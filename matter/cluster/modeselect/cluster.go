@@ -0,0 +1,257 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modeselect
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cybergarage/go-matter/matter/cluster/modebase"
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// 1.9. Mode Select Cluster
+const (
+	ClusterID       im.ClusterID = 0x0050
+	ClusterRevision              = 2
+
+	// FeatureOnOff indicates that the OnMode attribute is supported.
+	FeatureOnOff uint32 = 0x01
+
+	DescriptionAttributeID       im.AttributeID = 0x0000
+	StandardNamespaceAttributeID im.AttributeID = 0x0001
+	SupportedModesAttributeID    im.AttributeID = 0x0002
+	CurrentModeAttributeID       im.AttributeID = 0x0003
+	StartUpModeAttributeID       im.AttributeID = 0x0004
+	OnModeAttributeID            im.AttributeID = 0x0005
+
+	ChangeToModeCommandID im.CommandID = 0x00
+
+	// MaxDescriptionLength is the maximum length of the description.
+	MaxDescriptionLength = 64
+)
+
+// ChangeHandler is called when a client requests to change the current mode to the specified mode.
+// The mode is changed only if the handler returns no error, and the error should carry an
+// interaction model status.
+type ChangeHandler func(mode *modebase.ModeOption) error
+
+// Cluster represents a Mode Select cluster server. The semantic tags of the modes share the layout
+// of the mode tags of the Mode Base cluster, and are interpreted in the standard namespace.
+type Cluster struct {
+	*datamodel.BaseCluster
+	mutex       sync.Mutex
+	modes       []modebase.ModeOption
+	currentMode *datatype.Uint8
+	startUpMode *datatype.Nullable[*datatype.Uint8]
+	onMode      *datatype.Nullable[*datatype.Uint8]
+	namespace   *datatype.Nullable[*datatype.Enum16]
+	handler     ChangeHandler
+}
+
+// Option represents an option of the mode select cluster.
+type Option func(*Cluster)
+
+// WithStandardNamespace sets the namespace of the semantic tags of the modes.
+func WithStandardNamespace(namespace uint16) Option {
+	return func(cluster *Cluster) {
+		v := datatype.Enum16(namespace)
+		cluster.namespace.Set(&v)
+	}
+}
+
+// WithStartUpMode sets the mode which the device moves to when it is powered up.
+func WithStartUpMode(mode uint8) Option {
+	return func(cluster *Cluster) {
+		v := datatype.Uint8(mode)
+		cluster.startUpMode = datatype.NewNullable(&v)
+	}
+}
+
+// WithOnMode enables the OnOff feature, and sets the mode which the device moves to when it is turned on.
+func WithOnMode(mode uint8) Option {
+	return func(cluster *Cluster) {
+		v := datatype.Uint8(mode)
+		cluster.onMode = datatype.NewNullable(&v)
+	}
+}
+
+// WithChangeHandler sets the handler which accepts or rejects the mode changes requested by clients.
+func WithChangeHandler(handler ChangeHandler) Option {
+	return func(cluster *Cluster) {
+		cluster.handler = handler
+	}
+}
+
+// NewCluster returns a new mode select cluster of the specified description and modes. The current
+// mode is overridden by the start-up mode if it is specified.
+func NewCluster(description string, modes []modebase.ModeOption, current uint8, opts ...Option) (*Cluster, error) {
+	if MaxDescriptionLength < len(description) {
+		return nil, fmt.Errorf("%w description (%s)", modebase.ErrInvalid, description)
+	}
+	if err := modebase.ValidateModes(modes, nil); err != nil {
+		return nil, err
+	}
+	currentMode := datatype.Uint8(current)
+	cluster := &Cluster{
+		BaseCluster: datamodel.NewBaseCluster(ClusterID, ClusterRevision),
+		mutex:       sync.Mutex{},
+		modes:       append([]modebase.ModeOption{}, modes...),
+		currentMode: &currentMode,
+		startUpMode: nil,
+		onMode:      nil,
+		namespace:   datatype.NewNull(new(datatype.Enum16)),
+		handler:     nil,
+	}
+	for _, opt := range opts {
+		opt(cluster)
+	}
+
+	for _, mode := range []*datatype.Nullable[*datatype.Uint8]{cluster.startUpMode, cluster.onMode} {
+		if mode != nil && !cluster.isSupported(*mode.Value) {
+			return nil, fmt.Errorf("%w mode (%d) : not supported", modebase.ErrInvalid, *mode.Value)
+		}
+	}
+	if cluster.startUpMode != nil {
+		*cluster.currentMode = *cluster.startUpMode.Value
+	}
+	if !cluster.isSupported(*cluster.currentMode) {
+		return nil, fmt.Errorf("%w mode (%d) : not supported", modebase.ErrInvalid, *cluster.currentMode)
+	}
+
+	desc := datatype.String(description)
+	cluster.AddAttribute(datamodel.NewAttribute(DescriptionAttributeID, &desc))
+	cluster.AddAttribute(datamodel.NewAttribute(StandardNamespaceAttributeID, cluster.namespace))
+	cluster.AddAttribute(datamodel.NewAttribute(SupportedModesAttributeID, modebase.NewModeOptionList(cluster.modes)))
+	cluster.AddAttribute(datamodel.NewAttribute(CurrentModeAttributeID, cluster.currentMode))
+	if cluster.startUpMode != nil {
+		cluster.AddAttribute(datamodel.NewWritableAttribute(StartUpModeAttributeID, cluster.startUpMode))
+	}
+	if cluster.onMode != nil {
+		cluster.SetFeatureMap(FeatureOnOff)
+		cluster.AddAttribute(datamodel.NewWritableAttribute(OnModeAttributeID, cluster.onMode))
+	}
+	// StartUpMode and OnMode are configured together, so a write request does not apply either alone.
+	cluster.SetTransactional(true)
+	cluster.AddCommand(ChangeToModeCommandID, cluster.changeToMode)
+
+	return cluster, nil
+}
+
+func (cluster *Cluster) isSupported(mode datatype.Uint8) bool {
+	for _, opt := range cluster.modes {
+		if opt.Mode == uint8(mode) {
+			return true
+		}
+	}
+	return false
+}
+
+func (cluster *Cluster) lookupMode(mode uint8) (*modebase.ModeOption, bool) {
+	for n := range cluster.modes {
+		if cluster.modes[n].Mode == mode {
+			return &cluster.modes[n], true
+		}
+	}
+	return nil, false
+}
+
+// SupportedModes returns the supported modes.
+func (cluster *Cluster) SupportedModes() []modebase.ModeOption {
+	return append([]modebase.ModeOption{}, cluster.modes...)
+}
+
+// CurrentMode returns the current mode.
+func (cluster *Cluster) CurrentMode() modebase.ModeOption {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	mode, _ := cluster.lookupMode(uint8(*cluster.currentMode))
+	return *mode
+}
+
+// SetCurrentMode changes the current mode without calling the change handler.
+func (cluster *Cluster) SetCurrentMode(mode uint8) error {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	return cluster.setCurrentMode(mode)
+}
+
+func (cluster *Cluster) setCurrentMode(mode uint8) error {
+	if !cluster.isSupported(datatype.Uint8(mode)) {
+		return fmt.Errorf("%w mode (%d) : not supported", modebase.ErrInvalid, mode)
+	}
+	if uint8(*cluster.currentMode) == mode {
+		return nil
+	}
+	v := datatype.Uint8(mode)
+	cluster.currentMode = &v
+	return cluster.SetAttribute(CurrentModeAttributeID, cluster.currentMode)
+}
+
+// ApplyOnMode changes the current mode to the OnMode if it is not null. The On/Off cluster on the same
+// endpoint calls it when the device is turned on.
+func (cluster *Cluster) ApplyOnMode() error {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	v, err := cluster.BaseCluster.ReadAttribute(OnModeAttributeID)
+	if err != nil {
+		return nil
+	}
+	mode, ok := v.(*datatype.Nullable[*datatype.Uint8]).Get()
+	if !ok {
+		return nil
+	}
+	return cluster.setCurrentMode(uint8(*mode))
+}
+
+// WriteAttribute writes the specified attribute, and rejects the start-up and on modes which are not supported.
+func (cluster *Cluster) WriteAttribute(id im.AttributeID, data []byte) error {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	switch id {
+	case StartUpModeAttributeID, OnModeAttributeID:
+		mode := datatype.NewNull(new(datatype.Uint8))
+		if err := datatype.Decode(data, mode); err != nil {
+			break
+		}
+		if v, ok := mode.Get(); ok && !cluster.isSupported(*v) {
+			return fmt.Errorf("%w : mode (%d)", im.StatusConstraintError, *v)
+		}
+	}
+	return cluster.BaseCluster.WriteAttribute(id, data)
+}
+
+func (cluster *Cluster) changeToMode(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	newMode := new(datatype.Uint8)
+	if err := req.DecodeFields(datatype.NewStruct(datatype.NewField(0, newMode))); err != nil {
+		return nil, err
+	}
+
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+
+	mode, ok := cluster.lookupMode(uint8(*newMode))
+	if !ok {
+		return nil, fmt.Errorf("%w : mode (%d) is not supported", im.StatusInvalidCommand, *newMode)
+	}
+	if *newMode != *cluster.currentMode && cluster.handler != nil {
+		if err := cluster.handler(mode); err != nil {
+			return nil, err
+		}
+	}
+	return nil, cluster.setCurrentMode(uint8(*newMode))
+}
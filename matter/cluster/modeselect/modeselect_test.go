@@ -0,0 +1,79 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modeselect
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/cluster/modebase"
+	"github.com/cybergarage/go-matter/matter/datamodel/datamodeltest"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+func changeToMode(t *testing.T, cluster *Cluster, mode uint8) error {
+	t.Helper()
+	v := datatype.Uint8(mode)
+	_, err := datamodeltest.Invoke(t, cluster, 1, ChangeToModeCommandID, 1, datatype.NewStruct(datatype.NewField(0, &v)))
+	return err
+}
+
+func TestModeSelectCluster(t *testing.T) {
+	modes := []modebase.ModeOption{
+		modebase.NewModeOption("Black", 0),
+		modebase.NewModeOption("Cappuccino", 4),
+		modebase.NewModeOption("Espresso", 7),
+	}
+	cluster, err := NewCluster("Coffee", modes, 0,
+		WithStandardNamespace(0x0001),
+		WithChangeHandler(func(mode *modebase.ModeOption) error {
+			if mode.Label == "Espresso" {
+				return im.StatusBusy
+			}
+			return nil
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := changeToMode(t, cluster, 4); err != nil {
+		t.Error(err)
+	}
+	if cluster.CurrentMode().Label != "Cappuccino" {
+		t.Errorf("current mode (%s)", cluster.CurrentMode().Label)
+	}
+	if err := changeToMode(t, cluster, 7); im.StatusOf(err) != im.StatusBusy {
+		t.Errorf("%v is not %s", err, im.StatusBusy)
+	}
+	if err := changeToMode(t, cluster, 9); im.StatusOf(err) != im.StatusInvalidCommand {
+		t.Errorf("%v is not %s", err, im.StatusInvalidCommand)
+	}
+	if cluster.CurrentMode().Mode != 4 {
+		t.Errorf("current mode (%d)", cluster.CurrentMode().Mode)
+	}
+
+	v, err := cluster.ReadAttribute(StandardNamespaceAttributeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ns, ok := v.(*datatype.Nullable[*datatype.Enum16]).Get(); !ok || *ns != 0x0001 {
+		t.Errorf("standard namespace %v", v)
+	}
+
+	if _, err := NewCluster("Coffee", modes, 1); !errors.Is(err, modebase.ErrInvalid) {
+		t.Errorf("%v is not %v", err, modebase.ErrInvalid)
+	}
+}
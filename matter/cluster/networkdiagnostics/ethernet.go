@@ -0,0 +1,45 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkdiagnostics
+
+// PHYRate represents a physical layer rate of Ethernet interfaces (PHYRateEnum).
+type PHYRate uint8
+
+const (
+	PHYRate10M  PHYRate = 0x00
+	PHYRate100M PHYRate = 0x01
+	PHYRate1G   PHYRate = 0x02
+	PHYRate2_5G PHYRate = 0x03
+	PHYRate5G   PHYRate = 0x04
+	PHYRate10G  PHYRate = 0x05
+	PHYRate40G  PHYRate = 0x06
+	PHYRate100G PHYRate = 0x07
+	PHYRate200G PHYRate = 0x08
+	PHYRate400G PHYRate = 0x09
+)
+
+// EthernetDiagnostics represents the attributes of an Ethernet Network Diagnostics cluster. The nullable
+// attributes and the counters of the optional features are nil if the node has no values.
+type EthernetDiagnostics struct {
+	PHYRate        *PHYRate `tlv:"*/EthernetNetworkDiagnostics/PHYRate"`
+	FullDuplex     *bool    `tlv:"*/EthernetNetworkDiagnostics/FullDuplex"`
+	PacketRxCount  *uint64  `tlv:"*/EthernetNetworkDiagnostics/PacketRxCount"`
+	PacketTxCount  *uint64  `tlv:"*/EthernetNetworkDiagnostics/PacketTxCount"`
+	TxErrCount     *uint64  `tlv:"*/EthernetNetworkDiagnostics/TxErrCount"`
+	CollisionCount *uint64  `tlv:"*/EthernetNetworkDiagnostics/CollisionCount"`
+	OverrunCount   *uint64  `tlv:"*/EthernetNetworkDiagnostics/OverrunCount"`
+	CarrierDetect  *bool    `tlv:"*/EthernetNetworkDiagnostics/CarrierDetect"`
+	TimeSinceReset *uint64  `tlv:"*/EthernetNetworkDiagnostics/TimeSinceReset"`
+}
@@ -0,0 +1,42 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package networkdiagnostics provides the types of the Wi-Fi, Thread and Ethernet Network Diagnostics
+// clusters, which the clients decode the attributes of a node into.
+package networkdiagnostics
+
+import (
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// 11.14. Thread Network Diagnostics Cluster
+// 11.15. Wi-Fi Network Diagnostics Cluster
+// 11.16. Ethernet Network Diagnostics Cluster
+const (
+	ThreadClusterID   im.ClusterID = 0x0035
+	WiFiClusterID     im.ClusterID = 0x0036
+	EthernetClusterID im.ClusterID = 0x0037
+
+	// FeaturePacketCounts indicates that the packet counters are supported.
+	FeaturePacketCounts uint32 = 0x01
+	// FeatureErrorCounts indicates that the error counters are supported.
+	FeatureErrorCounts uint32 = 0x02
+	// FeatureMLECounts indicates that the Mesh Link Establishment counters of Thread are supported.
+	FeatureMLECounts uint32 = 0x04
+	// FeatureMACCounts indicates that the MAC counters of Thread are supported.
+	FeatureMACCounts uint32 = 0x08
+
+	// ResetCountsCommandID resets the counters of the cluster, which is the same in all the clusters.
+	ResetCountsCommandID im.CommandID = 0x00
+)
@@ -0,0 +1,45 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkdiagnostics
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func TestMeshLocalPrefix(t *testing.T) {
+	tests := []struct {
+		prefix []byte
+		want   netip.Prefix
+		err    error
+	}{
+		{nil, netip.Prefix{}, nil},
+		{[]byte{64, 0xFD, 0x00, 0x0D, 0xB8, 0x00, 0x00, 0x00, 0x00}, netip.MustParsePrefix("fd00:db8::/64"), nil},
+		{[]byte{64, 0xFD, 0x00}, netip.Prefix{}, ErrInvalid},
+		{[]byte{129}, netip.Prefix{}, ErrInvalid},
+	}
+	for _, test := range tests {
+		diag := &ThreadDiagnostics{MeshLocalPrefix: test.prefix}
+		prefix, err := diag.Prefix()
+		if !errors.Is(err, test.err) {
+			t.Errorf("%X : %v is not %v", test.prefix, err, test.err)
+			continue
+		}
+		if prefix != test.want {
+			t.Errorf("%X : %s != %s", test.prefix, prefix, test.want)
+		}
+	}
+}
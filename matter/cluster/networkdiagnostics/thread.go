@@ -0,0 +1,106 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkdiagnostics
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// RoutingRole represents a role of a Thread node (RoutingRoleEnum).
+type RoutingRole uint8
+
+const (
+	RoutingRoleUnspecified     RoutingRole = 0x00
+	RoutingRoleUnassigned      RoutingRole = 0x01
+	RoutingRoleSleepyEndDevice RoutingRole = 0x02
+	RoutingRoleEndDevice       RoutingRole = 0x03
+	RoutingRoleREED            RoutingRole = 0x04
+	RoutingRoleRouter          RoutingRole = 0x05
+	RoutingRoleLeader          RoutingRole = 0x06
+)
+
+// Neighbor represents a neighbor of a Thread node (NeighborTableStruct).
+type Neighbor struct {
+	ExtAddress       uint64 `tlv:"0"`
+	Age              uint32 `tlv:"1"`
+	Rloc16           uint16 `tlv:"2"`
+	LinkFrameCounter uint32 `tlv:"3"`
+	MleFrameCounter  uint32 `tlv:"4"`
+	LQI              uint8  `tlv:"5"`
+	AverageRssi      *int8  `tlv:"6"`
+	LastRssi         *int8  `tlv:"7"`
+	FrameErrorRate   uint8  `tlv:"8"`
+	MessageErrorRate uint8  `tlv:"9"`
+	RxOnWhenIdle     bool   `tlv:"10"`
+	FullThreadDevice bool   `tlv:"11"`
+	FullNetworkData  bool   `tlv:"12"`
+	IsChild          bool   `tlv:"13"`
+}
+
+// ThreadDiagnostics represents the attributes of a Thread Network Diagnostics cluster. The nullable
+// attributes and the counters of the optional features are nil if the node has no values.
+type ThreadDiagnostics struct {
+	Channel                           *uint16      `tlv:"*/ThreadNetworkDiagnostics/Channel"`
+	RoutingRole                       *RoutingRole `tlv:"*/ThreadNetworkDiagnostics/RoutingRole"`
+	NetworkName                       *string      `tlv:"*/ThreadNetworkDiagnostics/NetworkName"`
+	PanID                             *uint16      `tlv:"*/ThreadNetworkDiagnostics/PanID"`
+	ExtendedPanID                     *uint64      `tlv:"*/ThreadNetworkDiagnostics/ExtendedPanID"`
+	MeshLocalPrefix                   []byte       `tlv:"*/ThreadNetworkDiagnostics/MeshLocalPrefix"`
+	OverrunCount                      *uint64      `tlv:"*/ThreadNetworkDiagnostics/OverrunCount"`
+	NeighborTable                     []Neighbor   `tlv:"*/ThreadNetworkDiagnostics/NeighborTable"`
+	PartitionID                       *uint32      `tlv:"*/ThreadNetworkDiagnostics/PartitionID"`
+	Weighting                         *uint8       `tlv:"*/ThreadNetworkDiagnostics/Weighting"`
+	LeaderRouterID                    *uint8       `tlv:"*/ThreadNetworkDiagnostics/LeaderRouterID"`
+	DetachedRoleCount                 *uint16      `tlv:"*/ThreadNetworkDiagnostics/DetachedRoleCount"`
+	ChildRoleCount                    *uint16      `tlv:"*/ThreadNetworkDiagnostics/ChildRoleCount"`
+	RouterRoleCount                   *uint16      `tlv:"*/ThreadNetworkDiagnostics/RouterRoleCount"`
+	LeaderRoleCount                   *uint16      `tlv:"*/ThreadNetworkDiagnostics/LeaderRoleCount"`
+	AttachAttemptCount                *uint16      `tlv:"*/ThreadNetworkDiagnostics/AttachAttemptCount"`
+	PartitionIDChangeCount            *uint16      `tlv:"*/ThreadNetworkDiagnostics/PartitionIDChangeCount"`
+	BetterPartitionAttachAttemptCount *uint16      `tlv:"*/ThreadNetworkDiagnostics/BetterPartitionAttachAttemptCount"`
+	ParentChangeCount                 *uint16      `tlv:"*/ThreadNetworkDiagnostics/ParentChangeCount"`
+	TxTotalCount                      *uint32      `tlv:"*/ThreadNetworkDiagnostics/TxTotalCount"`
+	TxUnicastCount                    *uint32      `tlv:"*/ThreadNetworkDiagnostics/TxUnicastCount"`
+	TxBroadcastCount                  *uint32      `tlv:"*/ThreadNetworkDiagnostics/TxBroadcastCount"`
+	TxRetryCount                      *uint32      `tlv:"*/ThreadNetworkDiagnostics/TxRetryCount"`
+	TxErrCcaCount                     *uint32      `tlv:"*/ThreadNetworkDiagnostics/TxErrCcaCount"`
+	TxErrAbortCount                   *uint32      `tlv:"*/ThreadNetworkDiagnostics/TxErrAbortCount"`
+	TxErrBusyChannelCount             *uint32      `tlv:"*/ThreadNetworkDiagnostics/TxErrBusyChannelCount"`
+	RxTotalCount                      *uint32      `tlv:"*/ThreadNetworkDiagnostics/RxTotalCount"`
+	RxUnicastCount                    *uint32      `tlv:"*/ThreadNetworkDiagnostics/RxUnicastCount"`
+	RxBroadcastCount                  *uint32      `tlv:"*/ThreadNetworkDiagnostics/RxBroadcastCount"`
+	RxDuplicatedCount                 *uint32      `tlv:"*/ThreadNetworkDiagnostics/RxDuplicatedCount"`
+	RxErrNoFrameCount                 *uint32      `tlv:"*/ThreadNetworkDiagnostics/RxErrNoFrameCount"`
+	RxErrSecCount                     *uint32      `tlv:"*/ThreadNetworkDiagnostics/RxErrSecCount"`
+	RxErrFcsCount                     *uint32      `tlv:"*/ThreadNetworkDiagnostics/RxErrFcsCount"`
+	RxErrOtherCount                   *uint32      `tlv:"*/ThreadNetworkDiagnostics/RxErrOtherCount"`
+}
+
+// Prefix returns the mesh local prefix, which is encoded as the prefix length in bits followed by
+// the bytes of the prefix. The prefix is invalid if the node has no mesh local prefix.
+func (diag *ThreadDiagnostics) Prefix() (netip.Prefix, error) {
+	if len(diag.MeshLocalPrefix) == 0 {
+		return netip.Prefix{}, nil
+	}
+	bits := int(diag.MeshLocalPrefix[0])
+	b := diag.MeshLocalPrefix[1:]
+	if 128 < bits || len(b) != (bits+7)/8 {
+		return netip.Prefix{}, fmt.Errorf("%w mesh local prefix (%X)", ErrInvalid, diag.MeshLocalPrefix)
+	}
+	addr := [16]byte{}
+	copy(addr[:], b)
+	return netip.PrefixFrom(netip.AddrFrom16(addr), bits), nil
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkdiagnostics
+
+// SecurityType represents a security type of Wi-Fi networks (SecurityTypeEnum).
+type SecurityType uint8
+
+const (
+	SecurityTypeUnspecified SecurityType = 0x00
+	SecurityTypeNone        SecurityType = 0x01
+	SecurityTypeWEP         SecurityType = 0x02
+	SecurityTypeWPA         SecurityType = 0x03
+	SecurityTypeWPA2        SecurityType = 0x04
+	SecurityTypeWPA3        SecurityType = 0x05
+)
+
+// WiFiVersion represents an 802.11 standard of Wi-Fi networks (WiFiVersionEnum).
+type WiFiVersion uint8
+
+const (
+	WiFiVersionA  WiFiVersion = 0x00
+	WiFiVersionB  WiFiVersion = 0x01
+	WiFiVersionG  WiFiVersion = 0x02
+	WiFiVersionN  WiFiVersion = 0x03
+	WiFiVersionAC WiFiVersion = 0x04
+	WiFiVersionAX WiFiVersion = 0x05
+	WiFiVersionAH WiFiVersion = 0x06
+)
+
+// WiFiDiagnostics represents the attributes of a Wi-Fi Network Diagnostics cluster. The nullable
+// attributes and the counters of the optional features are nil if the node has no values.
+type WiFiDiagnostics struct {
+	BSSID                  []byte        `tlv:"*/WiFiNetworkDiagnostics/BSSID"`
+	SecurityType           *SecurityType `tlv:"*/WiFiNetworkDiagnostics/SecurityType"`
+	WiFiVersion            *WiFiVersion  `tlv:"*/WiFiNetworkDiagnostics/WiFiVersion"`
+	ChannelNumber          *uint16       `tlv:"*/WiFiNetworkDiagnostics/ChannelNumber"`
+	RSSI                   *int8         `tlv:"*/WiFiNetworkDiagnostics/RSSI"`
+	BeaconLostCount        *uint32       `tlv:"*/WiFiNetworkDiagnostics/BeaconLostCount"`
+	BeaconRxCount          *uint32       `tlv:"*/WiFiNetworkDiagnostics/BeaconRxCount"`
+	PacketMulticastRxCount *uint32       `tlv:"*/WiFiNetworkDiagnostics/PacketMulticastRxCount"`
+	PacketMulticastTxCount *uint32       `tlv:"*/WiFiNetworkDiagnostics/PacketMulticastTxCount"`
+	PacketUnicastRxCount   *uint32       `tlv:"*/WiFiNetworkDiagnostics/PacketUnicastRxCount"`
+	PacketUnicastTxCount   *uint32       `tlv:"*/WiFiNetworkDiagnostics/PacketUnicastTxCount"`
+	CurrentMaxRate         *uint64       `tlv:"*/WiFiNetworkDiagnostics/CurrentMaxRate"`
+	OverrunCount           *uint64       `tlv:"*/WiFiNetworkDiagnostics/OverrunCount"`
+}
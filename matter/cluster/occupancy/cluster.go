@@ -0,0 +1,167 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package occupancy
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// 2.7. Occupancy Sensing Cluster
+const (
+	ClusterID       im.ClusterID = 0x0406
+	ClusterRevision              = 4
+
+	OccupancyAttributeID                 im.AttributeID = 0x0000
+	OccupancySensorTypeAttributeID       im.AttributeID = 0x0001
+	OccupancySensorTypeBitmapAttributeID im.AttributeID = 0x0002
+
+	occupied datatype.Bitmap8 = 0x01
+)
+
+// SensorType represents a type of occupancy sensors.
+type SensorType uint8
+
+const (
+	SensorTypePIR              SensorType = 0x00
+	SensorTypeUltrasonic       SensorType = 0x01
+	SensorTypePIRAndUltrasonic SensorType = 0x02
+	SensorTypePhysicalContact  SensorType = 0x03
+)
+
+// bitmap returns the OccupancySensorTypeBitmap value of the sensor type.
+func (typ SensorType) bitmap() datatype.Bitmap8 {
+	switch typ {
+	case SensorTypePIR:
+		return 0x01
+	case SensorTypeUltrasonic:
+		return 0x02
+	case SensorTypePIRAndUltrasonic:
+		return 0x03
+	case SensorTypePhysicalContact:
+		return 0x04
+	}
+	return 0
+}
+
+// Reader is called to read the current occupancy from the sensor whenever the occupancy is read.
+// The previous occupancy is kept while the reader returns an error.
+type Reader func() (bool, error)
+
+// Cluster represents an Occupancy Sensing cluster server whose occupancy is set by the application,
+// fed from a channel, or read from a reader.
+type Cluster struct {
+	*datamodel.BaseCluster
+	mutex     sync.Mutex
+	occupancy *datatype.Bitmap8
+	reader    Reader
+}
+
+// Option represents an option of the occupancy sensing cluster.
+type Option func(*Cluster)
+
+// WithReader sets the reader which is called whenever the occupancy is read.
+func WithReader(reader Reader) Option {
+	return func(cluster *Cluster) {
+		cluster.reader = reader
+	}
+}
+
+// NewCluster returns a new occupancy sensing cluster of the specified sensor type, which is unoccupied until it is set.
+func NewCluster(typ SensorType, opts ...Option) *Cluster {
+	occupancy := datatype.Bitmap8(0)
+	cluster := &Cluster{
+		BaseCluster: datamodel.NewBaseCluster(ClusterID, ClusterRevision),
+		mutex:       sync.Mutex{},
+		occupancy:   &occupancy,
+		reader:      nil,
+	}
+	for _, opt := range opts {
+		opt(cluster)
+	}
+
+	sensorType := datatype.Enum8(typ)
+	sensorTypeBitmap := typ.bitmap()
+	cluster.AddAttribute(datamodel.NewAttribute(OccupancyAttributeID, cluster.occupancy))
+	cluster.AddAttribute(datamodel.NewAttribute(OccupancySensorTypeAttributeID, &sensorType))
+	cluster.AddAttribute(datamodel.NewAttribute(OccupancySensorTypeBitmapAttributeID, &sensorTypeBitmap))
+
+	return cluster
+}
+
+// Set sets the occupancy.
+func (cluster *Cluster) Set(v bool) {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	cluster.set(v)
+}
+
+func (cluster *Cluster) set(v bool) {
+	if cluster.occupancy.Has(occupied) == v {
+		return
+	}
+	occupancy := datatype.Bitmap8(0)
+	if v {
+		occupancy = occupied
+	}
+	cluster.occupancy = &occupancy
+	cluster.SetAttribute(OccupancyAttributeID, cluster.occupancy)
+}
+
+// Occupied returns true if the sensed area is occupied.
+func (cluster *Cluster) Occupied() bool {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	return cluster.occupancy.Has(occupied)
+}
+
+// Feed sets the occupancy received from the specified channel until the channel is closed or the context is done.
+func (cluster *Cluster) Feed(ctx context.Context, values <-chan bool) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case v, ok := <-values:
+			if !ok {
+				return nil
+			}
+			cluster.Set(v)
+		}
+	}
+}
+
+// Update reads the current occupancy with the reader if it is set.
+func (cluster *Cluster) Update() {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	if cluster.reader == nil {
+		return
+	}
+	if v, err := cluster.reader(); err == nil {
+		cluster.set(v)
+	}
+}
+
+// ReadAttribute returns the value of the specified attribute after reading the occupancy with the reader.
+func (cluster *Cluster) ReadAttribute(id im.AttributeID) (datatype.Value, error) {
+	if id == OccupancyAttributeID {
+		cluster.Update()
+	}
+	return cluster.BaseCluster.ReadAttribute(id)
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package occupancy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+)
+
+func TestOccupancyCluster(t *testing.T) {
+	cluster := NewCluster(SensorTypePIRAndUltrasonic)
+	v, err := cluster.ReadAttribute(OccupancySensorTypeBitmapAttributeID)
+	if err != nil || *v.(*datatype.Bitmap8) != 0x03 {
+		t.Errorf("sensor type bitmap %v : %v", v, err)
+	}
+
+	values := make(chan bool, 1)
+	values <- true
+	close(values)
+	if err := cluster.Feed(context.Background(), values); err != nil {
+		t.Fatal(err)
+	}
+	v, err = cluster.ReadAttribute(OccupancyAttributeID)
+	if err != nil || *v.(*datatype.Bitmap8) != occupied {
+		t.Errorf("occupancy %v : %v", v, err)
+	}
+
+	version := cluster.DataVersion()
+	cluster.Set(true)
+	if cluster.DataVersion() != version {
+		t.Error("data version is changed by the same occupancy")
+	}
+
+	sensed := false
+	cluster = NewCluster(SensorTypePhysicalContact, WithReader(func() (bool, error) {
+		return sensed, nil
+	}))
+	sensed = true
+	if v, _ := cluster.ReadAttribute(OccupancyAttributeID); *v.(*datatype.Bitmap8) != occupied {
+		t.Errorf("occupancy (%v) is not read", v)
+	}
+	if !cluster.Occupied() {
+		t.Error("not occupied")
+	}
+}
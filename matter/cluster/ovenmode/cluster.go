@@ -0,0 +1,60 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovenmode
+
+import (
+	"github.com/cybergarage/go-matter/matter/cluster/modebase"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// Oven Mode Cluster
+const (
+	ClusterID       im.ClusterID = 0x0049
+	ClusterRevision              = 1
+
+	ModeTagBake            modebase.ModeTag = 0x4000
+	ModeTagConvection      modebase.ModeTag = 0x4001
+	ModeTagGrill           modebase.ModeTag = 0x4002
+	ModeTagRoast           modebase.ModeTag = 0x4003
+	ModeTagClean           modebase.ModeTag = 0x4004
+	ModeTagConvectionBake  modebase.ModeTag = 0x4005
+	ModeTagConvectionRoast modebase.ModeTag = 0x4006
+	ModeTagWarming         modebase.ModeTag = 0x4007
+	ModeTagProofing        modebase.ModeTag = 0x4008
+)
+
+// Definition is the definition of the Oven Mode cluster.
+var Definition = &modebase.Definition{
+	ID:       ClusterID,
+	Revision: ClusterRevision,
+	ModeTags: map[modebase.ModeTag]string{
+		ModeTagBake:            "Bake",
+		ModeTagConvection:      "Convection",
+		ModeTagGrill:           "Grill",
+		ModeTagRoast:           "Roast",
+		ModeTagClean:           "Clean",
+		ModeTagConvectionBake:  "ConvectionBake",
+		ModeTagConvectionRoast: "ConvectionRoast",
+		ModeTagWarming:         "Warming",
+		ModeTagProofing:        "Proofing",
+	},
+	RequiredModeTags:    []modebase.ModeTag{},
+	SupportsStartUpMode: true,
+}
+
+// NewCluster returns a new Oven Mode cluster of the specified modes.
+func NewCluster(modes []modebase.ModeOption, current uint8, opts ...modebase.Option) (*modebase.Cluster, error) {
+	return modebase.NewCluster(Definition, modes, current, opts...)
+}
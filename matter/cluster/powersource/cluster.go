@@ -0,0 +1,383 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package powersource
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// 11.7. Power Source Cluster
+const (
+	ClusterID       im.ClusterID = 0x002F
+	ClusterRevision              = 2
+
+	// FeatureWired indicates that the power source is wired, such as a mains supply.
+	FeatureWired uint32 = 0x01
+	// FeatureBattery indicates that the power source is a battery.
+	FeatureBattery uint32 = 0x02
+	// FeatureRechargeable indicates that the battery is rechargeable.
+	FeatureRechargeable uint32 = 0x04
+	// FeatureReplaceable indicates that the battery is replaceable.
+	FeatureReplaceable uint32 = 0x08
+
+	StatusAttributeID                     im.AttributeID = 0x0000
+	OrderAttributeID                      im.AttributeID = 0x0001
+	DescriptionAttributeID                im.AttributeID = 0x0002
+	WiredCurrentTypeAttributeID           im.AttributeID = 0x0005
+	BatVoltageAttributeID                 im.AttributeID = 0x000B
+	BatPercentRemainingAttributeID        im.AttributeID = 0x000C
+	BatChargeLevelAttributeID             im.AttributeID = 0x000E
+	BatReplacementNeededAttributeID       im.AttributeID = 0x000F
+	BatReplaceabilityAttributeID          im.AttributeID = 0x0010
+	BatReplacementDescriptionAttributeID  im.AttributeID = 0x0013
+	BatQuantityAttributeID                im.AttributeID = 0x0019
+	BatChargeStateAttributeID             im.AttributeID = 0x001A
+	BatFunctionalWhileChargingAttributeID im.AttributeID = 0x001C
+	EndpointListAttributeID               im.AttributeID = 0x001F
+)
+
+// Status represents a status of power sources (PowerSourceStatusEnum).
+type Status uint8
+
+const (
+	StatusUnspecified Status = 0x00
+	StatusActive      Status = 0x01
+	StatusStandby     Status = 0x02
+	StatusUnavailable Status = 0x03
+)
+
+// WiredCurrentType represents a current type of wired power sources (WiredCurrentTypeEnum).
+type WiredCurrentType uint8
+
+const (
+	WiredCurrentTypeAC WiredCurrentType = 0x00
+	WiredCurrentTypeDC WiredCurrentType = 0x01
+)
+
+// ChargeLevel represents a charge level of batteries (BatChargeLevelEnum).
+type ChargeLevel uint8
+
+const (
+	ChargeLevelOK       ChargeLevel = 0x00
+	ChargeLevelWarning  ChargeLevel = 0x01
+	ChargeLevelCritical ChargeLevel = 0x02
+)
+
+// Replaceability represents how batteries are replaced (BatReplaceabilityEnum).
+type Replaceability uint8
+
+const (
+	ReplaceabilityUnspecified        Replaceability = 0x00
+	ReplaceabilityNotReplaceable     Replaceability = 0x01
+	ReplaceabilityUserReplaceable    Replaceability = 0x02
+	ReplaceabilityFactoryReplaceable Replaceability = 0x03
+)
+
+// ChargeState represents a charging state of rechargeable batteries (BatChargeStateEnum).
+type ChargeState uint8
+
+const (
+	ChargeStateUnknown        ChargeState = 0x00
+	ChargeStateIsCharging     ChargeState = 0x01
+	ChargeStateIsAtFullCharge ChargeState = 0x02
+	ChargeStateIsNotCharging  ChargeState = 0x03
+)
+
+// Cluster represents a Power Source cluster server of a wired supply or a battery, whose status and
+// battery state are set by the application.
+type Cluster struct {
+	*datamodel.BaseCluster
+	mutex          sync.Mutex
+	features       uint32
+	order          uint8
+	description    string
+	currentType    WiredCurrentType
+	replaceability Replaceability
+	replacement    string
+	quantity       uint8
+	functional     bool
+	endpoints      []im.EndpointID
+	status         Status
+	voltage        *uint32
+	percent        *uint8
+	chargeLevel    ChargeLevel
+	needed         bool
+	chargeState    ChargeState
+}
+
+// Option represents an option of the power source cluster.
+type Option func(*Cluster)
+
+// WithOrder sets the order of the power source, where lower values are used in preference to higher ones.
+func WithOrder(order uint8) Option {
+	return func(cluster *Cluster) {
+		cluster.order = order
+	}
+}
+
+// WithWired makes the power source a wired supply of the specified current type, which is the default.
+func WithWired(typ WiredCurrentType) Option {
+	return func(cluster *Cluster) {
+		cluster.features = FeatureWired
+		cluster.currentType = typ
+	}
+}
+
+// WithBattery makes the power source a battery of the specified replaceability.
+func WithBattery(replaceability Replaceability) Option {
+	return func(cluster *Cluster) {
+		cluster.features = (cluster.features &^ FeatureWired) | FeatureBattery
+		cluster.replaceability = replaceability
+	}
+}
+
+// WithRechargeable makes the power source a rechargeable battery, which is functional while charging if specified.
+func WithRechargeable(functional bool) Option {
+	return func(cluster *Cluster) {
+		cluster.features = (cluster.features &^ FeatureWired) | FeatureBattery | FeatureRechargeable
+		cluster.functional = functional
+	}
+}
+
+// WithReplaceable makes the power source a replaceable battery with the description of the replacement
+// such as "AA battery", and the quantity of the batteries.
+func WithReplaceable(description string, quantity uint8) Option {
+	return func(cluster *Cluster) {
+		cluster.features = (cluster.features &^ FeatureWired) | FeatureBattery | FeatureReplaceable
+		cluster.replacement = description
+		cluster.quantity = quantity
+	}
+}
+
+// WithEndpoints sets the endpoints which are powered by the power source.
+func WithEndpoints(ids ...im.EndpointID) Option {
+	return func(cluster *Cluster) {
+		cluster.endpoints = ids
+	}
+}
+
+// NewCluster returns a new power source cluster of the specified description, which is an active wired
+// AC supply unless the options make it a battery. The battery voltage and the remaining percentage are
+// null until they are set.
+func NewCluster(description string, opts ...Option) *Cluster {
+	cluster := &Cluster{
+		BaseCluster:    datamodel.NewBaseCluster(ClusterID, ClusterRevision),
+		mutex:          sync.Mutex{},
+		features:       FeatureWired,
+		order:          0,
+		description:    description,
+		currentType:    WiredCurrentTypeAC,
+		replaceability: ReplaceabilityUnspecified,
+		replacement:    "",
+		quantity:       0,
+		functional:     false,
+		endpoints:      nil,
+		status:         StatusActive,
+		voltage:        nil,
+		percent:        nil,
+		chargeLevel:    ChargeLevelOK,
+		needed:         false,
+		chargeState:    ChargeStateUnknown,
+	}
+	for _, opt := range opts {
+		opt(cluster)
+	}
+
+	cluster.SetFeatureMap(cluster.features)
+	status := datatype.Enum8(cluster.status)
+	order := datatype.Uint8(cluster.order)
+	desc := datatype.String(cluster.description)
+	cluster.AddAttribute(datamodel.NewAttribute(StatusAttributeID, &status))
+	cluster.AddAttribute(datamodel.NewAttribute(OrderAttributeID, &order))
+	cluster.AddAttribute(datamodel.NewAttribute(DescriptionAttributeID, &desc))
+	cluster.AddAttribute(datamodel.NewAttribute(EndpointListAttributeID, cluster.endpointList()))
+
+	if cluster.HasFeature(FeatureWired) {
+		currentType := datatype.Enum8(cluster.currentType)
+		cluster.AddAttribute(datamodel.NewAttribute(WiredCurrentTypeAttributeID, &currentType))
+	}
+	if cluster.HasFeature(FeatureBattery) {
+		chargeLevel := datatype.Enum8(cluster.chargeLevel)
+		needed := datatype.Bool(cluster.needed)
+		replaceability := datatype.Enum8(cluster.replaceability)
+		cluster.AddAttribute(datamodel.NewAttribute(BatVoltageAttributeID, datatype.NewNull(new(datatype.Uint32))))
+		cluster.AddAttribute(datamodel.NewAttribute(BatPercentRemainingAttributeID, datatype.NewNull(new(datatype.Uint8))))
+		cluster.AddAttribute(datamodel.NewAttribute(BatChargeLevelAttributeID, &chargeLevel))
+		cluster.AddAttribute(datamodel.NewAttribute(BatReplacementNeededAttributeID, &needed))
+		cluster.AddAttribute(datamodel.NewAttribute(BatReplaceabilityAttributeID, &replaceability))
+	}
+	if cluster.HasFeature(FeatureReplaceable) {
+		replacement := datatype.String(cluster.replacement)
+		quantity := datatype.Uint8(cluster.quantity)
+		cluster.AddAttribute(datamodel.NewAttribute(BatReplacementDescriptionAttributeID, &replacement))
+		cluster.AddAttribute(datamodel.NewAttribute(BatQuantityAttributeID, &quantity))
+	}
+	if cluster.HasFeature(FeatureRechargeable) {
+		chargeState := datatype.Enum8(cluster.chargeState)
+		functional := datatype.Bool(cluster.functional)
+		cluster.AddAttribute(datamodel.NewAttribute(BatChargeStateAttributeID, &chargeState))
+		cluster.AddAttribute(datamodel.NewAttribute(BatFunctionalWhileChargingAttributeID, &functional))
+	}
+
+	return cluster
+}
+
+// HasFeature returns true if the power source supports the specified feature.
+func (cluster *Cluster) HasFeature(feature uint32) bool {
+	return (cluster.features & feature) == feature
+}
+
+func (cluster *Cluster) endpointList() *datatype.List {
+	list := datatype.NewList(func() datatype.Value { return new(datatype.Uint16) })
+	for _, id := range cluster.endpoints {
+		v := datatype.Uint16(id)
+		list.Elements = append(list.Elements, &v)
+	}
+	return list
+}
+
+// Status returns the status of the power source.
+func (cluster *Cluster) Status() Status {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	return cluster.status
+}
+
+// SetStatus sets the status of the power source.
+func (cluster *Cluster) SetStatus(status Status) error {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	if cluster.status == status {
+		return nil
+	}
+	v := datatype.Enum8(status)
+	if err := cluster.SetAttribute(StatusAttributeID, &v); err != nil {
+		return err
+	}
+	cluster.status = status
+	return nil
+}
+
+// BatteryVoltage returns the battery voltage in millivolts, and false if it is unknown.
+func (cluster *Cluster) BatteryVoltage() (uint32, bool) {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	if cluster.voltage == nil {
+		return 0, false
+	}
+	return *cluster.voltage, true
+}
+
+// SetBatteryVoltage sets the battery voltage in millivolts.
+func (cluster *Cluster) SetBatteryVoltage(mv uint32) error {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	if cluster.voltage != nil && *cluster.voltage == mv {
+		return nil
+	}
+	v := datatype.Uint32(mv)
+	if err := cluster.SetAttribute(BatVoltageAttributeID, datatype.NewNullable(&v)); err != nil {
+		return err
+	}
+	cluster.voltage = &mv
+	return nil
+}
+
+// BatteryPercentRemaining returns the remaining battery percentage in half percent steps, and false if it is unknown.
+func (cluster *Cluster) BatteryPercentRemaining() (float64, bool) {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	if cluster.percent == nil {
+		return 0, false
+	}
+	return float64(*cluster.percent) / 2, true
+}
+
+// SetBatteryPercentRemaining sets the remaining battery percentage, which is rounded to half percent steps.
+func (cluster *Cluster) SetBatteryPercentRemaining(percent float64) error {
+	if !(0 <= percent && percent <= 100) {
+		return fmt.Errorf("%w : battery percentage (%g) is out of range", im.StatusConstraintError, percent)
+	}
+	halves := uint8(math.Round(percent * 2))
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	if cluster.percent != nil && *cluster.percent == halves {
+		return nil
+	}
+	v := datatype.Uint8(halves)
+	if err := cluster.SetAttribute(BatPercentRemainingAttributeID, datatype.NewNullable(&v)); err != nil {
+		return err
+	}
+	cluster.percent = &halves
+	return nil
+}
+
+// BatteryChargeLevel returns the charge level of the battery.
+func (cluster *Cluster) BatteryChargeLevel() ChargeLevel {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	return cluster.chargeLevel
+}
+
+// SetBatteryChargeLevel sets the charge level of the battery.
+func (cluster *Cluster) SetBatteryChargeLevel(level ChargeLevel) error {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	if cluster.chargeLevel == level {
+		return nil
+	}
+	v := datatype.Enum8(level)
+	if err := cluster.SetAttribute(BatChargeLevelAttributeID, &v); err != nil {
+		return err
+	}
+	cluster.chargeLevel = level
+	return nil
+}
+
+// SetBatteryReplacementNeeded sets whether the battery needs to be replaced.
+func (cluster *Cluster) SetBatteryReplacementNeeded(needed bool) error {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	if cluster.needed == needed {
+		return nil
+	}
+	v := datatype.Bool(needed)
+	if err := cluster.SetAttribute(BatReplacementNeededAttributeID, &v); err != nil {
+		return err
+	}
+	cluster.needed = needed
+	return nil
+}
+
+// SetBatteryChargeState sets the charging state of the rechargeable battery.
+func (cluster *Cluster) SetBatteryChargeState(state ChargeState) error {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	if cluster.chargeState == state {
+		return nil
+	}
+	v := datatype.Enum8(state)
+	if err := cluster.SetAttribute(BatChargeStateAttributeID, &v); err != nil {
+		return err
+	}
+	cluster.chargeState = state
+	return nil
+}
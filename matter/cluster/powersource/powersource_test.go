@@ -0,0 +1,92 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package powersource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+func TestWiredPowerSource(t *testing.T) {
+	cluster := NewCluster("Mains", WithWired(WiredCurrentTypeDC), WithEndpoints(1, 2))
+	if cluster.FeatureMap() != FeatureWired {
+		t.Errorf("feature map (%02X)", cluster.FeatureMap())
+	}
+	v, err := cluster.ReadAttribute(WiredCurrentTypeAttributeID)
+	if err != nil || *v.(*datatype.Enum8) != datatype.Enum8(WiredCurrentTypeDC) {
+		t.Errorf("wired current type %v : %v", v, err)
+	}
+	v, err = cluster.ReadAttribute(EndpointListAttributeID)
+	if err != nil || len(v.(*datatype.List).Elements) != 2 {
+		t.Errorf("endpoint list %v : %v", v, err)
+	}
+	if err := cluster.SetBatteryPercentRemaining(50); !errors.Is(err, im.StatusUnsupportedAttribute) {
+		t.Errorf("%v is not %v", err, im.StatusUnsupportedAttribute)
+	}
+
+	version := cluster.DataVersion()
+	if err := cluster.SetStatus(StatusActive); err != nil || cluster.DataVersion() != version {
+		t.Errorf("data version is changed by the same status : %v", err)
+	}
+	if err := cluster.SetStatus(StatusStandby); err != nil || cluster.Status() != StatusStandby {
+		t.Errorf("status (%d) : %v", cluster.Status(), err)
+	}
+}
+
+func TestBatteryPowerSource(t *testing.T) {
+	cluster := NewCluster("Battery", WithRechargeable(true), WithReplaceable("CR2032", 1))
+	if features := FeatureBattery | FeatureRechargeable | FeatureReplaceable; cluster.FeatureMap() != features {
+		t.Errorf("feature map (%02X) != %02X", cluster.FeatureMap(), features)
+	}
+	if _, err := cluster.ReadAttribute(WiredCurrentTypeAttributeID); err == nil {
+		t.Error("wired current type is supported")
+	}
+
+	v, err := cluster.ReadAttribute(BatPercentRemainingAttributeID)
+	if _, ok := v.(*datatype.Nullable[*datatype.Uint8]).Get(); err != nil || ok {
+		t.Errorf("battery percentage %v : %v", v, err)
+	}
+	if err := cluster.SetBatteryPercentRemaining(72.3); err != nil {
+		t.Fatal(err)
+	}
+	if percent, ok := cluster.BatteryPercentRemaining(); !ok || percent != 72.5 {
+		t.Errorf("battery percentage (%g)", percent)
+	}
+	v, _ = cluster.ReadAttribute(BatPercentRemainingAttributeID)
+	if percent, ok := v.(*datatype.Nullable[*datatype.Uint8]).Get(); !ok || *percent != 145 {
+		t.Errorf("battery percentage %v", v)
+	}
+	if err := cluster.SetBatteryPercentRemaining(101); !errors.Is(err, im.StatusConstraintError) {
+		t.Errorf("%v is not %v", err, im.StatusConstraintError)
+	}
+
+	if err := cluster.SetBatteryChargeLevel(ChargeLevelCritical); err != nil {
+		t.Fatal(err)
+	}
+	v, _ = cluster.ReadAttribute(BatChargeLevelAttributeID)
+	if *v.(*datatype.Enum8) != datatype.Enum8(ChargeLevelCritical) {
+		t.Errorf("charge level %v", v)
+	}
+	if err := cluster.SetBatteryChargeState(ChargeStateIsCharging); err != nil {
+		t.Fatal(err)
+	}
+	v, _ = cluster.ReadAttribute(BatQuantityAttributeID)
+	if *v.(*datatype.Uint8) != 1 {
+		t.Errorf("battery quantity %v", v)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package powersource
+
+import (
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// Source represents the attributes of a Power Source cluster read from a node. The nullable attributes
+// and the attributes of the unsupported features are nil if the node has no values.
+type Source struct {
+	Status                     *Status           `tlv:"*/PowerSource/Status"`
+	Order                      *uint8            `tlv:"*/PowerSource/Order"`
+	Description                *string           `tlv:"*/PowerSource/Description"`
+	WiredCurrentType           *WiredCurrentType `tlv:"*/PowerSource/WiredCurrentType"`
+	BatVoltage                 *uint32           `tlv:"*/PowerSource/BatVoltage"`
+	BatPercentRemaining        *uint8            `tlv:"*/PowerSource/BatPercentRemaining"`
+	BatChargeLevel             *ChargeLevel      `tlv:"*/PowerSource/BatChargeLevel"`
+	BatReplacementNeeded       *bool             `tlv:"*/PowerSource/BatReplacementNeeded"`
+	BatReplaceability          *Replaceability   `tlv:"*/PowerSource/BatReplaceability"`
+	BatReplacementDescription  *string           `tlv:"*/PowerSource/BatReplacementDescription"`
+	BatQuantity                *uint8            `tlv:"*/PowerSource/BatQuantity"`
+	BatChargeState             *ChargeState      `tlv:"*/PowerSource/BatChargeState"`
+	BatFunctionalWhileCharging *bool             `tlv:"*/PowerSource/BatFunctionalWhileCharging"`
+	EndpointList               []im.EndpointID   `tlv:"*/PowerSource/EndpointList"`
+}
+
+// IsBattery returns true if the power source reports the battery attributes.
+func (src *Source) IsBattery() bool {
+	return src.BatChargeLevel != nil
+}
+
+// BatteryPercentRemaining returns the remaining battery percentage, which is reported in half percent
+// steps, and false if it is unknown.
+func (src *Source) BatteryPercentRemaining() (float64, bool) {
+	if src.BatPercentRemaining == nil {
+		return 0, false
+	}
+	return float64(*src.BatPercentRemaining) / 2, true
+}
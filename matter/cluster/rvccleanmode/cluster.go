@@ -0,0 +1,50 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rvccleanmode
+
+import (
+	"github.com/cybergarage/go-matter/matter/cluster/modebase"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// RVC Clean Mode Cluster
+const (
+	ClusterID       im.ClusterID = 0x0055
+	ClusterRevision              = 3
+
+	ModeTagDeepClean modebase.ModeTag = 0x4000
+	ModeTagVacuum    modebase.ModeTag = 0x4001
+	ModeTagMop       modebase.ModeTag = 0x4002
+
+	StatusCleaningInProgress modebase.ChangeStatus = 0x40
+)
+
+// Definition is the definition of the RVC Clean Mode cluster.
+var Definition = &modebase.Definition{
+	ID:       ClusterID,
+	Revision: ClusterRevision,
+	ModeTags: map[modebase.ModeTag]string{
+		ModeTagDeepClean: "DeepClean",
+		ModeTagVacuum:    "Vacuum",
+		ModeTagMop:       "Mop",
+	},
+	RequiredModeTags:    []modebase.ModeTag{},
+	SupportsStartUpMode: false,
+}
+
+// NewCluster returns a new RVC Clean Mode cluster of the specified modes.
+func NewCluster(modes []modebase.ModeOption, current uint8, opts ...modebase.Option) (*modebase.Cluster, error) {
+	return modebase.NewCluster(Definition, modes, current, opts...)
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rvcrunmode
+
+import (
+	"github.com/cybergarage/go-matter/matter/cluster/modebase"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// RVC Run Mode Cluster
+const (
+	ClusterID       im.ClusterID = 0x0054
+	ClusterRevision              = 3
+
+	ModeTagIdle     modebase.ModeTag = 0x4000
+	ModeTagCleaning modebase.ModeTag = 0x4001
+	ModeTagMapping  modebase.ModeTag = 0x4002
+
+	StatusStuck                 modebase.ChangeStatus = 0x41
+	StatusDustBinMissing        modebase.ChangeStatus = 0x42
+	StatusDustBinFull           modebase.ChangeStatus = 0x43
+	StatusWaterTankEmpty        modebase.ChangeStatus = 0x44
+	StatusWaterTankMissing      modebase.ChangeStatus = 0x45
+	StatusWaterTankLidOpen      modebase.ChangeStatus = 0x46
+	StatusMopCleaningPadMissing modebase.ChangeStatus = 0x47
+	StatusBatteryLow            modebase.ChangeStatus = 0x48
+)
+
+// Definition is the definition of the RVC Run Mode cluster, which requires an idle and a cleaning mode.
+var Definition = &modebase.Definition{
+	ID:       ClusterID,
+	Revision: ClusterRevision,
+	ModeTags: map[modebase.ModeTag]string{
+		ModeTagIdle:     "Idle",
+		ModeTagCleaning: "Cleaning",
+		ModeTagMapping:  "Mapping",
+	},
+	RequiredModeTags:    []modebase.ModeTag{ModeTagIdle, ModeTagCleaning},
+	SupportsStartUpMode: false,
+}
+
+// NewCluster returns a new RVC Run Mode cluster of the specified modes.
+func NewCluster(modes []modebase.ModeOption, current uint8, opts ...modebase.Option) (*modebase.Cluster, error) {
+	return modebase.NewCluster(Definition, modes, current, opts...)
+}
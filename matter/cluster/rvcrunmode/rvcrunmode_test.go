@@ -0,0 +1,43 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rvcrunmode
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/cluster/modebase"
+)
+
+func TestNewCluster(t *testing.T) {
+	modes := []modebase.ModeOption{
+		modebase.NewModeOption("Idle", 0, ModeTagIdle),
+		modebase.NewModeOption("Cleaning", 1, ModeTagCleaning),
+	}
+	cluster, err := NewCluster(modes, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cluster.ID() != ClusterID {
+		t.Errorf("cluster (0x%04X) != (0x%04X)", cluster.ID(), ClusterID)
+	}
+
+	if _, err := NewCluster(modes[:1], 0); !errors.Is(err, modebase.ErrInvalid) {
+		t.Errorf("%v is not %v", err, modebase.ErrInvalid)
+	}
+	if _, err := NewCluster(modes, 0, modebase.WithOnMode(1)); !errors.Is(err, modebase.ErrInvalid) {
+		t.Errorf("%v is not %v", err, modebase.ErrInvalid)
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scenes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// 1.4. Scenes Management Cluster
+const (
+	ClusterID       im.ClusterID = 0x0062
+	ClusterRevision              = 1
+
+	// FeatureSceneNames indicates that scene names are supported.
+	FeatureSceneNames uint32 = 0x01
+
+	LastConfiguredByAttributeID im.AttributeID = 0x0000
+	SceneTableSizeAttributeID   im.AttributeID = 0x0001
+	FabricSceneInfoAttributeID  im.AttributeID = 0x0002
+
+	AddSceneCommandID           im.CommandID = 0x00
+	ViewSceneCommandID          im.CommandID = 0x01
+	RemoveSceneCommandID        im.CommandID = 0x02
+	RemoveAllScenesCommandID    im.CommandID = 0x03
+	StoreSceneCommandID         im.CommandID = 0x04
+	RecallSceneCommandID        im.CommandID = 0x05
+	GetSceneMembershipCommandID im.CommandID = 0x06
+	CopySceneCommandID          im.CommandID = 0x40
+
+	// DefaultSceneTableSize is the default number of scenes which can be stored on the endpoint.
+	DefaultSceneTableSize = 16
+	// MaxSceneNameLength is the maximum length of scene names.
+	MaxSceneNameLength = 16
+	// MaxTransitionTime is the maximum transition time of scenes.
+	MaxTransitionTime = 60000000 * time.Millisecond
+	// MaxSceneID is the maximum scene ID.
+	MaxSceneID = 0xFE
+
+	copyAllScenesMode = 0x01
+)
+
+// SceneHandler is implemented by the clusters whose attributes are stored in scenes. The scenes
+// cluster captures and applies the extension field sets through the clusters on the same endpoint.
+type SceneHandler interface {
+	datamodel.Cluster
+	// CaptureScene returns the current values of the attributes which are stored in scenes.
+	CaptureScene() ([]AttributeValuePair, error)
+	// ApplyScene applies the specified attribute values over the specified transition time.
+	ApplyScene(values []AttributeValuePair, transition time.Duration) error
+}
+
+// Cluster represents a Scenes Management cluster server.
+type Cluster struct {
+	*datamodel.BaseCluster
+	endpoint         *datamodel.Endpoint
+	table            *table
+	lastConfiguredBy *datatype.Nullable[*datatype.Uint64]
+}
+
+// Option represents an option of the scenes cluster.
+type Option func(*Cluster)
+
+// WithSceneTableSize sets the number of scenes which can be stored on the endpoint.
+func WithSceneTableSize(size uint16) Option {
+	return func(cluster *Cluster) {
+		cluster.table.size = int(size)
+	}
+}
+
+// NewCluster returns a new scenes cluster which stores the attributes of the clusters on the specified endpoint.
+func NewCluster(ep *datamodel.Endpoint, opts ...Option) *Cluster {
+	cluster := &Cluster{
+		BaseCluster:      datamodel.NewBaseCluster(ClusterID, ClusterRevision),
+		endpoint:         ep,
+		table:            newTable(DefaultSceneTableSize),
+		lastConfiguredBy: datatype.NewNull(new(datatype.Uint64)),
+	}
+	for _, opt := range opts {
+		opt(cluster)
+	}
+
+	tableSize := datatype.Uint16(cluster.table.size)
+	cluster.SetFeatureMap(FeatureSceneNames)
+	cluster.AddAttribute(datamodel.NewAttribute(LastConfiguredByAttributeID, cluster.lastConfiguredBy))
+	cluster.AddAttribute(datamodel.NewAttribute(SceneTableSizeAttributeID, &tableSize))
+	cluster.AddAttribute(datamodel.NewFabricScopedAttribute(FabricSceneInfoAttributeID, encodeSceneInfos(nil)))
+
+	commands := []struct {
+		id      im.CommandID
+		handler datamodel.CommandHandler
+		hasResp bool
+	}{
+		{AddSceneCommandID, cluster.addScene, true},
+		{ViewSceneCommandID, cluster.viewScene, true},
+		{RemoveSceneCommandID, cluster.removeScene, true},
+		{RemoveAllScenesCommandID, cluster.removeAllScenes, true},
+		{StoreSceneCommandID, cluster.storeScene, true},
+		{RecallSceneCommandID, cluster.recallScene, false},
+		{GetSceneMembershipCommandID, cluster.getSceneMembership, true},
+		{CopySceneCommandID, cluster.copyScene, true},
+	}
+	for _, cmd := range commands {
+		cluster.AddCommand(cmd.id, cmd.handler)
+		if cmd.hasResp {
+			cluster.AddGeneratedCommand(cmd.id)
+		}
+	}
+
+	return cluster
+}
+
+// LookupScene returns a copy of the specified scene of the fabric.
+func (cluster *Cluster) LookupScene(fabric types.FabricIndex, group message.GroupID, scene uint8) (*Scene, bool) {
+	return cluster.table.lookup(fabric, group, scene)
+}
+
+// InvalidateScenes marks the current scenes invalid. Clusters call it when an attribute which is
+// stored in scenes is changed other than by recalling a scene.
+func (cluster *Cluster) InvalidateScenes() {
+	cluster.table.invalidateAll()
+	cluster.updateSceneInfo()
+}
+
+// RemoveFabric removes the scenes of the specified fabric.
+func (cluster *Cluster) RemoveFabric(fabric types.FabricIndex) {
+	cluster.table.removeFabric(fabric)
+	cluster.updateSceneInfo()
+}
+
+// CaptureScene returns the extension field sets of the clusters on the endpoint.
+func (cluster *Cluster) CaptureScene() ([]ExtensionFieldSet, error) {
+	sets := []ExtensionFieldSet{}
+	for _, c := range cluster.endpoint.Clusters() {
+		handler, ok := c.(SceneHandler)
+		if !ok {
+			continue
+		}
+		values, err := handler.CaptureScene()
+		if err != nil {
+			return nil, fmt.Errorf("capturing cluster (0x%04X) : %w", c.ID(), err)
+		}
+		sets = append(sets, ExtensionFieldSet{ClusterID: c.ID(), AttributeValues: values})
+	}
+	return sets, nil
+}
+
+// ApplyScene applies the extension field sets of the specified scene to the clusters on the endpoint.
+// Extension field sets of clusters which are not on the endpoint are ignored.
+func (cluster *Cluster) ApplyScene(scene *Scene, transition time.Duration) error {
+	for _, set := range scene.ExtensionFieldSets {
+		c, ok := cluster.endpoint.LookupCluster(set.ClusterID)
+		if !ok {
+			continue
+		}
+		handler, ok := c.(SceneHandler)
+		if !ok {
+			continue
+		}
+		if err := handler.ApplyScene(set.AttributeValues, transition); err != nil {
+			return fmt.Errorf("applying cluster (0x%04X) : %w", c.ID(), err)
+		}
+	}
+	return nil
+}
+
+// filterExtensionFieldSets returns only the extension field sets of the scene clusters on the endpoint.
+func (cluster *Cluster) filterExtensionFieldSets(sets []ExtensionFieldSet) []ExtensionFieldSet {
+	accepted := []ExtensionFieldSet{}
+	for _, set := range sets {
+		c, ok := cluster.endpoint.LookupCluster(set.ClusterID)
+		if !ok {
+			continue
+		}
+		if _, ok := c.(SceneHandler); !ok {
+			continue
+		}
+		accepted = append(accepted, set)
+	}
+	return accepted
+}
+
+func (cluster *Cluster) updateSceneInfo() {
+	cluster.SetAttribute(FabricSceneInfoAttributeID, encodeSceneInfos(cluster.table.sceneInfos()))
+}
+
+func (cluster *Cluster) updateLastConfiguredBy(req *datamodel.CommandRequest) {
+	v := datatype.Uint64(req.SourceNodeID)
+	if req.SourceNodeID.IsUnspecified() {
+		cluster.SetAttribute(LastConfiguredByAttributeID, datatype.NewNull(&v))
+		return
+	}
+	cluster.SetAttribute(LastConfiguredByAttributeID, datatype.NewNullable(&v))
+}
+
+const (
+	sceneInfoSceneCountTag        = 0
+	sceneInfoCurrentSceneTag      = 1
+	sceneInfoCurrentGroupTag      = 2
+	sceneInfoSceneValidTag        = 3
+	sceneInfoRemainingCapacityTag = 4
+	fabricIndexTag                = 0xFE
+)
+
+func encodeSceneInfos(infos []SceneInfo) *datatype.List {
+	list := datatype.NewList(func() datatype.Value { return datatype.NewStruct() })
+	for _, info := range infos {
+		count := datatype.Uint8(info.SceneCount)
+		scene := datatype.Uint8(info.CurrentScene)
+		group := datatype.Uint16(info.CurrentGroup)
+		valid := datatype.Bool(info.SceneValid)
+		remaining := datatype.Uint8(info.RemainingCapacity)
+		fabric := datatype.Uint8(info.FabricIndex)
+		list.Elements = append(list.Elements, datatype.NewStruct(
+			datatype.NewField(sceneInfoSceneCountTag, &count),
+			datatype.NewField(sceneInfoCurrentSceneTag, &scene),
+			datatype.NewField(sceneInfoCurrentGroupTag, &group),
+			datatype.NewField(sceneInfoSceneValidTag, &valid),
+			datatype.NewField(sceneInfoRemainingCapacityTag, &remaining),
+			datatype.NewField(fabricIndexTag, &fabric)))
+	}
+	return list
+}
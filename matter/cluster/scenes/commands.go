@@ -0,0 +1,313 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scenes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// checkFabric returns an error if the command is not invoked on a fabric, since scenes are fabric-scoped.
+func checkFabric(req *datamodel.CommandRequest) error {
+	if !req.FabricIndex.IsValid() {
+		return fmt.Errorf("%w : no accessing fabric", im.StatusUnsupportedAccess)
+	}
+	return nil
+}
+
+func newStatus(status im.Status) *datatype.Enum8 {
+	v := datatype.Enum8(status)
+	return &v
+}
+
+// newSceneResponse returns a response of the status, group and scene fields.
+func newSceneResponse(id im.CommandID, status im.Status, group *datatype.Uint16, scene *datatype.Uint8) *datamodel.CommandResponse {
+	return datamodel.NewCommandResponse(id, datatype.NewStruct(
+		datatype.NewField(0, newStatus(status)),
+		datatype.NewField(1, group),
+		datatype.NewField(2, scene)))
+}
+
+// checkScene returns the status of the scene ID of a command.
+func checkScene(scene *datatype.Uint8) im.Status {
+	if MaxSceneID < *scene {
+		return im.StatusConstraintError
+	}
+	return im.StatusSuccess
+}
+
+func (cluster *Cluster) addScene(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	if err := checkFabric(req); err != nil {
+		return nil, err
+	}
+	group := new(datatype.Uint16)
+	scene := new(datatype.Uint8)
+	transition := new(datatype.Uint32)
+	name := new(datatype.String)
+	sets := newExtensionFieldSetList()
+	err := req.DecodeFields(datatype.NewStruct(
+		datatype.NewField(0, group),
+		datatype.NewField(1, scene),
+		datatype.NewField(2, transition),
+		datatype.NewField(3, name),
+		datatype.NewField(4, sets)))
+	if err != nil {
+		return nil, err
+	}
+
+	status := checkScene(scene)
+	transitionTime := time.Duration(*transition) * time.Millisecond
+	if MaxTransitionTime < transitionTime || MaxSceneNameLength < len(*name) {
+		status = im.StatusConstraintError
+	}
+	if status == im.StatusSuccess {
+		status = cluster.table.store(req.FabricIndex, &Scene{
+			GroupID:            message.GroupID(*group),
+			SceneID:            uint8(*scene),
+			Name:               string(*name),
+			TransitionTime:     transitionTime,
+			ExtensionFieldSets: cluster.filterExtensionFieldSets(decodeExtensionFieldSets(sets)),
+		})
+	}
+	if status == im.StatusSuccess {
+		cluster.updateLastConfiguredBy(req)
+		cluster.updateSceneInfo()
+	}
+	return newSceneResponse(AddSceneCommandID, status, group, scene), nil
+}
+
+func (cluster *Cluster) viewScene(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	if err := checkFabric(req); err != nil {
+		return nil, err
+	}
+	group := new(datatype.Uint16)
+	scene := new(datatype.Uint8)
+	err := req.DecodeFields(datatype.NewStruct(
+		datatype.NewField(0, group),
+		datatype.NewField(1, scene)))
+	if err != nil {
+		return nil, err
+	}
+
+	status := checkScene(scene)
+	var found *Scene
+	if status == im.StatusSuccess {
+		var ok bool
+		found, ok = cluster.table.lookup(req.FabricIndex, message.GroupID(*group), uint8(*scene))
+		if !ok {
+			status = im.StatusNotFound
+		}
+	}
+
+	fields := datatype.NewStruct(
+		datatype.NewField(0, newStatus(status)),
+		datatype.NewField(1, group),
+		datatype.NewField(2, scene))
+	if found != nil {
+		transition := datatype.Uint32(found.TransitionTime / time.Millisecond)
+		name := datatype.String(found.Name)
+		fields.Fields = append(fields.Fields,
+			datatype.NewField(3, &transition),
+			datatype.NewField(4, &name),
+			datatype.NewField(5, encodeExtensionFieldSets(found.ExtensionFieldSets)))
+	}
+	return datamodel.NewCommandResponse(ViewSceneCommandID, fields), nil
+}
+
+func (cluster *Cluster) removeScene(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	if err := checkFabric(req); err != nil {
+		return nil, err
+	}
+	group := new(datatype.Uint16)
+	scene := new(datatype.Uint8)
+	err := req.DecodeFields(datatype.NewStruct(
+		datatype.NewField(0, group),
+		datatype.NewField(1, scene)))
+	if err != nil {
+		return nil, err
+	}
+
+	status := checkScene(scene)
+	if status == im.StatusSuccess && !cluster.table.remove(req.FabricIndex, message.GroupID(*group), uint8(*scene)) {
+		status = im.StatusNotFound
+	}
+	if status == im.StatusSuccess {
+		cluster.updateSceneInfo()
+	}
+	return newSceneResponse(RemoveSceneCommandID, status, group, scene), nil
+}
+
+func (cluster *Cluster) removeAllScenes(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	if err := checkFabric(req); err != nil {
+		return nil, err
+	}
+	group := new(datatype.Uint16)
+	if err := req.DecodeFields(datatype.NewStruct(datatype.NewField(0, group))); err != nil {
+		return nil, err
+	}
+
+	cluster.table.removeGroup(req.FabricIndex, message.GroupID(*group))
+	cluster.updateSceneInfo()
+	return datamodel.NewCommandResponse(RemoveAllScenesCommandID, datatype.NewStruct(
+		datatype.NewField(0, newStatus(im.StatusSuccess)),
+		datatype.NewField(1, group))), nil
+}
+
+func (cluster *Cluster) storeScene(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	if err := checkFabric(req); err != nil {
+		return nil, err
+	}
+	group := new(datatype.Uint16)
+	scene := new(datatype.Uint8)
+	err := req.DecodeFields(datatype.NewStruct(
+		datatype.NewField(0, group),
+		datatype.NewField(1, scene)))
+	if err != nil {
+		return nil, err
+	}
+
+	status := checkScene(scene)
+	if status != im.StatusSuccess {
+		return newSceneResponse(StoreSceneCommandID, status, group, scene), nil
+	}
+
+	sets, err := cluster.CaptureScene()
+	if err != nil {
+		return nil, fmt.Errorf("%w : %w", im.StatusFailure, err)
+	}
+	stored, ok := cluster.table.lookup(req.FabricIndex, message.GroupID(*group), uint8(*scene))
+	if !ok {
+		stored = &Scene{
+			GroupID:            message.GroupID(*group),
+			SceneID:            uint8(*scene),
+			Name:               "",
+			TransitionTime:     0,
+			ExtensionFieldSets: nil,
+		}
+	}
+	stored.ExtensionFieldSets = sets
+	status = cluster.table.store(req.FabricIndex, stored)
+	if status == im.StatusSuccess {
+		cluster.table.setCurrent(req.FabricIndex, stored.GroupID, stored.SceneID)
+		cluster.updateLastConfiguredBy(req)
+		cluster.updateSceneInfo()
+	}
+	return newSceneResponse(StoreSceneCommandID, status, group, scene), nil
+}
+
+func (cluster *Cluster) recallScene(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	if err := checkFabric(req); err != nil {
+		return nil, err
+	}
+	group := new(datatype.Uint16)
+	scene := new(datatype.Uint8)
+	transition := datatype.NewUnset(datatype.NewNullable(new(datatype.Uint32)))
+	err := req.DecodeFields(datatype.NewStruct(
+		datatype.NewField(0, group),
+		datatype.NewField(1, scene),
+		datatype.NewField(2, transition)))
+	if err != nil {
+		return nil, err
+	}
+
+	if status := checkScene(scene); status != im.StatusSuccess {
+		return nil, status
+	}
+	found, ok := cluster.table.lookup(req.FabricIndex, message.GroupID(*group), uint8(*scene))
+	if !ok {
+		return nil, im.StatusNotFound
+	}
+	transitionTime := found.TransitionTime
+	if nullable, ok := transition.Get(); ok {
+		if v, ok := nullable.Get(); ok {
+			transitionTime = time.Duration(*v) * time.Millisecond
+		}
+	}
+	if MaxTransitionTime < transitionTime {
+		return nil, im.StatusConstraintError
+	}
+	if err := cluster.ApplyScene(found, transitionTime); err != nil {
+		return nil, fmt.Errorf("%w : %w", im.StatusFailure, err)
+	}
+	cluster.table.setCurrent(req.FabricIndex, found.GroupID, found.SceneID)
+	cluster.updateSceneInfo()
+	return nil, nil
+}
+
+func (cluster *Cluster) getSceneMembership(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	if err := checkFabric(req); err != nil {
+		return nil, err
+	}
+	group := new(datatype.Uint16)
+	if err := req.DecodeFields(datatype.NewStruct(datatype.NewField(0, group))); err != nil {
+		return nil, err
+	}
+
+	capacity := datatype.Uint8(clampUint8(cluster.table.capacity()))
+	scenes := datatype.NewList(func() datatype.Value { return new(datatype.Uint8) })
+	for _, id := range cluster.table.sceneIDs(req.FabricIndex, message.GroupID(*group)) {
+		v := datatype.Uint8(id)
+		scenes.Elements = append(scenes.Elements, &v)
+	}
+	return datamodel.NewCommandResponse(GetSceneMembershipCommandID, datatype.NewStruct(
+		datatype.NewField(0, newStatus(im.StatusSuccess)),
+		datatype.NewField(1, datatype.NewNullable(&capacity)),
+		datatype.NewField(2, group),
+		datatype.NewField(3, datatype.NewOptional(scenes)))), nil
+}
+
+func (cluster *Cluster) copyScene(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	if err := checkFabric(req); err != nil {
+		return nil, err
+	}
+	mode := new(datatype.Bitmap8)
+	fromGroup := new(datatype.Uint16)
+	fromScene := new(datatype.Uint8)
+	toGroup := new(datatype.Uint16)
+	toScene := new(datatype.Uint8)
+	err := req.DecodeFields(datatype.NewStruct(
+		datatype.NewField(0, mode),
+		datatype.NewField(1, fromGroup),
+		datatype.NewField(2, fromScene),
+		datatype.NewField(3, toGroup),
+		datatype.NewField(4, toScene)))
+	if err != nil {
+		return nil, err
+	}
+
+	var status im.Status
+	if mode.Has(copyAllScenesMode) {
+		status = cluster.table.copyScenes(req.FabricIndex, message.GroupID(*fromGroup), nil, message.GroupID(*toGroup), 0)
+	} else {
+		status = checkScene(fromScene)
+		if status == im.StatusSuccess {
+			status = checkScene(toScene)
+		}
+		if status == im.StatusSuccess {
+			from := uint8(*fromScene)
+			status = cluster.table.copyScenes(req.FabricIndex, message.GroupID(*fromGroup), &from, message.GroupID(*toGroup), uint8(*toScene))
+		}
+	}
+	if status == im.StatusSuccess {
+		cluster.updateLastConfiguredBy(req)
+		cluster.updateSceneInfo()
+	}
+	return newSceneResponse(CopySceneCommandID, status, fromGroup, fromScene), nil
+}
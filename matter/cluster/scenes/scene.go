@@ -0,0 +1,140 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scenes
+
+import (
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// AttributeValuePair represents a stored value of an attribute (AttributeValuePairStruct).
+type AttributeValuePair struct {
+	// AttributeID is the attribute ID.
+	AttributeID im.AttributeID
+	// Value is the attribute value.
+	Value uint32
+}
+
+// ExtensionFieldSet represents the stored attribute values of a cluster (ExtensionFieldSetStruct).
+type ExtensionFieldSet struct {
+	// ClusterID is the cluster ID.
+	ClusterID im.ClusterID
+	// AttributeValues are the stored attribute values.
+	AttributeValues []AttributeValuePair
+}
+
+// Scene represents an entry of the scene table.
+type Scene struct {
+	// GroupID is the group ID, which is zero for scenes not associated with a group.
+	GroupID message.GroupID
+	// SceneID is the scene ID.
+	SceneID uint8
+	// Name is the scene name.
+	Name string
+	// TransitionTime is the default transition time to recall the scene.
+	TransitionTime time.Duration
+	// ExtensionFieldSets are the stored attribute values of the clusters on the endpoint.
+	ExtensionFieldSets []ExtensionFieldSet
+}
+
+// copyScene returns a deep copy of the scene.
+func (scene *Scene) copyScene() *Scene {
+	sets := make([]ExtensionFieldSet, len(scene.ExtensionFieldSets))
+	for n, set := range scene.ExtensionFieldSets {
+		sets[n] = ExtensionFieldSet{
+			ClusterID:       set.ClusterID,
+			AttributeValues: append([]AttributeValuePair{}, set.AttributeValues...),
+		}
+	}
+	return &Scene{
+		GroupID:            scene.GroupID,
+		SceneID:            scene.SceneID,
+		Name:               scene.Name,
+		TransitionTime:     scene.TransitionTime,
+		ExtensionFieldSets: sets,
+	}
+}
+
+const (
+	attributeValuePairAttributeIDTag = 0
+	attributeValuePairValueTag       = 1
+	extensionFieldSetClusterIDTag    = 0
+	extensionFieldSetValuesTag       = 1
+)
+
+// newExtensionFieldSetList returns a list value to decode extension field sets into.
+func newExtensionFieldSetList() *datatype.List {
+	return datatype.NewList(func() datatype.Value {
+		return datatype.NewStruct(
+			datatype.NewField(extensionFieldSetClusterIDTag, new(datatype.Uint32)),
+			datatype.NewField(extensionFieldSetValuesTag, newAttributeValuePairList()))
+	})
+}
+
+func newAttributeValuePairList() *datatype.List {
+	return datatype.NewList(func() datatype.Value {
+		return datatype.NewStruct(
+			datatype.NewField(attributeValuePairAttributeIDTag, new(datatype.Uint32)),
+			datatype.NewField(attributeValuePairValueTag, new(datatype.Uint32)))
+	})
+}
+
+// encodeExtensionFieldSets returns the list value of the specified extension field sets.
+func encodeExtensionFieldSets(sets []ExtensionFieldSet) *datatype.List {
+	list := newExtensionFieldSetList()
+	for _, set := range sets {
+		pairs := newAttributeValuePairList()
+		for _, pair := range set.AttributeValues {
+			id := datatype.Uint32(pair.AttributeID)
+			v := datatype.Uint32(pair.Value)
+			pairs.Elements = append(pairs.Elements, datatype.NewStruct(
+				datatype.NewField(attributeValuePairAttributeIDTag, &id),
+				datatype.NewField(attributeValuePairValueTag, &v)))
+		}
+		id := datatype.Uint32(set.ClusterID)
+		list.Elements = append(list.Elements, datatype.NewStruct(
+			datatype.NewField(extensionFieldSetClusterIDTag, &id),
+			datatype.NewField(extensionFieldSetValuesTag, pairs)))
+	}
+	return list
+}
+
+// decodeExtensionFieldSets returns the extension field sets of the decoded list value.
+func decodeExtensionFieldSets(list *datatype.List) []ExtensionFieldSet {
+	sets := make([]ExtensionFieldSet, 0, len(list.Elements))
+	for _, elem := range list.Elements {
+		st := elem.(*datatype.Struct)
+		idField, _ := st.LookupField(extensionFieldSetClusterIDTag)
+		pairsField, _ := st.LookupField(extensionFieldSetValuesTag)
+		set := ExtensionFieldSet{
+			ClusterID:       im.ClusterID(*idField.Value.(*datatype.Uint32)),
+			AttributeValues: []AttributeValuePair{},
+		}
+		for _, pairElem := range pairsField.Value.(*datatype.List).Elements {
+			pair := pairElem.(*datatype.Struct)
+			attrField, _ := pair.LookupField(attributeValuePairAttributeIDTag)
+			valueField, _ := pair.LookupField(attributeValuePairValueTag)
+			set.AttributeValues = append(set.AttributeValues, AttributeValuePair{
+				AttributeID: im.AttributeID(*attrField.Value.(*datatype.Uint32)),
+				Value:       uint32(*valueField.Value.(*datatype.Uint32)),
+			})
+		}
+		sets = append(sets, set)
+	}
+	return sets
+}
@@ -0,0 +1,230 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scenes
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datamodel/datamodeltest"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+const (
+	testClusterID      im.ClusterID   = 0x0008
+	testAttributeID    im.AttributeID = 0x0000
+	testEndpointID     im.EndpointID  = 1
+	testFabricIndex                   = types.FabricIndex(1)
+	testOtherClusterID im.ClusterID   = 0x0300
+)
+
+// testCluster represents a level-like cluster whose current value is stored in scenes.
+type testCluster struct {
+	*datamodel.BaseCluster
+	level      datatype.Uint8
+	transition time.Duration
+}
+
+func newTestCluster() *testCluster {
+	cluster := &testCluster{
+		BaseCluster: datamodel.NewBaseCluster(testClusterID, 1),
+		level:       0,
+		transition:  0,
+	}
+	cluster.AddAttribute(datamodel.NewAttribute(testAttributeID, &cluster.level))
+	return cluster
+}
+
+func (cluster *testCluster) CaptureScene() ([]AttributeValuePair, error) {
+	return []AttributeValuePair{{AttributeID: testAttributeID, Value: uint32(cluster.level)}}, nil
+}
+
+func (cluster *testCluster) ApplyScene(values []AttributeValuePair, transition time.Duration) error {
+	for _, v := range values {
+		if v.AttributeID == testAttributeID {
+			cluster.level = datatype.Uint8(v.Value)
+		}
+	}
+	cluster.transition = transition
+	return nil
+}
+
+func sceneFields(group uint16, scene uint8) *datatype.Struct {
+	g := datatype.Uint16(group)
+	s := datatype.Uint8(scene)
+	return datatype.NewStruct(datatype.NewField(0, &g), datatype.NewField(1, &s))
+}
+
+func addSceneFields(group uint16, scene uint8, transition uint32, name string, sets []ExtensionFieldSet) *datatype.Struct {
+	fields := sceneFields(group, scene)
+	tt := datatype.Uint32(transition)
+	n := datatype.String(name)
+	fields.Fields = append(fields.Fields,
+		datatype.NewField(2, &tt),
+		datatype.NewField(3, &n),
+		datatype.NewField(4, encodeExtensionFieldSets(sets)))
+	return fields
+}
+
+func responseStatus(t *testing.T, res *datamodel.CommandResponse, err error) im.Status {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+	field, ok := res.Fields.(*datatype.Struct).LookupField(0)
+	if !ok {
+		t.Fatal("no status field")
+	}
+	return im.Status(*field.Value.(*datatype.Enum8))
+}
+
+func TestScenes(t *testing.T) {
+	ep := datamodel.NewEndpoint(testEndpointID)
+	level := newTestCluster()
+	cluster := NewCluster(ep, WithSceneTableSize(3))
+	for _, c := range []datamodel.Cluster{level, cluster} {
+		if err := ep.AddCluster(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sets := []ExtensionFieldSet{
+		{ClusterID: testClusterID, AttributeValues: []AttributeValuePair{{AttributeID: testAttributeID, Value: 200}}},
+		{ClusterID: testOtherClusterID, AttributeValues: []AttributeValuePair{{AttributeID: 0, Value: 1}}},
+	}
+	res, err := datamodeltest.Invoke(t, cluster, testEndpointID, AddSceneCommandID, testFabricIndex, addSceneFields(1, 1, 1500, "Evening", sets))
+	if status := responseStatus(t, res, err); status != im.StatusSuccess {
+		t.Fatalf("AddScene : %s", status)
+	}
+
+	// Extension field sets of clusters which are not on the endpoint are dropped.
+	scene, ok := cluster.LookupScene(testFabricIndex, 1, 1)
+	if !ok {
+		t.Fatal("scene is not found")
+	}
+	if len(scene.ExtensionFieldSets) != 1 || scene.ExtensionFieldSets[0].ClusterID != testClusterID {
+		t.Errorf("%v", scene.ExtensionFieldSets)
+	}
+	if scene.Name != "Evening" || scene.TransitionTime != 1500*time.Millisecond {
+		t.Errorf("%s (%s)", scene.Name, scene.TransitionTime)
+	}
+
+	res, err = datamodeltest.Invoke(t, cluster, testEndpointID, ViewSceneCommandID, testFabricIndex, sceneFields(1, 1))
+	if status := responseStatus(t, res, err); status != im.StatusSuccess {
+		t.Errorf("ViewScene : %s", status)
+	}
+	if len(res.Fields.(*datatype.Struct).Fields) != 6 {
+		t.Errorf("ViewSceneResponse has %d fields", len(res.Fields.(*datatype.Struct).Fields))
+	}
+
+	// RecallScene applies the stored value with the scene transition time.
+	if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, RecallSceneCommandID, testFabricIndex, sceneFields(1, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if level.level != 200 || level.transition != 1500*time.Millisecond {
+		t.Errorf("level (%d) transition (%s)", level.level, level.transition)
+	}
+
+	// StoreScene captures the current value.
+	level.level = 42
+	res, err = datamodeltest.Invoke(t, cluster, testEndpointID, StoreSceneCommandID, testFabricIndex, sceneFields(1, 2))
+	if status := responseStatus(t, res, err); status != im.StatusSuccess {
+		t.Fatalf("StoreScene : %s", status)
+	}
+	level.level = 0
+	recall := sceneFields(1, 2)
+	tt := datatype.Uint32(100)
+	recall.Fields = append(recall.Fields, datatype.NewField(2, datatype.NewNullable(&tt)))
+	if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, RecallSceneCommandID, testFabricIndex, recall); err != nil {
+		t.Fatal(err)
+	}
+	if level.level != 42 || level.transition != 100*time.Millisecond {
+		t.Errorf("level (%d) transition (%s)", level.level, level.transition)
+	}
+
+	res, err = datamodeltest.Invoke(t, cluster, testEndpointID, GetSceneMembershipCommandID, testFabricIndex, datatype.NewStruct(datatype.NewField(0, new(datatype.Uint16))))
+	if status := responseStatus(t, res, err); status != im.StatusSuccess {
+		t.Errorf("GetSceneMembership : %s", status)
+	}
+
+	// CopyScene fills the table, and the next copy exhausts it.
+	mode := datatype.Bitmap8(0)
+	copyFields := func(to uint8) *datatype.Struct {
+		fromGroup := datatype.Uint16(1)
+		fromScene := datatype.Uint8(1)
+		toGroup := datatype.Uint16(2)
+		toScene := datatype.Uint8(to)
+		return datatype.NewStruct(
+			datatype.NewField(0, &mode),
+			datatype.NewField(1, &fromGroup),
+			datatype.NewField(2, &fromScene),
+			datatype.NewField(3, &toGroup),
+			datatype.NewField(4, &toScene))
+	}
+	res, err = datamodeltest.Invoke(t, cluster, testEndpointID, CopySceneCommandID, testFabricIndex, copyFields(5))
+	if status := responseStatus(t, res, err); status != im.StatusSuccess {
+		t.Errorf("CopyScene : %s", status)
+	}
+	res, err = datamodeltest.Invoke(t, cluster, testEndpointID, CopySceneCommandID, testFabricIndex, copyFields(6))
+	if status := responseStatus(t, res, err); status != im.StatusResourceExhausted {
+		t.Errorf("CopyScene : %s", status)
+	}
+
+	res, err = datamodeltest.Invoke(t, cluster, testEndpointID, RemoveSceneCommandID, testFabricIndex, sceneFields(1, 1))
+	if status := responseStatus(t, res, err); status != im.StatusSuccess {
+		t.Errorf("RemoveScene : %s", status)
+	}
+	res, err = datamodeltest.Invoke(t, cluster, testEndpointID, ViewSceneCommandID, testFabricIndex, sceneFields(1, 1))
+	if status := responseStatus(t, res, err); status != im.StatusNotFound {
+		t.Errorf("ViewScene : %s", status)
+	}
+	if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, RecallSceneCommandID, testFabricIndex, sceneFields(1, 1)); !errors.Is(err, im.StatusNotFound) {
+		t.Errorf("%v is not %v", err, im.StatusNotFound)
+	}
+
+	// Scenes are fabric-scoped.
+	res, err = datamodeltest.Invoke(t, cluster, testEndpointID, ViewSceneCommandID, testFabricIndex+1, sceneFields(1, 2))
+	if status := responseStatus(t, res, err); status != im.StatusNotFound {
+		t.Errorf("ViewScene : %s", status)
+	}
+	if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, ViewSceneCommandID, types.NoFabricIndex, sceneFields(1, 2)); !errors.Is(err, im.StatusUnsupportedAccess) {
+		t.Errorf("%v is not %v", err, im.StatusUnsupportedAccess)
+	}
+
+	res, err = datamodeltest.Invoke(t, cluster, testEndpointID, AddSceneCommandID, testFabricIndex, addSceneFields(1, 3, 0, "A scene name which is too long", nil))
+	if status := responseStatus(t, res, err); status != im.StatusConstraintError {
+		t.Errorf("AddScene : %s", status)
+	}
+
+	v, err := cluster.ReadAttribute(FabricSceneInfoAttributeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	infos := v.(*datatype.List)
+	if len(infos.Elements) != 1 {
+		t.Fatalf("%d scene infos", len(infos.Elements))
+	}
+	count, _ := infos.Elements[0].(*datatype.Struct).LookupField(sceneInfoSceneCountTag)
+	if *count.Value.(*datatype.Uint8) != 2 {
+		t.Errorf("scene count (%d) != 2", *count.Value.(*datatype.Uint8))
+	}
+	if _, err := datatype.Encode(v); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scenes
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+type sceneKey struct {
+	fabric types.FabricIndex
+	group  message.GroupID
+	scene  uint8
+}
+
+// fabricState represents the last recalled or stored scene of a fabric.
+type fabricState struct {
+	group message.GroupID
+	scene uint8
+	valid bool
+}
+
+// SceneInfo represents the scene information of a fabric (SceneInfoStruct).
+type SceneInfo struct {
+	// FabricIndex is the fabric index.
+	FabricIndex types.FabricIndex
+	// SceneCount is the number of scenes of the fabric.
+	SceneCount uint8
+	// CurrentGroup is the group of the last recalled or stored scene.
+	CurrentGroup message.GroupID
+	// CurrentScene is the last recalled or stored scene.
+	CurrentScene uint8
+	// SceneValid is true if the current scene is still applied.
+	SceneValid bool
+	// RemainingCapacity is the number of scenes which the fabric can still add.
+	RemainingCapacity uint8
+}
+
+// table represents a scene table which is shared by the fabrics.
+type table struct {
+	sync.Mutex
+	size   int
+	scenes map[sceneKey]*Scene
+	states map[types.FabricIndex]*fabricState
+}
+
+func newTable(size int) *table {
+	return &table{
+		Mutex:  sync.Mutex{},
+		size:   size,
+		scenes: map[sceneKey]*Scene{},
+		states: map[types.FabricIndex]*fabricState{},
+	}
+}
+
+func newSceneKey(fabric types.FabricIndex, group message.GroupID, scene uint8) sceneKey {
+	return sceneKey{fabric: fabric, group: group, scene: scene}
+}
+
+func (tbl *table) remainingCapacity() int {
+	return tbl.size - len(tbl.scenes)
+}
+
+func (tbl *table) lookup(fabric types.FabricIndex, group message.GroupID, scene uint8) (*Scene, bool) {
+	tbl.Lock()
+	defer tbl.Unlock()
+	s, ok := tbl.scenes[newSceneKey(fabric, group, scene)]
+	if !ok {
+		return nil, false
+	}
+	return s.copyScene(), true
+}
+
+// store adds or replaces the specified scene.
+func (tbl *table) store(fabric types.FabricIndex, scene *Scene) im.Status {
+	tbl.Lock()
+	defer tbl.Unlock()
+	key := newSceneKey(fabric, scene.GroupID, scene.SceneID)
+	if _, ok := tbl.scenes[key]; !ok && tbl.remainingCapacity() <= 0 {
+		return im.StatusResourceExhausted
+	}
+	tbl.scenes[key] = scene.copyScene()
+	tbl.invalidate(fabric, scene.GroupID, scene.SceneID)
+	return im.StatusSuccess
+}
+
+// copyScenes copies the specified scenes, or all scenes of the source group if scene is nil.
+func (tbl *table) copyScenes(fabric types.FabricIndex, fromGroup message.GroupID, fromScene *uint8, toGroup message.GroupID, toScene uint8) im.Status {
+	tbl.Lock()
+	defer tbl.Unlock()
+	copies := []*Scene{}
+	for key, s := range tbl.scenes {
+		if key.fabric != fabric || key.group != fromGroup {
+			continue
+		}
+		if fromScene != nil && key.scene != *fromScene {
+			continue
+		}
+		c := s.copyScene()
+		c.GroupID = toGroup
+		if fromScene != nil {
+			c.SceneID = toScene
+		}
+		copies = append(copies, c)
+	}
+	if len(copies) == 0 {
+		return im.StatusNotFound
+	}
+	added := 0
+	for _, c := range copies {
+		if _, ok := tbl.scenes[newSceneKey(fabric, c.GroupID, c.SceneID)]; !ok {
+			added++
+		}
+	}
+	if tbl.remainingCapacity() < added {
+		return im.StatusResourceExhausted
+	}
+	for _, c := range copies {
+		tbl.scenes[newSceneKey(fabric, c.GroupID, c.SceneID)] = c
+		tbl.invalidate(fabric, c.GroupID, c.SceneID)
+	}
+	return im.StatusSuccess
+}
+
+func (tbl *table) remove(fabric types.FabricIndex, group message.GroupID, scene uint8) bool {
+	tbl.Lock()
+	defer tbl.Unlock()
+	key := newSceneKey(fabric, group, scene)
+	if _, ok := tbl.scenes[key]; !ok {
+		return false
+	}
+	delete(tbl.scenes, key)
+	tbl.invalidate(fabric, group, scene)
+	return true
+}
+
+func (tbl *table) removeGroup(fabric types.FabricIndex, group message.GroupID) {
+	tbl.Lock()
+	defer tbl.Unlock()
+	for key := range tbl.scenes {
+		if key.fabric == fabric && key.group == group {
+			delete(tbl.scenes, key)
+			tbl.invalidate(fabric, group, key.scene)
+		}
+	}
+}
+
+// removeFabric removes the scenes and the current scene of the fabric.
+func (tbl *table) removeFabric(fabric types.FabricIndex) {
+	tbl.Lock()
+	defer tbl.Unlock()
+	for key := range tbl.scenes {
+		if key.fabric == fabric {
+			delete(tbl.scenes, key)
+		}
+	}
+	delete(tbl.states, fabric)
+}
+
+// sceneIDs returns the scene IDs of the specified group in ascending order.
+func (tbl *table) sceneIDs(fabric types.FabricIndex, group message.GroupID) []uint8 {
+	tbl.Lock()
+	defer tbl.Unlock()
+	ids := []uint8{}
+	for key := range tbl.scenes {
+		if key.fabric == fabric && key.group == group {
+			ids = append(ids, key.scene)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func (tbl *table) capacity() int {
+	tbl.Lock()
+	defer tbl.Unlock()
+	return tbl.remainingCapacity()
+}
+
+// setCurrent marks the specified scene as the current valid scene of the fabric.
+func (tbl *table) setCurrent(fabric types.FabricIndex, group message.GroupID, scene uint8) {
+	tbl.Lock()
+	defer tbl.Unlock()
+	tbl.states[fabric] = &fabricState{group: group, scene: scene, valid: true}
+}
+
+// invalidate marks the current scene of the fabric invalid if it is the specified scene.
+func (tbl *table) invalidate(fabric types.FabricIndex, group message.GroupID, scene uint8) {
+	state, ok := tbl.states[fabric]
+	if ok && state.group == group && state.scene == scene {
+		state.valid = false
+	}
+}
+
+// invalidateAll marks the current scenes of all fabrics invalid.
+func (tbl *table) invalidateAll() {
+	tbl.Lock()
+	defer tbl.Unlock()
+	for _, state := range tbl.states {
+		state.valid = false
+	}
+}
+
+// sceneInfos returns the scene information of the fabrics which have scenes or a current scene.
+func (tbl *table) sceneInfos() []SceneInfo {
+	tbl.Lock()
+	defer tbl.Unlock()
+	counts := map[types.FabricIndex]int{}
+	for key := range tbl.scenes {
+		counts[key.fabric]++
+	}
+	for fabric := range tbl.states {
+		if _, ok := counts[fabric]; !ok {
+			counts[fabric] = 0
+		}
+	}
+	remaining := clampUint8(tbl.remainingCapacity())
+	infos := []SceneInfo{}
+	for fabric, count := range counts {
+		info := SceneInfo{
+			FabricIndex:       fabric,
+			SceneCount:        clampUint8(count),
+			CurrentGroup:      0,
+			CurrentScene:      0,
+			SceneValid:        false,
+			RemainingCapacity: remaining,
+		}
+		if state, ok := tbl.states[fabric]; ok {
+			info.CurrentGroup = state.group
+			info.CurrentScene = state.scene
+			info.SceneValid = state.valid
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].FabricIndex < infos[j].FabricIndex })
+	return infos
+}
+
+// clampUint8 returns the specified count clamped to the maximum non-null uint8 value.
+func clampUint8(n int) uint8 {
+	if n < 0 {
+		return 0
+	}
+	if 0xFE < n {
+		return 0xFE
+	}
+	return uint8(n)
+}
@@ -0,0 +1,149 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package softwarediagnostics
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// 11.12. Software Diagnostics Cluster
+const (
+	ClusterID       im.ClusterID = 0x0034
+	ClusterRevision              = 1
+
+	// FeatureWatermarks indicates that the high watermark of the heap usage is supported.
+	FeatureWatermarks uint32 = 0x01
+
+	CurrentHeapFreeAttributeID          im.AttributeID = 0x0001
+	CurrentHeapUsedAttributeID          im.AttributeID = 0x0002
+	CurrentHeapHighWatermarkAttributeID im.AttributeID = 0x0003
+
+	ResetWatermarksCommandID im.CommandID = 0x00
+
+	SoftwareFaultEventID im.EventID = 0x00
+)
+
+// MemStatsReader reads the memory statistics, which is runtime.ReadMemStats by default.
+type MemStatsReader func(stats *runtime.MemStats)
+
+// Cluster represents a Software Diagnostics cluster server which reports the heap usage of the Go runtime.
+// The heap attributes are read from the runtime whenever they are read, and do not change the data version
+// since they change continuously.
+type Cluster struct {
+	*datamodel.BaseCluster
+	mutex     sync.Mutex
+	endpoint  *datamodel.Endpoint
+	reader    MemStatsReader
+	watermark uint64
+}
+
+// Option represents an option of the software diagnostics cluster.
+type Option func(*Cluster)
+
+// WithMemStatsReader sets the reader of the memory statistics.
+func WithMemStatsReader(reader MemStatsReader) Option {
+	return func(cluster *Cluster) {
+		cluster.reader = reader
+	}
+}
+
+// NewCluster returns a new software diagnostics cluster on the specified endpoint.
+func NewCluster(ep *datamodel.Endpoint, opts ...Option) *Cluster {
+	cluster := &Cluster{
+		BaseCluster: datamodel.NewBaseCluster(ClusterID, ClusterRevision),
+		mutex:       sync.Mutex{},
+		endpoint:    ep,
+		reader:      runtime.ReadMemStats,
+		watermark:   0,
+	}
+	for _, opt := range opts {
+		opt(cluster)
+	}
+
+	cluster.SetFeatureMap(FeatureWatermarks)
+	for _, id := range []im.AttributeID{CurrentHeapFreeAttributeID, CurrentHeapUsedAttributeID, CurrentHeapHighWatermarkAttributeID} {
+		cluster.AddAttribute(datamodel.NewAttribute(id, new(datatype.Uint64)))
+	}
+	cluster.AddCommand(ResetWatermarksCommandID, cluster.resetWatermarks)
+
+	return cluster
+}
+
+// HeapUsage returns the free and used bytes of the heap, and the high watermark of the used bytes.
+// The free bytes are the idle heap spans which are not released to the operating system.
+func (cluster *Cluster) HeapUsage() (free uint64, used uint64, watermark uint64) {
+	stats := runtime.MemStats{}
+	cluster.reader(&stats)
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	cluster.watermark = max(cluster.watermark, stats.HeapAlloc)
+	return stats.HeapIdle - stats.HeapReleased, stats.HeapAlloc, cluster.watermark
+}
+
+// ResetWatermarks resets the high watermark to the current heap usage.
+func (cluster *Cluster) ResetWatermarks() {
+	stats := runtime.MemStats{}
+	cluster.reader(&stats)
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	cluster.watermark = stats.HeapAlloc
+}
+
+// ReadAttribute returns the value of the specified attribute, and the current heap usage for the heap attributes.
+func (cluster *Cluster) ReadAttribute(id im.AttributeID) (datatype.Value, error) {
+	var v uint64
+	switch id {
+	case CurrentHeapFreeAttributeID:
+		v, _, _ = cluster.HeapUsage()
+	case CurrentHeapUsedAttributeID:
+		_, v, _ = cluster.HeapUsage()
+	case CurrentHeapHighWatermarkAttributeID:
+		_, _, v = cluster.HeapUsage()
+	default:
+		return cluster.BaseCluster.ReadAttribute(id)
+	}
+	value := datatype.Uint64(v)
+	return &value, nil
+}
+
+// ReportSoftwareFault emits a SoftwareFault event of the specified fault, such as a recovered panic.
+// The name and the recording are omitted if they are empty.
+func (cluster *Cluster) ReportSoftwareFault(id uint64, name string, recording []byte) {
+	faultID := datatype.Uint64(id)
+	faultName := datatype.NewUnset(new(datatype.String))
+	if 0 < len(name) {
+		v := datatype.String(name)
+		faultName.Set(&v)
+	}
+	faultRecording := datatype.NewUnset(new(datatype.OctetString))
+	if 0 < len(recording) {
+		v := datatype.OctetString(recording)
+		faultRecording.Set(&v)
+	}
+	cluster.endpoint.EmitEvent(ClusterID, SoftwareFaultEventID, datamodel.InfoPriority, datatype.NewStruct(
+		datatype.NewField(0, &faultID),
+		datatype.NewField(1, faultName),
+		datatype.NewField(2, faultRecording)))
+}
+
+func (cluster *Cluster) resetWatermarks(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	cluster.ResetWatermarks()
+	return nil, nil
+}
@@ -0,0 +1,89 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package softwarediagnostics
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datamodel/datamodeltest"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+func TestSoftwareDiagnosticsCluster(t *testing.T) {
+	heapAlloc := uint64(4096)
+	cluster := NewCluster(datamodel.NewEndpoint(datamodel.RootEndpointID), WithMemStatsReader(func(stats *runtime.MemStats) {
+		stats.HeapAlloc = heapAlloc
+		stats.HeapIdle = 2048
+		stats.HeapReleased = 1024
+	}))
+
+	read := func(id im.AttributeID) uint64 {
+		t.Helper()
+		v, err := cluster.ReadAttribute(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return uint64(*v.(*datatype.Uint64))
+	}
+
+	if free := read(CurrentHeapFreeAttributeID); free != 1024 {
+		t.Errorf("heap free (%d) != 1024", free)
+	}
+	heapAlloc = 8192
+	if used := read(CurrentHeapUsedAttributeID); used != 8192 {
+		t.Errorf("heap used (%d) != 8192", used)
+	}
+	heapAlloc = 1024
+	if watermark := read(CurrentHeapHighWatermarkAttributeID); watermark != 8192 {
+		t.Errorf("heap watermark (%d) != 8192", watermark)
+	}
+
+	_, err := datamodeltest.Invoke(t, cluster, datamodel.RootEndpointID, ResetWatermarksCommandID, 1, datatype.NewStruct())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if watermark := read(CurrentHeapHighWatermarkAttributeID); watermark != 1024 {
+		t.Errorf("heap watermark (%d) != 1024", watermark)
+	}
+}
+
+func TestSoftwareFault(t *testing.T) {
+	node := datamodel.NewNode()
+	ep := datamodel.NewEndpoint(datamodel.RootEndpointID)
+	if err := node.AddEndpoint(ep); err != nil {
+		t.Fatal(err)
+	}
+	events := []*datamodel.Event{}
+	node.SetEventHandler(func(ev *datamodel.Event) {
+		events = append(events, ev)
+	})
+
+	cluster := NewCluster(ep)
+	cluster.ReportSoftwareFault(1, "worker", nil)
+	if len(events) != 1 || events[0].Path.Event != SoftwareFaultEventID {
+		t.Fatalf("events %v", events)
+	}
+	b, err := datatype.Encode(events[0].Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The empty recording is omitted.
+	if expected := []byte{0x15, 0x24, 0x00, 0x01, 0x2C, 0x01, 0x06, 'w', 'o', 'r', 'k', 'e', 'r', 0x18}; string(b) != string(expected) {
+		t.Errorf("%X != %X", b, expected)
+	}
+}
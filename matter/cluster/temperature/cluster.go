@@ -0,0 +1,46 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temperature
+
+import (
+	"github.com/cybergarage/go-matter/matter/cluster/measurement"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// 2.3. Temperature Measurement Cluster
+const (
+	ClusterID       im.ClusterID = 0x0402
+	ClusterRevision              = 4
+)
+
+// Definition is the definition of the Temperature Measurement cluster, whose values are fed in degrees
+// Celsius and measured in hundredths of a degree Celsius.
+var Definition = &measurement.Definition{
+	ID:       ClusterID,
+	Revision: ClusterRevision,
+	Scale:    100,
+	Min:      datatype.TemperatureMin,
+	Max:      32767,
+	NewValue: func(v int64) datatype.Value {
+		t := datatype.Temperature(v)
+		return &t
+	},
+}
+
+// NewCluster returns a new Temperature Measurement cluster.
+func NewCluster(opts ...measurement.Option) (*measurement.Cluster, error) {
+	return measurement.NewCluster(Definition, opts...)
+}
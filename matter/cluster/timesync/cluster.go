@@ -0,0 +1,364 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timesync
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// 11.17. Time Synchronization Cluster
+const (
+	ClusterID       im.ClusterID = 0x0038
+	ClusterRevision              = 2
+
+	UTCTimeAttributeID              im.AttributeID = 0x0000
+	GranularityAttributeID          im.AttributeID = 0x0001
+	TrustedTimeSourceAttributeID    im.AttributeID = 0x0003
+	TimeZoneAttributeID             im.AttributeID = 0x0005
+	DSTOffsetAttributeID            im.AttributeID = 0x0006
+	LocalTimeAttributeID            im.AttributeID = 0x0007
+	TimeZoneDatabaseAttributeID     im.AttributeID = 0x0008
+	TimeZoneListMaxSizeAttributeID  im.AttributeID = 0x000A
+	DSTOffsetListMaxSizeAttributeID im.AttributeID = 0x000B
+
+	SetUTCTimeCommandID           im.CommandID = 0x00
+	SetTrustedTimeSourceCommandID im.CommandID = 0x01
+	SetTimeZoneCommandID          im.CommandID = 0x02
+	SetTimeZoneResponseCommandID  im.CommandID = 0x03
+	SetDSTOffsetCommandID         im.CommandID = 0x04
+	DefaultTimeZoneListMaxSize                 = 2
+	DefaultDSTOffsetListMaxSize                = 1
+)
+
+// Features of the time synchronization cluster.
+const (
+	FeatureTimeZone       uint32 = 0x01
+	FeatureNTPClient      uint32 = 0x02
+	FeatureNTPServer      uint32 = 0x04
+	FeatureTimeSyncClient uint32 = 0x08
+)
+
+// Granularity represents the granularity of the time of a node.
+type Granularity uint8
+
+const (
+	NoTimeGranularity       Granularity = 0x00
+	MinutesGranularity      Granularity = 0x01
+	SecondsGranularity      Granularity = 0x02
+	MillisecondsGranularity Granularity = 0x03
+	MicrosecondsGranularity Granularity = 0x04
+)
+
+// IsValid returns true if the granularity is defined.
+func (granularity Granularity) IsValid() bool {
+	return granularity <= MicrosecondsGranularity
+}
+
+// TimeZoneDatabase represents the time zone database of a node.
+type TimeZoneDatabase uint8
+
+const (
+	TimeZoneDatabaseFull    TimeZoneDatabase = 0x00
+	TimeZoneDatabasePartial TimeZoneDatabase = 0x01
+	TimeZoneDatabaseNone    TimeZoneDatabase = 0x02
+)
+
+// Cluster represents a Time Synchronization cluster server with the time zone and the time sync
+// client features. The time is set by clients with SetUTCTime, and keeps running on the local clock.
+type Cluster struct {
+	*datamodel.BaseCluster
+	mutex            sync.Mutex
+	now              func() time.Time
+	utcTime          time.Time
+	setAt            time.Time
+	granularity      Granularity
+	trustedSource    *TrustedTimeSource
+	timeZones        []TimeZone
+	dstOffsets       []DSTOffset
+	timeZoneMaxSize  int
+	dstOffsetMaxSize int
+}
+
+// Option represents an option of the time synchronization cluster.
+type Option func(*Cluster)
+
+// WithClock sets the local clock which the time runs on after it is set.
+func WithClock(now func() time.Time) Option {
+	return func(cluster *Cluster) {
+		cluster.now = now
+	}
+}
+
+// WithTimeZoneListMaxSize sets the maximum size of the time zone list, which is one or two.
+func WithTimeZoneListMaxSize(n int) Option {
+	return func(cluster *Cluster) {
+		cluster.timeZoneMaxSize = min(max(n, 1), DefaultTimeZoneListMaxSize)
+	}
+}
+
+// WithDSTOffsetListMaxSize sets the maximum size of the DST offset list, which is at least one.
+func WithDSTOffsetListMaxSize(n int) Option {
+	return func(cluster *Cluster) {
+		cluster.dstOffsetMaxSize = min(max(n, 1), 0xFF)
+	}
+}
+
+// NewCluster returns a new time synchronization cluster whose time is not set.
+func NewCluster(opts ...Option) *Cluster {
+	cluster := &Cluster{
+		BaseCluster:      datamodel.NewBaseCluster(ClusterID, ClusterRevision),
+		mutex:            sync.Mutex{},
+		now:              time.Now,
+		utcTime:          time.Time{},
+		setAt:            time.Time{},
+		granularity:      NoTimeGranularity,
+		trustedSource:    nil,
+		timeZones:        []TimeZone{{Offset: 0, ValidAt: time.Time{}, Name: ""}},
+		dstOffsets:       []DSTOffset{},
+		timeZoneMaxSize:  DefaultTimeZoneListMaxSize,
+		dstOffsetMaxSize: DefaultDSTOffsetListMaxSize,
+	}
+	for _, opt := range opts {
+		opt(cluster)
+	}
+
+	cluster.SetFeatureMap(FeatureTimeZone | FeatureTimeSyncClient)
+	granularity := datatype.Enum8(NoTimeGranularity)
+	database := datatype.Enum8(TimeZoneDatabaseNone)
+	timeZoneMaxSize := datatype.Uint8(cluster.timeZoneMaxSize)
+	dstOffsetMaxSize := datatype.Uint8(cluster.dstOffsetMaxSize)
+	cluster.AddAttribute(datamodel.NewAttribute(UTCTimeAttributeID, datatype.NewNull(new(datatype.EpochUs))))
+	cluster.AddAttribute(datamodel.NewAttribute(GranularityAttributeID, &granularity))
+	cluster.AddAttribute(datamodel.NewAttribute(TrustedTimeSourceAttributeID, newTrustedTimeSourceValue(nil)))
+	cluster.AddAttribute(datamodel.NewAttribute(TimeZoneAttributeID, encodeTimeZones(cluster.timeZones)))
+	cluster.AddAttribute(datamodel.NewAttribute(DSTOffsetAttributeID, encodeDSTOffsets(cluster.dstOffsets)))
+	cluster.AddAttribute(datamodel.NewAttribute(LocalTimeAttributeID, datatype.NewNull(new(datatype.EpochUs))))
+	cluster.AddAttribute(datamodel.NewAttribute(TimeZoneDatabaseAttributeID, &database))
+	cluster.AddAttribute(datamodel.NewAttribute(TimeZoneListMaxSizeAttributeID, &timeZoneMaxSize))
+	cluster.AddAttribute(datamodel.NewAttribute(DSTOffsetListMaxSizeAttributeID, &dstOffsetMaxSize))
+	cluster.AddCommand(SetUTCTimeCommandID, cluster.setUTCTime)
+	cluster.AddCommand(SetTrustedTimeSourceCommandID, cluster.setTrustedTimeSource)
+	cluster.AddCommand(SetTimeZoneCommandID, cluster.setTimeZone)
+	cluster.AddCommand(SetDSTOffsetCommandID, cluster.setDSTOffset)
+	cluster.AddGeneratedCommand(SetTimeZoneResponseCommandID)
+
+	return cluster
+}
+
+// SetUTCTime sets the UTC time of the specified granularity.
+func (cluster *Cluster) SetUTCTime(t time.Time, granularity Granularity) {
+	cluster.mutex.Lock()
+	cluster.utcTime = t.UTC()
+	cluster.setAt = cluster.now()
+	cluster.granularity = granularity
+	cluster.mutex.Unlock()
+
+	v := datatype.Enum8(granularity)
+	cluster.SetAttribute(GranularityAttributeID, &v)
+}
+
+// UTCTime returns the current UTC time with its granularity, and false if the time is not set.
+// It is the clock to validate certificates and to run schedules.
+func (cluster *Cluster) UTCTime() (time.Time, Granularity, bool) {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	return cluster.utcTimeLocked()
+}
+
+func (cluster *Cluster) utcTimeLocked() (time.Time, Granularity, bool) {
+	if cluster.granularity == NoTimeGranularity {
+		return time.Time{}, NoTimeGranularity, false
+	}
+	return cluster.utcTime.Add(cluster.now().Sub(cluster.setAt)), cluster.granularity, true
+}
+
+// LocalTime returns the current local time with the offsets of the active time zone and DST offset,
+// and false if the time is not set.
+func (cluster *Cluster) LocalTime() (time.Time, bool) {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	utc, _, ok := cluster.utcTimeLocked()
+	if !ok {
+		return time.Time{}, false
+	}
+	offset := time.Duration(0)
+	for _, tz := range cluster.timeZones {
+		if !utc.Before(tz.ValidAt) {
+			offset = tz.Offset
+		}
+	}
+	for _, dst := range cluster.dstOffsets {
+		if dst.IsActiveAt(utc) {
+			offset += dst.Offset
+		}
+	}
+	return utc.Add(offset), true
+}
+
+// TrustedTimeSource returns the node which the time is synchronized with, and false if it is not set.
+func (cluster *Cluster) TrustedTimeSource() (TrustedTimeSource, bool) {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	if cluster.trustedSource == nil {
+		return TrustedTimeSource{}, false
+	}
+	return *cluster.trustedSource, true
+}
+
+// TimeZones returns the time zone list.
+func (cluster *Cluster) TimeZones() []TimeZone {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	return append([]TimeZone{}, cluster.timeZones...)
+}
+
+// DSTOffsets returns the DST offset list.
+func (cluster *Cluster) DSTOffsets() []DSTOffset {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	return append([]DSTOffset{}, cluster.dstOffsets...)
+}
+
+// ReadAttribute returns the value of the specified attribute, and the running times as UTCTime and LocalTime.
+func (cluster *Cluster) ReadAttribute(id im.AttributeID) (datatype.Value, error) {
+	var t time.Time
+	var ok bool
+	switch id {
+	case UTCTimeAttributeID:
+		t, _, ok = cluster.UTCTime()
+	case LocalTimeAttributeID:
+		t, ok = cluster.LocalTime()
+	default:
+		return cluster.BaseCluster.ReadAttribute(id)
+	}
+	v := datatype.NewNull(new(datatype.EpochUs))
+	if !ok {
+		return v, nil
+	}
+	us, err := datatype.NewEpochUs(t)
+	if err != nil {
+		return v, nil
+	}
+	v.Set(&us)
+	return v, nil
+}
+
+func (cluster *Cluster) setUTCTime(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	utcTime := new(datatype.EpochUs)
+	granularity := new(datatype.Enum8)
+	source := new(datatype.Enum8)
+	err := req.DecodeFields(datatype.NewStruct(
+		datatype.NewField(0, utcTime),
+		datatype.NewField(1, granularity),
+		datatype.NewOptionalField(2, source)))
+	if err != nil {
+		return nil, err
+	}
+	if !Granularity(*granularity).IsValid() || Granularity(*granularity) == NoTimeGranularity {
+		return nil, fmt.Errorf("%w : granularity (%d)", im.StatusConstraintError, *granularity)
+	}
+	// A time of a finer granularity is not replaced with a coarser one.
+	_, current, ok := cluster.UTCTime()
+	if ok && Granularity(*granularity) < current {
+		return nil, fmt.Errorf("%w : %w (granularity %d < %d)", im.StatusFailure, ErrTimeNotAccepted, *granularity, current)
+	}
+	cluster.SetUTCTime(utcTime.Time(), Granularity(*granularity))
+	return nil, nil
+}
+
+func (cluster *Cluster) setTrustedTimeSource(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	if req.FabricIndex == types.NoFabricIndex {
+		return nil, fmt.Errorf("%w : SetTrustedTimeSource over a PASE session", im.StatusUnsupportedAccess)
+	}
+	nodeID := new(datatype.Uint64)
+	endpoint := new(datatype.Uint16)
+	source := datatype.NewNullable(datatype.NewStruct(
+		datatype.NewField(0, nodeID),
+		datatype.NewField(1, endpoint)))
+	if err := req.DecodeFields(datatype.NewStruct(datatype.NewField(0, source))); err != nil {
+		return nil, err
+	}
+	var trusted *TrustedTimeSource
+	if !source.Null {
+		trusted = &TrustedTimeSource{
+			FabricIndex: req.FabricIndex,
+			NodeID:      types.NodeID(*nodeID),
+			Endpoint:    im.EndpointID(*endpoint),
+		}
+	}
+	cluster.mutex.Lock()
+	cluster.trustedSource = trusted
+	cluster.mutex.Unlock()
+	return nil, cluster.SetAttribute(TrustedTimeSourceAttributeID, newTrustedTimeSourceValue(trusted))
+}
+
+func (cluster *Cluster) setTimeZone(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	list := datatype.NewList(newTimeZoneValue)
+	if err := req.DecodeFields(datatype.NewStruct(datatype.NewField(0, list))); err != nil {
+		return nil, err
+	}
+	timeZones, err := decodeTimeZones(list)
+	if err != nil {
+		return nil, err
+	}
+	if len(timeZones) == 0 || cluster.timeZoneMaxSize < len(timeZones) {
+		return nil, fmt.Errorf("%w : %d time zones", im.StatusResourceExhausted, len(timeZones))
+	}
+	if !timeZones[0].ValidAt.IsZero() {
+		return nil, fmt.Errorf("%w : first time zone is valid at %s", im.StatusConstraintError, timeZones[0].ValidAt)
+	}
+
+	// Without a time zone database, the DST offsets of the new time zone are required from the client.
+	cluster.mutex.Lock()
+	cluster.timeZones = timeZones
+	cluster.dstOffsets = []DSTOffset{}
+	cluster.mutex.Unlock()
+	if err := cluster.SetAttribute(TimeZoneAttributeID, encodeTimeZones(timeZones)); err != nil {
+		return nil, err
+	}
+	if err := cluster.SetAttribute(DSTOffsetAttributeID, encodeDSTOffsets(nil)); err != nil {
+		return nil, err
+	}
+	required := datatype.Bool(true)
+	return datamodel.NewCommandResponse(SetTimeZoneResponseCommandID, datatype.NewStruct(datatype.NewField(0, &required))), nil
+}
+
+func (cluster *Cluster) setDSTOffset(req *datamodel.CommandRequest) (*datamodel.CommandResponse, error) {
+	list := datatype.NewList(newDSTOffsetValue)
+	if err := req.DecodeFields(datatype.NewStruct(datatype.NewField(0, list))); err != nil {
+		return nil, err
+	}
+	dstOffsets, err := decodeDSTOffsets(list)
+	if err != nil {
+		return nil, err
+	}
+	if cluster.dstOffsetMaxSize < len(dstOffsets) {
+		return nil, fmt.Errorf("%w : %d DST offsets", im.StatusResourceExhausted, len(dstOffsets))
+	}
+	if err := validateDSTOffsets(dstOffsets); err != nil {
+		return nil, err
+	}
+	cluster.mutex.Lock()
+	cluster.dstOffsets = dstOffsets
+	cluster.mutex.Unlock()
+	return nil, cluster.SetAttribute(DSTOffsetAttributeID, encodeDSTOffsets(dstOffsets))
+}
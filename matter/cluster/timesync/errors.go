@@ -0,0 +1,28 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timesync
+
+import (
+	matterr "github.com/cybergarage/go-matter/matter/errors"
+)
+
+// 11.17.5.1. StatusCode
+const (
+	// StatusTimeNotAccepted is the cluster-specific status when a node does not accept the received time.
+	StatusTimeNotAccepted uint8 = 0x02
+)
+
+// ErrTimeNotAccepted is returned when a received time is coarser than the current time of the node.
+var ErrTimeNotAccepted = matterr.New(matterr.ErrRejected, "time not accepted")
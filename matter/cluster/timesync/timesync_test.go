@@ -0,0 +1,228 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timesync
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/datamodel/datamodeltest"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+const (
+	testEndpointID  im.EndpointID = 0
+	testFabricIndex               = types.FabricIndex(1)
+)
+
+type testClock struct {
+	now time.Time
+}
+
+func (clock *testClock) Now() time.Time {
+	return clock.now
+}
+
+func TestUTCTime(t *testing.T) {
+	clock := &testClock{now: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)}
+	cluster := NewCluster(WithClock(clock.Now))
+
+	if _, _, ok := cluster.UTCTime(); ok {
+		t.Error("time is set")
+	}
+	v, err := cluster.ReadAttribute(UTCTimeAttributeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := v.(*datatype.Nullable[*datatype.EpochUs]).Get(); ok {
+		t.Error("UTCTime is not null")
+	}
+
+	utc := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	fields, err := NewSetUTCTimeFields(utc, SecondsGranularity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, SetUTCTimeCommandID, testFabricIndex, fields); err != nil {
+		t.Fatal(err)
+	}
+
+	// The time runs on the local clock.
+	clock.now = clock.now.Add(90 * time.Second)
+	now, granularity, ok := cluster.UTCTime()
+	if !ok || !now.Equal(utc.Add(90*time.Second)) || granularity != SecondsGranularity {
+		t.Errorf("%s (%d) != %s", now, granularity, utc.Add(90*time.Second))
+	}
+	v, err = cluster.ReadAttribute(UTCTimeAttributeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	us, ok := v.(*datatype.Nullable[*datatype.EpochUs]).Get()
+	if !ok || !us.Time().Equal(now) {
+		t.Errorf("UTCTime (%v) != %s", us, now)
+	}
+
+	// A coarser time is not accepted.
+	fields, _ = NewSetUTCTimeFields(utc, MinutesGranularity)
+	if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, SetUTCTimeCommandID, testFabricIndex, fields); !errors.Is(err, ErrTimeNotAccepted) || !errors.Is(err, im.StatusFailure) {
+		t.Errorf("%v is not %v", err, ErrTimeNotAccepted)
+	}
+	fields, _ = NewSetUTCTimeFields(utc, MillisecondsGranularity)
+	if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, SetUTCTimeCommandID, testFabricIndex, fields); err != nil {
+		t.Error(err)
+	}
+	fields, _ = NewSetUTCTimeFields(utc, NoTimeGranularity)
+	if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, SetUTCTimeCommandID, testFabricIndex, fields); !errors.Is(err, im.StatusConstraintError) {
+		t.Errorf("%v is not %v", err, im.StatusConstraintError)
+	}
+}
+
+func TestTimeZone(t *testing.T) {
+	clock := &testClock{now: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)}
+	cluster := NewCluster(WithClock(clock.Now))
+	utc := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	cluster.SetUTCTime(utc, SecondsGranularity)
+
+	change := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	timeZones := []TimeZone{
+		{Offset: 9 * time.Hour, ValidAt: time.Time{}, Name: "Asia/Tokyo"},
+		{Offset: -5 * time.Hour, ValidAt: change, Name: "America/New_York"},
+	}
+	res, err := datamodeltest.Invoke(t, cluster, testEndpointID, SetTimeZoneCommandID, testFabricIndex, NewSetTimeZoneFields(timeZones))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Command != SetTimeZoneResponseCommandID {
+		t.Errorf("response (0x%02X)", res.Command)
+	}
+	if field, ok := res.Fields.(*datatype.Struct).LookupField(0); !ok || !bool(*field.Value.(*datatype.Bool)) {
+		t.Error("DSTOffsetRequired is not true")
+	}
+	if tz := cluster.TimeZones(); len(tz) != 2 || tz[1].Name != "America/New_York" || !tz[1].ValidAt.Equal(change) {
+		t.Errorf("%v", tz)
+	}
+	if local, ok := cluster.LocalTime(); !ok || !local.Equal(utc.Add(9*time.Hour)) {
+		t.Errorf("%s != %s", local, utc.Add(9*time.Hour))
+	}
+
+	dstOffsets := []DSTOffset{{Offset: time.Hour, ValidStarting: utc, ValidUntil: change}}
+	if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, SetDSTOffsetCommandID, testFabricIndex, NewSetDSTOffsetFields(dstOffsets)); err != nil {
+		t.Fatal(err)
+	}
+	if local, ok := cluster.LocalTime(); !ok || !local.Equal(utc.Add(10*time.Hour)) {
+		t.Errorf("%s != %s", local, utc.Add(10*time.Hour))
+	}
+	clock.now = clock.now.Add(change.Sub(utc))
+	if local, ok := cluster.LocalTime(); !ok || !local.Equal(change.Add(-5*time.Hour)) {
+		t.Errorf("%s != %s", local, change.Add(-5*time.Hour))
+	}
+	if _, err := datatype.Encode(mustRead(t, cluster, LocalTimeAttributeID)); err != nil {
+		t.Error(err)
+	}
+	if _, err := datatype.Encode(mustRead(t, cluster, DSTOffsetAttributeID)); err != nil {
+		t.Error(err)
+	}
+
+	invalidZones := []struct {
+		name      string
+		timeZones []TimeZone
+		status    im.Status
+	}{
+		{"offset", []TimeZone{{Offset: 15 * time.Hour}}, im.StatusConstraintError},
+		{"valid at", []TimeZone{{Offset: 0, ValidAt: change}}, im.StatusConstraintError},
+		{"name", []TimeZone{{Offset: 0, Name: string(make([]byte, MaxTimeZoneNameLength+1))}}, im.StatusConstraintError},
+		{"size", []TimeZone{{}, {ValidAt: change}, {ValidAt: change.Add(time.Hour)}}, im.StatusResourceExhausted},
+		{"empty", []TimeZone{}, im.StatusResourceExhausted},
+	}
+	for _, tc := range invalidZones {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, SetTimeZoneCommandID, testFabricIndex, NewSetTimeZoneFields(tc.timeZones)); !errors.Is(err, tc.status) {
+				t.Errorf("%v is not %v", err, tc.status)
+			}
+		})
+	}
+
+	// A new time zone clears the DST offsets.
+	if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, SetTimeZoneCommandID, testFabricIndex, NewSetTimeZoneFields(timeZones[:1])); err != nil {
+		t.Fatal(err)
+	}
+	if dst := cluster.DSTOffsets(); len(dst) != 0 {
+		t.Errorf("%v", dst)
+	}
+
+	invalidOffsets := []struct {
+		name       string
+		dstOffsets []DSTOffset
+		status     im.Status
+	}{
+		{"ends before starts", []DSTOffset{{Offset: time.Hour, ValidStarting: change, ValidUntil: utc}}, im.StatusConstraintError},
+		{"offset", []DSTOffset{{Offset: 13 * time.Hour, ValidStarting: utc}}, im.StatusConstraintError},
+		{"size", []DSTOffset{{Offset: time.Hour, ValidStarting: utc, ValidUntil: change}, {Offset: time.Hour, ValidStarting: change}}, im.StatusResourceExhausted},
+	}
+	for _, tc := range invalidOffsets {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, SetDSTOffsetCommandID, testFabricIndex, NewSetDSTOffsetFields(tc.dstOffsets)); !errors.Is(err, tc.status) {
+				t.Errorf("%v is not %v", err, tc.status)
+			}
+		})
+	}
+
+	overlapping := []DSTOffset{
+		{Offset: time.Hour, ValidStarting: utc, ValidUntil: change},
+		{Offset: time.Hour, ValidStarting: change.Add(-time.Hour), ValidUntil: time.Time{}},
+	}
+	if err := validateDSTOffsets(overlapping); !errors.Is(err, im.StatusConstraintError) {
+		t.Errorf("%v is not %v", err, im.StatusConstraintError)
+	}
+	if err := validateDSTOffsets([]DSTOffset{overlapping[1], overlapping[0]}); !errors.Is(err, im.StatusConstraintError) {
+		t.Errorf("%v is not %v", err, im.StatusConstraintError)
+	}
+}
+
+func TestTrustedTimeSource(t *testing.T) {
+	cluster := NewCluster()
+	nodeID := types.NodeID(0x1122334455667788)
+	if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, SetTrustedTimeSourceCommandID, types.NoFabricIndex, NewSetTrustedTimeSourceFields(&nodeID, 0)); !errors.Is(err, im.StatusUnsupportedAccess) {
+		t.Errorf("%v is not %v", err, im.StatusUnsupportedAccess)
+	}
+	if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, SetTrustedTimeSourceCommandID, testFabricIndex, NewSetTrustedTimeSourceFields(&nodeID, 0)); err != nil {
+		t.Fatal(err)
+	}
+	source, ok := cluster.TrustedTimeSource()
+	if !ok || source.FabricIndex != testFabricIndex || source.NodeID != nodeID {
+		t.Errorf("%v", source)
+	}
+	if _, err := datatype.Encode(mustRead(t, cluster, TrustedTimeSourceAttributeID)); err != nil {
+		t.Error(err)
+	}
+	if _, err := datamodeltest.Invoke(t, cluster, testEndpointID, SetTrustedTimeSourceCommandID, testFabricIndex, NewSetTrustedTimeSourceFields(nil, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cluster.TrustedTimeSource(); ok {
+		t.Error("trusted time source is not cleared")
+	}
+}
+
+func mustRead(t *testing.T, cluster *Cluster, id im.AttributeID) datatype.Value {
+	t.Helper()
+	v, err := cluster.ReadAttribute(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
@@ -0,0 +1,292 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timesync
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+const (
+	// MaxTimeZoneNameLength is the maximum length of a time zone name.
+	MaxTimeZoneNameLength = 64
+	// MinTimeZoneOffset is the minimum offset of a time zone.
+	MinTimeZoneOffset = -12 * time.Hour
+	// MaxTimeZoneOffset is the maximum offset of a time zone.
+	MaxTimeZoneOffset = 14 * time.Hour
+	// MaxDSTOffset is the maximum absolute offset of a DST offset.
+	MaxDSTOffset = 12 * time.Hour
+)
+
+// TimeZone represents a time zone (TimeZoneStruct).
+type TimeZone struct {
+	// Offset is the offset from UTC.
+	Offset time.Duration
+	// ValidAt is the UTC time from which the time zone is used, which is zero for the first time zone.
+	ValidAt time.Time
+	// Name is the optional time zone name.
+	Name string
+}
+
+// DSTOffset represents a daylight saving time offset (DSTOffsetStruct).
+type DSTOffset struct {
+	// Offset is the offset added to the time zone offset.
+	Offset time.Duration
+	// ValidStarting is the UTC time from which the offset is used.
+	ValidStarting time.Time
+	// ValidUntil is the UTC time until which the offset is used, which is zero if the offset is used indefinitely.
+	ValidUntil time.Time
+}
+
+// IsActiveAt returns true if the offset is used at the specified UTC time.
+func (dst DSTOffset) IsActiveAt(t time.Time) bool {
+	if t.Before(dst.ValidStarting) {
+		return false
+	}
+	return dst.ValidUntil.IsZero() || t.Before(dst.ValidUntil)
+}
+
+// TrustedTimeSource represents a node which the time is synchronized with (TrustedTimeSourceStruct).
+type TrustedTimeSource struct {
+	// FabricIndex is the fabric which the node belongs to.
+	FabricIndex types.FabricIndex
+	// NodeID is the node ID.
+	NodeID types.NodeID
+	// Endpoint is the endpoint of the time synchronization cluster on the node.
+	Endpoint im.EndpointID
+}
+
+const (
+	timeZoneOffsetTag         = 0
+	timeZoneValidAtTag        = 1
+	timeZoneNameTag           = 2
+	dstOffsetOffsetTag        = 0
+	dstOffsetValidStartingTag = 1
+	dstOffsetValidUntilTag    = 2
+	trustedSourceFabricTag    = 0
+	trustedSourceNodeIDTag    = 1
+	trustedSourceEndpointTag  = 2
+)
+
+// newEpochUs returns the epoch time of the specified time, which is zero for the zero time.
+func newEpochUs(t time.Time) (*datatype.EpochUs, error) {
+	if t.IsZero() {
+		return new(datatype.EpochUs), nil
+	}
+	v, err := datatype.NewEpochUs(t)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// epochTime returns the time of the specified epoch time, which is the zero time for zero.
+func epochTime(v *datatype.EpochUs) time.Time {
+	if *v == 0 {
+		return time.Time{}
+	}
+	return v.Time()
+}
+
+func newTimeZoneValue() datatype.Value {
+	return datatype.NewStruct(
+		datatype.NewField(timeZoneOffsetTag, new(datatype.Int32)),
+		datatype.NewField(timeZoneValidAtTag, new(datatype.EpochUs)),
+		datatype.NewOptionalField(timeZoneNameTag, new(datatype.String)))
+}
+
+func newDSTOffsetValue() datatype.Value {
+	return datatype.NewStruct(
+		datatype.NewField(dstOffsetOffsetTag, new(datatype.Int32)),
+		datatype.NewField(dstOffsetValidStartingTag, new(datatype.EpochUs)),
+		datatype.NewField(dstOffsetValidUntilTag, datatype.NewNull(new(datatype.EpochUs))))
+}
+
+// encodeTimeZones returns the list value of the specified time zones.
+func encodeTimeZones(timeZones []TimeZone) *datatype.List {
+	list := datatype.NewList(newTimeZoneValue)
+	for _, tz := range timeZones {
+		offset := datatype.Int32(tz.Offset / time.Second)
+		validAt, err := newEpochUs(tz.ValidAt)
+		if err != nil {
+			continue
+		}
+		st := datatype.NewStruct(
+			datatype.NewField(timeZoneOffsetTag, &offset),
+			datatype.NewField(timeZoneValidAtTag, validAt))
+		if tz.Name != "" {
+			name := datatype.String(tz.Name)
+			st.Fields = append(st.Fields, datatype.NewField(timeZoneNameTag, &name))
+		}
+		list.Elements = append(list.Elements, st)
+	}
+	return list
+}
+
+// decodeTimeZones returns the time zones of the decoded list value.
+func decodeTimeZones(list *datatype.List) ([]TimeZone, error) {
+	timeZones := make([]TimeZone, 0, len(list.Elements))
+	for _, elem := range list.Elements {
+		st := elem.(*datatype.Struct)
+		offsetField, _ := st.LookupField(timeZoneOffsetTag)
+		validAtField, _ := st.LookupField(timeZoneValidAtTag)
+		tz := TimeZone{
+			Offset:  time.Duration(*offsetField.Value.(*datatype.Int32)) * time.Second,
+			ValidAt: epochTime(validAtField.Value.(*datatype.EpochUs)),
+			Name:    "",
+		}
+		if nameField, ok := st.LookupField(timeZoneNameTag); ok && nameField.Present {
+			tz.Name = string(*nameField.Value.(*datatype.String))
+		}
+		if tz.Offset < MinTimeZoneOffset || MaxTimeZoneOffset < tz.Offset {
+			return nil, fmt.Errorf("%w : time zone offset (%s)", im.StatusConstraintError, tz.Offset)
+		}
+		if MaxTimeZoneNameLength < len(tz.Name) {
+			return nil, fmt.Errorf("%w : time zone name (%s)", im.StatusConstraintError, tz.Name)
+		}
+		timeZones = append(timeZones, tz)
+	}
+	return timeZones, nil
+}
+
+// encodeDSTOffsets returns the list value of the specified DST offsets.
+func encodeDSTOffsets(dstOffsets []DSTOffset) *datatype.List {
+	list := datatype.NewList(newDSTOffsetValue)
+	for _, dst := range dstOffsets {
+		offset := datatype.Int32(dst.Offset / time.Second)
+		validStarting, err := newEpochUs(dst.ValidStarting)
+		if err != nil {
+			continue
+		}
+		validUntil := datatype.NewNull(new(datatype.EpochUs))
+		if !dst.ValidUntil.IsZero() {
+			v, err := newEpochUs(dst.ValidUntil)
+			if err != nil {
+				continue
+			}
+			validUntil.Set(v)
+		}
+		list.Elements = append(list.Elements, datatype.NewStruct(
+			datatype.NewField(dstOffsetOffsetTag, &offset),
+			datatype.NewField(dstOffsetValidStartingTag, validStarting),
+			datatype.NewField(dstOffsetValidUntilTag, validUntil)))
+	}
+	return list
+}
+
+// decodeDSTOffsets returns the DST offsets of the decoded list value.
+func decodeDSTOffsets(list *datatype.List) ([]DSTOffset, error) {
+	dstOffsets := make([]DSTOffset, 0, len(list.Elements))
+	for _, elem := range list.Elements {
+		st := elem.(*datatype.Struct)
+		offsetField, _ := st.LookupField(dstOffsetOffsetTag)
+		validStartingField, _ := st.LookupField(dstOffsetValidStartingTag)
+		validUntilField, _ := st.LookupField(dstOffsetValidUntilTag)
+		dst := DSTOffset{
+			Offset:        time.Duration(*offsetField.Value.(*datatype.Int32)) * time.Second,
+			ValidStarting: epochTime(validStartingField.Value.(*datatype.EpochUs)),
+			ValidUntil:    time.Time{},
+		}
+		if v, ok := validUntilField.Value.(*datatype.Nullable[*datatype.EpochUs]).Get(); ok {
+			dst.ValidUntil = epochTime(v)
+		}
+		if dst.Offset < -MaxDSTOffset || MaxDSTOffset < dst.Offset {
+			return nil, fmt.Errorf("%w : DST offset (%s)", im.StatusConstraintError, dst.Offset)
+		}
+		dstOffsets = append(dstOffsets, dst)
+	}
+	return dstOffsets, nil
+}
+
+// validateDSTOffsets returns an error unless the DST offsets are ordered by the starting times and
+// do not overlap. Only the last offset may be used indefinitely.
+func validateDSTOffsets(dstOffsets []DSTOffset) error {
+	for n, dst := range dstOffsets {
+		if !dst.ValidUntil.IsZero() && !dst.ValidStarting.Before(dst.ValidUntil) {
+			return fmt.Errorf("%w : DST offset (%d) ends before it starts", im.StatusConstraintError, n)
+		}
+		if n == len(dstOffsets)-1 {
+			break
+		}
+		if dst.ValidUntil.IsZero() {
+			return fmt.Errorf("%w : DST offset (%d) is not the last one but valid indefinitely", im.StatusConstraintError, n)
+		}
+		if dstOffsets[n+1].ValidStarting.Before(dst.ValidUntil) {
+			return fmt.Errorf("%w : DST offsets (%d) and (%d) overlap", im.StatusConstraintError, n, n+1)
+		}
+	}
+	return nil
+}
+
+// newTrustedTimeSourceValue returns the nullable value of the specified trusted time source.
+func newTrustedTimeSourceValue(source *TrustedTimeSource) *datatype.Nullable[*datatype.Struct] {
+	fabric := datatype.Uint8(0)
+	nodeID := datatype.Uint64(0)
+	endpoint := datatype.Uint16(0)
+	if source != nil {
+		fabric = datatype.Uint8(source.FabricIndex)
+		nodeID = datatype.Uint64(source.NodeID)
+		endpoint = datatype.Uint16(source.Endpoint)
+	}
+	st := datatype.NewStruct(
+		datatype.NewField(trustedSourceFabricTag, &fabric),
+		datatype.NewField(trustedSourceNodeIDTag, &nodeID),
+		datatype.NewField(trustedSourceEndpointTag, &endpoint))
+	if source == nil {
+		return datatype.NewNull(st)
+	}
+	return datatype.NewNullable(st)
+}
+
+// NewSetUTCTimeFields returns the fields of a SetUTCTime command.
+func NewSetUTCTimeFields(t time.Time, granularity Granularity) (*datatype.Struct, error) {
+	utcTime, err := datatype.NewEpochUs(t)
+	if err != nil {
+		return nil, err
+	}
+	g := datatype.Enum8(granularity)
+	return datatype.NewStruct(
+		datatype.NewField(0, &utcTime),
+		datatype.NewField(1, &g)), nil
+}
+
+// NewSetTrustedTimeSourceFields returns the fields of a SetTrustedTimeSource command, which clears
+// the trusted time source if the node is nil. The fabric index is given by the accessing fabric.
+func NewSetTrustedTimeSourceFields(nodeID *types.NodeID, endpoint im.EndpointID) *datatype.Struct {
+	id := datatype.Uint64(0)
+	ep := datatype.Uint16(endpoint)
+	source := datatype.NewNull(datatype.NewStruct(
+		datatype.NewField(0, &id),
+		datatype.NewField(1, &ep)))
+	if nodeID != nil {
+		id = datatype.Uint64(*nodeID)
+		source.Null = false
+	}
+	return datatype.NewStruct(datatype.NewField(0, source))
+}
+
+// NewSetTimeZoneFields returns the fields of a SetTimeZone command.
+func NewSetTimeZoneFields(timeZones []TimeZone) *datatype.Struct {
+	return datatype.NewStruct(datatype.NewField(0, encodeTimeZones(timeZones)))
+}
+
+// NewSetDSTOffsetFields returns the fields of a SetDSTOffset command.
+func NewSetDSTOffsetFields(dstOffsets []DSTOffset) *datatype.Struct {
+	return datatype.NewStruct(datatype.NewField(0, encodeDSTOffsets(dstOffsets)))
+}
@@ -16,8 +16,12 @@ package matter
 
 import (
 	_ "embed"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/cybergarage/go-matter/matter/session"
+	"github.com/cybergarage/go-matter/matter/types"
 	"github.com/cybergarage/go-mdns/mdns"
 	"github.com/cybergarage/go-mdns/mdns/dns"
 )
@@ -46,15 +50,17 @@ func NewCommissioneeWithService(service *mdns.Service) *Commissionee {
 
 // LookupSubtype returns a subtype for the specified prefix.
 func (com *Commissionee) LookupSubtype(prefix string) (string, bool) {
-	record, ok := com.Service.LookupResourceRecordForNamePrefix(prefix)
-	if !ok {
-		return "", false
-	}
-	names := strings.Split(record.Name(), ".")
-	if len(names) < 1 {
-		return "", false
+	for _, record := range com.Service.ResourceRecords() {
+		if !strings.HasPrefix(record.Name(), prefix) {
+			continue
+		}
+		names := strings.Split(record.Name(), ".")
+		if len(names) < 1 {
+			return "", false
+		}
+		return names[0][len(prefix):], true
 	}
-	return names[0][len(prefix):], true
+	return "", false
 }
 
 // LookupAttribute returns an attribute value for the specified name.
@@ -84,8 +90,8 @@ func (com *Commissionee) appendLookupAttribute(records []string, name string) []
 
 // 4.3.1.3. Commissioning Subtypes (_L,_S)
 // 4.3.1.5. TXT key for discriminator (D)
-// LookupDiscriminator returns a discriminator.
-func (com *Commissionee) LookupDiscriminator() (string, bool) {
+// LookupDiscriminator returns a full 12-bit discriminator if available, otherwise a short discriminator.
+func (com *Commissionee) LookupDiscriminator() (Discriminator, bool) {
 	d, ok := com.LookupFullDiscriminator()
 	if ok {
 		return d, true
@@ -94,53 +100,96 @@ func (com *Commissionee) LookupDiscriminator() (string, bool) {
 }
 
 // 4.3.1.3. Commissioning Subtypes (_L)
-// LookupDiscriminator returns a full 12-bit discriminator.
-func (com *Commissionee) LookupFullDiscriminator() (string, bool) {
-	d, ok := com.LookupAttribute(TxtRecordDiscriminator)
-	if ok {
-		return d, true
+// 4.3.1.5. TXT key for discriminator (D)
+// LookupFullDiscriminator returns a full 12-bit discriminator.
+func (com *Commissionee) LookupFullDiscriminator() (Discriminator, bool) {
+	s, ok := com.LookupAttribute(TxtRecordDiscriminator)
+	if !ok {
+		s, ok = com.LookupSubtype(SubtypeDiscriminatorLong)
 	}
-	d, ok = com.LookupSubtype(SubtypeDiscriminatorLong)
-	if ok {
-		return d, true
+	if !ok {
+		return 0, false
+	}
+	d, err := types.NewDiscriminatorFromString(s)
+	if err != nil {
+		return 0, false
 	}
-	return com.LookupSubtype(SubtypeDiscriminatorShort)
+	return d, true
 }
 
 // 4.3.1.3. Commissioning Subtypes (_S)
 // LookupShortDiscriminator returns a short 4-bit discriminator.
-func (com *Commissionee) LookupShortDiscriminator() (string, bool) {
-	return com.LookupSubtype(SubtypeDiscriminatorShort)
+func (com *Commissionee) LookupShortDiscriminator() (Discriminator, bool) {
+	s, ok := com.LookupSubtype(SubtypeDiscriminatorShort)
+	if !ok {
+		return 0, false
+	}
+	d, err := types.NewShortDiscriminatorFromString(s)
+	if err != nil {
+		return 0, false
+	}
+	return d, true
 }
 
 // 4.3.1.3. Commissioning Subtypes (_V)
 // 4.3.1.6. TXT key for Vendor ID and Product ID (VP)
-// LookupVendorID returns a vendor and product ID.
-func (com *Commissionee) LookupVendorID() (string, bool) {
+// LookupVendorID returns a vendor ID.
+func (com *Commissionee) LookupVendorID() (VenderID, bool) {
 	venderID, _, ok := com.LookupVendorProductID()
 	if ok {
 		return venderID, true
 	}
-	return com.LookupSubtype(SubtypeVendorID)
+	vid, ok := com.LookupSubtype(SubtypeVendorID)
+	if !ok {
+		return 0, false
+	}
+	venderID, err := types.NewVendorIDFromString(vid)
+	if err != nil {
+		return 0, false
+	}
+	return venderID, true
 }
 
 // 4.3.1.6. TXT key for Vendor ID and Product ID (VP)
-// LookupVendorProductID returns a vendor and product ID.
-func (com *Commissionee) LookupVendorProductID() (string, string, bool) {
-	splitVenderProductID := func(vp string) (string, string, bool) {
-		vpList := strings.Split(vp, "+")
-		if len(vpList) < 1 {
-			return vpList[0], "", true
-		}
-		return vpList[0], vpList[1], true
+// LookupProductID returns a product ID.
+func (com *Commissionee) LookupProductID() (ProductID, bool) {
+	vp, ok := com.LookupAttribute(TxtRecordVendorProductID)
+	if !ok {
+		return 0, false
+	}
+	vpList := strings.Split(vp, "+")
+	if len(vpList) < 2 {
+		return 0, false
 	}
+	productID, err := types.NewProductIDFromString(vpList[1])
+	if err != nil {
+		return 0, false
+	}
+	return productID, true
+}
 
+// 4.3.1.6. TXT key for Vendor ID and Product ID (VP)
+// LookupVendorProductID returns a vendor and product ID. The product ID is zero
+// if the TXT record has only the vendor ID.
+func (com *Commissionee) LookupVendorProductID() (VenderID, ProductID, bool) {
 	vp, ok := com.LookupAttribute(TxtRecordVendorProductID)
 	if !ok || len(vp) == 0 {
-		return "", "", false
+		return 0, 0, false
 	}
 
-	return splitVenderProductID(vp)
+	vpList := strings.Split(vp, "+")
+	venderID, err := types.NewVendorIDFromString(vpList[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	if len(vpList) < 2 {
+		return venderID, 0, true
+	}
+	productID, err := types.NewProductIDFromString(vpList[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return venderID, productID, true
 }
 
 // 4.3.1.3. Commissioning Subtypes (_CM)
@@ -225,3 +274,72 @@ func (com *Commissionee) LookupPairingHint() (PairingHint, bool) {
 func (com *Commissionee) LookupPairingInstructions() (string, bool) {
 	return com.LookupAttribute(TxtRecordPairingInstruction)
 }
+
+// 4.3.4. Common TXT Key/Value Pairs (SII, SAI, SAT)
+// LookupSessionParams returns the MRP parameters advertised by the node. The absent fields are zero.
+func (com *Commissionee) LookupSessionParams() (*session.Params, bool) {
+	params := session.NewParams()
+	found := false
+	fields := []struct {
+		key string
+		max time.Duration
+		to  *time.Duration
+	}{
+		{TxtRecordSessionIdleInterval, session.MaxInterval, &params.IdleInterval},
+		{TxtRecordSessionActiveInterval, session.MaxInterval, &params.ActiveInterval},
+		{TxtRecordSessionActiveThreshold, session.MaxActiveThreshold, &params.ActiveThreshold},
+	}
+	for _, field := range fields {
+		s, ok := com.LookupAttribute(field.key)
+		if !ok {
+			continue
+		}
+		ms, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			continue
+		}
+		d := time.Duration(ms) * time.Millisecond
+		if field.max < d {
+			continue
+		}
+		*field.to = d
+		found = true
+	}
+	return params, found
+}
+
+// 4.3.4. TXT key for ICD operating mode (ICD)
+// LookupICDOperatingMode returns the ICD operating mode, which is advertised only by ICDs.
+func (com *Commissionee) LookupICDOperatingMode() (ICDOperatingMode, bool) {
+	return lookupICDOperatingMode(com.Service)
+}
+
+// 4.3.4. TXT key for Joint Fabric (JF)
+// LookupJointFabricMode returns the Joint Fabric mode.
+func (com *Commissionee) LookupJointFabricMode() (JointFabricMode, bool) {
+	return lookupJointFabricMode(com.Service)
+}
+
+func lookupICDOperatingMode(srv *mdns.Service) (ICDOperatingMode, bool) {
+	attr, ok := srv.LookupAttribute(TxtRecordICD)
+	if !ok {
+		return ICDShortIdleTime, false
+	}
+	mode, err := NewICDOperatingModeFromString(attr.Value())
+	if err != nil {
+		return ICDShortIdleTime, false
+	}
+	return mode, true
+}
+
+func lookupJointFabricMode(srv *mdns.Service) (JointFabricMode, bool) {
+	attr, ok := srv.LookupAttribute(TxtRecordJointFabric)
+	if !ok {
+		return 0, false
+	}
+	mode, err := NewJointFabricModeFromString(attr.Value())
+	if err != nil {
+		return 0, false
+	}
+	return mode, true
+}
@@ -14,19 +14,199 @@
 
 package matter
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cybergarage/go-matter/matter/ble"
+	"github.com/cybergarage/go-matter/matter/metrics"
+	"github.com/cybergarage/go-matter/matter/payload"
+)
+
 // Commissioner represents a commissioner.
+// The commissioner is safe for concurrent use, and so are the operational devices which it returns.
+// The options are applied before the commissioner is shared, and the session establisher may be
+// replaced at any time; the devices returned before keep the previous establisher.
 type Commissioner struct {
 	*Discoverer
+	mutex       sync.RWMutex
+	establisher SessionEstablisher
+	resolver    OperationalResolver
+	policy      ReconnectPolicy
+	parallelism int
+	stats       *metrics.PeerTable
+	connector   *ble.Connector
+}
+
+// CommissionerOption represents a commissioner option.
+type CommissionerOption func(*Commissioner)
+
+// WithSessionEstablisher returns an option to set the CASE session establisher for commissioned nodes.
+func WithSessionEstablisher(establisher SessionEstablisher) CommissionerOption {
+	return func(com *Commissioner) {
+		com.SetSessionEstablisher(establisher)
+	}
+}
+
+// WithOperationalResolver returns an option to set the resolver which looks up commissioned nodes
+// on failures. The default resolver uses the DNS-SD discoverer of the commissioner.
+func WithOperationalResolver(resolver OperationalResolver) CommissionerOption {
+	return func(com *Commissioner) {
+		if resolver == nil {
+			resolver = NewNullOperationalResolver()
+		}
+		com.resolver = resolver
+	}
+}
+
+// WithReconnectPolicy returns an option to set the policy to re-establish CASE sessions on failures.
+func WithReconnectPolicy(policy ReconnectPolicy) CommissionerOption {
+	return func(com *Commissioner) {
+		com.policy = policy
+	}
+}
+
+// WithBLEConnector returns an option to set the BLE connector which discovers commissionable nodes
+// over BLE. Commissionable nodes are discovered over DNS-SD only if no connector is set.
+func WithBLEConnector(conn *ble.Connector) CommissionerOption {
+	return func(com *Commissioner) {
+		com.connector = conn
+	}
 }
 
 // NewCommissioner returns a new commissioner.
-func NewCommissioner() *Commissioner {
+func NewCommissioner(opts ...CommissionerOption) *Commissioner {
+	disc := NewDiscoverer()
 	com := &Commissioner{
-		Discoverer: NewDiscoverer(),
+		Discoverer:  disc,
+		mutex:       sync.RWMutex{},
+		establisher: NewNullSessionEstablisher(),
+		resolver:    NewDNSSDOperationalResolver(disc),
+		policy:      DefaultReconnectPolicy(),
+		parallelism: DefaultBulkParallelism,
+		stats:       metrics.NewPeerTable(),
+		connector:   nil,
+	}
+	for _, opt := range opts {
+		opt(com)
 	}
 	return com
 }
 
+// SetSessionEstablisher sets the CASE session establisher for commissioned nodes.
+func (com *Commissioner) SetSessionEstablisher(establisher SessionEstablisher) {
+	if establisher == nil {
+		establisher = NewNullSessionEstablisher()
+	}
+	com.mutex.Lock()
+	defer com.mutex.Unlock()
+	com.establisher = establisher
+}
+
+// sessionEstablisher returns the current session establisher.
+func (com *Commissioner) sessionEstablisher() SessionEstablisher {
+	com.mutex.RLock()
+	defer com.mutex.RUnlock()
+	return com.establisher
+}
+
+// OperationalDevice returns a handle of the commissioned node for post-commissioning operations.
+func (com *Commissioner) OperationalDevice(peer OperationalPeer) *OperationalDevice {
+	dev := NewOperationalDevice(peer, com.sessionEstablisher())
+	dev.SetOperationalResolver(com.resolver)
+	dev.SetReconnectPolicy(com.policy)
+	dev.SetPeerTable(com.stats)
+	return dev
+}
+
+// PeerTable returns the table of the transport statistics of the nodes operated by the commissioner.
+func (com *Commissioner) PeerTable() *metrics.PeerTable {
+	return com.stats
+}
+
+// NodeStats returns the transport statistics of the specified node.
+func (com *Commissioner) NodeStats(id NodeID) (metrics.PeerStats, bool) {
+	return com.stats.Lookup(id)
+}
+
+// DiscoveredNode represents a commissionable node with the transport which it was discovered over.
+type DiscoveredNode struct {
+	CommissionableNode
+	// Transport is the commissioning transport of the node.
+	Transport payload.Transport
+}
+
+// 5.4.3. Discovery by Commissioner
+// DiscoverCommissionableNodes returns the commissionable nodes of the onboarding payload which are
+// discovered over the first transport of the discovery capabilities (Transports) which has any.
+// On-network nodes are looked up in the services which the discoverer has received, so they are
+// browsed beforehand, and BLE nodes are scanned with the BLE connector. Payloads without discovery
+// capabilities, such as manual pairing codes, are discovered over DNS-SD and BLE. Wi-Fi PAF and
+// Soft-AP are not supported, and skipped.
+func (com *Commissioner) DiscoverCommissionableNodes(ctx context.Context, p *payload.OnboardingPayload) ([]*DiscoveredNode, error) {
+	caps := p.DiscoveryCapabilities()
+	if !caps.IsValid() {
+		caps = payload.DiscoveryCapabilityOnNetwork | payload.DiscoveryCapabilityBLE
+	}
+	matcher := NewCommissionableNodeMatcher(p)
+	var errs error
+	for _, transport := range caps.Transports() {
+		var nodes []CommissionableNode
+		var err error
+		switch transport {
+		case payload.TransportOnNetwork:
+			nodes = com.onNetworkNodes()
+		case payload.TransportBLE:
+			nodes, err = com.bleNodes(ctx, p.Discriminator())
+		default:
+			continue
+		}
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%s : %w", transport, err))
+			continue
+		}
+		discovered := []*DiscoveredNode{}
+		for _, node := range nodes {
+			if matcher(node) {
+				discovered = append(discovered, &DiscoveredNode{CommissionableNode: node, Transport: transport})
+			}
+		}
+		if len(discovered) != 0 {
+			return discovered, nil
+		}
+	}
+	return nil, errors.Join(fmt.Errorf("commissionable node (%s) %w", p.Discriminator(), ErrNotFound), errs)
+}
+
+// onNetworkNodes returns the commissionable nodes which the discoverer has received.
+func (com *Commissioner) onNetworkNodes() []CommissionableNode {
+	nodes := []CommissionableNode{}
+	for _, srv := range com.Services() {
+		if CommissionableServiceType.matches(srv.Name) {
+			nodes = append(nodes, NewCommissioneeWithService(srv))
+		}
+	}
+	return nodes
+}
+
+// bleNodes scans the commissionable nodes which advertise the discriminator over BLE.
+func (com *Commissioner) bleNodes(ctx context.Context, discriminator Discriminator) ([]CommissionableNode, error) {
+	if com.connector == nil {
+		return []CommissionableNode{}, nil
+	}
+	devs, err := com.connector.Candidates(ctx, discriminator)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]CommissionableNode, 0, len(devs))
+	for _, dev := range devs {
+		nodes = append(nodes, NewCommissionableNodeWithServiceDescriptor(dev.ServiceDescriptor()))
+	}
+	return nodes, nil
+}
+
 // Start starts the commissioner.
 func (com *Commissioner) Start() error {
 	err := com.Discoverer.Start()
@@ -0,0 +1,60 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/cluster/administratorcommissioning"
+	"github.com/cybergarage/go-matter/matter/cluster/commissionercontrol"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// 9.6. Commissioner Control Cluster
+// RequestCommissioningApproval asks the commissioner control cluster of the specified endpoint to approve
+// commissioning the specified device into the fabric of the invoker. The server answers later with a
+// CommissioningRequestResult event of the request ID.
+func RequestCommissioningApproval(ctx context.Context, invoker CommandInvoker, endpoint im.EndpointID, requestID uint64, vid VenderID, pid ProductID, label string) error {
+	fields, err := datatype.Encode(commissionercontrol.NewRequestCommissioningApprovalFields(requestID, uint16(vid), uint16(pid), label))
+	if err != nil {
+		return err
+	}
+	_, err = invoker.InvokeCommand(ctx, im.NewCommandPath(endpoint, commissionercontrol.ClusterID, commissionercontrol.RequestCommissioningApprovalCommandID), fields)
+	return err
+}
+
+// CommissionNode asks the commissioner control cluster of the specified endpoint to open a commissioning
+// window on the device of the approved request, and returns the parameters of the window which the invoker
+// commissions the device with. The timeout is between 30 seconds and 2 minutes.
+func CommissionNode(ctx context.Context, invoker CommandInvoker, endpoint im.EndpointID, requestID uint64, timeout time.Duration) (*administratorcommissioning.OpenCommissioningWindowRequest, error) {
+	fields, err := datatype.Encode(commissionercontrol.NewCommissionNodeFields(requestID, timeout))
+	if err != nil {
+		return nil, err
+	}
+	b, err := invoker.InvokeCommand(ctx, im.NewCommandPath(endpoint, commissionercontrol.ClusterID, commissionercontrol.CommissionNodeCommandID), fields)
+	if err != nil {
+		return nil, err
+	}
+	window, err := administratorcommissioning.NewOpenCommissioningWindowRequestFromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	if err := window.Validate(); err != nil {
+		return nil, err
+	}
+	return window, nil
+}
@@ -41,6 +41,21 @@ const (
 	TxtRecordPairingInstruction = "PI"
 )
 
+// Matter Specification Version 1.2
+// 4.3.4. Common TXT Key/Value Pairs.
+const (
+	TxtRecordSessionIdleInterval    = "SII"
+	TxtRecordSessionActiveInterval  = "SAI"
+	TxtRecordSessionActiveThreshold = "SAT"
+)
+
+// Matter Specification Version 1.3 and 1.4
+// 4.3.4. Common TXT Key/Value Pairs.
+const (
+	TxtRecordICD         = "ICD"
+	TxtRecordJointFabric = "JF"
+)
+
 // Matter Specification Version 1.2
 // 4.3.1.7. TXT key for commissioning mode (CM).
 const (
@@ -0,0 +1,178 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credential
+
+import (
+	"fmt"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+// Bundle represents a controller fabric identity which can be shared between tools and machines.
+type Bundle struct {
+	// RootCertificate is the root CA certificate (RCAC) of the fabric.
+	RootCertificate []byte
+	// IntermediateCertificate is the optional intermediate CA certificate (ICAC).
+	IntermediateCertificate []byte
+	// OperationalCertificate is the node operational certificate (NOC) of the controller.
+	OperationalCertificate []byte
+	// OperationalKey is the operational private key of the controller.
+	OperationalKey []byte
+	// IPK is the identity protection key epoch key of the fabric.
+	IPK []byte
+	// FabricID is the fabric ID.
+	FabricID uint64
+	// NodeID is the operational node ID of the controller.
+	NodeID message.NodeID
+	// AdminSubjects are the CASE subjects granted the administer privilege.
+	AdminSubjects []uint64
+}
+
+const (
+	bundleRootCertificateTag = iota
+	bundleIntermediateCertificateTag
+	bundleOperationalCertificateTag
+	bundleOperationalKeyTag
+	bundleIPKTag
+	bundleFabricIDTag
+	bundleNodeIDTag
+	bundleAdminSubjectsTag
+)
+
+// Validate returns an error if a mandatory field is missing.
+func (bundle *Bundle) Validate() error {
+	if len(bundle.RootCertificate) == 0 {
+		return fmt.Errorf("%w bundle : no root certificate", ErrInvalid)
+	}
+	if len(bundle.OperationalKey) == 0 {
+		return fmt.Errorf("%w bundle : no operational key", ErrInvalid)
+	}
+	if bundle.FabricID == 0 {
+		return fmt.Errorf("%w bundle : no fabric ID", ErrInvalid)
+	}
+	if bundle.NodeID == 0 {
+		return fmt.Errorf("%w bundle : no node ID", ErrInvalid)
+	}
+	return nil
+}
+
+// encode returns the TLV encoding of the bundle.
+func (bundle *Bundle) encode() []byte {
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.NewAnonymousTag())
+	enc.PutOctetString(tlv.NewContextTag(bundleRootCertificateTag), bundle.RootCertificate)
+	if 0 < len(bundle.IntermediateCertificate) {
+		enc.PutOctetString(tlv.NewContextTag(bundleIntermediateCertificateTag), bundle.IntermediateCertificate)
+	}
+	if 0 < len(bundle.OperationalCertificate) {
+		enc.PutOctetString(tlv.NewContextTag(bundleOperationalCertificateTag), bundle.OperationalCertificate)
+	}
+	enc.PutOctetString(tlv.NewContextTag(bundleOperationalKeyTag), bundle.OperationalKey)
+	if 0 < len(bundle.IPK) {
+		enc.PutOctetString(tlv.NewContextTag(bundleIPKTag), bundle.IPK)
+	}
+	enc.PutUnsigned(tlv.NewContextTag(bundleFabricIDTag), bundle.FabricID)
+	enc.PutUnsigned(tlv.NewContextTag(bundleNodeIDTag), uint64(bundle.NodeID))
+	enc.StartArray(tlv.NewContextTag(bundleAdminSubjectsTag))
+	for _, subject := range bundle.AdminSubjects {
+		enc.PutUnsigned(tlv.NewAnonymousTag(), subject)
+	}
+	enc.EndContainer()
+	enc.EndContainer()
+	return enc.Bytes()
+}
+
+// decodeBundle returns a bundle from the specified TLV encoding.
+func decodeBundle(b []byte) (*Bundle, error) {
+	bundle := &Bundle{
+		RootCertificate:         nil,
+		IntermediateCertificate: nil,
+		OperationalCertificate:  nil,
+		OperationalKey:          nil,
+		IPK:                     nil,
+		FabricID:                0,
+		NodeID:                  0,
+		AdminSubjects:           []uint64{},
+	}
+
+	dec := tlv.NewDecoder(b)
+	elem, err := dec.Next()
+	if err != nil {
+		return nil, err
+	}
+	if elem.Type() != tlv.Structure {
+		return nil, fmt.Errorf("%w bundle container (%s)", ErrInvalid, elem.Type())
+	}
+
+	for {
+		elem, err := dec.Next()
+		if err != nil {
+			return nil, err
+		}
+		if elem.IsEndOfContainer() {
+			break
+		}
+		if !elem.Tag().IsContext() {
+			return nil, fmt.Errorf("%w bundle tag (%s)", ErrInvalid, elem.Tag())
+		}
+		switch elem.Tag().Number() {
+		case bundleRootCertificateTag:
+			bundle.RootCertificate, err = elem.Bytes()
+		case bundleIntermediateCertificateTag:
+			bundle.IntermediateCertificate, err = elem.Bytes()
+		case bundleOperationalCertificateTag:
+			bundle.OperationalCertificate, err = elem.Bytes()
+		case bundleOperationalKeyTag:
+			bundle.OperationalKey, err = elem.Bytes()
+		case bundleIPKTag:
+			bundle.IPK, err = elem.Bytes()
+		case bundleFabricIDTag:
+			bundle.FabricID, err = elem.Unsigned()
+		case bundleNodeIDTag:
+			var nodeID uint64
+			nodeID, err = elem.Unsigned()
+			bundle.NodeID = message.NodeID(nodeID)
+		case bundleAdminSubjectsTag:
+			if elem.Type() != tlv.Array {
+				return nil, fmt.Errorf("%w bundle admin subjects (%s)", ErrInvalid, elem.Type())
+			}
+			for {
+				subject, err := dec.Next()
+				if err != nil {
+					return nil, err
+				}
+				if subject.IsEndOfContainer() {
+					break
+				}
+				v, err := subject.Unsigned()
+				if err != nil {
+					return nil, err
+				}
+				bundle.AdminSubjects = append(bundle.AdminSubjects, v)
+			}
+		default:
+			// Skips unknown fields written by newer versions.
+			if elem.IsContainer() {
+				err = dec.Skip()
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return bundle, bundle.Validate()
+}
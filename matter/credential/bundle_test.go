@@ -0,0 +1,68 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credential
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestBundle(t *testing.T) {
+	bundle := &Bundle{
+		RootCertificate:         []byte{0x15, 0x30, 0x01},
+		IntermediateCertificate: nil,
+		OperationalCertificate:  []byte{0x15, 0x30, 0x02},
+		OperationalKey:          []byte{0x01, 0x02, 0x03, 0x04},
+		IPK:                     bytes.Repeat([]byte{0xAB}, 16),
+		FabricID:                1,
+		NodeID:                  0x0000000000000001,
+		AdminSubjects:           []uint64{0x0000000000000001, 0xFFFFFFFD00010001},
+	}
+
+	exported, err := bundle.ExportWithIterations("secret", 1000)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	imported, err := ImportBundle(exported, "secret")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !bytes.Equal(imported.RootCertificate, bundle.RootCertificate) ||
+		!bytes.Equal(imported.OperationalCertificate, bundle.OperationalCertificate) ||
+		!bytes.Equal(imported.OperationalKey, bundle.OperationalKey) ||
+		!bytes.Equal(imported.IPK, bundle.IPK) ||
+		imported.FabricID != bundle.FabricID ||
+		imported.NodeID != bundle.NodeID ||
+		len(imported.AdminSubjects) != len(bundle.AdminSubjects) {
+		t.Errorf("%v != %v", imported, bundle)
+	}
+
+	if _, err := ImportBundle(exported, "wrong"); !errors.Is(err, ErrAuthentication) {
+		t.Errorf("%v != %v", err, ErrAuthentication)
+	}
+
+	exported[len(exported)-1] ^= 0x01
+	if _, err := ImportBundle(exported, "secret"); !errors.Is(err, ErrAuthentication) {
+		t.Errorf("%v != %v", err, ErrAuthentication)
+	}
+
+	if _, err := (&Bundle{}).Export("secret"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("empty bundle is exported (%v)", err)
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credential
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+)
+
+const (
+	// serialNumberLength is the length of the serial numbers of issued certificates.
+	serialNumberLength = 8
+)
+
+// Validity represents the validity period of issued certificates.
+type Validity struct {
+	// NotBefore is the start of the validity period.
+	NotBefore time.Time
+	// NotAfter is the end of the validity period, which is zero for no well-defined expiration.
+	NotAfter time.Time
+}
+
+// NewValidity returns a validity period of the specified duration from the specified time.
+func NewValidity(notBefore time.Time, d time.Duration) Validity {
+	return Validity{
+		NotBefore: notBefore,
+		NotAfter:  notBefore.Add(d),
+	}
+}
+
+// CertificateAuthority represents a root or intermediate CA of a fabric, which issues Matter TLV
+// encoded certificates such as RCAC, ICAC and NOC chains for tests and tools.
+type CertificateAuthority struct {
+	key  *ecdsa.PrivateKey
+	data *certificateData
+	cert []byte
+}
+
+// NewRootCertificateAuthority returns a new root CA of the specified RCAC ID with a new key pair and
+// a self-signed RCAC. The fabric ID is put into the subject unless it is zero.
+func NewRootCertificateAuthority(rcacID uint64, fabricID uint64, validity Validity) (*CertificateAuthority, error) {
+	subject := []dnAttribute{newDNIDAttribute(dnRCACIDTag, rcacID)}
+	if fabricID != 0 {
+		subject = append(subject, newDNIDAttribute(dnFabricIDTag, fabricID))
+	}
+	return newCertificateAuthority(nil, subject, validity)
+}
+
+// NewIntermediateCertificateAuthority returns a new intermediate CA of the specified ICAC ID with a new
+// key pair and an ICAC issued by the CA. The ICAC has the fabric ID of the CA.
+func (ca *CertificateAuthority) NewIntermediateCertificateAuthority(icacID uint64, validity Validity) (*CertificateAuthority, error) {
+	subject := []dnAttribute{newDNIDAttribute(dnICACIDTag, icacID)}
+	if fabricID := ca.FabricID(); fabricID != 0 {
+		subject = append(subject, newDNIDAttribute(dnFabricIDTag, fabricID))
+	}
+	return newCertificateAuthority(ca, subject, validity)
+}
+
+func newCertificateAuthority(issuer *CertificateAuthority, subject []dnAttribute, validity Validity) (*CertificateAuthority, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	ca := &CertificateAuthority{
+		key:  key,
+		data: nil,
+		cert: nil,
+	}
+	if issuer == nil {
+		issuer = ca
+	}
+	exts := []certificateExtension{
+		newBasicConstraintsExtension(true),
+		newKeyUsageExtension(keyUsageKeyCertSign | keyUsageCRLSign),
+	}
+	ca.data, ca.cert, err = issuer.issue(&key.PublicKey, subject, exts, validity)
+	if err != nil {
+		return nil, err
+	}
+	return ca, nil
+}
+
+// IssueNodeCertificate returns a new NOC of the specified public key, node ID and CASE authenticated tags
+// on the fabric of the CA.
+func (ca *CertificateAuthority) IssueNodeCertificate(pub *ecdsa.PublicKey, nodeID message.NodeID, cats []uint32, validity Validity) ([]byte, error) {
+	if !nodeID.IsValidOperational() {
+		return nil, fmt.Errorf("%w node ID (%016X) : not operational", ErrInvalid, uint64(nodeID))
+	}
+	fabricID := ca.FabricID()
+	if fabricID == 0 {
+		return nil, fmt.Errorf("%w CA : no fabric ID", ErrInvalid)
+	}
+	subject := []dnAttribute{
+		newDNIDAttribute(dnNodeIDTag, uint64(nodeID)),
+		newDNIDAttribute(dnFabricIDTag, fabricID),
+	}
+	for _, cat := range cats {
+		subject = append(subject, newDNIDAttribute(dnNOCCATTag, uint64(cat)))
+	}
+	exts := []certificateExtension{
+		newBasicConstraintsExtension(false),
+		newKeyUsageExtension(keyUsageDigitalSignature),
+		newExtendedKeyUsageExtension(extendedKeyUsageClientAuth, extendedKeyUsageServerAuth),
+	}
+	_, cert, err := ca.issue(pub, subject, exts, validity)
+	return cert, err
+}
+
+// issue returns a new certificate of the public key and the subject signed by the CA. The key ID
+// extensions are appended to the specified extensions.
+func (ca *CertificateAuthority) issue(pub *ecdsa.PublicKey, subject []dnAttribute, exts []certificateExtension, validity Validity) (*certificateData, []byte, error) {
+	if pub.Curve != elliptic.P256() {
+		return nil, nil, fmt.Errorf("%w public key : not P-256", ErrInvalid)
+	}
+	pubKey, err := pub.ECDH()
+	if err != nil {
+		return nil, nil, err
+	}
+	notBefore, err := matterTime(validity.NotBefore)
+	if err != nil {
+		return nil, nil, err
+	}
+	notAfter, err := matterTime(validity.NotAfter)
+	if err != nil {
+		return nil, nil, err
+	}
+	if notAfter != 0 && notAfter <= notBefore {
+		return nil, nil, fmt.Errorf("%w validity (%s - %s)", ErrInvalid, validity.NotBefore, validity.NotAfter)
+	}
+	serialNumber := make([]byte, serialNumberLength)
+	if _, err := rand.Read(serialNumber); err != nil {
+		return nil, nil, err
+	}
+	// The serial number is a positive DER INTEGER without leading zeros.
+	serialNumber[0] = serialNumber[0]&0x7F | 0x40
+
+	keyID := sha1.Sum(pubKey.Bytes())
+	issuer := subject
+	authorityKeyID := keyID[:]
+	if ca.data != nil {
+		issuer = ca.data.subject
+		authorityKeyID = ca.subjectKeyID()
+	}
+	data := &certificateData{
+		serialNumber: serialNumber,
+		issuer:       issuer,
+		notBefore:    notBefore,
+		notAfter:     notAfter,
+		subject:      subject,
+		publicKey:    pubKey.Bytes(),
+		extensions: append(exts,
+			newKeyIDExtension(extSubjectKeyIDTag, keyID[:]),
+			newKeyIDExtension(extAuthorityKeyIDTag, authorityKeyID)),
+		signature: nil,
+	}
+	tbs, err := data.tbsDER()
+	if err != nil {
+		return nil, nil, err
+	}
+	hash := sha256.Sum256(tbs)
+	r, s, err := ecdsa.Sign(rand.Reader, ca.key, hash[:])
+	if err != nil {
+		return nil, nil, err
+	}
+	data.signature = make([]byte, signatureLength)
+	r.FillBytes(data.signature[:signatureLength/2])
+	s.FillBytes(data.signature[signatureLength/2:])
+	return data, data.encode(), nil
+}
+
+func (ca *CertificateAuthority) subjectKeyID() []byte {
+	if ext, ok := ca.data.lookupExtension(extSubjectKeyIDTag); ok {
+		return ext.keyID
+	}
+	return nil
+}
+
+// FabricID returns the fabric ID of the CA certificate, which is zero if it is not specified.
+func (ca *CertificateAuthority) FabricID() uint64 {
+	for _, attr := range ca.data.subject {
+		if attr.tag == dnFabricIDTag {
+			return attr.id
+		}
+	}
+	return 0
+}
+
+// Certificate returns the Matter TLV encoded certificate of the CA.
+func (ca *CertificateAuthority) Certificate() []byte {
+	return append([]byte{}, ca.cert...)
+}
+
+// PrivateKey returns the private key of the CA.
+func (ca *CertificateAuthority) PrivateKey() *ecdsa.PrivateKey {
+	return ca.key
+}
@@ -0,0 +1,182 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credential
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+// 6.5.2. Matter certificate
+const (
+	certSerialNumberTag = 1
+	certIssuerTag       = 3
+	certNotBeforeTag    = 4
+	certNotAfterTag     = 5
+	certSubjectTag      = 6
+)
+
+// 6.5.6.1. Distinguished Name
+const (
+	dnNodeIDTag   = 17
+	dnFabricIDTag = 21
+)
+
+// Certificate represents the validity and the subject of a Matter TLV encoded certificate.
+// The signature is not verified.
+type Certificate struct {
+	// SerialNumber is the serial number.
+	SerialNumber []byte
+	// NotBefore is the start of the validity period.
+	NotBefore time.Time
+	// NotAfter is the end of the validity period, which is zero if the certificate has no well-defined expiration.
+	NotAfter time.Time
+	// NodeID is the operational node ID of the subject, which is zero for CA certificates.
+	NodeID message.NodeID
+	// FabricID is the fabric ID of the subject, which is zero if it is not specified.
+	FabricID uint64
+}
+
+// ParseCertificate returns a certificate from the specified Matter TLV encoding.
+func ParseCertificate(b []byte) (*Certificate, error) {
+	cert := &Certificate{
+		SerialNumber: nil,
+		NotBefore:    time.Time{},
+		NotAfter:     time.Time{},
+		NodeID:       0,
+		FabricID:     0,
+	}
+
+	dec := tlv.NewDecoder(b)
+	elem, err := dec.Next()
+	if err != nil {
+		return nil, err
+	}
+	if elem.Type() != tlv.Structure {
+		return nil, fmt.Errorf("%w certificate container (%s)", ErrInvalid, elem.Type())
+	}
+
+	validity := 0
+	for {
+		elem, err := dec.Next()
+		if err != nil {
+			return nil, err
+		}
+		if elem.IsEndOfContainer() {
+			break
+		}
+		if !elem.Tag().IsContext() {
+			if elem.IsContainer() {
+				err = dec.Skip()
+			}
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		switch elem.Tag().Number() {
+		case certSerialNumberTag:
+			cert.SerialNumber, err = elem.Bytes()
+		case certNotBeforeTag:
+			cert.NotBefore, err = certificateTime(elem)
+			validity++
+		case certNotAfterTag:
+			cert.NotAfter, err = certificateTime(elem)
+			validity++
+		case certSubjectTag:
+			err = cert.decodeSubject(dec, elem)
+		default:
+			if elem.IsContainer() {
+				err = dec.Skip()
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if validity != 2 {
+		return nil, fmt.Errorf("%w certificate : no validity period", ErrInvalid)
+	}
+	return cert, nil
+}
+
+// certificateTime returns the time of the specified epoch seconds, where zero is the time which never comes.
+func certificateTime(elem *tlv.Element) (time.Time, error) {
+	s, err := elem.Unsigned()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if s == 0 {
+		return time.Time{}, nil
+	}
+	return datatype.Epoch.Add(time.Duration(s) * time.Second), nil
+}
+
+func (cert *Certificate) decodeSubject(dec *tlv.Decoder, elem *tlv.Element) error {
+	if elem.Type() != tlv.List {
+		return fmt.Errorf("%w certificate subject (%s)", ErrInvalid, elem.Type())
+	}
+	for {
+		attr, err := dec.Next()
+		if err != nil {
+			return err
+		}
+		if attr.IsEndOfContainer() {
+			return nil
+		}
+		if attr.IsContainer() {
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+			continue
+		}
+		if !attr.Tag().IsContext() {
+			continue
+		}
+		switch attr.Tag().Number() {
+		case dnNodeIDTag:
+			var nodeID uint64
+			nodeID, err = attr.Unsigned()
+			cert.NodeID = message.NodeID(nodeID)
+		case dnFabricIDTag:
+			cert.FabricID, err = attr.Unsigned()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// HasExpiration returns true if the certificate has a well-defined expiration.
+func (cert *Certificate) HasExpiration() bool {
+	return !cert.NotAfter.IsZero()
+}
+
+// IsValidAt returns true if the specified time is in the validity period.
+func (cert *Certificate) IsValidAt(t time.Time) bool {
+	if t.Before(cert.NotBefore) {
+		return false
+	}
+	return !cert.HasExpiration() || !t.After(cert.NotAfter)
+}
+
+// ExpiresWithin returns true if the certificate expires within the specified duration from the specified time.
+func (cert *Certificate) ExpiresWithin(t time.Time, d time.Duration) bool {
+	return cert.HasExpiration() && !t.Add(d).Before(cert.NotAfter)
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credential
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+// encodeTestCertificate returns a Matter TLV certificate of the specified validity without a valid signature.
+func encodeTestCertificate(notBefore, notAfter uint32, nodeID, fabricID uint64) []byte {
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.NewAnonymousTag())
+	enc.PutOctetString(tlv.NewContextTag(certSerialNumberTag), []byte{0x01, 0x02})
+	enc.PutUnsigned(tlv.NewContextTag(2), 1)
+	enc.StartList(tlv.NewContextTag(certIssuerTag))
+	enc.PutUnsigned(tlv.NewContextTag(20), 1)
+	enc.EndContainer()
+	enc.PutUnsigned(tlv.NewContextTag(certNotBeforeTag), uint64(notBefore))
+	enc.PutUnsigned(tlv.NewContextTag(certNotAfterTag), uint64(notAfter))
+	enc.StartList(tlv.NewContextTag(certSubjectTag))
+	enc.PutUnsigned(tlv.NewContextTag(dnNodeIDTag), nodeID)
+	enc.PutUnsigned(tlv.NewContextTag(dnFabricIDTag), fabricID)
+	enc.EndContainer()
+	enc.StartList(tlv.NewContextTag(10))
+	enc.StartStructure(tlv.NewContextTag(1))
+	enc.PutBool(tlv.NewContextTag(1), false)
+	enc.EndContainer()
+	enc.EndContainer()
+	enc.PutOctetString(tlv.NewContextTag(11), make([]byte, 64))
+	enc.EndContainer()
+	return enc.Bytes()
+}
+
+func TestCertificate(t *testing.T) {
+	notBefore := datatype.Epoch.Add(time.Hour)
+	notAfter := notBefore.Add(24 * time.Hour)
+	cert, err := ParseCertificate(encodeTestCertificate(3600, 3600+24*3600, 0x1234, 0x5678))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cert.NotBefore.Equal(notBefore) || !cert.NotAfter.Equal(notAfter) {
+		t.Errorf("validity (%s - %s) != (%s - %s)", cert.NotBefore, cert.NotAfter, notBefore, notAfter)
+	}
+	if cert.NodeID != 0x1234 || cert.FabricID != 0x5678 {
+		t.Errorf("subject (%X, %X) != (1234, 5678)", cert.NodeID, cert.FabricID)
+	}
+
+	tests := []struct {
+		t        time.Time
+		valid    bool
+		expiring bool
+	}{
+		{notBefore.Add(-time.Second), false, false},
+		{notBefore, true, false},
+		{notAfter.Add(-time.Hour), true, true},
+		{notAfter.Add(time.Second), false, true},
+	}
+	for _, test := range tests {
+		if cert.IsValidAt(test.t) != test.valid {
+			t.Errorf("%s : valid (%t)", test.t, !test.valid)
+		}
+		if cert.ExpiresWithin(test.t, 2*time.Hour) != test.expiring {
+			t.Errorf("%s : expiring (%t)", test.t, !test.expiring)
+		}
+	}
+
+	// Certificates without well-defined expiration never expire.
+	cert, err = ParseCertificate(encodeTestCertificate(3600, 0, 1, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cert.HasExpiration() || cert.ExpiresWithin(notAfter, 100*365*24*time.Hour) || !cert.IsValidAt(notAfter) {
+		t.Errorf("certificate expires")
+	}
+
+	if _, err := ParseCertificate([]byte{0x15, 0x18}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+}
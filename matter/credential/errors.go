@@ -0,0 +1,26 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credential
+
+import (
+	matterr "github.com/cybergarage/go-matter/matter/errors"
+)
+
+var (
+	// ErrInvalid is returned when a bundle is malformed or incomplete.
+	ErrInvalid = matterr.New(matterr.ErrInvalidArgument, "invalid")
+	// ErrAuthentication is returned when a bundle can not be decrypted with the specified password.
+	ErrAuthentication = matterr.New(matterr.ErrSecurity, "authentication failed")
+)
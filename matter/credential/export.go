@@ -0,0 +1,126 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credential
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/crypto"
+)
+
+// The exported bundle is laid out as follows:
+//
+//	magic (4) | version (1) | iterations (4, big endian) | salt (16) | nonce (12) | AES-256-GCM ciphertext
+//
+// The AES key is derived from the password with Crypto_PBKDF, and the header is
+// authenticated as additional data.
+const (
+	bundleMagic             = "MTCB"
+	bundleVersion           = 1
+	bundleSaltSize          = 16
+	bundleNonceSize         = 12
+	bundleKeyBits           = 256
+	bundleHeaderSize        = len(bundleMagic) + 1 + 4 + bundleSaltSize + bundleNonceSize
+	DefaultBundleIterations = 100000
+)
+
+// Export returns the bundle encrypted with the specified password.
+func (bundle *Bundle) Export(password string) ([]byte, error) {
+	return bundle.ExportWithIterations(password, DefaultBundleIterations)
+}
+
+// ExportWithIterations returns the bundle encrypted with the specified password and PBKDF iterations.
+func (bundle *Bundle) ExportWithIterations(password string, iterations uint32) ([]byte, error) {
+	if len(password) == 0 {
+		return nil, fmt.Errorf("%w bundle password", ErrInvalid)
+	}
+	if iterations == 0 {
+		return nil, fmt.Errorf("%w bundle iterations (%d)", ErrInvalid, iterations)
+	}
+	if err := bundle.Validate(); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, bundleHeaderSize)
+	offset := copy(header, bundleMagic)
+	header[offset] = bundleVersion
+	offset++
+	binary.BigEndian.PutUint32(header[offset:], iterations)
+	offset += 4
+	if _, err := rand.Read(header[offset:]); err != nil {
+		return nil, err
+	}
+
+	aead, err := newBundleAEAD(password, header)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := header[bundleHeaderSize-bundleNonceSize:]
+	return aead.Seal(header, nonce, bundle.encode(), header), nil
+}
+
+// ImportBundle returns the bundle decrypted with the specified password.
+func ImportBundle(b []byte, password string) (*Bundle, error) {
+	if len(b) < bundleHeaderSize {
+		return nil, fmt.Errorf("%w bundle length (%d)", ErrInvalid, len(b))
+	}
+	header := b[:bundleHeaderSize]
+	if !bytes.Equal(header[:len(bundleMagic)], []byte(bundleMagic)) {
+		return nil, fmt.Errorf("%w bundle magic (%X)", ErrInvalid, header[:len(bundleMagic)])
+	}
+	if header[len(bundleMagic)] != bundleVersion {
+		return nil, fmt.Errorf("%w bundle version (%d)", ErrInvalid, header[len(bundleMagic)])
+	}
+
+	aead, err := newBundleAEAD(password, header)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := header[bundleHeaderSize-bundleNonceSize:]
+	plaintext, err := aead.Open(nil, nonce, b[bundleHeaderSize:], header)
+	if err != nil {
+		return nil, fmt.Errorf("%w : %w", ErrAuthentication, err)
+	}
+
+	return decodeBundle(plaintext)
+}
+
+func newBundleAEAD(password string, header []byte) (cipher.AEAD, error) {
+	offset := len(bundleMagic) + 1
+	iterations := binary.BigEndian.Uint32(header[offset:])
+	offset += 4
+	salt := header[offset : offset+bundleSaltSize]
+
+	if iterations == 0 || DefaultBundleIterations*10 < iterations {
+		return nil, fmt.Errorf("%w bundle iterations (%d)", ErrInvalid, iterations)
+	}
+
+	key, err := crypto.PBKDF([]byte(password), salt, int(iterations), bundleKeyBits)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,459 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credential
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+// 6.5.2. Matter certificate
+const (
+	certSignatureAlgorithmTag = 2
+	certPublicKeyAlgorithmTag = 7
+	certECCurveIDTag          = 8
+	certECPublicKeyTag        = 9
+	certExtensionsTag         = 10
+	certSignatureTag          = 11
+
+	signatureAlgorithmECDSAWithSHA256 = 1
+	publicKeyAlgorithmEC              = 1
+	ecCurvePrime256v1                 = 1
+
+	// publicKeyLength is the length of uncompressed P-256 public keys.
+	publicKeyLength = 65
+	// signatureLength is the length of the raw ECDSA P-256 signatures which are the r and s values.
+	signatureLength = 64
+)
+
+// 6.5.6.1. Distinguished Name
+const (
+	dnCommonNameTag        = 1
+	dnDomainComponentTag   = 16
+	dnICACIDTag            = 19
+	dnRCACIDTag            = 20
+	dnNOCCATTag            = 22
+	dnPrintableStringFlag  = 0x80
+	dnMatterAttributeFirst = dnNodeIDTag
+	dnMatterAttributeLast  = dnNOCCATTag
+)
+
+// 6.5.11. Certificate extensions
+const (
+	extBasicConstraintsTag      = 1
+	extKeyUsageTag              = 2
+	extExtendedKeyUsageTag      = 3
+	extSubjectKeyIDTag          = 4
+	extAuthorityKeyIDTag        = 5
+	extFutureExtensionTag       = 6
+	basicConstraintsIsCATag     = 1
+	basicConstraintsPathLenTag  = 2
+	keyUsageDigitalSignature    = 0x0001
+	keyUsageKeyCertSign         = 0x0020
+	keyUsageCRLSign             = 0x0040
+	extendedKeyUsageServerAuth  = 1
+	extendedKeyUsageClientAuth  = 2
+	extendedKeyUsageOCSPSigning = 6
+)
+
+// dnAttribute represents an attribute of a distinguished name.
+type dnAttribute struct {
+	// tag is the attribute tag without the printable string flag.
+	tag uint8
+	// printable is true if the string attribute is a PrintableString in X.509.
+	printable bool
+	// str is the value of the string attributes.
+	str string
+	// id is the value of the Matter specific attributes.
+	id uint64
+}
+
+func newDNIDAttribute(tag uint8, id uint64) dnAttribute {
+	return dnAttribute{tag: tag, printable: false, str: "", id: id}
+}
+
+// isMatterAttribute returns true if the attribute is a Matter specific attribute such as the node ID.
+func (attr dnAttribute) isMatterAttribute() bool {
+	return dnMatterAttributeFirst <= attr.tag && attr.tag <= dnMatterAttributeLast
+}
+
+// certificateExtension represents a certificate extension.
+type certificateExtension struct {
+	tag          uint8
+	isCA         bool
+	pathLen      int
+	keyUsage     uint16
+	extKeyUsages []uint8
+	keyID        []byte
+	raw          []byte
+}
+
+func newCertificateExtension(tag uint8) certificateExtension {
+	return certificateExtension{
+		tag:          tag,
+		isCA:         false,
+		pathLen:      -1,
+		keyUsage:     0,
+		extKeyUsages: nil,
+		keyID:        nil,
+		raw:          nil,
+	}
+}
+
+func newBasicConstraintsExtension(isCA bool) certificateExtension {
+	ext := newCertificateExtension(extBasicConstraintsTag)
+	ext.isCA = isCA
+	return ext
+}
+
+func newKeyUsageExtension(usage uint16) certificateExtension {
+	ext := newCertificateExtension(extKeyUsageTag)
+	ext.keyUsage = usage
+	return ext
+}
+
+func newExtendedKeyUsageExtension(usages ...uint8) certificateExtension {
+	ext := newCertificateExtension(extExtendedKeyUsageTag)
+	ext.extKeyUsages = usages
+	return ext
+}
+
+func newKeyIDExtension(tag uint8, keyID []byte) certificateExtension {
+	ext := newCertificateExtension(tag)
+	ext.keyID = keyID
+	return ext
+}
+
+// certificateData represents all fields of a Matter TLV encoded certificate, which maps one-to-one to
+// the fields of the X.509 certificate whose DER encoding is signed.
+type certificateData struct {
+	serialNumber []byte
+	issuer       []dnAttribute
+	notBefore    uint32
+	notAfter     uint32
+	subject      []dnAttribute
+	publicKey    []byte
+	extensions   []certificateExtension
+	signature    []byte
+}
+
+// matterTime returns the epoch seconds of the certificate time, where the zero time never comes.
+func matterTime(t time.Time) (uint32, error) {
+	if t.IsZero() {
+		return 0, nil
+	}
+	s := t.Sub(datatype.Epoch) / time.Second
+	if s <= 0 || 0xFFFFFFFF < s {
+		return 0, fmt.Errorf("%w certificate time (%s) : out of range", ErrInvalid, t)
+	}
+	return uint32(s), nil
+}
+
+// encode returns the Matter TLV encoding of the certificate.
+func (data *certificateData) encode() []byte {
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.NewAnonymousTag())
+	enc.PutOctetString(tlv.NewContextTag(certSerialNumberTag), data.serialNumber)
+	enc.PutUnsigned(tlv.NewContextTag(certSignatureAlgorithmTag), signatureAlgorithmECDSAWithSHA256)
+	encodeDN(enc, certIssuerTag, data.issuer)
+	enc.PutUnsigned(tlv.NewContextTag(certNotBeforeTag), uint64(data.notBefore))
+	enc.PutUnsigned(tlv.NewContextTag(certNotAfterTag), uint64(data.notAfter))
+	encodeDN(enc, certSubjectTag, data.subject)
+	enc.PutUnsigned(tlv.NewContextTag(certPublicKeyAlgorithmTag), publicKeyAlgorithmEC)
+	enc.PutUnsigned(tlv.NewContextTag(certECCurveIDTag), ecCurvePrime256v1)
+	enc.PutOctetString(tlv.NewContextTag(certECPublicKeyTag), data.publicKey)
+	enc.StartList(tlv.NewContextTag(certExtensionsTag))
+	for _, ext := range data.extensions {
+		tag := tlv.NewContextTag(ext.tag)
+		switch ext.tag {
+		case extBasicConstraintsTag:
+			enc.StartStructure(tag)
+			enc.PutBool(tlv.NewContextTag(basicConstraintsIsCATag), ext.isCA)
+			if 0 <= ext.pathLen {
+				enc.PutUnsigned(tlv.NewContextTag(basicConstraintsPathLenTag), uint64(ext.pathLen))
+			}
+			enc.EndContainer()
+		case extKeyUsageTag:
+			enc.PutUnsigned(tag, uint64(ext.keyUsage))
+		case extExtendedKeyUsageTag:
+			enc.StartArray(tag)
+			for _, usage := range ext.extKeyUsages {
+				enc.PutUnsigned(tlv.NewAnonymousTag(), uint64(usage))
+			}
+			enc.EndContainer()
+		case extSubjectKeyIDTag, extAuthorityKeyIDTag:
+			enc.PutOctetString(tag, ext.keyID)
+		default:
+			enc.PutOctetString(tag, ext.raw)
+		}
+	}
+	enc.EndContainer()
+	enc.PutOctetString(tlv.NewContextTag(certSignatureTag), data.signature)
+	enc.EndContainer()
+	return enc.Bytes()
+}
+
+func encodeDN(enc *tlv.Encoder, tag uint8, attrs []dnAttribute) {
+	enc.StartList(tlv.NewContextTag(tag))
+	for _, attr := range attrs {
+		switch {
+		case attr.isMatterAttribute():
+			enc.PutUnsigned(tlv.NewContextTag(attr.tag), attr.id)
+		case attr.printable:
+			enc.PutUTF8String(tlv.NewContextTag(attr.tag|dnPrintableStringFlag), attr.str)
+		default:
+			enc.PutUTF8String(tlv.NewContextTag(attr.tag), attr.str)
+		}
+	}
+	enc.EndContainer()
+}
+
+// decodeCertificateData returns all fields of the specified Matter TLV encoded certificate.
+func decodeCertificateData(b []byte) (*certificateData, error) {
+	data := &certificateData{
+		serialNumber: nil,
+		issuer:       nil,
+		notBefore:    0,
+		notAfter:     0,
+		subject:      nil,
+		publicKey:    nil,
+		extensions:   []certificateExtension{},
+		signature:    nil,
+	}
+
+	dec := tlv.NewDecoder(b)
+	elem, err := dec.Next()
+	if err != nil {
+		return nil, err
+	}
+	if elem.Type() != tlv.Structure {
+		return nil, fmt.Errorf("%w certificate container (%s)", ErrInvalid, elem.Type())
+	}
+
+	fields := map[uint32]bool{}
+	for {
+		elem, err := dec.Next()
+		if err != nil {
+			return nil, err
+		}
+		if elem.IsEndOfContainer() {
+			break
+		}
+		if !elem.Tag().IsContext() {
+			return nil, fmt.Errorf("%w certificate field (%s)", ErrInvalid, elem.Tag())
+		}
+		n := elem.Tag().Number()
+		fields[n] = true
+		var v uint64
+		switch n {
+		case certSerialNumberTag:
+			data.serialNumber, err = elem.Bytes()
+		case certSignatureAlgorithmTag:
+			v, err = elem.Unsigned()
+			if err == nil && v != signatureAlgorithmECDSAWithSHA256 {
+				err = fmt.Errorf("%w certificate signature algorithm (%d)", ErrInvalid, v)
+			}
+		case certIssuerTag:
+			data.issuer, err = decodeDN(dec, elem)
+		case certNotBeforeTag:
+			v, err = elem.Unsigned()
+			data.notBefore = uint32(v)
+		case certNotAfterTag:
+			v, err = elem.Unsigned()
+			data.notAfter = uint32(v)
+		case certSubjectTag:
+			data.subject, err = decodeDN(dec, elem)
+		case certPublicKeyAlgorithmTag:
+			v, err = elem.Unsigned()
+			if err == nil && v != publicKeyAlgorithmEC {
+				err = fmt.Errorf("%w certificate public key algorithm (%d)", ErrInvalid, v)
+			}
+		case certECCurveIDTag:
+			v, err = elem.Unsigned()
+			if err == nil && v != ecCurvePrime256v1 {
+				err = fmt.Errorf("%w certificate curve (%d)", ErrInvalid, v)
+			}
+		case certECPublicKeyTag:
+			data.publicKey, err = elem.Bytes()
+		case certExtensionsTag:
+			data.extensions, err = decodeExtensions(dec, elem)
+		case certSignatureTag:
+			data.signature, err = elem.Bytes()
+		default:
+			err = fmt.Errorf("%w certificate field (%d)", ErrInvalid, n)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	for n := uint32(certSerialNumberTag); n <= certSignatureTag; n++ {
+		if !fields[n] {
+			return nil, fmt.Errorf("%w certificate : no field (%d)", ErrInvalid, n)
+		}
+	}
+	if len(data.publicKey) != publicKeyLength {
+		return nil, fmt.Errorf("%w certificate public key length (%d)", ErrInvalid, len(data.publicKey))
+	}
+	if len(data.signature) != signatureLength {
+		return nil, fmt.Errorf("%w certificate signature length (%d)", ErrInvalid, len(data.signature))
+	}
+	return data, nil
+}
+
+func decodeDN(dec *tlv.Decoder, elem *tlv.Element) ([]dnAttribute, error) {
+	if elem.Type() != tlv.List {
+		return nil, fmt.Errorf("%w certificate distinguished name (%s)", ErrInvalid, elem.Type())
+	}
+	attrs := []dnAttribute{}
+	for {
+		elem, err := dec.Next()
+		if err != nil {
+			return nil, err
+		}
+		if elem.IsEndOfContainer() {
+			return attrs, nil
+		}
+		if !elem.Tag().IsContext() || elem.IsContainer() {
+			return nil, fmt.Errorf("%w certificate distinguished name attribute (%s)", ErrInvalid, elem.Tag())
+		}
+		n := elem.Tag().Number()
+		attr := dnAttribute{
+			tag:       uint8(n &^ dnPrintableStringFlag),
+			printable: n&dnPrintableStringFlag != 0,
+			str:       "",
+			id:        0,
+		}
+		if _, ok := lookupDNAttributeOID(attr.tag); !ok {
+			return nil, fmt.Errorf("%w certificate distinguished name attribute (%d)", ErrInvalid, n)
+		}
+		if attr.isMatterAttribute() {
+			if attr.printable {
+				return nil, fmt.Errorf("%w certificate distinguished name attribute (%d)", ErrInvalid, n)
+			}
+			attr.id, err = elem.Unsigned()
+		} else {
+			attr.str, err = elem.String()
+		}
+		if err != nil {
+			return nil, err
+		}
+		attrs = append(attrs, attr)
+	}
+}
+
+func decodeExtensions(dec *tlv.Decoder, elem *tlv.Element) ([]certificateExtension, error) {
+	if elem.Type() != tlv.List {
+		return nil, fmt.Errorf("%w certificate extensions (%s)", ErrInvalid, elem.Type())
+	}
+	exts := []certificateExtension{}
+	for {
+		elem, err := dec.Next()
+		if err != nil {
+			return nil, err
+		}
+		if elem.IsEndOfContainer() {
+			return exts, nil
+		}
+		if !elem.Tag().IsContext() {
+			return nil, fmt.Errorf("%w certificate extension (%s)", ErrInvalid, elem.Tag())
+		}
+		ext := newCertificateExtension(uint8(elem.Tag().Number()))
+		var v uint64
+		switch ext.tag {
+		case extBasicConstraintsTag:
+			err = ext.decodeBasicConstraints(dec, elem)
+		case extKeyUsageTag:
+			v, err = elem.Unsigned()
+			ext.keyUsage = uint16(v)
+		case extExtendedKeyUsageTag:
+			err = ext.decodeExtendedKeyUsages(dec, elem)
+		case extSubjectKeyIDTag, extAuthorityKeyIDTag:
+			ext.keyID, err = elem.Bytes()
+		case extFutureExtensionTag:
+			ext.raw, err = elem.Bytes()
+		default:
+			err = fmt.Errorf("%w certificate extension (%d)", ErrInvalid, ext.tag)
+		}
+		if err != nil {
+			return nil, err
+		}
+		exts = append(exts, ext)
+	}
+}
+
+func (ext *certificateExtension) decodeBasicConstraints(dec *tlv.Decoder, elem *tlv.Element) error {
+	if elem.Type() != tlv.Structure {
+		return fmt.Errorf("%w certificate basic constraints (%s)", ErrInvalid, elem.Type())
+	}
+	for {
+		elem, err := dec.Next()
+		if err != nil {
+			return err
+		}
+		if elem.IsEndOfContainer() {
+			return nil
+		}
+		switch {
+		case elem.Tag().IsContextNumber(basicConstraintsIsCATag):
+			ext.isCA, err = elem.Bool()
+		case elem.Tag().IsContextNumber(basicConstraintsPathLenTag):
+			var v uint64
+			v, err = elem.Unsigned()
+			ext.pathLen = int(v)
+		default:
+			err = fmt.Errorf("%w certificate basic constraints (%s)", ErrInvalid, elem.Tag())
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (ext *certificateExtension) decodeExtendedKeyUsages(dec *tlv.Decoder, elem *tlv.Element) error {
+	if elem.Type() != tlv.Array {
+		return fmt.Errorf("%w certificate extended key usage (%s)", ErrInvalid, elem.Type())
+	}
+	ext.extKeyUsages = []uint8{}
+	for {
+		elem, err := dec.Next()
+		if err != nil {
+			return err
+		}
+		if elem.IsEndOfContainer() {
+			return nil
+		}
+		v, err := elem.Unsigned()
+		if err != nil {
+			return err
+		}
+		if v < extendedKeyUsageServerAuth || extendedKeyUsageOCSPSigning < v {
+			return fmt.Errorf("%w certificate extended key usage (%d)", ErrInvalid, v)
+		}
+		ext.extKeyUsages = append(ext.extKeyUsages, uint8(v))
+	}
+}
+
+// lookupExtension returns the extension of the specified tag.
+func (data *certificateData) lookupExtension(tag uint8) (*certificateExtension, bool) {
+	for n := range data.extensions {
+		if data.extensions[n].tag == tag {
+			return &data.extensions[n], true
+		}
+	}
+	return nil, false
+}
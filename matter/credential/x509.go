@@ -0,0 +1,547 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credential
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+)
+
+// 6.5.15. Conversion between Matter and X.509 certificates
+var (
+	oidECDSAWithSHA256       = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+	oidECPublicKey           = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidPrime256v1            = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	oidBasicConstraints      = asn1.ObjectIdentifier{2, 5, 29, 19}
+	oidKeyUsage              = asn1.ObjectIdentifier{2, 5, 29, 15}
+	oidExtendedKeyUsage      = asn1.ObjectIdentifier{2, 5, 29, 37}
+	oidSubjectKeyID          = asn1.ObjectIdentifier{2, 5, 29, 14}
+	oidAuthorityKeyID        = asn1.ObjectIdentifier{2, 5, 29, 35}
+	oidExtendedKeyUsageFirst = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3}
+)
+
+// dnAttributeOIDs is the OIDs of the distinguished name attributes by their tags.
+var dnAttributeOIDs = map[uint8]asn1.ObjectIdentifier{
+	1:  {2, 5, 4, 3},
+	2:  {2, 5, 4, 4},
+	3:  {2, 5, 4, 5},
+	4:  {2, 5, 4, 6},
+	5:  {2, 5, 4, 7},
+	6:  {2, 5, 4, 8},
+	7:  {2, 5, 4, 10},
+	8:  {2, 5, 4, 11},
+	9:  {2, 5, 4, 12},
+	10: {2, 5, 4, 41},
+	11: {2, 5, 4, 42},
+	12: {2, 5, 4, 43},
+	13: {2, 5, 4, 44},
+	14: {2, 5, 4, 46},
+	15: {2, 5, 4, 65},
+	16: {0, 9, 2342, 19200300, 100, 1, 25},
+	17: {1, 3, 6, 1, 4, 1, 37244, 1, 1},
+	18: {1, 3, 6, 1, 4, 1, 37244, 1, 2},
+	19: {1, 3, 6, 1, 4, 1, 37244, 1, 3},
+	20: {1, 3, 6, 1, 4, 1, 37244, 1, 4},
+	21: {1, 3, 6, 1, 4, 1, 37244, 1, 5},
+	22: {1, 3, 6, 1, 4, 1, 37244, 1, 6},
+}
+
+func lookupDNAttributeOID(tag uint8) (asn1.ObjectIdentifier, bool) {
+	oid, ok := dnAttributeOIDs[tag]
+	return oid, ok
+}
+
+func lookupDNAttributeTag(oid asn1.ObjectIdentifier) (uint8, bool) {
+	for tag, attrOID := range dnAttributeOIDs {
+		if attrOID.Equal(oid) {
+			return tag, true
+		}
+	}
+	return 0, false
+}
+
+// noWellDefinedExpiration is the X.509 time of certificates which have no well-defined expiration.
+var noWellDefinedExpiration = time.Date(9999, time.December, 31, 23, 59, 59, 0, time.UTC)
+
+const (
+	derTagBoolean         = 0x01
+	derTagInteger         = 0x02
+	derTagBitString       = 0x03
+	derTagOctetString     = 0x04
+	derTagUTF8String      = 0x0C
+	derTagPrintableString = 0x13
+	derTagIA5String       = 0x16
+	derTagSequence        = 0x30
+	derTagSet             = 0x31
+	derTagVersion         = 0xA0
+	derTagExtensions      = 0xA3
+	derTagKeyIdentifier   = 0x80
+)
+
+// der returns the DER encoding of the specified tag and contents.
+func der(tag byte, contents ...[]byte) []byte {
+	n := 0
+	for _, c := range contents {
+		n += len(c)
+	}
+	b := []byte{tag}
+	switch {
+	case n < 0x80:
+		b = append(b, byte(n))
+	default:
+		size := []byte{}
+		for v := n; 0 < v; v >>= 8 {
+			size = append([]byte{byte(v)}, size...)
+		}
+		b = append(b, 0x80|byte(len(size)))
+		b = append(b, size...)
+	}
+	for _, c := range contents {
+		b = append(b, c...)
+	}
+	return b
+}
+
+func derOID(oid asn1.ObjectIdentifier) ([]byte, error) {
+	return asn1.Marshal(oid)
+}
+
+// derTime returns the UTCTime of the time before 2050, or the GeneralizedTime otherwise as RFC 5280 requires.
+func derTime(s uint32) ([]byte, error) {
+	t := noWellDefinedExpiration
+	if s != 0 {
+		t = datatype.Epoch.Add(time.Duration(s) * time.Second)
+	}
+	return asn1.Marshal(t)
+}
+
+// derBitString returns the DER encoding of the bit string without unused bits.
+func derBitString(b []byte) []byte {
+	return der(derTagBitString, []byte{0x00}, b)
+}
+
+// derKeyUsage returns the DER encoding of the key usage bits, whose trailing zero bits are removed.
+func derKeyUsage(usage uint16) []byte {
+	last := -1
+	for n := 0; n < 16; n++ {
+		if usage&(1<<n) != 0 {
+			last = n
+		}
+	}
+	if last < 0 {
+		return der(derTagBitString, []byte{0x00})
+	}
+	b := make([]byte, last/8+1)
+	for n := 0; n <= last; n++ {
+		if usage&(1<<n) != 0 {
+			b[n/8] |= 0x80 >> (n % 8)
+		}
+	}
+	return der(derTagBitString, []byte{byte(7 - last%8)}, b)
+}
+
+func derDN(attrs []dnAttribute) ([]byte, error) {
+	rdns := [][]byte{}
+	for _, attr := range attrs {
+		oid, ok := lookupDNAttributeOID(attr.tag)
+		if !ok {
+			return nil, fmt.Errorf("%w certificate distinguished name attribute (%d)", ErrInvalid, attr.tag)
+		}
+		oidBytes, err := derOID(oid)
+		if err != nil {
+			return nil, err
+		}
+		var value []byte
+		switch {
+		case attr.tag == dnNOCCATTag:
+			value = der(derTagUTF8String, []byte(fmt.Sprintf("%08X", attr.id)))
+		case attr.isMatterAttribute():
+			value = der(derTagUTF8String, []byte(fmt.Sprintf("%016X", attr.id)))
+		case attr.printable:
+			value = der(derTagPrintableString, []byte(attr.str))
+		case attr.tag == dnDomainComponentTag:
+			value = der(derTagIA5String, []byte(attr.str))
+		default:
+			value = der(derTagUTF8String, []byte(attr.str))
+		}
+		rdns = append(rdns, der(derTagSet, der(derTagSequence, oidBytes, value)))
+	}
+	return der(derTagSequence, rdns...), nil
+}
+
+func derExtension(oid asn1.ObjectIdentifier, critical bool, value []byte) ([]byte, error) {
+	oidBytes, err := derOID(oid)
+	if err != nil {
+		return nil, err
+	}
+	if critical {
+		return der(derTagSequence, oidBytes, der(derTagBoolean, []byte{0xFF}), der(derTagOctetString, value)), nil
+	}
+	return der(derTagSequence, oidBytes, der(derTagOctetString, value)), nil
+}
+
+func (ext *certificateExtension) der() ([]byte, error) {
+	switch ext.tag {
+	case extBasicConstraintsTag:
+		constraints := [][]byte{}
+		if ext.isCA {
+			constraints = append(constraints, der(derTagBoolean, []byte{0xFF}))
+		}
+		if 0 <= ext.pathLen {
+			pathLen, err := asn1.Marshal(ext.pathLen)
+			if err != nil {
+				return nil, err
+			}
+			constraints = append(constraints, pathLen)
+		}
+		return derExtension(oidBasicConstraints, true, der(derTagSequence, constraints...))
+	case extKeyUsageTag:
+		return derExtension(oidKeyUsage, true, derKeyUsage(ext.keyUsage))
+	case extExtendedKeyUsageTag:
+		usages := [][]byte{}
+		for _, usage := range ext.extKeyUsages {
+			oid, err := derOID(append(append(asn1.ObjectIdentifier{}, oidExtendedKeyUsageFirst...), extendedKeyUsageOIDNumber(usage)))
+			if err != nil {
+				return nil, err
+			}
+			usages = append(usages, oid)
+		}
+		return derExtension(oidExtendedKeyUsage, true, der(derTagSequence, usages...))
+	case extSubjectKeyIDTag:
+		return derExtension(oidSubjectKeyID, false, der(derTagOctetString, ext.keyID))
+	case extAuthorityKeyIDTag:
+		return derExtension(oidAuthorityKeyID, false, der(derTagSequence, der(derTagKeyIdentifier, ext.keyID)))
+	default:
+		return ext.raw, nil
+	}
+}
+
+// extendedKeyUsageOIDNumber returns the last number of the id-kp OID of the Matter extended key usage.
+// The usages from serverAuth to emailProtection are id-kp 1 to 4, and timeStamping and OCSPSigning are 8 and 9.
+func extendedKeyUsageOIDNumber(usage uint8) int {
+	if usage <= 4 {
+		return int(usage)
+	}
+	return int(usage) + 3
+}
+
+// tbsDER returns the DER encoding of the X.509 TBSCertificate, which is signed by the issuer.
+func (data *certificateData) tbsDER() ([]byte, error) {
+	sigAlg, err := derOID(oidECDSAWithSHA256)
+	if err != nil {
+		return nil, err
+	}
+	issuer, err := derDN(data.issuer)
+	if err != nil {
+		return nil, err
+	}
+	notBefore, err := derTime(data.notBefore)
+	if err != nil {
+		return nil, err
+	}
+	notAfter, err := derTime(data.notAfter)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := derDN(data.subject)
+	if err != nil {
+		return nil, err
+	}
+	pubKeyAlg, err := derOID(oidECPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	curve, err := derOID(oidPrime256v1)
+	if err != nil {
+		return nil, err
+	}
+	exts := [][]byte{}
+	for n := range data.extensions {
+		ext, err := data.extensions[n].der()
+		if err != nil {
+			return nil, err
+		}
+		exts = append(exts, ext)
+	}
+	fields := [][]byte{
+		der(derTagVersion, der(derTagInteger, []byte{0x02})),
+		der(derTagInteger, data.serialNumber),
+		der(derTagSequence, sigAlg),
+		issuer,
+		der(derTagSequence, notBefore, notAfter),
+		subject,
+		der(derTagSequence, der(derTagSequence, pubKeyAlg, curve), derBitString(data.publicKey)),
+	}
+	if 0 < len(exts) {
+		fields = append(fields, der(derTagExtensions, der(derTagSequence, exts...)))
+	}
+	return der(derTagSequence, fields...), nil
+}
+
+// ecdsaSignature represents the DER encoding of ECDSA signatures.
+type ecdsaSignature struct {
+	R *big.Int
+	S *big.Int
+}
+
+// x509DER returns the DER encoding of the X.509 certificate.
+func (data *certificateData) x509DER() ([]byte, error) {
+	tbs, err := data.tbsDER()
+	if err != nil {
+		return nil, err
+	}
+	sigAlg, err := derOID(oidECDSAWithSHA256)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := asn1.Marshal(ecdsaSignature{
+		R: new(big.Int).SetBytes(data.signature[:signatureLength/2]),
+		S: new(big.Int).SetBytes(data.signature[signatureLength/2:]),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return der(derTagSequence, tbs, der(derTagSequence, sigAlg), derBitString(sig)), nil
+}
+
+// rawDNAttribute represents an attribute of a distinguished name with the raw value.
+type rawDNAttribute struct {
+	Type  asn1.ObjectIdentifier
+	Value asn1.RawValue
+}
+
+// rawRDNSET represents a relative distinguished name, which is decoded as an ASN.1 SET.
+type rawRDNSET []rawDNAttribute
+
+func parseDN(b []byte) ([]dnAttribute, error) {
+	var rdns []rawRDNSET
+	if _, err := asn1.Unmarshal(b, &rdns); err != nil {
+		return nil, fmt.Errorf("%w certificate distinguished name : %w", ErrInvalid, err)
+	}
+	attrs := []dnAttribute{}
+	for _, rdn := range rdns {
+		if len(rdn) != 1 {
+			return nil, fmt.Errorf("%w certificate relative distinguished name : %d attributes", ErrInvalid, len(rdn))
+		}
+		tag, ok := lookupDNAttributeTag(rdn[0].Type)
+		if !ok {
+			return nil, fmt.Errorf("%w certificate distinguished name attribute (%s)", ErrInvalid, rdn[0].Type)
+		}
+		value := rdn[0].Value
+		attr := dnAttribute{tag: tag, printable: false, str: "", id: 0}
+		switch {
+		case attr.isMatterAttribute():
+			size := 16
+			if tag == dnNOCCATTag {
+				size = 8
+			}
+			if value.Tag != asn1.TagUTF8String || len(value.Bytes) != size {
+				return nil, fmt.Errorf("%w certificate distinguished name attribute (%s) : %x", ErrInvalid, rdn[0].Type, value.Bytes)
+			}
+			id, err := strconv.ParseUint(string(value.Bytes), 16, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w certificate distinguished name attribute (%s) : %w", ErrInvalid, rdn[0].Type, err)
+			}
+			attr.id = id
+		case value.Tag == asn1.TagPrintableString:
+			attr.printable = true
+			attr.str = string(value.Bytes)
+		case value.Tag == asn1.TagUTF8String, value.Tag == asn1.TagIA5String && tag == dnDomainComponentTag:
+			attr.str = string(value.Bytes)
+		default:
+			return nil, fmt.Errorf("%w certificate distinguished name attribute (%s) : string type (%d)", ErrInvalid, rdn[0].Type, value.Tag)
+		}
+		attrs = append(attrs, attr)
+	}
+	return attrs, nil
+}
+
+func parseExtension(ext pkix.Extension) (certificateExtension, error) {
+	parsed := newCertificateExtension(extFutureExtensionTag)
+	var rest []byte
+	var err error
+	switch {
+	case ext.Id.Equal(oidBasicConstraints):
+		var constraints struct {
+			IsCA    bool `asn1:"optional"`
+			PathLen int  `asn1:"optional,default:-1"`
+		}
+		parsed.tag = extBasicConstraintsTag
+		rest, err = asn1.Unmarshal(ext.Value, &constraints)
+		parsed.isCA = constraints.IsCA
+		parsed.pathLen = constraints.PathLen
+	case ext.Id.Equal(oidKeyUsage):
+		var bits asn1.BitString
+		parsed.tag = extKeyUsageTag
+		rest, err = asn1.Unmarshal(ext.Value, &bits)
+		for n := 0; n < bits.BitLength && n < 16; n++ {
+			if bits.At(n) != 0 {
+				parsed.keyUsage |= 1 << n
+			}
+		}
+	case ext.Id.Equal(oidExtendedKeyUsage):
+		var oids []asn1.ObjectIdentifier
+		parsed.tag = extExtendedKeyUsageTag
+		parsed.extKeyUsages = []uint8{}
+		rest, err = asn1.Unmarshal(ext.Value, &oids)
+		for _, oid := range oids {
+			usage, ok := extendedKeyUsageOf(oid)
+			if !ok {
+				return parsed, fmt.Errorf("%w certificate extended key usage (%s)", ErrInvalid, oid)
+			}
+			parsed.extKeyUsages = append(parsed.extKeyUsages, usage)
+		}
+	case ext.Id.Equal(oidSubjectKeyID):
+		parsed.tag = extSubjectKeyIDTag
+		rest, err = asn1.Unmarshal(ext.Value, &parsed.keyID)
+	case ext.Id.Equal(oidAuthorityKeyID):
+		var keyID struct {
+			ID []byte `asn1:"optional,tag:0"`
+		}
+		parsed.tag = extAuthorityKeyIDTag
+		rest, err = asn1.Unmarshal(ext.Value, &keyID)
+		parsed.keyID = keyID.ID
+	default:
+		parsed.raw, err = asn1.Marshal(ext)
+	}
+	if err == nil && 0 < len(rest) {
+		err = fmt.Errorf("trailing data")
+	}
+	if err != nil {
+		return parsed, fmt.Errorf("%w certificate extension (%s) : %w", ErrInvalid, ext.Id, err)
+	}
+	return parsed, nil
+}
+
+func extendedKeyUsageOf(oid asn1.ObjectIdentifier) (uint8, bool) {
+	if len(oid) != len(oidExtendedKeyUsageFirst)+1 || !oid[:len(oidExtendedKeyUsageFirst)].Equal(oidExtendedKeyUsageFirst) {
+		return 0, false
+	}
+	for usage := uint8(extendedKeyUsageServerAuth); usage <= extendedKeyUsageOCSPSigning; usage++ {
+		if extendedKeyUsageOIDNumber(usage) == oid[len(oid)-1] {
+			return usage, true
+		}
+	}
+	return 0, false
+}
+
+// parseX509CertificateData returns all fields of the DER encoded X.509 certificate.
+func parseX509CertificateData(b []byte) (*certificateData, error) {
+	cert, err := x509.ParseCertificate(b)
+	if err != nil {
+		return nil, fmt.Errorf("%w X.509 certificate : %w", ErrInvalid, err)
+	}
+	if cert.Version != 3 || cert.SignatureAlgorithm != x509.ECDSAWithSHA256 {
+		return nil, fmt.Errorf("%w X.509 certificate : version (%d) and signature algorithm (%s)", ErrInvalid, cert.Version, cert.SignatureAlgorithm)
+	}
+	pub, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok || pub.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("%w X.509 certificate : public key is not P-256", ErrInvalid)
+	}
+
+	data := &certificateData{
+		serialNumber: nil,
+		issuer:       nil,
+		notBefore:    0,
+		notAfter:     0,
+		subject:      nil,
+		publicKey:    nil,
+		extensions:   []certificateExtension{},
+		signature:    nil,
+	}
+	// The serial number is kept as the contents of the DER INTEGER including the leading zero.
+	serialDER, err := asn1.Marshal(cert.SerialNumber)
+	if err != nil {
+		return nil, err
+	}
+	var serial asn1.RawValue
+	if _, err := asn1.Unmarshal(serialDER, &serial); err != nil {
+		return nil, err
+	}
+	data.serialNumber = serial.Bytes
+	if data.issuer, err = parseDN(cert.RawIssuer); err != nil {
+		return nil, err
+	}
+	if data.subject, err = parseDN(cert.RawSubject); err != nil {
+		return nil, err
+	}
+	if data.notBefore, err = matterTime(cert.NotBefore); err != nil {
+		return nil, err
+	}
+	if !cert.NotAfter.Equal(noWellDefinedExpiration) {
+		if data.notAfter, err = matterTime(cert.NotAfter); err != nil {
+			return nil, err
+		}
+	}
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(cert.RawSubjectPublicKeyInfo, &spki); err != nil {
+		return nil, fmt.Errorf("%w X.509 certificate public key : %w", ErrInvalid, err)
+	}
+	data.publicKey = spki.PublicKey.Bytes
+	for _, ext := range cert.Extensions {
+		parsed, err := parseExtension(ext)
+		if err != nil {
+			return nil, err
+		}
+		data.extensions = append(data.extensions, parsed)
+	}
+	var sig ecdsaSignature
+	if _, err := asn1.Unmarshal(cert.Signature, &sig); err != nil {
+		return nil, fmt.Errorf("%w X.509 certificate signature : %w", ErrInvalid, err)
+	}
+	data.signature = make([]byte, signatureLength)
+	sig.R.FillBytes(data.signature[:signatureLength/2])
+	sig.S.FillBytes(data.signature[signatureLength/2:])
+	return data, nil
+}
+
+// ConvertX509ToTLV returns the Matter TLV encoding of the DER encoded X.509 certificate. The certificate
+// must be in the form which is converted back into the same DER encoding, so that the signature stays valid.
+func ConvertX509ToTLV(b []byte) ([]byte, error) {
+	data, err := parseX509CertificateData(b)
+	if err != nil {
+		return nil, err
+	}
+	converted, err := data.x509DER()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(converted, b) {
+		return nil, fmt.Errorf("%w X.509 certificate : not in the Matter certificate form", ErrInvalid)
+	}
+	return data.encode(), nil
+}
+
+// ConvertTLVToX509 returns the DER encoded X.509 certificate of the Matter TLV encoded certificate.
+func ConvertTLVToX509(b []byte) ([]byte, error) {
+	data, err := decodeCertificateData(b)
+	if err != nil {
+		return nil, err
+	}
+	return data.x509DER()
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credential
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+)
+
+func newTestChain(t *testing.T, validity Validity) (*CertificateAuthority, *CertificateAuthority, []byte) {
+	t.Helper()
+	root, err := NewRootCertificateAuthority(1, 0xFAB000000000001D, validity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	icac, err := root.NewIntermediateCertificateAuthority(2, validity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	noc, err := icac.IssueNodeCertificate(&key.PublicKey, message.NodeID(0xDEDEDEDE00010001), []uint32{0xABCD0002}, validity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return root, icac, noc
+}
+
+func convertTestCertificate(t *testing.T, cert []byte) *x509.Certificate {
+	t.Helper()
+	der, err := ConvertTLVToX509(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	converted, err := ConvertX509ToTLV(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(converted, cert) {
+		t.Errorf("%x != %x", converted, cert)
+	}
+	x509Cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return x509Cert
+}
+
+func TestCertificateAuthority(t *testing.T) {
+	notBefore := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	root, icac, noc := newTestChain(t, NewValidity(notBefore, 365*24*time.Hour))
+
+	rootCert := convertTestCertificate(t, root.Certificate())
+	icacCert := convertTestCertificate(t, icac.Certificate())
+	nocCert := convertTestCertificate(t, noc)
+	if err := rootCert.CheckSignatureFrom(rootCert); err != nil {
+		t.Error(err)
+	}
+	if err := icacCert.CheckSignatureFrom(rootCert); err != nil {
+		t.Error(err)
+	}
+	if err := nocCert.CheckSignatureFrom(icacCert); err != nil {
+		t.Error(err)
+	}
+	if !icacCert.IsCA || nocCert.IsCA || nocCert.KeyUsage != x509.KeyUsageDigitalSignature {
+		t.Error("basic constraints or key usage")
+	}
+	if !nocCert.NotBefore.Equal(notBefore) {
+		t.Errorf("%s != %s", nocCert.NotBefore, notBefore)
+	}
+
+	cert, err := ParseCertificate(noc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cert.NodeID != 0xDEDEDEDE00010001 || cert.FabricID != 0xFAB000000000001D {
+		t.Errorf("subject (%016X, %016X)", uint64(cert.NodeID), cert.FabricID)
+	}
+
+	if _, err := root.IssueNodeCertificate(&root.PrivateKey().PublicKey, 0, nil, NewValidity(notBefore, time.Hour)); !errors.Is(err, ErrInvalid) {
+		t.Errorf("non-operational node ID : %v", err)
+	}
+}
+
+func TestCertificateWithoutExpiration(t *testing.T) {
+	root, _, noc := newTestChain(t, Validity{NotBefore: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), NotAfter: time.Time{}})
+	convertTestCertificate(t, root.Certificate())
+	nocCert := convertTestCertificate(t, noc)
+	if !nocCert.NotAfter.Equal(noWellDefinedExpiration) {
+		t.Errorf("%s != %s", nocCert.NotAfter, noWellDefinedExpiration)
+	}
+	cert, err := ParseCertificate(noc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cert.HasExpiration() {
+		t.Error("certificate has an expiration")
+	}
+}
+
+func TestConvertX509ToTLV(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test", Organization: []string{"go-matter"}},
+		NotBefore:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := ConvertX509ToTLV(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	converted, err := ConvertTLVToX509(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(converted, der) {
+		t.Errorf("%x != %x", converted, der)
+	}
+
+	// Certificates which are not in the Matter certificate form, such as ones with a non-critical extended
+	// key usage, can not be converted without breaking the signature.
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	der, err = x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ConvertX509ToTLV(der); !errors.Is(err, ErrInvalid) {
+		t.Errorf("non-Matter certificate : %v", err)
+	}
+	if _, err := ConvertX509ToTLV([]byte{0x30, 0x00}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("malformed certificate : %v", err)
+	}
+	if _, err := ConvertTLVToX509(encodeTestCertificate(0, 0, 1, 1)); !errors.Is(err, ErrInvalid) {
+		t.Errorf("incomplete certificate : %v", err)
+	}
+}
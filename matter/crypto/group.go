@@ -0,0 +1,49 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	// GroupEpochKeyLength is the length of group epoch keys in bytes.
+	GroupEpochKeyLength = 16
+
+	groupKeyInfo     = "GroupKey v1.0"
+	groupKeyHashInfo = "GroupKeyHash"
+)
+
+// 4.17.2. Operational Group Key Derivation
+// GroupOperationalKey returns the operational group key of the epoch key on the fabric of the
+// specified compressed fabric identifier, which group messages are encrypted with.
+func GroupOperationalKey(epochKey []byte, compressedFabricID uint64) ([]byte, error) {
+	if len(epochKey) != GroupEpochKeyLength {
+		return nil, fmt.Errorf("%w group epoch key length (%d)", ErrInvalid, len(epochKey))
+	}
+	salt := binary.BigEndian.AppendUint64(nil, compressedFabricID)
+	return KDF(epochKey, salt, []byte(groupKeyInfo), GroupEpochKeyLength*8)
+}
+
+// GroupSessionID returns the group session ID of the operational group key, which is carried in
+// the group messages to find the candidate keys.
+func GroupSessionID(operationalKey []byte) (uint16, error) {
+	hash, err := KDF(operationalKey, nil, []byte(groupKeyHashInfo), 16)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint16(hash), nil
+}
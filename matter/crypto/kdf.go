@@ -0,0 +1,54 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+)
+
+// 3.8. Key Derivation Function (KDF)
+// KDF returns a key of the specified bit length derived from the input key, salt and info
+// using HKDF-SHA256 as defined by Crypto_KDF().
+func KDF(input []byte, salt []byte, info []byte, lengthBits int) ([]byte, error) {
+	if lengthBits < 8 || (lengthBits%8) != 0 || (255*sha256.Size*8) < lengthBits {
+		return nil, fmt.Errorf("%w KDF key length (%d)", ErrInvalid, lengthBits)
+	}
+
+	// HKDF-Extract
+	if len(salt) == 0 {
+		salt = make([]byte, sha256.Size)
+	}
+	extract := hmac.New(sha256.New, salt)
+	extract.Write(input)
+	prk := extract.Sum(nil)
+
+	// HKDF-Expand
+	keyLen := lengthBits / 8
+	expand := hmac.New(sha256.New, prk)
+	key := make([]byte, 0, keyLen+sha256.Size)
+	t := []byte{}
+	for n := 1; len(key) < keyLen; n++ {
+		expand.Reset()
+		expand.Write(t)
+		expand.Write(info)
+		expand.Write([]byte{byte(n)})
+		t = expand.Sum(nil)
+		key = append(key, t...)
+	}
+
+	return key[:keyLen], nil
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestKDF(t *testing.T) {
+	// RFC 5869 A. Test Vectors
+	ikm := bytes.Repeat([]byte{0x0B}, 22)
+	tests := []struct {
+		salt       string
+		info       string
+		lengthBits int
+		expected   string
+	}{
+		{"000102030405060708090a0b0c", "f0f1f2f3f4f5f6f7f8f9", 336, "3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865"},
+		{"", "", 336, "8da4e775a563c18f715f802a063c5a31b8a11f5c5ee1879ec3454e5f3c738d2d9d201395faa4b61a96c8"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expected[:8], func(t *testing.T) {
+			salt, _ := hex.DecodeString(test.salt)
+			info, _ := hex.DecodeString(test.info)
+			key, err := KDF(ikm, salt, info, test.lengthBits)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if hex.EncodeToString(key) != test.expected {
+				t.Errorf("%x != %s", key, test.expected)
+			}
+		})
+	}
+
+	if _, err := KDF(ikm, nil, nil, 12); err == nil {
+		t.Errorf("partial byte length is accepted")
+	}
+}
+
+func TestGroupOperationalKey(t *testing.T) {
+	// 4.17.2. Operational Group Key Derivation
+	epochKey, _ := hex.DecodeString("235bf7e62823d358dca4ba50b1535f4b")
+	key, err := GroupOperationalKey(epochKey, 0x87E1B004E235A130)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(key) != "a6f5306baf6d050af23ba4bd6b9dd960" {
+		t.Errorf("%x", key)
+	}
+	id, err := GroupSessionID(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 0xB9F7 {
+		t.Errorf("%04X != B9F7", id)
+	}
+
+	if _, err := GroupOperationalKey(epochKey[:8], 0x87E1B004E235A130); err == nil {
+		t.Errorf("short epoch key is accepted")
+	}
+}
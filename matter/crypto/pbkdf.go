@@ -0,0 +1,62 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// 3.9. Password-Based Key Derivation Function (PBKDF)
+// PBKDF returns a key of the specified bit length derived from the input and salt
+// using PBKDF2 with HMAC-SHA256 as defined by Crypto_PBKDF().
+func PBKDF(input []byte, salt []byte, iterations int, lengthBits int) ([]byte, error) {
+	if iterations < 1 {
+		return nil, fmt.Errorf("%w PBKDF iterations (%d)", ErrInvalid, iterations)
+	}
+	if lengthBits < 8 || (lengthBits%8) != 0 {
+		return nil, fmt.Errorf("%w PBKDF key length (%d)", ErrInvalid, lengthBits)
+	}
+
+	keyLen := lengthBits / 8
+	prf := hmac.New(sha256.New, input)
+	hashLen := prf.Size()
+	blocks := (keyLen + hashLen - 1) / hashLen
+
+	key := make([]byte, 0, blocks*hashLen)
+	blockIndex := make([]byte, 4)
+	u := make([]byte, hashLen)
+	for block := 1; block <= blocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(blockIndex, uint32(block))
+		prf.Write(blockIndex)
+		u = prf.Sum(u[:0])
+		t := append([]byte{}, u...)
+		for n := 1; n < iterations; n++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for i := range t {
+				t[i] ^= u[i]
+			}
+		}
+		key = append(key, t...)
+	}
+
+	return key[:keyLen], nil
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestPBKDF(t *testing.T) {
+	// RFC 7914 11. Test Vectors for PBKDF2 with HMAC-SHA-256
+	tests := []struct {
+		input      string
+		salt       string
+		iterations int
+		lengthBits int
+		expected   string
+	}{
+		{"passwd", "salt", 1, 512, "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"},
+		{"password", "salt", 1, 256, "120fb6cffcf8b32c43e7225256c4f837a86548c92ccc35480805987cb70be17b"},
+		{"password", "salt", 2, 256, "ae4d0c95af6b46d32d0adff928f06dd02a303f8ef3c251dfd6e2d85a95474c43"},
+		{"password", "salt", 4096, 256, "c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expected[:8], func(t *testing.T) {
+			key, err := PBKDF([]byte(test.input), []byte(test.salt), test.iterations, test.lengthBits)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if hex.EncodeToString(key) != test.expected {
+				t.Errorf("%x != %s", key, test.expected)
+			}
+		})
+	}
+
+	if _, err := PBKDF([]byte("password"), []byte("salt"), 0, 256); err == nil {
+		t.Errorf("zero iterations are accepted")
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/elliptic"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+const (
+	// SPAKE2pGroupSize is the size of a P-256 scalar (CRYPTO_GROUP_SIZE_BYTES).
+	SPAKE2pGroupSize = 32
+	// SPAKE2pWSize is the size of w0s and w1s (CRYPTO_W_SIZE_BYTES).
+	SPAKE2pWSize = SPAKE2pGroupSize + 8
+	// SPAKE2pPointSize is the size of an uncompressed P-256 point (CRYPTO_PUBLIC_KEY_SIZE_BYTES).
+	SPAKE2pPointSize = 65
+	// SPAKE2pVerifierSize is the size of the serialized verifier, w0 followed by L.
+	SPAKE2pVerifierSize = SPAKE2pGroupSize + SPAKE2pPointSize
+)
+
+// 3.10. Password-Authenticated Key Exchange (PAKE)
+// SPAKE2pVerifier represents the verifier of the SPAKE2+ protocol which a commissionee
+// stores instead of its passcode.
+type SPAKE2pVerifier struct {
+	// W0 is the w0 scalar.
+	W0 []byte
+	// L is the w1 scalar multiplied by the generator as an uncompressed point.
+	L []byte
+}
+
+// NewSPAKE2pVerifier returns the verifier derived from the passcode with Crypto_PBKDF.
+// This is computationally expensive, so the result should be computed once and stored.
+func NewSPAKE2pVerifier(passcode uint32, salt []byte, iterations int) (*SPAKE2pVerifier, error) {
+	input := binary.LittleEndian.AppendUint32(nil, passcode)
+	ws, err := PBKDF(input, salt, iterations, 2*SPAKE2pWSize*8)
+	if err != nil {
+		return nil, err
+	}
+
+	order := elliptic.P256().Params().N
+	w0 := new(big.Int).Mod(new(big.Int).SetBytes(ws[:SPAKE2pWSize]), order)
+	w1 := new(big.Int).Mod(new(big.Int).SetBytes(ws[SPAKE2pWSize:]), order)
+
+	key, err := ecdh.P256().NewPrivateKey(w1.FillBytes(make([]byte, SPAKE2pGroupSize)))
+	if err != nil {
+		return nil, fmt.Errorf("%w SPAKE2+ w1 : %w", ErrInvalid, err)
+	}
+
+	return &SPAKE2pVerifier{
+		W0: w0.FillBytes(make([]byte, SPAKE2pGroupSize)),
+		L:  key.PublicKey().Bytes(),
+	}, nil
+}
+
+// NewSPAKE2pVerifierFromBytes returns the verifier from the serialized w0 and L,
+// as provisioned to production devices by a factory tool.
+func NewSPAKE2pVerifierFromBytes(b []byte) (*SPAKE2pVerifier, error) {
+	if len(b) != SPAKE2pVerifierSize {
+		return nil, fmt.Errorf("%w SPAKE2+ verifier length (%d)", ErrInvalid, len(b))
+	}
+	verifier := &SPAKE2pVerifier{
+		W0: bytes.Clone(b[:SPAKE2pGroupSize]),
+		L:  bytes.Clone(b[SPAKE2pGroupSize:]),
+	}
+	if err := verifier.Validate(); err != nil {
+		return nil, err
+	}
+	return verifier, nil
+}
+
+// Validate returns an error if w0 is not a scalar of the group or L is not a point on the curve.
+func (verifier *SPAKE2pVerifier) Validate() error {
+	if len(verifier.W0) != SPAKE2pGroupSize {
+		return fmt.Errorf("%w SPAKE2+ w0 length (%d)", ErrInvalid, len(verifier.W0))
+	}
+	if new(big.Int).SetBytes(verifier.W0).Cmp(elliptic.P256().Params().N) >= 0 {
+		return fmt.Errorf("%w SPAKE2+ w0 (%X)", ErrInvalid, verifier.W0)
+	}
+	if _, err := ecdh.P256().NewPublicKey(verifier.L); err != nil {
+		return fmt.Errorf("%w SPAKE2+ L : %w", ErrInvalid, err)
+	}
+	return nil
+}
+
+// Bytes returns the serialized verifier, w0 followed by L.
+func (verifier *SPAKE2pVerifier) Bytes() []byte {
+	return append(bytes.Clone(verifier.W0), verifier.L...)
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+)
+
+func TestSPAKE2pVerifier(t *testing.T) {
+	// The test verifier of the connectedhomeip SDK for passcode 20202021.
+	const (
+		passcode   = 20202021
+		salt       = "SPAKE2P Key Salt"
+		iterations = 1000
+		expected   = "uWFwqugDNGiEck/po7KHwwMwwqZgN10XuyBajPGuyzUEV/iree4lOrao5GuwnlQ65CJzbeUB49s31EH+NEkg0JVI5MGCQGMMT/SRPFNRODm3wH/MBiehuFc6FJ/NH6Rmzw=="
+	)
+
+	verifier, err := NewSPAKE2pVerifier(passcode, []byte(salt), iterations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if base64.StdEncoding.EncodeToString(verifier.Bytes()) != expected {
+		t.Errorf("%s != %s", base64.StdEncoding.EncodeToString(verifier.Bytes()), expected)
+	}
+
+	b, err := base64.StdEncoding.DecodeString(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	provisioned, err := NewSPAKE2pVerifierFromBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(provisioned.W0, verifier.W0) || !bytes.Equal(provisioned.L, verifier.L) {
+		t.Errorf("%X != %X", provisioned.Bytes(), verifier.Bytes())
+	}
+
+	if _, err := NewSPAKE2pVerifierFromBytes(b[:len(b)-1]); err == nil {
+		t.Errorf("short verifier is accepted")
+	}
+	b[len(b)-1] ^= 0x01
+	if _, err := NewSPAKE2pVerifierFromBytes(b); err == nil {
+		t.Errorf("L which is not on the curve is accepted")
+	}
+}
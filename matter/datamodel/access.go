@@ -0,0 +1,155 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datamodel
+
+import (
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// 6.6.2.1. Privileges
+// Privilege represents an access privilege, and a higher privilege grants the lower privileges.
+type Privilege uint8
+
+const (
+	ViewPrivilege       Privilege = 1
+	ProxyViewPrivilege  Privilege = 2
+	OperatePrivilege    Privilege = 3
+	ManagePrivilege     Privilege = 4
+	AdministerPrivilege Privilege = 5
+)
+
+// String returns the string representation.
+func (privilege Privilege) String() string {
+	switch privilege {
+	case ViewPrivilege:
+		return "View"
+	case ProxyViewPrivilege:
+		return "ProxyView"
+	case OperatePrivilege:
+		return "Operate"
+	case ManagePrivilege:
+		return "Manage"
+	case AdministerPrivilege:
+		return "Administer"
+	}
+	return "Unknown"
+}
+
+// ReadRequest represents an attribute read by a client.
+type ReadRequest struct {
+	// Path is the concrete path of the attribute.
+	Path im.AttributePath
+	// FabricIndex is the accessing fabric, which is NoFabricIndex over PASE sessions.
+	FabricIndex types.FabricIndex
+	// FabricFiltered limits fabric-scoped lists to the elements of the accessing fabric.
+	FabricFiltered bool
+	// Privilege is the privilege granted to the client on the path by the access control.
+	Privilege Privilege
+}
+
+// WriteRequest represents an attribute write by a client.
+type WriteRequest struct {
+	// Path is the concrete path of the attribute.
+	Path im.AttributePath
+	// Data is the TLV encoded value with an anonymous tag.
+	Data []byte
+	// FabricIndex is the accessing fabric, which is NoFabricIndex over PASE sessions.
+	FabricIndex types.FabricIndex
+	// Privilege is the privilege granted to the client on the path by the access control.
+	Privilege Privilege
+	// Timed is true if the write is in a timed interaction.
+	Timed bool
+}
+
+// qualifier is implemented by the clusters which declare the qualities of their attributes, which are
+// the clusters embedding BaseCluster.
+type qualifier interface {
+	attributeQuality(id im.AttributeID) (*Attribute, bool)
+}
+
+// attributeQuality returns the qualities of the specified attribute without its value.
+// The global attributes are readable with the view privilege, and are not writable.
+func (cluster *BaseCluster) attributeQuality(id im.AttributeID) (*Attribute, bool) {
+	if isGlobalAttribute(id) {
+		return NewAttribute(id, nil), true
+	}
+	cluster.RLock()
+	defer cluster.RUnlock()
+	attr, ok := cluster.lookupAttribute(id)
+	if !ok {
+		return nil, false
+	}
+	quality := *attr
+	quality.Value = nil
+	return &quality, true
+}
+
+// lookupAttribute returns the cluster and the qualities of the specified concrete path.
+// Clusters which do not declare the qualities are assumed to have the default qualities.
+func (node *Node) lookupAttribute(path im.AttributePath) (Cluster, *Attribute, error) {
+	ep, ok := node.LookupEndpoint(path.Endpoint)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w : endpoint (%d)", im.StatusUnsupportedEndpoint, path.Endpoint)
+	}
+	cluster, ok := ep.LookupCluster(path.Cluster)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w : cluster (0x%04X)", im.StatusUnsupportedCluster, path.Cluster)
+	}
+	q, ok := cluster.(qualifier)
+	if !ok {
+		return cluster, NewWritableAttribute(path.Attribute, nil), nil
+	}
+	attr, ok := q.attributeQuality(path.Attribute)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w : attribute (0x%04X)", im.StatusUnsupportedAttribute, path.Attribute)
+	}
+	return cluster, attr, nil
+}
+
+// ReadAttribute reads the specified attribute for the client after checking the read privilege.
+func (node *Node) ReadAttribute(req *ReadRequest) (datatype.Value, error) {
+	cluster, attr, err := node.lookupAttribute(req.Path)
+	if err != nil {
+		return nil, err
+	}
+	if req.Privilege < attr.ReadPrivilege {
+		return nil, fmt.Errorf("%w : %s privilege is required to read %s", im.StatusUnsupportedAccess, attr.ReadPrivilege, req.Path)
+	}
+	return ReadFabricAttribute(cluster, req.Path.Attribute, req.FabricIndex, req.FabricFiltered)
+}
+
+// WriteAttribute writes the specified attribute for the client after checking the qualities of the
+// attribute, so clusters are only called with writes which have the required privilege and interaction.
+// The constraint of the attribute is checked before the written value is applied.
+func (node *Node) WriteAttribute(req *WriteRequest) error {
+	cluster, attr, err := node.lookupAttribute(req.Path)
+	if err != nil {
+		return err
+	}
+	if req.Privilege < attr.WritePrivilege {
+		return fmt.Errorf("%w : %s privilege is required to write %s", im.StatusUnsupportedAccess, attr.WritePrivilege, req.Path)
+	}
+	if !attr.Writable {
+		return fmt.Errorf("%w : attribute %s", im.StatusUnsupportedWrite, req.Path)
+	}
+	if attr.TimedWrite && !req.Timed {
+		return fmt.Errorf("%w : attribute %s", im.StatusNeedsTimedInteraction, req.Path)
+	}
+	return WriteFabricAttribute(cluster, req.Path.Attribute, req.Data, req.FabricIndex)
+}
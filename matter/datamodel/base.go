@@ -0,0 +1,301 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datamodel
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// BaseCluster represents a cluster which stores its attributes and dispatches its commands,
+// and provides the global attributes. Cluster implementations embed it and add their
+// attributes and commands.
+type BaseCluster struct {
+	sync.RWMutex
+	id            im.ClusterID
+	revision      datatype.Uint16
+	featureMap    datatype.Bitmap32
+	dataVersion   im.DataVersion
+	attributes    []*Attribute
+	commandIDs    []im.CommandID
+	handlers      map[im.CommandID]CommandHandler
+	generated     []im.CommandID
+	changed       func(im.AttributeID)
+	transactional bool
+}
+
+// NewBaseCluster returns a new base cluster of the specified ID and revision.
+func NewBaseCluster(id im.ClusterID, revision uint16) *BaseCluster {
+	return &BaseCluster{
+		RWMutex:       sync.RWMutex{},
+		id:            id,
+		revision:      datatype.Uint16(revision),
+		featureMap:    0,
+		dataVersion:   0,
+		attributes:    []*Attribute{},
+		commandIDs:    []im.CommandID{},
+		handlers:      map[im.CommandID]CommandHandler{},
+		generated:     []im.CommandID{},
+		changed:       nil,
+		transactional: false,
+	}
+}
+
+// ID returns the cluster ID.
+func (cluster *BaseCluster) ID() im.ClusterID {
+	return cluster.id
+}
+
+// SetFeatureMap sets the supported features.
+func (cluster *BaseCluster) SetFeatureMap(features uint32) {
+	cluster.Lock()
+	cluster.featureMap = datatype.Bitmap32(features)
+	cluster.dataVersion++
+	cluster.Unlock()
+	cluster.notifyChanged(FeatureMapAttributeID)
+}
+
+// FeatureMap returns the supported features.
+func (cluster *BaseCluster) FeatureMap() uint32 {
+	cluster.RLock()
+	defer cluster.RUnlock()
+	return uint32(cluster.featureMap)
+}
+
+// DataVersion returns the data version.
+func (cluster *BaseCluster) DataVersion() im.DataVersion {
+	cluster.RLock()
+	defer cluster.RUnlock()
+	return cluster.dataVersion
+}
+
+// AddAttribute adds the specified attribute.
+func (cluster *BaseCluster) AddAttribute(attr *Attribute) error {
+	cluster.Lock()
+	defer cluster.Unlock()
+	if _, ok := cluster.lookupAttribute(attr.ID); ok || isGlobalAttribute(attr.ID) {
+		return fmt.Errorf("attribute (0x%04X) %w", attr.ID, ErrExists)
+	}
+	if _, ok := attr.Value.(*datatype.List); attr.FabricScoped && !ok {
+		return fmt.Errorf("%w fabric-scoped attribute (0x%04X) : %s is not a list", ErrInvalid, attr.ID, attr.Value.Type())
+	}
+	cluster.attributes = append(cluster.attributes, attr)
+	return nil
+}
+
+// AddCommand adds the specified command handler.
+func (cluster *BaseCluster) AddCommand(id im.CommandID, handler CommandHandler) error {
+	cluster.Lock()
+	defer cluster.Unlock()
+	if _, ok := cluster.handlers[id]; ok {
+		return fmt.Errorf("command (0x%04X) %w", id, ErrExists)
+	}
+	cluster.handlers[id] = handler
+	cluster.commandIDs = append(cluster.commandIDs, id)
+	return nil
+}
+
+// AddGeneratedCommand adds the specified response command to the generated command list.
+func (cluster *BaseCluster) AddGeneratedCommand(id im.CommandID) {
+	cluster.Lock()
+	defer cluster.Unlock()
+	cluster.generated = append(cluster.generated, id)
+}
+
+func isGlobalAttribute(id im.AttributeID) bool {
+	switch id {
+	case GeneratedCommandListAttributeID, AcceptedCommandListAttributeID, AttributeListAttributeID, FeatureMapAttributeID, ClusterRevisionAttributeID:
+		return true
+	}
+	return false
+}
+
+func (cluster *BaseCluster) lookupAttribute(id im.AttributeID) (*Attribute, bool) {
+	for _, attr := range cluster.attributes {
+		if attr.ID == id {
+			return attr, true
+		}
+	}
+	return nil, false
+}
+
+// AttributeIDs returns the IDs of the supported attributes including the global attributes.
+func (cluster *BaseCluster) AttributeIDs() []im.AttributeID {
+	cluster.RLock()
+	defer cluster.RUnlock()
+	return cluster.attributeIDs()
+}
+
+func (cluster *BaseCluster) attributeIDs() []im.AttributeID {
+	ids := make([]im.AttributeID, 0, len(cluster.attributes)+5)
+	for _, attr := range cluster.attributes {
+		ids = append(ids, attr.ID)
+	}
+	return append(ids,
+		GeneratedCommandListAttributeID,
+		AcceptedCommandListAttributeID,
+		AttributeListAttributeID,
+		FeatureMapAttributeID,
+		ClusterRevisionAttributeID)
+}
+
+// CommandIDs returns the IDs of the accepted commands.
+func (cluster *BaseCluster) CommandIDs() []im.CommandID {
+	cluster.RLock()
+	defer cluster.RUnlock()
+	return append([]im.CommandID{}, cluster.commandIDs...)
+}
+
+// ReadAttribute returns the value of the specified attribute.
+func (cluster *BaseCluster) ReadAttribute(id im.AttributeID) (datatype.Value, error) {
+	cluster.RLock()
+	defer cluster.RUnlock()
+	switch id {
+	case GeneratedCommandListAttributeID:
+		return newCommandIDList(cluster.generated), nil
+	case AcceptedCommandListAttributeID:
+		return newCommandIDList(cluster.commandIDs), nil
+	case AttributeListAttributeID:
+		return newAttributeIDList(cluster.attributeIDs()), nil
+	case FeatureMapAttributeID:
+		featureMap := cluster.featureMap
+		return &featureMap, nil
+	case ClusterRevisionAttributeID:
+		revision := cluster.revision
+		return &revision, nil
+	}
+	attr, ok := cluster.lookupAttribute(id)
+	if !ok {
+		return nil, fmt.Errorf("%w : attribute (0x%04X)", im.StatusUnsupportedAttribute, id)
+	}
+	return attr.Value, nil
+}
+
+// SetAttribute replaces the value of the specified attribute, and changes the data version.
+func (cluster *BaseCluster) SetAttribute(id im.AttributeID, v datatype.Value) error {
+	if err := v.Validate(); err != nil {
+		return statusError(err, im.StatusConstraintError)
+	}
+	cluster.Lock()
+	attr, ok := cluster.lookupAttribute(id)
+	if !ok {
+		cluster.Unlock()
+		return fmt.Errorf("%w : attribute (0x%04X)", im.StatusUnsupportedAttribute, id)
+	}
+	if _, ok := v.(*datatype.List); attr.FabricScoped && !ok {
+		cluster.Unlock()
+		return fmt.Errorf("%w : fabric-scoped attribute (0x%04X)", im.StatusInvalidDataType, id)
+	}
+	attr.Value = v
+	cluster.dataVersion++
+	cluster.Unlock()
+	cluster.notifyChanged(id)
+	return nil
+}
+
+// WriteAttribute writes the TLV encoded value into the specified writable attribute.
+// The previous value is kept if the value can not be decoded or does not satisfy the constraint.
+func (cluster *BaseCluster) WriteAttribute(id im.AttributeID, data []byte) error {
+	if err := cluster.writeAttribute(id, data); err != nil {
+		return err
+	}
+	cluster.notifyChanged(id)
+	return nil
+}
+
+func (cluster *BaseCluster) writeAttribute(id im.AttributeID, data []byte) error {
+	cluster.Lock()
+	defer cluster.Unlock()
+	attr, ok := cluster.lookupAttribute(id)
+	if !ok {
+		if isGlobalAttribute(id) {
+			return fmt.Errorf("%w : attribute (0x%04X)", im.StatusUnsupportedWrite, id)
+		}
+		return fmt.Errorf("%w : attribute (0x%04X)", im.StatusUnsupportedAttribute, id)
+	}
+	if !attr.Writable {
+		return fmt.Errorf("%w : attribute (0x%04X)", im.StatusUnsupportedWrite, id)
+	}
+	prev, err := datatype.Encode(attr.Value)
+	if err != nil {
+		return err
+	}
+	if err := datatype.Decode(data, attr.Value); err != nil {
+		if rollbackErr := datatype.Decode(prev, attr.Value); rollbackErr != nil {
+			return rollbackErr
+		}
+		return statusError(err, im.StatusInvalidDataType)
+	}
+	if attr.Constraint != nil {
+		if err := attr.Constraint(attr.Value); err != nil {
+			if rollbackErr := datatype.Decode(prev, attr.Value); rollbackErr != nil {
+				return rollbackErr
+			}
+			return err
+		}
+	}
+	cluster.dataVersion++
+	return nil
+}
+
+// setChangeHandler sets the handler which is called after an attribute is changed.
+func (cluster *BaseCluster) setChangeHandler(h func(im.AttributeID)) {
+	cluster.Lock()
+	defer cluster.Unlock()
+	cluster.changed = h
+}
+
+func (cluster *BaseCluster) notifyChanged(id im.AttributeID) {
+	cluster.RLock()
+	h := cluster.changed
+	cluster.RUnlock()
+	if h != nil {
+		h(id)
+	}
+}
+
+// InvokeCommand invokes the handler of the specified command.
+func (cluster *BaseCluster) InvokeCommand(req *CommandRequest) (*CommandResponse, error) {
+	cluster.RLock()
+	handler, ok := cluster.handlers[req.Path.Command]
+	cluster.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w : command (0x%04X)", im.StatusUnsupportedCommand, req.Path.Command)
+	}
+	return handler(req)
+}
+
+// newAttributeIDList returns a list value of the specified attribute IDs.
+func newAttributeIDList(ids []im.AttributeID) *datatype.List {
+	list := datatype.NewList(func() datatype.Value { return new(datatype.Uint32) })
+	for _, id := range ids {
+		v := datatype.Uint32(id)
+		list.Elements = append(list.Elements, &v)
+	}
+	return list
+}
+
+// newCommandIDList returns a list value of the specified command IDs.
+func newCommandIDList(ids []im.CommandID) *datatype.List {
+	list := datatype.NewList(func() datatype.Value { return new(datatype.Uint32) })
+	for _, id := range ids {
+		v := datatype.Uint32(id)
+		list.Elements = append(list.Elements, &v)
+	}
+	return list
+}
@@ -0,0 +1,202 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datamodel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// 7.13. Global Elements
+const (
+	GeneratedCommandListAttributeID im.AttributeID = 0xFFF8
+	AcceptedCommandListAttributeID  im.AttributeID = 0xFFF9
+	AttributeListAttributeID        im.AttributeID = 0xFFFB
+	FeatureMapAttributeID           im.AttributeID = 0xFFFC
+	ClusterRevisionAttributeID      im.AttributeID = 0xFFFD
+)
+
+// Cluster represents a server cluster on an endpoint.
+// Errors returned by clusters carry an interaction model status which is retrieved with im.StatusOf.
+type Cluster interface {
+	// ID returns the cluster ID.
+	ID() im.ClusterID
+	// DataVersion returns the data version, which changes whenever an attribute changes.
+	DataVersion() im.DataVersion
+	// AttributeIDs returns the IDs of the supported attributes including the global attributes.
+	AttributeIDs() []im.AttributeID
+	// ReadAttribute returns the value of the specified attribute. The value must not be modified.
+	ReadAttribute(id im.AttributeID) (datatype.Value, error)
+	// WriteAttribute writes the TLV encoded value with an anonymous tag into the specified attribute.
+	WriteAttribute(id im.AttributeID, data []byte) error
+	// CommandIDs returns the IDs of the accepted commands.
+	CommandIDs() []im.CommandID
+	// InvokeCommand invokes the specified command, and returns the response command if the command has one.
+	InvokeCommand(req *CommandRequest) (*CommandResponse, error)
+}
+
+// changeNotifier is implemented by the clusters which notify their attribute changes, which are
+// the clusters embedding BaseCluster.
+type changeNotifier interface {
+	setChangeHandler(h func(im.AttributeID))
+}
+
+// Attribute represents an attribute of a cluster.
+type Attribute struct {
+	// ID is the attribute ID.
+	ID im.AttributeID
+	// Value is the current value.
+	Value datatype.Value
+	// Writable is true if the attribute can be written by clients.
+	Writable bool
+	// FabricScoped is true if the attribute is a list of fabric-scoped structures.
+	FabricScoped bool
+	// ReadPrivilege is the privilege required to read the attribute.
+	ReadPrivilege Privilege
+	// WritePrivilege is the privilege required to write the attribute.
+	WritePrivilege Privilege
+	// TimedWrite is true if the attribute can only be written in timed interactions.
+	TimedWrite bool
+	// Constraint is the constraint of the written values, which may be nil.
+	Constraint Constraint
+}
+
+// NewAttribute returns a new read-only attribute.
+func NewAttribute(id im.AttributeID, v datatype.Value) *Attribute {
+	return &Attribute{
+		ID:             id,
+		Value:          v,
+		Writable:       false,
+		FabricScoped:   false,
+		ReadPrivilege:  ViewPrivilege,
+		WritePrivilege: OperatePrivilege,
+		TimedWrite:     false,
+		Constraint:     nil,
+	}
+}
+
+// NewWritableAttribute returns a new writable attribute.
+func NewWritableAttribute(id im.AttributeID, v datatype.Value) *Attribute {
+	return &Attribute{
+		ID:             id,
+		Value:          v,
+		Writable:       true,
+		FabricScoped:   false,
+		ReadPrivilege:  ViewPrivilege,
+		WritePrivilege: OperatePrivilege,
+		TimedWrite:     false,
+		Constraint:     nil,
+	}
+}
+
+// NewFabricScopedAttribute returns a new read-only attribute of a fabric-scoped list.
+func NewFabricScopedAttribute(id im.AttributeID, list *datatype.List) *Attribute {
+	return &Attribute{
+		ID:             id,
+		Value:          list,
+		Writable:       false,
+		FabricScoped:   true,
+		ReadPrivilege:  ViewPrivilege,
+		WritePrivilege: OperatePrivilege,
+		TimedWrite:     false,
+		Constraint:     nil,
+	}
+}
+
+// NewWritableFabricScopedAttribute returns a new writable attribute of a fabric-scoped list,
+// whose writes replace the elements of the accessing fabric.
+func NewWritableFabricScopedAttribute(id im.AttributeID, list *datatype.List) *Attribute {
+	return &Attribute{
+		ID:             id,
+		Value:          list,
+		Writable:       true,
+		FabricScoped:   true,
+		ReadPrivilege:  ViewPrivilege,
+		WritePrivilege: OperatePrivilege,
+		TimedWrite:     false,
+		Constraint:     nil,
+	}
+}
+
+// CommandRequest represents an invoked command.
+type CommandRequest struct {
+	// Path is the path of the command.
+	Path im.CommandPath
+	// Fields is the TLV encoded command fields with an anonymous tag.
+	Fields []byte
+	// FabricIndex is the accessing fabric, which is NoFabricIndex over PASE sessions.
+	FabricIndex types.FabricIndex
+	// SourceNodeID is the node ID of the invoking node, which is unspecified over PASE sessions.
+	SourceNodeID types.NodeID
+	ctx          context.Context
+}
+
+// Context returns the context of the request, which is canceled when the invoking exchange ends.
+// It is context.Background if the request has no context.
+func (req *CommandRequest) Context() context.Context {
+	if req.ctx == nil {
+		return context.Background()
+	}
+	return req.ctx
+}
+
+// WithContext returns a shallow copy of the request with the specified context.
+func (req *CommandRequest) WithContext(ctx context.Context) *CommandRequest {
+	r := *req
+	r.ctx = ctx
+	return &r
+}
+
+// DecodeFields decodes the command fields into the specified value.
+// Malformed fields are reported with StatusInvalidCommand, and out of range fields with StatusConstraintError.
+func (req *CommandRequest) DecodeFields(v datatype.Value) error {
+	if err := datatype.Decode(req.Fields, v); err != nil {
+		return statusError(err, im.StatusInvalidCommand)
+	}
+	return nil
+}
+
+// CommandResponse represents a response command.
+type CommandResponse struct {
+	// Command is the response command ID.
+	Command im.CommandID
+	// Fields is the command fields.
+	Fields datatype.Value
+}
+
+// NewCommandResponse returns a new response command.
+func NewCommandResponse(id im.CommandID, fields datatype.Value) *CommandResponse {
+	return &CommandResponse{
+		Command: id,
+		Fields:  fields,
+	}
+}
+
+// CommandHandler represents a handler of a command.
+type CommandHandler func(req *CommandRequest) (*CommandResponse, error)
+
+// statusError wraps the specified error with StatusConstraintError if it is a range error,
+// and otherwise with the specified status.
+func statusError(err error, status im.Status) error {
+	if errors.Is(err, datatype.ErrOutOfRange) {
+		status = im.StatusConstraintError
+	}
+	return fmt.Errorf("%w : %w", status, err)
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datamodel
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// 7.18.1. Constraints
+// Constraint represents a constraint of attribute values written by clients, which returns an error
+// wrapping StatusConstraintError if the value does not satisfy the constraint. Null values satisfy
+// the constraints of nullable attributes.
+type Constraint func(v datatype.Value) error
+
+// RangeConstraint returns a constraint of integer values from min to max.
+func RangeConstraint(min int64, max int64) Constraint {
+	return func(v datatype.Value) error {
+		elem, err := encodedElement(v)
+		if err != nil || elem.IsNull() {
+			return err
+		}
+		switch {
+		case elem.Type().IsSigned():
+			n, err := elem.Signed()
+			if err != nil {
+				return err
+			}
+			if n < min || max < n {
+				return fmt.Errorf("%w : %d is out of [%d, %d]", im.StatusConstraintError, n, min, max)
+			}
+		case elem.Type().IsUnsigned():
+			n, err := elem.Unsigned()
+			if err != nil {
+				return err
+			}
+			if math.MaxInt64 < n || int64(n) < min || max < int64(n) {
+				return fmt.Errorf("%w : %d is out of [%d, %d]", im.StatusConstraintError, n, min, max)
+			}
+		default:
+			return fmt.Errorf("%w : %s is not an integer", im.StatusConstraintError, v.Type())
+		}
+		return nil
+	}
+}
+
+// LengthConstraint returns a constraint of the lengths of strings in bytes, octet strings and lists from min to max.
+func LengthConstraint(min int, max int) Constraint {
+	return func(v datatype.Value) error {
+		n := 0
+		if list, ok := v.(*datatype.List); ok {
+			n = len(list.Elements)
+		} else {
+			elem, err := encodedElement(v)
+			if err != nil || elem.IsNull() {
+				return err
+			}
+			switch {
+			case elem.Type().IsUTF8String():
+				s, err := elem.String()
+				if err != nil {
+					return err
+				}
+				n = len(s)
+			case elem.Type().IsOctetString():
+				b, err := elem.Bytes()
+				if err != nil {
+					return err
+				}
+				n = len(b)
+			default:
+				return fmt.Errorf("%w : %s has no length", im.StatusConstraintError, v.Type())
+			}
+		}
+		if n < min || max < n {
+			return fmt.Errorf("%w : length %d is out of [%d, %d]", im.StatusConstraintError, n, min, max)
+		}
+		return nil
+	}
+}
+
+// encodedElement returns the TLV element of the value, which is the common form of
+// the integer and string data types including their nullable variants.
+func encodedElement(v datatype.Value) (*tlv.Element, error) {
+	b, err := datatype.Encode(v)
+	if err != nil {
+		return nil, statusError(err, im.StatusConstraintError)
+	}
+	return tlv.NewDecoder(b).Next()
+}
@@ -0,0 +1,426 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datamodel
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+func TestBaseCluster(t *testing.T) {
+	cluster := NewBaseCluster(0x0006, 6)
+	onOff := datatype.Bool(false)
+	onTime := datatype.Uint16(0)
+	if err := cluster.AddAttribute(NewAttribute(0x0000, &onOff)); err != nil {
+		t.Fatal(err)
+	}
+	if err := cluster.AddAttribute(NewWritableAttribute(0x4001, &onTime)); err != nil {
+		t.Fatal(err)
+	}
+	if err := cluster.AddAttribute(NewAttribute(ClusterRevisionAttributeID, &onTime)); !errors.Is(err, ErrExists) {
+		t.Errorf("%v is not %v", err, ErrExists)
+	}
+	if err := cluster.AddCommand(0x00, func(req *CommandRequest) (*CommandResponse, error) { return nil, nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	globals := []struct {
+		id       im.AttributeID
+		expected string
+	}{
+		{ClusterRevisionAttributeID, "0406"},
+		{FeatureMapAttributeID, "0400"},
+		{AttributeListAttributeID, "16" + "0400" + "050140" + "05f8ff" + "05f9ff" + "05fbff" + "05fcff" + "05fdff" + "18"},
+		{AcceptedCommandListAttributeID, "16040018"},
+		{GeneratedCommandListAttributeID, "1618"},
+	}
+	for _, global := range globals {
+		v, err := cluster.ReadAttribute(global.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := datatype.Encode(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(b) != global.expected {
+			t.Errorf("0x%04X : %x != %s", global.id, b, global.expected)
+		}
+	}
+
+	version := cluster.DataVersion()
+	if err := cluster.WriteAttribute(0x4001, []byte{0x05, 0x2c, 0x01}); err != nil {
+		t.Fatal(err)
+	}
+	if onTime != 300 || cluster.DataVersion() == version {
+		t.Errorf("on time (%d) version (%d)", onTime, cluster.DataVersion())
+	}
+
+	writeErrors := []struct {
+		id     im.AttributeID
+		data   []byte
+		status im.Status
+	}{
+		{0x0000, []byte{0x09}, im.StatusUnsupportedWrite},
+		{0x4001, []byte{0x0c, 0x00}, im.StatusInvalidDataType},
+		{0x4001, []byte{0x06, 0x00, 0x00, 0x01, 0x00}, im.StatusConstraintError},
+		{0x9999, []byte{0x09}, im.StatusUnsupportedAttribute},
+		{ClusterRevisionAttributeID, []byte{0x04, 0x01}, im.StatusUnsupportedWrite},
+	}
+	for _, test := range writeErrors {
+		if err := cluster.WriteAttribute(test.id, test.data); im.StatusOf(err) != test.status {
+			t.Errorf("0x%04X : %v is not %s", test.id, err, test.status)
+		}
+	}
+	if onTime != 300 {
+		t.Errorf("on time (%d) is not kept", onTime)
+	}
+
+	req := &CommandRequest{Path: im.NewCommandPath(1, 0x0006, 0x02), Fields: nil, FabricIndex: 0, SourceNodeID: 0}
+	if _, err := cluster.InvokeCommand(req); im.StatusOf(err) != im.StatusUnsupportedCommand {
+		t.Errorf("%v is not %s", err, im.StatusUnsupportedCommand)
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	ep := NewEndpoint(1, DeviceType{ID: 0x0100, Revision: 3})
+	if err := ep.AddCluster(NewBaseCluster(0x0006, 6)); err != nil {
+		t.Fatal(err)
+	}
+	if err := ep.AddCluster(NewBaseCluster(0x0006, 6)); !errors.Is(err, ErrExists) {
+		t.Errorf("%v is not %v", err, ErrExists)
+	}
+	if _, ok := ep.LookupCluster(0x0006); !ok {
+		t.Error("cluster is not found")
+	}
+	if len(ep.Clusters()) != 1 || len(ep.DeviceTypes()) != 1 {
+		t.Errorf("%d clusters, %d device types", len(ep.Clusters()), len(ep.DeviceTypes()))
+	}
+}
+
+func TestEndpointComposition(t *testing.T) {
+	ep := NewEndpoint(1, NewDeviceType(types.OnOffLightDeviceType))
+	if dt := ep.DeviceTypes()[0]; dt.Revision != 3 {
+		t.Errorf("%s revision (%d)", dt.ID, dt.Revision)
+	}
+	if err := ep.AddCluster(NewBaseCluster(0x0006, 6)); err != nil {
+		t.Fatal(err)
+	}
+	if err := ep.ValidateComposition(); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+	for _, id := range []im.ClusterID{0x0003, 0x0004} {
+		if err := ep.AddCluster(NewBaseCluster(id, 4)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ep.ValidateComposition(); err != nil {
+		t.Error(err)
+	}
+
+	vendor := NewEndpoint(2, NewDeviceType(0xFFF10002))
+	if dt := vendor.DeviceTypes()[0]; dt.Revision != 1 {
+		t.Errorf("%s revision (%d)", dt.ID, dt.Revision)
+	}
+	if err := vendor.ValidateComposition(); err != nil {
+		t.Error(err)
+	}
+}
+
+func newTestBinding() datatype.Value {
+	return datatype.NewStruct(
+		datatype.NewFabricSensitiveField(1, new(datatype.Uint64)),
+		datatype.NewField(FabricIndexFieldID, new(datatype.Uint8)))
+}
+
+func TestFabricScopedAttribute(t *testing.T) {
+	const bindingAttributeID im.AttributeID = 0x0000
+	cluster := NewBaseCluster(0x001E, 1)
+	if err := cluster.AddAttribute(NewWritableFabricScopedAttribute(bindingAttributeID, datatype.NewList(newTestBinding))); err != nil {
+		t.Fatal(err)
+	}
+	onOff := datatype.Bool(false)
+	if err := cluster.AddAttribute(&Attribute{ID: 0x0001, Value: &onOff, Writable: false, FabricScoped: true}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+
+	write := func(fabric types.FabricIndex, nodeIDs ...uint64) error {
+		list := datatype.NewList(newTestBinding)
+		for _, nodeID := range nodeIDs {
+			id := datatype.Uint64(nodeID)
+			// The written fabric index is ignored and replaced with the accessing fabric.
+			index := datatype.Uint8(types.MaxFabricIndex)
+			list.Elements = append(list.Elements, datatype.NewStruct(
+				datatype.NewFabricSensitiveField(1, &id),
+				datatype.NewField(FabricIndexFieldID, &index)))
+		}
+		b, err := datatype.Encode(list)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return WriteFabricAttribute(cluster, bindingAttributeID, b, fabric)
+	}
+	read := func(fabric types.FabricIndex, fabricFiltered bool) []datatype.Value {
+		v, err := ReadFabricAttribute(cluster, bindingAttributeID, fabric, fabricFiltered)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v.(*datatype.List).Elements
+	}
+
+	if err := write(1, 0x10, 0x11); err != nil {
+		t.Fatal(err)
+	}
+	if err := write(2, 0x20); err != nil {
+		t.Fatal(err)
+	}
+	if err := write(types.NoFabricIndex, 0x30); !errors.Is(err, im.StatusUnsupportedAccess) {
+		t.Errorf("%v is not %v", err, im.StatusUnsupportedAccess)
+	}
+
+	// Writes replace only the elements of the accessing fabric.
+	if err := write(1, 0x12); err != nil {
+		t.Fatal(err)
+	}
+	elems := read(1, true)
+	if len(elems) != 1 {
+		t.Fatalf("%d elements", len(elems))
+	}
+	if index, ok := FabricIndexOf(elems[0]); !ok || index != 1 {
+		t.Errorf("fabric index (%d) != 1", index)
+	}
+
+	// Fabric-sensitive fields of other fabrics are omitted in reads without the fabric filter.
+	elems = read(1, false)
+	if len(elems) != 2 {
+		t.Fatalf("%d elements", len(elems))
+	}
+	for _, elem := range elems {
+		index, _ := FabricIndexOf(elem)
+		_, ok := elem.(*datatype.Struct).LookupField(1)
+		if ok != (index == 1) {
+			t.Errorf("fabric-sensitive field of fabric (%d) is %t", index, ok)
+		}
+	}
+
+	cluster.RemoveFabric(1)
+	if elems := read(2, false); len(elems) != 1 {
+		t.Errorf("%d elements", len(elems))
+	}
+}
+
+func TestNodeAccess(t *testing.T) {
+	const (
+		levelAttributeID im.AttributeID = 0x0000
+		labelAttributeID im.AttributeID = 0x0001
+		timedAttributeID im.AttributeID = 0x0002
+		adminAttributeID im.AttributeID = 0x0003
+		stateAttributeID im.AttributeID = 0x0004
+	)
+	cluster := NewBaseCluster(0x0008, 1)
+	level := NewWritableAttribute(levelAttributeID, datatype.NewNullable(new(datatype.Uint8)))
+	level.Constraint = RangeConstraint(1, 254)
+	label := NewWritableAttribute(labelAttributeID, new(datatype.String))
+	label.Constraint = LengthConstraint(0, 4)
+	timed := NewWritableAttribute(timedAttributeID, new(datatype.Bool))
+	timed.TimedWrite = true
+	admin := NewWritableAttribute(adminAttributeID, new(datatype.Bool))
+	admin.ReadPrivilege = ManagePrivilege
+	admin.WritePrivilege = AdministerPrivilege
+	for _, attr := range []*Attribute{level, label, timed, admin, NewAttribute(stateAttributeID, new(datatype.Bool))} {
+		if err := cluster.AddAttribute(attr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ep := NewEndpoint(1)
+	if err := ep.AddCluster(cluster); err != nil {
+		t.Fatal(err)
+	}
+	node := NewNode()
+	if err := node.AddEndpoint(ep); err != nil {
+		t.Fatal(err)
+	}
+
+	encode := func(v datatype.Value) []byte {
+		b, err := datatype.Encode(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	u8 := func(n uint8) datatype.Value {
+		v := datatype.Uint8(n)
+		return &v
+	}
+	str := func(s string) datatype.Value {
+		v := datatype.String(s)
+		return &v
+	}
+	on := datatype.Bool(true)
+
+	tests := []struct {
+		path      im.AttributePath
+		value     datatype.Value
+		privilege Privilege
+		timed     bool
+		expected  im.Status
+	}{
+		{im.NewAttributePath(2, 0x0008, levelAttributeID), u8(1), OperatePrivilege, false, im.StatusUnsupportedEndpoint},
+		{im.NewAttributePath(1, 0x0006, levelAttributeID), u8(1), OperatePrivilege, false, im.StatusUnsupportedCluster},
+		{im.NewAttributePath(1, 0x0008, 0x00FF), u8(1), OperatePrivilege, false, im.StatusUnsupportedAttribute},
+		{im.NewAttributePath(1, 0x0008, levelAttributeID), u8(1), ViewPrivilege, false, im.StatusUnsupportedAccess},
+		{im.NewAttributePath(1, 0x0008, levelAttributeID), u8(0), OperatePrivilege, false, im.StatusConstraintError},
+		{im.NewAttributePath(1, 0x0008, levelAttributeID), u8(100), OperatePrivilege, false, im.StatusSuccess},
+		{im.NewAttributePath(1, 0x0008, levelAttributeID), datatype.NewNull(new(datatype.Uint8)), OperatePrivilege, false, im.StatusSuccess},
+		{im.NewAttributePath(1, 0x0008, labelAttributeID), str("Kitchen"), OperatePrivilege, false, im.StatusConstraintError},
+		{im.NewAttributePath(1, 0x0008, labelAttributeID), str("Den"), OperatePrivilege, false, im.StatusSuccess},
+		{im.NewAttributePath(1, 0x0008, timedAttributeID), &on, OperatePrivilege, false, im.StatusNeedsTimedInteraction},
+		{im.NewAttributePath(1, 0x0008, timedAttributeID), &on, OperatePrivilege, true, im.StatusSuccess},
+		{im.NewAttributePath(1, 0x0008, adminAttributeID), &on, ManagePrivilege, false, im.StatusUnsupportedAccess},
+		{im.NewAttributePath(1, 0x0008, adminAttributeID), &on, AdministerPrivilege, false, im.StatusSuccess},
+		{im.NewAttributePath(1, 0x0008, stateAttributeID), &on, AdministerPrivilege, false, im.StatusUnsupportedWrite},
+		{im.NewAttributePath(1, 0x0008, FeatureMapAttributeID), u8(0), AdministerPrivilege, false, im.StatusUnsupportedWrite},
+	}
+	for _, test := range tests {
+		err := node.WriteAttribute(&WriteRequest{
+			Path:        test.path,
+			Data:        encode(test.value),
+			FabricIndex: 1,
+			Privilege:   test.privilege,
+			Timed:       test.timed,
+		})
+		if status := im.StatusOf(err); status != test.expected {
+			t.Errorf("%s : %s != %s (%v)", test.path, status, test.expected, err)
+		}
+	}
+
+	// Values which do not satisfy the constraints are not applied.
+	v, err := node.ReadAttribute(&ReadRequest{Path: im.NewAttributePath(1, 0x0008, labelAttributeID), Privilege: ViewPrivilege})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *v.(*datatype.String) != "Den" {
+		t.Errorf("%s != Den", *v.(*datatype.String))
+	}
+	_, err = node.ReadAttribute(&ReadRequest{Path: im.NewAttributePath(1, 0x0008, adminAttributeID), Privilege: OperatePrivilege})
+	if !errors.Is(err, im.StatusUnsupportedAccess) {
+		t.Errorf("%v is not %v", err, im.StatusUnsupportedAccess)
+	}
+}
+
+func TestWriteAttributes(t *testing.T) {
+	const (
+		minAttributeID im.AttributeID = 0x0000
+		maxAttributeID im.AttributeID = 0x0001
+	)
+	node := NewNode()
+	ep := NewEndpoint(1)
+	changes := 0
+	for _, id := range []im.ClusterID{0x0402, 0x0403} {
+		cluster := NewBaseCluster(id, 1)
+		for _, attrID := range []im.AttributeID{minAttributeID, maxAttributeID} {
+			attr := NewWritableAttribute(attrID, new(datatype.Uint8))
+			attr.Constraint = RangeConstraint(0, 200)
+			if err := cluster.AddAttribute(attr); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := ep.AddCluster(cluster); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := node.AddEndpoint(ep); err != nil {
+		t.Fatal(err)
+	}
+	transactional, _ := ep.LookupCluster(0x0402)
+	transactional.(*BaseCluster).SetTransactional(true)
+	node.AddAttributeListener(func(path im.AttributePath) {
+		changes++
+	})
+
+	write := func(cluster im.ClusterID, attr im.AttributeID, n uint8) *WriteRequest {
+		v := datatype.Uint8(n)
+		b, err := datatype.Encode(&v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &WriteRequest{Path: im.NewAttributePath(1, cluster, attr), Data: b, FabricIndex: 1, Privilege: OperatePrivilege, Timed: false}
+	}
+	read := func(cluster im.ClusterID, attr im.AttributeID) uint8 {
+		v, err := node.ReadAttribute(&ReadRequest{Path: im.NewAttributePath(1, cluster, attr), Privilege: ViewPrivilege})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return uint8(*v.(*datatype.Uint8))
+	}
+	check := func(statuses []im.AttributeStatus, expected ...im.Status) {
+		t.Helper()
+		if len(statuses) != len(expected) {
+			t.Fatalf("statuses (%d) != (%d)", len(statuses), len(expected))
+		}
+		for n, status := range statuses {
+			if status.Status != expected[n] {
+				t.Errorf("%s : %s != %s", status.Path, status.Status, expected[n])
+			}
+		}
+	}
+
+	check(node.WriteAttributes([]*WriteRequest{
+		write(0x0402, minAttributeID, 10),
+		write(0x0402, maxAttributeID, 100),
+	}), im.StatusSuccess, im.StatusSuccess)
+
+	// A failed write rolls the writes to the transactional cluster back, but not the writes to others.
+
+	changes = 0
+	check(node.WriteAttributes([]*WriteRequest{
+		write(0x0402, minAttributeID, 20),
+		write(0x0403, minAttributeID, 30),
+		write(0x0402, maxAttributeID, 201),
+		write(0x0403, maxAttributeID, 201),
+		write(0x0402, minAttributeID, 40),
+	}), im.StatusFailure, im.StatusSuccess, im.StatusConstraintError, im.StatusConstraintError, im.StatusFailure)
+	if min, max := read(0x0402, minAttributeID), read(0x0402, maxAttributeID); min != 10 || max != 100 {
+		t.Errorf("(%d, %d) != (10, 100)", min, max)
+	}
+	if min := read(0x0403, minAttributeID); min != 30 {
+		t.Errorf("%d != 30", min)
+	}
+	if changes != 3 {
+		t.Errorf("changes (%d) != (3)", changes)
+	}
+
+	// A write interaction rejects wildcard paths, and applies the others with WriteAttributes.
+
+	data := func(path im.AttributePath) im.AttributeData {
+		return im.AttributeData{Path: path, DataVersion: 0, Data: write(0x0402, minAttributeID, 50).Data}
+	}
+	check(node.Write(im.WriteRequest{
+		AttributeData: []im.AttributeData{
+			data(im.NewAttributePath(im.WildcardEndpointID, 0x0402, minAttributeID)),
+			data(im.NewAttributePath(1, 0x0402, minAttributeID)),
+			data(im.NewAttributePath(1, 0x0402, maxAttributeID)),
+		},
+		Timed: false,
+	}, 1, func(im.AttributePath) Privilege { return OperatePrivilege }), im.StatusInvalidAction, im.StatusSuccess, im.StatusSuccess)
+	if min, max := read(0x0402, minAttributeID), read(0x0402, maxAttributeID); min != 50 || max != 50 {
+		t.Errorf("(%d, %d) != (50, 50)", min, max)
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package datamodeltest provides utilities for testing clusters.
+package datamodeltest
+
+import (
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// SourceNodeID is the node ID of the client which invokes commands.
+const SourceNodeID types.NodeID = 0x1234
+
+// Invoke encodes the command fields, and invokes the command of the cluster on the specified endpoint
+// for a client on the specified fabric. The test fails if the fields can not be encoded.
+func Invoke(t testing.TB, cluster datamodel.Cluster, endpoint im.EndpointID, id im.CommandID, fabric types.FabricIndex, fields datatype.Value) (*datamodel.CommandResponse, error) {
+	t.Helper()
+	b, err := datatype.Encode(fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cluster.InvokeCommand(&datamodel.CommandRequest{
+		Path:         im.NewCommandPath(endpoint, cluster.ID(), id),
+		Fields:       b,
+		FabricIndex:  fabric,
+		SourceNodeID: SourceNodeID,
+	})
+}
@@ -0,0 +1,182 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datamodel
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// DeviceType represents a device type of an endpoint (DeviceTypeStruct).
+type DeviceType struct {
+	// ID is the device type ID.
+	ID types.DeviceType
+	// Revision is the device type revision.
+	Revision uint16
+}
+
+// NewDeviceType returns a new device type of the specified ID with the latest revision in the device
+// library, or with the revision 1 if the device type is not registered.
+func NewDeviceType(id types.DeviceType) DeviceType {
+	revision := uint16(1)
+	if info, ok := types.LookupDeviceType(id); ok {
+		revision = info.Revision
+	}
+	return DeviceType{
+		ID:       id,
+		Revision: revision,
+	}
+}
+
+// Endpoint represents an endpoint which has device types and server clusters.
+type Endpoint struct {
+	sync.RWMutex
+	id          im.EndpointID
+	parent      im.EndpointID
+	deviceTypes []DeviceType
+	clusters    []Cluster
+	node        *Node
+}
+
+// NewEndpoint returns a new endpoint of the specified ID and device types.
+func NewEndpoint(id im.EndpointID, deviceTypes ...DeviceType) *Endpoint {
+	return &Endpoint{
+		RWMutex:     sync.RWMutex{},
+		id:          id,
+		parent:      RootEndpointID,
+		deviceTypes: deviceTypes,
+		clusters:    []Cluster{},
+		node:        nil,
+	}
+}
+
+// ID returns the endpoint ID.
+func (ep *Endpoint) ID() im.EndpointID {
+	return ep.id
+}
+
+// SetParent sets the parent endpoint in the composition tree, which is the root endpoint by default.
+func (ep *Endpoint) SetParent(id im.EndpointID) {
+	ep.Lock()
+	defer ep.Unlock()
+	ep.parent = id
+}
+
+// Parent returns the parent endpoint in the composition tree.
+func (ep *Endpoint) Parent() im.EndpointID {
+	ep.RLock()
+	defer ep.RUnlock()
+	return ep.parent
+}
+
+// Node returns the node which the endpoint is added to.
+func (ep *Endpoint) Node() (*Node, bool) {
+	ep.RLock()
+	defer ep.RUnlock()
+	return ep.node, ep.node != nil
+}
+
+// EmitEvent emits an event of the specified cluster on the endpoint to the event handler of the node.
+// Events are dropped if the endpoint is not added to a node.
+func (ep *Endpoint) EmitEvent(cluster im.ClusterID, event im.EventID, priority EventPriority, data datatype.Value) {
+	node, ok := ep.Node()
+	if !ok {
+		return
+	}
+	node.EmitEvent(&Event{
+		Path:     im.NewEventPath(ep.id, cluster, event),
+		Priority: priority,
+		Data:     data,
+	})
+}
+
+func (ep *Endpoint) setNode(node *Node) {
+	ep.Lock()
+	defer ep.Unlock()
+	ep.node = node
+}
+
+// DeviceTypes returns the device types.
+func (ep *Endpoint) DeviceTypes() []DeviceType {
+	ep.RLock()
+	defer ep.RUnlock()
+	return append([]DeviceType{}, ep.deviceTypes...)
+}
+
+// AddCluster adds the specified cluster.
+func (ep *Endpoint) AddCluster(cluster Cluster) error {
+	ep.Lock()
+	defer ep.Unlock()
+	for _, c := range ep.clusters {
+		if c.ID() == cluster.ID() {
+			return fmt.Errorf("cluster (0x%04X) on endpoint (%d) %w", cluster.ID(), ep.id, ErrExists)
+		}
+	}
+	ep.clusters = append(ep.clusters, cluster)
+	if notifier, ok := cluster.(changeNotifier); ok {
+		clusterID := cluster.ID()
+		notifier.setChangeHandler(func(id im.AttributeID) {
+			if node, ok := ep.Node(); ok {
+				node.notifyAttributeChanged(im.NewAttributePath(ep.id, clusterID, id))
+			}
+		})
+	}
+	return nil
+}
+
+// LookupCluster returns the cluster of the specified ID.
+func (ep *Endpoint) LookupCluster(id im.ClusterID) (Cluster, bool) {
+	ep.RLock()
+	defer ep.RUnlock()
+	for _, cluster := range ep.clusters {
+		if cluster.ID() == id {
+			return cluster, true
+		}
+	}
+	return nil, false
+}
+
+// Clusters returns the clusters in the order in which they were added.
+func (ep *Endpoint) Clusters() []Cluster {
+	ep.RLock()
+	defer ep.RUnlock()
+	return append([]Cluster{}, ep.clusters...)
+}
+
+// ValidateComposition checks that the endpoint has the server clusters which are required by the
+// registered device types. Device types which are not registered are not checked.
+func (ep *Endpoint) ValidateComposition() error {
+	for _, dt := range ep.DeviceTypes() {
+		info, ok := types.LookupDeviceType(dt.ID)
+		if !ok {
+			continue
+		}
+		missing := []string{}
+		for _, id := range info.RequiredServerClusters {
+			if _, ok := ep.LookupCluster(im.ClusterID(id)); !ok {
+				missing = append(missing, fmt.Sprintf("0x%04X", id))
+			}
+		}
+		if 0 < len(missing) {
+			return fmt.Errorf("%w endpoint (%d) : %s requires clusters %s", ErrInvalid, ep.id, dt.ID, strings.Join(missing, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datamodel
+
+import (
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// 7.19.2.4. Event Priority
+// EventPriority represents an event priority.
+type EventPriority uint8
+
+const (
+	DebugPriority    EventPriority = 0
+	InfoPriority     EventPriority = 1
+	CriticalPriority EventPriority = 2
+)
+
+// String returns the string representation.
+func (priority EventPriority) String() string {
+	switch priority {
+	case DebugPriority:
+		return "Debug"
+	case InfoPriority:
+		return "Info"
+	case CriticalPriority:
+		return "Critical"
+	}
+	return "Unknown"
+}
+
+// Event represents an event emitted by a cluster.
+type Event struct {
+	// Path is the path of the event.
+	Path im.EventPath
+	// Priority is the priority of the event.
+	Priority EventPriority
+	// Data is the event fields.
+	Data datatype.Value
+}
+
+// EventHandler represents a handler which is called with the events emitted on a node.
+type EventHandler func(ev *Event)
@@ -0,0 +1,221 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datamodel
+
+import (
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// Fabric-Scoped Quality
+// FabricIndexFieldID is the ID of the global FabricIndex field of fabric-scoped structures.
+const FabricIndexFieldID uint8 = 0xFE
+
+// fabricScoper is implemented by the clusters which have fabric-scoped attributes, which are
+// the clusters embedding BaseCluster.
+type fabricScoper interface {
+	IsFabricScoped(id im.AttributeID) bool
+	writeFabricAttribute(id im.AttributeID, data []byte, fabric types.FabricIndex) error
+}
+
+// fabricRemover is implemented by the clusters which have data of fabrics.
+type fabricRemover interface {
+	RemoveFabric(fabric types.FabricIndex)
+}
+
+// FabricIndexOf returns the fabric index of the specified fabric-scoped structure.
+func FabricIndexOf(v datatype.Value) (types.FabricIndex, bool) {
+	st, ok := v.(*datatype.Struct)
+	if !ok {
+		return types.NoFabricIndex, false
+	}
+	field, ok := st.LookupField(FabricIndexFieldID)
+	if !ok {
+		return types.NoFabricIndex, false
+	}
+	index, ok := field.Value.(*datatype.Uint8)
+	if !ok {
+		return types.NoFabricIndex, false
+	}
+	return types.FabricIndex(*index), true
+}
+
+// setFabricIndex sets the fabric index of the specified fabric-scoped structure, which ignores
+// the fabric index written by clients.
+func setFabricIndex(v datatype.Value, fabric types.FabricIndex) error {
+	st, ok := v.(*datatype.Struct)
+	if !ok {
+		return fmt.Errorf("%w : %s is not a fabric-scoped struct", im.StatusInvalidDataType, v.Type())
+	}
+	index := datatype.Uint8(fabric)
+	if field, ok := st.LookupField(FabricIndexFieldID); ok {
+		field.Value = &index
+		field.Present = true
+		return nil
+	}
+	st.Fields = append(st.Fields, datatype.NewField(FabricIndexFieldID, &index))
+	return nil
+}
+
+// filterFabricScopedList returns the elements of the accessing fabric if the read is fabric-filtered, and
+// otherwise all elements whose fabric-sensitive fields are omitted for the other fabrics.
+func filterFabricScopedList(list *datatype.List, fabric types.FabricIndex, fabricFiltered bool) *datatype.List {
+	filtered := datatype.NewList(list.New)
+	for _, elem := range list.Elements {
+		if index, ok := FabricIndexOf(elem); ok && fabric.IsValid() && index == fabric {
+			filtered.Elements = append(filtered.Elements, elem)
+			continue
+		}
+		if fabricFiltered {
+			continue
+		}
+		st, ok := elem.(*datatype.Struct)
+		if !ok {
+			continue
+		}
+		omitted := datatype.NewStruct()
+		for _, field := range st.Fields {
+			if !field.FabricSensitive {
+				omitted.Fields = append(omitted.Fields, field)
+			}
+		}
+		filtered.Elements = append(filtered.Elements, omitted)
+	}
+	return filtered
+}
+
+// ReadFabricAttribute returns the value of the specified attribute read by the accessing fabric.
+// Fabric-scoped lists only have the elements of the accessing fabric for fabric-filtered reads, and
+// the fabric-sensitive fields of other fabrics are omitted otherwise. Other attributes are read as is.
+func ReadFabricAttribute(cluster Cluster, id im.AttributeID, fabric types.FabricIndex, fabricFiltered bool) (datatype.Value, error) {
+	v, err := cluster.ReadAttribute(id)
+	if err != nil {
+		return nil, err
+	}
+	scoper, ok := cluster.(fabricScoper)
+	if !ok || !scoper.IsFabricScoped(id) {
+		return v, nil
+	}
+	list, ok := v.(*datatype.List)
+	if !ok {
+		return v, nil
+	}
+	return filterFabricScopedList(list, fabric, fabricFiltered), nil
+}
+
+// WriteFabricAttribute writes the TLV encoded value into the specified attribute by the accessing fabric.
+// Writes of fabric-scoped lists replace only the elements of the accessing fabric, and the elements are
+// tagged with the accessing fabric. Other attributes are written as is.
+func WriteFabricAttribute(cluster Cluster, id im.AttributeID, data []byte, fabric types.FabricIndex) error {
+	scoper, ok := cluster.(fabricScoper)
+	if !ok || !scoper.IsFabricScoped(id) {
+		return cluster.WriteAttribute(id, data)
+	}
+	return scoper.writeFabricAttribute(id, data, fabric)
+}
+
+// RemoveFabric removes the data of the specified fabric in the clusters on the node, which is called
+// when the fabric is removed from the node.
+func RemoveFabric(node *Node, fabric types.FabricIndex) {
+	for _, ep := range node.Endpoints() {
+		for _, cluster := range ep.Clusters() {
+			if remover, ok := cluster.(fabricRemover); ok {
+				remover.RemoveFabric(fabric)
+			}
+		}
+	}
+}
+
+// IsFabricScoped returns true if the specified attribute is a fabric-scoped list.
+func (cluster *BaseCluster) IsFabricScoped(id im.AttributeID) bool {
+	cluster.RLock()
+	defer cluster.RUnlock()
+	attr, ok := cluster.lookupAttribute(id)
+	return ok && attr.FabricScoped
+}
+
+func (cluster *BaseCluster) writeFabricAttribute(id im.AttributeID, data []byte, fabric types.FabricIndex) error {
+	if !fabric.IsValid() {
+		return fmt.Errorf("%w : no accessing fabric", im.StatusUnsupportedAccess)
+	}
+	cluster.Lock()
+	attr, ok := cluster.lookupAttribute(id)
+	if !ok || !attr.Writable {
+		cluster.Unlock()
+		return fmt.Errorf("%w : attribute (0x%04X)", im.StatusUnsupportedWrite, id)
+	}
+	current := attr.Value.(*datatype.List)
+	written := datatype.NewList(current.New)
+	if err := datatype.Decode(data, written); err != nil {
+		cluster.Unlock()
+		return statusError(err, im.StatusInvalidDataType)
+	}
+	// The elements are tagged before the constraint, so it can check them against the accessing fabric.
+	for _, elem := range written.Elements {
+		if err := setFabricIndex(elem, fabric); err != nil {
+			cluster.Unlock()
+			return err
+		}
+	}
+	if attr.Constraint != nil {
+		if err := attr.Constraint(written); err != nil {
+			cluster.Unlock()
+			return err
+		}
+	}
+	elems := []datatype.Value{}
+	for _, elem := range current.Elements {
+		if index, ok := FabricIndexOf(elem); !ok || index != fabric {
+			elems = append(elems, elem)
+		}
+	}
+	current.Elements = append(elems, written.Elements...)
+	cluster.dataVersion++
+	cluster.Unlock()
+	cluster.notifyChanged(id)
+	return nil
+}
+
+// RemoveFabric removes the elements of the specified fabric from the fabric-scoped lists.
+func (cluster *BaseCluster) RemoveFabric(fabric types.FabricIndex) {
+	cluster.Lock()
+	changed := []im.AttributeID{}
+	for _, attr := range cluster.attributes {
+		if !attr.FabricScoped {
+			continue
+		}
+		list := attr.Value.(*datatype.List)
+		elems := []datatype.Value{}
+		for _, elem := range list.Elements {
+			if index, ok := FabricIndexOf(elem); !ok || index != fabric {
+				elems = append(elems, elem)
+			}
+		}
+		if len(elems) != len(list.Elements) {
+			list.Elements = elems
+			changed = append(changed, attr.ID)
+		}
+	}
+	if 0 < len(changed) {
+		cluster.dataVersion++
+	}
+	cluster.Unlock()
+	for _, id := range changed {
+		cluster.notifyChanged(id)
+	}
+}
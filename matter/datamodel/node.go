@@ -0,0 +1,225 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datamodel
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+const (
+	// RootEndpointID is the ID of the root endpoint.
+	RootEndpointID im.EndpointID = 0
+)
+
+// NodeListener represents a listener which is called after an endpoint is added to or removed from a node.
+type NodeListener func(ep *Endpoint, added bool)
+
+// AttributeListener represents a listener which is called after an attribute on a node is changed.
+// Attributes which are computed whenever they are read are not notified.
+type AttributeListener func(path im.AttributePath)
+
+// Node represents the data model of a node, whose endpoints can be added and removed at runtime.
+type Node struct {
+	sync.RWMutex
+	writing       sync.Mutex
+	endpoints     map[im.EndpointID]*Endpoint
+	nextID        im.EndpointID
+	listeners     []NodeListener
+	attrListeners []AttributeListener
+	eventHandler  EventHandler
+}
+
+// NewNode returns a new node without endpoints.
+func NewNode() *Node {
+	return &Node{
+		RWMutex:       sync.RWMutex{},
+		writing:       sync.Mutex{},
+		endpoints:     map[im.EndpointID]*Endpoint{},
+		nextID:        RootEndpointID + 1,
+		listeners:     []NodeListener{},
+		attrListeners: []AttributeListener{},
+		eventHandler:  nil,
+	}
+}
+
+// AddListener adds the specified listener of endpoint changes.
+func (node *Node) AddListener(l NodeListener) {
+	node.Lock()
+	defer node.Unlock()
+	node.listeners = append(node.listeners, l)
+}
+
+// AddAttributeListener adds the specified listener of attribute changes.
+func (node *Node) AddAttributeListener(l AttributeListener) {
+	node.Lock()
+	defer node.Unlock()
+	node.attrListeners = append(node.attrListeners, l)
+}
+
+func (node *Node) notifyAttributeChanged(path im.AttributePath) {
+	node.RLock()
+	listeners := append([]AttributeListener{}, node.attrListeners...)
+	node.RUnlock()
+	for _, l := range listeners {
+		l(path)
+	}
+}
+
+// SetEventHandler sets the handler of the events emitted on the node.
+func (node *Node) SetEventHandler(h EventHandler) {
+	node.Lock()
+	defer node.Unlock()
+	node.eventHandler = h
+}
+
+// EmitEvent passes the specified event to the event handler.
+func (node *Node) EmitEvent(ev *Event) {
+	node.RLock()
+	h := node.eventHandler
+	node.RUnlock()
+	if h != nil {
+		h(ev)
+	}
+}
+
+// NextEndpointID returns an endpoint ID for a dynamic endpoint. IDs are not reused
+// after endpoints are removed, so clients do not confuse a new endpoint with a removed one.
+func (node *Node) NextEndpointID() (im.EndpointID, error) {
+	node.Lock()
+	defer node.Unlock()
+	for node.nextID < im.WildcardEndpointID {
+		id := node.nextID
+		node.nextID++
+		if _, ok := node.endpoints[id]; !ok {
+			return id, nil
+		}
+	}
+	return 0, fmt.Errorf("%w : no endpoint ID", im.StatusResourceExhausted)
+}
+
+// AddEndpoint adds the specified endpoint.
+func (node *Node) AddEndpoint(ep *Endpoint) error {
+	node.Lock()
+	if _, ok := node.endpoints[ep.ID()]; ok {
+		node.Unlock()
+		return fmt.Errorf("endpoint (%d) %w", ep.ID(), ErrExists)
+	}
+	if ep.ID() == im.WildcardEndpointID {
+		node.Unlock()
+		return fmt.Errorf("%w endpoint (%d)", ErrInvalid, ep.ID())
+	}
+	node.endpoints[ep.ID()] = ep
+	if node.nextID <= ep.ID() {
+		node.nextID = ep.ID() + 1
+	}
+	listeners := append([]NodeListener{}, node.listeners...)
+	node.Unlock()
+
+	ep.setNode(node)
+	for _, l := range listeners {
+		l(ep, true)
+	}
+	return nil
+}
+
+// RemoveEndpoint removes the specified endpoint and its descendants in the composition tree.
+func (node *Node) RemoveEndpoint(id im.EndpointID) error {
+	node.Lock()
+	if _, ok := node.endpoints[id]; !ok {
+		node.Unlock()
+		return fmt.Errorf("endpoint (%d) is %w", id, ErrNotFound)
+	}
+	removed := []*Endpoint{}
+	for _, childID := range append(node.descendants(id), id) {
+		ep := node.endpoints[childID]
+		delete(node.endpoints, childID)
+		removed = append(removed, ep)
+	}
+	listeners := append([]NodeListener{}, node.listeners...)
+	node.Unlock()
+
+	for _, ep := range removed {
+		ep.setNode(nil)
+		for _, l := range listeners {
+			l(ep, false)
+		}
+	}
+	return nil
+}
+
+// LookupEndpoint returns the endpoint of the specified ID.
+func (node *Node) LookupEndpoint(id im.EndpointID) (*Endpoint, bool) {
+	node.RLock()
+	defer node.RUnlock()
+	ep, ok := node.endpoints[id]
+	return ep, ok
+}
+
+// Endpoints returns the endpoints in ascending order of the IDs.
+func (node *Node) Endpoints() []*Endpoint {
+	node.RLock()
+	defer node.RUnlock()
+	eps := make([]*Endpoint, 0, len(node.endpoints))
+	for _, ep := range node.endpoints {
+		eps = append(eps, ep)
+	}
+	sort.Slice(eps, func(i, j int) bool { return eps[i].ID() < eps[j].ID() })
+	return eps
+}
+
+// PartsList returns the endpoints which are the parts of the specified endpoint in ascending order.
+// The root endpoint has all other endpoints as the parts, and other endpoints have all their
+// descendants in the composition tree.
+func (node *Node) PartsList(id im.EndpointID) []im.EndpointID {
+	node.RLock()
+	defer node.RUnlock()
+	parts := []im.EndpointID{}
+	if id == RootEndpointID {
+		for childID := range node.endpoints {
+			if childID != RootEndpointID {
+				parts = append(parts, childID)
+			}
+		}
+	} else {
+		parts = node.descendants(id)
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i] < parts[j] })
+	return parts
+}
+
+// descendants returns the descendants of the specified endpoint, which are visited only once
+// even if the parents form a cycle.
+func (node *Node) descendants(id im.EndpointID) []im.EndpointID {
+	ids := []im.EndpointID{}
+	visited := map[im.EndpointID]bool{id: true}
+	queue := []im.EndpointID{id}
+	for 0 < len(queue) {
+		parent := queue[0]
+		queue = queue[1:]
+		for childID, ep := range node.endpoints {
+			if childID == RootEndpointID || visited[childID] || ep.Parent() != parent {
+				continue
+			}
+			visited[childID] = true
+			ids = append(ids, childID)
+			queue = append(queue, childID)
+		}
+	}
+	return ids
+}
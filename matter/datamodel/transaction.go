@@ -0,0 +1,191 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datamodel
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// Transactional is implemented by the clusters which declare whether the attribute writes of a write
+// request are applied to them atomically, which BaseCluster implements.
+type Transactional interface {
+	// IsTransactional returns true if the writes to the cluster are applied atomically.
+	IsTransactional() bool
+}
+
+// SetTransactional sets whether the writes of a write request to the cluster are applied atomically,
+// which is set by clusters whose attributes are only consistent together, such as a pair of limits.
+func (cluster *BaseCluster) SetTransactional(v bool) {
+	cluster.Lock()
+	defer cluster.Unlock()
+	cluster.transactional = v
+}
+
+// IsTransactional returns true if the writes of a write request to the cluster are applied atomically.
+func (cluster *BaseCluster) IsTransactional() bool {
+	cluster.RLock()
+	defer cluster.RUnlock()
+	return cluster.transactional
+}
+
+// clusterKey represents a cluster on an endpoint.
+type clusterKey struct {
+	endpoint im.EndpointID
+	cluster  im.ClusterID
+}
+
+// WriteAttributes writes the attributes of a write request in order like WriteAttribute, and returns
+// the statuses of the paths in the same order (AttributeStatusIB). The writes to a transactional cluster
+// are applied atomically: if a write fails, the writes already applied to the cluster are rolled back by
+// writing their previous values, the failed path has its status, and the other paths to the cluster have
+// StatusFailure. The writes to other clusters are applied independently. Write requests are applied
+// one at a time, so a transaction does not interleave with the others.
+func (node *Node) WriteAttributes(reqs []*WriteRequest) []im.AttributeStatus {
+	node.writing.Lock()
+	defer node.writing.Unlock()
+
+	statuses := make([]im.AttributeStatus, len(reqs))
+	groups := map[clusterKey][]int{}
+	keys := []clusterKey{}
+	for n, req := range reqs {
+		statuses[n] = im.AttributeStatus{Path: req.Path, Status: im.StatusSuccess}
+		key := clusterKey{endpoint: req.Path.Endpoint, cluster: req.Path.Cluster}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], n)
+	}
+
+	for _, key := range keys {
+		indexes := groups[key]
+		if !node.isTransactional(key) {
+			for _, n := range indexes {
+				statuses[n].Status = im.StatusOf(node.WriteAttribute(reqs[n]))
+			}
+			continue
+		}
+		failed, err := node.writeTransaction(reqs, indexes)
+		if err == nil {
+			continue
+		}
+		for _, n := range indexes {
+			statuses[n].Status = im.StatusFailure
+		}
+		statuses[failed].Status = im.StatusOf(err)
+	}
+	return statuses
+}
+
+// PrivilegeFunc represents a function which returns the privilege granted to the client on the
+// specified path by the access control.
+type PrivilegeFunc func(path im.AttributePath) Privilege
+
+// Write handles the write interaction of the client on the specified fabric, and returns the statuses
+// of the written paths (WriteResponseMessage). The writes are applied by WriteAttributes, and wildcard
+// paths are rejected with StatusInvalidAction.
+func (node *Node) Write(req im.WriteRequest, fabric types.FabricIndex, privilege PrivilegeFunc) []im.AttributeStatus {
+	statuses := make([]im.AttributeStatus, len(req.AttributeData))
+	reqs := []*WriteRequest{}
+	indexes := []int{}
+	for n, data := range req.AttributeData {
+		statuses[n] = im.AttributeStatus{Path: data.Path, Status: im.StatusInvalidAction}
+		if data.Path.IsWildcard() {
+			continue
+		}
+		reqs = append(reqs, &WriteRequest{
+			Path:        data.Path,
+			Data:        data.Data,
+			FabricIndex: fabric,
+			Privilege:   privilege(data.Path),
+			Timed:       req.Timed,
+		})
+		indexes = append(indexes, n)
+	}
+	for n, status := range node.WriteAttributes(reqs) {
+		statuses[indexes[n]] = status
+	}
+	return statuses
+}
+
+// isTransactional returns true if the specified cluster is transactional.
+func (node *Node) isTransactional(key clusterKey) bool {
+	ep, ok := node.LookupEndpoint(key.endpoint)
+	if !ok {
+		return false
+	}
+	cluster, ok := ep.LookupCluster(key.cluster)
+	if !ok {
+		return false
+	}
+	t, ok := cluster.(Transactional)
+	return ok && t.IsTransactional()
+}
+
+// writeTransaction writes the requests of the specified indexes to a cluster, and rolls the applied
+// writes back if a write fails. The index of the failed request is returned with the error.
+func (node *Node) writeTransaction(reqs []*WriteRequest, indexes []int) (int, error) {
+	applied := []*WriteRequest{}
+	for _, n := range indexes {
+		req := reqs[n]
+		prev, err := node.previousValue(req)
+		if err == nil {
+			err = node.WriteAttribute(req)
+		}
+		if err != nil {
+			if rollbackErr := node.rollback(applied); rollbackErr != nil {
+				err = errors.Join(err, rollbackErr)
+			}
+			return n, err
+		}
+		applied = append(applied, &WriteRequest{
+			Path:        req.Path,
+			Data:        prev,
+			FabricIndex: req.FabricIndex,
+			Privilege:   req.Privilege,
+			Timed:       req.Timed,
+		})
+	}
+	return -1, nil
+}
+
+// previousValue returns the TLV encoded value of the attribute which the write request replaces.
+// Fabric-scoped lists only have the elements of the accessing fabric, which are replaced by the write.
+func (node *Node) previousValue(req *WriteRequest) ([]byte, error) {
+	cluster, _, err := node.lookupAttribute(req.Path)
+	if err != nil {
+		return nil, err
+	}
+	v, err := ReadFabricAttribute(cluster, req.Path.Attribute, req.FabricIndex, true)
+	if err != nil {
+		return nil, err
+	}
+	return datatype.Encode(v)
+}
+
+// rollback writes the previous values of the applied writes in reverse order.
+func (node *Node) rollback(applied []*WriteRequest) error {
+	var errs error
+	for n := len(applied) - 1; 0 <= n; n-- {
+		if err := node.WriteAttribute(applied[n]); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("rollback %s : %w", applied[n].Path, err))
+		}
+	}
+	return errs
+}
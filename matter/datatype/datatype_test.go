@@ -0,0 +1,253 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datatype
+
+import (
+	"encoding/hex"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func newUint8(v uint8) *Uint8 {
+	u := Uint8(v)
+	return &u
+}
+
+func newString(v string) *String {
+	s := String(v)
+	return &s
+}
+
+func TestValueEncoding(t *testing.T) {
+	temperature := Temperature(-2050)
+	percent := Percent(PercentMax)
+	epoch := EpochS(0x12345678)
+	enum := Enum16(0x0102)
+	bitmap := Bitmap32(0x80000001)
+	b := Bool(true)
+	i64 := Int64(math.MinInt64 + 1)
+	octets := OctetString{0x01, 0x02}
+
+	tests := []struct {
+		name     string
+		value    Value
+		expected string
+		newValue func() Value
+	}{
+		{"bool", &b, "09", func() Value { return new(Bool) }},
+		{"uint8", newUint8(42), "042a", func() Value { return new(Uint8) }},
+		{"int64", &i64, "030100000000000080", func() Value { return new(Int64) }},
+		{"enum16", &enum, "050201", func() Value { return new(Enum16) }},
+		{"map32", &bitmap, "0601000080", func() Value { return new(Bitmap32) }},
+		{"percent", &percent, "0464", func() Value { return new(Percent) }},
+		{"temperature", &temperature, "01fef7", func() Value { return new(Temperature) }},
+		{"epoch-s", &epoch, "0678563412", func() Value { return new(EpochS) }},
+		{"string", newString("abc"), "0c03616263", func() Value { return new(String) }},
+		{"octstr", &octets, "10020102", func() Value { return new(OctetString) }},
+		{"nullable", NewNull(new(Uint8)), "14", func() Value { return NewNullable(new(Uint8)) }},
+		{"list", NewList(func() Value { return new(Uint8) }, newUint8(1), newUint8(2)), "1604010402" + "18", func() Value { return NewList(func() Value { return new(Uint8) }) }},
+		{
+			"struct",
+			NewStruct(NewField(0, newUint8(1)), NewOptionalField(1, new(Uint8)), NewField(2, newString("a"))),
+			"15240001" + "2c020161" + "18",
+			func() Value {
+				return NewStruct(NewField(0, new(Uint8)), NewOptionalField(1, new(Uint8)), NewField(2, new(String)))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := Encode(test.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hex.EncodeToString(b) != test.expected {
+				t.Errorf("%x != %s", b, test.expected)
+			}
+			v := test.newValue()
+			if err := Decode(b, v); err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := Encode(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hex.EncodeToString(decoded) != test.expected {
+				t.Errorf("%x != %s", decoded, test.expected)
+			}
+		})
+	}
+}
+
+func TestValueRange(t *testing.T) {
+	percent := Percent(PercentMax + 1)
+	percent100ths := Percent100ths(Percent100thsMax + 1)
+	temperature := Temperature(TemperatureMin - 1)
+
+	tests := []struct {
+		name  string
+		value Value
+	}{
+		{"percent", &percent},
+		{"percent100ths", &percent100ths},
+		{"temperature", &temperature},
+		{"nullable uint8", NewNullable(newUint8(math.MaxUint8))},
+		{"list", NewList(func() Value { return new(Percent) }, &percent)},
+		{"struct", NewStruct(NewField(0, &percent))},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Encode(test.value); !errors.Is(err, ErrOutOfRange) {
+				t.Errorf("%v is not %v", err, ErrOutOfRange)
+			}
+		})
+	}
+
+	decodeTests := []struct {
+		name    string
+		encoded string
+		value   Value
+		err     error
+	}{
+		{"uint8 width", "05ff01", new(Uint8), ErrOutOfRange},
+		{"int8 width", "018000", new(Int8), ErrOutOfRange},
+		{"percent", "0465", new(Percent), ErrOutOfRange},
+		{"nullable uint8", "04ff", NewNullable(new(Uint8)), ErrOutOfRange},
+		{"nullable int16", "010080", NewNullable(new(Int16)), ErrOutOfRange},
+		{"signed as unsigned", "0001", new(Uint8), ErrInvalid},
+		{"null as uint8", "14", new(Uint8), ErrInvalid},
+		{"missing field", "1518", NewStruct(NewField(0, new(Uint8))), ErrInvalid},
+		{"duplicated field", "1524000124000218", NewStruct(NewField(0, new(Uint8))), ErrInvalid},
+		{"list element", "16046518", NewList(func() Value { return new(Percent) }), ErrOutOfRange},
+		{"trailing bytes", "042a042a", new(Uint8), ErrInvalid},
+	}
+	for _, test := range decodeTests {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := hex.DecodeString(test.encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := Decode(b, test.value); !errors.Is(err, test.err) {
+				t.Errorf("%v is not %v", err, test.err)
+			}
+		})
+	}
+}
+
+func TestStructUnknownFields(t *testing.T) {
+	// The structure has an unknown nested list in field 5 and an unknown field 3.
+	b, err := hex.DecodeString("15240001" + "3605040118" + "240302" + "18")
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := NewStruct(NewField(0, new(Uint8)), NewOptionalField(1, new(Uint8)))
+	if err := Decode(b, st); err != nil {
+		t.Fatal(err)
+	}
+	field, _ := st.LookupField(1)
+	if field.Present {
+		t.Errorf("field (%d) is present", field.ID)
+	}
+}
+
+func TestEpochTime(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 34, 56, 789000000, time.UTC)
+
+	s, err := NewEpochS(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.Time().Equal(now.Truncate(time.Second)) {
+		t.Errorf("%s != %s", s.Time(), now)
+	}
+
+	us, err := NewEpochUs(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !us.Time().Equal(now) {
+		t.Errorf("%s != %s", us.Time(), now)
+	}
+
+	if _, err := NewEpochS(Epoch.Add(-time.Second)); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("%v is not %v", err, ErrOutOfRange)
+	}
+}
+
+func TestTemperature(t *testing.T) {
+	v, err := NewTemperatureFromCelsius(21.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != 2150 || v.Celsius() != 21.5 {
+		t.Errorf("%d (%f) != 2150", v, v.Celsius())
+	}
+	if _, err := NewTemperatureFromCelsius(-273.16); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("%v is not %v", err, ErrOutOfRange)
+	}
+}
+
+func TestOptionalNullable(t *testing.T) {
+	newStruct := func(v *Optional[*Nullable[*Uint8]]) *Struct {
+		return NewStruct(NewField(0, v))
+	}
+
+	tests := []struct {
+		name     string
+		value    *Optional[*Nullable[*Uint8]]
+		expected string
+	}{
+		{"unset", NewUnset(NewNullable(new(Uint8))), "1518"},
+		{"null", NewOptional(NewNull(new(Uint8))), "15340018"},
+		{"value", NewOptional(NewNullable(newUint8(5))), "1524000518"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := Encode(newStruct(test.value))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hex.EncodeToString(b) != test.expected {
+				t.Errorf("%x != %s", b, test.expected)
+			}
+
+			v := NewOptional(NewNullable(new(Uint8)))
+			if err := Decode(b, newStruct(v)); err != nil {
+				t.Fatal(err)
+			}
+			nullable, present := v.Get()
+			if present != test.value.Present {
+				t.Fatalf("present (%t) != (%t)", present, test.value.Present)
+			}
+			if !present {
+				return
+			}
+			u, ok := nullable.Get()
+			if ok != !test.value.Value.Null {
+				t.Fatalf("null (%t) != (%t)", !ok, test.value.Value.Null)
+			}
+			if ok && *u != *test.value.Value.Value {
+				t.Errorf("%d != %d", *u, *test.value.Value.Value)
+			}
+		})
+	}
+
+	if _, err := Encode(NewUnset(new(Uint8))); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datatype
+
+import (
+	"math"
+
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+// 7.18.2.1. Bitmap
+// 7.18.2.2. Enumeration
+// The valid enumeration values and bits are defined by each cluster, so only the widths are checked here.
+
+// Enum8 represents an 8-bit enumeration (enum8).
+type Enum8 uint8
+
+// Type returns the data type.
+func (v *Enum8) Type() Type {
+	return Enum8Type
+}
+
+// Validate returns nil since every value is in range.
+func (v *Enum8) Validate() error {
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *Enum8) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	enc.PutUnsigned(tag, uint64(*v))
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *Enum8) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	u, err := decodeUnsigned(Enum8Type, elem, math.MaxUint8)
+	if err != nil {
+		return err
+	}
+	*v = Enum8(u)
+	return v.Validate()
+}
+
+func (v *Enum8) isNullValue() bool {
+	return *v == math.MaxUint8
+}
+
+// Enum16 represents a 16-bit enumeration (enum16).
+type Enum16 uint16
+
+// Type returns the data type.
+func (v *Enum16) Type() Type {
+	return Enum16Type
+}
+
+// Validate returns nil since every value is in range.
+func (v *Enum16) Validate() error {
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *Enum16) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	enc.PutUnsigned(tag, uint64(*v))
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *Enum16) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	u, err := decodeUnsigned(Enum16Type, elem, math.MaxUint16)
+	if err != nil {
+		return err
+	}
+	*v = Enum16(u)
+	return v.Validate()
+}
+
+func (v *Enum16) isNullValue() bool {
+	return *v == math.MaxUint16
+}
+
+// Bitmap8 represents a 8-bit bitmap (map8).
+type Bitmap8 uint8
+
+// Type returns the data type.
+func (v *Bitmap8) Type() Type {
+	return Bitmap8Type
+}
+
+// Validate returns nil since every value is in range.
+func (v *Bitmap8) Validate() error {
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *Bitmap8) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	enc.PutUnsigned(tag, uint64(*v))
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *Bitmap8) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	u, err := decodeUnsigned(Bitmap8Type, elem, math.MaxUint8)
+	if err != nil {
+		return err
+	}
+	*v = Bitmap8(u)
+	return v.Validate()
+}
+
+// Has returns true if all the specified bits are set.
+func (v *Bitmap8) Has(bits Bitmap8) bool {
+	return (*v & bits) == bits
+}
+
+// Bitmap16 represents a 16-bit bitmap (map16).
+type Bitmap16 uint16
+
+// Type returns the data type.
+func (v *Bitmap16) Type() Type {
+	return Bitmap16Type
+}
+
+// Validate returns nil since every value is in range.
+func (v *Bitmap16) Validate() error {
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *Bitmap16) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	enc.PutUnsigned(tag, uint64(*v))
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *Bitmap16) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	u, err := decodeUnsigned(Bitmap16Type, elem, math.MaxUint16)
+	if err != nil {
+		return err
+	}
+	*v = Bitmap16(u)
+	return v.Validate()
+}
+
+// Has returns true if all the specified bits are set.
+func (v *Bitmap16) Has(bits Bitmap16) bool {
+	return (*v & bits) == bits
+}
+
+// Bitmap32 represents a 32-bit bitmap (map32).
+type Bitmap32 uint32
+
+// Type returns the data type.
+func (v *Bitmap32) Type() Type {
+	return Bitmap32Type
+}
+
+// Validate returns nil since every value is in range.
+func (v *Bitmap32) Validate() error {
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *Bitmap32) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	enc.PutUnsigned(tag, uint64(*v))
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *Bitmap32) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	u, err := decodeUnsigned(Bitmap32Type, elem, math.MaxUint32)
+	if err != nil {
+		return err
+	}
+	*v = Bitmap32(u)
+	return v.Validate()
+}
+
+// Has returns true if all the specified bits are set.
+func (v *Bitmap32) Has(bits Bitmap32) bool {
+	return (*v & bits) == bits
+}
+
+// Bitmap64 represents a 64-bit bitmap (map64).
+type Bitmap64 uint64
+
+// Type returns the data type.
+func (v *Bitmap64) Type() Type {
+	return Bitmap64Type
+}
+
+// Validate returns nil since every value is in range.
+func (v *Bitmap64) Validate() error {
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *Bitmap64) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	enc.PutUnsigned(tag, uint64(*v))
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *Bitmap64) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	u, err := decodeUnsigned(Bitmap64Type, elem, math.MaxUint64)
+	if err != nil {
+		return err
+	}
+	*v = Bitmap64(u)
+	return v.Validate()
+}
+
+// Has returns true if all the specified bits are set.
+func (v *Bitmap64) Has(bits Bitmap64) bool {
+	return (*v & bits) == bits
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datatype
+
+import (
+	"math"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+// Epoch is the origin of the epoch time data types, which is 2000-01-01 00:00:00 UTC.
+var Epoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// 7.18.2.11. Epoch Time in Microseconds
+// 7.18.2.12. Epoch Time in Seconds
+
+// EpochS represents a time in seconds since the epoch (epoch-s).
+type EpochS uint32
+
+// Type returns the data type.
+func (v *EpochS) Type() Type {
+	return EpochSType
+}
+
+// Validate returns nil since every value is in range.
+func (v *EpochS) Validate() error {
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *EpochS) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	enc.PutUnsigned(tag, uint64(*v))
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *EpochS) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	u, err := decodeUnsigned(EpochSType, elem, math.MaxUint32)
+	if err != nil {
+		return err
+	}
+	*v = EpochS(u)
+	return v.Validate()
+}
+
+func (v *EpochS) isNullValue() bool {
+	return *v == math.MaxUint32
+}
+
+// EpochUs represents a time in microseconds since the epoch (epoch-us).
+type EpochUs uint64
+
+// Type returns the data type.
+func (v *EpochUs) Type() Type {
+	return EpochUsType
+}
+
+// Validate returns nil since every value is in range.
+func (v *EpochUs) Validate() error {
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *EpochUs) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	enc.PutUnsigned(tag, uint64(*v))
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *EpochUs) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	u, err := decodeUnsigned(EpochUsType, elem, math.MaxUint64)
+	if err != nil {
+		return err
+	}
+	*v = EpochUs(u)
+	return v.Validate()
+}
+
+func (v *EpochUs) isNullValue() bool {
+	return *v == math.MaxUint64
+}
+
+// NewEpochS returns the epoch time in seconds of the specified time.
+func NewEpochS(t time.Time) (EpochS, error) {
+	if t.Before(Epoch) {
+		return 0, rangeError(EpochSType, t)
+	}
+	d := t.Unix() - Epoch.Unix()
+	if math.MaxUint32 < d {
+		return 0, rangeError(EpochSType, t)
+	}
+	return EpochS(d), nil
+}
+
+// Time returns the time of the epoch time in seconds.
+func (v *EpochS) Time() time.Time {
+	return time.Unix(Epoch.Unix()+int64(*v), 0).UTC()
+}
+
+// NewEpochUs returns the epoch time in microseconds of the specified time.
+func NewEpochUs(t time.Time) (EpochUs, error) {
+	if t.Before(Epoch) {
+		return 0, rangeError(EpochUsType, t)
+	}
+	return EpochUs(t.UnixMicro() - Epoch.UnixMicro()), nil
+}
+
+// Time returns the time of the epoch time in microseconds.
+func (v *EpochUs) Time() time.Time {
+	return time.UnixMicro(Epoch.UnixMicro() + int64(*v)).UTC()
+}
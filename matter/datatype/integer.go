@@ -0,0 +1,344 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datatype
+
+import (
+	"math"
+
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+// 7.18.2.3. Unsigned Integer
+// 7.18.2.4. Signed Integer
+// The nullable variants reserve the maximum unsigned value and the minimum signed value
+// to represent null, so these values are out of range of the nullable variants.
+
+// Uint8 represents a 8-bit unsigned integer (uint8).
+type Uint8 uint8
+
+// Type returns the data type.
+func (v *Uint8) Type() Type {
+	return Uint8Type
+}
+
+// Validate returns nil since every value is in range.
+func (v *Uint8) Validate() error {
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *Uint8) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	enc.PutUnsigned(tag, uint64(*v))
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *Uint8) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	u, err := decodeUnsigned(Uint8Type, elem, math.MaxUint8)
+	if err != nil {
+		return err
+	}
+	*v = Uint8(u)
+	return v.Validate()
+}
+
+func (v *Uint8) isNullValue() bool {
+	return *v == math.MaxUint8
+}
+
+// Uint16 represents a 16-bit unsigned integer (uint16).
+type Uint16 uint16
+
+// Type returns the data type.
+func (v *Uint16) Type() Type {
+	return Uint16Type
+}
+
+// Validate returns nil since every value is in range.
+func (v *Uint16) Validate() error {
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *Uint16) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	enc.PutUnsigned(tag, uint64(*v))
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *Uint16) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	u, err := decodeUnsigned(Uint16Type, elem, math.MaxUint16)
+	if err != nil {
+		return err
+	}
+	*v = Uint16(u)
+	return v.Validate()
+}
+
+func (v *Uint16) isNullValue() bool {
+	return *v == math.MaxUint16
+}
+
+// Uint32 represents a 32-bit unsigned integer (uint32).
+type Uint32 uint32
+
+// Type returns the data type.
+func (v *Uint32) Type() Type {
+	return Uint32Type
+}
+
+// Validate returns nil since every value is in range.
+func (v *Uint32) Validate() error {
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *Uint32) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	enc.PutUnsigned(tag, uint64(*v))
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *Uint32) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	u, err := decodeUnsigned(Uint32Type, elem, math.MaxUint32)
+	if err != nil {
+		return err
+	}
+	*v = Uint32(u)
+	return v.Validate()
+}
+
+func (v *Uint32) isNullValue() bool {
+	return *v == math.MaxUint32
+}
+
+// Uint64 represents a 64-bit unsigned integer (uint64).
+type Uint64 uint64
+
+// Type returns the data type.
+func (v *Uint64) Type() Type {
+	return Uint64Type
+}
+
+// Validate returns nil since every value is in range.
+func (v *Uint64) Validate() error {
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *Uint64) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	enc.PutUnsigned(tag, uint64(*v))
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *Uint64) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	u, err := decodeUnsigned(Uint64Type, elem, math.MaxUint64)
+	if err != nil {
+		return err
+	}
+	*v = Uint64(u)
+	return v.Validate()
+}
+
+func (v *Uint64) isNullValue() bool {
+	return *v == math.MaxUint64
+}
+
+// Int8 represents a 8-bit signed integer (int8).
+type Int8 int8
+
+// Type returns the data type.
+func (v *Int8) Type() Type {
+	return Int8Type
+}
+
+// Validate returns nil since every value is in range.
+func (v *Int8) Validate() error {
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *Int8) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	enc.PutSigned(tag, int64(*v))
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *Int8) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	n, err := decodeSigned(Int8Type, elem, math.MinInt8, math.MaxInt8)
+	if err != nil {
+		return err
+	}
+	*v = Int8(n)
+	return v.Validate()
+}
+
+func (v *Int8) isNullValue() bool {
+	return *v == math.MinInt8
+}
+
+// Int16 represents a 16-bit signed integer (int16).
+type Int16 int16
+
+// Type returns the data type.
+func (v *Int16) Type() Type {
+	return Int16Type
+}
+
+// Validate returns nil since every value is in range.
+func (v *Int16) Validate() error {
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *Int16) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	enc.PutSigned(tag, int64(*v))
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *Int16) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	n, err := decodeSigned(Int16Type, elem, math.MinInt16, math.MaxInt16)
+	if err != nil {
+		return err
+	}
+	*v = Int16(n)
+	return v.Validate()
+}
+
+func (v *Int16) isNullValue() bool {
+	return *v == math.MinInt16
+}
+
+// Int32 represents a 32-bit signed integer (int32).
+type Int32 int32
+
+// Type returns the data type.
+func (v *Int32) Type() Type {
+	return Int32Type
+}
+
+// Validate returns nil since every value is in range.
+func (v *Int32) Validate() error {
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *Int32) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	enc.PutSigned(tag, int64(*v))
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *Int32) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	n, err := decodeSigned(Int32Type, elem, math.MinInt32, math.MaxInt32)
+	if err != nil {
+		return err
+	}
+	*v = Int32(n)
+	return v.Validate()
+}
+
+func (v *Int32) isNullValue() bool {
+	return *v == math.MinInt32
+}
+
+// Int64 represents a 64-bit signed integer (int64).
+type Int64 int64
+
+// Type returns the data type.
+func (v *Int64) Type() Type {
+	return Int64Type
+}
+
+// Validate returns nil since every value is in range.
+func (v *Int64) Validate() error {
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *Int64) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	enc.PutSigned(tag, int64(*v))
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *Int64) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	n, err := decodeSigned(Int64Type, elem, math.MinInt64, math.MaxInt64)
+	if err != nil {
+		return err
+	}
+	*v = Int64(n)
+	return v.Validate()
+}
+
+func (v *Int64) isNullValue() bool {
+	return *v == math.MinInt64
+}
+
+// decodeUnsigned returns the unsigned integer value of the element if it is not greater than max.
+func decodeUnsigned(typ Type, elem *tlv.Element, max uint64) (uint64, error) {
+	if !elem.Type().IsUnsigned() {
+		return 0, typeError(typ, elem)
+	}
+	v, err := elem.Unsigned()
+	if err != nil {
+		return 0, err
+	}
+	if max < v {
+		return 0, rangeError(typ, v)
+	}
+	return v, nil
+}
+
+// decodeSigned returns the signed integer value of the element if it is between min and max.
+func decodeSigned(typ Type, elem *tlv.Element, min int64, max int64) (int64, error) {
+	if !elem.Type().IsSigned() {
+		return 0, typeError(typ, elem)
+	}
+	v, err := elem.Signed()
+	if err != nil {
+		return 0, err
+	}
+	if v < min || max < v {
+		return 0, rangeError(typ, v)
+	}
+	return v, nil
+}
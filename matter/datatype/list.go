@@ -0,0 +1,100 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datatype
+
+import (
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+// 7.18.3. Collection Data Types
+// List represents a list of values of the same data type (list), which is encoded as a TLV array.
+type List struct {
+	// New returns a new element value to decode each element into.
+	New func() Value
+	// Elements are the values of the list.
+	Elements []Value
+}
+
+// NewList returns a new list of the specified elements whose values are created by the specified function.
+func NewList(newElem func() Value, elems ...Value) *List {
+	return &List{
+		New:      newElem,
+		Elements: elems,
+	}
+}
+
+// Type returns the data type.
+func (list *List) Type() Type {
+	return ListType
+}
+
+// Validate returns an error if an element is out of range or has a different data type.
+func (list *List) Validate() error {
+	elemType := UnknownType
+	if list.New != nil {
+		elemType = list.New().Type()
+	}
+	for n, elem := range list.Elements {
+		if elemType != UnknownType && elem.Type() != elemType {
+			return fmt.Errorf("%w list element (%d) : %s is not %s", ErrInvalid, n, elem.Type(), elemType)
+		}
+		if err := elem.Validate(); err != nil {
+			return fmt.Errorf("list element (%d) : %w", n, err)
+		}
+	}
+	return nil
+}
+
+// Encode writes the list with the specified tag.
+func (list *List) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if err := list.Validate(); err != nil {
+		return err
+	}
+	enc.StartArray(tag)
+	for _, elem := range list.Elements {
+		if err := elem.Encode(enc, tlv.NewAnonymousTag()); err != nil {
+			return err
+		}
+	}
+	enc.EndContainer()
+	return nil
+}
+
+// Decode reads the list from the specified element and its members.
+func (list *List) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	if elem.Type() != tlv.Array {
+		return typeError(ListType, elem)
+	}
+	if list.New == nil {
+		return fmt.Errorf("%w list : no element type", ErrInvalid)
+	}
+	list.Elements = []Value{}
+	for {
+		member, err := dec.Next()
+		if err != nil {
+			return err
+		}
+		if member.IsEndOfContainer() {
+			return nil
+		}
+		v := list.New()
+		if err := v.Decode(dec, member); err != nil {
+			return fmt.Errorf("list element (%d) : %w", len(list.Elements), err)
+		}
+		list.Elements = append(list.Elements, v)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datatype
+
+import (
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+// 7.18.1. Nullable
+// Nullable represents a nullable variant of a data type, whose null is encoded as a TLV null.
+// The integer data types reserve a value for null, which is out of range of their nullable variants.
+type Nullable[T Value] struct {
+	// Value is the value, which is also used to decode a non-null value into.
+	Value T
+	// Null is true if the value is null.
+	Null bool
+}
+
+// NewNullable returns a new nullable value which is not null.
+func NewNullable[T Value](v T) *Nullable[T] {
+	return &Nullable[T]{
+		Value: v,
+		Null:  false,
+	}
+}
+
+// NewNull returns a new nullable value which is null, and whose non-null values are decoded into the specified value.
+func NewNull[T Value](v T) *Nullable[T] {
+	return &Nullable[T]{
+		Value: v,
+		Null:  true,
+	}
+}
+
+// Get returns the value and true if the value is not null.
+func (v *Nullable[T]) Get() (T, bool) {
+	return v.Value, !v.Null
+}
+
+// Set sets the specified non-null value.
+func (v *Nullable[T]) Set(value T) {
+	v.Value = value
+	v.Null = false
+}
+
+// SetNull sets the value to null.
+func (v *Nullable[T]) SetNull() {
+	v.Null = true
+}
+
+// Type returns the data type of the value.
+func (v *Nullable[T]) Type() Type {
+	return v.Value.Type()
+}
+
+// Validate returns an error if the value is not null and out of the range of the nullable variant.
+func (v *Nullable[T]) Validate() error {
+	if v.Null {
+		return nil
+	}
+	if err := v.Value.Validate(); err != nil {
+		return err
+	}
+	if nv, ok := any(v.Value).(nullableValue); ok && nv.isNullValue() {
+		return fmt.Errorf("nullable %s : null value is %w", v.Value.Type(), ErrOutOfRange)
+	}
+	return nil
+}
+
+// Encode writes the value or a null with the specified tag.
+func (v *Nullable[T]) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if v.Null {
+		enc.PutNull(tag)
+		return nil
+	}
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	return v.Value.Encode(enc, tag)
+}
+
+// Decode reads the value or a null from the specified element.
+func (v *Nullable[T]) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	if elem.IsNull() {
+		v.Null = true
+		return nil
+	}
+	v.Null = false
+	if err := v.Value.Decode(dec, elem); err != nil {
+		return err
+	}
+	return v.Validate()
+}
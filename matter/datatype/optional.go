@@ -0,0 +1,107 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datatype
+
+import (
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+// optionalValue is implemented by the values which may be omitted from their containers.
+type optionalValue interface {
+	isPresent() bool
+	setPresent(bool)
+}
+
+// Optional represents a value which may be omitted. An optional nullable value distinguishes
+// an omitted value, which leaves the stored value unchanged on writes, from a null value.
+type Optional[T Value] struct {
+	// Value is the value, which is also used to decode a present value into.
+	Value T
+	// Present is true if the value is set or was decoded.
+	Present bool
+}
+
+// NewOptional returns a new optional value which is present.
+func NewOptional[T Value](v T) *Optional[T] {
+	return &Optional[T]{
+		Value:   v,
+		Present: true,
+	}
+}
+
+// NewUnset returns a new optional value which is not present, and whose present values are decoded into the specified value.
+func NewUnset[T Value](v T) *Optional[T] {
+	return &Optional[T]{
+		Value:   v,
+		Present: false,
+	}
+}
+
+// Get returns the value and true if the value is present.
+func (v *Optional[T]) Get() (T, bool) {
+	return v.Value, v.Present
+}
+
+// Set sets the specified value.
+func (v *Optional[T]) Set(value T) {
+	v.Value = value
+	v.Present = true
+}
+
+// Unset omits the value.
+func (v *Optional[T]) Unset() {
+	v.Present = false
+}
+
+// Type returns the data type of the value.
+func (v *Optional[T]) Type() Type {
+	return v.Value.Type()
+}
+
+// Validate returns an error if the value is present and out of range.
+func (v *Optional[T]) Validate() error {
+	if !v.Present {
+		return nil
+	}
+	return v.Value.Validate()
+}
+
+// Encode writes the value with the specified tag. An error is returned if the value is not present,
+// since omitted values can only be encoded as fields of structures.
+func (v *Optional[T]) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if !v.Present {
+		return fmt.Errorf("%w optional %s : not present", ErrInvalid, v.Value.Type())
+	}
+	return v.Value.Encode(enc, tag)
+}
+
+// Decode reads the value from the specified element and marks it present.
+func (v *Optional[T]) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	if err := v.Value.Decode(dec, elem); err != nil {
+		return err
+	}
+	v.Present = true
+	return nil
+}
+
+func (v *Optional[T]) isPresent() bool {
+	return v.Present
+}
+
+func (v *Optional[T]) setPresent(present bool) {
+	v.Present = present
+}
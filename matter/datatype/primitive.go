@@ -0,0 +1,118 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datatype
+
+import (
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+// 7.18.2.5. Boolean
+// Bool represents a boolean (bool).
+type Bool bool
+
+// Type returns the data type.
+func (v *Bool) Type() Type {
+	return BoolType
+}
+
+// Validate returns nil since every value is in range.
+func (v *Bool) Validate() error {
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *Bool) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	enc.PutBool(tag, bool(*v))
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *Bool) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	if !elem.Type().IsBoolean() {
+		return typeError(BoolType, elem)
+	}
+	b, err := elem.Bool()
+	if err != nil {
+		return err
+	}
+	*v = Bool(b)
+	return nil
+}
+
+// 7.18.2.9. Character String
+// String represents a UTF-8 character string (string).
+type String string
+
+// Type returns the data type.
+func (v *String) Type() Type {
+	return StringType
+}
+
+// Validate returns nil since the maximum length is defined by each attribute.
+func (v *String) Validate() error {
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *String) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	enc.PutUTF8String(tag, string(*v))
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *String) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	if !elem.Type().IsUTF8String() {
+		return typeError(StringType, elem)
+	}
+	s, err := elem.String()
+	if err != nil {
+		return err
+	}
+	*v = String(s)
+	return nil
+}
+
+// 7.18.2.10. Octet String
+// OctetString represents an octet string (octstr).
+type OctetString []byte
+
+// Type returns the data type.
+func (v *OctetString) Type() Type {
+	return OctetStringType
+}
+
+// Validate returns nil since the maximum length is defined by each attribute.
+func (v *OctetString) Validate() error {
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *OctetString) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	enc.PutOctetString(tag, *v)
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *OctetString) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	if !elem.Type().IsOctetString() {
+		return typeError(OctetStringType, elem)
+	}
+	b, err := elem.Bytes()
+	if err != nil {
+		return err
+	}
+	*v = append(OctetString{}, b...)
+	return nil
+}
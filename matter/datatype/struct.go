@@ -0,0 +1,193 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datatype
+
+import (
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+// Field represents a field of a structure, which is encoded with a context tag of the field ID.
+type Field struct {
+	// ID is the field ID.
+	ID uint8
+	// Value is the field value.
+	Value Value
+	// Optional is true if the field may be omitted.
+	Optional bool
+	// Present is true if the field is encoded, or was decoded. Mandatory fields are always encoded.
+	Present bool
+	// FabricSensitive is true if the field of a fabric-scoped structure is omitted for other fabrics.
+	FabricSensitive bool
+}
+
+// NewField returns a new mandatory field.
+func NewField(id uint8, v Value) *Field {
+	return &Field{
+		ID:              id,
+		Value:           v,
+		Optional:        false,
+		Present:         true,
+		FabricSensitive: false,
+	}
+}
+
+// NewOptionalField returns a new optional field which is not present until it is set or decoded.
+func NewOptionalField(id uint8, v Value) *Field {
+	return &Field{
+		ID:              id,
+		Value:           v,
+		Optional:        true,
+		Present:         false,
+		FabricSensitive: false,
+	}
+}
+
+// NewFabricSensitiveField returns a new mandatory field which is only read by the fabric of the structure.
+func NewFabricSensitiveField(id uint8, v Value) *Field {
+	return &Field{
+		ID:              id,
+		Value:           v,
+		Optional:        false,
+		Present:         true,
+		FabricSensitive: true,
+	}
+}
+
+// isOptional returns true if the field may be omitted, which is also the case for optional values.
+func (field *Field) isOptional() bool {
+	if _, ok := field.Value.(optionalValue); ok {
+		return true
+	}
+	return field.Optional
+}
+
+// isPresent returns true if the field is encoded.
+func (field *Field) isPresent() bool {
+	if ov, ok := field.Value.(optionalValue); ok {
+		return ov.isPresent()
+	}
+	return !field.Optional || field.Present
+}
+
+// 7.18.3. Collection Data Types
+// Struct represents a structure of fields (struct).
+type Struct struct {
+	// Fields are the fields of the structure.
+	Fields []*Field
+}
+
+// NewStruct returns a new structure of the specified fields.
+func NewStruct(fields ...*Field) *Struct {
+	return &Struct{
+		Fields: fields,
+	}
+}
+
+// LookupField returns the field of the specified ID.
+func (st *Struct) LookupField(id uint8) (*Field, bool) {
+	for _, field := range st.Fields {
+		if field.ID == id {
+			return field, true
+		}
+	}
+	return nil, false
+}
+
+// Type returns the data type.
+func (st *Struct) Type() Type {
+	return StructType
+}
+
+// Validate returns an error if a present field is out of range.
+func (st *Struct) Validate() error {
+	for _, field := range st.Fields {
+		if !field.isPresent() {
+			continue
+		}
+		if err := field.Value.Validate(); err != nil {
+			return fmt.Errorf("struct field (%d) : %w", field.ID, err)
+		}
+	}
+	return nil
+}
+
+// Encode writes the structure with the specified tag. Optional fields and optional values which are
+// not present are omitted.
+func (st *Struct) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if err := st.Validate(); err != nil {
+		return err
+	}
+	enc.StartStructure(tag)
+	for _, field := range st.Fields {
+		if !field.isPresent() {
+			continue
+		}
+		if err := field.Value.Encode(enc, tlv.NewContextTag(field.ID)); err != nil {
+			return err
+		}
+	}
+	enc.EndContainer()
+	return nil
+}
+
+// Decode reads the structure from the specified element and its members.
+// Unknown fields are skipped, and an error is returned if a mandatory field is missing.
+func (st *Struct) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	if elem.Type() != tlv.Structure {
+		return typeError(StructType, elem)
+	}
+	decoded := map[uint8]bool{}
+	for {
+		member, err := dec.Next()
+		if err != nil {
+			return err
+		}
+		if member.IsEndOfContainer() {
+			break
+		}
+		var field *Field
+		ok := member.Tag().IsContext()
+		if ok {
+			field, ok = st.LookupField(uint8(member.Tag().Number()))
+		}
+		if !ok {
+			if member.IsContainer() {
+				if err := dec.Skip(); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if decoded[field.ID] {
+			return fmt.Errorf("%w struct field (%d) : duplicated", ErrInvalid, field.ID)
+		}
+		if err := field.Value.Decode(dec, member); err != nil {
+			return fmt.Errorf("struct field (%d) : %w", field.ID, err)
+		}
+		decoded[field.ID] = true
+	}
+	for _, field := range st.Fields {
+		field.Present = decoded[field.ID]
+		if ov, ok := field.Value.(optionalValue); ok {
+			ov.setPresent(field.Present)
+		}
+		if !field.isOptional() && !field.Present {
+			return fmt.Errorf("%w struct field (%d) : missing", ErrInvalid, field.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datatype
+
+// 7.18. Data Types
+// Type represents a data type of the data model.
+type Type int
+
+const (
+	UnknownType Type = iota
+	BoolType
+	Uint8Type
+	Uint16Type
+	Uint32Type
+	Uint64Type
+	Int8Type
+	Int16Type
+	Int32Type
+	Int64Type
+	Enum8Type
+	Enum16Type
+	Bitmap8Type
+	Bitmap16Type
+	Bitmap32Type
+	Bitmap64Type
+	PercentType
+	Percent100thsType
+	TemperatureType
+	EpochSType
+	EpochUsType
+	StringType
+	OctetStringType
+	ListType
+	StructType
+)
+
+// String returns the name of the data type in the specification.
+func (typ Type) String() string {
+	switch typ {
+	case BoolType:
+		return "bool"
+	case Uint8Type:
+		return "uint8"
+	case Uint16Type:
+		return "uint16"
+	case Uint32Type:
+		return "uint32"
+	case Uint64Type:
+		return "uint64"
+	case Int8Type:
+		return "int8"
+	case Int16Type:
+		return "int16"
+	case Int32Type:
+		return "int32"
+	case Int64Type:
+		return "int64"
+	case Enum8Type:
+		return "enum8"
+	case Enum16Type:
+		return "enum16"
+	case Bitmap8Type:
+		return "map8"
+	case Bitmap16Type:
+		return "map16"
+	case Bitmap32Type:
+		return "map32"
+	case Bitmap64Type:
+		return "map64"
+	case PercentType:
+		return "percent"
+	case Percent100thsType:
+		return "percent100ths"
+	case TemperatureType:
+		return "temperature"
+	case EpochSType:
+		return "epoch-s"
+	case EpochUsType:
+		return "epoch-us"
+	case StringType:
+		return "string"
+	case OctetStringType:
+		return "octstr"
+	case ListType:
+		return "list"
+	case StructType:
+		return "struct"
+	}
+	return "unknown"
+}
@@ -0,0 +1,165 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datatype
+
+import (
+	"math"
+
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+const (
+	// PercentMax is the maximum percent value.
+	PercentMax = 100
+	// Percent100thsMax is the maximum percent value in hundredths.
+	Percent100thsMax = 10000
+	// TemperatureMin is the minimum temperature in hundredths of a degree Celsius, which is absolute zero.
+	TemperatureMin = -27315
+)
+
+// 7.18.2.7. Percent
+// 7.18.2.8. Percent100ths
+// 7.18.2.21. Temperature
+
+// Percent represents a percentage from 0 to 100 (percent).
+type Percent uint8
+
+// Type returns the data type.
+func (v *Percent) Type() Type {
+	return PercentType
+}
+
+// Validate returns an error if the value is out of range.
+func (v *Percent) Validate() error {
+	if PercentMax < *v {
+		return rangeError(PercentType, *v)
+	}
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *Percent) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	enc.PutUnsigned(tag, uint64(*v))
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *Percent) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	u, err := decodeUnsigned(PercentType, elem, math.MaxUint8)
+	if err != nil {
+		return err
+	}
+	*v = Percent(u)
+	return v.Validate()
+}
+
+func (v *Percent) isNullValue() bool {
+	return *v == math.MaxUint8
+}
+
+// Percent100ths represents a percentage in hundredths from 0 to 10000 (percent100ths).
+type Percent100ths uint16
+
+// Type returns the data type.
+func (v *Percent100ths) Type() Type {
+	return Percent100thsType
+}
+
+// Validate returns an error if the value is out of range.
+func (v *Percent100ths) Validate() error {
+	if Percent100thsMax < *v {
+		return rangeError(Percent100thsType, *v)
+	}
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *Percent100ths) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	enc.PutUnsigned(tag, uint64(*v))
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *Percent100ths) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	u, err := decodeUnsigned(Percent100thsType, elem, math.MaxUint16)
+	if err != nil {
+		return err
+	}
+	*v = Percent100ths(u)
+	return v.Validate()
+}
+
+func (v *Percent100ths) isNullValue() bool {
+	return *v == math.MaxUint16
+}
+
+// Temperature represents a temperature in hundredths of a degree Celsius (temperature).
+type Temperature int16
+
+// Type returns the data type.
+func (v *Temperature) Type() Type {
+	return TemperatureType
+}
+
+// Validate returns an error if the value is out of range.
+func (v *Temperature) Validate() error {
+	if *v < TemperatureMin {
+		return rangeError(TemperatureType, *v)
+	}
+	return nil
+}
+
+// Encode writes the value with the specified tag.
+func (v *Temperature) Encode(enc *tlv.Encoder, tag tlv.Tag) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	enc.PutSigned(tag, int64(*v))
+	return nil
+}
+
+// Decode reads the value from the specified element.
+func (v *Temperature) Decode(dec *tlv.Decoder, elem *tlv.Element) error {
+	n, err := decodeSigned(TemperatureType, elem, math.MinInt16, math.MaxInt16)
+	if err != nil {
+		return err
+	}
+	*v = Temperature(n)
+	return v.Validate()
+}
+
+func (v *Temperature) isNullValue() bool {
+	return *v == math.MinInt16
+}
+
+// NewTemperatureFromCelsius returns the temperature of the specified degrees Celsius rounded to hundredths.
+func NewTemperatureFromCelsius(c float64) (Temperature, error) {
+	v := math.Round(c * 100)
+	if math.IsNaN(v) || v < TemperatureMin || math.MaxInt16 < v {
+		return 0, rangeError(TemperatureType, c)
+	}
+	return Temperature(v), nil
+}
+
+// Celsius returns the temperature in degrees Celsius.
+func (v *Temperature) Celsius() float64 {
+	return float64(*v) / 100
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datatype
+
+import (
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+// Value represents a value of a data type which is encoded into TLV.
+type Value interface {
+	// Type returns the data type of the value.
+	Type() Type
+	// Validate returns an error if the value is outside the range of the data type.
+	Validate() error
+	// Encode writes the value with the specified tag after validating it.
+	Encode(enc *tlv.Encoder, tag tlv.Tag) error
+	// Decode reads the value from the specified element, and reads the following members
+	// from the decoder if the element starts a container.
+	Decode(dec *tlv.Decoder, elem *tlv.Element) error
+}
+
+// nullableValue is implemented by the data types which reserve a value to represent null
+// in their nullable variants.
+type nullableValue interface {
+	isNullValue() bool
+}
+
+// Encode returns the TLV encoding of the specified value with an anonymous tag.
+func Encode(v Value) ([]byte, error) {
+	enc := tlv.NewEncoder()
+	if err := v.Encode(enc, tlv.NewAnonymousTag()); err != nil {
+		return nil, err
+	}
+	return enc.Bytes(), nil
+}
+
+// Decode reads the specified value from the TLV encoding with an anonymous tag.
+func Decode(b []byte, v Value) error {
+	dec := tlv.NewDecoder(b)
+	elem, err := dec.Next()
+	if err != nil {
+		return err
+	}
+	if err := v.Decode(dec, elem); err != nil {
+		return err
+	}
+	if dec.More() {
+		return fmt.Errorf("%w %s : trailing bytes at offset %d", ErrInvalid, v.Type(), dec.Offset())
+	}
+	return nil
+}
+
+func typeError(typ Type, elem *tlv.Element) error {
+	return fmt.Errorf("%w %s : element type (%s)", ErrInvalid, typ, elem.Type())
+}
+
+func rangeError(typ Type, v any) error {
+	return fmt.Errorf("%s (%v) is %w", typ, v, ErrOutOfRange)
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"github.com/cybergarage/go-matter/matter/metrics"
+)
+
+// OperationalDeviceState represents a read-only snapshot of an operational device handle for debugging.
+type OperationalDeviceState struct {
+	// Peer is the operational identity and the last known address of the node.
+	Peer OperationalPeer
+	// Addresses is the known addresses of the node in the order of preference.
+	Addresses []PeerAddress
+	// Connected is true if the handle has a CASE session to the node.
+	Connected bool
+	// Closed is true if the handle is closed.
+	Closed bool
+	// Stats is the transport statistics of the node.
+	Stats metrics.PeerStats
+}
+
+// Debug returns a snapshot of the handle.
+func (dev *OperationalDevice) Debug() OperationalDeviceState {
+	dev.Lock()
+	state := OperationalDeviceState{
+		Peer:      dev.peer,
+		Addresses: dev.addrs.Addresses(),
+		Connected: dev.session != nil,
+		Closed:    dev.closed,
+		Stats:     metrics.PeerStats{},
+	}
+	dev.Unlock()
+	state.Stats = dev.Stats()
+	return state
+}
+
+// PeerState represents the transport statistics of a node in a commissioner snapshot.
+type PeerState struct {
+	// NodeID is the operational node ID.
+	NodeID NodeID
+	// Stats is the transport statistics of the node.
+	Stats metrics.PeerStats
+}
+
+// CommissionerState represents a read-only snapshot of a commissioner for debugging.
+type CommissionerState struct {
+	// Peers is the nodes operated by the commissioner in ascending order of the node IDs.
+	Peers []PeerState
+}
+
+// Debug returns a snapshot of the nodes operated by the commissioner.
+func (com *Commissioner) Debug() CommissionerState {
+	ids := com.stats.Peers()
+	peers := make([]PeerState, 0, len(ids))
+	for _, id := range ids {
+		stats, ok := com.stats.Lookup(id)
+		if !ok {
+			continue
+		}
+		peers = append(peers, PeerState{
+			NodeID: id,
+			Stats:  stats,
+		})
+	}
+	return CommissionerState{
+		Peers: peers,
+	}
+}
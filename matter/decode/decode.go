@@ -0,0 +1,295 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package decode decodes Matter messages layer by layer into a tree of fields, so that
+// analysis tools such as packet dissectors can use go-matter as a decoding library.
+package decode
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/internal/protocol"
+	"github.com/cybergarage/go-matter/matter/securechannel"
+)
+
+// Layer represents a layer of a decoded message.
+type Layer string
+
+const (
+	// MessageLayer is the message header layer.
+	MessageLayer Layer = "message"
+	// ProtocolLayer is the protocol header layer.
+	ProtocolLayer Layer = "protocol"
+	// PayloadLayer is the application payload layer of a known protocol message.
+	PayloadLayer Layer = "payload"
+	// TLVLayer is the TLV element layer of a payload.
+	TLVLayer Layer = "tlv"
+)
+
+// Hints represents hints for decoding bytes. The zero value decodes a UDP message.
+type Hints struct {
+	// Start is the layer which the bytes start with, or the message layer if it is empty.
+	Start Layer
+	// LengthPrefixed is true if the message starts with the message length field of TCP.
+	LengthPrefixed bool
+	// Codec decrypts the payload of secured messages, which is left encrypted if it is nil.
+	Codec *message.SecureCodec
+	// ProtocolID is the protocol of the payload if the bytes start with the payload layer.
+	ProtocolID protocol.ProtocolID
+	// Opcode is the opcode of the payload if the bytes start with the payload layer.
+	Opcode protocol.Opcode
+}
+
+// DecodedMessage represents a message decoded as far as possible.
+// The node offsets in the message layer are relative to the decoded bytes. The node offsets in the
+// later layers are relative to the decrypted protocol message if the message is decrypted.
+type DecodedMessage struct {
+	// Layer is the last layer which was decoded.
+	Layer Layer
+	// MessageHeader is the message header, or nil if the message layer was not decoded.
+	MessageHeader *message.Header
+	// ProtocolHeader is the protocol header, or nil if the protocol layer was not decoded.
+	ProtocolHeader *protocol.Header
+	// Encrypted is true if the payload of the secured message was not decrypted without a codec.
+	Encrypted bool
+	// Decrypted is true if the payload of the secured message was decrypted with the codec.
+	Decrypted bool
+	// Payload is the application payload, or the encrypted payload with the MIC if it is encrypted.
+	Payload []byte
+	// Root is the root of the tree whose children are the decoded layers.
+	Root *Node
+}
+
+func newDecodedMessage() *DecodedMessage {
+	return &DecodedMessage{
+		Layer:          "",
+		MessageHeader:  nil,
+		ProtocolHeader: nil,
+		Encrypted:      false,
+		Decrypted:      false,
+		Payload:        nil,
+		Root:           newNode("", "matter", "", nil, 0, 0),
+	}
+}
+
+// Decode decodes the specified bytes from the layer of the hints down to the TLV elements of a known
+// protocol message. If a layer is malformed, the layers decoded before it are returned with the error,
+// which is a message.DecodeError for the message and protocol headers.
+func Decode(b []byte, hints Hints) (*DecodedMessage, error) {
+	msg := newDecodedMessage()
+	msg.Root.Size = len(b)
+	var err error
+	switch hints.Start {
+	case "", MessageLayer:
+		err = msg.decodeMessage(b, hints)
+	case ProtocolLayer:
+		err = msg.decodeProtocol(b, 0)
+	case PayloadLayer:
+		err = msg.decodePayload(b, 0, hints.ProtocolID, hints.Opcode)
+	case TLVLayer:
+		msg.Payload = b
+		err = msg.decodeTLV(msg.Root, b, 0)
+	default:
+		err = fmt.Errorf("%w start layer: %s", ErrInvalid, hints.Start)
+	}
+	return msg, err
+}
+
+// 4.4.1. Message Header Field Descriptions
+func (msg *DecodedMessage) decodeMessage(b []byte, hints Hints) error {
+	base := 0
+	var length *Node
+	if hints.LengthPrefixed {
+		if len(b) < 2 {
+			return message.NewDecodeError(message.PacketLayer, 0, len(b), fmt.Errorf("%w message length: short message (%d)", ErrInvalid, len(b)))
+		}
+		n := int(binary.LittleEndian.Uint16(b))
+		if len(b)-2 < n {
+			return message.NewDecodeError(message.PacketLayer, 0, len(b), fmt.Errorf("%w message length: %d exceeds %d bytes", ErrInvalid, n, len(b)-2))
+		}
+		length = newNode(MessageLayer, "message length", "uint16", uint64(n), 0, 2)
+		base = 2
+		b = b[base : base+n]
+	}
+
+	header, err := message.NewHeaderFromBytes(b)
+	if err != nil {
+		return shiftDecodeError(err, base)
+	}
+	msg.MessageHeader = header
+	msg.Layer = MessageLayer
+
+	layer := msg.Root.addChild(newNode(MessageLayer, "message header", "", nil, 0, base+header.Size()))
+	if length != nil {
+		layer.addChild(length)
+	}
+	fields := newFieldWriter(layer, MessageLayer, base)
+	fields.add("message flags", "uint8", uint64(header.Flag()), 1)
+	fields.add("session ID", "uint16", uint64(header.SessionID), 2)
+	fields.add("security flags", "uint8", uint64(header.SecurityFlag), 1)
+	fields.add("message counter", "uint32", uint64(header.Counter), 4)
+	if header.Flag().HasSourceNodeID() {
+		fields.add("source node ID", "uint64", uint64(header.SourceNodeID), 8)
+	}
+	switch {
+	case header.Flag().HasDestinationNodeID():
+		fields.add("destination node ID", "uint64", uint64(header.DestinationNodeID), 8)
+	case header.Flag().HasDestinationGroupID():
+		fields.add("destination group ID", "uint16", uint64(header.DestinationGroupID), 2)
+	}
+	if header.SecurityFlag.IsExtendedMessage() {
+		fields.add("message extensions length", "uint16", uint64(len(header.Extensions)), 2)
+		fields.add("message extensions", "bytes", header.Extensions, len(header.Extensions))
+	}
+
+	payload := b[header.Size():]
+	switch {
+	case header.SecurityFlag.IsUnicastSession() && header.SessionID == 0:
+		return msg.decodeProtocol(payload, base+header.Size())
+	case hints.Codec != nil:
+		_, plain, err := hints.Codec.Open(b)
+		if err != nil {
+			return err
+		}
+		msg.Decrypted = true
+		return msg.decodeProtocol(plain, 0)
+	}
+	msg.Encrypted = true
+	msg.Payload = payload
+	msg.Root.addChild(newNode(MessageLayer, "encrypted payload", "bytes", payload, base+header.Size(), len(payload)))
+	return nil
+}
+
+// 4.4.3. Protocol Header Field Descriptions
+func (msg *DecodedMessage) decodeProtocol(b []byte, base int) error {
+	header, err := protocol.NewHeaderFromBytes(b)
+	if err != nil {
+		return shiftDecodeError(err, base)
+	}
+	msg.ProtocolHeader = header
+	msg.Layer = ProtocolLayer
+
+	layer := msg.Root.addChild(newNode(ProtocolLayer, "protocol header", "", nil, base, header.Size()))
+	fields := newFieldWriter(layer, ProtocolLayer, base)
+	fields.add("exchange flags", "uint8", uint64(header.ExchangeFlag), 1)
+	fields.add("protocol opcode", "uint8", uint64(header.Opcode), 1)
+	fields.add("exchange ID", "uint16", uint64(header.ExchangeID), 2)
+	if header.ExchangeFlag.IsVendor() {
+		fields.add("protocol vendor ID", "uint16", uint64(header.VenderID), 2)
+	}
+	fields.add("protocol ID", "uint16", uint64(header.ProtocolID), 2)
+	if header.ExchangeFlag.IsAcknowledgement() {
+		fields.add("acknowledged message counter", "uint32", uint64(header.AckCounter), 4)
+	}
+	if header.ExchangeFlag.IsSecuredExtension() {
+		fields.add("secured extensions length", "uint16", uint64(len(header.Extensions)), 2)
+		fields.add("secured extensions", "bytes", header.Extensions, len(header.Extensions))
+	}
+	for _, violation := range header.Violations() {
+		layer.addChild(newNode(ProtocolLayer, "violation", "string", violation.Error(), base, 0))
+	}
+
+	// Vendor specific protocols are not known.
+	if header.ExchangeFlag.IsVendor() {
+		msg.Payload = b[header.Size():]
+		msg.Root.addChild(newNode(PayloadLayer, "vendor payload", "bytes", msg.Payload, base+header.Size(), len(msg.Payload)))
+		return nil
+	}
+	return msg.decodePayload(b[header.Size():], base+header.Size(), header.ProtocolID, header.Opcode)
+}
+
+func (msg *DecodedMessage) decodePayload(b []byte, base int, id protocol.ProtocolID, op protocol.Opcode) error {
+	msg.Payload = b
+	layer := msg.Root.addChild(newNode(PayloadLayer, messageName(id, op), "", nil, base, len(b)))
+	typ, ok := lookupMessageType(id, op)
+	if !ok {
+		layer.Type = "bytes"
+		layer.Value = b
+		return nil
+	}
+	msg.Layer = PayloadLayer
+
+	fields := newFieldWriter(layer, PayloadLayer, base)
+	switch typ.encoding {
+	case tlvEncoding:
+		return msg.decodeTLV(layer, b, base)
+	case statusReportEncoding:
+		// 4.10.1. Status Report Message
+		report, err := securechannel.NewStatusReportFromBytes(b)
+		if err != nil {
+			return fmt.Errorf("%w %s at offset %d: %w", ErrInvalid, layer.Name, base, err)
+		}
+		fields.add("general code", "uint16", uint64(report.GeneralCode), 2)
+		fields.add("protocol ID", "uint32", uint64(report.ProtocolID), 4)
+		fields.add("protocol code", "uint16", uint64(report.ProtocolCode), 2)
+		fields.add("protocol data", "bytes", report.ProtocolData, len(report.ProtocolData))
+	case counterEncoding, blockEncoding:
+		// 11.22.6. Block Messages
+		if len(b) < 4 {
+			return fmt.Errorf("%w %s at offset %d: short message (%d)", ErrInvalid, layer.Name, base, len(b))
+		}
+		fields.add("block counter", "uint32", uint64(binary.LittleEndian.Uint32(b)), 4)
+		if typ.encoding == blockEncoding {
+			fields.add("data", "bytes", b[4:], len(b)-4)
+		}
+	default:
+		if 0 < len(b) {
+			fields.add("data", "bytes", b, len(b))
+		}
+	}
+	return nil
+}
+
+func (msg *DecodedMessage) decodeTLV(parent *Node, b []byte, base int) error {
+	if len(b) == 0 {
+		return nil
+	}
+	if err := decodeTLV(parent, b, base); err != nil {
+		return err
+	}
+	msg.Layer = TLVLayer
+	return nil
+}
+
+// shiftDecodeError returns the decode error whose offset is shifted to be relative to the decoded bytes.
+func shiftDecodeError(err error, base int) error {
+	var derr *message.DecodeError
+	if base == 0 || !errors.As(err, &derr) {
+		return err
+	}
+	return message.NewDecodeError(derr.Layer, base+derr.Offset, derr.Remaining, derr.Err)
+}
+
+// fieldWriter adds the fixed size fields to a layer node in the encoded order.
+type fieldWriter struct {
+	node   *Node
+	layer  Layer
+	offset int
+}
+
+func newFieldWriter(node *Node, layer Layer, offset int) *fieldWriter {
+	return &fieldWriter{
+		node:   node,
+		layer:  layer,
+		offset: offset,
+	}
+}
+
+func (w *fieldWriter) add(name string, typ string, value any, size int) {
+	w.node.addChild(newNode(w.layer, name, typ, value, w.offset, size))
+	w.offset += size
+}
@@ -0,0 +1,243 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/internal/protocol"
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+	"github.com/cybergarage/go-matter/matter/securechannel"
+)
+
+func newReadRequest(t *testing.T, sessionID message.SessionID) (*message.Header, *protocol.Message) {
+	t.Helper()
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.NewAnonymousTag())
+	enc.StartArray(tlv.NewContextTag(0))
+	enc.StartList(tlv.NewAnonymousTag())
+	enc.PutUnsigned(tlv.NewContextTag(2), 1)
+	enc.PutUnsigned(tlv.NewContextTag(3), 6)
+	enc.EndContainer()
+	enc.EndContainer()
+	enc.PutBool(tlv.NewContextTag(3), true)
+	enc.PutUnsigned(tlv.NewContextTag(0xFF), 12)
+	enc.EndContainer()
+
+	header := message.NewHeader()
+	header.SessionID = sessionID
+	header.SecurityFlag = message.NewSecurityFlag(message.UnicastSession)
+	header.Counter = 0x01020304
+	if err := header.SetSourceNodeID(0x0000000000001234); err != nil {
+		t.Fatal(err)
+	}
+
+	protoHeader := protocol.NewHeader()
+	protoHeader.ExchangeFlag = protocol.InitiatorFlag | protocol.ReliabilityFlag
+	protoHeader.Opcode = protocol.ReadRequestMessage
+	protoHeader.ExchangeID = 0xABCD
+	protoHeader.ProtocolID = InteractionModelProtocolID
+	return header, protocol.NewMessage(protoHeader, enc.Bytes())
+}
+
+func lookupPath(t *testing.T, node *Node, names ...string) *Node {
+	t.Helper()
+	for _, name := range names {
+		child, ok := node.LookupChild(name)
+		if !ok {
+			t.Fatalf("%s is not found in %s", name, node.Name)
+		}
+		node = child
+	}
+	return node
+}
+
+func TestDecodeUnsecuredMessage(t *testing.T) {
+	header, protoMsg := newReadRequest(t, 0)
+	b := append(header.Bytes(), protoMsg.Bytes()...)
+
+	for _, prefixed := range []bool{false, true} {
+		in := b
+		base := 0
+		if prefixed {
+			in = append(binary.LittleEndian.AppendUint16(nil, uint16(len(b))), b...)
+			base = 2
+		}
+		msg, err := Decode(in, Hints{Start: "", LengthPrefixed: prefixed, Codec: nil, ProtocolID: 0, Opcode: 0})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if msg.Layer != TLVLayer || msg.Encrypted || msg.Decrypted {
+			t.Errorf("layer (%s) encrypted (%t) decrypted (%t)", msg.Layer, msg.Encrypted, msg.Decrypted)
+		}
+		if msg.MessageHeader.SourceNodeID != header.SourceNodeID || msg.ProtocolHeader.ExchangeID != 0xABCD {
+			t.Errorf("headers (%+v) (%+v)", msg.MessageHeader, msg.ProtocolHeader)
+		}
+
+		counter := lookupPath(t, msg.Root, "message header", "message counter")
+		if counter.Value != uint64(0x01020304) || counter.Offset != base+4 || counter.Size != 4 {
+			t.Errorf("message counter (%v) at (%d:%d)", counter.Value, counter.Offset, counter.Size)
+		}
+		if !bytes.Equal(in[counter.Offset:counter.Offset+counter.Size], []byte{0x04, 0x03, 0x02, 0x01}) {
+			t.Errorf("message counter bytes (%X)", in[counter.Offset:counter.Offset+counter.Size])
+		}
+		protocolID := lookupPath(t, msg.Root, "protocol header", "protocol ID")
+		if protocolID.Value != uint64(InteractionModelProtocolID) || protocolID.Offset != base+header.Size()+4 {
+			t.Errorf("protocol ID (%v) at (%d)", protocolID.Value, protocolID.Offset)
+		}
+
+		payload := lookupPath(t, msg.Root, "ReadRequest")
+		if payload.Offset != base+header.Size()+protoMsg.Header.Size() || payload.Size != len(protoMsg.Payload()) {
+			t.Errorf("payload at (%d:%d)", payload.Offset, payload.Size)
+		}
+		attr := lookupPath(t, payload, "anonymous", "0", "anonymous", "3")
+		if attr.Type != "uint8" || attr.Value != uint64(6) {
+			t.Errorf("attribute ID (%s) (%v)", attr.Type, attr.Value)
+		}
+		if in[attr.Offset] != 0x24 || attr.Size != 3 {
+			t.Errorf("attribute ID element (%02X) size (%d)", in[attr.Offset], attr.Size)
+		}
+		fabricFiltered := lookupPath(t, payload, "anonymous", "3")
+		if fabricFiltered.Value != true {
+			t.Errorf("fabric filtered (%v)", fabricFiltered.Value)
+		}
+		structure := lookupPath(t, payload, "anonymous")
+		if structure.Offset != payload.Offset || structure.Size != payload.Size {
+			t.Errorf("structure at (%d:%d) != (%d:%d)", structure.Offset, structure.Size, payload.Offset, payload.Size)
+		}
+	}
+}
+
+func TestDecodeSecuredMessage(t *testing.T) {
+	codec, err := message.NewSecureCodec([]byte("0123456789ABCDEF"), 0x0000000000001234)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, protoMsg := newReadRequest(t, 0x5678)
+	b, err := protoMsg.Seal(codec, header)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The payload is left encrypted without a codec.
+
+	msg, err := Decode(b, Hints{Start: MessageLayer, LengthPrefixed: false, Codec: nil, ProtocolID: 0, Opcode: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Layer != MessageLayer || !msg.Encrypted || msg.ProtocolHeader != nil {
+		t.Errorf("layer (%s) encrypted (%t)", msg.Layer, msg.Encrypted)
+	}
+	if !bytes.Equal(msg.Payload, b[header.Size():]) {
+		t.Errorf("encrypted payload (%X)", msg.Payload)
+	}
+	lookupPath(t, msg.Root, "encrypted payload")
+
+	// The payload is decrypted with the codec.
+
+	msg, err = Decode(b, Hints{Start: MessageLayer, LengthPrefixed: false, Codec: codec, ProtocolID: 0, Opcode: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Layer != TLVLayer || !msg.Decrypted || msg.ProtocolHeader.Opcode != protocol.ReadRequestMessage {
+		t.Errorf("layer (%s) decrypted (%t)", msg.Layer, msg.Decrypted)
+	}
+	if payload := lookupPath(t, msg.Root, "ReadRequest"); payload.Offset != protoMsg.Header.Size() {
+		t.Errorf("decrypted payload offset (%d) != (%d)", payload.Offset, protoMsg.Header.Size())
+	}
+
+	// A modified message is not decrypted, but the message header is returned.
+
+	b[len(b)-1] ^= 0x01
+	msg, err = Decode(b, Hints{Start: MessageLayer, LengthPrefixed: false, Codec: codec, ProtocolID: 0, Opcode: 0})
+	if err == nil {
+		t.Error("tampered message is decrypted")
+	}
+	if msg.Layer != MessageLayer || msg.MessageHeader == nil {
+		t.Errorf("layer (%s)", msg.Layer)
+	}
+}
+
+func TestDecodePartialMessage(t *testing.T) {
+	header, protoMsg := newReadRequest(t, 0)
+	b := append(header.Bytes(), protoMsg.Header.Bytes()[:3]...)
+
+	msg, err := Decode(b, Hints{Start: MessageLayer, LengthPrefixed: false, Codec: nil, ProtocolID: 0, Opcode: 0})
+	var derr *message.DecodeError
+	if !errors.As(err, &derr) {
+		t.Fatalf("error (%v) is not a decode error", err)
+	}
+	if derr.Layer != message.ExchangeLayer || derr.Offset != header.Size()+2 {
+		t.Errorf("decode error (%s) at (%d)", derr.Layer, derr.Offset)
+	}
+	if msg.Layer != MessageLayer || msg.MessageHeader == nil {
+		t.Errorf("layer (%s)", msg.Layer)
+	}
+
+	// A malformed TLV payload is returned with the decoded headers.
+
+	b = append(header.Bytes(), protocol.NewMessage(protoMsg.Header, []byte{0x15, 0x24, 0x01}).Bytes()...)
+	msg, err = Decode(b, Hints{Start: MessageLayer, LengthPrefixed: false, Codec: nil, ProtocolID: 0, Opcode: 0})
+	if !errors.Is(err, ErrInvalid) {
+		t.Errorf("error (%v) is not invalid", err)
+	}
+	if msg.Layer != PayloadLayer || msg.ProtocolHeader == nil {
+		t.Errorf("layer (%s)", msg.Layer)
+	}
+
+	if _, err := Decode(b, Hints{Start: "ethernet", LengthPrefixed: false, Codec: nil, ProtocolID: 0, Opcode: 0}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("start layer error (%v) is not invalid", err)
+	}
+}
+
+func TestDecodePayload(t *testing.T) {
+	report := securechannel.NewStatusReport(securechannel.GeneralBusy, securechannel.Busy, []byte{0xE8, 0x03})
+	msg, err := Decode(report.Bytes(), Hints{Start: PayloadLayer, LengthPrefixed: false, Codec: nil, ProtocolID: securechannel.ProtocolID, Opcode: securechannel.StatusReportOpcode})
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := lookupPath(t, msg.Root, "StatusReport", "protocol code")
+	if code.Value != uint64(securechannel.Busy) || code.Offset != 6 {
+		t.Errorf("protocol code (%v) at (%d)", code.Value, code.Offset)
+	}
+
+	// Unknown messages are decoded as bytes.
+
+	msg, err = Decode([]byte{0x01, 0x02}, Hints{Start: PayloadLayer, LengthPrefixed: false, Codec: nil, ProtocolID: 0x1234, Opcode: 0x01})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unknown := lookupPath(t, msg.Root, "0x1234:0x01"); !bytes.Equal(unknown.Value.([]byte), []byte{0x01, 0x02}) {
+		t.Errorf("unknown payload (%v)", unknown.Value)
+	}
+
+	// Raw TLV is decoded without headers.
+
+	msg, err = Decode([]byte{0x15, 0x24, 0x01, 0x2A, 0x18}, Hints{Start: TLVLayer, LengthPrefixed: false, Codec: nil, ProtocolID: 0, Opcode: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := lookupPath(t, msg.Root, "anonymous", "1"); v.Value != uint64(42) {
+		t.Errorf("TLV value (%v)", v.Value)
+	}
+
+	if name, ok := MessageName(InteractionModelProtocolID, protocol.InvokeRequestMessage); !ok || name != "InvokeRequest" {
+		t.Errorf("message name (%s)", name)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decode
+
+import (
+	"fmt"
+
+	"github.com/cybergarage/go-matter/internal/protocol"
+	"github.com/cybergarage/go-matter/matter/bdx"
+	"github.com/cybergarage/go-matter/matter/pase"
+	"github.com/cybergarage/go-matter/matter/securechannel"
+)
+
+const (
+	// InteractionModelProtocolID is the protocol ID of the interaction model protocol.
+	InteractionModelProtocolID protocol.ProtocolID = 0x0001
+	// UserDirectedCommissioningProtocolID is the protocol ID of the user directed commissioning protocol.
+	UserDirectedCommissioningProtocolID protocol.ProtocolID = 0x0003
+)
+
+// payloadEncoding represents how the payload of a message is encoded.
+type payloadEncoding uint8
+
+const (
+	rawEncoding payloadEncoding = iota
+	tlvEncoding
+	statusReportEncoding
+	counterEncoding
+	blockEncoding
+)
+
+type messageType struct {
+	name     string
+	encoding payloadEncoding
+}
+
+// 4.10.1. Secure Channel Protocol Messages
+var secureChannelMessages = map[protocol.Opcode]messageType{
+	0x00:                             {"MsgCounterSyncReq", rawEncoding},
+	0x01:                             {"MsgCounterSyncRsp", rawEncoding},
+	0x10:                             {"MRP Standalone Acknowledgement", rawEncoding},
+	pase.PBKDFParamRequestOpcode:     {"PBKDFParamRequest", tlvEncoding},
+	pase.PBKDFParamResponseOpcode:    {"PBKDFParamResponse", tlvEncoding},
+	pase.Pake1Opcode:                 {"PASE Pake1", tlvEncoding},
+	pase.Pake2Opcode:                 {"PASE Pake2", tlvEncoding},
+	pase.Pake3Opcode:                 {"PASE Pake3", tlvEncoding},
+	0x30:                             {"CASE Sigma1", tlvEncoding},
+	0x31:                             {"CASE Sigma2", tlvEncoding},
+	0x32:                             {"CASE Sigma3", tlvEncoding},
+	0x33:                             {"CASE Sigma2_Resume", tlvEncoding},
+	securechannel.StatusReportOpcode: {"StatusReport", statusReportEncoding},
+	0x50:                             {"ICD Check-In", rawEncoding},
+}
+
+// 10.2.1. Interaction Model Protocol Messages
+var interactionModelMessages = map[protocol.Opcode]messageType{
+	protocol.StatusResponseMessage:    {"StatusResponse", tlvEncoding},
+	protocol.ReadRequestMessage:       {"ReadRequest", tlvEncoding},
+	protocol.SubscribeRequestMessage:  {"SubscribeRequest", tlvEncoding},
+	protocol.SubscribeResponseMessage: {"SubscribeResponse", tlvEncoding},
+	protocol.ReportDataMessage:        {"ReportData", tlvEncoding},
+	protocol.WriteRequestMessage:      {"WriteRequest", tlvEncoding},
+	protocol.WriteResponseMessage:     {"WriteResponse", tlvEncoding},
+	protocol.InvokeRequestMessage:     {"InvokeRequest", tlvEncoding},
+	protocol.InvokeResponseMessage:    {"InvokeResponse", tlvEncoding},
+	protocol.TimedRequestMessage:      {"TimedRequest", tlvEncoding},
+}
+
+// 11.22.3. Bulk Data Exchange Protocol Messages
+var bdxMessages = map[protocol.Opcode]messageType{
+	bdx.SendInitOpcode:           {"SendInit", rawEncoding},
+	bdx.SendAcceptOpcode:         {"SendAccept", rawEncoding},
+	bdx.ReceiveInitOpcode:        {"ReceiveInit", rawEncoding},
+	bdx.ReceiveAcceptOpcode:      {"ReceiveAccept", rawEncoding},
+	bdx.BlockQueryOpcode:         {"BlockQuery", counterEncoding},
+	bdx.BlockOpcode:              {"Block", blockEncoding},
+	bdx.BlockEOFOpcode:           {"BlockEOF", blockEncoding},
+	bdx.BlockAckOpcode:           {"BlockAck", counterEncoding},
+	bdx.BlockAckEOFOpcode:        {"BlockAckEOF", counterEncoding},
+	bdx.BlockQueryWithSkipOpcode: {"BlockQueryWithSkip", rawEncoding},
+	bdx.StatusReportOpcode:       {"StatusReport", statusReportEncoding},
+}
+
+// 5.3. User Directed Commissioning Messages
+var udcMessages = map[protocol.Opcode]messageType{
+	0x00: {"IdentificationDeclaration", tlvEncoding},
+}
+
+type protocolType struct {
+	name     string
+	messages map[protocol.Opcode]messageType
+}
+
+var protocols = map[protocol.ProtocolID]protocolType{
+	securechannel.ProtocolID:            {"Secure Channel", secureChannelMessages},
+	InteractionModelProtocolID:          {"Interaction Model", interactionModelMessages},
+	bdx.ProtocolID:                      {"BDX", bdxMessages},
+	UserDirectedCommissioningProtocolID: {"User Directed Commissioning", udcMessages},
+}
+
+// ProtocolName returns the name of the specified standard protocol.
+func ProtocolName(id protocol.ProtocolID) (string, bool) {
+	p, ok := protocols[id]
+	if !ok {
+		return "", false
+	}
+	return p.name, true
+}
+
+// MessageName returns the name of the message of the specified standard protocol and opcode.
+func MessageName(id protocol.ProtocolID, op protocol.Opcode) (string, bool) {
+	typ, ok := lookupMessageType(id, op)
+	if !ok {
+		return "", false
+	}
+	return typ.name, true
+}
+
+func lookupMessageType(id protocol.ProtocolID, op protocol.Opcode) (messageType, bool) {
+	p, ok := protocols[id]
+	if !ok {
+		return messageType{"", rawEncoding}, false
+	}
+	typ, ok := p.messages[op]
+	return typ, ok
+}
+
+func messageName(id protocol.ProtocolID, op protocol.Opcode) string {
+	if name, ok := MessageName(id, op); ok {
+		return name
+	}
+	return fmt.Sprintf("0x%04X:0x%02X", uint16(id), uint8(op))
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decode
+
+import (
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+// Node represents a decoded field, which has a value or children.
+type Node struct {
+	// Layer is the layer which the field belongs to.
+	Layer Layer
+	// Name is the field name, which is the tag for TLV elements.
+	Name string
+	// Type is the field type such as uint16, bytes or struct.
+	Type string
+	// Value is a uint64, int64, bool, float32, float64, string, []byte or nil.
+	Value any
+	// Offset is the byte offset of the field. See DecodedMessage for the bytes which the offset is relative to.
+	Offset int
+	// Size is the encoded size of the field.
+	Size int
+	// Children are the nested fields.
+	Children []*Node
+}
+
+func newNode(layer Layer, name string, typ string, value any, offset int, size int) *Node {
+	return &Node{
+		Layer:    layer,
+		Name:     name,
+		Type:     typ,
+		Value:    value,
+		Offset:   offset,
+		Size:     size,
+		Children: []*Node{},
+	}
+}
+
+func (node *Node) addChild(child *Node) *Node {
+	node.Children = append(node.Children, child)
+	return child
+}
+
+// LookupChild returns the first child of the specified name.
+func (node *Node) LookupChild(name string) (*Node, bool) {
+	for _, child := range node.Children {
+		if child.Name == name {
+			return child, true
+		}
+	}
+	return nil, false
+}
+
+// Walk calls the specified function for the node and its descendants in depth-first order
+// with their depth from the node.
+func (node *Node) Walk(fn func(node *Node, depth int)) {
+	node.walk(fn, 0)
+}
+
+func (node *Node) walk(fn func(node *Node, depth int), depth int) {
+	fn(node, depth)
+	for _, child := range node.Children {
+		child.walk(fn, depth+1)
+	}
+}
+
+// String returns the string representation.
+func (node *Node) String() string {
+	switch v := node.Value.(type) {
+	case nil:
+		if node.Type == "" {
+			return node.Name
+		}
+		return fmt.Sprintf("%s (%s)", node.Name, node.Type)
+	case []byte:
+		return fmt.Sprintf("%s (%s) = %X", node.Name, node.Type, v)
+	case string:
+		return fmt.Sprintf("%s (%s) = %q", node.Name, node.Type, v)
+	default:
+		return fmt.Sprintf("%s (%s) = %v", node.Name, node.Type, v)
+	}
+}
+
+// decodeTLV decodes the TLV elements into the children of the parent node.
+func decodeTLV(parent *Node, b []byte, base int) error {
+	dec := tlv.NewDecoder(b)
+	stack := []*Node{parent}
+	for dec.Offset() < len(b) {
+		offset := dec.Offset()
+		elem, err := dec.Next()
+		if err != nil {
+			return fmt.Errorf("%w TLV element at offset %d: %w", ErrInvalid, base+offset, err)
+		}
+		top := stack[len(stack)-1]
+		if elem.IsEndOfContainer() {
+			top.Size = base + dec.Offset() - top.Offset
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		node := top.addChild(newNode(TLVLayer, elem.Tag().String(), elem.Type().String(), elem.Value(), base+offset, dec.Offset()-offset))
+		if elem.IsContainer() {
+			stack = append(stack, node)
+		}
+	}
+	if 1 < len(stack) {
+		return fmt.Errorf("%w TLV container (%s) at offset %d: end of container is missing", ErrInvalid, stack[len(stack)-1].Name, stack[len(stack)-1].Offset)
+	}
+	return nil
+}
@@ -15,13 +15,11 @@
 package matter
 
 import (
-	"strconv"
-
-	"github.com/cybergarage/go-safecast/safecast"
+	"github.com/cybergarage/go-matter/matter/types"
 )
 
 // DeviceType represents a device type.
-type DeviceType uint
+type DeviceType = types.DeviceType
 
 const (
 	// DeviceTypeUnknown represents an unknown device type.
@@ -30,13 +28,5 @@ const (
 
 // NewDeviceTypeFromString returns a new device type from a string.
 func NewDeviceTypeFromString(s string) (DeviceType, error) {
-	dti, err := strconv.Atoi(s)
-	if err != nil {
-		return DeviceTypeUnknown, err
-	}
-	var dt uint
-	if err := safecast.ToUint(dti, &dt); err != nil {
-		return DeviceTypeUnknown, err
-	}
-	return DeviceType(dt), nil
+	return types.NewDeviceTypeFromString(s)
 }
@@ -0,0 +1,129 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/bdx"
+	"github.com/cybergarage/go-matter/matter/cluster/diagnosticlogs"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// BDXAcceptor represents a node which accepts BDX transfers from the nodes it has sessions with.
+type BDXAcceptor interface {
+	// AcceptBDX returns the exchange which a transfer with the specified file designator is received on.
+	AcceptBDX(ctx context.Context, designator string) (bdx.Conn, error)
+}
+
+// RetrieveLogs retrieves the logs of the specified intent from the diagnostic logs cluster of the specified endpoint.
+// The logs are received with BDX if the acceptor is not nil and they do not fit in the response, and are returned
+// truncated otherwise. RetrieveLogs returns ErrNotFound if the node has no logs.
+func RetrieveLogs(ctx context.Context, invoker CommandInvoker, endpoint im.EndpointID, intent diagnosticlogs.Intent, acceptor BDXAcceptor) ([]byte, error) {
+	if acceptor == nil {
+		res, err := invokeRetrieveLogs(ctx, invoker, endpoint, intent, diagnosticlogs.ResponsePayloadProtocol, "")
+		if err != nil {
+			return nil, err
+		}
+		return res.LogContent, nil
+	}
+
+	designator, err := newLogFileDesignator()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := acceptor.AcceptBDX(ctx, designator)
+	if err != nil {
+		return nil, err
+	}
+
+	transferCtx, cancel := context.WithCancel(ctx)
+	var buf bytes.Buffer
+	done := make(chan error, 1)
+	go func() {
+		_, err := bdx.Receive(transferCtx, conn, &buf, func(init *bdx.TransferInit) error {
+			if string(init.FileDesignator) != designator {
+				return bdx.StatusFileDesignatorUnknown
+			}
+			return nil
+		})
+		done <- err
+	}()
+	// The receiver is waited for on every return path, so that it never takes a later transfer.
+	stop := func() {
+		cancel()
+		<-done
+	}
+
+	res, err := invokeRetrieveLogs(ctx, invoker, endpoint, intent, diagnosticlogs.BDXProtocol, designator)
+	if err != nil {
+		stop()
+		return nil, err
+	}
+	if 0 < len(res.LogContent) {
+		stop()
+		return res.LogContent, nil
+	}
+	// The node responds after the transfer ends.
+	select {
+	case err := <-done:
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+	case <-ctx.Done():
+		stop()
+		return nil, ctx.Err()
+	}
+	return buf.Bytes(), nil
+}
+
+func invokeRetrieveLogs(ctx context.Context, invoker CommandInvoker, endpoint im.EndpointID, intent diagnosticlogs.Intent, protocol diagnosticlogs.TransferProtocol, designator string) (*diagnosticlogs.RetrieveLogsResponse, error) {
+	fields, err := datatype.Encode(diagnosticlogs.NewRetrieveLogsRequestFields(intent, protocol, designator))
+	if err != nil {
+		return nil, err
+	}
+	b, err := invoker.InvokeCommand(ctx, im.NewCommandPath(endpoint, diagnosticlogs.ClusterID, diagnosticlogs.RetrieveLogsRequestCommandID), fields)
+	if err != nil {
+		return nil, err
+	}
+	res, err := diagnosticlogs.NewRetrieveLogsResponseFromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	switch res.Status {
+	case diagnosticlogs.SuccessStatus, diagnosticlogs.ExhaustedStatus:
+		return res, nil
+	case diagnosticlogs.NoLogsStatus:
+		return nil, fmt.Errorf("%w logs (intent %d)", ErrNotFound, intent)
+	case diagnosticlogs.BusyStatus:
+		return nil, fmt.Errorf("%w : logs are being transferred", ErrBusy)
+	default:
+		return nil, fmt.Errorf("%w logs (status %d)", ErrRejected, res.Status)
+	}
+}
+
+func newLogFileDesignator() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "logs-" + hex.EncodeToString(b), nil
+}
@@ -15,31 +15,114 @@
 package matter
 
 import (
-	"github.com/cybergarage/go-logger/log"
+	"net/netip"
+	"strings"
+	"sync"
+
+	"github.com/cybergarage/go-matter/matter/log"
 	"github.com/cybergarage/go-mdns/mdns"
 	"github.com/cybergarage/go-mdns/mdns/dns"
 )
 
 const (
-	DNSSDServerType = "_matter._tcp"
+	// DNSSDServerType is the service type of commissioned nodes.
+	//
+	// Deprecated: Use OperationalServiceType.
+	DNSSDServerType = string(OperationalServiceType)
 )
 
 // Discoverer represents a discoverer for commisionners.
+// The discoverer is safe for concurrent use. The listener is called from the goroutine which receives
+// mDNS messages, after the message is merged into the service table.
 type Discoverer struct {
 	*mdns.Client
+	mutex    sync.RWMutex
+	services *ServiceTable
+	listener mdns.MessageListener
 }
 
 // NewDiscoverer returns a new discoverer.
 func NewDiscoverer() *Discoverer {
 	disc := &Discoverer{
-		Client: mdns.NewClient(),
+		Client:   mdns.NewClient(),
+		mutex:    sync.RWMutex{},
+		services: NewServiceTable(),
+		listener: nil,
 	}
+	disc.Client.SetListener(messageListenerFunc(disc.messageReceived))
 	return disc
 }
 
+// messageListenerFunc represents a function which listens mDNS messages.
+type messageListenerFunc func(msg *dns.Message)
+
+// MessageReceived calls the function.
+func (f messageListenerFunc) MessageReceived(msg *dns.Message) {
+	f(msg)
+}
+
+func (disc *Discoverer) messageReceived(msg *dns.Message) {
+	disc.services.Update(msg)
+	disc.mutex.RLock()
+	listener := disc.listener
+	disc.mutex.RUnlock()
+	if listener != nil {
+		listener.MessageReceived(msg)
+	}
+}
+
+// SetListener sets a listener which is called with all received mDNS messages, or removes it if it is nil.
+// The listener may be set while the discoverer is running.
+func (disc *Discoverer) SetListener(l mdns.MessageListener) {
+	disc.mutex.Lock()
+	defer disc.mutex.Unlock()
+	disc.listener = l
+}
+
+// ServiceTable returns the discovered services keyed by the instance names.
+func (disc *Discoverer) ServiceTable() *ServiceTable {
+	return disc.services
+}
+
+// Services returns the discovered services in ascending order of the instance names.
+// Services answered repeatedly or on multiple interfaces are merged into one service,
+// which has the union of the host addresses and the latest TXT data.
+func (disc *Discoverer) Services() []*mdns.Service {
+	services := []*mdns.Service{}
+	for _, srv := range disc.services.Services() {
+		services = append(services, srv.Service)
+	}
+	return services
+}
+
 // MessageReceived is a callback when a message is received.
 func (disc *Discoverer) MessageReceived(msg *dns.Message) {
-	log.HexInfo(msg.Bytes())
+	log.Hexf(log.LevelInfo, msg.Bytes())
+}
+
+// QueryHost queries the AAAA and A records of the specified host, such as the SRV target of
+// a service whose addresses were missing from the answer.
+func (disc *Discoverer) QueryHost(host string) error {
+	// SRV targets are answered with only the first label of the host name.
+	name := strings.TrimSuffix(host, ".")
+	if !strings.Contains(name, ".") {
+		name += "." + mdns.DefaultDomain
+	}
+	msg := dns.NewRequestMessage()
+	for _, typ := range []dns.Type{dns.AAAA, dns.A} {
+		q := dns.NewQuestion()
+		q.SetName(name)
+		q.SetType(typ)
+		q.SetClass(dns.IN)
+		msg.AddQuestion(q)
+	}
+	return disc.AnnounceMessage(msg)
+}
+
+// LookupHostAddrs returns the addresses of the specified host which have been answered
+// in any response, including the answers to QueryHost.
+func (disc *Discoverer) LookupHostAddrs(host string) []netip.Addr {
+	return disc.services.LookupHostAddrs(host)
 }
 
 // Start starts this discoverer.
@@ -57,6 +140,8 @@ func (disc *Discoverer) Stop() error {
 // To discover a commissionable device over an existing IP-bearing network connection,
 // the Commis­ sioner SHALL perform service discovery using DNS-SD as detailed in
 // Section 4.3, “Discovery”, and more specifically in Section 4.3.1, “Commissionable Node Discovery”.
+//
+// Deprecated: Search queries the operational service type. Use Browse with the service types to search.
 func (disc *Discoverer) Search() error {
 	services := []string{
 		DNSSDServerType,
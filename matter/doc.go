@@ -0,0 +1,32 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package matter provides a Matter controller and the building blocks of commissionable nodes.
+//
+// Applications should use the stable API of the package, which is Controller, Device, Session
+// and OnboardingPayload, with the other types in this package. The message and protocol packages
+// which implement the wire formats are internal to the module, and the APIs of the implementation
+// types which the stable types wrap, such as Commissioner and OperationalDevice, may change between
+// releases.
+//
+// # Concurrency
+//
+// Controller, Device, the discovery types and the tables which they return are safe for concurrent
+// use by multiple goroutines, and so are the setters of their listeners and session establishers.
+// Options are applied by the constructors before the values are shared. Listeners and handlers are
+// called from the goroutines which receive messages or run timers, without holding internal locks,
+// so they may call back into the API but should return promptly. Values returned by the API, such
+// as discovered services and statistics, are copies which the caller owns. Types which are not
+// documented as safe, such as encoders and payload builders, must be used by one goroutine at a time.
+package matter
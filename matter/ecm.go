@@ -0,0 +1,128 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/cluster/administratorcommissioning"
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/pase"
+	"github.com/cybergarage/go-matter/matter/payload"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// 5.6.3. Enhanced Commissioning Method (ECM)
+// EnhancedCommissioningWindow represents a commissioning window which a commissioner opens on a
+// commissioned node to share it with another administrator. The node gets only the verifier of a
+// new random passcode, and the passcode is given to the next administrator in the onboarding payload.
+type EnhancedCommissioningWindow struct {
+	// Timeout is the duration of the window.
+	Timeout time.Duration
+	// Discriminator is the random discriminator which the node advertises while the window is open.
+	Discriminator Discriminator
+	// Passcode is the random passcode of the window.
+	Passcode uint32
+	// Credentials is the verifier of the passcode with the PBKDF parameters.
+	Credentials *pase.Credentials
+}
+
+// NewEnhancedCommissioningWindow returns a new window of the specified duration with a random
+// passcode, a random discriminator and a random PBKDF salt.
+func NewEnhancedCommissioningWindow(timeout time.Duration) (*EnhancedCommissioningWindow, error) {
+	passcode, err := payload.GeneratePasscode()
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, 2)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	discriminator := Discriminator(binary.LittleEndian.Uint16(b) & types.DiscriminatorMax)
+	params, err := pase.DefaultPBKDFPolicy().NewParams()
+	if err != nil {
+		return nil, err
+	}
+	creds, err := pase.NewCredentialsFromPasscode(passcode, params.Salt, params.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	window := &EnhancedCommissioningWindow{
+		Timeout:       timeout,
+		Discriminator: discriminator,
+		Passcode:      passcode,
+		Credentials:   creds,
+	}
+	if err := window.Request().Validate(); err != nil {
+		return nil, err
+	}
+	return window, nil
+}
+
+// Request returns the OpenCommissioningWindow command fields of the window.
+func (window *EnhancedCommissioningWindow) Request() *administratorcommissioning.OpenCommissioningWindowRequest {
+	return &administratorcommissioning.OpenCommissioningWindowRequest{
+		Timeout:              window.Timeout,
+		PAKEPasscodeVerifier: window.Credentials.Verifier.Bytes(),
+		Discriminator:        window.Discriminator,
+		Iterations:           window.Credentials.Iterations,
+		Salt:                 window.Credentials.Salt,
+	}
+}
+
+// OnboardingPayload returns the onboarding payload which the next administrator commissions the node with.
+func (window *EnhancedCommissioningWindow) OnboardingPayload(vid VenderID, pid ProductID) (*payload.OnboardingPayload, error) {
+	return payload.NewOnboardingPayload(
+		payload.WithVendorID(vid),
+		payload.WithProductID(pid),
+		payload.WithDiscoveryCapabilities(payload.DiscoveryCapabilityOnNetwork),
+		payload.WithDiscriminator(window.Discriminator),
+		payload.WithPasscode(window.Passcode))
+}
+
+// OpenEnhancedCommissioningWindow opens a commissioning window with a new random passcode on the
+// node for the specified duration, which is between 3 and 15 minutes. The node requires the
+// command in a timed interaction, so the invoker must send it with a timed request.
+func OpenEnhancedCommissioningWindow(ctx context.Context, invoker CommandInvoker, timeout time.Duration) (*EnhancedCommissioningWindow, error) {
+	window, err := NewEnhancedCommissioningWindow(timeout)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := window.Request().Bytes()
+	if err != nil {
+		return nil, err
+	}
+	path := im.NewCommandPath(datamodel.RootEndpointID, administratorcommissioning.ClusterID, administratorcommissioning.OpenCommissioningWindowCommandID)
+	if _, err := invoker.InvokeCommand(ctx, path, fields); err != nil {
+		return nil, err
+	}
+	return window, nil
+}
+
+// RevokeCommissioning closes the commissioning window which is open on the node.
+func RevokeCommissioning(ctx context.Context, invoker CommandInvoker) error {
+	fields, err := datatype.Encode(datatype.NewStruct())
+	if err != nil {
+		return err
+	}
+	path := im.NewCommandPath(datamodel.RootEndpointID, administratorcommissioning.ClusterID, administratorcommissioning.RevokeCommissioningCommandID)
+	_, err = invoker.InvokeCommand(ctx, path, fields)
+	return err
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package base38 implements the Base38 encoding of the onboarding payloads.
+//
+// Bytes are encoded in chunks of three bytes in little-endian order, and each chunk is written
+// as five characters of the alphabet from the least significant digit. A trailing chunk of
+// one or two bytes is written as two or four characters, so payloads of any size are encoded,
+// and a string whose length leaves one or three trailing characters is invalid.
+package base38
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 5.1.3.1. Base38 Encoding
+const (
+	// Alphabet is the alphabet of the encoding, whose index is the value of each character.
+	Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ-."
+	radix    = 38
+	// maxChunkLen is the number of bytes in a full chunk.
+	maxChunkLen = 3
+)
+
+// charsPerChunk holds the number of characters for each chunk byte length.
+var charsPerChunk = [maxChunkLen + 1]int{0, 2, 4, 5}
+
+// EncodedLen returns the length of the encoding of the specified number of bytes.
+func EncodedLen(n int) int {
+	return (n/maxChunkLen)*charsPerChunk[maxChunkLen] + charsPerChunk[n%maxChunkLen]
+}
+
+// DecodedLen returns the number of bytes of the encoding of the specified length.
+func DecodedLen(n int) (int, error) {
+	full := charsPerChunk[maxChunkLen]
+	for chunkLen, l := range charsPerChunk {
+		if l == n%full {
+			return (n/full)*maxChunkLen + chunkLen, nil
+		}
+	}
+	return 0, fmt.Errorf("%w base38 length (%d)", ErrInvalid, n)
+}
+
+// Encode encodes the specified bytes to a base38 string.
+func Encode(b []byte) string {
+	var sb strings.Builder
+	sb.Grow(EncodedLen(len(b)))
+	for offset := 0; offset < len(b); offset += maxChunkLen {
+		chunkLen := min(maxChunkLen, len(b)-offset)
+		var v uint32
+		for n := chunkLen - 1; 0 <= n; n-- {
+			v = (v << 8) | uint32(b[offset+n])
+		}
+		for n := 0; n < charsPerChunk[chunkLen]; n++ {
+			sb.WriteByte(Alphabet[v%radix])
+			v /= radix
+		}
+	}
+	return sb.String()
+}
+
+// Decode decodes the specified base38 string to bytes. Characters out of the alphabet including
+// lower case letters, invalid lengths, and chunks whose values exceed their byte lengths are rejected.
+func Decode(s string) ([]byte, error) {
+	n, err := DecodedLen(len(s))
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, 0, n)
+	full := charsPerChunk[maxChunkLen]
+	for offset := 0; offset < len(s); offset += full {
+		charsLen := min(full, len(s)-offset)
+		chunkLen := 0
+		for n, l := range charsPerChunk {
+			if l == charsLen {
+				chunkLen = n
+			}
+		}
+		var v uint32
+		for n := charsLen - 1; 0 <= n; n-- {
+			idx := strings.IndexByte(Alphabet, s[offset+n])
+			if idx < 0 {
+				return nil, fmt.Errorf("%w base38 character (%q) at offset %d", ErrInvalid, s[offset+n], offset+n)
+			}
+			v = v*radix + uint32(idx)
+		}
+		if (v >> (chunkLen * 8)) != 0 {
+			return nil, fmt.Errorf("%w base38 chunk (%s)", ErrInvalid, s[offset:offset+charsLen])
+		}
+		for n := 0; n < chunkLen; n++ {
+			b = append(b, byte(v>>(n*8)))
+		}
+	}
+	return b, nil
+}
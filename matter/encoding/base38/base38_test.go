@@ -0,0 +1,89 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package base38
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"testing/quick"
+)
+
+func TestBase38(t *testing.T) {
+	tests := []struct {
+		b []byte
+		s string
+	}{
+		{[]byte{}, ""},
+		{[]byte{0x00}, "00"},
+		{[]byte{0xFF}, "R6"},
+		{[]byte{0x00, 0x00}, "0000"},
+		{[]byte{0xFF, 0xFF}, "NE71"},
+		{[]byte{0x00, 0x00, 0x00}, "00000"},
+		{[]byte{0xFF, 0xFF, 0xFF}, "PLS18"},
+		{[]byte{0xFF, 0xFF, 0xFF, 0xFF}, "PLS18R6"},
+	}
+	for _, test := range tests {
+		s := Encode(test.b)
+		if s != test.s {
+			t.Errorf("%X : %s != %s", test.b, s, test.s)
+		}
+		if len(s) != EncodedLen(len(test.b)) {
+			t.Errorf("%X : length (%d) != (%d)", test.b, len(s), EncodedLen(len(test.b)))
+		}
+		b, err := Decode(test.s)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !bytes.Equal(b, test.b) {
+			t.Errorf("%s : %X != %X", test.s, b, test.b)
+		}
+	}
+}
+
+func TestBase38Errors(t *testing.T) {
+	tests := []string{
+		// Invalid lengths
+		"0",
+		"000",
+		"000000",
+		"00000000",
+		// Characters out of the alphabet
+		"a0",
+		"00 00",
+		"0*",
+		// Chunks out of range
+		"S6",
+		"OE71",
+		"QLS18",
+		"..",
+	}
+	for _, test := range tests {
+		if _, err := Decode(test); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%q : %v is not %v", test, err, ErrInvalid)
+		}
+	}
+}
+
+func TestBase38RoundTrip(t *testing.T) {
+	roundTrip := func(b []byte) bool {
+		decoded, err := Decode(Encode(b))
+		return err == nil && bytes.Equal(decoded, b)
+	}
+	if err := quick.Check(roundTrip, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlv
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// Decoder represents a TLV decoder which reads elements from a byte sequence.
+type Decoder struct {
+	b      []byte
+	offset int
+	depth  int
+}
+
+// NewDecoder returns a new decoder for the specified bytes.
+func NewDecoder(b []byte) *Decoder {
+	return &Decoder{
+		b:      b,
+		offset: 0,
+		depth:  0,
+	}
+}
+
+// Offset returns the current byte offset.
+func (dec *Decoder) Offset() int {
+	return dec.offset
+}
+
+// Depth returns the current container depth.
+func (dec *Decoder) Depth() int {
+	return dec.depth
+}
+
+// More returns true if there are remaining bytes to decode.
+func (dec *Decoder) More() bool {
+	return dec.offset < len(dec.b)
+}
+
+func (dec *Decoder) read(n int) ([]byte, error) {
+	if n < 0 || len(dec.b)-dec.offset < n {
+		return nil, fmt.Errorf("%w : %d bytes at offset %d", ErrShortBuffer, n, dec.offset)
+	}
+	b := dec.b[dec.offset : dec.offset+n]
+	dec.offset += n
+	return b, nil
+}
+
+func (dec *Decoder) readUint(n int) (uint64, error) {
+	b, err := dec.read(n)
+	if err != nil {
+		return 0, err
+	}
+	var v uint64
+	for i := n - 1; 0 <= i; i-- {
+		v = (v << 8) | uint64(b[i])
+	}
+	return v, nil
+}
+
+func (dec *Decoder) readTag(ctrl TagControl) (Tag, error) {
+	tag := NewAnonymousTag()
+	tag.control = ctrl
+	switch ctrl {
+	case AnonymousTagControl:
+		return tag, nil
+	case FullyQualified6TagControl, FullyQualified8TagControl:
+		vid, err := dec.readUint(profileTagSize2)
+		if err != nil {
+			return tag, err
+		}
+		pid, err := dec.readUint(profileTagSize2)
+		if err != nil {
+			return tag, err
+		}
+		tag.vendorID = types.VendorID(vid)
+		tag.profileID = uint16(pid)
+	}
+	n, err := dec.readUint(tagNumberSize(ctrl))
+	if err != nil {
+		return tag, err
+	}
+	tag.number = uint32(n)
+	return tag, nil
+}
+
+// tagNumberSize returns the byte size of the tag number field.
+func tagNumberSize(ctrl TagControl) int {
+	switch ctrl {
+	case FullyQualified6TagControl, FullyQualified8TagControl:
+		return ctrl.tagSize() - fullyQualifiedTagProfileSize
+	}
+	return ctrl.tagSize()
+}
+
+// Next decodes and returns the next element.
+// Containers are returned as a single element, and their members are returned by the following calls.
+func (dec *Decoder) Next() (*Element, error) {
+	ctrlByte, err := dec.read(1)
+	if err != nil {
+		return nil, err
+	}
+
+	typ := ElementType(ctrlByte[0] & elementTypeMask)
+	ctrl := TagControl((ctrlByte[0] & tagControlMask) >> tagControlShift)
+
+	if EndOfContainer < typ {
+		return nil, fmt.Errorf("%w element type (0x%02X) at offset %d", ErrInvalid, uint8(typ), dec.offset-1)
+	}
+
+	tag, err := dec.readTag(ctrl)
+	if err != nil {
+		return nil, err
+	}
+
+	elem := &Element{
+		tag:   tag,
+		typ:   typ,
+		value: nil,
+	}
+
+	switch {
+	case typ.IsSigned():
+		v, err := dec.readUint(typ.valueSize())
+		if err != nil {
+			return nil, err
+		}
+		shift := 64 - (typ.valueSize() * 8)
+		elem.value = int64(v<<shift) >> shift
+	case typ.IsUnsigned():
+		v, err := dec.readUint(typ.valueSize())
+		if err != nil {
+			return nil, err
+		}
+		elem.value = v
+	case typ.IsBoolean():
+		elem.value = (typ == BooleanTrue)
+	case typ == Float4:
+		v, err := dec.readUint(typ.valueSize())
+		if err != nil {
+			return nil, err
+		}
+		elem.value = math.Float32frombits(uint32(v))
+	case typ == Float8:
+		v, err := dec.readUint(typ.valueSize())
+		if err != nil {
+			return nil, err
+		}
+		elem.value = math.Float64frombits(v)
+	case typ.IsUTF8String(), typ.IsOctetString():
+		l, err := dec.readUint(typ.valueSize())
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(dec.b)-dec.offset) < l {
+			return nil, fmt.Errorf("%w : %d bytes at offset %d", ErrShortBuffer, l, dec.offset)
+		}
+		b, err := dec.read(int(l))
+		if err != nil {
+			return nil, err
+		}
+		if typ.IsOctetString() {
+			elem.value = append([]byte{}, b...)
+			break
+		}
+		s, err := validateUTF8String(b)
+		if err != nil {
+			return nil, err
+		}
+		elem.value = s
+	case typ.IsContainer():
+		dec.depth++
+	case typ == EndOfContainer:
+		if ctrl != AnonymousTagControl {
+			return nil, fmt.Errorf("%w end of container tag (%s)", ErrInvalid, tag)
+		}
+		if dec.depth <= 0 {
+			return nil, fmt.Errorf("%w end of container at offset %d", ErrInvalid, dec.offset-1)
+		}
+		dec.depth--
+	}
+
+	return elem, nil
+}
+
+// Skip skips the remaining members of the current container including its end of container.
+func (dec *Decoder) Skip() error {
+	depth := dec.depth
+	for depth <= dec.depth {
+		elem, err := dec.Next()
+		if err != nil {
+			return err
+		}
+		if elem.IsEndOfContainer() && dec.depth < depth {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlv
+
+import (
+	"fmt"
+	"math"
+	"unicode/utf8"
+)
+
+// Element represents a decoded TLV element.
+type Element struct {
+	tag   Tag
+	typ   ElementType
+	value any
+}
+
+// Tag returns the element tag.
+func (elem *Element) Tag() Tag {
+	return elem.tag
+}
+
+// Type returns the element type.
+func (elem *Element) Type() ElementType {
+	return elem.typ
+}
+
+// Value returns the decoded value which is an int64, uint64, bool, float32, float64, string, []byte or nil.
+func (elem *Element) Value() any {
+	return elem.value
+}
+
+// IsNull returns true if the element is a null.
+func (elem *Element) IsNull() bool {
+	return elem.typ == Null
+}
+
+// IsContainer returns true if the element starts a container.
+func (elem *Element) IsContainer() bool {
+	return elem.typ.IsContainer()
+}
+
+// IsEndOfContainer returns true if the element ends a container.
+func (elem *Element) IsEndOfContainer() bool {
+	return elem.typ == EndOfContainer
+}
+
+// Signed returns the signed integer value.
+// Unsigned integer values are accepted if they fit in an int64.
+func (elem *Element) Signed() (int64, error) {
+	switch v := elem.value.(type) {
+	case int64:
+		return v, nil
+	case uint64:
+		if v <= math.MaxInt64 {
+			return int64(v), nil
+		}
+	}
+	return 0, elem.typeError("signed integer")
+}
+
+// Unsigned returns the unsigned integer value of any width.
+func (elem *Element) Unsigned() (uint64, error) {
+	if v, ok := elem.value.(uint64); ok {
+		return v, nil
+	}
+	return 0, elem.typeError("unsigned integer")
+}
+
+// Bool returns the boolean value.
+func (elem *Element) Bool() (bool, error) {
+	if v, ok := elem.value.(bool); ok {
+		return v, nil
+	}
+	return false, elem.typeError("boolean")
+}
+
+// Float returns the floating point value.
+func (elem *Element) Float() (float64, error) {
+	switch v := elem.value.(type) {
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	}
+	return 0, elem.typeError("floating point")
+}
+
+// String returns the UTF-8 string value.
+func (elem *Element) String() (string, error) {
+	if v, ok := elem.value.(string); ok {
+		return v, nil
+	}
+	return "", elem.typeError("UTF-8 string")
+}
+
+// Bytes returns the octet string value.
+func (elem *Element) Bytes() ([]byte, error) {
+	if v, ok := elem.value.([]byte); ok {
+		return v, nil
+	}
+	return nil, elem.typeError("octet string")
+}
+
+func (elem *Element) typeError(expected string) error {
+	return fmt.Errorf("%w element type (%s) : expected %s", ErrInvalid, elem.typ, expected)
+}
+
+func validateUTF8String(b []byte) (string, error) {
+	if !utf8.Valid(b) {
+		return "", fmt.Errorf("%w UTF-8 string", ErrInvalid)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,165 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlv
+
+import (
+	"math"
+)
+
+// Encoder represents a TLV encoder which writes elements into a byte sequence.
+type Encoder struct {
+	b     []byte
+	depth int
+}
+
+// NewEncoder returns a new encoder.
+func NewEncoder() *Encoder {
+	return &Encoder{
+		b:     []byte{},
+		depth: 0,
+	}
+}
+
+// Bytes returns the encoded bytes.
+func (enc *Encoder) Bytes() []byte {
+	return enc.b
+}
+
+// Depth returns the current container depth.
+func (enc *Encoder) Depth() int {
+	return enc.depth
+}
+
+func (enc *Encoder) putUint(v uint64, n int) {
+	for i := 0; i < n; i++ {
+		enc.b = append(enc.b, byte(v>>(i*8)))
+	}
+}
+
+func (enc *Encoder) putControl(tag Tag, typ ElementType) {
+	enc.b = append(enc.b, (byte(tag.control)<<tagControlShift)|byte(typ))
+	switch tag.control {
+	case AnonymousTagControl:
+		return
+	case FullyQualified6TagControl, FullyQualified8TagControl:
+		enc.putUint(uint64(tag.vendorID), profileTagSize2)
+		enc.putUint(uint64(tag.profileID), profileTagSize2)
+	}
+	enc.putUint(uint64(tag.number), tagNumberSize(tag.control))
+}
+
+// unsignedSize returns the minimal byte size and the size index for the specified value.
+func unsignedSize(v uint64) (int, ElementType) {
+	switch {
+	case v <= math.MaxUint8:
+		return 1, 0
+	case v <= math.MaxUint16:
+		return 2, 1
+	case v <= math.MaxUint32:
+		return 4, 2
+	}
+	return 8, 3
+}
+
+// PutSigned writes a signed integer using the minimal width.
+func (enc *Encoder) PutSigned(tag Tag, v int64) {
+	var n int
+	var idx ElementType
+	switch {
+	case math.MinInt8 <= v && v <= math.MaxInt8:
+		n, idx = 1, 0
+	case math.MinInt16 <= v && v <= math.MaxInt16:
+		n, idx = 2, 1
+	case math.MinInt32 <= v && v <= math.MaxInt32:
+		n, idx = 4, 2
+	default:
+		n, idx = 8, 3
+	}
+	enc.putControl(tag, Signed1+idx)
+	enc.putUint(uint64(v), n)
+}
+
+// PutUnsigned writes an unsigned integer using the minimal width.
+func (enc *Encoder) PutUnsigned(tag Tag, v uint64) {
+	n, idx := unsignedSize(v)
+	enc.putControl(tag, Unsigned1+idx)
+	enc.putUint(v, n)
+}
+
+// PutBool writes a boolean.
+func (enc *Encoder) PutBool(tag Tag, v bool) {
+	if v {
+		enc.putControl(tag, BooleanTrue)
+		return
+	}
+	enc.putControl(tag, BooleanFalse)
+}
+
+// PutFloat32 writes a single precision floating point number.
+func (enc *Encoder) PutFloat32(tag Tag, v float32) {
+	enc.putControl(tag, Float4)
+	enc.putUint(uint64(math.Float32bits(v)), 4)
+}
+
+// PutFloat64 writes a double precision floating point number.
+func (enc *Encoder) PutFloat64(tag Tag, v float64) {
+	enc.putControl(tag, Float8)
+	enc.putUint(math.Float64bits(v), 8)
+}
+
+// PutUTF8String writes a UTF-8 string.
+func (enc *Encoder) PutUTF8String(tag Tag, v string) {
+	n, idx := unsignedSize(uint64(len(v)))
+	enc.putControl(tag, UTF8String1+idx)
+	enc.putUint(uint64(len(v)), n)
+	enc.b = append(enc.b, v...)
+}
+
+// PutOctetString writes an octet string.
+func (enc *Encoder) PutOctetString(tag Tag, v []byte) {
+	n, idx := unsignedSize(uint64(len(v)))
+	enc.putControl(tag, OctetString1+idx)
+	enc.putUint(uint64(len(v)), n)
+	enc.b = append(enc.b, v...)
+}
+
+// PutNull writes a null.
+func (enc *Encoder) PutNull(tag Tag) {
+	enc.putControl(tag, Null)
+}
+
+// StartStructure starts a structure container.
+func (enc *Encoder) StartStructure(tag Tag) {
+	enc.putControl(tag, Structure)
+	enc.depth++
+}
+
+// StartArray starts an array container.
+func (enc *Encoder) StartArray(tag Tag) {
+	enc.putControl(tag, Array)
+	enc.depth++
+}
+
+// StartList starts a list container.
+func (enc *Encoder) StartList(tag Tag) {
+	enc.putControl(tag, List)
+	enc.depth++
+}
+
+// EndContainer ends the current container.
+func (enc *Encoder) EndContainer() {
+	enc.putControl(NewAnonymousTag(), EndOfContainer)
+	enc.depth--
+}
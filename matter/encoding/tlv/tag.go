@@ -0,0 +1,180 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlv
+
+import (
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// A.8. Tag Control Field
+// TagControl represents a TLV tag control.
+type TagControl uint8
+
+const (
+	AnonymousTagControl        TagControl = 0x00
+	ContextSpecificTagControl  TagControl = 0x01
+	CommonProfile2TagControl   TagControl = 0x02
+	CommonProfile4TagControl   TagControl = 0x03
+	ImplicitProfile2TagControl TagControl = 0x04
+	ImplicitProfile4TagControl TagControl = 0x05
+	FullyQualified6TagControl  TagControl = 0x06
+	FullyQualified8TagControl  TagControl = 0x07
+)
+
+const (
+	contextSpecificTagSize       = 1
+	profileTagSize2              = 2
+	profileTagSize4              = 4
+	fullyQualifiedTagProfileSize = 4
+)
+
+// tagSize returns the byte size of the tag field.
+func (ctrl TagControl) tagSize() int {
+	switch ctrl {
+	case ContextSpecificTagControl:
+		return contextSpecificTagSize
+	case CommonProfile2TagControl, ImplicitProfile2TagControl:
+		return profileTagSize2
+	case CommonProfile4TagControl, ImplicitProfile4TagControl:
+		return profileTagSize4
+	case FullyQualified6TagControl:
+		return fullyQualifiedTagProfileSize + profileTagSize2
+	case FullyQualified8TagControl:
+		return fullyQualifiedTagProfileSize + profileTagSize4
+	}
+	return 0
+}
+
+// Tag represents a TLV tag.
+type Tag struct {
+	control   TagControl
+	vendorID  types.VendorID
+	profileID uint16
+	number    uint32
+}
+
+// NewAnonymousTag returns a new anonymous tag.
+func NewAnonymousTag() Tag {
+	return Tag{
+		control:   AnonymousTagControl,
+		vendorID:  0,
+		profileID: 0,
+		number:    0,
+	}
+}
+
+// NewContextTag returns a new context-specific tag.
+func NewContextTag(n uint8) Tag {
+	return Tag{
+		control:   ContextSpecificTagControl,
+		vendorID:  0,
+		profileID: 0,
+		number:    uint32(n),
+	}
+}
+
+// NewCommonProfileTag returns a new common profile tag.
+func NewCommonProfileTag(n uint32) Tag {
+	ctrl := CommonProfile4TagControl
+	if n <= 0xFFFF {
+		ctrl = CommonProfile2TagControl
+	}
+	return Tag{
+		control:   ctrl,
+		vendorID:  0,
+		profileID: 0,
+		number:    n,
+	}
+}
+
+// NewImplicitProfileTag returns a new implicit profile tag.
+func NewImplicitProfileTag(n uint32) Tag {
+	ctrl := ImplicitProfile4TagControl
+	if n <= 0xFFFF {
+		ctrl = ImplicitProfile2TagControl
+	}
+	return Tag{
+		control:   ctrl,
+		vendorID:  0,
+		profileID: 0,
+		number:    n,
+	}
+}
+
+// NewFullyQualifiedTag returns a new fully-qualified tag.
+func NewFullyQualifiedTag(vendorID types.VendorID, profileID uint16, n uint32) Tag {
+	ctrl := FullyQualified8TagControl
+	if n <= 0xFFFF {
+		ctrl = FullyQualified6TagControl
+	}
+	return Tag{
+		control:   ctrl,
+		vendorID:  vendorID,
+		profileID: profileID,
+		number:    n,
+	}
+}
+
+// Control returns the tag control.
+func (tag Tag) Control() TagControl {
+	return tag.control
+}
+
+// IsAnonymous returns true if the tag is anonymous.
+func (tag Tag) IsAnonymous() bool {
+	return tag.control == AnonymousTagControl
+}
+
+// IsContext returns true if the tag is context-specific.
+func (tag Tag) IsContext() bool {
+	return tag.control == ContextSpecificTagControl
+}
+
+// IsContextNumber returns true if the tag is the specified context-specific tag.
+func (tag Tag) IsContextNumber(n uint8) bool {
+	return tag.IsContext() && tag.number == uint32(n)
+}
+
+// VendorID returns the vendor ID of the fully-qualified tag.
+func (tag Tag) VendorID() types.VendorID {
+	return tag.vendorID
+}
+
+// ProfileID returns the profile number of the fully-qualified tag.
+func (tag Tag) ProfileID() uint16 {
+	return tag.profileID
+}
+
+// Number returns the tag number.
+func (tag Tag) Number() uint32 {
+	return tag.number
+}
+
+// String returns the string representation.
+func (tag Tag) String() string {
+	switch tag.control {
+	case AnonymousTagControl:
+		return "anonymous"
+	case ContextSpecificTagControl:
+		return fmt.Sprintf("%d", tag.number)
+	case CommonProfile2TagControl, CommonProfile4TagControl:
+		return fmt.Sprintf("common:%d", tag.number)
+	case ImplicitProfile2TagControl, ImplicitProfile4TagControl:
+		return fmt.Sprintf("implicit:%d", tag.number)
+	}
+	return fmt.Sprintf("0x%04X:0x%04X:%d", tag.vendorID, tag.profileID, tag.number)
+}
@@ -0,0 +1,224 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlv
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// A.12. Appendix A Examples.
+func TestEncoder(t *testing.T) {
+	tests := []struct {
+		name     string
+		encode   func(enc *Encoder)
+		expected string
+		value    any
+	}{
+		{"false", func(enc *Encoder) { enc.PutBool(NewAnonymousTag(), false) }, "08", false},
+		{"true", func(enc *Encoder) { enc.PutBool(NewAnonymousTag(), true) }, "09", true},
+		{"int8 42", func(enc *Encoder) { enc.PutSigned(NewAnonymousTag(), 42) }, "002a", int64(42)},
+		{"int8 -17", func(enc *Encoder) { enc.PutSigned(NewAnonymousTag(), -17) }, "00ef", int64(-17)},
+		{"uint8 42", func(enc *Encoder) { enc.PutUnsigned(NewAnonymousTag(), 42) }, "042a", uint64(42)},
+		{"int16 422", func(enc *Encoder) { enc.PutSigned(NewAnonymousTag(), 422) }, "01a601", int64(422)},
+		{"int32 -170000", func(enc *Encoder) { enc.PutSigned(NewAnonymousTag(), -170000) }, "02f067fdff", int64(-170000)},
+		{"int64 40000000000", func(enc *Encoder) { enc.PutSigned(NewAnonymousTag(), 40000000000) }, "0300902f5009000000", int64(40000000000)},
+		{"uint32 100000", func(enc *Encoder) { enc.PutUnsigned(NewAnonymousTag(), 100000) }, "06a0860100", uint64(100000)},
+		{"utf8 Hello!", func(enc *Encoder) { enc.PutUTF8String(NewAnonymousTag(), "Hello!") }, "0c0648656c6c6f21", "Hello!"},
+		{"utf8 Tschüs", func(enc *Encoder) { enc.PutUTF8String(NewAnonymousTag(), "Tschüs") }, "0c0754736368c3bc73", "Tschüs"},
+		{"octets", func(enc *Encoder) { enc.PutOctetString(NewAnonymousTag(), []byte{0, 1, 2, 3, 4}) }, "10050001020304", []byte{0, 1, 2, 3, 4}},
+		{"null", func(enc *Encoder) { enc.PutNull(NewAnonymousTag()) }, "14", nil},
+		{"float32 0.0", func(enc *Encoder) { enc.PutFloat32(NewAnonymousTag(), 0.0) }, "0a00000000", float32(0.0)},
+		{"float32 1/3", func(enc *Encoder) { enc.PutFloat32(NewAnonymousTag(), 1.0/3.0) }, "0aabaaaa3e", float32(1.0 / 3.0)},
+		{"float64 -inf", func(enc *Encoder) { enc.PutFloat64(NewAnonymousTag(), math.Inf(-1)) }, "0b000000000000f0ff", math.Inf(-1)},
+		{"context 1", func(enc *Encoder) { enc.PutUnsigned(NewContextTag(1), 42) }, "24012a", uint64(42)},
+		{"common 1", func(enc *Encoder) { enc.PutUnsigned(NewCommonProfileTag(1), 42) }, "4401002a", uint64(42)},
+		{"common 100000", func(enc *Encoder) { enc.PutUnsigned(NewCommonProfileTag(100000), 42) }, "64a08601002a", uint64(42)},
+		{"implicit 1", func(enc *Encoder) { enc.PutUnsigned(NewImplicitProfileTag(1), 42) }, "8401002a", uint64(42)},
+		{"fully-qualified 1", func(enc *Encoder) { enc.PutUnsigned(NewFullyQualifiedTag(0xFFF1, 0xDEED, 1), 42) }, "c4f1ffedde01002a", uint64(42)},
+		{"fully-qualified 0xAA55FEED", func(enc *Encoder) { enc.PutUnsigned(NewFullyQualifiedTag(0xFFF1, 0xDEED, 0xAA55FEED), 42) }, "e4f1ffeddeedfe55aa2a", uint64(42)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			enc := NewEncoder()
+			test.encode(enc)
+			expected, _ := hex.DecodeString(test.expected)
+			if !bytes.Equal(enc.Bytes(), expected) {
+				t.Errorf("%x != %s", enc.Bytes(), test.expected)
+			}
+
+			dec := NewDecoder(enc.Bytes())
+			elem, err := dec.Next()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if !reflect.DeepEqual(elem.Value(), test.value) {
+				t.Errorf("%v != %v", elem.Value(), test.value)
+			}
+			if dec.More() {
+				t.Errorf("%d bytes remain", len(enc.Bytes())-dec.Offset())
+			}
+		})
+	}
+}
+
+func TestContainer(t *testing.T) {
+	enc := NewEncoder()
+	enc.StartStructure(NewAnonymousTag())
+	enc.PutSigned(NewContextTag(0), 42)
+	enc.PutSigned(NewContextTag(1), -17)
+	enc.StartArray(NewContextTag(2))
+	enc.PutUnsigned(NewAnonymousTag(), 1)
+	enc.EndContainer()
+	enc.EndContainer()
+
+	expected := "1520002a2001ef360204011818"
+	if hex.EncodeToString(enc.Bytes()) != expected {
+		t.Errorf("%x != %s", enc.Bytes(), expected)
+	}
+
+	dec := NewDecoder(enc.Bytes())
+	elem, err := dec.Next()
+	if err != nil || elem.Type() != Structure {
+		t.Errorf("%v %v", elem, err)
+		return
+	}
+	elem, err = dec.Next()
+	if err != nil || !elem.Tag().IsContextNumber(0) {
+		t.Errorf("%v %v", elem, err)
+		return
+	}
+	if err := dec.Skip(); err != nil {
+		t.Error(err)
+		return
+	}
+	if dec.More() || dec.Depth() != 0 {
+		t.Errorf("%d bytes remain at depth %d", len(enc.Bytes())-dec.Offset(), dec.Depth())
+	}
+}
+
+func TestInvalidDecoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoded  string
+		expected error
+	}{
+		{"truncated integer", "01a6", ErrShortBuffer},
+		{"truncated string", "0c0648656c", ErrShortBuffer},
+		{"truncated tag", "c4f1ff", ErrShortBuffer},
+		{"reserved type", "19", ErrInvalid},
+		{"unbalanced end", "18", ErrInvalid},
+		{"invalid utf8", "0c02c328", ErrInvalid},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, _ := hex.DecodeString(test.encoded)
+			dec := NewDecoder(b)
+			_, err := dec.Next()
+			if !errors.Is(err, test.expected) {
+				t.Errorf("%v != %v", err, test.expected)
+			}
+		})
+	}
+}
+
+type testCelsius float64
+
+func (c *testCelsius) Decode(dec *Decoder, elem *Element) error {
+	v, err := elem.Signed()
+	if err != nil {
+		return err
+	}
+	*c = testCelsius(float64(v) / 100)
+	return nil
+}
+
+func TestUnmarshal(t *testing.T) {
+	type target struct {
+		ID       uint16      `tlv:"0"`
+		Offset   int8        `tlv:"1"`
+		Label    string      `tlv:"2"`
+		Nullable *uint8      `tlv:"3"`
+		Values   []uint32    `tlv:"4"`
+		Key      []byte      `tlv:"5"`
+		Temp     testCelsius `tlv:"6"`
+		Null     []byte      `tlv:"8"`
+		Nested   *struct {
+			On bool `tlv:"0"`
+		} `tlv:"7"`
+		Skipped string
+	}
+
+	enc := NewEncoder()
+	enc.StartStructure(NewAnonymousTag())
+	enc.PutUnsigned(NewContextTag(0), 0x1234)
+	enc.PutSigned(NewContextTag(1), -5)
+	enc.PutUTF8String(NewContextTag(2), "kitchen")
+	enc.PutNull(NewContextTag(3))
+	enc.StartArray(NewContextTag(4))
+	enc.PutUnsigned(NewAnonymousTag(), 6)
+	enc.PutUnsigned(NewAnonymousTag(), 8)
+	enc.EndContainer()
+	enc.PutOctetString(NewContextTag(5), []byte{0xCA, 0xFE})
+	enc.PutNull(NewContextTag(8))
+	enc.PutSigned(NewContextTag(6), 2150)
+	enc.StartStructure(NewContextTag(7))
+	enc.PutBool(NewContextTag(0), true)
+	enc.EndContainer()
+	enc.StartList(NewContextTag(9))
+	enc.PutBool(NewContextTag(0), false)
+	enc.EndContainer()
+	enc.EndContainer()
+
+	level := uint8(1)
+	v := target{Nullable: &level, Null: []byte{0x01}, Skipped: "kept"}
+	if err := Unmarshal(enc.Bytes(), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.ID != 0x1234 || v.Offset != -5 || v.Label != "kitchen" || v.Nullable != nil || v.Null != nil || v.Skipped != "kept" {
+		t.Errorf("%+v", v)
+	}
+	if len(v.Values) != 2 || v.Values[1] != 8 || len(v.Key) != 2 || v.Temp != 21.5 {
+		t.Errorf("%+v", v)
+	}
+	if v.Nested == nil || !v.Nested.On {
+		t.Errorf("nested %+v", v.Nested)
+	}
+
+	enc = NewEncoder()
+	enc.PutUnsigned(NewAnonymousTag(), 300)
+	var u8 uint8
+	if err := Unmarshal(enc.Bytes(), &u8); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+	var str string
+	if err := Unmarshal(enc.Bytes(), &str); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+	if err := Unmarshal(enc.Bytes(), u8); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+	var u16 uint16
+	if err := Unmarshal(enc.Bytes(), &u16); err != nil || u16 != 300 {
+		t.Errorf("%d %v", u16, err)
+	}
+}
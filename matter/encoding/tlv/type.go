@@ -0,0 +1,136 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlv
+
+import (
+	"fmt"
+)
+
+// A.7. Element Type Field
+// ElementType represents a TLV element type.
+type ElementType uint8
+
+const (
+	Signed1        ElementType = 0x00
+	Signed2        ElementType = 0x01
+	Signed4        ElementType = 0x02
+	Signed8        ElementType = 0x03
+	Unsigned1      ElementType = 0x04
+	Unsigned2      ElementType = 0x05
+	Unsigned4      ElementType = 0x06
+	Unsigned8      ElementType = 0x07
+	BooleanFalse   ElementType = 0x08
+	BooleanTrue    ElementType = 0x09
+	Float4         ElementType = 0x0A
+	Float8         ElementType = 0x0B
+	UTF8String1    ElementType = 0x0C
+	UTF8String2    ElementType = 0x0D
+	UTF8String4    ElementType = 0x0E
+	UTF8String8    ElementType = 0x0F
+	OctetString1   ElementType = 0x10
+	OctetString2   ElementType = 0x11
+	OctetString4   ElementType = 0x12
+	OctetString8   ElementType = 0x13
+	Null           ElementType = 0x14
+	Structure      ElementType = 0x15
+	Array          ElementType = 0x16
+	List           ElementType = 0x17
+	EndOfContainer ElementType = 0x18
+)
+
+const (
+	elementTypeMask = 0x1F
+	tagControlMask  = 0xE0
+	tagControlShift = 5
+)
+
+// IsSigned returns true if the element type is a signed integer.
+func (t ElementType) IsSigned() bool {
+	return Signed1 <= t && t <= Signed8
+}
+
+// IsUnsigned returns true if the element type is an unsigned integer.
+func (t ElementType) IsUnsigned() bool {
+	return Unsigned1 <= t && t <= Unsigned8
+}
+
+// IsBoolean returns true if the element type is a boolean.
+func (t ElementType) IsBoolean() bool {
+	return t == BooleanFalse || t == BooleanTrue
+}
+
+// IsFloat returns true if the element type is a floating point number.
+func (t ElementType) IsFloat() bool {
+	return t == Float4 || t == Float8
+}
+
+// IsUTF8String returns true if the element type is a UTF-8 string.
+func (t ElementType) IsUTF8String() bool {
+	return UTF8String1 <= t && t <= UTF8String8
+}
+
+// IsOctetString returns true if the element type is an octet string.
+func (t ElementType) IsOctetString() bool {
+	return OctetString1 <= t && t <= OctetString8
+}
+
+// IsContainer returns true if the element type is a structure, an array or a list.
+func (t ElementType) IsContainer() bool {
+	return t == Structure || t == Array || t == List
+}
+
+// valueSize returns the byte size of the fixed-length value or the length field.
+func (t ElementType) valueSize() int {
+	switch {
+	case t.IsSigned(), t.IsUnsigned(), t.IsUTF8String(), t.IsOctetString():
+		return 1 << (t & 0x03)
+	case t == Float4:
+		return 4
+	case t == Float8:
+		return 8
+	}
+	return 0
+}
+
+// String returns the string representation.
+func (t ElementType) String() string {
+	switch {
+	case t.IsSigned():
+		return fmt.Sprintf("int%d", t.valueSize()*8)
+	case t.IsUnsigned():
+		return fmt.Sprintf("uint%d", t.valueSize()*8)
+	case t.IsBoolean():
+		return "bool"
+	case t.IsFloat():
+		return fmt.Sprintf("float%d", t.valueSize()*8)
+	case t.IsUTF8String():
+		return "utf8"
+	case t.IsOctetString():
+		return "octets"
+	}
+	switch t {
+	case Null:
+		return "null"
+	case Structure:
+		return "struct"
+	case Array:
+		return "array"
+	case List:
+		return "list"
+	case EndOfContainer:
+		return "end"
+	}
+	return fmt.Sprintf("unknown(0x%02X)", uint8(t))
+}
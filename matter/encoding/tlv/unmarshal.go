@@ -0,0 +1,222 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlv
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Unmarshaler is implemented by the types which decode themselves from an element and its members,
+// such as the values of the datatype package.
+type Unmarshaler interface {
+	// Decode decodes the element, and the members from the decoder if the element is a container.
+	Decode(dec *Decoder, elem *Element) error
+}
+
+// Unmarshal decodes the TLV encoded element into the value pointed to by v.
+//
+// Booleans, integers, floats, strings and byte slices receive the elements of the matching types,
+// and integers which overflow the Go types are rejected. Slices receive arrays and lists. Structs
+// receive structures, whose members are stored into the fields tagged with their context tag
+// numbers such as `tlv:"1"`; members without a tagged field are skipped, and fields without a
+// member are left untouched. Pointers and slices receive nulls as nil, so nullable values should be
+// pointers.
+// Values implementing Unmarshaler decode themselves.
+func Unmarshal(b []byte, v any) error {
+	dec := NewDecoder(b)
+	elem, err := dec.Next()
+	if err != nil {
+		return err
+	}
+	if err := UnmarshalElement(dec, elem, v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return fmt.Errorf("%w : trailing bytes at offset %d", ErrInvalid, dec.Offset())
+	}
+	return nil
+}
+
+// UnmarshalElement decodes the element, and its members from the decoder if it is a container,
+// into the value pointed to by v like Unmarshal.
+func UnmarshalElement(dec *Decoder, elem *Element, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("%w unmarshal target (%T) : not a pointer", ErrInvalid, v)
+	}
+	return unmarshal(dec, elem, rv.Elem())
+}
+
+var unmarshalerType = reflect.TypeFor[Unmarshaler]()
+
+func unmarshal(dec *Decoder, elem *Element, rv reflect.Value) error {
+	if rv.CanAddr() && rv.Addr().Type().Implements(unmarshalerType) {
+		return rv.Addr().Interface().(Unmarshaler).Decode(dec, elem)
+	}
+
+	if rv.Kind() == reflect.Pointer {
+		if elem.IsNull() {
+			rv.SetZero()
+			return nil
+		}
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return unmarshal(dec, elem, rv.Elem())
+	}
+	if elem.IsNull() {
+		if rv.Kind() == reflect.Slice {
+			rv.SetZero()
+			return nil
+		}
+		return fmt.Errorf("%w : null into %s", ErrInvalid, rv.Type())
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		v, err := elem.Bool()
+		if err != nil {
+			return err
+		}
+		rv.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := elem.Signed()
+		if err != nil {
+			return err
+		}
+		if rv.OverflowInt(v) {
+			return fmt.Errorf("%w : %d overflows %s", ErrInvalid, v, rv.Type())
+		}
+		rv.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := elem.Unsigned()
+		if err != nil {
+			return err
+		}
+		if rv.OverflowUint(v) {
+			return fmt.Errorf("%w : %d overflows %s", ErrInvalid, v, rv.Type())
+		}
+		rv.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := elem.Float()
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(v)
+	case reflect.String:
+		v, err := elem.String()
+		if err != nil {
+			return err
+		}
+		rv.SetString(v)
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 && !elem.IsContainer() {
+			v, err := elem.Bytes()
+			if err != nil {
+				return err
+			}
+			rv.SetBytes(v)
+			return nil
+		}
+		return unmarshalSlice(dec, elem, rv)
+	case reflect.Struct:
+		return unmarshalStruct(dec, elem, rv)
+	default:
+		return fmt.Errorf("%w : %s element into %s", ErrInvalid, elem.Type(), rv.Type())
+	}
+	return nil
+}
+
+func unmarshalSlice(dec *Decoder, elem *Element, rv reflect.Value) error {
+	if elem.Type() != Array && elem.Type() != List {
+		return elem.typeError(rv.Type().String())
+	}
+	slice := reflect.MakeSlice(rv.Type(), 0, 0)
+	for {
+		member, err := dec.Next()
+		if err != nil {
+			return err
+		}
+		if member.IsEndOfContainer() {
+			break
+		}
+		v := reflect.New(rv.Type().Elem()).Elem()
+		if err := unmarshal(dec, member, v); err != nil {
+			return fmt.Errorf("element (%d) : %w", slice.Len(), err)
+		}
+		slice = reflect.Append(slice, v)
+	}
+	rv.Set(slice)
+	return nil
+}
+
+// structFields returns the indexes of the fields keyed by the context tag numbers of their tags.
+func structFields(typ reflect.Type) (map[uint8]int, error) {
+	fields := map[uint8]int{}
+	for n := 0; n < typ.NumField(); n++ {
+		field := typ.Field(n)
+		tag, ok := field.Tag.Lookup("tlv")
+		if !ok || !field.IsExported() {
+			continue
+		}
+		tag, _, _ = strings.Cut(tag, ",")
+		num, err := strconv.ParseUint(tag, 0, 8)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s.%s tag (%s) : %w", ErrInvalid, typ, field.Name, tag, err)
+		}
+		if _, ok := fields[uint8(num)]; ok {
+			return nil, fmt.Errorf("%w %s.%s tag (%s) : duplicated", ErrInvalid, typ, field.Name, tag)
+		}
+		fields[uint8(num)] = n
+	}
+	return fields, nil
+}
+
+func unmarshalStruct(dec *Decoder, elem *Element, rv reflect.Value) error {
+	if elem.Type() != Structure {
+		return elem.typeError(rv.Type().String())
+	}
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return err
+	}
+	for {
+		member, err := dec.Next()
+		if err != nil {
+			return err
+		}
+		if member.IsEndOfContainer() {
+			return nil
+		}
+		n, ok := -1, member.Tag().IsContext()
+		if ok {
+			n, ok = fields[uint8(member.Tag().Number())]
+		}
+		if !ok {
+			if member.IsContainer() {
+				if err := dec.Skip(); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := unmarshal(dec, member, rv.Field(n)); err != nil {
+			return fmt.Errorf("%s.%s : %w", rv.Type(), rv.Type().Field(n).Name, err)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package verhoeff implements the Verhoeff check digit algorithm, which detects all single
+// digit errors and all transpositions of adjacent digits in decimal strings.
+// It is used by the manual pairing codes, and is usable for other user-entered numbers.
+package verhoeff
+
+import (
+	"fmt"
+)
+
+// 5.1.4.1.1. Check Digit
+// The Verhoeff algorithm tables.
+var (
+	// d is the multiplication table of the dihedral group D5.
+	d = [10][10]uint8{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{1, 2, 3, 4, 0, 6, 7, 8, 9, 5},
+		{2, 3, 4, 0, 1, 7, 8, 9, 5, 6},
+		{3, 4, 0, 1, 2, 8, 9, 5, 6, 7},
+		{4, 0, 1, 2, 3, 9, 5, 6, 7, 8},
+		{5, 9, 8, 7, 6, 0, 4, 3, 2, 1},
+		{6, 5, 9, 8, 7, 1, 0, 4, 3, 2},
+		{7, 6, 5, 9, 8, 2, 1, 0, 4, 3},
+		{8, 7, 6, 5, 9, 3, 2, 1, 0, 4},
+		{9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+	}
+	// p is the permutation table applied to each position.
+	p = [8][10]uint8{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{1, 5, 7, 6, 2, 8, 3, 0, 9, 4},
+		{5, 8, 0, 3, 7, 9, 6, 1, 4, 2},
+		{8, 9, 1, 6, 0, 4, 3, 5, 2, 7},
+		{9, 4, 5, 3, 1, 2, 6, 8, 7, 0},
+		{4, 2, 8, 6, 5, 7, 3, 9, 0, 1},
+		{2, 7, 9, 3, 8, 0, 6, 4, 1, 5},
+		{7, 0, 4, 6, 9, 1, 3, 2, 5, 8},
+	}
+	// inv is the inverse table of the dihedral group D5.
+	inv = [10]uint8{0, 4, 3, 2, 1, 5, 6, 7, 8, 9}
+)
+
+// Checksum returns the check digit character of the specified decimal string.
+func Checksum(digits string) (byte, error) {
+	var c uint8
+	for n := 0; n < len(digits); n++ {
+		digit := digits[len(digits)-1-n]
+		if digit < '0' || '9' < digit {
+			return 0, fmt.Errorf("%w digit (%q) at offset %d", ErrInvalid, digit, len(digits)-1-n)
+		}
+		c = d[c][p[(n+1)%8][digit-'0']]
+	}
+	return '0' + inv[c], nil
+}
+
+// Append returns the specified decimal string followed by its check digit.
+func Append(digits string) (string, error) {
+	c, err := Checksum(digits)
+	if err != nil {
+		return "", err
+	}
+	return digits + string(c), nil
+}
+
+// Validate returns true if the last digit of the specified decimal string is its valid check digit.
+func Validate(digits string) bool {
+	if len(digits) < 1 {
+		return false
+	}
+	c, err := Checksum(digits[:len(digits)-1])
+	if err != nil {
+		return false
+	}
+	return c == digits[len(digits)-1]
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verhoeff
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestChecksum(t *testing.T) {
+	tests := []struct {
+		digits   string
+		expected byte
+	}{
+		{"", '0'},
+		{"0", '4'},
+		{"236", '3'},
+		{"12345", '1'},
+		{"142857", '0'},
+		{"123456789012", '0'},
+	}
+	for _, test := range tests {
+		c, err := Checksum(test.digits)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if c != test.expected {
+			t.Errorf("%s : %c != %c", test.digits, c, test.expected)
+		}
+		s, err := Append(test.digits)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !Validate(s) {
+			t.Errorf("%s is not valid", s)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		digits string
+		valid  bool
+	}{
+		{"2363", true},
+		{"2364", false},
+		// Single digit errors
+		{"2463", false},
+		// Adjacent transpositions
+		{"3263", false},
+		{"2633", false},
+		{"", false},
+		{"23a3", false},
+		{"236-3", false},
+	}
+	for _, test := range tests {
+		if Validate(test.digits) != test.valid {
+			t.Errorf("%s : valid (%t)", test.digits, !test.valid)
+		}
+	}
+	if _, err := Checksum("12 34"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+}
+
+func TestErrorDetection(t *testing.T) {
+	s, err := Append("34972026")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for n := 0; n < len(s); n++ {
+		for digit := byte('0'); digit <= '9'; digit++ {
+			if digit == s[n] {
+				continue
+			}
+			changed := []byte(s)
+			changed[n] = digit
+			if Validate(string(changed)) {
+				t.Errorf("single digit error (%s) is not detected", changed)
+			}
+		}
+		if n+1 < len(s) && s[n] != s[n+1] {
+			swapped := []byte(s)
+			swapped[n], swapped[n+1] = swapped[n+1], swapped[n]
+			if Validate(string(swapped)) {
+				t.Errorf("transposition (%s) is not detected", swapped)
+			}
+		}
+	}
+}
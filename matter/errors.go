@@ -0,0 +1,36 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	matterr "github.com/cybergarage/go-matter/matter/errors"
+)
+
+var (
+	// ErrNotSupported is returned when a function is not supported.
+	ErrNotSupported = matterr.New(matterr.ErrUnsupported, "not supported")
+	// ErrNotFound is returned when a node is not found.
+	ErrNotFound = matterr.New(matterr.ErrNotFound, "not found")
+	// ErrClosed is returned when an operational device handle has been closed.
+	ErrClosed = matterr.New(matterr.ErrClosed, "closed")
+	// ErrRejected is returned when a node rejects a request with a cluster-specific status.
+	ErrRejected = matterr.New(matterr.ErrRejected, "rejected")
+	// ErrInvalid is returned when a parameter is invalid.
+	ErrInvalid = matterr.New(matterr.ErrInvalidArgument, "invalid")
+	// ErrBusy is returned when a commissioning window is already open.
+	ErrBusy = matterr.New(matterr.ErrRejected, "busy")
+	// ErrSubscriptionTimeout is returned when no report of a subscription is received within its maximum interval.
+	ErrSubscriptionTimeout = matterr.New(matterr.ErrTimeout, "subscription timed out")
+)
@@ -0,0 +1,95 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package errors defines the categories of the errors returned by the packages of the stack.
+//
+// Each package keeps its own errors such as tlv.ErrInvalid, and each of them belongs to
+// a category, so callers check either a specific error or a category with errors.Is:
+//
+//	if errors.Is(err, tlv.ErrInvalid) { ... }
+//	if errors.Is(err, matterr.ErrWire) { ... }
+package errors
+
+import (
+	"errors"
+)
+
+var (
+	// ErrInvalidArgument is the category of the errors of invalid values passed by callers.
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrWire is the category of the errors of malformed data received from peers or read from captures.
+	ErrWire = errors.New("malformed data")
+	// ErrTimeout is the category of the errors of peers which do not respond.
+	ErrTimeout = errors.New("timeout")
+	// ErrUnsupported is the category of the errors of features which are not supported.
+	ErrUnsupported = errors.New("unsupported")
+	// ErrSecurity is the category of the errors of failed authentication or integrity checks.
+	ErrSecurity = errors.New("security")
+	// ErrNotFound is the category of the errors of missing objects.
+	ErrNotFound = errors.New("not found")
+	// ErrClosed is the category of the errors of objects used after they are closed.
+	ErrClosed = errors.New("closed")
+	// ErrRejected is the category of the errors of requests rejected by peers.
+	ErrRejected = errors.New("rejected")
+)
+
+var categories = []error{
+	ErrInvalidArgument,
+	ErrWire,
+	ErrTimeout,
+	ErrUnsupported,
+	ErrSecurity,
+	ErrNotFound,
+	ErrClosed,
+	ErrRejected,
+}
+
+// Error represents a package-level error which belongs to a category.
+type Error struct {
+	msg      string
+	category error
+}
+
+// New returns a new error of the specified message in the specified category.
+func New(category error, msg string) error {
+	return &Error{
+		msg:      msg,
+		category: category,
+	}
+}
+
+// Error returns the message.
+func (err *Error) Error() string {
+	return err.msg
+}
+
+// Unwrap returns the category.
+func (err *Error) Unwrap() error {
+	return err.category
+}
+
+// Category returns the category.
+func (err *Error) Category() error {
+	return err.category
+}
+
+// CategoryOf returns the category of the specified error, or nil if it has no category.
+func CategoryOf(err error) error {
+	for _, category := range categories {
+		if errors.Is(err, category) {
+			return category
+		}
+	}
+	return nil
+}
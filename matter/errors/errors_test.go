@@ -0,0 +1,45 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCategory(t *testing.T) {
+	errInvalid := New(ErrWire, "invalid")
+	err := fmt.Errorf("%w header (%d)", errInvalid, 1)
+	if !errors.Is(err, errInvalid) || !errors.Is(err, ErrWire) {
+		t.Errorf("%v is not %v in %v", err, errInvalid, ErrWire)
+	}
+	if errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("%v is %v", err, ErrInvalidArgument)
+	}
+	if CategoryOf(err) != ErrWire {
+		t.Errorf("category (%v) != (%v)", CategoryOf(err), ErrWire)
+	}
+	var categorized *Error
+	if !errors.As(err, &categorized) || categorized.Category() != ErrWire {
+		t.Errorf("%v is not categorized", err)
+	}
+	if err.Error() != "invalid header (1)" {
+		t.Errorf("message (%s) is changed", err.Error())
+	}
+	if CategoryOf(errors.New("test")) != nil {
+		t.Errorf("uncategorized error has a category")
+	}
+}
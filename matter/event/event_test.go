@@ -0,0 +1,91 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/storage"
+)
+
+const (
+	testClusterID im.ClusterID = 0x0028
+	testEventID   im.EventID   = 0x0000
+)
+
+func TestManager(t *testing.T) {
+	store := storage.NewMemoryStore()
+	mgr, err := NewManager(WithStore(store), WithBufferSize(datamodel.DebugPriority, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	logged := []*Record{}
+	mgr.AddListener(func(rec *Record) { logged = append(logged, rec) })
+
+	node := datamodel.NewNode()
+	node.SetEventHandler(mgr.HandleEvent)
+	ep := datamodel.NewEndpoint(datamodel.RootEndpointID)
+	if err := node.AddEndpoint(ep); err != nil {
+		t.Fatal(err)
+	}
+
+	// Debug events are dropped when the debug buffer is full, while other events are kept.
+	ep.EmitEvent(testClusterID, testEventID, datamodel.CriticalPriority, datatype.NewStruct())
+	for range 3 {
+		ep.EmitEvent(testClusterID, testEventID+1, datamodel.DebugPriority, datatype.NewStruct())
+	}
+	ep.EmitEvent(testClusterID+1, testEventID, datamodel.InfoPriority, datatype.NewStruct())
+	if len(logged) != 5 {
+		t.Fatalf("%d events are logged", len(logged))
+	}
+	for n, rec := range logged {
+		if rec.Number != im.EventNumber(n) {
+			t.Errorf("event number (%d) != %d", rec.Number, n)
+		}
+	}
+
+	all := []im.EventPath{im.NewEventPath(im.WildcardEndpointID, im.WildcardClusterID, im.WildcardEventID)}
+	recs := mgr.Events(all, 0)
+	if len(recs) != 4 || recs[0].Number != 0 || recs[1].Number != 2 || recs[3].Number != 4 {
+		t.Errorf("%v", recs)
+	}
+	if recs := mgr.Events(all, 3); len(recs) != 2 {
+		t.Errorf("%v", recs)
+	}
+	if recs := mgr.Events([]im.EventPath{im.NewEventPath(0, testClusterID, im.WildcardEventID)}, 0); len(recs) != 3 {
+		t.Errorf("%v", recs)
+	}
+
+	// Event numbers increase across reboots.
+	next := mgr.NextNumber()
+	mgr, err = NewManager(WithStore(store))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mgr.NextNumber() <= next {
+		t.Errorf("event number (%d) <= %d", mgr.NextNumber(), next)
+	}
+
+	if _, err := mgr.Emit(&datamodel.Event{
+		Path:     im.NewEventPath(im.WildcardEndpointID, testClusterID, testEventID),
+		Priority: datamodel.InfoPriority,
+		Data:     datatype.NewStruct(),
+	}); err == nil {
+		t.Error("event of a wildcard path is logged")
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/log"
+	"github.com/cybergarage/go-matter/matter/storage"
+)
+
+const (
+	// Namespace is the storage namespace of the event number.
+	Namespace = "events"
+	// NumberKey is the storage key of the event number.
+	NumberKey = "event-number"
+	// DefaultNumberPersistenceStep is the default number of event numbers reserved per storage write.
+	DefaultNumberPersistenceStep = 1000
+)
+
+// Default sizes of the event buffers of each priority.
+const (
+	DefaultDebugBufferSize    = 64
+	DefaultInfoBufferSize     = 64
+	DefaultCriticalBufferSize = 32
+)
+
+// Listener represents a listener which is called after an event is logged.
+type Listener func(rec *Record)
+
+// Option represents an option of the manager.
+type Option func(*Manager)
+
+// WithStore sets the store to persist the event number, so event numbers increase across reboots.
+func WithStore(store storage.Store) Option {
+	return func(mgr *Manager) {
+		mgr.store = store
+	}
+}
+
+// WithBufferSize sets the number of events kept for the specified priority.
+func WithBufferSize(priority datamodel.EventPriority, size int) Option {
+	return func(mgr *Manager) {
+		mgr.sizes[priority] = size
+	}
+}
+
+// WithClock sets the function which returns the current time.
+func WithClock(now func() time.Time) Option {
+	return func(mgr *Manager) {
+		mgr.now = now
+	}
+}
+
+// Manager represents the event log of a node, which assigns event numbers to the emitted events and keeps
+// them in a circular buffer of each priority, so events of higher priorities are kept longer.
+type Manager struct {
+	sync.Mutex
+	store     storage.Store
+	sizes     map[datamodel.EventPriority]int
+	buffers   map[datamodel.EventPriority]*ring
+	number    im.EventNumber
+	limit     im.EventNumber
+	now       func() time.Time
+	listeners []Listener
+}
+
+// NewManager returns a new event manager, which continues the event numbers persisted in the store.
+func NewManager(opts ...Option) (*Manager, error) {
+	mgr := &Manager{
+		Mutex: sync.Mutex{},
+		store: nil,
+		sizes: map[datamodel.EventPriority]int{
+			datamodel.DebugPriority:    DefaultDebugBufferSize,
+			datamodel.InfoPriority:     DefaultInfoBufferSize,
+			datamodel.CriticalPriority: DefaultCriticalBufferSize,
+		},
+		buffers:   map[datamodel.EventPriority]*ring{},
+		number:    0,
+		limit:     0,
+		now:       time.Now,
+		listeners: []Listener{},
+	}
+	for _, opt := range opts {
+		opt(mgr)
+	}
+	for priority, size := range mgr.sizes {
+		if size < 0 {
+			return nil, fmt.Errorf("%w %s event buffer size (%d)", datamodel.ErrInvalid, priority, size)
+		}
+		mgr.buffers[priority] = newRing(size)
+	}
+
+	if mgr.store == nil {
+		return mgr, nil
+	}
+	b, err := mgr.store.Get(Namespace, NumberKey)
+	switch {
+	case err == nil:
+		if len(b) != 8 {
+			return nil, fmt.Errorf("%w persisted event number : %X", datamodel.ErrInvalid, b)
+		}
+		mgr.number = im.EventNumber(binary.BigEndian.Uint64(b))
+	case errors.Is(err, storage.ErrNotFound):
+	default:
+		return nil, err
+	}
+	if err := mgr.reserve(); err != nil {
+		return nil, err
+	}
+	return mgr, nil
+}
+
+// reserve persists the end of the next reserved range of event numbers, and the numbers continue
+// from the persisted end after a reboot.
+func (mgr *Manager) reserve() error {
+	limit := mgr.number + DefaultNumberPersistenceStep
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(limit))
+	if err := mgr.store.Set(Namespace, NumberKey, b); err != nil {
+		return err
+	}
+	mgr.limit = limit
+	return nil
+}
+
+// AddListener adds the specified listener of logged events.
+func (mgr *Manager) AddListener(l Listener) {
+	mgr.Lock()
+	defer mgr.Unlock()
+	mgr.listeners = append(mgr.listeners, l)
+}
+
+// NextNumber returns the event number of the next event.
+func (mgr *Manager) NextNumber() im.EventNumber {
+	mgr.Lock()
+	defer mgr.Unlock()
+	return mgr.number
+}
+
+// HandleEvent logs the specified event, which is set as the event handler of a node.
+func (mgr *Manager) HandleEvent(ev *datamodel.Event) {
+	if _, err := mgr.Emit(ev); err != nil {
+		log.Warnf("dropped an event %s (%s)", ev.Path, err)
+	}
+}
+
+// Emit assigns the next event number to the specified event, and logs it.
+func (mgr *Manager) Emit(ev *datamodel.Event) (*Record, error) {
+	if ev.Path.IsWildcard() {
+		return nil, fmt.Errorf("%w event path (%s)", datamodel.ErrInvalid, ev.Path)
+	}
+	b, err := datatype.Encode(ev.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	mgr.Lock()
+	buffer, ok := mgr.buffers[ev.Priority]
+	if !ok {
+		mgr.Unlock()
+		return nil, fmt.Errorf("%w event priority (%d)", datamodel.ErrInvalid, ev.Priority)
+	}
+	if mgr.store != nil && mgr.number == mgr.limit {
+		if err := mgr.reserve(); err != nil {
+			mgr.Unlock()
+			return nil, err
+		}
+	}
+	rec := &Record{
+		Path:      ev.Path,
+		Number:    mgr.number,
+		Priority:  ev.Priority,
+		Timestamp: mgr.now(),
+		Data:      b,
+	}
+	mgr.number++
+	buffer.push(rec)
+	listeners := append([]Listener{}, mgr.listeners...)
+	mgr.Unlock()
+
+	for _, l := range listeners {
+		l(rec)
+	}
+	return rec, nil
+}
+
+// Events returns the logged events which match any of the specified paths and whose numbers are
+// not less than the specified minimum event number, in ascending order of the event numbers.
+func (mgr *Manager) Events(paths []im.EventPath, eventMin im.EventNumber) []*Record {
+	mgr.Lock()
+	defer mgr.Unlock()
+	recs := []*Record{}
+	for _, buffer := range mgr.buffers {
+		buffer.each(func(rec *Record) {
+			if rec.Number < eventMin {
+				return
+			}
+			for _, path := range paths {
+				if path.Match(rec.Path) {
+					recs = append(recs, rec)
+					return
+				}
+			}
+		})
+	}
+	sort.Slice(recs, func(i, j int) bool { return recs[i].Number < recs[j].Number })
+	return recs
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// Record represents a logged event (EventDataIB).
+type Record struct {
+	// Path is the concrete path of the event.
+	Path im.EventPath
+	// Number is the event number.
+	Number im.EventNumber
+	// Priority is the priority of the event.
+	Priority datamodel.EventPriority
+	// Timestamp is the time when the event was emitted.
+	Timestamp time.Time
+	// Data is the TLV encoded event fields with an anonymous tag.
+	Data []byte
+}
+
+// ring represents a circular buffer of records, which drops the oldest record when it is full.
+type ring struct {
+	records []*Record
+	start   int
+	n       int
+}
+
+func newRing(size int) *ring {
+	return &ring{
+		records: make([]*Record, size),
+		start:   0,
+		n:       0,
+	}
+}
+
+// push adds the record, and drops the oldest record if the buffer is full.
+func (r *ring) push(rec *Record) {
+	if len(r.records) == 0 {
+		return
+	}
+	if r.n < len(r.records) {
+		r.records[(r.start+r.n)%len(r.records)] = rec
+		r.n++
+		return
+	}
+	r.records[r.start] = rec
+	r.start = (r.start + 1) % len(r.records)
+}
+
+// each calls the function with the records from the oldest one.
+func (r *ring) each(f func(rec *Record)) {
+	for i := 0; i < r.n; i++ {
+		f(r.records[(r.start+i)%len(r.records)])
+	}
+}
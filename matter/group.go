@@ -15,7 +15,7 @@
 package matter
 
 import (
-	"github.com/cybergarage/go-matter/matter/message"
+	"github.com/cybergarage/go-matter/internal/message"
 )
 
 // GroupID represents a group ID.
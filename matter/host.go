@@ -0,0 +1,74 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"net"
+	"net/netip"
+	"slices"
+	"sync"
+
+	"github.com/cybergarage/go-mdns/mdns/dns"
+)
+
+// hostAddrs represents the addresses of the hosts answered in DNS-SD responses. Answers to
+// host queries have only AAAA and A records, which the services of the mDNS client do not keep.
+type hostAddrs struct {
+	sync.RWMutex
+	addrs map[string][]netip.Addr
+}
+
+func newHostAddrs() *hostAddrs {
+	return &hostAddrs{
+		RWMutex: sync.RWMutex{},
+		addrs:   map[string][]netip.Addr{},
+	}
+}
+
+// update records the AAAA and A records of the specified response.
+func (hosts *hostAddrs) update(msg *dns.Message) {
+	if !msg.IsResponse() {
+		return
+	}
+	hosts.Lock()
+	defer hosts.Unlock()
+	for _, record := range msg.ResourceRecords() {
+		var ip net.IP
+		switch rr := record.(type) {
+		case *dns.AAAARecord:
+			ip = rr.Address()
+		case *dns.ARecord:
+			ip = rr.Address()
+		default:
+			continue
+		}
+		addr, ok := netip.AddrFromSlice(ip)
+		if !ok {
+			continue
+		}
+		addr = addr.Unmap()
+		host := hostLabel(record.Name())
+		if !slices.Contains(hosts.addrs[host], addr) {
+			hosts.addrs[host] = append(hosts.addrs[host], addr)
+		}
+	}
+}
+
+// Lookup returns the recorded addresses of the specified host.
+func (hosts *hostAddrs) Lookup(host string) []netip.Addr {
+	hosts.RLock()
+	defer hosts.RUnlock()
+	return slices.Clone(hosts.addrs[hostLabel(host)])
+}
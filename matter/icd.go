@@ -0,0 +1,158 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/session"
+)
+
+// ActiveModeTriggerPath is the attribute which is read to bring an intermittently connected device (ICD)
+// into active mode. DataModelRevision of the Basic Information cluster is mandatory and cheap to report.
+var ActiveModeTriggerPath = im.NewAttributePath(0, im.ClusterBasicInformation, im.AttributeBasicInformationDataModelRevision)
+
+// 4.3.4. TXT key for ICD operating mode (ICD)
+// ICDOperatingMode represents the operating mode of an intermittently connected device.
+type ICDOperatingMode uint8
+
+const (
+	// ICDShortIdleTime indicates that the ICD operates in short idle time (SIT) mode.
+	ICDShortIdleTime ICDOperatingMode = 0
+	// ICDLongIdleTime indicates that the ICD operates in long idle time (LIT) mode,
+	// and may not respond until it checks in.
+	ICDLongIdleTime ICDOperatingMode = 1
+)
+
+// NewICDOperatingModeFromString returns the operating mode of the specified ICD key value.
+func NewICDOperatingModeFromString(s string) (ICDOperatingMode, error) {
+	switch s {
+	case "0":
+		return ICDShortIdleTime, nil
+	case "1":
+		return ICDLongIdleTime, nil
+	}
+	return ICDShortIdleTime, fmt.Errorf("%w ICD operating mode: %s", ErrInvalid, s)
+}
+
+// IsLongIdleTime returns true if the mode is the LIT mode.
+func (mode ICDOperatingMode) IsLongIdleTime() bool {
+	return mode == ICDLongIdleTime
+}
+
+// String returns the ICD key value.
+func (mode ICDOperatingMode) String() string {
+	return strconv.Itoa(int(mode))
+}
+
+// ActiveWindow represents a period in which an ICD stays in active mode after its last message.
+type ActiveWindow struct {
+	// Start is the time when the ICD responded.
+	Start time.Time
+	// Params are the effective MRP parameters of the ICD.
+	Params *session.Params
+}
+
+// NewActiveWindow returns a new active window which starts at the specified time.
+func NewActiveWindow(start time.Time, params *session.Params) ActiveWindow {
+	return ActiveWindow{
+		Start:  start,
+		Params: params.Effective(),
+	}
+}
+
+// Deadline returns the time when the ICD returns to idle mode.
+func (window ActiveWindow) Deadline() time.Time {
+	if window.Params == nil {
+		return window.Start
+	}
+	return window.Start.Add(window.Params.ActiveThreshold)
+}
+
+// IsActive returns true if the ICD is still in active mode at the specified time, leaving
+// at least one active interval to respond.
+func (window ActiveWindow) IsActive(now time.Time) bool {
+	if window.Params == nil {
+		return false
+	}
+	return now.Add(window.Params.ActiveInterval).Before(window.Deadline())
+}
+
+// WakeUpTimeout returns the time to wait for a response from an idle ICD. The ICD checks for
+// pending messages at least once per idle interval, and then responds within its active threshold.
+func WakeUpTimeout(params *session.Params) time.Duration {
+	p := params.Effective()
+	return p.IdleInterval + p.ActiveThreshold
+}
+
+// SetSessionParams sets the MRP parameters of the node, such as the SII, SAI and SAT hints from discovery.
+func (dev *OperationalDevice) SetSessionParams(params *session.Params) {
+	dev.Lock()
+	defer dev.Unlock()
+	if params == nil {
+		params = session.NewParams()
+	}
+	dev.params = params.Copy()
+}
+
+// SessionParams returns the effective MRP parameters of the node.
+func (dev *OperationalDevice) SessionParams() *session.Params {
+	dev.Lock()
+	defer dev.Unlock()
+	return dev.params.Effective()
+}
+
+// ActiveWindow returns the active window since the last wake-up.
+func (dev *OperationalDevice) ActiveWindow() ActiveWindow {
+	dev.Lock()
+	defer dev.Unlock()
+	return dev.window
+}
+
+// WakeUp triggers active mode of the ICD by reading ActiveModeTriggerPath, waiting for the response
+// for WakeUpTimeout at most, and returns the active window which starts with the response.
+func (dev *OperationalDevice) WakeUp(ctx context.Context) (ActiveWindow, error) {
+	params := dev.SessionParams()
+	ctx, cancel := context.WithTimeout(ctx, WakeUpTimeout(params))
+	defer cancel()
+	if _, err := dev.ReadAttribute(ctx, ActiveModeTriggerPath); err != nil {
+		return ActiveWindow{Start: time.Time{}, Params: nil}, err
+	}
+	window := NewActiveWindow(time.Now(), params)
+	dev.Lock()
+	dev.window = window
+	dev.Unlock()
+	return window, nil
+}
+
+// WithActiveMode runs the function while the ICD is in active mode. The ICD is woken up unless it is
+// still active, and the context passed to the function is bounded by the deadline of the active window.
+func (dev *OperationalDevice) WithActiveMode(ctx context.Context, fn func(ctx context.Context) error) error {
+	window := dev.ActiveWindow()
+	if !window.IsActive(time.Now()) {
+		var err error
+		window, err = dev.WakeUp(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	ctx, cancel := context.WithDeadline(ctx, window.Deadline())
+	defer cancel()
+	return fn(ctx)
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/cluster/identify"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// CommandInvoker represents a session or a device handle which invokes commands, such as a PASE
+// session to a commissionee or an OperationalDevice.
+type CommandInvoker interface {
+	// InvokeCommand invokes the command with the TLV encoded fields, and returns the TLV encoded response fields.
+	InvokeCommand(ctx context.Context, path im.CommandPath, fields []byte) ([]byte, error)
+}
+
+// Identify asks the specified endpoint to identify itself for the duration which is rounded up to
+// seconds, or to stop identifying if the duration is not positive.
+func Identify(ctx context.Context, invoker CommandInvoker, endpoint im.EndpointID, d time.Duration) error {
+	seconds := math.Max(0, math.Min(math.Ceil(d.Seconds()), math.MaxUint16))
+	identifyTime := datatype.Uint16(seconds)
+	fields, err := datatype.Encode(datatype.NewStruct(datatype.NewField(0, &identifyTime)))
+	if err != nil {
+		return err
+	}
+	_, err = invoker.InvokeCommand(ctx, im.NewCommandPath(endpoint, identify.ClusterID, identify.IdentifyCommandID), fields)
+	return err
+}
+
+// TriggerIdentifyEffect asks the specified endpoint to run the identification effect.
+func TriggerIdentifyEffect(ctx context.Context, invoker CommandInvoker, endpoint im.EndpointID, effect identify.Effect, variant identify.EffectVariant) error {
+	effectValue := datatype.Enum8(effect)
+	variantValue := datatype.Enum8(variant)
+	fields, err := datatype.Encode(datatype.NewStruct(
+		datatype.NewField(0, &effectValue),
+		datatype.NewField(1, &variantValue)))
+	if err != nil {
+		return err
+	}
+	_, err = invoker.InvokeCommand(ctx, im.NewCommandPath(endpoint, identify.ClusterID, identify.TriggerEffectCommandID), fields)
+	return err
+}
+
+// IdentifyConfirmer is called while the candidate of the specified index identifies itself, and returns
+// true if the user confirms that it is the device to commission.
+type IdentifyConfirmer func(ctx context.Context, n int) (bool, error)
+
+// DisambiguateByIdentify asks the candidate devices to identify themselves one by one, and returns the
+// index of the first candidate which the user confirms. It is used when several commissionable devices
+// match the onboarding payload. Candidates which fail to identify are skipped, and ErrNotFound is
+// returned if no candidate is confirmed.
+func DisambiguateByIdentify(ctx context.Context, candidates []CommandInvoker, endpoint im.EndpointID, d time.Duration, confirm IdentifyConfirmer) (int, error) {
+	var errs error
+	for n, candidate := range candidates {
+		if err := Identify(ctx, candidate, endpoint, d); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("candidate (%d) : %w", n, err))
+			continue
+		}
+		ok, err := confirm(ctx, n)
+		// Stops identifying regardless of the confirmation since the next candidate starts.
+		stopErr := Identify(ctx, candidate, endpoint, 0)
+		if err != nil {
+			return -1, err
+		}
+		if ok {
+			return n, nil
+		}
+		if stopErr != nil {
+			errs = errors.Join(errs, fmt.Errorf("candidate (%d) : %w", n, stopErr))
+		}
+	}
+	if errs != nil {
+		return -1, fmt.Errorf("candidate is %w : %w", ErrNotFound, errs)
+	}
+	return -1, fmt.Errorf("candidate is %w", ErrNotFound)
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package im
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+// reportField represents a struct field which receives the attributes of a path.
+type reportField struct {
+	path  AttributePath
+	index int
+}
+
+var (
+	reportFieldsCache = sync.Map{}
+	endpointIDType    = reflect.TypeFor[EndpointID]()
+)
+
+// reportFields returns the fields of the struct type which are tagged with attribute paths.
+func reportFields(typ reflect.Type) ([]reportField, error) {
+	if fields, ok := reportFieldsCache.Load(typ); ok {
+		return fields.([]reportField), nil
+	}
+	fields := []reportField{}
+	for n := 0; n < typ.NumField(); n++ {
+		field := typ.Field(n)
+		tag, ok := field.Tag.Lookup("tlv")
+		if !ok || !field.IsExported() {
+			continue
+		}
+		path, err := ParseAttributePath(tag)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s : %w", typ, field.Name, err)
+		}
+		if path.Endpoint == WildcardEndpointID && field.Type.Kind() == reflect.Map && field.Type.Key() != endpointIDType {
+			return nil, fmt.Errorf("%w %s.%s : map key is not %s", ErrInvalid, typ, field.Name, endpointIDType)
+		}
+		fields = append(fields, reportField{path: path, index: n})
+	}
+	reportFieldsCache.Store(typ, fields)
+	return fields, nil
+}
+
+// DecodeAttributeData decodes the attribute data into the fields of the struct pointed to by v, which
+// are tagged with attribute paths such as `tlv:"1/OnOff/OnOff"` or `tlv:"1/0x0008/0x0000"`. The values
+// are decoded with tlv.Unmarshal, so a field of a struct value has its own context tags. A field of a
+// wildcard endpoint path receives the values of all endpoints if it is a map keyed by EndpointID, and
+// the last value otherwise. Data which match no field are ignored, and fields which fail to decode
+// keep their previous values.
+//
+// DecodeAttributeData returns the paths of the decoded data, with the errors of the data which fail.
+func DecodeAttributeData(data []AttributeData, v any) ([]AttributePath, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w report target (%T) : not a pointer to a struct", ErrInvalid, v)
+	}
+	rv = rv.Elem()
+	fields, err := reportFields(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := []AttributePath{}
+	var errs error
+	for _, d := range data {
+		ok := false
+		for _, field := range fields {
+			if !field.path.Match(d.Path) {
+				continue
+			}
+			if err := decodeReportField(rv.Field(field.index), d); err != nil {
+				errs = errors.Join(errs, fmt.Errorf("%s : %w", d.Path.Name(), err))
+				continue
+			}
+			ok = true
+		}
+		if ok {
+			decoded = append(decoded, d.Path)
+		}
+	}
+	return decoded, errs
+}
+
+func decodeReportField(fv reflect.Value, d AttributeData) error {
+	if fv.Kind() == reflect.Map && fv.Type().Key() == endpointIDType {
+		ev := reflect.New(fv.Type().Elem())
+		if err := tlv.Unmarshal(d.Data, ev.Interface()); err != nil {
+			return err
+		}
+		if fv.IsNil() {
+			fv.Set(reflect.MakeMap(fv.Type()))
+		}
+		fv.SetMapIndex(reflect.ValueOf(d.Path.Endpoint), ev.Elem())
+		return nil
+	}
+	ev := reflect.New(fv.Type())
+	if err := tlv.Unmarshal(d.Data, ev.Interface()); err != nil {
+		return err
+	}
+	fv.Set(ev.Elem())
+	return nil
+}
+
+// StructReportHandler is called with the struct which the attributes of a report are decoded into,
+// and the paths of the decoded attributes.
+type StructReportHandler[T any] func(v *T, paths []AttributePath, err error)
+
+// NewStructReportHandler returns a report handler which decodes each report into the struct pointed
+// to by v with DecodeAttributeData, and calls the handler. Reports which neither decode nor fail to
+// decode any attribute are not passed. The struct is updated while the handler is not called, so it
+// should only be read in the handler. An error is returned if the tags of the struct are invalid.
+func NewStructReportHandler[T any](v *T, handler StructReportHandler[T]) (ReportHandler, error) {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w report target (%s) : not a struct", ErrInvalid, typ)
+	}
+	if _, err := reportFields(typ); err != nil {
+		return nil, err
+	}
+	var mutex sync.Mutex
+	return func(data []AttributeData) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		paths, err := DecodeAttributeData(data, v)
+		if len(paths) == 0 && err == nil {
+			return
+		}
+		handler(v, paths, err)
+	}, nil
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package im
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+func newTestAttributeData(endpoint EndpointID, cluster ClusterID, attribute AttributeID, put func(enc *tlv.Encoder)) AttributeData {
+	enc := tlv.NewEncoder()
+	put(enc)
+	return AttributeData{Path: NewAttributePath(endpoint, cluster, attribute), DataVersion: 1, Data: enc.Bytes()}
+}
+
+func TestDecodeAttributeData(t *testing.T) {
+	type light struct {
+		OnOff        bool   `tlv:"1/OnOff/OnOff"`
+		CurrentLevel *uint8 `tlv:"1/0x0008/0x0000"`
+		Labels       []struct {
+			Label string `tlv:"0"`
+			Value string `tlv:"1"`
+		} `tlv:"1/UserLabel/LabelList"`
+		Reachable map[EndpointID]bool `tlv:"*/BridgedDeviceBasicInformation/Reachable"`
+		Unrelated string
+	}
+
+	data := []AttributeData{
+		newTestAttributeData(1, 0x0006, 0x0000, func(enc *tlv.Encoder) { enc.PutBool(tlv.NewAnonymousTag(), true) }),
+		newTestAttributeData(1, 0x0008, 0x0000, func(enc *tlv.Encoder) { enc.PutNull(tlv.NewAnonymousTag()) }),
+		newTestAttributeData(1, 0x0041, 0x0000, func(enc *tlv.Encoder) {
+			enc.StartArray(tlv.NewAnonymousTag())
+			enc.StartStructure(tlv.NewAnonymousTag())
+			enc.PutUTF8String(tlv.NewContextTag(0), "room")
+			enc.PutUTF8String(tlv.NewContextTag(1), "kitchen")
+			enc.EndContainer()
+			enc.EndContainer()
+		}),
+		newTestAttributeData(2, 0x0039, 0x0011, func(enc *tlv.Encoder) { enc.PutBool(tlv.NewAnonymousTag(), true) }),
+		newTestAttributeData(3, 0x0039, 0x0011, func(enc *tlv.Encoder) { enc.PutBool(tlv.NewAnonymousTag(), false) }),
+		newTestAttributeData(2, 0x0006, 0x0000, func(enc *tlv.Encoder) { enc.PutBool(tlv.NewAnonymousTag(), false) }),
+	}
+
+	level := uint8(100)
+	v := light{CurrentLevel: &level, Unrelated: "kept"}
+	paths, err := DecodeAttributeData(data, &v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 5 {
+		t.Errorf("decoded paths %v", paths)
+	}
+	if !v.OnOff || v.CurrentLevel != nil || v.Unrelated != "kept" {
+		t.Errorf("%+v", v)
+	}
+	if len(v.Labels) != 1 || v.Labels[0].Value != "kitchen" {
+		t.Errorf("labels %+v", v.Labels)
+	}
+	if len(v.Reachable) != 2 || !v.Reachable[2] || v.Reachable[3] {
+		t.Errorf("reachable %v", v.Reachable)
+	}
+
+	// Fields which fail to decode keep their values.
+	invalid := []AttributeData{
+		newTestAttributeData(1, 0x0006, 0x0000, func(enc *tlv.Encoder) { enc.PutUnsigned(tlv.NewAnonymousTag(), 1) }),
+	}
+	if _, err := DecodeAttributeData(invalid, &v); !errors.Is(err, tlv.ErrInvalid) {
+		t.Errorf("%v is not %v", err, tlv.ErrInvalid)
+	}
+	if !v.OnOff {
+		t.Errorf("OnOff is overwritten")
+	}
+
+	if _, err := DecodeAttributeData(data, v); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+	type invalidTag struct {
+		OnOff bool `tlv:"1/OnOff"`
+	}
+	if _, err := DecodeAttributeData(data, &invalidTag{}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+}
+
+func TestStructReportHandler(t *testing.T) {
+	type onOff struct {
+		OnOff bool `tlv:"*/OnOff/OnOff"`
+	}
+	var v onOff
+	reports := 0
+	handler, err := NewStructReportHandler(&v, func(v *onOff, paths []AttributePath, err error) {
+		if err != nil {
+			t.Error(err)
+		}
+		reports++
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler([]AttributeData{newTestAttributeData(1, 0x0006, 0x0000, func(enc *tlv.Encoder) { enc.PutBool(tlv.NewAnonymousTag(), true) })})
+	handler([]AttributeData{newTestAttributeData(1, 0x0008, 0x0000, func(enc *tlv.Encoder) { enc.PutUnsigned(tlv.NewAnonymousTag(), 1) })})
+	if reports != 1 || !v.OnOff {
+		t.Errorf("reports (%d) %+v", reports, v)
+	}
+
+	if _, err := NewStructReportHandler(new(int), func(*int, []AttributePath, error) {}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package im
+
+//go:generate go run ./internal/idgen -in ids.txt -out ids_gen.go
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// normalizeName returns the name without cases and separators, so names such as OnOff, onOff
+// and on_off are the same.
+func normalizeName(name string) string {
+	r := strings.NewReplacer("_", "", "-", "", " ", "")
+	return strings.ToLower(r.Replace(name))
+}
+
+// ClusterName returns the name of the specified cluster such as OnOff, or the hexadecimal ID
+// such as 0xFC00 if the cluster is not known.
+func ClusterName(id ClusterID) string {
+	if name, ok := clusterNames[id]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%04X", uint32(id))
+}
+
+// LookupClusterID returns the ID of the cluster of the specified name or ID. Names are
+// compared without cases and separators.
+func LookupClusterID(name string) (ClusterID, bool) {
+	if id, err := strconv.ParseUint(name, 0, 32); err == nil {
+		return ClusterID(id), true
+	}
+	for id, n := range clusterNames {
+		if normalizeName(n) == normalizeName(name) {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// AttributeName returns the name of the specified attribute of the cluster such as OnTime,
+// or the hexadecimal ID such as 0x4001 if the attribute is not known.
+func AttributeName(cluster ClusterID, id AttributeID) string {
+	if name, ok := globalAttributeNames[id]; ok {
+		return name
+	}
+	if name, ok := attributeNames[cluster][id]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%04X", uint32(id))
+}
+
+// LookupAttributeID returns the ID of the attribute of the specified name or ID in the cluster,
+// including the global attributes. Names are compared without cases and separators.
+func LookupAttributeID(cluster ClusterID, name string) (AttributeID, bool) {
+	if id, err := strconv.ParseUint(name, 0, 32); err == nil {
+		return AttributeID(id), true
+	}
+	for _, names := range []map[AttributeID]string{globalAttributeNames, attributeNames[cluster]} {
+		for id, n := range names {
+			if normalizeName(n) == normalizeName(name) {
+				return id, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Clusters returns the IDs of the known clusters in ascending order.
+func Clusters() []ClusterID {
+	ids := make([]ClusterID, 0, len(clusterNames))
+	for id := range clusterNames {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// ClusterAttributes returns the IDs of the known attributes of the cluster in ascending order,
+// which do not include the global attributes.
+func ClusterAttributes(cluster ClusterID) []AttributeID {
+	ids := make([]AttributeID, 0, len(attributeNames[cluster]))
+	for id := range attributeNames[cluster] {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// LookupStatus returns the status of the specified name in the specification such as
+// CONSTRAINT_ERROR. Names are compared without cases and separators.
+func LookupStatus(name string) (Status, bool) {
+	for status, n := range statusNames {
+		if normalizeName(n) == normalizeName(name) {
+			return status, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,1188 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by idgen from ids.txt. DO NOT EDIT.
+
+package im
+
+// 8.10. Status Code Table
+const (
+	StatusSuccess                Status = 0x00
+	StatusFailure                Status = 0x01
+	StatusInvalidSubscription    Status = 0x7D
+	StatusUnsupportedAccess      Status = 0x7E
+	StatusUnsupportedEndpoint    Status = 0x7F
+	StatusInvalidAction          Status = 0x80
+	StatusUnsupportedCommand     Status = 0x81
+	StatusInvalidCommand         Status = 0x85
+	StatusUnsupportedAttribute   Status = 0x86
+	StatusConstraintError        Status = 0x87
+	StatusUnsupportedWrite       Status = 0x88
+	StatusResourceExhausted      Status = 0x89
+	StatusNotFound               Status = 0x8B
+	StatusUnreportableAttribute  Status = 0x8C
+	StatusInvalidDataType        Status = 0x8D
+	StatusUnsupportedRead        Status = 0x8F
+	StatusDataVersionMismatch    Status = 0x92
+	StatusTimeout                Status = 0x94
+	StatusBusy                   Status = 0x9C
+	StatusUnsupportedCluster     Status = 0xC3
+	StatusNoUpstreamSubscription Status = 0xC5
+	StatusNeedsTimedInteraction  Status = 0xC6
+	StatusUnsupportedEvent       Status = 0xC7
+	StatusPathsExhausted         Status = 0xC8
+	StatusTimedRequestMismatch   Status = 0xC9
+	StatusFailsafeRequired       Status = 0xCA
+	StatusInvalidInState         Status = 0xCB
+	StatusNoCommandResponse      Status = 0xCC
+)
+
+var statusNames = map[Status]string{
+	StatusSuccess:                "SUCCESS",
+	StatusFailure:                "FAILURE",
+	StatusInvalidSubscription:    "INVALID_SUBSCRIPTION",
+	StatusUnsupportedAccess:      "UNSUPPORTED_ACCESS",
+	StatusUnsupportedEndpoint:    "UNSUPPORTED_ENDPOINT",
+	StatusInvalidAction:          "INVALID_ACTION",
+	StatusUnsupportedCommand:     "UNSUPPORTED_COMMAND",
+	StatusInvalidCommand:         "INVALID_COMMAND",
+	StatusUnsupportedAttribute:   "UNSUPPORTED_ATTRIBUTE",
+	StatusConstraintError:        "CONSTRAINT_ERROR",
+	StatusUnsupportedWrite:       "UNSUPPORTED_WRITE",
+	StatusResourceExhausted:      "RESOURCE_EXHAUSTED",
+	StatusNotFound:               "NOT_FOUND",
+	StatusUnreportableAttribute:  "UNREPORTABLE_ATTRIBUTE",
+	StatusInvalidDataType:        "INVALID_DATA_TYPE",
+	StatusUnsupportedRead:        "UNSUPPORTED_READ",
+	StatusDataVersionMismatch:    "DATA_VERSION_MISMATCH",
+	StatusTimeout:                "TIMEOUT",
+	StatusBusy:                   "BUSY",
+	StatusUnsupportedCluster:     "UNSUPPORTED_CLUSTER",
+	StatusNoUpstreamSubscription: "NO_UPSTREAM_SUBSCRIPTION",
+	StatusNeedsTimedInteraction:  "NEEDS_TIMED_INTERACTION",
+	StatusUnsupportedEvent:       "UNSUPPORTED_EVENT",
+	StatusPathsExhausted:         "PATHS_EXHAUSTED",
+	StatusTimedRequestMismatch:   "TIMED_REQUEST_MISMATCH",
+	StatusFailsafeRequired:       "FAILSAFE_REQUIRED",
+	StatusInvalidInState:         "INVALID_IN_STATE",
+	StatusNoCommandResponse:      "NO_COMMAND_RESPONSE",
+}
+
+// 7.13. Global Elements
+const (
+	AttributeGeneratedCommandList AttributeID = 0xFFF8
+	AttributeAcceptedCommandList  AttributeID = 0xFFF9
+	AttributeEventList            AttributeID = 0xFFFA
+	AttributeAttributeList        AttributeID = 0xFFFB
+	AttributeFeatureMap           AttributeID = 0xFFFC
+	AttributeClusterRevision      AttributeID = 0xFFFD
+)
+
+var globalAttributeNames = map[AttributeID]string{
+	AttributeGeneratedCommandList: "GeneratedCommandList",
+	AttributeAcceptedCommandList:  "AcceptedCommandList",
+	AttributeEventList:            "EventList",
+	AttributeAttributeList:        "AttributeList",
+	AttributeFeatureMap:           "FeatureMap",
+	AttributeClusterRevision:      "ClusterRevision",
+}
+
+const (
+	ClusterIdentify                      ClusterID = 0x0003
+	ClusterGroups                        ClusterID = 0x0004
+	ClusterOnOff                         ClusterID = 0x0006
+	ClusterLevelControl                  ClusterID = 0x0008
+	ClusterDescriptor                    ClusterID = 0x001D
+	ClusterBinding                       ClusterID = 0x001E
+	ClusterAccessControl                 ClusterID = 0x001F
+	ClusterBasicInformation              ClusterID = 0x0028
+	ClusterOTASoftwareUpdateProvider     ClusterID = 0x0029
+	ClusterOTASoftwareUpdateRequestor    ClusterID = 0x002A
+	ClusterLocalizationConfiguration     ClusterID = 0x002B
+	ClusterTimeFormatLocalization        ClusterID = 0x002C
+	ClusterUnitLocalization              ClusterID = 0x002D
+	ClusterPowerSourceConfiguration      ClusterID = 0x002E
+	ClusterPowerSource                   ClusterID = 0x002F
+	ClusterGeneralCommissioning          ClusterID = 0x0030
+	ClusterNetworkCommissioning          ClusterID = 0x0031
+	ClusterDiagnosticLogs                ClusterID = 0x0032
+	ClusterGeneralDiagnostics            ClusterID = 0x0033
+	ClusterSoftwareDiagnostics           ClusterID = 0x0034
+	ClusterThreadNetworkDiagnostics      ClusterID = 0x0035
+	ClusterWiFiNetworkDiagnostics        ClusterID = 0x0036
+	ClusterEthernetNetworkDiagnostics    ClusterID = 0x0037
+	ClusterTimeSynchronization           ClusterID = 0x0038
+	ClusterBridgedDeviceBasicInformation ClusterID = 0x0039
+	ClusterSwitch                        ClusterID = 0x003B
+	ClusterAdministratorCommissioning    ClusterID = 0x003C
+	ClusterOperationalCredentials        ClusterID = 0x003E
+	ClusterGroupKeyManagement            ClusterID = 0x003F
+	ClusterFixedLabel                    ClusterID = 0x0040
+	ClusterUserLabel                     ClusterID = 0x0041
+	ClusterBooleanState                  ClusterID = 0x0045
+	ClusterICDManagement                 ClusterID = 0x0046
+	ClusterOvenMode                      ClusterID = 0x0049
+	ClusterModeSelect                    ClusterID = 0x0050
+	ClusterLaundryWasherMode             ClusterID = 0x0051
+	ClusterRVCRunMode                    ClusterID = 0x0054
+	ClusterRVCCleanMode                  ClusterID = 0x0055
+	ClusterScenesManagement              ClusterID = 0x0062
+	ClusterDoorLock                      ClusterID = 0x0101
+	ClusterWindowCovering                ClusterID = 0x0102
+	ClusterThermostat                    ClusterID = 0x0201
+	ClusterFanControl                    ClusterID = 0x0202
+	ClusterColorControl                  ClusterID = 0x0300
+	ClusterIlluminanceMeasurement        ClusterID = 0x0400
+	ClusterTemperatureMeasurement        ClusterID = 0x0402
+	ClusterPressureMeasurement           ClusterID = 0x0403
+	ClusterFlowMeasurement               ClusterID = 0x0404
+	ClusterRelativeHumidityMeasurement   ClusterID = 0x0405
+	ClusterOccupancySensing              ClusterID = 0x0406
+	ClusterCommissionerControl           ClusterID = 0x0751
+)
+
+// Identify Cluster
+const (
+	AttributeIdentifyIdentifyTime AttributeID = 0x0000
+	AttributeIdentifyIdentifyType AttributeID = 0x0001
+)
+
+// Groups Cluster
+const (
+	AttributeGroupsNameSupport AttributeID = 0x0000
+)
+
+// OnOff Cluster
+const (
+	AttributeOnOffOnOff              AttributeID = 0x0000
+	AttributeOnOffGlobalSceneControl AttributeID = 0x4000
+	AttributeOnOffOnTime             AttributeID = 0x4001
+	AttributeOnOffOffWaitTime        AttributeID = 0x4002
+	AttributeOnOffStartUpOnOff       AttributeID = 0x4003
+)
+
+// LevelControl Cluster
+const (
+	AttributeLevelControlCurrentLevel        AttributeID = 0x0000
+	AttributeLevelControlRemainingTime       AttributeID = 0x0001
+	AttributeLevelControlMinLevel            AttributeID = 0x0002
+	AttributeLevelControlMaxLevel            AttributeID = 0x0003
+	AttributeLevelControlCurrentFrequency    AttributeID = 0x0004
+	AttributeLevelControlMinFrequency        AttributeID = 0x0005
+	AttributeLevelControlMaxFrequency        AttributeID = 0x0006
+	AttributeLevelControlOptions             AttributeID = 0x000F
+	AttributeLevelControlOnOffTransitionTime AttributeID = 0x0010
+	AttributeLevelControlOnLevel             AttributeID = 0x0011
+	AttributeLevelControlOnTransitionTime    AttributeID = 0x0012
+	AttributeLevelControlOffTransitionTime   AttributeID = 0x0013
+	AttributeLevelControlDefaultMoveRate     AttributeID = 0x0014
+	AttributeLevelControlStartUpCurrentLevel AttributeID = 0x4000
+)
+
+// Descriptor Cluster
+const (
+	AttributeDescriptorDeviceTypeList AttributeID = 0x0000
+	AttributeDescriptorServerList     AttributeID = 0x0001
+	AttributeDescriptorClientList     AttributeID = 0x0002
+	AttributeDescriptorPartsList      AttributeID = 0x0003
+	AttributeDescriptorTagList        AttributeID = 0x0004
+)
+
+// Binding Cluster
+const (
+	AttributeBindingBinding AttributeID = 0x0000
+)
+
+// AccessControl Cluster
+const (
+	AttributeAccessControlACL                           AttributeID = 0x0000
+	AttributeAccessControlExtension                     AttributeID = 0x0001
+	AttributeAccessControlSubjectsPerAccessControlEntry AttributeID = 0x0002
+	AttributeAccessControlTargetsPerAccessControlEntry  AttributeID = 0x0003
+	AttributeAccessControlAccessControlEntriesPerFabric AttributeID = 0x0004
+)
+
+// BasicInformation Cluster
+const (
+	AttributeBasicInformationDataModelRevision     AttributeID = 0x0000
+	AttributeBasicInformationVendorName            AttributeID = 0x0001
+	AttributeBasicInformationVendorID              AttributeID = 0x0002
+	AttributeBasicInformationProductName           AttributeID = 0x0003
+	AttributeBasicInformationProductID             AttributeID = 0x0004
+	AttributeBasicInformationNodeLabel             AttributeID = 0x0005
+	AttributeBasicInformationLocation              AttributeID = 0x0006
+	AttributeBasicInformationHardwareVersion       AttributeID = 0x0007
+	AttributeBasicInformationHardwareVersionString AttributeID = 0x0008
+	AttributeBasicInformationSoftwareVersion       AttributeID = 0x0009
+	AttributeBasicInformationSoftwareVersionString AttributeID = 0x000A
+	AttributeBasicInformationManufacturingDate     AttributeID = 0x000B
+	AttributeBasicInformationPartNumber            AttributeID = 0x000C
+	AttributeBasicInformationProductURL            AttributeID = 0x000D
+	AttributeBasicInformationProductLabel          AttributeID = 0x000E
+	AttributeBasicInformationSerialNumber          AttributeID = 0x000F
+	AttributeBasicInformationLocalConfigDisabled   AttributeID = 0x0010
+	AttributeBasicInformationReachable             AttributeID = 0x0011
+	AttributeBasicInformationUniqueID              AttributeID = 0x0012
+	AttributeBasicInformationCapabilityMinima      AttributeID = 0x0013
+	AttributeBasicInformationProductAppearance     AttributeID = 0x0014
+	AttributeBasicInformationSpecificationVersion  AttributeID = 0x0015
+	AttributeBasicInformationMaxPathsPerInvoke     AttributeID = 0x0016
+)
+
+// OTASoftwareUpdateRequestor Cluster
+const (
+	AttributeOTASoftwareUpdateRequestorDefaultOTAProviders AttributeID = 0x0000
+	AttributeOTASoftwareUpdateRequestorUpdatePossible      AttributeID = 0x0001
+	AttributeOTASoftwareUpdateRequestorUpdateState         AttributeID = 0x0002
+	AttributeOTASoftwareUpdateRequestorUpdateStateProgress AttributeID = 0x0003
+)
+
+// LocalizationConfiguration Cluster
+const (
+	AttributeLocalizationConfigurationActiveLocale     AttributeID = 0x0000
+	AttributeLocalizationConfigurationSupportedLocales AttributeID = 0x0001
+)
+
+// TimeFormatLocalization Cluster
+const (
+	AttributeTimeFormatLocalizationHourFormat             AttributeID = 0x0000
+	AttributeTimeFormatLocalizationActiveCalendarType     AttributeID = 0x0001
+	AttributeTimeFormatLocalizationSupportedCalendarTypes AttributeID = 0x0002
+)
+
+// UnitLocalization Cluster
+const (
+	AttributeUnitLocalizationTemperatureUnit AttributeID = 0x0000
+)
+
+// PowerSourceConfiguration Cluster
+const (
+	AttributePowerSourceConfigurationSources AttributeID = 0x0000
+)
+
+// PowerSource Cluster
+const (
+	AttributePowerSourceStatus                      AttributeID = 0x0000
+	AttributePowerSourceOrder                       AttributeID = 0x0001
+	AttributePowerSourceDescription                 AttributeID = 0x0002
+	AttributePowerSourceWiredAssessedInputVoltage   AttributeID = 0x0003
+	AttributePowerSourceWiredAssessedInputFrequency AttributeID = 0x0004
+	AttributePowerSourceWiredCurrentType            AttributeID = 0x0005
+	AttributePowerSourceWiredAssessedCurrent        AttributeID = 0x0006
+	AttributePowerSourceWiredNominalVoltage         AttributeID = 0x0007
+	AttributePowerSourceWiredMaximumCurrent         AttributeID = 0x0008
+	AttributePowerSourceWiredPresent                AttributeID = 0x0009
+	AttributePowerSourceActiveWiredFaults           AttributeID = 0x000A
+	AttributePowerSourceBatVoltage                  AttributeID = 0x000B
+	AttributePowerSourceBatPercentRemaining         AttributeID = 0x000C
+	AttributePowerSourceBatTimeRemaining            AttributeID = 0x000D
+	AttributePowerSourceBatChargeLevel              AttributeID = 0x000E
+	AttributePowerSourceBatReplacementNeeded        AttributeID = 0x000F
+	AttributePowerSourceBatReplaceability           AttributeID = 0x0010
+	AttributePowerSourceBatPresent                  AttributeID = 0x0011
+	AttributePowerSourceActiveBatFaults             AttributeID = 0x0012
+	AttributePowerSourceBatReplacementDescription   AttributeID = 0x0013
+	AttributePowerSourceBatCommonDesignation        AttributeID = 0x0014
+	AttributePowerSourceBatANSIDesignation          AttributeID = 0x0015
+	AttributePowerSourceBatIECDesignation           AttributeID = 0x0016
+	AttributePowerSourceBatApprovedChemistry        AttributeID = 0x0017
+	AttributePowerSourceBatCapacity                 AttributeID = 0x0018
+	AttributePowerSourceBatQuantity                 AttributeID = 0x0019
+	AttributePowerSourceBatChargeState              AttributeID = 0x001A
+	AttributePowerSourceBatTimeToFullCharge         AttributeID = 0x001B
+	AttributePowerSourceBatFunctionalWhileCharging  AttributeID = 0x001C
+	AttributePowerSourceBatChargingCurrent          AttributeID = 0x001D
+	AttributePowerSourceActiveBatChargeFaults       AttributeID = 0x001E
+	AttributePowerSourceEndpointList                AttributeID = 0x001F
+)
+
+// GeneralCommissioning Cluster
+const (
+	AttributeGeneralCommissioningBreadcrumb                   AttributeID = 0x0000
+	AttributeGeneralCommissioningBasicCommissioningInfo       AttributeID = 0x0001
+	AttributeGeneralCommissioningRegulatoryConfig             AttributeID = 0x0002
+	AttributeGeneralCommissioningLocationCapability           AttributeID = 0x0003
+	AttributeGeneralCommissioningSupportsConcurrentConnection AttributeID = 0x0004
+)
+
+// NetworkCommissioning Cluster
+const (
+	AttributeNetworkCommissioningMaxNetworks           AttributeID = 0x0000
+	AttributeNetworkCommissioningNetworks              AttributeID = 0x0001
+	AttributeNetworkCommissioningScanMaxTimeSeconds    AttributeID = 0x0002
+	AttributeNetworkCommissioningConnectMaxTimeSeconds AttributeID = 0x0003
+	AttributeNetworkCommissioningInterfaceEnabled      AttributeID = 0x0004
+	AttributeNetworkCommissioningLastNetworkingStatus  AttributeID = 0x0005
+	AttributeNetworkCommissioningLastNetworkID         AttributeID = 0x0006
+	AttributeNetworkCommissioningLastConnectErrorValue AttributeID = 0x0007
+)
+
+// GeneralDiagnostics Cluster
+const (
+	AttributeGeneralDiagnosticsNetworkInterfaces        AttributeID = 0x0000
+	AttributeGeneralDiagnosticsRebootCount              AttributeID = 0x0001
+	AttributeGeneralDiagnosticsUpTime                   AttributeID = 0x0002
+	AttributeGeneralDiagnosticsTotalOperationalHours    AttributeID = 0x0003
+	AttributeGeneralDiagnosticsBootReason               AttributeID = 0x0004
+	AttributeGeneralDiagnosticsActiveHardwareFaults     AttributeID = 0x0005
+	AttributeGeneralDiagnosticsActiveRadioFaults        AttributeID = 0x0006
+	AttributeGeneralDiagnosticsActiveNetworkFaults      AttributeID = 0x0007
+	AttributeGeneralDiagnosticsTestEventTriggersEnabled AttributeID = 0x0008
+)
+
+// SoftwareDiagnostics Cluster
+const (
+	AttributeSoftwareDiagnosticsThreadMetrics            AttributeID = 0x0000
+	AttributeSoftwareDiagnosticsCurrentHeapFree          AttributeID = 0x0001
+	AttributeSoftwareDiagnosticsCurrentHeapUsed          AttributeID = 0x0002
+	AttributeSoftwareDiagnosticsCurrentHeapHighWatermark AttributeID = 0x0003
+)
+
+// ThreadNetworkDiagnostics Cluster
+const (
+	AttributeThreadNetworkDiagnosticsChannel                           AttributeID = 0x0000
+	AttributeThreadNetworkDiagnosticsRoutingRole                       AttributeID = 0x0001
+	AttributeThreadNetworkDiagnosticsNetworkName                       AttributeID = 0x0002
+	AttributeThreadNetworkDiagnosticsPanID                             AttributeID = 0x0003
+	AttributeThreadNetworkDiagnosticsExtendedPanID                     AttributeID = 0x0004
+	AttributeThreadNetworkDiagnosticsMeshLocalPrefix                   AttributeID = 0x0005
+	AttributeThreadNetworkDiagnosticsOverrunCount                      AttributeID = 0x0006
+	AttributeThreadNetworkDiagnosticsNeighborTable                     AttributeID = 0x0007
+	AttributeThreadNetworkDiagnosticsRouteTable                        AttributeID = 0x0008
+	AttributeThreadNetworkDiagnosticsPartitionID                       AttributeID = 0x0009
+	AttributeThreadNetworkDiagnosticsWeighting                         AttributeID = 0x000A
+	AttributeThreadNetworkDiagnosticsDataVersion                       AttributeID = 0x000B
+	AttributeThreadNetworkDiagnosticsStableDataVersion                 AttributeID = 0x000C
+	AttributeThreadNetworkDiagnosticsLeaderRouterID                    AttributeID = 0x000D
+	AttributeThreadNetworkDiagnosticsDetachedRoleCount                 AttributeID = 0x000E
+	AttributeThreadNetworkDiagnosticsChildRoleCount                    AttributeID = 0x000F
+	AttributeThreadNetworkDiagnosticsRouterRoleCount                   AttributeID = 0x0010
+	AttributeThreadNetworkDiagnosticsLeaderRoleCount                   AttributeID = 0x0011
+	AttributeThreadNetworkDiagnosticsAttachAttemptCount                AttributeID = 0x0012
+	AttributeThreadNetworkDiagnosticsPartitionIDChangeCount            AttributeID = 0x0013
+	AttributeThreadNetworkDiagnosticsBetterPartitionAttachAttemptCount AttributeID = 0x0014
+	AttributeThreadNetworkDiagnosticsParentChangeCount                 AttributeID = 0x0015
+	AttributeThreadNetworkDiagnosticsTxTotalCount                      AttributeID = 0x0016
+	AttributeThreadNetworkDiagnosticsTxUnicastCount                    AttributeID = 0x0017
+	AttributeThreadNetworkDiagnosticsTxBroadcastCount                  AttributeID = 0x0018
+	AttributeThreadNetworkDiagnosticsTxAckRequestedCount               AttributeID = 0x0019
+	AttributeThreadNetworkDiagnosticsTxAckedCount                      AttributeID = 0x001A
+	AttributeThreadNetworkDiagnosticsTxNoAckRequestedCount             AttributeID = 0x001B
+	AttributeThreadNetworkDiagnosticsTxDataCount                       AttributeID = 0x001C
+	AttributeThreadNetworkDiagnosticsTxDataPollCount                   AttributeID = 0x001D
+	AttributeThreadNetworkDiagnosticsTxBeaconCount                     AttributeID = 0x001E
+	AttributeThreadNetworkDiagnosticsTxBeaconRequestCount              AttributeID = 0x001F
+	AttributeThreadNetworkDiagnosticsTxOtherCount                      AttributeID = 0x0020
+	AttributeThreadNetworkDiagnosticsTxRetryCount                      AttributeID = 0x0021
+	AttributeThreadNetworkDiagnosticsTxDirectMaxRetryExpiryCount       AttributeID = 0x0022
+	AttributeThreadNetworkDiagnosticsTxIndirectMaxRetryExpiryCount     AttributeID = 0x0023
+	AttributeThreadNetworkDiagnosticsTxErrCcaCount                     AttributeID = 0x0024
+	AttributeThreadNetworkDiagnosticsTxErrAbortCount                   AttributeID = 0x0025
+	AttributeThreadNetworkDiagnosticsTxErrBusyChannelCount             AttributeID = 0x0026
+	AttributeThreadNetworkDiagnosticsRxTotalCount                      AttributeID = 0x0027
+	AttributeThreadNetworkDiagnosticsRxUnicastCount                    AttributeID = 0x0028
+	AttributeThreadNetworkDiagnosticsRxBroadcastCount                  AttributeID = 0x0029
+	AttributeThreadNetworkDiagnosticsRxDataCount                       AttributeID = 0x002A
+	AttributeThreadNetworkDiagnosticsRxDataPollCount                   AttributeID = 0x002B
+	AttributeThreadNetworkDiagnosticsRxBeaconCount                     AttributeID = 0x002C
+	AttributeThreadNetworkDiagnosticsRxBeaconRequestCount              AttributeID = 0x002D
+	AttributeThreadNetworkDiagnosticsRxOtherCount                      AttributeID = 0x002E
+	AttributeThreadNetworkDiagnosticsRxAddressFilteredCount            AttributeID = 0x002F
+	AttributeThreadNetworkDiagnosticsRxDestAddrFilteredCount           AttributeID = 0x0030
+	AttributeThreadNetworkDiagnosticsRxDuplicatedCount                 AttributeID = 0x0031
+	AttributeThreadNetworkDiagnosticsRxErrNoFrameCount                 AttributeID = 0x0032
+	AttributeThreadNetworkDiagnosticsRxErrUnknownNeighborCount         AttributeID = 0x0033
+	AttributeThreadNetworkDiagnosticsRxErrInvalidSrcAddrCount          AttributeID = 0x0034
+	AttributeThreadNetworkDiagnosticsRxErrSecCount                     AttributeID = 0x0035
+	AttributeThreadNetworkDiagnosticsRxErrFcsCount                     AttributeID = 0x0036
+	AttributeThreadNetworkDiagnosticsRxErrOtherCount                   AttributeID = 0x0037
+	AttributeThreadNetworkDiagnosticsActiveTimestamp                   AttributeID = 0x0038
+	AttributeThreadNetworkDiagnosticsPendingTimestamp                  AttributeID = 0x0039
+	AttributeThreadNetworkDiagnosticsDelay                             AttributeID = 0x003A
+	AttributeThreadNetworkDiagnosticsSecurityPolicy                    AttributeID = 0x003B
+	AttributeThreadNetworkDiagnosticsChannelPage0Mask                  AttributeID = 0x003C
+	AttributeThreadNetworkDiagnosticsOperationalDatasetComponents      AttributeID = 0x003D
+	AttributeThreadNetworkDiagnosticsActiveNetworkFaultsList           AttributeID = 0x003E
+)
+
+// WiFiNetworkDiagnostics Cluster
+const (
+	AttributeWiFiNetworkDiagnosticsBSSID                  AttributeID = 0x0000
+	AttributeWiFiNetworkDiagnosticsSecurityType           AttributeID = 0x0001
+	AttributeWiFiNetworkDiagnosticsWiFiVersion            AttributeID = 0x0002
+	AttributeWiFiNetworkDiagnosticsChannelNumber          AttributeID = 0x0003
+	AttributeWiFiNetworkDiagnosticsRSSI                   AttributeID = 0x0004
+	AttributeWiFiNetworkDiagnosticsBeaconLostCount        AttributeID = 0x0005
+	AttributeWiFiNetworkDiagnosticsBeaconRxCount          AttributeID = 0x0006
+	AttributeWiFiNetworkDiagnosticsPacketMulticastRxCount AttributeID = 0x0007
+	AttributeWiFiNetworkDiagnosticsPacketMulticastTxCount AttributeID = 0x0008
+	AttributeWiFiNetworkDiagnosticsPacketUnicastRxCount   AttributeID = 0x0009
+	AttributeWiFiNetworkDiagnosticsPacketUnicastTxCount   AttributeID = 0x000A
+	AttributeWiFiNetworkDiagnosticsCurrentMaxRate         AttributeID = 0x000B
+	AttributeWiFiNetworkDiagnosticsOverrunCount           AttributeID = 0x000C
+)
+
+// EthernetNetworkDiagnostics Cluster
+const (
+	AttributeEthernetNetworkDiagnosticsPHYRate        AttributeID = 0x0000
+	AttributeEthernetNetworkDiagnosticsFullDuplex     AttributeID = 0x0001
+	AttributeEthernetNetworkDiagnosticsPacketRxCount  AttributeID = 0x0002
+	AttributeEthernetNetworkDiagnosticsPacketTxCount  AttributeID = 0x0003
+	AttributeEthernetNetworkDiagnosticsTxErrCount     AttributeID = 0x0004
+	AttributeEthernetNetworkDiagnosticsCollisionCount AttributeID = 0x0005
+	AttributeEthernetNetworkDiagnosticsOverrunCount   AttributeID = 0x0006
+	AttributeEthernetNetworkDiagnosticsCarrierDetect  AttributeID = 0x0007
+	AttributeEthernetNetworkDiagnosticsTimeSinceReset AttributeID = 0x0008
+)
+
+// TimeSynchronization Cluster
+const (
+	AttributeTimeSynchronizationUTCTime              AttributeID = 0x0000
+	AttributeTimeSynchronizationGranularity          AttributeID = 0x0001
+	AttributeTimeSynchronizationTimeSource           AttributeID = 0x0002
+	AttributeTimeSynchronizationTrustedTimeSource    AttributeID = 0x0003
+	AttributeTimeSynchronizationDefaultNTP           AttributeID = 0x0004
+	AttributeTimeSynchronizationTimeZone             AttributeID = 0x0005
+	AttributeTimeSynchronizationDSTOffset            AttributeID = 0x0006
+	AttributeTimeSynchronizationLocalTime            AttributeID = 0x0007
+	AttributeTimeSynchronizationTimeZoneDatabase     AttributeID = 0x0008
+	AttributeTimeSynchronizationNTPServerAvailable   AttributeID = 0x0009
+	AttributeTimeSynchronizationTimeZoneListMaxSize  AttributeID = 0x000A
+	AttributeTimeSynchronizationDSTOffsetListMaxSize AttributeID = 0x000B
+	AttributeTimeSynchronizationSupportsDNSResolve   AttributeID = 0x000C
+)
+
+// BridgedDeviceBasicInformation Cluster
+const (
+	AttributeBridgedDeviceBasicInformationVendorName            AttributeID = 0x0001
+	AttributeBridgedDeviceBasicInformationVendorID              AttributeID = 0x0002
+	AttributeBridgedDeviceBasicInformationProductName           AttributeID = 0x0003
+	AttributeBridgedDeviceBasicInformationNodeLabel             AttributeID = 0x0005
+	AttributeBridgedDeviceBasicInformationHardwareVersion       AttributeID = 0x0007
+	AttributeBridgedDeviceBasicInformationHardwareVersionString AttributeID = 0x0008
+	AttributeBridgedDeviceBasicInformationSoftwareVersion       AttributeID = 0x0009
+	AttributeBridgedDeviceBasicInformationSoftwareVersionString AttributeID = 0x000A
+	AttributeBridgedDeviceBasicInformationManufacturingDate     AttributeID = 0x000B
+	AttributeBridgedDeviceBasicInformationPartNumber            AttributeID = 0x000C
+	AttributeBridgedDeviceBasicInformationProductURL            AttributeID = 0x000D
+	AttributeBridgedDeviceBasicInformationProductLabel          AttributeID = 0x000E
+	AttributeBridgedDeviceBasicInformationSerialNumber          AttributeID = 0x000F
+	AttributeBridgedDeviceBasicInformationReachable             AttributeID = 0x0011
+	AttributeBridgedDeviceBasicInformationUniqueID              AttributeID = 0x0012
+	AttributeBridgedDeviceBasicInformationProductAppearance     AttributeID = 0x0014
+)
+
+// Switch Cluster
+const (
+	AttributeSwitchNumberOfPositions AttributeID = 0x0000
+	AttributeSwitchCurrentPosition   AttributeID = 0x0001
+	AttributeSwitchMultiPressMax     AttributeID = 0x0002
+)
+
+// AdministratorCommissioning Cluster
+const (
+	AttributeAdministratorCommissioningWindowStatus     AttributeID = 0x0000
+	AttributeAdministratorCommissioningAdminFabricIndex AttributeID = 0x0001
+	AttributeAdministratorCommissioningAdminVendorID    AttributeID = 0x0002
+)
+
+// OperationalCredentials Cluster
+const (
+	AttributeOperationalCredentialsNOCs                    AttributeID = 0x0000
+	AttributeOperationalCredentialsFabrics                 AttributeID = 0x0001
+	AttributeOperationalCredentialsSupportedFabrics        AttributeID = 0x0002
+	AttributeOperationalCredentialsCommissionedFabrics     AttributeID = 0x0003
+	AttributeOperationalCredentialsTrustedRootCertificates AttributeID = 0x0004
+	AttributeOperationalCredentialsCurrentFabricIndex      AttributeID = 0x0005
+)
+
+// GroupKeyManagement Cluster
+const (
+	AttributeGroupKeyManagementGroupKeyMap           AttributeID = 0x0000
+	AttributeGroupKeyManagementGroupTable            AttributeID = 0x0001
+	AttributeGroupKeyManagementMaxGroupsPerFabric    AttributeID = 0x0002
+	AttributeGroupKeyManagementMaxGroupKeysPerFabric AttributeID = 0x0003
+)
+
+// FixedLabel Cluster
+const (
+	AttributeFixedLabelLabelList AttributeID = 0x0000
+)
+
+// UserLabel Cluster
+const (
+	AttributeUserLabelLabelList AttributeID = 0x0000
+)
+
+// BooleanState Cluster
+const (
+	AttributeBooleanStateStateValue AttributeID = 0x0000
+)
+
+// ICDManagement Cluster
+const (
+	AttributeICDManagementIdleModeDuration                 AttributeID = 0x0000
+	AttributeICDManagementActiveModeDuration               AttributeID = 0x0001
+	AttributeICDManagementActiveModeThreshold              AttributeID = 0x0002
+	AttributeICDManagementRegisteredClients                AttributeID = 0x0003
+	AttributeICDManagementICDCounter                       AttributeID = 0x0004
+	AttributeICDManagementClientsSupportedPerFabric        AttributeID = 0x0005
+	AttributeICDManagementUserActiveModeTriggerHint        AttributeID = 0x0006
+	AttributeICDManagementUserActiveModeTriggerInstruction AttributeID = 0x0007
+	AttributeICDManagementOperatingMode                    AttributeID = 0x0008
+)
+
+// OvenMode Cluster
+const (
+	AttributeOvenModeSupportedModes AttributeID = 0x0000
+	AttributeOvenModeCurrentMode    AttributeID = 0x0001
+	AttributeOvenModeStartUpMode    AttributeID = 0x0002
+	AttributeOvenModeOnMode         AttributeID = 0x0003
+)
+
+// ModeSelect Cluster
+const (
+	AttributeModeSelectDescription       AttributeID = 0x0000
+	AttributeModeSelectStandardNamespace AttributeID = 0x0001
+	AttributeModeSelectSupportedModes    AttributeID = 0x0002
+	AttributeModeSelectCurrentMode       AttributeID = 0x0003
+	AttributeModeSelectStartUpMode       AttributeID = 0x0004
+	AttributeModeSelectOnMode            AttributeID = 0x0005
+)
+
+// LaundryWasherMode Cluster
+const (
+	AttributeLaundryWasherModeSupportedModes AttributeID = 0x0000
+	AttributeLaundryWasherModeCurrentMode    AttributeID = 0x0001
+	AttributeLaundryWasherModeStartUpMode    AttributeID = 0x0002
+	AttributeLaundryWasherModeOnMode         AttributeID = 0x0003
+)
+
+// RVCRunMode Cluster
+const (
+	AttributeRVCRunModeSupportedModes AttributeID = 0x0000
+	AttributeRVCRunModeCurrentMode    AttributeID = 0x0001
+	AttributeRVCRunModeStartUpMode    AttributeID = 0x0002
+	AttributeRVCRunModeOnMode         AttributeID = 0x0003
+)
+
+// RVCCleanMode Cluster
+const (
+	AttributeRVCCleanModeSupportedModes AttributeID = 0x0000
+	AttributeRVCCleanModeCurrentMode    AttributeID = 0x0001
+	AttributeRVCCleanModeStartUpMode    AttributeID = 0x0002
+	AttributeRVCCleanModeOnMode         AttributeID = 0x0003
+)
+
+// ScenesManagement Cluster
+const (
+	AttributeScenesManagementLastConfiguredBy AttributeID = 0x0000
+	AttributeScenesManagementSceneTableSize   AttributeID = 0x0001
+	AttributeScenesManagementFabricSceneInfo  AttributeID = 0x0002
+)
+
+// DoorLock Cluster
+const (
+	AttributeDoorLockLockState       AttributeID = 0x0000
+	AttributeDoorLockLockType        AttributeID = 0x0001
+	AttributeDoorLockActuatorEnabled AttributeID = 0x0002
+)
+
+// WindowCovering Cluster
+const (
+	AttributeWindowCoveringType              AttributeID = 0x0000
+	AttributeWindowCoveringConfigStatus      AttributeID = 0x0007
+	AttributeWindowCoveringOperationalStatus AttributeID = 0x000A
+	AttributeWindowCoveringEndProductType    AttributeID = 0x000D
+	AttributeWindowCoveringMode              AttributeID = 0x0017
+)
+
+// Thermostat Cluster
+const (
+	AttributeThermostatLocalTemperature           AttributeID = 0x0000
+	AttributeThermostatOccupiedCoolingSetpoint    AttributeID = 0x0011
+	AttributeThermostatOccupiedHeatingSetpoint    AttributeID = 0x0012
+	AttributeThermostatControlSequenceOfOperation AttributeID = 0x001B
+	AttributeThermostatSystemMode                 AttributeID = 0x001C
+)
+
+// FanControl Cluster
+const (
+	AttributeFanControlFanMode         AttributeID = 0x0000
+	AttributeFanControlFanModeSequence AttributeID = 0x0001
+	AttributeFanControlPercentSetting  AttributeID = 0x0002
+	AttributeFanControlPercentCurrent  AttributeID = 0x0003
+)
+
+// ColorControl Cluster
+const (
+	AttributeColorControlCurrentHue             AttributeID = 0x0000
+	AttributeColorControlCurrentSaturation      AttributeID = 0x0001
+	AttributeColorControlRemainingTime          AttributeID = 0x0002
+	AttributeColorControlCurrentX               AttributeID = 0x0003
+	AttributeColorControlCurrentY               AttributeID = 0x0004
+	AttributeColorControlColorTemperatureMireds AttributeID = 0x0007
+	AttributeColorControlColorMode              AttributeID = 0x0008
+	AttributeColorControlOptions                AttributeID = 0x000F
+)
+
+// IlluminanceMeasurement Cluster
+const (
+	AttributeIlluminanceMeasurementMeasuredValue    AttributeID = 0x0000
+	AttributeIlluminanceMeasurementMinMeasuredValue AttributeID = 0x0001
+	AttributeIlluminanceMeasurementMaxMeasuredValue AttributeID = 0x0002
+	AttributeIlluminanceMeasurementTolerance        AttributeID = 0x0003
+)
+
+// TemperatureMeasurement Cluster
+const (
+	AttributeTemperatureMeasurementMeasuredValue    AttributeID = 0x0000
+	AttributeTemperatureMeasurementMinMeasuredValue AttributeID = 0x0001
+	AttributeTemperatureMeasurementMaxMeasuredValue AttributeID = 0x0002
+	AttributeTemperatureMeasurementTolerance        AttributeID = 0x0003
+)
+
+// PressureMeasurement Cluster
+const (
+	AttributePressureMeasurementMeasuredValue    AttributeID = 0x0000
+	AttributePressureMeasurementMinMeasuredValue AttributeID = 0x0001
+	AttributePressureMeasurementMaxMeasuredValue AttributeID = 0x0002
+	AttributePressureMeasurementTolerance        AttributeID = 0x0003
+)
+
+// FlowMeasurement Cluster
+const (
+	AttributeFlowMeasurementMeasuredValue    AttributeID = 0x0000
+	AttributeFlowMeasurementMinMeasuredValue AttributeID = 0x0001
+	AttributeFlowMeasurementMaxMeasuredValue AttributeID = 0x0002
+	AttributeFlowMeasurementTolerance        AttributeID = 0x0003
+)
+
+// RelativeHumidityMeasurement Cluster
+const (
+	AttributeRelativeHumidityMeasurementMeasuredValue    AttributeID = 0x0000
+	AttributeRelativeHumidityMeasurementMinMeasuredValue AttributeID = 0x0001
+	AttributeRelativeHumidityMeasurementMaxMeasuredValue AttributeID = 0x0002
+	AttributeRelativeHumidityMeasurementTolerance        AttributeID = 0x0003
+)
+
+// OccupancySensing Cluster
+const (
+	AttributeOccupancySensingOccupancy                 AttributeID = 0x0000
+	AttributeOccupancySensingOccupancySensorType       AttributeID = 0x0001
+	AttributeOccupancySensingOccupancySensorTypeBitmap AttributeID = 0x0002
+	AttributeOccupancySensingHoldTime                  AttributeID = 0x0003
+)
+
+// CommissionerControl Cluster
+const (
+	AttributeCommissionerControlSupportedDeviceCategories AttributeID = 0x0000
+)
+
+var clusterNames = map[ClusterID]string{
+	ClusterIdentify:                      "Identify",
+	ClusterGroups:                        "Groups",
+	ClusterOnOff:                         "OnOff",
+	ClusterLevelControl:                  "LevelControl",
+	ClusterDescriptor:                    "Descriptor",
+	ClusterBinding:                       "Binding",
+	ClusterAccessControl:                 "AccessControl",
+	ClusterBasicInformation:              "BasicInformation",
+	ClusterOTASoftwareUpdateProvider:     "OTASoftwareUpdateProvider",
+	ClusterOTASoftwareUpdateRequestor:    "OTASoftwareUpdateRequestor",
+	ClusterLocalizationConfiguration:     "LocalizationConfiguration",
+	ClusterTimeFormatLocalization:        "TimeFormatLocalization",
+	ClusterUnitLocalization:              "UnitLocalization",
+	ClusterPowerSourceConfiguration:      "PowerSourceConfiguration",
+	ClusterPowerSource:                   "PowerSource",
+	ClusterGeneralCommissioning:          "GeneralCommissioning",
+	ClusterNetworkCommissioning:          "NetworkCommissioning",
+	ClusterDiagnosticLogs:                "DiagnosticLogs",
+	ClusterGeneralDiagnostics:            "GeneralDiagnostics",
+	ClusterSoftwareDiagnostics:           "SoftwareDiagnostics",
+	ClusterThreadNetworkDiagnostics:      "ThreadNetworkDiagnostics",
+	ClusterWiFiNetworkDiagnostics:        "WiFiNetworkDiagnostics",
+	ClusterEthernetNetworkDiagnostics:    "EthernetNetworkDiagnostics",
+	ClusterTimeSynchronization:           "TimeSynchronization",
+	ClusterBridgedDeviceBasicInformation: "BridgedDeviceBasicInformation",
+	ClusterSwitch:                        "Switch",
+	ClusterAdministratorCommissioning:    "AdministratorCommissioning",
+	ClusterOperationalCredentials:        "OperationalCredentials",
+	ClusterGroupKeyManagement:            "GroupKeyManagement",
+	ClusterFixedLabel:                    "FixedLabel",
+	ClusterUserLabel:                     "UserLabel",
+	ClusterBooleanState:                  "BooleanState",
+	ClusterICDManagement:                 "ICDManagement",
+	ClusterOvenMode:                      "OvenMode",
+	ClusterModeSelect:                    "ModeSelect",
+	ClusterLaundryWasherMode:             "LaundryWasherMode",
+	ClusterRVCRunMode:                    "RVCRunMode",
+	ClusterRVCCleanMode:                  "RVCCleanMode",
+	ClusterScenesManagement:              "ScenesManagement",
+	ClusterDoorLock:                      "DoorLock",
+	ClusterWindowCovering:                "WindowCovering",
+	ClusterThermostat:                    "Thermostat",
+	ClusterFanControl:                    "FanControl",
+	ClusterColorControl:                  "ColorControl",
+	ClusterIlluminanceMeasurement:        "IlluminanceMeasurement",
+	ClusterTemperatureMeasurement:        "TemperatureMeasurement",
+	ClusterPressureMeasurement:           "PressureMeasurement",
+	ClusterFlowMeasurement:               "FlowMeasurement",
+	ClusterRelativeHumidityMeasurement:   "RelativeHumidityMeasurement",
+	ClusterOccupancySensing:              "OccupancySensing",
+	ClusterCommissionerControl:           "CommissionerControl",
+}
+
+var attributeNames = map[ClusterID]map[AttributeID]string{
+	ClusterIdentify: {
+		AttributeIdentifyIdentifyTime: "IdentifyTime",
+		AttributeIdentifyIdentifyType: "IdentifyType",
+	},
+	ClusterGroups: {
+		AttributeGroupsNameSupport: "NameSupport",
+	},
+	ClusterOnOff: {
+		AttributeOnOffOnOff:              "OnOff",
+		AttributeOnOffGlobalSceneControl: "GlobalSceneControl",
+		AttributeOnOffOnTime:             "OnTime",
+		AttributeOnOffOffWaitTime:        "OffWaitTime",
+		AttributeOnOffStartUpOnOff:       "StartUpOnOff",
+	},
+	ClusterLevelControl: {
+		AttributeLevelControlCurrentLevel:        "CurrentLevel",
+		AttributeLevelControlRemainingTime:       "RemainingTime",
+		AttributeLevelControlMinLevel:            "MinLevel",
+		AttributeLevelControlMaxLevel:            "MaxLevel",
+		AttributeLevelControlCurrentFrequency:    "CurrentFrequency",
+		AttributeLevelControlMinFrequency:        "MinFrequency",
+		AttributeLevelControlMaxFrequency:        "MaxFrequency",
+		AttributeLevelControlOptions:             "Options",
+		AttributeLevelControlOnOffTransitionTime: "OnOffTransitionTime",
+		AttributeLevelControlOnLevel:             "OnLevel",
+		AttributeLevelControlOnTransitionTime:    "OnTransitionTime",
+		AttributeLevelControlOffTransitionTime:   "OffTransitionTime",
+		AttributeLevelControlDefaultMoveRate:     "DefaultMoveRate",
+		AttributeLevelControlStartUpCurrentLevel: "StartUpCurrentLevel",
+	},
+	ClusterDescriptor: {
+		AttributeDescriptorDeviceTypeList: "DeviceTypeList",
+		AttributeDescriptorServerList:     "ServerList",
+		AttributeDescriptorClientList:     "ClientList",
+		AttributeDescriptorPartsList:      "PartsList",
+		AttributeDescriptorTagList:        "TagList",
+	},
+	ClusterBinding: {
+		AttributeBindingBinding: "Binding",
+	},
+	ClusterAccessControl: {
+		AttributeAccessControlACL:                           "ACL",
+		AttributeAccessControlExtension:                     "Extension",
+		AttributeAccessControlSubjectsPerAccessControlEntry: "SubjectsPerAccessControlEntry",
+		AttributeAccessControlTargetsPerAccessControlEntry:  "TargetsPerAccessControlEntry",
+		AttributeAccessControlAccessControlEntriesPerFabric: "AccessControlEntriesPerFabric",
+	},
+	ClusterBasicInformation: {
+		AttributeBasicInformationDataModelRevision:     "DataModelRevision",
+		AttributeBasicInformationVendorName:            "VendorName",
+		AttributeBasicInformationVendorID:              "VendorID",
+		AttributeBasicInformationProductName:           "ProductName",
+		AttributeBasicInformationProductID:             "ProductID",
+		AttributeBasicInformationNodeLabel:             "NodeLabel",
+		AttributeBasicInformationLocation:              "Location",
+		AttributeBasicInformationHardwareVersion:       "HardwareVersion",
+		AttributeBasicInformationHardwareVersionString: "HardwareVersionString",
+		AttributeBasicInformationSoftwareVersion:       "SoftwareVersion",
+		AttributeBasicInformationSoftwareVersionString: "SoftwareVersionString",
+		AttributeBasicInformationManufacturingDate:     "ManufacturingDate",
+		AttributeBasicInformationPartNumber:            "PartNumber",
+		AttributeBasicInformationProductURL:            "ProductURL",
+		AttributeBasicInformationProductLabel:          "ProductLabel",
+		AttributeBasicInformationSerialNumber:          "SerialNumber",
+		AttributeBasicInformationLocalConfigDisabled:   "LocalConfigDisabled",
+		AttributeBasicInformationReachable:             "Reachable",
+		AttributeBasicInformationUniqueID:              "UniqueID",
+		AttributeBasicInformationCapabilityMinima:      "CapabilityMinima",
+		AttributeBasicInformationProductAppearance:     "ProductAppearance",
+		AttributeBasicInformationSpecificationVersion:  "SpecificationVersion",
+		AttributeBasicInformationMaxPathsPerInvoke:     "MaxPathsPerInvoke",
+	},
+	ClusterOTASoftwareUpdateRequestor: {
+		AttributeOTASoftwareUpdateRequestorDefaultOTAProviders: "DefaultOTAProviders",
+		AttributeOTASoftwareUpdateRequestorUpdatePossible:      "UpdatePossible",
+		AttributeOTASoftwareUpdateRequestorUpdateState:         "UpdateState",
+		AttributeOTASoftwareUpdateRequestorUpdateStateProgress: "UpdateStateProgress",
+	},
+	ClusterLocalizationConfiguration: {
+		AttributeLocalizationConfigurationActiveLocale:     "ActiveLocale",
+		AttributeLocalizationConfigurationSupportedLocales: "SupportedLocales",
+	},
+	ClusterTimeFormatLocalization: {
+		AttributeTimeFormatLocalizationHourFormat:             "HourFormat",
+		AttributeTimeFormatLocalizationActiveCalendarType:     "ActiveCalendarType",
+		AttributeTimeFormatLocalizationSupportedCalendarTypes: "SupportedCalendarTypes",
+	},
+	ClusterUnitLocalization: {
+		AttributeUnitLocalizationTemperatureUnit: "TemperatureUnit",
+	},
+	ClusterPowerSourceConfiguration: {
+		AttributePowerSourceConfigurationSources: "Sources",
+	},
+	ClusterPowerSource: {
+		AttributePowerSourceStatus:                      "Status",
+		AttributePowerSourceOrder:                       "Order",
+		AttributePowerSourceDescription:                 "Description",
+		AttributePowerSourceWiredAssessedInputVoltage:   "WiredAssessedInputVoltage",
+		AttributePowerSourceWiredAssessedInputFrequency: "WiredAssessedInputFrequency",
+		AttributePowerSourceWiredCurrentType:            "WiredCurrentType",
+		AttributePowerSourceWiredAssessedCurrent:        "WiredAssessedCurrent",
+		AttributePowerSourceWiredNominalVoltage:         "WiredNominalVoltage",
+		AttributePowerSourceWiredMaximumCurrent:         "WiredMaximumCurrent",
+		AttributePowerSourceWiredPresent:                "WiredPresent",
+		AttributePowerSourceActiveWiredFaults:           "ActiveWiredFaults",
+		AttributePowerSourceBatVoltage:                  "BatVoltage",
+		AttributePowerSourceBatPercentRemaining:         "BatPercentRemaining",
+		AttributePowerSourceBatTimeRemaining:            "BatTimeRemaining",
+		AttributePowerSourceBatChargeLevel:              "BatChargeLevel",
+		AttributePowerSourceBatReplacementNeeded:        "BatReplacementNeeded",
+		AttributePowerSourceBatReplaceability:           "BatReplaceability",
+		AttributePowerSourceBatPresent:                  "BatPresent",
+		AttributePowerSourceActiveBatFaults:             "ActiveBatFaults",
+		AttributePowerSourceBatReplacementDescription:   "BatReplacementDescription",
+		AttributePowerSourceBatCommonDesignation:        "BatCommonDesignation",
+		AttributePowerSourceBatANSIDesignation:          "BatANSIDesignation",
+		AttributePowerSourceBatIECDesignation:           "BatIECDesignation",
+		AttributePowerSourceBatApprovedChemistry:        "BatApprovedChemistry",
+		AttributePowerSourceBatCapacity:                 "BatCapacity",
+		AttributePowerSourceBatQuantity:                 "BatQuantity",
+		AttributePowerSourceBatChargeState:              "BatChargeState",
+		AttributePowerSourceBatTimeToFullCharge:         "BatTimeToFullCharge",
+		AttributePowerSourceBatFunctionalWhileCharging:  "BatFunctionalWhileCharging",
+		AttributePowerSourceBatChargingCurrent:          "BatChargingCurrent",
+		AttributePowerSourceActiveBatChargeFaults:       "ActiveBatChargeFaults",
+		AttributePowerSourceEndpointList:                "EndpointList",
+	},
+	ClusterGeneralCommissioning: {
+		AttributeGeneralCommissioningBreadcrumb:                   "Breadcrumb",
+		AttributeGeneralCommissioningBasicCommissioningInfo:       "BasicCommissioningInfo",
+		AttributeGeneralCommissioningRegulatoryConfig:             "RegulatoryConfig",
+		AttributeGeneralCommissioningLocationCapability:           "LocationCapability",
+		AttributeGeneralCommissioningSupportsConcurrentConnection: "SupportsConcurrentConnection",
+	},
+	ClusterNetworkCommissioning: {
+		AttributeNetworkCommissioningMaxNetworks:           "MaxNetworks",
+		AttributeNetworkCommissioningNetworks:              "Networks",
+		AttributeNetworkCommissioningScanMaxTimeSeconds:    "ScanMaxTimeSeconds",
+		AttributeNetworkCommissioningConnectMaxTimeSeconds: "ConnectMaxTimeSeconds",
+		AttributeNetworkCommissioningInterfaceEnabled:      "InterfaceEnabled",
+		AttributeNetworkCommissioningLastNetworkingStatus:  "LastNetworkingStatus",
+		AttributeNetworkCommissioningLastNetworkID:         "LastNetworkID",
+		AttributeNetworkCommissioningLastConnectErrorValue: "LastConnectErrorValue",
+	},
+	ClusterGeneralDiagnostics: {
+		AttributeGeneralDiagnosticsNetworkInterfaces:        "NetworkInterfaces",
+		AttributeGeneralDiagnosticsRebootCount:              "RebootCount",
+		AttributeGeneralDiagnosticsUpTime:                   "UpTime",
+		AttributeGeneralDiagnosticsTotalOperationalHours:    "TotalOperationalHours",
+		AttributeGeneralDiagnosticsBootReason:               "BootReason",
+		AttributeGeneralDiagnosticsActiveHardwareFaults:     "ActiveHardwareFaults",
+		AttributeGeneralDiagnosticsActiveRadioFaults:        "ActiveRadioFaults",
+		AttributeGeneralDiagnosticsActiveNetworkFaults:      "ActiveNetworkFaults",
+		AttributeGeneralDiagnosticsTestEventTriggersEnabled: "TestEventTriggersEnabled",
+	},
+	ClusterSoftwareDiagnostics: {
+		AttributeSoftwareDiagnosticsThreadMetrics:            "ThreadMetrics",
+		AttributeSoftwareDiagnosticsCurrentHeapFree:          "CurrentHeapFree",
+		AttributeSoftwareDiagnosticsCurrentHeapUsed:          "CurrentHeapUsed",
+		AttributeSoftwareDiagnosticsCurrentHeapHighWatermark: "CurrentHeapHighWatermark",
+	},
+	ClusterThreadNetworkDiagnostics: {
+		AttributeThreadNetworkDiagnosticsChannel:                           "Channel",
+		AttributeThreadNetworkDiagnosticsRoutingRole:                       "RoutingRole",
+		AttributeThreadNetworkDiagnosticsNetworkName:                       "NetworkName",
+		AttributeThreadNetworkDiagnosticsPanID:                             "PanID",
+		AttributeThreadNetworkDiagnosticsExtendedPanID:                     "ExtendedPanID",
+		AttributeThreadNetworkDiagnosticsMeshLocalPrefix:                   "MeshLocalPrefix",
+		AttributeThreadNetworkDiagnosticsOverrunCount:                      "OverrunCount",
+		AttributeThreadNetworkDiagnosticsNeighborTable:                     "NeighborTable",
+		AttributeThreadNetworkDiagnosticsRouteTable:                        "RouteTable",
+		AttributeThreadNetworkDiagnosticsPartitionID:                       "PartitionID",
+		AttributeThreadNetworkDiagnosticsWeighting:                         "Weighting",
+		AttributeThreadNetworkDiagnosticsDataVersion:                       "DataVersion",
+		AttributeThreadNetworkDiagnosticsStableDataVersion:                 "StableDataVersion",
+		AttributeThreadNetworkDiagnosticsLeaderRouterID:                    "LeaderRouterID",
+		AttributeThreadNetworkDiagnosticsDetachedRoleCount:                 "DetachedRoleCount",
+		AttributeThreadNetworkDiagnosticsChildRoleCount:                    "ChildRoleCount",
+		AttributeThreadNetworkDiagnosticsRouterRoleCount:                   "RouterRoleCount",
+		AttributeThreadNetworkDiagnosticsLeaderRoleCount:                   "LeaderRoleCount",
+		AttributeThreadNetworkDiagnosticsAttachAttemptCount:                "AttachAttemptCount",
+		AttributeThreadNetworkDiagnosticsPartitionIDChangeCount:            "PartitionIDChangeCount",
+		AttributeThreadNetworkDiagnosticsBetterPartitionAttachAttemptCount: "BetterPartitionAttachAttemptCount",
+		AttributeThreadNetworkDiagnosticsParentChangeCount:                 "ParentChangeCount",
+		AttributeThreadNetworkDiagnosticsTxTotalCount:                      "TxTotalCount",
+		AttributeThreadNetworkDiagnosticsTxUnicastCount:                    "TxUnicastCount",
+		AttributeThreadNetworkDiagnosticsTxBroadcastCount:                  "TxBroadcastCount",
+		AttributeThreadNetworkDiagnosticsTxAckRequestedCount:               "TxAckRequestedCount",
+		AttributeThreadNetworkDiagnosticsTxAckedCount:                      "TxAckedCount",
+		AttributeThreadNetworkDiagnosticsTxNoAckRequestedCount:             "TxNoAckRequestedCount",
+		AttributeThreadNetworkDiagnosticsTxDataCount:                       "TxDataCount",
+		AttributeThreadNetworkDiagnosticsTxDataPollCount:                   "TxDataPollCount",
+		AttributeThreadNetworkDiagnosticsTxBeaconCount:                     "TxBeaconCount",
+		AttributeThreadNetworkDiagnosticsTxBeaconRequestCount:              "TxBeaconRequestCount",
+		AttributeThreadNetworkDiagnosticsTxOtherCount:                      "TxOtherCount",
+		AttributeThreadNetworkDiagnosticsTxRetryCount:                      "TxRetryCount",
+		AttributeThreadNetworkDiagnosticsTxDirectMaxRetryExpiryCount:       "TxDirectMaxRetryExpiryCount",
+		AttributeThreadNetworkDiagnosticsTxIndirectMaxRetryExpiryCount:     "TxIndirectMaxRetryExpiryCount",
+		AttributeThreadNetworkDiagnosticsTxErrCcaCount:                     "TxErrCcaCount",
+		AttributeThreadNetworkDiagnosticsTxErrAbortCount:                   "TxErrAbortCount",
+		AttributeThreadNetworkDiagnosticsTxErrBusyChannelCount:             "TxErrBusyChannelCount",
+		AttributeThreadNetworkDiagnosticsRxTotalCount:                      "RxTotalCount",
+		AttributeThreadNetworkDiagnosticsRxUnicastCount:                    "RxUnicastCount",
+		AttributeThreadNetworkDiagnosticsRxBroadcastCount:                  "RxBroadcastCount",
+		AttributeThreadNetworkDiagnosticsRxDataCount:                       "RxDataCount",
+		AttributeThreadNetworkDiagnosticsRxDataPollCount:                   "RxDataPollCount",
+		AttributeThreadNetworkDiagnosticsRxBeaconCount:                     "RxBeaconCount",
+		AttributeThreadNetworkDiagnosticsRxBeaconRequestCount:              "RxBeaconRequestCount",
+		AttributeThreadNetworkDiagnosticsRxOtherCount:                      "RxOtherCount",
+		AttributeThreadNetworkDiagnosticsRxAddressFilteredCount:            "RxAddressFilteredCount",
+		AttributeThreadNetworkDiagnosticsRxDestAddrFilteredCount:           "RxDestAddrFilteredCount",
+		AttributeThreadNetworkDiagnosticsRxDuplicatedCount:                 "RxDuplicatedCount",
+		AttributeThreadNetworkDiagnosticsRxErrNoFrameCount:                 "RxErrNoFrameCount",
+		AttributeThreadNetworkDiagnosticsRxErrUnknownNeighborCount:         "RxErrUnknownNeighborCount",
+		AttributeThreadNetworkDiagnosticsRxErrInvalidSrcAddrCount:          "RxErrInvalidSrcAddrCount",
+		AttributeThreadNetworkDiagnosticsRxErrSecCount:                     "RxErrSecCount",
+		AttributeThreadNetworkDiagnosticsRxErrFcsCount:                     "RxErrFcsCount",
+		AttributeThreadNetworkDiagnosticsRxErrOtherCount:                   "RxErrOtherCount",
+		AttributeThreadNetworkDiagnosticsActiveTimestamp:                   "ActiveTimestamp",
+		AttributeThreadNetworkDiagnosticsPendingTimestamp:                  "PendingTimestamp",
+		AttributeThreadNetworkDiagnosticsDelay:                             "Delay",
+		AttributeThreadNetworkDiagnosticsSecurityPolicy:                    "SecurityPolicy",
+		AttributeThreadNetworkDiagnosticsChannelPage0Mask:                  "ChannelPage0Mask",
+		AttributeThreadNetworkDiagnosticsOperationalDatasetComponents:      "OperationalDatasetComponents",
+		AttributeThreadNetworkDiagnosticsActiveNetworkFaultsList:           "ActiveNetworkFaultsList",
+	},
+	ClusterWiFiNetworkDiagnostics: {
+		AttributeWiFiNetworkDiagnosticsBSSID:                  "BSSID",
+		AttributeWiFiNetworkDiagnosticsSecurityType:           "SecurityType",
+		AttributeWiFiNetworkDiagnosticsWiFiVersion:            "WiFiVersion",
+		AttributeWiFiNetworkDiagnosticsChannelNumber:          "ChannelNumber",
+		AttributeWiFiNetworkDiagnosticsRSSI:                   "RSSI",
+		AttributeWiFiNetworkDiagnosticsBeaconLostCount:        "BeaconLostCount",
+		AttributeWiFiNetworkDiagnosticsBeaconRxCount:          "BeaconRxCount",
+		AttributeWiFiNetworkDiagnosticsPacketMulticastRxCount: "PacketMulticastRxCount",
+		AttributeWiFiNetworkDiagnosticsPacketMulticastTxCount: "PacketMulticastTxCount",
+		AttributeWiFiNetworkDiagnosticsPacketUnicastRxCount:   "PacketUnicastRxCount",
+		AttributeWiFiNetworkDiagnosticsPacketUnicastTxCount:   "PacketUnicastTxCount",
+		AttributeWiFiNetworkDiagnosticsCurrentMaxRate:         "CurrentMaxRate",
+		AttributeWiFiNetworkDiagnosticsOverrunCount:           "OverrunCount",
+	},
+	ClusterEthernetNetworkDiagnostics: {
+		AttributeEthernetNetworkDiagnosticsPHYRate:        "PHYRate",
+		AttributeEthernetNetworkDiagnosticsFullDuplex:     "FullDuplex",
+		AttributeEthernetNetworkDiagnosticsPacketRxCount:  "PacketRxCount",
+		AttributeEthernetNetworkDiagnosticsPacketTxCount:  "PacketTxCount",
+		AttributeEthernetNetworkDiagnosticsTxErrCount:     "TxErrCount",
+		AttributeEthernetNetworkDiagnosticsCollisionCount: "CollisionCount",
+		AttributeEthernetNetworkDiagnosticsOverrunCount:   "OverrunCount",
+		AttributeEthernetNetworkDiagnosticsCarrierDetect:  "CarrierDetect",
+		AttributeEthernetNetworkDiagnosticsTimeSinceReset: "TimeSinceReset",
+	},
+	ClusterTimeSynchronization: {
+		AttributeTimeSynchronizationUTCTime:              "UTCTime",
+		AttributeTimeSynchronizationGranularity:          "Granularity",
+		AttributeTimeSynchronizationTimeSource:           "TimeSource",
+		AttributeTimeSynchronizationTrustedTimeSource:    "TrustedTimeSource",
+		AttributeTimeSynchronizationDefaultNTP:           "DefaultNTP",
+		AttributeTimeSynchronizationTimeZone:             "TimeZone",
+		AttributeTimeSynchronizationDSTOffset:            "DSTOffset",
+		AttributeTimeSynchronizationLocalTime:            "LocalTime",
+		AttributeTimeSynchronizationTimeZoneDatabase:     "TimeZoneDatabase",
+		AttributeTimeSynchronizationNTPServerAvailable:   "NTPServerAvailable",
+		AttributeTimeSynchronizationTimeZoneListMaxSize:  "TimeZoneListMaxSize",
+		AttributeTimeSynchronizationDSTOffsetListMaxSize: "DSTOffsetListMaxSize",
+		AttributeTimeSynchronizationSupportsDNSResolve:   "SupportsDNSResolve",
+	},
+	ClusterBridgedDeviceBasicInformation: {
+		AttributeBridgedDeviceBasicInformationVendorName:            "VendorName",
+		AttributeBridgedDeviceBasicInformationVendorID:              "VendorID",
+		AttributeBridgedDeviceBasicInformationProductName:           "ProductName",
+		AttributeBridgedDeviceBasicInformationNodeLabel:             "NodeLabel",
+		AttributeBridgedDeviceBasicInformationHardwareVersion:       "HardwareVersion",
+		AttributeBridgedDeviceBasicInformationHardwareVersionString: "HardwareVersionString",
+		AttributeBridgedDeviceBasicInformationSoftwareVersion:       "SoftwareVersion",
+		AttributeBridgedDeviceBasicInformationSoftwareVersionString: "SoftwareVersionString",
+		AttributeBridgedDeviceBasicInformationManufacturingDate:     "ManufacturingDate",
+		AttributeBridgedDeviceBasicInformationPartNumber:            "PartNumber",
+		AttributeBridgedDeviceBasicInformationProductURL:            "ProductURL",
+		AttributeBridgedDeviceBasicInformationProductLabel:          "ProductLabel",
+		AttributeBridgedDeviceBasicInformationSerialNumber:          "SerialNumber",
+		AttributeBridgedDeviceBasicInformationReachable:             "Reachable",
+		AttributeBridgedDeviceBasicInformationUniqueID:              "UniqueID",
+		AttributeBridgedDeviceBasicInformationProductAppearance:     "ProductAppearance",
+	},
+	ClusterSwitch: {
+		AttributeSwitchNumberOfPositions: "NumberOfPositions",
+		AttributeSwitchCurrentPosition:   "CurrentPosition",
+		AttributeSwitchMultiPressMax:     "MultiPressMax",
+	},
+	ClusterAdministratorCommissioning: {
+		AttributeAdministratorCommissioningWindowStatus:     "WindowStatus",
+		AttributeAdministratorCommissioningAdminFabricIndex: "AdminFabricIndex",
+		AttributeAdministratorCommissioningAdminVendorID:    "AdminVendorID",
+	},
+	ClusterOperationalCredentials: {
+		AttributeOperationalCredentialsNOCs:                    "NOCs",
+		AttributeOperationalCredentialsFabrics:                 "Fabrics",
+		AttributeOperationalCredentialsSupportedFabrics:        "SupportedFabrics",
+		AttributeOperationalCredentialsCommissionedFabrics:     "CommissionedFabrics",
+		AttributeOperationalCredentialsTrustedRootCertificates: "TrustedRootCertificates",
+		AttributeOperationalCredentialsCurrentFabricIndex:      "CurrentFabricIndex",
+	},
+	ClusterGroupKeyManagement: {
+		AttributeGroupKeyManagementGroupKeyMap:           "GroupKeyMap",
+		AttributeGroupKeyManagementGroupTable:            "GroupTable",
+		AttributeGroupKeyManagementMaxGroupsPerFabric:    "MaxGroupsPerFabric",
+		AttributeGroupKeyManagementMaxGroupKeysPerFabric: "MaxGroupKeysPerFabric",
+	},
+	ClusterFixedLabel: {
+		AttributeFixedLabelLabelList: "LabelList",
+	},
+	ClusterUserLabel: {
+		AttributeUserLabelLabelList: "LabelList",
+	},
+	ClusterBooleanState: {
+		AttributeBooleanStateStateValue: "StateValue",
+	},
+	ClusterICDManagement: {
+		AttributeICDManagementIdleModeDuration:                 "IdleModeDuration",
+		AttributeICDManagementActiveModeDuration:               "ActiveModeDuration",
+		AttributeICDManagementActiveModeThreshold:              "ActiveModeThreshold",
+		AttributeICDManagementRegisteredClients:                "RegisteredClients",
+		AttributeICDManagementICDCounter:                       "ICDCounter",
+		AttributeICDManagementClientsSupportedPerFabric:        "ClientsSupportedPerFabric",
+		AttributeICDManagementUserActiveModeTriggerHint:        "UserActiveModeTriggerHint",
+		AttributeICDManagementUserActiveModeTriggerInstruction: "UserActiveModeTriggerInstruction",
+		AttributeICDManagementOperatingMode:                    "OperatingMode",
+	},
+	ClusterOvenMode: {
+		AttributeOvenModeSupportedModes: "SupportedModes",
+		AttributeOvenModeCurrentMode:    "CurrentMode",
+		AttributeOvenModeStartUpMode:    "StartUpMode",
+		AttributeOvenModeOnMode:         "OnMode",
+	},
+	ClusterModeSelect: {
+		AttributeModeSelectDescription:       "Description",
+		AttributeModeSelectStandardNamespace: "StandardNamespace",
+		AttributeModeSelectSupportedModes:    "SupportedModes",
+		AttributeModeSelectCurrentMode:       "CurrentMode",
+		AttributeModeSelectStartUpMode:       "StartUpMode",
+		AttributeModeSelectOnMode:            "OnMode",
+	},
+	ClusterLaundryWasherMode: {
+		AttributeLaundryWasherModeSupportedModes: "SupportedModes",
+		AttributeLaundryWasherModeCurrentMode:    "CurrentMode",
+		AttributeLaundryWasherModeStartUpMode:    "StartUpMode",
+		AttributeLaundryWasherModeOnMode:         "OnMode",
+	},
+	ClusterRVCRunMode: {
+		AttributeRVCRunModeSupportedModes: "SupportedModes",
+		AttributeRVCRunModeCurrentMode:    "CurrentMode",
+		AttributeRVCRunModeStartUpMode:    "StartUpMode",
+		AttributeRVCRunModeOnMode:         "OnMode",
+	},
+	ClusterRVCCleanMode: {
+		AttributeRVCCleanModeSupportedModes: "SupportedModes",
+		AttributeRVCCleanModeCurrentMode:    "CurrentMode",
+		AttributeRVCCleanModeStartUpMode:    "StartUpMode",
+		AttributeRVCCleanModeOnMode:         "OnMode",
+	},
+	ClusterScenesManagement: {
+		AttributeScenesManagementLastConfiguredBy: "LastConfiguredBy",
+		AttributeScenesManagementSceneTableSize:   "SceneTableSize",
+		AttributeScenesManagementFabricSceneInfo:  "FabricSceneInfo",
+	},
+	ClusterDoorLock: {
+		AttributeDoorLockLockState:       "LockState",
+		AttributeDoorLockLockType:        "LockType",
+		AttributeDoorLockActuatorEnabled: "ActuatorEnabled",
+	},
+	ClusterWindowCovering: {
+		AttributeWindowCoveringType:              "Type",
+		AttributeWindowCoveringConfigStatus:      "ConfigStatus",
+		AttributeWindowCoveringOperationalStatus: "OperationalStatus",
+		AttributeWindowCoveringEndProductType:    "EndProductType",
+		AttributeWindowCoveringMode:              "Mode",
+	},
+	ClusterThermostat: {
+		AttributeThermostatLocalTemperature:           "LocalTemperature",
+		AttributeThermostatOccupiedCoolingSetpoint:    "OccupiedCoolingSetpoint",
+		AttributeThermostatOccupiedHeatingSetpoint:    "OccupiedHeatingSetpoint",
+		AttributeThermostatControlSequenceOfOperation: "ControlSequenceOfOperation",
+		AttributeThermostatSystemMode:                 "SystemMode",
+	},
+	ClusterFanControl: {
+		AttributeFanControlFanMode:         "FanMode",
+		AttributeFanControlFanModeSequence: "FanModeSequence",
+		AttributeFanControlPercentSetting:  "PercentSetting",
+		AttributeFanControlPercentCurrent:  "PercentCurrent",
+	},
+	ClusterColorControl: {
+		AttributeColorControlCurrentHue:             "CurrentHue",
+		AttributeColorControlCurrentSaturation:      "CurrentSaturation",
+		AttributeColorControlRemainingTime:          "RemainingTime",
+		AttributeColorControlCurrentX:               "CurrentX",
+		AttributeColorControlCurrentY:               "CurrentY",
+		AttributeColorControlColorTemperatureMireds: "ColorTemperatureMireds",
+		AttributeColorControlColorMode:              "ColorMode",
+		AttributeColorControlOptions:                "Options",
+	},
+	ClusterIlluminanceMeasurement: {
+		AttributeIlluminanceMeasurementMeasuredValue:    "MeasuredValue",
+		AttributeIlluminanceMeasurementMinMeasuredValue: "MinMeasuredValue",
+		AttributeIlluminanceMeasurementMaxMeasuredValue: "MaxMeasuredValue",
+		AttributeIlluminanceMeasurementTolerance:        "Tolerance",
+	},
+	ClusterTemperatureMeasurement: {
+		AttributeTemperatureMeasurementMeasuredValue:    "MeasuredValue",
+		AttributeTemperatureMeasurementMinMeasuredValue: "MinMeasuredValue",
+		AttributeTemperatureMeasurementMaxMeasuredValue: "MaxMeasuredValue",
+		AttributeTemperatureMeasurementTolerance:        "Tolerance",
+	},
+	ClusterPressureMeasurement: {
+		AttributePressureMeasurementMeasuredValue:    "MeasuredValue",
+		AttributePressureMeasurementMinMeasuredValue: "MinMeasuredValue",
+		AttributePressureMeasurementMaxMeasuredValue: "MaxMeasuredValue",
+		AttributePressureMeasurementTolerance:        "Tolerance",
+	},
+	ClusterFlowMeasurement: {
+		AttributeFlowMeasurementMeasuredValue:    "MeasuredValue",
+		AttributeFlowMeasurementMinMeasuredValue: "MinMeasuredValue",
+		AttributeFlowMeasurementMaxMeasuredValue: "MaxMeasuredValue",
+		AttributeFlowMeasurementTolerance:        "Tolerance",
+	},
+	ClusterRelativeHumidityMeasurement: {
+		AttributeRelativeHumidityMeasurementMeasuredValue:    "MeasuredValue",
+		AttributeRelativeHumidityMeasurementMinMeasuredValue: "MinMeasuredValue",
+		AttributeRelativeHumidityMeasurementMaxMeasuredValue: "MaxMeasuredValue",
+		AttributeRelativeHumidityMeasurementTolerance:        "Tolerance",
+	},
+	ClusterOccupancySensing: {
+		AttributeOccupancySensingOccupancy:                 "Occupancy",
+		AttributeOccupancySensingOccupancySensorType:       "OccupancySensorType",
+		AttributeOccupancySensingOccupancySensorTypeBitmap: "OccupancySensorTypeBitmap",
+		AttributeOccupancySensingHoldTime:                  "HoldTime",
+	},
+	ClusterCommissionerControl: {
+		AttributeCommissionerControlSupportedDeviceCategories: "SupportedDeviceCategories",
+	},
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package im
+
+import (
+	"testing"
+)
+
+func TestIDs(t *testing.T) {
+	if ClusterOnOff != 0x0006 || AttributeOnOffOnTime != 0x4001 || AttributeClusterRevision != 0xFFFD {
+		t.Errorf("0x%04X 0x%04X 0x%04X", ClusterOnOff, AttributeOnOffOnTime, AttributeClusterRevision)
+	}
+
+	clusters := []struct {
+		name     string
+		expected ClusterID
+	}{
+		{"OnOff", ClusterOnOff},
+		{"on_off", ClusterOnOff},
+		{"basic information", ClusterBasicInformation},
+		{"0x0006", ClusterOnOff},
+		{"64512", 0xFC00},
+	}
+	for _, test := range clusters {
+		id, ok := LookupClusterID(test.name)
+		if !ok || id != test.expected {
+			t.Errorf("%s : 0x%04X != 0x%04X", test.name, id, test.expected)
+		}
+	}
+	if _, ok := LookupClusterID("unknown"); ok {
+		t.Error("unknown cluster is found")
+	}
+	if ClusterName(ClusterLevelControl) != "LevelControl" || ClusterName(0xFC00) != "0xFC00" {
+		t.Errorf("%s %s", ClusterName(ClusterLevelControl), ClusterName(0xFC00))
+	}
+
+	if id, ok := LookupAttributeID(ClusterOnOff, "onTime"); !ok || id != AttributeOnOffOnTime {
+		t.Errorf("onTime : 0x%04X", id)
+	}
+	if id, ok := LookupAttributeID(ClusterOnOff, "FeatureMap"); !ok || id != AttributeFeatureMap {
+		t.Errorf("FeatureMap : 0x%04X", id)
+	}
+	if _, ok := LookupAttributeID(ClusterLevelControl, "onTime"); ok {
+		t.Error("onTime of LevelControl is found")
+	}
+	if AttributeName(ClusterOnOff, 0x4003) != "StartUpOnOff" || AttributeName(ClusterOnOff, 0x5000) != "0x5000" {
+		t.Errorf("%s %s", AttributeName(ClusterOnOff, 0x4003), AttributeName(ClusterOnOff, 0x5000))
+	}
+	if len(ClusterAttributes(ClusterOnOff)) != 5 || ClusterAttributes(ClusterOnOff)[0] != AttributeOnOffOnOff {
+		t.Errorf("%v", ClusterAttributes(ClusterOnOff))
+	}
+
+	if status, ok := LookupStatus("constraint_error"); !ok || status != StatusConstraintError {
+		t.Errorf("%s", status)
+	}
+
+	paths := []struct {
+		path     AttributePath
+		expected string
+	}{
+		{NewAttributePath(1, ClusterOnOff, AttributeOnOffOnTime), "1/OnOff/OnTime"},
+		{NewAttributePath(0, ClusterDescriptor, AttributeAttributeList), "0/Descriptor/AttributeList"},
+		{NewAttributePath(WildcardEndpointID, 0xFC00, WildcardAttributeID), "*/0xFC00/*"},
+	}
+	for _, test := range paths {
+		if name := test.path.Name(); name != test.expected {
+			t.Errorf("%s != %s", name, test.expected)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// idgen generates the constants and the name tables of the status codes, the clusters and
+// the attributes of the im package from a text file.
+//
+//	go run ./internal/idgen -in ids.txt -out ids_gen.go
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type entry struct {
+	id   uint64
+	name string
+}
+
+type cluster struct {
+	entry
+	attributes []entry
+}
+
+type table struct {
+	statuses []entry
+	globals  []entry
+	clusters []*cluster
+}
+
+func parse(path string) (*table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	t := &table{statuses: nil, globals: nil, clusters: nil}
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%s:%d: invalid line : %s", path, n, line)
+		}
+		id, err := strconv.ParseUint(fields[1], 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, n, err)
+		}
+		e := entry{id: id, name: fields[2]}
+		switch fields[0] {
+		case "status":
+			t.statuses = append(t.statuses, e)
+		case "global":
+			t.globals = append(t.globals, e)
+		case "cluster":
+			t.clusters = append(t.clusters, &cluster{entry: e, attributes: nil})
+		case "attribute":
+			if len(t.clusters) == 0 {
+				return nil, fmt.Errorf("%s:%d: attribute without cluster", path, n)
+			}
+			c := t.clusters[len(t.clusters)-1]
+			c.attributes = append(c.attributes, e)
+		default:
+			return nil, fmt.Errorf("%s:%d: unknown kind : %s", path, n, fields[0])
+		}
+	}
+	return t, scanner.Err()
+}
+
+// statusConstName returns the constant name of the status such as StatusConstraintError for CONSTRAINT_ERROR.
+func statusConstName(name string) string {
+	var b strings.Builder
+	b.WriteString("Status")
+	for _, word := range strings.Split(name, "_") {
+		b.WriteString(word[:1] + strings.ToLower(word[1:]))
+	}
+	return b.String()
+}
+
+func generate(t *table, in string) ([]byte, error) {
+	var b bytes.Buffer
+	p := func(format string, args ...any) {
+		fmt.Fprintf(&b, format+"\n", args...)
+	}
+	p("%s", strings.TrimSpace(header))
+	p("")
+	p("// Code generated by idgen from %s. DO NOT EDIT.", in)
+	p("")
+	p("package im")
+	p("")
+	p("// 8.10. Status Code Table")
+	p("const (")
+	for _, e := range t.statuses {
+		p("%s Status = 0x%02X", statusConstName(e.name), e.id)
+	}
+	p(")")
+	p("")
+	p("var statusNames = map[Status]string{")
+	for _, e := range t.statuses {
+		p("%s: %q,", statusConstName(e.name), e.name)
+	}
+	p("}")
+	p("")
+	p("// 7.13. Global Elements")
+	p("const (")
+	for _, e := range t.globals {
+		p("Attribute%s AttributeID = 0x%04X", e.name, e.id)
+	}
+	p(")")
+	p("")
+	p("var globalAttributeNames = map[AttributeID]string{")
+	for _, e := range t.globals {
+		p("Attribute%s: %q,", e.name, e.name)
+	}
+	p("}")
+	p("")
+	p("const (")
+	for _, c := range t.clusters {
+		p("Cluster%s ClusterID = 0x%04X", c.name, c.id)
+	}
+	p(")")
+	for _, c := range t.clusters {
+		if len(c.attributes) == 0 {
+			continue
+		}
+		p("")
+		p("// %s Cluster", c.name)
+		p("const (")
+		for _, a := range c.attributes {
+			p("Attribute%s%s AttributeID = 0x%04X", c.name, a.name, a.id)
+		}
+		p(")")
+	}
+	p("")
+	p("var clusterNames = map[ClusterID]string{")
+	for _, c := range t.clusters {
+		p("Cluster%s: %q,", c.name, c.name)
+	}
+	p("}")
+	p("")
+	p("var attributeNames = map[ClusterID]map[AttributeID]string{")
+	for _, c := range t.clusters {
+		if len(c.attributes) == 0 {
+			continue
+		}
+		p("Cluster%s: {", c.name)
+		for _, a := range c.attributes {
+			p("Attribute%s%s: %q,", c.name, a.name, a.name)
+		}
+		p("},")
+	}
+	p("}")
+	return format.Source(b.Bytes())
+}
+
+func main() {
+	in := flag.String("in", "ids.txt", "source file")
+	out := flag.String("out", "ids_gen.go", "generated file")
+	flag.Parse()
+	t, err := parse(*in)
+	if err == nil {
+		var src []byte
+		src, err = generate(t, *in)
+		if err == nil {
+			err = os.WriteFile(*out, src, 0o644)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+const header = `// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.`
@@ -0,0 +1,206 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package im
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// EndpointID represents an endpoint ID.
+type EndpointID uint16
+
+// ClusterID represents a cluster ID.
+type ClusterID uint32
+
+// AttributeID represents an attribute ID.
+type AttributeID uint32
+
+// CommandID represents a command ID.
+type CommandID uint32
+
+// EventID represents an event ID.
+type EventID uint32
+
+// The wildcard values are invalid as IDs, and represent omitted path fields.
+const (
+	WildcardEndpointID  EndpointID  = 0xFFFF
+	WildcardClusterID   ClusterID   = 0xFFFFFFFF
+	WildcardAttributeID AttributeID = 0xFFFFFFFF
+	WildcardEventID     EventID     = 0xFFFFFFFF
+)
+
+// AttributePath represents a path to an attribute (AttributePathIB).
+type AttributePath struct {
+	Endpoint  EndpointID
+	Cluster   ClusterID
+	Attribute AttributeID
+}
+
+// NewAttributePath returns a new concrete attribute path.
+func NewAttributePath(endpoint EndpointID, cluster ClusterID, attribute AttributeID) AttributePath {
+	return AttributePath{
+		Endpoint:  endpoint,
+		Cluster:   cluster,
+		Attribute: attribute,
+	}
+}
+
+// ParseAttributePath returns the attribute path of the string representation such as 1/0x0006/0x0000,
+// whose fields may also be decimal IDs, names such as 1/OnOff/OnTime, or wildcards.
+func ParseAttributePath(s string) (AttributePath, error) {
+	path := NewAttributePath(WildcardEndpointID, WildcardClusterID, WildcardAttributeID)
+	fields := strings.Split(s, "/")
+	if len(fields) != 3 {
+		return path, fmt.Errorf("%w attribute path (%s)", ErrInvalid, s)
+	}
+	if fields[0] != "*" {
+		endpoint, err := strconv.ParseUint(fields[0], 0, 16)
+		if err != nil || EndpointID(endpoint) == WildcardEndpointID {
+			return path, fmt.Errorf("%w attribute path (%s) endpoint", ErrInvalid, s)
+		}
+		path.Endpoint = EndpointID(endpoint)
+	}
+	if fields[1] != "*" {
+		cluster, ok := LookupClusterID(fields[1])
+		if !ok {
+			return path, fmt.Errorf("%w attribute path (%s) cluster", ErrInvalid, s)
+		}
+		path.Cluster = cluster
+	}
+	if fields[2] != "*" {
+		if path.Cluster == WildcardClusterID {
+			return path, fmt.Errorf("%w attribute path (%s) : attribute of wildcard cluster", ErrInvalid, s)
+		}
+		attribute, ok := LookupAttributeID(path.Cluster, fields[2])
+		if !ok {
+			return path, fmt.Errorf("%w attribute path (%s) attribute", ErrInvalid, s)
+		}
+		path.Attribute = attribute
+	}
+	return path, nil
+}
+
+// IsWildcard returns true if any field of the path is a wildcard.
+func (path AttributePath) IsWildcard() bool {
+	return path.Endpoint == WildcardEndpointID || path.Cluster == WildcardClusterID || path.Attribute == WildcardAttributeID
+}
+
+// Match returns true if the specified concrete path matches the path, whose wildcard fields match any value.
+func (path AttributePath) Match(concrete AttributePath) bool {
+	return (path.Endpoint == WildcardEndpointID || path.Endpoint == concrete.Endpoint) &&
+		(path.Cluster == WildcardClusterID || path.Cluster == concrete.Cluster) &&
+		(path.Attribute == WildcardAttributeID || path.Attribute == concrete.Attribute)
+}
+
+// String returns the string representation such as 1/0x0006/0x0000.
+func (path AttributePath) String() string {
+	return fmt.Sprintf("%s/%s/%s", formatPathField(path.Endpoint == WildcardEndpointID, "%d", path.Endpoint),
+		formatPathField(path.Cluster == WildcardClusterID, "0x%04X", path.Cluster),
+		formatPathField(path.Attribute == WildcardAttributeID, "0x%04X", path.Attribute))
+}
+
+// Name returns the readable representation such as 1/OnOff/OnTime, whose unknown IDs are
+// hexadecimal and wildcards are *.
+func (path AttributePath) Name() string {
+	cluster := "*"
+	if path.Cluster != WildcardClusterID {
+		cluster = ClusterName(path.Cluster)
+	}
+	attribute := "*"
+	if path.Attribute != WildcardAttributeID {
+		attribute = AttributeName(path.Cluster, path.Attribute)
+	}
+	return fmt.Sprintf("%s/%s/%s", formatPathField(path.Endpoint == WildcardEndpointID, "%d", path.Endpoint), cluster, attribute)
+}
+
+// CommandPath represents a path to a command (CommandPathIB).
+type CommandPath struct {
+	Endpoint EndpointID
+	Cluster  ClusterID
+	Command  CommandID
+}
+
+// NewCommandPath returns a new command path.
+func NewCommandPath(endpoint EndpointID, cluster ClusterID, command CommandID) CommandPath {
+	return CommandPath{
+		Endpoint: endpoint,
+		Cluster:  cluster,
+		Command:  command,
+	}
+}
+
+// String returns the string representation such as 1/0x0006/0x0001.
+func (path CommandPath) String() string {
+	return fmt.Sprintf("%s/0x%04X/0x%04X", formatPathField(path.Endpoint == WildcardEndpointID, "%d", path.Endpoint), path.Cluster, path.Command)
+}
+
+// EventPath represents a path to an event (EventPathIB).
+type EventPath struct {
+	Endpoint EndpointID
+	Cluster  ClusterID
+	Event    EventID
+	// IsUrgent requests the events of the path to be reported without waiting for the min interval of
+	// the subscription, which is used for events such as smoke alarms.
+	IsUrgent bool
+}
+
+// NewEventPath returns a new concrete event path.
+func NewEventPath(endpoint EndpointID, cluster ClusterID, event EventID) EventPath {
+	return EventPath{
+		Endpoint: endpoint,
+		Cluster:  cluster,
+		Event:    event,
+		IsUrgent: false,
+	}
+}
+
+// Urgent returns the path whose events are reported urgently.
+func (path EventPath) Urgent() EventPath {
+	path.IsUrgent = true
+	return path
+}
+
+// IsWildcard returns true if any field of the path is a wildcard.
+func (path EventPath) IsWildcard() bool {
+	return path.Endpoint == WildcardEndpointID || path.Cluster == WildcardClusterID || path.Event == WildcardEventID
+}
+
+// Match returns true if the specified concrete path matches the path, whose wildcard fields match any value.
+// The urgency of the paths is ignored.
+func (path EventPath) Match(concrete EventPath) bool {
+	return (path.Endpoint == WildcardEndpointID || path.Endpoint == concrete.Endpoint) &&
+		(path.Cluster == WildcardClusterID || path.Cluster == concrete.Cluster) &&
+		(path.Event == WildcardEventID || path.Event == concrete.Event)
+}
+
+// String returns the string representation such as 0/0x0028/0x0000, which ends with ! if the path is urgent.
+func (path EventPath) String() string {
+	s := fmt.Sprintf("%s/%s/%s", formatPathField(path.Endpoint == WildcardEndpointID, "%d", path.Endpoint),
+		formatPathField(path.Cluster == WildcardClusterID, "0x%04X", path.Cluster),
+		formatPathField(path.Event == WildcardEventID, "0x%04X", path.Event))
+	if path.IsUrgent {
+		s += "!"
+	}
+	return s
+}
+
+func formatPathField(wildcard bool, format string, v any) string {
+	if wildcard {
+		return "*"
+	}
+	return fmt.Sprintf(format, v)
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package im
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPathString(t *testing.T) {
+	tests := []struct {
+		path     interface{ String() string }
+		expected string
+	}{
+		{NewAttributePath(1, 0x0006, 0x0000), "1/0x0006/0x0000"},
+		{NewAttributePath(WildcardEndpointID, 0x0028, WildcardAttributeID), "*/0x0028/*"},
+		{NewCommandPath(1, 0x0006, 0x0002), "1/0x0006/0x0002"},
+		{NewEventPath(0, 0x0028, 0x0000), "0/0x0028/0x0000"},
+		{NewEventPath(WildcardEndpointID, WildcardClusterID, WildcardEventID), "*/*/*"},
+		{NewEventPath(1, 0x005C, WildcardEventID).Urgent(), "1/0x005C/*!"},
+	}
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			if test.path.String() != test.expected {
+				t.Errorf("%s != %s", test.path.String(), test.expected)
+			}
+		})
+	}
+
+	if NewAttributePath(1, 0x0006, 0x0000).IsWildcard() || !NewAttributePath(1, WildcardClusterID, 0x0000).IsWildcard() {
+		t.Errorf("attribute wildcard is broken")
+	}
+	if NewEventPath(1, 0x0028, 0x0000).IsWildcard() || !NewEventPath(1, 0x0028, WildcardEventID).IsWildcard() {
+		t.Errorf("event wildcard is broken")
+	}
+	if !NewAttributePath(WildcardEndpointID, 0x0006, WildcardAttributeID).Match(NewAttributePath(2, 0x0006, 0x4000)) ||
+		NewAttributePath(1, 0x0006, 0x0000).Match(NewAttributePath(2, 0x0006, 0x0000)) {
+		t.Errorf("attribute path match is broken")
+	}
+	if !NewEventPath(1, WildcardClusterID, WildcardEventID).Urgent().Match(NewEventPath(1, 0x0028, 0x0000)) ||
+		NewEventPath(1, 0x0028, 0x0001).Match(NewEventPath(1, 0x0028, 0x0000)) {
+		t.Errorf("event path match is broken")
+	}
+}
+
+func TestParseAttributePath(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected AttributePath
+	}{
+		{"1/0x0006/0x0000", NewAttributePath(1, 0x0006, 0x0000)},
+		{"1/OnOff/OnTime", NewAttributePath(1, 0x0006, 0x4001)},
+		{"*/6/*", NewAttributePath(WildcardEndpointID, 0x0006, WildcardAttributeID)},
+		{"0/BasicInformation/ClusterRevision", NewAttributePath(0, 0x0028, 0xFFFD)},
+	}
+	for _, test := range tests {
+		path, err := ParseAttributePath(test.s)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if path != test.expected {
+			t.Errorf("%s != %s", path, test.expected)
+		}
+	}
+	for _, s := range []string{"1/0x0006", "65535/OnOff/OnOff", "1/Unknown/OnOff", "1/OnOff/Unknown", "1/*/0x0000"} {
+		if _, err := ParseAttributePath(s); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%s : %v is not %v", s, err, ErrInvalid)
+		}
+	}
+}
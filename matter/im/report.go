@@ -0,0 +1,88 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package im
+
+import (
+	"time"
+)
+
+// DataVersion represents a cluster data version.
+type DataVersion uint32
+
+// AttributeData represents a reported attribute value (AttributeDataIB).
+type AttributeData struct {
+	// Path is the concrete path of the attribute.
+	Path AttributePath
+	// DataVersion is the data version of the cluster.
+	DataVersion DataVersion
+	// Data is the TLV encoded value with an anonymous tag.
+	Data []byte
+}
+
+// DataVersionFilter represents a data version of a cluster which the client already has (DataVersionFilterIB).
+// The server does not report the attributes of the cluster if its data version is still the same.
+type DataVersionFilter struct {
+	// Endpoint is the endpoint of the cluster.
+	Endpoint EndpointID
+	// Cluster is the cluster ID.
+	Cluster ClusterID
+	// DataVersion is the data version which the client has.
+	DataVersion DataVersion
+}
+
+// AttributeStatus represents a status of an attribute which can not be reported (AttributeStatusIB).
+type AttributeStatus struct {
+	// Path is the concrete path of the attribute.
+	Path AttributePath
+	// Status is the status of the attribute.
+	Status Status
+}
+
+// EventNumber represents an event number, which increases monotonically on a node across reboots.
+type EventNumber uint64
+
+// ReportHandler represents a handler which is called with the attribute data of each subscription report.
+type ReportHandler func(data []AttributeData)
+
+// SubscribeRequest represents the parameters of a subscribe interaction.
+type SubscribeRequest struct {
+	// Paths are the attribute paths to subscribe.
+	Paths []AttributePath
+	// EventPaths are the event paths to subscribe.
+	EventPaths []EventPath
+	// EventMin is the minimum event number to report, which skips the events already received (EventFilterIB).
+	EventMin EventNumber
+	// MinInterval is the minimum interval between reports.
+	MinInterval time.Duration
+	// MaxInterval is the maximum interval between reports requested by the client.
+	MaxInterval time.Duration
+	// KeepSubscriptions keeps the existing subscriptions of the client on the server.
+	KeepSubscriptions bool
+	// FabricFiltered limits fabric-scoped lists to the elements of the accessing fabric.
+	FabricFiltered bool
+}
+
+// SubscriptionID represents a subscription ID.
+type SubscriptionID uint32
+
+// Subscription represents an active subscription.
+type Subscription interface {
+	// ID returns the subscription ID assigned by the server.
+	ID() SubscriptionID
+	// MaxInterval returns the maximum interval negotiated with the server.
+	MaxInterval() time.Duration
+	// Close cancels the subscription.
+	Close() error
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package im
+
+import (
+	"errors"
+	"fmt"
+
+	matterr "github.com/cybergarage/go-matter/matter/errors"
+)
+
+// 8.10. Status Code Table
+// Status represents an interaction model status code. Statuses other than StatusSuccess are
+// errors, so handlers can return them directly or wrap them with fmt.Errorf and %w.
+type Status uint8
+
+// StatusOf returns the status of the specified error. A nil error is StatusSuccess, and
+// an error without a status is StatusFailure.
+func StatusOf(err error) Status {
+	if err == nil {
+		return StatusSuccess
+	}
+	var status Status
+	if errors.As(err, &status) {
+		return status
+	}
+	return StatusFailure
+}
+
+// String returns the name of the status in the specification.
+func (status Status) String() string {
+	if name, ok := statusNames[status]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%02X", uint8(status))
+}
+
+// Error returns the string representation as an error.
+func (status Status) Error() string {
+	return "status " + status.String()
+}
+
+// Is returns true if the target is the category of the requests rejected by peers, which
+// statuses other than StatusSuccess belong to.
+func (status Status) Is(target error) bool {
+	return status != StatusSuccess && target == matterr.ErrRejected
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package im
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected Status
+	}{
+		{nil, StatusSuccess},
+		{errors.New("error"), StatusFailure},
+		{StatusNotFound, StatusNotFound},
+		{fmt.Errorf("%w : scene (1)", StatusConstraintError), StatusConstraintError},
+	}
+	for _, test := range tests {
+		if status := StatusOf(test.err); status != test.expected {
+			t.Errorf("%v : %s != %s", test.err, status, test.expected)
+		}
+	}
+
+	if StatusUnsupportedCluster.String() != "UNSUPPORTED_CLUSTER" {
+		t.Errorf("%s", StatusUnsupportedCluster)
+	}
+	if Status(0x02).String() != "0x02" {
+		t.Errorf("%s", Status(0x02))
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// 4.3.4. TXT key for Joint Fabric (JF)
+// JointFabricMode represents the Joint Fabric capabilities advertised by a node.
+type JointFabricMode uint8
+
+const (
+	// JointFabricAvailable indicates that the node supports Joint Fabric.
+	JointFabricAvailable JointFabricMode = 0x01
+	// JointFabricAdministrator indicates that the node is a Joint Fabric administrator.
+	JointFabricAdministrator JointFabricMode = 0x02
+	// JointFabricAnchor indicates that the node is the anchor administrator of the Joint Fabric.
+	JointFabricAnchor JointFabricMode = 0x04
+	// JointFabricDatastore indicates that the node hosts the Joint Fabric datastore.
+	JointFabricDatastore JointFabricMode = 0x08
+)
+
+// NewJointFabricModeFromString returns the Joint Fabric mode of the specified JF key value.
+func NewJointFabricModeFromString(s string) (JointFabricMode, error) {
+	v, err := strconv.ParseUint(s, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("%w Joint Fabric mode: %s", ErrInvalid, s)
+	}
+	return JointFabricMode(v), nil
+}
+
+// Has returns true if the mode has all the specified flags.
+func (mode JointFabricMode) Has(flags JointFabricMode) bool {
+	return mode&flags == flags
+}
+
+// String returns the JF key value.
+func (mode JointFabricMode) String() string {
+	return strconv.Itoa(int(mode))
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/cluster/label"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// AttributeReader represents a session or a device handle which reads attributes, such as an OperationalDevice.
+type AttributeReader interface {
+	// ReadAttribute reads the attributes of the specified path.
+	ReadAttribute(ctx context.Context, path im.AttributePath) ([]im.AttributeData, error)
+}
+
+// AttributeWriter represents a session or a device handle which writes attributes, such as an OperationalDevice.
+type AttributeWriter interface {
+	// WriteAttribute writes the TLV encoded value to the specified attribute.
+	WriteAttribute(ctx context.Context, path im.AttributePath, data []byte) error
+}
+
+// ReadFixedLabels reads the labels of the Fixed Label cluster of the specified endpoint.
+func ReadFixedLabels(ctx context.Context, reader AttributeReader, endpoint im.EndpointID) ([]label.Label, error) {
+	return readLabels(ctx, reader, im.NewAttributePath(endpoint, label.FixedLabelClusterID, label.LabelListAttributeID))
+}
+
+// ReadUserLabels reads the labels of the User Label cluster of the specified endpoint.
+func ReadUserLabels(ctx context.Context, reader AttributeReader, endpoint im.EndpointID) ([]label.Label, error) {
+	return readLabels(ctx, reader, im.NewAttributePath(endpoint, label.UserLabelClusterID, label.LabelListAttributeID))
+}
+
+func readLabels(ctx context.Context, reader AttributeReader, path im.AttributePath) ([]label.Label, error) {
+	data, err := reader.ReadAttribute(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%s is %w", path.Name(), ErrNotFound)
+	}
+	return label.DecodeLabels(data[0].Data)
+}
+
+// WriteUserLabels replaces the labels of the User Label cluster of the specified endpoint, which
+// requires the manage privilege. The labels are validated before they are written.
+func WriteUserLabels(ctx context.Context, writer AttributeWriter, endpoint im.EndpointID, labels []label.Label) error {
+	for _, l := range labels {
+		if err := l.Validate(); err != nil {
+			return err
+		}
+	}
+	data, err := datatype.Encode(label.NewLabelList(labels))
+	if err != nil {
+		return err
+	}
+	return writer.WriteAttribute(ctx, im.NewAttributePath(endpoint, label.UserLabelClusterID, label.LabelListAttributeID), data)
+}
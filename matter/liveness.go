@@ -0,0 +1,352 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+const (
+	// DefaultLivenessMargin is the default time which a report may be late after the maximum interval
+	// before the subscription is considered timed out, which covers the retransmissions of the report.
+	DefaultLivenessMargin = 10 * time.Second
+	// DefaultResubscribeInitialInterval is the default interval before the first resubscription.
+	DefaultResubscribeInitialInterval = time.Second
+	// DefaultResubscribeMaxInterval is the default upper bound of the resubscription interval.
+	DefaultResubscribeMaxInterval = 5 * time.Minute
+	// DefaultResubscribeJitter is the default ratio of the random jitter of the resubscription interval.
+	DefaultResubscribeJitter = 0.25
+)
+
+// ResubscribePolicy represents a jittered exponential backoff policy to re-establish subscriptions.
+type ResubscribePolicy struct {
+	// InitialInterval is the interval before the first resubscription.
+	InitialInterval time.Duration
+	// MaxInterval is the upper bound of the resubscription interval.
+	MaxInterval time.Duration
+	// Jitter is the ratio of the interval which is randomly added or subtracted, such as 0.25,
+	// so subscriptions of many nodes lost at once are not re-established at once.
+	Jitter float64
+	// MaxAttempts is the number of resubscriptions after a timeout. Zero retries forever.
+	MaxAttempts int
+}
+
+// DefaultResubscribePolicy returns the default resubscribe policy, which retries forever.
+func DefaultResubscribePolicy() ResubscribePolicy {
+	return ResubscribePolicy{
+		InitialInterval: DefaultResubscribeInitialInterval,
+		MaxInterval:     DefaultResubscribeMaxInterval,
+		Jitter:          DefaultResubscribeJitter,
+		MaxAttempts:     0,
+	}
+}
+
+// Interval returns the interval before the specified attempt, which starts from one. The interval
+// backs off exponentially from the initial interval up to the maximum interval, and is spread by the
+// jitter with the random value in [0, 1).
+func (policy ResubscribePolicy) Interval(attempt int, random float64) time.Duration {
+	interval := policy.InitialInterval
+	for n := 1; n < attempt && (policy.MaxInterval <= 0 || interval < policy.MaxInterval); n++ {
+		interval *= 2
+	}
+	if 0 < policy.MaxInterval && policy.MaxInterval < interval {
+		interval = policy.MaxInterval
+	}
+	return time.Duration(float64(interval) * (1 + policy.Jitter*(2*random-1)))
+}
+
+// LivenessEvent represents a change of the liveness of a node which is observed through a subscription.
+type LivenessEvent struct {
+	// Node is the node ID.
+	Node NodeID
+	// Alive is true if the subscription is re-established, and false if it is timed out or given up.
+	Alive bool
+	// Time is the time of the change.
+	Time time.Time
+	// Attempts is the number of the resubscriptions which are made before the change.
+	Attempts int
+	// Err is the reason why the node is not alive.
+	Err error
+}
+
+// String returns the string representation.
+func (ev *LivenessEvent) String() string {
+	if ev.Alive {
+		return fmt.Sprintf("node (%016X) is alive (%d attempts)", uint64(ev.Node), ev.Attempts)
+	}
+	return fmt.Sprintf("node (%016X) is not alive (%d attempts) : %v", uint64(ev.Node), ev.Attempts, ev.Err)
+}
+
+// LivenessListener represents a listener which is called after the liveness of a node changes.
+type LivenessListener func(ev *LivenessEvent)
+
+// LiveSubscriptionOption represents a live subscription option.
+type LiveSubscriptionOption func(*LiveSubscription)
+
+// WithLivenessMargin returns an option to set the time which a report may be late after the maximum interval.
+func WithLivenessMargin(d time.Duration) LiveSubscriptionOption {
+	return func(sub *LiveSubscription) {
+		sub.margin = d
+	}
+}
+
+// WithResubscribePolicy returns an option to set the resubscribe policy.
+func WithResubscribePolicy(policy ResubscribePolicy) LiveSubscriptionOption {
+	return func(sub *LiveSubscription) {
+		sub.policy = policy
+	}
+}
+
+// WithLivenessListener returns an option to add the listener of the liveness changes.
+func WithLivenessListener(l LivenessListener) LiveSubscriptionOption {
+	return func(sub *LiveSubscription) {
+		sub.listeners = append(sub.listeners, l)
+	}
+}
+
+// LiveSubscription represents a long-running subscription which detects missed reports. A node
+// sends a report, which may be empty, at least every maximum interval, so the subscription is
+// considered timed out if no report is received within the maximum interval and the margin,
+// such as when the node reboots or leaves the network. The timed out subscription is closed, and
+// re-established according to the resubscribe policy. The subscription is safe for concurrent use.
+type LiveSubscription struct {
+	mutex      sync.Mutex
+	dev        *OperationalDevice
+	req        im.SubscribeRequest
+	handler    im.ReportHandler
+	margin     time.Duration
+	policy     ResubscribePolicy
+	listeners  []LivenessListener
+	sub        im.Subscription
+	generation uint64
+	alive      bool
+	err        error
+	reported   chan struct{}
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+// SubscribeLive subscribes the attributes like Subscribe, and keeps the subscription alive until it is
+// closed. The handler is called for each report including the empty ones, and with the priming report
+// of each resubscription. An error is returned if the first subscription fails.
+func (dev *OperationalDevice) SubscribeLive(ctx context.Context, req im.SubscribeRequest, handler im.ReportHandler, opts ...LiveSubscriptionOption) (*LiveSubscription, error) {
+	sub := &LiveSubscription{
+		mutex:      sync.Mutex{},
+		dev:        dev,
+		req:        req,
+		handler:    handler,
+		margin:     DefaultLivenessMargin,
+		policy:     DefaultResubscribePolicy(),
+		listeners:  []LivenessListener{},
+		sub:        nil,
+		generation: 0,
+		alive:      false,
+		err:        nil,
+		reported:   make(chan struct{}, 1),
+		cancel:     nil,
+		done:       make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(sub)
+	}
+	if err := sub.subscribe(ctx); err != nil {
+		return nil, err
+	}
+	runCtx, cancel := context.WithCancel(context.Background())
+	sub.cancel = cancel
+	go sub.run(runCtx)
+	return sub, nil
+}
+
+// ID returns the ID of the current subscription, which is zero while it is re-established.
+func (sub *LiveSubscription) ID() im.SubscriptionID {
+	sub.mutex.Lock()
+	defer sub.mutex.Unlock()
+	if sub.sub == nil {
+		return 0
+	}
+	return sub.sub.ID()
+}
+
+// MaxInterval returns the maximum interval of the current subscription, which is zero while it is re-established.
+func (sub *LiveSubscription) MaxInterval() time.Duration {
+	sub.mutex.Lock()
+	defer sub.mutex.Unlock()
+	if sub.sub == nil {
+		return 0
+	}
+	return sub.sub.MaxInterval()
+}
+
+// IsAlive returns true if the subscription is not timed out.
+func (sub *LiveSubscription) IsAlive() bool {
+	sub.mutex.Lock()
+	defer sub.mutex.Unlock()
+	return sub.alive
+}
+
+// Done returns a channel which is closed when the subscription is closed or given up.
+func (sub *LiveSubscription) Done() <-chan struct{} {
+	return sub.done
+}
+
+// Err returns the error which the subscription is given up with, or nil.
+func (sub *LiveSubscription) Err() error {
+	sub.mutex.Lock()
+	defer sub.mutex.Unlock()
+	return sub.err
+}
+
+// Close stops keeping the subscription alive, and cancels the current subscription.
+func (sub *LiveSubscription) Close() error {
+	sub.cancel()
+	<-sub.done
+	return sub.closeSubscription()
+}
+
+// subscribe makes a new subscription, whose reports are dropped once it is replaced.
+func (sub *LiveSubscription) subscribe(ctx context.Context) error {
+	sub.mutex.Lock()
+	sub.generation++
+	generation := sub.generation
+	sub.mutex.Unlock()
+
+	s, err := sub.dev.Subscribe(ctx, sub.req, func(data []im.AttributeData) {
+		sub.report(generation, data)
+	})
+	if err != nil {
+		return err
+	}
+	sub.mutex.Lock()
+	sub.sub = s
+	sub.alive = true
+	sub.mutex.Unlock()
+	return nil
+}
+
+func (sub *LiveSubscription) report(generation uint64, data []im.AttributeData) {
+	sub.mutex.Lock()
+	current := generation == sub.generation
+	sub.mutex.Unlock()
+	if !current {
+		return
+	}
+	select {
+	case sub.reported <- struct{}{}:
+	default:
+	}
+	if sub.handler != nil {
+		sub.handler(data)
+	}
+}
+
+// timeout returns the time to wait for the next report.
+func (sub *LiveSubscription) timeout() time.Duration {
+	sub.mutex.Lock()
+	defer sub.mutex.Unlock()
+	maxInterval := sub.req.MaxInterval
+	if sub.sub != nil {
+		maxInterval = sub.sub.MaxInterval()
+	}
+	return maxInterval + sub.margin
+}
+
+func (sub *LiveSubscription) closeSubscription() error {
+	sub.mutex.Lock()
+	s := sub.sub
+	sub.sub = nil
+	sub.generation++
+	sub.mutex.Unlock()
+	if s == nil {
+		return nil
+	}
+	return s.Close()
+}
+
+// run waits for the reports, and resubscribes after a timeout until the context is done.
+func (sub *LiveSubscription) run(ctx context.Context) {
+	defer close(sub.done)
+	for {
+		timer := time.NewTimer(sub.timeout())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-sub.reported:
+			timer.Stop()
+			continue
+		case <-timer.C:
+		}
+
+		// The node may have rebooted and lost the subscription, so it is closed regardless of errors.
+		sub.closeSubscription()
+		sub.changed(false, 0, fmt.Errorf("node (%016X) : %w", uint64(sub.dev.NodeID()), ErrSubscriptionTimeout))
+
+		if err := sub.resubscribe(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			sub.mutex.Lock()
+			sub.err = err
+			sub.mutex.Unlock()
+			return
+		}
+	}
+}
+
+// resubscribe re-establishes the subscription according to the policy.
+func (sub *LiveSubscription) resubscribe(ctx context.Context) error {
+	var err error
+	for attempt := 1; sub.policy.MaxAttempts <= 0 || attempt <= sub.policy.MaxAttempts; attempt++ {
+		if err := sleepContext(ctx, sub.policy.Interval(attempt, rand.Float64())); err != nil {
+			return err
+		}
+		err = sub.subscribe(ctx)
+		if err == nil {
+			sub.changed(true, attempt, nil)
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	err = fmt.Errorf("resubscription is given up after %d attempts : %w", sub.policy.MaxAttempts, err)
+	sub.changed(false, sub.policy.MaxAttempts, err)
+	return err
+}
+
+func (sub *LiveSubscription) changed(alive bool, attempts int, err error) {
+	sub.mutex.Lock()
+	sub.alive = alive
+	listeners := append([]LivenessListener{}, sub.listeners...)
+	sub.mutex.Unlock()
+
+	ev := &LivenessEvent{
+		Node:     sub.dev.NodeID(),
+		Alive:    alive,
+		Time:     time.Now(),
+		Attempts: attempts,
+		Err:      err,
+	}
+	for _, l := range listeners {
+		l(ev)
+	}
+}
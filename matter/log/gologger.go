@@ -0,0 +1,46 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	gologger "github.com/cybergarage/go-logger/log"
+)
+
+// GoLogger represents an adapter which outputs to the shared logger of go-logger.
+type GoLogger struct{}
+
+// NewGoLogger returns a new adapter of the shared logger of go-logger, which is the default logger.
+func NewGoLogger() *GoLogger {
+	return &GoLogger{}
+}
+
+// Logf outputs the formatted message to the shared logger of go-logger.
+func (logger *GoLogger) Logf(level Level, format string, args ...any) {
+	gologger.Outputf(goLoggerLevel(level), format, args...)
+}
+
+func goLoggerLevel(level Level) gologger.Level {
+	switch level {
+	case LevelTrace:
+		return gologger.LevelTrace
+	case LevelDebug:
+		return gologger.LevelDebug
+	case LevelInfo:
+		return gologger.LevelInfo
+	case LevelWarn:
+		return gologger.LevelWarn
+	}
+	return gologger.LevelError
+}
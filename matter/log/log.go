@@ -0,0 +1,133 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package log routes the logging of the stack to a Logger set by the application.
+//
+// The stack outputs to go-logger's shared logger by default. Applications which use
+// another logging library set an adapter instead:
+//
+//	log.SetLogger(log.NewSlogLogger(slog.Default()))
+//	log.SetLogger(log.NewZapLogger(zapLogger.Sugar()))
+package log
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cybergarage/go-logger/log/hexdump"
+)
+
+// Level represents a log level.
+type Level int
+
+const (
+	LevelTrace Level = iota
+	LevelDebug
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelStrings = map[Level]string{
+	LevelTrace: "TRACE",
+	LevelDebug: "DEBUG",
+	LevelInfo:  "INFO",
+	LevelWarn:  "WARN",
+	LevelError: "ERROR",
+}
+
+// String returns the level name.
+func (level Level) String() string {
+	if s, ok := levelStrings[level]; ok {
+		return s
+	}
+	return fmt.Sprintf("LEVEL(%d)", int(level))
+}
+
+// Logger represents a destination of the log messages of the stack.
+type Logger interface {
+	// Logf outputs the formatted message at the specified level.
+	Logf(level Level, format string, args ...any)
+}
+
+var (
+	sharedMutex  sync.RWMutex
+	sharedLogger Logger = NewGoLogger()
+)
+
+// SetLogger sets the logger of the stack. A nil logger discards all messages.
+func SetLogger(logger Logger) {
+	if logger == nil {
+		logger = NewNopLogger()
+	}
+	sharedMutex.Lock()
+	defer sharedMutex.Unlock()
+	sharedLogger = logger
+}
+
+// GetLogger returns the logger of the stack.
+func GetLogger() Logger {
+	sharedMutex.RLock()
+	defer sharedMutex.RUnlock()
+	return sharedLogger
+}
+
+// Logf outputs the formatted message at the specified level to the logger of the stack.
+func Logf(level Level, format string, args ...any) {
+	GetLogger().Logf(level, format, args...)
+}
+
+// Tracef outputs the formatted message at the trace level.
+func Tracef(format string, args ...any) {
+	Logf(LevelTrace, format, args...)
+}
+
+// Debugf outputs the formatted message at the debug level.
+func Debugf(format string, args ...any) {
+	Logf(LevelDebug, format, args...)
+}
+
+// Infof outputs the formatted message at the info level.
+func Infof(format string, args ...any) {
+	Logf(LevelInfo, format, args...)
+}
+
+// Warnf outputs the formatted message at the warn level.
+func Warnf(format string, args ...any) {
+	Logf(LevelWarn, format, args...)
+}
+
+// Errorf outputs the formatted message at the error level.
+func Errorf(format string, args ...any) {
+	Logf(LevelError, format, args...)
+}
+
+// Hexf outputs the bytes in the hexdump format of go-logger at the specified level, one line per message.
+func Hexf(level Level, b []byte) {
+	logger := GetLogger()
+	for _, line := range hexdump.EncodeBytesToStringLines(b) {
+		logger.Logf(level, "%s", line)
+	}
+}
+
+// NopLogger represents a logger which discards all messages.
+type NopLogger struct{}
+
+// NewNopLogger returns a new logger which discards all messages.
+func NewNopLogger() *NopLogger {
+	return &NopLogger{}
+}
+
+// Logf discards the message.
+func (logger *NopLogger) Logf(level Level, format string, args ...any) {}
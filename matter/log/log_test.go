@@ -0,0 +1,96 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type testLogger struct {
+	messages []string
+}
+
+func (logger *testLogger) Logf(level Level, format string, args ...any) {
+	logger.messages = append(logger.messages, level.String()+" "+fmt.Sprintf(format, args...))
+}
+
+type testZapLogger struct {
+	testLogger
+}
+
+func (logger *testZapLogger) Debugf(template string, args ...any) {
+	logger.Logf(LevelDebug, template, args...)
+}
+
+func (logger *testZapLogger) Infof(template string, args ...any) {
+	logger.Logf(LevelInfo, template, args...)
+}
+
+func (logger *testZapLogger) Warnf(template string, args ...any) {
+	logger.Logf(LevelWarn, template, args...)
+}
+
+func (logger *testZapLogger) Errorf(template string, args ...any) {
+	logger.Logf(LevelError, template, args...)
+}
+
+func TestLogger(t *testing.T) {
+	defer SetLogger(GetLogger())
+
+	logger := &testLogger{messages: nil}
+	SetLogger(logger)
+	Tracef("trace %d", 1)
+	Warnf("warn %s", "message")
+	Hexf(LevelInfo, []byte("0123456789abcdefXYZ"))
+	if len(logger.messages) != 4 {
+		t.Fatalf("%v", logger.messages)
+	}
+	if logger.messages[0] != "TRACE trace 1" || logger.messages[1] != "WARN warn message" {
+		t.Errorf("%v", logger.messages)
+	}
+
+	SetLogger(nil)
+	Errorf("discarded")
+	if len(logger.messages) != 4 {
+		t.Errorf("%v", logger.messages)
+	}
+
+	t.Run("slog", func(t *testing.T) {
+		var buf bytes.Buffer
+		handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+		SetLogger(NewSlogLogger(slog.New(handler)))
+		Tracef("trace")
+		Debugf("debug %d", 2)
+		Errorf("error")
+		out := buf.String()
+		if strings.Contains(out, "msg=trace") || !strings.Contains(out, "level=DEBUG msg=\"debug 2\"") || !strings.Contains(out, "level=ERROR msg=error") {
+			t.Errorf("%s", out)
+		}
+	})
+
+	t.Run("zap", func(t *testing.T) {
+		zap := &testZapLogger{testLogger: testLogger{messages: nil}}
+		SetLogger(NewZapLogger(zap))
+		Tracef("trace")
+		Infof("info")
+		if len(zap.messages) != 2 || zap.messages[0] != "DEBUG trace" || zap.messages[1] != "INFO info" {
+			t.Errorf("%v", zap.messages)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// SlogLevelTrace is the slog level of the trace messages, which is below slog.LevelDebug.
+const SlogLevelTrace = slog.LevelDebug - 4
+
+// SlogLogger represents an adapter which outputs to a slog logger.
+type SlogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger returns a new adapter of the specified slog logger.
+func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	return &SlogLogger{
+		logger: logger,
+	}
+}
+
+// Logf outputs the formatted message to the slog logger. Messages are not formatted unless the level is enabled.
+func (logger *SlogLogger) Logf(level Level, format string, args ...any) {
+	ctx := context.Background()
+	l := slogLevel(level)
+	if !logger.logger.Enabled(ctx, l) {
+		return
+	}
+	logger.logger.Log(ctx, l, fmt.Sprintf(format, args...))
+}
+
+func slogLevel(level Level) slog.Level {
+	switch level {
+	case LevelTrace:
+		return SlogLevelTrace
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelInfo:
+		return slog.LevelInfo
+	case LevelWarn:
+		return slog.LevelWarn
+	}
+	return slog.LevelError
+}
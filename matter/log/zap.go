@@ -0,0 +1,51 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+// ZapSugaredLogger represents the formatting methods of a zap logger, which *zap.SugaredLogger
+// implements. The adapter depends only on the methods, so the stack does not require zap.
+type ZapSugaredLogger interface {
+	Debugf(template string, args ...any)
+	Infof(template string, args ...any)
+	Warnf(template string, args ...any)
+	Errorf(template string, args ...any)
+}
+
+// ZapLogger represents an adapter which outputs to a zap sugared logger.
+type ZapLogger struct {
+	logger ZapSugaredLogger
+}
+
+// NewZapLogger returns a new adapter of the specified zap sugared logger.
+// Zap has no trace level, so trace messages are output at the debug level.
+func NewZapLogger(logger ZapSugaredLogger) *ZapLogger {
+	return &ZapLogger{
+		logger: logger,
+	}
+}
+
+// Logf outputs the formatted message to the zap logger.
+func (logger *ZapLogger) Logf(level Level, format string, args ...any) {
+	switch level {
+	case LevelTrace, LevelDebug:
+		logger.logger.Debugf(format, args...)
+	case LevelInfo:
+		logger.logger.Infof(format, args...)
+	case LevelWarn:
+		logger.logger.Warnf(format, args...)
+	default:
+		logger.logger.Errorf(format, args...)
+	}
+}
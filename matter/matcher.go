@@ -0,0 +1,85 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"github.com/cybergarage/go-matter/matter/ble"
+	"github.com/cybergarage/go-matter/matter/payload"
+)
+
+// CommissionableNode represents a commissionable node discovered over DNS-SD or BLE.
+type CommissionableNode interface {
+	// LookupDiscriminator returns a full 12-bit discriminator if available, otherwise a short discriminator.
+	LookupDiscriminator() (Discriminator, bool)
+	// LookupVendorID returns the advertised vendor ID.
+	LookupVendorID() (VenderID, bool)
+	// LookupProductID returns the advertised product ID.
+	LookupProductID() (ProductID, bool)
+}
+
+// CommissionableNodeMatcher represents a predicate which returns true if the node is the device of an onboarding payload.
+type CommissionableNodeMatcher func(node CommissionableNode) bool
+
+// 5.4.3. Discovery by Commissioner
+// NewCommissionableNodeMatcher returns a matcher for commissionable nodes of the specified onboarding payload.
+// The discriminator must match, comparing only the upper 4 bits when either side is a short discriminator.
+// The vendor and product IDs are compared only when both the payload and the node have them,
+// since they are optional in the manual pairing code, in DNS-SD and in anonymized BLE advertisements.
+func NewCommissionableNodeMatcher(p *payload.OnboardingPayload) CommissionableNodeMatcher {
+	return func(node CommissionableNode) bool {
+		d, ok := node.LookupDiscriminator()
+		if !ok || !p.Discriminator().Matches(d) {
+			return false
+		}
+		if vid := p.VendorID(); vid != 0 {
+			if nodeVID, ok := node.LookupVendorID(); ok && nodeVID != 0 && nodeVID != vid {
+				return false
+			}
+		}
+		if pid := p.ProductID(); pid != AnonymizedProductID {
+			if nodePID, ok := node.LookupProductID(); ok && nodePID != AnonymizedProductID && nodePID != pid {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// bleCommissionableNode represents a commissionable node discovered over BLE.
+type bleCommissionableNode struct {
+	desc *ble.ServiceDescriptor
+}
+
+// NewCommissionableNodeWithServiceDescriptor returns a commissionable node of the specified BLE service descriptor.
+func NewCommissionableNodeWithServiceDescriptor(desc *ble.ServiceDescriptor) CommissionableNode {
+	return &bleCommissionableNode{
+		desc: desc,
+	}
+}
+
+// LookupDiscriminator returns the advertised 12-bit discriminator.
+func (node *bleCommissionableNode) LookupDiscriminator() (Discriminator, bool) {
+	return node.desc.Discriminator(), true
+}
+
+// LookupVendorID returns the advertised vendor ID.
+func (node *bleCommissionableNode) LookupVendorID() (VenderID, bool) {
+	return node.desc.VendorID(), true
+}
+
+// LookupProductID returns the advertised product ID.
+func (node *bleCommissionableNode) LookupProductID() (ProductID, bool) {
+	return node.desc.ProductID(), true
+}
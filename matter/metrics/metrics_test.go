@@ -0,0 +1,113 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSharedRecorder(t *testing.T) {
+	if _, ok := SharedRecorder().(*NullRecorder); !ok {
+		t.Errorf("default recorder is not a null recorder")
+	}
+
+	recorder := NewPrometheusRecorder("matter")
+	SetSharedRecorder(recorder)
+	if SharedRecorder() != Recorder(recorder) {
+		t.Errorf("shared recorder is not updated")
+	}
+
+	SetSharedRecorder(nil)
+	if _, ok := SharedRecorder().(*NullRecorder); !ok {
+		t.Errorf("nil recorder is not replaced with a null recorder")
+	}
+}
+
+func TestPrometheusRecorder(t *testing.T) {
+	recorder := NewPrometheusRecorder("matter")
+	recorder.MessageSent(0x0001)
+	recorder.MessageSent(0x0001)
+	recorder.MessageSent(0x0000)
+	recorder.MessageReceived(0x0001)
+	recorder.MessageRetransmitted(0x0001)
+	recorder.HandshakeCompleted(PASEHandshake, 300*time.Millisecond, nil)
+	recorder.HandshakeCompleted(CASEHandshake, 45*time.Second, errors.New("timeout"))
+	recorder.DecodeError("message")
+
+	var b strings.Builder
+	_, err := recorder.WriteTo(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := b.String()
+	expecteds := []string{
+		"# TYPE matter_messages_sent_total counter\n",
+		"matter_messages_sent_total{protocol=\"0x0000\"} 1\n",
+		"matter_messages_sent_total{protocol=\"0x0001\"} 2\n",
+		"matter_messages_received_total{protocol=\"0x0001\"} 1\n",
+		"matter_messages_retransmitted_total{protocol=\"0x0001\"} 1\n",
+		"# TYPE matter_handshake_duration_seconds histogram\n",
+		"matter_handshake_duration_seconds_bucket{handshake=\"pase\",result=\"success\",le=\"0.25\"} 0\n",
+		"matter_handshake_duration_seconds_bucket{handshake=\"pase\",result=\"success\",le=\"0.5\"} 1\n",
+		"matter_handshake_duration_seconds_bucket{handshake=\"case\",result=\"failure\",le=\"30\"} 0\n",
+		"matter_handshake_duration_seconds_bucket{handshake=\"case\",result=\"failure\",le=\"+Inf\"} 1\n",
+		"matter_handshake_duration_seconds_count{handshake=\"pase\",result=\"success\"} 1\n",
+		"matter_decode_errors_total{layer=\"message\"} 1\n",
+	}
+	for _, expected := range expecteds {
+		if !strings.Contains(out, expected) {
+			t.Errorf("%q is not found in\n%s", expected, out)
+		}
+	}
+}
+
+func TestPeerTable(t *testing.T) {
+	now := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	table := NewPeerTable()
+	table.SetClock(func() time.Time { return now })
+
+	table.MessageSent(1)
+	table.MessageRetransmitted(1)
+	table.MessageReceived(1)
+	table.RoundTripMeasured(1, 100*time.Millisecond)
+	table.RoundTripMeasured(1, 200*time.Millisecond)
+	table.HandshakeFailed(2)
+
+	stats, ok := table.Lookup(1)
+	if !ok {
+		t.Fatal("peer is not found")
+	}
+	if stats.MessagesSent != 2 || stats.Retransmissions != 1 || stats.MessagesReceived != 1 || !stats.LastSeen.Equal(now) {
+		t.Errorf("%+v", stats)
+	}
+	if stats.RetransmissionRate() != 0.5 {
+		t.Errorf("retransmission rate (%f) != (0.5)", stats.RetransmissionRate())
+	}
+	if stats.SmoothedRTT != 112500*time.Microsecond || stats.RTTVariance != 62500*time.Microsecond {
+		t.Errorf("RTT (%s, %s) != (112.5ms, 62.5ms)", stats.SmoothedRTT, stats.RTTVariance)
+	}
+
+	if peers := table.Peers(); len(peers) != 2 || peers[0] != 1 || peers[1] != 2 {
+		t.Errorf("peers (%v) != [1 2]", peers)
+	}
+	table.Remove(2)
+	if _, ok := table.Lookup(2); ok {
+		t.Errorf("removed peer is found")
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// The round-trip time estimate follows the smoothing of RFC 6298.
+const (
+	rttAlpha = 8
+	rttBeta  = 4
+)
+
+// PeerStats represents the transport statistics of a peer node.
+type PeerStats struct {
+	// MessagesSent is the number of the messages sent to the peer including retransmissions.
+	MessagesSent uint64
+	// MessagesReceived is the number of the messages received from the peer.
+	MessagesReceived uint64
+	// Retransmissions is the number of the retransmitted messages.
+	Retransmissions uint64
+	// FailedHandshakes is the number of the failed session establishments.
+	FailedHandshakes uint64
+	// SmoothedRTT is the smoothed round-trip time, which is zero until a round trip is measured.
+	SmoothedRTT time.Duration
+	// RTTVariance is the variation of the round-trip time.
+	RTTVariance time.Duration
+	// LastSeen is the time when a message was last received from the peer.
+	LastSeen time.Time
+}
+
+// RetransmissionRate returns the ratio of the retransmissions to the sent messages.
+func (stats PeerStats) RetransmissionRate() float64 {
+	if stats.MessagesSent == 0 {
+		return 0
+	}
+	return float64(stats.Retransmissions) / float64(stats.MessagesSent)
+}
+
+// PeerTable represents the transport statistics of peer nodes.
+type PeerTable struct {
+	sync.Mutex
+	peers map[types.NodeID]*PeerStats
+	now   func() time.Time
+}
+
+// NewPeerTable returns a new empty peer table.
+func NewPeerTable() *PeerTable {
+	return &PeerTable{
+		Mutex: sync.Mutex{},
+		peers: map[types.NodeID]*PeerStats{},
+		now:   time.Now,
+	}
+}
+
+// SetClock sets the clock which stamps the received messages.
+func (table *PeerTable) SetClock(now func() time.Time) {
+	table.Lock()
+	defer table.Unlock()
+	table.now = now
+}
+
+func (table *PeerTable) update(peer types.NodeID, f func(stats *PeerStats)) {
+	table.Lock()
+	defer table.Unlock()
+	stats, ok := table.peers[peer]
+	if !ok {
+		stats = &PeerStats{
+			MessagesSent:     0,
+			MessagesReceived: 0,
+			Retransmissions:  0,
+			FailedHandshakes: 0,
+			SmoothedRTT:      0,
+			RTTVariance:      0,
+			LastSeen:         time.Time{},
+		}
+		table.peers[peer] = stats
+	}
+	f(stats)
+}
+
+// MessageSent records a message sent to the peer.
+func (table *PeerTable) MessageSent(peer types.NodeID) {
+	table.update(peer, func(stats *PeerStats) {
+		stats.MessagesSent++
+	})
+}
+
+// MessageRetransmitted records a message retransmitted to the peer.
+func (table *PeerTable) MessageRetransmitted(peer types.NodeID) {
+	table.update(peer, func(stats *PeerStats) {
+		stats.MessagesSent++
+		stats.Retransmissions++
+	})
+}
+
+// MessageReceived records a message received from the peer.
+func (table *PeerTable) MessageReceived(peer types.NodeID) {
+	table.update(peer, func(stats *PeerStats) {
+		stats.MessagesReceived++
+		stats.LastSeen = table.now()
+	})
+}
+
+// RoundTripMeasured updates the round-trip time estimate of the peer with the specified sample.
+func (table *PeerTable) RoundTripMeasured(peer types.NodeID, rtt time.Duration) {
+	table.update(peer, func(stats *PeerStats) {
+		if stats.SmoothedRTT == 0 {
+			stats.SmoothedRTT = rtt
+			stats.RTTVariance = rtt / 2
+			return
+		}
+		stats.RTTVariance += ((stats.SmoothedRTT - rtt).Abs() - stats.RTTVariance) / rttBeta
+		stats.SmoothedRTT += (rtt - stats.SmoothedRTT) / rttAlpha
+	})
+}
+
+// HandshakeFailed records a failed session establishment with the peer.
+func (table *PeerTable) HandshakeFailed(peer types.NodeID) {
+	table.update(peer, func(stats *PeerStats) {
+		stats.FailedHandshakes++
+	})
+}
+
+// Lookup returns the statistics of the specified peer.
+func (table *PeerTable) Lookup(peer types.NodeID) (PeerStats, bool) {
+	table.Lock()
+	defer table.Unlock()
+	stats, ok := table.peers[peer]
+	if !ok {
+		return PeerStats{}, false
+	}
+	return *stats, true
+}
+
+// Peers returns the peers which have statistics in ascending order.
+func (table *PeerTable) Peers() []types.NodeID {
+	table.Lock()
+	defer table.Unlock()
+	peers := make([]types.NodeID, 0, len(table.peers))
+	for peer := range table.peers {
+		peers = append(peers, peer)
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i] < peers[j] })
+	return peers
+}
+
+// Remove removes the statistics of the specified peer.
+func (table *PeerTable) Remove(peer types.NodeID) {
+	table.Lock()
+	defer table.Unlock()
+	delete(table.peers, peer)
+}
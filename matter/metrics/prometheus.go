@@ -0,0 +1,185 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/protocol"
+)
+
+// HandshakeBuckets are the upper bounds in seconds of the handshake duration histogram.
+var HandshakeBuckets = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+type histogram struct {
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+// PrometheusRecorder represents a recorder which aggregates all metrics in memory
+// and exposes them in the Prometheus text exposition format.
+type PrometheusRecorder struct {
+	sync.Mutex
+	namespace     string
+	sent          map[protocol.ProtocolID]uint64
+	received      map[protocol.ProtocolID]uint64
+	retransmitted map[protocol.ProtocolID]uint64
+	handshakes    map[string]*histogram
+	decodeErrors  map[string]uint64
+}
+
+// NewPrometheusRecorder returns a new recorder whose metric names start with the specified namespace.
+func NewPrometheusRecorder(namespace string) *PrometheusRecorder {
+	return &PrometheusRecorder{
+		Mutex:         sync.Mutex{},
+		namespace:     namespace,
+		sent:          map[protocol.ProtocolID]uint64{},
+		received:      map[protocol.ProtocolID]uint64{},
+		retransmitted: map[protocol.ProtocolID]uint64{},
+		handshakes:    map[string]*histogram{},
+		decodeErrors:  map[string]uint64{},
+	}
+}
+
+// MessageSent increments the sent message counter of the specified protocol.
+func (recorder *PrometheusRecorder) MessageSent(id protocol.ProtocolID) {
+	recorder.Lock()
+	defer recorder.Unlock()
+	recorder.sent[id]++
+}
+
+// MessageReceived increments the received message counter of the specified protocol.
+func (recorder *PrometheusRecorder) MessageReceived(id protocol.ProtocolID) {
+	recorder.Lock()
+	defer recorder.Unlock()
+	recorder.received[id]++
+}
+
+// MessageRetransmitted increments the retransmitted message counter of the specified protocol.
+func (recorder *PrometheusRecorder) MessageRetransmitted(id protocol.ProtocolID) {
+	recorder.Lock()
+	defer recorder.Unlock()
+	recorder.retransmitted[id]++
+}
+
+// HandshakeCompleted observes the handshake duration.
+func (recorder *PrometheusRecorder) HandshakeCompleted(handshake Handshake, d time.Duration, err error) {
+	recorder.Lock()
+	defer recorder.Unlock()
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	key := fmt.Sprintf("handshake=%q,result=%q", handshake, result)
+	h, ok := recorder.handshakes[key]
+	if !ok {
+		h = &histogram{
+			buckets: make([]uint64, len(HandshakeBuckets)),
+			count:   0,
+			sum:     0,
+		}
+		recorder.handshakes[key] = h
+	}
+	secs := d.Seconds()
+	for n, bound := range HandshakeBuckets {
+		if secs <= bound {
+			h.buckets[n]++
+		}
+	}
+	h.count++
+	h.sum += secs
+}
+
+// DecodeError increments the decode error counter of the specified layer.
+func (recorder *PrometheusRecorder) DecodeError(layer string) {
+	recorder.Lock()
+	defer recorder.Unlock()
+	recorder.decodeErrors[layer]++
+}
+
+func (recorder *PrometheusRecorder) name(name string) string {
+	if len(recorder.namespace) == 0 {
+		return name
+	}
+	return recorder.namespace + "_" + name
+}
+
+func writeProtocolCounter(w *strings.Builder, name string, help string, counters map[protocol.ProtocolID]uint64) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+	ids := make([]protocol.ProtocolID, 0, len(counters))
+	for id := range counters {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		fmt.Fprintf(w, "%s{protocol=\"0x%04X\"} %d\n", name, uint16(id), counters[id])
+	}
+}
+
+// WriteTo writes all metrics in the Prometheus text exposition format.
+func (recorder *PrometheusRecorder) WriteTo(w io.Writer) (int64, error) {
+	recorder.Lock()
+	defer recorder.Unlock()
+
+	var b strings.Builder
+
+	writeProtocolCounter(&b, recorder.name("messages_sent_total"), "Number of sent messages.", recorder.sent)
+	writeProtocolCounter(&b, recorder.name("messages_received_total"), "Number of received messages.", recorder.received)
+	writeProtocolCounter(&b, recorder.name("messages_retransmitted_total"), "Number of retransmitted messages.", recorder.retransmitted)
+
+	name := recorder.name("handshake_duration_seconds")
+	fmt.Fprintf(&b, "# HELP %s Duration of secure session establishments.\n# TYPE %s histogram\n", name, name)
+	keys := make([]string, 0, len(recorder.handshakes))
+	for key := range recorder.handshakes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		h := recorder.handshakes[key]
+		for n, bound := range HandshakeBuckets {
+			fmt.Fprintf(&b, "%s_bucket{%s,le=\"%g\"} %d\n", name, key, bound, h.buckets[n])
+		}
+		fmt.Fprintf(&b, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, key, h.count)
+		fmt.Fprintf(&b, "%s_sum{%s} %g\n", name, key, h.sum)
+		fmt.Fprintf(&b, "%s_count{%s} %d\n", name, key, h.count)
+	}
+
+	name = recorder.name("decode_errors_total")
+	fmt.Fprintf(&b, "# HELP %s Number of undecodable messages.\n# TYPE %s counter\n", name, name)
+	layers := make([]string, 0, len(recorder.decodeErrors))
+	for layer := range recorder.decodeErrors {
+		layers = append(layers, layer)
+	}
+	sort.Strings(layers)
+	for _, layer := range layers {
+		fmt.Fprintf(&b, "%s{layer=%q} %d\n", name, layer, recorder.decodeErrors[layer])
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// ServeHTTP serves all metrics in the Prometheus text exposition format.
+func (recorder *PrometheusRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = recorder.WriteTo(w)
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/protocol"
+)
+
+// Handshake represents a secure session establishment protocol.
+type Handshake string
+
+const (
+	// PASEHandshake represents the passcode-authenticated session establishment.
+	PASEHandshake Handshake = "pase"
+	// CASEHandshake represents the certificate-authenticated session establishment.
+	CASEHandshake Handshake = "case"
+)
+
+// Recorder represents a receiver of the stack health metrics.
+type Recorder interface {
+	// MessageSent is called when a message of the specified protocol is sent.
+	MessageSent(id protocol.ProtocolID)
+	// MessageReceived is called when a message of the specified protocol is received.
+	MessageReceived(id protocol.ProtocolID)
+	// MessageRetransmitted is called when a message of the specified protocol is retransmitted.
+	MessageRetransmitted(id protocol.ProtocolID)
+	// HandshakeCompleted is called when a session establishment finishes successfully or not.
+	HandshakeCompleted(handshake Handshake, d time.Duration, err error)
+	// DecodeError is called when a received message could not be decoded.
+	DecodeError(layer string)
+}
+
+var (
+	sharedRecorder      Recorder = NewNullRecorder()
+	sharedRecorderMutex          = sync.RWMutex{}
+)
+
+// SetSharedRecorder sets the recorder which receives all metrics.
+func SetSharedRecorder(recorder Recorder) {
+	sharedRecorderMutex.Lock()
+	defer sharedRecorderMutex.Unlock()
+	if recorder == nil {
+		recorder = NewNullRecorder()
+	}
+	sharedRecorder = recorder
+}
+
+// SharedRecorder returns the recorder which receives all metrics.
+func SharedRecorder() Recorder {
+	sharedRecorderMutex.RLock()
+	defer sharedRecorderMutex.RUnlock()
+	return sharedRecorder
+}
+
+// NullRecorder represents a recorder which discards all metrics.
+type NullRecorder struct{}
+
+// NewNullRecorder returns a new recorder which discards all metrics.
+func NewNullRecorder() *NullRecorder {
+	return &NullRecorder{}
+}
+
+// MessageSent discards the metric.
+func (recorder *NullRecorder) MessageSent(id protocol.ProtocolID) {}
+
+// MessageReceived discards the metric.
+func (recorder *NullRecorder) MessageReceived(id protocol.ProtocolID) {}
+
+// MessageRetransmitted discards the metric.
+func (recorder *NullRecorder) MessageRetransmitted(id protocol.ProtocolID) {}
+
+// HandshakeCompleted discards the metric.
+func (recorder *NullRecorder) HandshakeCompleted(handshake Handshake, d time.Duration, err error) {}
+
+// DecodeError discards the metric.
+func (recorder *NullRecorder) DecodeError(layer string) {}
@@ -0,0 +1,395 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-mdns/mdns"
+	"github.com/cybergarage/go-mdns/mdns/dns"
+)
+
+const (
+	// DefaultMonitorTTL is the duration which an advertisement is considered current after it is last seen,
+	// which is the TTL of the commissionable SRV and TXT records.
+	DefaultMonitorTTL = 120 * time.Second
+	// DefaultFlappingThreshold is the number of commissioning mode changes which are flagged as flapping.
+	DefaultFlappingThreshold = 3
+	// DefaultFlappingWindow is the duration in which commissioning mode changes are counted.
+	DefaultFlappingWindow = time.Minute
+)
+
+// AdvertisementAnomalyKind represents a kind of anomalies of commissionable advertisements.
+type AdvertisementAnomalyKind int
+
+const (
+	// DiscriminatorCollisionAnomaly is flagged when nodes on different hosts in commissioning mode advertise
+	// the same discriminator, so commissioners may pick the wrong node for an onboarding payload.
+	DiscriminatorCollisionAnomaly AdvertisementAnomalyKind = iota
+	// CommissioningModeFlappingAnomaly is flagged when a node changes its commissioning mode repeatedly
+	// in a short time, such as a node which reboots in a loop.
+	CommissioningModeFlappingAnomaly
+	// RotatingIDReuseAnomaly is flagged when different nodes advertise the same rotating device ID,
+	// which must be unique to each node.
+	RotatingIDReuseAnomaly
+)
+
+// String returns the name of the kind.
+func (kind AdvertisementAnomalyKind) String() string {
+	switch kind {
+	case DiscriminatorCollisionAnomaly:
+		return "discriminator collision"
+	case CommissioningModeFlappingAnomaly:
+		return "commissioning mode flapping"
+	case RotatingIDReuseAnomaly:
+		return "rotating ID reuse"
+	}
+	return fmt.Sprintf("anomaly (%d)", int(kind))
+}
+
+// AdvertisementAnomaly represents an anomaly flagged by an advertisement monitor.
+type AdvertisementAnomaly struct {
+	// Kind is the kind of the anomaly.
+	Kind AdvertisementAnomalyKind
+	// Time is the time when the anomaly is flagged.
+	Time time.Time
+	// Instances is the instance names of the involved advertisements.
+	Instances []string
+	// Detail is a description of the anomaly.
+	Detail string
+}
+
+// String returns the string representation.
+func (anomaly *AdvertisementAnomaly) String() string {
+	return fmt.Sprintf("%s : %s (%s)", anomaly.Kind, anomaly.Detail, strings.Join(anomaly.Instances, ", "))
+}
+
+// Advertisement represents the commissionable advertisements of an instance which are seen repeatedly
+// without changes.
+type Advertisement struct {
+	// Instance is the service instance name.
+	Instance string
+	// Host is the host name of the SRV record.
+	Host string
+	// Discriminator is the full discriminator, which is valid if HasDiscriminator is true.
+	Discriminator Discriminator
+	// HasDiscriminator is true if the advertisement has a full discriminator.
+	HasDiscriminator bool
+	// VendorID is the vendor ID of the VP key, which is zero if it is not advertised.
+	VendorID VenderID
+	// ProductID is the product ID of the VP key, which is zero if it is not advertised.
+	ProductID ProductID
+	// CommissioningMode is the value of the CM key.
+	CommissioningMode string
+	// RotatingDeviceID is the value of the RI key, which is empty if it is not advertised.
+	RotatingDeviceID string
+	// FirstSeen is the time when the advertisement is first seen.
+	FirstSeen time.Time
+	// LastSeen is the time when the advertisement is last seen.
+	LastSeen time.Time
+	// Count is the number of times the advertisement is seen.
+	Count int
+}
+
+// newAdvertisement returns the advertisement of the commissionable service.
+func newAdvertisement(srv *mdns.Service, now time.Time) *Advertisement {
+	com := NewCommissioneeWithService(srv)
+	adv := &Advertisement{
+		Instance:          strings.TrimSuffix(srv.Name, "."),
+		Host:              srv.Host,
+		Discriminator:     0,
+		HasDiscriminator:  false,
+		VendorID:          0,
+		ProductID:         0,
+		CommissioningMode: CommissioningModeNone,
+		RotatingDeviceID:  "",
+		FirstSeen:         now,
+		LastSeen:          now,
+		Count:             1,
+	}
+	adv.Discriminator, adv.HasDiscriminator = com.LookupFullDiscriminator()
+	if vid, pid, ok := com.LookupVendorProductID(); ok {
+		adv.VendorID, adv.ProductID = vid, pid
+	}
+	if cm, ok := com.LookupCommissioningMode(); ok {
+		adv.CommissioningMode = cm
+	}
+	if ri, ok := com.LookupRotatingDeviceID(); ok {
+		adv.RotatingDeviceID = ri
+	}
+	return adv
+}
+
+// sameAs returns true if the advertisements have the same contents.
+func (adv *Advertisement) sameAs(other *Advertisement) bool {
+	return adv.Host == other.Host &&
+		adv.Discriminator == other.Discriminator &&
+		adv.HasDiscriminator == other.HasDiscriminator &&
+		adv.VendorID == other.VendorID &&
+		adv.ProductID == other.ProductID &&
+		adv.CommissioningMode == other.CommissioningMode &&
+		adv.RotatingDeviceID == other.RotatingDeviceID
+}
+
+// isCommissionable returns true if the advertisement is in commissioning mode.
+func (adv *Advertisement) isCommissionable() bool {
+	return adv.CommissioningMode != CommissioningModeNone
+}
+
+// String returns the string representation.
+func (adv *Advertisement) String() string {
+	d := "-"
+	if adv.HasDiscriminator {
+		d = fmt.Sprintf("%d", adv.Discriminator)
+	}
+	return fmt.Sprintf("%s D=%s VP=%d+%d CM=%s RI=%s", adv.Instance, d, adv.VendorID, adv.ProductID, adv.CommissioningMode, adv.RotatingDeviceID)
+}
+
+// AdvertisementMonitorOption represents an advertisement monitor option.
+type AdvertisementMonitorOption func(*AdvertisementMonitor)
+
+// WithAdvertisementTTL returns an option to set the duration which advertisements are current after they are last seen.
+func WithAdvertisementTTL(d time.Duration) AdvertisementMonitorOption {
+	return func(mon *AdvertisementMonitor) {
+		mon.ttl = d
+	}
+}
+
+// WithFlappingThreshold returns an option to set the number of commissioning mode changes in the window
+// which are flagged as flapping.
+func WithFlappingThreshold(n int, window time.Duration) AdvertisementMonitorOption {
+	return func(mon *AdvertisementMonitor) {
+		mon.flapThreshold = n
+		mon.flapWindow = window
+	}
+}
+
+// WithAdvertisementHandler returns an option to set the handler which is called with new and changed advertisements.
+func WithAdvertisementHandler(h func(adv *Advertisement)) AdvertisementMonitorOption {
+	return func(mon *AdvertisementMonitor) {
+		mon.advHandler = h
+	}
+}
+
+// WithAnomalyHandler returns an option to set the handler which is called with the flagged anomalies.
+func WithAnomalyHandler(h func(anomaly *AdvertisementAnomaly)) AdvertisementMonitorOption {
+	return func(mon *AdvertisementMonitor) {
+		mon.anomalyHandler = h
+	}
+}
+
+// WithAdvertisementClock returns an option to set the clock of the advertisement monitor.
+func WithAdvertisementClock(now func() time.Time) AdvertisementMonitorOption {
+	return func(mon *AdvertisementMonitor) {
+		mon.now = now
+	}
+}
+
+// AdvertisementMonitor passively records the commissionable advertisements over time, and flags anomalies
+// which confuse commissioners in crowded environments such as labs. The same anomaly is flagged again only
+// after the advertisement TTL. The monitor is safe for concurrent use.
+type AdvertisementMonitor struct {
+	sync.Mutex
+	history        map[string][]*Advertisement
+	cmChanges      map[string][]time.Time
+	rotatingIDs    map[string]string
+	anomalies      []*AdvertisementAnomaly
+	flagged        map[string]time.Time
+	ttl            time.Duration
+	flapThreshold  int
+	flapWindow     time.Duration
+	advHandler     func(adv *Advertisement)
+	anomalyHandler func(anomaly *AdvertisementAnomaly)
+	now            func() time.Time
+}
+
+// NewAdvertisementMonitor returns a new advertisement monitor.
+func NewAdvertisementMonitor(opts ...AdvertisementMonitorOption) *AdvertisementMonitor {
+	mon := &AdvertisementMonitor{
+		Mutex:          sync.Mutex{},
+		history:        map[string][]*Advertisement{},
+		cmChanges:      map[string][]time.Time{},
+		rotatingIDs:    map[string]string{},
+		anomalies:      []*AdvertisementAnomaly{},
+		flagged:        map[string]time.Time{},
+		ttl:            DefaultMonitorTTL,
+		flapThreshold:  DefaultFlappingThreshold,
+		flapWindow:     DefaultFlappingWindow,
+		advHandler:     nil,
+		anomalyHandler: nil,
+		now:            time.Now,
+	}
+	for _, opt := range opts {
+		opt(mon)
+	}
+	return mon
+}
+
+// MessageReceived records the commissionable advertisement of the mDNS response, so the monitor can be
+// set as the listener of a discoverer.
+func (mon *AdvertisementMonitor) MessageReceived(msg *dns.Message) {
+	if !msg.IsResponse() {
+		return
+	}
+	srv, err := mdns.NewServiceWithMessage(msg)
+	if err != nil || !CommissionableServiceType.matches(srv.Name) {
+		return
+	}
+	mon.Record(srv)
+}
+
+// Record records the advertisement of the commissionable service, and returns the anomalies which it causes.
+func (mon *AdvertisementMonitor) Record(srv *mdns.Service) []*AdvertisementAnomaly {
+	now := mon.now()
+	adv := newAdvertisement(srv, now)
+	key := serviceKey(adv.Instance)
+
+	mon.Lock()
+	history := mon.history[key]
+	var prev *Advertisement
+	if 0 < len(history) {
+		prev = history[len(history)-1]
+	}
+	if prev != nil && prev.sameAs(adv) {
+		prev.LastSeen = now
+		prev.Count++
+		mon.Unlock()
+		return []*AdvertisementAnomaly{}
+	}
+	mon.history[key] = append(history, adv)
+
+	anomalies := []*AdvertisementAnomaly{}
+	flag := func(kind AdvertisementAnomalyKind, instances []string, detail string) {
+		sort.Strings(instances)
+		id := fmt.Sprintf("%d/%s", kind, strings.Join(instances, "/"))
+		if last, ok := mon.flagged[id]; ok && now.Sub(last) < mon.ttl {
+			return
+		}
+		mon.flagged[id] = now
+		anomaly := &AdvertisementAnomaly{
+			Kind:      kind,
+			Time:      now,
+			Instances: instances,
+			Detail:    detail,
+		}
+		mon.anomalies = append(mon.anomalies, anomaly)
+		anomalies = append(anomalies, anomaly)
+	}
+
+	if prev != nil && prev.CommissioningMode != adv.CommissioningMode {
+		changes := []time.Time{}
+		for _, t := range append(mon.cmChanges[key], now) {
+			if now.Sub(t) < mon.flapWindow {
+				changes = append(changes, t)
+			}
+		}
+		mon.cmChanges[key] = changes
+		if 0 < mon.flapThreshold && mon.flapThreshold <= len(changes) {
+			flag(CommissioningModeFlappingAnomaly, []string{adv.Instance},
+				fmt.Sprintf("CM changed %d times in %s", len(changes), mon.flapWindow))
+		}
+	}
+
+	if adv.HasDiscriminator && adv.isCommissionable() {
+		for otherKey, others := range mon.history {
+			other := others[len(others)-1]
+			if otherKey == key || !other.HasDiscriminator || !other.isCommissionable() ||
+				other.Discriminator != adv.Discriminator || other.Host == adv.Host || mon.ttl <= now.Sub(other.LastSeen) {
+				continue
+			}
+			flag(DiscriminatorCollisionAnomaly, []string{adv.Instance, other.Instance},
+				fmt.Sprintf("discriminator %d is advertised by %s and %s", adv.Discriminator, adv.Host, other.Host))
+		}
+	}
+
+	if adv.RotatingDeviceID != "" {
+		if owner, ok := mon.rotatingIDs[adv.RotatingDeviceID]; ok && owner != key {
+			flag(RotatingIDReuseAnomaly, []string{adv.Instance, mon.history[owner][0].Instance},
+				fmt.Sprintf("rotating ID %s is advertised by different instances", adv.RotatingDeviceID))
+		} else {
+			mon.rotatingIDs[adv.RotatingDeviceID] = key
+		}
+	}
+	mon.Unlock()
+
+	if mon.advHandler != nil {
+		mon.advHandler(adv)
+	}
+	if mon.anomalyHandler != nil {
+		for _, anomaly := range anomalies {
+			mon.anomalyHandler(anomaly)
+		}
+	}
+	return anomalies
+}
+
+// Advertisements returns the recorded advertisements in the order they are first seen.
+func (mon *AdvertisementMonitor) Advertisements() []*Advertisement {
+	mon.Lock()
+	defer mon.Unlock()
+	advs := []*Advertisement{}
+	for _, history := range mon.history {
+		for _, adv := range history {
+			copied := *adv
+			advs = append(advs, &copied)
+		}
+	}
+	sort.SliceStable(advs, func(i, j int) bool { return advs[i].FirstSeen.Before(advs[j].FirstSeen) })
+	return advs
+}
+
+// Instances returns the instance names of the recorded advertisements in ascending order.
+func (mon *AdvertisementMonitor) Instances() []string {
+	mon.Lock()
+	defer mon.Unlock()
+	instances := []string{}
+	for _, history := range mon.history {
+		instances = append(instances, history[0].Instance)
+	}
+	sort.Strings(instances)
+	return instances
+}
+
+// Anomalies returns the flagged anomalies in the order they are flagged.
+func (mon *AdvertisementMonitor) Anomalies() []*AdvertisementAnomaly {
+	mon.Lock()
+	defer mon.Unlock()
+	return append([]*AdvertisementAnomaly{}, mon.anomalies...)
+}
+
+// Run records the advertisements received by the discoverer until the context is done, which replaces
+// the listener of the discoverer. The monitor only listens to the advertisements unless the query interval
+// is positive, in which case commissionable nodes are also queried at the interval.
+func (mon *AdvertisementMonitor) Run(ctx context.Context, disc *Discoverer, queryInterval time.Duration) error {
+	disc.SetListener(mon)
+	defer disc.SetListener(nil)
+	if queryInterval <= 0 {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	for {
+		if err := disc.Query(mdns.NewQueryWithServices([]string{CommissionableServiceType.String()})); err != nil {
+			return err
+		}
+		if err := sleepContext(ctx, queryInterval); err != nil {
+			return err
+		}
+	}
+}
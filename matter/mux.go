@@ -0,0 +1,271 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// SubscriptionMux coalesces the subscriptions of application consumers to a node into
+// a single device subscription, and fans the reports out to the consumers locally.
+// The device subscription covers the union of the consumer paths with the most demanding
+// intervals, and is replaced only when a consumer subscribes paths which it does not cover.
+// It is kept until the last consumer closes, so constrained devices are not resubscribed
+// whenever consumers leave.
+type SubscriptionMux struct {
+	sync.Mutex
+	subscribing sync.Mutex
+	dev         *OperationalDevice
+	consumers   map[*muxConsumer]bool
+	sub         im.Subscription
+	paths       []im.AttributePath
+	minInterval time.Duration
+	maxInterval time.Duration
+	generation  uint64
+	latest      map[im.AttributePath]im.AttributeData
+}
+
+// NewSubscriptionMux returns a new subscription multiplexer of the specified node.
+func NewSubscriptionMux(dev *OperationalDevice) *SubscriptionMux {
+	return &SubscriptionMux{
+		Mutex:       sync.Mutex{},
+		subscribing: sync.Mutex{},
+		dev:         dev,
+		consumers:   map[*muxConsumer]bool{},
+		sub:         nil,
+		paths:       []im.AttributePath{},
+		minInterval: 0,
+		maxInterval: 0,
+		generation:  0,
+		latest:      map[im.AttributePath]im.AttributeData{},
+	}
+}
+
+// muxConsumer represents a subscription of an application consumer.
+type muxConsumer struct {
+	mux     *SubscriptionMux
+	req     im.SubscribeRequest
+	handler im.ReportHandler
+}
+
+// ID returns the ID of the device subscription shared with the other consumers.
+func (c *muxConsumer) ID() im.SubscriptionID {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.mux.sub == nil {
+		return 0
+	}
+	return c.mux.sub.ID()
+}
+
+// MaxInterval returns the maximum interval of the device subscription, which is not longer than the requested one.
+func (c *muxConsumer) MaxInterval() time.Duration {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.mux.sub == nil {
+		return 0
+	}
+	return c.mux.sub.MaxInterval()
+}
+
+// Close removes the consumer, and cancels the device subscription if no consumers remain.
+func (c *muxConsumer) Close() error {
+	return c.mux.remove(c)
+}
+
+// matches returns true if the consumer subscribes the specified concrete path.
+func (c *muxConsumer) matches(path im.AttributePath) bool {
+	for _, p := range c.req.Paths {
+		if p.Match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// covers returns true if any of the specified paths covers the path.
+func covers(paths []im.AttributePath, path im.AttributePath) bool {
+	for _, p := range paths {
+		if p.Match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// coversAll returns true if the specified paths cover all the added paths.
+func coversAll(paths []im.AttributePath, added []im.AttributePath) bool {
+	for _, path := range added {
+		if !covers(paths, path) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergePaths returns the union of the paths without the paths covered by the others.
+func mergePaths(paths []im.AttributePath, added []im.AttributePath) []im.AttributePath {
+	merged := append([]im.AttributePath{}, paths...)
+	for _, path := range added {
+		if covers(merged, path) {
+			continue
+		}
+		kept := merged[:0]
+		for _, p := range merged {
+			if !path.Match(p) {
+				kept = append(kept, p)
+			}
+		}
+		merged = append(kept, path)
+	}
+	return merged
+}
+
+// Subscribe adds a consumer of the specified attribute paths. The consumer receives the
+// attribute data matching its paths, and the latest data known to the multiplexer as its
+// priming report if the device subscription is shared. Event paths are not supported.
+func (mux *SubscriptionMux) Subscribe(ctx context.Context, req im.SubscribeRequest, handler im.ReportHandler) (im.Subscription, error) {
+	if 0 < len(req.EventPaths) {
+		return nil, fmt.Errorf("shared event subscriptions are %w", ErrNotSupported)
+	}
+	c := &muxConsumer{mux: mux, req: req, handler: handler}
+
+	mux.subscribing.Lock()
+	defer mux.subscribing.Unlock()
+
+	mux.Lock()
+	paths := mergePaths(mux.paths, req.Paths)
+	minInterval, maxInterval := req.MinInterval, req.MaxInterval
+	if mux.sub != nil {
+		minInterval = min(minInterval, mux.minInterval)
+		maxInterval = min(maxInterval, mux.maxInterval)
+	}
+	if mux.sub != nil && coversAll(mux.paths, req.Paths) && minInterval == mux.minInterval && maxInterval == mux.maxInterval {
+		mux.consumers[c] = true
+		priming := []im.AttributeData{}
+		for path, data := range mux.latest {
+			if c.matches(path) {
+				priming = append(priming, data)
+			}
+		}
+		mux.Unlock()
+		if 0 < len(priming) {
+			handler(priming)
+		}
+		return c, nil
+	}
+	mux.generation++
+	generation := mux.generation
+	// The consumer is added before subscribing, so it receives the priming report.
+	mux.consumers[c] = true
+	mux.Unlock()
+
+	sub, err := mux.dev.Subscribe(ctx, im.SubscribeRequest{
+		Paths:             paths,
+		EventPaths:        nil,
+		EventMin:          0,
+		MinInterval:       minInterval,
+		MaxInterval:       maxInterval,
+		KeepSubscriptions: true,
+		FabricFiltered:    req.FabricFiltered,
+	}, func(data []im.AttributeData) {
+		mux.report(generation, data)
+	})
+
+	mux.Lock()
+	if err != nil {
+		delete(mux.consumers, c)
+		mux.Unlock()
+		return nil, err
+	}
+	prev := mux.sub
+	mux.sub = sub
+	mux.paths = paths
+	mux.minInterval = minInterval
+	mux.maxInterval = maxInterval
+	mux.Unlock()
+
+	if prev != nil {
+		if err := prev.Close(); err != nil {
+			return c, err
+		}
+	}
+	return c, nil
+}
+
+// report fans the attribute data of the device subscription out to the matching consumers.
+// Reports of the replaced device subscriptions are dropped.
+func (mux *SubscriptionMux) report(generation uint64, data []im.AttributeData) {
+	mux.Lock()
+	if generation != mux.generation {
+		mux.Unlock()
+		return
+	}
+	for _, d := range data {
+		mux.latest[d.Path] = d
+	}
+	consumers := make([]*muxConsumer, 0, len(mux.consumers))
+	for c := range mux.consumers {
+		consumers = append(consumers, c)
+	}
+	mux.Unlock()
+
+	for _, c := range consumers {
+		matched := []im.AttributeData{}
+		for _, d := range data {
+			if c.matches(d.Path) {
+				matched = append(matched, d)
+			}
+		}
+		if 0 < len(matched) {
+			c.handler(matched)
+		}
+	}
+}
+
+func (mux *SubscriptionMux) remove(c *muxConsumer) error {
+	mux.subscribing.Lock()
+	defer mux.subscribing.Unlock()
+
+	mux.Lock()
+	if !mux.consumers[c] {
+		mux.Unlock()
+		return nil
+	}
+	delete(mux.consumers, c)
+	if 0 < len(mux.consumers) || mux.sub == nil {
+		mux.Unlock()
+		return nil
+	}
+	sub := mux.sub
+	mux.sub = nil
+	mux.paths = []im.AttributePath{}
+	mux.latest = map[im.AttributePath]im.AttributeData{}
+	mux.generation++
+	mux.Unlock()
+	return sub.Close()
+}
+
+// Consumers returns the number of the consumers.
+func (mux *SubscriptionMux) Consumers() int {
+	mux.Lock()
+	defer mux.Unlock()
+	return len(mux.consumers)
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/cluster/networkdiagnostics"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// ReadWiFiDiagnostics reads the attributes of the Wi-Fi Network Diagnostics cluster of the specified endpoint.
+func ReadWiFiDiagnostics(ctx context.Context, reader AttributeReader, endpoint im.EndpointID) (*networkdiagnostics.WiFiDiagnostics, error) {
+	diag := &networkdiagnostics.WiFiDiagnostics{}
+	if err := readCluster(ctx, reader, endpoint, networkdiagnostics.WiFiClusterID, diag); err != nil {
+		return nil, err
+	}
+	return diag, nil
+}
+
+// ReadThreadDiagnostics reads the attributes of the Thread Network Diagnostics cluster of the specified endpoint.
+func ReadThreadDiagnostics(ctx context.Context, reader AttributeReader, endpoint im.EndpointID) (*networkdiagnostics.ThreadDiagnostics, error) {
+	diag := &networkdiagnostics.ThreadDiagnostics{}
+	if err := readCluster(ctx, reader, endpoint, networkdiagnostics.ThreadClusterID, diag); err != nil {
+		return nil, err
+	}
+	return diag, nil
+}
+
+// ReadEthernetDiagnostics reads the attributes of the Ethernet Network Diagnostics cluster of the specified endpoint.
+func ReadEthernetDiagnostics(ctx context.Context, reader AttributeReader, endpoint im.EndpointID) (*networkdiagnostics.EthernetDiagnostics, error) {
+	diag := &networkdiagnostics.EthernetDiagnostics{}
+	if err := readCluster(ctx, reader, endpoint, networkdiagnostics.EthernetClusterID, diag); err != nil {
+		return nil, err
+	}
+	return diag, nil
+}
+
+// readCluster reads all the attributes of the cluster, and decodes them into the struct pointed to by v
+// with im.DecodeAttributeData. The attributes which fail to decode are reported as errors, since they
+// violate the cluster specification.
+func readCluster(ctx context.Context, reader AttributeReader, endpoint im.EndpointID, cluster im.ClusterID, v any) error {
+	path := im.NewAttributePath(endpoint, cluster, im.WildcardAttributeID)
+	data, err := reader.ReadAttribute(ctx, path)
+	if err != nil {
+		return err
+	}
+	paths, err := im.DecodeAttributeData(data, v)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("%s is %w", path.Name(), ErrNotFound)
+	}
+	return nil
+}
+
+// ResetWiFiCounts resets the counters of the Wi-Fi Network Diagnostics cluster of the specified endpoint.
+func ResetWiFiCounts(ctx context.Context, invoker CommandInvoker, endpoint im.EndpointID) error {
+	return resetCounts(ctx, invoker, endpoint, networkdiagnostics.WiFiClusterID)
+}
+
+// ResetThreadCounts resets the counters of the Thread Network Diagnostics cluster of the specified endpoint.
+func ResetThreadCounts(ctx context.Context, invoker CommandInvoker, endpoint im.EndpointID) error {
+	return resetCounts(ctx, invoker, endpoint, networkdiagnostics.ThreadClusterID)
+}
+
+// ResetEthernetCounts resets the counters of the Ethernet Network Diagnostics cluster of the specified endpoint.
+func ResetEthernetCounts(ctx context.Context, invoker CommandInvoker, endpoint im.EndpointID) error {
+	return resetCounts(ctx, invoker, endpoint, networkdiagnostics.EthernetClusterID)
+}
+
+func resetCounts(ctx context.Context, invoker CommandInvoker, endpoint im.EndpointID, cluster im.ClusterID) error {
+	fields, err := datatype.Encode(datatype.NewStruct())
+	if err != nil {
+		return err
+	}
+	_, err = invoker.InvokeCommand(ctx, im.NewCommandPath(endpoint, cluster, networkdiagnostics.ResetCountsCommandID), fields)
+	return err
+}
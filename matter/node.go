@@ -15,12 +15,13 @@
 package matter
 
 import (
-	"github.com/cybergarage/go-matter/matter/message"
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/matter/types"
 )
 
 // NodeID represents a node ID.
 type NodeID = message.NodeID
 
 const (
-	UnspecifiedNodeID = (NodeID)(0x0000000000000000)
+	UnspecifiedNodeID = types.UnspecifiedNodeID
 )
@@ -0,0 +1,600 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/metrics"
+	"github.com/cybergarage/go-matter/matter/securechannel"
+	"github.com/cybergarage/go-matter/matter/session"
+	"github.com/cybergarage/go-matter/matter/trace"
+)
+
+// OperationalPeer represents the operational identity and address of a commissioned node.
+type OperationalPeer struct {
+	// FabricID is the fabric ID which the node is commissioned into.
+	FabricID uint64
+	// CompressedFabricID is the compressed fabric identifier used in the operational instance name.
+	CompressedFabricID uint64
+	// NodeID is the operational node ID.
+	NodeID NodeID
+	// Address is the last known operational address of the node.
+	Address netip.AddrPort
+}
+
+// 4.3.2.1. Operational Instance Name
+// InstanceName returns the DNS-SD operational instance name of the node.
+func (peer OperationalPeer) InstanceName() string {
+	return fmt.Sprintf("%016X-%016X", peer.CompressedFabricID, uint64(peer.NodeID))
+}
+
+// String returns the string representation.
+func (peer OperationalPeer) String() string {
+	return fmt.Sprintf("%s (%s)", peer.InstanceName(), peer.Address)
+}
+
+// OperationalSession represents a CASE session to a commissioned node.
+// The session returns session.ErrExpired or session.ErrClosed when it can no longer be used.
+type OperationalSession interface {
+	// ReadAttribute reads the attributes of the specified path.
+	ReadAttribute(ctx context.Context, path im.AttributePath) ([]im.AttributeData, error)
+	// WriteAttribute writes the TLV encoded value to the specified attribute.
+	WriteAttribute(ctx context.Context, path im.AttributePath, data []byte) error
+	// InvokeCommand invokes the command with the TLV encoded fields, and returns the TLV encoded response fields.
+	InvokeCommand(ctx context.Context, path im.CommandPath, fields []byte) ([]byte, error)
+	// Subscribe subscribes the attributes, and calls the handler for each report.
+	Subscribe(ctx context.Context, req im.SubscribeRequest, handler im.ReportHandler) (im.Subscription, error)
+	// Close closes the session.
+	Close() error
+}
+
+// FilteredReader is implemented by the sessions which send data version filters with reads.
+type FilteredReader interface {
+	// ReadAttributeFiltered reads the attributes of the specified path, and the server omits the
+	// attributes of the clusters whose data versions match the filters.
+	ReadAttributeFiltered(ctx context.Context, path im.AttributePath, filters []im.DataVersionFilter) ([]im.AttributeData, error)
+}
+
+// PeerAddressSetter is implemented by the sessions which can move to another address of the node
+// without being re-established.
+type PeerAddressSetter interface {
+	// SetPeerAddress sets the address which the session sends messages to.
+	SetPeerAddress(addr netip.AddrPort) error
+}
+
+// SessionEstablisher represents an establisher of CASE sessions to commissioned nodes.
+type SessionEstablisher interface {
+	// EstablishSession establishes a new CASE session to the specified peer. It returns a
+	// *securechannel.BusyError when the peer answers Sigma1 with a busy StatusReport, and the
+	// establishment is retried after the wait time of the peer.
+	EstablishSession(ctx context.Context, peer OperationalPeer) (OperationalSession, error)
+}
+
+// NullSessionEstablisher represents a session establisher which establishes no sessions.
+type NullSessionEstablisher struct{}
+
+// NewNullSessionEstablisher returns a new null session establisher.
+func NewNullSessionEstablisher() *NullSessionEstablisher {
+	return &NullSessionEstablisher{}
+}
+
+// EstablishSession returns ErrNotSupported.
+func (est *NullSessionEstablisher) EstablishSession(ctx context.Context, peer OperationalPeer) (OperationalSession, error) {
+	return nil, fmt.Errorf("CASE session to %s is %w", peer, ErrNotSupported)
+}
+
+// OperationalDevice represents a handle of a commissioned node. The handle establishes
+// a CASE session on the first operation, and re-establishes it transparently when the
+// session expires or the address of the node changes. The handle tracks all known addresses
+// of the node, and fails over to the other addresses when the node is not reached at one.
+// When all addresses fail, the node is re-resolved and retried with exponential backoff.
+// Operations may be issued from multiple goroutines, and share the session.
+type OperationalDevice struct {
+	sync.Mutex
+	peer        OperationalPeer
+	addrs       *PeerAddressTable
+	establisher SessionEstablisher
+	resolver    OperationalResolver
+	policy      ReconnectPolicy
+	params      *session.Params
+	window      ActiveWindow
+	session     OperationalSession
+	pending     *pendingSession
+	closed      bool
+	mux         *SubscriptionMux
+	stats       *metrics.PeerTable
+}
+
+// NewOperationalDevice returns a new operational device handle.
+func NewOperationalDevice(peer OperationalPeer, establisher SessionEstablisher) *OperationalDevice {
+	dev := &OperationalDevice{
+		Mutex:       sync.Mutex{},
+		peer:        peer,
+		addrs:       NewPeerAddressTable(peer.Address),
+		establisher: establisher,
+		resolver:    NewNullOperationalResolver(),
+		policy:      DefaultReconnectPolicy(),
+		params:      session.NewParams(),
+		window:      ActiveWindow{Start: time.Time{}, Params: nil},
+		session:     nil,
+		pending:     nil,
+		closed:      false,
+		mux:         nil,
+		stats:       metrics.NewPeerTable(),
+	}
+	dev.mux = NewSubscriptionMux(dev)
+	return dev
+}
+
+// SetOperationalResolver sets the resolver which looks up the address of the node on failures.
+func (dev *OperationalDevice) SetOperationalResolver(resolver OperationalResolver) {
+	dev.Lock()
+	defer dev.Unlock()
+	if resolver == nil {
+		resolver = NewNullOperationalResolver()
+	}
+	dev.resolver = resolver
+}
+
+// SetReconnectPolicy sets the policy to re-establish sessions on failures.
+func (dev *OperationalDevice) SetReconnectPolicy(policy ReconnectPolicy) {
+	dev.Lock()
+	defer dev.Unlock()
+	dev.policy = policy
+}
+
+// SetPeerTable sets the table which records the transport statistics of the node.
+func (dev *OperationalDevice) SetPeerTable(table *metrics.PeerTable) {
+	dev.Lock()
+	defer dev.Unlock()
+	if table == nil {
+		table = metrics.NewPeerTable()
+	}
+	dev.stats = table
+}
+
+// Stats returns the transport statistics of the node.
+func (dev *OperationalDevice) Stats() metrics.PeerStats {
+	dev.Lock()
+	stats := dev.stats
+	dev.Unlock()
+	peerStats, _ := stats.Lookup(dev.NodeID())
+	return peerStats
+}
+
+// Peer returns the operational identity and the last known address of the node.
+func (dev *OperationalDevice) Peer() OperationalPeer {
+	dev.Lock()
+	defer dev.Unlock()
+	return dev.peer
+}
+
+// NodeID returns the operational node ID.
+func (dev *OperationalDevice) NodeID() NodeID {
+	return dev.Peer().NodeID
+}
+
+// Addresses returns the known addresses of the node in the order of preference.
+func (dev *OperationalDevice) Addresses() []PeerAddress {
+	return dev.addrs.Addresses()
+}
+
+// AddAddresses adds the addresses of the node which are tried when the current address fails.
+func (dev *OperationalDevice) AddAddresses(addrs ...netip.AddrPort) {
+	dev.addrs.Add(addrs...)
+}
+
+// SetAddress updates the address of the node. If the address changes, the current session moves to
+// the address if it implements PeerAddressSetter, otherwise it is closed and a new session is established
+// to the address on the next operation.
+func (dev *OperationalDevice) SetAddress(addr netip.AddrPort) error {
+	dev.Lock()
+	defer dev.Unlock()
+	if dev.peer.Address == addr {
+		return nil
+	}
+	dev.addrs.Add(addr)
+	return dev.moveSession(addr)
+}
+
+// pendingSession represents a CASE session establishment in flight. The callers which need a session
+// while it is established wait for it and share its result.
+type pendingSession struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	done    chan struct{}
+	session OperationalSession
+	err     error
+}
+
+// Session returns the current CASE session, establishing a new one if none is active. The session is
+// established without holding the handle lock, so the other methods of the handle are not blocked by
+// the handshake, and concurrent callers share one establishment. Close cancels a pending establishment.
+func (dev *OperationalDevice) Session(ctx context.Context) (OperationalSession, error) {
+	for {
+		dev.Lock()
+		if dev.closed {
+			dev.Unlock()
+			return nil, ErrClosed
+		}
+		if dev.session != nil {
+			s := dev.session
+			dev.Unlock()
+			return s, nil
+		}
+		pending := dev.pending
+		if pending == nil {
+			estCtx, cancel := context.WithCancel(ctx)
+			pending = &pendingSession{
+				ctx:     estCtx,
+				cancel:  cancel,
+				done:    make(chan struct{}),
+				session: nil,
+				err:     nil,
+			}
+			dev.pending = pending
+			dev.Unlock()
+			dev.establish(pending)
+			return pending.session, pending.err
+		}
+		dev.Unlock()
+
+		select {
+		case <-pending.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		// An establishment which ended with the context of another caller is retried with this context.
+		if pending.err == nil || !isContextError(pending.err) || ctx.Err() != nil {
+			return pending.session, pending.err
+		}
+	}
+}
+
+// establish establishes a CASE session for the pending establishment, and makes it the current session.
+func (dev *OperationalDevice) establish(pending *pendingSession) {
+	defer close(pending.done)
+	defer pending.cancel()
+
+	dev.Lock()
+	peer := dev.peer
+	establisher := dev.establisher
+	policy := dev.policy
+	stats := dev.stats
+	dev.Unlock()
+
+	// The current address is tried first, and the other addresses in the order of preference
+	// while the node is not reached.
+	addrs := []netip.AddrPort{peer.Address}
+	for _, addr := range dev.addrs.Addresses() {
+		if addr.Addr != peer.Address {
+			addrs = append(addrs, addr.Addr)
+		}
+	}
+	ctx := pending.ctx
+	var s OperationalSession
+	var err error
+	for _, addr := range addrs {
+		peer.Address = addr
+		err = securechannel.RetryBusy(ctx, peer.String(), policy.BusyRetries, func(ctx context.Context) error {
+			var err error
+			start := time.Now()
+			s, err = establisher.EstablishSession(ctx, peer)
+			if isHandshakeAttempted(err) {
+				metrics.SharedRecorder().HandshakeCompleted(metrics.CASEHandshake, time.Since(start), err)
+			}
+			return err
+		})
+		if err == nil {
+			dev.addrs.Succeeded(addr)
+			break
+		}
+		if isHandshakeAttempted(err) {
+			stats.HandshakeFailed(peer.NodeID)
+		}
+		if !isUnreachable(err) || ctx.Err() != nil {
+			break
+		}
+		dev.addrs.Failed(addr)
+	}
+
+	dev.Lock()
+	defer dev.Unlock()
+	dev.pending = nil
+	if dev.closed {
+		// The handle was closed during the handshake, so a new session is dropped.
+		if err == nil {
+			_ = s.Close()
+		}
+		s, err = nil, ErrClosed
+	}
+	if err == nil {
+		dev.peer.Address = peer.Address
+		dev.session = s
+	}
+	pending.session, pending.err = s, err
+}
+
+// ReadAttribute reads the attributes of the specified path.
+func (dev *OperationalDevice) ReadAttribute(ctx context.Context, path im.AttributePath) ([]im.AttributeData, error) {
+	var data []im.AttributeData
+	err := dev.do(ctx, func(s OperationalSession) error {
+		var err error
+		data, err = s.ReadAttribute(ctx, path)
+		return err
+	})
+	return data, err
+}
+
+// ReadAttributeFiltered reads the attributes of the specified path with the data version filters.
+// The filters are not sent if the session does not implement FilteredReader, and all the
+// attributes are reported then.
+func (dev *OperationalDevice) ReadAttributeFiltered(ctx context.Context, path im.AttributePath, filters []im.DataVersionFilter) ([]im.AttributeData, error) {
+	var data []im.AttributeData
+	err := dev.do(ctx, func(s OperationalSession) error {
+		var err error
+		if reader, ok := s.(FilteredReader); ok && 0 < len(filters) {
+			data, err = reader.ReadAttributeFiltered(ctx, path, filters)
+		} else {
+			data, err = s.ReadAttribute(ctx, path)
+		}
+		return err
+	})
+	return data, err
+}
+
+// WriteAttribute writes the TLV encoded value to the specified attribute.
+func (dev *OperationalDevice) WriteAttribute(ctx context.Context, path im.AttributePath, data []byte) error {
+	return dev.do(ctx, func(s OperationalSession) error {
+		return s.WriteAttribute(ctx, path, data)
+	})
+}
+
+// InvokeCommand invokes the command with the TLV encoded fields, and returns the TLV encoded response fields.
+func (dev *OperationalDevice) InvokeCommand(ctx context.Context, path im.CommandPath, fields []byte) ([]byte, error) {
+	var res []byte
+	err := dev.do(ctx, func(s OperationalSession) error {
+		var err error
+		res, err = s.InvokeCommand(ctx, path, fields)
+		return err
+	})
+	return res, err
+}
+
+// Subscribe subscribes the attributes, and calls the handler for each report.
+// The subscription ends with the session, and is not re-established by the handle unless it is
+// subscribed with SubscribeLive.
+func (dev *OperationalDevice) Subscribe(ctx context.Context, req im.SubscribeRequest, handler im.ReportHandler) (im.Subscription, error) {
+	var sub im.Subscription
+	err := dev.do(ctx, func(s OperationalSession) error {
+		var err error
+		sub, err = s.Subscribe(ctx, req, handler)
+		return err
+	})
+	return sub, err
+}
+
+// SubscribeShared subscribes the attributes like Subscribe, but coalesces the subscriptions of
+// the consumers of the node into a single device subscription whose reports are fanned out locally.
+func (dev *OperationalDevice) SubscribeShared(ctx context.Context, req im.SubscribeRequest, handler im.ReportHandler) (im.Subscription, error) {
+	return dev.mux.Subscribe(ctx, req, handler)
+}
+
+// Close closes the current session and cancels a pending establishment, and the handle can no longer be used.
+func (dev *OperationalDevice) Close() error {
+	dev.Lock()
+	defer dev.Unlock()
+	dev.closed = true
+	if dev.pending != nil {
+		dev.pending.cancel()
+	}
+	return dev.closeSession()
+}
+
+// do runs the operation on the current session. If the session is no longer usable or the node
+// does not respond, the operation is retried on a new session according to the reconnect policy.
+// When the node does not respond, its address is re-resolved before the retry since it may have changed.
+func (dev *OperationalDevice) do(ctx context.Context, op func(OperationalSession) error) error {
+	dev.Lock()
+	policy := dev.policy
+	stats := dev.stats
+	nodeID := dev.peer.NodeID
+	dev.Unlock()
+
+	var err error
+	tried := map[netip.AddrPort]bool{}
+	failover := false
+	for retry, attempt := 0, 0; ; attempt++ {
+		if 0 < retry && !failover {
+			if ctxErr := sleepContext(ctx, policy.Interval(retry)); ctxErr != nil {
+				return ctxErr
+			}
+			if errors.Is(err, session.ErrTimeout) {
+				dev.resolve(ctx)
+			}
+		}
+		failover = false
+		var s OperationalSession
+		s, err = dev.Session(ctx)
+		if err == nil {
+			if 0 < attempt {
+				stats.MessageRetransmitted(nodeID)
+				traceRetransmit(dev.Peer().Address, attempt)
+			} else {
+				stats.MessageSent(nodeID)
+			}
+			start := time.Now()
+			err = op(s)
+			// Operations which end without session failures are answered by the node.
+			if !isRetryable(err) && ctx.Err() == nil {
+				stats.MessageReceived(nodeID)
+				stats.RoundTripMeasured(nodeID, time.Since(start))
+				dev.addrs.Succeeded(dev.Peer().Address)
+			}
+			// The node not responding at the address is retried at once at the next address.
+			if isUnreachable(err) && ctx.Err() == nil {
+				failover = dev.failover(s, tried)
+			}
+			if isRetryable(err) && !failover {
+				dev.invalidateSession(s)
+			}
+		}
+		if !isRetryable(err) {
+			return err
+		}
+		if failover {
+			continue
+		}
+		if policy.MaxAttempts <= retry {
+			return err
+		}
+		retry++
+	}
+}
+
+// traceRetransmit passes the retried operation to the shared tracer and the shared recorder.
+// The exchange and the message counter of the retried operation belong to the session, so
+// they are not known here and are left zero.
+func traceRetransmit(addr netip.AddrPort, attempt int) {
+	trace.SharedTracer().TraceRetransmit(&trace.RetransmitEvent{
+		Time:       time.Now(),
+		Peer:       addr.String(),
+		ExchangeID: 0,
+		Counter:    0,
+		Attempt:    attempt,
+	})
+	metrics.SharedRecorder().MessageRetransmitted(im.ProtocolID)
+}
+
+// failover records the failure of the current address, and moves the specified session to the
+// most preferred address which is not tried yet. It returns false if all addresses have been tried.
+func (dev *OperationalDevice) failover(s OperationalSession, tried map[netip.AddrPort]bool) bool {
+	dev.Lock()
+	defer dev.Unlock()
+	tried[dev.peer.Address] = true
+	dev.addrs.Failed(dev.peer.Address)
+	for _, addr := range dev.addrs.Addresses() {
+		if tried[addr.Addr] {
+			continue
+		}
+		switch dev.session {
+		case s:
+			// The session is re-established at the address if it cannot move.
+			_ = dev.moveSession(addr.Addr)
+		case nil:
+			dev.peer.Address = addr.Addr
+		}
+		return true
+	}
+	return false
+}
+
+// resolve updates the node address with the resolver. The known address is kept if the node is not resolved.
+func (dev *OperationalDevice) resolve(ctx context.Context) {
+	dev.Lock()
+	resolver := dev.resolver
+	peer := dev.peer
+	dev.Unlock()
+
+	if addrsResolver, ok := resolver.(OperationalAddrsResolver); ok {
+		addrs, err := addrsResolver.ResolveOperationalAddrs(ctx, peer)
+		if err != nil || len(addrs) == 0 {
+			return
+		}
+		dev.addrs.Add(addrs...)
+		if best, ok := dev.addrs.Best(); ok {
+			// The stale session is dropped regardless of the result of closing it.
+			_ = dev.SetAddress(best)
+		}
+		return
+	}
+
+	addr, err := resolver.ResolveOperational(ctx, peer)
+	if err != nil || !addr.IsValid() {
+		return
+	}
+	// The stale session is dropped regardless of the result of closing it.
+	_ = dev.SetAddress(addr)
+}
+
+// moveSession moves the current session to the address, or closes it if it cannot move.
+func (dev *OperationalDevice) moveSession(addr netip.AddrPort) error {
+	dev.peer.Address = addr
+	if setter, ok := dev.session.(PeerAddressSetter); ok {
+		if err := setter.SetPeerAddress(addr); err == nil {
+			return nil
+		}
+	}
+	return dev.closeSession()
+}
+
+// invalidateSession drops the specified session if it is still the current one.
+func (dev *OperationalDevice) invalidateSession(s OperationalSession) {
+	dev.Lock()
+	defer dev.Unlock()
+	if dev.session == s {
+		// The lost session is dropped regardless of the result of closing it.
+		_ = dev.closeSession()
+	}
+}
+
+func (dev *OperationalDevice) closeSession() error {
+	if dev.session == nil {
+		return nil
+	}
+	err := dev.session.Close()
+	dev.session = nil
+	return err
+}
+
+func isRetryable(err error) bool {
+	return errors.Is(err, session.ErrExpired) || errors.Is(err, session.ErrClosed) || isUnreachable(err)
+}
+
+// isUnreachable returns true if the node is not reached at its current address.
+func isUnreachable(err error) bool {
+	var opErr *net.OpError
+	return errors.Is(err, session.ErrTimeout) || errors.As(err, &opErr)
+}
+
+// isHandshakeAttempted returns false if the handshake was not supported or was canceled by the caller,
+// which has not failed with the node.
+func isHandshakeAttempted(err error) bool {
+	return !errors.Is(err, ErrNotSupported) && !isContextError(err)
+}
+
+// isContextError returns true if the error is caused by the end of a context.
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
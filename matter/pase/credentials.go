@@ -0,0 +1,162 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pase
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/crypto"
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+	"github.com/cybergarage/go-matter/matter/storage"
+)
+
+// CredentialsNamespace is the storage namespace of PASE credentials.
+const CredentialsNamespace = "pase"
+
+const (
+	credentialsVerifierTag = iota + 1
+	credentialsSaltTag
+	credentialsIterationsTag
+)
+
+// Credentials represents the PASE credentials of a commissionee: the SPAKE2+ verifier
+// and the PBKDF parameters which the verifier was derived with. The passcode itself is
+// not kept, so production devices never need to store it, and commissioning does not
+// have to run the PBKDF again.
+type Credentials struct {
+	Verifier   *crypto.SPAKE2pVerifier
+	Salt       []byte
+	Iterations uint32
+}
+
+// NewCredentialsFromPasscode returns the credentials whose verifier is computed from the passcode.
+func NewCredentialsFromPasscode(passcode uint32, salt []byte, iterations uint32) (*Credentials, error) {
+	verifier, err := crypto.NewSPAKE2pVerifier(passcode, salt, int(iterations))
+	if err != nil {
+		return nil, fmt.Errorf("%w passcode verifier: %w", ErrInvalid, err)
+	}
+	return newCredentials(verifier, salt, iterations)
+}
+
+// NewCredentialsFromVerifier returns the credentials of an externally provisioned verifier,
+// which is the serialized w0 and L, with the salt and iterations used to compute it.
+func NewCredentialsFromVerifier(verifier []byte, salt []byte, iterations uint32) (*Credentials, error) {
+	v, err := crypto.NewSPAKE2pVerifierFromBytes(verifier)
+	if err != nil {
+		return nil, fmt.Errorf("%w passcode verifier: %w", ErrInvalid, err)
+	}
+	return newCredentials(v, salt, iterations)
+}
+
+func newCredentials(verifier *crypto.SPAKE2pVerifier, salt []byte, iterations uint32) (*Credentials, error) {
+	creds := &Credentials{
+		Verifier:   verifier,
+		Salt:       bytes.Clone(salt),
+		Iterations: iterations,
+	}
+	if err := creds.Validate(); err != nil {
+		return nil, err
+	}
+	return creds, nil
+}
+
+// NewCredentialsFromBytes returns the credentials decoded from the specified TLV encoding.
+func NewCredentialsFromBytes(b []byte) (*Credentials, error) {
+	dec := tlv.NewDecoder(b)
+	elem, err := dec.Next()
+	if err != nil {
+		return nil, err
+	}
+	if elem.Type() != tlv.Structure {
+		return nil, fmt.Errorf("%w credentials container: %s", ErrInvalid, elem.Type())
+	}
+
+	var verifier, salt []byte
+	var iterations uint64
+	for {
+		elem, err := dec.Next()
+		if err != nil {
+			return nil, err
+		}
+		if elem.IsEndOfContainer() {
+			break
+		}
+		switch elem.Tag().Number() {
+		case credentialsVerifierTag:
+			verifier, err = elem.Bytes()
+		case credentialsSaltTag:
+			salt, err = elem.Bytes()
+		case credentialsIterationsTag:
+			iterations, err = elem.Unsigned()
+			if err == nil && 0xFFFFFFFF < iterations {
+				err = fmt.Errorf("%w credentials iterations: %d", ErrInvalid, iterations)
+			}
+		default:
+			if elem.IsContainer() {
+				err = dec.Skip()
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewCredentialsFromVerifier(verifier, salt, uint32(iterations))
+}
+
+// LoadCredentials returns the credentials stored with the specified key, or storage.ErrNotFound.
+func LoadCredentials(store storage.Store, key string) (*Credentials, error) {
+	b, err := store.Get(CredentialsNamespace, key)
+	if err != nil {
+		return nil, err
+	}
+	return NewCredentialsFromBytes(b)
+}
+
+// Validate returns an error if the verifier is invalid or the PBKDF parameters are out of the specification ranges.
+func (creds *Credentials) Validate() error {
+	if creds.Verifier == nil {
+		return fmt.Errorf("%w credentials: no verifier", ErrInvalid)
+	}
+	if err := creds.Verifier.Validate(); err != nil {
+		return fmt.Errorf("%w passcode verifier: %w", ErrInvalid, err)
+	}
+	return DefaultPBKDFPolicy().Check(creds.PBKDFParams())
+}
+
+// PBKDFParams returns the PBKDF parameters which a responder sends with the credentials.
+func (creds *Credentials) PBKDFParams() *PBKDFParams {
+	return &PBKDFParams{
+		Iterations: creds.Iterations,
+		Salt:       creds.Salt,
+	}
+}
+
+// Bytes returns the TLV encoding of the credentials.
+func (creds *Credentials) Bytes() []byte {
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.NewAnonymousTag())
+	enc.PutOctetString(tlv.NewContextTag(credentialsVerifierTag), creds.Verifier.Bytes())
+	enc.PutOctetString(tlv.NewContextTag(credentialsSaltTag), creds.Salt)
+	enc.PutUnsigned(tlv.NewContextTag(credentialsIterationsTag), uint64(creds.Iterations))
+	enc.EndContainer()
+	return enc.Bytes()
+}
+
+// Save stores the credentials with the specified key.
+func (creds *Credentials) Save(store storage.Store, key string) error {
+	return store.Set(CredentialsNamespace, key, creds.Bytes())
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pase
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/storage"
+)
+
+const (
+	testPasscode   = 20202021
+	testSalt       = "SPAKE2P Key Salt"
+	testIterations = 1000
+	testVerifier   = "uWFwqugDNGiEck/po7KHwwMwwqZgN10XuyBajPGuyzUEV/iree4lOrao5GuwnlQ65CJzbeUB49s31EH+NEkg0JVI5MGCQGMMT/SRPFNRODm3wH/MBiehuFc6FJ/NH6Rmzw=="
+)
+
+func TestCredentials(t *testing.T) {
+	verifier, err := base64.StdEncoding.DecodeString(testVerifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+	provisioned, err := NewCredentialsFromVerifier(verifier, []byte(testSalt), testIterations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	computed, err := NewCredentialsFromPasscode(testPasscode, []byte(testSalt), testIterations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(computed.Bytes(), provisioned.Bytes()) {
+		t.Errorf("%X != %X", computed.Bytes(), provisioned.Bytes())
+	}
+
+	store := storage.NewMemoryStore()
+	if _, err := LoadCredentials(store, "default"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("%v is not %v", err, storage.ErrNotFound)
+	}
+	if err := provisioned.Save(store, "default"); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadCredentials(store, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(loaded.Verifier.Bytes(), verifier) || !bytes.Equal(loaded.Salt, []byte(testSalt)) || loaded.Iterations != testIterations {
+		t.Errorf("%X != %X", loaded.Bytes(), provisioned.Bytes())
+	}
+
+	if _, err := NewCredentialsFromVerifier(verifier[1:], []byte(testSalt), testIterations); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+	if _, err := NewCredentialsFromVerifier(verifier, nil, testIterations); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pase
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/internal/protocol"
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+	"github.com/cybergarage/go-matter/matter/session"
+)
+
+// Secure channel protocol opcodes of the PASE messages.
+const (
+	PBKDFParamRequestOpcode  protocol.Opcode = 0x20
+	PBKDFParamResponseOpcode protocol.Opcode = 0x21
+	Pake1Opcode              protocol.Opcode = 0x22
+	Pake2Opcode              protocol.Opcode = 0x23
+	Pake3Opcode              protocol.Opcode = 0x24
+)
+
+const (
+	// RandomSize is the size of the initiator and responder randoms.
+	RandomSize = 32
+	// DefaultPasscodeID is the passcode ID of the default commissioning passcode.
+	DefaultPasscodeID = 0
+)
+
+const (
+	paramRequestInitiatorRandomTag = iota + 1
+	paramRequestInitiatorSessionIDTag
+	paramRequestPasscodeIDTag
+	paramRequestHasPBKDFParamsTag
+	paramRequestSessionParamsTag
+)
+
+const (
+	paramResponseInitiatorRandomTag = iota + 1
+	paramResponseResponderRandomTag
+	paramResponseResponderSessionIDTag
+	paramResponsePBKDFParamsTag
+	paramResponseSessionParamsTag
+)
+
+// PBKDFParamRequest represents a PBKDFParamRequest message (pbkdfparamreq-struct).
+type PBKDFParamRequest struct {
+	InitiatorRandom    []byte
+	InitiatorSessionID message.SessionID
+	PasscodeID         uint16
+	HasPBKDFParams     bool
+	// SessionParams is optional, and omitted if nil.
+	SessionParams *session.Params
+}
+
+// PBKDFParamResponse represents a PBKDFParamResponse message (pbkdfparamresp-struct).
+type PBKDFParamResponse struct {
+	InitiatorRandom    []byte
+	ResponderRandom    []byte
+	ResponderSessionID message.SessionID
+	// PBKDFParams is omitted if nil, which is allowed only if the initiator has the parameters.
+	PBKDFParams *PBKDFParams
+	// SessionParams is optional, and omitted if nil.
+	SessionParams *session.Params
+}
+
+// Bytes returns the TLV encoding of the request. Each field is written once in tag order.
+func (req *PBKDFParamRequest) Bytes() []byte {
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.NewAnonymousTag())
+	enc.PutOctetString(tlv.NewContextTag(paramRequestInitiatorRandomTag), req.InitiatorRandom)
+	enc.PutUnsigned(tlv.NewContextTag(paramRequestInitiatorSessionIDTag), uint64(req.InitiatorSessionID))
+	enc.PutUnsigned(tlv.NewContextTag(paramRequestPasscodeIDTag), uint64(req.PasscodeID))
+	enc.PutBool(tlv.NewContextTag(paramRequestHasPBKDFParamsTag), req.HasPBKDFParams)
+	if req.SessionParams != nil {
+		req.SessionParams.Encode(enc, tlv.NewContextTag(paramRequestSessionParamsTag))
+	}
+	enc.EndContainer()
+	return enc.Bytes()
+}
+
+// NewPBKDFParamRequestFromBytes returns the request decoded from the specified TLV encoding.
+func NewPBKDFParamRequestFromBytes(b []byte) (*PBKDFParamRequest, error) {
+	req := &PBKDFParamRequest{
+		InitiatorRandom:    nil,
+		InitiatorSessionID: 0,
+		PasscodeID:         0,
+		HasPBKDFParams:     false,
+		SessionParams:      nil,
+	}
+	err := decodeStructure(b, "PBKDFParamRequest", func(dec *tlv.Decoder, elem *tlv.Element) error {
+		var err error
+		switch elem.Tag().Number() {
+		case paramRequestInitiatorRandomTag:
+			req.InitiatorRandom, err = decodeRandom(elem, "initiator random")
+		case paramRequestInitiatorSessionIDTag:
+			req.InitiatorSessionID, err = decodeSessionID(elem, "initiator session ID")
+		case paramRequestPasscodeIDTag:
+			var v uint64
+			v, err = decodeUnsigned(elem, "passcode ID", 0xFFFF)
+			req.PasscodeID = uint16(v)
+		case paramRequestHasPBKDFParamsTag:
+			req.HasPBKDFParams, err = elem.Bool()
+		case paramRequestSessionParamsTag:
+			req.SessionParams, err = session.NewParamsFromDecoder(dec, elem)
+		default:
+			if elem.IsContainer() {
+				err = dec.Skip()
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(req.InitiatorRandom) == 0 {
+		return nil, fmt.Errorf("%w PBKDFParamRequest: no initiator random", ErrInvalid)
+	}
+	return req, nil
+}
+
+// Bytes returns the TLV encoding of the response. Each field is written once in tag order.
+func (res *PBKDFParamResponse) Bytes() []byte {
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.NewAnonymousTag())
+	enc.PutOctetString(tlv.NewContextTag(paramResponseInitiatorRandomTag), res.InitiatorRandom)
+	enc.PutOctetString(tlv.NewContextTag(paramResponseResponderRandomTag), res.ResponderRandom)
+	enc.PutUnsigned(tlv.NewContextTag(paramResponseResponderSessionIDTag), uint64(res.ResponderSessionID))
+	if res.PBKDFParams != nil {
+		res.PBKDFParams.Encode(enc, tlv.NewContextTag(paramResponsePBKDFParamsTag))
+	}
+	if res.SessionParams != nil {
+		res.SessionParams.Encode(enc, tlv.NewContextTag(paramResponseSessionParamsTag))
+	}
+	enc.EndContainer()
+	return enc.Bytes()
+}
+
+// NewPBKDFParamResponseFromBytes returns the response decoded from the specified TLV encoding,
+// checking the PBKDF parameters with the policy.
+func NewPBKDFParamResponseFromBytes(b []byte, policy PBKDFPolicy) (*PBKDFParamResponse, error) {
+	res := &PBKDFParamResponse{
+		InitiatorRandom:    nil,
+		ResponderRandom:    nil,
+		ResponderSessionID: 0,
+		PBKDFParams:        nil,
+		SessionParams:      nil,
+	}
+	err := decodeStructure(b, "PBKDFParamResponse", func(dec *tlv.Decoder, elem *tlv.Element) error {
+		var err error
+		switch elem.Tag().Number() {
+		case paramResponseInitiatorRandomTag:
+			res.InitiatorRandom, err = decodeRandom(elem, "initiator random")
+		case paramResponseResponderRandomTag:
+			res.ResponderRandom, err = decodeRandom(elem, "responder random")
+		case paramResponseResponderSessionIDTag:
+			res.ResponderSessionID, err = decodeSessionID(elem, "responder session ID")
+		case paramResponsePBKDFParamsTag:
+			res.PBKDFParams, err = NewPBKDFParamsFromDecoder(dec, elem, policy)
+		case paramResponseSessionParamsTag:
+			res.SessionParams, err = session.NewParamsFromDecoder(dec, elem)
+		default:
+			if elem.IsContainer() {
+				err = dec.Skip()
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.InitiatorRandom) == 0 || len(res.ResponderRandom) == 0 {
+		return nil, fmt.Errorf("%w PBKDFParamResponse: no random", ErrInvalid)
+	}
+	return res, nil
+}
+
+// decodeStructure decodes an anonymous structure, calling the handler for each context-tagged member.
+func decodeStructure(b []byte, name string, handler func(*tlv.Decoder, *tlv.Element) error) error {
+	dec := tlv.NewDecoder(b)
+	elem, err := dec.Next()
+	if err != nil {
+		return err
+	}
+	if elem.Type() != tlv.Structure {
+		return fmt.Errorf("%w %s container: %s", ErrInvalid, name, elem.Type())
+	}
+	for {
+		elem, err := dec.Next()
+		if err != nil {
+			return err
+		}
+		if elem.IsEndOfContainer() {
+			return nil
+		}
+		if !elem.Tag().IsContext() {
+			return fmt.Errorf("%w %s tag: %s", ErrInvalid, name, elem.Tag())
+		}
+		if err := handler(dec, elem); err != nil {
+			return err
+		}
+	}
+}
+
+func decodeRandom(elem *tlv.Element, name string) ([]byte, error) {
+	b, err := elem.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != RandomSize {
+		return nil, fmt.Errorf("%w %s length: %d", ErrInvalid, name, len(b))
+	}
+	return bytes.Clone(b), nil
+}
+
+func decodeUnsigned(elem *tlv.Element, name string, max uint64) (uint64, error) {
+	v, err := elem.Unsigned()
+	if err != nil {
+		return 0, err
+	}
+	if max < v {
+		return 0, fmt.Errorf("%w %s: %d", ErrInvalid, name, v)
+	}
+	return v, nil
+}
+
+func decodeSessionID(elem *tlv.Element, name string) (message.SessionID, error) {
+	v, err := decodeUnsigned(elem, name, 0xFFFF)
+	return message.SessionID(v), err
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pase
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/session"
+)
+
+func testRandom(start byte) []byte {
+	b := make([]byte, RandomSize)
+	for n := range b {
+		b[n] = start + byte(n)
+	}
+	return b
+}
+
+func testSessionParams() *session.Params {
+	params := session.NewParams()
+	params.IdleInterval = 500 * time.Millisecond
+	params.ActiveInterval = 300 * time.Millisecond
+	return params
+}
+
+func TestPBKDFParamRequest(t *testing.T) {
+	// The golden vector is encoded by hand from pbkdfparamreq-struct.
+	expected := strings.Join([]string{
+		"15",
+		"3001" + "20" + hex.EncodeToString(testRandom(0x00)),
+		"2502" + "3412",
+		"2403" + "00",
+		"2804",
+		"3505" + "2501f401" + "25022c01" + "18",
+		"18",
+	}, "")
+
+	req := &PBKDFParamRequest{
+		InitiatorRandom:    testRandom(0x00),
+		InitiatorSessionID: 0x1234,
+		PasscodeID:         DefaultPasscodeID,
+		HasPBKDFParams:     false,
+		SessionParams:      testSessionParams(),
+	}
+	if hex.EncodeToString(req.Bytes()) != expected {
+		t.Errorf("%X != %s", req.Bytes(), expected)
+	}
+
+	decoded, err := NewPBKDFParamRequestFromBytes(req.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded.InitiatorRandom, req.InitiatorRandom) ||
+		decoded.InitiatorSessionID != req.InitiatorSessionID ||
+		decoded.PasscodeID != req.PasscodeID ||
+		decoded.HasPBKDFParams != req.HasPBKDFParams ||
+		decoded.SessionParams == nil || *decoded.SessionParams != *req.SessionParams {
+		t.Errorf("%v != %v", decoded, req)
+	}
+
+	req.SessionParams = nil
+	decoded, err = NewPBKDFParamRequestFromBytes(req.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.SessionParams != nil {
+		t.Errorf("%v", decoded.SessionParams)
+	}
+
+	req.InitiatorRandom = req.InitiatorRandom[1:]
+	if _, err := NewPBKDFParamRequestFromBytes(req.Bytes()); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+}
+
+func TestPBKDFParamResponse(t *testing.T) {
+	salt := bytes.Repeat([]byte{0x53}, PBKDFSaltSizeMin)
+	// The golden vector is encoded by hand from pbkdfparamresp-struct.
+	expected := strings.Join([]string{
+		"15",
+		"3001" + "20" + hex.EncodeToString(testRandom(0x00)),
+		"3002" + "20" + hex.EncodeToString(testRandom(0x20)),
+		"2503" + "7856",
+		"3504" + "2501e803" + "300210" + hex.EncodeToString(salt) + "18",
+		"3505" + "2501f401" + "25022c01" + "18",
+		"18",
+	}, "")
+
+	res := &PBKDFParamResponse{
+		InitiatorRandom:    testRandom(0x00),
+		ResponderRandom:    testRandom(0x20),
+		ResponderSessionID: 0x5678,
+		PBKDFParams: &PBKDFParams{
+			Iterations: 1000,
+			Salt:       salt,
+		},
+		SessionParams: testSessionParams(),
+	}
+	if hex.EncodeToString(res.Bytes()) != expected {
+		t.Errorf("%X != %s", res.Bytes(), expected)
+	}
+
+	decoded, err := NewPBKDFParamResponseFromBytes(res.Bytes(), DefaultPBKDFPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded.InitiatorRandom, res.InitiatorRandom) ||
+		!bytes.Equal(decoded.ResponderRandom, res.ResponderRandom) ||
+		decoded.ResponderSessionID != res.ResponderSessionID ||
+		decoded.PBKDFParams == nil ||
+		decoded.PBKDFParams.Iterations != res.PBKDFParams.Iterations ||
+		!bytes.Equal(decoded.PBKDFParams.Salt, res.PBKDFParams.Salt) ||
+		decoded.SessionParams == nil || *decoded.SessionParams != *res.SessionParams {
+		t.Errorf("%v != %v", decoded, res)
+	}
+
+	res.PBKDFParams.Iterations = PBKDFIterationsMin - 1
+	var rangeErr *PBKDFRangeError
+	if _, err := NewPBKDFParamResponseFromBytes(res.Bytes(), DefaultPBKDFPolicy()); !errors.As(err, &rangeErr) {
+		t.Errorf("%v is not a range error", err)
+	}
+
+	res.PBKDFParams = nil
+	decoded, err = NewPBKDFParamResponseFromBytes(res.Bytes(), DefaultPBKDFPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.PBKDFParams != nil {
+		t.Errorf("%v", decoded.PBKDFParams)
+	}
+}
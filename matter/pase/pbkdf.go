@@ -0,0 +1,206 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pase
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+// Ranges of the PBKDF parameters defined by the specification.
+const (
+	// PBKDFIterationsMin is CRYPTO_PBKDF_ITERATIONS_MIN.
+	PBKDFIterationsMin = 1000
+	// PBKDFIterationsMax is CRYPTO_PBKDF_ITERATIONS_MAX.
+	PBKDFIterationsMax = 100000
+	// PBKDFSaltSizeMin is the minimum salt size in bytes.
+	PBKDFSaltSizeMin = 16
+	// PBKDFSaltSizeMax is the maximum salt size in bytes.
+	PBKDFSaltSizeMax = 32
+	// DefaultPBKDFIterations is the default number of iterations which a responder generates.
+	DefaultPBKDFIterations = PBKDFIterationsMin
+	// DefaultPBKDFSaltSize is the default salt size which a responder generates.
+	DefaultPBKDFSaltSize = PBKDFSaltSizeMax
+)
+
+const (
+	pbkdfIterationsTag = iota + 1
+	pbkdfSaltTag
+)
+
+// PBKDFParams represents the Crypto_PBKDFParameterSet which a responder sends to the initiator.
+type PBKDFParams struct {
+	Iterations uint32
+	Salt       []byte
+}
+
+// PBKDFRangeError represents a PBKDF parameter which is out of the accepted range.
+type PBKDFRangeError struct {
+	Field string
+	Value uint64
+	Min   uint64
+	Max   uint64
+}
+
+// Error returns the error message.
+func (err *PBKDFRangeError) Error() string {
+	return fmt.Sprintf("%s PBKDF %s: %d (%d..%d)", ErrInvalid, err.Field, err.Value, err.Min, err.Max)
+}
+
+// Unwrap returns ErrInvalid.
+func (err *PBKDFRangeError) Unwrap() error {
+	return ErrInvalid
+}
+
+// PBKDFPolicy represents the accepted ranges of the PBKDF parameters, and the parameters
+// which a responder generates. The ranges can only be narrower than the specification ranges.
+type PBKDFPolicy struct {
+	MinIterations uint32
+	MaxIterations uint32
+	MinSaltSize   int
+	MaxSaltSize   int
+	Iterations    uint32
+	SaltSize      int
+}
+
+// DefaultPBKDFPolicy returns the policy which accepts the specification ranges.
+func DefaultPBKDFPolicy() PBKDFPolicy {
+	return PBKDFPolicy{
+		MinIterations: PBKDFIterationsMin,
+		MaxIterations: PBKDFIterationsMax,
+		MinSaltSize:   PBKDFSaltSizeMin,
+		MaxSaltSize:   PBKDFSaltSizeMax,
+		Iterations:    DefaultPBKDFIterations,
+		SaltSize:      DefaultPBKDFSaltSize,
+	}
+}
+
+// Validate returns an error if the ranges exceed the specification ranges or the
+// generated parameters are out of the ranges.
+func (policy PBKDFPolicy) Validate() error {
+	if policy.MinIterations < PBKDFIterationsMin || PBKDFIterationsMax < policy.MaxIterations || policy.MaxIterations < policy.MinIterations {
+		return fmt.Errorf("%w PBKDF policy iterations: %d..%d", ErrInvalid, policy.MinIterations, policy.MaxIterations)
+	}
+	if policy.MinSaltSize < PBKDFSaltSizeMin || PBKDFSaltSizeMax < policy.MaxSaltSize || policy.MaxSaltSize < policy.MinSaltSize {
+		return fmt.Errorf("%w PBKDF policy salt size: %d..%d", ErrInvalid, policy.MinSaltSize, policy.MaxSaltSize)
+	}
+	return policy.Check(&PBKDFParams{
+		Iterations: policy.Iterations,
+		Salt:       make([]byte, policy.SaltSize),
+	})
+}
+
+// Check returns a *PBKDFRangeError if the parameters are out of the accepted ranges.
+func (policy PBKDFPolicy) Check(params *PBKDFParams) error {
+	if params.Iterations < policy.MinIterations || policy.MaxIterations < params.Iterations {
+		return &PBKDFRangeError{
+			Field: "iterations",
+			Value: uint64(params.Iterations),
+			Min:   uint64(policy.MinIterations),
+			Max:   uint64(policy.MaxIterations),
+		}
+	}
+	if len(params.Salt) < policy.MinSaltSize || policy.MaxSaltSize < len(params.Salt) {
+		return &PBKDFRangeError{
+			Field: "salt size",
+			Value: uint64(len(params.Salt)),
+			Min:   uint64(policy.MinSaltSize),
+			Max:   uint64(policy.MaxSaltSize),
+		}
+	}
+	return nil
+}
+
+// NewParams returns new responder parameters with a random salt.
+func (policy PBKDFPolicy) NewParams() (*PBKDFParams, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	salt := make([]byte, policy.SaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return &PBKDFParams{
+		Iterations: policy.Iterations,
+		Salt:       salt,
+	}, nil
+}
+
+// Encode writes the parameters as a Crypto_PBKDFParameterSet with the specified tag.
+func (params *PBKDFParams) Encode(enc *tlv.Encoder, tag tlv.Tag) {
+	enc.StartStructure(tag)
+	enc.PutUnsigned(tlv.NewContextTag(pbkdfIterationsTag), uint64(params.Iterations))
+	enc.PutOctetString(tlv.NewContextTag(pbkdfSaltTag), params.Salt)
+	enc.EndContainer()
+}
+
+// NewPBKDFParamsFromDecoder returns new parameters decoded from the Crypto_PBKDFParameterSet
+// whose start element was just returned by the specified decoder, and checks them with the policy.
+func NewPBKDFParamsFromDecoder(dec *tlv.Decoder, start *tlv.Element, policy PBKDFPolicy) (*PBKDFParams, error) {
+	if start.Type() != tlv.Structure {
+		return nil, fmt.Errorf("%w PBKDF parameters container: %s", ErrInvalid, start.Type())
+	}
+
+	var iterations uint64
+	var salt []byte
+	hasIterations, hasSalt := false, false
+	for {
+		elem, err := dec.Next()
+		if err != nil {
+			return nil, err
+		}
+		if elem.IsEndOfContainer() {
+			break
+		}
+		switch elem.Tag().Number() {
+		case pbkdfIterationsTag:
+			iterations, err = elem.Unsigned()
+			if err == nil && 0xFFFFFFFF < iterations {
+				err = &PBKDFRangeError{
+					Field: "iterations",
+					Value: iterations,
+					Min:   uint64(policy.MinIterations),
+					Max:   uint64(policy.MaxIterations),
+				}
+			}
+			hasIterations = true
+		case pbkdfSaltTag:
+			salt, err = elem.Bytes()
+			hasSalt = true
+		default:
+			if elem.IsContainer() {
+				err = dec.Skip()
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !hasIterations || !hasSalt {
+		return nil, fmt.Errorf("%w PBKDF parameters: missing iterations or salt", ErrInvalid)
+	}
+
+	params := &PBKDFParams{
+		Iterations: uint32(iterations),
+		Salt:       bytes.Clone(salt),
+	}
+	if err := policy.Check(params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
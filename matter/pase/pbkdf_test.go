@@ -0,0 +1,149 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pase
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+func decodePBKDFParams(b []byte, policy PBKDFPolicy) (*PBKDFParams, error) {
+	dec := tlv.NewDecoder(b)
+	start, err := dec.Next()
+	if err != nil {
+		return nil, err
+	}
+	return NewPBKDFParamsFromDecoder(dec, start, policy)
+}
+
+func TestPBKDFParams(t *testing.T) {
+	tests := []struct {
+		name       string
+		iterations uint32
+		saltSize   int
+		field      string
+	}{
+		{"min", PBKDFIterationsMin, PBKDFSaltSizeMin, ""},
+		{"max", PBKDFIterationsMax, PBKDFSaltSizeMax, ""},
+		{"few iterations", PBKDFIterationsMin - 1, PBKDFSaltSizeMin, "iterations"},
+		{"many iterations", PBKDFIterationsMax + 1, PBKDFSaltSizeMin, "iterations"},
+		{"short salt", PBKDFIterationsMin, PBKDFSaltSizeMin - 1, "salt size"},
+		{"long salt", PBKDFIterationsMin, PBKDFSaltSizeMax + 1, "salt size"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params := &PBKDFParams{
+				Iterations: test.iterations,
+				Salt:       bytes.Repeat([]byte{0x53}, test.saltSize),
+			}
+			enc := tlv.NewEncoder()
+			params.Encode(enc, tlv.NewContextTag(4))
+			decoded, err := decodePBKDFParams(enc.Bytes(), DefaultPBKDFPolicy())
+			if len(test.field) == 0 {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if decoded.Iterations != params.Iterations || !bytes.Equal(decoded.Salt, params.Salt) {
+					t.Errorf("%v != %v", decoded, params)
+				}
+				return
+			}
+			var rangeErr *PBKDFRangeError
+			if !errors.As(err, &rangeErr) || rangeErr.Field != test.field {
+				t.Fatalf("%v is not a %s range error", err, test.field)
+			}
+			if !errors.Is(err, ErrInvalid) {
+				t.Errorf("%v is not %v", err, ErrInvalid)
+			}
+		})
+	}
+}
+
+func TestPBKDFPolicy(t *testing.T) {
+	policy := DefaultPBKDFPolicy()
+	params, err := policy.NewParams()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params.Iterations != DefaultPBKDFIterations || len(params.Salt) != DefaultPBKDFSaltSize {
+		t.Errorf("%d %d", params.Iterations, len(params.Salt))
+	}
+
+	policy.MinIterations = 10000
+	if err := policy.Validate(); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+	policy.Iterations = 10000
+	if err := policy.Validate(); err != nil {
+		t.Error(err)
+	}
+	if err := policy.Check(&PBKDFParams{Iterations: 1000, Salt: make([]byte, 16)}); err == nil {
+		t.Errorf("iterations below the policy are accepted")
+	}
+
+	policy.MaxIterations = PBKDFIterationsMax + 1
+	if _, err := policy.NewParams(); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+}
+
+func TestPBKDFParamsIterationsWidth(t *testing.T) {
+	salt := bytes.Repeat([]byte{0x53}, PBKDFSaltSizeMin)
+	tests := []struct {
+		name       string
+		iterations []byte
+		expected   uint32
+	}{
+		{"2 bytes", []byte{0x25, 0x01, 0xE8, 0x03}, 1000},
+		{"4 bytes", []byte{0x26, 0x01, 0xE8, 0x03, 0x00, 0x00}, 1000},
+		{"8 bytes", []byte{0x27, 0x01, 0xE8, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, 1000},
+		{"32-bit", []byte{0x26, 0x01, 0xA0, 0x86, 0x01, 0x00}, 100000},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := append([]byte{0x15}, test.iterations...)
+			b = append(b, 0x30, 0x02, byte(len(salt)))
+			b = append(b, salt...)
+			b = append(b, 0x18)
+			params, err := decodePBKDFParams(b, DefaultPBKDFPolicy())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if params.Iterations != test.expected {
+				t.Errorf("%d != %d", params.Iterations, test.expected)
+			}
+
+			// The encoder always uses the minimal width.
+			enc := tlv.NewEncoder()
+			params.Encode(enc, tlv.NewAnonymousTag())
+			minimal := tlv.NewEncoder()
+			minimal.PutUnsigned(tlv.NewContextTag(pbkdfIterationsTag), uint64(test.expected))
+			if !bytes.Equal(enc.Bytes()[1:1+len(minimal.Bytes())], minimal.Bytes()) {
+				t.Errorf("%X does not start with %X", enc.Bytes()[1:], minimal.Bytes())
+			}
+		})
+	}
+
+	b := []byte{0x15, 0x27, 0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x30, 0x02, 0x10}
+	b = append(b, salt...)
+	b = append(b, 0x18)
+	var rangeErr *PBKDFRangeError
+	if _, err := decodePBKDFParams(b, DefaultPBKDFPolicy()); !errors.As(err, &rangeErr) {
+		t.Errorf("%v is not a range error", err)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payload
+
+import (
+	"strings"
+)
+
+// 5.1.1.4. Discovery Capabilities Bitmask
+// DiscoveryCapabilities represents a discovery capabilities bitmask.
+type DiscoveryCapabilities uint8
+
+const (
+	DiscoveryCapabilitySoftAP    DiscoveryCapabilities = 0x01
+	DiscoveryCapabilityBLE       DiscoveryCapabilities = 0x02
+	DiscoveryCapabilityOnNetwork DiscoveryCapabilities = 0x04
+	DiscoveryCapabilityWiFiPAF   DiscoveryCapabilities = 0x08
+	discoveryCapabilitiesMask                          = DiscoveryCapabilitySoftAP | DiscoveryCapabilityBLE | DiscoveryCapabilityOnNetwork | DiscoveryCapabilityWiFiPAF
+)
+
+// Transport represents a commissioning transport.
+type Transport uint8
+
+const (
+	TransportOnNetwork Transport = iota
+	TransportBLE
+	TransportWiFiPAF
+	TransportSoftAP
+)
+
+// HasSoftAP returns true if the device supports Soft-AP discovery.
+func (caps DiscoveryCapabilities) HasSoftAP() bool {
+	return (caps & DiscoveryCapabilitySoftAP) != 0
+}
+
+// HasBLE returns true if the device supports BLE discovery.
+func (caps DiscoveryCapabilities) HasBLE() bool {
+	return (caps & DiscoveryCapabilityBLE) != 0
+}
+
+// HasOnNetwork returns true if the device supports on-network discovery.
+func (caps DiscoveryCapabilities) HasOnNetwork() bool {
+	return (caps & DiscoveryCapabilityOnNetwork) != 0
+}
+
+// HasWiFiPAF returns true if the device supports Wi-Fi Public Action Frame discovery.
+func (caps DiscoveryCapabilities) HasWiFiPAF() bool {
+	return (caps & DiscoveryCapabilityWiFiPAF) != 0
+}
+
+// IsValid returns true if at least one capability is set and no reserved bit is set.
+func (caps DiscoveryCapabilities) IsValid() bool {
+	return caps != 0 && (caps & ^discoveryCapabilitiesMask) == 0
+}
+
+// Transports returns the supported commissioning transports in the order in which
+// a commissioner should try them. Already-connected networks are preferred over
+// transports which need an additional radio or a network switch.
+func (caps DiscoveryCapabilities) Transports() []Transport {
+	transports := []Transport{}
+	if caps.HasOnNetwork() {
+		transports = append(transports, TransportOnNetwork)
+	}
+	if caps.HasBLE() {
+		transports = append(transports, TransportBLE)
+	}
+	if caps.HasWiFiPAF() {
+		transports = append(transports, TransportWiFiPAF)
+	}
+	if caps.HasSoftAP() {
+		transports = append(transports, TransportSoftAP)
+	}
+	return transports
+}
+
+// String returns the string representation.
+func (caps DiscoveryCapabilities) String() string {
+	names := []string{}
+	for _, transport := range caps.Transports() {
+		names = append(names, transport.String())
+	}
+	return strings.Join(names, "|")
+}
+
+// String returns the string representation.
+func (transport Transport) String() string {
+	switch transport {
+	case TransportOnNetwork:
+		return "OnNetwork"
+	case TransportBLE:
+		return "BLE"
+	case TransportWiFiPAF:
+		return "WiFiPAF"
+	case TransportSoftAP:
+		return "SoftAP"
+	}
+	return "Unknown"
+}
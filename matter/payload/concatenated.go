@@ -0,0 +1,57 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payload
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 5.1.3. QR Code (concatenation of multiple payloads)
+// NewOnboardingPayloadsFromQRCode returns onboarding payloads from the specified QR code.
+// The code may be a single payload or a concatenation of several payloads such as "MT:...*...".
+func NewOnboardingPayloadsFromQRCode(code string) ([]*OnboardingPayload, error) {
+	if !strings.HasPrefix(code, QRCodePrefix) {
+		return nil, fmt.Errorf("%w QR code prefix (%s)", ErrInvalid, code)
+	}
+	payloads := []*OnboardingPayload{}
+	for _, part := range strings.Split(code[len(QRCodePrefix):], QRCodeSeparator) {
+		if len(part) == 0 {
+			return nil, fmt.Errorf("%w empty payload in QR code (%s)", ErrInvalid, code)
+		}
+		payload, err := NewOnboardingPayloadFromQRCode(QRCodePrefix + part)
+		if err != nil {
+			return nil, err
+		}
+		payloads = append(payloads, payload)
+	}
+	return payloads, nil
+}
+
+// ConcatenatedQRCode returns the concatenated QR code string of the specified payloads.
+func ConcatenatedQRCode(payloads ...*OnboardingPayload) (string, error) {
+	if len(payloads) == 0 {
+		return "", fmt.Errorf("%w no payloads for QR code", ErrInvalid)
+	}
+	parts := make([]string, len(payloads))
+	for n, payload := range payloads {
+		code, err := payload.QRCode()
+		if err != nil {
+			return "", err
+		}
+		parts[n] = strings.TrimPrefix(code, QRCodePrefix)
+	}
+	return QRCodePrefix + strings.Join(parts, QRCodeSeparator), nil
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payload
+
+import (
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// 5.1.3. QR Code.
+const (
+	QRCodePrefix = "MT:"
+	// QRCodeSeparator is the delimiter between payloads of a concatenated QR code.
+	QRCodeSeparator = "*"
+)
+
+// 5.1.3. QR Code
+// Table 38. Packed Binary Data Structure for Onboarding Payload.
+const (
+	qrVersionBits               = 3
+	qrVendorIDBits              = 16
+	qrProductIDBits             = 16
+	qrCommissioningFlowBits     = 2
+	qrDiscoveryCapabilitiesBits = 8
+	qrDiscriminatorBits         = 12
+	qrPasscodeBits              = 27
+	qrPaddingBits               = 4
+	qrPayloadBits               = 88
+	qrPayloadBytes              = qrPayloadBits / 8
+)
+
+// 5.1.1.6. Discriminator value.
+const (
+	DiscriminatorMax      = types.DiscriminatorMax
+	ShortDiscriminatorMax = types.ShortDiscriminatorMax
+)
+
+// 5.1.1.1. Version.
+const (
+	Version = 0
+)
+
+// 5.1.1.3. Custom Flow
+// CommissioningFlow represents a commissioning flow.
+type CommissioningFlow uint8
+
+const (
+	// StandardCommissioningFlow represents the standard commissioning flow.
+	StandardCommissioningFlow CommissioningFlow = 0
+	// UserIntentCommissioningFlow represents the user-intent commissioning flow.
+	UserIntentCommissioningFlow CommissioningFlow = 1
+	// CustomCommissioningFlow represents the custom commissioning flow.
+	CustomCommissioningFlow CommissioningFlow = 2
+)
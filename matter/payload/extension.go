@@ -0,0 +1,195 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payload
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+// 5.1.5. TLV Data
+// Table 40. Matter-common reserved tags.
+const (
+	SerialNumberTag         = 0x00
+	VendorSpecificTagMin    = 0x80
+	VendorSpecificTagMax    = 0xFF
+	matterCommonTagMaxKnown = SerialNumberTag
+)
+
+// ExtensionData represents an optional TLV data element of a QR code payload.
+type ExtensionData struct {
+	tag   uint8
+	value any
+}
+
+// NewExtensionData returns a new extension data element with the specified context tag and value.
+// The value must be a string, a []byte, a signed integer or an unsigned integer.
+func NewExtensionData(tag uint8, v any) (*ExtensionData, error) {
+	var value any
+	switch v := v.(type) {
+	case string, []byte, int64, uint64:
+		value = v
+	case int:
+		value = int64(v)
+	case int8:
+		value = int64(v)
+	case int16:
+		value = int64(v)
+	case int32:
+		value = int64(v)
+	case uint:
+		value = uint64(v)
+	case uint8:
+		value = uint64(v)
+	case uint16:
+		value = uint64(v)
+	case uint32:
+		value = uint64(v)
+	default:
+		return nil, fmt.Errorf("%w extension data type (%T)", ErrNotSupported, v)
+	}
+	return &ExtensionData{
+		tag:   tag,
+		value: value,
+	}, nil
+}
+
+// Tag returns the context tag number.
+func (data *ExtensionData) Tag() uint8 {
+	return data.tag
+}
+
+// IsVendorSpecific returns true if the element has a vendor-specific tag.
+func (data *ExtensionData) IsVendorSpecific() bool {
+	return VendorSpecificTagMin <= data.tag
+}
+
+// Value returns the value which is a string, a []byte, an int64 or an uint64.
+func (data *ExtensionData) Value() any {
+	return data.value
+}
+
+// String returns the string representation of the value.
+func (data *ExtensionData) String() string {
+	switch v := data.value.(type) {
+	case string:
+		return v
+	case []byte:
+		return fmt.Sprintf("%X", v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	}
+	return ""
+}
+
+// Equal returns true if the element is equal to the specified element.
+func (data *ExtensionData) Equal(other *ExtensionData) bool {
+	if data.tag != other.tag {
+		return false
+	}
+	if b, ok := data.value.([]byte); ok {
+		ob, ok := other.value.([]byte)
+		return ok && bytes.Equal(b, ob)
+	}
+	return data.value == other.value
+}
+
+func (data *ExtensionData) validate() error {
+	if matterCommonTagMaxKnown < data.tag && data.tag < VendorSpecificTagMin {
+		return fmt.Errorf("%w reserved extension tag (0x%02X)", ErrInvalid, data.tag)
+	}
+	if data.tag == SerialNumberTag {
+		switch data.value.(type) {
+		case string, uint64:
+		default:
+			return fmt.Errorf("%w serial number type (%T)", ErrInvalid, data.value)
+		}
+	}
+	return nil
+}
+
+// encodeExtensionData encodes the specified elements as an anonymous structure.
+func encodeExtensionData(elems []*ExtensionData) []byte {
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.NewAnonymousTag())
+	for _, elem := range elems {
+		tag := tlv.NewContextTag(elem.tag)
+		switch v := elem.value.(type) {
+		case string:
+			enc.PutUTF8String(tag, v)
+		case []byte:
+			enc.PutOctetString(tag, v)
+		case int64:
+			enc.PutSigned(tag, v)
+		case uint64:
+			enc.PutUnsigned(tag, v)
+		}
+	}
+	enc.EndContainer()
+	return enc.Bytes()
+}
+
+// decodeExtensionData decodes the anonymous structure of the TLV data section.
+func decodeExtensionData(b []byte) ([]*ExtensionData, error) {
+	dec := tlv.NewDecoder(b)
+	elem, err := dec.Next()
+	if err != nil {
+		return nil, fmt.Errorf("%w QR code TLV data : %w", ErrInvalid, err)
+	}
+	if elem.Type() != tlv.Structure || !elem.Tag().IsAnonymous() {
+		return nil, fmt.Errorf("%w QR code TLV data container (%s)", ErrInvalid, elem.Type())
+	}
+
+	elems := []*ExtensionData{}
+	seen := map[uint8]bool{}
+	for {
+		elem, err := dec.Next()
+		if err != nil {
+			return nil, fmt.Errorf("%w QR code TLV data : %w", ErrInvalid, err)
+		}
+		if elem.IsEndOfContainer() {
+			break
+		}
+		if !elem.Tag().IsContext() {
+			return nil, fmt.Errorf("%w QR code TLV data tag (%s)", ErrInvalid, elem.Tag())
+		}
+		if elem.IsContainer() {
+			return nil, fmt.Errorf("QR code TLV data type (%s) is %w", elem.Type(), ErrNotSupported)
+		}
+		data, err := NewExtensionData(uint8(elem.Tag().Number()), elem.Value())
+		if err != nil {
+			return nil, err
+		}
+		if err := data.validate(); err != nil {
+			return nil, err
+		}
+		if seen[data.tag] {
+			return nil, fmt.Errorf("%w duplicate extension tag (0x%02X)", ErrInvalid, data.tag)
+		}
+		seen[data.tag] = true
+		elems = append(elems, data)
+	}
+
+	if dec.More() {
+		return nil, fmt.Errorf("%w QR code TLV data trailing bytes (%d)", ErrInvalid, len(b)-dec.Offset())
+	}
+
+	return elems, nil
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build qrcode
+
+package payload
+
+import (
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cybergarage/go-matter/matter/payload/qrcode"
+)
+
+const (
+	// QRCodeLevel is the error correction level of the rendered QR codes.
+	QRCodeLevel = qrcode.LevelMedium
+	// QRCodeScale is the default number of pixels of each module of the rendered images.
+	QRCodeScale = 8
+)
+
+// QRCodeSymbol returns the QR code symbol of the payload.
+func (payload *OnboardingPayload) QRCodeSymbol() (*qrcode.Code, error) {
+	code, err := payload.QRCode()
+	if err != nil {
+		return nil, err
+	}
+	return qrcode.Encode(code, QRCodeLevel)
+}
+
+// WriteQRCodePNG writes the PNG image of the QR code of the payload, where each module is the specified number of pixels square.
+func (payload *OnboardingPayload) WriteQRCodePNG(w io.Writer, scale int) error {
+	code, err := payload.QRCodeSymbol()
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, code.Image(scale))
+}
+
+// QRCodeTerminal returns the QR code of the payload drawn with block characters for terminals. The light
+// modules are drawn if inverted is true, which is for terminals with a dark background.
+func (payload *OnboardingPayload) QRCodeTerminal(inverted bool) (string, error) {
+	code, err := payload.QRCodeSymbol()
+	if err != nil {
+		return "", err
+	}
+	return code.Terminal(inverted), nil
+}
+
+// ReadQRCodeImage returns the MT: payload string of the QR code in the image. The image must show
+// the code upright on a light background, such as a screenshot or a scan of a label.
+func ReadQRCodeImage(img image.Image) (string, error) {
+	code, err := qrcode.Decode(img)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(code, QRCodePrefix) {
+		return "", fmt.Errorf("%w QR code : %q has no %s prefix", ErrInvalid, code, QRCodePrefix)
+	}
+	return code, nil
+}
+
+// ReadQRCodeImageFile returns the MT: payload string of the QR code in the PNG, JPEG or GIF image file.
+func ReadQRCodeImageFile(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return "", err
+	}
+	return ReadQRCodeImage(img)
+}
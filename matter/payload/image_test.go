@@ -0,0 +1,59 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build qrcode
+
+package payload
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestQRCodeImage(t *testing.T) {
+	payload, err := NewOnboardingPayloadFromString("MT:Y.K9042C00KA0648G00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := filepath.Join(t.TempDir(), "qrcode.png")
+	var buf bytes.Buffer
+	if err := payload.WriteQRCodePNG(&buf, QRCodeScale); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	code, err := ReadQRCodeImageFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scanned, err := NewOnboardingPayloadFromString(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !scanned.Equal(payload) {
+		t.Errorf("%s != %s", scanned, payload)
+	}
+
+	terminal, err := payload.QRCodeTerminal(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(terminal, "█") {
+		t.Error(terminal)
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payload
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cybergarage/go-matter/matter/encoding/verhoeff"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// 5.1.4.1. Manual Pairing Code
+// Table 39. Manual Pairing Code Elements.
+const (
+	manualChunk1Digits                = 1
+	manualChunk2Digits                = 5
+	manualChunk3Digits                = 4
+	manualVendorIDDigits              = 5
+	manualProductIDDigits             = 5
+	manualShortCodeDigits             = manualChunk1Digits + manualChunk2Digits + manualChunk3Digits + 1
+	manualLongCodeDigits              = manualShortCodeDigits + manualVendorIDDigits + manualProductIDDigits
+	manualChunk1DiscriminatorMsbsBits = 2
+	manualChunk1VidPidPresentBitPos   = 2
+	manualChunk2DiscriminatorLsbsBits = 2
+	manualChunk2DiscriminatorLsbsPos  = 14
+	manualChunk2PasscodeLsbsBits      = 14
+	manualChunk3PasscodeMsbsBits      = 13
+)
+
+// PairingCodeFields represents the fields encoded in a manual pairing code.
+type PairingCodeFields struct {
+	// ShortDiscriminator is the upper 4 bits of the 12-bit discriminator.
+	ShortDiscriminator uint8
+	// Passcode is the 27-bit passcode.
+	Passcode uint32
+	// VIDPIDPresent specifies whether the vendor and product IDs are encoded as the 21-digit code.
+	VIDPIDPresent bool
+	// VendorID is encoded only if VIDPIDPresent is true.
+	VendorID types.VendorID
+	// ProductID is encoded only if VIDPIDPresent is true.
+	ProductID types.ProductID
+}
+
+// EncodePairingCode returns the manual pairing code string of the specified fields.
+// The 11-digit code is returned for the standard commissioning flow, and the 21-digit code
+// including the vendor and product IDs is returned if VIDPIDPresent is set.
+func EncodePairingCode(fields PairingCodeFields) (string, error) {
+	if ShortDiscriminatorMax < fields.ShortDiscriminator {
+		return "", fmt.Errorf("%w short discriminator (%d)", ErrInvalid, fields.ShortDiscriminator)
+	}
+	if err := validatePasscode(fields.Passcode); err != nil {
+		return "", err
+	}
+
+	discriminator := uint32(fields.ShortDiscriminator)
+	passcode := fields.Passcode
+
+	vidPidPresent := uint32(0)
+	if fields.VIDPIDPresent {
+		vidPidPresent = 1
+	}
+
+	chunk1 := (vidPidPresent << manualChunk1VidPidPresentBitPos) |
+		((discriminator >> manualChunk2DiscriminatorLsbsBits) & ((1 << manualChunk1DiscriminatorMsbsBits) - 1))
+	chunk2 := ((discriminator & ((1 << manualChunk2DiscriminatorLsbsBits) - 1)) << manualChunk2DiscriminatorLsbsPos) |
+		(passcode & ((1 << manualChunk2PasscodeLsbsBits) - 1))
+	chunk3 := (passcode >> manualChunk2PasscodeLsbsBits) & ((1 << manualChunk3PasscodeMsbsBits) - 1)
+
+	code := fmt.Sprintf("%01d%05d%04d", chunk1, chunk2, chunk3)
+	if fields.VIDPIDPresent {
+		code += fmt.Sprintf("%05d%05d", fields.VendorID, fields.ProductID)
+	}
+
+	return verhoeff.Append(code)
+}
+
+// DecodePairingCode returns the fields of the specified manual pairing code.
+// Dashes and spaces in the code are ignored.
+func DecodePairingCode(code string) (PairingCodeFields, error) {
+	fields := PairingCodeFields{
+		ShortDiscriminator: 0,
+		Passcode:           0,
+		VIDPIDPresent:      false,
+		VendorID:           0,
+		ProductID:          0,
+	}
+
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+
+	if len(code) != manualShortCodeDigits && len(code) != manualLongCodeDigits {
+		return fields, fmt.Errorf("%w manual pairing code length (%d)", ErrInvalid, len(code))
+	}
+	if !verhoeff.Validate(code) {
+		return fields, fmt.Errorf("%w manual pairing code check digit (%s)", ErrInvalid, code)
+	}
+
+	offset := 0
+	readChunk := func(digits int) (uint32, error) {
+		v, err := strconv.ParseUint(code[offset:offset+digits], 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("%w manual pairing code (%s)", ErrInvalid, code)
+		}
+		offset += digits
+		return uint32(v), nil
+	}
+
+	chunk1, err := readChunk(manualChunk1Digits)
+	if err != nil {
+		return fields, err
+	}
+	chunk2, err := readChunk(manualChunk2Digits)
+	if err != nil {
+		return fields, err
+	}
+	chunk3, err := readChunk(manualChunk3Digits)
+	if err != nil {
+		return fields, err
+	}
+
+	// Digits 8 and 9 are reserved for future versions of the manual pairing code.
+	if (1 << (manualChunk1VidPidPresentBitPos + 1)) <= chunk1 {
+		return fields, fmt.Errorf("%w manual pairing code version (%d)", ErrNotSupported, chunk1)
+	}
+
+	fields.VIDPIDPresent = ((chunk1 >> manualChunk1VidPidPresentBitPos) & 0x01) != 0
+	if fields.VIDPIDPresent != (len(code) == manualLongCodeDigits) {
+		return fields, fmt.Errorf("%w manual pairing code length (%d)", ErrInvalid, len(code))
+	}
+
+	discriminator := ((chunk1 & ((1 << manualChunk1DiscriminatorMsbsBits) - 1)) << manualChunk2DiscriminatorLsbsBits) |
+		((chunk2 >> manualChunk2DiscriminatorLsbsPos) & ((1 << manualChunk2DiscriminatorLsbsBits) - 1))
+	fields.ShortDiscriminator = uint8(discriminator)
+
+	fields.Passcode = (chunk2 & ((1 << manualChunk2PasscodeLsbsBits) - 1)) |
+		((chunk3 & ((1 << manualChunk3PasscodeMsbsBits) - 1)) << manualChunk2PasscodeLsbsBits)
+
+	if fields.VIDPIDPresent {
+		vid, err := readChunk(manualVendorIDDigits)
+		if err != nil {
+			return fields, err
+		}
+		pid, err := readChunk(manualProductIDDigits)
+		if err != nil {
+			return fields, err
+		}
+		if 0xFFFF < vid || 0xFFFF < pid {
+			return fields, fmt.Errorf("%w manual pairing code vendor/product ID (%d/%d)", ErrInvalid, vid, pid)
+		}
+		fields.VendorID = types.VendorID(vid)
+		fields.ProductID = types.ProductID(pid)
+	}
+
+	if err := validatePasscode(fields.Passcode); err != nil {
+		return fields, err
+	}
+
+	return fields, nil
+}
+
+// encodeManualPairingCode returns the manual pairing code string of the specified onboarding payload.
+// The vendor and product IDs are included for the user-intent and custom commissioning flows.
+func encodeManualPairingCode(payload *OnboardingPayload) (string, error) {
+	return EncodePairingCode(PairingCodeFields{
+		ShortDiscriminator: payload.ShortDiscriminator(),
+		Passcode:           payload.passcode,
+		VIDPIDPresent:      payload.commissioningFlow != StandardCommissioningFlow,
+		VendorID:           payload.vendorID,
+		ProductID:          payload.productID,
+	})
+}
+
+// NewOnboardingPayloadFromManualPairingCode returns a new onboarding payload from the specified manual pairing code.
+// Dashes and spaces in the code are ignored. The returned payload has only a short discriminator,
+// and the custom commissioning flow is assumed if the code includes the vendor and product IDs.
+func NewOnboardingPayloadFromManualPairingCode(code string) (*OnboardingPayload, error) {
+	fields, err := DecodePairingCode(code)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := newOnboardingPayload()
+	payload.discoveryCapabilities = 0
+	payload.discriminator = types.NewShortDiscriminator(fields.ShortDiscriminator)
+	payload.passcode = fields.Passcode
+	if fields.VIDPIDPresent {
+		payload.commissioningFlow = CustomCommissioningFlow
+		payload.vendorID = fields.VendorID
+		payload.productID = fields.ProductID
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payload
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPairingCode(t *testing.T) {
+	tests := []struct {
+		fields   PairingCodeFields
+		expected string
+	}{
+		// connectedhomeip: TestManualCode.cpp
+		{PairingCodeFields{ShortDiscriminator: 10, Passcode: 12345679, VIDPIDPresent: false, VendorID: 0, ProductID: 0}, "24129507533"},
+		{PairingCodeFields{ShortDiscriminator: 10, Passcode: 12345679, VIDPIDPresent: true, VendorID: 1, ProductID: 1}, "641295075300001000017"},
+		// chip-tool: chip-all-clusters-app defaults
+		{PairingCodeFields{ShortDiscriminator: 15, Passcode: 20202021, VIDPIDPresent: false, VendorID: 0, ProductID: 0}, "34970112332"},
+		{PairingCodeFields{ShortDiscriminator: 15, Passcode: 20202021, VIDPIDPresent: true, VendorID: 0xFFF1, ProductID: 0x8000}, "749701123365521327687"},
+		{PairingCodeFields{ShortDiscriminator: 0, Passcode: PasscodeMin, VIDPIDPresent: false, VendorID: 0, ProductID: 0}, "00000100007"},
+		{PairingCodeFields{ShortDiscriminator: 15, Passcode: PasscodeMax, VIDPIDPresent: true, VendorID: 0xFFFF, ProductID: 0xFFFF}, "757598610365535655351"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			code, err := EncodePairingCode(test.fields)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if code != test.expected {
+				t.Errorf("%s != %s", code, test.expected)
+			}
+			fields, err := DecodePairingCode(code)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if fields != test.fields {
+				t.Errorf("%v != %v", fields, test.fields)
+			}
+		})
+	}
+}
+
+func TestCustomFlowPairingCode(t *testing.T) {
+	for _, flow := range []CommissioningFlow{UserIntentCommissioningFlow, CustomCommissioningFlow} {
+		payload, err := NewOnboardingPayload(
+			WithVendorID(0xFFF1),
+			WithProductID(0x8000),
+			WithCommissioningFlow(flow),
+			WithDiscriminator(3840),
+			WithPasscode(20202021),
+		)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		code, err := payload.ManualPairingCode()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != "749701123365521327687" {
+			t.Errorf("%s != %s", code, "749701123365521327687")
+		}
+		decoded, err := NewOnboardingPayloadFromManualPairingCode("7497-011-2336-55213-27687")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if decoded.VendorID() != payload.VendorID() || decoded.ProductID() != payload.ProductID() {
+			t.Errorf("%s != %s", decoded, payload)
+		}
+	}
+}
+
+func TestInvalidPairingCode(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected error
+	}{
+		{"34970112331", ErrInvalid},
+		{"349701123365521327680", ErrInvalid},
+		{"749701123", ErrInvalid},
+		{"74970112334", ErrInvalid},
+		{"34970112332655213276", ErrInvalid},
+		{"84970112331", ErrNotSupported},
+	}
+
+	for _, test := range tests {
+		t.Run(test.code, func(t *testing.T) {
+			_, err := DecodePairingCode(test.code)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("%v != %v", err, test.expected)
+			}
+		})
+	}
+
+	_, err := EncodePairingCode(PairingCodeFields{ShortDiscriminator: 16, Passcode: 20202021, VIDPIDPresent: false, VendorID: 0, ProductID: 0})
+	if !errors.Is(err, ErrInvalid) {
+		t.Errorf("short discriminator (%d) is accepted", 16)
+	}
+}
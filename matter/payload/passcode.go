@@ -0,0 +1,78 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payload
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+)
+
+// 5.1.1.6. Passcode
+// The passcode SHALL be restricted to the values 0x0000001 to 0x5F5E0FE (00000001 to 99999998 in decimal).
+const (
+	PasscodeMin = 0x0000001
+	PasscodeMax = 0x5F5E0FE
+)
+
+// 5.1.7.1. Invalid Passcodes.
+var invalidPasscodes = []uint32{
+	0,
+	11111111,
+	22222222,
+	33333333,
+	44444444,
+	55555555,
+	66666666,
+	77777777,
+	88888888,
+	99999999,
+	12345678,
+	87654321,
+}
+
+// IsValidPasscode returns true if the specified passcode is allowed by the specification.
+func IsValidPasscode(passcode uint32) bool {
+	if passcode < PasscodeMin || PasscodeMax < passcode {
+		return false
+	}
+	for _, invalidPasscode := range invalidPasscodes {
+		if passcode == invalidPasscode {
+			return false
+		}
+	}
+	return true
+}
+
+// GeneratePasscode returns a random passcode which is allowed by the specification.
+func GeneratePasscode() (uint32, error) {
+	b := make([]byte, 4)
+	for {
+		if _, err := rand.Read(b); err != nil {
+			return 0, err
+		}
+		passcode := PasscodeMin + (binary.LittleEndian.Uint32(b) % PasscodeMax)
+		if IsValidPasscode(passcode) {
+			return passcode, nil
+		}
+	}
+}
+
+func validatePasscode(passcode uint32) error {
+	if !IsValidPasscode(passcode) {
+		return fmt.Errorf("%w passcode (%d)", ErrInvalid, passcode)
+	}
+	return nil
+}
@@ -0,0 +1,304 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payload
+
+import (
+	"fmt"
+
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// 5.1.1. Onboarding Payload
+// OnboardingPayload represents an onboarding payload.
+type OnboardingPayload struct {
+	version               uint8
+	vendorID              types.VendorID
+	productID             types.ProductID
+	commissioningFlow     CommissioningFlow
+	discoveryCapabilities DiscoveryCapabilities
+	discriminator         types.Discriminator
+	passcode              uint32
+	extensions            []*ExtensionData
+}
+
+// Option represents an option for an onboarding payload.
+type Option func(*OnboardingPayload) error
+
+// WithVendorID returns an option to set a vendor ID.
+func WithVendorID(vid types.VendorID) Option {
+	return func(payload *OnboardingPayload) error {
+		payload.vendorID = vid
+		return nil
+	}
+}
+
+// WithProductID returns an option to set a product ID.
+func WithProductID(pid types.ProductID) Option {
+	return func(payload *OnboardingPayload) error {
+		payload.productID = pid
+		return nil
+	}
+}
+
+// WithCommissioningFlow returns an option to set a commissioning flow.
+func WithCommissioningFlow(flow CommissioningFlow) Option {
+	return func(payload *OnboardingPayload) error {
+		payload.commissioningFlow = flow
+		return nil
+	}
+}
+
+// WithDiscoveryCapabilities returns an option to set discovery capabilities.
+func WithDiscoveryCapabilities(caps DiscoveryCapabilities) Option {
+	return func(payload *OnboardingPayload) error {
+		payload.discoveryCapabilities = caps
+		return nil
+	}
+}
+
+// WithDiscriminator returns an option to set a 12-bit or a short discriminator.
+func WithDiscriminator(discriminator types.Discriminator) Option {
+	return func(payload *OnboardingPayload) error {
+		payload.discriminator = discriminator
+		return nil
+	}
+}
+
+// WithPasscode returns an option to set a passcode.
+func WithPasscode(passcode uint32) Option {
+	return func(payload *OnboardingPayload) error {
+		payload.passcode = passcode
+		return nil
+	}
+}
+
+// WithRandomPasscode returns an option to set a random passcode which is allowed by the specification.
+func WithRandomPasscode() Option {
+	return func(payload *OnboardingPayload) error {
+		passcode, err := GeneratePasscode()
+		if err != nil {
+			return err
+		}
+		payload.passcode = passcode
+		return nil
+	}
+}
+
+func newOnboardingPayload() *OnboardingPayload {
+	return &OnboardingPayload{
+		version:               Version,
+		vendorID:              0,
+		productID:             0,
+		commissioningFlow:     StandardCommissioningFlow,
+		discoveryCapabilities: DiscoveryCapabilityOnNetwork,
+		discriminator:         0,
+		passcode:              0,
+		extensions:            []*ExtensionData{},
+	}
+}
+
+// WithSerialNumber returns an option to set a serial number into the QR code TLV data.
+func WithSerialNumber(sn string) Option {
+	return WithExtensionData(SerialNumberTag, sn)
+}
+
+// WithExtensionData returns an option to add an element into the QR code TLV data.
+// The tag must be the serial number tag or a vendor-specific tag.
+func WithExtensionData(tag uint8, v any) Option {
+	return func(payload *OnboardingPayload) error {
+		data, err := NewExtensionData(tag, v)
+		if err != nil {
+			return err
+		}
+		if err := data.validate(); err != nil {
+			return err
+		}
+		if _, ok := payload.LookupExtensionData(tag); ok {
+			return fmt.Errorf("%w duplicate extension tag (0x%02X)", ErrInvalid, tag)
+		}
+		payload.extensions = append(payload.extensions, data)
+		return nil
+	}
+}
+
+// NewOnboardingPayload returns a new onboarding payload with the specified options.
+// The discovery capabilities default to on-network discovery only.
+// The returned payload is validated, and an error is returned if any field is out of range.
+func NewOnboardingPayload(opts ...Option) (*OnboardingPayload, error) {
+	payload := newOnboardingPayload()
+	for _, opt := range opts {
+		if err := opt(payload); err != nil {
+			return nil, err
+		}
+	}
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// Version returns the version.
+func (payload *OnboardingPayload) Version() uint8 {
+	return payload.version
+}
+
+// VendorID returns the vendor ID.
+func (payload *OnboardingPayload) VendorID() types.VendorID {
+	return payload.vendorID
+}
+
+// ProductID returns the product ID.
+func (payload *OnboardingPayload) ProductID() types.ProductID {
+	return payload.productID
+}
+
+// CommissioningFlow returns the commissioning flow.
+func (payload *OnboardingPayload) CommissioningFlow() CommissioningFlow {
+	return payload.commissioningFlow
+}
+
+// DiscoveryCapabilities returns the discovery capabilities bitmask.
+func (payload *OnboardingPayload) DiscoveryCapabilities() DiscoveryCapabilities {
+	return payload.discoveryCapabilities
+}
+
+// Discriminator returns the discriminator which may be a short discriminator.
+func (payload *OnboardingPayload) Discriminator() types.Discriminator {
+	return payload.discriminator
+}
+
+// ShortDiscriminator returns the upper 4 bits of the discriminator.
+func (payload *OnboardingPayload) ShortDiscriminator() uint8 {
+	return payload.discriminator.Short()
+}
+
+// IsShortDiscriminator returns true if only the upper 4 bits of the discriminator are known,
+// as is the case for payloads parsed from a manual pairing code.
+func (payload *OnboardingPayload) IsShortDiscriminator() bool {
+	return payload.discriminator.IsShort()
+}
+
+// Passcode returns the passcode.
+func (payload *OnboardingPayload) Passcode() uint32 {
+	return payload.passcode
+}
+
+// SerialNumber returns the serial number in the QR code TLV data.
+func (payload *OnboardingPayload) SerialNumber() (string, bool) {
+	data, ok := payload.LookupExtensionData(SerialNumberTag)
+	if !ok {
+		return "", false
+	}
+	return data.String(), true
+}
+
+// ExtensionData returns all elements in the QR code TLV data.
+func (payload *OnboardingPayload) ExtensionData() []*ExtensionData {
+	return payload.extensions
+}
+
+// VendorData returns the vendor-specific elements in the QR code TLV data.
+func (payload *OnboardingPayload) VendorData() []*ExtensionData {
+	elems := []*ExtensionData{}
+	for _, data := range payload.extensions {
+		if data.IsVendorSpecific() {
+			elems = append(elems, data)
+		}
+	}
+	return elems
+}
+
+// LookupExtensionData returns the element of the specified tag in the QR code TLV data.
+func (payload *OnboardingPayload) LookupExtensionData(tag uint8) (*ExtensionData, bool) {
+	for _, data := range payload.extensions {
+		if data.tag == tag {
+			return data, true
+		}
+	}
+	return nil, false
+}
+
+// Equal returns true if the payload is equal to the specified payload.
+func (payload *OnboardingPayload) Equal(other *OnboardingPayload) bool {
+	if other == nil {
+		return false
+	}
+	if payload.version != other.version ||
+		payload.vendorID != other.vendorID ||
+		payload.productID != other.productID ||
+		payload.commissioningFlow != other.commissioningFlow ||
+		payload.discoveryCapabilities != other.discoveryCapabilities ||
+		payload.discriminator != other.discriminator ||
+		payload.passcode != other.passcode {
+		return false
+	}
+	if len(payload.extensions) != len(other.extensions) {
+		return false
+	}
+	for n, data := range payload.extensions {
+		if !data.Equal(other.extensions[n]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate returns an error if any field is out of the range defined by the specification.
+func (payload *OnboardingPayload) Validate() error {
+	if payload.version != Version {
+		return fmt.Errorf("%w version (%d)", ErrInvalid, payload.version)
+	}
+	switch payload.commissioningFlow {
+	case StandardCommissioningFlow, UserIntentCommissioningFlow, CustomCommissioningFlow:
+	default:
+		return fmt.Errorf("%w commissioning flow (%d)", ErrInvalid, payload.commissioningFlow)
+	}
+	if !payload.discriminator.IsShort() && !payload.discoveryCapabilities.IsValid() {
+		return fmt.Errorf("%w discovery capabilities (0x%02X)", ErrInvalid, uint8(payload.discoveryCapabilities))
+	}
+	if !payload.discriminator.IsValid() {
+		return fmt.Errorf("%w discriminator (0x%04X)", ErrInvalid, uint16(payload.discriminator))
+	}
+	return validatePasscode(payload.passcode)
+}
+
+// QRCode returns the QR code string of the payload.
+func (payload *OnboardingPayload) QRCode() (string, error) {
+	if err := payload.Validate(); err != nil {
+		return "", err
+	}
+	if payload.discriminator.IsShort() {
+		return "", fmt.Errorf("%w short discriminator for QR code (%d)", ErrInvalid, payload.ShortDiscriminator())
+	}
+	return newQRPayloadWithOnboardingPayload(payload).String(), nil
+}
+
+// ManualPairingCode returns the manual pairing code string of the payload.
+func (payload *OnboardingPayload) ManualPairingCode() (string, error) {
+	if err := payload.Validate(); err != nil {
+		return "", err
+	}
+	return encodeManualPairingCode(payload)
+}
+
+// String returns the string representation.
+func (payload *OnboardingPayload) String() string {
+	return fmt.Sprintf("VID:%s PID:%s Flow:%d Caps:%s D:%s",
+		payload.vendorID,
+		payload.productID,
+		payload.commissioningFlow,
+		payload.discoveryCapabilities,
+		payload.discriminator)
+}
@@ -0,0 +1,304 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payload
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+func TestOnboardingPayload(t *testing.T) {
+	tests := []struct {
+		vendorID      types.VendorID
+		productID     types.ProductID
+		caps          DiscoveryCapabilities
+		discriminator types.Discriminator
+		passcode      uint32
+		qrCode        string
+		manualCode    string
+	}{
+		{12, 1, DiscoveryCapabilitySoftAP, 128, 2048, "MT:M5L90MP500K64J00000", "00204800002"},
+		{0xFFF1, 0x8000, DiscoveryCapabilityBLE, 3840, 20202021, "MT:Y.K9042C00KA0648G00", "34970112332"},
+		{0xFFF1, 0x8001, DiscoveryCapabilityBLE, 3840, 20202021, "MT:-24J042C00KA0648G00", "34970112332"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.qrCode, func(t *testing.T) {
+			payload, err := NewOnboardingPayload(
+				WithVendorID(test.vendorID),
+				WithProductID(test.productID),
+				WithDiscoveryCapabilities(test.caps),
+				WithDiscriminator(test.discriminator),
+				WithPasscode(test.passcode),
+			)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			qrCode, err := payload.QRCode()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if qrCode != test.qrCode {
+				t.Errorf("QR code (%s) != (%s)", qrCode, test.qrCode)
+			}
+
+			manualCode, err := payload.ManualPairingCode()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if manualCode != test.manualCode {
+				t.Errorf("manual pairing code (%s) != (%s)", manualCode, test.manualCode)
+			}
+
+			qrPayload, err := NewOnboardingPayloadFromString(qrCode)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if !qrPayload.Equal(payload) {
+				t.Errorf("QR payload (%s) != (%s)", qrPayload, payload)
+			}
+
+			manualPayload, err := NewOnboardingPayloadFromString(manualCode)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if manualPayload.ShortDiscriminator() != payload.ShortDiscriminator() {
+				t.Errorf("short discriminator (%d) != (%d)", manualPayload.ShortDiscriminator(), payload.ShortDiscriminator())
+			}
+			if manualPayload.Passcode() != payload.Passcode() {
+				t.Errorf("passcode (%d) != (%d)", manualPayload.Passcode(), payload.Passcode())
+			}
+		})
+	}
+}
+
+func TestInvalidOnboardingPayload(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{"passcode 0", []Option{WithPasscode(0)}},
+		{"passcode 11111111", []Option{WithPasscode(11111111)}},
+		{"passcode 12345678", []Option{WithPasscode(12345678)}},
+		{"passcode 99999999", []Option{WithPasscode(99999999)}},
+		{"passcode 27-bit max", []Option{WithPasscode(0x7FFFFFF)}},
+		{"discriminator", []Option{WithPasscode(20202021), WithDiscriminator(0x1000)}},
+		{"commissioning flow", []Option{WithPasscode(20202021), WithCommissioningFlow(3)}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewOnboardingPayload(test.opts...)
+			if !errors.Is(err, ErrInvalid) {
+				t.Errorf("%s is accepted (%v)", test.name, err)
+			}
+		})
+	}
+}
+
+func TestGeneratePasscode(t *testing.T) {
+	for n := 0; n < 100; n++ {
+		payload, err := NewOnboardingPayload(WithRandomPasscode())
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !IsValidPasscode(payload.Passcode()) {
+			t.Errorf("passcode (%d) is invalid", payload.Passcode())
+		}
+	}
+}
+
+func TestInvalidCodes(t *testing.T) {
+	codes := []string{
+		"MT:Y.K9042C00KA0648G0",
+		"MT:Y.K9042C00KA0648G00AB",
+		"MT:Y.K9042C00KA0648G0a",
+		"MX:Y.K9042C00KA0648G00",
+		"34970112331",
+		"3497011233",
+		"94970112332",
+	}
+
+	for _, code := range codes {
+		t.Run(code, func(t *testing.T) {
+			_, err := NewOnboardingPayloadFromString(code)
+			if err == nil {
+				t.Errorf("%s is accepted", code)
+			}
+		})
+	}
+}
+
+func TestExtensionData(t *testing.T) {
+	payload, err := NewOnboardingPayload(
+		WithVendorID(0xFFF1),
+		WithProductID(0x8000),
+		WithDiscriminator(3840),
+		WithPasscode(20202021),
+		WithSerialNumber("1234567890"),
+		WithExtensionData(0x80, uint32(100000)),
+		WithExtensionData(0x81, []byte{0xDE, 0xAD}),
+		WithExtensionData(0x82, -1),
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	qrCode, err := payload.QRCode()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	decoded, err := NewOnboardingPayloadFromQRCode(qrCode)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !decoded.Equal(payload) {
+		t.Errorf("%s != %s", decoded, payload)
+	}
+
+	sn, ok := decoded.SerialNumber()
+	if !ok || sn != "1234567890" {
+		t.Errorf("serial number (%s) != (%s)", sn, "1234567890")
+	}
+	if len(decoded.VendorData()) != 3 {
+		t.Errorf("vendor data (%d) != (%d)", len(decoded.VendorData()), 3)
+	}
+
+	invalidOpts := [][]Option{
+		{WithPasscode(20202021), WithExtensionData(0x01, "reserved")},
+		{WithPasscode(20202021), WithExtensionData(SerialNumberTag, []byte{0x01})},
+		{WithPasscode(20202021), WithExtensionData(0x80, 1), WithExtensionData(0x80, 2)},
+	}
+	for _, opts := range invalidOpts {
+		if _, err := NewOnboardingPayload(opts...); !errors.Is(err, ErrInvalid) {
+			t.Errorf("invalid extension data is accepted (%v)", err)
+		}
+	}
+
+	// QR codes are parsed with the same checks as the builder.
+	invalidExtensions := [][]*ExtensionData{
+		{{tag: 0x01, value: "reserved"}},
+		{{tag: SerialNumberTag, value: []byte{0x01}}},
+		{{tag: 0x80, value: uint64(1)}, {tag: 0x80, value: uint64(2)}},
+	}
+	for _, extensions := range invalidExtensions {
+		payload.extensions = extensions
+		qrCode, err := payload.QRCode()
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if _, err := NewOnboardingPayloadFromQRCode(qrCode); !errors.Is(err, ErrInvalid) {
+			t.Errorf("invalid extension data is parsed (%v)", err)
+		}
+	}
+}
+
+func TestConcatenatedQRCode(t *testing.T) {
+	payloads := []*OnboardingPayload{}
+	for n := 0; n < 3; n++ {
+		payload, err := NewOnboardingPayload(
+			WithVendorID(0xFFF1),
+			WithProductID(types.ProductID(0x8000+n)),
+			WithDiscriminator(types.Discriminator(3840+n)),
+			WithRandomPasscode(),
+		)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		payloads = append(payloads, payload)
+	}
+
+	code, err := ConcatenatedQRCode(payloads...)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	decoded, err := NewOnboardingPayloadsFromQRCode(code)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(decoded) != len(payloads) {
+		t.Errorf("payloads (%d) != (%d)", len(decoded), len(payloads))
+		return
+	}
+	for n, payload := range payloads {
+		if !decoded[n].Equal(payload) {
+			t.Errorf("%s != %s", decoded[n], payload)
+		}
+	}
+
+	if _, err := NewOnboardingPayloadFromQRCode(code); !errors.Is(err, ErrInvalid) {
+		t.Errorf("concatenated QR code is accepted as a single payload (%v)", err)
+	}
+
+	for _, code := range []string{"MT:Y.K9042C00KA0648G00*", "MT:*Y.K9042C00KA0648G00"} {
+		if _, err := NewOnboardingPayloadsFromQRCode(code); err == nil {
+			t.Errorf("%s is accepted", code)
+		}
+	}
+}
+
+func TestDiscoveryCapabilities(t *testing.T) {
+	tests := []struct {
+		caps       DiscoveryCapabilities
+		valid      bool
+		transports []Transport
+	}{
+		{0, false, []Transport{}},
+		{DiscoveryCapabilityBLE, true, []Transport{TransportBLE}},
+		{DiscoveryCapabilitySoftAP | DiscoveryCapabilityBLE | DiscoveryCapabilityOnNetwork, true, []Transport{TransportOnNetwork, TransportBLE, TransportSoftAP}},
+		{DiscoveryCapabilityWiFiPAF | DiscoveryCapabilityOnNetwork, true, []Transport{TransportOnNetwork, TransportWiFiPAF}},
+		{0x10 | DiscoveryCapabilityBLE, false, []Transport{TransportBLE}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.caps.String(), func(t *testing.T) {
+			if test.caps.IsValid() != test.valid {
+				t.Errorf("0x%02X : valid (%t) != (%t)", uint8(test.caps), test.caps.IsValid(), test.valid)
+			}
+			transports := test.caps.Transports()
+			if len(transports) != len(test.transports) {
+				t.Errorf("%v != %v", transports, test.transports)
+				return
+			}
+			for n, transport := range transports {
+				if transport != test.transports[n] {
+					t.Errorf("%v != %v", transports, test.transports)
+				}
+			}
+		})
+	}
+
+	if _, err := NewOnboardingPayload(WithPasscode(20202021), WithDiscoveryCapabilities(0)); !errors.Is(err, ErrInvalid) {
+		t.Errorf("empty discovery capabilities are accepted (%v)", err)
+	}
+}
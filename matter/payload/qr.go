@@ -0,0 +1,148 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payload
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cybergarage/go-matter/matter/encoding/base38"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// 5.1.3. QR Code
+// qrPayload represents a packed binary data structure of the QR code followed by the optional TLV data.
+type qrPayload struct {
+	bytes []byte
+}
+
+// newQRPayloadWithOnboardingPayload returns a new QR payload from the specified onboarding payload.
+func newQRPayloadWithOnboardingPayload(payload *OnboardingPayload) *qrPayload {
+	qr := &qrPayload{
+		bytes: make([]byte, qrPayloadBytes),
+	}
+	offset := 0
+	offset = qr.putBits(offset, uint64(payload.version), qrVersionBits)
+	offset = qr.putBits(offset, uint64(payload.vendorID), qrVendorIDBits)
+	offset = qr.putBits(offset, uint64(payload.productID), qrProductIDBits)
+	offset = qr.putBits(offset, uint64(payload.commissioningFlow), qrCommissioningFlowBits)
+	offset = qr.putBits(offset, uint64(payload.discoveryCapabilities), qrDiscoveryCapabilitiesBits)
+	offset = qr.putBits(offset, uint64(payload.discriminator.Value()), qrDiscriminatorBits)
+	offset = qr.putBits(offset, uint64(payload.passcode), qrPasscodeBits)
+	qr.putBits(offset, 0, qrPaddingBits)
+	if 0 < len(payload.extensions) {
+		qr.bytes = append(qr.bytes, encodeExtensionData(payload.extensions)...)
+	}
+	return qr
+}
+
+// newQRPayloadWithString returns a new QR payload from the specified QR code string.
+func newQRPayloadWithString(code string) (*qrPayload, error) {
+	if !strings.HasPrefix(code, QRCodePrefix) {
+		return nil, fmt.Errorf("%w QR code prefix (%s)", ErrInvalid, code)
+	}
+	if strings.Contains(code, QRCodeSeparator) {
+		return nil, fmt.Errorf("%w concatenated QR code (%s)", ErrInvalid, code)
+	}
+	b, err := base38.Decode(code[len(QRCodePrefix):])
+	if err != nil {
+		return nil, fmt.Errorf("%w QR code : %w", ErrInvalid, err)
+	}
+	if len(b) < qrPayloadBytes {
+		return nil, fmt.Errorf("%w QR code length (%d)", ErrInvalid, len(b))
+	}
+	qr := &qrPayload{
+		bytes: b,
+	}
+	return qr, nil
+}
+
+// putBits packs the specified value into the payload LSB first, and returns the next bit offset.
+func (qr *qrPayload) putBits(offset int, v uint64, bits int) int {
+	for n := 0; n < bits; n++ {
+		if (v>>n)&0x01 != 0 {
+			idx := offset + n
+			qr.bytes[idx/8] |= 1 << (idx % 8)
+		}
+	}
+	return offset + bits
+}
+
+// getBits unpacks a value from the payload LSB first, and returns the next bit offset.
+func (qr *qrPayload) getBits(offset int, bits int) (uint64, int) {
+	var v uint64
+	for n := 0; n < bits; n++ {
+		idx := offset + n
+		if (qr.bytes[idx/8]>>(idx%8))&0x01 != 0 {
+			v |= 1 << n
+		}
+	}
+	return v, offset + bits
+}
+
+// OnboardingPayload returns the onboarding payload of the QR payload.
+func (qr *qrPayload) OnboardingPayload() (*OnboardingPayload, error) {
+	var v uint64
+	offset := 0
+	payload := newOnboardingPayload()
+	v, offset = qr.getBits(offset, qrVersionBits)
+	payload.version = uint8(v)
+	v, offset = qr.getBits(offset, qrVendorIDBits)
+	payload.vendorID = types.VendorID(v)
+	v, offset = qr.getBits(offset, qrProductIDBits)
+	payload.productID = types.ProductID(v)
+	v, offset = qr.getBits(offset, qrCommissioningFlowBits)
+	payload.commissioningFlow = CommissioningFlow(v)
+	v, offset = qr.getBits(offset, qrDiscoveryCapabilitiesBits)
+	payload.discoveryCapabilities = DiscoveryCapabilities(v)
+	v, offset = qr.getBits(offset, qrDiscriminatorBits)
+	payload.discriminator = types.Discriminator(v)
+	v, offset = qr.getBits(offset, qrPasscodeBits)
+	payload.passcode = uint32(v)
+	v, _ = qr.getBits(offset, qrPaddingBits)
+	if v != 0 {
+		return nil, fmt.Errorf("%w QR code padding (%d)", ErrInvalid, v)
+	}
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+	if qrPayloadBytes < len(qr.bytes) {
+		extensions, err := decodeExtensionData(qr.bytes[qrPayloadBytes:])
+		if err != nil {
+			return nil, err
+		}
+		payload.extensions = extensions
+	}
+	return payload, nil
+}
+
+// Bytes returns the packed binary data.
+func (qr *qrPayload) Bytes() []byte {
+	return qr.bytes
+}
+
+// String returns the QR code string.
+func (qr *qrPayload) String() string {
+	return QRCodePrefix + base38.Encode(qr.bytes)
+}
+
+// NewOnboardingPayloadFromQRCode returns a new onboarding payload from the specified QR code string.
+func NewOnboardingPayloadFromQRCode(code string) (*OnboardingPayload, error) {
+	qr, err := newQRPayloadWithString(code)
+	if err != nil {
+		return nil, err
+	}
+	return qr.OnboardingPayload()
+}
@@ -0,0 +1,199 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qrcode
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+)
+
+// Decode returns the text of the code in the image. The image must show the code upright and unrotated
+// on a light background without other dark marks, such as the images rendered by Code.Image, screenshots
+// and scans of labels cropped around the code. Photos with perspective distortion are not supported.
+func Decode(img image.Image) (string, error) {
+	bounds := img.Bounds()
+	lum := func(x, y int) uint8 {
+		return color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y
+	}
+	lo, hi := uint8(0xFF), uint8(0x00)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			l := lum(x, y)
+			lo, hi = min(lo, l), max(hi, l)
+		}
+	}
+	if hi-lo < 0x40 {
+		return "", fmt.Errorf("QR code is %w : no contrast", ErrNotFound)
+	}
+	threshold := (int(lo) + int(hi)) / 2
+	dark := func(x, y int) bool {
+		return int(lum(x, y)) < threshold
+	}
+
+	left, top, right, bottom := bounds.Max.X, bounds.Max.Y, bounds.Min.X-1, bounds.Min.Y-1
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if dark(x, y) {
+				left, top, right, bottom = min(left, x), min(top, y), max(right, x), max(bottom, y)
+			}
+		}
+	}
+	if right < left {
+		return "", fmt.Errorf("QR code is %w : no dark module", ErrNotFound)
+	}
+
+	// The top-left finder pattern starts with a run of seven dark modules.
+	run := 0
+	for x := left; x <= right && dark(x, top); x++ {
+		run++
+	}
+	moduleSize := float64(run) / 7
+	width, height := float64(right-left+1), float64(bottom-top+1)
+	version := int(math.Round((width/moduleSize - 17) / 4))
+	if version < MinVersion || MaxVersion < version || 0.1 < math.Abs(width-height)/width {
+		return "", fmt.Errorf("QR code is %w : %dx%d pixels", ErrNotFound, right-left+1, bottom-top+1)
+	}
+	mat := newMatrix(version)
+	for y := 0; y < mat.size; y++ {
+		for x := 0; x < mat.size; x++ {
+			px := left + int((float64(x)+0.5)*width/float64(mat.size))
+			py := top + int((float64(y)+0.5)*height/float64(mat.size))
+			mat.modules[y][x] = dark(px, py)
+		}
+	}
+	return decodeMatrix(mat)
+}
+
+// decodeMatrix returns the text of the sampled modules.
+func decodeMatrix(mat *matrix) (string, error) {
+	level, mask, ok := mat.readFormat()
+	if !ok {
+		return "", fmt.Errorf("%w format information", ErrInvalid)
+	}
+	mat.applyMask(mask)
+	codewords := mat.readCodewords()
+
+	spec := blockSpecs[mat.version][level]
+	sizes := spec.blockSizes()
+	blocks := make([][]byte, len(sizes))
+	n := 0
+	for i := 0; i < max(spec.data1, spec.data2); i++ {
+		for b, size := range sizes {
+			if i < size {
+				blocks[b] = append(blocks[b], codewords[n])
+				n++
+			}
+		}
+	}
+	for i := 0; i < spec.ecc; i++ {
+		for b := range blocks {
+			blocks[b] = append(blocks[b], codewords[n])
+			n++
+		}
+	}
+	data := []byte{}
+	for b, block := range blocks {
+		if !rsCorrect(block, spec.ecc) {
+			return "", fmt.Errorf("%w codewords : block %d has too many errors", ErrInvalid, b)
+		}
+		data = append(data, block[:sizes[b]]...)
+	}
+	return decodeSegments(data, mat.version)
+}
+
+// bitReader reads bits from the data codewords.
+type bitReader struct {
+	data   []byte
+	offset int
+}
+
+func (r *bitReader) remaining() int {
+	return len(r.data)*8 - r.offset
+}
+
+func (r *bitReader) read(n int) (int, error) {
+	if r.remaining() < n {
+		return 0, fmt.Errorf("%w segment : %d bits are short", ErrInvalid, n-r.remaining())
+	}
+	v := 0
+	for i := 0; i < n; i++ {
+		v = v<<1 | int(r.data[r.offset>>3]>>(7-r.offset&7)&1)
+		r.offset++
+	}
+	return v, nil
+}
+
+// decodeSegments returns the text of the numeric, alphanumeric and byte segments.
+func decodeSegments(data []byte, version int) (string, error) {
+	var sb strings.Builder
+	r := &bitReader{data: data, offset: 0}
+	for 4 <= r.remaining() {
+		v, err := r.read(4)
+		if err != nil {
+			return "", err
+		}
+		m := mode(v)
+		if m == 0 {
+			break
+		}
+		if m != numericMode && m != alphanumericMode && m != byteMode {
+			return "", fmt.Errorf("segment mode (%d) is %w", m, ErrNotSupported)
+		}
+		count, err := r.read(m.countBits(version))
+		if err != nil {
+			return "", err
+		}
+		for count > 0 {
+			switch m {
+			case numericMode:
+				n := min(3, count)
+				v, err := r.read(n*3 + 1)
+				if err != nil {
+					return "", err
+				}
+				fmt.Fprintf(&sb, "%0*d", n, v)
+				count -= n
+			case alphanumericMode:
+				n := min(2, count)
+				v, err := r.read(n*5 + 1)
+				if err != nil {
+					return "", err
+				}
+				if n == 1 {
+					v *= 45
+				}
+				if len(alphanumericChars)*len(alphanumericChars) <= v {
+					return "", fmt.Errorf("%w alphanumeric segment : %d", ErrInvalid, v)
+				}
+				sb.WriteByte(alphanumericChars[v/45])
+				if n == 2 {
+					sb.WriteByte(alphanumericChars[v%45])
+				}
+				count -= n
+			default:
+				v, err := r.read(8)
+				if err != nil {
+					return "", err
+				}
+				sb.WriteByte(byte(v))
+				count--
+			}
+		}
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,196 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qrcode
+
+// gfExp and gfLog are the exponent and logarithm tables of GF(256) with the primitive polynomial 0x11D.
+var gfExp, gfLog = newGFTables()
+
+func newGFTables() ([512]byte, [256]byte) {
+	var exp [512]byte
+	var log [256]byte
+	x := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(x)
+		log[x] = byte(i)
+		x <<= 1
+		if 0x100 <= x {
+			x ^= 0x11D
+		}
+	}
+	for i := 255; i < len(exp); i++ {
+		exp[i] = exp[i-255]
+	}
+	return exp, log
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+func gfDiv(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+255-int(gfLog[b])]
+}
+
+// gfPow returns α^n.
+func gfPow(n int) byte {
+	n %= 255
+	if n < 0 {
+		n += 255
+	}
+	return gfExp[n]
+}
+
+// rsDivisor returns the coefficients of the generator polynomial of the specified degree, whose roots
+// are α^0 to α^(degree-1), from the highest degree without the leading 1.
+func rsDivisor(degree int) []byte {
+	divisor := make([]byte, degree)
+	divisor[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range divisor {
+			divisor[j] = gfMul(divisor[j], root)
+			if j+1 < len(divisor) {
+				divisor[j] ^= divisor[j+1]
+			}
+		}
+		root = gfMul(root, 0x02)
+	}
+	return divisor
+}
+
+// rsEncode returns the error correction codewords of the data.
+func rsEncode(data []byte, n int) []byte {
+	divisor := rsDivisor(n)
+	ecc := make([]byte, n)
+	for _, b := range data {
+		factor := b ^ ecc[0]
+		copy(ecc, ecc[1:])
+		ecc[n-1] = 0
+		for i := range ecc {
+			ecc[i] ^= gfMul(divisor[i], factor)
+		}
+	}
+	return ecc
+}
+
+// rsCorrect corrects the errors of the block whose last n codewords are the error correction codewords,
+// and returns false if there are more errors than the codewords can correct.
+func rsCorrect(block []byte, n int) bool {
+	syndromes := make([]byte, n)
+	clean := true
+	for j := range syndromes {
+		x := gfPow(j)
+		s := byte(0)
+		for _, b := range block {
+			s = gfMul(s, x) ^ b
+		}
+		syndromes[j] = s
+		clean = clean && s == 0
+	}
+	if clean {
+		return true
+	}
+
+	// Berlekamp-Massey finds the error locator whose coefficients are from the lowest degree.
+	locator := []byte{1}
+	prev := []byte{1}
+	errs := 0
+	shift := 1
+	prevDiscrepancy := byte(1)
+	for k := 0; k < n; k++ {
+		d := syndromes[k]
+		for i := 1; i <= errs && i < len(locator); i++ {
+			d ^= gfMul(locator[i], syndromes[k-i])
+		}
+		if d == 0 {
+			shift++
+			continue
+		}
+		coef := gfDiv(d, prevDiscrepancy)
+		next := make([]byte, max(len(locator), len(prev)+shift))
+		copy(next, locator)
+		for i, c := range prev {
+			next[i+shift] ^= gfMul(coef, c)
+		}
+		if 2*errs <= k {
+			prev = locator
+			errs = k + 1 - errs
+			prevDiscrepancy = d
+			shift = 1
+		} else {
+			shift++
+		}
+		locator = next
+	}
+	if n < 2*errs {
+		return false
+	}
+
+	// The evaluator is the syndromes multiplied by the locator modulo x^n.
+	evaluator := make([]byte, n)
+	for i, s := range syndromes {
+		for j, c := range locator {
+			if i+j < n {
+				evaluator[i+j] ^= gfMul(s, c)
+			}
+		}
+	}
+	eval := func(poly []byte, x byte) byte {
+		y := byte(0)
+		for i := len(poly) - 1; 0 <= i; i-- {
+			y = gfMul(y, x) ^ poly[i]
+		}
+		return y
+	}
+
+	// Chien search finds the positions, and Forney computes the magnitudes.
+	found := 0
+	for i := range block {
+		power := len(block) - 1 - i
+		xinv := gfPow(-power)
+		if eval(locator, xinv) != 0 {
+			continue
+		}
+		derivative := byte(0)
+		for j := 1; j < len(locator); j += 2 {
+			derivative ^= gfMul(locator[j], gfPow(-power*(j-1)))
+		}
+		if derivative == 0 {
+			return false
+		}
+		block[i] ^= gfMul(gfPow(power), gfDiv(eval(evaluator, xinv), derivative))
+		found++
+	}
+	if found != errs {
+		return false
+	}
+	for j := 0; j < n; j++ {
+		x := gfPow(j)
+		s := byte(0)
+		for _, b := range block {
+			s = gfMul(s, x) ^ b
+		}
+		if s != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qrcode
+
+import (
+	"image"
+	"image/color"
+	"strings"
+)
+
+// Image returns the grayscale image of the code with the quiet zone, where each module is the specified
+// number of pixels square.
+func (code *Code) Image(scale int) image.Image {
+	scale = max(scale, 1)
+	side := (code.Size() + QuietZone*2) * scale
+	img := image.NewGray(image.Rect(0, 0, side, side))
+	for y := 0; y < side; y++ {
+		for x := 0; x < side; x++ {
+			c := color.Gray{Y: 0xFF}
+			if code.IsDark(x/scale-QuietZone, y/scale-QuietZone) {
+				c = color.Gray{Y: 0x00}
+			}
+			img.SetGray(x, y, c)
+		}
+	}
+	return img
+}
+
+// Terminal returns the code drawn with half block characters with the quiet zone, where each line
+// has two rows of the modules. The blocks are the light modules if inverted is true, which is for
+// terminals drawing light characters on a dark background.
+func (code *Code) Terminal(inverted bool) string {
+	var sb strings.Builder
+	dark := func(x, y int) bool {
+		return code.IsDark(x, y) != inverted
+	}
+	for y := -QuietZone; y < code.Size()+QuietZone; y += 2 {
+		for x := -QuietZone; x < code.Size()+QuietZone; x++ {
+			upper, lower := dark(x, y), dark(x, y+1)
+			if y+1 == code.Size()+QuietZone {
+				lower = inverted
+			}
+			switch {
+			case upper && lower:
+				sb.WriteString("█")
+			case upper:
+				sb.WriteString("▀")
+			case lower:
+				sb.WriteString("▄")
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
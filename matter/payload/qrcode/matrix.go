@@ -0,0 +1,259 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qrcode
+
+// matrix represents the modules of a code with the function modules which do not hold codewords.
+type matrix struct {
+	version  int
+	size     int
+	modules  [][]bool
+	function [][]bool
+}
+
+// newMatrix returns a matrix of the version with the function patterns, whose format information is reserved.
+func newMatrix(version int) *matrix {
+	size := version*4 + 17
+	mat := &matrix{
+		version:  version,
+		size:     size,
+		modules:  make([][]bool, size),
+		function: make([][]bool, size),
+	}
+	for y := 0; y < size; y++ {
+		mat.modules[y] = make([]bool, size)
+		mat.function[y] = make([]bool, size)
+	}
+
+	for i := 0; i < size; i++ {
+		mat.set(6, i, i%2 == 0)
+		mat.set(i, 6, i%2 == 0)
+	}
+	for _, center := range [][2]int{{3, 3}, {size - 4, 3}, {3, size - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := center[0]+dx, center[1]+dy
+				if x < 0 || y < 0 || size <= x || size <= y {
+					continue
+				}
+				dist := max(abs(dx), abs(dy))
+				mat.set(x, y, dist != 2 && dist != 4)
+			}
+		}
+	}
+	positions := alignmentPositions(version)
+	last := len(positions) - 1
+	for i, cy := range positions {
+		for j, cx := range positions {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					mat.set(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+	mat.drawFormat(LevelLow, 0)
+	if 7 <= version {
+		bits := versionBits(version)
+		for i := 0; i < 18; i++ {
+			dark := (bits>>i)&1 == 1
+			a, b := size-11+i%3, i/3
+			mat.set(a, b, dark)
+			mat.set(b, a, dark)
+		}
+	}
+	return mat
+}
+
+// alignmentPositions returns the centers of the alignment patterns on each axis.
+func alignmentPositions(version int) []int {
+	if version == 1 {
+		return []int{}
+	}
+	n := version/7 + 2
+	size := version*4 + 17
+	step := (version*4 + n*2 + 1) / (n*2 - 2) * 2
+	positions := make([]int, n)
+	positions[0] = 6
+	for i := n - 1; 1 <= i; i-- {
+		positions[i] = size - 7 - (n-1-i)*step
+	}
+	return positions
+}
+
+func (mat *matrix) set(x, y int, dark bool) {
+	mat.modules[y][x] = dark
+	mat.function[y][x] = true
+}
+
+// formatBits returns the 15-bit format information of the level and the mask.
+func formatBits(level Level, mask int) int {
+	data := level.formatBits()<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// versionBits returns the 18-bit version information.
+func versionBits(version int) int {
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	return version<<12 | rem
+}
+
+// formatPositions returns the positions of the bits of the two copies of the format information.
+func (mat *matrix) formatPositions() ([15][2]int, [15][2]int) {
+	var first, second [15][2]int
+	for i := 0; i < 15; i++ {
+		switch {
+		case i < 6:
+			first[i] = [2]int{8, i}
+		case i < 8:
+			first[i] = [2]int{8, i + 1}
+		case i == 8:
+			first[i] = [2]int{7, 8}
+		default:
+			first[i] = [2]int{14 - i, 8}
+		}
+		if i < 8 {
+			second[i] = [2]int{mat.size - 1 - i, 8}
+		} else {
+			second[i] = [2]int{8, mat.size - 15 + i}
+		}
+	}
+	return first, second
+}
+
+// drawFormat draws the format information with the dark module.
+func (mat *matrix) drawFormat(level Level, mask int) {
+	bits := formatBits(level, mask)
+	first, second := mat.formatPositions()
+	for i := 0; i < 15; i++ {
+		dark := (bits>>i)&1 == 1
+		mat.set(first[i][0], first[i][1], dark)
+		mat.set(second[i][0], second[i][1], dark)
+	}
+	mat.set(8, mat.size-8, true)
+}
+
+// readFormat returns the level and the mask of the nearest format information of the two copies.
+func (mat *matrix) readFormat() (Level, int, bool) {
+	first, second := mat.formatPositions()
+	bestDistance := 16
+	var bestLevel Level
+	bestMask := 0
+	for _, positions := range [][15][2]int{first, second} {
+		bits := 0
+		for i, pos := range positions {
+			if mat.modules[pos[1]][pos[0]] {
+				bits |= 1 << i
+			}
+		}
+		for level := LevelLow; level <= LevelHigh; level++ {
+			for mask := 0; mask < 8; mask++ {
+				distance := 0
+				for diff := bits ^ formatBits(level, mask); diff != 0; diff &= diff - 1 {
+					distance++
+				}
+				if distance < bestDistance {
+					bestDistance, bestLevel, bestMask = distance, level, mask
+				}
+			}
+		}
+	}
+	return bestLevel, bestMask, bestDistance <= 3
+}
+
+// eachDataModule calls the function with the positions of the data modules in the placement order.
+func (mat *matrix) eachDataModule(fn func(x, y int)) {
+	for right := mat.size - 1; 1 <= right; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < mat.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = mat.size - 1 - vert
+				}
+				if !mat.function[y][x] {
+					fn(x, y)
+				}
+			}
+		}
+	}
+}
+
+// drawCodewords draws the codewords on the data modules, whose remainder bits are light.
+func (mat *matrix) drawCodewords(codewords []byte) {
+	i := 0
+	mat.eachDataModule(func(x, y int) {
+		if i < len(codewords)*8 {
+			mat.modules[y][x] = (codewords[i>>3]>>(7-i&7))&1 == 1
+			i++
+		}
+	})
+}
+
+// readCodewords returns the codewords of the data modules without the remainder bits.
+func (mat *matrix) readCodewords() []byte {
+	codewords := []byte{}
+	i := 0
+	mat.eachDataModule(func(x, y int) {
+		if i%8 == 0 {
+			codewords = append(codewords, 0)
+		}
+		if mat.modules[y][x] {
+			codewords[len(codewords)-1] |= 1 << (7 - i%8)
+		}
+		i++
+	})
+	return codewords[:i/8]
+}
+
+// applyMask inverts the data modules of the mask pattern, which also removes the mask.
+func (mat *matrix) applyMask(mask int) {
+	mat.eachDataModule(func(x, y int) {
+		var invert bool
+		switch mask {
+		case 0:
+			invert = (x+y)%2 == 0
+		case 1:
+			invert = y%2 == 0
+		case 2:
+			invert = x%3 == 0
+		case 3:
+			invert = (x+y)%3 == 0
+		case 4:
+			invert = (x/3+y/2)%2 == 0
+		case 5:
+			invert = x*y%2+x*y%3 == 0
+		case 6:
+			invert = (x*y%2+x*y%3)%2 == 0
+		default:
+			invert = ((x+y)%2+x*y%3)%2 == 0
+		}
+		if invert {
+			mat.modules[y][x] = !mat.modules[y][x]
+		}
+	})
+}
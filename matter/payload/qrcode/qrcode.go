@@ -0,0 +1,374 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package qrcode encodes texts into QR codes and decodes them from images without external dependencies.
+// The versions up to 10 are supported, which hold onboarding payloads with large extensions.
+package qrcode
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Level represents an error correction level.
+type Level int
+
+const (
+	// LevelLow recovers about 7% of the codewords.
+	LevelLow Level = iota
+	// LevelMedium recovers about 15% of the codewords.
+	LevelMedium
+	// LevelQuartile recovers about 25% of the codewords.
+	LevelQuartile
+	// LevelHigh recovers about 30% of the codewords.
+	LevelHigh
+)
+
+const (
+	// MinVersion is the smallest supported version of 21x21 modules.
+	MinVersion = 1
+	// MaxVersion is the largest supported version of 57x57 modules.
+	MaxVersion = 10
+	// QuietZone is the number of the light modules around codes in images.
+	QuietZone = 4
+)
+
+// formatBits returns the bits of the level in the format information.
+func (level Level) formatBits() int {
+	return [...]int{1, 0, 3, 2}[level]
+}
+
+// String returns the name of the level such as M.
+func (level Level) String() string {
+	if level < LevelLow || LevelHigh < level {
+		return fmt.Sprintf("Level(%d)", int(level))
+	}
+	return [...]string{"L", "M", "Q", "H"}[level]
+}
+
+// blockSpec represents the error correction codewords per block and the numbers and the data codewords
+// of the blocks in the two groups of a version and a level.
+type blockSpec struct {
+	ecc     int
+	blocks1 int
+	data1   int
+	blocks2 int
+	data2   int
+}
+
+// ISO/IEC 18004 Table 9 in the order of the levels.
+var blockSpecs = [MaxVersion + 1][4]blockSpec{
+	{},
+	{{7, 1, 19, 0, 0}, {10, 1, 16, 0, 0}, {13, 1, 13, 0, 0}, {17, 1, 9, 0, 0}},
+	{{10, 1, 34, 0, 0}, {16, 1, 28, 0, 0}, {22, 1, 22, 0, 0}, {28, 1, 16, 0, 0}},
+	{{15, 1, 55, 0, 0}, {26, 1, 44, 0, 0}, {18, 2, 17, 0, 0}, {22, 2, 13, 0, 0}},
+	{{20, 1, 80, 0, 0}, {18, 2, 32, 0, 0}, {26, 2, 24, 0, 0}, {16, 4, 9, 0, 0}},
+	{{26, 1, 108, 0, 0}, {24, 2, 43, 0, 0}, {18, 2, 15, 2, 16}, {22, 2, 11, 2, 12}},
+	{{18, 2, 68, 0, 0}, {16, 4, 27, 0, 0}, {24, 4, 19, 0, 0}, {28, 4, 15, 0, 0}},
+	{{20, 2, 78, 0, 0}, {18, 4, 31, 0, 0}, {18, 2, 14, 4, 15}, {26, 4, 13, 1, 14}},
+	{{24, 2, 97, 0, 0}, {22, 2, 38, 2, 39}, {22, 4, 18, 2, 19}, {26, 4, 14, 2, 15}},
+	{{30, 2, 116, 0, 0}, {22, 3, 36, 2, 37}, {20, 4, 16, 4, 17}, {24, 4, 12, 4, 13}},
+	{{18, 2, 68, 2, 69}, {26, 4, 43, 1, 44}, {24, 6, 19, 2, 20}, {28, 6, 15, 2, 16}},
+}
+
+// dataCodewords returns the number of the data codewords.
+func (spec blockSpec) dataCodewords() int {
+	return spec.blocks1*spec.data1 + spec.blocks2*spec.data2
+}
+
+// blockSizes returns the data codewords of each block.
+func (spec blockSpec) blockSizes() []int {
+	sizes := []int{}
+	for i := 0; i < spec.blocks1; i++ {
+		sizes = append(sizes, spec.data1)
+	}
+	for i := 0; i < spec.blocks2; i++ {
+		sizes = append(sizes, spec.data2)
+	}
+	return sizes
+}
+
+// mode represents a segment mode.
+type mode int
+
+const (
+	numericMode      mode = 0x1
+	alphanumericMode mode = 0x2
+	byteMode         mode = 0x4
+)
+
+const alphanumericChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// countBits returns the bits of the character count of the mode.
+func (m mode) countBits(version int) int {
+	large := 10 <= version
+	switch m {
+	case numericMode:
+		if large {
+			return 12
+		}
+		return 10
+	case alphanumericMode:
+		if large {
+			return 11
+		}
+		return 9
+	default:
+		if large {
+			return 16
+		}
+		return 8
+	}
+}
+
+// selectMode returns the most compact mode which encodes the whole text, since onboarding payloads
+// are alphanumeric.
+func selectMode(text string) mode {
+	numeric := true
+	alphanumeric := true
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		numeric = numeric && '0' <= c && c <= '9'
+		alphanumeric = alphanumeric && strings.IndexByte(alphanumericChars, c) != -1
+	}
+	switch {
+	case numeric:
+		return numericMode
+	case alphanumeric:
+		return alphanumericMode
+	}
+	return byteMode
+}
+
+// bitBuffer represents a sequence of bits.
+type bitBuffer []bool
+
+func (buf *bitBuffer) append(v int, n int) {
+	for i := n - 1; 0 <= i; i-- {
+		*buf = append(*buf, (v>>i)&1 == 1)
+	}
+}
+
+// dataBits returns the bits of the segment of the text without the mode and the character count.
+func dataBits(m mode, text string) bitBuffer {
+	buf := bitBuffer{}
+	switch m {
+	case numericMode:
+		for i := 0; i < len(text); i += 3 {
+			n := min(3, len(text)-i)
+			v := 0
+			for _, c := range text[i : i+n] {
+				v = v*10 + int(c-'0')
+			}
+			buf.append(v, n*3+1)
+		}
+	case alphanumericMode:
+		for i := 0; i < len(text); i += 2 {
+			v := strings.IndexByte(alphanumericChars, text[i])
+			if i+1 < len(text) {
+				buf.append(v*45+strings.IndexByte(alphanumericChars, text[i+1]), 11)
+			} else {
+				buf.append(v, 6)
+			}
+		}
+	default:
+		for i := 0; i < len(text); i++ {
+			buf.append(int(text[i]), 8)
+		}
+	}
+	return buf
+}
+
+// Code represents a QR code symbol.
+type Code struct {
+	version int
+	level   Level
+	mask    int
+	modules [][]bool
+}
+
+// Encode returns the code of the smallest version which holds the text in the specified level.
+// Texts are encoded in a single numeric, alphanumeric or byte segment.
+func Encode(text string, level Level) (*Code, error) {
+	if level < LevelLow || LevelHigh < level {
+		return nil, fmt.Errorf("%w level : %s", ErrInvalid, level)
+	}
+	m := selectMode(text)
+	body := dataBits(m, text)
+	for version := MinVersion; version <= MaxVersion; version++ {
+		spec := blockSpecs[version][level]
+		capacity := spec.dataCodewords() * 8
+		if len(text) >= 1<<m.countBits(version) || capacity < 4+m.countBits(version)+len(body) {
+			continue
+		}
+		buf := bitBuffer{}
+		buf.append(int(m), 4)
+		buf.append(len(text), m.countBits(version))
+		buf = append(buf, body...)
+		buf.append(0, min(4, capacity-len(buf)))
+		buf.append(0, (8-len(buf)%8)%8)
+		data := make([]byte, 0, spec.dataCodewords())
+		for i := 0; i < len(buf); i += 8 {
+			b := byte(0)
+			for _, bit := range buf[i : i+8] {
+				b <<= 1
+				if bit {
+					b |= 1
+				}
+			}
+			data = append(data, b)
+		}
+		for pad := byte(0xEC); len(data) < spec.dataCodewords(); pad ^= 0xEC ^ 0x11 {
+			data = append(data, pad)
+		}
+		return newCode(version, level, interleave(spec, data)), nil
+	}
+	return nil, fmt.Errorf("%w text : %d bytes exceed version %d-%s", ErrInvalid, len(text), MaxVersion, level)
+}
+
+// interleave returns the codewords of the data split into the blocks with their error correction codewords.
+func interleave(spec blockSpec, data []byte) []byte {
+	blocks := [][]byte{}
+	eccs := [][]byte{}
+	offset := 0
+	for _, size := range spec.blockSizes() {
+		block := data[offset : offset+size]
+		offset += size
+		blocks = append(blocks, block)
+		eccs = append(eccs, rsEncode(block, spec.ecc))
+	}
+	codewords := []byte{}
+	for i := 0; i < max(spec.data1, spec.data2); i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				codewords = append(codewords, block[i])
+			}
+		}
+	}
+	for i := 0; i < spec.ecc; i++ {
+		for _, ecc := range eccs {
+			codewords = append(codewords, ecc[i])
+		}
+	}
+	return codewords
+}
+
+// newCode returns the code of the codewords with the mask of the lowest penalty.
+func newCode(version int, level Level, codewords []byte) *Code {
+	var best *Code
+	bestPenalty := 0
+	for mask := 0; mask < 8; mask++ {
+		mat := newMatrix(version)
+		mat.drawFormat(level, mask)
+		mat.drawCodewords(codewords)
+		mat.applyMask(mask)
+		code := &Code{
+			version: version,
+			level:   level,
+			mask:    mask,
+			modules: mat.modules,
+		}
+		if penalty := code.penalty(); best == nil || penalty < bestPenalty {
+			best = code
+			bestPenalty = penalty
+		}
+	}
+	return best
+}
+
+// Version returns the version of the code.
+func (code *Code) Version() int {
+	return code.version
+}
+
+// Level returns the error correction level of the code.
+func (code *Code) Level() Level {
+	return code.level
+}
+
+// Size returns the number of the modules on each side of the code without the quiet zone.
+func (code *Code) Size() int {
+	return len(code.modules)
+}
+
+// IsDark returns true if the module at the specified column and row is dark.
+func (code *Code) IsDark(x, y int) bool {
+	if x < 0 || y < 0 || code.Size() <= x || code.Size() <= y {
+		return false
+	}
+	return code.modules[y][x]
+}
+
+// penalty returns the penalty of the mask pattern of the code.
+func (code *Code) penalty() int {
+	size := code.Size()
+	penalty := 0
+	lines := func(transpose bool) {
+		for i := 0; i < size; i++ {
+			run := 0
+			var prev bool
+			bits := 0
+			for j := 0; j < size; j++ {
+				dark := code.modules[i][j]
+				if transpose {
+					dark = code.modules[j][i]
+				}
+				if j == 0 || dark != prev {
+					run = 1
+				} else {
+					run++
+					if run == 5 {
+						penalty += 3
+					} else if 5 < run {
+						penalty++
+					}
+				}
+				prev = dark
+				bits = (bits << 1) & 0x7FF
+				if dark {
+					bits |= 1
+				}
+				if 10 <= j && (bits == 0x5D0 || bits == 0x05D) {
+					penalty += 40
+				}
+			}
+		}
+	}
+	lines(false)
+	lines(true)
+	dark := 0
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if code.modules[y][x] {
+				dark++
+			}
+			if x+1 < size && y+1 < size {
+				c := code.modules[y][x]
+				if c == code.modules[y][x+1] && c == code.modules[y+1][x] && c == code.modules[y+1][x+1] {
+					penalty += 3
+				}
+			}
+		}
+	}
+	total := size * size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	return penalty + k*10
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
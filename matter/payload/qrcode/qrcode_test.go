@@ -0,0 +1,168 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestCodewords(t *testing.T) {
+	// ISO/IEC 18004 Annex I encodes HELLO WORLD in 1-M.
+	code, err := Encode("HELLO WORLD", LevelMedium)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code.Version() != 1 || code.Size() != 21 {
+		t.Errorf("version %d (%d modules)", code.Version(), code.Size())
+	}
+	expected := []byte{
+		32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17,
+		196, 35, 39, 119, 235, 215, 231, 226, 93, 23,
+	}
+	mat := &matrix{version: 1, size: code.Size(), modules: code.modules, function: newMatrix(1).function}
+	mat.applyMask(code.mask)
+	if codewords := mat.readCodewords(); !bytes.Equal(codewords, expected) {
+		t.Errorf("%v != %v", codewords, expected)
+	}
+}
+
+func TestFunctionPatterns(t *testing.T) {
+	formats := []struct {
+		level    Level
+		mask     int
+		expected string
+	}{
+		{LevelLow, 0, "111011111000100"},
+		{LevelMedium, 0, "101010000010010"},
+		{LevelQuartile, 0, "011010101011111"},
+		{LevelHigh, 0, "001011010001001"},
+	}
+	for _, format := range formats {
+		if bits := fmt.Sprintf("%015b", formatBits(format.level, format.mask)); bits != format.expected {
+			t.Errorf("%s-%d : %s != %s", format.level, format.mask, bits, format.expected)
+		}
+	}
+	if bits := fmt.Sprintf("%018b", versionBits(7)); bits != "000111110010010100" {
+		t.Errorf("version 7 : %s", bits)
+	}
+
+	codewords := []int{0, 26, 44, 70, 100, 134, 172, 196, 242, 292, 346}
+	for version := MinVersion; version <= MaxVersion; version++ {
+		n := 0
+		newMatrix(version).eachDataModule(func(x, y int) { n++ })
+		if n/8 != codewords[version] {
+			t.Errorf("version %d : %d codewords != %d", version, n/8, codewords[version])
+		}
+		for level := LevelLow; level <= LevelHigh; level++ {
+			spec := blockSpecs[version][level]
+			if total := spec.dataCodewords() + (spec.blocks1+spec.blocks2)*spec.ecc; total != codewords[version] {
+				t.Errorf("version %d-%s : %d codewords", version, level, total)
+			}
+		}
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	texts := []string{
+		"MT:Y.K9042C00KA0648G00",
+		"MT:-24J0AFN00KA064IJ3P0IXZB0DK5N1K8SQ1RYCU1O0",
+		"0123456789",
+		"hello, world",
+		"MT:" + strings.Repeat("0123456789ABCDEF", 16),
+	}
+	for _, text := range texts {
+		for level := LevelLow; level <= LevelHigh; level++ {
+			code, err := Encode(text, level)
+			if err != nil {
+				if errors.Is(err, ErrInvalid) && level != LevelLow {
+					continue
+				}
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			if err := png.Encode(&buf, code.Image(3)); err != nil {
+				t.Fatal(err)
+			}
+			img, err := png.Decode(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := Decode(img)
+			if err != nil {
+				t.Fatalf("%s (%d-%s) : %s", text, code.Version(), level, err)
+			}
+			if decoded != text {
+				t.Errorf("%s != %s", decoded, text)
+			}
+		}
+	}
+
+	if _, err := Encode(strings.Repeat("x", 300), LevelHigh); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+	if _, err := Decode(image.NewGray(image.Rect(0, 0, 10, 10))); !errors.Is(err, ErrNotFound) {
+		t.Errorf("%v is not %v", err, ErrNotFound)
+	}
+}
+
+func TestErrorCorrection(t *testing.T) {
+	text := "MT:Y.K9042C00KA0648G00"
+	for level := LevelLow; level <= LevelHigh; level++ {
+		code, err := Encode(text, level)
+		if err != nil {
+			t.Fatal(err)
+		}
+		spec := blockSpecs[code.Version()][level]
+		mat := newMatrix(code.Version())
+		mat.modules = code.modules
+		// Each corrupted codeword flips all its bits, and each block corrects half of its error correction codewords.
+		errs := 0
+		mat.eachDataModule(func(x, y int) {
+			if errs < spec.ecc/2*8 {
+				mat.modules[y][x] = !mat.modules[y][x]
+				errs++
+			}
+		})
+		decoded, err := decodeMatrix(mat)
+		if err != nil {
+			t.Fatalf("%s : %s", level, err)
+		}
+		if decoded != text {
+			t.Errorf("%s != %s", decoded, text)
+		}
+	}
+}
+
+func TestTerminal(t *testing.T) {
+	code, err := Encode("MT:Y.K9042C00KA0648G00", LevelMedium)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(code.Terminal(false), "\n"), "\n")
+	if len(lines) != (code.Size()+QuietZone*2+1)/2 {
+		t.Errorf("%d lines", len(lines))
+	}
+	for _, line := range lines {
+		if n := len([]rune(line)); n != code.Size()+QuietZone*2 {
+			t.Errorf("%d columns", n)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"sync"
+	"time"
+)
+
+// AddressScope represents the scope of a node address.
+type AddressScope int
+
+const (
+	// LinkLocalScope is the scope of IPv6 link-local addresses, which are only reachable on their interface.
+	LinkLocalScope AddressScope = iota
+	// UniqueLocalScope is the scope of IPv6 unique local addresses (ULA).
+	UniqueLocalScope
+	// GlobalScope is the scope of IPv6 global unicast addresses (GUA).
+	GlobalScope
+	// IPv4Scope is the scope of IPv4 addresses.
+	IPv4Scope
+)
+
+// NewAddressScope returns the scope of the address.
+func NewAddressScope(addr netip.Addr) AddressScope {
+	switch {
+	case addr.Is4() || addr.Is4In6():
+		return IPv4Scope
+	case addr.IsLinkLocalUnicast():
+		return LinkLocalScope
+	case addr.IsPrivate():
+		return UniqueLocalScope
+	}
+	return GlobalScope
+}
+
+// String returns the string representation.
+func (scope AddressScope) String() string {
+	switch scope {
+	case LinkLocalScope:
+		return "link-local"
+	case UniqueLocalScope:
+		return "ULA"
+	case GlobalScope:
+		return "GUA"
+	case IPv4Scope:
+		return "IPv4"
+	}
+	return fmt.Sprintf("scope (%d)", int(scope))
+}
+
+// PeerAddress represents a known address of a node and its reachability history.
+type PeerAddress struct {
+	// Addr is the address and port of the node.
+	Addr netip.AddrPort
+	// Scope is the scope of the address.
+	Scope AddressScope
+	// Successes is the number of times the node is reached at the address.
+	Successes int
+	// Failures is the number of consecutive times the node is not reached at the address.
+	Failures int
+	// LastSuccess is the time when the node is last reached at the address.
+	LastSuccess time.Time
+	// LastFailure is the time when the node is last not reached at the address.
+	LastFailure time.Time
+}
+
+// Interface returns the interface name of the link-local address, or an empty string for other addresses.
+func (addr PeerAddress) Interface() string {
+	return addr.Addr.Addr().Zone()
+}
+
+// String returns the string representation.
+func (addr PeerAddress) String() string {
+	return fmt.Sprintf("%s (%s, %d/%d)", addr.Addr, addr.Scope, addr.Successes, addr.Failures)
+}
+
+// PeerAddressTable represents the known addresses of a node. Link-local addresses on different
+// interfaces are tracked separately. The addresses are ordered by their reachability history:
+// the addresses with fewer consecutive failures come first, then the ones reached more recently,
+// and the addresses with the same history keep the order they are added in.
+type PeerAddressTable struct {
+	sync.Mutex
+	addrs []*PeerAddress
+	now   func() time.Time
+}
+
+// NewPeerAddressTable returns a new peer address table with the specified addresses.
+func NewPeerAddressTable(addrs ...netip.AddrPort) *PeerAddressTable {
+	table := &PeerAddressTable{
+		Mutex: sync.Mutex{},
+		addrs: []*PeerAddress{},
+		now:   time.Now,
+	}
+	table.Add(addrs...)
+	return table
+}
+
+// Add adds the addresses which are not known yet. Invalid addresses are ignored.
+func (table *PeerAddressTable) Add(addrs ...netip.AddrPort) {
+	table.Lock()
+	defer table.Unlock()
+	for _, addr := range addrs {
+		if !addr.IsValid() || table.lookup(addr) != nil {
+			continue
+		}
+		table.addrs = append(table.addrs, &PeerAddress{
+			Addr:        addr,
+			Scope:       NewAddressScope(addr.Addr()),
+			Successes:   0,
+			Failures:    0,
+			LastSuccess: time.Time{},
+			LastFailure: time.Time{},
+		})
+	}
+}
+
+// Remove removes the address.
+func (table *PeerAddressTable) Remove(addr netip.AddrPort) {
+	table.Lock()
+	defer table.Unlock()
+	for n, known := range table.addrs {
+		if known.Addr == addr {
+			table.addrs = append(table.addrs[:n], table.addrs[n+1:]...)
+			return
+		}
+	}
+}
+
+// Succeeded records that the node is reached at the address, which is added if it is not known yet.
+func (table *PeerAddressTable) Succeeded(addr netip.AddrPort) {
+	table.Add(addr)
+	table.Lock()
+	defer table.Unlock()
+	if known := table.lookup(addr); known != nil {
+		known.Successes++
+		known.Failures = 0
+		known.LastSuccess = table.now()
+	}
+}
+
+// Failed records that the node is not reached at the address, which is added if it is not known yet.
+func (table *PeerAddressTable) Failed(addr netip.AddrPort) {
+	table.Add(addr)
+	table.Lock()
+	defer table.Unlock()
+	if known := table.lookup(addr); known != nil {
+		known.Failures++
+		known.LastFailure = table.now()
+	}
+}
+
+// Addresses returns the known addresses in the order of preference.
+func (table *PeerAddressTable) Addresses() []PeerAddress {
+	table.Lock()
+	defer table.Unlock()
+	addrs := make([]PeerAddress, len(table.addrs))
+	for n, addr := range table.addrs {
+		addrs[n] = *addr
+	}
+	sort.SliceStable(addrs, func(i, j int) bool {
+		if addrs[i].Failures != addrs[j].Failures {
+			return addrs[i].Failures < addrs[j].Failures
+		}
+		return addrs[i].LastSuccess.After(addrs[j].LastSuccess)
+	})
+	return addrs
+}
+
+// Best returns the most preferred address.
+func (table *PeerAddressTable) Best() (netip.AddrPort, bool) {
+	addrs := table.Addresses()
+	if len(addrs) == 0 {
+		return netip.AddrPort{}, false
+	}
+	return addrs[0].Addr, true
+}
+
+func (table *PeerAddressTable) lookup(addr netip.AddrPort) *PeerAddress {
+	for _, known := range table.addrs {
+		if known.Addr == addr {
+			return known
+		}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+
+	"github.com/cybergarage/go-matter/matter/cluster/powersource"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// ReadPowerSource reads the attributes of the Power Source cluster of the specified endpoint, such as
+// the status and the remaining battery percentage.
+func ReadPowerSource(ctx context.Context, reader AttributeReader, endpoint im.EndpointID) (*powersource.Source, error) {
+	src := &powersource.Source{}
+	if err := readCluster(ctx, reader, endpoint, powersource.ClusterID, src); err != nil {
+		return nil, err
+	}
+	return src, nil
+}
@@ -14,9 +14,13 @@
 
 package matter
 
+import (
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
 // ProductID represents a product ID.
-type ProductID uint16
+type ProductID = types.ProductID
 
 const (
-	AnonymizedProductID = (ProductID)(0x0000)
+	AnonymizedProductID = types.AnonymizedProductID
 )
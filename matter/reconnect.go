@@ -0,0 +1,73 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/securechannel"
+)
+
+const (
+	// DefaultReconnectInitialInterval is the default interval before the first re-establishment retry.
+	DefaultReconnectInitialInterval = time.Second
+	// DefaultReconnectMaxInterval is the default upper bound of the re-establishment retry interval.
+	DefaultReconnectMaxInterval = 30 * time.Second
+	// DefaultReconnectMaxAttempts is the default number of re-establishment retries.
+	DefaultReconnectMaxAttempts = 5
+)
+
+// ReconnectPolicy represents an exponential backoff policy to re-establish CASE sessions.
+type ReconnectPolicy struct {
+	// InitialInterval is the interval before the first retry.
+	InitialInterval time.Duration
+	// MaxInterval is the upper bound of the retry interval.
+	MaxInterval time.Duration
+	// MaxAttempts is the number of retries after the first failure. Zero disables retries.
+	MaxAttempts int
+	// BusyRetries is the number of retries after the node answers busy, which are made after the wait
+	// time of the node. Zero disables retries.
+	BusyRetries int
+}
+
+// DefaultReconnectPolicy returns the default reconnect policy.
+func DefaultReconnectPolicy() ReconnectPolicy {
+	return ReconnectPolicy{
+		InitialInterval: DefaultReconnectInitialInterval,
+		MaxInterval:     DefaultReconnectMaxInterval,
+		MaxAttempts:     DefaultReconnectMaxAttempts,
+		BusyRetries:     securechannel.DefaultBusyRetries,
+	}
+}
+
+// Interval returns the interval before the specified retry, which starts from one.
+// The first retry is immediate since a lost session can usually be re-established at once,
+// and later retries back off exponentially from the initial interval up to the maximum interval.
+func (policy ReconnectPolicy) Interval(retry int) time.Duration {
+	if retry <= 1 {
+		return 0
+	}
+	interval := policy.InitialInterval
+	for n := 2; n < retry; n++ {
+		interval *= 2
+		if policy.MaxInterval <= interval {
+			return policy.MaxInterval
+		}
+	}
+	if 0 < policy.MaxInterval && policy.MaxInterval < interval {
+		return policy.MaxInterval
+	}
+	return interval
+}
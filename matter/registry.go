@@ -0,0 +1,476 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/cluster/label"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/storage"
+)
+
+const (
+	// NodeRegistryNamespace is the storage namespace of the commissioned node records.
+	NodeRegistryNamespace = "nodes"
+)
+
+// NodeEndpoint represents a snapshot of an endpoint of a commissioned node.
+type NodeEndpoint struct {
+	// ID is the endpoint ID.
+	ID im.EndpointID
+	// DeviceTypes is the device types of the endpoint.
+	DeviceTypes []DeviceType
+	// Clusters is the server clusters of the endpoint.
+	Clusters []im.ClusterID
+	// FixedLabels is the labels of the Fixed Label cluster of the endpoint.
+	FixedLabels []label.Label
+	// UserLabels is the labels of the User Label cluster of the endpoint.
+	UserLabels []label.Label
+}
+
+// newNodeEndpoint returns a new endpoint snapshot without metadata.
+func newNodeEndpoint(id im.EndpointID) *NodeEndpoint {
+	return &NodeEndpoint{
+		ID:          id,
+		DeviceTypes: []DeviceType{},
+		Clusters:    []im.ClusterID{},
+		FixedLabels: []label.Label{},
+		UserLabels:  []label.Label{},
+	}
+}
+
+// Label returns the value of the specified label of the endpoint, such as "room". User labels take
+// precedence over fixed labels.
+func (ep NodeEndpoint) Label(name string) (string, bool) {
+	if v, ok := label.Lookup(ep.UserLabels, name); ok {
+		return v, true
+	}
+	return label.Lookup(ep.FixedLabels, name)
+}
+
+// NodeRecord represents the metadata of a commissioned node.
+type NodeRecord struct {
+	// Peer is the operational identity and the last known address of the node.
+	Peer OperationalPeer
+	// VendorID is the vendor ID of the node.
+	VendorID VenderID
+	// ProductID is the product ID of the node.
+	ProductID ProductID
+	// Endpoints is the snapshot of the endpoints of the node in ascending order of the IDs.
+	Endpoints []NodeEndpoint
+	// Label is the local label or alias of the node.
+	Label string
+	// CommissionedAt is the time when the node is first recorded.
+	CommissionedAt time.Time
+	// LastSeen is the time when the node last answered.
+	LastSeen time.Time
+}
+
+// NewNodeRecord returns a new record of the node without metadata.
+func NewNodeRecord(peer OperationalPeer) *NodeRecord {
+	return &NodeRecord{
+		Peer:           peer,
+		VendorID:       0,
+		ProductID:      0,
+		Endpoints:      []NodeEndpoint{},
+		Label:          "",
+		CommissionedAt: time.Time{},
+		LastSeen:       time.Time{},
+	}
+}
+
+// DeviceTypes returns the device types of all endpoints in ascending order.
+func (record *NodeRecord) DeviceTypes() []DeviceType {
+	dts := []DeviceType{}
+	for _, ep := range record.Endpoints {
+		for _, dt := range ep.DeviceTypes {
+			if !slices.Contains(dts, dt) {
+				dts = append(dts, dt)
+			}
+		}
+	}
+	slices.Sort(dts)
+	return dts
+}
+
+// Name returns the label of the node, or the operational instance name if it has no label.
+func (record *NodeRecord) Name() string {
+	if record.Label != "" {
+		return record.Label
+	}
+	return record.Peer.InstanceName()
+}
+
+func newNodeEndpointStruct() *datatype.Struct {
+	return datatype.NewStruct(
+		datatype.NewField(0, new(datatype.Uint16)),
+		datatype.NewField(1, datatype.NewList(func() datatype.Value { return new(datatype.Uint32) })),
+		datatype.NewField(2, datatype.NewList(func() datatype.Value { return new(datatype.Uint32) })),
+		datatype.NewOptionalField(3, label.NewLabelList(nil)),
+		datatype.NewOptionalField(4, label.NewLabelList(nil)))
+}
+
+func newNodeRecordStruct() *datatype.Struct {
+	return datatype.NewStruct(
+		datatype.NewField(0, new(datatype.Uint64)),
+		datatype.NewField(1, new(datatype.Uint64)),
+		datatype.NewField(2, new(datatype.Uint64)),
+		datatype.NewField(3, new(datatype.String)),
+		datatype.NewField(4, new(datatype.Uint16)),
+		datatype.NewField(5, new(datatype.Uint16)),
+		datatype.NewField(6, datatype.NewList(func() datatype.Value { return newNodeEndpointStruct() })),
+		datatype.NewField(7, new(datatype.String)),
+		datatype.NewField(8, new(datatype.Int64)),
+		datatype.NewField(9, new(datatype.Int64)))
+}
+
+// unixMicro returns the persisted form of the time, which is zero for the zero time.
+func unixMicro(t time.Time) *datatype.Int64 {
+	v := datatype.Int64(0)
+	if !t.IsZero() {
+		v = datatype.Int64(t.UnixMicro())
+	}
+	return &v
+}
+
+func timeFromUnixMicro(v int64) time.Time {
+	if v == 0 {
+		return time.Time{}
+	}
+	return time.UnixMicro(v)
+}
+
+// Bytes returns the persisted form of the record.
+func (record *NodeRecord) Bytes() ([]byte, error) {
+	fabricID := datatype.Uint64(record.Peer.FabricID)
+	compressed := datatype.Uint64(record.Peer.CompressedFabricID)
+	nodeID := datatype.Uint64(record.Peer.NodeID)
+	addr := datatype.String("")
+	if record.Peer.Address.IsValid() {
+		addr = datatype.String(record.Peer.Address.String())
+	}
+	vendorID := datatype.Uint16(record.VendorID)
+	productID := datatype.Uint16(record.ProductID)
+	endpoints := datatype.NewList(func() datatype.Value { return newNodeEndpointStruct() })
+	for _, ep := range record.Endpoints {
+		id := datatype.Uint16(ep.ID)
+		dts := datatype.NewList(func() datatype.Value { return new(datatype.Uint32) })
+		for _, dt := range ep.DeviceTypes {
+			v := datatype.Uint32(dt)
+			dts.Elements = append(dts.Elements, &v)
+		}
+		clusters := datatype.NewList(func() datatype.Value { return new(datatype.Uint32) })
+		for _, c := range ep.Clusters {
+			v := datatype.Uint32(c)
+			clusters.Elements = append(clusters.Elements, &v)
+		}
+		endpoints.Elements = append(endpoints.Elements, datatype.NewStruct(
+			datatype.NewField(0, &id),
+			datatype.NewField(1, dts),
+			datatype.NewField(2, clusters),
+			datatype.NewField(3, label.NewLabelList(ep.FixedLabels)),
+			datatype.NewField(4, label.NewLabelList(ep.UserLabels))))
+	}
+	label := datatype.String(record.Label)
+	return datatype.Encode(datatype.NewStruct(
+		datatype.NewField(0, &fabricID),
+		datatype.NewField(1, &compressed),
+		datatype.NewField(2, &nodeID),
+		datatype.NewField(3, &addr),
+		datatype.NewField(4, &vendorID),
+		datatype.NewField(5, &productID),
+		datatype.NewField(6, endpoints),
+		datatype.NewField(7, &label),
+		datatype.NewField(8, unixMicro(record.CommissionedAt)),
+		datatype.NewField(9, unixMicro(record.LastSeen))))
+}
+
+// NewNodeRecordFromBytes returns the record of the persisted form.
+func NewNodeRecordFromBytes(b []byte) (*NodeRecord, error) {
+	st := newNodeRecordStruct()
+	if err := datatype.Decode(b, st); err != nil {
+		return nil, fmt.Errorf("%w node record : %w", ErrInvalid, err)
+	}
+	field := func(id uint8) datatype.Value {
+		f, _ := st.LookupField(id)
+		return f.Value
+	}
+	record := NewNodeRecord(OperationalPeer{
+		FabricID:           uint64(*field(0).(*datatype.Uint64)),
+		CompressedFabricID: uint64(*field(1).(*datatype.Uint64)),
+		NodeID:             NodeID(*field(2).(*datatype.Uint64)),
+		Address:            netip.AddrPort{},
+	})
+	if addr := string(*field(3).(*datatype.String)); addr != "" {
+		var err error
+		record.Peer.Address, err = netip.ParseAddrPort(addr)
+		if err != nil {
+			return nil, fmt.Errorf("%w node record address : %w", ErrInvalid, err)
+		}
+	}
+	record.VendorID = VenderID(*field(4).(*datatype.Uint16))
+	record.ProductID = ProductID(*field(5).(*datatype.Uint16))
+	for _, elem := range field(6).(*datatype.List).Elements {
+		ep := elem.(*datatype.Struct)
+		id, _ := ep.LookupField(0)
+		dts, _ := ep.LookupField(1)
+		clusters, _ := ep.LookupField(2)
+		endpoint := newNodeEndpoint(im.EndpointID(*id.Value.(*datatype.Uint16)))
+		for _, dt := range dts.Value.(*datatype.List).Elements {
+			endpoint.DeviceTypes = append(endpoint.DeviceTypes, DeviceType(*dt.(*datatype.Uint32)))
+		}
+		for _, c := range clusters.Value.(*datatype.List).Elements {
+			endpoint.Clusters = append(endpoint.Clusters, im.ClusterID(*c.(*datatype.Uint32)))
+		}
+		if labels, ok := ep.LookupField(3); ok && labels.Present {
+			endpoint.FixedLabels = label.NewLabelsFromList(labels.Value.(*datatype.List))
+		}
+		if labels, ok := ep.LookupField(4); ok && labels.Present {
+			endpoint.UserLabels = label.NewLabelsFromList(labels.Value.(*datatype.List))
+		}
+		record.Endpoints = append(record.Endpoints, *endpoint)
+	}
+	record.Label = string(*field(7).(*datatype.String))
+	record.CommissionedAt = timeFromUnixMicro(int64(*field(8).(*datatype.Int64)))
+	record.LastSeen = timeFromUnixMicro(int64(*field(9).(*datatype.Int64)))
+	return record, nil
+}
+
+// NodeRegistry represents a registry of the commissioned nodes which is persisted in a store.
+// Records are added or updated with Refresh after commissioning and on demand. The registry is safe
+// for concurrent use if the store is.
+type NodeRegistry struct {
+	sync.Mutex
+	store storage.Store
+	now   func() time.Time
+}
+
+// NewNodeRegistry returns a new node registry persisted in the specified store.
+func NewNodeRegistry(store storage.Store) *NodeRegistry {
+	return &NodeRegistry{
+		Mutex: sync.Mutex{},
+		store: store,
+		now:   time.Now,
+	}
+}
+
+func nodeRecordKey(peer OperationalPeer) string {
+	return peer.InstanceName()
+}
+
+// Put adds or replaces the record.
+func (reg *NodeRegistry) Put(record *NodeRecord) error {
+	b, err := record.Bytes()
+	if err != nil {
+		return err
+	}
+	return reg.store.Set(NodeRegistryNamespace, nodeRecordKey(record.Peer), b)
+}
+
+// Lookup returns the record of the node, or storage.ErrNotFound.
+func (reg *NodeRegistry) Lookup(peer OperationalPeer) (*NodeRecord, error) {
+	b, err := reg.store.Get(NodeRegistryNamespace, nodeRecordKey(peer))
+	if err != nil {
+		return nil, err
+	}
+	return NewNodeRecordFromBytes(b)
+}
+
+// LookupByName returns the record whose label or operational instance name is the specified name.
+func (reg *NodeRegistry) LookupByName(name string) (*NodeRecord, error) {
+	records, err := reg.Records()
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		if record.Label == name || record.Peer.InstanceName() == name {
+			return record, nil
+		}
+	}
+	return nil, fmt.Errorf("node (%s) is %w", name, ErrNotFound)
+}
+
+// Records returns all records in ascending order of the operational instance names.
+func (reg *NodeRegistry) Records() ([]*NodeRecord, error) {
+	keys, err := reg.store.Keys(NodeRegistryNamespace)
+	if err != nil {
+		return nil, err
+	}
+	records := []*NodeRecord{}
+	for _, key := range keys {
+		b, err := reg.store.Get(NodeRegistryNamespace, key)
+		if err != nil {
+			return nil, err
+		}
+		record, err := NewNodeRecordFromBytes(b)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// Remove removes the record of the node, such as after the node is removed from the fabric.
+func (reg *NodeRegistry) Remove(peer OperationalPeer) error {
+	return reg.store.Delete(NodeRegistryNamespace, nodeRecordKey(peer))
+}
+
+// update updates the record of the node with the function, adding a new record if the node is not recorded yet.
+func (reg *NodeRegistry) update(peer OperationalPeer, fn func(record *NodeRecord)) (*NodeRecord, error) {
+	reg.Lock()
+	defer reg.Unlock()
+	record, err := reg.Lookup(peer)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			return nil, err
+		}
+		record = NewNodeRecord(peer)
+		record.CommissionedAt = reg.now()
+	}
+	if peer.Address.IsValid() {
+		record.Peer.Address = peer.Address
+	}
+	if peer.FabricID != 0 {
+		record.Peer.FabricID = peer.FabricID
+	}
+	fn(record)
+	return record, reg.Put(record)
+}
+
+// SetLabel sets the label of the node.
+func (reg *NodeRegistry) SetLabel(peer OperationalPeer, label string) error {
+	_, err := reg.update(peer, func(record *NodeRecord) {
+		record.Label = label
+	})
+	return err
+}
+
+// Touch records that the node answered now.
+func (reg *NodeRegistry) Touch(peer OperationalPeer) error {
+	_, err := reg.update(peer, func(record *NodeRecord) {
+		record.LastSeen = reg.now()
+	})
+	return err
+}
+
+// Refresh reads the vendor and product IDs from the Basic Information cluster, and the endpoints from
+// the Descriptor, Fixed Label and User Label clusters of the node, and updates its record. The label
+// and the commissioned time are kept.
+func (reg *NodeRegistry) Refresh(ctx context.Context, dev *OperationalDevice) (*NodeRecord, error) {
+	basic, err := dev.ReadAttribute(ctx, im.NewAttributePath(0, im.ClusterBasicInformation, im.WildcardAttributeID))
+	if err != nil {
+		return nil, err
+	}
+	attrs := []im.AttributeData{}
+	for _, cluster := range []im.ClusterID{im.ClusterDescriptor, label.FixedLabelClusterID, label.UserLabelClusterID} {
+		data, err := dev.ReadAttribute(ctx, im.NewAttributePath(im.WildcardEndpointID, cluster, im.WildcardAttributeID))
+		if err != nil {
+			return nil, err
+		}
+		attrs = append(attrs, data...)
+	}
+
+	var vendorID, productID datatype.Uint16
+	for _, data := range basic {
+		switch data.Path.Attribute {
+		case im.AttributeBasicInformationVendorID:
+			err = datatype.Decode(data.Data, &vendorID)
+		case im.AttributeBasicInformationProductID:
+			err = datatype.Decode(data.Data, &productID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w %s : %w", ErrInvalid, data.Path.Name(), err)
+		}
+	}
+	endpoints, err := newNodeEndpoints(attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	return reg.update(dev.Peer(), func(record *NodeRecord) {
+		record.VendorID = VenderID(vendorID)
+		record.ProductID = ProductID(productID)
+		record.Endpoints = endpoints
+		record.LastSeen = reg.now()
+	})
+}
+
+// newNodeEndpoints returns the endpoints of the device type and server lists of the Descriptor clusters,
+// and the label lists of the Fixed Label and User Label clusters.
+func newNodeEndpoints(attrs []im.AttributeData) ([]NodeEndpoint, error) {
+	endpoints := map[im.EndpointID]*NodeEndpoint{}
+	endpoint := func(id im.EndpointID) *NodeEndpoint {
+		ep, ok := endpoints[id]
+		if !ok {
+			ep = newNodeEndpoint(id)
+			endpoints[id] = ep
+		}
+		return ep
+	}
+	for _, data := range attrs {
+		var err error
+		switch {
+		case data.Path.Cluster == im.ClusterDescriptor && data.Path.Attribute == im.AttributeDescriptorDeviceTypeList:
+			list := datatype.NewList(func() datatype.Value {
+				return datatype.NewStruct(
+					datatype.NewField(0, new(datatype.Uint32)),
+					datatype.NewField(1, new(datatype.Uint16)))
+			})
+			if err = datatype.Decode(data.Data, list); err != nil {
+				break
+			}
+			ep := endpoint(data.Path.Endpoint)
+			for _, elem := range list.Elements {
+				dt, _ := elem.(*datatype.Struct).LookupField(0)
+				ep.DeviceTypes = append(ep.DeviceTypes, DeviceType(*dt.Value.(*datatype.Uint32)))
+			}
+		case data.Path.Cluster == im.ClusterDescriptor && data.Path.Attribute == im.AttributeDescriptorServerList:
+			list := datatype.NewList(func() datatype.Value { return new(datatype.Uint32) })
+			if err = datatype.Decode(data.Data, list); err != nil {
+				break
+			}
+			ep := endpoint(data.Path.Endpoint)
+			for _, elem := range list.Elements {
+				ep.Clusters = append(ep.Clusters, im.ClusterID(*elem.(*datatype.Uint32)))
+			}
+		case data.Path.Cluster == label.FixedLabelClusterID && data.Path.Attribute == label.LabelListAttributeID:
+			endpoint(data.Path.Endpoint).FixedLabels, err = label.DecodeLabels(data.Data)
+		case data.Path.Cluster == label.UserLabelClusterID && data.Path.Attribute == label.LabelListAttributeID:
+			endpoint(data.Path.Endpoint).UserLabels, err = label.DecodeLabels(data.Data)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w %s : %w", ErrInvalid, data.Path.Name(), err)
+		}
+	}
+	ids := []im.EndpointID{}
+	for id := range endpoints {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	list := []NodeEndpoint{}
+	for _, id := range ids {
+		list = append(list, *endpoints[id])
+	}
+	return list, nil
+}
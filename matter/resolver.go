@@ -0,0 +1,192 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-mdns/mdns"
+	"github.com/cybergarage/go-safecast/safecast"
+)
+
+const (
+	// DefaultResolveQueryInterval is the default interval of DNS-SD queries while resolving an operational node.
+	DefaultResolveQueryInterval = 500 * time.Millisecond
+	// DefaultResolveQueryCount is the default number of DNS-SD queries before giving up resolving an operational node.
+	DefaultResolveQueryCount = 3
+)
+
+// OperationalResolver represents a resolver of the operational addresses of commissioned nodes.
+type OperationalResolver interface {
+	// ResolveOperational returns the current address of the specified node.
+	ResolveOperational(ctx context.Context, peer OperationalPeer) (netip.AddrPort, error)
+}
+
+// OperationalAddrsResolver is implemented by the resolvers which resolve all addresses of nodes.
+type OperationalAddrsResolver interface {
+	// ResolveOperationalAddrs returns the current addresses of the specified node in the order of preference.
+	ResolveOperationalAddrs(ctx context.Context, peer OperationalPeer) ([]netip.AddrPort, error)
+}
+
+// NullOperationalResolver represents a resolver which resolves no nodes.
+type NullOperationalResolver struct{}
+
+// NewNullOperationalResolver returns a new null operational resolver.
+func NewNullOperationalResolver() *NullOperationalResolver {
+	return &NullOperationalResolver{}
+}
+
+// ResolveOperational returns ErrNotSupported.
+func (resolver *NullOperationalResolver) ResolveOperational(ctx context.Context, peer OperationalPeer) (netip.AddrPort, error) {
+	return netip.AddrPort{}, fmt.Errorf("resolving %s is %w", peer.InstanceName(), ErrNotSupported)
+}
+
+// 4.3.2. Operational Discovery
+// DNSSDOperationalResolver represents a resolver which looks up operational nodes with DNS-SD.
+// The resolver is safe for concurrent use, and the settings may be changed while nodes are resolved.
+type DNSSDOperationalResolver struct {
+	sync.RWMutex
+	disc       *Discoverer
+	interval   time.Duration
+	count      int
+	addressing *ThreadAddressing
+}
+
+// NewDNSSDOperationalResolver returns a new DNS-SD operational resolver using the specified discoverer.
+// The discoverer must be started to receive responses.
+func NewDNSSDOperationalResolver(disc *Discoverer) *DNSSDOperationalResolver {
+	return &DNSSDOperationalResolver{
+		RWMutex:    sync.RWMutex{},
+		disc:       disc,
+		interval:   DefaultResolveQueryInterval,
+		count:      DefaultResolveQueryCount,
+		addressing: NewThreadAddressing(),
+	}
+}
+
+// SetQueryInterval sets the interval and the number of DNS-SD queries while resolving.
+func (resolver *DNSSDOperationalResolver) SetQueryInterval(d time.Duration, count int) {
+	resolver.Lock()
+	defer resolver.Unlock()
+	resolver.interval = d
+	resolver.count = count
+}
+
+// SetThreadAddressing sets the policy to order the addresses of nodes on Thread networks.
+func (resolver *DNSSDOperationalResolver) SetThreadAddressing(ta *ThreadAddressing) {
+	resolver.Lock()
+	defer resolver.Unlock()
+	resolver.addressing = ta
+}
+
+// ResolveOperational queries the operational service until the node answers, the queries
+// are exhausted or the context is done.
+func (resolver *DNSSDOperationalResolver) ResolveOperational(ctx context.Context, peer OperationalPeer) (netip.AddrPort, error) {
+	addrs, err := resolver.ResolveOperationalAddrs(ctx, peer)
+	if err != nil {
+		return netip.AddrPort{}, err
+	}
+	return addrs[0], nil
+}
+
+// ResolveOperationalAddrs queries the operational service like ResolveOperational, and returns all addresses
+// of the node in the order of preference of the Thread addressing.
+func (resolver *DNSSDOperationalResolver) ResolveOperationalAddrs(ctx context.Context, peer OperationalPeer) ([]netip.AddrPort, error) {
+	instance := peer.InstanceName()
+	host := ""
+	resolver.RLock()
+	interval, count := resolver.interval, resolver.count
+	resolver.RUnlock()
+	for n := 0; n < count; n++ {
+		err := resolver.disc.Query(mdns.NewQueryWithServices([]string{OperationalServiceType.String()}))
+		if err != nil {
+			return nil, err
+		}
+		if err := sleepContext(ctx, interval); err != nil {
+			return nil, fmt.Errorf("%s is not resolved: %w", instance, err)
+		}
+		var addrs []netip.AddrPort
+		addrs, host = resolver.lookupOperationalAddrs(peer)
+		if len(addrs) == 0 && 0 < len(host) {
+			// The SRV target host was answered without its AAAA and A records.
+			if err := resolver.disc.QueryHost(host); err != nil {
+				return nil, err
+			}
+			if err := sleepContext(ctx, interval); err != nil {
+				return nil, fmt.Errorf("%s (%s) is not resolved: %w", instance, host, err)
+			}
+			addrs, _ = resolver.lookupOperationalAddrs(peer)
+		}
+		if 0 < len(addrs) {
+			return addrs, nil
+		}
+	}
+	if 0 < len(host) {
+		return nil, fmt.Errorf("%s (%s) address is %w", instance, host, ErrNotFound)
+	}
+	return nil, fmt.Errorf("%s is %w", instance, ErrNotFound)
+}
+
+// LookupOperational returns the most preferred address of the specified node from the discovered services.
+func (resolver *DNSSDOperationalResolver) LookupOperational(peer OperationalPeer) (netip.AddrPort, bool) {
+	addrs, ok := resolver.LookupOperationalAddrs(peer)
+	if !ok {
+		return netip.AddrPort{}, false
+	}
+	return addrs[0], true
+}
+
+// LookupOperationalAddrs returns all addresses of the specified node from the discovered services
+// in the order of preference of the Thread addressing. The addresses answered for the SRV target
+// host in any response are merged.
+func (resolver *DNSSDOperationalResolver) LookupOperationalAddrs(peer OperationalPeer) ([]netip.AddrPort, bool) {
+	addrs, _ := resolver.lookupOperationalAddrs(peer)
+	return addrs, 0 < len(addrs)
+}
+
+// lookupOperationalAddrs returns the addresses of the specified node, or the SRV target host
+// without addresses if the service is discovered but the addresses of the host are not.
+func (resolver *DNSSDOperationalResolver) lookupOperationalAddrs(peer OperationalPeer) ([]netip.AddrPort, string) {
+	instance := strings.ToUpper(peer.InstanceName()) + "."
+	host := ""
+	resolver.RLock()
+	addressing := resolver.addressing
+	resolver.RUnlock()
+	for _, srv := range resolver.disc.ServiceTable().Services() {
+		if !strings.HasPrefix(strings.ToUpper(srv.Name), instance) {
+			continue
+		}
+		var port uint16
+		if safecast.ToUint16(srv.Port, &port) != nil || port == 0 {
+			continue
+		}
+		srvAddrs := addressing.SortAddrs(srv.Addrs)
+		if len(srvAddrs) == 0 {
+			host = srv.Host
+			continue
+		}
+		addrs := []netip.AddrPort{}
+		for _, addr := range srvAddrs {
+			addrs = append(addrs, netip.AddrPortFrom(addr, port))
+		}
+		return addrs, ""
+	}
+	return nil, host
+}
@@ -0,0 +1,238 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"github.com/cybergarage/go-matter/matter/datatype"
+)
+
+// newField returns a new mandatory field.
+func newField(id uint8, name string, typ datatype.Type) *Field {
+	return &Field{
+		ID:       id,
+		Name:     name,
+		Type:     typ,
+		Nullable: false,
+		Optional: false,
+		Fields:   nil,
+		Elem:     nil,
+	}
+}
+
+// newOptionalField returns a new optional field.
+func newOptionalField(id uint8, name string, typ datatype.Type) *Field {
+	field := newField(id, name, typ)
+	field.Optional = true
+	return field
+}
+
+// newNullableField returns a new nullable field.
+func newNullableField(id uint8, name string, typ datatype.Type) *Field {
+	field := newField(id, name, typ)
+	field.Nullable = true
+	return field
+}
+
+// newStructField returns a new structure field of the specified fields.
+func newStructField(id uint8, name string, fields ...*Field) *Field {
+	field := newField(id, name, datatype.StructType)
+	field.Fields = fields
+	return field
+}
+
+// newListField returns a new list field of the specified elements.
+func newListField(id uint8, name string, elem *Field) *Field {
+	field := newField(id, name, datatype.ListType)
+	field.Elem = elem
+	return field
+}
+
+func init() {
+	// 1.2. Identify Cluster
+	Register(&Cluster{
+		ID:   0x0003,
+		Name: "identify",
+		Attributes: []*Attribute{
+			{ID: 0x0000, Name: "identifyTime", Value: newField(0, "identifyTime", datatype.Uint16Type)},
+			{ID: 0x0001, Name: "identifyType", Value: newField(0, "identifyType", datatype.Enum8Type)},
+		},
+		Commands: []*Command{
+			{ID: 0x00, Name: "identify", Fields: []*Field{
+				newField(0, "identifyTime", datatype.Uint16Type),
+			}},
+			{ID: 0x40, Name: "triggerEffect", Fields: []*Field{
+				newField(0, "effectIdentifier", datatype.Enum8Type),
+				newField(1, "effectVariant", datatype.Enum8Type),
+			}},
+		},
+	})
+
+	// 1.5. On/Off Cluster
+	Register(&Cluster{
+		ID:   0x0006,
+		Name: "onOff",
+		Attributes: []*Attribute{
+			{ID: 0x0000, Name: "onOff", Value: newField(0, "onOff", datatype.BoolType)},
+			{ID: 0x4001, Name: "onTime", Value: newField(0, "onTime", datatype.Uint16Type)},
+			{ID: 0x4002, Name: "offWaitTime", Value: newField(0, "offWaitTime", datatype.Uint16Type)},
+			{ID: 0x4003, Name: "startUpOnOff", Value: newNullableField(0, "startUpOnOff", datatype.Enum8Type)},
+		},
+		Commands: []*Command{
+			{ID: 0x00, Name: "off", Fields: []*Field{}},
+			{ID: 0x01, Name: "on", Fields: []*Field{}},
+			{ID: 0x02, Name: "toggle", Fields: []*Field{}},
+			{ID: 0x40, Name: "offWithEffect", Fields: []*Field{
+				newField(0, "effectIdentifier", datatype.Enum8Type),
+				newField(1, "effectVariant", datatype.Enum8Type),
+			}},
+			{ID: 0x41, Name: "onWithRecallGlobalScene", Fields: []*Field{}},
+			{ID: 0x42, Name: "onWithTimedOff", Fields: []*Field{
+				newField(0, "onOffControl", datatype.Bitmap8Type),
+				newField(1, "onTime", datatype.Uint16Type),
+				newField(2, "offWaitTime", datatype.Uint16Type),
+			}},
+		},
+	})
+
+	// 1.6. Level Control Cluster
+	levelFields := func(fields ...*Field) []*Field {
+		return append(fields,
+			newField(uint8(len(fields)), "optionsMask", datatype.Bitmap8Type),
+			newField(uint8(len(fields)+1), "optionsOverride", datatype.Bitmap8Type))
+	}
+	Register(&Cluster{
+		ID:   0x0008,
+		Name: "levelControl",
+		Attributes: []*Attribute{
+			{ID: 0x0000, Name: "currentLevel", Value: newNullableField(0, "currentLevel", datatype.Uint8Type)},
+			{ID: 0x000F, Name: "options", Value: newField(0, "options", datatype.Bitmap8Type)},
+			{ID: 0x0010, Name: "onOffTransitionTime", Value: newField(0, "onOffTransitionTime", datatype.Uint16Type)},
+			{ID: 0x0011, Name: "onLevel", Value: newNullableField(0, "onLevel", datatype.Uint8Type)},
+			{ID: 0x4000, Name: "startUpCurrentLevel", Value: newNullableField(0, "startUpCurrentLevel", datatype.Uint8Type)},
+		},
+		Commands: []*Command{
+			{ID: 0x00, Name: "moveToLevel", Fields: levelFields(
+				newField(0, "level", datatype.Uint8Type),
+				newNullableField(1, "transitionTime", datatype.Uint16Type))},
+			{ID: 0x01, Name: "move", Fields: levelFields(
+				newField(0, "moveMode", datatype.Enum8Type),
+				newNullableField(1, "rate", datatype.Uint8Type))},
+			{ID: 0x02, Name: "step", Fields: levelFields(
+				newField(0, "stepMode", datatype.Enum8Type),
+				newField(1, "stepSize", datatype.Uint8Type),
+				newNullableField(2, "transitionTime", datatype.Uint16Type))},
+			{ID: 0x03, Name: "stop", Fields: levelFields()},
+			{ID: 0x04, Name: "moveToLevelWithOnOff", Fields: levelFields(
+				newField(0, "level", datatype.Uint8Type),
+				newNullableField(1, "transitionTime", datatype.Uint16Type))},
+			{ID: 0x05, Name: "moveWithOnOff", Fields: levelFields(
+				newField(0, "moveMode", datatype.Enum8Type),
+				newNullableField(1, "rate", datatype.Uint8Type))},
+			{ID: 0x06, Name: "stepWithOnOff", Fields: levelFields(
+				newField(0, "stepMode", datatype.Enum8Type),
+				newField(1, "stepSize", datatype.Uint8Type),
+				newNullableField(2, "transitionTime", datatype.Uint16Type))},
+			{ID: 0x07, Name: "stopWithOnOff", Fields: levelFields()},
+		},
+	})
+
+	// 11.3. Basic Information Cluster
+	Register(&Cluster{
+		ID:   0x0028,
+		Name: "basicInformation",
+		Attributes: []*Attribute{
+			{ID: 0x0005, Name: "nodeLabel", Value: newField(0, "nodeLabel", datatype.StringType)},
+			{ID: 0x0006, Name: "location", Value: newField(0, "location", datatype.StringType)},
+			{ID: 0x0010, Name: "localConfigDisabled", Value: newField(0, "localConfigDisabled", datatype.BoolType)},
+		},
+		Commands: []*Command{},
+	})
+
+	// 11.12. General Diagnostics Cluster
+	Register(&Cluster{
+		ID:   0x0033,
+		Name: "generalDiagnostics",
+		Attributes: []*Attribute{
+			{ID: 0x0001, Name: "rebootCount", Value: newField(0, "rebootCount", datatype.Uint16Type)},
+			{ID: 0x0002, Name: "upTime", Value: newField(0, "upTime", datatype.Uint64Type)},
+			{ID: 0x0008, Name: "testEventTriggersEnabled", Value: newField(0, "testEventTriggersEnabled", datatype.BoolType)},
+		},
+		Commands: []*Command{
+			{ID: 0x00, Name: "testEventTrigger", Fields: []*Field{
+				newField(0, "enableKey", datatype.OctetStringType),
+				newField(1, "eventTrigger", datatype.Uint64Type),
+			}},
+			{ID: 0x01, Name: "timeSnapshot", Fields: []*Field{}},
+		},
+	})
+
+	// 1.9. Mode Select Cluster
+	Register(&Cluster{
+		ID:   0x0050,
+		Name: "modeSelect",
+		Attributes: []*Attribute{
+			{ID: 0x0003, Name: "currentMode", Value: newField(0, "currentMode", datatype.Uint8Type)},
+			{ID: 0x0004, Name: "startUpMode", Value: newNullableField(0, "startUpMode", datatype.Uint8Type)},
+			{ID: 0x0005, Name: "onMode", Value: newNullableField(0, "onMode", datatype.Uint8Type)},
+		},
+		Commands: []*Command{
+			{ID: 0x00, Name: "changeToMode", Fields: []*Field{
+				newField(0, "newMode", datatype.Uint8Type),
+			}},
+		},
+	})
+
+	// 1.4. Scenes Management Cluster
+	extensionFieldSets := newListField(4, "extensionFieldSets", newStructField(0, "extensionFieldSet",
+		newField(0, "clusterID", datatype.Uint32Type),
+		newListField(1, "attributeValueList", newStructField(0, "attributeValuePair",
+			newField(0, "attributeID", datatype.Uint32Type),
+			newField(1, "valueUnsigned32", datatype.Uint32Type)))))
+	sceneFields := func(fields ...*Field) []*Field {
+		return append([]*Field{
+			newField(0, "groupID", datatype.Uint16Type),
+			newField(1, "sceneID", datatype.Uint8Type),
+		}, fields...)
+	}
+	Register(&Cluster{
+		ID:         0x0062,
+		Name:       "scenesManagement",
+		Attributes: []*Attribute{},
+		Commands: []*Command{
+			{ID: 0x00, Name: "addScene", Fields: sceneFields(
+				newField(2, "transitionTime", datatype.Uint32Type),
+				newField(3, "sceneName", datatype.StringType),
+				extensionFieldSets)},
+			{ID: 0x01, Name: "viewScene", Fields: sceneFields()},
+			{ID: 0x02, Name: "removeScene", Fields: sceneFields()},
+			{ID: 0x03, Name: "removeAllScenes", Fields: []*Field{
+				newField(0, "groupID", datatype.Uint16Type),
+			}},
+			{ID: 0x04, Name: "storeScene", Fields: sceneFields()},
+			{ID: 0x05, Name: "recallScene", Fields: sceneFields(
+				&Field{ID: 2, Name: "transitionTime", Type: datatype.Uint32Type, Nullable: true, Optional: true, Fields: nil, Elem: nil})},
+			{ID: 0x06, Name: "getSceneMembership", Fields: []*Field{
+				newField(0, "groupID", datatype.Uint16Type),
+			}},
+			{ID: 0x40, Name: "copyScene", Fields: []*Field{
+				newField(0, "mode", datatype.Bitmap8Type),
+				newField(1, "groupIdentifierFrom", datatype.Uint16Type),
+				newField(2, "sceneIdentifierFrom", datatype.Uint8Type),
+				newField(3, "groupIdentifierTo", datatype.Uint16Type),
+				newField(4, "sceneIdentifierTo", datatype.Uint8Type),
+			}},
+		},
+	})
+}
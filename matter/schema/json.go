@@ -0,0 +1,361 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+// HexPrefix is the prefix of octet strings written in hexadecimal as in chip-tool, such as hex:0102.
+const HexPrefix = "hex:"
+
+// parseJSON decodes the JSON text keeping numbers as they are written.
+func parseJSON(s string) (any, error) {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("%w JSON : %w", ErrInvalid, err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("%w JSON : trailing data", ErrInvalid)
+	}
+	return v, nil
+}
+
+// parseArg decodes a command line argument, which is a JSON value or a bare string as in chip-tool.
+func parseArg(field *Field, arg string) any {
+	if field.Type == datatype.StringType || field.Type == datatype.OctetStringType {
+		if !strings.HasPrefix(arg, "\"") && arg != "null" {
+			return arg
+		}
+	}
+	v, err := parseJSON(arg)
+	if err != nil {
+		return arg
+	}
+	return v
+}
+
+// newValue returns an empty value of the field to decode into.
+func (field *Field) newValue() datatype.Value {
+	var v datatype.Value
+	switch field.Type {
+	case datatype.BoolType:
+		v = new(datatype.Bool)
+	case datatype.Uint8Type:
+		v = new(datatype.Uint8)
+	case datatype.Uint16Type:
+		v = new(datatype.Uint16)
+	case datatype.Uint32Type:
+		v = new(datatype.Uint32)
+	case datatype.Uint64Type:
+		v = new(datatype.Uint64)
+	case datatype.Int8Type:
+		v = new(datatype.Int8)
+	case datatype.Int16Type:
+		v = new(datatype.Int16)
+	case datatype.Int32Type:
+		v = new(datatype.Int32)
+	case datatype.Int64Type:
+		v = new(datatype.Int64)
+	case datatype.Enum8Type:
+		v = new(datatype.Enum8)
+	case datatype.Enum16Type:
+		v = new(datatype.Enum16)
+	case datatype.Bitmap8Type:
+		v = new(datatype.Bitmap8)
+	case datatype.Bitmap16Type:
+		v = new(datatype.Bitmap16)
+	case datatype.Bitmap32Type:
+		v = new(datatype.Bitmap32)
+	case datatype.Bitmap64Type:
+		v = new(datatype.Bitmap64)
+	case datatype.PercentType:
+		v = new(datatype.Percent)
+	case datatype.Percent100thsType:
+		v = new(datatype.Percent100ths)
+	case datatype.TemperatureType:
+		v = new(datatype.Temperature)
+	case datatype.EpochSType:
+		v = new(datatype.EpochS)
+	case datatype.EpochUsType:
+		v = new(datatype.EpochUs)
+	case datatype.StringType:
+		v = new(datatype.String)
+	case datatype.OctetStringType:
+		v = new(datatype.OctetString)
+	case datatype.ListType:
+		v = datatype.NewList(field.Elem.newValue)
+	default:
+		st := datatype.NewStruct()
+		for _, f := range field.Fields {
+			if f.Optional {
+				st.Fields = append(st.Fields, datatype.NewOptionalField(f.ID, f.newValue()))
+			} else {
+				st.Fields = append(st.Fields, datatype.NewField(f.ID, f.newValue()))
+			}
+		}
+		v = st
+	}
+	if field.Nullable {
+		return datatype.NewNullable(v)
+	}
+	return v
+}
+
+func isSigned(typ datatype.Type) bool {
+	switch typ {
+	case datatype.Int8Type, datatype.Int16Type, datatype.Int32Type, datatype.Int64Type, datatype.TemperatureType:
+		return true
+	}
+	return false
+}
+
+// integerString returns the string of a JSON number, or a string of a number such as "0x10".
+func integerString(v any) (string, bool) {
+	switch v := v.(type) {
+	case json.Number:
+		return v.String(), true
+	case string:
+		return v, true
+	}
+	return "", false
+}
+
+// encode writes the JSON value of the field with the specified tag.
+func (field *Field) encode(enc *tlv.Encoder, tag tlv.Tag, v any) error {
+	if v == nil {
+		if !field.Nullable {
+			return fmt.Errorf("%w %s : null is not allowed", ErrInvalid, field.Name)
+		}
+		enc.PutNull(tag)
+		return nil
+	}
+	typeError := func() error {
+		return fmt.Errorf("%w %s : %v is not %s", ErrInvalid, field.Name, v, field.Type)
+	}
+	switch field.Type {
+	case datatype.BoolType:
+		b, ok := v.(bool)
+		if !ok {
+			return typeError()
+		}
+		enc.PutBool(tag, b)
+	case datatype.StringType:
+		s, ok := v.(string)
+		if !ok {
+			return typeError()
+		}
+		enc.PutUTF8String(tag, s)
+	case datatype.OctetStringType:
+		s, ok := v.(string)
+		if !ok {
+			return typeError()
+		}
+		b := []byte(s)
+		if strings.HasPrefix(s, HexPrefix) {
+			var err error
+			b, err = hex.DecodeString(strings.TrimPrefix(s, HexPrefix))
+			if err != nil {
+				return fmt.Errorf("%w %s : %w", ErrInvalid, field.Name, err)
+			}
+		}
+		enc.PutOctetString(tag, b)
+	case datatype.ListType:
+		elems, ok := v.([]any)
+		if !ok {
+			return typeError()
+		}
+		enc.StartArray(tag)
+		for _, elem := range elems {
+			if err := field.Elem.encode(enc, tlv.NewAnonymousTag(), elem); err != nil {
+				return err
+			}
+		}
+		enc.EndContainer()
+	case datatype.StructType:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return typeError()
+		}
+		return encodeFields(enc, tag, field.Fields, obj)
+	default:
+		s, ok := integerString(v)
+		if !ok {
+			return typeError()
+		}
+		if isSigned(field.Type) {
+			n, err := strconv.ParseInt(s, 0, 64)
+			if err != nil {
+				return typeError()
+			}
+			enc.PutSigned(tag, n)
+		} else {
+			n, err := strconv.ParseUint(s, 0, 64)
+			if err != nil {
+				return typeError()
+			}
+			enc.PutUnsigned(tag, n)
+		}
+	}
+	return nil
+}
+
+// encodeFields writes the structure of the fields whose JSON values are keyed by the field names or IDs.
+func encodeFields(enc *tlv.Encoder, tag tlv.Tag, fields []*Field, obj map[string]any) error {
+	values := map[uint8]any{}
+	for key, v := range obj {
+		field, ok := lookupField(fields, key)
+		if !ok {
+			return fmt.Errorf("field (%s) is %w", key, ErrNotFound)
+		}
+		values[field.ID] = v
+	}
+	enc.StartStructure(tag)
+	for _, field := range fields {
+		v, ok := values[field.ID]
+		if !ok {
+			if field.Optional {
+				continue
+			}
+			return fmt.Errorf("%w %s : mandatory field is missing", ErrInvalid, field.Name)
+		}
+		if err := field.encode(enc, tlv.NewContextTag(field.ID), v); err != nil {
+			return err
+		}
+	}
+	enc.EndContainer()
+	return nil
+}
+
+// validate decodes the encoded value, so values which are out of the ranges of their data types are rejected.
+func validate(b []byte, v datatype.Value, name string) ([]byte, error) {
+	if err := datatype.Decode(b, v); err != nil {
+		return nil, fmt.Errorf("%w %s : %w", ErrInvalid, name, err)
+	}
+	return b, nil
+}
+
+// EncodeJSON returns the TLV encoding of the attribute value written in JSON.
+func (attr *Attribute) EncodeJSON(s string) ([]byte, error) {
+	v, err := parseJSON(s)
+	if err != nil {
+		return nil, err
+	}
+	return attr.encode(v)
+}
+
+// EncodeArg returns the TLV encoding of the attribute value written as a command line argument,
+// which is a JSON value or a bare string.
+func (attr *Attribute) EncodeArg(arg string) ([]byte, error) {
+	return attr.encode(parseArg(attr.Value, arg))
+}
+
+func (attr *Attribute) encode(v any) ([]byte, error) {
+	enc := tlv.NewEncoder()
+	if err := attr.Value.encode(enc, tlv.NewAnonymousTag(), v); err != nil {
+		return nil, err
+	}
+	return validate(enc.Bytes(), attr.Value.newValue(), attr.Name)
+}
+
+func (cmd *Command) fieldsStruct() *Field {
+	return &Field{
+		ID:       0,
+		Name:     cmd.Name,
+		Type:     datatype.StructType,
+		Nullable: false,
+		Optional: false,
+		Fields:   cmd.Fields,
+		Elem:     nil,
+	}
+}
+
+// EncodeJSON returns the TLV encoding of the command fields written as a JSON object keyed by
+// the field names or IDs, such as {"level": 10, "transitionTime": 0}.
+func (cmd *Command) EncodeJSON(s string) ([]byte, error) {
+	v, err := parseJSON(s)
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w %s : fields are not a JSON object", ErrInvalid, cmd.Name)
+	}
+	return cmd.encode(obj)
+}
+
+// EncodeArgs returns the TLV encoding of the command fields written as command line arguments as in chip-tool.
+// The mandatory fields are passed in order, and the optional fields follow as pairs of --name and value.
+// Each value is a JSON value or a bare string.
+func (cmd *Command) EncodeArgs(args []string) ([]byte, error) {
+	obj := map[string]any{}
+	n := 0
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if name, ok := strings.CutPrefix(arg, "--"); ok {
+			field, ok := lookupField(cmd.Fields, name)
+			if !ok {
+				return nil, fmt.Errorf("field (%s) is %w", name, ErrNotFound)
+			}
+			if len(args) <= i+1 {
+				return nil, fmt.Errorf("%w %s : no value", ErrInvalid, field.Name)
+			}
+			i++
+			obj[field.Name] = parseArg(field, args[i])
+			continue
+		}
+		for n < len(cmd.Fields) && cmd.Fields[n].Optional {
+			n++
+		}
+		if len(cmd.Fields) <= n {
+			return nil, fmt.Errorf("%w %s : too many arguments", ErrInvalid, cmd.Name)
+		}
+		obj[cmd.Fields[n].Name] = parseArg(cmd.Fields[n], arg)
+		n++
+	}
+	return cmd.encode(obj)
+}
+
+func (cmd *Command) encode(obj map[string]any) ([]byte, error) {
+	enc := tlv.NewEncoder()
+	if err := encodeFields(enc, tlv.NewAnonymousTag(), cmd.Fields, obj); err != nil {
+		return nil, fmt.Errorf("%s : %w", cmd.Name, err)
+	}
+	return validate(enc.Bytes(), cmd.fieldsStruct().newValue(), cmd.Name)
+}
+
+// String returns the chip-tool style usage of the command such as move-to-level level transitionTime.
+func (cmd *Command) String() string {
+	var b bytes.Buffer
+	b.WriteString(cmd.Name)
+	for _, field := range cmd.Fields {
+		if field.Optional {
+			fmt.Fprintf(&b, " [--%s]", field.Name)
+		} else {
+			fmt.Fprintf(&b, " %s", field.Name)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// Field represents a command field, a structure field, or an attribute value.
+type Field struct {
+	// ID is the field ID, which is not used for attribute values and list elements.
+	ID uint8
+	// Name is the field name in lower camel case as in the specification.
+	Name string
+	// Type is the data type.
+	Type datatype.Type
+	// Nullable is true if the field can be null.
+	Nullable bool
+	// Optional is true if the field can be omitted.
+	Optional bool
+	// Fields are the fields of a structure.
+	Fields []*Field
+	// Elem is the element of a list.
+	Elem *Field
+}
+
+// Attribute represents the metadata of an attribute.
+type Attribute struct {
+	// ID is the attribute ID.
+	ID im.AttributeID
+	// Name is the attribute name in lower camel case.
+	Name string
+	// Value is the value of the attribute.
+	Value *Field
+}
+
+// Command represents the metadata of a command.
+type Command struct {
+	// ID is the command ID.
+	ID im.CommandID
+	// Name is the command name in lower camel case.
+	Name string
+	// Fields are the command fields.
+	Fields []*Field
+}
+
+// Cluster represents the metadata of a cluster.
+type Cluster struct {
+	// ID is the cluster ID.
+	ID im.ClusterID
+	// Name is the cluster name in lower camel case.
+	Name string
+	// Attributes are the attributes.
+	Attributes []*Attribute
+	// Commands are the commands accepted by the server.
+	Commands []*Command
+}
+
+var registry = struct {
+	sync.RWMutex
+	clusters map[im.ClusterID]*Cluster
+}{
+	RWMutex:  sync.RWMutex{},
+	clusters: map[im.ClusterID]*Cluster{},
+}
+
+// Register registers the metadata of the specified cluster, which replaces the registered one of the same ID.
+// Applications register the metadata of their manufacturer-specific clusters.
+func Register(cluster *Cluster) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.clusters[cluster.ID] = cluster
+}
+
+// Clusters returns the registered clusters in ascending order of the IDs.
+func Clusters() []*Cluster {
+	registry.RLock()
+	defer registry.RUnlock()
+	clusters := make([]*Cluster, 0, len(registry.clusters))
+	for _, cluster := range registry.clusters {
+		clusters = append(clusters, cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].ID < clusters[j].ID })
+	return clusters
+}
+
+// normalizeName returns the name without cases and separators, so the names in the specification
+// such as MoveToLevel match the names of chip-tool such as move-to-level.
+func normalizeName(name string) string {
+	return strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(name))
+}
+
+// parseID returns the ID of the name if it is a number such as 6 or 0x0006.
+func parseID(name string) (uint64, bool) {
+	id, err := strconv.ParseUint(name, 0, 32)
+	return id, err == nil
+}
+
+// LookupCluster returns the cluster of the specified name or ID.
+func LookupCluster(name string) (*Cluster, error) {
+	id, isID := parseID(name)
+	for _, cluster := range Clusters() {
+		if (isID && uint64(cluster.ID) == id) || normalizeName(cluster.Name) == normalizeName(name) {
+			return cluster, nil
+		}
+	}
+	return nil, fmt.Errorf("cluster (%s) is %w", name, ErrNotFound)
+}
+
+// LookupAttribute returns the attribute of the specified name or ID.
+func (cluster *Cluster) LookupAttribute(name string) (*Attribute, error) {
+	id, isID := parseID(name)
+	for _, attr := range cluster.Attributes {
+		if (isID && uint64(attr.ID) == id) || normalizeName(attr.Name) == normalizeName(name) {
+			return attr, nil
+		}
+	}
+	return nil, fmt.Errorf("attribute (%s) of %s is %w", name, cluster.Name, ErrNotFound)
+}
+
+// LookupCommand returns the command of the specified name or ID.
+func (cluster *Cluster) LookupCommand(name string) (*Command, error) {
+	id, isID := parseID(name)
+	for _, cmd := range cluster.Commands {
+		if (isID && uint64(cmd.ID) == id) || normalizeName(cmd.Name) == normalizeName(name) {
+			return cmd, nil
+		}
+	}
+	return nil, fmt.Errorf("command (%s) of %s is %w", name, cluster.Name, ErrNotFound)
+}
+
+// lookupField returns the field of the specified name or ID.
+func lookupField(fields []*Field, name string) (*Field, bool) {
+	id, isID := parseID(name)
+	for _, field := range fields {
+		if (isID && uint64(field.ID) == id) || normalizeName(field.Name) == normalizeName(name) {
+			return field, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,224 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		cluster string
+		command string
+	}{
+		{"levelControl", "moveToLevel"},
+		{"level-control", "move-to-level"},
+		{"LevelControl", "MoveToLevel"},
+		{"0x0008", "0"},
+		{"8", "0x00"},
+	}
+	for _, test := range tests {
+		cluster, err := LookupCluster(test.cluster)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cmd, err := cluster.LookupCommand(test.command)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cmd.ID != 0x00 || cluster.ID != 0x0008 {
+			t.Errorf("%s %s : 0x%04X 0x%02X", test.cluster, test.command, cluster.ID, cmd.ID)
+		}
+	}
+	if _, err := LookupCluster("unknown"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("%v is not %v", err, ErrNotFound)
+	}
+}
+
+func TestCommandEncoding(t *testing.T) {
+	level := datatype.Uint8(10)
+	transition := datatype.Uint16(5)
+	mask := datatype.Bitmap8(0)
+	override := datatype.Bitmap8(0)
+	moveToLevel, err := datatype.Encode(datatype.NewStruct(
+		datatype.NewField(0, &level),
+		datatype.NewField(1, datatype.NewNullable(&transition)),
+		datatype.NewField(2, &mask),
+		datatype.NewField(3, &override)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	moveToLevelNull, err := datatype.Encode(datatype.NewStruct(
+		datatype.NewField(0, &level),
+		datatype.NewField(1, datatype.NewNull(&transition)),
+		datatype.NewField(2, &mask),
+		datatype.NewField(3, &override)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		cluster  string
+		command  string
+		json     string
+		args     []string
+		expected []byte
+	}{
+		{"levelControl", "moveToLevel", `{"level": 10, "transitionTime": 5, "optionsMask": 0, "optionsOverride": 0}`, []string{"10", "5", "0", "0"}, moveToLevel},
+		{"levelControl", "moveToLevel", `{"0": 10, "1": null, "2": 0, "3": "0x00"}`, []string{"10", "null", "0", "0"}, moveToLevelNull},
+	}
+	for _, test := range tests {
+		cluster, err := LookupCluster(test.cluster)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cmd, err := cluster.LookupCommand(test.command)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := cmd.EncodeJSON(test.json)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, test.expected) {
+			t.Errorf("%s : %X != %X", test.json, b, test.expected)
+		}
+		b, err = cmd.EncodeArgs(test.args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, test.expected) {
+			t.Errorf("%v : %X != %X", test.args, b, test.expected)
+		}
+	}
+}
+
+func TestCommandEncodingErrors(t *testing.T) {
+	tests := []struct {
+		cluster string
+		command string
+		json    string
+	}{
+		// Missing mandatory field
+		{"levelControl", "moveToLevel", `{"level": 10}`},
+		// Out of range
+		{"levelControl", "moveToLevel", `{"level": 256, "transitionTime": 0, "optionsMask": 0, "optionsOverride": 0}`},
+		// Null of a non-nullable field
+		{"levelControl", "moveToLevel", `{"level": null, "transitionTime": 0, "optionsMask": 0, "optionsOverride": 0}`},
+		// Unknown field
+		{"identify", "identify", `{"identifyTime": 10, "unknown": 1}`},
+		// Type mismatch
+		{"identify", "identify", `{"identifyTime": "ten"}`},
+		// Malformed octet string
+		{"generalDiagnostics", "testEventTrigger", `{"enableKey": "hex:0", "eventTrigger": 1}`},
+		// Not an object
+		{"identify", "identify", `[10]`},
+	}
+	for _, test := range tests {
+		cluster, err := LookupCluster(test.cluster)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cmd, err := cluster.LookupCommand(test.command)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := cmd.EncodeJSON(test.json); err == nil {
+			t.Errorf("%s %s", cmd.Name, test.json)
+		}
+	}
+}
+
+func TestNestedEncoding(t *testing.T) {
+	cluster, err := LookupCluster("scenesManagement")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd, err := cluster.LookupCommand("add-scene")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := cmd.EncodeArgs([]string{"1", "2", "1000", "Evening", `[{"clusterID": 8, "attributeValueList": [{"attributeID": 0, "valueUnsigned32": 200}]}]`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := datatype.NewStruct(
+		datatype.NewField(0, new(datatype.Uint16)),
+		datatype.NewField(1, new(datatype.Uint8)),
+		datatype.NewField(2, new(datatype.Uint32)),
+		datatype.NewField(3, new(datatype.String)),
+		datatype.NewField(4, cmd.Fields[4].newValue()))
+	if err := datatype.Decode(b, fields); err != nil {
+		t.Fatal(err)
+	}
+	name, _ := fields.LookupField(3)
+	if *name.Value.(*datatype.String) != "Evening" {
+		t.Errorf("%s", *name.Value.(*datatype.String))
+	}
+	sets, _ := fields.LookupField(4)
+	if n := len(sets.Value.(*datatype.List).Elements); n != 1 {
+		t.Errorf("%d extension field sets", n)
+	}
+
+	cmd, err = cluster.LookupCommand("recallScene")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cmd.EncodeArgs([]string{"1", "2", "--transitionTime", "null"}); err != nil {
+		t.Error(err)
+	}
+	if _, err := cmd.EncodeArgs([]string{"1", "2", "3"}); err == nil {
+		t.Error("too many arguments are accepted")
+	}
+}
+
+func TestAttributeEncoding(t *testing.T) {
+	tests := []struct {
+		cluster   string
+		attribute string
+		arg       string
+		expected  datatype.Value
+	}{
+		{"basicInformation", "nodeLabel", "Kitchen", func() datatype.Value { v := datatype.String("Kitchen"); return &v }()},
+		{"basicInformation", "nodeLabel", `"Kitchen"`, func() datatype.Value { v := datatype.String("Kitchen"); return &v }()},
+		{"levelControl", "onLevel", "null", datatype.NewNull(new(datatype.Uint8))},
+		{"onOff", "onTime", "0x10", func() datatype.Value { v := datatype.Uint16(16); return &v }()},
+	}
+	for _, test := range tests {
+		cluster, err := LookupCluster(test.cluster)
+		if err != nil {
+			t.Fatal(err)
+		}
+		attr, err := cluster.LookupAttribute(test.attribute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := attr.EncodeArg(test.arg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, err := datatype.Encode(test.expected)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, expected) {
+			t.Errorf("%s : %X != %X", test.arg, b, expected)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securechannel
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/trace"
+)
+
+const (
+	// DefaultBusyRetries is the default number of retries after busy statuses.
+	DefaultBusyRetries = 3
+	// DefaultBusyWait is the wait time before retrying after busy statuses without the wait time.
+	DefaultBusyWait = 500 * time.Millisecond
+)
+
+// RetryBusy runs the PASE or CASE establishment, and runs it again after the wait time while the responder
+// answers the PBKDFParamRequest or Sigma1 message with busy statuses, up to the specified number of retries.
+// Each busy status is traced as a SessionBusyPhase event of the peer with the *BusyError.
+func RetryBusy(ctx context.Context, peer string, retries int, establish func(context.Context) error) error {
+	for retry := 0; ; retry++ {
+		err := establish(ctx)
+		var busyErr *BusyError
+		if !errors.As(err, &busyErr) {
+			return err
+		}
+		trace.SharedTracer().TraceSession(&trace.SessionEvent{
+			Time:      time.Now(),
+			Peer:      peer,
+			SessionID: 0,
+			Phase:     trace.SessionBusyPhase,
+			Err:       busyErr,
+		})
+		if retries <= retry {
+			return err
+		}
+		wait := busyErr.Wait
+		if wait <= 0 {
+			wait = DefaultBusyWait
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
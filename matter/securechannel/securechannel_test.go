@@ -0,0 +1,125 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securechannel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	matterr "github.com/cybergarage/go-matter/matter/errors"
+	"github.com/cybergarage/go-matter/matter/trace"
+)
+
+func TestStatusReport(t *testing.T) {
+	busy := NewBusyStatusReport(1500 * time.Millisecond)
+	b := busy.Bytes()
+	if expected := []byte{0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x04, 0x00, 0xDC, 0x05}; !bytes.Equal(b, expected) {
+		t.Errorf("%X != %X", b, expected)
+	}
+	report, err := NewStatusReportFromBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.IsBusy() {
+		t.Errorf("%+v is not busy", report)
+	}
+	if wait, ok := report.BusyWait(); !ok || wait != 1500*time.Millisecond {
+		t.Errorf("wait (%s) != (%s)", wait, 1500*time.Millisecond)
+	}
+	var busyErr *BusyError
+	if err := report.Err(); !errors.As(err, &busyErr) || busyErr.Wait != 1500*time.Millisecond || !errors.Is(err, ErrBusy) || !errors.Is(err, matterr.ErrRejected) {
+		t.Errorf("%v is not a busy error", err)
+	}
+
+	if err := NewStatusReport(GeneralSuccess, SessionEstablishmentSuccess, nil).Err(); err != nil {
+		t.Error(err)
+	}
+	if err := NewStatusReport(GeneralFailure, InvalidParameter, nil).Err(); !errors.Is(err, ErrFailure) || errors.Is(err, ErrBusy) {
+		t.Errorf("%v is not %v", err, ErrFailure)
+	}
+	// Busy statuses without the wait time are retried after the default wait.
+	if err := NewStatusReport(GeneralBusy, Busy, nil).Err(); !errors.As(err, &busyErr) || busyErr.Wait != 0 {
+		t.Errorf("%v", err)
+	}
+	if _, err := NewStatusReportFromBytes(b[:7]); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+}
+
+type busyTracer struct {
+	trace.NullTracer
+	events []*trace.SessionEvent
+}
+
+func (tracer *busyTracer) TraceSession(e *trace.SessionEvent) {
+	tracer.events = append(tracer.events, e)
+}
+
+func TestRetryBusy(t *testing.T) {
+	tracer := &busyTracer{NullTracer: trace.NullTracer{}, events: nil}
+	trace.SetSharedTracer(tracer)
+	defer trace.SetSharedTracer(nil)
+
+	busy := NewBusyStatusReport(10 * time.Millisecond).Err()
+	attempts := 0
+	start := time.Now()
+	err := RetryBusy(context.Background(), "peer", 3, func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return busy
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 3 || time.Since(start) < 20*time.Millisecond {
+		t.Errorf("attempts (%d) in %s", attempts, time.Since(start))
+	}
+	if len(tracer.events) != 2 || tracer.events[0].Phase != trace.SessionBusyPhase || tracer.events[0].Peer != "peer" {
+		t.Errorf("%v", tracer.events)
+	}
+
+	// The busy status is returned when the retries are exhausted.
+	attempts = 0
+	err = RetryBusy(context.Background(), "peer", 1, func(ctx context.Context) error {
+		attempts++
+		return busy
+	})
+	if !errors.Is(err, ErrBusy) || attempts != 2 {
+		t.Errorf("%v attempts (%d)", err, attempts)
+	}
+
+	// Other errors are not retried.
+	errTest := errors.New("test")
+	attempts = 0
+	if err := RetryBusy(context.Background(), "peer", 3, func(ctx context.Context) error {
+		attempts++
+		return errTest
+	}); !errors.Is(err, errTest) || attempts != 1 {
+		t.Errorf("%v attempts (%d)", err, attempts)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := RetryBusy(ctx, "peer", 3, func(ctx context.Context) error {
+		return &BusyError{Wait: time.Hour}
+	}); !errors.Is(err, context.Canceled) {
+		t.Errorf("%v is not %v", err, context.Canceled)
+	}
+}
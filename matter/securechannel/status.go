@@ -0,0 +1,164 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securechannel
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/protocol"
+)
+
+const (
+	// ProtocolID is the protocol ID of the secure channel protocol.
+	ProtocolID protocol.ProtocolID = 0x0000
+	// StatusReportOpcode is the opcode of the StatusReport message.
+	StatusReportOpcode protocol.Opcode = 0x40
+
+	statusReportMinSize = 8
+)
+
+// 4.10.1.2. General Status Codes
+// GeneralCode represents a general status code of a StatusReport message.
+type GeneralCode uint16
+
+const (
+	GeneralSuccess           GeneralCode = 0
+	GeneralFailure           GeneralCode = 1
+	GeneralBadPrecondition   GeneralCode = 2
+	GeneralOutOfRange        GeneralCode = 3
+	GeneralBadRequest        GeneralCode = 4
+	GeneralUnsupported       GeneralCode = 5
+	GeneralUnexpected        GeneralCode = 6
+	GeneralResourceExhausted GeneralCode = 7
+	GeneralBusy              GeneralCode = 8
+	GeneralTimeout           GeneralCode = 9
+	GeneralContinue          GeneralCode = 10
+	GeneralAborted           GeneralCode = 11
+	GeneralInvalidArgument   GeneralCode = 12
+	GeneralNotFound          GeneralCode = 13
+	GeneralAlreadyExists     GeneralCode = 14
+	GeneralPermissionDenied  GeneralCode = 15
+	GeneralDataLoss          GeneralCode = 16
+)
+
+// 4.11.1.4. Secure Channel Protocol Codes
+// ProtocolCode represents a protocol specific status code of the secure channel protocol.
+type ProtocolCode uint16
+
+const (
+	SessionEstablishmentSuccess ProtocolCode = 0x0000
+	NoSharedTrustRoots          ProtocolCode = 0x0001
+	InvalidParameter            ProtocolCode = 0x0002
+	CloseSession                ProtocolCode = 0x0003
+	Busy                        ProtocolCode = 0x0004
+)
+
+// 4.10.1. Status Report Message
+// StatusReport represents a StatusReport message.
+type StatusReport struct {
+	GeneralCode  GeneralCode
+	ProtocolID   uint32
+	ProtocolCode ProtocolCode
+	ProtocolData []byte
+}
+
+// NewStatusReport returns a new StatusReport message of the secure channel protocol.
+func NewStatusReport(general GeneralCode, code ProtocolCode, data []byte) *StatusReport {
+	return &StatusReport{
+		GeneralCode:  general,
+		ProtocolID:   uint32(ProtocolID),
+		ProtocolCode: code,
+		ProtocolData: data,
+	}
+}
+
+// 4.11.1.5. Busy
+// NewBusyStatusReport returns a new StatusReport message which asks the initiator to retry after the wait time.
+func NewBusyStatusReport(wait time.Duration) *StatusReport {
+	ms := wait.Milliseconds()
+	ms = max(0, min(ms, 0xFFFF))
+	return NewStatusReport(GeneralBusy, Busy, binary.LittleEndian.AppendUint16(nil, uint16(ms)))
+}
+
+// NewStatusReportFromBytes returns a new StatusReport message decoded from the specified payload.
+func NewStatusReportFromBytes(b []byte) (*StatusReport, error) {
+	if len(b) < statusReportMinSize {
+		return nil, fmt.Errorf("%w status report : short message (%d)", ErrInvalid, len(b))
+	}
+	return &StatusReport{
+		GeneralCode:  GeneralCode(binary.LittleEndian.Uint16(b)),
+		ProtocolID:   binary.LittleEndian.Uint32(b[2:]),
+		ProtocolCode: ProtocolCode(binary.LittleEndian.Uint16(b[6:])),
+		ProtocolData: append([]byte{}, b[statusReportMinSize:]...),
+	}, nil
+}
+
+// Bytes returns the encoded payload.
+func (report *StatusReport) Bytes() []byte {
+	b := make([]byte, 0, statusReportMinSize+len(report.ProtocolData))
+	b = binary.LittleEndian.AppendUint16(b, uint16(report.GeneralCode))
+	b = binary.LittleEndian.AppendUint32(b, report.ProtocolID)
+	b = binary.LittleEndian.AppendUint16(b, uint16(report.ProtocolCode))
+	return append(b, report.ProtocolData...)
+}
+
+// IsSecureChannel returns true if the status is of the secure channel protocol.
+func (report *StatusReport) IsSecureChannel() bool {
+	return report.ProtocolID == uint32(ProtocolID)
+}
+
+// IsBusy returns true if the responder is busy.
+func (report *StatusReport) IsBusy() bool {
+	return report.IsSecureChannel() && report.GeneralCode == GeneralBusy && report.ProtocolCode == Busy
+}
+
+// BusyWait returns the minimum wait time before retrying, which is carried in the protocol data of
+// a busy status.
+func (report *StatusReport) BusyWait() (time.Duration, bool) {
+	if !report.IsBusy() || len(report.ProtocolData) < 2 {
+		return 0, false
+	}
+	return time.Duration(binary.LittleEndian.Uint16(report.ProtocolData)) * time.Millisecond, true
+}
+
+// Err returns nil for successful statuses, a *BusyError for busy statuses, and an ErrFailure error otherwise.
+func (report *StatusReport) Err() error {
+	if report.GeneralCode == GeneralSuccess {
+		return nil
+	}
+	if report.IsBusy() {
+		wait, _ := report.BusyWait()
+		return &BusyError{Wait: wait}
+	}
+	return fmt.Errorf("%w : general code (%d) protocol (0x%08X) code (0x%04X)", ErrFailure, report.GeneralCode, report.ProtocolID, uint16(report.ProtocolCode))
+}
+
+// BusyError represents a busy status of a responder with the minimum wait time before retrying.
+type BusyError struct {
+	// Wait is the minimum wait time before retrying, which is zero if the responder did not send it.
+	Wait time.Duration
+}
+
+// Error returns the string representation.
+func (e *BusyError) Error() string {
+	return fmt.Sprintf("%s (retry after %s)", ErrBusy, e.Wait)
+}
+
+// Unwrap returns ErrBusy.
+func (e *BusyError) Unwrap() error {
+	return ErrBusy
+}
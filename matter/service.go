@@ -0,0 +1,99 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cybergarage/go-mdns/mdns"
+)
+
+// 4.3. Discovery
+// ServiceType represents a DNS-SD service type of Matter nodes.
+type ServiceType string
+
+const (
+	// CommissionableServiceType is the service type of commissionable nodes (4.3.1).
+	CommissionableServiceType ServiceType = "_matterc._udp"
+	// OperationalServiceType is the service type of commissioned nodes (4.3.2).
+	OperationalServiceType ServiceType = "_matter._tcp"
+	// CommissionerServiceType is the service type of commissioners for user-directed commissioning (4.3.3).
+	CommissionerServiceType ServiceType = "_matterd._udp"
+)
+
+// ServiceTypes returns all service types of Matter nodes.
+func ServiceTypes() []ServiceType {
+	return []ServiceType{
+		CommissionableServiceType,
+		OperationalServiceType,
+		CommissionerServiceType,
+	}
+}
+
+// NewServiceTypeFromString returns the service type of the specified name, which is either
+// the service type itself or one of "commissionable", "operational" and "commissioner".
+func NewServiceTypeFromString(s string) (ServiceType, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "commissionable", string(CommissionableServiceType):
+		return CommissionableServiceType, nil
+	case "operational", string(OperationalServiceType):
+		return OperationalServiceType, nil
+	case "commissioner", string(CommissionerServiceType):
+		return CommissionerServiceType, nil
+	}
+	return "", fmt.Errorf("%w service type: %s", ErrNotSupported, s)
+}
+
+// String returns the string representation.
+func (typ ServiceType) String() string {
+	return string(typ)
+}
+
+// matches returns true if the specified service instance name belongs to the service type.
+func (typ ServiceType) matches(name string) bool {
+	return strings.Contains(strings.ToLower(name), "."+string(typ)+".")
+}
+
+// Browse queries the specified service types, or commissionable nodes if no type is specified,
+// and returns the services of the types which are discovered until the context is done.
+func (disc *Discoverer) Browse(ctx context.Context, types ...ServiceType) ([]*mdns.Service, error) {
+	if len(types) == 0 {
+		types = []ServiceType{CommissionableServiceType}
+	}
+	names := make([]string, len(types))
+	for n, typ := range types {
+		names[n] = typ.String()
+	}
+
+	err := disc.Query(mdns.NewQueryWithServices(names))
+	if err != nil {
+		return nil, err
+	}
+
+	<-ctx.Done()
+
+	services := []*mdns.Service{}
+	for _, srv := range disc.Services() {
+		for _, typ := range types {
+			if typ.matches(srv.Name) {
+				services = append(services, srv)
+				break
+			}
+		}
+	}
+	return services, nil
+}
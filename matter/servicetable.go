@@ -0,0 +1,240 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"net"
+	"net/netip"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-mdns/mdns"
+	"github.com/cybergarage/go-mdns/mdns/dns"
+)
+
+// DiscoveredService represents a service instance merged from all the responses which answered it,
+// such as repeated announcements and responses received on multiple interfaces.
+type DiscoveredService struct {
+	*mdns.Service
+	// Addrs is the union of the addresses answered for the host of the service.
+	Addrs []netip.Addr
+	// Updated is the time when the TXT data of the service was last answered.
+	Updated time.Time
+}
+
+// 4.3.4. TXT key for ICD operating mode (ICD)
+// LookupICDOperatingMode returns the ICD operating mode, which is advertised only by ICDs.
+func (srv *DiscoveredService) LookupICDOperatingMode() (ICDOperatingMode, bool) {
+	return lookupICDOperatingMode(srv.Service)
+}
+
+// 4.3.4. TXT key for Joint Fabric (JF)
+// LookupJointFabricMode returns the Joint Fabric mode.
+func (srv *DiscoveredService) LookupJointFabricMode() (JointFabricMode, bool) {
+	return lookupJointFabricMode(srv.Service)
+}
+
+// 4.3.1.8. TXT key for device type (DT)
+// LookupDeviceType returns the device type, whose registered name is shown by its String.
+func (srv *DiscoveredService) LookupDeviceType() (DeviceType, bool) {
+	return NewCommissioneeWithService(srv.Service).LookupDeviceType()
+}
+
+// serviceEntry represents the merged records of a service instance.
+type serviceEntry struct {
+	name    string
+	host    string
+	port    uint
+	attrs   dns.Attributes
+	msg     *dns.Message
+	updated time.Time
+}
+
+// ServiceTable represents the discovered service instances keyed by the instance names.
+// Instance names are compared case-insensitively. The table is safe for concurrent use, since the
+// discoverer updates it while applications read it.
+type ServiceTable struct {
+	sync.RWMutex
+	entries map[string]*serviceEntry
+	hosts   *hostAddrs
+	now     func() time.Time
+}
+
+// NewServiceTable returns a new empty service table.
+func NewServiceTable() *ServiceTable {
+	return &ServiceTable{
+		RWMutex: sync.RWMutex{},
+		entries: map[string]*serviceEntry{},
+		hosts:   newHostAddrs(),
+		now:     time.Now,
+	}
+}
+
+// serviceKey returns the key of the specified instance name.
+func serviceKey(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// Update merges the PTR, SRV, TXT, AAAA and A records of the specified response.
+func (table *ServiceTable) Update(msg *dns.Message) {
+	if !msg.IsResponse() {
+		return
+	}
+	table.hosts.update(msg)
+
+	table.Lock()
+	defer table.Unlock()
+	now := table.now()
+	entry := func(name string) *serviceEntry {
+		key := serviceKey(name)
+		e, ok := table.entries[key]
+		if !ok {
+			e = &serviceEntry{
+				name:    strings.TrimSuffix(name, "."),
+				host:    "",
+				port:    0,
+				attrs:   dns.Attributes{},
+				msg:     nil,
+				updated: time.Time{},
+			}
+			table.entries[key] = e
+		}
+		e.msg = msg
+		return e
+	}
+	names := table.instanceNames(msg)
+	for _, record := range msg.ResourceRecords() {
+		switch rr := record.(type) {
+		case *dns.SRVRecord:
+			name, ok := lookupRecordName(record, names)
+			if !ok {
+				continue
+			}
+			e := entry(name)
+			if host := rr.Target(); 0 < len(host) {
+				e.host = host
+			}
+			if port := rr.Port(); 0 < port {
+				e.port = port
+			}
+		case *dns.TXTRecord:
+			name, ok := lookupRecordName(record, names)
+			if !ok {
+				continue
+			}
+			attrs, err := rr.Attributes()
+			if err != nil {
+				continue
+			}
+			e := entry(name)
+			e.attrs = attrs
+			e.updated = now
+		}
+	}
+}
+
+// instanceNames returns the instance names which the PTR records of the specified message point to,
+// and the names of the known instances.
+func (table *ServiceTable) instanceNames(msg *dns.Message) []string {
+	names := []string{}
+	for _, record := range msg.ResourceRecords() {
+		if ptr, ok := record.(*dns.PTRRecord); ok && 0 < len(ptr.DomainName()) {
+			names = append(names, ptr.DomainName())
+		}
+	}
+	for _, e := range table.entries {
+		names = append(names, e.name)
+	}
+	return names
+}
+
+// lookupRecordName returns the instance name of the specified record. The owner names of SRV
+// records are not decoded by the mDNS client, so they are only compared with the known names.
+func lookupRecordName(record dns.ResourceRecord, names []string) (string, bool) {
+	for _, name := range names {
+		name = strings.TrimSuffix(name, ".")
+		if record.IsName(name) || record.IsName(name+".") {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// Lookup returns the service of the specified instance name.
+func (table *ServiceTable) Lookup(name string) (*DiscoveredService, bool) {
+	table.RLock()
+	defer table.RUnlock()
+	e, ok := table.entries[serviceKey(name)]
+	if !ok {
+		return nil, false
+	}
+	return table.service(e), true
+}
+
+// Len returns the number of the services.
+func (table *ServiceTable) Len() int {
+	table.RLock()
+	defer table.RUnlock()
+	return len(table.entries)
+}
+
+// Services returns the services in ascending order of the instance names.
+func (table *ServiceTable) Services() []*DiscoveredService {
+	table.RLock()
+	defer table.RUnlock()
+	keys := make([]string, 0, len(table.entries))
+	for key := range table.entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	services := make([]*DiscoveredService, len(keys))
+	for n, key := range keys {
+		services[n] = table.service(table.entries[key])
+	}
+	return services
+}
+
+// LookupHostAddrs returns the addresses answered for the specified host.
+func (table *ServiceTable) LookupHostAddrs(host string) []netip.Addr {
+	return table.hosts.Lookup(host)
+}
+
+// service returns a snapshot of the specified entry.
+func (table *ServiceTable) service(e *serviceEntry) *DiscoveredService {
+	srv := mdns.NewService(e.name, "", e.port)
+	srv.Message = e.msg
+	srv.Host = e.host
+	srv.Attributes = slices.Clone(e.attrs)
+	addrs := []netip.Addr{}
+	if 0 < len(e.host) {
+		addrs = table.hosts.Lookup(e.host)
+	}
+	for _, addr := range addrs {
+		switch {
+		case addr.Is6() && srv.AddrV6 == nil:
+			srv.AddrV6 = net.IP(addr.AsSlice())
+		case addr.Is4() && srv.AddrV4 == nil:
+			srv.AddrV4 = net.IP(addr.AsSlice())
+		}
+	}
+	return &DiscoveredService{
+		Service: srv,
+		Addrs:   addrs,
+		Updated: e.updated,
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"sort"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// SessionState represents a read-only snapshot of a secure session for debugging. It holds
+// the metadata of the session, and never the session keys.
+type SessionState struct {
+	// ID is the local session ID.
+	ID message.SessionID
+	// Type is the session establishment protocol.
+	Type Type
+	// FabricIndex is the fabric of the peer.
+	FabricIndex types.FabricIndex
+	// PeerNodeID is the node ID of the peer.
+	PeerNodeID message.NodeID
+	// Peer is the address of the peer.
+	Peer string
+	// LastActive is the time when the session was last used.
+	LastActive time.Time
+}
+
+// TableState represents a read-only snapshot of the secure session table for debugging.
+type TableState struct {
+	// Limits is the limits of the table.
+	Limits Limits
+	// Sessions is the sessions from the least recently used one.
+	Sessions []SessionState
+}
+
+// Debug returns a snapshot of the table, which is not changed by later operations on the table.
+func (table *Table) Debug() TableState {
+	table.Lock()
+	defer table.Unlock()
+	sessions := make([]*SecureSession, 0, len(table.sessions))
+	for _, s := range table.sessions {
+		sessions = append(sessions, s)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].lru < sessions[j].lru
+	})
+	states := make([]SessionState, len(sessions))
+	for n, s := range sessions {
+		states[n] = SessionState{
+			ID:          s.ID,
+			Type:        s.Type,
+			FabricIndex: s.FabricIndex,
+			PeerNodeID:  s.PeerNodeID,
+			Peer:        s.Peer,
+			LastActive:  s.LastActive,
+		}
+	}
+	return TableState{
+		Limits:   table.limits,
+		Sessions: states,
+	}
+}
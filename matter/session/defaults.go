@@ -0,0 +1,90 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"sync"
+	"time"
+)
+
+// Default values of the session parameters and the reliable message protocol parameters.
+const (
+	// DefaultIdleInterval is the default SESSION_IDLE_INTERVAL.
+	DefaultIdleInterval = 500 * time.Millisecond
+	// DefaultActiveInterval is the default SESSION_ACTIVE_INTERVAL.
+	DefaultActiveInterval = 300 * time.Millisecond
+	// DefaultActiveThreshold is the default SESSION_ACTIVE_THRESHOLD.
+	DefaultActiveThreshold = 4000 * time.Millisecond
+	// MaxInterval is the maximum SESSION_IDLE_INTERVAL and SESSION_ACTIVE_INTERVAL.
+	MaxInterval = time.Hour
+	// MaxActiveThreshold is the maximum SESSION_ACTIVE_THRESHOLD encodable in 16 bits.
+	MaxActiveThreshold = 0xFFFF * time.Millisecond
+	// DefaultDataModelRevision is the data model revision implemented by this stack.
+	DefaultDataModelRevision = 17
+	// DefaultInteractionModelRevision is the interaction model revision implemented by this stack.
+	DefaultInteractionModelRevision = 11
+	// DefaultSpecificationVersion is the specification version implemented by this stack (1.2.0).
+	DefaultSpecificationVersion = 0x01020000
+	// DefaultMaxPathsPerInvoke is the MAX_PATHS_PER_INVOKE assumed for peers which do not advertise it.
+	DefaultMaxPathsPerInvoke = 1
+	// DefaultMaxTCPMessageSize is the default MAX_TCP_MESSAGE_SIZE.
+	DefaultMaxTCPMessageSize = 64000
+)
+
+var (
+	defaultParams      = newDefaultParams()
+	defaultParamsMutex = sync.RWMutex{}
+)
+
+func newDefaultParams() *Params {
+	return &Params{
+		IdleInterval:             DefaultIdleInterval,
+		ActiveInterval:           DefaultActiveInterval,
+		ActiveThreshold:          DefaultActiveThreshold,
+		DataModelRevision:        DefaultDataModelRevision,
+		InteractionModelRevision: DefaultInteractionModelRevision,
+		SpecificationVersion:     DefaultSpecificationVersion,
+		MaxPathsPerInvoke:        DefaultMaxPathsPerInvoke,
+		SupportedTransports:      0,
+		MaxTCPMessageSize:        0,
+	}
+}
+
+// DefaultParams returns a copy of the local session parameters which the message codecs,
+// the reliable message protocol and the session establishment protocols advertise and use.
+func DefaultParams() *Params {
+	defaultParamsMutex.RLock()
+	defer defaultParamsMutex.RUnlock()
+	return defaultParams.Copy()
+}
+
+// SetDefaultParams replaces the local session parameters. Absent fields are filled with the defaults.
+func SetDefaultParams(params *Params) error {
+	params = params.Effective()
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	defaultParamsMutex.Lock()
+	defer defaultParamsMutex.Unlock()
+	defaultParams = params
+	return nil
+}
+
+// ResetDefaultParams restores the local session parameters to the specification defaults.
+func ResetDefaultParams() {
+	defaultParamsMutex.Lock()
+	defer defaultParamsMutex.Unlock()
+	defaultParams = newDefaultParams()
+}
@@ -0,0 +1,30 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	matterr "github.com/cybergarage/go-matter/matter/errors"
+)
+
+var (
+	// ErrInvalid is returned when session parameters are invalid.
+	ErrInvalid = matterr.New(matterr.ErrInvalidArgument, "invalid")
+	// ErrExpired is returned when a secure session has expired or has been evicted by the peer.
+	ErrExpired = matterr.New(matterr.ErrClosed, "session expired")
+	// ErrClosed is returned when a secure session has been closed.
+	ErrClosed = matterr.New(matterr.ErrClosed, "session closed")
+	// ErrTimeout is returned when a peer does not respond, for example after its address has changed.
+	ErrTimeout = matterr.New(matterr.ErrTimeout, "session timeout")
+)
@@ -0,0 +1,193 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/protocol"
+)
+
+// ExchangeOption represents an exchange option.
+type ExchangeOption func(*Exchange)
+
+// WithLocalParams returns an option to set the local session parameters, which are DefaultParams by default.
+func WithLocalParams(params *Params) ExchangeOption {
+	return func(ex *Exchange) {
+		ex.local = params
+	}
+}
+
+// WithHopType returns an option to set the transport of the hop to the peer, which is UDPHop by default.
+func WithHopType(hop HopType) ExchangeOption {
+	return func(ex *Exchange) {
+		ex.hop = hop
+	}
+}
+
+// WithExchangeClock returns an option to set the clock of the exchange.
+func WithExchangeClock(now func() time.Time) ExchangeOption {
+	return func(ex *Exchange) {
+		ex.now = now
+	}
+}
+
+// Exchange represents an exchange with a peer, which waits for the responses to its requests
+// within the timeouts derived from the session parameters of both sides and the hop type.
+type Exchange struct {
+	sync.Mutex
+	id           protocol.ExchangeID
+	local        *Params
+	peer         *Params
+	hop          HopType
+	timeout      time.Duration
+	peerActivity time.Time
+	responses    chan *protocol.Message
+	done         chan struct{}
+	closed       bool
+	now          func() time.Time
+}
+
+// NewExchange returns a new exchange with the peer with the specified session parameters. The response
+// timeout is the round-trip timeout with ExpectedIMProcessingTime until it is set.
+func NewExchange(id protocol.ExchangeID, peer *Params, opts ...ExchangeOption) *Exchange {
+	ex := &Exchange{
+		Mutex:        sync.Mutex{},
+		id:           id,
+		local:        DefaultParams(),
+		peer:         peer,
+		hop:          UDPHop,
+		timeout:      0,
+		peerActivity: time.Time{},
+		responses:    make(chan *protocol.Message, 1),
+		done:         make(chan struct{}),
+		closed:       false,
+		now:          time.Now,
+	}
+	for _, opt := range opts {
+		opt(ex)
+	}
+	if ex.peer == nil {
+		ex.peer = NewParams()
+	}
+	ex.UseSuggestedResponseTimeout(ExpectedIMProcessingTime)
+	return ex
+}
+
+// ID returns the exchange ID.
+func (ex *Exchange) ID() protocol.ExchangeID {
+	return ex.id
+}
+
+// HopType returns the transport of the hop to the peer.
+func (ex *Exchange) HopType() HopType {
+	return ex.hop
+}
+
+// PeerActive records that a message has been received from the peer, which keeps the peer active for
+// its active threshold.
+func (ex *Exchange) PeerActive() {
+	ex.Lock()
+	defer ex.Unlock()
+	ex.peerActivity = ex.now()
+}
+
+// sincePeerActivity returns the time since the last activity of the peer, which is the active threshold
+// if the peer has never been active so that it is assumed idle.
+func (ex *Exchange) sincePeerActivity() time.Duration {
+	if ex.peerActivity.IsZero() {
+		return ex.peer.Effective().ActiveThreshold
+	}
+	return ex.now().Sub(ex.peerActivity)
+}
+
+// UseSuggestedResponseTimeout sets the response timeout to the round-trip timeout to the peer
+// with the specified processing time of the peer.
+func (ex *Exchange) UseSuggestedResponseTimeout(processing time.Duration) {
+	ex.Lock()
+	defer ex.Unlock()
+	ex.timeout = RoundTripTimeout(ex.local, ex.peer, ex.hop, ex.sincePeerActivity(), processing)
+}
+
+// SetResponseTimeout sets the response timeout. Zero means waiting until the context is done.
+func (ex *Exchange) SetResponseTimeout(d time.Duration) {
+	ex.Lock()
+	defer ex.Unlock()
+	ex.timeout = d
+}
+
+// ResponseTimeout returns the response timeout.
+func (ex *Exchange) ResponseTimeout() time.Duration {
+	ex.Lock()
+	defer ex.Unlock()
+	return ex.timeout
+}
+
+// Deliver delivers a response received on the exchange to WaitForResponse, and records the activity of the peer.
+func (ex *Exchange) Deliver(msg *protocol.Message) error {
+	if msg.ExchangeID != ex.id {
+		return fmt.Errorf("%w exchange ID: %04X != %04X", ErrInvalid, uint16(msg.ExchangeID), uint16(ex.id))
+	}
+	ex.Lock()
+	closed := ex.closed
+	ex.peerActivity = ex.now()
+	ex.Unlock()
+	if closed {
+		return fmt.Errorf("exchange %04X %w", uint16(ex.id), ErrClosed)
+	}
+	select {
+	case <-ex.done:
+		return fmt.Errorf("exchange %04X %w", uint16(ex.id), ErrClosed)
+	case ex.responses <- msg:
+		return nil
+	}
+}
+
+// WaitForResponse waits for the next response on the exchange within the response timeout.
+// ErrTimeout is returned if the peer does not respond in time, and ErrClosed if the exchange is closed.
+func (ex *Exchange) WaitForResponse(ctx context.Context) (*protocol.Message, error) {
+	parent := ctx
+	if timeout := ex.ResponseTimeout(); 0 < timeout {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	select {
+	case msg := <-ex.responses:
+		return msg, nil
+	case <-ex.done:
+		return nil, fmt.Errorf("exchange %04X %w", uint16(ex.id), ErrClosed)
+	case <-ctx.Done():
+		if err := parent.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("exchange %04X response: %w", uint16(ex.id), ErrTimeout)
+	}
+}
+
+// Close closes the exchange, and WaitForResponse returns ErrClosed.
+func (ex *Exchange) Close() error {
+	ex.Lock()
+	defer ex.Unlock()
+	if ex.closed {
+		return nil
+	}
+	ex.closed = true
+	close(ex.done)
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/protocol"
+)
+
+func TestMRPTimeouts(t *testing.T) {
+	near := func(d time.Duration, expected time.Duration) bool {
+		return (d - expected).Abs() < time.Microsecond
+	}
+
+	backoffs := []time.Duration{412500 * time.Microsecond, 412500 * time.Microsecond, 660 * time.Millisecond, 1056 * time.Millisecond}
+	for n, expected := range backoffs {
+		if d := MRPBackoff(300*time.Millisecond, n); !near(d, expected) {
+			t.Errorf("backoff (%d) %s != %s", n, d, expected)
+		}
+	}
+
+	params := NewParams()
+	tests := []struct {
+		since    time.Duration
+		expected time.Duration
+	}{
+		{0, 4230600 * time.Microsecond},
+		{3 * time.Second, 6061 * time.Millisecond},
+		{DefaultActiveThreshold, 7051 * time.Millisecond},
+	}
+	for _, test := range tests {
+		if d := params.RetransmissionTimeout(test.since); !near(d, test.expected) {
+			t.Errorf("retransmission timeout (%s) %s != %s", test.since, d, test.expected)
+		}
+	}
+
+	// The response is retransmitted with the local parameters while the local node is active.
+
+	local := NewParams()
+	local.ActiveInterval = 100 * time.Millisecond
+	sleepy := NewParams()
+	sleepy.IdleInterval = 5 * time.Second
+	rtt := RoundTripTimeout(local, sleepy, UDPHop, time.Hour, ExpectedIMProcessingTime)
+	expected := sleepy.RetransmissionTimeout(time.Hour) + ExpectedIMProcessingTime + local.RetransmissionTimeout(0)
+	if rtt != expected || rtt <= RoundTripTimeout(local, NewParams(), UDPHop, time.Hour, ExpectedIMProcessingTime) {
+		t.Errorf("round-trip timeout %s != %s", rtt, expected)
+	}
+	if rtt := RoundTripTimeout(local, sleepy, TCPHop, time.Hour, ExpectedIMProcessingTime); rtt != 2*TCPAckTimeout+ExpectedIMProcessingTime {
+		t.Errorf("TCP round-trip timeout %s", rtt)
+	}
+	if rtt := RoundTripTimeout(local, sleepy, BLEHop, 0, 0); rtt != 2*BLEAckTimeout {
+		t.Errorf("BLE round-trip timeout %s", rtt)
+	}
+}
+
+func TestExchange(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	local := NewParams()
+	peer := NewParams()
+	ex := NewExchange(0x1234, peer, WithLocalParams(local), WithExchangeClock(func() time.Time { return now }))
+	defer ex.Close()
+
+	// Peers which have not been active are assumed idle.
+
+	idle := RoundTripTimeout(local, peer, UDPHop, DefaultActiveThreshold, ExpectedIMProcessingTime)
+	if ex.ResponseTimeout() != idle {
+		t.Errorf("response timeout %s != %s", ex.ResponseTimeout(), idle)
+	}
+	ex.PeerActive()
+	ex.UseSuggestedResponseTimeout(ExpectedHighProcessingTime)
+	active := RoundTripTimeout(local, peer, UDPHop, 0, ExpectedHighProcessingTime)
+	if ex.ResponseTimeout() != active {
+		t.Errorf("response timeout %s != %s", ex.ResponseTimeout(), active)
+	}
+
+	header := protocol.NewHeader()
+	header.ExchangeID = 0x1234
+	res := protocol.NewMessage(header, []byte{0x15, 0x18})
+	go func() {
+		_ = ex.Deliver(res)
+	}()
+	msg, err := ex.WaitForResponse(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg != res {
+		t.Errorf("%v != %v", msg, res)
+	}
+
+	other := protocol.NewHeader()
+	other.ExchangeID = 0x4321
+	if err := ex.Deliver(protocol.NewMessage(other, nil)); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+
+	// The response timeout and the context are distinguished.
+
+	ex.SetResponseTimeout(time.Millisecond)
+	if _, err := ex.WaitForResponse(context.Background()); !errors.Is(err, ErrTimeout) {
+		t.Errorf("%v is not %v", err, ErrTimeout)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ex.SetResponseTimeout(0)
+	if _, err := ex.WaitForResponse(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("%v is not %v", err, context.Canceled)
+	}
+
+	if err := ex.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ex.WaitForResponse(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Errorf("%v is not %v", err, ErrClosed)
+	}
+	if err := ex.Deliver(res); !errors.Is(err, ErrClosed) {
+		t.Errorf("%v is not %v", err, ErrClosed)
+	}
+}
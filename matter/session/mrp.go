@@ -0,0 +1,118 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// 4.12.8. Parameters and Constants
+const (
+	// MRPMaxTransmissions is MRP_MAX_TRANSMISSIONS, the number of transmissions of a message including the first one.
+	MRPMaxTransmissions = 5
+	// MRPBackoffBase is MRP_BACKOFF_BASE, the base of the exponential backoff.
+	MRPBackoffBase = 1.6
+	// MRPBackoffJitter is MRP_BACKOFF_JITTER, the maximum random jitter of the backoff.
+	MRPBackoffJitter = 0.25
+	// MRPBackoffMargin is MRP_BACKOFF_MARGIN, the margin over the peer's advertised intervals.
+	MRPBackoffMargin = 1.1
+	// MRPBackoffThreshold is MRP_BACKOFF_THRESHOLD, the number of retransmissions before the backoff grows.
+	MRPBackoffThreshold = 1
+)
+
+// Expected processing times of the upper layers which are added to the round-trip timeouts.
+const (
+	// ExpectedLowProcessingTime is the expected processing time of simple requests.
+	ExpectedLowProcessingTime = 2 * time.Second
+	// ExpectedHighProcessingTime is the expected processing time of requests doing heavy computation.
+	ExpectedHighProcessingTime = 30 * time.Second
+	// ExpectedIMProcessingTime is the expected processing time of interaction model requests.
+	ExpectedIMProcessingTime = ExpectedLowProcessingTime
+	// TCPAckTimeout is the time to wait for a message over TCP, which does not use MRP.
+	TCPAckTimeout = 30 * time.Second
+	// BLEAckTimeout is the time to wait for a message over BTP, which does not use MRP.
+	BLEAckTimeout = 15 * time.Second
+)
+
+// HopType represents the transport of a hop to a peer, which determines how long a message may take.
+type HopType uint8
+
+const (
+	// UDPHop is a hop over UDP, where messages are retransmitted with MRP.
+	UDPHop HopType = iota
+	// TCPHop is a hop over TCP, which is reliable without MRP.
+	TCPHop
+	// BLEHop is a hop over BTP, which is reliable without MRP.
+	BLEHop
+)
+
+// String returns the string representation.
+func (hop HopType) String() string {
+	switch hop {
+	case UDPHop:
+		return "UDP"
+	case TCPHop:
+		return "TCP"
+	case BLEHop:
+		return "BLE"
+	}
+	return fmt.Sprintf("hop (%d)", int(hop))
+}
+
+// 4.12.2.1. Retransmissions
+// MRPBackoff returns the maximum backoff before the retransmission of the specified send count,
+// which starts from zero for the first transmission, with the maximum jitter.
+func MRPBackoff(base time.Duration, sendCount int) time.Duration {
+	exp := math.Max(0, float64(sendCount-MRPBackoffThreshold))
+	backoff := float64(base) * MRPBackoffMargin * math.Pow(MRPBackoffBase, exp) * (1 + MRPBackoffJitter)
+	return time.Duration(backoff)
+}
+
+// RetransmissionTimeout returns the maximum time which all the MRP transmissions of a message to the node
+// with the parameters take. The node is assumed active while the time since its last activity is within
+// its active threshold, and idle afterwards.
+func (params *Params) RetransmissionTimeout(sinceActivity time.Duration) time.Duration {
+	e := params.Effective()
+	timeout := time.Duration(0)
+	for n := 0; n < MRPMaxTransmissions; n++ {
+		base := e.ActiveInterval
+		if e.ActiveThreshold <= sinceActivity+timeout {
+			base = e.IdleInterval
+		}
+		timeout += MRPBackoff(base, n)
+	}
+	return timeout
+}
+
+// AckTimeout returns the maximum time which a message to the node with the parameters takes over the hop.
+func (params *Params) AckTimeout(hop HopType, sinceActivity time.Duration) time.Duration {
+	switch hop {
+	case TCPHop:
+		return TCPAckTimeout
+	case BLEHop:
+		return BLEAckTimeout
+	}
+	return params.RetransmissionTimeout(sinceActivity)
+}
+
+// RoundTripTimeout returns the time to wait for the response to a request sent to the peer over the hop:
+// the time which the request takes to reach the peer, the processing time of the peer, and the time
+// which the response takes to reach the local node. The request is sent with the peer's parameters,
+// and the response with the local parameters. The local node is considered active for the response.
+func RoundTripTimeout(local *Params, peer *Params, hop HopType, sincePeerActivity time.Duration, processing time.Duration) time.Duration {
+	return peer.AckTimeout(hop, sincePeerActivity) + processing + local.AckTimeout(hop, 0)
+}
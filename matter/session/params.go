@@ -0,0 +1,297 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// Transports represents the SUPPORTED_TRANSPORTS bitmap.
+type Transports uint8
+
+const (
+	// TCPClient represents that the node can initiate TCP connections.
+	TCPClient Transports = 0x01
+	// TCPServer represents that the node can accept TCP connections.
+	TCPServer Transports = 0x02
+)
+
+// HasTCPClient returns true if the node can initiate TCP connections.
+func (transports Transports) HasTCPClient() bool {
+	return (transports & TCPClient) != 0
+}
+
+// HasTCPServer returns true if the node can accept TCP connections.
+func (transports Transports) HasTCPServer() bool {
+	return (transports & TCPServer) != 0
+}
+
+const (
+	paramsIdleIntervalTag = iota + 1
+	paramsActiveIntervalTag
+	paramsActiveThresholdTag
+	paramsDataModelRevisionTag
+	paramsInteractionModelRevisionTag
+	paramsSpecificationVersionTag
+	paramsMaxPathsPerInvokeTag
+	paramsSupportedTransportsTag
+	paramsMaxTCPMessageSizeTag
+)
+
+// Params represents the session parameters (session-parameter-struct) which a node advertises
+// during the session establishment.
+// A zero field means that the field is absent, and the specification default is assumed for it.
+type Params struct {
+	IdleInterval             time.Duration
+	ActiveInterval           time.Duration
+	ActiveThreshold          time.Duration
+	DataModelRevision        uint16
+	InteractionModelRevision uint16
+	SpecificationVersion     uint32
+	MaxPathsPerInvoke        uint16
+	SupportedTransports      Transports
+	MaxTCPMessageSize        uint32
+}
+
+// NewParams returns new session parameters whose fields are all absent.
+func NewParams() *Params {
+	return &Params{
+		IdleInterval:             0,
+		ActiveInterval:           0,
+		ActiveThreshold:          0,
+		DataModelRevision:        0,
+		InteractionModelRevision: 0,
+		SpecificationVersion:     0,
+		MaxPathsPerInvoke:        0,
+		SupportedTransports:      0,
+		MaxTCPMessageSize:        0,
+	}
+}
+
+// Copy returns a copy of the parameters.
+func (params *Params) Copy() *Params {
+	c := *params
+	return &c
+}
+
+// Version returns the specification version, which is unknown if the peer does not send it.
+func (params *Params) Version() types.Version {
+	return types.Version(params.SpecificationVersion)
+}
+
+// Effective returns a copy of the parameters whose absent fields are filled with the defaults.
+// Peers implementing older revisions of the specification only send the MRP intervals,
+// and the revisions of such peers remain unknown (zero).
+func (params *Params) Effective() *Params {
+	e := params.Copy()
+	if e.IdleInterval == 0 {
+		e.IdleInterval = DefaultIdleInterval
+	}
+	if e.ActiveInterval == 0 {
+		e.ActiveInterval = DefaultActiveInterval
+	}
+	if e.ActiveThreshold == 0 {
+		e.ActiveThreshold = DefaultActiveThreshold
+	}
+	if e.MaxPathsPerInvoke == 0 {
+		e.MaxPathsPerInvoke = DefaultMaxPathsPerInvoke
+	}
+	if e.SupportedTransports != 0 && e.MaxTCPMessageSize == 0 {
+		e.MaxTCPMessageSize = DefaultMaxTCPMessageSize
+	}
+	return e
+}
+
+// Merge returns the effective parameters to communicate with the specified peer:
+//
+//   - The MRP intervals and threshold are the peer's ones, because they describe how the peer
+//     is reachable, falling back to the defaults for absent fields.
+//   - The revisions are the lower of the two known revisions.
+//   - MaxPathsPerInvoke is the peer's limit, which is 1 if the peer does not advertise it.
+//   - TCP is usable only in the direction which one side can initiate and the other accept,
+//     and MaxTCPMessageSize is the lower of the two sizes.
+func (params *Params) Merge(peer *Params) *Params {
+	local := params.Effective()
+	remote := peer.Effective()
+
+	minKnown16 := func(a, b uint16) uint16 {
+		if a == 0 || (b != 0 && b < a) {
+			return b
+		}
+		return a
+	}
+	minKnown32 := func(a, b uint32) uint32 {
+		if a == 0 || (b != 0 && b < a) {
+			return b
+		}
+		return a
+	}
+
+	transports := Transports(0)
+	if local.SupportedTransports.HasTCPClient() && remote.SupportedTransports.HasTCPServer() {
+		transports |= TCPClient
+	}
+	if local.SupportedTransports.HasTCPServer() && remote.SupportedTransports.HasTCPClient() {
+		transports |= TCPServer
+	}
+	maxTCPMessageSize := uint32(0)
+	if transports != 0 {
+		maxTCPMessageSize = minKnown32(local.MaxTCPMessageSize, remote.MaxTCPMessageSize)
+	}
+
+	return &Params{
+		IdleInterval:             remote.IdleInterval,
+		ActiveInterval:           remote.ActiveInterval,
+		ActiveThreshold:          remote.ActiveThreshold,
+		DataModelRevision:        minKnown16(local.DataModelRevision, remote.DataModelRevision),
+		InteractionModelRevision: minKnown16(local.InteractionModelRevision, remote.InteractionModelRevision),
+		SpecificationVersion:     minKnown32(local.SpecificationVersion, remote.SpecificationVersion),
+		MaxPathsPerInvoke:        remote.MaxPathsPerInvoke,
+		SupportedTransports:      transports,
+		MaxTCPMessageSize:        maxTCPMessageSize,
+	}
+}
+
+// Validate returns an error if a field is out of range.
+func (params *Params) Validate() error {
+	if MaxInterval < params.IdleInterval || params.IdleInterval < 0 {
+		return fmt.Errorf("%w idle interval: %s", ErrInvalid, params.IdleInterval)
+	}
+	if MaxInterval < params.ActiveInterval || params.ActiveInterval < 0 {
+		return fmt.Errorf("%w active interval: %s", ErrInvalid, params.ActiveInterval)
+	}
+	if MaxActiveThreshold < params.ActiveThreshold || params.ActiveThreshold < 0 {
+		return fmt.Errorf("%w active threshold: %s", ErrInvalid, params.ActiveThreshold)
+	}
+	if (params.SupportedTransports & ^(TCPClient | TCPServer)) != 0 {
+		return fmt.Errorf("%w supported transports: %02X", ErrInvalid, uint8(params.SupportedTransports))
+	}
+	return nil
+}
+
+// Encode writes the parameters as a session-parameter-struct with the specified tag. Absent fields are omitted.
+func (params *Params) Encode(enc *tlv.Encoder, tag tlv.Tag) {
+	putUnsigned := func(num uint8, v uint64) {
+		if v == 0 {
+			return
+		}
+		enc.PutUnsigned(tlv.NewContextTag(num), v)
+	}
+	enc.StartStructure(tag)
+	putUnsigned(paramsIdleIntervalTag, uint64(params.IdleInterval.Milliseconds()))
+	putUnsigned(paramsActiveIntervalTag, uint64(params.ActiveInterval.Milliseconds()))
+	putUnsigned(paramsActiveThresholdTag, uint64(params.ActiveThreshold.Milliseconds()))
+	putUnsigned(paramsDataModelRevisionTag, uint64(params.DataModelRevision))
+	putUnsigned(paramsInteractionModelRevisionTag, uint64(params.InteractionModelRevision))
+	putUnsigned(paramsSpecificationVersionTag, uint64(params.SpecificationVersion))
+	putUnsigned(paramsMaxPathsPerInvokeTag, uint64(params.MaxPathsPerInvoke))
+	putUnsigned(paramsSupportedTransportsTag, uint64(params.SupportedTransports))
+	putUnsigned(paramsMaxTCPMessageSizeTag, uint64(params.MaxTCPMessageSize))
+	enc.EndContainer()
+}
+
+// Bytes returns the TLV encoding of the parameters as an anonymous structure.
+func (params *Params) Bytes() []byte {
+	enc := tlv.NewEncoder()
+	params.Encode(enc, tlv.NewAnonymousTag())
+	return enc.Bytes()
+}
+
+// NewParamsFromDecoder returns new parameters decoded from the session-parameter-struct
+// whose start element was just returned by the specified decoder.
+func NewParamsFromDecoder(dec *tlv.Decoder, start *tlv.Element) (*Params, error) {
+	if start.Type() != tlv.Structure {
+		return nil, fmt.Errorf("%w session parameters container: %s", ErrInvalid, start.Type())
+	}
+
+	params := NewParams()
+	for {
+		elem, err := dec.Next()
+		if err != nil {
+			return nil, err
+		}
+		if elem.IsEndOfContainer() {
+			break
+		}
+		if !elem.Tag().IsContext() {
+			return nil, fmt.Errorf("%w session parameters tag: %s", ErrInvalid, elem.Tag())
+		}
+		if elem.IsContainer() {
+			// Skips unknown fields written by newer revisions.
+			if err := dec.Skip(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		v, err := elem.Unsigned()
+		if err != nil {
+			return nil, fmt.Errorf("%w session parameter %d: %w", ErrInvalid, elem.Tag().Number(), err)
+		}
+		outOfRange := func(max uint64) error {
+			if max < v {
+				return fmt.Errorf("%w session parameter %d: %d", ErrInvalid, elem.Tag().Number(), v)
+			}
+			return nil
+		}
+		switch elem.Tag().Number() {
+		case paramsIdleIntervalTag:
+			err = outOfRange(0xFFFFFFFF)
+			params.IdleInterval = time.Duration(v) * time.Millisecond
+		case paramsActiveIntervalTag:
+			err = outOfRange(0xFFFFFFFF)
+			params.ActiveInterval = time.Duration(v) * time.Millisecond
+		case paramsActiveThresholdTag:
+			err = outOfRange(0xFFFF)
+			params.ActiveThreshold = time.Duration(v) * time.Millisecond
+		case paramsDataModelRevisionTag:
+			err = outOfRange(0xFFFF)
+			params.DataModelRevision = uint16(v)
+		case paramsInteractionModelRevisionTag:
+			err = outOfRange(0xFFFF)
+			params.InteractionModelRevision = uint16(v)
+		case paramsSpecificationVersionTag:
+			err = outOfRange(0xFFFFFFFF)
+			params.SpecificationVersion = uint32(v)
+		case paramsMaxPathsPerInvokeTag:
+			err = outOfRange(0xFFFF)
+			params.MaxPathsPerInvoke = uint16(v)
+		case paramsSupportedTransportsTag:
+			err = outOfRange(0xFF)
+			params.SupportedTransports = Transports(v)
+		case paramsMaxTCPMessageSizeTag:
+			err = outOfRange(0xFFFFFFFF)
+			params.MaxTCPMessageSize = uint32(v)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return params, params.Validate()
+}
+
+// NewParamsFromBytes returns new parameters decoded from the specified TLV encoding.
+func NewParamsFromBytes(b []byte) (*Params, error) {
+	dec := tlv.NewDecoder(b)
+	start, err := dec.Next()
+	if err != nil {
+		return nil, err
+	}
+	return NewParamsFromDecoder(dec, start)
+}
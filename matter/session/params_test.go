@@ -0,0 +1,164 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+func TestParamsEncoding(t *testing.T) {
+	tests := []struct {
+		name   string
+		params *Params
+	}{
+		{"empty", NewParams()},
+		{"mrp", &Params{
+			IdleInterval:             5000 * time.Millisecond,
+			ActiveInterval:           300 * time.Millisecond,
+			ActiveThreshold:          4000 * time.Millisecond,
+			DataModelRevision:        0,
+			InteractionModelRevision: 0,
+			SpecificationVersion:     0,
+			MaxPathsPerInvoke:        0,
+			SupportedTransports:      0,
+			MaxTCPMessageSize:        0,
+		}},
+		{"default", DefaultParams()},
+		{"tcp", &Params{
+			IdleInterval:             DefaultIdleInterval,
+			ActiveInterval:           DefaultActiveInterval,
+			ActiveThreshold:          DefaultActiveThreshold,
+			DataModelRevision:        17,
+			InteractionModelRevision: 11,
+			SpecificationVersion:     0x01030000,
+			MaxPathsPerInvoke:        10,
+			SupportedTransports:      TCPClient | TCPServer,
+			MaxTCPMessageSize:        100000,
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params, err := NewParamsFromBytes(test.params.Bytes())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *params != *test.params {
+				t.Errorf("%v != %v", params, test.params)
+			}
+		})
+	}
+}
+
+func TestParamsDecodingErrors(t *testing.T) {
+	newParamsBytes := func(num uint8, v uint64) []byte {
+		enc := tlv.NewEncoder()
+		enc.StartStructure(tlv.NewAnonymousTag())
+		enc.PutUnsigned(tlv.NewContextTag(num), v)
+		enc.EndContainer()
+		return enc.Bytes()
+	}
+	tests := []struct {
+		name string
+		b    []byte
+	}{
+		{"idle interval", newParamsBytes(paramsIdleIntervalTag, uint64(time.Hour.Milliseconds())+1)},
+		{"active threshold", newParamsBytes(paramsActiveThresholdTag, 0x10000)},
+		{"transports", newParamsBytes(paramsSupportedTransportsTag, 0x04)},
+		{"container", []byte{0x16, 0x18}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewParamsFromBytes(test.b)
+			if !errors.Is(err, ErrInvalid) {
+				t.Errorf("%v is not %v", err, ErrInvalid)
+			}
+		})
+	}
+}
+
+func TestParamsMerge(t *testing.T) {
+	local := DefaultParams()
+	local.SupportedTransports = TCPClient
+	local.MaxTCPMessageSize = 80000
+
+	legacy := NewParams()
+	legacy.IdleInterval = 5 * time.Second
+
+	merged := local.Merge(legacy)
+	if merged.IdleInterval != 5*time.Second {
+		t.Errorf("%s != %s", merged.IdleInterval, 5*time.Second)
+	}
+	if merged.ActiveInterval != DefaultActiveInterval {
+		t.Errorf("%s != %s", merged.ActiveInterval, DefaultActiveInterval)
+	}
+	if merged.InteractionModelRevision != DefaultInteractionModelRevision {
+		t.Errorf("%d != %d", merged.InteractionModelRevision, DefaultInteractionModelRevision)
+	}
+	if merged.MaxPathsPerInvoke != DefaultMaxPathsPerInvoke {
+		t.Errorf("%d != %d", merged.MaxPathsPerInvoke, DefaultMaxPathsPerInvoke)
+	}
+	if merged.SupportedTransports != 0 || merged.MaxTCPMessageSize != 0 {
+		t.Errorf("TCP is enabled for a legacy peer: %v", merged)
+	}
+
+	peer := DefaultParams()
+	peer.InteractionModelRevision = 10
+	peer.MaxPathsPerInvoke = 5
+	peer.SupportedTransports = TCPServer
+	merged = local.Merge(peer)
+	if merged.InteractionModelRevision != 10 {
+		t.Errorf("%d != %d", merged.InteractionModelRevision, 10)
+	}
+	if merged.MaxPathsPerInvoke != 5 {
+		t.Errorf("%d != %d", merged.MaxPathsPerInvoke, 5)
+	}
+	if merged.SupportedTransports != TCPClient {
+		t.Errorf("%02X != %02X", merged.SupportedTransports, TCPClient)
+	}
+	if merged.MaxTCPMessageSize != DefaultMaxTCPMessageSize {
+		t.Errorf("%d != %d", merged.MaxTCPMessageSize, DefaultMaxTCPMessageSize)
+	}
+}
+
+func TestDefaultParams(t *testing.T) {
+	defer ResetDefaultParams()
+
+	params := NewParams()
+	params.IdleInterval = 2 * time.Second
+	if err := SetDefaultParams(params); err != nil {
+		t.Fatal(err)
+	}
+	if DefaultParams().IdleInterval != 2*time.Second {
+		t.Errorf("%s != %s", DefaultParams().IdleInterval, 2*time.Second)
+	}
+	if DefaultParams().ActiveInterval != DefaultActiveInterval {
+		t.Errorf("%s != %s", DefaultParams().ActiveInterval, DefaultActiveInterval)
+	}
+
+	params.IdleInterval = 2 * time.Hour
+	if err := SetDefaultParams(params); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+
+	// DefaultParams returns a copy.
+	DefaultParams().IdleInterval = 0
+	if DefaultParams().IdleInterval == 0 {
+		t.Errorf("default params are modified")
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/matter/trace"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+const (
+	// DefaultMaxSessions is the default maximum number of concurrent secure sessions.
+	DefaultMaxSessions = 16
+	// DefaultMinSessionsPerFabric is the default number of sessions per fabric which are protected from
+	// eviction by sessions of other fabrics.
+	DefaultMinSessionsPerFabric = 3
+)
+
+// Type represents a secure session type.
+type Type uint8
+
+const (
+	// PASESession represents a session established with PASE.
+	PASESession Type = iota
+	// CASESession represents a session established with CASE.
+	CASESession
+)
+
+// String returns the string representation.
+func (typ Type) String() string {
+	if typ == PASESession {
+		return "PASE"
+	}
+	return "CASE"
+}
+
+// SecureSession represents an entry of the secure session table.
+type SecureSession struct {
+	// ID is the local session ID.
+	ID message.SessionID
+	// Type is the session establishment protocol.
+	Type Type
+	// FabricIndex is the fabric of the peer. PASE sessions have no fabric.
+	FabricIndex types.FabricIndex
+	// PeerNodeID is the node ID of the peer.
+	PeerNodeID message.NodeID
+	// Peer is the address of the peer.
+	Peer string
+	// LastActive is the time when the session was last used.
+	LastActive time.Time
+	lru        uint64
+}
+
+// Limits represents the limits of the secure session table.
+type Limits struct {
+	// MaxSessions is the maximum number of concurrent secure sessions.
+	MaxSessions int
+	// MinSessionsPerFabric is the number of sessions per fabric which are protected from eviction
+	// as long as another fabric uses more than its fair share.
+	MinSessionsPerFabric int
+}
+
+// DefaultLimits returns the default limits of the secure session table.
+func DefaultLimits() Limits {
+	return Limits{
+		MaxSessions:          DefaultMaxSessions,
+		MinSessionsPerFabric: DefaultMinSessionsPerFabric,
+	}
+}
+
+// Validate returns an error if the limits are invalid.
+func (limits Limits) Validate() error {
+	if limits.MaxSessions < 1 {
+		return fmt.Errorf("%w max sessions (%d)", ErrInvalid, limits.MaxSessions)
+	}
+	if limits.MinSessionsPerFabric < 0 {
+		return fmt.Errorf("%w min sessions per fabric (%d)", ErrInvalid, limits.MinSessionsPerFabric)
+	}
+	return nil
+}
+
+// EvictionHandler represents a handler which is called when a session is evicted.
+type EvictionHandler func(s *SecureSession)
+
+// Table represents a secure session table with a limited number of entries.
+type Table struct {
+	sync.Mutex
+	limits   Limits
+	sessions map[message.SessionID]*SecureSession
+	lru      uint64
+	handler  EvictionHandler
+}
+
+// NewTable returns a new secure session table with the default limits.
+func NewTable() *Table {
+	return &Table{
+		Mutex:    sync.Mutex{},
+		limits:   DefaultLimits(),
+		sessions: map[message.SessionID]*SecureSession{},
+		lru:      0,
+		handler:  nil,
+	}
+}
+
+// SetLimits sets the limits. Sessions exceeding the new limits are evicted.
+func (table *Table) SetLimits(limits Limits) error {
+	if err := limits.Validate(); err != nil {
+		return err
+	}
+	table.Lock()
+	table.limits = limits
+	evicted := []*SecureSession{}
+	for table.limits.MaxSessions < len(table.sessions) {
+		evicted = append(evicted, table.evict(table.selectVictim(types.NoFabricIndex, false)))
+	}
+	handler := table.handler
+	table.Unlock()
+
+	notifyEvicted(handler, evicted)
+	return nil
+}
+
+// Limits returns the limits.
+func (table *Table) Limits() Limits {
+	table.Lock()
+	defer table.Unlock()
+	return table.limits
+}
+
+// SetEvictionHandler sets the handler which is called when a session is evicted.
+// The handler is called without holding the table lock, so it can use the table.
+func (table *Table) SetEvictionHandler(handler EvictionHandler) {
+	table.Lock()
+	defer table.Unlock()
+	table.handler = handler
+}
+
+// Add adds the session as the most recently used one. If the table is full, a session is evicted
+// from the fabric which uses the most sessions over its fair share, otherwise the least recently
+// used session of the fabric of the new session, otherwise the least recently used session.
+func (table *Table) Add(s *SecureSession) error {
+	table.Lock()
+	if _, ok := table.sessions[s.ID]; ok {
+		table.Unlock()
+		return fmt.Errorf("%w session ID (%d) is in use", ErrInvalid, s.ID)
+	}
+	evicted := []*SecureSession{}
+	for table.limits.MaxSessions <= len(table.sessions) {
+		evicted = append(evicted, table.evict(table.selectVictim(s.FabricIndex, true)))
+	}
+	table.touch(s)
+	table.sessions[s.ID] = s
+	handler := table.handler
+	table.Unlock()
+
+	notifyEvicted(handler, evicted)
+	return nil
+}
+
+// Lookup returns the session of the specified ID.
+func (table *Table) Lookup(id message.SessionID) (*SecureSession, bool) {
+	table.Lock()
+	defer table.Unlock()
+	s, ok := table.sessions[id]
+	return s, ok
+}
+
+// Touch marks the session of the specified ID as the most recently used one.
+func (table *Table) Touch(id message.SessionID) bool {
+	table.Lock()
+	defer table.Unlock()
+	s, ok := table.sessions[id]
+	if !ok {
+		return false
+	}
+	table.touch(s)
+	return true
+}
+
+// Remove removes the session of the specified ID without calling the eviction handler.
+func (table *Table) Remove(id message.SessionID) bool {
+	table.Lock()
+	defer table.Unlock()
+	_, ok := table.sessions[id]
+	delete(table.sessions, id)
+	return ok
+}
+
+// Len returns the number of sessions.
+func (table *Table) Len() int {
+	table.Lock()
+	defer table.Unlock()
+	return len(table.sessions)
+}
+
+// Sessions returns all sessions from the least recently used one.
+func (table *Table) Sessions() []*SecureSession {
+	table.Lock()
+	defer table.Unlock()
+	sessions := make([]*SecureSession, 0, len(table.sessions))
+	for _, s := range table.sessions {
+		sessions = append(sessions, s)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].lru < sessions[j].lru
+	})
+	return sessions
+}
+
+func (table *Table) touch(s *SecureSession) {
+	table.lru++
+	s.lru = table.lru
+	s.LastActive = time.Now()
+}
+
+// selectVictim returns the session to evict for a new session of the specified fabric.
+func (table *Table) selectVictim(newFabric types.FabricIndex, adding bool) *SecureSession {
+	lrus := map[types.FabricIndex]*SecureSession{}
+	counts := map[types.FabricIndex]int{}
+	for _, s := range table.sessions {
+		counts[s.FabricIndex]++
+		if lru, ok := lrus[s.FabricIndex]; !ok || s.lru < lru.lru {
+			lrus[s.FabricIndex] = s
+		}
+	}
+	if adding {
+		counts[newFabric]++
+	}
+
+	share := table.limits.MaxSessions / len(counts)
+	if share < table.limits.MinSessionsPerFabric {
+		share = table.limits.MinSessionsPerFabric
+	}
+
+	// Evicts from the fabric which uses the most sessions over its fair share.
+	var victim *SecureSession
+	victimCount := share
+	for fabric, lru := range lrus {
+		count := counts[fabric]
+		if count < victimCount || count <= share {
+			continue
+		}
+		if count == victimCount && victim != nil && victim.lru < lru.lru {
+			continue
+		}
+		victim, victimCount = lru, count
+	}
+	if victim != nil {
+		return victim
+	}
+
+	// Otherwise, the new session replaces a session of its own fabric.
+	if lru, ok := lrus[newFabric]; ok && adding {
+		return lru
+	}
+
+	for _, lru := range lrus {
+		if victim == nil || lru.lru < victim.lru {
+			victim = lru
+		}
+	}
+	return victim
+}
+
+// evict removes the session, and returns it to be notified after the table is unlocked.
+func (table *Table) evict(s *SecureSession) *SecureSession {
+	delete(table.sessions, s.ID)
+	return s
+}
+
+// notifyEvicted traces the evicted sessions, and calls the handler with them.
+func notifyEvicted(handler EvictionHandler, evicted []*SecureSession) {
+	for _, s := range evicted {
+		trace.SharedTracer().TraceSession(&trace.SessionEvent{
+			Time:      time.Now(),
+			Peer:      s.Peer,
+			SessionID: s.ID,
+			Phase:     trace.SessionEvictedPhase,
+			Err:       nil,
+		})
+		if handler != nil {
+			handler(s)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+func newTestSecureSession(id message.SessionID, fabric types.FabricIndex) *SecureSession {
+	typ := CASESession
+	if fabric == types.NoFabricIndex {
+		typ = PASESession
+	}
+	return &SecureSession{
+		ID:          id,
+		Type:        typ,
+		FabricIndex: fabric,
+		PeerNodeID:  message.NodeID(id),
+		Peer:        "",
+	}
+}
+
+func TestTableEviction(t *testing.T) {
+	type entry struct {
+		id     message.SessionID
+		fabric types.FabricIndex
+	}
+	tests := []struct {
+		name    string
+		limits  Limits
+		entries []entry
+		touched []message.SessionID
+		added   entry
+		evicted message.SessionID
+	}{
+		{
+			"lru",
+			Limits{MaxSessions: 3, MinSessionsPerFabric: 0},
+			[]entry{{1, 1}, {2, 1}, {3, 1}},
+			[]message.SessionID{1},
+			entry{4, 1},
+			2,
+		},
+		{
+			"over-share",
+			Limits{MaxSessions: 6, MinSessionsPerFabric: 2},
+			[]entry{{1, 2}, {2, 1}, {3, 1}, {4, 1}, {5, 1}, {6, 1}},
+			[]message.SessionID{2},
+			entry{7, 2},
+			3,
+		},
+		{
+			"own-fabric",
+			Limits{MaxSessions: 4, MinSessionsPerFabric: 2},
+			[]entry{{1, 1}, {2, 2}, {3, 2}, {4, 1}},
+			nil,
+			entry{5, 2},
+			2,
+		},
+		{
+			"new-fabric",
+			Limits{MaxSessions: 4, MinSessionsPerFabric: 2},
+			[]entry{{1, 1}, {2, 2}, {3, 2}, {4, 1}},
+			[]message.SessionID{1},
+			entry{5, 3},
+			2,
+		},
+		{
+			"pase",
+			Limits{MaxSessions: 4, MinSessionsPerFabric: 1},
+			[]entry{{1, 1}, {2, 0}, {3, 1}, {4, 1}},
+			nil,
+			entry{5, 0},
+			1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			table := NewTable()
+			if err := table.SetLimits(test.limits); err != nil {
+				t.Fatal(err)
+			}
+			evicted := []message.SessionID{}
+			table.SetEvictionHandler(func(s *SecureSession) {
+				evicted = append(evicted, s.ID)
+			})
+			for _, e := range test.entries {
+				if err := table.Add(newTestSecureSession(e.id, e.fabric)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, id := range test.touched {
+				if !table.Touch(id) {
+					t.Errorf("session (%d) is not found", id)
+				}
+			}
+			if err := table.Add(newTestSecureSession(test.added.id, test.added.fabric)); err != nil {
+				t.Fatal(err)
+			}
+			if len(evicted) != 1 || evicted[0] != test.evicted {
+				t.Errorf("evicted (%v) != (%d)", evicted, test.evicted)
+			}
+			if table.Len() != test.limits.MaxSessions {
+				t.Errorf("sessions (%d) != (%d)", table.Len(), test.limits.MaxSessions)
+			}
+			if _, ok := table.Lookup(test.evicted); ok {
+				t.Errorf("session (%d) is not evicted", test.evicted)
+			}
+		})
+	}
+}
+
+func TestTable(t *testing.T) {
+	table := NewTable()
+	for id := message.SessionID(1); id <= 4; id++ {
+		if err := table.Add(newTestSecureSession(id, 1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := table.Add(newTestSecureSession(1, 1)); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+
+	table.Touch(1)
+	sessions := table.Sessions()
+	if len(sessions) != 4 || sessions[0].ID != 2 || sessions[3].ID != 1 {
+		t.Errorf("sessions are not in LRU order")
+	}
+
+	if !table.Remove(2) || table.Remove(2) {
+		t.Errorf("session (2) is not removed once")
+	}
+
+	// The handler is called without the table lock, after the session is removed.
+	evicted := 0
+	table.SetEvictionHandler(func(s *SecureSession) {
+		evicted++
+		if _, ok := table.Lookup(s.ID); ok {
+			t.Errorf("evicted session (%d) is found", s.ID)
+		}
+	})
+	if err := table.SetLimits(Limits{MaxSessions: 1, MinSessionsPerFabric: 0}); err != nil {
+		t.Fatal(err)
+	}
+	if evicted != 2 || table.Len() != 1 {
+		t.Errorf("evicted (%d) != (2)", evicted)
+	}
+	if _, ok := table.Lookup(1); !ok {
+		t.Errorf("most recently used session is evicted")
+	}
+
+	for _, limits := range []Limits{{MaxSessions: 0, MinSessionsPerFabric: 0}, {MaxSessions: 1, MinSessionsPerFabric: -1}} {
+		if err := table.SetLimits(limits); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%v is not %v", err, ErrInvalid)
+		}
+	}
+}
+
+func TestTableDebug(t *testing.T) {
+	table := NewTable()
+	for _, s := range []*SecureSession{newTestSecureSession(1, 1), newTestSecureSession(2, types.NoFabricIndex)} {
+		if err := table.Add(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	table.Touch(1)
+
+	state := table.Debug()
+	if state.Limits != DefaultLimits() {
+		t.Errorf("%v != %v", state.Limits, DefaultLimits())
+	}
+	if len(state.Sessions) != 2 || state.Sessions[0].ID != 2 || state.Sessions[1].ID != 1 {
+		t.Fatalf("%v", state.Sessions)
+	}
+	if state.Sessions[0].Type != PASESession || state.Sessions[1].FabricIndex != 1 {
+		t.Errorf("%v", state.Sessions)
+	}
+
+	// The snapshot does not follow the table.
+	table.Remove(1)
+	if len(state.Sessions) != 2 {
+		t.Errorf("%v", state.Sessions)
+	}
+}
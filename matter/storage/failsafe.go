@@ -0,0 +1,243 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FailSafeStore represents a store which stages the mutations made while the fail-safe is armed,
+// such as the fabric, ACL and group state written during commissioning. The staged mutations are
+// written through the underlying store in a single batch when they are committed on CommissioningComplete,
+// and are dropped when the fail-safe expires or is disarmed. Since the staged mutations are only kept
+// in memory, a crash during commissioning leaves the underlying store in the last committed state.
+// Mutations made while the fail-safe is not armed are written through immediately.
+type FailSafeStore struct {
+	sync.Mutex
+	store Store
+	armed bool
+	ops   []memoryBatchOp
+	timer *time.Timer
+	armID uint64
+}
+
+// NewFailSafeStore returns a new fail-safe store on the specified store.
+func NewFailSafeStore(store Store) *FailSafeStore {
+	return &FailSafeStore{
+		Mutex: sync.Mutex{},
+		store: store,
+		armed: false,
+		ops:   []memoryBatchOp{},
+		timer: nil,
+		armID: 0,
+	}
+}
+
+// Arm arms the fail-safe for the specified period, or extends the period if it is already armed.
+// The staged mutations are kept while the fail-safe is re-armed, and a non-positive period expires
+// the fail-safe immediately as ArmFailSafe with a zero expiry does.
+func (store *FailSafeStore) Arm(expiry time.Duration) {
+	if expiry <= 0 {
+		store.Revert()
+		return
+	}
+	store.Lock()
+	defer store.Unlock()
+	store.stopTimer()
+	store.armed = true
+	store.armID++
+	armID := store.armID
+	store.timer = time.AfterFunc(expiry, func() {
+		store.expire(armID)
+	})
+}
+
+// IsArmed returns true if the fail-safe is armed.
+func (store *FailSafeStore) IsArmed() bool {
+	store.Lock()
+	defer store.Unlock()
+	return store.armed
+}
+
+// Commit writes the staged mutations through the underlying store atomically, and disarms the fail-safe.
+// The mutations are kept staged if the underlying store fails to commit them.
+func (store *FailSafeStore) Commit() error {
+	store.Lock()
+	defer store.Unlock()
+	if !store.armed {
+		return fmt.Errorf("fail-safe is %w", ErrNotArmed)
+	}
+	batch := store.store.NewBatch()
+	for _, op := range store.ops {
+		if op.value == nil {
+			batch.Delete(op.ns, op.key)
+			continue
+		}
+		batch.Set(op.ns, op.key, op.value)
+	}
+	if err := batch.Commit(); err != nil {
+		return err
+	}
+	store.disarm()
+	return nil
+}
+
+// Revert drops the staged mutations, and disarms the fail-safe.
+func (store *FailSafeStore) Revert() {
+	store.Lock()
+	defer store.Unlock()
+	store.disarm()
+}
+
+func (store *FailSafeStore) expire(armID uint64) {
+	store.Lock()
+	defer store.Unlock()
+	// The timer may fire after the fail-safe is re-armed or committed.
+	if !store.armed || store.armID != armID {
+		return
+	}
+	store.disarm()
+}
+
+func (store *FailSafeStore) disarm() {
+	store.stopTimer()
+	store.armed = false
+	store.ops = []memoryBatchOp{}
+}
+
+func (store *FailSafeStore) stopTimer() {
+	if store.timer != nil {
+		store.timer.Stop()
+		store.timer = nil
+	}
+}
+
+// Get returns the value of the specified key in the namespace including the staged mutations, or ErrNotFound.
+func (store *FailSafeStore) Get(ns string, key string) ([]byte, error) {
+	store.Lock()
+	defer store.Unlock()
+	for n := len(store.ops) - 1; 0 <= n; n-- {
+		op := store.ops[n]
+		if op.ns != ns || op.key != key {
+			continue
+		}
+		if op.value == nil {
+			return nil, fmt.Errorf("%s/%s is %w", ns, key, ErrNotFound)
+		}
+		return append([]byte{}, op.value...), nil
+	}
+	return store.store.Get(ns, key)
+}
+
+// Set sets the value of the specified key in the namespace.
+func (store *FailSafeStore) Set(ns string, key string, value []byte) error {
+	batch := store.NewBatch()
+	batch.Set(ns, key, value)
+	return batch.Commit()
+}
+
+// Delete deletes the specified key in the namespace.
+func (store *FailSafeStore) Delete(ns string, key string) error {
+	batch := store.NewBatch()
+	batch.Delete(ns, key)
+	return batch.Commit()
+}
+
+// Keys returns all keys in the namespace including the staged mutations in lexical order.
+func (store *FailSafeStore) Keys(ns string) ([]string, error) {
+	store.Lock()
+	defer store.Unlock()
+	keys, err := store.store.Keys(ns)
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		set[key] = true
+	}
+	for _, op := range store.ops {
+		if op.ns == ns {
+			set[op.key] = op.value != nil
+		}
+	}
+	keys = keys[:0]
+	for key, ok := range set {
+		if ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// NewBatch returns a new batch whose operations are staged together on commit while the fail-safe
+// is armed, and are written through the underlying store otherwise.
+func (store *FailSafeStore) NewBatch() Batch {
+	return &failSafeBatch{
+		store: store,
+		ops:   []memoryBatchOp{},
+	}
+}
+
+func (store *FailSafeStore) commit(ops []memoryBatchOp) error {
+	store.Lock()
+	defer store.Unlock()
+	if store.armed {
+		store.ops = append(store.ops, ops...)
+		return nil
+	}
+	batch := store.store.NewBatch()
+	for _, op := range ops {
+		if op.value == nil {
+			batch.Delete(op.ns, op.key)
+			continue
+		}
+		batch.Set(op.ns, op.key, op.value)
+	}
+	return batch.Commit()
+}
+
+type failSafeBatch struct {
+	store *FailSafeStore
+	ops   []memoryBatchOp
+}
+
+// Set adds a set operation into the batch.
+func (batch *failSafeBatch) Set(ns string, key string, value []byte) {
+	batch.ops = append(batch.ops, memoryBatchOp{
+		ns:    ns,
+		key:   key,
+		value: append([]byte{}, value...),
+	})
+}
+
+// Delete adds a delete operation into the batch.
+func (batch *failSafeBatch) Delete(ns string, key string) {
+	batch.ops = append(batch.ops, memoryBatchOp{
+		ns:    ns,
+		key:   key,
+		value: nil,
+	})
+}
+
+// Commit stages or applies all operations in the batch atomically.
+func (batch *failSafeBatch) Commit() error {
+	ops := batch.ops
+	batch.ops = []memoryBatchOp{}
+	return batch.store.commit(ops)
+}
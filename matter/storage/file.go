@@ -0,0 +1,93 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+const (
+	fileStorePerm = 0o600
+)
+
+// FileStore represents a store persisted into a single JSON file.
+// Every commit rewrites a temporary file and renames it over the store file,
+// so the file always holds the state before or after a whole batch.
+type FileStore struct {
+	*MemoryStore
+	path string
+}
+
+// NewFileStore returns a new file store for the specified path, loading the existing state if the file exists.
+func NewFileStore(path string) (*FileStore, error) {
+	store := &FileStore{
+		MemoryStore: NewMemoryStore(),
+		path:        path,
+	}
+
+	b, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(b, &store.MemoryStore.namespaces); err != nil {
+			return nil, err
+		}
+	case errors.Is(err, os.ErrNotExist):
+	default:
+		return nil, err
+	}
+
+	store.MemoryStore.onCommit = store.write
+
+	return store, nil
+}
+
+// Path returns the store file path.
+func (store *FileStore) Path() string {
+	return store.path
+}
+
+func (store *FileStore) write(namespaces map[string]map[string][]byte) error {
+	b, err := json.Marshal(namespaces)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(store.path), filepath.Base(store.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(fileStorePerm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), store.path)
+}
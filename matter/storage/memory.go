@@ -0,0 +1,157 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// MemoryStore represents a volatile in-memory store.
+type MemoryStore struct {
+	sync.Mutex
+	namespaces map[string]map[string][]byte
+	onCommit   func(map[string]map[string][]byte) error
+}
+
+// NewMemoryStore returns a new in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		Mutex:      sync.Mutex{},
+		namespaces: map[string]map[string][]byte{},
+		onCommit:   nil,
+	}
+}
+
+// Get returns the value of the specified key in the namespace, or ErrNotFound.
+func (store *MemoryStore) Get(ns string, key string) ([]byte, error) {
+	store.Lock()
+	defer store.Unlock()
+	value, ok := store.namespaces[ns][key]
+	if !ok {
+		return nil, fmt.Errorf("%s/%s is %w", ns, key, ErrNotFound)
+	}
+	return append([]byte{}, value...), nil
+}
+
+// Set sets the value of the specified key in the namespace.
+func (store *MemoryStore) Set(ns string, key string, value []byte) error {
+	batch := store.NewBatch()
+	batch.Set(ns, key, value)
+	return batch.Commit()
+}
+
+// Delete deletes the specified key in the namespace.
+func (store *MemoryStore) Delete(ns string, key string) error {
+	batch := store.NewBatch()
+	batch.Delete(ns, key)
+	return batch.Commit()
+}
+
+// Keys returns all keys in the namespace in lexical order.
+func (store *MemoryStore) Keys(ns string) ([]string, error) {
+	store.Lock()
+	defer store.Unlock()
+	keys := make([]string, 0, len(store.namespaces[ns]))
+	for key := range store.namespaces[ns] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// NewBatch returns a new batch whose operations are applied atomically on commit.
+func (store *MemoryStore) NewBatch() Batch {
+	return &memoryBatch{
+		store: store,
+		ops:   []memoryBatchOp{},
+	}
+}
+
+// commit applies the operations to a copy of the namespaces, and replaces them
+// only if the commit hook succeeds.
+func (store *MemoryStore) commit(ops []memoryBatchOp) error {
+	store.Lock()
+	defer store.Unlock()
+
+	namespaces := make(map[string]map[string][]byte, len(store.namespaces))
+	for ns, keys := range store.namespaces {
+		namespaces[ns] = make(map[string][]byte, len(keys))
+		for key, value := range keys {
+			namespaces[ns][key] = value
+		}
+	}
+
+	for _, op := range ops {
+		if op.value == nil {
+			delete(namespaces[op.ns], op.key)
+			if len(namespaces[op.ns]) == 0 {
+				delete(namespaces, op.ns)
+			}
+			continue
+		}
+		if _, ok := namespaces[op.ns]; !ok {
+			namespaces[op.ns] = map[string][]byte{}
+		}
+		namespaces[op.ns][op.key] = op.value
+	}
+
+	if store.onCommit != nil {
+		if err := store.onCommit(namespaces); err != nil {
+			return err
+		}
+	}
+
+	store.namespaces = namespaces
+
+	return nil
+}
+
+type memoryBatchOp struct {
+	ns    string
+	key   string
+	value []byte
+}
+
+type memoryBatch struct {
+	store *MemoryStore
+	ops   []memoryBatchOp
+}
+
+// Set adds a set operation into the batch.
+func (batch *memoryBatch) Set(ns string, key string, value []byte) {
+	batch.ops = append(batch.ops, memoryBatchOp{
+		ns:    ns,
+		key:   key,
+		value: append([]byte{}, value...),
+	})
+}
+
+// Delete adds a delete operation into the batch.
+func (batch *memoryBatch) Delete(ns string, key string) {
+	batch.ops = append(batch.ops, memoryBatchOp{
+		ns:    ns,
+		key:   key,
+		value: nil,
+	})
+}
+
+// Commit applies all operations in the batch atomically.
+func (batch *memoryBatch) Commit() error {
+	ops := batch.ops
+	batch.ops = []memoryBatchOp{}
+	return batch.store.commit(ops)
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	matterr "github.com/cybergarage/go-matter/matter/errors"
+)
+
+// ErrNotFound is returned when the specified key is not found.
+var ErrNotFound = matterr.New(matterr.ErrNotFound, "not found")
+
+// ErrNotArmed is returned when staged mutations are committed without an armed fail-safe.
+var ErrNotArmed = matterr.New(matterr.ErrRejected, "not armed")
+
+// Store represents a namespaced key-value store for controller and device state.
+type Store interface {
+	// Get returns the value of the specified key in the namespace, or ErrNotFound.
+	Get(ns string, key string) ([]byte, error)
+	// Set sets the value of the specified key in the namespace.
+	Set(ns string, key string, value []byte) error
+	// Delete deletes the specified key in the namespace. Deleting a missing key is not an error.
+	Delete(ns string, key string) error
+	// Keys returns all keys in the namespace in lexical order.
+	Keys(ns string) ([]string, error)
+	// NewBatch returns a new batch whose operations are applied atomically on commit.
+	NewBatch() Batch
+}
+
+// Batch represents a set of store operations which are applied atomically.
+type Batch interface {
+	// Set adds a set operation into the batch.
+	Set(ns string, key string, value []byte)
+	// Delete adds a delete operation into the batch.
+	Delete(ns string, key string)
+	// Commit applies all operations in the batch atomically.
+	Commit() error
+}
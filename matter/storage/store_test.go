@@ -0,0 +1,182 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func storeTest(t *testing.T, store Store) {
+	t.Helper()
+
+	if _, err := store.Get("fabric", "1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("%v != %v", err, ErrNotFound)
+	}
+
+	if err := store.Set("fabric", "1", []byte("root")); err != nil {
+		t.Error(err)
+		return
+	}
+	value, err := store.Get("fabric", "1")
+	if err != nil || !bytes.Equal(value, []byte("root")) {
+		t.Errorf("%s != %s (%v)", value, "root", err)
+	}
+
+	batch := store.NewBatch()
+	batch.Set("fabric", "2", []byte("noc"))
+	batch.Set("acl", "1", []byte("entry"))
+	batch.Delete("fabric", "1")
+	if _, err := store.Get("fabric", "2"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("uncommitted batch is visible")
+	}
+	if err := batch.Commit(); err != nil {
+		t.Error(err)
+		return
+	}
+
+	keys, err := store.Keys("fabric")
+	if err != nil || len(keys) != 1 || keys[0] != "2" {
+		t.Errorf("%v != %v (%v)", keys, []string{"2"}, err)
+	}
+	keys, err = store.Keys("acl")
+	if err != nil || len(keys) != 1 || keys[0] != "1" {
+		t.Errorf("%v != %v (%v)", keys, []string{"1"}, err)
+	}
+
+	if err := store.Delete("acl", "1"); err != nil {
+		t.Error(err)
+	}
+	if err := store.Delete("acl", "1"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	storeTest(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "matter.json")
+
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	storeTest(t, store)
+
+	reopened, err := NewFileStore(path)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	value, err := reopened.Get("fabric", "2")
+	if err != nil || !bytes.Equal(value, []byte("noc")) {
+		t.Errorf("%s != %s (%v)", value, "noc", err)
+	}
+	keys, _ := reopened.Keys("acl")
+	if len(keys) != 0 {
+		t.Errorf("%v is not deleted", keys)
+	}
+}
+
+func TestFailSafeStore(t *testing.T) {
+	storeTest(t, NewFailSafeStore(NewMemoryStore()))
+
+	path := filepath.Join(t.TempDir(), "matter.json")
+	file, err := NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Set("acl", "1", []byte("admin")); err != nil {
+		t.Fatal(err)
+	}
+	store := NewFailSafeStore(file)
+
+	if err := store.Commit(); !errors.Is(err, ErrNotArmed) {
+		t.Errorf("%v is not %v", err, ErrNotArmed)
+	}
+
+	// Mutations during commissioning are staged until CommissioningComplete.
+	store.Arm(time.Minute)
+	if err := store.Set("fabric", "1", []byte("noc")); err != nil {
+		t.Fatal(err)
+	}
+	batch := store.NewBatch()
+	batch.Set("acl", "2", []byte("operate"))
+	batch.Delete("acl", "1")
+	if err := batch.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.Get("fabric", "1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("staged mutation is written through")
+	}
+	if value, err := store.Get("fabric", "1"); err != nil || !bytes.Equal(value, []byte("noc")) {
+		t.Errorf("%s != %s (%v)", value, "noc", err)
+	}
+	if _, err := store.Get("acl", "1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("staged delete is not visible")
+	}
+	if keys, _ := store.Keys("acl"); len(keys) != 1 || keys[0] != "2" {
+		t.Errorf("%v != %v", keys, []string{"2"})
+	}
+	if err := store.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if store.IsArmed() {
+		t.Error("fail-safe is armed after commit")
+	}
+
+	reopened, err := NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys, _ := reopened.Keys("acl"); len(keys) != 1 || keys[0] != "2" {
+		t.Errorf("%v != %v", keys, []string{"2"})
+	}
+	if _, err := reopened.Get("fabric", "1"); err != nil {
+		t.Error(err)
+	}
+
+	// Mutations are dropped when the fail-safe is disarmed or expires.
+	store.Arm(time.Minute)
+	if err := store.Delete("fabric", "1"); err != nil {
+		t.Fatal(err)
+	}
+	store.Arm(0)
+	if _, err := store.Get("fabric", "1"); err != nil {
+		t.Error(err)
+	}
+
+	store.Arm(time.Millisecond)
+	if err := store.Set("group", "1", []byte("key")); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for store.IsArmed() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if store.IsArmed() {
+		t.Fatal("fail-safe does not expire")
+	}
+	if _, err := store.Get("group", "1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expired mutation is visible")
+	}
+}
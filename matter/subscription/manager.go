@@ -0,0 +1,399 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subscription
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/cluster/descriptor"
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/event"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/log"
+	"github.com/cybergarage/go-matter/matter/storage"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+const (
+	// MaxIntervalPublisherLimit is the upper limit of the max interval which the publisher selects
+	// (SUBSCRIPTION_MAX_INTERVAL_PUBLISHER_LIMIT).
+	MaxIntervalPublisherLimit = 60 * time.Minute
+	// DefaultMaxSubscriptionsPerFabric is the minimum number of subscriptions which a node supports per fabric.
+	DefaultMaxSubscriptionsPerFabric = 3
+	// idleInterval is the interval to process subscriptions when there is no subscription.
+	idleInterval = MaxIntervalPublisherLimit
+)
+
+// Sender represents a sender of reports to subscribers. The sender establishes a CASE session to the
+// subscriber if there is no session, which is the case for subscriptions resumed after a reboot.
+type Sender interface {
+	// SendReport sends the report of the subscription, and returns an error if the subscriber does not respond.
+	SendReport(ctx context.Context, sub *Subscription, report *Report) error
+}
+
+// Option represents an option of the manager.
+type Option func(*Manager)
+
+// WithStore sets the store to persist subscriptions, which are resumed after a reboot.
+func WithStore(store storage.Store) Option {
+	return func(mgr *Manager) {
+		mgr.store = store
+	}
+}
+
+// WithEventManager sets the event log of the node, whose events are reported to the event subscriptions.
+func WithEventManager(events *event.Manager) Option {
+	return func(mgr *Manager) {
+		mgr.events = events
+	}
+}
+
+// WithMaxInterval sets the max interval which the publisher prefers. The negotiated max interval is not
+// less than the max interval requested by the subscriber.
+func WithMaxInterval(d time.Duration) Option {
+	return func(mgr *Manager) {
+		mgr.maxInterval = d
+	}
+}
+
+// WithMaxSubscriptionsPerFabric sets the maximum number of subscriptions per fabric.
+func WithMaxSubscriptionsPerFabric(n int) Option {
+	return func(mgr *Manager) {
+		mgr.maxPerFabric = n
+	}
+}
+
+// WithReportBudget sets the maximum number of reports sent in each processing, which is unlimited by default.
+// The remaining reports are sent in the next processing in order of their priorities.
+func WithReportBudget(n int) Option {
+	return func(mgr *Manager) {
+		mgr.budget = n
+	}
+}
+
+// WithClock sets the function which returns the current time.
+func WithClock(now func() time.Time) Option {
+	return func(mgr *Manager) {
+		mgr.now = now
+	}
+}
+
+// Manager represents the subscriptions on a publisher node, which reports the changes of the subscribed
+// attributes. Paths with wildcards are expanded whenever they are reported, so endpoints which are added
+// or removed at runtime are reported without errors.
+type Manager struct {
+	sync.Mutex
+	node         *datamodel.Node
+	sender       Sender
+	store        storage.Store
+	events       *event.Manager
+	subs         map[im.SubscriptionID]*Subscription
+	nextID       im.SubscriptionID
+	maxInterval  time.Duration
+	maxPerFabric int
+	budget       int
+	now          func() time.Time
+	wake         chan struct{}
+}
+
+// NewManager returns a new manager of the subscriptions on the specified node, and resumes the
+// persisted subscriptions.
+func NewManager(node *datamodel.Node, sender Sender, opts ...Option) (*Manager, error) {
+	mgr := &Manager{
+		Mutex:        sync.Mutex{},
+		node:         node,
+		sender:       sender,
+		store:        nil,
+		events:       nil,
+		subs:         map[im.SubscriptionID]*Subscription{},
+		nextID:       newSubscriptionID(),
+		maxInterval:  0,
+		maxPerFabric: DefaultMaxSubscriptionsPerFabric,
+		budget:       0,
+		now:          time.Now,
+		wake:         make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(mgr)
+	}
+	if mgr.store != nil {
+		subs, err := loadSubscriptions(mgr.store)
+		if err != nil {
+			return nil, err
+		}
+		for _, sub := range subs {
+			mgr.subs[sub.id] = sub
+		}
+	}
+	node.AddAttributeListener(mgr.attributeChanged)
+	node.AddListener(mgr.endpointChanged)
+	if mgr.events != nil {
+		mgr.events.AddListener(mgr.eventLogged)
+	}
+	return mgr, nil
+}
+
+// newSubscriptionID returns a random initial subscription ID, so IDs are not reused after a reboot.
+func newSubscriptionID() im.SubscriptionID {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return 1
+	}
+	return im.SubscriptionID(binary.LittleEndian.Uint32(b))
+}
+
+// negotiateMaxInterval returns the max interval of a subscription, which is between the max interval
+// requested by the subscriber and the larger of the publisher limit and the requested interval.
+func (mgr *Manager) negotiateMaxInterval(req im.SubscribeRequest) time.Duration {
+	ceiling := max(MaxIntervalPublisherLimit, req.MaxInterval)
+	return min(max(req.MaxInterval, mgr.maxInterval), ceiling)
+}
+
+// Subscribe starts a subscription of the subscriber, and returns the subscription and its priming report.
+// The existing subscriptions of the subscriber are removed unless the request keeps them.
+func (mgr *Manager) Subscribe(subscriber Subscriber, req im.SubscribeRequest) (*Subscription, *Report, error) {
+	if len(req.Paths) == 0 && len(req.EventPaths) == 0 {
+		return nil, nil, fmt.Errorf("%w : no attribute and event path", im.StatusInvalidAction)
+	}
+	if req.MinInterval < 0 || req.MaxInterval < req.MinInterval {
+		return nil, nil, fmt.Errorf("%w : invalid intervals (%s, %s)", im.StatusInvalidAction, req.MinInterval, req.MaxInterval)
+	}
+
+	mgr.Lock()
+	if !req.KeepSubscriptions {
+		for _, sub := range mgr.subs {
+			if sub.subscriber == subscriber {
+				mgr.removeLocked(sub.id)
+			}
+		}
+	}
+	if 0 < mgr.maxPerFabric && mgr.maxPerFabric <= mgr.countLocked(subscriber.FabricIndex) {
+		mgr.Unlock()
+		return nil, nil, fmt.Errorf("%w : subscriptions on fabric (%d)", im.StatusResourceExhausted, subscriber.FabricIndex)
+	}
+	for _, ok := mgr.subs[mgr.nextID]; ok; _, ok = mgr.subs[mgr.nextID] {
+		mgr.nextID++
+	}
+	sub := newSubscription(mgr.nextID, subscriber, req, mgr.negotiateMaxInterval(req))
+	mgr.nextID++
+	mgr.subs[sub.id] = sub
+	mgr.Unlock()
+
+	report := sub.newReport(mgr.node, mgr.events, mgr.now())
+	if err := mgr.save(sub); err != nil {
+		mgr.Lock()
+		mgr.removeLocked(sub.id)
+		mgr.Unlock()
+		return nil, nil, err
+	}
+	return sub, report, nil
+}
+
+// Unsubscribe removes the specified subscription.
+func (mgr *Manager) Unsubscribe(id im.SubscriptionID) error {
+	mgr.Lock()
+	defer mgr.Unlock()
+	if _, ok := mgr.subs[id]; !ok {
+		return fmt.Errorf("subscription (%08X) is %w", uint32(id), ErrNotFound)
+	}
+	mgr.removeLocked(id)
+	return nil
+}
+
+// RemoveFabric removes the subscriptions of the specified fabric, which is called when the fabric is removed.
+func (mgr *Manager) RemoveFabric(index types.FabricIndex) {
+	mgr.Lock()
+	defer mgr.Unlock()
+	for _, sub := range mgr.subs {
+		if sub.subscriber.FabricIndex == index {
+			mgr.removeLocked(sub.id)
+		}
+	}
+}
+
+// LookupSubscription returns the subscription of the specified ID.
+func (mgr *Manager) LookupSubscription(id im.SubscriptionID) (*Subscription, bool) {
+	mgr.Lock()
+	defer mgr.Unlock()
+	sub, ok := mgr.subs[id]
+	return sub, ok
+}
+
+// Subscriptions returns the subscriptions in ascending order of the IDs.
+func (mgr *Manager) Subscriptions() []*Subscription {
+	mgr.Lock()
+	defer mgr.Unlock()
+	subs := make([]*Subscription, 0, len(mgr.subs))
+	for _, sub := range mgr.subs {
+		subs = append(subs, sub)
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].id < subs[j].id })
+	return subs
+}
+
+func (mgr *Manager) countLocked(index types.FabricIndex) int {
+	n := 0
+	for _, sub := range mgr.subs {
+		if sub.subscriber.FabricIndex == index {
+			n++
+		}
+	}
+	return n
+}
+
+func (mgr *Manager) removeLocked(id im.SubscriptionID) {
+	delete(mgr.subs, id)
+	if mgr.store == nil {
+		return
+	}
+	if err := mgr.store.Delete(Namespace, subscriptionKey(id)); err != nil {
+		log.Warnf("subscription (%08X) is not deleted from the store (%s)", uint32(id), err)
+	}
+}
+
+func (mgr *Manager) save(sub *Subscription) error {
+	if mgr.store == nil {
+		return nil
+	}
+	b, err := sub.bytes()
+	if err != nil {
+		return err
+	}
+	return mgr.store.Set(Namespace, subscriptionKey(sub.id), b)
+}
+
+// Process sends the reports of the subscriptions which are due, and returns the duration until the next
+// report is due. Subscriptions whose reports are not delivered are removed.
+func (mgr *Manager) Process(ctx context.Context) time.Duration {
+	now := mgr.now()
+	due := []*Subscription{}
+	for _, sub := range mgr.Subscriptions() {
+		if !now.Before(sub.deadline()) {
+			due = append(due, sub)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		pi, ti := due[i].priority(now)
+		pj, tj := due[j].priority(now)
+		if pi != pj {
+			return pi < pj
+		}
+		return ti.Before(tj)
+	})
+	if 0 < mgr.budget && mgr.budget < len(due) {
+		due = due[:mgr.budget]
+	}
+
+	for _, sub := range due {
+		report := sub.newReport(mgr.node, mgr.events, now)
+		if err := mgr.sender.SendReport(ctx, sub, report); err != nil {
+			log.Warnf("subscription (%08X) is removed (%s)", uint32(sub.id), err)
+			mgr.Lock()
+			mgr.removeLocked(sub.id)
+			mgr.Unlock()
+		}
+	}
+
+	next := idleInterval
+	for _, sub := range mgr.Subscriptions() {
+		next = min(next, max(sub.deadline().Sub(now), 0))
+	}
+	return next
+}
+
+// Run processes the subscriptions until the context is canceled. Changes of the subscribed attributes
+// wake up the processing.
+func (mgr *Manager) Run(ctx context.Context) error {
+	for {
+		timer := time.NewTimer(mgr.Process(ctx))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		case <-mgr.wake:
+			timer.Stop()
+		}
+	}
+}
+
+func (mgr *Manager) markDirty(path im.AttributePath) {
+	now := mgr.now()
+	marked := false
+	for _, sub := range mgr.Subscriptions() {
+		if sub.markDirty(path, now) {
+			marked = true
+		}
+	}
+	if marked {
+		mgr.wakeUp()
+	}
+}
+
+func (mgr *Manager) wakeUp() {
+	select {
+	case mgr.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (mgr *Manager) eventLogged(rec *event.Record) {
+	now := mgr.now()
+	marked := false
+	for _, sub := range mgr.Subscriptions() {
+		if sub.markEvent(rec.Path, rec.Number, now) {
+			marked = true
+		}
+	}
+	if marked {
+		mgr.wakeUp()
+	}
+}
+
+func (mgr *Manager) attributeChanged(path im.AttributePath) {
+	mgr.markDirty(path)
+}
+
+// endpointChanged marks the attributes of the added endpoint dirty, and the concrete paths of the removed
+// endpoint dirty to report their statuses. The parts lists of the descriptors also change.
+func (mgr *Manager) endpointChanged(ep *datamodel.Endpoint, added bool) {
+	paths := []im.AttributePath{}
+	for _, sub := range mgr.Subscriptions() {
+		for _, path := range sub.paths {
+			if path.Endpoint != im.WildcardEndpointID && path.Endpoint != ep.ID() {
+				continue
+			}
+			switch {
+			case added && path.IsWildcard():
+				path.Endpoint = ep.ID()
+				paths = append(paths, expandPath(mgr.node, path)...)
+			case !path.IsWildcard():
+				paths = append(paths, path)
+			}
+		}
+	}
+	for _, other := range mgr.node.Endpoints() {
+		paths = append(paths, im.NewAttributePath(other.ID(), descriptor.ClusterID, descriptor.PartsListAttributeID))
+	}
+	for _, path := range paths {
+		mgr.markDirty(path)
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subscription
+
+import (
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/event"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// Report represents a report of a subscription (ReportDataMessage).
+type Report struct {
+	// SubscriptionID is the ID of the reported subscription.
+	SubscriptionID im.SubscriptionID
+	// Priming is true if the report includes all subscribed attributes, which is the first report
+	// of a subscription and the first report of a subscription resumed after a reboot.
+	Priming bool
+	// AttributeData is the changed attribute values.
+	AttributeData []im.AttributeData
+	// AttributeStatuses is the statuses of the concrete paths which can not be read.
+	AttributeStatuses []im.AttributeStatus
+	// EventData is the events logged after the last report in ascending order of the event numbers.
+	EventData []*event.Record
+}
+
+// IsEmpty returns true if the report has no attribute and event, which is an empty report to keep
+// the subscription alive.
+func (report *Report) IsEmpty() bool {
+	return len(report.AttributeData) == 0 && len(report.AttributeStatuses) == 0 && len(report.EventData) == 0
+}
+
+// expandPath returns the concrete paths of the existing attributes which match the specified path.
+// Wildcard fields never match missing elements, so wildcard paths do not yield any errors.
+func expandPath(node *datamodel.Node, path im.AttributePath) []im.AttributePath {
+	paths := []im.AttributePath{}
+	for _, ep := range node.Endpoints() {
+		if path.Endpoint != im.WildcardEndpointID && path.Endpoint != ep.ID() {
+			continue
+		}
+		for _, cluster := range ep.Clusters() {
+			if path.Cluster != im.WildcardClusterID && path.Cluster != cluster.ID() {
+				continue
+			}
+			for _, id := range cluster.AttributeIDs() {
+				if path.Attribute != im.WildcardAttributeID && path.Attribute != id {
+					continue
+				}
+				paths = append(paths, im.NewAttributePath(ep.ID(), cluster.ID(), id))
+			}
+		}
+	}
+	return paths
+}
+
+// readAttribute returns the encoded value and the data version of the specified concrete path
+// read by the subscriber.
+func (sub *Subscription) readAttribute(node *datamodel.Node, path im.AttributePath) (*im.AttributeData, error) {
+	ep, ok := node.LookupEndpoint(path.Endpoint)
+	if !ok {
+		return nil, im.StatusUnsupportedEndpoint
+	}
+	cluster, ok := ep.LookupCluster(path.Cluster)
+	if !ok {
+		return nil, im.StatusUnsupportedCluster
+	}
+	v, err := datamodel.ReadFabricAttribute(cluster, path.Attribute, sub.subscriber.FabricIndex, sub.fabricFiltered)
+	if err != nil {
+		return nil, err
+	}
+	b, err := datatype.Encode(v)
+	if err != nil {
+		return nil, err
+	}
+	return &im.AttributeData{
+		Path:        path,
+		DataVersion: cluster.DataVersion(),
+		Data:        b,
+	}, nil
+}
+
+// newReport reads the subscribed attributes, which are all attributes for priming reports and the dirty
+// attributes otherwise, with the subscribed events in the event log, and clears the dirty attributes. Dirty attributes whose data versions are
+// not changed since the last report are skipped. The attributes are read without holding the lock, since
+// reading attributes which are updated on read notifies their changes.
+func (sub *Subscription) newReport(node *datamodel.Node, events *event.Manager, now time.Time) *Report {
+	sub.Lock()
+	priming := !sub.primed
+	nDirty := len(sub.dirty)
+	paths := []im.AttributePath{}
+	if priming {
+		for _, path := range sub.paths {
+			if path.IsWildcard() {
+				paths = append(paths, expandPath(node, path)...)
+			} else {
+				paths = append(paths, path)
+			}
+		}
+	} else {
+		paths = append(paths, sub.dirty...)
+	}
+	versions := map[im.AttributePath]im.DataVersion{}
+	for path, version := range sub.versions {
+		versions[path] = version
+	}
+	eventMin := sub.eventMin
+	sub.Unlock()
+
+	report := &Report{
+		SubscriptionID:    sub.id,
+		Priming:           priming,
+		AttributeData:     []im.AttributeData{},
+		AttributeStatuses: []im.AttributeStatus{},
+		EventData:         []*event.Record{},
+	}
+	read := map[im.AttributePath]bool{}
+	for _, path := range paths {
+		if read[path] {
+			continue
+		}
+		read[path] = true
+		data, err := sub.readAttribute(node, path)
+		if err != nil {
+			delete(versions, path)
+			if sub.isConcrete(path) {
+				report.AttributeStatuses = append(report.AttributeStatuses, im.AttributeStatus{
+					Path:   path,
+					Status: im.StatusOf(err),
+				})
+			}
+			continue
+		}
+		if version, ok := versions[path]; ok && !priming && version == data.DataVersion {
+			continue
+		}
+		versions[path] = data.DataVersion
+		report.AttributeData = append(report.AttributeData, *data)
+	}
+	if events != nil && 0 < len(sub.eventPaths) {
+		report.EventData = events.Events(sub.eventPaths, eventMin)
+		if n := len(report.EventData); 0 < n {
+			eventMin = report.EventData[n-1].Number + 1
+		}
+	}
+
+	sub.Lock()
+	defer sub.Unlock()
+	// The attributes which are changed while reading are kept dirty, and skipped in the next report
+	// if they are changed by reading the attributes in this report.
+	sub.dirty = append([]im.AttributePath{}, sub.dirty[nDirty:]...)
+	sub.eventMin = eventMin
+	if sub.isDirty() {
+		sub.dirtySince = now
+	}
+	sub.versions = versions
+	sub.lastReport = now
+	sub.primed = true
+	sub.resumed = false
+	return report
+}
@@ -0,0 +1,197 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subscription
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/storage"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+const (
+	// Namespace is the storage namespace of the subscriptions which are resumed after a reboot.
+	Namespace = "subscriptions"
+)
+
+func subscriptionKey(id im.SubscriptionID) string {
+	return fmt.Sprintf("%08X", uint32(id))
+}
+
+func newPathStruct() *datatype.Struct {
+	return datatype.NewStruct(
+		datatype.NewField(0, new(datatype.Uint16)),
+		datatype.NewField(1, new(datatype.Uint32)),
+		datatype.NewField(2, new(datatype.Uint32)))
+}
+
+// newEventPathStruct returns the persisted form of an event path, whose urgency is persisted only if
+// it is urgent so subscriptions persisted without the urgency are still resumed.
+func newEventPathStruct() *datatype.Struct {
+	path := newPathStruct()
+	path.Fields = append(path.Fields, datatype.NewOptionalField(3, new(datatype.Bool)))
+	return path
+}
+
+// durationSeconds returns the seconds of the interval, which is encoded in seconds on the wire.
+func durationSeconds(d time.Duration) uint16 {
+	secs := d / time.Second
+	if secs < 0 {
+		return 0
+	}
+	if 0xFFFF < secs {
+		return 0xFFFF
+	}
+	return uint16(secs)
+}
+
+// bytes returns the persisted form of the subscription.
+func (sub *Subscription) bytes() ([]byte, error) {
+	fabric := datatype.Uint8(sub.subscriber.FabricIndex)
+	nodeID := datatype.Uint64(sub.subscriber.NodeID)
+	minInterval := datatype.Uint16(durationSeconds(sub.minInterval))
+	maxInterval := datatype.Uint16(durationSeconds(sub.maxInterval))
+	paths := datatype.NewList(func() datatype.Value { return newPathStruct() })
+	for _, path := range sub.paths {
+		paths.Elements = append(paths.Elements, encodePath(uint16(path.Endpoint), uint32(path.Cluster), uint32(path.Attribute)))
+	}
+	eventPaths := datatype.NewList(func() datatype.Value { return newEventPathStruct() })
+	for _, path := range sub.eventPaths {
+		elem := encodePath(uint16(path.Endpoint), uint32(path.Cluster), uint32(path.Event))
+		if path.IsUrgent {
+			urgent := datatype.Bool(true)
+			elem.Fields = append(elem.Fields, datatype.NewField(3, &urgent))
+		}
+		eventPaths.Elements = append(eventPaths.Elements, elem)
+	}
+	sub.Lock()
+	eventMin := datatype.Uint64(sub.eventMin)
+	sub.Unlock()
+	fabricFiltered := datatype.Bool(sub.fabricFiltered)
+	return datatype.Encode(datatype.NewStruct(
+		datatype.NewField(0, &fabric),
+		datatype.NewField(1, &nodeID),
+		datatype.NewField(2, &minInterval),
+		datatype.NewField(3, &maxInterval),
+		datatype.NewField(4, paths),
+		datatype.NewField(5, eventPaths),
+		datatype.NewField(6, &eventMin),
+		datatype.NewField(7, &fabricFiltered)))
+}
+
+func encodePath(endpoint uint16, cluster uint32, element uint32) *datatype.Struct {
+	ep := datatype.Uint16(endpoint)
+	c := datatype.Uint32(cluster)
+	e := datatype.Uint32(element)
+	return datatype.NewStruct(
+		datatype.NewField(0, &ep),
+		datatype.NewField(1, &c),
+		datatype.NewField(2, &e))
+}
+
+// decodePath returns the endpoint, cluster and element IDs of the decoded path.
+func decodePath(elem datatype.Value) (uint16, uint32, uint32) {
+	st := elem.(*datatype.Struct)
+	endpoint, _ := st.LookupField(0)
+	cluster, _ := st.LookupField(1)
+	element, _ := st.LookupField(2)
+	return uint16(*endpoint.Value.(*datatype.Uint16)), uint32(*cluster.Value.(*datatype.Uint32)), uint32(*element.Value.(*datatype.Uint32))
+}
+
+// newSubscriptionFromBytes returns the subscription of the persisted form, which is resumed
+// with a priming report.
+func newSubscriptionFromBytes(id im.SubscriptionID, b []byte) (*Subscription, error) {
+	fabric := new(datatype.Uint8)
+	nodeID := new(datatype.Uint64)
+	minInterval := new(datatype.Uint16)
+	maxInterval := new(datatype.Uint16)
+	paths := datatype.NewList(func() datatype.Value { return newPathStruct() })
+	eventPaths := datatype.NewList(func() datatype.Value { return newEventPathStruct() })
+	eventMin := new(datatype.Uint64)
+	fabricFiltered := new(datatype.Bool)
+	err := datatype.Decode(b, datatype.NewStruct(
+		datatype.NewField(0, fabric),
+		datatype.NewField(1, nodeID),
+		datatype.NewField(2, minInterval),
+		datatype.NewField(3, maxInterval),
+		datatype.NewField(4, paths),
+		datatype.NewField(5, eventPaths),
+		datatype.NewField(6, eventMin),
+		datatype.NewField(7, fabricFiltered)))
+	if err != nil {
+		return nil, fmt.Errorf("%w subscription (%08X) : %w", ErrInvalid, uint32(id), err)
+	}
+
+	subscriber := Subscriber{
+		FabricIndex: types.FabricIndex(*fabric),
+		NodeID:      types.NodeID(*nodeID),
+	}
+	req := im.SubscribeRequest{
+		Paths:             []im.AttributePath{},
+		EventPaths:        []im.EventPath{},
+		EventMin:          im.EventNumber(*eventMin),
+		MinInterval:       time.Duration(*minInterval) * time.Second,
+		MaxInterval:       time.Duration(*maxInterval) * time.Second,
+		KeepSubscriptions: true,
+		FabricFiltered:    bool(*fabricFiltered),
+	}
+	for _, elem := range paths.Elements {
+		endpoint, cluster, attribute := decodePath(elem)
+		req.Paths = append(req.Paths, im.NewAttributePath(im.EndpointID(endpoint), im.ClusterID(cluster), im.AttributeID(attribute)))
+	}
+	for _, elem := range eventPaths.Elements {
+		endpoint, cluster, ev := decodePath(elem)
+		path := im.NewEventPath(im.EndpointID(endpoint), im.ClusterID(cluster), im.EventID(ev))
+		if urgent, ok := elem.(*datatype.Struct).LookupField(3); ok && urgent.Present {
+			path.IsUrgent = bool(*urgent.Value.(*datatype.Bool))
+		}
+		req.EventPaths = append(req.EventPaths, path)
+	}
+	sub := newSubscription(id, subscriber, req, req.MaxInterval)
+	sub.resumed = true
+	return sub, nil
+}
+
+// loadSubscriptions returns the persisted subscriptions. Invalid subscriptions are deleted.
+func loadSubscriptions(store storage.Store) ([]*Subscription, error) {
+	keys, err := store.Keys(Namespace)
+	if err != nil {
+		return nil, err
+	}
+	subs := []*Subscription{}
+	for _, key := range keys {
+		b, err := store.Get(Namespace, key)
+		if err != nil {
+			return nil, err
+		}
+		id, err := strconv.ParseUint(key, 16, 32)
+		var sub *Subscription
+		if err == nil {
+			sub, err = newSubscriptionFromBytes(im.SubscriptionID(id), b)
+		}
+		if err != nil {
+			if err := store.Delete(Namespace, key); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
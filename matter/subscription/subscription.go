@@ -0,0 +1,241 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subscription
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// Subscriber represents a node which subscribes, which is identified on its fabric.
+type Subscriber struct {
+	// FabricIndex is the fabric of the subscriber.
+	FabricIndex types.FabricIndex
+	// NodeID is the operational node ID of the subscriber.
+	NodeID types.NodeID
+}
+
+// Subscription represents the state of a subscription on the publisher. The attributes which are
+// changed after the last report are tracked as dirty paths, and reported after the min interval
+// with the events logged after the last report. Events of urgent paths are reported without waiting
+// for the min interval.
+type Subscription struct {
+	sync.Mutex
+	id             im.SubscriptionID
+	subscriber     Subscriber
+	paths          []im.AttributePath
+	eventPaths     []im.EventPath
+	fabricFiltered bool
+	minInterval    time.Duration
+	maxInterval    time.Duration
+	dirty          []im.AttributePath
+	dirtySince     time.Time
+	versions       map[im.AttributePath]im.DataVersion
+	eventMin       im.EventNumber
+	lastEvent      im.EventNumber
+	eventLogged    bool
+	urgentEvent    im.EventNumber
+	urgentLogged   bool
+	lastReport     time.Time
+	primed         bool
+	resumed        bool
+}
+
+func newSubscription(id im.SubscriptionID, subscriber Subscriber, req im.SubscribeRequest, maxInterval time.Duration) *Subscription {
+	return &Subscription{
+		Mutex:          sync.Mutex{},
+		id:             id,
+		subscriber:     subscriber,
+		paths:          append([]im.AttributePath{}, req.Paths...),
+		eventPaths:     append([]im.EventPath{}, req.EventPaths...),
+		fabricFiltered: req.FabricFiltered,
+		minInterval:    req.MinInterval,
+		maxInterval:    maxInterval,
+		dirty:          []im.AttributePath{},
+		dirtySince:     time.Time{},
+		versions:       map[im.AttributePath]im.DataVersion{},
+		eventMin:       req.EventMin,
+		lastEvent:      0,
+		eventLogged:    false,
+		urgentEvent:    0,
+		urgentLogged:   false,
+		lastReport:     time.Time{},
+		primed:         false,
+		resumed:        false,
+	}
+}
+
+// ID returns the subscription ID.
+func (sub *Subscription) ID() im.SubscriptionID {
+	return sub.id
+}
+
+// Subscriber returns the subscriber.
+func (sub *Subscription) Subscriber() Subscriber {
+	return sub.subscriber
+}
+
+// Paths returns the subscribed attribute paths, which may have wildcards.
+func (sub *Subscription) Paths() []im.AttributePath {
+	return append([]im.AttributePath{}, sub.paths...)
+}
+
+// EventPaths returns the subscribed event paths, which may have wildcards.
+func (sub *Subscription) EventPaths() []im.EventPath {
+	return append([]im.EventPath{}, sub.eventPaths...)
+}
+
+// EventMin returns the minimum event number of the next report.
+func (sub *Subscription) EventMin() im.EventNumber {
+	sub.Lock()
+	defer sub.Unlock()
+	return sub.eventMin
+}
+
+// IsFabricFiltered returns true if fabric-scoped lists are limited to the elements of the subscriber fabric.
+func (sub *Subscription) IsFabricFiltered() bool {
+	return sub.fabricFiltered
+}
+
+// MinInterval returns the minimum interval between reports.
+func (sub *Subscription) MinInterval() time.Duration {
+	return sub.minInterval
+}
+
+// MaxInterval returns the maximum interval between reports negotiated with the subscriber.
+func (sub *Subscription) MaxInterval() time.Duration {
+	return sub.maxInterval
+}
+
+// IsResumed returns true if the subscription was restored from the store and has not been primed yet.
+func (sub *Subscription) IsResumed() bool {
+	sub.Lock()
+	defer sub.Unlock()
+	return sub.resumed
+}
+
+// isSubscribed returns true if the concrete path matches one of the subscribed paths.
+func (sub *Subscription) isSubscribed(path im.AttributePath) bool {
+	for _, pattern := range sub.paths {
+		if pattern.Match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// isConcrete returns true if the concrete path is subscribed without wildcards, whose errors are reported.
+func (sub *Subscription) isConcrete(path im.AttributePath) bool {
+	for _, pattern := range sub.paths {
+		if pattern == path {
+			return true
+		}
+	}
+	return false
+}
+
+// markDirty marks the concrete path dirty, and returns true if it is subscribed.
+func (sub *Subscription) markDirty(path im.AttributePath, now time.Time) bool {
+	if !sub.isSubscribed(path) {
+		return false
+	}
+	sub.Lock()
+	defer sub.Unlock()
+	for _, dirty := range sub.dirty {
+		if dirty == path {
+			return true
+		}
+	}
+	if !sub.isDirty() {
+		sub.dirtySince = now
+	}
+	sub.dirty = append(sub.dirty, path)
+	return true
+}
+
+// markEvent marks the logged event to be reported, and returns true if it is subscribed. The event is
+// urgent if any of the matching paths is urgent.
+func (sub *Subscription) markEvent(path im.EventPath, number im.EventNumber, now time.Time) bool {
+	subscribed := false
+	urgent := false
+	for _, pattern := range sub.eventPaths {
+		if pattern.Match(path) {
+			subscribed = true
+			urgent = urgent || pattern.IsUrgent
+		}
+	}
+	if !subscribed {
+		return false
+	}
+	sub.Lock()
+	defer sub.Unlock()
+	if !sub.isDirty() {
+		sub.dirtySince = now
+	}
+	sub.eventLogged = true
+	sub.lastEvent = max(sub.lastEvent, number)
+	if urgent {
+		sub.urgentLogged = true
+		sub.urgentEvent = max(sub.urgentEvent, number)
+	}
+	return true
+}
+
+// isDirty returns true if there are changed attributes or logged events which are not reported yet.
+// The caller must hold the lock.
+func (sub *Subscription) isDirty() bool {
+	return 0 < len(sub.dirty) || (sub.eventLogged && sub.eventMin <= sub.lastEvent)
+}
+
+// isUrgent returns true if there are urgent events which are not reported yet. The caller must hold the lock.
+func (sub *Subscription) isUrgent() bool {
+	return sub.urgentLogged && sub.eventMin <= sub.urgentEvent
+}
+
+// deadline returns the time when the next report is due. Unprimed subscriptions and subscriptions with
+// urgent events are due immediately, dirty subscriptions after the min interval, and others after the
+// max interval to keep them alive.
+func (sub *Subscription) deadline() time.Time {
+	sub.Lock()
+	defer sub.Unlock()
+	if !sub.primed || sub.isUrgent() {
+		return time.Time{}
+	}
+	if sub.isDirty() {
+		return sub.lastReport.Add(sub.minInterval)
+	}
+	return sub.lastReport.Add(sub.maxInterval)
+}
+
+// priority returns the priority class of a due subscription, where a smaller class is reported first.
+// Resumed and new subscriptions are primed first, subscriptions with urgent events come next, then
+// subscriptions which reach the max interval since the subscribers drop them otherwise, and then dirty
+// subscriptions in order of their changes.
+func (sub *Subscription) priority(now time.Time) (int, time.Time) {
+	sub.Lock()
+	defer sub.Unlock()
+	switch {
+	case !sub.primed:
+		return 0, sub.lastReport
+	case sub.isUrgent():
+		return 1, sub.dirtySince
+	case !now.Before(sub.lastReport.Add(sub.maxInterval)):
+		return 2, sub.lastReport
+	}
+	return 3, sub.dirtySince
+}
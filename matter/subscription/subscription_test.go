@@ -0,0 +1,419 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subscription
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/event"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/storage"
+)
+
+const (
+	testClusterID   im.ClusterID   = 0x0006
+	testAttributeID im.AttributeID = 0x0000
+)
+
+var testSubscriber = Subscriber{FabricIndex: 1, NodeID: 0x1234}
+
+type testClock struct {
+	now time.Time
+}
+
+func (clock *testClock) Now() time.Time {
+	return clock.now
+}
+
+func (clock *testClock) Advance(d time.Duration) {
+	clock.now = clock.now.Add(d)
+}
+
+type testSender struct {
+	reports []*Report
+	err     error
+}
+
+func (sender *testSender) SendReport(ctx context.Context, sub *Subscription, report *Report) error {
+	if sender.err != nil {
+		return sender.err
+	}
+	sender.reports = append(sender.reports, report)
+	return nil
+}
+
+func (sender *testSender) take() []*Report {
+	reports := sender.reports
+	sender.reports = nil
+	return reports
+}
+
+func newTestEndpoint(t *testing.T, id im.EndpointID) (*datamodel.Endpoint, *datamodel.BaseCluster) {
+	t.Helper()
+	ep := datamodel.NewEndpoint(id)
+	cluster := datamodel.NewBaseCluster(testClusterID, 1)
+	v := datatype.Uint8(0)
+	if err := cluster.AddAttribute(datamodel.NewAttribute(testAttributeID, &v)); err != nil {
+		t.Fatal(err)
+	}
+	if err := ep.AddCluster(cluster); err != nil {
+		t.Fatal(err)
+	}
+	return ep, cluster
+}
+
+func setValue(t *testing.T, cluster *datamodel.BaseCluster, v uint8) {
+	t.Helper()
+	value := datatype.Uint8(v)
+	if err := cluster.SetAttribute(testAttributeID, &value); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestRequest(minInterval time.Duration, maxInterval time.Duration, paths ...im.AttributePath) im.SubscribeRequest {
+	return im.SubscribeRequest{
+		Paths:             paths,
+		MinInterval:       minInterval,
+		MaxInterval:       maxInterval,
+		KeepSubscriptions: true,
+	}
+}
+
+func TestSubscription(t *testing.T) {
+	node := datamodel.NewNode()
+	ep, cluster := newTestEndpoint(t, 1)
+	if err := node.AddEndpoint(ep); err != nil {
+		t.Fatal(err)
+	}
+	clock := &testClock{now: time.Unix(0, 0)}
+	sender := &testSender{}
+	mgr, err := NewManager(node, sender, WithClock(clock.Now))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Wildcard paths report the existing attributes, and concrete paths report their statuses.
+	wildcard := im.NewAttributePath(im.WildcardEndpointID, testClusterID, testAttributeID)
+	missing := im.NewAttributePath(5, testClusterID, testAttributeID)
+	sub, report, err := mgr.Subscribe(testSubscriber, newTestRequest(time.Second, 10*time.Second, wildcard, missing))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Priming || len(report.AttributeData) != 1 || len(report.AttributeStatuses) != 1 {
+		t.Fatalf("%+v", report)
+	}
+	if report.AttributeStatuses[0].Status != im.StatusUnsupportedEndpoint {
+		t.Errorf("%s != %s", report.AttributeStatuses[0].Status, im.StatusUnsupportedEndpoint)
+	}
+	if d := mgr.Process(context.Background()); d != 10*time.Second {
+		t.Errorf("next report in %s", d)
+	}
+
+	// Changes are reported after the min interval.
+	setValue(t, cluster, 1)
+	if d := mgr.Process(context.Background()); d != time.Second || len(sender.take()) != 0 {
+		t.Errorf("next report in %s", d)
+	}
+	clock.Advance(time.Second)
+	mgr.Process(context.Background())
+	reports := sender.take()
+	if len(reports) != 1 || reports[0].Priming || len(reports[0].AttributeData) != 1 {
+		t.Fatalf("%+v", reports)
+	}
+	if reports[0].AttributeData[0].DataVersion != cluster.DataVersion() {
+		t.Errorf("data version (%d) != %d", reports[0].AttributeData[0].DataVersion, cluster.DataVersion())
+	}
+
+	// Endpoints added at runtime are reported, and removed ones are not reported as errors.
+	ep2, _ := newTestEndpoint(t, 2)
+	if err := node.AddEndpoint(ep2); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Second)
+	mgr.Process(context.Background())
+	reports = sender.take()
+	if len(reports) != 1 || len(reports[0].AttributeData) != 1 || reports[0].AttributeData[0].Path.Endpoint != 2 {
+		t.Fatalf("%+v", reports)
+	}
+	if err := node.RemoveEndpoint(2); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Second)
+	mgr.Process(context.Background())
+	if reports := sender.take(); len(reports) != 0 {
+		t.Errorf("%+v", reports[0])
+	}
+
+	// Subscriptions are kept alive with empty reports at the max interval.
+	clock.Advance(10 * time.Second)
+	mgr.Process(context.Background())
+	reports = sender.take()
+	if len(reports) != 1 || !reports[0].IsEmpty() {
+		t.Fatalf("%+v", reports)
+	}
+
+	// Subscriptions whose reports are not delivered are removed.
+	sender.err = errors.New("no response")
+	setValue(t, cluster, 2)
+	clock.Advance(time.Second)
+	mgr.Process(context.Background())
+	if _, ok := mgr.LookupSubscription(sub.ID()); ok {
+		t.Error("subscription is not removed")
+	}
+}
+
+func TestSubscribeLimits(t *testing.T) {
+	node := datamodel.NewNode()
+	ep, _ := newTestEndpoint(t, 1)
+	if err := node.AddEndpoint(ep); err != nil {
+		t.Fatal(err)
+	}
+	mgr, err := NewManager(node, &testSender{}, WithMaxInterval(2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := im.NewAttributePath(1, testClusterID, testAttributeID)
+
+	if _, _, err := mgr.Subscribe(testSubscriber, newTestRequest(2*time.Second, time.Second, path)); !errors.Is(err, im.StatusInvalidAction) {
+		t.Errorf("%v is not %v", err, im.StatusInvalidAction)
+	}
+
+	// The publisher selects a max interval up to the publisher limit.
+	sub, _, err := mgr.Subscribe(testSubscriber, newTestRequest(0, time.Minute, path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sub.MaxInterval() != MaxIntervalPublisherLimit {
+		t.Errorf("max interval (%s) != %s", sub.MaxInterval(), MaxIntervalPublisherLimit)
+	}
+
+	for range DefaultMaxSubscriptionsPerFabric - 1 {
+		if _, _, err := mgr.Subscribe(testSubscriber, newTestRequest(0, time.Minute, path)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, _, err := mgr.Subscribe(testSubscriber, newTestRequest(0, time.Minute, path)); !errors.Is(err, im.StatusResourceExhausted) {
+		t.Errorf("%v is not %v", err, im.StatusResourceExhausted)
+	}
+
+	// Subscriptions without keeping the existing ones replace them.
+	req := newTestRequest(0, time.Minute, path)
+	req.KeepSubscriptions = false
+	if _, _, err := mgr.Subscribe(testSubscriber, req); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(mgr.Subscriptions()); n != 1 {
+		t.Errorf("%d subscriptions", n)
+	}
+}
+
+func TestSubscriptionPriority(t *testing.T) {
+	node := datamodel.NewNode()
+	ep, cluster := newTestEndpoint(t, 1)
+	if err := node.AddEndpoint(ep); err != nil {
+		t.Fatal(err)
+	}
+	clock := &testClock{now: time.Unix(0, 0)}
+	sender := &testSender{}
+	mgr, err := NewManager(node, sender, WithClock(clock.Now), WithReportBudget(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := im.NewAttributePath(1, testClusterID, testAttributeID)
+	dirty, _, err := mgr.Subscribe(testSubscriber, newTestRequest(0, time.Hour, path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	idle, _, err := mgr.Subscribe(testSubscriber, newTestRequest(0, 5*time.Second, im.NewAttributePath(1, testClusterID, datamodel.ClusterRevisionAttributeID)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The subscription reaching the max interval is reported before the dirty subscription.
+	clock.Advance(5 * time.Second)
+	setValue(t, cluster, 1)
+	if d := mgr.Process(context.Background()); d != 0 {
+		t.Errorf("next report in %s", d)
+	}
+	mgr.Process(context.Background())
+	reports := sender.take()
+	if len(reports) != 2 || reports[0].SubscriptionID != idle.ID() || reports[1].SubscriptionID != dirty.ID() {
+		t.Fatalf("%+v", reports)
+	}
+}
+
+func TestEventSubscription(t *testing.T) {
+	node := datamodel.NewNode()
+	ep, _ := newTestEndpoint(t, 1)
+	if err := node.AddEndpoint(ep); err != nil {
+		t.Fatal(err)
+	}
+	events, err := event.NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	node.SetEventHandler(events.HandleEvent)
+	clock := &testClock{now: time.Unix(0, 0)}
+	sender := &testSender{}
+	mgr, err := NewManager(node, sender, WithClock(clock.Now), WithEventManager(events))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Events before the event filter are not reported.
+	ep.EmitEvent(testClusterID, 0, datamodel.InfoPriority, datatype.NewStruct())
+	ep.EmitEvent(testClusterID, 1, datamodel.InfoPriority, datatype.NewStruct())
+	req := newTestRequest(time.Second, time.Minute)
+	req.EventPaths = []im.EventPath{im.NewEventPath(im.WildcardEndpointID, testClusterID, 1)}
+	req.EventMin = 1
+	sub, report, err := mgr.Subscribe(testSubscriber, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.EventData) != 1 || report.EventData[0].Number != 1 || sub.EventMin() != 2 {
+		t.Fatalf("%+v", report)
+	}
+
+	// Subscribed events are reported after the min interval.
+	ep.EmitEvent(testClusterID, 0, datamodel.InfoPriority, datatype.NewStruct())
+	if d := mgr.Process(context.Background()); d != time.Minute {
+		t.Errorf("next report in %s", d)
+	}
+	ep.EmitEvent(testClusterID, 1, datamodel.CriticalPriority, datatype.NewStruct())
+	clock.Advance(time.Second)
+	mgr.Process(context.Background())
+	reports := sender.take()
+	if len(reports) != 1 || len(reports[0].EventData) != 1 || reports[0].EventData[0].Number != 3 {
+		t.Fatalf("%+v", reports)
+	}
+	if d := mgr.Process(context.Background()); d != time.Minute {
+		t.Errorf("next report in %s", d)
+	}
+}
+
+func TestUrgentEventSubscription(t *testing.T) {
+	node := datamodel.NewNode()
+	ep, _ := newTestEndpoint(t, 1)
+	if err := node.AddEndpoint(ep); err != nil {
+		t.Fatal(err)
+	}
+	events, err := event.NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	node.SetEventHandler(events.HandleEvent)
+	clock := &testClock{now: time.Unix(0, 0)}
+	sender := &testSender{}
+	store := storage.NewMemoryStore()
+	mgr, err := NewManager(node, sender, WithClock(clock.Now), WithEventManager(events), WithStore(store))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := newTestRequest(10*time.Second, time.Minute)
+	req.EventPaths = []im.EventPath{
+		im.NewEventPath(im.WildcardEndpointID, testClusterID, im.WildcardEventID),
+		im.NewEventPath(1, testClusterID, 1).Urgent(),
+	}
+	sub, _, err := mgr.Subscribe(testSubscriber, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Events are batched until the min interval unless one of them is urgent.
+	ep.EmitEvent(testClusterID, 0, datamodel.InfoPriority, datatype.NewStruct())
+	if d := mgr.Process(context.Background()); d != 10*time.Second {
+		t.Errorf("next report in %s", d)
+	}
+	clock.Advance(time.Second)
+	ep.EmitEvent(testClusterID, 1, datamodel.CriticalPriority, datatype.NewStruct())
+	if d := mgr.Process(context.Background()); d != time.Minute {
+		t.Errorf("next report in %s", d)
+	}
+	reports := sender.take()
+	if len(reports) != 1 || len(reports[0].EventData) != 2 {
+		t.Fatalf("%+v", reports)
+	}
+
+	// Urgent paths are resumed after a reboot.
+	mgr, err = NewManager(node, &testSender{}, WithStore(store))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resumed, ok := mgr.LookupSubscription(sub.ID())
+	if !ok {
+		t.Fatal("subscription is not resumed")
+	}
+	if paths := resumed.EventPaths(); len(paths) != 2 || paths[0].IsUrgent || !paths[1].IsUrgent {
+		t.Errorf("%v", paths)
+	}
+}
+
+func TestSubscriptionResumption(t *testing.T) {
+	node := datamodel.NewNode()
+	ep, _ := newTestEndpoint(t, 1)
+	if err := node.AddEndpoint(ep); err != nil {
+		t.Fatal(err)
+	}
+	store := storage.NewMemoryStore()
+	mgr, err := NewManager(node, &testSender{}, WithStore(store))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := im.NewAttributePath(im.WildcardEndpointID, testClusterID, im.WildcardAttributeID)
+	eventPath := im.NewEventPath(1, testClusterID, im.WildcardEventID)
+	req := newTestRequest(time.Second, time.Minute, path)
+	req.EventPaths = []im.EventPath{eventPath}
+	req.FabricFiltered = true
+	sub, _, err := mgr.Subscribe(testSubscriber, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Subscriptions are resumed after a reboot with priming reports.
+	sender := &testSender{}
+	mgr, err = NewManager(node, sender, WithStore(store))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resumed, ok := mgr.LookupSubscription(sub.ID())
+	if !ok || !resumed.IsResumed() {
+		t.Fatal("subscription is not resumed")
+	}
+	if resumed.Subscriber() != testSubscriber || resumed.MinInterval() != time.Second || resumed.MaxInterval() != sub.MaxInterval() || !resumed.IsFabricFiltered() {
+		t.Errorf("%+v", resumed)
+	}
+	if paths := resumed.Paths(); len(paths) != 1 || paths[0] != path {
+		t.Errorf("%v", paths)
+	}
+	if paths := resumed.EventPaths(); len(paths) != 1 || paths[0] != eventPath {
+		t.Errorf("%v", paths)
+	}
+	mgr.Process(context.Background())
+	reports := sender.take()
+	if len(reports) != 1 || !reports[0].Priming || len(reports[0].AttributeData) != len(ep.Clusters()[0].AttributeIDs()) {
+		t.Fatalf("%+v", reports)
+	}
+
+	mgr.RemoveFabric(testSubscriber.FabricIndex)
+	if keys, _ := store.Keys(Namespace); len(keys) != 0 {
+		t.Errorf("%v", keys)
+	}
+}
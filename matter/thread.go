@@ -0,0 +1,254 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/cybergarage/go-mdns/mdns"
+	"github.com/cybergarage/go-mdns/mdns/dns"
+)
+
+const (
+	locatorIID  = 0x000000FFFE000000
+	locatorMask = 0xFFFFFFFFFFFF0000
+)
+
+// ThreadAddressType represents a class of the addresses advertised for Matter nodes on Thread networks.
+type ThreadAddressType int
+
+const (
+	// ThreadUnknownAddress is an address which should not be used to reach a node.
+	ThreadUnknownAddress ThreadAddressType = iota
+	// ThreadOMRAddress is an address in an off-mesh routable (OMR) prefix advertised by a border router.
+	ThreadOMRAddress
+	// ThreadGlobalAddress is a global unicast address.
+	ThreadGlobalAddress
+	// ThreadIPv4Address is an IPv4 address, which is advertised only by non-Thread nodes.
+	ThreadIPv4Address
+	// ThreadMLEIDAddress is the mesh-local endpoint identifier (ML-EID), which is reachable only inside the mesh.
+	ThreadMLEIDAddress
+	// ThreadLinkLocalAddress is a link-local address.
+	ThreadLinkLocalAddress
+	// ThreadLocatorAddress is a routing or anycast locator (RLOC or ALOC), which changes with the topology.
+	ThreadLocatorAddress
+)
+
+// String returns the string representation.
+func (typ ThreadAddressType) String() string {
+	switch typ {
+	case ThreadOMRAddress:
+		return "OMR"
+	case ThreadGlobalAddress:
+		return "Global"
+	case ThreadIPv4Address:
+		return "IPv4"
+	case ThreadMLEIDAddress:
+		return "ML-EID"
+	case ThreadLinkLocalAddress:
+		return "LinkLocal"
+	case ThreadLocatorAddress:
+		return "Locator"
+	}
+	return "Unknown"
+}
+
+// ThreadAddressing represents a policy to select and dial the addresses of Matter nodes
+// on Thread networks, which are registered to border routers with SRP and advertised on
+// the infrastructure link by their advertising proxies.
+type ThreadAddressing struct {
+	meshLocalPrefix netip.Prefix
+	onMesh          bool
+	dialer          *net.Dialer
+}
+
+// ThreadAddressingOption represents an option of the Thread addressing.
+type ThreadAddressingOption func(*ThreadAddressing)
+
+// WithMeshLocalPrefix sets the mesh-local prefix of the Thread network to distinguish ML-EIDs from OMR addresses.
+// Without the prefix, ML-EIDs are indistinguishable from other unique local addresses.
+func WithMeshLocalPrefix(prefix netip.Prefix) ThreadAddressingOption {
+	return func(ta *ThreadAddressing) {
+		ta.meshLocalPrefix = prefix.Masked()
+	}
+}
+
+// WithOnMesh sets whether the controller is attached to the Thread mesh, such as when it runs
+// on a border router. ML-EIDs are preferred on the mesh since they do not change when border
+// routers renumber the OMR prefix.
+func WithOnMesh(onMesh bool) ThreadAddressingOption {
+	return func(ta *ThreadAddressing) {
+		ta.onMesh = onMesh
+	}
+}
+
+// NewThreadAddressing returns a new Thread addressing policy with the specified options.
+func NewThreadAddressing(opts ...ThreadAddressingOption) *ThreadAddressing {
+	ta := &ThreadAddressing{
+		meshLocalPrefix: netip.Prefix{},
+		onMesh:          false,
+		dialer:          &net.Dialer{},
+	}
+	for _, opt := range opts {
+		opt(ta)
+	}
+	return ta
+}
+
+// MeshLocalPrefix returns the mesh-local prefix, which is invalid if it is not set.
+func (ta *ThreadAddressing) MeshLocalPrefix() netip.Prefix {
+	return ta.meshLocalPrefix
+}
+
+// IsMLEID returns true if the specified address is an ML-EID in the mesh-local prefix.
+func (ta *ThreadAddressing) IsMLEID(addr netip.Addr) bool {
+	return ta.AddressType(addr) == ThreadMLEIDAddress
+}
+
+// AddressType returns the class of the specified address.
+func (ta *ThreadAddressing) AddressType(addr netip.Addr) ThreadAddressType {
+	addr = addr.Unmap()
+	switch {
+	case !addr.IsValid(), addr.IsUnspecified(), addr.IsLoopback(), addr.IsMulticast():
+		return ThreadUnknownAddress
+	case addr.Is4():
+		return ThreadIPv4Address
+	case addr.IsLinkLocalUnicast():
+		return ThreadLinkLocalAddress
+	case isThreadLocator(addr):
+		return ThreadLocatorAddress
+	case ta.meshLocalPrefix.IsValid() && ta.meshLocalPrefix.Contains(addr):
+		return ThreadMLEIDAddress
+	case addr.IsPrivate():
+		return ThreadOMRAddress
+	case addr.IsGlobalUnicast():
+		return ThreadGlobalAddress
+	}
+	return ThreadUnknownAddress
+}
+
+// isThreadLocator returns true if the interface identifier of the address is 0000:00ff:fe00:xxxx,
+// which is reserved for RLOCs and ALOCs.
+func isThreadLocator(addr netip.Addr) bool {
+	b := addr.As16()
+	var iid uint64
+	for _, v := range b[8:] {
+		iid = (iid << 8) | uint64(v)
+	}
+	return (iid & locatorMask) == locatorIID
+}
+
+// preference returns the rank of the address type, where the lower rank is dialed first.
+func (ta *ThreadAddressing) preference(typ ThreadAddressType) int {
+	if ta.onMesh && typ == ThreadMLEIDAddress {
+		return 0
+	}
+	return int(typ)
+}
+
+// SortAddrs returns the usable addresses in the order of preference. Duplicated addresses,
+// locators and unusable addresses are removed, and the order of the same class is kept.
+func (ta *ThreadAddressing) SortAddrs(addrs []netip.Addr) []netip.Addr {
+	sorted := make([]netip.Addr, 0, len(addrs))
+	seen := map[netip.Addr]bool{}
+	for _, addr := range addrs {
+		addr = addr.Unmap()
+		switch ta.AddressType(addr) {
+		case ThreadUnknownAddress, ThreadLocatorAddress:
+			continue
+		}
+		if seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		sorted = append(sorted, addr)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return ta.preference(ta.AddressType(sorted[i])) < ta.preference(ta.AddressType(sorted[j]))
+	})
+	return sorted
+}
+
+// ServiceAddrs returns all addresses of the host of the specified service in the order of preference.
+// SRP-registered hosts often have several AAAA records, such as the OMR address and the ML-EID,
+// which are all collected from the service message.
+func (ta *ThreadAddressing) ServiceAddrs(srv *mdns.Service) []netip.Addr {
+	addrs := []netip.Addr{}
+	appendIP := func(ip net.IP) {
+		if addr, ok := netip.AddrFromSlice(ip); ok {
+			addrs = append(addrs, addr)
+		}
+	}
+	if srv.Message != nil {
+		host := hostLabel(srv.Host)
+		for _, record := range srv.Message.ResourceRecords() {
+			if 0 < len(host) && hostLabel(record.Name()) != host {
+				continue
+			}
+			switch rr := record.(type) {
+			case *dns.AAAARecord:
+				appendIP(rr.Address())
+			case *dns.ARecord:
+				appendIP(rr.Address())
+			}
+		}
+	}
+	if len(addrs) == 0 {
+		appendIP(srv.AddrV6)
+		appendIP(srv.AddrV4)
+	}
+	return ta.SortAddrs(addrs)
+}
+
+// hostLabel returns the first label of the host name in lower case. Only the label is compared
+// since advertising proxies may answer SRP-registered hosts in either the default.service.arpa
+// or the local domain.
+func hostLabel(name string) string {
+	label, _, _ := strings.Cut(name, ".")
+	return strings.ToLower(label)
+}
+
+// DialUDP dials the specified addresses in order and returns the first connection. Addresses
+// which have no route from this host, such as ML-EIDs on off-mesh controllers, are skipped.
+func (ta *ThreadAddressing) DialUDP(ctx context.Context, addrs []netip.AddrPort) (*net.UDPConn, error) {
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("address is %w", ErrNotFound)
+	}
+	errs := []error{}
+	for _, addr := range addrs {
+		conn, err := ta.dialer.DialContext(ctx, "udp", addr.String())
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			errs = append(errs, err)
+			continue
+		}
+		udpConn, ok := conn.(*net.UDPConn)
+		if !ok {
+			conn.Close()
+			errs = append(errs, fmt.Errorf("%s is %w", addr, ErrNotSupported))
+			continue
+		}
+		return udpConn, nil
+	}
+	return nil, errors.Join(errs...)
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/cluster/timesync"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// SetUTCTime sets the UTC time of the specified granularity on the time synchronization cluster of the
+// specified endpoint. Nodes need the time to validate certificates and to run schedules.
+func SetUTCTime(ctx context.Context, invoker CommandInvoker, endpoint im.EndpointID, t time.Time, granularity timesync.Granularity) error {
+	fields, err := timesync.NewSetUTCTimeFields(t, granularity)
+	if err != nil {
+		return err
+	}
+	return invokeTimeSync(ctx, invoker, endpoint, timesync.SetUTCTimeCommandID, fields)
+}
+
+// SetTrustedTimeSource sets the node which the specified endpoint synchronizes its time with,
+// or clears it if the node is nil.
+func SetTrustedTimeSource(ctx context.Context, invoker CommandInvoker, endpoint im.EndpointID, nodeID *types.NodeID, sourceEndpoint im.EndpointID) error {
+	return invokeTimeSync(ctx, invoker, endpoint, timesync.SetTrustedTimeSourceCommandID, timesync.NewSetTrustedTimeSourceFields(nodeID, sourceEndpoint))
+}
+
+// SetTimeZone sets the time zones of the specified endpoint, and returns true if the DST offsets
+// need to be set again with SetDSTOffset.
+func SetTimeZone(ctx context.Context, invoker CommandInvoker, endpoint im.EndpointID, timeZones []timesync.TimeZone) (bool, error) {
+	fields, err := datatype.Encode(timesync.NewSetTimeZoneFields(timeZones))
+	if err != nil {
+		return false, err
+	}
+	b, err := invoker.InvokeCommand(ctx, im.NewCommandPath(endpoint, timesync.ClusterID, timesync.SetTimeZoneCommandID), fields)
+	if err != nil {
+		return false, err
+	}
+	required := new(datatype.Bool)
+	if err := datatype.Decode(b, datatype.NewStruct(datatype.NewField(0, required))); err != nil {
+		return false, err
+	}
+	return bool(*required), nil
+}
+
+// SetDSTOffset sets the DST offsets of the specified endpoint.
+func SetDSTOffset(ctx context.Context, invoker CommandInvoker, endpoint im.EndpointID, dstOffsets []timesync.DSTOffset) error {
+	return invokeTimeSync(ctx, invoker, endpoint, timesync.SetDSTOffsetCommandID, timesync.NewSetDSTOffsetFields(dstOffsets))
+}
+
+func invokeTimeSync(ctx context.Context, invoker CommandInvoker, endpoint im.EndpointID, id im.CommandID, v datatype.Value) error {
+	fields, err := datatype.Encode(v)
+	if err != nil {
+		return err
+	}
+	_, err = invoker.InvokeCommand(ctx, im.NewCommandPath(endpoint, timesync.ClusterID, id), fields)
+	return err
+}
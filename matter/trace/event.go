@@ -0,0 +1,132 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/internal/protocol"
+)
+
+// Direction represents a message direction.
+type Direction uint8
+
+const (
+	// Transmit represents an outgoing message.
+	Transmit Direction = iota
+	// Receive represents an incoming message.
+	Receive
+)
+
+// String returns the string representation.
+func (dir Direction) String() string {
+	if dir == Transmit {
+		return "TX"
+	}
+	return "RX"
+}
+
+// SessionPhase represents a phase of the secure session establishment.
+type SessionPhase uint8
+
+const (
+	PBKDFParamRequestPhase SessionPhase = iota
+	PBKDFParamResponsePhase
+	Pake1Phase
+	Pake2Phase
+	Pake3Phase
+	Sigma1Phase
+	Sigma2Phase
+	Sigma3Phase
+	Sigma2ResumePhase
+	SessionEstablishedPhase
+	SessionFailedPhase
+	SessionClosedPhase
+	SessionEvictedPhase
+	SessionBusyPhase
+)
+
+// String returns the string representation.
+func (phase SessionPhase) String() string {
+	switch phase {
+	case PBKDFParamRequestPhase:
+		return "PBKDFParamRequest"
+	case PBKDFParamResponsePhase:
+		return "PBKDFParamResponse"
+	case Pake1Phase:
+		return "Pake1"
+	case Pake2Phase:
+		return "Pake2"
+	case Pake3Phase:
+		return "Pake3"
+	case Sigma1Phase:
+		return "Sigma1"
+	case Sigma2Phase:
+		return "Sigma2"
+	case Sigma3Phase:
+		return "Sigma3"
+	case Sigma2ResumePhase:
+		return "Sigma2Resume"
+	case SessionEstablishedPhase:
+		return "Established"
+	case SessionFailedPhase:
+		return "Failed"
+	case SessionClosedPhase:
+		return "Closed"
+	case SessionEvictedPhase:
+		return "Evicted"
+	case SessionBusyPhase:
+		return "Busy"
+	}
+	return "Unknown"
+}
+
+// MessageEvent represents a transmitted or received message.
+type MessageEvent struct {
+	Time           time.Time
+	Direction      Direction
+	Peer           string
+	Header         *message.Header
+	ProtocolHeader *protocol.Header
+	Length         int
+}
+
+// ExchangeEvent represents an exchange opened or closed.
+type ExchangeEvent struct {
+	Time       time.Time
+	Peer       string
+	ExchangeID protocol.ExchangeID
+	Initiator  bool
+	Closed     bool
+}
+
+// SessionEvent represents a phase of the secure session establishment.
+type SessionEvent struct {
+	Time      time.Time
+	Peer      string
+	SessionID message.SessionID
+	Phase     SessionPhase
+	Err       error
+}
+
+// RetransmitEvent represents a message retransmitted by the message reliability protocol.
+type RetransmitEvent struct {
+	Time       time.Time
+	Peer       string
+	ExchangeID protocol.ExchangeID
+	Counter    message.Counter
+	Attempt    int
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"github.com/cybergarage/go-matter/matter/log"
+)
+
+// LogTracer represents a tracer which outputs all events to the logger of the stack.
+type LogTracer struct {
+	level log.Level
+}
+
+// NewLogTracer returns a new tracer which outputs all events to the logger of the stack at the debug level.
+func NewLogTracer() *LogTracer {
+	return NewLogTracerWithLevel(log.LevelDebug)
+}
+
+// NewLogTracerWithLevel returns a new tracer which outputs all events to the logger of the stack at the specified level.
+func NewLogTracerWithLevel(level log.Level) *LogTracer {
+	return &LogTracer{
+		level: level,
+	}
+}
+
+// TraceMessage outputs the message event.
+func (tracer *LogTracer) TraceMessage(e *MessageEvent) {
+	msg := "message"
+	args := []any{e.Direction, e.Peer, e.Length}
+	if e.Header != nil {
+		msg += " session:%d counter:%d flag:0x%02X"
+		args = append(args, e.Header.SessionID, e.Header.Counter, uint8(e.Header.Flag()))
+	}
+	if e.ProtocolHeader != nil {
+		msg += " exchange:%d opcode:0x%02X protocol:0x%04X"
+		args = append(args, e.ProtocolHeader.ExchangeID, uint8(e.ProtocolHeader.Opcode), uint16(e.ProtocolHeader.ProtocolID))
+	}
+	log.Logf(tracer.level, "%s %s (%d bytes) "+msg, args...)
+}
+
+// TraceExchange outputs the exchange event.
+func (tracer *LogTracer) TraceExchange(e *ExchangeEvent) {
+	state := "opened"
+	if e.Closed {
+		state = "closed"
+	}
+	log.Logf(tracer.level, "exchange %s %s exchange:%d initiator:%t", state, e.Peer, e.ExchangeID, e.Initiator)
+}
+
+// TraceSession outputs the session event.
+func (tracer *LogTracer) TraceSession(e *SessionEvent) {
+	if e.Err != nil {
+		log.Logf(tracer.level, "session %s %s session:%d (%s)", e.Phase, e.Peer, e.SessionID, e.Err)
+		return
+	}
+	log.Logf(tracer.level, "session %s %s session:%d", e.Phase, e.Peer, e.SessionID)
+}
+
+// TraceRetransmit outputs the retransmit event.
+func (tracer *LogTracer) TraceRetransmit(e *RetransmitEvent) {
+	log.Logf(tracer.level, "retransmit %s exchange:%d counter:%d attempt:%d", e.Peer, e.ExchangeID, e.Counter, e.Attempt)
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/internal/protocol"
+)
+
+type recordTracer struct {
+	events []any
+}
+
+func (tracer *recordTracer) TraceMessage(e *MessageEvent)   { tracer.events = append(tracer.events, e) }
+func (tracer *recordTracer) TraceExchange(e *ExchangeEvent) { tracer.events = append(tracer.events, e) }
+func (tracer *recordTracer) TraceSession(e *SessionEvent)   { tracer.events = append(tracer.events, e) }
+func (tracer *recordTracer) TraceRetransmit(e *RetransmitEvent) {
+	tracer.events = append(tracer.events, e)
+}
+
+func TestSharedTracer(t *testing.T) {
+	if _, ok := SharedTracer().(*NullTracer); !ok {
+		t.Errorf("default tracer is not a null tracer")
+	}
+
+	tracer := &recordTracer{events: nil}
+	SetSharedTracer(tracer)
+	defer SetSharedTracer(nil)
+
+	SharedTracer().TraceSession(&SessionEvent{
+		Time:      time.Now(),
+		Peer:      "peer",
+		SessionID: 1,
+		Phase:     Pake1Phase,
+		Err:       nil,
+	})
+	if len(tracer.events) != 1 {
+		t.Errorf("%d != %d", len(tracer.events), 1)
+	}
+
+	SetSharedTracer(nil)
+	if _, ok := SharedTracer().(*NullTracer); !ok {
+		t.Errorf("nil tracer is not replaced with a null tracer")
+	}
+}
+
+func TestLogTracer(t *testing.T) {
+	tracers := []Tracer{
+		NewNullTracer(),
+		NewLogTracer(),
+	}
+	for _, tracer := range tracers {
+		tracer.TraceMessage(&MessageEvent{
+			Time:           time.Now(),
+			Direction:      Transmit,
+			Peer:           "peer",
+			Header:         message.NewHeader(),
+			ProtocolHeader: protocol.NewHeader(),
+			Length:         8,
+		})
+		tracer.TraceMessage(&MessageEvent{
+			Time:           time.Now(),
+			Direction:      Receive,
+			Peer:           "peer",
+			Header:         nil,
+			ProtocolHeader: nil,
+			Length:         0,
+		})
+		tracer.TraceExchange(&ExchangeEvent{
+			Time:       time.Now(),
+			Peer:       "peer",
+			ExchangeID: 1,
+			Initiator:  true,
+			Closed:     false,
+		})
+		tracer.TraceSession(&SessionEvent{
+			Time:      time.Now(),
+			Peer:      "peer",
+			SessionID: 1,
+			Phase:     SessionFailedPhase,
+			Err:       errors.New("timeout"),
+		})
+		tracer.TraceRetransmit(&RetransmitEvent{
+			Time:       time.Now(),
+			Peer:       "peer",
+			ExchangeID: 1,
+			Counter:    1,
+			Attempt:    2,
+		})
+	}
+}
+
+func TestSessionPhaseString(t *testing.T) {
+	for phase := PBKDFParamRequestPhase; phase <= SessionBusyPhase; phase++ {
+		if phase.String() == "Unknown" {
+			t.Errorf("%d has no name", phase)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"sync"
+)
+
+// Tracer represents a receiver of protocol trace events.
+type Tracer interface {
+	// TraceMessage is called when a message is transmitted or received.
+	TraceMessage(e *MessageEvent)
+	// TraceExchange is called when an exchange is opened or closed.
+	TraceExchange(e *ExchangeEvent)
+	// TraceSession is called when a secure session establishment enters a phase.
+	TraceSession(e *SessionEvent)
+	// TraceRetransmit is called when a message is retransmitted.
+	TraceRetransmit(e *RetransmitEvent)
+}
+
+var (
+	sharedTracer      Tracer = NewNullTracer()
+	sharedTracerMutex        = sync.RWMutex{}
+)
+
+// SetSharedTracer sets the tracer which receives all protocol trace events.
+func SetSharedTracer(tracer Tracer) {
+	sharedTracerMutex.Lock()
+	defer sharedTracerMutex.Unlock()
+	if tracer == nil {
+		tracer = NewNullTracer()
+	}
+	sharedTracer = tracer
+}
+
+// SharedTracer returns the tracer which receives all protocol trace events.
+func SharedTracer() Tracer {
+	sharedTracerMutex.RLock()
+	defer sharedTracerMutex.RUnlock()
+	return sharedTracer
+}
+
+// NullTracer represents a tracer which discards all events.
+type NullTracer struct{}
+
+// NewNullTracer returns a new tracer which discards all events.
+func NewNullTracer() *NullTracer {
+	return &NullTracer{}
+}
+
+// TraceMessage discards the event.
+func (tracer *NullTracer) TraceMessage(e *MessageEvent) {}
+
+// TraceExchange discards the event.
+func (tracer *NullTracer) TraceExchange(e *ExchangeEvent) {}
+
+// TraceSession discards the event.
+func (tracer *NullTracer) TraceSession(e *SessionEvent) {}
+
+// TraceRetransmit discards the event.
+func (tracer *NullTracer) TraceRetransmit(e *RetransmitEvent) {}
@@ -0,0 +1,144 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/internal/protocol"
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+// MarshalText returns the text representation for the transcript.
+func (dir Direction) MarshalText() ([]byte, error) {
+	return []byte(dir.String()), nil
+}
+
+// UnmarshalText parses the text representation in the transcript.
+func (dir *Direction) UnmarshalText(b []byte) error {
+	switch string(b) {
+	case Transmit.String():
+		*dir = Transmit
+	case Receive.String():
+		*dir = Receive
+	default:
+		return fmt.Errorf("%w direction: %s", ErrInvalid, string(b))
+	}
+	return nil
+}
+
+// TranscriptEntry represents a handshake message recorded in a transcript.
+type TranscriptEntry struct {
+	Time       time.Time           `json:"time"`
+	Direction  Direction           `json:"direction"`
+	Peer       string              `json:"peer"`
+	SessionID  message.SessionID   `json:"session"`
+	ExchangeID protocol.ExchangeID `json:"exchange"`
+	ProtocolID protocol.ProtocolID `json:"protocol"`
+	Opcode     protocol.Opcode     `json:"opcode"`
+	Payload    []byte              `json:"payload"`
+}
+
+// NewDecoder returns a TLV decoder for the recorded payload to replay it.
+func (entry *TranscriptEntry) NewDecoder() *tlv.Decoder {
+	return tlv.NewDecoder(entry.Payload)
+}
+
+// TranscriptWriter represents a writer which records PASE and CASE handshake messages
+// as JSON lines, one entry per line, so that a transcript can be replayed later.
+type TranscriptWriter struct {
+	sync.Mutex
+	w      *bufio.Writer
+	closer io.Closer
+}
+
+// NewTranscriptWriter returns a new transcript writer to the specified writer.
+func NewTranscriptWriter(w io.Writer) *TranscriptWriter {
+	return &TranscriptWriter{
+		Mutex:  sync.Mutex{},
+		w:      bufio.NewWriter(w),
+		closer: nil,
+	}
+}
+
+// CreateTranscriptFile creates or truncates the specified file and returns a new transcript writer to it.
+func CreateTranscriptFile(path string) (*TranscriptWriter, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	w := NewTranscriptWriter(file)
+	w.closer = file
+	return w, nil
+}
+
+// Write records the specified entry and flushes it immediately not to lose it on a crash.
+func (tw *TranscriptWriter) Write(entry *TranscriptEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	tw.Lock()
+	defer tw.Unlock()
+	if _, err := tw.w.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	return tw.w.Flush()
+}
+
+// Close flushes the transcript and closes the underlying file if the writer owns it.
+func (tw *TranscriptWriter) Close() error {
+	tw.Lock()
+	defer tw.Unlock()
+	err := tw.w.Flush()
+	if tw.closer != nil {
+		if cerr := tw.closer.Close(); err == nil {
+			err = cerr
+		}
+		tw.closer = nil
+	}
+	return err
+}
+
+// ReadTranscript reads all entries of a transcript in the recorded order.
+func ReadTranscript(r io.Reader) ([]*TranscriptEntry, error) {
+	entries := []*TranscriptEntry{}
+	dec := json.NewDecoder(r)
+	for dec.More() {
+		var entry TranscriptEntry
+		if err := dec.Decode(&entry); err != nil {
+			return nil, fmt.Errorf("%w transcript entry %d: %w", ErrInvalid, len(entries), err)
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, nil
+}
+
+// ReadTranscriptFile reads all entries of the specified transcript file in the recorded order.
+func ReadTranscriptFile(path string) ([]*TranscriptEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadTranscript(file)
+}
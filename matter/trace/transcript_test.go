@@ -0,0 +1,103 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+)
+
+func TestTranscript(t *testing.T) {
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.NewAnonymousTag())
+	enc.PutOctetString(tlv.NewContextTag(1), []byte{0x01, 0x02, 0x03})
+	enc.PutUnsigned(tlv.NewContextTag(2), 1)
+	enc.EndContainer()
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	entries := []*TranscriptEntry{
+		{
+			Time:       now,
+			Direction:  Transmit,
+			Peer:       "[fe80::1]:5540",
+			SessionID:  0,
+			ExchangeID: 0x1234,
+			ProtocolID: 0x0000,
+			Opcode:     0x20,
+			Payload:    enc.Bytes(),
+		},
+		{
+			Time:       now.Add(time.Millisecond),
+			Direction:  Receive,
+			Peer:       "[fe80::1]:5540",
+			SessionID:  0,
+			ExchangeID: 0x1234,
+			ProtocolID: 0x0000,
+			Opcode:     0x21,
+			Payload:    []byte{},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "pase.jsonl")
+	w, err := CreateTranscriptFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if err := w.Write(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	replayed, err := ReadTranscriptFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replayed) != len(entries) {
+		t.Fatalf("%d != %d", len(replayed), len(entries))
+	}
+	for n, entry := range entries {
+		r := replayed[n]
+		if !r.Time.Equal(entry.Time) || r.Direction != entry.Direction || r.Peer != entry.Peer ||
+			r.ExchangeID != entry.ExchangeID || r.Opcode != entry.Opcode || !bytes.Equal(r.Payload, entry.Payload) {
+			t.Errorf("%v != %v", r, entry)
+		}
+	}
+
+	dec := replayed[0].NewDecoder()
+	elem, err := dec.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !elem.IsContainer() {
+		t.Errorf("%s is not a container", elem.Type())
+	}
+}
+
+func TestTranscriptInvalid(t *testing.T) {
+	_, err := ReadTranscript(strings.NewReader(`{"direction":"XX"}`))
+	if !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux && (amd64 || arm64)
+
+package transport
+
+import (
+	"fmt"
+	"net"
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+// maxBatchSize is the maximum number of messages per sendmmsg(2) call (UIO_MAXIOV).
+const maxBatchSize = 1024
+
+// mmsghdr represents struct mmsghdr of sendmmsg(2).
+type mmsghdr struct {
+	hdr syscall.Msghdr
+	len uint32
+	_   [4]byte
+}
+
+// writeBatch writes the packets with sendmmsg(2), and falls back to one write per
+// packet if the connection does not expose its socket.
+func (codec *Codec) writeBatch(packets []packet) error {
+	sc, ok := codec.conn.(syscall.Conn)
+	if !ok {
+		return codec.writePackets(packets)
+	}
+	rc, err := sc.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	inet6 := isInet6Conn(codec.conn)
+	for 0 < len(packets) {
+		n := min(len(packets), maxBatchSize)
+		sent, err := sendmmsg(rc, packets[:n], inet6)
+		if err != nil {
+			return err
+		}
+		packets = packets[sent:]
+	}
+	return nil
+}
+
+func sendmmsg(rc syscall.RawConn, packets []packet, inet6 bool) (int, error) {
+	hdrs := make([]mmsghdr, len(packets))
+	iovs := make([]syscall.Iovec, len(packets))
+	addrs4 := []syscall.RawSockaddrInet4{}
+	addrs6 := []syscall.RawSockaddrInet6{}
+	if inet6 {
+		addrs6 = make([]syscall.RawSockaddrInet6, len(packets))
+	} else {
+		addrs4 = make([]syscall.RawSockaddrInet4, len(packets))
+	}
+
+	for n, pkt := range packets {
+		if 0 < len(pkt.b) {
+			iovs[n].Base = &pkt.b[0]
+		}
+		iovs[n].SetLen(len(pkt.b))
+		hdrs[n].hdr.Iov = &iovs[n]
+		hdrs[n].hdr.Iovlen = 1
+
+		port := pkt.addr.Port()
+		if inet6 {
+			sa := &addrs6[n]
+			sa.Family = syscall.AF_INET6
+			putPort(&sa.Port, port)
+			sa.Addr = pkt.addr.Addr().As16()
+			hdrs[n].hdr.Name = (*byte)(unsafe.Pointer(sa))
+			hdrs[n].hdr.Namelen = syscall.SizeofSockaddrInet6
+			continue
+		}
+		if !pkt.addr.Addr().Unmap().Is4() {
+			return 0, fmt.Errorf("%w message address: %s on IPv4 socket", ErrInvalid, pkt.addr)
+		}
+		sa := &addrs4[n]
+		sa.Family = syscall.AF_INET
+		putPort(&sa.Port, port)
+		sa.Addr = pkt.addr.Addr().Unmap().As4()
+		hdrs[n].hdr.Name = (*byte)(unsafe.Pointer(sa))
+		hdrs[n].hdr.Namelen = syscall.SizeofSockaddrInet4
+	}
+
+	var sent int
+	var errno syscall.Errno
+	err := rc.Write(func(fd uintptr) bool {
+		r, _, e := syscall.Syscall6(sysSendmmsg, fd, uintptr(unsafe.Pointer(&hdrs[0])), uintptr(len(hdrs)), 0, 0, 0)
+		if e == syscall.EAGAIN {
+			return false
+		}
+		sent, errno = int(r), e
+		return true
+	})
+	runtime.KeepAlive(packets)
+	runtime.KeepAlive(addrs4)
+	runtime.KeepAlive(addrs6)
+	if err != nil {
+		return 0, err
+	}
+	if errno != 0 {
+		return 0, errno
+	}
+	return sent, nil
+}
+
+// putPort stores the port in network byte order.
+func putPort(p *uint16, port uint16) {
+	b := (*[2]byte)(unsafe.Pointer(p))
+	b[0] = byte(port >> 8)
+	b[1] = byte(port)
+}
+
+// isInet6Conn returns true if the connection is bound to an IPv6 socket,
+// to which IPv4 destinations are written as IPv4-mapped addresses.
+func isInet6Conn(conn PacketConn) bool {
+	addr, ok := conn.LocalAddr().(*net.UDPAddr)
+	if !ok {
+		return false
+	}
+	return addr.IP.To4() == nil
+}
@@ -12,8 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package protocol
+package transport
 
-// 4.4.3.5. Protocol Vendor ID (16 bits)
-// VendorID represents a vendor ID.
-type VenderID uint16
+// sysSendmmsg is not defined by the syscall package on linux/amd64.
+const sysSendmmsg = 307
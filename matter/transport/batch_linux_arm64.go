@@ -12,7 +12,10 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package message
+package transport
 
-// Discriminator represents a message discriminator.
-type Discriminator uint16
+import (
+	"syscall"
+)
+
+const sysSendmmsg = syscall.SYS_SENDMMSG
@@ -12,14 +12,10 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package protocol
+//go:build !linux || !(amd64 || arm64)
 
-// 4.4.3. Protocol Header Field Descriptions
-// Header represents a protocol header.
-type Header struct {
-	ExchangeFlag ExchangeFlag
-	Opcode       Opcode
-	ExchangeID   ExchangeID
-	VenderID     VenderID
-	ProtocolID   ProtocolID
+package transport
+
+func (codec *Codec) writeBatch(packets []packet) error {
+	return codec.writePackets(packets)
 }
@@ -12,23 +12,30 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package message
+package transport
 
-// 4.4.1.2. Message Flags (8 bits)
-// Flag represents a message flag.
-type Flag uint8
+import (
+	"sync"
+)
 
-// Version returns the matter message format version.
-func (flag Flag) Version() int {
-	return int((flag & 0xF0) >> 4)
+// bufferPool holds message buffers of MaxMessageSize capacity shared by the codec and receivers.
+var bufferPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, MaxMessageSize)
+		return &b
+	},
 }
 
-// HasVersion returns true if the message has a version.
-func (flag Flag) HasSourceNodeID() bool {
-	return (flag & 0x04) != 0
+func getBuffer() *[]byte {
+	buf, _ := bufferPool.Get().(*[]byte)
+	*buf = (*buf)[:0]
+	return buf
 }
 
-// HasDestinationNodeID returns true if the message has a destination node ID.
-func (flag Flag) HasDestinationNodeID() bool {
-	return (flag & 0x02) != 0
+// putBuffer returns the buffer to the pool unless it has been reallocated.
+func putBuffer(buf *[]byte) {
+	if cap(*buf) != MaxMessageSize {
+		return
+	}
+	bufferPool.Put(buf)
 }
@@ -0,0 +1,187 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/matter/trace"
+)
+
+// PacketConn represents a datagram connection such as *net.UDPConn.
+type PacketConn interface {
+	// ReadFromUDPAddrPort reads a datagram and returns its source address.
+	ReadFromUDPAddrPort(b []byte) (int, netip.AddrPort, error)
+	// WriteToUDPAddrPort writes a datagram to the specified address.
+	WriteToUDPAddrPort(b []byte, addr netip.AddrPort) (int, error)
+	// SetReadDeadline sets the deadline for future reads.
+	SetReadDeadline(t time.Time) error
+	// SetWriteDeadline sets the deadline for future writes.
+	SetWriteDeadline(t time.Time) error
+	// LocalAddr returns the local address.
+	LocalAddr() net.Addr
+	// Close closes the connection.
+	Close() error
+}
+
+// Codec represents a message codec which encodes messages and writes them to a datagram connection.
+type Codec struct {
+	conn    PacketConn
+	counter *message.GlobalCounter
+}
+
+// CodecOption represents a codec option.
+type CodecOption func(*Codec)
+
+// WithUnsecuredCounter returns an option to set the global unencrypted message counter, which
+// assigns the message counters of the unsecured messages when they are transmitted. The counters
+// of the secured messages are assigned before they are encrypted, so they are not changed.
+func WithUnsecuredCounter(counter *message.GlobalCounter) CodecOption {
+	return func(codec *Codec) {
+		codec.counter = counter
+	}
+}
+
+// packet represents an encoded message and its destination.
+type packet struct {
+	addr netip.AddrPort
+	b    []byte
+}
+
+// NewCodec returns a new codec which writes to the specified connection.
+func NewCodec(conn PacketConn, opts ...CodecOption) *Codec {
+	codec := &Codec{
+		conn:    conn,
+		counter: nil,
+	}
+	for _, opt := range opts {
+		opt(codec)
+	}
+	return codec
+}
+
+// Conn returns the underlying connection.
+func (codec *Codec) Conn() PacketConn {
+	return codec.conn
+}
+
+// Transmit encodes the message and writes it to its address.
+func (codec *Codec) Transmit(ctx context.Context, msg *Message) error {
+	if err := codec.setWriteDeadline(ctx); err != nil {
+		return err
+	}
+	if err := validateMessage(msg); err != nil {
+		return err
+	}
+	if err := codec.assignCounter(msg); err != nil {
+		return err
+	}
+	buf := getBuffer()
+	defer putBuffer(buf)
+	*buf = msg.AppendBytes((*buf)[:0])
+	if _, err := codec.conn.WriteToUDPAddrPort(*buf, msg.Addr); err != nil {
+		return err
+	}
+	traceMessage(trace.Transmit, msg)
+	return nil
+}
+
+// TransmitBatch encodes the messages into a single buffer and writes them with as few
+// system calls as the platform allows, using sendmmsg(2) on Linux. No message is written
+// if any message is invalid.
+func (codec *Codec) TransmitBatch(ctx context.Context, msgs []*Message) error {
+	if err := codec.setWriteDeadline(ctx); err != nil {
+		return err
+	}
+
+	size := 0
+	for _, msg := range msgs {
+		if err := validateMessage(msg); err != nil {
+			return err
+		}
+		size += msg.Size()
+	}
+	for _, msg := range msgs {
+		if err := codec.assignCounter(msg); err != nil {
+			return err
+		}
+	}
+
+	// The buffer never grows, so the packets can share it.
+	buf := make([]byte, 0, size)
+	packets := make([]packet, len(msgs))
+	for n, msg := range msgs {
+		offset := len(buf)
+		buf = msg.AppendBytes(buf)
+		packets[n] = packet{
+			addr: msg.Addr,
+			b:    buf[offset:len(buf):len(buf)],
+		}
+	}
+
+	if err := codec.writeBatch(packets); err != nil {
+		return err
+	}
+	for _, msg := range msgs {
+		traceMessage(trace.Transmit, msg)
+	}
+	return nil
+}
+
+// writePackets writes the packets one by one.
+func (codec *Codec) writePackets(packets []packet) error {
+	for _, pkt := range packets {
+		if _, err := codec.conn.WriteToUDPAddrPort(pkt.b, pkt.addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// assignCounter assigns the next global unencrypted message counter to the unsecured message.
+func (codec *Codec) assignCounter(msg *Message) error {
+	if codec.counter == nil || !msg.Header.IsUnsecured() {
+		return nil
+	}
+	counter, err := codec.counter.Next()
+	if err != nil {
+		return err
+	}
+	msg.Header.Counter = counter
+	return nil
+}
+
+func (codec *Codec) setWriteDeadline(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	return codec.conn.SetWriteDeadline(deadline)
+}
+
+func validateMessage(msg *Message) error {
+	if msg == nil || msg.Header == nil {
+		return fmt.Errorf("%w message: no header", ErrInvalid)
+	}
+	if !msg.Addr.IsValid() {
+		return fmt.Errorf("%w message address: %s", ErrInvalid, msg.Addr)
+	}
+	return msg.Header.Validate()
+}
@@ -0,0 +1,196 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/matter/storage"
+)
+
+func newTestMessages(t testing.TB, addr netip.AddrPort, n int) []*Message {
+	t.Helper()
+	msgs := make([]*Message, n)
+	for i := range msgs {
+		header := message.NewHeader()
+		header.SessionID = 0x1234
+		header.Counter = message.Counter(i)
+		if err := header.SetSourceNodeID(0x0102030405060708); err != nil {
+			t.Fatal(err)
+		}
+		msgs[i] = NewMessage(addr, header, make([]byte, 64))
+	}
+	return msgs
+}
+
+func newTestConns(t testing.TB) (*net.UDPConn, *net.UDPConn) {
+	t.Helper()
+	addr := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 0}
+	sender, err := net.ListenUDP("udp4", addr)
+	if err != nil {
+		t.Skip(err)
+	}
+	receiver, err := net.ListenUDP("udp4", addr)
+	if err != nil {
+		sender.Close()
+		t.Skip(err)
+	}
+	if err := receiver.SetReadBuffer(1 << 20); err != nil {
+		t.Log(err)
+	}
+	t.Cleanup(func() {
+		sender.Close()
+		receiver.Close()
+	})
+	return sender, receiver
+}
+
+func TestCodecTransmitBatch(t *testing.T) {
+	sender, receiver := newTestConns(t)
+	codec := NewCodec(sender)
+	addr := receiver.LocalAddr().(*net.UDPAddr).AddrPort()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	msgs := newTestMessages(t, addr, 16)
+	if err := codec.TransmitBatch(ctx, msgs); err != nil {
+		t.Fatal(err)
+	}
+	if err := codec.Transmit(ctx, newTestMessages(t, addr, 17)[16]); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := receiver.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	b := make([]byte, 1280)
+	for n := 0; n <= len(msgs); n++ {
+		l, _, err := receiver.ReadFromUDPAddrPort(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if l != msgs[0].Size() {
+			t.Errorf("%d != %d", l, msgs[0].Size())
+		}
+		header, err := message.NewHeaderFromBytes(b[:l])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if header.Counter != message.Counter(n) {
+			t.Errorf("%d != %d", header.Counter, n)
+		}
+	}
+}
+
+func TestCodecUnsecuredCounter(t *testing.T) {
+	sender, receiver := newTestConns(t)
+	counter, err := message.NewGlobalCounter(storage.NewMemoryStore(), message.GlobalUnencryptedCounterKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	codec := NewCodec(sender, WithUnsecuredCounter(counter))
+	addr := receiver.LocalAddr().(*net.UDPAddr).AddrPort()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Unsecured messages are counted by the global counter, and secured messages keep their counters.
+	msgs := newTestMessages(t, addr, 3)
+	msgs[0].Header.SessionID = 0
+	msgs[2].Header.SessionID = 0
+	first := counter.Value()
+	if err := codec.TransmitBatch(ctx, msgs[:2]); err != nil {
+		t.Fatal(err)
+	}
+	if err := codec.Transmit(ctx, msgs[2]); err != nil {
+		t.Fatal(err)
+	}
+	for n, expected := range []message.Counter{first, 1, first + 1} {
+		if msgs[n].Header.Counter != expected {
+			t.Errorf("message (%d) counter (%d) != (%d)", n, msgs[n].Header.Counter, expected)
+		}
+	}
+}
+
+func TestCodecTransmitBatchErrors(t *testing.T) {
+	sender, receiver := newTestConns(t)
+	codec := NewCodec(sender)
+	addr := receiver.LocalAddr().(*net.UDPAddr).AddrPort()
+
+	msgs := newTestMessages(t, addr, 2)
+	msgs[1].Header.SourceNodeID = 0
+	if err := codec.TransmitBatch(context.Background(), msgs); !errors.Is(err, message.ErrInvalid) {
+		t.Errorf("%v is not %v", err, message.ErrInvalid)
+	}
+
+	msgs = newTestMessages(t, netip.AddrPort{}, 1)
+	if err := codec.TransmitBatch(context.Background(), msgs); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+}
+
+func benchmarkTransmit(b *testing.B, batch bool) {
+	const batchSize = 256
+	sender, receiver := newTestConns(b)
+	codec := NewCodec(sender)
+	msgs := newTestMessages(b, receiver.LocalAddr().(*net.UDPAddr).AddrPort(), batchSize)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if batch {
+			if err := codec.TransmitBatch(ctx, msgs); err != nil {
+				b.Fatal(err)
+			}
+			continue
+		}
+		for _, msg := range msgs {
+			if err := codec.Transmit(ctx, msg); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkTransmit(b *testing.B) {
+	benchmarkTransmit(b, false)
+}
+
+func BenchmarkTransmitBatch(b *testing.B) {
+	benchmarkTransmit(b, true)
+}
+
+func TestMessageAppendBytes(t *testing.T) {
+	msg := newTestMessages(t, netip.MustParseAddrPort("127.0.0.1:5540"), 1)[0]
+	buf := getBuffer()
+	defer putBuffer(buf)
+	allocs := testing.AllocsPerRun(100, func() {
+		*buf = msg.AppendBytes((*buf)[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("%f allocations", allocs)
+	}
+	if !bytes.Equal(*buf, msg.Bytes()) {
+		t.Errorf("%X != %X", *buf, msg.Bytes())
+	}
+}
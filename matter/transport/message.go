@@ -0,0 +1,68 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"net/netip"
+
+	"github.com/cybergarage/go-matter/internal/message"
+)
+
+// Message represents a message which is sent to or received from a peer address.
+// The payload is the message payload following the message header, which is
+// encrypted unless the message belongs to an unsecured session.
+type Message struct {
+	Addr    netip.AddrPort
+	Header  *message.Header
+	Payload []byte
+	buf     *[]byte
+}
+
+// NewMessage returns a new message to the specified address.
+func NewMessage(addr netip.AddrPort, header *message.Header, payload []byte) *Message {
+	return &Message{
+		Addr:    addr,
+		Header:  header,
+		Payload: payload,
+		buf:     nil,
+	}
+}
+
+// Size returns the encoded size of the message.
+func (msg *Message) Size() int {
+	return msg.Header.Size() + len(msg.Payload)
+}
+
+// Bytes returns the encoded message.
+func (msg *Message) Bytes() []byte {
+	return msg.AppendBytes(make([]byte, 0, msg.Size()))
+}
+
+// AppendBytes appends the encoded message to the specified buffer.
+func (msg *Message) AppendBytes(b []byte) []byte {
+	return append(msg.Header.AppendBytes(b), msg.Payload...)
+}
+
+// Release returns the receive buffer of the message to the buffer pool. The payload
+// must not be used after the message is released. Releasing is optional, and does
+// nothing for messages which were not received by a Receiver.
+func (msg *Message) Release() {
+	if msg.buf == nil {
+		return
+	}
+	putBuffer(msg.buf)
+	msg.buf = nil
+	msg.Payload = nil
+}
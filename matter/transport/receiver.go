@@ -0,0 +1,199 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"net/netip"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/matter/log"
+	"github.com/cybergarage/go-matter/matter/metrics"
+	"github.com/cybergarage/go-matter/matter/trace"
+)
+
+const (
+	// MaxMessageSize is the maximum size of a message over UDP, which is the IPv6 minimum MTU.
+	MaxMessageSize = 1280
+	// DefaultReceiveWorkers is the default number of receive workers.
+	DefaultReceiveWorkers = 1
+	// DefaultReceiveQueueSize is the default number of pending messages per receive worker.
+	DefaultReceiveQueueSize = 64
+)
+
+// Handler represents a handler which is called for each received message.
+// The handler can release the message to reuse its receive buffer once it no
+// longer refers to the payload.
+type Handler func(msg *Message)
+
+// ReceiverOption represents an option for a receiver.
+type ReceiverOption func(*Receiver)
+
+// WithReceiveWorkers returns an option to set the number of workers which decode and dispatch messages.
+func WithReceiveWorkers(n int) ReceiverOption {
+	return func(receiver *Receiver) {
+		receiver.workers = max(n, 1)
+	}
+}
+
+// WithReceiveQueueSize returns an option to set the number of pending messages per worker.
+func WithReceiveQueueSize(n int) ReceiverOption {
+	return func(receiver *Receiver) {
+		receiver.queueSize = max(n, 1)
+	}
+}
+
+// Receiver represents a receive loop which decodes and dispatches messages on a worker pool.
+// Messages of the same session are always dispatched by the same worker in the received
+// order, so a slow handler only delays the sessions which share its worker. A message is
+// dropped when the queue of its worker is full, as the reliable messaging protocol
+// retransmits it.
+type Receiver struct {
+	conn      PacketConn
+	handler   Handler
+	workers   int
+	queueSize int
+}
+
+// receivedPacket represents a received datagram in a pooled buffer.
+type receivedPacket struct {
+	addr netip.AddrPort
+	buf  *[]byte
+}
+
+// NewReceiver returns a new receiver which reads from the specified connection.
+func NewReceiver(conn PacketConn, handler Handler, opts ...ReceiverOption) *Receiver {
+	receiver := &Receiver{
+		conn:      conn,
+		handler:   handler,
+		workers:   DefaultReceiveWorkers,
+		queueSize: DefaultReceiveQueueSize,
+	}
+	for _, opt := range opts {
+		opt(receiver)
+	}
+	return receiver
+}
+
+// Workers returns the number of workers.
+func (receiver *Receiver) Workers() int {
+	return receiver.workers
+}
+
+// Run reads and dispatches messages until the context is done or reading fails.
+func (receiver *Receiver) Run(ctx context.Context) error {
+	queues := make([]chan receivedPacket, receiver.workers)
+	var wg sync.WaitGroup
+	for n := range queues {
+		queues[n] = make(chan receivedPacket, receiver.queueSize)
+		wg.Add(1)
+		go func(queue chan receivedPacket) {
+			defer wg.Done()
+			for pkt := range queue {
+				receiver.dispatch(pkt)
+			}
+		}(queues[n])
+	}
+
+	// Interrupts the blocking read when the context is done.
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = receiver.conn.SetReadDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	err := receiver.readLoop(ctx, queues)
+
+	close(done)
+	for _, queue := range queues {
+		close(queue)
+	}
+	wg.Wait()
+	_ = receiver.conn.SetReadDeadline(time.Time{})
+
+	return err
+}
+
+func (receiver *Receiver) readLoop(ctx context.Context, queues []chan receivedPacket) error {
+	for {
+		buf := getBuffer()
+		n, addr, err := receiver.conn.ReadFromUDPAddrPort((*buf)[:MaxMessageSize])
+		if err != nil {
+			putBuffer(buf)
+			if ctxErr := ctx.Err(); ctxErr != nil && errors.Is(err, os.ErrDeadlineExceeded) {
+				return ctxErr
+			}
+			return err
+		}
+		if n < message.HeaderMinSize {
+			putBuffer(buf)
+			metrics.SharedRecorder().DecodeError(string(message.PacketLayer))
+			log.Warnf("dropped a short message from %s (%d bytes)", addr, n)
+			continue
+		}
+		*buf = (*buf)[:n]
+		pkt := receivedPacket{
+			addr: addr,
+			buf:  buf,
+		}
+		queue := queues[receiver.workerIndex(pkt)]
+		select {
+		case queue <- pkt:
+		default:
+			putBuffer(buf)
+			log.Warnf("dropped a message from %s (receive queue full)", addr)
+		}
+	}
+}
+
+// workerIndex returns the worker of the session of the packet. Secure sessions are
+// identified by the session ID, and unsecured sessions, which all use session ID 0,
+// by the peer address.
+func (receiver *Receiver) workerIndex(pkt receivedPacket) int {
+	// 4.4.1.3. Session ID (16 bits) follows the message flags.
+	sessionID := binary.LittleEndian.Uint16((*pkt.buf)[1:3])
+	key := uint32(sessionID)
+	if sessionID == 0 {
+		h := fnv.New32a()
+		b, _ := pkt.addr.MarshalBinary()
+		_, _ = h.Write(b)
+		key = h.Sum32()
+	}
+	return int(key % uint32(receiver.workers))
+}
+
+func (receiver *Receiver) dispatch(pkt receivedPacket) {
+	b := *pkt.buf
+	header, err := message.NewHeaderFromBytes(b)
+	if err != nil {
+		putBuffer(pkt.buf)
+		recordDecodeError(err)
+		log.Warnf("dropped a message from %s (%s)", pkt.addr, err)
+		return
+	}
+	msg := NewMessage(pkt.addr, header, b[header.Size():])
+	msg.buf = pkt.buf
+	traceMessage(trace.Receive, msg)
+	receiver.handler(msg)
+}
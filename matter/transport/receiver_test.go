@@ -0,0 +1,109 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+)
+
+func TestReceiverSessionOrdering(t *testing.T) {
+	const (
+		slowSessionID = message.SessionID(1)
+		fastSessionID = message.SessionID(2)
+		count         = 8
+	)
+
+	sender, receiver := newTestConns(t)
+	addr := receiver.LocalAddr().(*net.UDPAddr).AddrPort()
+
+	var mutex sync.Mutex
+	received := map[message.SessionID][]message.Counter{}
+	release := make(chan struct{})
+	fastDone := make(chan struct{})
+	slowDone := make(chan struct{})
+	handler := func(msg *Message) {
+		if msg.Header.SessionID == slowSessionID {
+			<-release
+		}
+		defer msg.Release()
+		mutex.Lock()
+		defer mutex.Unlock()
+		received[msg.Header.SessionID] = append(received[msg.Header.SessionID], msg.Header.Counter)
+		if len(received[msg.Header.SessionID]) == count {
+			switch msg.Header.SessionID {
+			case slowSessionID:
+				close(slowDone)
+			case fastSessionID:
+				close(fastDone)
+			}
+		}
+	}
+
+	r := NewReceiver(receiver, handler, WithReceiveWorkers(2))
+	if r.Workers() != 2 {
+		t.Errorf("%d != %d", r.Workers(), 2)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- r.Run(ctx)
+	}()
+
+	msgs := []*Message{}
+	for n := 0; n < count; n++ {
+		for _, sessionID := range []message.SessionID{slowSessionID, fastSessionID} {
+			msg := newTestMessages(t, addr, n+1)[n]
+			msg.Header.SessionID = sessionID
+			msgs = append(msgs, msg)
+		}
+	}
+	if err := NewCodec(sender).TransmitBatch(ctx, msgs); err != nil {
+		t.Fatal(err)
+	}
+
+	// The fast session is dispatched while the slow session handler is blocked.
+	select {
+	case <-fastDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("fast session is stalled")
+	}
+	close(release)
+	select {
+	case <-slowDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("slow session is not dispatched")
+	}
+
+	cancel()
+	if err := <-runErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("%v is not %v", err, context.Canceled)
+	}
+
+	for sessionID, counters := range received {
+		for n, counter := range counters {
+			if counter != message.Counter(n) {
+				t.Errorf("session %d: %v", sessionID, counters)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/internal/protocol"
+	"github.com/cybergarage/go-matter/matter/metrics"
+	"github.com/cybergarage/go-matter/matter/trace"
+)
+
+// protocolHeader returns the protocol header of the message if it is readable. Only
+// the payload of unsecured session messages is readable, the payload of secure
+// session messages is encrypted.
+func (msg *Message) protocolHeader() *protocol.Header {
+	if msg.Header.SessionID != 0 || !msg.Header.SecurityFlag.IsUnicastSession() {
+		return nil
+	}
+	header, err := protocol.NewHeaderFromBytes(msg.Payload)
+	if err != nil {
+		return nil
+	}
+	return header
+}
+
+// traceMessage passes the transmitted or received message to the shared tracer, and
+// counts it on the shared recorder when its protocol is known.
+func traceMessage(dir trace.Direction, msg *Message) {
+	header := msg.protocolHeader()
+	trace.SharedTracer().TraceMessage(&trace.MessageEvent{
+		Time:           time.Now(),
+		Direction:      dir,
+		Peer:           msg.Addr.String(),
+		Header:         msg.Header,
+		ProtocolHeader: header,
+		Length:         msg.Size(),
+	})
+	if header == nil {
+		return
+	}
+	switch dir {
+	case trace.Transmit:
+		metrics.SharedRecorder().MessageSent(header.ProtocolID)
+	case trace.Receive:
+		metrics.SharedRecorder().MessageReceived(header.ProtocolID)
+	}
+}
+
+// recordDecodeError counts the decode error on the shared recorder by its layer.
+func recordDecodeError(err error) {
+	layer := message.PacketLayer
+	var decodeErr *message.DecodeError
+	if errors.As(err, &decodeErr) {
+		layer = decodeErr.Layer
+	}
+	metrics.SharedRecorder().DecodeError(string(layer))
+}
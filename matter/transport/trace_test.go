@@ -0,0 +1,137 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/internal/protocol"
+	"github.com/cybergarage/go-matter/matter/metrics"
+	"github.com/cybergarage/go-matter/matter/trace"
+)
+
+type testTracer struct {
+	trace.NullTracer
+	sync.Mutex
+	events []*trace.MessageEvent
+}
+
+func (tracer *testTracer) TraceMessage(e *trace.MessageEvent) {
+	tracer.Lock()
+	defer tracer.Unlock()
+	tracer.events = append(tracer.events, e)
+}
+
+type testRecorder struct {
+	metrics.NullRecorder
+	sync.Mutex
+	sent         []protocol.ProtocolID
+	received     []protocol.ProtocolID
+	decodeErrors []string
+}
+
+func (recorder *testRecorder) MessageSent(id protocol.ProtocolID) {
+	recorder.Lock()
+	defer recorder.Unlock()
+	recorder.sent = append(recorder.sent, id)
+}
+
+func (recorder *testRecorder) MessageReceived(id protocol.ProtocolID) {
+	recorder.Lock()
+	defer recorder.Unlock()
+	recorder.received = append(recorder.received, id)
+}
+
+func (recorder *testRecorder) DecodeError(layer string) {
+	recorder.Lock()
+	defer recorder.Unlock()
+	recorder.decodeErrors = append(recorder.decodeErrors, layer)
+}
+
+func TestTraceMessages(t *testing.T) {
+	tracer := &testTracer{}
+	trace.SetSharedTracer(tracer)
+	defer trace.SetSharedTracer(nil)
+	recorder := &testRecorder{}
+	metrics.SetSharedRecorder(recorder)
+	defer metrics.SetSharedRecorder(nil)
+
+	sender, receiver := newTestConns(t)
+	addr := receiver.LocalAddr().(*net.UDPAddr).AddrPort()
+
+	received := make(chan struct{}, 2)
+	r := NewReceiver(receiver, func(msg *Message) {
+		msg.Release()
+		received <- struct{}{}
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Run(ctx)
+
+	// A short message is counted as a decode error.
+	if _, err := sender.WriteToUDPAddrPort([]byte{0x00, 0x00}, addr); err != nil {
+		t.Fatal(err)
+	}
+
+	// The protocol of an unsecured message is readable, but not that of a secured message.
+	protoHeader := protocol.NewHeader()
+	protoHeader.ProtocolID = protocol.ProtocolID(0x0001)
+	unsecured := NewMessage(addr, message.NewHeader(), protoHeader.Bytes())
+	secured := newTestMessages(t, addr, 1)[0]
+	codec := NewCodec(sender)
+	if err := codec.Transmit(ctx, unsecured); err != nil {
+		t.Fatal(err)
+	}
+	if err := codec.TransmitBatch(ctx, []*Message{secured}); err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		select {
+		case <-received:
+		case <-time.After(5 * time.Second):
+			t.Fatal("message is not received")
+		}
+	}
+
+	tracer.Lock()
+	defer tracer.Unlock()
+	directions := map[trace.Direction]int{}
+	for _, e := range tracer.events {
+		directions[e.Direction]++
+		if (e.Header.SessionID == 0) != (e.ProtocolHeader != nil) {
+			t.Errorf("session %d: protocol header %v", e.Header.SessionID, e.ProtocolHeader)
+		}
+	}
+	if directions[trace.Transmit] != 2 || directions[trace.Receive] != 2 {
+		t.Errorf("%v", directions)
+	}
+
+	recorder.Lock()
+	defer recorder.Unlock()
+	if len(recorder.sent) != 1 || recorder.sent[0] != protoHeader.ProtocolID {
+		t.Errorf("sent %v", recorder.sent)
+	}
+	if len(recorder.received) != 1 || recorder.received[0] != protoHeader.ProtocolID {
+		t.Errorf("received %v", recorder.received)
+	}
+	if len(recorder.decodeErrors) != 1 || recorder.decodeErrors[0] != string(message.PacketLayer) {
+		t.Errorf("decode errors %v", recorder.decodeErrors)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// Matter Device Library Specification Version 1.2
+const (
+	DoorLockDeviceType              DeviceType = 0x000A
+	AggregatorDeviceType            DeviceType = 0x000E
+	GenericSwitchDeviceType         DeviceType = 0x000F
+	PowerSourceDeviceType           DeviceType = 0x0011
+	OTARequestorDeviceType          DeviceType = 0x0012
+	BridgedNodeDeviceType           DeviceType = 0x0013
+	OTAProviderDeviceType           DeviceType = 0x0014
+	ContactSensorDeviceType         DeviceType = 0x0015
+	RootNodeDeviceType              DeviceType = 0x0016
+	ModeSelectDeviceType            DeviceType = 0x0027
+	FanDeviceType                   DeviceType = 0x002B
+	AirQualitySensorDeviceType      DeviceType = 0x002C
+	AirPurifierDeviceType           DeviceType = 0x002D
+	LaundryWasherDeviceType         DeviceType = 0x0073
+	RoboticVacuumCleanerDeviceType  DeviceType = 0x0074
+	SmokeCOAlarmDeviceType          DeviceType = 0x0076
+	OnOffLightDeviceType            DeviceType = 0x0100
+	DimmableLightDeviceType         DeviceType = 0x0101
+	OnOffLightSwitchDeviceType      DeviceType = 0x0103
+	DimmerSwitchDeviceType          DeviceType = 0x0104
+	ColorDimmerSwitchDeviceType     DeviceType = 0x0105
+	LightSensorDeviceType           DeviceType = 0x0106
+	OccupancySensorDeviceType       DeviceType = 0x0107
+	OnOffPlugInUnitDeviceType       DeviceType = 0x010A
+	DimmablePlugInUnitDeviceType    DeviceType = 0x010B
+	ColorTemperatureLightDeviceType DeviceType = 0x010C
+	ExtendedColorLightDeviceType    DeviceType = 0x010D
+	WindowCoveringDeviceType        DeviceType = 0x0202
+	ThermostatDeviceType            DeviceType = 0x0301
+	TemperatureSensorDeviceType     DeviceType = 0x0302
+	PressureSensorDeviceType        DeviceType = 0x0305
+	FlowSensorDeviceType            DeviceType = 0x0306
+	HumiditySensorDeviceType        DeviceType = 0x0307
+)
+
+// Server cluster IDs which are required by the device types.
+const (
+	identifyCluster                   = 0x0003
+	groupsCluster                     = 0x0004
+	onOffCluster                      = 0x0006
+	levelControlCluster               = 0x0008
+	accessControlCluster              = 0x001F
+	basicInformationCluster           = 0x0028
+	otaProviderCluster                = 0x0029
+	otaRequestorCluster               = 0x002A
+	powerSourceCluster                = 0x002F
+	generalCommissioningCluster       = 0x0030
+	generalDiagnosticsCluster         = 0x0033
+	bridgedDeviceBasicInfoCluster     = 0x0039
+	switchCluster                     = 0x003B
+	administratorCommissioningCluster = 0x003C
+	nodeOperationalCredentialsCluster = 0x003E
+	groupKeyManagementCluster         = 0x003F
+	booleanStateCluster               = 0x0045
+	modeSelectCluster                 = 0x0050
+	rvcRunModeCluster                 = 0x0054
+	airQualityCluster                 = 0x005B
+	smokeCOAlarmCluster               = 0x005C
+	operationalStateCluster           = 0x0060
+	rvcOperationalStateCluster        = 0x0061
+	doorLockCluster                   = 0x0101
+	windowCoveringCluster             = 0x0102
+	thermostatCluster                 = 0x0201
+	fanControlCluster                 = 0x0202
+	colorControlCluster               = 0x0300
+	illuminanceMeasurementCluster     = 0x0400
+	temperatureMeasurementCluster     = 0x0402
+	pressureMeasurementCluster        = 0x0403
+	flowMeasurementCluster            = 0x0404
+	relativeHumidityCluster           = 0x0405
+	occupancySensingCluster           = 0x0406
+)
+
+// deviceLibrary is the device types which are registered by default.
+var deviceLibrary = []*DeviceTypeInfo{
+	{RootNodeDeviceType, "Root Node", 2, UtilityDeviceTypeClass, []uint32{basicInformationCluster, accessControlCluster, groupKeyManagementCluster, generalCommissioningCluster, administratorCommissioningCluster, nodeOperationalCredentialsCluster, generalDiagnosticsCluster}},
+	{PowerSourceDeviceType, "Power Source", 1, UtilityDeviceTypeClass, []uint32{powerSourceCluster}},
+	{OTARequestorDeviceType, "OTA Requestor", 1, UtilityDeviceTypeClass, []uint32{otaRequestorCluster}},
+	{OTAProviderDeviceType, "OTA Provider", 1, UtilityDeviceTypeClass, []uint32{otaProviderCluster}},
+	{AggregatorDeviceType, "Aggregator", 1, SimpleDeviceTypeClass, []uint32{}},
+	{BridgedNodeDeviceType, "Bridged Node", 2, UtilityDeviceTypeClass, []uint32{bridgedDeviceBasicInfoCluster}},
+	{OnOffLightDeviceType, "On/Off Light", 3, SimpleDeviceTypeClass, []uint32{identifyCluster, groupsCluster, onOffCluster}},
+	{DimmableLightDeviceType, "Dimmable Light", 3, SimpleDeviceTypeClass, []uint32{identifyCluster, groupsCluster, onOffCluster, levelControlCluster}},
+	{ColorTemperatureLightDeviceType, "Color Temperature Light", 4, SimpleDeviceTypeClass, []uint32{identifyCluster, groupsCluster, onOffCluster, levelControlCluster, colorControlCluster}},
+	{ExtendedColorLightDeviceType, "Extended Color Light", 4, SimpleDeviceTypeClass, []uint32{identifyCluster, groupsCluster, onOffCluster, levelControlCluster, colorControlCluster}},
+	{OnOffPlugInUnitDeviceType, "On/Off Plug-in Unit", 3, SimpleDeviceTypeClass, []uint32{identifyCluster, groupsCluster, onOffCluster}},
+	{DimmablePlugInUnitDeviceType, "Dimmable Plug-in Unit", 4, SimpleDeviceTypeClass, []uint32{identifyCluster, groupsCluster, onOffCluster, levelControlCluster}},
+	{OnOffLightSwitchDeviceType, "On/Off Light Switch", 3, SimpleDeviceTypeClass, []uint32{identifyCluster}},
+	{DimmerSwitchDeviceType, "Dimmer Switch", 3, SimpleDeviceTypeClass, []uint32{identifyCluster}},
+	{ColorDimmerSwitchDeviceType, "Color Dimmer Switch", 3, SimpleDeviceTypeClass, []uint32{identifyCluster}},
+	{GenericSwitchDeviceType, "Generic Switch", 2, SimpleDeviceTypeClass, []uint32{identifyCluster, switchCluster}},
+	{ContactSensorDeviceType, "Contact Sensor", 1, SimpleDeviceTypeClass, []uint32{identifyCluster, booleanStateCluster}},
+	{LightSensorDeviceType, "Light Sensor", 3, SimpleDeviceTypeClass, []uint32{identifyCluster, illuminanceMeasurementCluster}},
+	{OccupancySensorDeviceType, "Occupancy Sensor", 3, SimpleDeviceTypeClass, []uint32{identifyCluster, occupancySensingCluster}},
+	{TemperatureSensorDeviceType, "Temperature Sensor", 2, SimpleDeviceTypeClass, []uint32{identifyCluster, temperatureMeasurementCluster}},
+	{PressureSensorDeviceType, "Pressure Sensor", 2, SimpleDeviceTypeClass, []uint32{identifyCluster, pressureMeasurementCluster}},
+	{FlowSensorDeviceType, "Flow Sensor", 2, SimpleDeviceTypeClass, []uint32{identifyCluster, flowMeasurementCluster}},
+	{HumiditySensorDeviceType, "Humidity Sensor", 2, SimpleDeviceTypeClass, []uint32{identifyCluster, relativeHumidityCluster}},
+	{AirQualitySensorDeviceType, "Air Quality Sensor", 1, SimpleDeviceTypeClass, []uint32{identifyCluster, airQualityCluster}},
+	{SmokeCOAlarmDeviceType, "Smoke CO Alarm", 1, SimpleDeviceTypeClass, []uint32{identifyCluster, smokeCOAlarmCluster}},
+	{DoorLockDeviceType, "Door Lock", 2, SimpleDeviceTypeClass, []uint32{identifyCluster, doorLockCluster}},
+	{WindowCoveringDeviceType, "Window Covering", 2, SimpleDeviceTypeClass, []uint32{identifyCluster, windowCoveringCluster}},
+	{ThermostatDeviceType, "Thermostat", 2, SimpleDeviceTypeClass, []uint32{identifyCluster, thermostatCluster}},
+	{FanDeviceType, "Fan", 2, SimpleDeviceTypeClass, []uint32{identifyCluster, groupsCluster, fanControlCluster}},
+	{AirPurifierDeviceType, "Air Purifier", 1, SimpleDeviceTypeClass, []uint32{identifyCluster, fanControlCluster}},
+	{ModeSelectDeviceType, "Mode Select", 1, SimpleDeviceTypeClass, []uint32{modeSelectCluster}},
+	{LaundryWasherDeviceType, "Laundry Washer", 1, SimpleDeviceTypeClass, []uint32{operationalStateCluster}},
+	{RoboticVacuumCleanerDeviceType, "Robotic Vacuum Cleaner", 1, SimpleDeviceTypeClass, []uint32{identifyCluster, rvcRunModeCluster, rvcOperationalStateCluster}},
+}
@@ -0,0 +1,158 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// DeviceType represents a device type ID defined in the device library.
+type DeviceType uint32
+
+// NewDeviceTypeFromString returns a new device type from a decimal or a 0x-prefixed hexadecimal string.
+func NewDeviceTypeFromString(s string) (DeviceType, error) {
+	v, err := parseUint("device type", s, 32)
+	if err != nil {
+		return 0, err
+	}
+	return DeviceType(v), nil
+}
+
+// Info returns the registered information of the device type.
+func (dt DeviceType) Info() (*DeviceTypeInfo, bool) {
+	return LookupDeviceType(dt)
+}
+
+// Name returns the registered name of the device type, or an empty string if the device type is not registered.
+func (dt DeviceType) Name() string {
+	info, ok := LookupDeviceType(dt)
+	if !ok {
+		return ""
+	}
+	return info.Name
+}
+
+// DecimalString returns the decimal representation which is used in DNS-SD TXT records.
+func (dt DeviceType) DecimalString() string {
+	return strconv.FormatUint(uint64(dt), 10)
+}
+
+// String returns the hexadecimal representation with the registered name such as 0x0100 (On/Off Light).
+func (dt DeviceType) String() string {
+	if name := dt.Name(); 0 < len(name) {
+		return fmt.Sprintf("0x%04X (%s)", uint32(dt), name)
+	}
+	return fmt.Sprintf("0x%04X", uint32(dt))
+}
+
+// DeviceTypeClass represents a device type class.
+type DeviceTypeClass uint8
+
+const (
+	// UtilityDeviceTypeClass represents the device types which provide the utility functions of a node.
+	UtilityDeviceTypeClass DeviceTypeClass = iota
+	// SimpleDeviceTypeClass represents the device types which provide the application functions.
+	SimpleDeviceTypeClass
+)
+
+// String returns the string representation.
+func (class DeviceTypeClass) String() string {
+	switch class {
+	case UtilityDeviceTypeClass:
+		return "Utility"
+	case SimpleDeviceTypeClass:
+		return "Simple"
+	default:
+		return fmt.Sprintf("DeviceTypeClass(%d)", uint8(class))
+	}
+}
+
+// DeviceTypeInfo represents a device type definition in the device library.
+type DeviceTypeInfo struct {
+	// ID is the device type ID.
+	ID DeviceType
+	// Name is the device type name.
+	Name string
+	// Revision is the latest device type revision.
+	Revision uint16
+	// Class is the device type class.
+	Class DeviceTypeClass
+	// RequiredServerClusters is the IDs of the server clusters which the endpoints must have.
+	RequiredServerClusters []uint32
+}
+
+var deviceTypes = struct {
+	sync.RWMutex
+	infos map[DeviceType]*DeviceTypeInfo
+}{
+	RWMutex: sync.RWMutex{},
+	infos:   newDeviceTypeInfoMap(deviceLibrary),
+}
+
+func newDeviceTypeInfoMap(infos []*DeviceTypeInfo) map[DeviceType]*DeviceTypeInfo {
+	m := map[DeviceType]*DeviceTypeInfo{}
+	for _, info := range infos {
+		m[info.ID] = info
+	}
+	return m
+}
+
+// RegisterDeviceType registers the specified device type, which replaces the registered one of the same ID.
+// It is used to register manufacturer specific device types.
+func RegisterDeviceType(info *DeviceTypeInfo) error {
+	if len(info.Name) == 0 {
+		return fmt.Errorf("%w device type (0x%04X) : no name", ErrInvalid, uint32(info.ID))
+	}
+	deviceTypes.Lock()
+	defer deviceTypes.Unlock()
+	deviceTypes.infos[info.ID] = info
+	return nil
+}
+
+// UnregisterDeviceType removes the registered device type of the specified ID. A device type of
+// the device library is restored to the one in the library.
+func UnregisterDeviceType(id DeviceType) {
+	deviceTypes.Lock()
+	defer deviceTypes.Unlock()
+	delete(deviceTypes.infos, id)
+	for _, info := range deviceLibrary {
+		if info.ID == id {
+			deviceTypes.infos[id] = info
+		}
+	}
+}
+
+// LookupDeviceType returns the registered device type of the specified ID.
+func LookupDeviceType(id DeviceType) (*DeviceTypeInfo, bool) {
+	deviceTypes.RLock()
+	defer deviceTypes.RUnlock()
+	info, ok := deviceTypes.infos[id]
+	return info, ok
+}
+
+// DeviceTypes returns the registered device types in ascending order of the IDs.
+func DeviceTypes() []*DeviceTypeInfo {
+	deviceTypes.RLock()
+	defer deviceTypes.RUnlock()
+	infos := make([]*DeviceTypeInfo, 0, len(deviceTypes.infos))
+	for _, info := range deviceTypes.infos {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos
+}
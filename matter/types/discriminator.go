@@ -0,0 +1,121 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// 5.1.1.6. Discriminator value
+const (
+	// DiscriminatorMax is the maximum 12-bit discriminator.
+	DiscriminatorMax = 0x0FFF
+	// ShortDiscriminatorMax is the maximum 4-bit short discriminator.
+	ShortDiscriminatorMax = 0x0F
+
+	discriminatorMask       = 0x0FFF
+	shortDiscriminatorShift = 8
+	shortDiscriminatorFlag  = 0x8000
+)
+
+// Discriminator represents a 12-bit discriminator, or a short discriminator of which only
+// the upper 4 bits are known as in a manual pairing code or a DNS-SD _S subtype.
+// A short discriminator is marked with a bit above the 12-bit range, so both forms
+// can be compared with == and used as map keys.
+type Discriminator uint16
+
+// NewShortDiscriminator returns a new discriminator of which only the upper 4 bits are known.
+func NewShortDiscriminator(short uint8) Discriminator {
+	return Discriminator(shortDiscriminatorFlag | (uint16(short&ShortDiscriminatorMax) << shortDiscriminatorShift))
+}
+
+// NewDiscriminatorFromString returns a new 12-bit discriminator from a decimal or a 0x-prefixed hexadecimal string.
+func NewDiscriminatorFromString(s string) (Discriminator, error) {
+	v, err := parseUint("discriminator", s, 16)
+	if err != nil {
+		return 0, err
+	}
+	if DiscriminatorMax < v {
+		return 0, fmt.Errorf("%w discriminator (%d)", ErrInvalid, v)
+	}
+	return Discriminator(v), nil
+}
+
+// NewShortDiscriminatorFromString returns a new short discriminator from a decimal or a 0x-prefixed hexadecimal string.
+func NewShortDiscriminatorFromString(s string) (Discriminator, error) {
+	v, err := parseUint("short discriminator", s, 8)
+	if err != nil {
+		return 0, err
+	}
+	if ShortDiscriminatorMax < v {
+		return 0, fmt.Errorf("%w short discriminator (%d)", ErrInvalid, v)
+	}
+	return NewShortDiscriminator(uint8(v)), nil
+}
+
+// IsShort returns true if only the upper 4 bits of the discriminator are known.
+func (d Discriminator) IsShort() bool {
+	return (d & shortDiscriminatorFlag) != 0
+}
+
+// IsValid returns true if the discriminator is within the 12-bit range.
+func (d Discriminator) IsValid() bool {
+	if d.IsShort() {
+		return (d & ^Discriminator(shortDiscriminatorFlag|(ShortDiscriminatorMax<<shortDiscriminatorShift))) == 0
+	}
+	return d <= DiscriminatorMax
+}
+
+// Value returns the 12-bit value. The lower 8 bits are zero for a short discriminator.
+func (d Discriminator) Value() uint16 {
+	return uint16(d & discriminatorMask)
+}
+
+// Short returns the upper 4 bits of the discriminator.
+func (d Discriminator) Short() uint8 {
+	return uint8(d.Value() >> shortDiscriminatorShift)
+}
+
+// Matches returns true if the discriminators are equal. When either is a short
+// discriminator, only the upper 4 bits are compared.
+func (d Discriminator) Matches(other Discriminator) bool {
+	if d.IsShort() || other.IsShort() {
+		return d.Short() == other.Short()
+	}
+	return d.Value() == other.Value()
+}
+
+// DecimalString returns the decimal representation which is used in DNS-SD subtypes and TXT records.
+// The short discriminator is represented by the upper 4 bits only.
+func (d Discriminator) DecimalString() string {
+	if d.IsShort() {
+		return strconv.FormatUint(uint64(d.Short()), 10)
+	}
+	return strconv.FormatUint(uint64(d.Value()), 10)
+}
+
+// HexString returns the hexadecimal representation such as 0xF00 or 0xF for a short discriminator.
+func (d Discriminator) HexString() string {
+	if d.IsShort() {
+		return fmt.Sprintf("0x%X", d.Short())
+	}
+	return fmt.Sprintf("0x%03X", d.Value())
+}
+
+// String returns the decimal representation.
+func (d Discriminator) String() string {
+	return d.DecimalString()
+}
@@ -0,0 +1,32 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// FabricIndex represents a fabric index which identifies a fabric locally on a node.
+type FabricIndex uint8
+
+const (
+	// NoFabricIndex represents no fabric, as in PASE sessions before commissioning.
+	NoFabricIndex FabricIndex = 0
+	// MinFabricIndex is the minimum valid fabric index.
+	MinFabricIndex FabricIndex = 1
+	// MaxFabricIndex is the maximum valid fabric index.
+	MaxFabricIndex FabricIndex = 254
+)
+
+// IsValid returns true if the fabric index identifies a fabric.
+func (idx FabricIndex) IsValid() bool {
+	return MinFabricIndex <= idx && idx <= MaxFabricIndex
+}
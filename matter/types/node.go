@@ -0,0 +1,139 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// 2.5.5. Operational Node ID
+// NodeID represents a 64-bit node ID.
+type NodeID uint64
+
+// NodeIDCategory represents a category of the node ID space.
+type NodeIDCategory uint8
+
+const (
+	UnspecifiedNodeIDCategory NodeIDCategory = iota
+	OperationalNodeIDCategory
+	GroupNodeIDCategory
+	TemporaryLocalNodeIDCategory
+	PAKEKeyIDCategory
+	CASEAuthenticatedTagCategory
+	ReservedNodeIDCategory
+)
+
+// 2.5.5.1. Operational Node ID Ranges
+const (
+	// UnspecifiedNodeID represents the unspecified node ID.
+	UnspecifiedNodeID NodeID = 0x0000000000000000
+	// OperationalNodeIDMin is the minimum operational node ID.
+	OperationalNodeIDMin NodeID = 0x0000000000000001
+	// OperationalNodeIDMax is the maximum operational node ID.
+	OperationalNodeIDMax NodeID = 0xFFFFFFEFFFFFFFFF
+	// PAKEKeyIDMin is the minimum PAKE key identifier.
+	PAKEKeyIDMin NodeID = 0xFFFFFFFB00000000
+	// PAKEKeyIDMax is the maximum PAKE key identifier.
+	PAKEKeyIDMax NodeID = 0xFFFFFFFBFFFFFFFF
+	// CASEAuthenticatedTagMin is the minimum CASE authenticated tag node ID.
+	CASEAuthenticatedTagMin NodeID = 0xFFFFFFFD00000000
+	// CASEAuthenticatedTagMax is the maximum CASE authenticated tag node ID.
+	CASEAuthenticatedTagMax NodeID = 0xFFFFFFFDFFFFFFFF
+	// TemporaryLocalNodeIDMin is the minimum temporary local node ID.
+	TemporaryLocalNodeIDMin NodeID = 0xFFFFFFFE00000000
+	// TemporaryLocalNodeIDMax is the maximum temporary local node ID.
+	TemporaryLocalNodeIDMax NodeID = 0xFFFFFFFEFFFFFFFF
+	// GroupNodeIDMin is the minimum group node ID.
+	GroupNodeIDMin NodeID = 0xFFFFFFFFFFFF0000
+	// GroupNodeIDMax is the maximum group node ID.
+	GroupNodeIDMax NodeID = 0xFFFFFFFFFFFFFFFF
+)
+
+// NewGroupNodeID returns the group node ID of the specified group ID.
+func NewGroupNodeID(groupID uint16) NodeID {
+	return GroupNodeIDMin | NodeID(groupID)
+}
+
+// Category returns the category of the node ID.
+func (id NodeID) Category() NodeIDCategory {
+	switch {
+	case id == UnspecifiedNodeID:
+		return UnspecifiedNodeIDCategory
+	case id <= OperationalNodeIDMax:
+		return OperationalNodeIDCategory
+	case PAKEKeyIDMin <= id && id <= PAKEKeyIDMax:
+		return PAKEKeyIDCategory
+	case CASEAuthenticatedTagMin <= id && id <= CASEAuthenticatedTagMax:
+		return CASEAuthenticatedTagCategory
+	case TemporaryLocalNodeIDMin <= id && id <= TemporaryLocalNodeIDMax:
+		return TemporaryLocalNodeIDCategory
+	case GroupNodeIDMin <= id:
+		return GroupNodeIDCategory
+	}
+	return ReservedNodeIDCategory
+}
+
+// IsUnspecified returns true if the node ID is the unspecified node ID.
+func (id NodeID) IsUnspecified() bool {
+	return id == UnspecifiedNodeID
+}
+
+// IsValidOperational returns true if the node ID is in the operational node ID range.
+func (id NodeID) IsValidOperational() bool {
+	return id.Category() == OperationalNodeIDCategory
+}
+
+// IsGroup returns true if the node ID is a group node ID.
+func (id NodeID) IsGroup() bool {
+	return id.Category() == GroupNodeIDCategory
+}
+
+// IsTemporaryLocal returns true if the node ID is a temporary local node ID.
+func (id NodeID) IsTemporaryLocal() bool {
+	return id.Category() == TemporaryLocalNodeIDCategory
+}
+
+// IsPAKEKeyID returns true if the node ID is a PAKE key identifier.
+func (id NodeID) IsPAKEKeyID() bool {
+	return id.Category() == PAKEKeyIDCategory
+}
+
+// IsCASEAuthenticatedTag returns true if the node ID is a CASE authenticated tag.
+func (id NodeID) IsCASEAuthenticatedTag() bool {
+	return id.Category() == CASEAuthenticatedTagCategory
+}
+
+// GroupID returns the group ID of the group node ID, and false if the node ID is not a group node ID.
+func (id NodeID) GroupID() (uint16, bool) {
+	if !id.IsGroup() {
+		return 0, false
+	}
+	return uint16(id & 0xFFFF), true
+}
+
+// String returns the string representation.
+func (category NodeIDCategory) String() string {
+	switch category {
+	case UnspecifiedNodeIDCategory:
+		return "Unspecified"
+	case OperationalNodeIDCategory:
+		return "Operational"
+	case GroupNodeIDCategory:
+		return "Group"
+	case TemporaryLocalNodeIDCategory:
+		return "TemporaryLocal"
+	case PAKEKeyIDCategory:
+		return "PAKEKeyID"
+	case CASEAuthenticatedTagCategory:
+		return "CASEAuthenticatedTag"
+	}
+	return "Reserved"
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	hexPrefix = "0x"
+)
+
+// parseUint parses a decimal or a 0x-prefixed hexadecimal string.
+func parseUint(name string, s string, bitSize int) (uint64, error) {
+	s = strings.TrimSpace(s)
+	base := 10
+	if strings.HasPrefix(strings.ToLower(s), hexPrefix) {
+		s = s[len(hexPrefix):]
+		base = 16
+	}
+	v, err := strconv.ParseUint(s, base, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("%w %s: %w", ErrInvalid, name, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,47 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ProductID represents a product ID which is assigned by a vendor.
+type ProductID uint16
+
+const (
+	// AnonymizedProductID represents a product ID which is not disclosed.
+	AnonymizedProductID ProductID = 0x0000
+)
+
+// NewProductIDFromString returns a new product ID from a decimal or a 0x-prefixed hexadecimal string.
+func NewProductIDFromString(s string) (ProductID, error) {
+	v, err := parseUint("product ID", s, 16)
+	if err != nil {
+		return 0, err
+	}
+	return ProductID(v), nil
+}
+
+// DecimalString returns the decimal representation which is used in DNS-SD TXT records.
+func (pid ProductID) DecimalString() string {
+	return strconv.FormatUint(uint64(pid), 10)
+}
+
+// String returns the hexadecimal representation such as 0x8000.
+func (pid ProductID) String() string {
+	return fmt.Sprintf("0x%04X", uint16(pid))
+}
@@ -0,0 +1,329 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVendorProductID(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected uint16
+	}{
+		{"65521", 0xFFF1},
+		{"0xFFF1", 0xFFF1},
+		{"0xfff1", 0xFFF1},
+		{"0X8000", 0x8000},
+		{" 0840 ", 840},
+		{"0", 0},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			vid, err := NewVendorIDFromString(test.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if vid != VendorID(test.expected) {
+				t.Errorf("%s != %d", vid, test.expected)
+			}
+			pid, err := NewProductIDFromString(test.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pid != ProductID(test.expected) {
+				t.Errorf("%s != %d", pid, test.expected)
+			}
+			for _, s := range []string{vid.String(), vid.DecimalString()} {
+				v, err := NewVendorIDFromString(s)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if v != vid {
+					t.Errorf("%s != %s", v, vid)
+				}
+			}
+		})
+	}
+
+	for _, s := range []string{"", "0x", "65536", "0x10000", "-1", "FFF1", "0xFFG1"} {
+		t.Run(s, func(t *testing.T) {
+			if _, err := NewVendorIDFromString(s); !errors.Is(err, ErrInvalid) {
+				t.Errorf("%v is not %v", err, ErrInvalid)
+			}
+			if _, err := NewProductIDFromString(s); !errors.Is(err, ErrInvalid) {
+				t.Errorf("%v is not %v", err, ErrInvalid)
+			}
+		})
+	}
+
+	if !VendorID(0xFFF2).IsTest() || VendorID(0xFFF5).IsTest() {
+		t.Errorf("test vendor ID range is broken")
+	}
+	if VendorID(0xFFF1).String() != "0xFFF1" || ProductID(0x8000).DecimalString() != "32768" {
+		t.Errorf("formatting is broken")
+	}
+}
+
+func TestDiscriminator(t *testing.T) {
+	tests := []struct {
+		d       Discriminator
+		isShort bool
+		value   uint16
+		short   uint8
+		str     string
+		hex     string
+	}{
+		{3840, false, 3840, 15, "3840", "0xF00"},
+		{840, false, 840, 3, "840", "0x348"},
+		{0, false, 0, 0, "0", "0x000"},
+		{NewShortDiscriminator(15), true, 0xF00, 15, "15", "0xF"},
+		{NewShortDiscriminator(3), true, 0x300, 3, "3", "0x3"},
+	}
+	for _, test := range tests {
+		t.Run(test.hex, func(t *testing.T) {
+			if !test.d.IsValid() {
+				t.Errorf("%s is not valid", test.d)
+			}
+			if test.d.IsShort() != test.isShort {
+				t.Errorf("%t != %t", test.d.IsShort(), test.isShort)
+			}
+			if test.d.Value() != test.value {
+				t.Errorf("%d != %d", test.d.Value(), test.value)
+			}
+			if test.d.Short() != test.short {
+				t.Errorf("%d != %d", test.d.Short(), test.short)
+			}
+			if test.d.String() != test.str {
+				t.Errorf("%s != %s", test.d.String(), test.str)
+			}
+			if test.d.HexString() != test.hex {
+				t.Errorf("%s != %s", test.d.HexString(), test.hex)
+			}
+			parse := NewDiscriminatorFromString
+			if test.isShort {
+				parse = NewShortDiscriminatorFromString
+			}
+			for _, s := range []string{test.d.String(), test.d.HexString()} {
+				d, err := parse(s)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if d != test.d {
+					t.Errorf("%s != %s", d, test.d)
+				}
+			}
+		})
+	}
+
+	matches := []struct {
+		a        Discriminator
+		b        Discriminator
+		expected bool
+	}{
+		{3840, 3840, true},
+		{3840, 3841, false},
+		{3840, NewShortDiscriminator(15), true},
+		{NewShortDiscriminator(15), 4095, true},
+		{NewShortDiscriminator(15), 840, false},
+		{NewShortDiscriminator(3), NewShortDiscriminator(3), true},
+	}
+	for _, m := range matches {
+		if m.a.Matches(m.b) != m.expected || m.b.Matches(m.a) != m.expected {
+			t.Errorf("%s matches %s != %t", m.a, m.b, m.expected)
+		}
+	}
+
+	for _, s := range []string{"4096", "0x1000", "-1", "D"} {
+		if _, err := NewDiscriminatorFromString(s); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%v is not %v", err, ErrInvalid)
+		}
+	}
+	for _, s := range []string{"16", "0x10"} {
+		if _, err := NewShortDiscriminatorFromString(s); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%v is not %v", err, ErrInvalid)
+		}
+	}
+	if Discriminator(0x1000).IsValid() || Discriminator(0x8F01).IsValid() {
+		t.Errorf("reserved bits are accepted")
+	}
+}
+
+func TestNodeID(t *testing.T) {
+	tests := []struct {
+		id       NodeID
+		expected NodeIDCategory
+	}{
+		{0x0000000000000000, UnspecifiedNodeIDCategory},
+		{0x0000000000000001, OperationalNodeIDCategory},
+		{0xFFFFFFEFFFFFFFFF, OperationalNodeIDCategory},
+		{0xFFFFFFF000000000, ReservedNodeIDCategory},
+		{0xFFFFFFFB00000001, PAKEKeyIDCategory},
+		{0xFFFFFFFC00000000, ReservedNodeIDCategory},
+		{0xFFFFFFFD00010001, CASEAuthenticatedTagCategory},
+		{0xFFFFFFFE00000001, TemporaryLocalNodeIDCategory},
+		{0xFFFFFFFF00000000, ReservedNodeIDCategory},
+		{0xFFFFFFFFFFFF0001, GroupNodeIDCategory},
+		{0xFFFFFFFFFFFFFFFF, GroupNodeIDCategory},
+	}
+	for _, test := range tests {
+		t.Run(test.expected.String(), func(t *testing.T) {
+			if test.id.Category() != test.expected {
+				t.Errorf("%016X: %s != %s", uint64(test.id), test.id.Category(), test.expected)
+			}
+			if test.id.IsValidOperational() != (test.expected == OperationalNodeIDCategory) {
+				t.Errorf("%016X: operational %t", uint64(test.id), test.id.IsValidOperational())
+			}
+		})
+	}
+
+	id := NewGroupNodeID(0x1234)
+	if groupID, ok := id.GroupID(); !ok || groupID != 0x1234 {
+		t.Errorf("%016X: %04X", uint64(id), groupID)
+	}
+	if _, ok := OperationalNodeIDMin.GroupID(); ok {
+		t.Errorf("%016X", uint64(OperationalNodeIDMin))
+	}
+}
+
+func TestDeviceType(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected DeviceType
+		name     string
+	}{
+		{"256", OnOffLightDeviceType, "On/Off Light"},
+		{"0x0016", RootNodeDeviceType, "Root Node"},
+		{"0x000e", AggregatorDeviceType, "Aggregator"},
+		{"0xFFF10001", 0xFFF10001, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			dt, err := NewDeviceTypeFromString(test.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dt != test.expected {
+				t.Errorf("%s != %s", dt, test.expected)
+			}
+			if dt.Name() != test.name {
+				t.Errorf("%s != %s", dt.Name(), test.name)
+			}
+			v, err := NewDeviceTypeFromString(dt.DecimalString())
+			if err != nil || v != dt {
+				t.Errorf("%s != %s (%v)", v, dt, err)
+			}
+		})
+	}
+
+	if _, err := NewDeviceTypeFromString("0x100000000"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+	if OnOffLightDeviceType.String() != "0x0100 (On/Off Light)" {
+		t.Errorf("%s", OnOffLightDeviceType.String())
+	}
+
+	infos := DeviceTypes()
+	for n := 1; n < len(infos); n++ {
+		if infos[n].ID <= infos[n-1].ID {
+			t.Errorf("%s <= %s", infos[n].ID, infos[n-1].ID)
+		}
+	}
+
+	vendorDeviceType := DeviceType(0xFFF10001)
+	if err := RegisterDeviceType(&DeviceTypeInfo{ID: vendorDeviceType, Name: "", Revision: 1, Class: SimpleDeviceTypeClass, RequiredServerClusters: nil}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("%v is not %v", err, ErrInvalid)
+	}
+	if _, ok := LookupDeviceType(vendorDeviceType); ok {
+		t.Fatalf("%s is already registered", vendorDeviceType)
+	}
+	if err := RegisterDeviceType(&DeviceTypeInfo{ID: vendorDeviceType, Name: "Vendor Device", Revision: 1, Class: SimpleDeviceTypeClass, RequiredServerClusters: nil}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		UnregisterDeviceType(vendorDeviceType)
+	})
+	if vendorDeviceType.Name() != "Vendor Device" || len(DeviceTypes()) != len(infos)+1 {
+		t.Errorf("%s is not registered", vendorDeviceType)
+	}
+
+	UnregisterDeviceType(vendorDeviceType)
+	if _, ok := LookupDeviceType(vendorDeviceType); ok || len(DeviceTypes()) != len(infos) {
+		t.Errorf("%s is not unregistered", vendorDeviceType)
+	}
+
+	// The device types of the device library are restored when they are unregistered.
+
+	t.Cleanup(func() {
+		UnregisterDeviceType(OnOffLightDeviceType)
+	})
+	if err := RegisterDeviceType(&DeviceTypeInfo{ID: OnOffLightDeviceType, Name: "Vendor Light", Revision: 1, Class: SimpleDeviceTypeClass, RequiredServerClusters: nil}); err != nil {
+		t.Fatal(err)
+	}
+	if OnOffLightDeviceType.Name() != "Vendor Light" {
+		t.Errorf("%s is not replaced", OnOffLightDeviceType)
+	}
+	UnregisterDeviceType(OnOffLightDeviceType)
+	if OnOffLightDeviceType.Name() != "On/Off Light" {
+		t.Errorf("%s is not restored", OnOffLightDeviceType)
+	}
+}
+
+func TestVersion(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected Version
+		str      string
+	}{
+		{"1.3", NewVersion(1, 3, 0), "1.3.0"},
+		{"1.2.1", NewVersion(1, 2, 1), "1.2.1"},
+		{"1.4.0", 0x01040000, "1.4.0"},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			v, err := NewVersionFromString(test.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v != test.expected {
+				t.Errorf("0x%08X != 0x%08X", uint32(v), uint32(test.expected))
+			}
+			if v.String() != test.str {
+				t.Errorf("%s != %s", v, test.str)
+			}
+		})
+	}
+
+	for _, s := range []string{"", "1", "1.2.3.4", "1.x", "256.0"} {
+		if _, err := NewVersionFromString(s); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%q : %v is not %v", s, err, ErrInvalid)
+		}
+	}
+
+	v := NewVersion(1, 3, 0)
+	if !v.AtLeast(1, 2) || !v.AtLeast(1, 3) || v.AtLeast(1, 4) {
+		t.Errorf("%s", v)
+	}
+	if v.Compare(NewVersion(1, 3, 0)|0x01) != 0 || v.Compare(NewVersion(1, 2, 9)) != 1 || v.Compare(NewVersion(2, 0, 0)) != -1 {
+		t.Errorf("%s", v)
+	}
+	if !v.SupportsFeature(TCPFeature) || v.SupportsFeature(JointFabricFeature) {
+		t.Errorf("%s", v)
+	}
+	if UnknownVersion.AtLeast(0, 0) || UnknownVersion.SupportsFeature(SessionParametersFeature) || UnknownVersion.String() != "unknown" {
+		t.Errorf("%s", UnknownVersion)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// VendorID represents a vendor ID which is assigned by the Connectivity Standards Alliance.
+type VendorID uint16
+
+const (
+	// StandardVendorID represents the vendor ID of the Matter standard.
+	StandardVendorID VendorID = 0x0000
+	// TestVendorIDMin is the minimum test vendor ID.
+	TestVendorIDMin VendorID = 0xFFF1
+	// TestVendorIDMax is the maximum test vendor ID.
+	TestVendorIDMax VendorID = 0xFFF4
+)
+
+// NewVendorIDFromString returns a new vendor ID from a decimal or a 0x-prefixed hexadecimal string.
+func NewVendorIDFromString(s string) (VendorID, error) {
+	v, err := parseUint("vendor ID", s, 16)
+	if err != nil {
+		return 0, err
+	}
+	return VendorID(v), nil
+}
+
+// IsTest returns true if the vendor ID is reserved for tests.
+func (vid VendorID) IsTest() bool {
+	return TestVendorIDMin <= vid && vid <= TestVendorIDMax
+}
+
+// DecimalString returns the decimal representation which is used in DNS-SD TXT records.
+func (vid VendorID) DecimalString() string {
+	return strconv.FormatUint(uint64(vid), 10)
+}
+
+// String returns the hexadecimal representation such as 0xFFF1.
+func (vid VendorID) String() string {
+	return fmt.Sprintf("0x%04X", uint16(vid))
+}
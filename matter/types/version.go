@@ -0,0 +1,141 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version represents a specification version (SpecificationVersion) which is encoded as
+// the major, minor and dot versions in the upper three bytes and a reserved zero byte.
+// The zero version means that the version is unknown, as older nodes do not report it.
+type Version uint32
+
+const (
+	// UnknownVersion represents a version which is not reported.
+	UnknownVersion Version = 0
+)
+
+// NewVersion returns a new version of the specified major, minor and dot versions.
+func NewVersion(major uint8, minor uint8, dot uint8) Version {
+	return Version(uint32(major)<<24 | uint32(minor)<<16 | uint32(dot)<<8)
+}
+
+// NewVersionFromString returns a new version from a string such as 1.3 or 1.3.0.
+func NewVersionFromString(s string) (Version, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) < 2 || 3 < len(parts) {
+		return UnknownVersion, fmt.Errorf("%w version : %s", ErrInvalid, s)
+	}
+	nums := [3]uint8{}
+	for n, part := range parts {
+		v, err := strconv.ParseUint(part, 10, 8)
+		if err != nil {
+			return UnknownVersion, fmt.Errorf("%w version : %s", ErrInvalid, s)
+		}
+		nums[n] = uint8(v)
+	}
+	return NewVersion(nums[0], nums[1], nums[2]), nil
+}
+
+// Major returns the major version.
+func (v Version) Major() uint8 {
+	return uint8(v >> 24)
+}
+
+// Minor returns the minor version.
+func (v Version) Minor() uint8 {
+	return uint8(v >> 16)
+}
+
+// Dot returns the dot version.
+func (v Version) Dot() uint8 {
+	return uint8(v >> 8)
+}
+
+// IsKnown returns true if the version is reported.
+func (v Version) IsKnown() bool {
+	return v != UnknownVersion
+}
+
+// Compare returns -1, 0 or 1 if the version is older than, equal to or newer than the specified version.
+// The reserved byte is ignored.
+func (v Version) Compare(other Version) int {
+	a := v &^ 0xFF
+	b := other &^ 0xFF
+	switch {
+	case a < b:
+		return -1
+	case b < a:
+		return 1
+	}
+	return 0
+}
+
+// AtLeast returns true if the version is the specified major and minor version or newer.
+// Unknown versions are older than any version.
+func (v Version) AtLeast(major uint8, minor uint8) bool {
+	if !v.IsKnown() {
+		return false
+	}
+	return 0 <= v.Compare(NewVersion(major, minor, 0))
+}
+
+// SupportsFeature returns true if the version implements the specified feature.
+func (v Version) SupportsFeature(feature VersionFeature) bool {
+	min, ok := versionFeatures[feature]
+	if !ok {
+		return false
+	}
+	return v.IsKnown() && 0 <= v.Compare(min)
+}
+
+// String returns the representation such as 1.3.0, or unknown.
+func (v Version) String() string {
+	if !v.IsKnown() {
+		return "unknown"
+	}
+	return fmt.Sprintf("%d.%d.%d", v.Major(), v.Minor(), v.Dot())
+}
+
+// VersionFeature represents a feature which is introduced in a specification version.
+type VersionFeature int
+
+const (
+	// SessionParametersFeature represents the revisions and the limits in the session parameters.
+	SessionParametersFeature VersionFeature = iota
+	// TCPFeature represents the TCP transport.
+	TCPFeature
+	// MultiplePathsPerInvokeFeature represents invoke requests of multiple commands.
+	MultiplePathsPerInvokeFeature
+	// LongIdleTimeICDFeature represents the long idle time mode of intermittently connected devices.
+	LongIdleTimeICDFeature
+	// JointFabricFeature represents the joint fabric.
+	JointFabricFeature
+	// FabricSynchronizationFeature represents the fabric synchronization with the Commissioner Control cluster.
+	FabricSynchronizationFeature
+)
+
+// versionFeatures is the first versions which introduce the features.
+var versionFeatures = map[VersionFeature]Version{
+	SessionParametersFeature:      NewVersion(1, 2, 0),
+	TCPFeature:                    NewVersion(1, 3, 0),
+	MultiplePathsPerInvokeFeature: NewVersion(1, 3, 0),
+	LongIdleTimeICDFeature:        NewVersion(1, 3, 0),
+	JointFabricFeature:            NewVersion(1, 4, 0),
+	FabricSynchronizationFeature:  NewVersion(1, 4, 0),
+}
@@ -15,7 +15,7 @@
 package matter
 
 import (
-	"github.com/cybergarage/go-matter/matter/protocol"
+	"github.com/cybergarage/go-matter/internal/protocol"
 )
 
 // VendorID represents a vendor ID.
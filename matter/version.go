@@ -0,0 +1,80 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// Version represents a specification version.
+type Version = types.Version
+
+// VersionFeature represents a feature which is introduced in a specification version.
+type VersionFeature = types.VersionFeature
+
+const (
+	SessionParametersFeature      = types.SessionParametersFeature
+	TCPFeature                    = types.TCPFeature
+	MultiplePathsPerInvokeFeature = types.MultiplePathsPerInvokeFeature
+	LongIdleTimeICDFeature        = types.LongIdleTimeICDFeature
+	JointFabricFeature            = types.JointFabricFeature
+	FabricSynchronizationFeature  = types.FabricSynchronizationFeature
+)
+
+// SpecificationVersionPath is the SpecificationVersion attribute of the Basic Information cluster.
+var SpecificationVersionPath = im.NewAttributePath(0, im.ClusterBasicInformation, im.AttributeBasicInformationSpecificationVersion)
+
+// SpecificationVersion returns the specification version of the node. The version in the session
+// parameters is used if the node sent it, and otherwise it is read from the Basic Information cluster
+// and kept in the session parameters. Nodes implementing older revisions report neither, and their
+// version is unknown.
+func (dev *OperationalDevice) SpecificationVersion(ctx context.Context) (Version, error) {
+	if v := dev.SessionParams().Version(); v.IsKnown() {
+		return v, nil
+	}
+	data, err := dev.ReadAttribute(ctx, SpecificationVersionPath)
+	if err != nil {
+		if errors.Is(err, im.StatusUnsupportedAttribute) {
+			return types.UnknownVersion, nil
+		}
+		return types.UnknownVersion, err
+	}
+	if len(data) == 0 {
+		return types.UnknownVersion, nil
+	}
+	var v datatype.Uint32
+	if err := datatype.Decode(data[0].Data, &v); err != nil {
+		return types.UnknownVersion, err
+	}
+	dev.Lock()
+	dev.params.SpecificationVersion = uint32(v)
+	dev.Unlock()
+	return Version(v), nil
+}
+
+// SupportsFeature returns true if the specification version of the node implements the specified feature.
+// Features are not supported if the version is unknown.
+func (dev *OperationalDevice) SupportsFeature(ctx context.Context, feature VersionFeature) (bool, error) {
+	v, err := dev.SpecificationVersion(ctx)
+	if err != nil {
+		return false, err
+	}
+	return v.SupportsFeature(feature), nil
+}
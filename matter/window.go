@@ -0,0 +1,177 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// 11.18.5.1. CommissioningWindowStatusEnum
+// CommissioningWindowStatus represents the status of a commissioning window.
+type CommissioningWindowStatus uint8
+
+const (
+	// WindowNotOpen indicates that the commissioning window is not open.
+	WindowNotOpen CommissioningWindowStatus = 0
+	// EnhancedWindowOpen indicates that the window was opened with a new onboarding passcode.
+	EnhancedWindowOpen CommissioningWindowStatus = 1
+	// BasicWindowOpen indicates that the window was opened with the original onboarding passcode.
+	BasicWindowOpen CommissioningWindowStatus = 2
+)
+
+const (
+	// MinCommissioningTimeout is the minimum duration of commissioning windows (5.4.2.3).
+	MinCommissioningTimeout = 3 * time.Minute
+	// MaxCommissioningTimeout is the maximum duration of commissioning windows opened by administrators (11.18.8.1).
+	MaxCommissioningTimeout = 15 * time.Minute
+)
+
+// String returns the string representation.
+func (status CommissioningWindowStatus) String() string {
+	switch status {
+	case WindowNotOpen:
+		return "WindowNotOpen"
+	case EnhancedWindowOpen:
+		return "EnhancedWindowOpen"
+	case BasicWindowOpen:
+		return "BasicWindowOpen"
+	}
+	return fmt.Sprintf("CommissioningWindowStatus(%d)", uint8(status))
+}
+
+// 4.3.1.7. TXT key for commissioning mode (CM)
+// CommissioningMode returns the value of the CM key which is advertised in the status.
+func (status CommissioningWindowStatus) CommissioningMode() string {
+	switch status {
+	case BasicWindowOpen:
+		return CommissioningMode1
+	case EnhancedWindowOpen:
+		return CommissioningMode2
+	}
+	return CommissioningModeNone
+}
+
+// CommissioningWindowListener represents a listener which is called after a commissioning window
+// is opened, closed or expired.
+type CommissioningWindowListener func(status CommissioningWindowStatus)
+
+// CommissioningWindow represents the commissioning window of a commissionable node,
+// which closes itself when the timeout expires.
+type CommissioningWindow struct {
+	sync.Mutex
+	status     CommissioningWindowStatus
+	deadline   time.Time
+	timer      *time.Timer
+	generation uint64
+	listeners  []CommissioningWindowListener
+}
+
+// NewCommissioningWindow returns a new closed commissioning window.
+func NewCommissioningWindow() *CommissioningWindow {
+	return &CommissioningWindow{
+		Mutex:      sync.Mutex{},
+		status:     WindowNotOpen,
+		deadline:   time.Time{},
+		timer:      nil,
+		generation: 0,
+		listeners:  []CommissioningWindowListener{},
+	}
+}
+
+// AddListener adds the specified listener of the window status changes.
+func (window *CommissioningWindow) AddListener(l CommissioningWindowListener) {
+	window.Lock()
+	defer window.Unlock()
+	window.listeners = append(window.listeners, l)
+}
+
+// Open opens the window with the specified status until the timeout expires. The timeout should
+// be at least MinCommissioningTimeout, and must not exceed MaxCommissioningTimeout.
+// ErrBusy is returned if the window is already open.
+func (window *CommissioningWindow) Open(status CommissioningWindowStatus, timeout time.Duration) error {
+	if status != BasicWindowOpen && status != EnhancedWindowOpen {
+		return fmt.Errorf("%w commissioning window status: %s", ErrInvalid, status)
+	}
+	if timeout <= 0 || MaxCommissioningTimeout < timeout {
+		return fmt.Errorf("%w commissioning timeout: %s", ErrInvalid, timeout)
+	}
+	window.Lock()
+	if window.status != WindowNotOpen {
+		window.Unlock()
+		return fmt.Errorf("commissioning window (%s) is %w", window.status, ErrBusy)
+	}
+	window.generation++
+	generation := window.generation
+	window.status = status
+	window.deadline = time.Now().Add(timeout)
+	window.timer = time.AfterFunc(timeout, func() {
+		window.expire(generation)
+	})
+	listeners := append([]CommissioningWindowListener{}, window.listeners...)
+	window.Unlock()
+
+	for _, l := range listeners {
+		l(status)
+	}
+	return nil
+}
+
+// Close closes the window, such as when commissioning completes. Closing a closed window does nothing.
+func (window *CommissioningWindow) Close() {
+	window.expire(0)
+}
+
+// expire closes the window if the window is still opened in the specified generation,
+// or regardless of the generation if it is zero.
+func (window *CommissioningWindow) expire(generation uint64) {
+	window.Lock()
+	if window.status == WindowNotOpen || (generation != 0 && generation != window.generation) {
+		window.Unlock()
+		return
+	}
+	if window.timer != nil {
+		window.timer.Stop()
+	}
+	window.status = WindowNotOpen
+	window.deadline = time.Time{}
+	window.timer = nil
+	listeners := append([]CommissioningWindowListener{}, window.listeners...)
+	window.Unlock()
+
+	for _, l := range listeners {
+		l(WindowNotOpen)
+	}
+}
+
+// Status returns the current status.
+func (window *CommissioningWindow) Status() CommissioningWindowStatus {
+	window.Lock()
+	defer window.Unlock()
+	return window.status
+}
+
+// IsOpen returns true if the window is open.
+func (window *CommissioningWindow) IsOpen() bool {
+	return window.Status() != WindowNotOpen
+}
+
+// Deadline returns the time when the open window expires.
+func (window *CommissioningWindow) Deadline() (time.Time, bool) {
+	window.Lock()
+	defer window.Unlock()
+	return window.deadline, window.status != WindowNotOpen
+}
@@ -0,0 +1,185 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-mdns/mdns"
+	"github.com/cybergarage/go-mdns/mdns/dns"
+)
+
+func TestCommissioningWindow(t *testing.T) {
+	window := matter.NewCommissioningWindow()
+	statuses := make(chan matter.CommissioningWindowStatus, 4)
+	window.AddListener(func(status matter.CommissioningWindowStatus) {
+		statuses <- status
+	})
+
+	if err := window.Open(matter.WindowNotOpen, time.Second); !errors.Is(err, matter.ErrInvalid) {
+		t.Errorf("%v is not %v", err, matter.ErrInvalid)
+	}
+	if err := window.Open(matter.BasicWindowOpen, matter.MaxCommissioningTimeout+time.Second); !errors.Is(err, matter.ErrInvalid) {
+		t.Errorf("%v is not %v", err, matter.ErrInvalid)
+	}
+
+	if err := window.Open(matter.EnhancedWindowOpen, 50*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := window.Open(matter.BasicWindowOpen, time.Second); !errors.Is(err, matter.ErrBusy) {
+		t.Errorf("%v is not %v", err, matter.ErrBusy)
+	}
+	if _, ok := window.Deadline(); !ok || window.Status() != matter.EnhancedWindowOpen {
+		t.Errorf("%s", window.Status())
+	}
+
+	// The window closes itself when the timeout expires.
+	for _, expected := range []matter.CommissioningWindowStatus{matter.EnhancedWindowOpen, matter.WindowNotOpen} {
+		select {
+		case status := <-statuses:
+			if status != expected {
+				t.Errorf("%s != %s", status, expected)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s is not notified", expected)
+		}
+	}
+	if window.IsOpen() {
+		t.Error("window is open")
+	}
+
+	if err := window.Open(matter.BasicWindowOpen, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	window.Close()
+	window.Close()
+	if window.IsOpen() || len(statuses) != 2 {
+		t.Errorf("%s (%d notifications)", window.Status(), len(statuses))
+	}
+}
+
+func TestCommissionableAdvertiser(t *testing.T) {
+	newQuery := func(service string) *dns.Message {
+		t.Helper()
+		msg, err := dns.NewMessageWithBytes(mdns.NewRequestWithQuery(mdns.NewQueryWithService(service)).Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return msg
+	}
+	respond := func(adv *matter.CommissionableAdvertiser, query *dns.Message) (*matter.Commissionee, bool) {
+		t.Helper()
+		res, ok := adv.Respond(query)
+		if !ok {
+			return nil, false
+		}
+		msg, err := dns.NewMessageWithBytes(res.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		com, err := matter.NewCommissioneeWithMessage(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return com, true
+	}
+
+	window := matter.NewCommissioningWindow()
+	adv := matter.NewCommissionableAdvertiser(window,
+		matter.WithAdvertisedInstanceName("DD200C20D25AE5F7"),
+		matter.WithAdvertisedHost("E45F010F27530000.local", net.ParseIP("fd00::10"), net.ParseIP("192.168.1.10")),
+		matter.WithAdvertisedDiscriminator(3840),
+		matter.WithAdvertisedVendorProduct(0xFFF1, 0x8000))
+
+	// Queries are not answered while the window is not open.
+	query := newQuery(matter.CommissionableServiceType.String())
+	if _, ok := respond(adv, query); ok || adv.IsAdvertising() {
+		t.Error("closed window is answered")
+	}
+
+	if err := window.Open(matter.BasicWindowOpen, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	defer window.Close()
+	for _, service := range []string{matter.CommissionableServiceType.String(), "_L3840._sub._matterc._udp", "_CM._sub._matterc._udp"} {
+		com, ok := respond(adv, newQuery(service))
+		if !ok {
+			t.Fatalf("%s is not answered", service)
+		}
+		if mode, ok := com.LookupCommissioningMode(); !ok || mode != matter.CommissioningMode1 {
+			t.Errorf("CM (%s) != %s", mode, matter.CommissioningMode1)
+		}
+		if _, ok := com.LookupSubtype(matter.SubtypeCommissioningMode); !ok {
+			t.Errorf("%s subtype is not advertised", matter.SubtypeCommissioningMode)
+		}
+		if d, ok := com.LookupDiscriminator(); !ok || d != 3840 {
+			t.Errorf("discriminator (%d) != 3840", d)
+		}
+		if vid, pid, ok := com.LookupVendorProductID(); !ok || vid != 0xFFF1 || pid != 0x8000 {
+			t.Errorf("%d+%d", vid, pid)
+		}
+		if com.Port != 5540 || com.AddrV6 == nil || com.AddrV4 == nil {
+			t.Errorf("%s", com.String())
+		}
+	}
+	if _, ok := respond(adv, newQuery(matter.OperationalServiceType.String())); ok {
+		t.Error("operational service is answered")
+	}
+
+	// Closing the window removes the advertisement.
+	window.Close()
+	if _, ok := respond(adv, query); ok {
+		t.Error("closed window is answered")
+	}
+	for _, rr := range adv.Announcement(matter.WindowNotOpen).Answers {
+		if rr.TTL() != 0 {
+			t.Errorf("%s TTL (%d) != 0", rr.Name(), rr.TTL())
+		}
+	}
+}
+
+func TestCommissionableAdvertiserExtendedDiscovery(t *testing.T) {
+	window := matter.NewCommissioningWindow()
+	adv := matter.NewCommissionableAdvertiser(window,
+		matter.WithAdvertisedDiscriminator(3840),
+		matter.WithExtendedDiscovery(true))
+
+	query, err := dns.NewMessageWithBytes(mdns.NewRequestWithQuery(mdns.NewQueryWithService(matter.CommissionableServiceType.String())).Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, ok := adv.Respond(query)
+	if !ok {
+		t.Fatal("extended discovery is not answered")
+	}
+	msg, err := dns.NewMessageWithBytes(res.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	com, err := matter.NewCommissioneeWithMessage(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode, ok := com.LookupCommissioningMode(); !ok || mode != matter.CommissioningModeNone {
+		t.Errorf("CM (%s) != %s", mode, matter.CommissioningModeNone)
+	}
+	if _, ok := com.LookupSubtype(matter.SubtypeCommissioningMode); ok {
+		t.Errorf("%s subtype is advertised", matter.SubtypeCommissioningMode)
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter"
+)
+
+func TestAPI(t *testing.T) {
+	onboarding, err := matter.NewOnboardingPayloadFromString("MT:Y.K9042C00KA0648G00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if onboarding.Discriminator() != 3840 || onboarding.Passcode() != 20202021 {
+		t.Errorf("%s", onboarding)
+	}
+	if code, err := onboarding.QRCode(); err != nil || code != "MT:Y.K9042C00KA0648G00" {
+		t.Errorf("%s (%v)", code, err)
+	}
+
+	ctrl := matter.NewController(matter.WithReconnectPolicy(matter.DefaultReconnectPolicy()))
+	var dev *matter.Device = ctrl.OperationalDevice(matter.OperationalPeer{FabricID: 1, CompressedFabricID: 0x87E1B004E235A130, NodeID: 0x0000000000000001})
+	if dev.Peer().NodeID != 1 {
+		t.Errorf("%s", dev.Peer())
+	}
+	var s matter.Session = dev
+	if err := s.Close(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	bridge "github.com/cybergarage/go-matter/matter/bridge/http"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+func doBridgeRequest(t *testing.T, srv *httptest.Server, method string, path string, req any, code int, res any) {
+	t.Helper()
+	var body bytes.Buffer
+	if req != nil {
+		if err := json.NewEncoder(&body).Encode(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r, err := http.NewRequest(method, srv.URL+path, &body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := srv.Client().Do(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != code {
+		var e bridge.Error
+		json.NewDecoder(resp.Body).Decode(&e)
+		t.Fatalf("%s %s : status (%d) != (%d) : %s", method, path, resp.StatusCode, code, e.Error)
+	}
+	if res != nil {
+		if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestBridge(t *testing.T) {
+	est := &testSessionEstablisher{sessions: nil, err: nil}
+	ctrl := matter.NewController(matter.WithSessionEstablisher(est))
+	b := bridge.NewServer(ctrl, bridge.WithTimeout(time.Second))
+	defer b.Close()
+	srv := httptest.NewServer(b)
+	defer srv.Close()
+
+	// The onboarding code is resolved, and no node is discovered yet.
+
+	var onboarding bridge.OnboardingResponse
+	doBridgeRequest(t, srv, http.MethodPost, "/onboarding/resolve", bridge.OnboardingRequest{Code: "MT:Y.K9042C00KA0648G00"}, http.StatusOK, &onboarding)
+	if onboarding.Discriminator != 3840 || len(onboarding.Matches) != 0 {
+		t.Errorf("onboarding (%+v)", onboarding)
+	}
+	doBridgeRequest(t, srv, http.MethodPost, "/onboarding/resolve", bridge.OnboardingRequest{Code: "MT:invalid"}, http.StatusBadRequest, nil)
+	doBridgeRequest(t, srv, http.MethodPost, "/onboarding/resolve", bridge.OnboardingRequest{Code: strings.Repeat("0", bridge.MaxRequestSize)}, http.StatusRequestEntityTooLarge, nil)
+
+	// Commissioned nodes are registered with the operational instance name.
+
+	var node bridge.Node
+	doBridgeRequest(t, srv, http.MethodPut, "/nodes/87E1B004E235A130-1234", bridge.NodeRequest{FabricID: 1, Address: "[::1]:5540"}, http.StatusOK, &node)
+	if node.Name != "87E1B004E235A130-0000000000001234" || node.NodeID != 0x1234 || node.Address != "[::1]:5540" {
+		t.Errorf("node (%+v)", node)
+	}
+	nodePath := "/nodes/" + node.Name
+	var nodes []bridge.Node
+	doBridgeRequest(t, srv, http.MethodGet, "/nodes", nil, http.StatusOK, &nodes)
+	if len(nodes) != 1 || nodes[0] != node {
+		t.Errorf("nodes (%+v)", nodes)
+	}
+	doBridgeRequest(t, srv, http.MethodPut, "/nodes/invalid", bridge.NodeRequest{FabricID: 1, Address: ""}, http.StatusBadRequest, nil)
+
+	// Attributes are written in JSON and read back.
+
+	doBridgeRequest(t, srv, http.MethodPost, nodePath+"/write", bridge.WriteRequest{Path: "1/OnOff/OnTime", Value: json.RawMessage("300"), TLV: ""}, http.StatusNoContent, nil)
+	var read bridge.ReadResponse
+	doBridgeRequest(t, srv, http.MethodPost, nodePath+"/read", bridge.ReadRequest{Path: "1/OnOff/OnTime"}, http.StatusOK, &read)
+	if len(read.Attributes) != 1 || read.Attributes[0].Path != "1/0x0006/0x4001" || read.Attributes[0].Value != float64(300) || read.Attributes[0].TLV != "052c01" {
+		t.Errorf("read (%+v)", read)
+	}
+	doBridgeRequest(t, srv, http.MethodPost, nodePath+"/write", bridge.WriteRequest{Path: "1/OnOff/OnTime", Value: json.RawMessage(`"on"`), TLV: ""}, http.StatusBadRequest, nil)
+	doBridgeRequest(t, srv, http.MethodPost, nodePath+"/write", bridge.WriteRequest{Path: "1/OnOff/*", Value: json.RawMessage("1"), TLV: ""}, http.StatusBadRequest, nil)
+
+	// Commands are invoked with JSON fields, and the test node echoes them as the response.
+
+	var invoke bridge.InvokeResponse
+	fields := json.RawMessage(`{"onOffControl": 1, "onTime": 10, "offWaitTime": 20}`)
+	doBridgeRequest(t, srv, http.MethodPost, nodePath+"/invoke", bridge.InvokeRequest{Path: "1/OnOff/OnWithTimedOff", Fields: fields, TLV: ""}, http.StatusOK, &invoke)
+	obj, ok := invoke.Value.(map[string]any)
+	if !ok || obj["0"] != float64(1) || obj["1"] != float64(10) || obj["2"] != float64(20) {
+		t.Errorf("invoke (%+v)", invoke)
+	}
+	doBridgeRequest(t, srv, http.MethodPost, nodePath+"/invoke", bridge.InvokeRequest{Path: "1/0x0006/0x0002", Fields: nil, TLV: "1518"}, http.StatusOK, &invoke)
+	if invoke.TLV != "1518" {
+		t.Errorf("invoke (%+v)", invoke)
+	}
+	doBridgeRequest(t, srv, http.MethodPost, nodePath+"/invoke", bridge.InvokeRequest{Path: "1/0xFFF1/0x0001", Fields: json.RawMessage("{}"), TLV: ""}, http.StatusNotFound, nil)
+
+	// Statuses of the node are returned with the error.
+
+	est.sessions[0].fail(im.StatusUnsupportedAttribute)
+	res := &bridge.Error{Error: "", Status: ""}
+	doBridgeRequest(t, srv, http.MethodPost, nodePath+"/read", bridge.ReadRequest{Path: "1/OnOff/OnOff"}, http.StatusBadGateway, res)
+	if res.Status != "UNSUPPORTED_ATTRIBUTE" {
+		t.Errorf("error (%+v)", res)
+	}
+
+	// Unregistered nodes are not found.
+
+	doBridgeRequest(t, srv, http.MethodDelete, nodePath, nil, http.StatusNoContent, nil)
+	doBridgeRequest(t, srv, http.MethodPost, nodePath+"/read", bridge.ReadRequest{Path: "1/OnOff/OnOff"}, http.StatusNotFound, nil)
+	doBridgeRequest(t, srv, http.MethodDelete, nodePath, nil, http.StatusNotFound, nil)
+}
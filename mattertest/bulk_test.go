@@ -0,0 +1,138 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// testBulkSession represents a session which records the number of concurrent operations.
+type testBulkSession struct {
+	*testOperationalSession
+	est *testBulkEstablisher
+}
+
+func (s *testBulkSession) InvokeCommand(ctx context.Context, path im.CommandPath, fields []byte) ([]byte, error) {
+	s.est.enter()
+	defer s.est.leave()
+	if s.peer.NodeID%10 == 0 {
+		return nil, im.StatusUnsupportedCommand
+	}
+	return s.testOperationalSession.InvokeCommand(ctx, path, fields)
+}
+
+type testBulkEstablisher struct {
+	sync.Mutex
+	running    int
+	maxRunning int
+}
+
+func (est *testBulkEstablisher) enter() {
+	est.Lock()
+	est.running++
+	est.maxRunning = max(est.maxRunning, est.running)
+	est.Unlock()
+	time.Sleep(time.Millisecond)
+}
+
+func (est *testBulkEstablisher) leave() {
+	est.Lock()
+	defer est.Unlock()
+	est.running--
+}
+
+func (est *testBulkEstablisher) EstablishSession(ctx context.Context, peer matter.OperationalPeer) (matter.OperationalSession, error) {
+	return &testBulkSession{
+		testOperationalSession: &testOperationalSession{
+			Mutex:   sync.Mutex{},
+			peer:    peer,
+			err:     nil,
+			closed:  false,
+			written: map[im.AttributePath][]byte{},
+			reads:   []im.AttributePath{},
+		},
+		est: est,
+	}, nil
+}
+
+func TestBulkOperations(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	const parallelism = 4
+	est := &testBulkEstablisher{Mutex: sync.Mutex{}, running: 0, maxRunning: 0}
+	com := matter.NewCommissioner(
+		matter.WithSessionEstablisher(est),
+		matter.WithBulkParallelism(parallelism),
+	)
+	devs := []*matter.OperationalDevice{}
+	for n := 1; n <= 30; n++ {
+		devs = append(devs, com.OperationalDevice(matter.OperationalPeer{
+			FabricID:           1,
+			CompressedFabricID: 1,
+			NodeID:             matter.NodeID(n),
+			Address:            netip.MustParseAddrPort("[::1]:5540"),
+		}))
+	}
+
+	// Every tenth node fails, and the others succeed.
+	results, err := com.InvokeOnNodes(ctx, devs, im.NewCommandPath(1, 0x0006, 0x0000), []byte{0x15, 0x18})
+	if !errors.Is(err, im.StatusUnsupportedCommand) {
+		t.Errorf("%v is not %v", err, im.StatusUnsupportedCommand)
+	}
+	if len(results) != len(devs) {
+		t.Fatalf("results (%d) != (%d)", len(results), len(devs))
+	}
+	for i, res := range results {
+		if res.Device != devs[i] {
+			t.Errorf("result (%d) is not in order", i)
+		}
+		failed := res.Device.NodeID()%10 == 0
+		if failed != (res.Err != nil) {
+			t.Errorf("node (%d) : %v", res.Device.NodeID(), res.Err)
+		}
+		if !failed && len(res.Response) != 2 {
+			t.Errorf("node (%d) : response (%X)", res.Device.NodeID(), res.Response)
+		}
+	}
+	if est.maxRunning < 2 || parallelism < est.maxRunning {
+		t.Errorf("concurrency (%d) is not in (2, %d)", est.maxRunning, parallelism)
+	}
+
+	readResults, err := com.ReadFromNodes(ctx, devs, im.NewAttributePath(1, 0x0006, 0x0000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, res := range readResults {
+		if len(res.Data) != 1 {
+			t.Errorf("node (%d) : data (%v)", res.Device.NodeID(), res.Data)
+		}
+	}
+
+	// Nodes fail with the context error after the context is done.
+	canceled, cancelNow := context.WithCancel(ctx)
+	cancelNow()
+	if _, err := com.ReadFromNodes(canceled, devs, im.NewAttributePath(1, 0x0006, 0x0000)); !errors.Is(err, context.Canceled) {
+		t.Errorf("%v is not %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"context"
+	"net/netip"
+	"sync"
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// testFilteredSession represents a session to a node of versioned clusters which honors data version filters.
+type testFilteredSession struct {
+	*testSubscribeSession
+	versions map[im.ClusterID]im.DataVersion
+	values   map[im.AttributePath][]byte
+	filtered [][]im.DataVersionFilter
+}
+
+func (s *testFilteredSession) set(path im.AttributePath, b []byte) im.AttributeData {
+	s.versions[path.Cluster]++
+	s.values[path] = b
+	return im.AttributeData{Path: path, DataVersion: s.versions[path.Cluster], Data: b}
+}
+
+func (s *testFilteredSession) ReadAttribute(ctx context.Context, path im.AttributePath) ([]im.AttributeData, error) {
+	return s.ReadAttributeFiltered(ctx, path, nil)
+}
+
+func (s *testFilteredSession) ReadAttributeFiltered(ctx context.Context, path im.AttributePath, filters []im.DataVersionFilter) ([]im.AttributeData, error) {
+	s.reads = append(s.reads, path)
+	s.filtered = append(s.filtered, filters)
+	data := []im.AttributeData{}
+	for p, b := range s.values {
+		if !path.Match(p) {
+			continue
+		}
+		skipped := false
+		for _, f := range filters {
+			if f.Endpoint == p.Endpoint && f.Cluster == p.Cluster && f.DataVersion == s.versions[p.Cluster] {
+				skipped = true
+			}
+		}
+		if !skipped {
+			data = append(data, im.AttributeData{Path: p, DataVersion: s.versions[p.Cluster], Data: b})
+		}
+	}
+	return data, nil
+}
+
+func TestAttributeCache(t *testing.T) {
+	ctx := context.Background()
+	s := &testFilteredSession{
+		testSubscribeSession: &testSubscribeSession{
+			testOperationalSession: &testOperationalSession{
+				Mutex:   sync.Mutex{},
+				peer:    matter.OperationalPeer{},
+				err:     nil,
+				closed:  false,
+				written: map[im.AttributePath][]byte{},
+				reads:   []im.AttributePath{},
+			},
+			subs: nil,
+		},
+		versions: map[im.ClusterID]im.DataVersion{},
+		values:   map[im.AttributePath][]byte{},
+		filtered: nil,
+	}
+	peer := matter.OperationalPeer{FabricID: 1, CompressedFabricID: 1, NodeID: 1, Address: netip.MustParseAddrPort("[::1]:5540")}
+	dev := matter.NewOperationalDevice(peer, &testFilteredEstablisher{session: s})
+
+	onOff := im.NewAttributePath(1, 0x0006, 0x0000)
+	onTime := im.NewAttributePath(1, 0x0006, 0x4001)
+	level := im.NewAttributePath(1, 0x0008, 0x0000)
+	s.set(onOff, []byte{0x08})
+	s.set(onTime, []byte{0x24, 0x00})
+	s.set(level, []byte{0x24, 0x10})
+
+	cache := matter.NewAttributeCache()
+	wildcard := im.NewAttributePath(1, im.WildcardClusterID, im.WildcardAttributeID)
+	data, err := cache.Read(ctx, dev, wildcard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 3 || len(s.filtered[0]) != 0 {
+		t.Fatalf("%v (%v)", data, s.filtered[0])
+	}
+
+	// The second wildcard read sends the data versions, and the unchanged clusters are served from the cache.
+	s.set(level, []byte{0x24, 0x20})
+	data, err = cache.Read(ctx, dev, wildcard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.filtered[1]) != 2 {
+		t.Errorf("filters (%v)", s.filtered[1])
+	}
+	if len(data) != 3 {
+		t.Fatalf("%v", data)
+	}
+	if d, ok := cache.Lookup(dev.NodeID(), level); !ok || d.Data[1] != 0x20 || d.DataVersion != 2 {
+		t.Errorf("%v", d)
+	}
+
+	// Concrete reads are sent unless a subscription keeps the attribute fresh.
+	reads := len(s.reads)
+	if _, err := cache.Read(ctx, dev, onOff); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.reads) != reads+1 {
+		t.Errorf("reads (%d) != (%d)", len(s.reads), reads+1)
+	}
+
+	var consumer testConsumer
+	onOffCluster := im.NewAttributePath(1, 0x0006, im.WildcardAttributeID)
+	sub, err := cache.Subscribe(ctx, dev, im.SubscribeRequest{Paths: []im.AttributePath{onOffCluster}}, consumer.handle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.subs[0].handler([]im.AttributeData{s.set(onOff, []byte{0x09})})
+	reads = len(s.reads)
+	data, err = cache.Read(ctx, dev, onOff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.reads) != reads || len(data) != 1 || data[0].Data[0] != 0x09 {
+		t.Errorf("%v (reads %d)", data, len(s.reads)-reads)
+	}
+	// Attributes kept fresh by the subscription survive the data version change.
+	if _, ok := cache.Lookup(dev.NodeID(), onTime); !ok {
+		t.Error("subscribed attribute is dropped")
+	}
+	if len(consumer.reset()) != 1 {
+		t.Error("report is not passed to the handler")
+	}
+
+	if err := sub.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.Read(ctx, dev, onOff); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.reads) != reads+1 {
+		t.Errorf("reads (%d) != (%d)", len(s.reads), reads+1)
+	}
+
+	cache.Invalidate(dev.NodeID())
+	if filters := cache.DataVersionFilters(dev.NodeID(), wildcard); len(filters) != 0 {
+		t.Errorf("%v", filters)
+	}
+}
+
+type testFilteredEstablisher struct {
+	session *testFilteredSession
+}
+
+func (est *testFilteredEstablisher) EstablishSession(ctx context.Context, peer matter.OperationalPeer) (matter.OperationalSession, error) {
+	return est.session, nil
+}
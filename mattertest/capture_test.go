@@ -0,0 +1,229 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/internal/protocol"
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+	"github.com/cybergarage/go-matter/mattertest/fixture"
+)
+
+// TestCaptureDecoders decodes the synthesized captures, which were built from the specification
+// layouts, so it checks the decoders against the specification as they read it, not against devices.
+func TestCaptureDecoders(t *testing.T) {
+	type expectedMessage struct {
+		sessionID message.SessionID
+		counter   message.Counter
+		srcNodeID message.NodeID
+		dstNodeID message.NodeID
+	}
+	type expectedProtocol struct {
+		flag       protocol.ExchangeFlag
+		opcode     protocol.Opcode
+		exchangeID protocol.ExchangeID
+		protocolID protocol.ProtocolID
+		ackCounter message.Counter
+	}
+	type expectedElement struct {
+		path  []uint8
+		value any
+	}
+	tests := []struct {
+		name     string
+		message  *expectedMessage
+		protocol expectedProtocol
+		elements []expectedElement
+	}{
+		{
+			"pase-pbkdfparamrequest",
+			&expectedMessage{0x0000, 0x0A1B2C3D, 0x1122334455667788, 0},
+			expectedProtocol{protocol.InitiatorFlag | protocol.ReliabilityFlag, 0x20, 0x4DE1, 0x0000, 0},
+			[]expectedElement{
+				{[]uint8{2}, uint64(0x3A5C)},
+				{[]uint8{3}, uint64(0)},
+				{[]uint8{4}, false},
+			},
+		},
+		{
+			"pase-pbkdfparamresponse",
+			&expectedMessage{0x0000, 0x5E6F7081, 0, 0x1122334455667788},
+			expectedProtocol{protocol.AcknowledgementFlag | protocol.ReliabilityFlag, 0x21, 0x4DE1, 0x0000, 0x0A1B2C3D},
+			[]expectedElement{
+				{[]uint8{3}, uint64(0x8F21)},
+				{[]uint8{4, 1}, uint64(1000)},
+				{[]uint8{4, 2}, []byte("SPAKE2P Key Salt")},
+			},
+		},
+		{
+			"pase-pbkdfparamresponse-iterations32",
+			&expectedMessage{0x0000, 0x5E6F7081, 0, 0x1122334455667788},
+			expectedProtocol{protocol.AcknowledgementFlag | protocol.ReliabilityFlag, 0x21, 0x4DE1, 0x0000, 0x0A1B2C3D},
+			[]expectedElement{
+				{[]uint8{3}, uint64(0x8F21)},
+				{[]uint8{4, 1}, uint64(100000)},
+				{[]uint8{4, 2}, []byte("SPAKE2P Key Salt")},
+			},
+		},
+		{
+			"im-readrequest",
+			nil,
+			expectedProtocol{protocol.InitiatorFlag | protocol.ReliabilityFlag, protocol.ReadRequestMessage, 0x4DE2, 0x0001, 0},
+			[]expectedElement{
+				{[]uint8{3}, true},
+				{[]uint8{0xFF}, uint64(11)},
+			},
+		},
+		{
+			"im-reportdata",
+			nil,
+			expectedProtocol{protocol.AcknowledgementFlag | protocol.ReliabilityFlag, protocol.ReportDataMessage, 0x4DE2, 0x0001, 0x0A1B2C40},
+			[]expectedElement{
+				{[]uint8{4}, true},
+				{[]uint8{0xFF}, uint64(11)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			capture, err := fixture.LookupCapture(test.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			b := capture.Bytes
+			if capture.Layer == fixture.MessageLayer {
+				if test.message == nil {
+					t.Fatalf("%s has no expected message header", test.name)
+				}
+				header, err := message.NewHeaderFromBytes(b)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if header.SessionID != test.message.sessionID {
+					t.Errorf("%d != %d", header.SessionID, test.message.sessionID)
+				}
+				if header.Counter != test.message.counter {
+					t.Errorf("%08X != %08X", header.Counter, test.message.counter)
+				}
+				if header.SourceNodeID != test.message.srcNodeID {
+					t.Errorf("%016X != %016X", header.SourceNodeID, test.message.srcNodeID)
+				}
+				if header.DestinationNodeID != test.message.dstNodeID {
+					t.Errorf("%016X != %016X", header.DestinationNodeID, test.message.dstNodeID)
+				}
+				if !bytes.Equal(header.Bytes(), b[:header.Size()]) {
+					t.Errorf("%X != %X", header.Bytes(), b[:header.Size()])
+				}
+				b = b[header.Size():]
+			}
+
+			msg, err := protocol.NewMessageFromBytes(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if msg.ExchangeFlag != test.protocol.flag {
+				t.Errorf("%02X != %02X", msg.ExchangeFlag, test.protocol.flag)
+			}
+			if msg.Opcode != test.protocol.opcode {
+				t.Errorf("%02X != %02X", msg.Opcode, test.protocol.opcode)
+			}
+			if msg.ExchangeID != test.protocol.exchangeID {
+				t.Errorf("%04X != %04X", msg.ExchangeID, test.protocol.exchangeID)
+			}
+			if msg.ProtocolID != test.protocol.protocolID {
+				t.Errorf("%04X != %04X", msg.ProtocolID, test.protocol.protocolID)
+			}
+			if msg.AckCounter != test.protocol.ackCounter {
+				t.Errorf("%08X != %08X", msg.AckCounter, test.protocol.ackCounter)
+			}
+			if !bytes.Equal(msg.Bytes(), b) {
+				t.Errorf("%X != %X", msg.Bytes(), b)
+			}
+
+			values, err := decodeContextValues(msg.Payload())
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range test.elements {
+				key := string(e.path)
+				v, ok := values[key]
+				if !ok {
+					t.Errorf("%v is not found", e.path)
+					continue
+				}
+				switch ev := e.value.(type) {
+				case []byte:
+					if !bytes.Equal(v.([]byte), ev) {
+						t.Errorf("%v: %X != %X", e.path, v, ev)
+					}
+				default:
+					if v != ev {
+						t.Errorf("%v: %v != %v", e.path, v, ev)
+					}
+				}
+			}
+		})
+	}
+}
+
+// decodeContextValues decodes all primitive elements in the payload structure
+// into a map keyed by their context tag numbers path.
+func decodeContextValues(b []byte) (map[string]any, error) {
+	values := map[string]any{}
+	path := []uint8{}
+	dec := tlv.NewDecoder(b)
+	for dec.More() {
+		elem, err := dec.Next()
+		if err != nil {
+			return nil, err
+		}
+		if elem.IsEndOfContainer() {
+			if 0 < len(path) {
+				path = path[:len(path)-1]
+			}
+			continue
+		}
+		num := uint8(0)
+		if elem.Tag().IsContext() {
+			num = uint8(elem.Tag().Number())
+		}
+		if elem.IsContainer() {
+			if dec.Depth() != 1 {
+				path = append(path, num)
+			}
+			continue
+		}
+		key := string(append(append([]uint8{}, path...), num))
+		switch {
+		case elem.Type().IsUnsigned():
+			values[key], err = elem.Unsigned()
+		case elem.Type().IsBoolean():
+			values[key], err = elem.Bool()
+		case elem.Type().IsOctetString():
+			values[key], err = elem.Bytes()
+		case elem.Type().IsUTF8String():
+			values[key], err = elem.String()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,226 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/credential"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// encodeTestNOC returns a Matter TLV NOC of the specified validity without a valid signature.
+func encodeTestNOC(nodeID matter.NodeID, notBefore, notAfter time.Time) []byte {
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.NewAnonymousTag())
+	enc.PutOctetString(tlv.NewContextTag(1), []byte{0x01})
+	enc.PutUnsigned(tlv.NewContextTag(4), uint64(notBefore.Sub(datatype.Epoch)/time.Second))
+	enc.PutUnsigned(tlv.NewContextTag(5), uint64(notAfter.Sub(datatype.Epoch)/time.Second))
+	enc.StartList(tlv.NewContextTag(6))
+	enc.PutUnsigned(tlv.NewContextTag(17), uint64(nodeID))
+	enc.PutUnsigned(tlv.NewContextTag(21), 1)
+	enc.EndContainer()
+	enc.EndContainer()
+	return enc.Bytes()
+}
+
+// testCredentialsSession represents a session to a node which implements the commissioning
+// commands used to rotate its NOC.
+type testCredentialsSession struct {
+	*testOperationalSession
+	node *testCredentialsNode
+}
+
+type testCredentialsNode struct {
+	sync.Mutex
+	noc      []byte
+	pending  []byte
+	armed    bool
+	commands []im.CommandID
+	sessions int
+}
+
+func (s *testCredentialsSession) ReadAttribute(ctx context.Context, path im.AttributePath) ([]im.AttributeData, error) {
+	s.node.Lock()
+	defer s.node.Unlock()
+	noc := datatype.OctetString(s.node.noc)
+	fabric := datatype.Uint8(1)
+	nocs := datatype.NewList(nil)
+	nocs.Elements = append(nocs.Elements, datatype.NewStruct(
+		datatype.NewField(1, &noc),
+		datatype.NewField(2, datatype.NewNull(new(datatype.OctetString))),
+		datatype.NewField(0xFE, &fabric)))
+	b, err := datatype.Encode(nocs)
+	if err != nil {
+		return nil, err
+	}
+	return []im.AttributeData{{Path: path, DataVersion: 1, Data: b}}, nil
+}
+
+func (s *testCredentialsSession) InvokeCommand(ctx context.Context, path im.CommandPath, fields []byte) ([]byte, error) {
+	s.node.Lock()
+	defer s.node.Unlock()
+	s.node.commands = append(s.node.commands, path.Command)
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.NewAnonymousTag())
+	switch {
+	case path.Cluster == 0x0030 && path.Command == 0x00:
+		st := datatype.NewStruct(datatype.NewField(0, new(datatype.Uint16)), datatype.NewField(1, new(datatype.Uint64)))
+		if err := datatype.Decode(fields, st); err != nil {
+			return nil, err
+		}
+		expiry, _ := st.LookupField(0)
+		s.node.armed = *expiry.Value.(*datatype.Uint16) != 0
+		if !s.node.armed {
+			s.node.pending = nil
+		}
+		enc.PutUnsigned(tlv.NewContextTag(0), 0)
+		enc.PutUTF8String(tlv.NewContextTag(1), "")
+	case path.Cluster == 0x0030 && path.Command == 0x04:
+		if s.node.pending != nil {
+			s.node.noc = s.node.pending
+			s.node.pending = nil
+		}
+		s.node.armed = false
+		enc.PutUnsigned(tlv.NewContextTag(0), 0)
+		enc.PutUTF8String(tlv.NewContextTag(1), "")
+	case path.Cluster == 0x003E && path.Command == 0x04:
+		enc.PutOctetString(tlv.NewContextTag(0), []byte{0x15, 0x18})
+		enc.PutOctetString(tlv.NewContextTag(1), make([]byte, 64))
+	case path.Cluster == 0x003E && path.Command == 0x07:
+		st := datatype.NewStruct(datatype.NewField(0, new(datatype.OctetString)))
+		if err := datatype.Decode(fields, st); err != nil {
+			return nil, err
+		}
+		status := uint64(0)
+		if s.node.armed {
+			noc, _ := st.LookupField(0)
+			s.node.pending = *noc.Value.(*datatype.OctetString)
+		} else {
+			status = 1
+		}
+		enc.PutUnsigned(tlv.NewContextTag(0), status)
+	default:
+		return nil, im.StatusUnsupportedCommand
+	}
+	enc.EndContainer()
+	return enc.Bytes(), nil
+}
+
+func (node *testCredentialsNode) EstablishSession(ctx context.Context, peer matter.OperationalPeer) (matter.OperationalSession, error) {
+	node.Lock()
+	defer node.Unlock()
+	node.sessions++
+	return &testCredentialsSession{
+		testOperationalSession: &testOperationalSession{
+			Mutex:   sync.Mutex{},
+			peer:    peer,
+			err:     nil,
+			closed:  false,
+			written: map[im.AttributePath][]byte{},
+			reads:   []im.AttributePath{},
+		},
+		node: node,
+	}, nil
+}
+
+type testNOCIssuer struct {
+	notBefore time.Time
+	validity  time.Duration
+	err       error
+}
+
+func (issuer *testNOCIssuer) IssueNOC(ctx context.Context, peer matter.OperationalPeer, nocsrElements []byte) ([]byte, []byte, error) {
+	if issuer.err != nil {
+		return nil, nil, issuer.err
+	}
+	return encodeTestNOC(peer.NodeID, issuer.notBefore, issuer.notBefore.Add(issuer.validity)), nil, nil
+}
+
+func TestCertificateRotation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	now := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	validity := 365 * 24 * time.Hour
+	node := &testCredentialsNode{
+		Mutex: sync.Mutex{},
+		noc:   encodeTestNOC(0x1234, now.Add(-validity), now.Add(10*24*time.Hour)),
+	}
+	com := matter.NewCommissioner(matter.WithSessionEstablisher(node))
+	dev := com.OperationalDevice(matter.OperationalPeer{FabricID: 1, CompressedFabricID: 1, NodeID: 0x1234, Address: netip.MustParseAddrPort("[::1]:5540")})
+
+	cert, err := dev.OperationalCertificate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cert.ExpiresWithin(now, matter.DefaultRenewBefore) {
+		t.Fatalf("certificate (%s) does not expire", cert.NotAfter)
+	}
+
+	// Failed rotations disarm the fail-safe and keep the current NOC.
+	errIssuer := errors.New("issuer")
+	issuer := &testNOCIssuer{notBefore: now, validity: validity, err: errIssuer}
+	rotated := 0
+	mon := matter.NewCertificateMonitor(issuer,
+		matter.WithMonitorClock(func() time.Time { return now }),
+		matter.WithRotationHandler(func(dev *matter.OperationalDevice, cert *credential.Certificate, err error) {
+			if err == nil {
+				rotated++
+			}
+		}))
+	if _, err := mon.Check(ctx, []*matter.OperationalDevice{dev}); !errors.Is(err, errIssuer) {
+		t.Errorf("%v is not %v", err, errIssuer)
+	}
+	if node.armed {
+		t.Errorf("fail-safe is armed")
+	}
+
+	// Expiring NOCs are rotated, and committed on a new session.
+	issuer.err = nil
+	next, err := mon.Check(ctx, []*matter.OperationalDevice{dev})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated != 1 || node.pending != nil {
+		t.Fatalf("NOC is not rotated")
+	}
+	if expected := now.Add(validity - matter.DefaultRenewBefore); !next.Equal(expected) {
+		t.Errorf("next check (%s) != (%s)", next, expected)
+	}
+	if node.sessions < 2 {
+		t.Errorf("session is not re-established")
+	}
+	cert, err = dev.OperationalCertificate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cert.NotAfter.Equal(now.Add(validity)) {
+		t.Errorf("certificate (%s) is not rotated", cert.NotAfter)
+	}
+
+	// Valid NOCs are kept.
+	if _, err := mon.Check(ctx, []*matter.OperationalDevice{dev}); err != nil || rotated != 1 {
+		t.Errorf("NOC is rotated again (%v)", err)
+	}
+}
@@ -98,7 +98,7 @@ func TestCommissionee(t *testing.T) {
 				if !ok {
 					t.Errorf("discriminator not found")
 				}
-				if disc != test.expected.disc {
+				if disc.String() != test.expected.disc {
 					t.Errorf("discriminator (%s) != (%s)", disc, test.expected.disc)
 				}
 			}
@@ -108,7 +108,7 @@ func TestCommissionee(t *testing.T) {
 				if !ok {
 					t.Errorf("short discriminator not found")
 				}
-				if discs != test.expected.discs {
+				if discs.String() != test.expected.discs {
 					t.Errorf("short discriminator (%s) != (%s)", discs, test.expected.discs)
 				}
 			}
@@ -125,3 +125,67 @@ func TestCommissionee(t *testing.T) {
 		})
 	}
 }
+
+func TestCommissioneeICDAndJointFabric(t *testing.T) {
+	tests := []struct {
+		service string
+		txts    []string
+		icd     matter.ICDOperatingMode
+		icdOk   bool
+		jf      matter.JointFabricMode
+		jfOk    bool
+	}{
+		{"_matterc._udp.local", []string{"D=840", "CM=1"}, 0, false, 0, false},
+		{"_matterc._udp.local", []string{"D=840", "ICD=1", "JF=3"}, matter.ICDLongIdleTime, true, matter.JointFabricAvailable | matter.JointFabricAdministrator, true},
+		{"_matter._tcp.local", []string{"SII=5000", "ICD=0", "JF=14"}, matter.ICDShortIdleTime, true, matter.JointFabricAdministrator | matter.JointFabricAnchor | matter.JointFabricDatastore, true},
+		{"_matter._tcp.local", []string{"ICD=2", "JF=256"}, 0, false, 0, false},
+	}
+	for _, test := range tests {
+		t.Run(strings.Join(test.txts, ","), func(t *testing.T) {
+			instance := "87E1B004E235A130-8FC7772401CD0696." + test.service
+			msg := newDNSSDMessage()
+			msg.addPTR(test.service, instance)
+			msg.addSRV(instance, 5540, "0E8B3C9A1F2D4E5B.local")
+			msg.addTXT(instance, test.txts...)
+			res, err := dns.NewMessageWithBytes(msg.Bytes())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			com, err := matter.NewCommissioneeWithMessage(res)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if icd, ok := com.LookupICDOperatingMode(); icd != test.icd || ok != test.icdOk {
+				t.Errorf("ICD (%s, %t) != (%s, %t)", icd, ok, test.icd, test.icdOk)
+			}
+			if jf, ok := com.LookupJointFabricMode(); jf != test.jf || ok != test.jfOk {
+				t.Errorf("JF (%s, %t) != (%s, %t)", jf, ok, test.jf, test.jfOk)
+			}
+
+			// Operational records are parsed from the discovered services in the same way.
+			disc := matter.NewDiscoverer()
+			if _, err := disc.Client.MessageReceived(res); err != nil {
+				t.Fatal(err)
+			}
+			srv, ok := disc.ServiceTable().Lookup(instance)
+			if !ok {
+				t.Fatalf("%s is not found", instance)
+			}
+			if icd, ok := srv.LookupICDOperatingMode(); icd != test.icd || ok != test.icdOk {
+				t.Errorf("ICD (%s, %t) != (%s, %t)", icd, ok, test.icd, test.icdOk)
+			}
+			if jf, ok := srv.LookupJointFabricMode(); jf != test.jf || ok != test.jfOk {
+				t.Errorf("JF (%s, %t) != (%s, %t)", jf, ok, test.jf, test.jfOk)
+			}
+		})
+	}
+
+	if !matter.ICDLongIdleTime.IsLongIdleTime() || matter.ICDShortIdleTime.IsLongIdleTime() {
+		t.Error("ICD operating mode is invalid")
+	}
+	mode := matter.JointFabricAvailable | matter.JointFabricAnchor
+	if !mode.Has(matter.JointFabricAnchor) || mode.Has(matter.JointFabricAnchor|matter.JointFabricDatastore) {
+		t.Errorf("%s", mode)
+	}
+}
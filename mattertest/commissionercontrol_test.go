@@ -0,0 +1,63 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/cluster/administratorcommissioning"
+	"github.com/cybergarage/go-matter/matter/cluster/commissionercontrol"
+	"github.com/cybergarage/go-matter/matter/datamodel"
+)
+
+func TestCommissionerControl(t *testing.T) {
+	ctx := context.Background()
+	var window *matter.EnhancedCommissioningWindow
+	var cluster *commissionercontrol.Cluster
+	cluster = commissionercontrol.NewCluster(datamodel.NewEndpoint(1),
+		commissionercontrol.WithApprovalHandler(func(req *commissionercontrol.ApprovalRequest) {
+			if err := cluster.Approve(req, true); err != nil {
+				t.Error(err)
+			}
+		}),
+		commissionercontrol.WithWindowOpener(func(req *commissionercontrol.ApprovalRequest, timeout time.Duration) (*administratorcommissioning.OpenCommissioningWindowRequest, error) {
+			var err error
+			window, err = matter.NewEnhancedCommissioningWindow(administratorcommissioning.MinCommissioningTimeout)
+			if err != nil {
+				return nil, err
+			}
+			return window.Request(), nil
+		}))
+	invoker := &testClusterInvoker{cluster: cluster, fabric: 2}
+
+	if err := matter.RequestCommissioningApproval(ctx, invoker, 1, 0x10, matter.TestVender01ID, 0x8000, "light"); err != nil {
+		t.Fatal(err)
+	}
+	req, err := matter.CommissionNode(ctx, invoker, 1, 0x10, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Discriminator != window.Discriminator || req.Iterations != window.Credentials.Iterations {
+		t.Errorf("window %s (%d)", req.Discriminator.HexString(), req.Iterations)
+	}
+
+	// Approvals are used once.
+	if _, err := matter.CommissionNode(ctx, invoker, 1, 0x10, time.Minute); err == nil {
+		t.Error("approval is used twice")
+	}
+}
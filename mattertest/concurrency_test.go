@@ -0,0 +1,104 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-mdns/mdns/dns"
+)
+
+type testMessageListener struct {
+	received atomic.Int64
+}
+
+func (l *testMessageListener) MessageReceived(msg *dns.Message) {
+	l.received.Add(1)
+}
+
+// TestCommissionerConcurrency exercises the commissioner from many goroutines, and is meant to be run
+// with the race detector.
+func TestCommissionerConcurrency(t *testing.T) {
+	const (
+		workers    = 8
+		iterations = 50
+	)
+
+	peer := matter.OperationalPeer{FabricID: 1, CompressedFabricID: 0x87E1B004E235A130, NodeID: 0x1234, Address: netip.AddrPort{}}
+	service := "_matter._tcp.local"
+	instance := peer.InstanceName() + "." + service
+	host := "0E8B3C9A1F2D4E5B.local"
+	msg := newDNSSDMessage()
+	msg.addPTR(service, instance)
+	msg.addSRV(instance, 5540, host)
+	msg.addAAAA(host, net.ParseIP("fd00::10"))
+	res, err := dns.NewMessageWithBytes(msg.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	com := matter.NewCommissioner()
+	resolver := matter.NewDNSSDOperationalResolver(com.Discoverer)
+	listener := &testMessageListener{received: atomic.Int64{}}
+	path := im.NewAttributePath(1, 0x0006, 0x0000)
+
+	var wg sync.WaitGroup
+	for n := 0; n < workers; n++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				switch n % 4 {
+				case 0:
+					// Messages are delivered while the listener is replaced.
+					if _, err := com.Discoverer.Client.MessageReceived(res); err != nil {
+						t.Error(err)
+						return
+					}
+					com.SetListener(listener)
+				case 1:
+					_ = com.Services()
+					_, _ = resolver.LookupOperational(peer)
+					resolver.SetQueryInterval(time.Millisecond, 1)
+				case 2:
+					com.SetSessionEstablisher(&testNodeEstablisher{attrs: nil})
+				case 3:
+					ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+					dev := com.OperationalDevice(peer)
+					_, _ = dev.ReadAttribute(ctx, path)
+					_ = dev.Close()
+					_, _ = com.NodeStats(peer.NodeID)
+					cancel()
+				}
+			}
+		}(n)
+	}
+	wg.Wait()
+
+	if _, ok := resolver.LookupOperational(peer); !ok {
+		t.Errorf("%s is not found", peer.InstanceName())
+	}
+	if listener.received.Load() == 0 {
+		t.Errorf("listener is not called")
+	}
+}
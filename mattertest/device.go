@@ -0,0 +1,195 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"crypto/rand"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"testing"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/pase"
+	"github.com/cybergarage/go-matter/matter/payload"
+	"github.com/cybergarage/go-matter/matter/types"
+	"github.com/cybergarage/go-mdns/mdns/dns"
+)
+
+const (
+	commissionableServiceType = string(matter.CommissionableServiceType)
+	localDomain               = "local"
+)
+
+// VirtualDeviceSalt is the PBKDF salt of the virtual device credentials, which is the test salt
+// of the specification examples.
+var VirtualDeviceSalt = []byte("SPAKE2P Key Salt")
+
+// VirtualDevice represents an in-process commissionee for controller integration tests.
+// It advertises the onboarding payload as a DNS-SD response message on loopback, answers
+// the PBKDF parameter exchange of PASE with the credentials of the passcode, and has the
+// data model node which Interaction Model assertions are made against. The Pake1..3
+// exchange and the Interaction Model messages are not served yet.
+type VirtualDevice struct {
+	*payload.OnboardingPayload
+	instanceName string
+	hostName     string
+	port         uint16
+	addr         net.IP
+	creds        *pase.Credentials
+	sessionID    message.SessionID
+	node         *datamodel.Node
+}
+
+// DefaultVirtualDeviceOptions returns the onboarding payload options of the test vendor device
+// which is used in the Matter specification examples.
+func DefaultVirtualDeviceOptions() []payload.Option {
+	return []payload.Option{
+		payload.WithVendorID(0xFFF1),
+		payload.WithProductID(0x8000),
+		payload.WithDiscriminator(3840),
+		payload.WithPasscode(20202021),
+		payload.WithDiscoveryCapabilities(payload.DiscoveryCapabilityOnNetwork),
+	}
+}
+
+// NewVirtualDevice returns a new virtual commissionee with the specified onboarding payload options
+// which override the default options. The instance name is derived from the test name, so that
+// the advertisement is reproducible.
+func NewVirtualDevice(t testing.TB, opts ...payload.Option) *VirtualDevice {
+	t.Helper()
+
+	onboarding, err := payload.NewOnboardingPayload(append(DefaultVirtualDeviceOptions(), opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	creds, err := pase.NewCredentialsFromPasscode(onboarding.Passcode(), VirtualDeviceSalt, pase.PBKDFIterationsMin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node := datamodel.NewNode()
+	root := datamodel.NewEndpoint(datamodel.RootEndpointID, datamodel.NewDeviceType(types.RootNodeDeviceType))
+	if err := node.AddEndpoint(root); err != nil {
+		t.Fatal(err)
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(t.Name()))
+	id := h.Sum64()
+
+	return &VirtualDevice{
+		OnboardingPayload: onboarding,
+		instanceName:      fmt.Sprintf("%016X", id),
+		hostName:          fmt.Sprintf("%016X.%s", id, localDomain),
+		port:              matter.Port,
+		addr:              net.IPv6loopback,
+		creds:             creds,
+		sessionID:         message.SessionID(id),
+		node:              node,
+	}
+}
+
+// InstanceName returns the commissionable instance name.
+func (dev *VirtualDevice) InstanceName() string {
+	return dev.instanceName
+}
+
+// Port returns the advertised port.
+func (dev *VirtualDevice) Port() uint16 {
+	return dev.port
+}
+
+// Address returns the advertised address.
+func (dev *VirtualDevice) Address() net.IP {
+	return dev.addr
+}
+
+// MessageBytes returns the DNS-SD response message which advertises the commissionable node.
+func (dev *VirtualDevice) MessageBytes() []byte {
+	service := commissionableServiceType + "." + localDomain
+	instance := dev.instanceName + "." + service
+
+	msg := newDNSSDMessage()
+	// 4.3.1.3. Commissioning Subtypes
+	msg.addPTR(fmt.Sprintf("%s%s._sub.%s", matter.SubtypeDiscriminatorLong, dev.Discriminator(), service), instance)
+	msg.addPTR(fmt.Sprintf("%s%d._sub.%s", matter.SubtypeDiscriminatorShort, dev.ShortDiscriminator(), service), instance)
+	msg.addPTR(fmt.Sprintf("%s%d._sub.%s", matter.SubtypeVendorID, dev.VendorID(), service), instance)
+	msg.addPTR(fmt.Sprintf("%s1._sub.%s", matter.SubtypeCommissioningMode, service), instance)
+	msg.addPTR(service, instance)
+	msg.addSRV(instance, dev.port, dev.hostName)
+	// 4.3.1.4. TXT Records
+	msg.addTXT(instance,
+		fmt.Sprintf("%s=%s", matter.TxtRecordDiscriminator, dev.Discriminator()),
+		fmt.Sprintf("%s=%d+%d", matter.TxtRecordVendorProductID, dev.VendorID(), dev.ProductID()),
+		fmt.Sprintf("%s=%s", matter.TxtRecordCommissioningMode, matter.CommissioningMode1),
+	)
+	msg.addAAAA(dev.hostName, dev.addr)
+	return msg.Bytes()
+}
+
+// Message returns the parsed DNS-SD response message which advertises the commissionable node.
+func (dev *VirtualDevice) Message() (*dns.Message, error) {
+	return dns.NewMessageWithBytes(dev.MessageBytes())
+}
+
+// Commissionee returns the commissionee which a controller discovers from the advertisement.
+func (dev *VirtualDevice) Commissionee() (*matter.Commissionee, error) {
+	msg, err := dev.Message()
+	if err != nil {
+		return nil, err
+	}
+	return matter.NewCommissioneeWithMessage(msg)
+}
+
+// Credentials returns the PASE credentials which are derived from the passcode.
+func (dev *VirtualDevice) Credentials() *pase.Credentials {
+	return dev.creds
+}
+
+// Node returns the data model node, which has the root endpoint only.
+func (dev *VirtualDevice) Node() *datamodel.Node {
+	return dev.node
+}
+
+// PBKDFParamResponse returns the encoded PBKDFParamResponse which answers the specified
+// encoded PBKDFParamRequest. The PBKDF parameters are omitted if the initiator has them.
+func (dev *VirtualDevice) PBKDFParamResponse(b []byte) ([]byte, error) {
+	req, err := pase.NewPBKDFParamRequestFromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	if req.PasscodeID != pase.DefaultPasscodeID {
+		return nil, fmt.Errorf("%w passcode ID: %d", pase.ErrInvalid, req.PasscodeID)
+	}
+	random := make([]byte, pase.RandomSize)
+	if _, err := rand.Read(random); err != nil {
+		return nil, err
+	}
+	res := &pase.PBKDFParamResponse{
+		InitiatorRandom:    req.InitiatorRandom,
+		ResponderRandom:    random,
+		ResponderSessionID: dev.sessionID,
+		PBKDFParams:        nil,
+		SessionParams:      nil,
+	}
+	if !req.HasPBKDFParams {
+		res.PBKDFParams = dev.creds.PBKDFParams()
+	}
+	return res.Bytes(), nil
+}
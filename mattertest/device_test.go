@@ -0,0 +1,125 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/pase"
+	"github.com/cybergarage/go-matter/matter/payload"
+)
+
+func TestVirtualDevice(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []payload.Option
+	}{
+		{"default", nil},
+		{"custom", []payload.Option{payload.WithVendorID(0xFFF2), payload.WithProductID(0x8001), payload.WithDiscriminator(840)}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dev := NewVirtualDevice(t, test.opts...)
+
+			code, err := dev.QRCode()
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := payload.NewOnboardingPayloadFromString(code)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !decoded.Equal(dev.OnboardingPayload) {
+				t.Errorf("%s != %s", decoded, dev.OnboardingPayload)
+			}
+
+			com, err := dev.Commissionee()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if cm, ok := com.LookupCommissioningMode(); !ok || cm != "1" {
+				t.Errorf("commissioning mode (%s) != (1)", cm)
+			}
+			disc, ok := com.LookupDiscriminator()
+			if !ok || disc != dev.Discriminator() {
+				t.Errorf("%s != %s", disc, dev.Discriminator())
+			}
+			discs, ok := com.LookupShortDiscriminator()
+			if !ok || !discs.IsShort() || !discs.Matches(dev.Discriminator()) {
+				t.Errorf("%s does not match %s", discs, dev.Discriminator())
+			}
+
+			vid, pid, ok := com.LookupVendorProductID()
+			if !ok {
+				t.Errorf("vendor and product IDs are not found")
+			} else if vid != dev.VendorID() || pid != dev.ProductID() {
+				t.Errorf("%s+%s != %s+%s", vid, pid, dev.VendorID(), dev.ProductID())
+			}
+			if vid, ok := com.LookupVendorID(); !ok || vid != dev.VendorID() {
+				t.Errorf("%s != %s", vid, dev.VendorID())
+			}
+
+			if com.Port != uint(dev.Port()) {
+				t.Errorf("%d != %d", com.Port, dev.Port())
+			}
+			if !com.AddrV6.Equal(dev.Address()) {
+				t.Errorf("%s != %s", com.AddrV6, dev.Address())
+			}
+		})
+	}
+}
+
+func TestVirtualDevicePBKDFParamResponse(t *testing.T) {
+	dev := NewVirtualDevice(t)
+
+	reqBytes := lookupPASEPayload(t, "pase-pbkdfparamrequest")
+	req, err := pase.NewPBKDFParamRequestFromBytes(reqBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := dev.PBKDFParamResponse(reqBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := pase.NewPBKDFParamResponseFromBytes(b, pase.DefaultPBKDFPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(res.InitiatorRandom, req.InitiatorRandom) {
+		t.Errorf("%X != %X", res.InitiatorRandom, req.InitiatorRandom)
+	}
+	if req.HasPBKDFParams {
+		t.Skip("the captured initiator has the PBKDF parameters")
+	}
+	if res.PBKDFParams == nil {
+		t.Fatal("PBKDF parameters are not found")
+	}
+
+	creds, err := pase.NewCredentialsFromPasscode(dev.Passcode(), res.PBKDFParams.Salt, res.PBKDFParams.Iterations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(creds.Verifier.Bytes(), dev.Credentials().Verifier.Bytes()) {
+		t.Errorf("%X != %X", creds.Verifier.Bytes(), dev.Credentials().Verifier.Bytes())
+	}
+
+	if _, ok := dev.Node().LookupEndpoint(datamodel.RootEndpointID); !ok {
+		t.Errorf("endpoint (%d) is not found", datamodel.RootEndpointID)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/bdx"
+	"github.com/cybergarage/go-matter/matter/cluster/diagnosticlogs"
+	"github.com/cybergarage/go-matter/matter/datamodel"
+)
+
+// testBDXConn represents one end of an in-memory BDX exchange.
+type testBDXConn struct {
+	in  chan *bdx.Message
+	out chan *bdx.Message
+}
+
+func (conn *testBDXConn) Send(ctx context.Context, msg *bdx.Message) error {
+	select {
+	case conn.out <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (conn *testBDXConn) Receive(ctx context.Context) (*bdx.Message, error) {
+	select {
+	case msg := <-conn.in:
+		return msg, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// testBDXAcceptor connects the BDX exchanges which the cluster initiates to the controller.
+type testBDXAcceptor struct {
+	device     *testBDXConn
+	controller *testBDXConn
+}
+
+func newTestBDXAcceptor() *testBDXAcceptor {
+	a := make(chan *bdx.Message, 16)
+	b := make(chan *bdx.Message, 16)
+	return &testBDXAcceptor{
+		device:     &testBDXConn{in: a, out: b},
+		controller: &testBDXConn{in: b, out: a},
+	}
+}
+
+func (acceptor *testBDXAcceptor) AcceptBDX(ctx context.Context, designator string) (bdx.Conn, error) {
+	return acceptor.controller, nil
+}
+
+func (acceptor *testBDXAcceptor) InitiateBDX(ctx context.Context, req *datamodel.CommandRequest) (bdx.Conn, error) {
+	return acceptor.device, nil
+}
+
+func TestRetrieveLogs(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	logs := map[diagnosticlogs.Intent][]byte{
+		diagnosticlogs.EndUserSupportIntent: []byte("end user support logs"),
+		diagnosticlogs.CrashLogsIntent:      bytes.Repeat([]byte("crash "), 1000),
+	}
+	// Each call has its own acceptor, so that an exchange of a call is never taken by another.
+	var mutex sync.Mutex
+	var acceptor *testBDXAcceptor
+	newAcceptor := func() *testBDXAcceptor {
+		mutex.Lock()
+		defer mutex.Unlock()
+		acceptor = newTestBDXAcceptor()
+		return acceptor
+	}
+	cluster := diagnosticlogs.NewCluster(
+		diagnosticlogs.WithLogProvider(func(intent diagnosticlogs.Intent) ([]byte, error) {
+			return logs[intent], nil
+		}),
+		diagnosticlogs.WithBDXInitiator(func(ctx context.Context, req *datamodel.CommandRequest) (bdx.Conn, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			return acceptor.InitiateBDX(ctx, req)
+		}))
+	invoker := &testClusterInvoker{cluster: cluster, fabric: 1}
+
+	tests := []struct {
+		intent   diagnosticlogs.Intent
+		bdx      bool
+		expected []byte
+	}{
+		{diagnosticlogs.EndUserSupportIntent, false, logs[diagnosticlogs.EndUserSupportIntent]},
+		{diagnosticlogs.EndUserSupportIntent, true, logs[diagnosticlogs.EndUserSupportIntent]},
+		{diagnosticlogs.CrashLogsIntent, false, logs[diagnosticlogs.CrashLogsIntent][:diagnosticlogs.MaxLogContentSize]},
+		{diagnosticlogs.CrashLogsIntent, true, logs[diagnosticlogs.CrashLogsIntent]},
+	}
+	for _, test := range tests {
+		var bdxAcceptor matter.BDXAcceptor
+		if test.bdx {
+			bdxAcceptor = newAcceptor()
+		}
+		b, err := matter.RetrieveLogs(ctx, invoker, 0, test.intent, bdxAcceptor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, test.expected) {
+			t.Errorf("intent %d : %d bytes != %d bytes", test.intent, len(b), len(test.expected))
+		}
+	}
+
+	if _, err := matter.RetrieveLogs(ctx, invoker, 0, diagnosticlogs.NetworkDiagIntent, newAcceptor()); !errors.Is(err, matter.ErrNotFound) {
+		t.Errorf("%v is not %v", err, matter.ErrNotFound)
+	}
+}
@@ -15,9 +15,17 @@
 package mattertest
 
 import (
+	"context"
+	"errors"
+	"net"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/ble"
+	"github.com/cybergarage/go-matter/matter/payload"
+	"github.com/cybergarage/go-mdns/mdns/dns"
 )
 
 func TestDiscoverer(t *testing.T) {
@@ -35,3 +43,224 @@ func TestDiscoverer(t *testing.T) {
 		return
 	}
 }
+
+func TestServiceTypes(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected matter.ServiceType
+	}{
+		{"commissionable", matter.CommissionableServiceType},
+		{"_matterc._udp", matter.CommissionableServiceType},
+		{"Operational", matter.OperationalServiceType},
+		{"_matter._tcp", matter.OperationalServiceType},
+		{"commissioner", matter.CommissionerServiceType},
+		{"_matterd._udp", matter.CommissionerServiceType},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			typ, err := matter.NewServiceTypeFromString(test.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if typ != test.expected {
+				t.Errorf("%s != %s", typ, test.expected)
+			}
+		})
+	}
+
+	if _, err := matter.NewServiceTypeFromString("_companion-link._tcp"); !errors.Is(err, matter.ErrNotSupported) {
+		t.Errorf("%v is not %v", err, matter.ErrNotSupported)
+	}
+	if len(matter.ServiceTypes()) != 3 {
+		t.Errorf("%v", matter.ServiceTypes())
+	}
+}
+
+func TestDiscovererBrowse(t *testing.T) {
+	disc := matter.NewDiscoverer()
+	if err := disc.Start(); err != nil {
+		t.Skip(err)
+	}
+	defer disc.Stop()
+
+	instances := map[matter.ServiceType]string{
+		matter.CommissionableServiceType: "DD200C20D25AE5F7",
+		matter.OperationalServiceType:    "87E1B004E235A130-8FC7772401CD0696",
+	}
+	for typ, instance := range instances {
+		service := typ.String() + ".local"
+		name := instance + "." + service
+		host := instance + ".local"
+		msg := newDNSSDMessage()
+		msg.addPTR(service, name)
+		msg.addSRV(name, 5540, host)
+		msg.addAAAA(host, net.ParseIP("fd00::10"))
+		res, err := dns.NewMessageWithBytes(msg.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := disc.Client.MessageReceived(res); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		types    []matter.ServiceType
+		expected []string
+	}{
+		{nil, []string{instances[matter.CommissionableServiceType]}},
+		{[]matter.ServiceType{matter.OperationalServiceType}, []string{instances[matter.OperationalServiceType]}},
+		{[]matter.ServiceType{matter.CommissionerServiceType}, []string{}},
+		{matter.ServiceTypes(), []string{instances[matter.CommissionableServiceType], instances[matter.OperationalServiceType]}},
+	}
+	for _, test := range tests {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		services, err := disc.Browse(ctx, test.types...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(services) != len(test.expected) {
+			t.Errorf("%v: %d != %d", test.types, len(services), len(test.expected))
+			continue
+		}
+		for _, instance := range test.expected {
+			found := false
+			for _, srv := range services {
+				if strings.HasPrefix(srv.Name, instance+".") {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("%v: %s is not found", test.types, instance)
+			}
+		}
+	}
+}
+
+func TestDiscovererServiceMerge(t *testing.T) {
+	disc := matter.NewDiscoverer()
+	received := func(msg *dnssdMessage) {
+		t.Helper()
+		res, err := dns.NewMessageWithBytes(msg.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := disc.Client.MessageReceived(res); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The same instance is announced repeatedly on two interfaces with different addresses.
+	service := "_matterc._udp.local"
+	instance := "DD200C20D25AE5F7"
+	host := "E45F010F27530000.local"
+	announcements := []struct {
+		name string
+		ip   string
+		txt  string
+	}{
+		{instance, "fd00::10", "CM=1"},
+		{strings.ToLower(instance), "192.168.1.10", "CM=1"},
+		{instance, "fd00::10", "CM=0"},
+	}
+	for _, a := range announcements {
+		name := a.name + "." + service
+		msg := newDNSSDMessage()
+		msg.addPTR(service, name)
+		msg.addSRV(name, 5540, host)
+		msg.addTXT(name, "D=3840", a.txt)
+		if ip := net.ParseIP(a.ip); ip.To4() != nil {
+			msg.addA(host, ip)
+		} else {
+			msg.addAAAA(host, ip)
+		}
+		received(msg)
+	}
+
+	table := disc.ServiceTable()
+	if table.Len() != 1 || len(disc.Services()) != 1 {
+		t.Fatalf("%d services", table.Len())
+	}
+	srv, ok := table.Lookup(strings.ToLower(instance) + "." + service)
+	if !ok {
+		t.Fatalf("%s is not found", instance)
+	}
+	if len(srv.Addrs) != 2 || srv.AddrV6 == nil || srv.AddrV4 == nil {
+		t.Errorf("%v is invalid", srv.Addrs)
+	}
+	if srv.Port != 5540 || srv.Updated.IsZero() {
+		t.Errorf("%d %s", srv.Port, srv.Updated)
+	}
+	com := matter.NewCommissioneeWithService(srv.Service)
+	if v, ok := com.LookupAttribute("CM"); !ok || v != "0" {
+		t.Errorf("CM (%s) is not the latest", v)
+	}
+	if d, ok := com.LookupDiscriminator(); !ok || d != 3840 {
+		t.Errorf("discriminator (%d) != 3840", d)
+	}
+	if len(srv.Attributes) != 2 {
+		t.Errorf("%d attributes", len(srv.Attributes))
+	}
+}
+
+func TestCommissionerDiscoverCommissionableNodes(t *testing.T) {
+	const onNetworkBLE = payload.DiscoveryCapabilityOnNetwork | payload.DiscoveryCapabilityBLE
+	tests := []struct {
+		name      string
+		caps      payload.DiscoveryCapabilities
+		dnssd     bool
+		transport payload.Transport
+		found     bool
+	}{
+		{"on-network first", onNetworkBLE, true, payload.TransportOnNetwork, true},
+		{"ble fallback", onNetworkBLE, false, payload.TransportBLE, true},
+		{"ble only", payload.DiscoveryCapabilityBLE, true, payload.TransportBLE, true},
+		{"on-network only", payload.DiscoveryCapabilityOnNetwork, false, payload.TransportOnNetwork, false},
+		{"soft-ap only", payload.DiscoveryCapabilitySoftAP, true, payload.TransportSoftAP, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dev := NewVirtualDevice(t, payload.WithDiscoveryCapabilities(test.caps))
+
+			transport := ble.NewMemoryTransport()
+			transport.AddDevice(ble.NewMemoryDevice("00:00:00:00:00:01", -40, ble.NewServiceDescriptor(dev.Discriminator(), dev.VendorID(), dev.ProductID())))
+			transport.AddDevice(ble.NewMemoryDevice("00:00:00:00:00:02", -30, ble.NewServiceDescriptor(dev.Discriminator()+1, dev.VendorID(), dev.ProductID())))
+			com := matter.NewCommissioner(matter.WithBLEConnector(ble.NewConnector(transport, ble.WithScanDuration(10*time.Millisecond))))
+			if test.dnssd {
+				if _, err := com.Client.MessageReceived(mustMessage(t, dev)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			nodes, err := com.DiscoverCommissionableNodes(context.Background(), dev.OnboardingPayload)
+			if !test.found {
+				if !errors.Is(err, matter.ErrNotFound) {
+					t.Errorf("%v is not %v", err, matter.ErrNotFound)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(nodes) != 1 {
+				t.Fatalf("nodes (%d) != (1)", len(nodes))
+			}
+			if nodes[0].Transport != test.transport {
+				t.Errorf("%s != %s", nodes[0].Transport, test.transport)
+			}
+			if d, ok := nodes[0].LookupDiscriminator(); !ok || d != dev.Discriminator() {
+				t.Errorf("%s != %s", d, dev.Discriminator())
+			}
+		})
+	}
+}
+
+func mustMessage(t *testing.T, dev *VirtualDevice) *dns.Message {
+	t.Helper()
+	msg, err := dev.Message()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"encoding/binary"
+	"net"
+	"strings"
+)
+
+// DNS resource record types used by DNS-SD.
+const (
+	dnsTypeA    = 1
+	dnsTypeAAAA = 28
+	dnsTypePTR  = 12
+	dnsTypeSRV  = 33
+	dnsTypeTXT  = 16
+	dnsClassIN  = 1
+	dnsTTL      = 120
+)
+
+// dnssdMessage represents a minimal DNS-SD response message builder.
+type dnssdMessage struct {
+	records [][]byte
+}
+
+func newDNSSDMessage() *dnssdMessage {
+	return &dnssdMessage{
+		records: [][]byte{},
+	}
+}
+
+func appendDNSName(b []byte, name string) []byte {
+	for _, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		b = append(b, byte(len(label)))
+		b = append(b, label...)
+	}
+	return append(b, 0x00)
+}
+
+func (msg *dnssdMessage) addRecord(name string, typ uint16, data []byte) {
+	b := appendDNSName(nil, name)
+	b = binary.BigEndian.AppendUint16(b, typ)
+	b = binary.BigEndian.AppendUint16(b, dnsClassIN)
+	b = binary.BigEndian.AppendUint32(b, dnsTTL)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(data)))
+	b = append(b, data...)
+	msg.records = append(msg.records, b)
+}
+
+func (msg *dnssdMessage) addPTR(name string, domain string) {
+	msg.addRecord(name, dnsTypePTR, appendDNSName(nil, domain))
+}
+
+func (msg *dnssdMessage) addSRV(name string, port uint16, target string) {
+	data := []byte{0x00, 0x00, 0x00, 0x00}
+	data = binary.BigEndian.AppendUint16(data, port)
+	msg.addRecord(name, dnsTypeSRV, appendDNSName(data, target))
+}
+
+func (msg *dnssdMessage) addTXT(name string, strs ...string) {
+	data := []byte{}
+	for _, str := range strs {
+		data = append(data, byte(len(str)))
+		data = append(data, str...)
+	}
+	msg.addRecord(name, dnsTypeTXT, data)
+}
+
+func (msg *dnssdMessage) addAAAA(name string, ip net.IP) {
+	msg.addRecord(name, dnsTypeAAAA, ip.To16())
+}
+
+func (msg *dnssdMessage) addA(name string, ip net.IP) {
+	msg.addRecord(name, dnsTypeA, ip.To4())
+}
+
+// Bytes returns the response message with all records in the answer section.
+func (msg *dnssdMessage) Bytes() []byte {
+	b := []byte{
+		0x00, 0x00, // ID
+		0x84, 0x00, // QR, AA
+		0x00, 0x00, // QDCOUNT
+	}
+	b = binary.BigEndian.AppendUint16(b, uint16(len(msg.records)))
+	b = append(b, 0x00, 0x00, 0x00, 0x00) // NSCOUNT, ARCOUNT
+	for _, record := range msg.records {
+		b = append(b, record...)
+	}
+	return b
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/cluster/administratorcommissioning"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/pase"
+	"github.com/cybergarage/go-matter/matter/payload"
+)
+
+// testRecordingInvoker records the invoked commands.
+type testRecordingInvoker struct {
+	paths  []im.CommandPath
+	fields [][]byte
+}
+
+func (invoker *testRecordingInvoker) InvokeCommand(ctx context.Context, path im.CommandPath, fields []byte) ([]byte, error) {
+	invoker.paths = append(invoker.paths, path)
+	invoker.fields = append(invoker.fields, fields)
+	return nil, nil
+}
+
+func TestOpenEnhancedCommissioningWindow(t *testing.T) {
+	invoker := &testRecordingInvoker{paths: nil, fields: nil}
+	window, err := matter.OpenEnhancedCommissioningWindow(context.Background(), invoker, 5*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(invoker.paths) != 1 || invoker.paths[0].Cluster != administratorcommissioning.ClusterID || invoker.paths[0].Command != administratorcommissioning.OpenCommissioningWindowCommandID {
+		t.Fatalf("%v", invoker.paths)
+	}
+
+	// The node gets the verifier of the new passcode.
+
+	req, err := administratorcommissioning.NewOpenCommissioningWindowRequestFromBytes(invoker.fields[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := req.Validate(); err != nil {
+		t.Error(err)
+	}
+	if req.Timeout != 5*time.Minute || req.Discriminator != window.Discriminator {
+		t.Errorf("%s %s != %s %s", req.Timeout, req.Discriminator, 5*time.Minute, window.Discriminator)
+	}
+	creds, err := pase.NewCredentialsFromPasscode(window.Passcode, req.Salt, req.Iterations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(creds.Verifier.Bytes(), req.PAKEPasscodeVerifier) {
+		t.Errorf("verifier does not match the passcode")
+	}
+
+	// The next administrator gets the passcode in the onboarding payload.
+
+	onboarding, err := window.OnboardingPayload(0xFFF1, 0x8000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := onboarding.ManualPairingCode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := payload.NewOnboardingPayloadFromString(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Passcode() != window.Passcode || !decoded.Discriminator().Matches(window.Discriminator) {
+		t.Errorf("%s != %s", decoded, onboarding)
+	}
+
+	if _, err := matter.OpenEnhancedCommissioningWindow(context.Background(), invoker, time.Minute); !errors.Is(err, administratorcommissioning.ErrInvalid) {
+		t.Errorf("%v is not %v", err, administratorcommissioning.ErrInvalid)
+	}
+
+	if err := matter.RevokeCommissioning(context.Background(), invoker); err != nil {
+		t.Fatal(err)
+	}
+	if len(invoker.paths) != 2 || invoker.paths[1].Command != administratorcommissioning.RevokeCommissioningCommandID {
+		t.Errorf("%v", invoker.paths)
+	}
+}
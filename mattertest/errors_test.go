@@ -0,0 +1,76 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/internal/protocol"
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/ble"
+	"github.com/cybergarage/go-matter/matter/credential"
+	"github.com/cybergarage/go-matter/matter/crypto"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+	matterr "github.com/cybergarage/go-matter/matter/errors"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/payload"
+	"github.com/cybergarage/go-matter/matter/session"
+)
+
+func TestErrorCategories(t *testing.T) {
+	tests := []struct {
+		err      error
+		category error
+	}{
+		{tlv.ErrInvalid, matterr.ErrWire},
+		{tlv.ErrShortBuffer, matterr.ErrWire},
+		{message.ErrInvalid, matterr.ErrWire},
+		{protocol.ErrInvalid, matterr.ErrWire},
+		{ble.ErrInvalid, matterr.ErrWire},
+		{ble.ErrNotSupported, matterr.ErrUnsupported},
+		{datatype.ErrOutOfRange, matterr.ErrInvalidArgument},
+		{payload.ErrInvalid, matterr.ErrInvalidArgument},
+		{crypto.ErrInvalid, matterr.ErrInvalidArgument},
+		{credential.ErrAuthentication, matterr.ErrSecurity},
+		{session.ErrTimeout, matterr.ErrTimeout},
+		{session.ErrExpired, matterr.ErrClosed},
+		{matter.ErrNotSupported, matterr.ErrUnsupported},
+		{matter.ErrNotFound, matterr.ErrNotFound},
+		{im.StatusUnsupportedAttribute, matterr.ErrRejected},
+	}
+	for _, test := range tests {
+		if !errors.Is(test.err, test.category) {
+			t.Errorf("%v is not %v", test.err, test.category)
+		}
+		if matterr.CategoryOf(test.err) != test.category {
+			t.Errorf("%v : category (%v) != (%v)", test.err, matterr.CategoryOf(test.err), test.category)
+		}
+	}
+
+	// Wrapped errors keep their categories.
+	_, err := payload.NewOnboardingPayloadFromString("MT:abc")
+	if !errors.Is(err, matterr.ErrInvalidArgument) {
+		t.Errorf("%v is not %v", err, matterr.ErrInvalidArgument)
+	}
+	if _, err := crypto.NewSPAKE2pVerifierFromBytes(nil); !errors.Is(err, matterr.ErrInvalidArgument) {
+		t.Errorf("%v is not %v", err, matterr.ErrInvalidArgument)
+	}
+	if errors.Is(im.StatusSuccess, matterr.ErrRejected) {
+		t.Errorf("%v is %v", im.StatusSuccess, matterr.ErrRejected)
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fixture
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cybergarage/go-logger/log/hexdump"
+)
+
+// Layer represents the layer where a capture starts.
+type Layer string
+
+const (
+	// MessageLayer represents a capture which starts with the message header, that is a UDP payload.
+	MessageLayer Layer = "message"
+	// ProtocolLayer represents a capture which starts with the protocol header, that is a decrypted message payload.
+	ProtocolLayer Layer = "protocol"
+)
+
+const (
+	hexdumpExt     = ".hex"
+	commentPrefix  = "#"
+	layerDirective = "layer:"
+)
+
+// The embedded corpus is synthesized: no real chip-tool captures are available, so the payloads
+// were built by hand from the message layouts of the specification. They are decoded with the same
+// reading of the specification as the decoders, so they do not catch a misreading shared by both.
+// Real captures can be loaded with LoadHexdumpFile and LoadPcapFile.
+//
+//go:embed synthesized/*.hex
+var synthesized embed.FS
+
+const synthesizedDir = "synthesized"
+
+// Capture represents a captured payload.
+type Capture struct {
+	Name        string
+	Description string
+	Layer       Layer
+	Bytes       []byte
+	// Synthesized is true if the payload was built from the specification instead of captured.
+	Synthesized bool
+}
+
+// ReadHexdump reads a capture in the hexdump format of go-logger.
+// Lines starting with '#' are comments, and a 'layer:' comment specifies the capture layer.
+func ReadHexdump(name string, reader io.Reader) (*Capture, error) {
+	capture := &Capture{
+		Name:        name,
+		Description: "",
+		Layer:       MessageLayer,
+		Bytes:       nil,
+		Synthesized: false,
+	}
+
+	descs := []string{}
+	lines := []string{}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, commentPrefix):
+			comment := strings.TrimSpace(strings.TrimPrefix(line, commentPrefix))
+			if strings.HasPrefix(comment, layerDirective) {
+				capture.Layer = Layer(strings.TrimSpace(strings.TrimPrefix(comment, layerDirective)))
+				continue
+			}
+			descs = append(descs, comment)
+		case len(strings.TrimSpace(line)) == 0:
+			continue
+		default:
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	switch capture.Layer {
+	case MessageLayer, ProtocolLayer:
+	default:
+		return nil, fmt.Errorf("%w layer: %s", ErrInvalid, capture.Layer)
+	}
+
+	b, err := decodeHexdumpLines(lines)
+	if err != nil {
+		return nil, fmt.Errorf("%w hexdump %s: %w", ErrInvalid, name, err)
+	}
+
+	capture.Description = strings.Join(descs, " ")
+	capture.Bytes = b
+	return capture, nil
+}
+
+func decodeHexdumpLines(lines []string) (b []byte, err error) {
+	// The hexdump decoder expects well-formed lines and may panic on truncated ones.
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return hexdump.DecodeStringLinesToBytes(lines)
+}
+
+// LoadHexdumpFile loads a capture from the specified hexdump file.
+func LoadHexdumpFile(filename string) (*Capture, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadHexdump(strings.TrimSuffix(filepath.Base(filename), hexdumpExt), file)
+}
+
+// Captures returns all embedded captures in name order, which are all synthesized.
+func Captures() ([]*Capture, error) {
+	entries, err := synthesized.ReadDir(synthesizedDir)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), hexdumpExt))
+	}
+	sort.Strings(names)
+
+	all := []*Capture{}
+	for _, name := range names {
+		capture, err := LookupCapture(name)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, capture)
+	}
+	return all, nil
+}
+
+// LookupCapture returns the embedded capture with the specified name, which is synthesized.
+func LookupCapture(name string) (*Capture, error) {
+	file, err := synthesized.Open(path.Join(synthesizedDir, name+hexdumpExt))
+	if err != nil {
+		return nil, fmt.Errorf("%w capture: %s", ErrNotFound, name)
+	}
+	defer file.Close()
+	capture, err := ReadHexdump(name, file)
+	if err != nil {
+		return nil, err
+	}
+	capture.Synthesized = true
+	return capture, nil
+}
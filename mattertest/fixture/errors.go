@@ -0,0 +1,28 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fixture
+
+import (
+	matterr "github.com/cybergarage/go-matter/matter/errors"
+)
+
+var (
+	// ErrInvalid is returned when a capture is invalid.
+	ErrInvalid = matterr.New(matterr.ErrWire, "invalid")
+	// ErrNotSupported is returned when a capture format is not supported.
+	ErrNotSupported = matterr.New(matterr.ErrUnsupported, "not supported")
+	// ErrNotFound is returned when a capture is not found.
+	ErrNotFound = matterr.New(matterr.ErrNotFound, "not found")
+)
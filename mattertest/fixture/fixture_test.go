@@ -0,0 +1,191 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fixture
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+func TestCaptures(t *testing.T) {
+	captures, err := Captures()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(captures) == 0 {
+		t.Fatal("no captures")
+	}
+	for _, capture := range captures {
+		t.Run(capture.Name, func(t *testing.T) {
+			if len(capture.Bytes) == 0 {
+				t.Errorf("%s is empty", capture.Name)
+			}
+			if len(capture.Description) == 0 {
+				t.Errorf("%s has no description", capture.Name)
+			}
+			if !capture.Synthesized {
+				t.Errorf("%s is not marked as synthesized", capture.Name)
+			}
+		})
+	}
+
+	_, err = LookupCapture("unknown")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("%v is not %v", err, ErrNotFound)
+	}
+}
+
+func TestReadHexdumpInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"layer", "# layer: unknown\n0000 00\n"},
+		{"truncated", "0000 00 01\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ReadHexdump(test.name, strings.NewReader(test.src))
+			if !errors.Is(err, ErrInvalid) {
+				t.Errorf("%v is not %v", err, ErrInvalid)
+			}
+		})
+	}
+}
+
+func newTestPcap(order binary.AppendByteOrder, linkType uint32, frames ...[]byte) []byte {
+	b := order.AppendUint32(nil, pcapMagicMicroseconds)
+	b = order.AppendUint16(b, 2)
+	b = order.AppendUint16(b, 4)
+	b = append(b, make([]byte, 8)...)
+	b = order.AppendUint32(b, 0xFFFF)
+	b = order.AppendUint32(b, linkType)
+	for n, frame := range frames {
+		b = order.AppendUint32(b, uint32(1700000000+n))
+		b = order.AppendUint32(b, 500)
+		b = order.AppendUint32(b, uint32(len(frame)))
+		b = order.AppendUint32(b, uint32(len(frame)))
+		b = append(b, frame...)
+	}
+	return b
+}
+
+func newTestUDP(src, dst uint16, payload []byte) []byte {
+	b := binary.BigEndian.AppendUint16(nil, src)
+	b = binary.BigEndian.AppendUint16(b, dst)
+	b = binary.BigEndian.AppendUint16(b, uint16(udpHeaderSize+len(payload)))
+	b = append(b, 0x00, 0x00)
+	return append(b, payload...)
+}
+
+func newTestIPv4(proto byte, src, dst netip.Addr, payload []byte) []byte {
+	b := []byte{0x45, 0x00}
+	b = binary.BigEndian.AppendUint16(b, uint16(20+len(payload)))
+	b = append(b, 0x00, 0x00, 0x00, 0x00, 0x40, proto, 0x00, 0x00)
+	b = append(b, src.AsSlice()...)
+	b = append(b, dst.AsSlice()...)
+	return append(b, payload...)
+}
+
+func newTestIPv6(src, dst netip.Addr, payload []byte) []byte {
+	b := []byte{0x60, 0x00, 0x00, 0x00}
+	b = binary.BigEndian.AppendUint16(b, uint16(len(payload)))
+	b = append(b, ipProtocolUDP, 0x40)
+	b = append(b, src.AsSlice()...)
+	b = append(b, dst.AsSlice()...)
+	return append(b, payload...)
+}
+
+func newTestEthernet(etherType uint16, payload []byte) []byte {
+	b := make([]byte, 12)
+	b = binary.BigEndian.AppendUint16(b, etherType)
+	return append(b, payload...)
+}
+
+func TestReadPcap(t *testing.T) {
+	capture, err := LookupCapture("pase-pbkdfparamrequest")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src4 := netip.MustParseAddr("192.168.1.10")
+	dst4 := netip.MustParseAddr("192.168.1.20")
+	src6 := netip.MustParseAddr("fe80::1")
+	dst6 := netip.MustParseAddr("fe80::2")
+
+	tests := []struct {
+		name     string
+		pcap     []byte
+		expected []netip.AddrPort
+	}{
+		{
+			"ethernet",
+			newTestPcap(binary.LittleEndian, linkTypeEthernet,
+				newTestEthernet(etherTypeIPv6, newTestIPv6(src6, dst6, newTestUDP(49152, 5540, capture.Bytes))),
+				newTestEthernet(0x0806, []byte{0x00}),
+				newTestEthernet(etherTypeIPv4, newTestIPv4(6, src4, dst4, []byte{0x00})),
+				newTestEthernet(etherTypeIPv4, newTestIPv4(ipProtocolUDP, src4, dst4, newTestUDP(49153, 5540, capture.Bytes))),
+			),
+			[]netip.AddrPort{
+				netip.AddrPortFrom(src6, 49152),
+				netip.AddrPortFrom(src4, 49153),
+			},
+		},
+		{
+			"raw",
+			newTestPcap(binary.BigEndian, linkTypeRaw,
+				newTestIPv6(src6, dst6, newTestUDP(49152, 5540, capture.Bytes)),
+			),
+			[]netip.AddrPort{
+				netip.AddrPortFrom(src6, 49152),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			packets, err := ReadPcap(bytes.NewReader(test.pcap))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(packets) != len(test.expected) {
+				t.Fatalf("%d != %d", len(packets), len(test.expected))
+			}
+			for n, packet := range packets {
+				if packet.Source != test.expected[n] {
+					t.Errorf("%s != %s", packet.Source, test.expected[n])
+				}
+				if packet.Destination.Port() != 5540 {
+					t.Errorf("%d != %d", packet.Destination.Port(), 5540)
+				}
+				if !bytes.Equal(packet.Payload, capture.Bytes) {
+					t.Errorf("%X != %X", packet.Payload, capture.Bytes)
+				}
+				if packet.Time.IsZero() {
+					t.Errorf("no timestamp")
+				}
+			}
+		})
+	}
+
+	_, err = ReadPcap(bytes.NewReader(make([]byte, pcapFileHeaderSize)))
+	if !errors.Is(err, ErrNotSupported) {
+		t.Errorf("%v is not %v", err, ErrNotSupported)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fixture
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"time"
+)
+
+// pcap file format magic numbers.
+const (
+	pcapMagicMicroseconds = 0xA1B2C3D4
+	pcapMagicNanoseconds  = 0xA1B23C4D
+	pcapFileHeaderSize    = 24
+	pcapRecordHeaderSize  = 16
+	pcapMaxSnapLen        = 262144
+)
+
+// pcap link types.
+const (
+	linkTypeNull     = 0
+	linkTypeEthernet = 1
+	linkTypeRaw      = 101
+	linkTypeLinuxSLL = 113
+	linkTypeLinuxSL2 = 276
+)
+
+const (
+	etherTypeIPv4  = 0x0800
+	etherTypeIPv6  = 0x86DD
+	etherTypeVLAN  = 0x8100
+	ipProtocolUDP  = 17
+	udpHeaderSize  = 8
+	ipv6HeaderSize = 40
+)
+
+// Packet represents a UDP datagram in a pcap capture.
+type Packet struct {
+	Time        time.Time
+	Source      netip.AddrPort
+	Destination netip.AddrPort
+	Payload     []byte
+}
+
+// ReadPcap reads all UDP datagrams in the classic pcap format. The other packets are skipped.
+func ReadPcap(reader io.Reader) ([]*Packet, error) {
+	header := make([]byte, pcapFileHeaderSize)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, fmt.Errorf("%w pcap header: %w", ErrInvalid, err)
+	}
+
+	var order binary.ByteOrder
+	var nanoRes bool
+	switch {
+	case binary.LittleEndian.Uint32(header) == pcapMagicMicroseconds:
+		order = binary.LittleEndian
+	case binary.BigEndian.Uint32(header) == pcapMagicMicroseconds:
+		order = binary.BigEndian
+	case binary.LittleEndian.Uint32(header) == pcapMagicNanoseconds:
+		order, nanoRes = binary.LittleEndian, true
+	case binary.BigEndian.Uint32(header) == pcapMagicNanoseconds:
+		order, nanoRes = binary.BigEndian, true
+	default:
+		return nil, fmt.Errorf("%w pcap magic: %08X", ErrNotSupported, binary.BigEndian.Uint32(header))
+	}
+	linkType := order.Uint32(header[20:])
+
+	packets := []*Packet{}
+	record := make([]byte, pcapRecordHeaderSize)
+	for {
+		_, err := io.ReadFull(reader, record)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w pcap record %d: %w", ErrInvalid, len(packets), err)
+		}
+		secs := int64(order.Uint32(record[0:]))
+		frac := int64(order.Uint32(record[4:]))
+		if !nanoRes {
+			frac *= int64(time.Microsecond)
+		}
+		frameLen := order.Uint32(record[8:])
+		if pcapMaxSnapLen < frameLen {
+			return nil, fmt.Errorf("%w pcap record %d length: %d", ErrInvalid, len(packets), frameLen)
+		}
+		frame := make([]byte, frameLen)
+		if _, err := io.ReadFull(reader, frame); err != nil {
+			return nil, fmt.Errorf("%w pcap record %d: %w", ErrInvalid, len(packets), err)
+		}
+		packet, ok := parseLinkFrame(linkType, frame)
+		if !ok {
+			continue
+		}
+		packet.Time = time.Unix(secs, frac)
+		packets = append(packets, packet)
+	}
+	return packets, nil
+}
+
+// LoadPcapFile loads all UDP datagrams from the specified pcap file.
+func LoadPcapFile(filename string) ([]*Packet, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadPcap(file)
+}
+
+func parseLinkFrame(linkType uint32, frame []byte) (*Packet, bool) {
+	switch linkType {
+	case linkTypeNull:
+		if len(frame) < 4 {
+			return nil, false
+		}
+		return parseIPPacket(frame[4:])
+	case linkTypeEthernet:
+		if len(frame) < 14 {
+			return nil, false
+		}
+		etherType := binary.BigEndian.Uint16(frame[12:])
+		frame = frame[14:]
+		if etherType == etherTypeVLAN {
+			if len(frame) < 4 {
+				return nil, false
+			}
+			etherType = binary.BigEndian.Uint16(frame[2:])
+			frame = frame[4:]
+		}
+		if etherType != etherTypeIPv4 && etherType != etherTypeIPv6 {
+			return nil, false
+		}
+		return parseIPPacket(frame)
+	case linkTypeRaw:
+		return parseIPPacket(frame)
+	case linkTypeLinuxSLL:
+		if len(frame) < 16 {
+			return nil, false
+		}
+		return parseIPPacket(frame[16:])
+	case linkTypeLinuxSL2:
+		if len(frame) < 20 {
+			return nil, false
+		}
+		return parseIPPacket(frame[20:])
+	}
+	return nil, false
+}
+
+func parseIPPacket(b []byte) (*Packet, bool) {
+	if len(b) < 1 {
+		return nil, false
+	}
+	var src, dst netip.Addr
+	switch b[0] >> 4 {
+	case 4:
+		if len(b) < 20 {
+			return nil, false
+		}
+		ihl := int(b[0]&0x0F) * 4
+		if ihl < 20 || len(b) < ihl || b[9] != ipProtocolUDP {
+			return nil, false
+		}
+		src = netip.AddrFrom4([4]byte(b[12:16]))
+		dst = netip.AddrFrom4([4]byte(b[16:20]))
+		b = b[ihl:]
+	case 6:
+		if len(b) < ipv6HeaderSize || b[6] != ipProtocolUDP {
+			return nil, false
+		}
+		src = netip.AddrFrom16([16]byte(b[8:24]))
+		dst = netip.AddrFrom16([16]byte(b[24:40]))
+		b = b[ipv6HeaderSize:]
+	default:
+		return nil, false
+	}
+
+	if len(b) < udpHeaderSize {
+		return nil, false
+	}
+	length := int(binary.BigEndian.Uint16(b[4:]))
+	if length < udpHeaderSize || len(b) < length {
+		return nil, false
+	}
+	return &Packet{
+		Time:        time.Time{},
+		Source:      netip.AddrPortFrom(src, binary.BigEndian.Uint16(b[0:])),
+		Destination: netip.AddrPortFrom(dst, binary.BigEndian.Uint16(b[2:])),
+		Payload:     b[udpHeaderSize:length],
+	}, true
+}
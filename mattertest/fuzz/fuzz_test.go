@@ -0,0 +1,355 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fuzz
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/internal/protocol"
+	"github.com/cybergarage/go-matter/matter/ble"
+	"github.com/cybergarage/go-matter/matter/decode"
+	"github.com/cybergarage/go-matter/matter/encoding/base38"
+	"github.com/cybergarage/go-matter/matter/encoding/tlv"
+	"github.com/cybergarage/go-matter/matter/pase"
+	"github.com/cybergarage/go-matter/matter/payload"
+	"github.com/cybergarage/go-matter/mattertest/fixture"
+)
+
+func addCaptureCorpus(f *testing.F, layer fixture.Layer) {
+	f.Helper()
+	captures, err := fixture.Captures()
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, capture := range captures {
+		if capture.Layer != layer {
+			continue
+		}
+		f.Add(capture.Bytes)
+	}
+}
+
+// addPayloadCorpus adds the payloads of the message layer captures whose names have the specified prefix.
+func addPayloadCorpus(f *testing.F, prefix string) {
+	f.Helper()
+	captures, err := fixture.Captures()
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, capture := range captures {
+		if capture.Layer != fixture.MessageLayer || !strings.HasPrefix(capture.Name, prefix) {
+			continue
+		}
+		header, err := message.NewHeaderFromBytes(capture.Bytes)
+		if err != nil {
+			f.Fatal(err)
+		}
+		msg, err := protocol.NewMessageFromBytes(capture.Bytes[header.Size():])
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(msg.Payload())
+	}
+}
+
+func FuzzMessageHeader(f *testing.F) {
+	addCaptureCorpus(f, fixture.MessageLayer)
+	f.Fuzz(func(t *testing.T, b []byte) {
+		header, err := message.NewHeaderFromBytes(b)
+		if err != nil {
+			return
+		}
+		if len(b) < header.Size() {
+			t.Fatalf("%d < %d", len(b), header.Size())
+		}
+		if !bytes.Equal(header.Bytes(), b[:header.Size()]) {
+			t.Errorf("%X != %X", header.Bytes(), b[:header.Size()])
+		}
+	})
+}
+
+func FuzzProtocolMessage(f *testing.F) {
+	addCaptureCorpus(f, fixture.ProtocolLayer)
+	f.Fuzz(func(t *testing.T, b []byte) {
+		msg, err := protocol.NewMessageFromBytes(b)
+		if err != nil {
+			return
+		}
+		if !bytes.Equal(msg.Bytes(), b) {
+			t.Errorf("%X != %X", msg.Bytes(), b)
+		}
+	})
+}
+
+func FuzzDecode(f *testing.F) {
+	captures, err := fixture.Captures()
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, capture := range captures {
+		f.Add(capture.Bytes, string(capture.Layer), false)
+	}
+	f.Fuzz(func(t *testing.T, b []byte, start string, lengthPrefixed bool) {
+		hints := decode.Hints{
+			Start:          decode.Layer(start),
+			LengthPrefixed: lengthPrefixed,
+			Codec:          nil,
+			ProtocolID:     0,
+			Opcode:         0,
+		}
+		msg, _ := decode.Decode(b, hints)
+		if msg == nil || msg.Root == nil {
+			t.Fatal("no decoded message")
+		}
+		// Without a codec nothing is decrypted, so all fields are within the decoded bytes.
+		msg.Root.Walk(func(node *decode.Node, depth int) {
+			if node.Offset < 0 || node.Size < 0 || len(b) < node.Offset+node.Size {
+				t.Errorf("%s (%d, %d) is out of %d bytes", node.Name, node.Offset, node.Size, len(b))
+			}
+		})
+	})
+}
+
+func FuzzMessageExtensions(f *testing.F) {
+	f.Add([]byte{0x00, 0x00, 0x00, 0x20, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00})
+	f.Add([]byte{0x00, 0x00, 0x00, 0x20, 0x00, 0x00, 0x00, 0x00, 0x02, 0x00, 0xAA, 0xBB, 0xCC})
+	f.Add([]byte{0x04, 0x00, 0x00, 0x20, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0xAA})
+	f.Add([]byte{0x00, 0x00, 0x00, 0x20, 0x00, 0x00, 0x00, 0x00, 0xFF, 0xFF, 0xAA})
+	f.Fuzz(func(t *testing.T, b []byte) {
+		header, err := message.NewHeaderFromBytes(b)
+		// Fragmented reads without the known length decode the same header.
+		fragmented, fragmentedErr := message.NewHeaderFromReader(iotest.OneByteReader(bytes.NewReader(b)))
+		if (err == nil) != (fragmentedErr == nil) {
+			t.Fatalf("%v != %v", err, fragmentedErr)
+		}
+		if err != nil {
+			return
+		}
+		if !bytes.Equal(header.Bytes(), fragmented.Bytes()) {
+			t.Errorf("%X != %X", header.Bytes(), fragmented.Bytes())
+		}
+		if len(b) < header.Size() {
+			t.Fatalf("%d < %d", len(b), header.Size())
+		}
+		if header.SecurityFlag.IsExtendedMessage() {
+			end := header.Size()
+			start := end - len(header.Extensions)
+			if !bytes.Equal(header.Extensions, b[start:end]) {
+				t.Errorf("%X != %X", header.Extensions, b[start:end])
+			}
+		}
+	})
+}
+
+func FuzzSecuredExtensions(f *testing.F) {
+	f.Add([]byte{0x08, 0x02, 0x34, 0x12, 0x01, 0x00, 0x02, 0x00, 0xAA, 0xBB})
+	f.Add([]byte{0x0A, 0x02, 0x34, 0x12, 0x01, 0x00, 0x78, 0x56, 0x34, 0x12, 0x01, 0x00, 0xAA, 0x18})
+	f.Add([]byte{0x18, 0x02, 0x34, 0x12, 0xF1, 0xFF, 0x01, 0x00, 0x01, 0x00, 0xAA, 0x18})
+	f.Add([]byte{0x08, 0x02, 0x34, 0x12, 0x01, 0x00, 0xFF, 0xFF, 0x01})
+	f.Fuzz(func(t *testing.T, b []byte) {
+		msg, err := protocol.NewMessageFromBytes(b)
+		_, fragmentedErr := protocol.NewHeaderFromReader(iotest.OneByteReader(bytes.NewReader(b)))
+		if (err == nil) != (fragmentedErr == nil) {
+			t.Fatalf("%v != %v", err, fragmentedErr)
+		}
+		if err != nil {
+			return
+		}
+		if msg.Size()+len(msg.Payload()) != len(b) {
+			t.Fatalf("%d + %d != %d", msg.Size(), len(msg.Payload()), len(b))
+		}
+		if msg.ExchangeFlag.IsSecuredExtension() {
+			end := msg.Size()
+			start := end - len(msg.Extensions)
+			if !bytes.Equal(msg.Extensions, b[start:end]) {
+				t.Errorf("%X != %X", msg.Extensions, b[start:end])
+			}
+		}
+		if !bytes.Equal(msg.Bytes(), b) {
+			t.Errorf("%X != %X", msg.Bytes(), b)
+		}
+	})
+}
+
+func FuzzPBKDFParamRequest(f *testing.F) {
+	addPayloadCorpus(f, "pase-pbkdfparamrequest")
+	f.Fuzz(func(t *testing.T, b []byte) {
+		req, err := pase.NewPBKDFParamRequestFromBytes(b)
+		if err != nil {
+			return
+		}
+		// The decoded request is encoded canonically, so the encoding is stable.
+		decoded, err := pase.NewPBKDFParamRequestFromBytes(req.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decoded.Bytes(), req.Bytes()) {
+			t.Errorf("%X != %X", decoded.Bytes(), req.Bytes())
+		}
+	})
+}
+
+func FuzzPBKDFParamResponse(f *testing.F) {
+	addPayloadCorpus(f, "pase-pbkdfparamresponse")
+	policy := pase.DefaultPBKDFPolicy()
+	f.Fuzz(func(t *testing.T, b []byte) {
+		res, err := pase.NewPBKDFParamResponseFromBytes(b, policy)
+		if err != nil {
+			return
+		}
+		decoded, err := pase.NewPBKDFParamResponseFromBytes(res.Bytes(), policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decoded.Bytes(), res.Bytes()) {
+			t.Errorf("%X != %X", decoded.Bytes(), res.Bytes())
+		}
+	})
+}
+
+func FuzzBTPPacket(f *testing.F) {
+	f.Add([]byte{0x05, 0x00, 0x03, 0x00, 0xAA, 0xBB, 0xCC})
+	f.Add([]byte{0x0A, 0x04, 0x05, 0xAA})
+	f.Add([]byte{0x08, 0x01, 0x02})
+	f.Add([]byte{0x2D, 0x01, 0x02, 0x03, 0x10, 0x00})
+	f.Fuzz(func(t *testing.T, b []byte) {
+		pkt, err := ble.NewPacketFromBytes(b)
+		if err != nil {
+			return
+		}
+		if !bytes.Equal(pkt.Bytes(), b) {
+			t.Errorf("%X != %X", pkt.Bytes(), b)
+		}
+	})
+}
+
+func FuzzBTPHandshake(f *testing.F) {
+	f.Add([]byte{0x65, 0x6C, 0x04, 0x00, 0x00, 0x00, 0xF7, 0x00, 0x06})
+	f.Add([]byte{0x65, 0x6C, 0x04, 0xF4, 0x00, 0x06})
+	f.Fuzz(func(t *testing.T, b []byte) {
+		if req, err := ble.NewHandshakeRequestFromBytes(b); err == nil && !bytes.Equal(req.Bytes(), b) {
+			t.Errorf("%X != %X", req.Bytes(), b)
+		}
+		if res, err := ble.NewHandshakeResponseFromBytes(b); err == nil && !bytes.Equal(res.Bytes(), b) {
+			t.Errorf("%X != %X", res.Bytes(), b)
+		}
+	})
+}
+
+func FuzzTLV(f *testing.F) {
+	addCaptureCorpus(f, fixture.ProtocolLayer)
+	f.Add([]byte{0x15, 0x36, 0x01, 0x17, 0x18, 0x18, 0x18})
+	f.Fuzz(func(t *testing.T, b []byte) {
+		dec := tlv.NewDecoder(b)
+		for dec.More() {
+			offset := dec.Offset()
+			if _, err := dec.Next(); err != nil {
+				return
+			}
+			if dec.Offset() <= offset {
+				t.Fatalf("decoder does not advance at %d", offset)
+			}
+		}
+	})
+}
+
+func FuzzBase38(f *testing.F) {
+	f.Add("Y.K9042C00KA0648G00")
+	f.Add("PLS18R6")
+	f.Add("NE71")
+	f.Fuzz(func(t *testing.T, s string) {
+		b, err := base38.Decode(s)
+		if err != nil {
+			return
+		}
+		// Valid strings are canonical, so they are encoded back as they are.
+		if encoded := base38.Encode(b); encoded != s {
+			t.Errorf("%s != %s", encoded, s)
+		}
+	})
+}
+
+func FuzzQRCode(f *testing.F) {
+	f.Add("MT:Y.K9042C00KA0648G00")
+	f.Add("MT:M5L90MP500K64J00000")
+	f.Add("MT:Y.K9042C00KA0648G00*MT:-24J042C00KA0648G00")
+	f.Fuzz(func(t *testing.T, code string) {
+		payloads, err := payload.NewOnboardingPayloadsFromQRCode(code)
+		if err != nil {
+			return
+		}
+		for _, p := range payloads {
+			encoded, err := p.QRCode()
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := payload.NewOnboardingPayloadFromQRCode(encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !decoded.Equal(p) {
+				t.Errorf("%s != %s", decoded, p)
+			}
+		}
+	})
+}
+
+func FuzzManualPairingCode(f *testing.F) {
+	f.Add("34970112332")
+	f.Add("749701123365521327687")
+	f.Add("3497-011-2332")
+	f.Fuzz(func(t *testing.T, code string) {
+		fields, err := payload.DecodePairingCode(code)
+		if err != nil {
+			return
+		}
+		encoded, err := payload.EncodePairingCode(fields)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := payload.DecodePairingCode(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if decoded != fields {
+			t.Errorf("%v != %v", decoded, fields)
+		}
+	})
+}
+
+func FuzzOnboardingPayload(f *testing.F) {
+	f.Add("MT:Y.K9042C00KA0648G00")
+	f.Add("34970112332")
+	f.Fuzz(func(t *testing.T, code string) {
+		p, err := payload.NewOnboardingPayloadFromString(code)
+		if err != nil {
+			return
+		}
+		if err := p.Validate(); err != nil {
+			t.Errorf("%s: %s", code, err)
+		}
+	})
+}
+
+func FuzzPcap(f *testing.F) {
+	f.Add([]byte{0xD4, 0xC3, 0xB2, 0xA1, 0x02, 0x00, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xFF, 0xFF, 0x00, 0x00, 0x65, 0x00, 0x00, 0x00})
+	f.Fuzz(func(t *testing.T, b []byte) {
+		_, _ = fixture.ReadPcap(bytes.NewReader(b))
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/session"
+	"github.com/cybergarage/go-mdns/mdns/dns"
+)
+
+func TestCommissioneeSessionParams(t *testing.T) {
+	service := "_matter._tcp.local"
+	instance := "87E1B004E235A130-8FC7772401CD0696." + service
+	msg := newDNSSDMessage()
+	msg.addPTR(service, instance)
+	msg.addTXT(instance, "SII=5000", "SAI=300", "SAT=1000", "T=0")
+	res, err := dns.NewMessageWithBytes(msg.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	com, err := matter.NewCommissioneeWithMessage(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params, ok := com.LookupSessionParams()
+	if !ok {
+		t.Fatal("session parameters are not found")
+	}
+	if params.IdleInterval != 5*time.Second || params.ActiveInterval != 300*time.Millisecond || params.ActiveThreshold != time.Second {
+		t.Errorf("session parameters (%v) are invalid", params)
+	}
+
+	dev := NewVirtualDevice(t)
+	com, err = dev.Commissionee()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := com.LookupSessionParams(); ok {
+		t.Errorf("session parameters are found")
+	}
+}
+
+func TestOperationalDeviceActiveMode(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	est := &testSessionEstablisher{sessions: nil, err: nil}
+	com := matter.NewCommissioner(matter.WithSessionEstablisher(est))
+	dev := com.OperationalDevice(matter.OperationalPeer{FabricID: 1, CompressedFabricID: 1, NodeID: 1, Address: netip.MustParseAddrPort("[fd00::1]:5540")})
+
+	params := session.NewParams()
+	params.IdleInterval = 5 * time.Second
+	params.ActiveInterval = 10 * time.Millisecond
+	params.ActiveThreshold = 500 * time.Millisecond
+	dev.SetSessionParams(params)
+
+	if timeout := matter.WakeUpTimeout(params); timeout != 5500*time.Millisecond {
+		t.Errorf("wake-up timeout (%s) is invalid", timeout)
+	}
+	if dev.ActiveWindow().IsActive(time.Now()) {
+		t.Errorf("device is active before waking up")
+	}
+
+	for n := 0; n < 2; n++ {
+		err := dev.WithActiveMode(ctx, func(ctx context.Context) error {
+			deadline, ok := ctx.Deadline()
+			if !ok || params.ActiveThreshold < time.Until(deadline) {
+				t.Errorf("deadline (%s) is not bounded by the active threshold", deadline)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(est.sessions) != 1 {
+		t.Fatalf("sessions (%d) != (1)", len(est.sessions))
+	}
+	reads := est.sessions[0].reads
+	if len(reads) != 1 || reads[0] != matter.ActiveModeTriggerPath {
+		t.Errorf("active mode is triggered (%v) times", reads)
+	}
+	if !dev.ActiveWindow().IsActive(time.Now()) {
+		t.Errorf("device is not active after waking up")
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/cluster/identify"
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// testIdentifyInvoker invokes commands on an identify cluster directly.
+type testIdentifyInvoker struct {
+	cluster *identify.Cluster
+	err     error
+}
+
+func (invoker *testIdentifyInvoker) InvokeCommand(ctx context.Context, path im.CommandPath, fields []byte) ([]byte, error) {
+	if invoker.err != nil {
+		return nil, invoker.err
+	}
+	_, err := invoker.cluster.InvokeCommand(&datamodel.CommandRequest{
+		Path:         path,
+		Fields:       fields,
+		FabricIndex:  0,
+		SourceNodeID: 0,
+	})
+	return nil, err
+}
+
+func TestDisambiguateByIdentify(t *testing.T) {
+	invokers := []*testIdentifyInvoker{}
+	candidates := []matter.CommandInvoker{}
+	for n := 0; n < 3; n++ {
+		invoker := &testIdentifyInvoker{cluster: identify.NewCluster(identify.TypeLightOutput), err: nil}
+		invokers = append(invokers, invoker)
+		candidates = append(candidates, invoker)
+	}
+	invokers[0].err = errors.New("unreachable")
+
+	identified := []int{}
+	n, err := matter.DisambiguateByIdentify(context.Background(), candidates, 1, 10*time.Second, func(ctx context.Context, n int) (bool, error) {
+		if !invokers[n].cluster.IsIdentifying() {
+			t.Errorf("candidate (%d) is not identifying", n)
+		}
+		identified = append(identified, n)
+		return n == 2, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 || len(identified) != 2 {
+		t.Errorf("candidate (%d) is confirmed after %v", n, identified)
+	}
+	for n, invoker := range invokers {
+		if invoker.cluster.IsIdentifying() {
+			t.Errorf("candidate (%d) is still identifying", n)
+		}
+	}
+
+	_, err = matter.DisambiguateByIdentify(context.Background(), candidates, 1, time.Second, func(ctx context.Context, n int) (bool, error) {
+		return false, nil
+	})
+	if !errors.Is(err, matter.ErrNotFound) {
+		t.Errorf("%v is not %v", err, matter.ErrNotFound)
+	}
+}
+
+func TestTriggerIdentifyEffect(t *testing.T) {
+	effects := []identify.Effect{}
+	invoker := &testIdentifyInvoker{
+		cluster: identify.NewCluster(identify.TypeLightOutput, identify.WithEffectHandler(func(effect identify.Effect, variant identify.EffectVariant) {
+			effects = append(effects, effect)
+		})),
+		err: nil,
+	}
+	if err := matter.TriggerIdentifyEffect(context.Background(), invoker, 1, identify.EffectOkay, identify.EffectVariantDefault); err != nil {
+		t.Fatal(err)
+	}
+	if len(effects) != 1 || effects[0] != identify.EffectOkay {
+		t.Errorf("effects %v", effects)
+	}
+}
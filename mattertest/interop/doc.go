@@ -0,0 +1,25 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package interop provides an optional conformance test suite which runs go-matter
+// against the connectedhomeip example applications. The tests are built only with
+// the interop build tag and require the following environment variables:
+//
+//	CHIP_ALL_CLUSTERS_APP  path to chip-all-clusters-app
+//	CHIP_TOOL              path to chip-tool
+//
+// For example:
+//
+//	CHIP_ALL_CLUSTERS_APP=... CHIP_TOOL=... go test -tags interop ./mattertest/interop
+package interop
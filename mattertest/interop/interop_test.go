@@ -0,0 +1,220 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build interop
+
+package interop
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/payload"
+	"github.com/cybergarage/go-mdns/mdns"
+)
+
+const (
+	allClustersAppEnv = "CHIP_ALL_CLUSTERS_APP"
+	chipToolEnv       = "CHIP_TOOL"
+	testDiscriminator = 3840
+	testPasscode      = 20202021
+	startupTimeout    = 30 * time.Second
+	discoveryTimeout  = 10 * time.Second
+)
+
+var (
+	qrCodeRegex     = regexp.MustCompile(`SetupQRCode:\s*\[(MT:[0-9A-Z.\-]+)\]`)
+	manualCodeRegex = regexp.MustCompile(`Manual pairing code:\s*\[([0-9]+)\]`)
+)
+
+// allClustersApp represents a running chip-all-clusters-app process.
+type allClustersApp struct {
+	sync.Mutex
+	cmd        *exec.Cmd
+	qrCode     string
+	manualCode string
+	ready      chan struct{}
+}
+
+func startAllClustersApp(t *testing.T) *allClustersApp {
+	t.Helper()
+
+	path := os.Getenv(allClustersAppEnv)
+	if len(path) == 0 {
+		t.Skipf("%s is not set", allClustersAppEnv)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := exec.CommandContext(ctx, path,
+		"--discriminator", strconv.Itoa(testDiscriminator),
+		"--passcode", strconv.Itoa(testPasscode),
+		"--KVS", filepath.Join(t.TempDir(), "chip_kvs"),
+	)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd.Stderr = cmd.Stdout
+
+	app := &allClustersApp{
+		Mutex:      sync.Mutex{},
+		cmd:        cmd,
+		qrCode:     "",
+		manualCode: "",
+		ready:      make(chan struct{}),
+	}
+
+	if err := cmd.Start(); err != nil {
+		cancel()
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		cancel()
+		_ = cmd.Wait()
+	})
+
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			line := scanner.Text()
+			app.Lock()
+			if m := qrCodeRegex.FindStringSubmatch(line); m != nil {
+				app.qrCode = m[1]
+			}
+			if m := manualCodeRegex.FindStringSubmatch(line); m != nil {
+				app.manualCode = m[1]
+			}
+			ok := 0 < len(app.qrCode) && 0 < len(app.manualCode)
+			app.Unlock()
+			if ok {
+				close(app.ready)
+				break
+			}
+		}
+		// Drain the remaining output not to block the application.
+		_, _ = io.Copy(io.Discard, stdout)
+	}()
+
+	select {
+	case <-app.ready:
+	case <-time.After(startupTimeout):
+		t.Fatalf("%s did not print the onboarding codes in %s", path, startupTimeout)
+	}
+
+	return app
+}
+
+func TestInteropOnboardingCodes(t *testing.T) {
+	app := startAllClustersApp(t)
+
+	for _, code := range []string{app.qrCode, app.manualCode} {
+		t.Run(code, func(t *testing.T) {
+			p, err := payload.NewOnboardingPayloadFromString(code)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Passcode() != testPasscode {
+				t.Errorf("%d != %d", p.Passcode(), testPasscode)
+			}
+			if p.ShortDiscriminator() != uint8(testDiscriminator>>8) {
+				t.Errorf("%d != %d", p.ShortDiscriminator(), testDiscriminator>>8)
+			}
+			if !p.Discriminator().Matches(testDiscriminator) {
+				t.Errorf("%d != %d", p.Discriminator(), testDiscriminator)
+			}
+		})
+	}
+}
+
+func TestInteropDiscovery(t *testing.T) {
+	startAllClustersApp(t)
+
+	client := matter.NewCommissioner()
+	if err := client.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer client.Stop()
+
+	deadline := time.Now().Add(discoveryTimeout)
+	for time.Now().Before(deadline) {
+		err := client.Query(mdns.NewQueryWithServices([]string{"_matterc._udp"}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Second)
+		for _, srv := range client.Services() {
+			com := matter.NewCommissioneeWithService(srv)
+			disc, ok := com.LookupDiscriminator()
+			if ok && disc.Matches(testDiscriminator) {
+				return
+			}
+		}
+	}
+	t.Errorf("commissionee (D=%d) is not discovered in %s", testDiscriminator, discoveryTimeout)
+}
+
+func TestInteropSetupPayloadParse(t *testing.T) {
+	path := os.Getenv(chipToolEnv)
+	if len(path) == 0 {
+		t.Skipf("%s is not set", chipToolEnv)
+	}
+
+	p, err := payload.NewOnboardingPayload(
+		payload.WithVendorID(0xFFF1),
+		payload.WithProductID(0x8000),
+		payload.WithDiscriminator(testDiscriminator),
+		payload.WithPasscode(testPasscode),
+		payload.WithDiscoveryCapabilities(payload.DiscoveryCapabilityBLE),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	qrCode, err := p.QRCode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	manualCode, err := p.ManualPairingCode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expecteds := []*regexp.Regexp{
+		regexp.MustCompile(fmt.Sprintf(`Passcode:\s+%d`, testPasscode)),
+		regexp.MustCompile(fmt.Sprintf(`(?i)discriminator:\s+%d`, testDiscriminator>>8)),
+	}
+	for _, code := range []string{qrCode, manualCode} {
+		t.Run(code, func(t *testing.T) {
+			out, err := exec.Command(path, "payload", "parse-setup-payload", code).CombinedOutput()
+			if err != nil {
+				t.Fatalf("%s\n%s", err, out)
+			}
+			for _, expected := range expecteds {
+				if !expected.Match(out) {
+					t.Errorf("%s is not found in\n%s", expected, out)
+				}
+			}
+		})
+	}
+}
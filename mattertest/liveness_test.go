@@ -0,0 +1,184 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+// testLiveSession represents a session whose device subscriptions report only when the test asks.
+type testLiveSession struct {
+	*testOperationalSession
+	mutex sync.Mutex
+	subs  []*testDeviceSubscription
+	fails bool
+}
+
+func (s *testLiveSession) Subscribe(ctx context.Context, req im.SubscribeRequest, handler im.ReportHandler) (im.Subscription, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.fails {
+		return nil, context.DeadlineExceeded
+	}
+	sub := &testDeviceSubscription{id: im.SubscriptionID(len(s.subs) + 1), req: req, handler: handler, closed: false}
+	s.subs = append(s.subs, sub)
+	return sub, nil
+}
+
+func (s *testLiveSession) fail() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.fails = true
+}
+
+func (s *testLiveSession) subscriptions() []*testDeviceSubscription {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]*testDeviceSubscription{}, s.subs...)
+}
+
+func newTestLiveDevice(t *testing.T) (*matter.OperationalDevice, *testLiveSession) {
+	t.Helper()
+	s := &testLiveSession{
+		testOperationalSession: &testOperationalSession{
+			Mutex:   sync.Mutex{},
+			peer:    matter.OperationalPeer{},
+			err:     nil,
+			closed:  false,
+			written: map[im.AttributePath][]byte{},
+			reads:   []im.AttributePath{},
+		},
+		mutex: sync.Mutex{},
+		subs:  nil,
+		fails: false,
+	}
+	com := matter.NewCommissioner(matter.WithSessionEstablisher(&testLiveEstablisher{session: s}))
+	dev := com.OperationalDevice(matter.OperationalPeer{FabricID: 1, CompressedFabricID: 1, NodeID: 1, Address: netip.MustParseAddrPort("[::1]:5540")})
+	return dev, s
+}
+
+type testLiveEstablisher struct {
+	session *testLiveSession
+}
+
+func (est *testLiveEstablisher) EstablishSession(ctx context.Context, peer matter.OperationalPeer) (matter.OperationalSession, error) {
+	return est.session, nil
+}
+
+func TestResubscribePolicy(t *testing.T) {
+	policy := matter.ResubscribePolicy{InitialInterval: time.Second, MaxInterval: 10 * time.Second, Jitter: 0.5, MaxAttempts: 0}
+	tests := []struct {
+		attempt int
+		random  float64
+		want    time.Duration
+	}{
+		{1, 0.5, time.Second},
+		{2, 0.5, 2 * time.Second},
+		{4, 0.5, 8 * time.Second},
+		{5, 0.5, 10 * time.Second},
+		{100, 0.5, 10 * time.Second},
+		{1, 0, 500 * time.Millisecond},
+		{2, 1, 3 * time.Second},
+	}
+	for _, test := range tests {
+		if got := policy.Interval(test.attempt, test.random); got != test.want {
+			t.Errorf("attempt (%d, %g) : %s != %s", test.attempt, test.random, got, test.want)
+		}
+	}
+}
+
+func TestLiveSubscription(t *testing.T) {
+	ctx := context.Background()
+	dev, s := newTestLiveDevice(t)
+	defer dev.Close()
+
+	events := make(chan *matter.LivenessEvent, 4)
+	reports := make(chan []im.AttributeData, 4)
+	req := im.SubscribeRequest{Paths: []im.AttributePath{im.NewAttributePath(1, 0x0006, 0x0000)}, MaxInterval: 100 * time.Millisecond}
+	sub, err := dev.SubscribeLive(ctx, req, func(data []im.AttributeData) { reports <- data },
+		matter.WithLivenessMargin(50*time.Millisecond),
+		matter.WithResubscribePolicy(matter.ResubscribePolicy{InitialInterval: 10 * time.Millisecond, MaxInterval: 10 * time.Millisecond, Jitter: 0, MaxAttempts: 2}),
+		matter.WithLivenessListener(func(ev *matter.LivenessEvent) { events <- ev }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sub.IsAlive() || sub.ID() != 1 {
+		t.Errorf("subscription (%d) is not alive", sub.ID())
+	}
+
+	// Empty reports within the maximum interval keep the subscription alive.
+
+	for n := 0; n < 3; n++ {
+		time.Sleep(75 * time.Millisecond)
+		s.subscriptions()[0].handler(nil)
+		<-reports
+	}
+	if subs := s.subscriptions(); len(subs) != 1 {
+		t.Fatalf("device subscriptions (%d) != (1)", len(subs))
+	}
+
+	// Missed reports time the subscription out, and it is re-established.
+
+	ev := <-events
+	if ev.Alive || !errors.Is(ev.Err, matter.ErrSubscriptionTimeout) || ev.Node != 1 {
+		t.Errorf("%s", ev)
+	}
+	ev = <-events
+	if !ev.Alive || ev.Attempts != 1 {
+		t.Errorf("%s", ev)
+	}
+	subs := s.subscriptions()
+	if len(subs) != 2 || !subs[0].closed || subs[1].closed || sub.ID() != 2 {
+		t.Fatalf("device subscriptions (%d) : %d", len(subs), sub.ID())
+	}
+
+	// Reports of the timed out subscription are dropped.
+
+	subs[0].handler(nil)
+	subs[1].handler(nil)
+	if data := <-reports; data != nil {
+		t.Errorf("%v", data)
+	}
+	select {
+	case data := <-reports:
+		t.Errorf("report (%v) of the closed subscription", data)
+	default:
+	}
+
+	// The subscription is given up after the attempts of the policy.
+
+	s.fail()
+	if ev := <-events; ev.Alive {
+		t.Errorf("%s", ev)
+	}
+	if ev := <-events; ev.Alive || ev.Attempts != 2 || !errors.Is(ev.Err, context.DeadlineExceeded) {
+		t.Errorf("%s", ev)
+	}
+	<-sub.Done()
+	if sub.IsAlive() || sub.Err() == nil {
+		t.Errorf("subscription is not given up : %v", sub.Err())
+	}
+	if err := sub.Close(); err != nil {
+		t.Error(err)
+	}
+}
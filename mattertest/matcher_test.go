@@ -0,0 +1,94 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/ble"
+	"github.com/cybergarage/go-matter/matter/payload"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+func TestCommissionableNodeMatcher(t *testing.T) {
+	dev := NewVirtualDevice(t)
+	com, err := dev.Commissionee()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manualCode, err := dev.ManualPairingCode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	manual, err := payload.NewOnboardingPayloadFromString(manualCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodes := []struct {
+		name string
+		node matter.CommissionableNode
+	}{
+		{"dnssd", com},
+		{"ble", matter.NewCommissionableNodeWithServiceDescriptor(ble.NewServiceDescriptor(dev.Discriminator(), dev.VendorID(), dev.ProductID()))},
+		{"ble-anonymized", matter.NewCommissionableNodeWithServiceDescriptor(ble.NewServiceDescriptor(dev.Discriminator(), 0, 0))},
+	}
+
+	tests := []struct {
+		name     string
+		payload  *payload.OnboardingPayload
+		expected bool
+	}{
+		{"qr", dev.OnboardingPayload, true},
+		{"manual", manual, true},
+		{"short", newMatcherTestPayload(t, payload.WithDiscriminator(types.NewShortDiscriminator(dev.ShortDiscriminator()))), true},
+		{"discriminator", newMatcherTestPayload(t, payload.WithDiscriminator(dev.Discriminator()+1)), false},
+		{"short-discriminator", newMatcherTestPayload(t, payload.WithDiscriminator(types.NewShortDiscriminator(dev.ShortDiscriminator()-1))), false},
+		{"vendor", newMatcherTestPayload(t, payload.WithDiscriminator(dev.Discriminator()), payload.WithVendorID(dev.VendorID()+1)), false},
+		{"product", newMatcherTestPayload(t, payload.WithDiscriminator(dev.Discriminator()), payload.WithProductID(dev.ProductID()+1)), false},
+	}
+
+	for _, node := range nodes {
+		for _, test := range tests {
+			t.Run(node.name+"/"+test.name, func(t *testing.T) {
+				expected := test.expected
+				// Anonymized advertisements match any vendor and product.
+				if node.name == "ble-anonymized" && (test.name == "vendor" || test.name == "product") {
+					expected = true
+				}
+				matcher := matter.NewCommissionableNodeMatcher(test.payload)
+				if matcher(node.node) != expected {
+					t.Errorf("%s matches %s != %t", test.payload, node.name, expected)
+				}
+			})
+		}
+	}
+}
+
+func newMatcherTestPayload(t *testing.T, opts ...payload.Option) *payload.OnboardingPayload {
+	t.Helper()
+	opts = append([]payload.Option{
+		payload.WithVendorID(0),
+		payload.WithProductID(0),
+		payload.WithPasscode(20202021),
+	}, opts...)
+	p, err := payload.NewOnboardingPayload(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-mdns/mdns/dns"
+)
+
+func newCommissionableAdvertisement(t *testing.T, instance string, host string, txts ...string) *dns.Message {
+	t.Helper()
+	name := instance + "._matterc._udp.local"
+	msg := newDNSSDMessage()
+	msg.addPTR("_matterc._udp.local", name)
+	msg.addSRV(name, 5540, host)
+	msg.addTXT(name, txts...)
+	res, err := dns.NewMessageWithBytes(msg.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestAdvertisementMonitor(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	handled := []*matter.AdvertisementAnomaly{}
+	mon := matter.NewAdvertisementMonitor(
+		matter.WithAdvertisementClock(clock),
+		matter.WithFlappingThreshold(3, time.Minute),
+		matter.WithAnomalyHandler(func(anomaly *matter.AdvertisementAnomaly) {
+			handled = append(handled, anomaly)
+		}),
+	)
+
+	// Repeated advertisements are merged without anomalies.
+
+	for n := 0; n < 2; n++ {
+		mon.MessageReceived(newCommissionableAdvertisement(t, "A1", "hosta.local", "D=840", "CM=1", "VP=65521+32769", "RI=0001AABB"))
+		now = now.Add(time.Second)
+	}
+	advs := mon.Advertisements()
+	if len(advs) != 1 {
+		t.Fatalf("advertisements (%d) != (%d)", len(advs), 1)
+	}
+	if adv := advs[0]; adv.Count != 2 || adv.Discriminator != 840 || adv.VendorID != 65521 || adv.ProductID != 32769 {
+		t.Errorf("advertisement (%s) count (%d)", adv, adv.Count)
+	}
+
+	// A different host advertising the same discriminator collides.
+
+	mon.MessageReceived(newCommissionableAdvertisement(t, "B2", "hostb.local", "D=840", "CM=1", "RI=0002CCDD"))
+	// Nodes not in commissioning mode do not collide.
+	mon.MessageReceived(newCommissionableAdvertisement(t, "C3", "hostc.local", "D=840", "CM=0"))
+
+	// A rotating ID advertised by another node is flagged.
+
+	mon.MessageReceived(newCommissionableAdvertisement(t, "D4", "hostd.local", "D=1234", "CM=1", "RI=0001AABB"))
+	// The same host with another instance name, such as a renamed instance, does not collide.
+	mon.MessageReceived(newCommissionableAdvertisement(t, "D4R", "hostd.local", "D=1234", "CM=1"))
+
+	// Commissioning mode changes are flagged when they exceed the threshold in the window.
+
+	for n := 0; n < 3; n++ {
+		now = now.Add(time.Second)
+		cm := "CM=0"
+		if n%2 == 1 {
+			cm = "CM=1"
+		}
+		mon.MessageReceived(newCommissionableAdvertisement(t, "E5", "hoste.local", "D=3000", "CM=1"))
+		mon.MessageReceived(newCommissionableAdvertisement(t, "E5", "hoste.local", "D=3000", cm))
+	}
+
+	expected := []struct {
+		kind      matter.AdvertisementAnomalyKind
+		instances []string
+	}{
+		{matter.DiscriminatorCollisionAnomaly, []string{"A1._matterc._udp.local", "B2._matterc._udp.local"}},
+		{matter.RotatingIDReuseAnomaly, []string{"A1._matterc._udp.local", "D4._matterc._udp.local"}},
+		{matter.CommissioningModeFlappingAnomaly, []string{"E5._matterc._udp.local"}},
+	}
+	anomalies := mon.Anomalies()
+	if len(anomalies) != len(expected) || len(handled) != len(expected) {
+		t.Fatalf("anomalies (%v) != (%v)", anomalies, expected)
+	}
+	for n, e := range expected {
+		anomaly := anomalies[n]
+		if anomaly.Kind != e.kind {
+			t.Errorf("anomaly (%s) != (%s)", anomaly.Kind, e.kind)
+			continue
+		}
+		if len(anomaly.Instances) != len(e.instances) {
+			t.Errorf("instances (%v) != (%v)", anomaly.Instances, e.instances)
+			continue
+		}
+		for i, instance := range e.instances {
+			if anomaly.Instances[i] != instance {
+				t.Errorf("instances (%v) != (%v)", anomaly.Instances, e.instances)
+			}
+		}
+	}
+
+	// The same anomaly is flagged again only after the TTL.
+
+	mon.MessageReceived(newCommissionableAdvertisement(t, "B2", "hostb.local", "D=840", "CM=2"))
+	mon.MessageReceived(newCommissionableAdvertisement(t, "B2", "hostb.local", "D=840", "CM=1", "RI=0002CCDD"))
+	if n := len(mon.Anomalies()); n != len(expected) {
+		t.Errorf("anomalies (%d) != (%d)", n, len(expected))
+	}
+	now = now.Add(matter.DefaultMonitorTTL)
+	mon.MessageReceived(newCommissionableAdvertisement(t, "A1", "hosta.local", "D=840", "CM=1"))
+	mon.MessageReceived(newCommissionableAdvertisement(t, "B2", "hostb.local", "D=840", "CM=2"))
+	if n := len(mon.Anomalies()); n != len(expected)+1 {
+		t.Errorf("anomalies (%d) != (%d)", n, len(expected)+1)
+	}
+
+	if instances := mon.Instances(); len(instances) != 6 {
+		t.Errorf("instances (%v)", instances)
+	}
+}
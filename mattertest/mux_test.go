@@ -0,0 +1,172 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"context"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+type testDeviceSubscription struct {
+	id      im.SubscriptionID
+	req     im.SubscribeRequest
+	handler im.ReportHandler
+	closed  bool
+}
+
+func (sub *testDeviceSubscription) ID() im.SubscriptionID {
+	return sub.id
+}
+
+func (sub *testDeviceSubscription) MaxInterval() time.Duration {
+	return sub.req.MaxInterval
+}
+
+func (sub *testDeviceSubscription) Close() error {
+	sub.closed = true
+	return nil
+}
+
+// testSubscribeSession represents a session which records the device subscriptions.
+type testSubscribeSession struct {
+	*testOperationalSession
+	subs []*testDeviceSubscription
+}
+
+func (s *testSubscribeSession) Subscribe(ctx context.Context, req im.SubscribeRequest, handler im.ReportHandler) (im.Subscription, error) {
+	sub := &testDeviceSubscription{id: im.SubscriptionID(len(s.subs) + 1), req: req, handler: handler, closed: false}
+	s.subs = append(s.subs, sub)
+	return sub, nil
+}
+
+type testSubscribeEstablisher struct {
+	session *testSubscribeSession
+}
+
+func (est *testSubscribeEstablisher) EstablishSession(ctx context.Context, peer matter.OperationalPeer) (matter.OperationalSession, error) {
+	return est.session, nil
+}
+
+type testConsumer struct {
+	sync.Mutex
+	data []im.AttributeData
+}
+
+func (c *testConsumer) handle(data []im.AttributeData) {
+	c.Lock()
+	defer c.Unlock()
+	c.data = append(c.data, data...)
+}
+
+func (c *testConsumer) reset() []im.AttributeData {
+	c.Lock()
+	defer c.Unlock()
+	data := c.data
+	c.data = nil
+	return data
+}
+
+func TestSubscriptionMux(t *testing.T) {
+	ctx := context.Background()
+	s := &testSubscribeSession{
+		testOperationalSession: &testOperationalSession{
+			Mutex:   sync.Mutex{},
+			peer:    matter.OperationalPeer{},
+			err:     nil,
+			closed:  false,
+			written: map[im.AttributePath][]byte{},
+			reads:   []im.AttributePath{},
+		},
+		subs: nil,
+	}
+	com := matter.NewCommissioner(matter.WithSessionEstablisher(&testSubscribeEstablisher{session: s}))
+	dev := com.OperationalDevice(matter.OperationalPeer{FabricID: 1, CompressedFabricID: 1, NodeID: 1, Address: netip.MustParseAddrPort("[::1]:5540")})
+
+	onOff := im.NewAttributePath(1, 0x0006, 0x0000)
+	level := im.NewAttributePath(1, 0x0008, 0x0000)
+	onOffData := im.AttributeData{Path: onOff, DataVersion: 1, Data: []byte{0x09}}
+	levelData := im.AttributeData{Path: level, DataVersion: 1, Data: []byte{0x24, 0x10}}
+
+	var consumers [3]testConsumer
+	sub1, err := dev.SubscribeShared(ctx, im.SubscribeRequest{Paths: []im.AttributePath{onOff}, MinInterval: time.Second, MaxInterval: time.Minute}, consumers[0].handle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.subs) != 1 {
+		t.Fatalf("device subscriptions (%d) != (1)", len(s.subs))
+	}
+	s.subs[0].handler([]im.AttributeData{onOffData})
+
+	// A consumer of covered paths shares the device subscription and is primed with the latest data.
+	sub2, err := dev.SubscribeShared(ctx, im.SubscribeRequest{Paths: []im.AttributePath{onOff}, MinInterval: time.Second, MaxInterval: time.Minute}, consumers[1].handle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.subs) != 1 || sub1.ID() != sub2.ID() {
+		t.Fatalf("device subscription is not shared")
+	}
+	if data := consumers[1].reset(); len(data) != 1 || data[0].Path != onOff {
+		t.Errorf("priming report (%v) is invalid", data)
+	}
+
+	// A consumer of new paths replaces the device subscription with the union of the paths.
+	wildcard := im.NewAttributePath(1, 0x0008, im.WildcardAttributeID)
+	sub3, err := dev.SubscribeShared(ctx, im.SubscribeRequest{Paths: []im.AttributePath{wildcard}, MinInterval: 0, MaxInterval: 30 * time.Second}, consumers[2].handle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.subs) != 2 || !s.subs[0].closed {
+		t.Fatalf("device subscription is not replaced")
+	}
+	req := s.subs[1].req
+	if len(req.Paths) != 2 || req.MinInterval != 0 || req.MaxInterval != 30*time.Second {
+		t.Errorf("device subscription (%v) is invalid", req)
+	}
+	if sub1.MaxInterval() != 30*time.Second {
+		t.Errorf("max interval (%s) != (%s)", sub1.MaxInterval(), 30*time.Second)
+	}
+
+	// Reports are fanned out to the matching consumers, and reports of replaced subscriptions are dropped.
+	consumers[0].reset()
+	s.subs[0].handler([]im.AttributeData{onOffData})
+	s.subs[1].handler([]im.AttributeData{onOffData, levelData})
+	for i, expected := range []im.AttributePath{onOff, onOff, level} {
+		if data := consumers[i].reset(); len(data) != 1 || data[0].Path != expected {
+			t.Errorf("consumer (%d) report (%v) is invalid", i, data)
+		}
+	}
+
+	// The device subscription is kept until the last consumer closes.
+	for _, sub := range []im.Subscription{sub1, sub2} {
+		if err := sub.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s.subs[1].closed {
+		t.Errorf("device subscription is closed with consumers")
+	}
+	if err := sub3.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !s.subs[1].closed {
+		t.Errorf("device subscription is not closed")
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/cluster/networkdiagnostics"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+func TestNetworkDiagnostics(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	rssi := datatype.Int8(-60)
+	channel := datatype.Uint16(6)
+	security := datatype.Enum8(networkdiagnostics.SecurityTypeWPA3)
+	rxCount := datatype.Uint32(100)
+	role := datatype.Enum8(networkdiagnostics.RoutingRoleRouter)
+	extAddress := datatype.Uint64(0x1122334455667788)
+	lqi := datatype.Uint8(3)
+	isChild := datatype.Bool(true)
+	neighbors := datatype.NewList(nil, datatype.NewStruct(
+		datatype.NewField(0, &extAddress),
+		datatype.NewField(5, &lqi),
+		datatype.NewField(6, datatype.NewNullable(&rssi)),
+		datatype.NewField(7, datatype.NewNull(new(datatype.Int8))),
+		datatype.NewField(13, &isChild)))
+	carrier := datatype.Bool(true)
+
+	est := &testNodeEstablisher{attrs: []im.AttributeData{
+		newTestNodeAttribute(t, 1, networkdiagnostics.WiFiClusterID, im.AttributeWiFiNetworkDiagnosticsRSSI, datatype.NewNullable(&rssi)),
+		newTestNodeAttribute(t, 1, networkdiagnostics.WiFiClusterID, im.AttributeWiFiNetworkDiagnosticsChannelNumber, datatype.NewNullable(&channel)),
+		newTestNodeAttribute(t, 1, networkdiagnostics.WiFiClusterID, im.AttributeWiFiNetworkDiagnosticsSecurityType, datatype.NewNullable(&security)),
+		newTestNodeAttribute(t, 1, networkdiagnostics.WiFiClusterID, im.AttributeWiFiNetworkDiagnosticsBSSID, datatype.NewNull(new(datatype.OctetString))),
+		newTestNodeAttribute(t, 1, networkdiagnostics.WiFiClusterID, im.AttributeWiFiNetworkDiagnosticsBeaconRxCount, datatype.NewNullable(&rxCount)),
+		newTestNodeAttribute(t, 2, networkdiagnostics.ThreadClusterID, im.AttributeThreadNetworkDiagnosticsRoutingRole, datatype.NewNullable(&role)),
+		newTestNodeAttribute(t, 2, networkdiagnostics.ThreadClusterID, im.AttributeThreadNetworkDiagnosticsNeighborTable, neighbors),
+		newTestNodeAttribute(t, 3, networkdiagnostics.EthernetClusterID, im.AttributeEthernetNetworkDiagnosticsCarrierDetect, datatype.NewNullable(&carrier)),
+	}}
+	com := matter.NewCommissioner()
+	com.SetSessionEstablisher(est)
+	dev := com.OperationalDevice(matter.OperationalPeer{
+		FabricID:           1,
+		CompressedFabricID: 1,
+		NodeID:             0x1234,
+		Address:            netip.MustParseAddrPort("[::1]:5540"),
+	})
+	defer dev.Close()
+
+	wifi, err := matter.ReadWiFiDiagnostics(ctx, dev, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wifi.RSSI == nil || *wifi.RSSI != -60 || wifi.ChannelNumber == nil || *wifi.ChannelNumber != 6 {
+		t.Errorf("%v %v", wifi.RSSI, wifi.ChannelNumber)
+	}
+	if wifi.SecurityType == nil || *wifi.SecurityType != networkdiagnostics.SecurityTypeWPA3 {
+		t.Errorf("%v", wifi.SecurityType)
+	}
+	if wifi.BSSID != nil || wifi.BeaconRxCount == nil || *wifi.BeaconRxCount != 100 || wifi.OverrunCount != nil {
+		t.Errorf("%v %v %v", wifi.BSSID, wifi.BeaconRxCount, wifi.OverrunCount)
+	}
+
+	thread, err := matter.ReadThreadDiagnostics(ctx, dev, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if thread.RoutingRole == nil || *thread.RoutingRole != networkdiagnostics.RoutingRoleRouter {
+		t.Errorf("%v", thread.RoutingRole)
+	}
+	if len(thread.NeighborTable) != 1 {
+		t.Fatalf("%v", thread.NeighborTable)
+	}
+	neighbor := thread.NeighborTable[0]
+	if neighbor.ExtAddress != 0x1122334455667788 || neighbor.LQI != 3 || !neighbor.IsChild || neighbor.AverageRssi == nil || *neighbor.AverageRssi != -60 || neighbor.LastRssi != nil {
+		t.Errorf("%+v", neighbor)
+	}
+
+	ethernet, err := matter.ReadEthernetDiagnostics(ctx, dev, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ethernet.CarrierDetect == nil || !*ethernet.CarrierDetect || ethernet.PHYRate != nil {
+		t.Errorf("%v %v", ethernet.CarrierDetect, ethernet.PHYRate)
+	}
+
+	// The cluster is not found on the endpoints without it.
+
+	if _, err := matter.ReadEthernetDiagnostics(ctx, dev, 1); !errors.Is(err, matter.ErrNotFound) {
+		t.Errorf("%v is not %v", err, matter.ErrNotFound)
+	}
+}
+
+func TestResetNetworkDiagnosticsCounts(t *testing.T) {
+	ctx := context.Background()
+	invoker := &testRecordingInvoker{paths: nil, fields: nil}
+	resets := []func(context.Context, matter.CommandInvoker, im.EndpointID) error{
+		matter.ResetWiFiCounts,
+		matter.ResetThreadCounts,
+		matter.ResetEthernetCounts,
+	}
+	for _, reset := range resets {
+		if err := reset(ctx, invoker, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	clusters := []im.ClusterID{networkdiagnostics.WiFiClusterID, networkdiagnostics.ThreadClusterID, networkdiagnostics.EthernetClusterID}
+	if len(invoker.paths) != len(clusters) {
+		t.Fatalf("%v", invoker.paths)
+	}
+	for n, path := range invoker.paths {
+		if path != im.NewCommandPath(1, clusters[n], networkdiagnostics.ResetCountsCommandID) {
+			t.Errorf("%v", path)
+		}
+	}
+}
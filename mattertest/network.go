@@ -0,0 +1,329 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"math/rand"
+	"net"
+	"net/netip"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/transport"
+)
+
+const (
+	// DefaultReorderDelay is the extra delay of reordered datagrams, which lets later datagrams overtake them.
+	DefaultReorderDelay = 20 * time.Millisecond
+	networkQueueSize    = 1024
+)
+
+// NetworkStats represents the counts of the datagrams on a simulated network.
+type NetworkStats struct {
+	// Sent is the number of the datagrams written by the connections.
+	Sent int
+	// Dropped is the number of the dropped datagrams including the ones to full or closed connections.
+	Dropped int
+	// Duplicated is the number of the extra copies of duplicated datagrams.
+	Duplicated int
+	// Reordered is the number of the datagrams which are held back to be overtaken.
+	Reordered int
+	// Delivered is the number of the datagrams queued to the destination connections.
+	Delivered int
+}
+
+// NetworkOption represents an option of a simulated network.
+type NetworkOption func(*Network)
+
+// WithDropRate sets the probability that a datagram is dropped.
+func WithDropRate(p float64) NetworkOption {
+	return func(network *Network) {
+		network.dropRate = p
+	}
+}
+
+// WithDuplicateRate sets the probability that a datagram is delivered twice.
+func WithDuplicateRate(p float64) NetworkOption {
+	return func(network *Network) {
+		network.duplicateRate = p
+	}
+}
+
+// WithReorderRate sets the probability that a datagram is held back by the reorder delay.
+func WithReorderRate(p float64) NetworkOption {
+	return func(network *Network) {
+		network.reorderRate = p
+	}
+}
+
+// WithReorderDelay sets the extra delay of reordered datagrams.
+func WithReorderDelay(d time.Duration) NetworkOption {
+	return func(network *Network) {
+		network.reorderDelay = d
+	}
+}
+
+// WithDelay sets the base delay of datagrams and the maximum random jitter which is added to it.
+func WithDelay(delay time.Duration, jitter time.Duration) NetworkOption {
+	return func(network *Network) {
+		network.delay = delay
+		network.jitter = jitter
+	}
+}
+
+// WithSeed sets the seed of the impairments, so that a test is reproducible.
+func WithSeed(seed int64) NetworkOption {
+	return func(network *Network) {
+		network.rand = rand.New(rand.NewSource(seed))
+	}
+}
+
+// Network represents an in-process datagram network which drops, duplicates, reorders and delays
+// datagrams between its connections. It is used to test the session and interaction layers
+// against adverse networks without sockets.
+type Network struct {
+	sync.Mutex
+	rand          *rand.Rand
+	dropRate      float64
+	duplicateRate float64
+	reorderRate   float64
+	reorderDelay  time.Duration
+	delay         time.Duration
+	jitter        time.Duration
+	conns         map[netip.AddrPort]*NetworkConn
+	nextPort      uint16
+	stats         NetworkStats
+	pending       sync.WaitGroup
+}
+
+// NewNetwork returns a new simulated network which delivers all datagrams immediately unless
+// impairments are specified.
+func NewNetwork(opts ...NetworkOption) *Network {
+	network := &Network{
+		Mutex:         sync.Mutex{},
+		rand:          rand.New(rand.NewSource(1)),
+		dropRate:      0,
+		duplicateRate: 0,
+		reorderRate:   0,
+		reorderDelay:  DefaultReorderDelay,
+		delay:         0,
+		jitter:        0,
+		conns:         map[netip.AddrPort]*NetworkConn{},
+		nextPort:      matter.Port,
+		stats:         NetworkStats{},
+		pending:       sync.WaitGroup{},
+	}
+	for _, opt := range opts {
+		opt(network)
+	}
+	return network
+}
+
+// NewConn returns a new connection on the network with a loopback address of a new port.
+func (network *Network) NewConn() *NetworkConn {
+	network.Lock()
+	defer network.Unlock()
+	addr := netip.AddrPortFrom(netip.IPv6Loopback(), network.nextPort)
+	network.nextPort++
+	conn := &NetworkConn{
+		network:  network,
+		addr:     addr,
+		inbox:    make(chan networkDatagram, networkQueueSize),
+		closed:   make(chan struct{}),
+		wake:     make(chan struct{}, 1),
+		mutex:    sync.Mutex{},
+		deadline: time.Time{},
+		once:     sync.Once{},
+	}
+	network.conns[addr] = conn
+	return conn
+}
+
+// Stats returns the counts of the datagrams.
+func (network *Network) Stats() NetworkStats {
+	network.Lock()
+	defer network.Unlock()
+	return network.stats
+}
+
+// Wait waits until the delayed datagrams are delivered.
+func (network *Network) Wait() {
+	network.pending.Wait()
+}
+
+func (network *Network) send(b []byte, from netip.AddrPort, to netip.AddrPort) {
+	network.Lock()
+	defer network.Unlock()
+	network.stats.Sent++
+	if network.rand.Float64() < network.dropRate {
+		network.stats.Dropped++
+		return
+	}
+	copies := 1
+	if network.rand.Float64() < network.duplicateRate {
+		network.stats.Duplicated++
+		copies++
+	}
+	for n := 0; n < copies; n++ {
+		delay := network.delay
+		if 0 < network.jitter {
+			delay += time.Duration(network.rand.Int63n(int64(network.jitter)))
+		}
+		if network.rand.Float64() < network.reorderRate {
+			network.stats.Reordered++
+			delay += network.reorderDelay
+		}
+		dgram := networkDatagram{
+			b:    append([]byte{}, b...),
+			from: from,
+		}
+		if delay <= 0 {
+			network.deliver(dgram, to)
+			continue
+		}
+		network.pending.Add(1)
+		time.AfterFunc(delay, func() {
+			defer network.pending.Done()
+			network.Lock()
+			defer network.Unlock()
+			network.deliver(dgram, to)
+		})
+	}
+}
+
+func (network *Network) deliver(dgram networkDatagram, to netip.AddrPort) {
+	conn, ok := network.conns[to]
+	if !ok {
+		network.stats.Dropped++
+		return
+	}
+	select {
+	case <-conn.closed:
+		network.stats.Dropped++
+	case conn.inbox <- dgram:
+		network.stats.Delivered++
+	default:
+		network.stats.Dropped++
+	}
+}
+
+type networkDatagram struct {
+	b    []byte
+	from netip.AddrPort
+}
+
+// NetworkConn represents a datagram connection on a simulated network, which implements transport.PacketConn.
+type NetworkConn struct {
+	network  *Network
+	addr     netip.AddrPort
+	inbox    chan networkDatagram
+	closed   chan struct{}
+	wake     chan struct{}
+	mutex    sync.Mutex
+	deadline time.Time
+	once     sync.Once
+}
+
+var _ transport.PacketConn = (*NetworkConn)(nil)
+
+// AddrPort returns the address of the connection.
+func (conn *NetworkConn) AddrPort() netip.AddrPort {
+	return conn.addr
+}
+
+// ReadFromUDPAddrPort reads a datagram and returns its source address.
+func (conn *NetworkConn) ReadFromUDPAddrPort(b []byte) (int, netip.AddrPort, error) {
+	for {
+		conn.mutex.Lock()
+		deadline := conn.deadline
+		conn.mutex.Unlock()
+
+		var timer *time.Timer
+		var expired <-chan time.Time
+		if !deadline.IsZero() {
+			d := time.Until(deadline)
+			if d <= 0 {
+				return 0, netip.AddrPort{}, os.ErrDeadlineExceeded
+			}
+			timer = time.NewTimer(d)
+			expired = timer.C
+		}
+
+		n, from, ok, err := conn.read(b, expired)
+		if timer != nil {
+			timer.Stop()
+		}
+		if ok {
+			return n, from, err
+		}
+	}
+}
+
+// read returns false if the read deadline is changed while waiting.
+func (conn *NetworkConn) read(b []byte, expired <-chan time.Time) (int, netip.AddrPort, bool, error) {
+	select {
+	case dgram := <-conn.inbox:
+		return copy(b, dgram.b), dgram.from, true, nil
+	case <-conn.closed:
+		return 0, netip.AddrPort{}, true, net.ErrClosed
+	case <-expired:
+		return 0, netip.AddrPort{}, true, os.ErrDeadlineExceeded
+	case <-conn.wake:
+		return 0, netip.AddrPort{}, false, nil
+	}
+}
+
+// WriteToUDPAddrPort writes a datagram to the specified address through the network impairments.
+func (conn *NetworkConn) WriteToUDPAddrPort(b []byte, addr netip.AddrPort) (int, error) {
+	select {
+	case <-conn.closed:
+		return 0, net.ErrClosed
+	default:
+	}
+	conn.network.send(b, conn.addr, addr)
+	return len(b), nil
+}
+
+// SetReadDeadline sets the deadline for future and blocked reads.
+func (conn *NetworkConn) SetReadDeadline(t time.Time) error {
+	conn.mutex.Lock()
+	conn.deadline = t
+	conn.mutex.Unlock()
+	select {
+	case conn.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// SetWriteDeadline does nothing since writes never block.
+func (conn *NetworkConn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
+// LocalAddr returns the local address.
+func (conn *NetworkConn) LocalAddr() net.Addr {
+	return net.UDPAddrFromAddrPort(conn.addr)
+}
+
+// Close closes the connection.
+func (conn *NetworkConn) Close() error {
+	conn.once.Do(func() {
+		close(conn.closed)
+	})
+	return nil
+}
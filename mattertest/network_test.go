@@ -0,0 +1,173 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/matter/transport"
+)
+
+// drainNetworkConn reads datagrams until the connection is quiet, and returns their sequence numbers.
+func drainNetworkConn(t *testing.T, conn *NetworkConn) []uint32 {
+	t.Helper()
+	seqs := []uint32{}
+	b := make([]byte, transport.MaxMessageSize)
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(50 * time.Millisecond)); err != nil {
+			t.Fatal(err)
+		}
+		n, _, err := conn.ReadFromUDPAddrPort(b)
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			return seqs
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		seqs = append(seqs, binary.LittleEndian.Uint32(b[:n]))
+	}
+}
+
+func TestNetworkImpairments(t *testing.T) {
+	const count = 500
+
+	tests := []struct {
+		name       string
+		opts       []NetworkOption
+		dropped    bool
+		duplicated bool
+		reordered  bool
+	}{
+		{"clean", nil, false, false, false},
+		{"drop", []NetworkOption{WithDropRate(0.2)}, true, false, false},
+		{"duplicate", []NetworkOption{WithDuplicateRate(0.2)}, false, true, false},
+		{"reorder", []NetworkOption{WithReorderRate(0.2), WithReorderDelay(5 * time.Millisecond)}, false, false, true},
+		{"delay", []NetworkOption{WithDelay(time.Millisecond, time.Millisecond)}, false, false, true},
+		{"all", []NetworkOption{WithDropRate(0.1), WithDuplicateRate(0.1), WithReorderRate(0.1), WithDelay(0, time.Millisecond)}, true, true, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			network := NewNetwork(append([]NetworkOption{WithSeed(42)}, tc.opts...)...)
+			sender := network.NewConn()
+			receiver := network.NewConn()
+			defer sender.Close()
+			defer receiver.Close()
+
+			b := make([]byte, 4)
+			for n := 0; n < count; n++ {
+				binary.LittleEndian.PutUint32(b, uint32(n))
+				if _, err := sender.WriteToUDPAddrPort(b, receiver.AddrPort()); err != nil {
+					t.Fatal(err)
+				}
+			}
+			network.Wait()
+			seqs := drainNetworkConn(t, receiver)
+
+			stats := network.Stats()
+			if stats.Sent != count || stats.Delivered != len(seqs) || stats.Sent-stats.Dropped+stats.Duplicated != stats.Delivered {
+				t.Errorf("%+v (%d received)", stats, len(seqs))
+			}
+
+			seen := map[uint32]bool{}
+			duplicated := false
+			reordered := false
+			for n, seq := range seqs {
+				duplicated = duplicated || seen[seq]
+				seen[seq] = true
+				reordered = reordered || (0 < n && seq < seqs[n-1] && !duplicated)
+			}
+			if dropped := len(seen) < count; dropped != tc.dropped {
+				t.Errorf("dropped (%t) != %t", dropped, tc.dropped)
+			}
+			if duplicated != tc.duplicated {
+				t.Errorf("duplicated (%t) != %t", duplicated, tc.duplicated)
+			}
+			if reordered != tc.reordered {
+				t.Errorf("reordered (%t) != %t", reordered, tc.reordered)
+			}
+		})
+	}
+}
+
+func TestNetworkReceiver(t *testing.T) {
+	const count = 200
+
+	network := NewNetwork(
+		WithSeed(7),
+		WithDropRate(0.1),
+		WithDuplicateRate(0.1),
+		WithReorderRate(0.1),
+		WithDelay(0, time.Millisecond))
+	sender := network.NewConn()
+	conn := network.NewConn()
+	defer sender.Close()
+	defer conn.Close()
+
+	var mutex sync.Mutex
+	received := map[message.Counter]int{}
+	handler := func(msg *transport.Message) {
+		defer msg.Release()
+		mutex.Lock()
+		defer mutex.Unlock()
+		received[msg.Header.Counter]++
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- transport.NewReceiver(conn, handler, transport.WithReceiveWorkers(2), transport.WithReceiveQueueSize(2*count)).Run(ctx)
+	}()
+
+	msgs := make([]*transport.Message, count)
+	for n := range msgs {
+		header := message.NewHeader()
+		header.SessionID = 0x1234
+		header.Counter = message.Counter(n)
+		msgs[n] = transport.NewMessage(conn.AddrPort(), header, make([]byte, 32))
+	}
+	if err := transport.NewCodec(sender).TransmitBatch(ctx, msgs); err != nil {
+		t.Fatal(err)
+	}
+	network.Wait()
+
+	// Every delivered datagram is decoded and dispatched in spite of the impairments.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mutex.Lock()
+		total := 0
+		for _, n := range received {
+			total += n
+		}
+		mutex.Unlock()
+		if total == network.Stats().Delivered {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d of %d datagrams are dispatched", total, network.Stats().Delivered)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	cancel()
+	if err := <-runErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("%v is not %v", err, context.Canceled)
+	}
+}
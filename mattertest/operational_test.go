@@ -0,0 +1,703 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/internal/protocol"
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/metrics"
+	"github.com/cybergarage/go-matter/matter/securechannel"
+	"github.com/cybergarage/go-matter/matter/session"
+	"github.com/cybergarage/go-matter/matter/trace"
+	"github.com/cybergarage/go-mdns/mdns/dns"
+)
+
+type testOperationalSession struct {
+	sync.Mutex
+	peer    matter.OperationalPeer
+	err     error
+	closed  bool
+	written map[im.AttributePath][]byte
+	reads   []im.AttributePath
+}
+
+func (s *testOperationalSession) fail(err error) {
+	s.Lock()
+	defer s.Unlock()
+	s.err = err
+}
+
+func (s *testOperationalSession) check() error {
+	s.Lock()
+	defer s.Unlock()
+	if s.closed {
+		return session.ErrClosed
+	}
+	return s.err
+}
+
+func (s *testOperationalSession) ReadAttribute(ctx context.Context, path im.AttributePath) ([]im.AttributeData, error) {
+	if err := s.check(); err != nil {
+		return nil, err
+	}
+	s.reads = append(s.reads, path)
+	return []im.AttributeData{{Path: path, DataVersion: 1, Data: s.written[path]}}, nil
+}
+
+func (s *testOperationalSession) WriteAttribute(ctx context.Context, path im.AttributePath, data []byte) error {
+	if err := s.check(); err != nil {
+		return err
+	}
+	s.written[path] = data
+	return nil
+}
+
+func (s *testOperationalSession) InvokeCommand(ctx context.Context, path im.CommandPath, fields []byte) ([]byte, error) {
+	if err := s.check(); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func (s *testOperationalSession) Subscribe(ctx context.Context, req im.SubscribeRequest, handler im.ReportHandler) (im.Subscription, error) {
+	return nil, s.check()
+}
+
+func (s *testOperationalSession) Close() error {
+	s.Lock()
+	defer s.Unlock()
+	s.closed = true
+	return nil
+}
+
+type testSessionEstablisher struct {
+	sessions []*testOperationalSession
+	err      error
+}
+
+func (est *testSessionEstablisher) EstablishSession(ctx context.Context, peer matter.OperationalPeer) (matter.OperationalSession, error) {
+	if est.err != nil {
+		return nil, est.err
+	}
+	s := &testOperationalSession{
+		Mutex:   sync.Mutex{},
+		peer:    peer,
+		err:     nil,
+		closed:  false,
+		written: map[im.AttributePath][]byte{},
+		reads:   []im.AttributePath{},
+	}
+	est.sessions = append(est.sessions, s)
+	return s, nil
+}
+
+func TestOperationalDevice(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	est := &testSessionEstablisher{sessions: nil, err: nil}
+	com := matter.NewCommissioner()
+	com.SetSessionEstablisher(est)
+
+	peer := matter.OperationalPeer{
+		FabricID:           1,
+		CompressedFabricID: 1,
+		NodeID:             0x1234,
+		Address:            netip.MustParseAddrPort("[::1]:5540"),
+	}
+	dev := com.OperationalDevice(peer)
+
+	path := im.NewAttributePath(1, 0x0006, 0x0000)
+	if err := dev.WriteAttribute(ctx, path, []byte{0x09}); err != nil {
+		t.Fatal(err)
+	}
+	if len(est.sessions) != 1 {
+		t.Fatalf("sessions (%d) != (1)", len(est.sessions))
+	}
+
+	// Expired sessions are re-established transparently.
+	est.sessions[0].fail(session.ErrExpired)
+	res, err := dev.InvokeCommand(ctx, im.NewCommandPath(1, 0x0006, 0x0001), []byte{0x15, 0x18})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 2 {
+		t.Errorf("response (%X) is not echoed", res)
+	}
+	if len(est.sessions) != 2 || !est.sessions[0].closed {
+		t.Fatalf("expired session is not replaced")
+	}
+	if stats := dev.Stats(); stats.Retransmissions != 1 || stats.MessagesSent != 3 || stats.MessagesReceived != 2 {
+		t.Errorf("%+v", stats)
+	}
+
+	// Other errors are returned as is.
+	errTest := errors.New("test")
+	est.sessions[1].fail(errTest)
+	if _, err := dev.ReadAttribute(ctx, path); !errors.Is(err, errTest) {
+		t.Errorf("%v is not %v", err, errTest)
+	}
+	est.sessions[1].fail(nil)
+
+	// Address changes close the current session.
+	addr := netip.MustParseAddrPort("[fd00::1]:5540")
+	if err := dev.SetAddress(addr); err != nil {
+		t.Fatal(err)
+	}
+	data, err := dev.ReadAttribute(ctx, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 1 || data[0].Path != path {
+		t.Errorf("unexpected report (%v)", data)
+	}
+	if len(est.sessions) != 3 || est.sessions[2].peer.Address != addr {
+		t.Fatalf("session is not established to the new address")
+	}
+
+	state := dev.Debug()
+	if !state.Connected || state.Closed || state.Peer.Address != addr || state.Stats != dev.Stats() {
+		t.Errorf("%+v", state)
+	}
+	if peers := com.Debug().Peers; len(peers) != 1 || peers[0].NodeID != peer.NodeID || peers[0].Stats != dev.Stats() {
+		t.Errorf("%+v", peers)
+	}
+
+	if err := dev.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if state := dev.Debug(); state.Connected || !state.Closed {
+		t.Errorf("%+v", state)
+	}
+	if _, err := dev.ReadAttribute(ctx, path); !errors.Is(err, matter.ErrClosed) {
+		t.Errorf("%v is not %v", err, matter.ErrClosed)
+	}
+}
+
+func TestOperationalDeviceWithoutEstablisher(t *testing.T) {
+	dev := matter.NewCommissioner().OperationalDevice(matter.OperationalPeer{FabricID: 1, CompressedFabricID: 1, NodeID: 1, Address: netip.AddrPort{}})
+	if _, err := dev.ReadAttribute(context.Background(), im.NewAttributePath(0, 0x0028, 0x0000)); !errors.Is(err, matter.ErrNotSupported) {
+		t.Errorf("%v is not %v", err, matter.ErrNotSupported)
+	}
+	// A handshake which is not supported has not failed with the node.
+	if stats := dev.Stats(); stats.FailedHandshakes != 0 {
+		t.Errorf("handshake failures (%d) != (%d)", stats.FailedHandshakes, 0)
+	}
+}
+
+type testOperationalResolver struct {
+	addr     netip.AddrPort
+	resolved int
+}
+
+func (resolver *testOperationalResolver) ResolveOperational(ctx context.Context, peer matter.OperationalPeer) (netip.AddrPort, error) {
+	resolver.resolved++
+	return resolver.addr, nil
+}
+
+type testTimeoutEstablisher struct {
+	*testSessionEstablisher
+	reachable netip.AddrPort
+	attempts  int
+}
+
+func (est *testTimeoutEstablisher) EstablishSession(ctx context.Context, peer matter.OperationalPeer) (matter.OperationalSession, error) {
+	est.attempts++
+	if peer.Address != est.reachable {
+		return nil, session.ErrTimeout
+	}
+	return est.testSessionEstablisher.EstablishSession(ctx, peer)
+}
+
+type testRetransmitTracer struct {
+	trace.NullTracer
+	events []*trace.RetransmitEvent
+}
+
+func (tracer *testRetransmitTracer) TraceRetransmit(e *trace.RetransmitEvent) {
+	tracer.events = append(tracer.events, e)
+}
+
+type testOperationalRecorder struct {
+	metrics.NullRecorder
+	ids        []protocol.ProtocolID
+	handshakes []error
+}
+
+func (recorder *testOperationalRecorder) MessageRetransmitted(id protocol.ProtocolID) {
+	recorder.ids = append(recorder.ids, id)
+}
+
+func (recorder *testOperationalRecorder) HandshakeCompleted(handshake metrics.Handshake, d time.Duration, err error) {
+	if handshake == metrics.CASEHandshake {
+		recorder.handshakes = append(recorder.handshakes, err)
+	}
+}
+
+func TestOperationalDeviceReconnect(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	oldAddr := netip.MustParseAddrPort("[fd00::1]:5540")
+	newAddr := netip.MustParseAddrPort("[fd00::2]:5540")
+	policy := matter.ReconnectPolicy{
+		InitialInterval: time.Millisecond,
+		MaxInterval:     4 * time.Millisecond,
+		MaxAttempts:     3,
+	}
+	peer := matter.OperationalPeer{FabricID: 1, CompressedFabricID: 0x87E1B004E235A130, NodeID: 0x8FC7772401CD0696, Address: oldAddr}
+	path := im.NewAttributePath(0, 0x0028, 0x0000)
+
+	t.Run("re-resolve", func(t *testing.T) {
+		tracer := &testRetransmitTracer{events: nil}
+		trace.SetSharedTracer(tracer)
+		defer trace.SetSharedTracer(nil)
+		recorder := &testOperationalRecorder{ids: nil, handshakes: nil}
+		metrics.SetSharedRecorder(recorder)
+		defer metrics.SetSharedRecorder(nil)
+
+		est := &testTimeoutEstablisher{
+			testSessionEstablisher: &testSessionEstablisher{sessions: nil, err: nil},
+			reachable:              newAddr,
+			attempts:               0,
+		}
+		resolver := &testOperationalResolver{addr: newAddr, resolved: 0}
+		com := matter.NewCommissioner(
+			matter.WithSessionEstablisher(est),
+			matter.WithOperationalResolver(resolver),
+			matter.WithReconnectPolicy(policy),
+		)
+		dev := com.OperationalDevice(peer)
+		if _, err := dev.ReadAttribute(ctx, path); err != nil {
+			t.Fatal(err)
+		}
+		if est.attempts != 2 || resolver.resolved != 1 {
+			t.Errorf("attempts (%d) and resolutions (%d) != (2, 1)", est.attempts, resolver.resolved)
+		}
+		stats, ok := com.NodeStats(peer.NodeID)
+		if !ok {
+			t.Fatalf("node (%016X) has no statistics", uint64(peer.NodeID))
+		}
+		if stats.FailedHandshakes != 1 || stats.MessagesSent != 1 || stats.MessagesReceived != 1 || stats.LastSeen.IsZero() {
+			t.Errorf("%+v", stats)
+		}
+		if dev.Peer().Address != newAddr {
+			t.Errorf("%s != %s", dev.Peer().Address, newAddr)
+		}
+		if len(tracer.events) != 1 || tracer.events[0].Peer != newAddr.String() || tracer.events[0].Attempt != 1 {
+			t.Errorf("retransmit events %+v", tracer.events)
+		}
+		if len(recorder.ids) != 1 || recorder.ids[0] != im.ProtocolID {
+			t.Errorf("retransmitted protocols %v", recorder.ids)
+		}
+		if len(recorder.handshakes) != 2 || !errors.Is(recorder.handshakes[0], session.ErrTimeout) || recorder.handshakes[1] != nil {
+			t.Errorf("handshakes %v", recorder.handshakes)
+		}
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		est := &testTimeoutEstablisher{
+			testSessionEstablisher: &testSessionEstablisher{sessions: nil, err: nil},
+			reachable:              newAddr,
+			attempts:               0,
+		}
+		resolver := &testOperationalResolver{addr: oldAddr, resolved: 0}
+		com := matter.NewCommissioner(
+			matter.WithSessionEstablisher(est),
+			matter.WithOperationalResolver(resolver),
+			matter.WithReconnectPolicy(policy),
+		)
+		dev := com.OperationalDevice(peer)
+		if _, err := dev.ReadAttribute(ctx, path); !errors.Is(err, session.ErrTimeout) {
+			t.Errorf("%v is not %v", err, session.ErrTimeout)
+		}
+		if est.attempts != policy.MaxAttempts+1 {
+			t.Errorf("attempts (%d) != (%d)", est.attempts, policy.MaxAttempts+1)
+		}
+	})
+}
+
+type testMovableSession struct {
+	*testOperationalSession
+	addr        netip.AddrPort
+	unreachable map[netip.AddrPort]bool
+	moves       int
+}
+
+func (s *testMovableSession) SetPeerAddress(addr netip.AddrPort) error {
+	s.addr = addr
+	s.moves++
+	return nil
+}
+
+func (s *testMovableSession) ReadAttribute(ctx context.Context, path im.AttributePath) ([]im.AttributeData, error) {
+	if s.unreachable[s.addr] {
+		return nil, session.ErrTimeout
+	}
+	return s.testOperationalSession.ReadAttribute(ctx, path)
+}
+
+type testFailoverEstablisher struct {
+	*testSessionEstablisher
+	unreachable map[netip.AddrPort]bool
+	attempts    []netip.AddrPort
+	movable     []*testMovableSession
+}
+
+func (est *testFailoverEstablisher) EstablishSession(ctx context.Context, peer matter.OperationalPeer) (matter.OperationalSession, error) {
+	est.attempts = append(est.attempts, peer.Address)
+	if est.unreachable[peer.Address] {
+		return nil, session.ErrTimeout
+	}
+	s, err := est.testSessionEstablisher.EstablishSession(ctx, peer)
+	if err != nil {
+		return nil, err
+	}
+	movable := &testMovableSession{
+		testOperationalSession: s.(*testOperationalSession),
+		addr:                   peer.Address,
+		unreachable:            est.unreachable,
+		moves:                  0,
+	}
+	est.movable = append(est.movable, movable)
+	return movable, nil
+}
+
+func TestOperationalDeviceFailover(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	linkLocal := netip.MustParseAddrPort("[fe80::1%eth0]:5540")
+	ula := netip.MustParseAddrPort("[fd00::1]:5540")
+	gua := netip.MustParseAddrPort("[2001:db8::1]:5540")
+	scopes := map[netip.AddrPort]matter.AddressScope{
+		linkLocal: matter.LinkLocalScope,
+		ula:       matter.UniqueLocalScope,
+		gua:       matter.GlobalScope,
+	}
+
+	est := &testFailoverEstablisher{
+		testSessionEstablisher: &testSessionEstablisher{sessions: nil, err: nil},
+		unreachable:            map[netip.AddrPort]bool{linkLocal: true, ula: true},
+		attempts:               nil,
+		movable:                nil,
+	}
+	policy := matter.ReconnectPolicy{
+		InitialInterval: time.Hour,
+		MaxInterval:     time.Hour,
+		MaxAttempts:     1,
+	}
+	com := matter.NewCommissioner(
+		matter.WithSessionEstablisher(est),
+		matter.WithReconnectPolicy(policy),
+	)
+	peer := matter.OperationalPeer{FabricID: 1, CompressedFabricID: 1, NodeID: 0x1234, Address: linkLocal}
+	dev := com.OperationalDevice(peer)
+	dev.AddAddresses(ula, gua, linkLocal)
+
+	for _, addr := range dev.Addresses() {
+		if addr.Scope != scopes[addr.Addr] {
+			t.Errorf("%s scope (%s) != (%s)", addr.Addr, addr.Scope, scopes[addr.Addr])
+		}
+	}
+
+	// The session is established at the first reachable address without backoff.
+
+	path := im.NewAttributePath(0, 0x0028, 0x0000)
+	if _, err := dev.ReadAttribute(ctx, path); err != nil {
+		t.Fatal(err)
+	}
+	if len(est.attempts) != 3 || est.attempts[2] != gua || dev.Peer().Address != gua {
+		t.Fatalf("attempts (%v) are not failed over to (%s)", est.attempts, gua)
+	}
+	addrs := dev.Addresses()
+	if len(addrs) != 3 || addrs[0].Addr != gua || addrs[0].Failures != 0 || addrs[0].Successes != 2 ||
+		addrs[1].Addr != linkLocal || addrs[1].Failures != 1 || addrs[1].Interface() != "eth0" ||
+		addrs[2].Addr != ula || addrs[2].Failures != 1 {
+		t.Errorf("addresses (%v) are not ordered by reachability", addrs)
+	}
+
+	// The session moves to the next address when the node stops responding at the current one.
+
+	est.unreachable[gua] = true
+	delete(est.unreachable, ula)
+	if _, err := dev.ReadAttribute(ctx, path); err != nil {
+		t.Fatal(err)
+	}
+	s := est.movable[0]
+	if len(est.movable) != 1 || s.addr != ula || s.closed || dev.Peer().Address != ula {
+		t.Errorf("session (%s) is not moved to (%s)", s.addr, ula)
+	}
+	if s.moves != 2 {
+		t.Errorf("moves (%d) != (2)", s.moves)
+	}
+	if best := dev.Debug().Addresses[0]; best.Addr != ula {
+		t.Errorf("%s != %s", best.Addr, ula)
+	}
+
+	// All addresses failing end with the error after the retries.
+
+	est.unreachable[ula] = true
+	if _, err := dev.ReadAttribute(ctx, path); !errors.Is(err, session.ErrTimeout) {
+		t.Errorf("%v is not %v", err, session.ErrTimeout)
+	}
+}
+
+type testBusyEstablisher struct {
+	*testSessionEstablisher
+	busy     int
+	attempts int
+}
+
+func (est *testBusyEstablisher) EstablishSession(ctx context.Context, peer matter.OperationalPeer) (matter.OperationalSession, error) {
+	est.attempts++
+	if est.attempts <= est.busy {
+		return nil, securechannel.NewBusyStatusReport(time.Millisecond).Err()
+	}
+	return est.testSessionEstablisher.EstablishSession(ctx, peer)
+}
+
+func TestOperationalDeviceBusy(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	peer := matter.OperationalPeer{FabricID: 1, CompressedFabricID: 1, NodeID: 0x1234, Address: netip.MustParseAddrPort("[fd00::1]:5540")}
+	path := im.NewAttributePath(0, 0x0028, 0x0000)
+
+	est := &testBusyEstablisher{
+		testSessionEstablisher: &testSessionEstablisher{sessions: nil, err: nil},
+		busy:                   2,
+		attempts:               0,
+	}
+	dev := matter.NewCommissioner(matter.WithSessionEstablisher(est)).OperationalDevice(peer)
+	if _, err := dev.ReadAttribute(ctx, path); err != nil {
+		t.Fatal(err)
+	}
+	if est.attempts != 3 || len(est.sessions) != 1 {
+		t.Errorf("attempts (%d) != (3)", est.attempts)
+	}
+
+	// The busy status is returned when the busy retries are disabled.
+	policy := matter.DefaultReconnectPolicy()
+	policy.BusyRetries = 0
+	est = &testBusyEstablisher{
+		testSessionEstablisher: &testSessionEstablisher{sessions: nil, err: nil},
+		busy:                   1,
+		attempts:               0,
+	}
+	dev = matter.NewCommissioner(matter.WithSessionEstablisher(est), matter.WithReconnectPolicy(policy)).OperationalDevice(peer)
+	if _, err := dev.ReadAttribute(ctx, path); !errors.Is(err, securechannel.ErrBusy) {
+		t.Errorf("%v is not %v", err, securechannel.ErrBusy)
+	}
+}
+
+type testBlockingEstablisher struct {
+	*testSessionEstablisher
+	sync.Mutex
+	started  chan struct{}
+	release  chan struct{}
+	attempts int
+}
+
+func (est *testBlockingEstablisher) EstablishSession(ctx context.Context, peer matter.OperationalPeer) (matter.OperationalSession, error) {
+	est.Lock()
+	est.attempts++
+	if est.attempts == 1 {
+		close(est.started)
+	}
+	est.Unlock()
+	select {
+	case <-est.release:
+		return est.testSessionEstablisher.EstablishSession(ctx, peer)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func newTestBlockingEstablisher() *testBlockingEstablisher {
+	return &testBlockingEstablisher{
+		testSessionEstablisher: &testSessionEstablisher{sessions: nil, err: nil},
+		Mutex:                  sync.Mutex{},
+		started:                make(chan struct{}),
+		release:                make(chan struct{}),
+		attempts:               0,
+	}
+}
+
+func TestOperationalDeviceSessionUnlocked(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	peer := matter.OperationalPeer{FabricID: 1, CompressedFabricID: 0x87E1B004E235A130, NodeID: 0x8FC7772401CD0696, Address: netip.MustParseAddrPort("[fd00::1]:5540")}
+
+	t.Run("shared", func(t *testing.T) {
+		est := newTestBlockingEstablisher()
+		dev := matter.NewOperationalDevice(peer, est)
+		sessions := make(chan matter.OperationalSession, 2)
+		for range 2 {
+			go func() {
+				s, err := dev.Session(ctx)
+				if err != nil {
+					t.Error(err)
+				}
+				sessions <- s
+			}()
+		}
+		<-est.started
+
+		// The handle is not locked during the handshake.
+		if dev.Peer() != peer {
+			t.Errorf("%s != %s", dev.Peer(), peer)
+		}
+		_ = dev.Stats()
+		_ = dev.Debug()
+		if err := dev.SetAddress(peer.Address); err != nil {
+			t.Error(err)
+		}
+
+		close(est.release)
+		if s0, s1 := <-sessions, <-sessions; s0 == nil || s0 != s1 {
+			t.Errorf("sessions (%v, %v) are not shared", s0, s1)
+		}
+		if est.attempts != 1 {
+			t.Errorf("attempts (%d) != (%d)", est.attempts, 1)
+		}
+	})
+
+	t.Run("close", func(t *testing.T) {
+		est := newTestBlockingEstablisher()
+		dev := matter.NewOperationalDevice(peer, est)
+		errs := make(chan error, 1)
+		go func() {
+			_, err := dev.Session(ctx)
+			errs <- err
+		}()
+		<-est.started
+		if err := dev.Close(); err != nil {
+			t.Error(err)
+		}
+		if err := <-errs; !errors.Is(err, matter.ErrClosed) {
+			t.Errorf("%v is not %v", err, matter.ErrClosed)
+		}
+	})
+}
+
+func TestReconnectPolicy(t *testing.T) {
+	policy := matter.ReconnectPolicy{
+		InitialInterval: time.Second,
+		MaxInterval:     5 * time.Second,
+		MaxAttempts:     10,
+	}
+	expecteds := []time.Duration{0, 0, time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for retry, expected := range expecteds {
+		if d := policy.Interval(retry); d != expected {
+			t.Errorf("retry (%d) interval (%s) != (%s)", retry, d, expected)
+		}
+	}
+}
+
+func TestDNSSDOperationalResolver(t *testing.T) {
+	peer := matter.OperationalPeer{FabricID: 1, CompressedFabricID: 0x87E1B004E235A130, NodeID: 0x8FC7772401CD0696, Address: netip.AddrPort{}}
+	if peer.InstanceName() != "87E1B004E235A130-8FC7772401CD0696" {
+		t.Errorf("instance name (%s) is invalid", peer.InstanceName())
+	}
+
+	service := "_matter._tcp.local"
+	instance := peer.InstanceName() + "." + service
+	host := "0E8B3C9A1F2D4E5B.local"
+	ip := net.ParseIP("fd00::10")
+	msg := newDNSSDMessage()
+	msg.addPTR(service, instance)
+	msg.addSRV(instance, 5540, host)
+	msg.addAAAA(host, ip)
+	res, err := dns.NewMessageWithBytes(msg.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	com := matter.NewCommissioner()
+	if _, err := com.Discoverer.Client.MessageReceived(res); err != nil {
+		t.Fatal(err)
+	}
+	resolver := matter.NewDNSSDOperationalResolver(com.Discoverer)
+	addr, ok := resolver.LookupOperational(peer)
+	if !ok {
+		t.Fatalf("%s is not found", peer.InstanceName())
+	}
+	if addr != netip.MustParseAddrPort("[fd00::10]:5540") {
+		t.Errorf("%s is invalid", addr)
+	}
+
+	peer.NodeID++
+	if _, ok := resolver.LookupOperational(peer); ok {
+		t.Errorf("%s is found", peer.InstanceName())
+	}
+}
+
+func TestDNSSDOperationalResolverHostFallback(t *testing.T) {
+	peer := matter.OperationalPeer{FabricID: 1, CompressedFabricID: 0x87E1B004E235A130, NodeID: 0x8FC7772401CD0696, Address: netip.AddrPort{}}
+
+	received := func(com *matter.Commissioner, msg *dnssdMessage) {
+		t.Helper()
+		res, err := dns.NewMessageWithBytes(msg.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := com.Discoverer.Client.MessageReceived(res); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The SRV target host is answered without the AAAA and A records.
+	service := "_matter._tcp.local"
+	instance := peer.InstanceName() + "." + service
+	host := "0E8B3C9A1F2D4E5B.local"
+	com := matter.NewCommissioner()
+	srvMsg := newDNSSDMessage()
+	srvMsg.addPTR(service, instance)
+	srvMsg.addSRV(instance, 5540, host)
+	received(com, srvMsg)
+
+	resolver := matter.NewDNSSDOperationalResolver(com.Discoverer)
+	if addrs, ok := resolver.LookupOperationalAddrs(peer); ok {
+		t.Fatalf("%v is found", addrs)
+	}
+
+	// The answers to the follow-up host query are merged.
+	hostMsg := newDNSSDMessage()
+	hostMsg.addA("0e8b3c9a1f2d4e5b.local", net.ParseIP("192.168.1.10"))
+	hostMsg.addAAAA("0e8b3c9a1f2d4e5b.local", net.ParseIP("fd00::10"))
+	hostMsg.addAAAA("OtherHost.local", net.ParseIP("fd00::20"))
+	received(com, hostMsg)
+
+	if addrs := com.Discoverer.LookupHostAddrs(host); len(addrs) != 2 {
+		t.Errorf("%v is invalid", addrs)
+	}
+	addrs, ok := resolver.LookupOperationalAddrs(peer)
+	if !ok {
+		t.Fatalf("%s is not found", peer.InstanceName())
+	}
+	if len(addrs) != 2 || addrs[0] != netip.MustParseAddrPort("[fd00::10]:5540") || addrs[1] != netip.MustParseAddrPort("192.168.1.10:5540") {
+		t.Errorf("%v is invalid", addrs)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"testing"
+
+	"github.com/cybergarage/go-matter/internal/message"
+	"github.com/cybergarage/go-matter/internal/protocol"
+	"github.com/cybergarage/go-matter/matter/pase"
+	"github.com/cybergarage/go-matter/mattertest/fixture"
+)
+
+func lookupPASEPayload(t *testing.T, name string) []byte {
+	t.Helper()
+	capture, err := fixture.LookupCapture(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, err := message.NewHeaderFromBytes(capture.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := protocol.NewMessageFromBytes(capture.Bytes[header.Size():])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg.Payload()
+}
+
+func TestPASECaptures(t *testing.T) {
+	req, err := pase.NewPBKDFParamRequestFromBytes(lookupPASEPayload(t, "pase-pbkdfparamrequest"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.InitiatorSessionID != 0x3A5C || req.PasscodeID != pase.DefaultPasscodeID || req.HasPBKDFParams {
+		t.Errorf("%v", req)
+	}
+
+	tests := []struct {
+		name       string
+		iterations uint32
+	}{
+		{"pase-pbkdfparamresponse", 1000},
+		{"pase-pbkdfparamresponse-iterations32", 100000},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := pase.NewPBKDFParamResponseFromBytes(lookupPASEPayload(t, test.name), pase.DefaultPBKDFPolicy())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.ResponderSessionID != 0x8F21 || res.PBKDFParams == nil {
+				t.Fatalf("%v", res)
+			}
+			if res.PBKDFParams.Iterations != test.iterations {
+				t.Errorf("%d != %d", res.PBKDFParams.Iterations, test.iterations)
+			}
+			if string(res.PBKDFParams.Salt) != "SPAKE2P Key Salt" {
+				t.Errorf("%s", res.PBKDFParams.Salt)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"context"
+	"net/netip"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/cluster/powersource"
+	"github.com/cybergarage/go-matter/matter/im"
+)
+
+func TestPowerSource(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	cluster := powersource.NewCluster("Battery", powersource.WithReplaceable("CR2032", 1), powersource.WithEndpoints(1))
+	if err := cluster.SetBatteryPercentRemaining(15); err != nil {
+		t.Fatal(err)
+	}
+	if err := cluster.SetBatteryChargeLevel(powersource.ChargeLevelWarning); err != nil {
+		t.Fatal(err)
+	}
+
+	// The node reports the attributes of the cluster server.
+
+	attrs := []im.AttributeData{}
+	for _, id := range cluster.AttributeIDs() {
+		v, err := cluster.ReadAttribute(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		attrs = append(attrs, newTestNodeAttribute(t, 0, powersource.ClusterID, id, v))
+	}
+	com := matter.NewCommissioner()
+	com.SetSessionEstablisher(&testNodeEstablisher{attrs: attrs})
+	dev := com.OperationalDevice(matter.OperationalPeer{
+		FabricID:           1,
+		CompressedFabricID: 1,
+		NodeID:             0x1234,
+		Address:            netip.MustParseAddrPort("[::1]:5540"),
+	})
+	defer dev.Close()
+
+	src, err := matter.ReadPowerSource(ctx, dev, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !src.IsBattery() || src.Status == nil || *src.Status != powersource.StatusActive {
+		t.Errorf("%v %v", src.IsBattery(), src.Status)
+	}
+	if percent, ok := src.BatteryPercentRemaining(); !ok || percent != 15 {
+		t.Errorf("battery percentage (%g)", percent)
+	}
+	if src.BatChargeLevel == nil || *src.BatChargeLevel != powersource.ChargeLevelWarning {
+		t.Errorf("%v", src.BatChargeLevel)
+	}
+	if src.BatVoltage != nil || src.WiredCurrentType != nil || src.BatChargeState != nil {
+		t.Errorf("%v %v %v", src.BatVoltage, src.WiredCurrentType, src.BatChargeState)
+	}
+	if src.BatReplacementDescription == nil || *src.BatReplacementDescription != "CR2032" {
+		t.Errorf("%v", src.BatReplacementDescription)
+	}
+	if !slices.Equal(src.EndpointList, []im.EndpointID{1}) {
+		t.Errorf("%v", src.EndpointList)
+	}
+}
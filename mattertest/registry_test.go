@@ -0,0 +1,232 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/cluster/label"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/storage"
+)
+
+// testNodeSession answers wildcard reads with the attributes of a light node.
+type testNodeSession struct {
+	*testOperationalSession
+	attrs []im.AttributeData
+}
+
+func (s *testNodeSession) ReadAttribute(ctx context.Context, path im.AttributePath) ([]im.AttributeData, error) {
+	if err := s.check(); err != nil {
+		return nil, err
+	}
+	data := []im.AttributeData{}
+	for _, attr := range s.attrs {
+		if path.Match(attr.Path) {
+			data = append(data, attr)
+		}
+	}
+	return data, nil
+}
+
+type testNodeEstablisher struct {
+	attrs []im.AttributeData
+}
+
+func (est *testNodeEstablisher) EstablishSession(ctx context.Context, peer matter.OperationalPeer) (matter.OperationalSession, error) {
+	return &testNodeSession{
+		testOperationalSession: &testOperationalSession{
+			Mutex:   sync.Mutex{},
+			peer:    peer,
+			err:     nil,
+			closed:  false,
+			written: map[im.AttributePath][]byte{},
+			reads:   []im.AttributePath{},
+		},
+		attrs: est.attrs,
+	}, nil
+}
+
+func newTestNodeAttribute(t *testing.T, ep im.EndpointID, cluster im.ClusterID, attr im.AttributeID, v datatype.Value) im.AttributeData {
+	t.Helper()
+	b, err := datatype.Encode(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return im.AttributeData{Path: im.NewAttributePath(ep, cluster, attr), DataVersion: 1, Data: b}
+}
+
+func newTestDeviceTypeList(dts ...uint32) datatype.Value {
+	list := datatype.NewList(nil)
+	for _, dt := range dts {
+		v := datatype.Uint32(dt)
+		rev := datatype.Uint16(1)
+		list.Elements = append(list.Elements, datatype.NewStruct(
+			datatype.NewField(0, &v),
+			datatype.NewField(1, &rev)))
+	}
+	return list
+}
+
+func newTestServerList(clusters ...uint32) datatype.Value {
+	list := datatype.NewList(nil)
+	for _, c := range clusters {
+		v := datatype.Uint32(c)
+		list.Elements = append(list.Elements, &v)
+	}
+	return list
+}
+
+func TestNodeRegistry(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	vendorID := datatype.Uint16(0xFFF1)
+	productID := datatype.Uint16(0x8001)
+	est := &testNodeEstablisher{
+		attrs: []im.AttributeData{
+			newTestNodeAttribute(t, 0, im.ClusterBasicInformation, im.AttributeBasicInformationVendorID, &vendorID),
+			newTestNodeAttribute(t, 0, im.ClusterBasicInformation, im.AttributeBasicInformationProductID, &productID),
+			newTestNodeAttribute(t, 0, im.ClusterDescriptor, im.AttributeDescriptorDeviceTypeList, newTestDeviceTypeList(0x0016)),
+			newTestNodeAttribute(t, 0, im.ClusterDescriptor, im.AttributeDescriptorServerList, newTestServerList(0x001D, 0x0028)),
+			newTestNodeAttribute(t, 1, im.ClusterDescriptor, im.AttributeDescriptorDeviceTypeList, newTestDeviceTypeList(0x0100)),
+			newTestNodeAttribute(t, 1, im.ClusterDescriptor, im.AttributeDescriptorServerList, newTestServerList(0x001D, 0x0006, 0x0040, 0x0041)),
+			newTestNodeAttribute(t, 1, label.FixedLabelClusterID, label.LabelListAttributeID, label.NewLabelList([]label.Label{label.NewLabel("room", "hall")})),
+			newTestNodeAttribute(t, 1, label.UserLabelClusterID, label.LabelListAttributeID, label.NewLabelList([]label.Label{label.NewLabel("room", "kitchen")})),
+		},
+	}
+	com := matter.NewCommissioner()
+	com.SetSessionEstablisher(est)
+
+	peer := matter.OperationalPeer{
+		FabricID:           1,
+		CompressedFabricID: 0x87E1B004E235A130,
+		NodeID:             0x1234,
+		Address:            netip.MustParseAddrPort("[fd00::1]:5540"),
+	}
+
+	store := storage.NewMemoryStore()
+	reg := matter.NewNodeRegistry(store)
+	if _, err := reg.Lookup(peer); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("%v is not %v", err, storage.ErrNotFound)
+	}
+
+	record, err := reg.Refresh(ctx, com.OperationalDevice(peer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.VendorID != 0xFFF1 || record.ProductID != 0x8001 {
+		t.Errorf("vendor/product (%04X/%04X) != (FFF1/8001)", record.VendorID, record.ProductID)
+	}
+	if dts := record.DeviceTypes(); !slices.Equal(dts, []matter.DeviceType{0x0016, 0x0100}) {
+		t.Errorf("device types %v", dts)
+	}
+	if record.CommissionedAt.IsZero() || record.LastSeen.IsZero() {
+		t.Errorf("times are not recorded")
+	}
+
+	if err := reg.SetLabel(peer, "kitchen"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Records are read back from the store, and the label survives refreshes.
+	if _, err := matter.NewNodeRegistry(store).Refresh(ctx, com.OperationalDevice(peer)); err != nil {
+		t.Fatal(err)
+	}
+	reg = matter.NewNodeRegistry(store)
+	got, err := reg.LookupByName("kitchen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Peer != peer || got.Name() != "kitchen" {
+		t.Errorf("%+v", got.Peer)
+	}
+	if len(got.Endpoints) != 2 {
+		t.Fatalf("endpoints (%d) != (2)", len(got.Endpoints))
+	}
+	if ep := got.Endpoints[1]; ep.ID != 1 || !slices.Equal(ep.Clusters, []im.ClusterID{0x001D, 0x0006, 0x0040, 0x0041}) || !slices.Equal(ep.DeviceTypes, []matter.DeviceType{0x0100}) {
+		t.Errorf("%+v", ep)
+	}
+	if room, ok := got.Endpoints[1].Label("room"); !ok || room != "kitchen" {
+		t.Errorf("room (%s) != (kitchen)", room)
+	}
+	if len(got.Endpoints[0].FixedLabels) != 0 || len(got.Endpoints[1].FixedLabels) != 1 {
+		t.Errorf("fixed labels %v %v", got.Endpoints[0].FixedLabels, got.Endpoints[1].FixedLabels)
+	}
+	if !got.CommissionedAt.Equal(record.CommissionedAt.Truncate(time.Microsecond)) {
+		t.Errorf("commissioned time (%v) != (%v)", got.CommissionedAt, record.CommissionedAt)
+	}
+
+	other := peer
+	other.NodeID = 0x0001
+	if err := reg.Touch(other); err != nil {
+		t.Fatal(err)
+	}
+	records, err := reg.Records()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].Peer.NodeID != 0x0001 || records[1].Peer.NodeID != 0x1234 {
+		t.Fatalf("records are not ordered by instance names")
+	}
+
+	if err := reg.Remove(peer); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reg.LookupByName("kitchen"); !errors.Is(err, matter.ErrNotFound) {
+		t.Errorf("%v is not %v", err, matter.ErrNotFound)
+	}
+}
+
+func TestUserLabels(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	est := &testSessionEstablisher{sessions: nil, err: nil}
+	com := matter.NewCommissioner()
+	com.SetSessionEstablisher(est)
+	dev := com.OperationalDevice(matter.OperationalPeer{
+		FabricID:           1,
+		CompressedFabricID: 1,
+		NodeID:             0x1234,
+		Address:            netip.MustParseAddrPort("[::1]:5540"),
+	})
+	defer dev.Close()
+
+	labels := []label.Label{label.NewLabel("room", "bedroom 2"), label.NewLabel("floor", "2")}
+	if err := matter.WriteUserLabels(ctx, dev, 1, labels); err != nil {
+		t.Fatal(err)
+	}
+	got, err := matter.ReadUserLabels(ctx, dev, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, labels) {
+		t.Errorf("%v != %v", got, labels)
+	}
+
+	long := []label.Label{label.NewLabel("room", "a room with a long name")}
+	if err := matter.WriteUserLabels(ctx, dev, 1, long); !errors.Is(err, label.ErrInvalid) {
+		t.Errorf("%v is not %v", err, label.ErrInvalid)
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-mdns/mdns"
+	"github.com/cybergarage/go-mdns/mdns/dns"
+)
+
+func TestThreadAddressType(t *testing.T) {
+	ta := matter.NewThreadAddressing(matter.WithMeshLocalPrefix(netip.MustParsePrefix("fdde:ad00:beef:0::/64")))
+	tests := []struct {
+		addr     string
+		expected matter.ThreadAddressType
+	}{
+		{"fd11:22::1234", matter.ThreadOMRAddress},
+		{"2001:db8::1", matter.ThreadGlobalAddress},
+		{"192.168.1.10", matter.ThreadIPv4Address},
+		{"::ffff:192.168.1.10", matter.ThreadIPv4Address},
+		{"fdde:ad00:beef:0:6c5c:e1d0:8a3f:9b21", matter.ThreadMLEIDAddress},
+		{"fdde:ad00:beef:0:0:ff:fe00:5800", matter.ThreadLocatorAddress},
+		{"fdde:ad00:beef:0:0:ff:fe00:fc00", matter.ThreadLocatorAddress},
+		{"fe80::1", matter.ThreadLinkLocalAddress},
+		{"::", matter.ThreadUnknownAddress},
+		{"ff02::fb", matter.ThreadUnknownAddress},
+	}
+	for _, test := range tests {
+		t.Run(test.addr, func(t *testing.T) {
+			typ := ta.AddressType(netip.MustParseAddr(test.addr))
+			if typ != test.expected {
+				t.Errorf("%s != %s", typ, test.expected)
+			}
+		})
+	}
+
+	// Without the mesh-local prefix, ML-EIDs are unique local addresses.
+	mleid := netip.MustParseAddr("fdde:ad00:beef:0:6c5c:e1d0:8a3f:9b21")
+	if typ := matter.NewThreadAddressing().AddressType(mleid); typ != matter.ThreadOMRAddress {
+		t.Errorf("%s != %s", typ, matter.ThreadOMRAddress)
+	}
+}
+
+func TestThreadAddressingSortAddrs(t *testing.T) {
+	addrs := []netip.Addr{
+		netip.MustParseAddr("fe80::1"),
+		netip.MustParseAddr("fdde:ad00:beef:0:6c5c:e1d0:8a3f:9b21"),
+		netip.MustParseAddr("fdde:ad00:beef:0:0:ff:fe00:5800"),
+		netip.MustParseAddr("2001:db8::1"),
+		netip.MustParseAddr("fd11:22::1234"),
+		netip.MustParseAddr("fd11:22::1234"),
+	}
+	prefix := netip.MustParsePrefix("fdde:ad00:beef:0::/64")
+
+	tests := []struct {
+		opts     []matter.ThreadAddressingOption
+		expected []string
+	}{
+		{
+			[]matter.ThreadAddressingOption{matter.WithMeshLocalPrefix(prefix)},
+			[]string{"fd11:22::1234", "2001:db8::1", "fdde:ad00:beef:0:6c5c:e1d0:8a3f:9b21", "fe80::1"},
+		},
+		{
+			[]matter.ThreadAddressingOption{matter.WithMeshLocalPrefix(prefix), matter.WithOnMesh(true)},
+			[]string{"fdde:ad00:beef:0:6c5c:e1d0:8a3f:9b21", "fd11:22::1234", "2001:db8::1", "fe80::1"},
+		},
+	}
+	for _, test := range tests {
+		sorted := matter.NewThreadAddressing(test.opts...).SortAddrs(addrs)
+		if len(sorted) != len(test.expected) {
+			t.Errorf("%v != %v", sorted, test.expected)
+			continue
+		}
+		for n, addr := range sorted {
+			if addr != netip.MustParseAddr(test.expected[n]) {
+				t.Errorf("%v != %v", sorted, test.expected)
+				break
+			}
+		}
+	}
+}
+
+func newThreadServiceMessage(t *testing.T, instance string) *dns.Message {
+	t.Helper()
+	service := "_matter._tcp.local"
+	name := instance + "." + service
+	host := "0E8B3C9A1F2D4E5B.default.service.arpa"
+	msg := newDNSSDMessage()
+	msg.addPTR(service, name)
+	msg.addSRV(name, 5540, host)
+	msg.addAAAA("0e8b3c9a1f2d4e5b.local", net.ParseIP("fdde:ad00:beef:0:6c5c:e1d0:8a3f:9b21"))
+	msg.addAAAA("0e8b3c9a1f2d4e5b.local", net.ParseIP("fd11:22::1234"))
+	msg.addAAAA("0e8b3c9a1f2d4e5b.local", net.ParseIP("fdde:ad00:beef:0:0:ff:fe00:5800"))
+	msg.addAAAA("OtherHost.local", net.ParseIP("fd11:22::5678"))
+	res, err := dns.NewMessageWithBytes(msg.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestThreadAddressingServiceAddrs(t *testing.T) {
+	srv, err := mdns.NewServiceWithMessage(newThreadServiceMessage(t, "87E1B004E235A130-8FC7772401CD0696"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ta := matter.NewThreadAddressing(matter.WithMeshLocalPrefix(netip.MustParsePrefix("fdde:ad00:beef:0::/64")))
+	addrs := ta.ServiceAddrs(srv)
+	expected := []netip.Addr{
+		netip.MustParseAddr("fd11:22::1234"),
+		netip.MustParseAddr("fdde:ad00:beef:0:6c5c:e1d0:8a3f:9b21"),
+	}
+	if len(addrs) != len(expected) {
+		t.Fatalf("%v != %v", addrs, expected)
+	}
+	for n, addr := range addrs {
+		if addr != expected[n] {
+			t.Errorf("%v != %v", addrs, expected)
+		}
+	}
+	if !ta.IsMLEID(addrs[1]) {
+		t.Errorf("%s is not ML-EID", addrs[1])
+	}
+}
+
+func TestDNSSDOperationalResolverThreadAddrs(t *testing.T) {
+	peer := matter.OperationalPeer{FabricID: 1, CompressedFabricID: 0x87E1B004E235A130, NodeID: 0x8FC7772401CD0696, Address: netip.AddrPort{}}
+
+	com := matter.NewCommissioner()
+	if _, err := com.Discoverer.Client.MessageReceived(newThreadServiceMessage(t, peer.InstanceName())); err != nil {
+		t.Fatal(err)
+	}
+	resolver := matter.NewDNSSDOperationalResolver(com.Discoverer)
+	resolver.SetThreadAddressing(matter.NewThreadAddressing(matter.WithMeshLocalPrefix(netip.MustParsePrefix("fdde:ad00:beef:0::/64"))))
+
+	addrs, ok := resolver.LookupOperationalAddrs(peer)
+	if !ok {
+		t.Fatalf("%s is not found", peer.InstanceName())
+	}
+	if len(addrs) != 2 || addrs[0] != netip.MustParseAddrPort("[fd11:22::1234]:5540") {
+		t.Errorf("%v is invalid", addrs)
+	}
+	addr, ok := resolver.LookupOperational(peer)
+	if !ok || addr != addrs[0] {
+		t.Errorf("%s != %s", addr, addrs[0])
+	}
+}
+
+func TestThreadAddressingDialUDP(t *testing.T) {
+	ta := matter.NewThreadAddressing()
+
+	if _, err := ta.DialUDP(context.Background(), nil); err == nil {
+		t.Error("no address is dialed")
+	}
+
+	listener, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 0})
+	if err != nil {
+		t.Skip(err)
+	}
+	defer listener.Close()
+
+	addrs := []netip.AddrPort{
+		netip.MustParseAddrPort("[fe80::1%invalid0]:5540"),
+		listener.LocalAddr().(*net.UDPAddr).AddrPort(),
+	}
+	conn, err := ta.DialUDP(context.Background(), addrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if conn.RemoteAddr().(*net.UDPAddr).AddrPort() != addrs[1] {
+		t.Errorf("%s != %s", conn.RemoteAddr(), addrs[1])
+	}
+}
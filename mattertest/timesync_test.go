@@ -0,0 +1,87 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/cluster/timesync"
+	"github.com/cybergarage/go-matter/matter/datamodel"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/im"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+// testClusterInvoker invokes commands on a cluster directly.
+type testClusterInvoker struct {
+	cluster datamodel.Cluster
+	fabric  types.FabricIndex
+}
+
+func (invoker *testClusterInvoker) InvokeCommand(ctx context.Context, path im.CommandPath, fields []byte) ([]byte, error) {
+	req := &datamodel.CommandRequest{
+		Path:         path,
+		Fields:       fields,
+		FabricIndex:  invoker.fabric,
+		SourceNodeID: 0x1234,
+	}
+	res, err := invoker.cluster.InvokeCommand(req.WithContext(ctx))
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return datatype.Encode(res.Fields)
+}
+
+func TestTimeSync(t *testing.T) {
+	ctx := context.Background()
+	clock := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	cluster := timesync.NewCluster(timesync.WithClock(func() time.Time { return clock }))
+	invoker := &testClusterInvoker{cluster: cluster, fabric: 1}
+
+	utc := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	if err := matter.SetUTCTime(ctx, invoker, 0, utc, timesync.MillisecondsGranularity); err != nil {
+		t.Fatal(err)
+	}
+	if now, granularity, ok := cluster.UTCTime(); !ok || !now.Equal(utc) || granularity != timesync.MillisecondsGranularity {
+		t.Errorf("%s (%d)", now, granularity)
+	}
+
+	required, err := matter.SetTimeZone(ctx, invoker, 0, []timesync.TimeZone{{Offset: time.Hour, ValidAt: time.Time{}, Name: "Europe/Paris"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !required {
+		t.Error("DST offsets are not required")
+	}
+	dst := timesync.DSTOffset{Offset: time.Hour, ValidStarting: utc.Add(-time.Hour), ValidUntil: time.Time{}}
+	if err := matter.SetDSTOffset(ctx, invoker, 0, []timesync.DSTOffset{dst}); err != nil {
+		t.Fatal(err)
+	}
+	now, _, _ := cluster.UTCTime()
+	if local, ok := cluster.LocalTime(); !ok || local.Sub(now) != 2*time.Hour {
+		t.Errorf("%s - %s != %s", local, now, 2*time.Hour)
+	}
+
+	nodeID := types.NodeID(0x10)
+	if err := matter.SetTrustedTimeSource(ctx, invoker, 0, &nodeID, 0); err != nil {
+		t.Fatal(err)
+	}
+	if source, ok := cluster.TrustedTimeSource(); !ok || source.NodeID != nodeID || source.FabricIndex != 1 {
+		t.Errorf("%v", source)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2024 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mattertest
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-matter/matter"
+	"github.com/cybergarage/go-matter/matter/datatype"
+	"github.com/cybergarage/go-matter/matter/session"
+	"github.com/cybergarage/go-matter/matter/types"
+)
+
+func TestSpecificationVersion(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	est := &testSessionEstablisher{sessions: nil, err: nil}
+	peer := matter.OperationalPeer{
+		FabricID:           1,
+		CompressedFabricID: 1,
+		NodeID:             0x1234,
+		Address:            netip.MustParseAddrPort("[::1]:5540"),
+	}
+	dev := matter.NewOperationalDevice(peer, est)
+
+	// The version is read from the Basic Information cluster, and kept in the session parameters.
+	reported := datatype.Uint32(types.NewVersion(1, 3, 0))
+	data, err := datatype.Encode(&reported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.WriteAttribute(ctx, matter.SpecificationVersionPath, data); err != nil {
+		t.Fatal(err)
+	}
+	for n := 0; n < 2; n++ {
+		ok, err := dev.SupportsFeature(ctx, matter.TCPFeature)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Error("TCP is not supported")
+		}
+	}
+	if reads := len(est.sessions[0].reads); reads != 1 {
+		t.Errorf("%d reads != 1", reads)
+	}
+	if ok, _ := dev.SupportsFeature(ctx, matter.JointFabricFeature); ok {
+		t.Error("joint fabric is supported")
+	}
+
+	// The version in the session parameters is preferred.
+	params := session.NewParams()
+	params.SpecificationVersion = uint32(types.NewVersion(1, 4, 0))
+	dev.SetSessionParams(params)
+	if ok, _ := dev.SupportsFeature(ctx, matter.JointFabricFeature); !ok {
+		t.Error("joint fabric is not supported")
+	}
+	if reads := len(est.sessions[0].reads); reads != 1 {
+		t.Errorf("%d reads != 1", reads)
+	}
+}